@@ -18,6 +18,7 @@ func main() {
 	}
 	rootCmd.AddCommand(
 		newLintCmd(),
+		newValidateCmd(),
 		newVersionCmd(),
 	)
 
@@ -88,7 +88,7 @@ func newLintCmd() *cobra.Command {
 			return lint(opts, &output{cmd.OutOrStdout()})
 		},
 	}
-	lintCmd.Flags().StringVarP(&opts.kind, "kind", "k", "helm", "repository kind: coredns, falco, helm, helm-plugin, keda-scaler, keptn, krew, olm, opa, tbaction, tekton-task, tekton-pipeline")
+	lintCmd.Flags().StringVarP(&opts.kind, "kind", "k", "helm", "repository kind: coredns, crossplane, falco, headlamp, helm, helm-plugin, keda-scaler, keptn, knative-func, krew, kyverno, olm, opa, tbaction, tekton-task, tekton-pipeline")
 	lintCmd.Flags().StringVarP(&opts.path, "path", "p", ".", "repository's packages path")
 	return lintCmd
 }
@@ -108,9 +108,13 @@ func lint(opts *lintOptions, out *output) error {
 	switch kind {
 	case
 		hub.CoreDNS,
+		hub.Crossplane,
 		hub.Falco,
+		hub.Headlamp,
 		hub.KedaScaler,
 		hub.Keptn,
+		hub.KnativeFunc,
+		hub.Kyverno,
 		hub.OPA,
 		hub.TBAction:
 		report = lintGeneric(opts.path, kind)
@@ -563,6 +567,14 @@ func (out *output) printPkgDetails(pkg *hub.Package) {
 		fmt.Fprintf(out, "%c Screenshots: %s\n", warning, notProvided)
 	}
 
+	// Videos (only displayed when provided, as they are rarely used)
+	if len(pkg.Videos) > 0 {
+		fmt.Fprintf(out, "%c Videos:\n", success)
+		for _, v := range pkg.Videos {
+			fmt.Fprintf(out, "      - Title: %s | URL: %s\n", v.Title, v.URL)
+		}
+	}
+
 	// Operator
 	out.print("Operator", strconv.FormatBool(pkg.IsOperator))
 	if pkg.IsOperator {
@@ -575,9 +587,13 @@ func (out *output) printPkgDetails(pkg *hub.Package) {
 	switch pkg.Repository.Kind {
 	case
 		hub.CoreDNS,
+		hub.Crossplane,
 		hub.Falco,
+		hub.Headlamp,
 		hub.KedaScaler,
 		hub.Keptn,
+		hub.KnativeFunc,
+		hub.Kyverno,
 		hub.OPA,
 		hub.TBAction:
 
@@ -591,16 +607,74 @@ func (out *output) printPkgDetails(pkg *hub.Package) {
 		switch pkg.Repository.Kind {
 		case hub.Falco:
 			// Rules files
-			fmt.Fprintf(out, "%c Rules: %s\n", success, provided)
-			for name := range pkg.Data[generic.FalcoRulesKey].(map[string]string) {
+			if rules, ok := pkg.Data[generic.FalcoRulesKey].(map[string]string); ok {
+				fmt.Fprintf(out, "%c Rules: %s\n", success, provided)
+				for name := range rules {
+					fmt.Fprintf(out, "  - %s\n", name)
+				}
+			}
+
+			// Plugin
+			if plugin, ok := pkg.Data[generic.FalcoPluginKey].(*generic.FalcoPlugin); ok {
+				fmt.Fprintf(out, "%c Plugin: %s\n", success, plugin.Ref)
+				out.print("Required Falco version", plugin.RequiredFalcoVersion)
+				if len(plugin.Fields) > 0 {
+					fmt.Fprintf(out, "%c Plugin fields: %s\n", success, provided)
+					for _, field := range plugin.Fields {
+						fmt.Fprintf(out, "  - %s (%s)\n", field.Name, field.Type)
+					}
+				}
+			}
+		case hub.Headlamp:
+			archiveURL, _ := pkg.Data[generic.HeadlampArchiveURLKey].(string)
+			out.print("Archive url", archiveURL)
+			archiveChecksum, _ := pkg.Data[generic.HeadlampArchiveChecksumKey].(string)
+			out.print("Archive checksum", archiveChecksum)
+			versionCompat, _ := pkg.Data[generic.HeadlampVersionCompatKey].(string)
+			out.print("Headlamp version compatibility", versionCompat)
+			distroCompat, _ := pkg.Data[generic.HeadlampDistroCompatKey].(string)
+			out.print("Headlamp distributions compatibility", distroCompat)
+		case hub.KnativeFunc:
+			runtime, _ := pkg.Data[generic.KnativeFuncRuntimeKey].(string)
+			out.print("Runtime", runtime)
+			template, _ := pkg.Data[generic.KnativeFuncTemplateKey].(string)
+			out.print("Template", template)
+			invoke, _ := pkg.Data[generic.KnativeFuncInvokeKey].(string)
+			out.print("Invoke", invoke)
+		case hub.Kyverno:
+			// Policies files
+			fmt.Fprintf(out, "%c Policies: %s\n", success, provided)
+			for name := range pkg.Data[generic.KyvernoPoliciesKey].(map[string]string) {
 				fmt.Fprintf(out, "  - %s\n", name)
 			}
+
+			// Categories
+			if categories, ok := pkg.Data[generic.KyvernoCategoriesKey].([]string); ok {
+				fmt.Fprintf(out, "%c Categories: %s\n", success, provided)
+				for _, category := range categories {
+					fmt.Fprintf(out, "  - %s\n", category)
+				}
+			} else {
+				fmt.Fprintf(out, "%c Categories: %s\n", warning, notProvided)
+			}
+
+			// Severity
+			severity, _ := pkg.Data[generic.KyvernoSeverityKey].(string)
+			out.print("Severity", severity)
 		case hub.OPA:
 			// Policies files
 			fmt.Fprintf(out, "%c Policies: %s\n", success, provided)
 			for name := range pkg.Data[generic.OPAPoliciesKey].(map[string]string) {
 				fmt.Fprintf(out, "  - %s\n", name)
 			}
+
+			// Gatekeeper constraint templates
+			if _, ok := pkg.Data[generic.OPAConstraintTemplatesKey]; ok {
+				fmt.Fprintf(out, "%c Constraint templates: %s\n", success, provided)
+				for _, crd := range pkg.CRDs {
+					fmt.Fprintf(out, "  - %s\n", crd.(map[string]interface{})["kind"])
+				}
+			}
 		}
 	case hub.Helm:
 		out.print("Sign key", pkg.SignKey)
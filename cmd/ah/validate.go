@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/metadata"
+	"github.com/spf13/cobra"
+)
+
+// validateDesc represents the long description of the validate command.
+var validateDesc = `Validate Artifact Hub metadata files
+
+Use this command to check that the Artifact Hub metadata files (artifacthub-pkg.yml
+and artifacthub-repo.yml) provided are valid. Files and directories can be
+provided as arguments. Directories will be walked looking for metadata files.
+When no arguments are provided, the current directory will be used.`
+
+var (
+	// errValidationFailed indicates that the validate command failed. This
+	// happens when errors are found in any of the metadata files checked.
+	errValidationFailed = errors.New("validation failed")
+
+	// errNoMetadataFilesFound indicates that no metadata files were found in
+	// the paths provided.
+	errNoMetadataFilesFound = errors.New("no metadata files found")
+)
+
+// validateReportEntry represents an entry of the validate report. It contains
+// the path of a metadata file and the result of validating it.
+type validateReportEntry struct {
+	path   string
+	result *metadata.ValidationResult
+}
+
+// newValidateCmd creates a new validate command.
+func newValidateCmd() *cobra.Command {
+	validateCmd := &cobra.Command{
+		Use:   "validate [path...]",
+		Short: "Validate Artifact Hub metadata files",
+		Long:  validateDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"."}
+			}
+			return validate(args, &output{cmd.OutOrStdout()})
+		},
+	}
+	return validateCmd
+}
+
+// validate checks that the metadata files found in the paths provided are
+// valid. The results will be printed to the output provided.
+func validate(paths []string, out *output) error {
+	// Collect metadata files from the paths provided
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		_ = filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return nil
+			}
+			if metadata.GetKind(filePath) != "" {
+				files = append(files, filePath)
+			}
+			return nil
+		})
+	}
+	if len(files) == 0 {
+		return errNoMetadataFilesFound
+	}
+
+	// Validate metadata files
+	entries := make([]*validateReportEntry, 0, len(files))
+	for _, file := range files {
+		result, err := metadata.ValidateFile(file)
+		if err != nil {
+			result = &metadata.ValidationResult{Errors: []string{err.Error()}}
+		}
+		entries = append(entries, &validateReportEntry{
+			path:   file,
+			result: result,
+		})
+	}
+
+	// Print validate report and return the corresponding error
+	out.printValidateReport(entries)
+	for _, e := range entries {
+		if !e.result.Valid() {
+			return errValidationFailed
+		}
+	}
+	return nil
+}
+
+// printValidateReport prints the provided validate report entries to the
+// receiver output.
+func (out *output) printValidateReport(entries []*validateReportEntry) {
+	var filesWithErrors int
+	for _, e := range entries {
+		// Header
+		mark := success
+		if !e.result.Valid() {
+			mark = failure
+			filesWithErrors++
+		}
+		fmt.Fprintf(out, "\n%s\n", strings.Repeat("-", sepLen))
+		fmt.Fprintf(out, "%c %s\n", mark, e.path)
+		fmt.Fprintf(out, "%s\n\n", strings.Repeat("-", sepLen))
+
+		// Details
+		if e.result.Valid() {
+			fmt.Fprintf(out, "Metadata file validation SUCCEEDED!\n")
+		} else {
+			fmt.Fprintf(out, "Metadata file validation FAILED. %d error(s) occurred:\n\n", len(e.result.Errors))
+			for _, err := range e.result.Errors {
+				fmt.Fprintf(out, "  * %s\n", strings.TrimSpace(err))
+			}
+		}
+	}
+
+	// Print footer summary
+	fmt.Fprintf(out, "\n%s\n", strings.Repeat("-", sepLen))
+	fmt.Fprintf(out, "\n%d metadata file(s) found, %d file(s) with errors\n\n", len(entries), filesWithErrors)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCmd(t *testing.T) {
+	testCases := []struct {
+		path          string
+		desc          string
+		expectedError error
+	}{
+		{
+			"test1",
+			"two metadata files found, no errors",
+			nil,
+		},
+		{
+			"test2",
+			"two metadata files found, both with errors",
+			errValidationFailed,
+		},
+		{
+			"test3",
+			"no metadata files found",
+			errNoMetadataFilesFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+
+			// Prepare command and execute it
+			var b bytes.Buffer
+			cmd := newValidateCmd()
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			cmd.SetOut(&b)
+			cmd.SetArgs([]string{filepath.Join("testdata", "validate", tc.path, "files")})
+			cmdErr := cmd.Execute()
+
+			// Read command output and check it matches what we expect
+			cmdOutput, err := io.ReadAll(&b)
+			require.NoError(t, err)
+			goldenPath := filepath.Join("testdata", "validate", tc.path, "output.golden")
+			if *update {
+				// Update tests golden files
+				golden, err := os.Create(goldenPath)
+				require.NoError(t, err)
+				_, err = golden.Write(cmdOutput)
+				require.NoError(t, err)
+			}
+			expectedOutput, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			assert.Equal(t, expectedOutput, cmdOutput)
+			assert.Equal(t, tc.expectedError, cmdErr)
+		})
+	}
+}
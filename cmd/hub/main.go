@@ -10,24 +10,33 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/artifacthub/hub/database/migrations/schema"
 	"github.com/artifacthub/hub/internal/apikey"
 	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/blocklist"
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/event"
+	"github.com/artifacthub/hub/internal/githubapp"
 	"github.com/artifacthub/hub/internal/handlers"
+	"github.com/artifacthub/hub/internal/health"
 	"github.com/artifacthub/hub/internal/hub"
-	"github.com/artifacthub/hub/internal/img/pg"
+	"github.com/artifacthub/hub/internal/inbox"
+	"github.com/artifacthub/hub/internal/issuetracker"
+	"github.com/artifacthub/hub/internal/job"
+	"github.com/artifacthub/hub/internal/maintainer"
+	"github.com/artifacthub/hub/internal/maintenance"
 	"github.com/artifacthub/hub/internal/notification"
 	"github.com/artifacthub/hub/internal/oci"
 	"github.com/artifacthub/hub/internal/org"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/sitemap"
 	"github.com/artifacthub/hub/internal/stats"
 	"github.com/artifacthub/hub/internal/subscription"
 	"github.com/artifacthub/hub/internal/user"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/artifacthub/hub/internal/webhook"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog/log"
 )
 
@@ -41,39 +50,106 @@ func main() {
 	if err := util.SetupLogger(cfg, fields); err != nil {
 		log.Fatal().Err(err).Msg("logger setup failed")
 	}
+	if err := repo.RegisterGitProviders(cfg); err != nil {
+		log.Fatal().Err(err).Msg("git providers setup failed")
+	}
 
 	// Setup services
 	db, err := util.SetupDB(cfg)
 	if err != nil {
 		log.Fatal().Err(err).Msg("database setup failed")
 	}
-	var es hub.EmailSender
-	if s := email.NewSender(cfg); s != nil {
-		es = s
+	rdb, err := util.SetupDBReplica(cfg, db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("database replica setup failed")
 	}
 	az, err := authz.NewAuthorizer(db)
 	if err != nil {
 		log.Fatal().Err(err).Msg("authorizer setup failed")
 	}
 	hc := util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"), util.HTTPClientDefaultTimeout)
-	vt := pkg.NewViewsTracker(db)
+	var es hub.EmailSender
+	var ep hub.EmailWebhooksProcessor
+	sender := email.NewSender(cfg, db, hc)
+	if sender != nil {
+		es = sender
+		ep = sender
+	}
+	var gp hub.GitHubAppWebhooksProcessor
+	if cfg.GetString("githubApp.webhookSecret") != "" {
+		gp = githubapp.NewWebhooksProcessor(cfg, db)
+	}
+	is, err := util.SetupImageStore(cfg, db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("image store setup failed")
+	}
+	mm := maintenance.NewManager(db)
+	vt := pkg.NewViewsTracker(db, pkg.WithMaintenanceManager(mm))
+	ut := apikey.NewUsageTracker(db, apikey.WithMaintenanceManager(mm))
+	cache, err := util.SetupCache(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cache setup failed")
+	}
+	var eab event.Bucket
+	if cfg.GetString("events.archive.store") != "" {
+		b, err := util.SetupBucket(cfg, "events.archive")
+		if err != nil {
+			log.Fatal().Err(err).Msg("events archive bucket setup failed")
+		}
+		eab = b
+	}
+	hck, err := health.NewChecker(db, schema.Migrations)
+	if err != nil {
+		log.Fatal().Err(err).Msg("health checker setup failed")
+	}
+	jm := job.NewManager(db)
 
 	// Setup and launch http server
+	cfg.SetDefault("db.queryTimeouts.api", 30*time.Second)
+	cfg.SetDefault("db.queryTimeouts.read", 10*time.Second)
+	cfg.SetDefault("db.slowQueryThreshold", 5*time.Second)
+	cfg.SetDefault("db.circuitBreaker.failureThreshold", 10)
+	cfg.SetDefault("db.circuitBreaker.openTimeout", 30*time.Second)
+	failureThreshold := cfg.GetInt("db.circuitBreaker.failureThreshold")
+	openTimeout := cfg.GetDuration("db.circuitBreaker.openTimeout")
+	slowQueryThreshold := cfg.GetDuration("db.slowQueryThreshold")
+	cbDB := util.NewCircuitBreakerDB(db, failureThreshold, openTimeout)
+	cbRDB := cbDB
+	if rdb != db {
+		cbRDB = util.NewCircuitBreakerDB(rdb, failureThreshold, openTimeout)
+	}
+	apiDB := util.NewTimeoutDB(cbDB, cfg.GetDuration("db.queryTimeouts.api"), slowQueryThreshold)
+	readDB := util.NewTimeoutDB(cbRDB, cfg.GetDuration("db.queryTimeouts.read"), slowQueryThreshold)
 	ctx, stop := context.WithCancel(context.Background())
+	rm := repo.NewManager(cfg, apiDB, az, hc, repo.WithEmailSender(es), repo.WithCache(cache))
+	if err := rm.RegisterCustomKinds(ctx); err != nil {
+		log.Fatal().Err(err).Msg("custom repositories kinds setup failed")
+	}
 	hSvc := &handlers.Services{
-		OrganizationManager: org.NewManager(cfg, db, es, az),
-		UserManager:         user.NewManager(cfg, db, es),
-		RepositoryManager:   repo.NewManager(cfg, db, az, hc),
-		PackageManager:      pkg.NewManager(db),
-		SubscriptionManager: subscription.NewManager(db),
-		WebhookManager:      webhook.NewManager(db),
-		APIKeyManager:       apikey.NewManager(db),
-		StatsManager:        stats.NewManager(db),
-		ImageStore:          pg.NewImageStore(cfg, db, hc),
+		OrganizationManager: org.NewManager(cfg, apiDB, es, az),
+		UserManager:         user.NewManager(cfg, apiDB, es),
+		RepositoryManager:   rm,
+		PackageManager:      pkg.NewManager(apiDB, pkg.WithReplicaDB(readDB), pkg.WithCache(cache)),
+		SubscriptionManager: subscription.NewManager(apiDB),
+		WebhookManager:      webhook.NewManager(apiDB),
+		APIKeyManager:       apikey.NewManager(apiDB),
+		APIKeyUsageTracker:  ut,
+		EmailProcessor:      ep,
+		GitHubAppProcessor:  gp,
+		StatsManager:        stats.NewManager(apiDB, stats.WithReplicaDB(readDB), stats.WithCache(cache)),
+		SitemapManager:      sitemap.NewManager(apiDB, sitemap.WithCache(cache)),
+		ImageStore:          is,
 		Authorizer:          az,
 		HTTPClient:          hc,
 		OCIPuller:           &oci.Puller{},
 		ViewsTracker:        vt,
+		HealthChecker:       hck,
+		BlocklistManager:    blocklist.NewManager(apiDB, blocklist.WithCache(cache)),
+		InboxManager:        inbox.NewManager(apiDB),
+		IssueTrackerManager: issuetracker.NewManager(apiDB),
+		MaintainerManager:   maintainer.NewManager(cfg, apiDB, es),
+		MaintenanceManager:  maintenance.NewManager(apiDB),
+		JobManager:          jm,
 	}
 	h, err := handlers.Setup(ctx, cfg, hSvc)
 	if err != nil {
@@ -95,32 +171,65 @@ func main() {
 	log.Info().Str("addr", addr).Int("pid", os.Getpid()).Msg("hub server running!")
 
 	// Setup and launch metrics server
-	go func() {
-		http.Handle("/metrics", promhttp.Handler())
-		err := http.ListenAndServe(cfg.GetString("server.metricsAddr"), nil)
-		if err != nil {
-			log.Fatal().Err(err).Msg("metrics server ListenAndServe failed")
-		}
-	}()
+	prometheus.MustRegister(util.NewDBStatsCollector(db, "primary"))
+	if rdb != db {
+		prometheus.MustRegister(util.NewDBStatsCollector(rdb, "replica"))
+	}
+	util.SetupMetricsServer(cfg.GetString("server.metricsAddr"))
 
 	// Launch views tracker flusher
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go vt.Flusher(ctx, &wg)
 
+	// Launch api keys usage tracker flusher
+	wg.Add(1)
+	go ut.Flusher(ctx, &wg)
+
+	// Setup and launch background jobs scheduler
+	js := job.NewScheduler(jm, job.WithMaintenanceManager(mm))
+	js.Register(&job.Job{
+		Name:      "rankings-refresher",
+		Frequency: pkg.RankingsRefreshFrequency,
+		Run:       pkg.NewRankingsRefresher(db).Refresh,
+		Heartbeat: hck.RegisterWorker("rankings-refresher", 3*time.Hour),
+	})
+	js.Register(&job.Job{
+		Name:      "eol-events-registerer",
+		Frequency: pkg.EOLEventsCheckFrequency,
+		Run:       pkg.NewEOLEventsRegisterer(db).Register,
+		Heartbeat: hck.RegisterWorker("eol-events-registerer", 3*time.Hour),
+	})
+	js.Register(&job.Job{
+		Name:      "events-archiver",
+		Frequency: event.ArchiveFrequency,
+		Run:       event.NewArchiver(cfg, db, eab).Archive,
+		Heartbeat: hck.RegisterWorker("events-archiver", 18*time.Hour),
+	})
+	wg.Add(1)
+	go js.Run(ctx, &wg)
+
 	// Setup and launch events dispatcher
 	eSvc := &event.Services{
 		DB:                  db,
 		EventManager:        event.NewManager(),
 		SubscriptionManager: subscription.NewManager(db),
 		WebhookManager:      webhook.NewManager(db),
+		IssueTrackerManager: issuetracker.NewManager(db),
 		NotificationManager: notification.NewManager(),
 	}
-	eventsDispatcher := event.NewDispatcher(eSvc)
+	eventsDispatcher := event.NewDispatcher(eSvc,
+		event.WithHeartbeat(hck.RegisterWorker("events-dispatcher", 15*time.Minute)),
+		event.WithMaintenanceManager(mm),
+	)
 	wg.Add(1)
 	go eventsDispatcher.Run(ctx, &wg)
 
 	// Setup and launch notifications dispatcher
+	webhooksHC, err := util.SetupWebhooksHTTPClient(cfg, handlers.WebhooksHTTPClientTimeout)
+	if err != nil {
+		log.Fatal().Err(err).Msg("webhooks http client setup failed")
+	}
 	nSvc := &notification.Services{
 		Cfg:                 cfg,
 		DB:                  db,
@@ -129,12 +238,25 @@ func main() {
 		SubscriptionManager: subscription.NewManager(db),
 		RepositoryManager:   repo.NewManager(cfg, db, az, hc),
 		PackageManager:      pkg.NewManager(db),
-		HTTPClient:          util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"), handlers.WebhooksHTTPClientTimeout),
+		IssueTrackerManager: issuetracker.NewManager(db),
+		HTTPClient:          webhooksHC,
 	}
-	notificationsDispatcher := notification.NewDispatcher(nSvc)
+	notificationsDispatcher := notification.NewDispatcher(nSvc,
+		notification.WithHeartbeat(hck.RegisterWorker("notifications-dispatcher", 15*time.Minute)),
+		notification.WithMaintenanceManager(mm),
+	)
 	wg.Add(1)
 	go notificationsDispatcher.Run(ctx, &wg)
 
+	// Launch configuration reloader
+	cr := util.NewConfigReloader(cfg)
+	cr.OnReload("log", util.SetLogLevel)
+	if sender != nil {
+		cr.OnReload("email", sender.Reload)
+	}
+	wg.Add(1)
+	go cr.Run(ctx, &wg)
+
 	// Shutdown server gracefully when SIGINT or SIGTERM signal is received
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
@@ -7,16 +7,37 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/artifacthub/hub/internal/authz"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
 	"github.com/artifacthub/hub/internal/scanner"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/rs/zerolog/log"
 )
 
+var (
+	// pendingSnapshots tracks the number of snapshots waiting to be scanned.
+	pendingSnapshots = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "scanner_pending_snapshots",
+		Help: "Number of snapshots waiting to be scanned.",
+	})
+
+	// snapshotDuration tracks how long it takes to scan snapshots.
+	snapshotDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "scanner_snapshot_duration_seconds",
+		Help:    "Duration of the snapshots scans by outcome.",
+		Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600},
+	},
+		[]string{"outcome"},
+	)
+)
+
 func main() {
 	// Setup configuration and logger
 	cfg, err := util.SetupConfig("scanner")
@@ -40,8 +61,12 @@ func main() {
 	}()
 
 	// Check required external tools are available
-	if _, err := exec.LookPath("trivy"); err != nil {
-		log.Fatal().Err(err).Msg("trivy not found")
+	backend := scanner.GetBackend(cfg)
+	if _, err := exec.LookPath(backend); err != nil {
+		log.Fatal().Err(err).Msgf("%s not found", backend)
+	}
+	if _, err := exec.LookPath("syft"); err != nil {
+		log.Fatal().Err(err).Msg("syft not found")
 	}
 
 	// Setup services
@@ -49,28 +74,42 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("database setup failed")
 	}
+	prometheus.MustRegister(util.NewDBStatsCollector(db, "primary"))
+	if addr := cfg.GetString("scanner.metricsAddr"); addr != "" {
+		util.SetupMetricsServer(addr)
+	}
 	az, err := authz.NewAuthorizer(db)
 	if err != nil {
 		log.Fatal().Err(err).Msg("authorizer setup failed")
 	}
 	hc := util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"), util.HTTPClientDefaultTimeout)
+	cache, err := util.SetupCache(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cache setup failed")
+	}
 	rm := repo.NewManager(cfg, db, az, hc)
-	pm := pkg.NewManager(db)
+	pm := pkg.NewManager(db, pkg.WithCache(cache))
 	ec := repo.NewErrorsCollector(rm, repo.Scanner)
-	s := scanner.New(ctx, cfg, ec)
+	mm := maintenance.NewManager(db)
+
+	// Work in progress is cancelled as soon as the maintenance mode is enabled
+	wctx, stopWork := maintenance.CancelOnEnabled(ctx, mm)
+	defer stopWork()
+	s := scanner.New(wctx, cfg, ec, scanner.WithImageScanStore(pm))
 
 	// Scan pending snapshots
 	snapshots, err := pm.GetSnapshotsToScan(ctx)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error getting snapshots to scan")
 	}
+	pendingSnapshots.Set(float64(len(snapshots)))
 	cfg.SetDefault("scanner.concurrency", 1)
 	limiter := make(chan struct{}, cfg.GetInt("scanner.concurrency"))
 	var wg sync.WaitGroup
 L:
 	for _, sn := range snapshots {
 		select {
-		case <-ctx.Done():
+		case <-wctx.Done():
 			break L
 		default:
 		}
@@ -79,14 +118,19 @@ L:
 		wg.Add(1)
 		go func(snapshot *hub.SnapshotToScan) {
 			defer wg.Done()
+			defer pendingSnapshots.Dec()
 
 			logger := log.With().Str("pkg", snapshot.PackageID).Str("version", snapshot.Version).Logger()
 			logger.Info().Msg("scanning snapshot")
+			start := time.Now()
+			outcome := "success"
 			report, err := s.Scan(snapshot)
 			if err != nil {
 				logger.Error().Err(err).Send()
+				outcome = "error"
 			}
-			if err := pm.UpdateSnapshotSecurityReport(ctx, report); err != nil {
+			snapshotDuration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+			if err := pm.UpdateSnapshotSecurityReport(wctx, report); err != nil {
 				logger.Error().Err(err).Msg("error updating snapshot security report")
 			}
 
@@ -94,6 +138,13 @@ L:
 		}(sn)
 	}
 	wg.Wait()
-	ec.Flush()
+	if wctx.Err() == nil {
+		ec.Flush()
+	}
+	if url := cfg.GetString("scanner.pushgatewayURL"); url != "" {
+		if err := util.PushMetrics(url, "scanner"); err != nil {
+			log.Error().Err(err).Msg("error pushing metrics")
+		}
+	}
 	log.Info().Msg("scanner finished")
 }
@@ -12,13 +12,19 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/cache"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
 	"github.com/artifacthub/hub/internal/oci"
 	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/releasenotes"
 	"github.com/artifacthub/hub/internal/repo"
 	"github.com/artifacthub/hub/internal/tracker"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
 )
 
 const (
@@ -27,6 +33,15 @@ const (
 
 var (
 	errTimeout = errors.New("repository tracking timed out")
+
+	// repositoryDuration tracks how long it takes to process repositories.
+	repositoryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "tracker_repository_duration_seconds",
+		Help:    "Duration of the repositories tracking by kind and outcome.",
+		Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600},
+	},
+		[]string{"kind", "outcome"},
+	)
 )
 
 func main() {
@@ -39,6 +54,9 @@ func main() {
 	if err := util.SetupLogger(cfg, fields); err != nil {
 		log.Fatal().Err(err).Msg("logger setup failed")
 	}
+	if err := repo.RegisterGitProviders(cfg); err != nil {
+		log.Fatal().Err(err).Msg("git providers setup failed")
+	}
 
 	// Shutdown gracefully when SIGINT or SIGTERM signal is received
 	log.Info().Int("pid", os.Getpid()).Msg("tracker started")
@@ -61,29 +79,53 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("database setup failed")
 	}
+	prometheus.MustRegister(util.NewDBStatsCollector(db, "primary"))
+	if addr := cfg.GetString("tracker.metricsAddr"); addr != "" {
+		util.SetupMetricsServer(addr)
+	}
 	az, err := authz.NewAuthorizer(db)
 	if err != nil {
 		log.Fatal().Err(err).Msg("authorizer setup failed")
 	}
 	hc := util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"), util.HTTPClientDefaultTimeout)
-	rm := repo.NewManager(cfg, db, az, hc)
-	pm := pkg.NewManager(db)
-	is, err := util.SetupImageStore(cfg, db, hc)
+	c, err := util.SetupCache(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cache setup failed")
+	}
+	rm := repo.NewManager(cfg, db, az, hc, repo.WithCache(c))
+	if err := rm.RegisterCustomKinds(ctx); err != nil {
+		log.Fatal().Err(err).Msg("custom repositories kinds setup failed")
+	}
+	pm := pkg.NewManager(db, pkg.WithCache(c))
+	is, err := util.SetupImageStore(cfg, db)
 	if err != nil {
 		log.Fatal().Err(err).Msg("image store setup failed")
 	}
 	ec := repo.NewErrorsCollector(rm, repo.Tracker)
+	mm := maintenance.NewManager(db)
+
+	// Work in progress is cancelled as soon as the maintenance mode is enabled
+	wctx, stopWork := maintenance.CancelOnEnabled(ctx, mm)
+	defer stopWork()
+	pluginsConns, err := tracker.RegisterPluginSources(cfg)
+	for _, conn := range pluginsConns {
+		defer conn.Close()
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("tracker source plugins setup failed")
+	}
 	svc := &hub.TrackerServices{
-		Ctx:                ctx,
+		Ctx:                wctx,
 		Cfg:                cfg,
 		Rm:                 rm,
 		Pm:                 pm,
-		Rc:                 &repo.Cloner{},
+		Rc:                 &repo.Cloner{CacheDir: cfg.GetString("tracker.gitCacheDir")},
 		Oe:                 &repo.OLMOCIExporter{},
 		Ec:                 ec,
-		Hc:                 hc,
+		Hc:                 util.NewHostRateLimitedHTTPClient(hc, cfg.GetFloat64("tracker.downloadsRateLimit")),
 		Op:                 &oci.Puller{},
 		Is:                 is,
+		Rn:                 releasenotes.NewGitHubFetcher(hc, cfg.GetString("tracker.githubToken")),
 		SetupTrackerSource: tracker.SetupSource,
 	}
 
@@ -93,49 +135,90 @@ func main() {
 		log.Fatal().Err(err).Msg("error getting repositories")
 	}
 	cfg.SetDefault("tracker.concurrency", 1)
-	limiter := make(chan struct{}, cfg.GetInt("tracker.concurrency"))
+	limiter := util.NewLimiter(cfg.GetInt("tracker.concurrency"))
+
+	// Launch configuration reloader
+	cr := util.NewConfigReloader(cfg)
+	cr.OnReload("log", util.SetLogLevel)
+	cr.OnReload("tracker", func(cfg *viper.Viper) error {
+		limiter.SetLimit(cfg.GetInt("tracker.concurrency"))
+		return nil
+	})
+	crCtx, stopConfigReloader := context.WithCancel(ctx)
+	var crWG sync.WaitGroup
+	crWG.Add(1)
+	go cr.Run(crCtx, &crWG)
+
 	var wg sync.WaitGroup
 L:
 	for _, r := range repos {
 		select {
-		case <-ctx.Done():
+		case <-wctx.Done():
 			break L
 		default:
 		}
 
-		limiter <- struct{}{}
+		limiter.Acquire()
 		wg.Add(1)
 		go func(r *hub.Repository) {
 			defer func() {
-				<-limiter
+				limiter.Release()
 				wg.Done()
 			}()
 			logger := log.With().Str("repo", r.Name).Str("kind", hub.GetKindName(r.Kind)).Logger()
-			done := make(chan struct{})
+			start := time.Now()
+			done := make(chan string)
 			go func() {
+				outcome := "success"
 				defer func() {
-					done <- struct{}{}
+					done <- outcome
 				}()
 				defer func() {
 					if r := recover(); r != nil {
 						logger.Error().Bytes("stacktrace", debug.Stack()).Interface("recover", r).Send()
+						outcome = "error"
 					}
 				}()
 				t := tracker.New(svc, r, logger)
 				if err := t.Run(); err != nil {
 					logger.Error().Err(err).Send()
 					svc.Ec.Append(r.RepositoryID, err.Error())
+					outcome = "error"
 				}
 			}()
+			var outcome string
 			select {
-			case <-done:
+			case outcome = <-done:
 			case <-time.After(repositoryTimeout):
 				logger.Error().Err(errTimeout).Send()
 				svc.Ec.Append(r.RepositoryID, errTimeout.Error())
+				outcome = "timeout"
+			}
+			duration := time.Since(start)
+			repositoryDuration.WithLabelValues(hub.GetKindName(r.Kind), outcome).Observe(duration.Seconds())
+			if wctx.Err() != nil {
+				// Tracking was cancelled, nothing else should be written
+				return
+			}
+			if err := rm.RegisterTrackingRun(ctx, r.RepositoryID, duration, outcome); err != nil {
+				logger.Error().Err(err).Msg("error registering tracking run")
 			}
 		}(r)
 	}
 	wg.Wait()
-	ec.Flush()
+	stopConfigReloader()
+	crWG.Wait()
+	if wctx.Err() == nil {
+		ec.Flush()
+	}
+
+	// Invalidate the sitemap so that it is regenerated including the changes
+	// applied during this run
+	cache.Invalidate(context.Background(), c, cache.SitemapTag)
+	if url := cfg.GetString("tracker.pushgatewayURL"); url != "" {
+		if err := util.PushMetrics(url, "tracker"); err != nil {
+			log.Error().Err(err).Msg("error pushing metrics")
+		}
+	}
 	log.Info().Msg("tracker finished")
 }
@@ -1,34 +1,92 @@
+{{ template "blocklist/get_blocked_reason.sql" }}
 {{ template "repositories/get_repository_by_id.sql" }}
+{{ template "repositories/get_publisher_profile_content.sql" }}
 {{ template "repositories/get_repository_summary.sql" }}
 
 {{ template "api_keys/add_api_key.sql" }}
 {{ template "api_keys/delete_api_key.sql" }}
 {{ template "api_keys/get_api_key.sql" }}
+{{ template "api_keys/get_api_key_usage.sql" }}
 {{ template "api_keys/get_user_api_keys.sql" }}
 {{ template "api_keys/update_api_key.sql" }}
+{{ template "api_keys/update_api_keys_usage.sql" }}
 
+{{ template "blocklist/block_content.sql" }}
+{{ template "blocklist/get_blocked_content.sql" }}
+{{ template "blocklist/unblock_content.sql" }}
+
+{{ template "emails/add_email_suppression.sql" }}
+{{ template "emails/is_email_suppressed.sql" }}
+
+{{ template "events/get_events_partitions.sql" }}
+{{ template "events/drop_events_partition.sql" }}
+{{ template "events/get_events_archive.sql" }}
+{{ template "events/get_events_partitions_to_archive.sql" }}
 {{ template "events/get_pending_event.sql" }}
+{{ template "events/maintain_events_partitions.sql" }}
+
+{{ template "github_app/link_github_app_installation.sql" }}
+{{ template "github_app/process_github_app_push.sql" }}
+{{ template "github_app/register_github_app_installation.sql" }}
+{{ template "github_app/unregister_github_app_installation.sql" }}
 
 {{ template "images/get_image.sql" }}
 {{ template "images/register_image.sql" }}
 
+{{ template "inbox/get_inbox_notifications.sql" }}
+{{ template "inbox/get_inbox_unread_count.sql" }}
+{{ template "inbox/mark_all_inbox_notifications_as_read.sql" }}
+{{ template "inbox/mark_inbox_notification_as_read.sql" }}
+
+{{ template "issue_trackers/add_issue_tracker.sql" }}
+{{ template "issue_trackers/delete_issue_tracker.sql" }}
+{{ template "issue_trackers/get_issue_tracker_pending_vulnerabilities.sql" }}
+{{ template "issue_trackers/get_issue_trackers_for_package.sql" }}
+{{ template "issue_trackers/get_org_issue_trackers.sql" }}
+{{ template "issue_trackers/register_issue_tracker_issue.sql" }}
+{{ template "issue_trackers/update_issue_tracker.sql" }}
+
+{{ template "jobs/acquire_job_lease.sql" }}
+{{ template "jobs/get_job_runs.sql" }}
+{{ template "jobs/register_job_run.sql" }}
+
+{{ template "maintainers/register_maintainer_contact.sql" }}
+{{ template "maintainers/register_maintainer_verification_code.sql" }}
+{{ template "maintainers/verify_maintainer.sql" }}
+
+{{ template "maintenance/get_maintenance_mode.sql" }}
+{{ template "maintenance/set_maintenance_mode.sql" }}
+
 {{ template "notifications/add_notification.sql" }}
 {{ template "notifications/get_pending_notification.sql" }}
 {{ template "notifications/update_notification_status.sql" }}
 
+{{ template "organizations/add_organization_domain.sql" }}
 {{ template "organizations/add_organization_member.sql" }}
 {{ template "organizations/add_organization.sql" }}
+{{ template "organizations/approve_organization_join_request.sql" }}
 {{ template "organizations/confirm_organization_membership.sql" }}
 {{ template "organizations/delete_organization.sql" }}
+{{ template "organizations/delete_organization_domain.sql" }}
+{{ template "organizations/delete_organization_join_request.sql" }}
 {{ template "organizations/delete_organization_member.sql" }}
+{{ template "organizations/get_authorization_decisions.sql" }}
 {{ template "organizations/get_authorization_policies.sql" }}
 {{ template "organizations/get_authorization_policy.sql" }}
 {{ template "organizations/get_organization.sql" }}
+{{ template "organizations/get_organization_domains.sql" }}
+{{ template "organizations/get_organization_join_requests.sql" }}
 {{ template "organizations/get_organization_members.sql" }}
+{{ template "organizations/get_organization_public_profile.sql" }}
+{{ template "organizations/get_organization_security_overview.sql" }}
 {{ template "organizations/get_user_organizations.sql" }}
+{{ template "organizations/join_organization.sql" }}
+{{ template "organizations/register_authorization_decision.sql" }}
 {{ template "organizations/update_authorization_policy.sql" }}
 {{ template "organizations/update_organization.sql" }}
+{{ template "organizations/update_organization_domain.sql" }}
 {{ template "organizations/user_belongs_to_organization.sql" }}
+{{ template "organizations/verify_organization_domain.sql" }}
 
 {{ template "packages/add_production_usage.sql" }}
 {{ template "packages/are_all_containers_images_whitelisted.sql" }}
@@ -37,18 +95,32 @@
 {{ template "packages/generate_package_tsdoc.sql" }}
 {{ template "packages/get_harbor_replication_dump.sql" }}
 {{ template "packages/get_helm_exporter_dump.sql" }}
+{{ template "packages/get_image_scan.sql" }}
+{{ template "packages/get_license_family.sql" }}
 {{ template "packages/get_package.sql" }}
 {{ template "packages/get_package_changelog.sql" }}
+{{ template "packages/get_package_downloads.sql" }}
 {{ template "packages/get_package_summary.sql" }}
+{{ template "packages/get_packages_ranking.sql" }}
 {{ template "packages/get_packages_starred_by_user.sql" }}
 {{ template "packages/get_package_stars.sql" }}
+{{ template "packages/get_package_upgrade_graph.sql" }}
 {{ template "packages/get_package_views.sql" }}
 {{ template "packages/get_packages_stats.sql" }}
 {{ template "packages/get_production_usage.sql" }}
 {{ template "packages/get_random_packages.sql" }}
+{{ template "packages/get_snapshot_bundle_data.sql" }}
+{{ template "packages/get_snapshot_images.sql" }}
+{{ template "packages/get_snapshot_license_inventory.sql" }}
 {{ template "packages/get_snapshots_to_scan.sql" }}
+{{ template "packages/get_vulnerability_statements.sql" }}
 {{ template "packages/is_latest.sql" }}
+{{ template "packages/register_eol_events.sql" }}
+{{ template "packages/register_image_scan.sql" }}
 {{ template "packages/register_package.sql" }}
+{{ template "packages/register_packages_downloads.sql" }}
+{{ template "packages/refresh_packages_rankings.sql" }}
+{{ template "packages/request_snapshot_scan.sql" }}
 {{ template "packages/search_packages.sql" }}
 {{ template "packages/search_packages_monocular.sql" }}
 {{ template "packages/semver_gt.sql" }}
@@ -57,11 +129,24 @@
 {{ template "packages/unregister_package.sql" }}
 {{ template "packages/update_packages_views.sql" }}
 {{ template "packages/update_snapshot_security_report.sql" }}
+{{ template "packages/update_vulnerability_statements.sql" }}
 
 {{ template "repositories/add_repository.sql" }}
+{{ template "repositories/approve_repository_change.sql" }}
 {{ template "repositories/delete_repository.sql" }}
+{{ template "repositories/get_pending_repository_changes.sql" }}
 {{ template "repositories/get_repository_by_name.sql" }}
+{{ template "repositories/get_repository_change_approvers.sql" }}
+{{ template "repositories/get_repository_events.sql" }}
 {{ template "repositories/get_repository_packages_digest.sql" }}
+{{ template "repositories/get_repository_stats.sql" }}
+{{ template "repositories/get_repository_subscriptions.sql" }}
+{{ template "repositories/get_repository_views.sql" }}
+{{ template "repositories/register_repository_change.sql" }}
+{{ template "repositories/register_repository_kind.sql" }}
+{{ template "repositories/register_repository_ownership_claim_token.sql" }}
+{{ template "repositories/register_repository_tracking_run.sql" }}
+{{ template "repositories/reject_repository_change.sql" }}
 {{ template "repositories/search_repositories.sql" }}
 {{ template "repositories/set_last_scanning_results.sql" }}
 {{ template "repositories/set_last_tracking_results.sql" }}
@@ -69,7 +154,12 @@
 {{ template "repositories/transfer_repository.sql" }}
 {{ template "repositories/update_repository.sql" }}
 
+{{ template "sitemap/get_sitemap_packages.sql" }}
+{{ template "sitemap/get_sitemap_repositories.sql" }}
+
 {{ template "stats/get_stats.sql" }}
+{{ template "stats/get_stats_running_total.sql" }}
+{{ template "stats/get_stats_time_series.sql" }}
 
 {{ template "subscriptions/add_opt_out.sql" }}
 {{ template "subscriptions/add_subscription.sql" }}
@@ -84,15 +174,29 @@
 {{ template "users/approve_session.sql" }}
 {{ template "users/check_user_alias_availability.sql" }}
 {{ template "users/delete_user.sql" }}
+{{ template "users/delete_user_email_suppression.sql" }}
+{{ template "users/get_admin_audit_log.sql" }}
+{{ template "users/get_user_email_suppression.sql" }}
+{{ template "users/get_user_identities.sql" }}
 {{ template "users/get_user_profile.sql" }}
+{{ template "users/get_user_public_profile.sql" }}
 {{ template "users/get_user_tfa_config.sql" }}
+{{ template "users/link_user_identity.sql" }}
+{{ template "users/register_admin_audit_entry.sql" }}
 {{ template "users/register_delete_user_code.sql" }}
+{{ template "users/register_email_verification_code.sql" }}
 {{ template "users/register_password_reset_code.sql" }}
+{{ template "users/register_impersonation_session.sql" }}
 {{ template "users/register_session.sql" }}
 {{ template "users/register_user.sql" }}
 {{ template "users/reset_user_password.sql" }}
+{{ template "users/reset_user_tfa.sql" }}
+{{ template "users/search_users.sql" }}
+{{ template "users/set_user_disabled.sql" }}
+{{ template "users/unlink_user_identity.sql" }}
 {{ template "users/update_user_password.sql" }}
 {{ template "users/update_user_profile.sql" }}
+{{ template "users/verify_admin_audit_log.sql" }}
 {{ template "users/verify_email.sql" }}
 {{ template "users/verify_password_reset_code.sql" }}
 
@@ -102,6 +206,7 @@
 {{ template "webhooks/get_org_webhooks.sql" }}
 {{ template "webhooks/get_user_webhooks.sql" }}
 {{ template "webhooks/get_webhooks_subscribed_to_package.sql" }}
+{{ template "webhooks/replay_webhook_events.sql" }}
 {{ template "webhooks/update_webhook.sql" }}
 {{ template "webhooks/user_has_access_to_webhook.sql" }}
 
@@ -7,10 +7,12 @@ begin
     insert into api_key (
         name,
         secret,
+        allowed_cidrs,
         user_id
     ) values (
         p_api_key->>'name',
         p_api_key->>'secret',
+        nullif(array(select jsonb_array_elements_text(nullif(p_api_key->'allowed_cidrs', 'null'::jsonb)))::cidr[], '{}'),
         (p_api_key->>'user_id')::uuid
     ) returning api_key_id into v_api_key_id;
 
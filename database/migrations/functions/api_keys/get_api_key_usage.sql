@@ -0,0 +1,34 @@
+-- get_api_key_usage returns the usage of the api key provided during the last
+-- 30 days as a json object.
+create or replace function get_api_key_usage(p_user_id uuid, p_api_key_id uuid)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'api_key_id', ak.api_key_id,
+        'last_used_at', floor(extract(epoch from ak.last_used_at)),
+        'requests', coalesce((
+            select sum(requests)
+            from api_key_usage
+            where api_key_id = ak.api_key_id
+            and day > current_date - '30 days'::interval
+        ), 0),
+        'errors', coalesce((
+            select sum(errors)
+            from api_key_usage
+            where api_key_id = ak.api_key_id
+            and day > current_date - '30 days'::interval
+        ), 0),
+        'daily', (
+            select coalesce(json_agg(json_build_object(
+                'day', day,
+                'requests', requests,
+                'errors', errors
+            ) order by day asc), '[]')
+            from api_key_usage
+            where api_key_id = ak.api_key_id
+            and day > current_date - '30 days'::interval
+        )
+    ))
+    from api_key ak
+    where ak.api_key_id = p_api_key_id
+    and ak.user_id = p_user_id;
+$$ language sql;
@@ -2,7 +2,9 @@
 create or replace function update_api_key(p_api_key jsonb)
 returns void as $$
     update api_key
-    set name = p_api_key->>'name'
+    set
+        name = p_api_key->>'name',
+        allowed_cidrs = nullif(array(select jsonb_array_elements_text(nullif(p_api_key->'allowed_cidrs', 'null'::jsonb)))::cidr[], '{}')
     where api_key_id = (p_api_key->>'api_key_id')::uuid
     and user_id = (p_api_key->>'user_id')::uuid;
 $$ language sql;
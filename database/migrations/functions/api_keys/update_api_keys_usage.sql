@@ -0,0 +1,33 @@
+-- update_api_keys_usage updates the usage counters and the last time used of
+-- the api keys provided.
+create or replace function update_api_keys_usage(p_lock_key bigint, p_data jsonb)
+returns void as $$
+    -- Make sure only one batch of updates is processed at a time
+    select pg_advisory_xact_lock(p_lock_key);
+
+    -- Insert or update the corresponding usage counters as needed (api keys
+    -- deleted since the usage was tracked are ignored)
+    insert into api_key_usage (api_key_id, day, requests, errors)
+    select
+        (value->>0)::uuid as api_key_id,
+        (value->>1)::date as day,
+        (value->>2)::integer as requests,
+        (value->>3)::integer as errors
+    from jsonb_array_elements(p_data)
+    where exists (select from api_key where api_key_id = (value->>0)::uuid)
+    on conflict (api_key_id, day) do
+    update set
+        requests = api_key_usage.requests + excluded.requests,
+        errors = api_key_usage.errors + excluded.errors;
+
+    -- Update the last time the api keys were used
+    update api_key ak set last_used_at = greatest(ak.last_used_at, u.last_used_at)
+    from (
+        select
+            (value->>0)::uuid as api_key_id,
+            max(to_timestamp((value->>4)::bigint)) as last_used_at
+        from jsonb_array_elements(p_data)
+        group by 1
+    ) u
+    where ak.api_key_id = u.api_key_id;
+$$ language sql;
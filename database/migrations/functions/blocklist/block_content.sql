@@ -0,0 +1,54 @@
+-- block_content blocks the repository or package provided, so that it's
+-- hidden from search results and package endpoints and it's not processed by
+-- the tracker anymore. The operation is recorded in the admin audit log.
+create or replace function block_content(p_input jsonb, p_audit jsonb)
+returns uuid as $$
+declare
+    v_repository_id uuid;
+    v_package_id uuid;
+    v_blocked_content_id uuid;
+begin
+    select repository_id into v_repository_id
+    from repository
+    where name = p_input->>'repository_name';
+    if not found then
+        raise 'content not found';
+    end if;
+
+    if p_input->>'package_name' <> '' then
+        select package_id into v_package_id
+        from package
+        where repository_id = v_repository_id
+        and normalized_name = p_input->>'package_name';
+        if not found then
+            raise 'content not found';
+        end if;
+        v_repository_id = null;
+    end if;
+
+    insert into blocked_content (
+        repository_id,
+        package_id,
+        reason
+    ) values (
+        v_repository_id,
+        v_package_id,
+        p_input->>'reason'
+    ) returning blocked_content_id into v_blocked_content_id;
+
+    perform register_admin_audit_entry(
+        'block_content',
+        null,
+        p_audit || jsonb_build_object(
+            'reason', p_input->>'reason',
+            'details', jsonb_build_object(
+                'blocked_content_id', v_blocked_content_id,
+                'repository_name', p_input->>'repository_name',
+                'package_name', nullif(p_input->>'package_name', '')
+            )
+        )
+    );
+
+    return v_blocked_content_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,16 @@
+-- get_blocked_content returns all the repositories and packages that have
+-- been blocked as a json array.
+create or replace function get_blocked_content()
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'blocked_content_id', bc.blocked_content_id,
+        'repository_name', coalesce(r.name, pr.name),
+        'package_name', p.normalized_name,
+        'reason', bc.reason,
+        'created_at', floor(extract(epoch from bc.created_at))
+    )) order by bc.created_at desc), '[]')
+    from blocked_content bc
+    left join repository r on r.repository_id = bc.repository_id
+    left join package p on p.package_id = bc.package_id
+    left join repository pr on pr.repository_id = p.repository_id;
+$$ language sql;
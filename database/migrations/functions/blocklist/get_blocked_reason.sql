@@ -0,0 +1,12 @@
+-- get_blocked_reason returns the reason why the repository or package
+-- provided has been blocked. When the content has not been blocked, null is
+-- returned.
+create or replace function get_blocked_reason(p_repository_id uuid, p_package_id uuid)
+returns text as $$
+    select reason
+    from blocked_content
+    where repository_id = p_repository_id
+    or package_id = p_package_id
+    order by repository_id nulls last
+    limit 1;
+$$ language sql;
@@ -0,0 +1,34 @@
+-- unblock_content unblocks the repository or package identified by the
+-- blocked content id provided. The operation is recorded in the admin audit
+-- log.
+create or replace function unblock_content(p_blocked_content_id uuid, p_audit jsonb)
+returns void as $$
+declare
+    v_repository_name text;
+    v_package_name text;
+begin
+    select r.name, p.normalized_name into v_repository_name, v_package_name
+    from blocked_content bc
+    left join package p using (package_id)
+    left join repository r on r.repository_id = coalesce(bc.repository_id, p.repository_id)
+    where bc.blocked_content_id = p_blocked_content_id;
+
+    delete from blocked_content
+    where blocked_content_id = p_blocked_content_id;
+    if not found then
+        raise 'content not found';
+    end if;
+
+    perform register_admin_audit_entry(
+        'unblock_content',
+        null,
+        p_audit || jsonb_build_object(
+            'details', jsonb_build_object(
+                'blocked_content_id', p_blocked_content_id,
+                'repository_name', v_repository_name,
+                'package_name', v_package_name
+            )
+        )
+    );
+end
+$$ language plpgsql;
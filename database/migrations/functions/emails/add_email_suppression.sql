@@ -0,0 +1,12 @@
+-- add_email_suppression adds the email address provided to the suppression
+-- list, so that no more emails are sent to it.
+create or replace function add_email_suppression(p_email text, p_reason text, p_provider text)
+returns void as $$
+    insert into email_suppression (email, reason, provider)
+    values (lower(p_email), p_reason, p_provider)
+    on conflict (email) do update
+    set
+        reason = excluded.reason,
+        provider = excluded.provider,
+        created_at = current_timestamp;
+$$ language sql;
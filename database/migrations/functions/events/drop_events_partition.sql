@@ -0,0 +1,19 @@
+-- drop_events_partition drops the events and notifications partitions that
+-- start on the date provided.
+create or replace function drop_events_partition(p_partition_start date)
+returns void as $$
+declare
+    v_partition record;
+begin
+    -- Notifications partition is dropped first, as it references the events one
+    for v_partition in
+        select *
+        from get_events_partitions()
+        where start_date = p_partition_start
+        order by parent_table = 'public.event'
+    loop
+        execute format('alter table %s detach partition %s', v_partition.parent_table, v_partition.partition_table);
+        execute format('drop table %s', v_partition.partition_table);
+    end loop;
+end
+$$ language plpgsql;
@@ -0,0 +1,29 @@
+-- get_events_archive returns all the events and notifications stored in the
+-- partitions that start on the date provided. When no partitions are found,
+-- null is returned.
+create or replace function get_events_archive(p_partition_start date)
+returns json as $$
+declare
+    v_partition record;
+    v_rows json;
+    v_archive jsonb := '{}';
+begin
+    for v_partition in
+        select * from get_events_partitions() where start_date = p_partition_start
+    loop
+        execute format('select coalesce(json_agg(t), ''[]'') from %s t', v_partition.partition_table)
+        into v_rows;
+        v_archive := v_archive || jsonb_build_object(
+            case v_partition.parent_table
+                when 'public.event' then 'events'
+                else 'notifications'
+            end,
+            v_rows
+        );
+    end loop;
+    if v_archive = '{}' then
+        return null;
+    end if;
+    return v_archive;
+end
+$$ language plpgsql;
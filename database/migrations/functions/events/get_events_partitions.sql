@@ -0,0 +1,16 @@
+-- get_events_partitions returns the partitions of the events and notifications
+-- tables, along with the period of time each of them covers.
+create or replace function get_events_partitions()
+returns table(parent_table text, partition_table text, start_date date, end_date date) as $$
+    select
+        pt.parent_table,
+        p.partition_schemaname || '.' || p.partition_tablename,
+        i.child_start_time::date,
+        i.child_end_time::date
+    from unnest(array['public.event', 'public.notification']) as pt(parent_table)
+    cross join lateral partman.show_partitions(pt.parent_table) p
+    cross join lateral partman.show_partition_info(
+        p.partition_schemaname || '.' || p.partition_tablename,
+        p_parent_table := pt.parent_table
+    ) i;
+$$ language sql;
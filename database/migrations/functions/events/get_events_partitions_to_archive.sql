@@ -0,0 +1,10 @@
+-- get_events_partitions_to_archive returns the start date of the events
+-- partitions that only contain events older than the retention period
+-- provided (in days).
+create or replace function get_events_partitions_to_archive(p_retention_days int)
+returns setof json as $$
+    select coalesce(json_agg(start_date order by start_date), '[]')
+    from get_events_partitions()
+    where parent_table = 'public.event'
+    and end_date <= current_date - p_retention_days;
+$$ language sql;
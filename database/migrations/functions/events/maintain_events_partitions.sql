@@ -0,0 +1,9 @@
+-- maintain_events_partitions makes sure the partitions needed to store
+-- upcoming events and notifications are available.
+create or replace function maintain_events_partitions()
+returns void as $$
+begin
+    perform partman.run_maintenance('public.event');
+    perform partman.run_maintenance('public.notification');
+end
+$$ language plpgsql;
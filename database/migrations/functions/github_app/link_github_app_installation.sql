@@ -0,0 +1,25 @@
+-- link_github_app_installation links the GitHub App installation provided to
+-- the user or organization given, allowing the repositories they own to be
+-- verified using it. Installations can only be linked once.
+create or replace function link_github_app_installation(
+    p_installation_id bigint,
+    p_user_id uuid,
+    p_org_name text
+) returns void as $$
+begin
+    if p_org_name is not null and not user_belongs_to_organization(p_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    update github_app_installation set
+        user_id = case when p_org_name is null then p_user_id end,
+        organization_id = (select organization_id from organization where name = p_org_name)
+    where installation_id = p_installation_id
+    and user_id is null
+    and organization_id is null;
+
+    if not found then
+        raise exception 'installation not found or already linked';
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,25 @@
+-- process_github_app_push processes a push event received from the GitHub
+-- App installation provided. The git based repositories located in the
+-- GitHub repository pushed and owned by the user or organization the
+-- installation has been linked to are marked as verified (the app can only be
+-- installed by the owners of the GitHub account) and their tracking is
+-- requested. It returns the number of repositories updated.
+create or replace function process_github_app_push(p_installation_id bigint, p_repository_url text)
+returns integer as $$
+    with updated_repositories as (
+        update repository r set
+            github_app_installation_id = i.installation_id,
+            verified_publisher = true,
+            tracking_requested_at = current_timestamp
+        from github_app_installation i
+        where i.installation_id = p_installation_id
+        and lower(split_part(p_repository_url, '/', 4)) = lower(i.account)
+        and (r.user_id = i.user_id or r.organization_id = i.organization_id)
+        and (
+            lower(r.url) = lower(p_repository_url)
+            or left(lower(r.url), length(p_repository_url) + 1) = lower(p_repository_url) || '/'
+        )
+        returning r.repository_id
+    )
+    select count(*)::integer from updated_repositories;
+$$ language sql;
@@ -0,0 +1,8 @@
+-- register_github_app_installation registers the GitHub App installation
+-- provided, or updates the account it belongs to if it was already registered.
+create or replace function register_github_app_installation(p_installation_id bigint, p_account text)
+returns void as $$
+    insert into github_app_installation (installation_id, account)
+    values (p_installation_id, p_account)
+    on conflict (installation_id) do update set account = excluded.account;
+$$ language sql;
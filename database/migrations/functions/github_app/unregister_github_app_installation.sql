@@ -0,0 +1,6 @@
+-- unregister_github_app_installation unregisters the GitHub App installation
+-- provided. Repositories verified using it will stop being linked to it.
+create or replace function unregister_github_app_installation(p_installation_id bigint)
+returns void as $$
+    delete from github_app_installation where installation_id = p_installation_id;
+$$ language sql;
@@ -0,0 +1,37 @@
+-- get_inbox_notifications returns the notifications in the inbox of the
+-- requesting user as a json array, most recent first. When unread only is set,
+-- only the notifications not read yet will be returned.
+create or replace function get_inbox_notifications(
+    p_user_id uuid,
+    p_unread_only boolean,
+    p_limit int,
+    p_offset int
+) returns table(data json, total_count bigint) as $$
+    with user_inbox_notifications as (
+        select
+            inbox_notification_id,
+            kind,
+            data,
+            read,
+            created_at
+        from inbox_notification
+        where user_id = p_user_id
+        and (p_unread_only = false or read = false)
+    )
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'inbox_notification_id', inbox_notification_id,
+            'kind', kind,
+            'data', data,
+            'read', read,
+            'created_at', floor(extract(epoch from created_at))
+        ))), '[]'),
+        (select count(*) from user_inbox_notifications)
+    from (
+        select *
+        from user_inbox_notifications
+        order by created_at desc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) n;
+$$ language sql;
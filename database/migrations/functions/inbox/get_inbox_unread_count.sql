@@ -0,0 +1,11 @@
+-- get_inbox_unread_count returns the number of notifications not read yet in
+-- the inbox of the requesting user as a json object.
+create or replace function get_inbox_unread_count(p_user_id uuid)
+returns setof json as $$
+    select json_build_object(
+        'count', count(*)
+    )
+    from inbox_notification
+    where user_id = p_user_id
+    and read = false;
+$$ language sql;
@@ -0,0 +1,10 @@
+-- mark_all_inbox_notifications_as_read marks all the notifications in the
+-- inbox of the requesting user as read.
+create or replace function mark_all_inbox_notifications_as_read(p_user_id uuid)
+returns void as $$
+    update inbox_notification set
+        read = true,
+        read_at = current_timestamp
+    where user_id = p_user_id
+    and read = false;
+$$ language sql;
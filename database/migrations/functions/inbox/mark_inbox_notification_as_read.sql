@@ -0,0 +1,11 @@
+-- mark_inbox_notification_as_read marks the provided notification in the
+-- inbox of the requesting user as read.
+create or replace function mark_inbox_notification_as_read(p_user_id uuid, p_inbox_notification_id uuid)
+returns void as $$
+    update inbox_notification set
+        read = true,
+        read_at = current_timestamp
+    where inbox_notification_id = p_inbox_notification_id
+    and user_id = p_user_id
+    and read = false;
+$$ language sql;
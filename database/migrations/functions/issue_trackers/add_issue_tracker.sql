@@ -0,0 +1,33 @@
+-- add_issue_tracker adds the provided issue tracker integration to the
+-- organization given, if the requesting user belongs to it.
+create or replace function add_issue_tracker(
+    p_user_id uuid,
+    p_org_name text,
+    p_issue_tracker jsonb
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    insert into issue_tracker (
+        organization_id,
+        kind,
+        url,
+        project,
+        labels,
+        username,
+        token,
+        active
+    ) values (
+        (select organization_id from organization where name = p_org_name),
+        p_issue_tracker->>'kind',
+        nullif(p_issue_tracker->>'url', ''),
+        p_issue_tracker->>'project',
+        (select nullif(array(select jsonb_array_elements_text(nullif(p_issue_tracker->'labels', 'null'::jsonb))), '{}')),
+        nullif(p_issue_tracker->>'username', ''),
+        p_issue_tracker->>'token',
+        (p_issue_tracker->>'active')::boolean
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,18 @@
+-- delete_issue_tracker deletes the provided issue tracker integration from the
+-- database, if the requesting user belongs to the organization owning it.
+create or replace function delete_issue_tracker(p_user_id uuid, p_issue_tracker_id uuid)
+returns void as $$
+declare
+    v_org_name text;
+begin
+    select o.name into v_org_name
+    from issue_tracker it
+    join organization o using (organization_id)
+    where it.issue_tracker_id = p_issue_tracker_id;
+    if not user_belongs_to_organization(p_user_id, v_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from issue_tracker where issue_tracker_id = p_issue_tracker_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,43 @@
+-- get_issue_tracker_pending_vulnerabilities returns the critical
+-- vulnerabilities found in the security report of the package version
+-- provided that don't have an issue registered yet in the issue tracker given.
+create or replace function get_issue_tracker_pending_vulnerabilities(
+    p_issue_tracker_id uuid,
+    p_package_id uuid,
+    p_package_version text
+) returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'vulnerability_id', v.vulnerability_id,
+        'pkg_name', v.pkg_name,
+        'installed_version', v.installed_version,
+        'fixed_version', v.fixed_version,
+        'title', v.title,
+        'primary_url', v.primary_url,
+        'images', v.images
+    )) order by v.vulnerability_id asc), '[]')
+    from (
+        select
+            vuln->>'VulnerabilityID' as vulnerability_id,
+            min(vuln->>'PkgName') as pkg_name,
+            min(vuln->>'InstalledVersion') as installed_version,
+            min(vuln->>'FixedVersion') as fixed_version,
+            min(vuln->>'Title') as title,
+            min(vuln->>'PrimaryURL') as primary_url,
+            array_agg(distinct ir.image order by ir.image) as images
+        from snapshot s
+        cross join jsonb_each(s.security_report) as ir(image, report)
+        cross join jsonb_array_elements(coalesce(nullif(ir.report->'Results', 'null'), '[]')) as res
+        cross join jsonb_array_elements(coalesce(nullif(res->'Vulnerabilities', 'null'), '[]')) as vuln
+        where s.package_id = p_package_id
+        and s.version = p_package_version
+        and vuln->>'Severity' = 'CRITICAL'
+        and not exists (
+            select 1
+            from issue_tracker_issue iti
+            where iti.issue_tracker_id = p_issue_tracker_id
+            and iti.package_id = p_package_id
+            and iti.vulnerability_id = vuln->>'VulnerabilityID'
+        )
+        group by vuln->>'VulnerabilityID'
+    ) v;
+$$ language sql;
@@ -0,0 +1,24 @@
+-- get_issue_trackers_for_package returns the active issue tracker integrations
+-- of the organization owning the package provided, as long as the security
+-- report of the package version given contains critical vulnerabilities.
+create or replace function get_issue_trackers_for_package(p_package_id uuid, p_package_version text)
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'issue_tracker_id', it.issue_tracker_id,
+        'kind', it.kind,
+        'url', it.url,
+        'project', it.project,
+        'labels', it.labels,
+        'username', it.username,
+        'token', it.token,
+        'active', it.active
+    ))), '[]')
+    from issue_tracker it
+    join repository r using (organization_id)
+    join package p using (repository_id)
+    join snapshot s using (package_id)
+    where p.package_id = p_package_id
+    and s.version = p_package_version
+    and coalesce((s.security_report_summary->>'critical')::int, 0) > 0
+    and it.active = true;
+$$ language sql;
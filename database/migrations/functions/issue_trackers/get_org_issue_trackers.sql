@@ -0,0 +1,25 @@
+-- get_org_issue_trackers returns the issue tracker integrations that belong to
+-- the organization provided if the requesting user belongs to it. Tokens are
+-- never returned.
+create or replace function get_org_issue_trackers(p_user_id uuid, p_org_name text)
+returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'issue_tracker_id', it.issue_tracker_id,
+        'kind', it.kind,
+        'url', it.url,
+        'project', it.project,
+        'labels', it.labels,
+        'username', it.username,
+        'active', it.active
+    )) order by it.kind asc, it.project asc), '[]')
+    from issue_tracker it
+    join organization o using (organization_id)
+    where o.name = p_org_name;
+end
+$$ language plpgsql;
@@ -0,0 +1,22 @@
+-- register_issue_tracker_issue registers the issue created in the issue
+-- tracker provided for the given package and vulnerability, so that no more
+-- issues are created for them.
+create or replace function register_issue_tracker_issue(
+    p_issue_tracker_id uuid,
+    p_package_id uuid,
+    p_vulnerability_id text,
+    p_url text
+) returns void as $$
+    insert into issue_tracker_issue (
+        issue_tracker_id,
+        package_id,
+        vulnerability_id,
+        url
+    ) values (
+        p_issue_tracker_id,
+        p_package_id,
+        p_vulnerability_id,
+        nullif(p_url, '')
+    )
+    on conflict do nothing;
+$$ language sql;
@@ -0,0 +1,29 @@
+-- update_issue_tracker updates the provided issue tracker integration in the
+-- database, if the requesting user belongs to the organization owning it. The
+-- token is only updated when a new one is provided.
+create or replace function update_issue_tracker(p_user_id uuid, p_issue_tracker jsonb)
+returns void as $$
+declare
+    v_issue_tracker_id uuid := (p_issue_tracker->>'issue_tracker_id')::uuid;
+    v_org_name text;
+begin
+    select o.name into v_org_name
+    from issue_tracker it
+    join organization o using (organization_id)
+    where it.issue_tracker_id = v_issue_tracker_id;
+    if not user_belongs_to_organization(p_user_id, v_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    update issue_tracker set
+        kind = p_issue_tracker->>'kind',
+        url = nullif(p_issue_tracker->>'url', ''),
+        project = p_issue_tracker->>'project',
+        labels = (select nullif(array(select jsonb_array_elements_text(nullif(p_issue_tracker->'labels', 'null'::jsonb))), '{}')),
+        username = nullif(p_issue_tracker->>'username', ''),
+        token = coalesce(nullif(p_issue_tracker->>'token', ''), token),
+        active = (p_issue_tracker->>'active')::boolean,
+        updated_at = current_timestamp
+    where issue_tracker_id = v_issue_tracker_id;
+end
+$$ language plpgsql;
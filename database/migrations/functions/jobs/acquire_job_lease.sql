@@ -0,0 +1,25 @@
+-- acquire_job_lease tries to acquire the lease of the job provided for the
+-- current period. Periods are obtained by dividing the time since the epoch
+-- by the job frequency (in seconds), so they are the same for all holders.
+-- The lease can only be acquired once per period. It returns true when the
+-- lease has been acquired.
+create or replace function acquire_job_lease(p_job_name text, p_holder text, p_frequency int)
+returns boolean as $$
+    with lease as (
+        insert into job_lease (
+            job_name,
+            holder,
+            period
+        ) values (
+            p_job_name,
+            p_holder,
+            floor(extract(epoch from current_timestamp) / p_frequency)::bigint
+        )
+        on conflict (job_name) do update set
+            holder = excluded.holder,
+            period = excluded.period
+        where job_lease.period < excluded.period
+        returning job_name
+    )
+    select exists (select * from lease);
+$$ language sql;
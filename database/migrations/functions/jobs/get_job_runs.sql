@@ -0,0 +1,43 @@
+-- get_job_runs returns the background jobs runs that match the criteria in
+-- the input provided, most recent first.
+create or replace function get_job_runs(p_input jsonb)
+returns table(data json, total_count bigint) as $$
+declare
+    v_job_name text := p_input->>'job_name';
+    v_only_failed boolean := coalesce((p_input->>'only_failed')::boolean, false);
+begin
+    return query
+    with runs_found as (
+        select *
+        from job_run jr
+        where
+            case when v_job_name is not null and v_job_name <> '' then
+                jr.job_name = v_job_name
+            else true end
+        and
+            case when v_only_failed then
+                jr.succeeded = false
+            else true end
+    )
+    select
+        (
+            select coalesce(json_agg(json_strip_nulls(json_build_object(
+                'job_run_id', job_run_id,
+                'job_name', job_name,
+                'started_at', floor(extract(epoch from started_at)),
+                'duration', duration,
+                'attempts', attempts,
+                'succeeded', succeeded,
+                'error', error
+            ))), '[]')
+            from (
+                select *
+                from runs_found
+                order by started_at desc
+                limit (p_input->>'limit')::int
+                offset (p_input->>'offset')::int
+            ) rf
+        ),
+        (select count(*) from runs_found);
+end
+$$ language plpgsql;
@@ -0,0 +1,23 @@
+-- register_job_run registers the provided background job run. Runs older than
+-- 30 days are removed.
+create or replace function register_job_run(p_input jsonb)
+returns void as $$
+    insert into job_run (
+        job_name,
+        started_at,
+        duration,
+        attempts,
+        succeeded,
+        error
+    ) values (
+        p_input->>'job_name',
+        to_timestamp((p_input->>'started_at')::bigint),
+        (p_input->>'duration')::int,
+        (p_input->>'attempts')::int,
+        (p_input->>'succeeded')::boolean,
+        nullif(p_input->>'error', '')
+    );
+
+    delete from job_run
+    where started_at < current_timestamp - '30 days'::interval;
+$$ language sql;
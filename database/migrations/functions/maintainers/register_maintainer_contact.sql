@@ -0,0 +1,32 @@
+-- register_maintainer_contact registers that the user provided has contacted
+-- the maintainer given. Users can contact up to 5 maintainers per day, and the
+-- same maintainer only once per hour. It returns false when the request has
+-- been rejected because any of these limits has been reached.
+create or replace function register_maintainer_contact(
+    p_user_id uuid,
+    p_maintainer_id uuid
+) returns boolean as $$
+begin
+    -- Reject request if the user has reached the contact limits
+    if (
+        select count(*) from maintainer_contact
+        where user_id = p_user_id
+        and created_at > current_timestamp - '1 day'::interval
+    ) >= 5 then
+        return false;
+    end if;
+    perform from maintainer_contact
+    where user_id = p_user_id
+    and maintainer_id = p_maintainer_id
+    and created_at > current_timestamp - '1 hour'::interval;
+    if found then
+        return false;
+    end if;
+
+    -- Register contact
+    insert into maintainer_contact (user_id, maintainer_id)
+    values (p_user_id, p_maintainer_id);
+
+    return true;
+end
+$$ language plpgsql;
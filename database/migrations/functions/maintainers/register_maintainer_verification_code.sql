@@ -0,0 +1,45 @@
+-- register_maintainer_verification_code registers a verification code for the
+-- maintainer provided. Only the owner of a repository (or the members of the
+-- organization owning it) containing a package maintained by the maintainer
+-- can request the verification. It returns false when the request has been
+-- rejected because a verification code was registered recently.
+create or replace function register_maintainer_verification_code(
+    p_requesting_user_id uuid,
+    p_maintainer_id uuid,
+    p_code text
+) returns boolean as $$
+begin
+    -- Check if the user doing the request owns (or belongs to the organization
+    -- owning) any of the repositories containing packages maintained by the
+    -- maintainer provided
+    perform from package__maintainer pm
+    join package p using (package_id)
+    join repository r using (repository_id)
+    left join organization o using (organization_id)
+    where pm.maintainer_id = p_maintainer_id
+    and (
+        r.user_id = p_requesting_user_id
+        or (o.name is not null and user_belongs_to_organization(p_requesting_user_id, o.name))
+    );
+    if not found then
+        raise insufficient_privilege;
+    end if;
+
+    -- Reject request if a verification code was registered recently
+    perform from maintainer_verification_code
+    where maintainer_id = p_maintainer_id
+    and created_at > current_timestamp - '1 hour'::interval;
+    if found then
+        return false;
+    end if;
+
+    -- Register verification code
+    insert into maintainer_verification_code (maintainer_verification_code_id, maintainer_id)
+    values (p_code, p_maintainer_id)
+    on conflict (maintainer_id) do update set
+        maintainer_verification_code_id = p_code,
+        created_at = current_timestamp;
+
+    return true;
+end
+$$ language plpgsql;
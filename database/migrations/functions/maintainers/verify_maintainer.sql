@@ -0,0 +1,28 @@
+-- verify_maintainer verifies a maintainer using the provided verification
+-- code, returning true if the maintainer was verified successfully or false
+-- otherwise.
+create or replace function verify_maintainer(p_code text)
+returns boolean as $$
+declare
+    v_maintainer_id uuid;
+begin
+    -- Check if maintainer verification code exists and is not expired
+    select maintainer_id into v_maintainer_id
+    from maintainer_verification_code
+    where maintainer_verification_code_id = p_code
+    and created_at + '1 day'::interval > current_timestamp;
+    if not found then
+        return false;
+    end if;
+
+    -- Mark maintainer as verified
+    update maintainer set verified = true
+    where maintainer_id = v_maintainer_id;
+
+    -- Delete maintainer verification code
+    delete from maintainer_verification_code
+    where maintainer_verification_code_id = p_code;
+
+    return true;
+end
+$$ language plpgsql;
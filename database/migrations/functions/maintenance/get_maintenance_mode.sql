@@ -0,0 +1,10 @@
+-- get_maintenance_mode returns the maintenance mode status as a json object.
+create or replace function get_maintenance_mode()
+returns setof json as $$
+    select json_build_object(
+        'enabled', enabled,
+        'retry_after', retry_after,
+        'updated_at', floor(extract(epoch from updated_at))
+    )
+    from maintenance_mode;
+$$ language sql;
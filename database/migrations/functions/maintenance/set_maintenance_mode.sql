@@ -0,0 +1,10 @@
+-- set_maintenance_mode enables or disables the maintenance mode. While it is
+-- enabled the hub API is read-only and the tracker and scanner do not write
+-- to the database.
+create or replace function set_maintenance_mode(p_input jsonb)
+returns void as $$
+    update maintenance_mode set
+        enabled = (p_input->>'enabled')::boolean,
+        retry_after = coalesce(nullif((p_input->>'retry_after')::int, 0), retry_after),
+        updated_at = current_timestamp;
+$$ language sql;
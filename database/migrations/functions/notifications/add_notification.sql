@@ -1,13 +1,43 @@
--- add_notification adds the provided notification to the database.
+-- add_notification adds the provided notification to the database. When the
+-- notification is addressed to a user, it's also added to the user's inbox.
 create or replace function add_notification(p_notification jsonb)
 returns void as $$
     insert into notification (
         event_id,
+        event_created_at,
         user_id,
-        webhook_id
-    ) values (
-        ((p_notification->'event')->>'event_id')::uuid,
+        webhook_id,
+        issue_tracker_id
+    )
+    select
+        e.event_id,
+        e.created_at,
         ((p_notification->'user')->>'user_id')::uuid,
-        ((p_notification->'webhook')->>'webhook_id')::uuid
-    );
+        ((p_notification->'webhook')->>'webhook_id')::uuid,
+        ((p_notification->'issue_tracker')->>'issue_tracker_id')::uuid
+    from event e
+    where e.event_id = ((p_notification->'event')->>'event_id')::uuid;
+
+    insert into inbox_notification (
+        user_id,
+        kind,
+        data
+    )
+    select
+        ((p_notification->'user')->>'user_id')::uuid,
+        'event',
+        jsonb_strip_nulls(jsonb_build_object(
+            'event_kind', e.event_kind_id,
+            'package_id', p.package_id,
+            'package_name', p.name,
+            'package_normalized_name', p.normalized_name,
+            'package_version', e.package_version,
+            'repository_name', r.name,
+            'repository_kind_id', r.repository_kind_id
+        ))
+    from event e
+    left join package p using (package_id)
+    left join repository r on r.repository_id = coalesce(e.repository_id, p.repository_id)
+    where e.event_id = ((p_notification->'event')->>'event_id')::uuid
+    and (p_notification->'user')->>'user_id' is not null;
 $$ language sql;
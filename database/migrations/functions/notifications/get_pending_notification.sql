@@ -1,5 +1,9 @@
--- get_pending_notification returns a pending notification if available.
-create or replace function get_pending_notification()
+-- get_pending_notification returns a pending notification to be delivered
+-- through the channel provided if available. Notifications are returned by
+-- priority: security alerts first, then the rest of the events except new
+-- releases, which are usually generated in bursts by the tracker and are the
+-- least urgent ones.
+create or replace function get_pending_notification(p_channel text)
 returns setof json as $$
     select json_strip_nulls(json_build_object(
         'notification_id', n.notification_id,
@@ -12,9 +16,10 @@ returns setof json as $$
         ),
         'user', (select nullif(
             jsonb_build_object(
-                'email', u.email
+                'email', u.email,
+                'locale', u.locale
             ),
-            '{"email": null}'::jsonb
+            '{"email": null, "locale": null}'::jsonb
         )),
         'webhook', (select nullif(
             jsonb_build_object(
@@ -25,13 +30,39 @@ returns setof json as $$
                 'template', wh.template
             ),
             '{"name": null, "url": null, "secret": null, "content_type": null, "template": null}'::jsonb
+        )),
+        'issue_tracker', (select nullif(
+            jsonb_build_object(
+                'issue_tracker_id', it.issue_tracker_id,
+                'kind', it.kind,
+                'url', it.url,
+                'project', it.project,
+                'labels', it.labels,
+                'username', it.username,
+                'token', it.token
+            ),
+            '{"issue_tracker_id": null, "kind": null, "url": null, "project": null, "labels": null, "username": null, "token": null}'::jsonb
         ))
     ))
     from notification n
-    join event e using (event_id)
+    join event e on e.event_id = n.event_id and e.created_at = n.event_created_at
     left join "user" u using (user_id)
     left join webhook wh using (webhook_id)
+    left join issue_tracker it using (issue_tracker_id)
     where n.processed = false
+    and case p_channel
+        when 'email' then n.user_id is not null
+        when 'issue-tracker' then n.issue_tracker_id is not null
+        when 'webhook' then n.webhook_id is not null
+        else false
+    end
+    order by
+        case e.event_kind_id
+            when 1 then 0 -- Security alert
+            when 0 then 2 -- New release
+            else 1
+        end asc,
+        n.created_at asc
     for update of n skip locked
     limit 1;
 $$ language sql;
@@ -10,13 +10,15 @@ begin
         display_name,
         description,
         home_url,
-        logo_image_id
+        logo_image_id,
+        repository_changes_approval
     ) values (
         p_org->>'name',
         nullif(p_org->>'display_name', ''),
         nullif(p_org->>'description', ''),
         nullif(p_org->>'home_url', ''),
-        nullif(p_org->>'logo_image_id', '')::uuid
+        nullif(p_org->>'logo_image_id', '')::uuid,
+        coalesce((p_org->>'repository_changes_approval')::boolean, false)
     ) returning organization_id into v_org_id;
 
     -- Add user who created the organization to it
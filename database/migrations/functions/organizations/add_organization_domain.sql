@@ -0,0 +1,24 @@
+-- add_organization_domain adds a domain pending of verification to the
+-- provided organization.
+create or replace function add_organization_domain(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_domain text,
+    p_verification_token text
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    insert into organization_domain (
+        organization_id,
+        domain,
+        verification_token
+    ) values (
+        (select organization_id from organization where name = p_org_name),
+        p_domain,
+        p_verification_token
+    );
+end
+$$ language plpgsql;
@@ -1,4 +1,5 @@
--- add_organization_member adds a member to the provided organization.
+-- add_organization_member adds a member to the provided organization. An
+-- invitation is added to the inbox of the new member.
 create or replace function add_organization_member(
     p_requesting_user_id uuid,
     p_org_name text,
@@ -15,5 +16,22 @@ begin
         (select user_id from "user" where alias = p_user_alias),
         (select organization_id from organization where name = p_org_name)
     );
+
+    insert into inbox_notification (
+        user_id,
+        kind,
+        data
+    )
+    select
+        u.user_id,
+        'organization-invitation',
+        jsonb_strip_nulls(jsonb_build_object(
+            'organization_name', o.name,
+            'organization_display_name', o.display_name,
+            'invited_by', (select alias from "user" where user_id = p_requesting_user_id)
+        ))
+    from "user" u, organization o
+    where u.alias = p_user_alias
+    and o.name = p_org_name;
 end
 $$ language plpgsql;
@@ -0,0 +1,30 @@
+-- approve_organization_join_request approves the join request of the user
+-- provided, who becomes a member of the organization.
+create or replace function approve_organization_join_request(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_user_alias text
+) returns void as $$
+declare
+    v_org_id uuid;
+    v_user_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    select organization_id into v_org_id from organization where name = p_org_name;
+    select user_id into v_user_id from "user" where alias = p_user_alias;
+
+    delete from organization_join_request
+    where organization_id = v_org_id
+    and user_id = v_user_id;
+    if not found then
+        raise 'join request not found';
+    end if;
+
+    insert into user__organization (user_id, organization_id, confirmed)
+    values (v_user_id, v_org_id, true)
+    on conflict (user_id, organization_id) do update set confirmed = true;
+end
+$$ language plpgsql;
@@ -1,5 +1,5 @@
 -- confirm_organization_membership confirms a user's membership to the provided
--- organization.
+-- organization. The pending invitations in the user's inbox are marked as read.
 create or replace function confirm_organization_membership(p_user_id uuid, p_org_name text)
 returns void as $$
 begin
@@ -12,5 +12,13 @@ begin
     if not found then
         raise 'organization membership confirmation failed';
     end if;
+
+    update inbox_notification set
+        read = true,
+        read_at = current_timestamp
+    where user_id = p_user_id
+    and kind = 'organization-invitation'
+    and data->>'organization_name' = p_org_name
+    and read = false;
 end
 $$ language plpgsql;
@@ -0,0 +1,17 @@
+-- delete_organization_domain deletes a domain from the provided organization.
+-- Pending join requests are kept, so they can still be handled.
+create or replace function delete_organization_domain(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_domain text
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from organization_domain
+    where organization_id = (select organization_id from organization where name = p_org_name)
+    and domain = p_domain;
+end
+$$ language plpgsql;
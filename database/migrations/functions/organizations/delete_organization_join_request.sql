@@ -0,0 +1,17 @@
+-- delete_organization_join_request deletes the join request of the user
+-- provided, rejecting it.
+create or replace function delete_organization_join_request(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_user_alias text
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from organization_join_request
+    where organization_id = (select organization_id from organization where name = p_org_name)
+    and user_id = (select user_id from "user" where alias = p_user_alias);
+end
+$$ language plpgsql;
@@ -0,0 +1,51 @@
+-- get_authorization_decisions returns the most recent authorization decisions
+-- registered for the organization provided as a json array. When denied only
+-- is set, only the actions that were not allowed will be returned.
+create or replace function get_authorization_decisions(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_denied_only boolean,
+    p_limit int,
+    p_offset int
+) returns table(data json, total_count bigint) as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with decisions as (
+        select
+            d.authorization_decision_id,
+            u.alias as user_alias,
+            d.action,
+            d.allowed,
+            d.policy_digest,
+            d.error,
+            d.created_at
+        from authorization_decision d
+        join organization o using (organization_id)
+        join "user" u using (user_id)
+        where o.name = p_org_name
+        and (p_denied_only = false or d.allowed = false)
+    )
+    select
+        coalesce(json_agg(json_strip_nulls(json_build_object(
+            'authorization_decision_id', authorization_decision_id,
+            'user_alias', user_alias,
+            'action', action,
+            'allowed', allowed,
+            'policy_digest', policy_digest,
+            'error', error,
+            'created_at', floor(extract(epoch from created_at))
+        ))), '[]'),
+        (select count(*) from decisions)
+    from (
+        select *
+        from decisions
+        order by created_at desc
+        limit (case when p_limit = 0 then null else p_limit end)
+        offset p_offset
+    ) d;
+end
+$$ language plpgsql;
@@ -6,7 +6,9 @@ returns setof json as $$
         'display_name', o.display_name,
         'description', o.description,
         'home_url', o.home_url,
-        'logo_image_id', o.logo_image_id
+        'logo_image_id', o.logo_image_id,
+        'repository_changes_approval', o.repository_changes_approval,
+        'public_profile', o.public_profile
     ))
     from organization o
     where o.name = p_org_name;
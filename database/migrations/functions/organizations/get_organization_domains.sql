@@ -0,0 +1,25 @@
+-- get_organization_domains returns the domains of the organization provided as
+-- a json array.
+create or replace function get_organization_domains(
+    p_requesting_user_id uuid,
+    p_org_name text
+) returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'domain', d.domain,
+        'verification_token', d.verification_token,
+        'verified', d.verified_at is not null,
+        'verified_at', floor(extract(epoch from d.verified_at)),
+        'join_mode', d.join_mode,
+        'created_at', floor(extract(epoch from d.created_at))
+    )) order by d.domain), '[]')
+    from organization_domain d
+    join organization o using (organization_id)
+    where o.name = p_org_name;
+end
+$$ language plpgsql;
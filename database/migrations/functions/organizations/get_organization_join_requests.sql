@@ -0,0 +1,25 @@
+-- get_organization_join_requests returns the pending join requests of the
+-- organization provided as a json array.
+create or replace function get_organization_join_requests(
+    p_requesting_user_id uuid,
+    p_org_name text
+) returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'alias', u.alias,
+        'first_name', u.first_name,
+        'last_name', u.last_name,
+        'email', u.email,
+        'created_at', floor(extract(epoch from jr.created_at))
+    )) order by jr.created_at), '[]')
+    from organization_join_request jr
+    join organization o using (organization_id)
+    join "user" u using (user_id)
+    where o.name = p_org_name;
+end
+$$ language plpgsql;
@@ -0,0 +1,31 @@
+-- get_organization_public_profile returns the public profile of the provided
+-- organization as a json object. Only organizations that have enabled their
+-- public profile are returned.
+create or replace function get_organization_public_profile(p_org_name text)
+returns setof json as $$
+    select (
+        jsonb_strip_nulls(jsonb_build_object(
+            'name', o.name,
+            'display_name', o.display_name,
+            'description', o.description,
+            'home_url', o.home_url,
+            'logo_image_id', o.logo_image_id,
+            'members', (
+                select coalesce(jsonb_agg(jsonb_strip_nulls(jsonb_build_object(
+                    'alias', u.alias,
+                    'profile_image_id', u.profile_image_id
+                )) order by u.alias asc), '[]')
+                from "user" u
+                join user__organization uo using (user_id)
+                where uo.organization_id = o.organization_id
+                and uo.confirmed = true
+                and u.public_profile = true
+                and u.disabled = false
+            )
+        ))
+        || get_publisher_profile_content(null, o.organization_id)
+    )::json
+    from organization o
+    where o.name = p_org_name
+    and o.public_profile = true;
+$$ language sql;
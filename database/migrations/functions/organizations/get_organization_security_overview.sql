@@ -0,0 +1,110 @@
+-- get_organization_security_overview returns an overview of the security
+-- reports of the packages in the repositories owned by the organization
+-- provided as a json object.
+create or replace function get_organization_security_overview(
+    p_requesting_user_id uuid,
+    p_org_name text
+) returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with org_packages as (
+        select p.package_id, p.name, p.normalized_name, p.latest_version, r.repository_id
+        from package p
+        join repository r using (repository_id)
+        join organization o using (organization_id)
+        where o.name = p_org_name
+    ), latest_snapshots as (
+        select
+            op.package_id,
+            op.name,
+            op.normalized_name,
+            op.repository_id,
+            s.version,
+            s.security_report_summary,
+            s.security_report_created_at,
+            coalesce((s.security_report_summary->>'critical')::int, 0) as critical,
+            coalesce((s.security_report_summary->>'high')::int, 0) as high,
+            coalesce((s.security_report_summary->>'medium')::int, 0) as medium,
+            coalesce((s.security_report_summary->>'low')::int, 0) as low,
+            coalesce((s.security_report_summary->>'unknown')::int, 0) as unknown
+        from org_packages op
+        join snapshot s on s.package_id = op.package_id and s.version = op.latest_version
+    ), months as (
+        select generate_series(
+            date_trunc('month', current_date) - '11 months'::interval,
+            date_trunc('month', current_date),
+            '1 month'::interval
+        ) as month
+    ), monthly_snapshots as (
+        select m.month, ms.*
+        from months m
+        cross join lateral (
+            select distinct on (s.package_id)
+                coalesce((s.security_report_summary->>'critical')::int, 0) as critical,
+                coalesce((s.security_report_summary->>'high')::int, 0) as high,
+                coalesce((s.security_report_summary->>'medium')::int, 0) as medium,
+                coalesce((s.security_report_summary->>'low')::int, 0) as low,
+                coalesce((s.security_report_summary->>'unknown')::int, 0) as unknown
+            from snapshot s
+            join org_packages op using (package_id)
+            where s.security_report_created_at < m.month + '1 month'::interval
+            order by s.package_id, s.ts desc
+        ) ms
+    )
+    select json_build_object(
+        'summary', (
+            select json_build_object(
+                'packages', count(*),
+                'affected_packages', count(*) filter (
+                    where critical + high + medium + low + unknown > 0
+                ),
+                'critical', coalesce(sum(critical), 0),
+                'high', coalesce(sum(high), 0),
+                'medium', coalesce(sum(medium), 0),
+                'low', coalesce(sum(low), 0),
+                'unknown', coalesce(sum(unknown), 0)
+            )
+            from latest_snapshots
+        ),
+        'packages', (
+            select coalesce(json_agg(json_strip_nulls(json_build_object(
+                'package_id', package_id,
+                'name', name,
+                'normalized_name', normalized_name,
+                'version', version,
+                'security_report_summary', security_report_summary,
+                'security_report_created_at', floor(extract(epoch from security_report_created_at)),
+                'repository', (select get_repository_summary(repository_id))
+            )) order by critical desc, high desc, medium desc, low desc, unknown desc, name asc), '[]')
+            from latest_snapshots
+            where critical + high + medium + low + unknown > 0
+        ),
+        'trends', (
+            select json_agg(json_build_object(
+                'month', floor(extract(epoch from month)),
+                'critical', critical,
+                'high', high,
+                'medium', medium,
+                'low', low,
+                'unknown', unknown
+            ) order by month asc)
+            from (
+                select
+                    m.month,
+                    coalesce(sum(ms.critical), 0) as critical,
+                    coalesce(sum(ms.high), 0) as high,
+                    coalesce(sum(ms.medium), 0) as medium,
+                    coalesce(sum(ms.low), 0) as low,
+                    coalesce(sum(ms.unknown), 0) as unknown
+                from months m
+                left join monthly_snapshots ms using (month)
+                group by m.month
+            ) mt
+        )
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,55 @@
+-- join_organization adds the user provided to the organization given when the
+-- user's verified email belongs to one of the organization's verified domains
+-- that allow joining. Depending on the domain's join mode, the user joins the
+-- organization directly (auto) or a join request is registered (request). The
+-- join mode applied is returned.
+create or replace function join_organization(p_user_id uuid, p_org_name text)
+returns text as $$
+declare
+    v_org_id uuid;
+    v_join_mode text;
+begin
+    select organization_id into v_org_id
+    from organization
+    where name = p_org_name;
+
+    -- Check the user is not a member of the organization yet
+    perform from user__organization
+    where user_id = p_user_id
+    and organization_id = v_org_id;
+    if found then
+        raise 'user is already a member of the organization';
+    end if;
+
+    -- Get the join mode of the domain matching the user's email (auto mode
+    -- takes precedence when several domains match)
+    select d.join_mode into v_join_mode
+    from organization_domain d
+    join "user" u on lower(split_part(u.email, '@', 2)) = d.domain
+    where d.organization_id = v_org_id
+    and d.verified_at is not null
+    and d.join_mode <> 'disabled'
+    and u.user_id = p_user_id
+    and u.email_verified = true
+    order by d.join_mode = 'auto' desc
+    limit 1;
+    if not found then
+        raise insufficient_privilege;
+    end if;
+
+    -- Join organization or register join request
+    if v_join_mode = 'auto' then
+        insert into user__organization (user_id, organization_id, confirmed)
+        values (p_user_id, v_org_id, true);
+        delete from organization_join_request
+        where organization_id = v_org_id
+        and user_id = p_user_id;
+    else
+        insert into organization_join_request (organization_id, user_id)
+        values (v_org_id, p_user_id)
+        on conflict do nothing;
+    end if;
+
+    return v_join_mode;
+end
+$$ language plpgsql;
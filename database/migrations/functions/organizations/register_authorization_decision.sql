@@ -0,0 +1,36 @@
+-- register_authorization_decision registers the authorization decision
+-- provided. Decisions older than one week are deleted, as they are only kept
+-- to help organizations debug their authorization policies.
+create or replace function register_authorization_decision(p_decision jsonb)
+returns void as $$
+declare
+    v_organization_id uuid;
+begin
+    select organization_id into v_organization_id
+    from organization
+    where name = p_decision->>'organization_name';
+    if not found then
+        return;
+    end if;
+
+    insert into authorization_decision (
+        organization_id,
+        user_id,
+        action,
+        allowed,
+        policy_digest,
+        error
+    ) values (
+        v_organization_id,
+        (p_decision->>'user_id')::uuid,
+        p_decision->>'action',
+        (p_decision->>'allowed')::boolean,
+        p_decision->>'policy_digest',
+        nullif(p_decision->>'error', '')
+    );
+
+    delete from authorization_decision
+    where organization_id = v_organization_id
+    and created_at < current_timestamp - '1 week'::interval;
+end
+$$ language plpgsql;
@@ -12,7 +12,12 @@ begin
         display_name = nullif(p_org->>'display_name', ''),
         description = nullif(p_org->>'description', ''),
         home_url = nullif(p_org->>'home_url', ''),
-        logo_image_id = nullif(p_org->>'logo_image_id', '')::uuid
+        logo_image_id = nullif(p_org->>'logo_image_id', '')::uuid,
+        repository_changes_approval = coalesce(
+            (p_org->>'repository_changes_approval')::boolean,
+            repository_changes_approval
+        ),
+        public_profile = coalesce((p_org->>'public_profile')::boolean, public_profile)
     where name = p_org_name;
 end
 $$ language plpgsql;
@@ -0,0 +1,24 @@
+-- update_organization_domain updates the join mode of the provided
+-- organization domain. Joining can only be enabled for verified domains.
+create or replace function update_organization_domain(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_domain text,
+    p_join_mode text
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    update organization_domain set
+        join_mode = p_join_mode
+    where organization_id = (select organization_id from organization where name = p_org_name)
+    and domain = p_domain
+    and (verified_at is not null or p_join_mode = 'disabled');
+
+    if not found then
+        raise 'domain not found or not verified';
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,30 @@
+-- verify_organization_domain marks the provided organization domain as
+-- verified. A domain can only be verified by one organization.
+create or replace function verify_organization_domain(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_domain text
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    perform from organization_domain
+    where domain = p_domain
+    and verified_at is not null
+    and organization_id <> (select organization_id from organization where name = p_org_name);
+    if found then
+        raise 'domain already verified by another organization';
+    end if;
+
+    update organization_domain set
+        verified_at = coalesce(verified_at, current_timestamp)
+    where organization_id = (select organization_id from organization where name = p_org_name)
+    and domain = p_domain;
+
+    if not found then
+        raise 'domain not found';
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,13 @@
+-- get_image_scan returns the cached scan results of the container image
+-- identified by the digest provided as a json object.
+create or replace function get_image_scan(p_digest text)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'digest', digest,
+        'report', report,
+        'sboms', sboms,
+        'created_at', floor(extract(epoch from created_at))
+    ))
+    from image_scan
+    where digest = p_digest;
+$$ language sql;
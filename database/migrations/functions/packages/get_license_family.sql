@@ -0,0 +1,13 @@
+-- get_license_family returns the family of the license provided. Licenses are
+-- classified using their SPDX identifier (or name when it is not available).
+create or replace function get_license_family(p_license text)
+returns text as $$
+    select case
+        when p_license is null or p_license = '' then 'unknown'
+        when p_license ~* '^(AGPL|SSPL)' then 'network-copyleft'
+        when p_license ~* '^GPL' then 'strong-copyleft'
+        when p_license ~* '^(LGPL|MPL|EPL|CDDL|EUPL|OSL|CPL)' then 'weak-copyleft'
+        when p_license ~* '^(MIT|Apache|BSD|0BSD|ISC|Zlib|Unlicense|CC0|CC-BY-[0-9]|PostgreSQL|Python|PSF|BSL|X11|WTFPL|Artistic)' then 'permissive'
+        else 'unknown'
+    end;
+$$ language sql immutable;
@@ -1,31 +1,43 @@
 -- get_package returns the details as a json object of the package identified
--- by the input provided.
+-- by the input provided. When a locale is provided and the package version has
+-- a translation for it (or for its language), the translated description and
+-- readme are returned.
 create or replace function get_package(p_input jsonb)
 returns setof json as $$
 declare
+    v_blocked_reason text;
     v_package_id uuid;
+    v_repository_id uuid;
     v_repository_kind_id int;
     v_package_name text := p_input->>'package_name';
     v_repository_name text := p_input->>'repository_name';
+    v_locale text := nullif(p_input->>'locale', '');
 begin
     if p_input->>'package_id' <> '' then
         v_package_id = p_input->>'package_id';
 
-        select r.repository_kind_id into v_repository_kind_id
+        select r.repository_id, r.repository_kind_id
+        into v_repository_id, v_repository_kind_id
         from package p
         join repository r using (repository_id)
         where package_id = v_package_id;
     else
-        select p.package_id, r.repository_kind_id
-        into v_package_id, v_repository_kind_id
+        select p.package_id, r.repository_id, r.repository_kind_id
+        into v_package_id, v_repository_id, v_repository_kind_id
         from package p
         join repository r using (repository_id)
         where p.normalized_name = v_package_name
         and r.name = v_repository_name;
     end if;
 
+    -- Packages blocked by site admins are not returned
+    v_blocked_reason = get_blocked_reason(v_repository_id, v_package_id);
+    if v_blocked_reason is not null then
+        raise 'content blocked: %', v_blocked_reason;
+    end if;
+
     return query
-    select json_strip_nulls(json_build_object(
+    select jsonb_strip_nulls(jsonb_build_object(
         'package_id', p.package_id,
         'name', p.name,
         'normalized_name', p.normalized_name,
@@ -34,11 +46,12 @@ begin
         'channels', p.channels,
         'default_channel', p.default_channel,
         'display_name', s.display_name,
-        'description', s.description,
+        'description', coalesce(t.translation->>'description', s.description),
         'logo_image_id', s.logo_image_id,
+        'logo_dark_image_id', s.logo_dark_image_id,
         'keywords', s.keywords,
         'home_url', s.home_url,
-        'readme', s.readme,
+        'readme', coalesce(t.translation->>'readme', s.readme),
         'install', s.install,
         'links', s.links,
         'crds', s.crds,
@@ -53,6 +66,8 @@ begin
                 'version', version,
                 'contains_security_updates', contains_security_updates,
                 'prerelease', prerelease,
+                'support', support,
+                'k8s_compatibility', k8s_compatibility,
                 'ts', floor(extract(epoch from ts))
             ))
             from (
@@ -65,25 +80,39 @@ begin
         'app_version', s.app_version,
         'digest', s.digest,
         'deprecated', s.deprecated,
+        'replaced_by', s.replaced_by,
         'contains_security_updates', s.contains_security_updates,
         'prerelease', s.prerelease,
         'license', s.license,
         'signed', s.signed,
         'signatures', s.signatures,
+        'signature_verified', s.signature_verified,
         'content_url', s.content_url,
         'containers_images', s.containers_images,
         'all_containers_images_whitelisted', are_all_containers_images_whitelisted(s.containers_images),
         'provider', s.provider,
         'has_values_schema', (s.values_schema is not null and s.values_schema <> '{}'),
+        'has_crds_schemas', (s.crds_schemas is not null and s.crds_schemas <> '[]'),
+        'support', s.support,
+        'k8s_compatibility', s.k8s_compatibility,
+        'locale', t.locale,
+        'available_locales', (
+            select json_agg(locale order by locale)
+            from jsonb_object_keys(s.translations) as locale
+        ),
         'has_changelog', (select exists (
             select 1 from snapshot where package_id = v_package_id and changes is not null
         )),
         'changes', s.changes,
-        'ts', floor(extract(epoch from s.ts)),
+        'release_notes', s.release_notes,
+        'ts', floor(extract(epoch from s.ts))
+    ) || jsonb_build_object(
         'maintainers', (
             select json_agg(json_build_object(
+                'maintainer_id', m.maintainer_id,
                 'name', m.name,
-                'email', m.email
+                'email', m.email,
+                'verified', m.verified
             ))
             from maintainer m
             join package__maintainer pm using (maintainer_id)
@@ -91,7 +120,9 @@ begin
         ),
         'recommendations', s.recommendations,
         'screenshots', s.screenshots,
+        'videos', s.videos,
         'sign_key', s.sign_key,
+        'provenance', s.provenance,
         'repository', (select get_repository_summary(r.repository_id)),
         'stats', json_build_object(
             'subscriptions', (select count(*) from subscription where package_id = v_package_id),
@@ -112,10 +143,17 @@ begin
                 order by o.name asc
             ) o
         )
-    ))
+    ))::json
     from package p
     join snapshot s using (package_id)
     join repository r using (repository_id)
+    left join lateral (
+        select locale, translation
+        from jsonb_each(s.translations) as tr(locale, translation)
+        where locale in (v_locale, split_part(v_locale, '-', 1))
+        order by locale = v_locale desc
+        limit 1
+    ) t on true
     where p.package_id = v_package_id
     and
         case when p_input->>'version' <> '' then
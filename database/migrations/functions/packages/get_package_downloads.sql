@@ -0,0 +1,27 @@
+-- get_package_downloads returns the total number of downloads of the given
+-- package as well as the number of downloads per day in the time range
+-- delimited by the start and end provided organized by version as a json
+-- object.
+create or replace function get_package_downloads(p_package_id uuid, p_start date, p_end date)
+returns setof json as $$
+    with last_month_downloads as (
+        select version, day, total
+        from package_downloads
+        where package_id = p_package_id
+        and day >= p_start
+        and day <= p_end
+    )
+    select json_build_object(
+        'total', p.downloads,
+        'versions', (
+            select coalesce(json_object_agg(version, (
+                select json_object_agg(day, total)
+                from last_month_downloads
+                where version = versions.version
+            )), '{}')
+            from (select distinct(version) from last_month_downloads) as versions
+        )
+    )
+    from package p
+    where p.package_id = p_package_id;
+$$ language sql;
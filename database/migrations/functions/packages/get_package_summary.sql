@@ -23,16 +23,19 @@ begin
         'name', p.name,
         'normalized_name', p.normalized_name,
         'stars', p.stars,
+        'downloads', p.downloads,
         'official', p.official,
         'display_name', s.display_name,
         'description', s.description,
         'logo_image_id', s.logo_image_id,
+        'logo_dark_image_id', s.logo_dark_image_id,
         'version', s.version,
         'app_version', s.app_version,
         'license', s.license,
         'deprecated', s.deprecated,
         'signed', s.signed,
         'signatures', s.signatures,
+        'signature_verified', s.signature_verified,
         'security_report_summary', s.security_report_summary,
         'all_containers_images_whitelisted', are_all_containers_images_whitelisted(s.containers_images),
         'production_organizations_count', (select nullif(
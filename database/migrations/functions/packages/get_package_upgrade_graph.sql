@@ -0,0 +1,22 @@
+-- get_package_upgrade_graph returns the channels of the package identified by
+-- the id provided as well as the upgrade edges (replaces, skips and skip range)
+-- declared by each of its versions as a json object.
+create or replace function get_package_upgrade_graph(p_package_id uuid)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'default_channel', p.default_channel,
+        'channels', coalesce(p.channels, '[]'),
+        'versions', (
+            select coalesce(json_agg(json_build_object(
+                'version', s.version,
+                'replaces', s.data->>'replaces',
+                'skips', s.data->'skips',
+                'skip_range', s.data->>'skipRange'
+            ) order by s.ts desc), '[]')
+            from snapshot s
+            where s.package_id = p.package_id
+        )
+    ))
+    from package p
+    where p.package_id = p_package_id;
+$$ language sql;
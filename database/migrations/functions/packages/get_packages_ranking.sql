@@ -0,0 +1,9 @@
+-- get_packages_ranking returns the packages in the ranking provided, sorted by
+-- score, as a json array.
+create or replace function get_packages_ranking(p_ranking text)
+returns setof json as $$
+    select coalesce(json_agg(pkgJSON order by pr.score desc), '[]')
+    from package_ranking pr
+    cross join get_package_summary(jsonb_build_object('package_id', pr.package_id)) as pkgJSON
+    where pr.ranking = p_ranking;
+$$ language sql;
@@ -9,6 +9,7 @@ returns setof json as $$
         where s.version = p.latest_version
         and (s.deprecated is null or s.deprecated = false)
         and s.readme is not null
+        and get_blocked_reason(p.repository_id, p.package_id) is null
         and s.ts between current_timestamp - '6 months'::interval and current_timestamp
         order by random() limit 10
     ) rp
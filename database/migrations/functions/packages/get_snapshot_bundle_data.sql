@@ -0,0 +1,54 @@
+-- get_snapshot_bundle_data returns the information needed to assemble the
+-- offline bundle of the provided package's snapshot as a json object. When a
+-- requesting user is provided, only the owner of the repository (or the
+-- members of the organization owning it) can get it.
+create or replace function get_snapshot_bundle_data(
+    p_requesting_user_id uuid,
+    p_package_id uuid,
+    p_version text
+) returns setof json as $$
+declare
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns the repository
+    if p_requesting_user_id is not null then
+        select r.user_id, o.name into v_owner_user_id, v_owner_organization_name
+        from package p
+        join repository r using (repository_id)
+        left join organization o using (organization_id)
+        where p.package_id = p_package_id;
+        if v_owner_organization_name is not null then
+            if not user_belongs_to_organization(p_requesting_user_id, v_owner_organization_name) then
+                raise insufficient_privilege;
+            end if;
+        elsif v_owner_user_id is null or v_owner_user_id <> p_requesting_user_id then
+            raise insufficient_privilege;
+        end if;
+    end if;
+
+    return query
+    select json_strip_nulls(json_build_object(
+        'name', p.name,
+        'version', s.version,
+        'content_url', s.content_url,
+        'containers_images', s.containers_images,
+        'sboms', (
+            select json_object_agg(ss.image, ss.sbom)
+            from snapshot_sbom ss
+            where ss.package_id = s.package_id
+            and ss.version = s.version
+            and ss.format = 'cyclonedx'
+        ),
+        'repository_id', r.repository_id,
+        'repository_kind_id', r.repository_kind_id,
+        'private', (case when r.auth_user is not null or r.auth_pass is not null then true else null end)
+    ))
+    from snapshot s
+    join package p using (package_id)
+    join repository r using (repository_id)
+    where s.package_id = p_package_id
+    and s.version = p_version;
+end
+$$ language plpgsql;
@@ -0,0 +1,31 @@
+-- get_snapshot_images returns the containers images used by the package's
+-- snapshot provided as a json array. Each image includes a summary of the
+-- vulnerabilities found in it when the snapshot has been scanned.
+create or replace function get_snapshot_images(p_package_id uuid, p_version text)
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'image', i->>'image',
+        'name', i->>'name',
+        'whitelisted', coalesce((i->>'whitelisted')::boolean, false),
+        'security_report_summary', (
+            case when s.security_report ? (i->>'image') then (
+                select json_build_object(
+                    'critical', count(*) filter (where v #>> '{}' = 'CRITICAL'),
+                    'high', count(*) filter (where v #>> '{}' = 'HIGH'),
+                    'medium', count(*) filter (where v #>> '{}' = 'MEDIUM'),
+                    'low', count(*) filter (where v #>> '{}' = 'LOW'),
+                    'unknown', count(*) filter (where v #>> '{}' = 'UNKNOWN')
+                )
+                from jsonb_path_query(
+                    s.security_report->(i->>'image'),
+                    '$.Results[*].Vulnerabilities[*].Severity'
+                ) as v
+            ) else null end
+        )
+    )) order by i->>'image') filter (where i is not null), '[]')
+    from snapshot s
+    left join lateral jsonb_array_elements(s.containers_images) as i on true
+    where s.package_id = p_package_id
+    and s.version = p_version
+    group by s.package_id, s.version;
+$$ language sql;
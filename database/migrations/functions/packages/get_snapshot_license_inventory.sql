@@ -0,0 +1,51 @@
+-- get_snapshot_license_inventory returns the license inventory of the
+-- package's snapshot provided as a json object. The inventory includes the
+-- package license and the licenses of the packages bundled in its containers
+-- images. When requested, only problematic licenses will be returned.
+create or replace function get_snapshot_license_inventory(
+    p_package_id uuid,
+    p_version text,
+    p_problematic_only boolean
+) returns setof json as $$
+declare
+    v_problematic_families text[] := '{network-copyleft, strong-copyleft, unknown}';
+begin
+    return query
+    select json_strip_nulls(json_build_object(
+        'package', (
+            case when not p_problematic_only
+            or get_license_family(s.license) = any(v_problematic_families) then
+                json_build_object(
+                    'license', s.license,
+                    'family', get_license_family(s.license),
+                    'problematic', get_license_family(s.license) = any(v_problematic_families)
+                )
+            else null end
+        ),
+        'images', (
+            select coalesce(json_agg(json_build_object(
+                'image', image,
+                'licenses', licenses
+            ) order by image), '[]')
+            from (
+                select
+                    il.image,
+                    json_agg(json_build_object(
+                        'license', l->>'license',
+                        'family', get_license_family(l->>'license'),
+                        'problematic', get_license_family(l->>'license') = any(v_problematic_families),
+                        'packages', l->'packages'
+                    ) order by l->>'license') as licenses
+                from jsonb_each(s.images_licenses) as il(image, licenses)
+                cross join lateral jsonb_array_elements(il.licenses) as l
+                where not p_problematic_only
+                or get_license_family(l->>'license') = any(v_problematic_families)
+                group by il.image
+            ) i
+        )
+    ))
+    from snapshot s
+    where s.package_id = p_package_id
+    and s.version = p_version;
+end
+$$ language plpgsql;
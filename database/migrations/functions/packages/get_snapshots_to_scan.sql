@@ -2,7 +2,7 @@
 -- vulnerabilities as a json array.
 create or replace function get_snapshots_to_scan()
 returns setof json as $$
-    select coalesce(json_agg(json_build_object(
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
         'repository_id', repository_id,
         'package_id', package_id,
         'package_name', package_name,
@@ -10,8 +10,18 @@ returns setof json as $$
         'containers_images', jsonb_path_query_array(
             containers_images,
             '$[*] ? (!exists(@.whitelisted) || @.whitelisted <> true)'
+        ),
+        'vulnerability_statements', (
+            select json_agg(json_build_object(
+                'vulnerability_id', vs.vulnerability_id,
+                'status', vs.status,
+                'justification', vs.justification
+            ))
+            from vulnerability_statement vs
+            where vs.package_id = s.package_id
+            and vs.version = s.version
         )
-    )), '[]')
+    ))), '[]')
     from (
         select
             p.repository_id,
@@ -29,7 +39,10 @@ returns setof json as $$
             security_report is null
             or (security_report_created_at < (current_timestamp - '1 day'::interval) and s.version = p.latest_version)
             or security_report_created_at < (current_timestamp - '1 week'::interval)
+            or security_scan_requested_at > security_report_created_at
         )
-        order by s.created_at desc
+        order by
+            (security_scan_requested_at > security_report_created_at) is true desc,
+            s.created_at desc
     ) s;
 $$ language sql;
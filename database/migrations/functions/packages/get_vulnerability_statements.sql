@@ -0,0 +1,13 @@
+-- get_vulnerability_statements returns the vulnerability statements of the
+-- provided package's snapshot as a json array.
+create or replace function get_vulnerability_statements(p_package_id uuid, p_version text)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'vulnerability_id', vulnerability_id,
+        'status', status,
+        'justification', justification
+    ) order by vulnerability_id), '[]')
+    from vulnerability_statement
+    where package_id = p_package_id
+    and version = p_version;
+$$ language sql;
@@ -0,0 +1,68 @@
+-- refresh_packages_rankings computes the trending packages (based on their
+-- views and stars velocity over the last 7 and 30 days) and the new and
+-- noteworthy packages (added during the last 30 days), replacing the rankings
+-- previously computed. When several instances try to refresh the rankings at
+-- the same time, only one of them will do the work.
+create or replace function refresh_packages_rankings(p_lock_key bigint)
+returns void as $$
+declare
+    v_period int;
+begin
+    -- Make sure only one refresh is processed at a time
+    if not pg_try_advisory_xact_lock(p_lock_key) then
+        return;
+    end if;
+
+    delete from package_ranking;
+
+    -- Trending packages
+    foreach v_period in array array[7, 30] loop
+        insert into package_ranking (ranking, package_id, score)
+        select format('trending-%sd', v_period), package_id, score
+        from (
+            select
+                p.package_id,
+                coalesce(v.total, 0) + 10 * coalesce(st.total, 0) as score
+            from package p
+            join snapshot s on s.package_id = p.package_id and s.version = p.latest_version
+            left join (
+                select package_id, sum(total) as total
+                from package_views
+                where day > current_date - v_period
+                group by package_id
+            ) v on v.package_id = p.package_id
+            left join (
+                select package_id, count(*) as total
+                from user_starred_package
+                where created_at > current_timestamp - make_interval(days => v_period)
+                group by package_id
+            ) st on st.package_id = p.package_id
+            where (s.deprecated is null or s.deprecated = false)
+        ) as scores
+        where score > 0
+        order by score desc
+        limit 20;
+    end loop;
+
+    -- New and noteworthy packages
+    insert into package_ranking (ranking, package_id, score)
+    select 'new', package_id, score
+    from (
+        select
+            p.package_id,
+            coalesce(v.total, 0) + 10 * p.stars as score
+        from package p
+        join snapshot s on s.package_id = p.package_id and s.version = p.latest_version
+        left join (
+            select package_id, sum(total) as total
+            from package_views
+            group by package_id
+        ) v on v.package_id = p.package_id
+        where p.created_at > current_timestamp - '30 days'::interval
+        and (s.deprecated is null or s.deprecated = false)
+        and s.readme is not null
+    ) as scores
+    order by score desc
+    limit 20;
+end
+$$ language plpgsql;
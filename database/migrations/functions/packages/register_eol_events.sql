@@ -0,0 +1,26 @@
+-- register_eol_events registers an event for each package version that has
+-- reached its end of life, as declared by the publisher in the version support
+-- information. Events are only registered once per version. When several
+-- instances try to register the events at the same time, only one of them will
+-- do the work.
+create or replace function register_eol_events(p_lock_key bigint)
+returns void as $$
+begin
+    -- Make sure only one registration is processed at a time
+    if not pg_try_advisory_xact_lock(p_lock_key) then
+        return;
+    end if;
+
+    with eol_snapshots as (
+        update snapshot set eol_event_registered = true
+        where eol_event_registered = false
+        and (
+            support->>'status' = 'eol'
+            or (support->>'eol_date')::date <= current_date
+        )
+        returning package_id, version
+    )
+    insert into event (package_id, package_version, event_kind_id)
+    select package_id, version, 6 from eol_snapshots;
+end
+$$ language plpgsql;
@@ -0,0 +1,23 @@
+-- register_image_scan registers the scan results of the container image
+-- provided, replacing the existing ones if the image was already scanned.
+-- Results that haven't been refreshed in the last month are deleted, as the
+-- images they belong to are unlikely to be referenced anymore.
+create or replace function register_image_scan(p_image_scan jsonb)
+returns void as $$
+begin
+    insert into image_scan (digest, report, sboms)
+    values (
+        p_image_scan->>'digest',
+        p_image_scan->'report',
+        nullif(p_image_scan->'sboms', 'null')
+    )
+    on conflict (digest) do update
+    set
+        report = excluded.report,
+        sboms = excluded.sboms,
+        created_at = current_timestamp;
+
+    delete from image_scan
+    where created_at < current_timestamp - '1 month'::interval;
+end
+$$ language plpgsql;
@@ -15,10 +15,13 @@ declare
     v_provider text := nullif(p_pkg->>'provider', '');
     v_signatures text[] := (select nullif(array(select jsonb_array_elements_text(nullif(p_pkg->'signatures', 'null'::jsonb))), '{}'));
 
+    v_content_warnings jsonb := nullif(p_pkg->'content_warnings', 'null');
+
     v_latest_version_updated boolean;
     v_maintainer jsonb;
     v_maintainer_id uuid;
     v_package_id uuid;
+    v_previous_content_warnings jsonb;
     v_previous_latest_version text;
     v_previous_latest_version_ts timestamptz;
     v_repository_disabled boolean;
@@ -48,6 +51,15 @@ begin
         raise 'repository is disabled';
     end if;
 
+    -- If the package or its repository have been blocked by a site admin,
+    -- package registration will be skipped.
+    if get_blocked_reason(
+        v_repository_id,
+        (select package_id from package where name = v_name and repository_id = v_repository_id)
+    ) is not null then
+        return;
+    end if;
+
     -- Get package's latest version info before registration, if available
     select p.latest_version, s.ts into v_previous_latest_version, v_previous_latest_version_ts
     from package p
@@ -139,6 +151,12 @@ begin
         and repository_id = v_repository_id;
     end if;
 
+    -- Get snapshot's content warnings before registration, if available
+    select content_warnings into v_previous_content_warnings
+    from snapshot
+    where package_id = v_package_id
+    and version = v_version;
+
     -- Package snapshot
     insert into snapshot (
         package_id,
@@ -147,6 +165,7 @@ begin
         description,
         logo_url,
         logo_image_id,
+        logo_dark_image_id,
         keywords,
         home_url,
         app_version,
@@ -156,12 +175,15 @@ begin
         links,
         crds,
         crds_examples,
+        crds_schemas,
         capabilities,
         data,
         deprecated,
+        replaced_by,
         license,
         signed,
         signatures,
+        signature_verified,
         content_url,
         containers_images,
         provider,
@@ -171,7 +193,14 @@ begin
         prerelease,
         recommendations,
         screenshots,
+        videos,
         sign_key,
+        provenance,
+        content_warnings,
+        support,
+        k8s_compatibility,
+        translations,
+        release_notes,
         ts
     ) values (
         v_package_id,
@@ -180,6 +209,7 @@ begin
         v_description,
         nullif(p_pkg->>'logo_url', ''),
         nullif(p_pkg->>'logo_image_id', '')::uuid,
+        nullif(p_pkg->>'logo_dark_image_id', '')::uuid,
         v_keywords,
         nullif(p_pkg->>'home_url', ''),
         nullif(p_pkg->>'app_version', ''),
@@ -189,12 +219,15 @@ begin
         nullif(p_pkg->'links', 'null'),
         nullif(p_pkg->'crds', 'null'),
         nullif(p_pkg->'crds_examples', 'null'),
+        nullif(p_pkg->'crds_schemas', 'null'),
         nullif(p_pkg->>'capabilities', ''),
         nullif(p_pkg->'data', 'null'),
         (p_pkg->>'deprecated')::boolean,
+        nullif(p_pkg->>'replaced_by', ''),
         nullif(p_pkg->>'license', ''),
         (p_pkg->>'signed')::boolean,
         v_signatures,
+        (p_pkg->>'signature_verified')::boolean,
         nullif(p_pkg->>'content_url', ''),
         nullif(p_pkg->'containers_images', 'null'),
         v_provider,
@@ -204,7 +237,14 @@ begin
         (p_pkg->>'prerelease')::boolean,
         nullif(p_pkg->'recommendations', 'null'),
         nullif(p_pkg->'screenshots', 'null'),
+        nullif(p_pkg->'videos', 'null'),
         nullif(p_pkg->'sign_key', 'null'),
+        nullif(p_pkg->'provenance', 'null'),
+        v_content_warnings,
+        nullif(p_pkg->'support', 'null'),
+        nullif(p_pkg->'k8s_compatibility', 'null'),
+        nullif(p_pkg->'translations', 'null'),
+        nullif(p_pkg->'release_notes', 'null'),
         v_ts
     )
     on conflict (package_id, version) do update
@@ -213,6 +253,7 @@ begin
         description = excluded.description,
         logo_url = excluded.logo_url,
         logo_image_id = excluded.logo_image_id,
+        logo_dark_image_id = excluded.logo_dark_image_id,
         keywords = excluded.keywords,
         home_url = excluded.home_url,
         app_version = excluded.app_version,
@@ -222,12 +263,15 @@ begin
         links = excluded.links,
         crds = excluded.crds,
         crds_examples = excluded.crds_examples,
+        crds_schemas = excluded.crds_schemas,
         capabilities = excluded.capabilities,
         data = excluded.data,
         deprecated = excluded.deprecated,
+        replaced_by = excluded.replaced_by,
         license = excluded.license,
         signed = excluded.signed,
         signatures = excluded.signatures,
+        signature_verified = excluded.signature_verified,
         content_url = excluded.content_url,
         containers_images = excluded.containers_images,
         provider = excluded.provider,
@@ -237,10 +281,25 @@ begin
         prerelease = excluded.prerelease,
         recommendations = excluded.recommendations,
         screenshots = excluded.screenshots,
+        videos = excluded.videos,
         sign_key = excluded.sign_key,
+        provenance = excluded.provenance,
+        content_warnings = excluded.content_warnings,
+        support = excluded.support,
+        eol_event_registered = (
+            snapshot.eol_event_registered
+            and snapshot.support is not distinct from excluded.support
+        ),
+        k8s_compatibility = excluded.k8s_compatibility,
+        translations = excluded.translations,
+        release_notes = excluded.release_notes,
         ts = v_ts;
 
     -- Register new release event if package's latest version has been updated
+    -- and the release has not been announced yet. Releases already announced
+    -- may be registered again, for example after being unregistered when the
+    -- repository could not be processed correctly. Container images tags can
+    -- be updated, so their digest is also taken into account.
     v_latest_version_updated := false;
     case v_repository_kind_id
         when 12 then -- Container image
@@ -253,8 +312,27 @@ begin
             end if;
     end case;
     if v_latest_version_updated then
+        insert into announced_release (repository_id, package_name, version, digest)
+        values (
+            v_repository_id,
+            v_name,
+            v_version,
+            case when v_repository_kind_id = 12 then coalesce(p_pkg->>'digest', '') else '' end
+        )
+        on conflict do nothing;
+        if found then
+            insert into event (package_id, package_version, event_kind_id)
+            values (v_package_id, v_version, 0);
+        end if;
+    end if;
+
+    -- Register content warnings event if the package's latest version has
+    -- new content warnings
+    if v_content_warnings is not null
+    and (v_previous_content_warnings is null or v_content_warnings <> v_previous_content_warnings)
+    and v_version = (select latest_version from package where package_id = v_package_id) then
         insert into event (package_id, package_version, event_kind_id)
-        values (v_package_id, v_version, 0);
+        values (v_package_id, v_version, 5);
     end if;
 end
 $$ language plpgsql;
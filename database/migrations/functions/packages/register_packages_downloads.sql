@@ -0,0 +1,65 @@
+-- register_packages_downloads registers the downloads counters provided for
+-- the packages of the given repository. Only the owner of the repository (or
+-- the members of the organization owning it) can register downloads. Counters
+-- for a package version and day replace any previously registered, so that
+-- the same downloads logs can be ingested more than once safely. Entries that
+-- do not match any of the repository's packages are ignored.
+create or replace function register_packages_downloads(
+    p_user_id uuid,
+    p_repository_name text,
+    p_data jsonb
+) returns void as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+    v_packages_ids uuid[];
+begin
+    -- Get repository and owner details
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns the repository (requests for repositories that
+    -- do not exist are also rejected here)
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id is null or v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Register downloads counters
+    with downloads as (
+        select
+            p.package_id,
+            e->>'version' as version,
+            (e->>'day')::date as day,
+            sum((e->>'total')::integer) as total
+        from jsonb_array_elements(p_data) e
+        join package p on p.name = e->>'package_name'
+        where p.repository_id = v_repository_id
+        group by p.package_id, e->>'version', (e->>'day')::date
+    ), registered_downloads as (
+        insert into package_downloads (package_id, version, day, total)
+        select package_id, version, day, total from downloads
+        on conflict (package_id, version, day) do
+        update set total = excluded.total
+        returning package_id
+    )
+    select array_agg(distinct package_id) into v_packages_ids
+    from registered_downloads;
+
+    -- Update the total downloads of the packages affected
+    update package p set downloads = (
+        select coalesce(sum(total), 0)
+        from package_downloads
+        where package_id = p.package_id
+    )
+    where p.package_id = any(v_packages_ids);
+end
+$$ language plpgsql;
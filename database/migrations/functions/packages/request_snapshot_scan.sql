@@ -0,0 +1,81 @@
+-- request_snapshot_scan requests a new security scan of the provided package's
+-- snapshot. Only the owner of the repository (or the members of the
+-- organization owning it) can request scans, and they can only be requested
+-- once per hour for each snapshot. It returns false when the request has been
+-- rejected because a scan was requested recently. Requests for snapshots that
+-- are excluded from scanning are rejected with an error including the reason.
+create or replace function request_snapshot_scan(
+    p_requesting_user_id uuid,
+    p_package_id uuid,
+    p_version text
+) returns boolean as $$
+declare
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+    v_security_scan_requested_at timestamptz;
+    v_scanner_disabled boolean;
+    v_ts timestamptz;
+    v_images_to_scan jsonb;
+begin
+    -- Get snapshot and repository owner details
+    select
+        r.user_id,
+        o.name,
+        s.security_scan_requested_at,
+        r.scanner_disabled,
+        s.ts,
+        jsonb_path_query_array(
+            s.containers_images,
+            '$[*] ? (!exists(@.whitelisted) || @.whitelisted <> true)'
+        )
+    into
+        v_owner_user_id,
+        v_owner_organization_name,
+        v_security_scan_requested_at,
+        v_scanner_disabled,
+        v_ts,
+        v_images_to_scan
+    from snapshot s
+    join package p using (package_id)
+    join repository r using (repository_id)
+    left join organization o using (organization_id)
+    where s.package_id = p_package_id
+    and s.version = p_version
+    for update of s;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns the repository (requests for snapshots that do
+    -- not exist are also rejected here)
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_requesting_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id is null or v_owner_user_id <> p_requesting_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Reject request if the snapshot is excluded from scanning (the same
+    -- criteria used in get_snapshots_to_scan apply here)
+    if v_scanner_disabled then
+        raise 'snapshot not scannable: security scanning is disabled for this repository';
+    end if;
+    if v_images_to_scan is null or jsonb_array_length(v_images_to_scan) = 0 then
+        raise 'snapshot not scannable: no containers images to scan';
+    end if;
+    if v_ts < current_timestamp - '1 year'::interval then
+        raise 'snapshot not scannable: versions older than one year are not scanned';
+    end if;
+
+    -- Reject request if a scan was requested recently
+    if v_security_scan_requested_at > current_timestamp - '1 hour'::interval then
+        return false;
+    end if;
+
+    -- Register scan request
+    update snapshot set security_scan_requested_at = current_timestamp
+    where package_id = p_package_id
+    and version = p_version;
+
+    return true;
+end
+$$ language plpgsql;
@@ -8,7 +8,9 @@ declare
     v_orgs text[];
     v_repositories text[];
     v_licenses text[];
+    v_license_families text[];
     v_capabilities text[];
+    v_k8s_minor int := split_part(p_input->>'k8s_version', '.', 2)::int;
     v_facets boolean := (p_input->>'facets')::boolean;
     v_tsquery_web tsquery := websearch_to_tsquery(p_input->>'ts_query_web');
     v_tsquery_web_with_prefix_matching tsquery;
@@ -26,6 +28,8 @@ begin
     from jsonb_array_elements_text(p_input->'repositories') e;
     select array_agg(e::text) into v_licenses
     from jsonb_array_elements_text(p_input->'licenses') e;
+    select array_agg(e::text) into v_license_families
+    from jsonb_array_elements_text(p_input->'license_families') e;
     select array_agg(e::text) into v_capabilities
     from jsonb_array_elements_text(p_input->'capabilities') e;
 
@@ -50,11 +54,13 @@ begin
             p.name,
             p.normalized_name,
             p.stars,
+            p.downloads,
             p.tsdoc,
             p.official as package_official,
             s.display_name,
             s.description,
             s.logo_image_id,
+            s.logo_dark_image_id,
             s.version,
             s.app_version,
             s.license,
@@ -62,6 +68,7 @@ begin
             s.deprecated,
             s.signed,
             s.signatures,
+            s.signature_verified,
             s.security_report_summary,
             s.containers_images,
             s.ts,
@@ -84,6 +91,7 @@ begin
         left join "user" u using (user_id)
         left join organization o using (organization_id)
         where s.version = p.latest_version
+        and get_blocked_reason(r.repository_id, p.package_id) is null
         and
             case when v_tsquery_web is not null then
                 v_tsquery_web_with_prefix_matching @@ p.tsdoc
@@ -110,6 +118,23 @@ begin
             else
                 true
             end
+        and
+            case when p_input ? 'signature_verified' and (p_input->>'signature_verified')::boolean = true then
+                s.signature_verified = true
+            else
+                true
+            end
+        and
+            case when v_k8s_minor is not null then
+                exists (
+                    select 1
+                    from jsonb_array_elements(s.k8s_compatibility) r
+                    where split_part(r->>'min', '.', 2)::int <= v_k8s_minor
+                    and (r->>'max' is null or split_part(r->>'max', '.', 2)::int >= v_k8s_minor)
+                )
+            else
+                true
+            end
         and
             case when p_input ? 'deprecated' and (p_input->>'deprecated')::boolean = true then
                 true
@@ -137,6 +162,9 @@ begin
         and
             case when cardinality(v_licenses) > 0
             then license = any(v_licenses) else true end
+        and
+            case when cardinality(v_license_families) > 0
+            then get_license_family(license) = any(v_license_families) else true end
         and
             case when cardinality(v_capabilities) > 0
             then capabilities = any(v_capabilities) else true end
@@ -149,7 +177,9 @@ begin
                     'name', name,
                     'normalized_name', normalized_name,
                     'logo_image_id', logo_image_id,
+                    'logo_dark_image_id', logo_dark_image_id,
                     'stars', stars,
+                    'downloads', downloads,
                     'official', package_official,
                     'display_name', display_name,
                     'description', description,
@@ -159,6 +189,7 @@ begin
                     'deprecated', deprecated,
                     'signed', signed,
                     'signatures', signatures,
+                    'signature_verified', signature_verified,
                     'security_report_summary', security_report_summary,
                     'all_containers_images_whitelisted', are_all_containers_images_whitelisted(containers_images),
                     'production_organizations_count', (
@@ -197,6 +228,8 @@ begin
                     order by
                         case when v_sort = 'relevance' then (relevance, stars) end desc,
                         case when v_sort = 'stars' then (stars, relevance) end desc,
+                        case when v_sort = 'downloads' then (downloads, relevance) end desc,
+                        case when v_sort = 'last_updated' then ts end desc,
                         official desc,
                         verified_publisher desc,
                         name asc
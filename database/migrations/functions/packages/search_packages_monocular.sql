@@ -25,6 +25,7 @@ begin
         where r.repository_kind_id = 0 -- Helm
         and s.version = p.latest_version
         and (s.deprecated is null or s.deprecated = false)
+        and get_blocked_reason(r.repository_id, p.package_id) is null
         and
             case when p_tsquery_web <> '' then
                 v_tsquery_web @@ p.tsdoc
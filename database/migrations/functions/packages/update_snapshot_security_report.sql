@@ -7,21 +7,52 @@ declare
     v_version text := p_report->>'version';
     v_alert_digest text := nullif(p_report->>'alert_digest', '');
     v_previous_alert_digest text;
+    v_previous_report jsonb;
+    v_new_vulnerabilities jsonb;
 begin
     -- Register security alert event for the associated package if the package's
     -- version is the latest and the security report's alert digest has changed
-    select security_report_alert_digest
+    select security_report_alert_digest, security_report
     from snapshot s
     join package p using (package_id)
     where package_id = v_package_id
     and s.version = v_version
     and s.version = p.latest_version
-    into v_previous_alert_digest;
+    into v_previous_alert_digest, v_previous_report;
     if found then
         if v_alert_digest is not null
         and (v_previous_alert_digest is null or v_alert_digest <> v_previous_alert_digest) then
-            insert into event (package_id, package_version, event_kind_id)
-            values (v_package_id, v_version, 1);
+            -- When the snapshot has not been scanned before, the vulnerabilities
+            -- found are compared against the previous snapshot's ones
+            if v_previous_report is null then
+                select security_report into v_previous_report
+                from snapshot
+                where package_id = v_package_id
+                and version <> v_version
+                and security_report is not null
+                order by ts desc
+                limit 1;
+            end if;
+
+            -- Summarize by severity the vulnerabilities that were not present in
+            -- the previous report, so that subscriptions with a minimum
+            -- severity are only notified about new vulnerabilities
+            select jsonb_object_agg(severity, total) into v_new_vulnerabilities
+            from (
+                select lower(v->>'Severity') as severity, count(distinct v->>'VulnerabilityID') as total
+                from jsonb_path_query(p_report->'images_reports', '$.*.Results[*].Vulnerabilities[*]') v
+                where not exists (
+                    select 1
+                    from jsonb_path_query(v_previous_report, '$.*.Results[*].Vulnerabilities[*]') pv
+                    where pv->>'VulnerabilityID' = v->>'VulnerabilityID'
+                )
+                group by lower(v->>'Severity')
+            ) nv;
+
+            insert into event (package_id, package_version, event_kind_id, data)
+            values (v_package_id, v_version, 1, jsonb_build_object(
+                'new_vulnerabilities', coalesce(v_new_vulnerabilities, '{}')
+            ));
         end if;
     end if;
 
@@ -30,8 +61,19 @@ begin
         security_report = p_report->'images_reports',
         security_report_alert_digest = v_alert_digest,
         security_report_summary = p_report->'summary',
-        security_report_created_at = current_timestamp
+        security_report_suppressed = p_report->'suppressed_vulnerabilities',
+        security_report_created_at = current_timestamp,
+        images_licenses = p_report->'images_licenses'
+    where package_id = v_package_id
+    and version = v_version;
+
+    -- Replace the SBOMs of the snapshot's images
+    delete from snapshot_sbom
     where package_id = v_package_id
     and version = v_version;
+    insert into snapshot_sbom (package_id, version, format, image, sbom)
+    select v_package_id, v_version, f.key, i.key, i.value
+    from jsonb_each(coalesce(nullif(p_report->'sboms', 'null'), '{}')) f
+    cross join jsonb_each(f.value) i;
 end
 $$ language plpgsql;
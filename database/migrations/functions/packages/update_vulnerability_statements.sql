@@ -0,0 +1,62 @@
+-- update_vulnerability_statements replaces the vulnerability statements of the
+-- provided package's snapshot. Only the owner of the repository (or the
+-- members of the organization owning it) can update them. A new security scan
+-- of the snapshot is requested so that the statements are applied to its
+-- security report.
+create or replace function update_vulnerability_statements(
+    p_requesting_user_id uuid,
+    p_package_id uuid,
+    p_version text,
+    p_statements jsonb
+) returns void as $$
+declare
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get snapshot repository owner details
+    select r.user_id, o.name
+    into v_owner_user_id, v_owner_organization_name
+    from snapshot s
+    join package p using (package_id)
+    join repository r using (repository_id)
+    left join organization o using (organization_id)
+    where s.package_id = p_package_id
+    and s.version = p_version
+    for update of s;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns the repository (requests for snapshots that do
+    -- not exist are also rejected here)
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_requesting_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id is null or v_owner_user_id <> p_requesting_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Replace snapshot vulnerability statements
+    delete from vulnerability_statement
+    where package_id = p_package_id
+    and version = p_version;
+    insert into vulnerability_statement (
+        package_id,
+        version,
+        vulnerability_id,
+        status,
+        justification
+    )
+    select
+        p_package_id,
+        p_version,
+        s->>'vulnerability_id',
+        s->>'status',
+        nullif(s->>'justification', '')
+    from jsonb_array_elements(nullif(p_statements, 'null')) as s;
+
+    -- Request a new scan of the snapshot to apply the statements
+    update snapshot set security_scan_requested_at = current_timestamp
+    where package_id = p_package_id
+    and version = p_version;
+end
+$$ language plpgsql;
@@ -0,0 +1,50 @@
+-- approve_repository_change applies the repository change provided, deleting
+-- it once it's been applied. Changes must be approved by a member of the
+-- organization other than the one who requested them. Repositories transfers
+-- are applied on behalf of the user who requested them.
+create or replace function approve_repository_change(
+    p_user_id uuid,
+    p_org_name text,
+    p_repository_change_id uuid
+) returns void as $$
+declare
+    v_change repository_change%rowtype;
+begin
+    if not user_belongs_to_organization(p_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    -- Get change details
+    select rc.* into v_change
+    from repository_change rc
+    join organization o using (organization_id)
+    where o.name = p_org_name
+    and rc.repository_change_id = p_repository_change_id
+    for update of rc;
+    if not found then
+        raise exception 'repository change not found';
+    end if;
+    if v_change.requested_by = p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Apply change
+    case v_change.kind
+        when 'add' then
+            perform add_repository(p_user_id, p_org_name, v_change.repository);
+        when 'update' then
+            perform update_repository(p_user_id, v_change.repository);
+        when 'delete' then
+            perform delete_repository(p_user_id, v_change.repository_name);
+        when 'transfer' then
+            perform transfer_repository(
+                v_change.repository_name,
+                v_change.requested_by,
+                (select name from organization where organization_id = v_change.transfer_organization_id),
+                v_change.ownership_claim_method
+            );
+    end case;
+
+    delete from repository_change where repository_change_id = p_repository_change_id;
+end
+$$ language plpgsql;
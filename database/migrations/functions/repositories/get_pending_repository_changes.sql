@@ -0,0 +1,28 @@
+-- get_pending_repository_changes returns the repository changes pending of
+-- approval in the organization provided as a json array. Repositories
+-- credentials are never included.
+create or replace function get_pending_repository_changes(p_user_id uuid, p_org_name text)
+returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'repository_change_id', rc.repository_change_id,
+        'kind', rc.kind,
+        'repository_name', rc.repository_name,
+        'repository', rc.repository - 'auth_user' - 'auth_pass',
+        'transfer_organization_name', t.name,
+        'ownership_claim_method', rc.ownership_claim_method,
+        'requested_by', u.alias,
+        'created_at', floor(extract(epoch from rc.created_at))
+    )) order by rc.created_at asc), '[]')
+    from repository_change rc
+    join organization o using (organization_id)
+    join "user" u on rc.requested_by = u.user_id
+    left join organization t on rc.transfer_organization_id = t.organization_id
+    where o.name = p_org_name;
+end
+$$ language plpgsql;
@@ -0,0 +1,81 @@
+-- get_publisher_profile_content returns the repositories, the most popular
+-- packages and the latest releases published by the user or organization
+-- provided, as well as some stats about them, as a jsonb object. Disabled
+-- repositories are not included.
+create or replace function get_publisher_profile_content(p_user_id uuid, p_organization_id uuid)
+returns jsonb as $$
+    with publisher_repositories as (
+        select *
+        from repository r
+        where r.disabled = false
+        and (
+            (p_user_id is not null and r.user_id = p_user_id)
+            or (p_organization_id is not null and r.organization_id = p_organization_id)
+        )
+    ), publisher_packages as (
+        select p.*, r.name as repository_name, r.repository_kind_id
+        from package p
+        join publisher_repositories r using (repository_id)
+    )
+    select jsonb_build_object(
+        'repositories', (
+            select coalesce(jsonb_agg(jsonb_strip_nulls(jsonb_build_object(
+                'name', r.name,
+                'display_name', r.display_name,
+                'url', r.url,
+                'private', (
+                    case when r.auth_user is not null or r.auth_pass is not null then true
+                    else false end
+                ),
+                'kind', r.repository_kind_id,
+                'verified_publisher', r.verified_publisher,
+                'official', r.official,
+                'packages_count', (
+                    select count(*) from package where repository_id = r.repository_id
+                )
+            )) order by r.name asc), '[]')
+            from publisher_repositories r
+        ),
+        'packages', (
+            select coalesce(jsonb_agg(pkg order by stars desc, name asc), '[]')
+            from (
+                select jsonb_strip_nulls(jsonb_build_object(
+                    'package_id', p.package_id,
+                    'name', p.name,
+                    'normalized_name', p.normalized_name,
+                    'logo_image_id', p.logo_image_id,
+                    'stars', p.stars,
+                    'version', p.latest_version,
+                    'display_name', s.display_name,
+                    'description', s.description,
+                    'repository_name', p.repository_name,
+                    'repository_kind_id', p.repository_kind_id
+                )) as pkg, p.stars, p.name
+                from publisher_packages p
+                join snapshot s on s.package_id = p.package_id and s.version = p.latest_version
+                order by p.stars desc, p.name asc
+                limit 20
+            ) top_packages
+        ),
+        'activity', (
+            select coalesce(jsonb_agg(release order by ts desc), '[]')
+            from (
+                select jsonb_build_object(
+                    'package_id', p.package_id,
+                    'name', p.name,
+                    'normalized_name', p.normalized_name,
+                    'version', s.version,
+                    'repository_name', p.repository_name,
+                    'repository_kind_id', p.repository_kind_id,
+                    'ts', floor(extract(epoch from s.ts))
+                ) as release, s.ts
+                from publisher_packages p
+                join snapshot s on s.package_id = p.package_id
+                order by s.ts desc
+                limit 20
+            ) latest_releases
+        ),
+        'packages_count', (select count(*) from publisher_packages),
+        'stars', (select coalesce(sum(stars), 0) from publisher_packages)
+    );
+$$ language sql;
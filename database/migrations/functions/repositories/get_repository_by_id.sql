@@ -16,15 +16,19 @@ returns setof json as $$
         'official', r.official,
         'disabled', r.disabled,
         'scanner_disabled', r.scanner_disabled,
+        'blocked', (case when get_blocked_reason(r.repository_id, null) is not null then true else null end),
         'digest', r.digest,
         'last_scanning_ts', floor(extract(epoch from r.last_scanning_ts)),
         'last_scanning_errors', r.last_scanning_errors,
         'last_tracking_ts', floor(extract(epoch from r.last_tracking_ts)),
         'last_tracking_errors', r.last_tracking_errors,
+        'last_tracking_errors_details', r.last_tracking_errors_details,
+        'tracking_requested', (case when r.tracking_requested_at is not null then true else null end),
         'data', r.data,
         'user_alias', u.alias,
         'organization_name', o.name,
-        'organization_display_name', o.display_name
+        'organization_display_name', o.display_name,
+        'version', r.version
     ))
     from repository r
     left join "user" u using (user_id)
@@ -0,0 +1,16 @@
+-- get_repository_change_approvers returns the email and locale of the members
+-- of the organization that can approve the repository change provided as a
+-- json array.
+create or replace function get_repository_change_approvers(p_repository_change_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'email', u.email,
+        'locale', u.locale
+    ))), '[]')
+    from repository_change rc
+    join user__organization uo using (organization_id)
+    join "user" u using (user_id)
+    where rc.repository_change_id = p_repository_change_id
+    and uo.confirmed = true
+    and uo.user_id <> rc.requested_by;
+$$ language sql;
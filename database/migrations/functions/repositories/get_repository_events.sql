@@ -0,0 +1,94 @@
+-- get_repository_events returns the events registered for the repository
+-- provided (and for its packages) as a json object, from the most recent to
+-- the oldest one. Results are paginated using a cursor: when more events are
+-- available, the position of the last event returned is included in the
+-- result so that it can be used to get the next page. Only the owner of the
+-- repository (or the members of the organization owning it) can get them.
+create or replace function get_repository_events(
+    p_user_id uuid,
+    p_repository_name text,
+    p_input jsonb
+) returns setof json as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+    v_limit int := coalesce((p_input->>'limit')::int, 20);
+    v_cursor_created_at timestamptz := (p_input->'cursor'->>'created_at')::timestamptz;
+    v_cursor_event_id uuid := (p_input->'cursor'->>'event_id')::uuid;
+    v_event_kinds int[];
+begin
+    -- Get repository and owner details
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns the repository (requests for repositories that
+    -- do not exist are also rejected here)
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id is null or v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Prepare filters
+    if p_input ? 'event_kinds' then
+        select array_agg(e::int) into v_event_kinds
+        from jsonb_array_elements_text(p_input->'event_kinds') e;
+    end if;
+
+    return query
+    with events as (
+        select
+            e.event_id,
+            e.created_at,
+            e.event_kind_id,
+            e.package_version,
+            e.data,
+            p.package_id,
+            p.name as package_name
+        from event e
+        left join package p using (package_id)
+        where (e.repository_id = v_repository_id or p.repository_id = v_repository_id)
+        and (v_event_kinds is null or e.event_kind_id = any(v_event_kinds))
+        and (p_input->>'package_name' is null or p.name = p_input->>'package_name')
+        and (v_cursor_created_at is null or (e.created_at, e.event_id) < (v_cursor_created_at, v_cursor_event_id))
+        order by e.created_at desc, e.event_id desc
+        limit v_limit + 1
+    ), events_page as (
+        select *
+        from events
+        order by created_at desc, event_id desc
+        limit v_limit
+    )
+    select json_strip_nulls(json_build_object(
+        'events', (
+            select coalesce(json_agg(json_build_object(
+                'event_id', event_id,
+                'event_kind', event_kind_id,
+                'created_at', floor(extract(epoch from created_at)),
+                'package_id', package_id,
+                'package_name', package_name,
+                'package_version', package_version,
+                'data', data
+            ) order by created_at desc, event_id desc), '[]')
+            from events_page
+        ),
+        'next', (
+            select json_build_object(
+                'created_at', created_at::text,
+                'event_id', event_id
+            )
+            from events_page
+            where (select count(*) from events) > v_limit
+            order by created_at asc, event_id asc
+            limit 1
+        )
+    ));
+end
+$$ language plpgsql;
@@ -0,0 +1,122 @@
+-- get_repository_stats returns some stats about the repository provided, in
+-- the time range delimited by the start and end provided, as a json object.
+-- The stats include the number of packages, views, stars gained and new
+-- subscriptions per day, as well as some details about the tracker runs. Only
+-- the owner of the repository (or the members of the organization owning it)
+-- can get them.
+create or replace function get_repository_stats(
+    p_user_id uuid,
+    p_repository_name text,
+    p_start date,
+    p_end date
+) returns setof json as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get repository and owner details
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns the repository (requests for repositories that
+    -- do not exist are also rejected here)
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id is null or v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with tracking_runs as (
+        select duration, outcome, created_at
+        from repository_tracking_run
+        where repository_id = v_repository_id
+        and created_at::date >= p_start
+        and created_at::date <= p_end
+    )
+    select json_build_object(
+        'packages', (
+            select coalesce(json_object_agg(to_char(day, 'YYYY-MM-DD'), (
+                select count(*)
+                from package
+                where repository_id = v_repository_id
+                and created_at::date <= day
+            ) order by day), '{}')
+            from generate_series(p_start, p_end, '1 day'::interval) as day
+        ),
+        'views', (
+            select coalesce(json_object_agg(day, total order by day), '{}')
+            from (
+                select pv.day, sum(pv.total) as total
+                from package_views pv
+                join package p using (package_id)
+                where p.repository_id = v_repository_id
+                and pv.day >= p_start
+                and pv.day <= p_end
+                group by pv.day
+            ) as views
+        ),
+        'stars', (
+            select coalesce(json_object_agg(day, total order by day), '{}')
+            from (
+                select usp.created_at::date as day, count(*) as total
+                from user_starred_package usp
+                join package p using (package_id)
+                where p.repository_id = v_repository_id
+                and usp.created_at::date >= p_start
+                and usp.created_at::date <= p_end
+                group by usp.created_at::date
+            ) as stars
+        ),
+        'subscriptions', (
+            select coalesce(json_object_agg(day, total order by day), '{}')
+            from (
+                select s.created_at::date as day, count(*) as total
+                from subscription s
+                join package p using (package_id)
+                where p.repository_id = v_repository_id
+                and s.created_at::date >= p_start
+                and s.created_at::date <= p_end
+                group by s.created_at::date
+            ) as subscriptions
+        ),
+        'tracking', (
+            select json_build_object(
+                'runs', count(*),
+                'errors', count(*) filter (where outcome <> 'success'),
+                'error_rate', coalesce(round(
+                    (count(*) filter (where outcome <> 'success'))::numeric / nullif(count(*), 0), 4
+                ), 0),
+                'avg_duration', coalesce(round(avg(duration)::numeric, 2), 0),
+                'max_duration', coalesce(round(max(duration)::numeric, 2), 0),
+                'durations', (
+                    select coalesce(json_object_agg(day, avg_duration order by day), '{}')
+                    from (
+                        select created_at::date as day, round(avg(duration)::numeric, 2) as avg_duration
+                        from tracking_runs
+                        group by created_at::date
+                    ) as durations
+                ),
+                'last_tracking_ts', (
+                    select floor(extract(epoch from last_tracking_ts))
+                    from repository
+                    where repository_id = v_repository_id
+                ),
+                'last_tracking_errors', (
+                    select last_tracking_errors is not null
+                    from repository
+                    where repository_id = v_repository_id
+                )
+            )
+            from tracking_runs
+        )
+    );
+end
+$$ language plpgsql;
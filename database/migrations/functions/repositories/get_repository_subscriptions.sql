@@ -0,0 +1,67 @@
+-- get_repository_subscriptions returns the number of subscribers of the
+-- packages in the repository provided, as a json object. Subscribers are
+-- aggregated for the whole repository as well as per package, including a
+-- breakdown by event kind. Only the owner of the repository (or the members
+-- of the organization owning it) can get them. The subscribers identity is
+-- never exposed.
+create or replace function get_repository_subscriptions(
+    p_user_id uuid,
+    p_repository_name text
+) returns setof json as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get repository and owner details
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns the repository (requests for repositories that
+    -- do not exist are also rejected here)
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id is null or v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with repository_subscriptions as (
+        select p.name, s.user_id, s.event_kind_id
+        from subscription s
+        join package p using (package_id)
+        where p.repository_id = v_repository_id
+    )
+    select json_build_object(
+        'total', (
+            select count(distinct user_id)
+            from repository_subscriptions
+        ),
+        'packages', (
+            select coalesce(json_object_agg(name, json_build_object(
+                'total', (
+                    select count(distinct user_id)
+                    from repository_subscriptions
+                    where name = packages.name
+                ),
+                'event_kinds', (
+                    select json_object_agg(event_kind_id, total)
+                    from (
+                        select event_kind_id, count(*) as total
+                        from repository_subscriptions
+                        where name = packages.name
+                        group by event_kind_id
+                    ) as event_kinds
+                )
+            )), '{}')
+            from (select distinct(name) from repository_subscriptions) as packages
+        )
+    );
+end
+$$ language plpgsql;
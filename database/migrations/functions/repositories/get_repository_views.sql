@@ -0,0 +1,65 @@
+-- get_repository_views returns the number of views per day of the packages
+-- in the repository provided, in the time range delimited by the start and
+-- end provided, as a json object. Views are aggregated for the whole
+-- repository as well as per package (all versions included). Only the owner
+-- of the repository (or the members of the organization owning it) can get
+-- them.
+create or replace function get_repository_views(
+    p_user_id uuid,
+    p_repository_name text,
+    p_start date,
+    p_end date
+) returns setof json as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get repository and owner details
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns the repository (requests for repositories that
+    -- do not exist are also rejected here)
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id is null or v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with packages_views as (
+        select p.name, pv.day, sum(pv.total) as total
+        from package_views pv
+        join package p using (package_id)
+        where p.repository_id = v_repository_id
+        and pv.day >= p_start
+        and pv.day <= p_end
+        group by p.name, pv.day
+    )
+    select json_build_object(
+        'total', (
+            select coalesce(json_object_agg(day, total), '{}')
+            from (
+                select day, sum(total) as total
+                from packages_views
+                group by day
+            ) as days
+        ),
+        'packages', (
+            select coalesce(json_object_agg(name, (
+                select json_object_agg(day, total)
+                from packages_views
+                where name = packages.name
+            )), '{}')
+            from (select distinct(name) from packages_views) as packages
+        )
+    );
+end
+$$ language plpgsql;
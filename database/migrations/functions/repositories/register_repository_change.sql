@@ -0,0 +1,46 @@
+-- register_repository_change registers a change in a repository owned by the
+-- organization provided that must be approved by another member of the
+-- organization before being applied. The id of the change is returned.
+-- Transfers requested as part of an ownership claim that has been previously
+-- verified can be registered by users who don't belong to the organization.
+create or replace function register_repository_change(
+    p_user_id uuid,
+    p_org_name text,
+    p_change jsonb
+) returns uuid as $$
+declare
+    v_repository_change_id uuid;
+begin
+    if p_change->>'ownership_claim_method' is null
+    and not user_belongs_to_organization(p_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    -- When transferring a repository to an organization, check the requesting
+    -- user belongs to it
+    if p_change->>'transfer_organization_name' is not null
+    and not user_belongs_to_organization(p_user_id, p_change->>'transfer_organization_name') then
+        raise insufficient_privilege;
+    end if;
+
+    insert into repository_change (
+        organization_id,
+        kind,
+        repository_name,
+        repository,
+        transfer_organization_id,
+        ownership_claim_method,
+        requested_by
+    ) values (
+        (select organization_id from organization where name = p_org_name),
+        p_change->>'kind',
+        p_change->>'repository_name',
+        nullif(p_change->'repository', 'null'),
+        (select organization_id from organization where name = p_change->>'transfer_organization_name'),
+        p_change->>'ownership_claim_method',
+        p_user_id
+    ) returning repository_change_id into v_repository_change_id;
+
+    return v_repository_change_id;
+end
+$$ language plpgsql;
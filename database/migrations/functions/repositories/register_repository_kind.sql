@@ -0,0 +1,15 @@
+-- register_repository_kind registers the custom repository kind provided,
+-- updating its name if it had already been registered. Built-in kinds cannot
+-- be modified.
+create or replace function register_repository_kind(p_kind_id integer, p_name text)
+returns void as $$
+begin
+    if p_kind_id < 100 then
+        raise 'invalid custom repository kind: % is reserved for built-in kinds', p_kind_id;
+    end if;
+
+    insert into repository_kind (repository_kind_id, name)
+    values (p_kind_id, p_name)
+    on conflict (repository_kind_id) do update set name = excluded.name;
+end
+$$ language plpgsql;
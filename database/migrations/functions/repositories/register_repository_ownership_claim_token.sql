@@ -0,0 +1,15 @@
+-- register_repository_ownership_claim_token registers a token that allows the
+-- user provided to claim the ownership of the given repository by adding it to
+-- the repository metadata file. Registering a new token for the same user and
+-- repository replaces the previous one.
+create or replace function register_repository_ownership_claim_token(
+    p_user_id uuid,
+    p_repository_id uuid,
+    p_token text
+) returns void as $$
+    insert into repository_ownership_claim_token (repository_id, user_id, token)
+    values (p_repository_id, p_user_id, p_token)
+    on conflict (repository_id, user_id) do update set
+        token = excluded.token,
+        created_at = current_timestamp;
+$$ language sql;
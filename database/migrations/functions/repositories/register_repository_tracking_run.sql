@@ -0,0 +1,16 @@
+-- register_repository_tracking_run registers the duration (in seconds) and
+-- the outcome of a tracker run for the repository provided. Runs older than
+-- 90 days are removed.
+create or replace function register_repository_tracking_run(
+    p_repository_id uuid,
+    p_duration real,
+    p_outcome text
+)
+returns void as $$
+    delete from repository_tracking_run
+    where repository_id = p_repository_id
+    and created_at < current_timestamp - '90 days'::interval;
+
+    insert into repository_tracking_run (repository_id, duration, outcome)
+    values (p_repository_id, p_duration, p_outcome);
+$$ language sql;
@@ -0,0 +1,17 @@
+-- reject_repository_change deletes the repository change provided without
+-- applying it.
+create or replace function reject_repository_change(
+    p_user_id uuid,
+    p_org_name text,
+    p_repository_change_id uuid
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from repository_change
+    where repository_change_id = p_repository_change_id
+    and organization_id = (select organization_id from organization where name = p_org_name);
+end
+$$ language plpgsql;
@@ -37,10 +37,13 @@ begin
             r.last_scanning_errors,
             r.last_tracking_ts,
             r.last_tracking_errors,
+            r.last_tracking_errors_details,
+            r.tracking_requested_at,
             r.data as repository_data,
             u.alias as user_alias,
             o.name as organization_name,
-            o.display_name as organization_display_name
+            o.display_name as organization_display_name,
+            r.version
         from repository r
         left join "user" u using (user_id)
         left join organization o using (organization_id)
@@ -75,15 +78,19 @@ begin
             'official', official,
             'disabled', disabled,
             'scanner_disabled', scanner_disabled,
+            'blocked', (case when get_blocked_reason(repository_id, null) is not null then true else null end),
             'digest', digest,
             'last_scanning_ts', floor(extract(epoch from last_scanning_ts)),
             'last_scanning_errors', last_scanning_errors,
             'last_tracking_ts', floor(extract(epoch from last_tracking_ts)),
             'last_tracking_errors', last_tracking_errors,
+            'last_tracking_errors_details', last_tracking_errors_details,
+            'tracking_requested', (case when tracking_requested_at is not null then true else null end),
             'data', repository_data,
             'user_alias', user_alias,
             'organization_name', organization_name,
-            'organization_display_name', organization_display_name
+            'organization_display_name', organization_display_name,
+            'version', version
         ))), '[]'),
         (select count(*) from filtered_repositories)
     from (
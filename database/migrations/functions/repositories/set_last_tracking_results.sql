@@ -1,8 +1,10 @@
 -- set_last_tracking_results updates the timestamp and errors of the last
--- tracking.
+-- tracking. The errors details include the code of the category each error
+-- belongs to.
 create or replace function set_last_tracking_results(
     p_repository_id uuid,
     p_last_tracking_errors text,
+    p_last_tracking_errors_details jsonb,
     p_tracking_errors_event_enabled boolean
 )
 returns void as $$
@@ -24,7 +26,9 @@ begin
     -- Update repository with last tracking results
     update repository set
 		last_tracking_ts = current_timestamp,
-		last_tracking_errors = v_last_tracking_errors
+		last_tracking_errors = v_last_tracking_errors,
+		last_tracking_errors_details = (case when v_last_tracking_errors is not null then nullif(p_last_tracking_errors_details, '[]') else null end),
+		tracking_requested_at = null
 	where repository_id = p_repository_id;
 end
 $$ language plpgsql;
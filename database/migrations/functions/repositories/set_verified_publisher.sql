@@ -1,8 +1,9 @@
 -- set_verified_publisher updates the verified publisher flag of the provided
--- repository.
+-- repository. Repositories verified using a GitHub App installation remain
+-- verified while the installation is registered.
 create or replace function set_verified_publisher(p_repository_id uuid, p_verified boolean)
 returns void as $$
     update repository set
-        verified_publisher = p_verified
+        verified_publisher = p_verified or github_app_installation_id is not null
     where repository_id = p_repository_id;
 $$ language sql;
@@ -2,26 +2,30 @@
 -- to the requesting user or an organization he belongs to. The user must own
 -- the repository transferred or belong to the organization which owns it,
 -- unless this transfer is part of an ownership claim request that has been
--- previously authorized.
+-- previously authorized. In that case, the method used to verify the claim
+-- must be provided, and an audit record of the claim will be registered.
 create or replace function transfer_repository(
     p_repository_name text,
     p_user_id uuid,
     p_org_name text,
-    p_ownership_claim boolean
+    p_ownership_claim_method text
 ) returns void as $$
 declare
+    v_ownership_claim boolean := p_ownership_claim_method is not null;
     v_owner_user_id uuid;
+    v_owner_organization_id uuid;
     v_owner_organization_name text;
 begin
+    -- Get user or organization owning the repository
+    select r.user_id, r.organization_id, o.name
+    into v_owner_user_id, v_owner_organization_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
     -- Validate repository ownership unless this transfer is part of an
     -- ownership claim request
-    if not p_ownership_claim then
-        -- Get user or organization owning the repository
-        select r.user_id, o.name into v_owner_user_id, v_owner_organization_name
-        from repository r
-        left join organization o using (organization_id)
-        where r.name = p_repository_name;
-
+    if not v_ownership_claim then
         -- Check if the user doing the request is the owner or belongs to the
         -- organization which owns it
         if v_owner_organization_name is not null then
@@ -42,7 +46,7 @@ begin
     -- Register repository ownership claim event if needed
     -- We need to store the repository subscriptors before the transfer so that
     -- we can notify them afterwards.
-    if p_ownership_claim then
+    if v_ownership_claim then
         insert into event (repository_id, event_kind_id, data)
         select repository_id, 3, json_build_object(
             'subscriptors', get_repository_subscriptors(repository_id, 3)
@@ -91,5 +95,30 @@ begin
     from new_tsdoc
     where package.package_id = new_tsdoc.package_id;
 
+    -- Register ownership claim audit record and clean up the claim tokens
+    -- registered for the repository
+    if v_ownership_claim then
+        insert into repository_ownership_claim (
+            repository_id,
+            method,
+            user_id,
+            organization_id,
+            previous_user_id,
+            previous_organization_id
+        )
+        select
+            repository_id,
+            p_ownership_claim_method,
+            p_user_id,
+            organization_id,
+            v_owner_user_id,
+            v_owner_organization_id
+        from repository where name = p_repository_name;
+
+        delete from repository_ownership_claim_token
+        where repository_id = (
+            select repository_id from repository where name = p_repository_name
+        );
+    end if;
 end
 $$ language plpgsql;
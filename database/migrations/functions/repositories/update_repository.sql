@@ -9,6 +9,7 @@ declare
     v_scanner_disabled boolean;
     v_auth_user text;
     v_auth_pass text;
+    v_version integer;
 begin
     -- Get some information about the repository
     select
@@ -16,13 +17,15 @@ begin
         disabled,
         scanner_disabled,
         auth_user,
-        auth_pass
+        auth_pass,
+        version
     into
         v_repository_id,
         v_disabled,
         v_scanner_disabled,
         v_auth_user,
-        v_auth_pass
+        v_auth_pass,
+        v_version
     from repository r
     where r.name = p_repository->>'name'
     for update;
@@ -43,6 +46,11 @@ begin
         raise insufficient_privilege;
     end if;
 
+    -- Check the repository has not been modified since the version provided
+    if p_repository->>'version' is not null and (p_repository->>'version')::int <> v_version then
+        raise 'version conflict';
+    end if;
+
     -- Update repository
     update repository set
         display_name = nullif(p_repository->>'display_name', ''),
@@ -62,7 +70,8 @@ begin
         ),
         disabled = (p_repository->>'disabled')::boolean,
         scanner_disabled = (p_repository->>'scanner_disabled')::boolean,
-        data = nullif(p_repository->'data', 'null')
+        data = nullif(p_repository->'data', 'null'),
+        version = version + 1
     where repository_id = v_repository_id;
 
     -- If the repository has been disabled, remove packages belonging to it and
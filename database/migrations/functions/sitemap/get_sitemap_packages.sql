@@ -0,0 +1,25 @@
+-- get_sitemap_packages returns the information needed to include the packages
+-- in the sitemap as a json array. Blocked packages are not included.
+create or replace function get_sitemap_packages(p_limit int, p_offset int)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'normalized_name', normalized_name,
+        'repository_name', repository_name,
+        'repository_kind_id', repository_kind_id,
+        'ts', ts
+    )), '[]')
+    from (
+        select
+            p.normalized_name,
+            r.name as repository_name,
+            r.repository_kind_id,
+            floor(extract(epoch from s.ts)) as ts
+        from package p
+        join repository r using (repository_id)
+        join snapshot s on s.package_id = p.package_id and s.version = p.latest_version
+        where get_blocked_reason(r.repository_id, p.package_id) is null
+        order by p.created_at asc, p.package_id asc
+        limit p_limit
+        offset p_offset
+    ) sp;
+$$ language sql;
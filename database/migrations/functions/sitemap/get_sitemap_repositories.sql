@@ -0,0 +1,22 @@
+-- get_sitemap_repositories returns the information needed to include the
+-- repositories in the sitemap as a json array. The last modification time of
+-- a repository is the most recent release of the packages it contains. Blocked
+-- repositories and packages are not taken into account.
+create or replace function get_sitemap_repositories()
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'name', name,
+        'ts', ts
+    )), '[]')
+    from (
+        select
+            r.name,
+            floor(extract(epoch from max(s.ts))) as ts
+        from repository r
+        join package p using (repository_id)
+        join snapshot s on s.package_id = p.package_id and s.version = p.latest_version
+        where get_blocked_reason(r.repository_id, p.package_id) is null
+        group by r.name
+        order by r.name asc
+    ) sr;
+$$ language sql;
@@ -0,0 +1,30 @@
+-- get_stats_running_total returns the running total of items registered in
+-- the table provided as a json array, aggregated using the granularity
+-- provided. Periods with no new items are included in the series. Only the
+-- items that currently exist are counted, so the totals of past periods
+-- decrease when items registered in them are deleted.
+create or replace function get_stats_running_total(p_table text, p_granularity text)
+returns json as $$
+declare
+    v_series json;
+begin
+    execute format('
+        select json_agg(json_build_array(floor(extract(epoch from period)*1000), running_total))
+        from (
+            select period, sum(total) over (order by period asc) as running_total
+            from (
+                select s.period, count(t.created_at) as total
+                from generate_series(
+                    date_trunc(%1$L, (select min(created_at) from %2$I)),
+                    date_trunc(%1$L, current_timestamp),
+                    %3$L::interval
+                ) as s(period)
+                left join %2$I t on date_trunc(%1$L, t.created_at) = s.period
+                group by s.period
+            ) pt
+        ) rt
+    ', p_granularity, p_table, '1 ' || p_granularity) into v_series;
+
+    return coalesce(v_series, '[]');
+end
+$$ language plpgsql;
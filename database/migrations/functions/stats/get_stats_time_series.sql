@@ -0,0 +1,23 @@
+-- get_stats_time_series returns the evolution over time of the number of
+-- packages, repositories, organizations and users registered, aggregated
+-- using the granularity provided (day, week or month). The series are built
+-- from the items that currently exist (deleted ones are not counted in any
+-- period), which is reported in the counts field.
+create or replace function get_stats_time_series(p_granularity text)
+returns setof json as $$
+begin
+    if p_granularity not in ('day', 'week', 'month') then
+        raise 'invalid granularity';
+    end if;
+
+    return query select json_build_object(
+        'generated_at', floor(extract(epoch from current_timestamp)*1000),
+        'granularity', p_granularity,
+        'counts', 'currently_existing',
+        'packages', get_stats_running_total('package', p_granularity),
+        'repositories', get_stats_running_total('repository', p_granularity),
+        'organizations', get_stats_running_total('organization', p_granularity),
+        'users', get_stats_running_total('user', p_granularity)
+    );
+end
+$$ language plpgsql;
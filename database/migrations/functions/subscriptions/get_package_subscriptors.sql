@@ -1,12 +1,39 @@
 -- get_package_subscriptors returns the users subscribed to the package
--- provided for the given event kind.
-create or replace function get_package_subscriptors(p_package_id uuid, p_event_kind int)
-returns setof json as $$
+-- provided for the given event kind. For security alerts, subscriptions that
+-- define a minimum severity are only included when the event data provided
+-- reports new vulnerabilities (not present in the previous security report)
+-- at or above it.
+create or replace function get_package_subscriptors(
+    p_package_id uuid,
+    p_event_kind int,
+    p_event_data jsonb
+) returns setof json as $$
     select coalesce(json_agg(json_build_object(
         'user_id', u.user_id
     )), '[]')
     from subscription s
     join "user" u using (user_id)
     where s.package_id = p_package_id
-    and s.event_kind_id = p_event_kind;
+    and s.event_kind_id = p_event_kind
+    and (
+        p_event_kind <> 1
+        or s.min_severity is null
+        or (
+            case s.min_severity
+                when 'critical' then coalesce((p_event_data->'new_vulnerabilities'->>'critical')::int, 0)
+                when 'high' then
+                    coalesce((p_event_data->'new_vulnerabilities'->>'critical')::int, 0) +
+                    coalesce((p_event_data->'new_vulnerabilities'->>'high')::int, 0)
+                when 'medium' then
+                    coalesce((p_event_data->'new_vulnerabilities'->>'critical')::int, 0) +
+                    coalesce((p_event_data->'new_vulnerabilities'->>'high')::int, 0) +
+                    coalesce((p_event_data->'new_vulnerabilities'->>'medium')::int, 0)
+                when 'low' then
+                    coalesce((p_event_data->'new_vulnerabilities'->>'critical')::int, 0) +
+                    coalesce((p_event_data->'new_vulnerabilities'->>'high')::int, 0) +
+                    coalesce((p_event_data->'new_vulnerabilities'->>'medium')::int, 0) +
+                    coalesce((p_event_data->'new_vulnerabilities'->>'low')::int, 0)
+            end
+        ) > 0
+    );
 $$ language sql;
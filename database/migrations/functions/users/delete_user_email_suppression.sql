@@ -0,0 +1,7 @@
+-- delete_user_email_suppression removes the email address of the user
+-- provided from the suppression list.
+create or replace function delete_user_email_suppression(p_user_id uuid)
+returns void as $$
+    delete from email_suppression
+    where email = (select lower(email) from "user" where user_id = p_user_id);
+$$ language sql;
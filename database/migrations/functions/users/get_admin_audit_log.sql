@@ -0,0 +1,24 @@
+-- get_admin_audit_log returns the entries registered in the admin audit log,
+-- optionally filtered by the time range provided, as a json array. Entries are
+-- returned in the order they were registered, including the hashes that chain
+-- them together.
+create or replace function get_admin_audit_log(p_input jsonb)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'admin_audit_log_id', admin_audit_log_id,
+        'entry_number', entry_number,
+        'action', action,
+        'user_id', user_id,
+        'admin_user_id', admin_user_id,
+        'reason', reason,
+        'ip', ip,
+        'user_agent', user_agent,
+        'details', details,
+        'created_at', floor(extract(epoch from created_at)),
+        'previous_hash', previous_hash,
+        'hash', hash
+    ) order by entry_number asc), '[]')
+    from admin_audit_log
+    where (p_input->>'from' is null or created_at >= (p_input->>'from')::timestamptz)
+    and (p_input->>'to' is null or created_at < (p_input->>'to')::timestamptz);
+$$ language sql;
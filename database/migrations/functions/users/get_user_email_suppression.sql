@@ -0,0 +1,13 @@
+-- get_user_email_suppression returns the suppression status of the email
+-- address of the user provided as a json object.
+create or replace function get_user_email_suppression(p_user_id uuid)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'suppressed', s.email is not null,
+        'reason', s.reason,
+        'created_at', floor(extract(epoch from s.created_at))
+    ))
+    from "user" u
+    left join email_suppression s on s.email = lower(u.email)
+    where u.user_id = p_user_id;
+$$ language sql;
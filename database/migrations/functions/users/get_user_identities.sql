@@ -0,0 +1,12 @@
+-- get_user_identities returns the external identities linked to the account
+-- of the user provided as a json array.
+create or replace function get_user_identities(p_user_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'provider', provider,
+        'email', email,
+        'created_at', floor(extract(epoch from created_at))
+    )) order by provider), '[]')
+    from user_identity
+    where user_id = p_user_id;
+$$ language sql;
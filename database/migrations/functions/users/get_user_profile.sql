@@ -6,9 +6,13 @@ returns setof json as $$
         'first_name', u.first_name,
         'last_name', u.last_name,
         'email', u.email,
+        'email_verified', u.email_verified,
         'profile_image_id', u.profile_image_id,
         'password_set', (select u.password is not null),
-        'tfa_enabled', u.tfa_enabled
+        'tfa_enabled', u.tfa_enabled,
+        'locale', u.locale,
+        'public_profile', u.public_profile,
+        'version', u.version
     ))
     from "user" u
     where u.user_id = p_user_id;
@@ -0,0 +1,30 @@
+-- get_user_public_profile returns the public profile of the provided user as a
+-- json object. Only users who have enabled their public profile are returned.
+create or replace function get_user_public_profile(p_user_alias text)
+returns setof json as $$
+    select (
+        jsonb_strip_nulls(jsonb_build_object(
+            'alias', u.alias,
+            'first_name', u.first_name,
+            'last_name', u.last_name,
+            'profile_image_id', u.profile_image_id,
+            'organizations', (
+                select coalesce(jsonb_agg(jsonb_strip_nulls(jsonb_build_object(
+                    'name', o.name,
+                    'display_name', o.display_name,
+                    'logo_image_id', o.logo_image_id
+                )) order by o.name asc), '[]')
+                from organization o
+                join user__organization uo using (organization_id)
+                where uo.user_id = u.user_id
+                and uo.confirmed = true
+                and o.public_profile = true
+            )
+        ))
+        || get_publisher_profile_content(u.user_id, null)
+    )::json
+    from "user" u
+    where u.alias = p_user_alias
+    and u.public_profile = true
+    and u.disabled = false;
+$$ language sql;
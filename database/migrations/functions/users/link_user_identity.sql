@@ -0,0 +1,45 @@
+-- link_user_identity links the provided external identity to the account of
+-- the user it belongs to. Identities can only be linked to one account, and
+-- each account can only have one identity per provider.
+create or replace function link_user_identity(p_identity jsonb)
+returns void as $$
+declare
+    v_user_id uuid := (p_identity->>'user_id')::uuid;
+    v_provider text := p_identity->>'provider';
+    v_subject text := p_identity->>'subject';
+    v_linked_user_id uuid;
+begin
+    -- Check if the identity has already been linked
+    select user_id into v_linked_user_id
+    from user_identity
+    where provider = v_provider
+    and subject = v_subject;
+    if found then
+        if v_linked_user_id <> v_user_id then
+            raise 'identity already linked to another user';
+        end if;
+        return;
+    end if;
+
+    -- Check the user does not have another identity for the same provider
+    perform from user_identity
+    where user_id = v_user_id
+    and provider = v_provider;
+    if found then
+        raise 'provider already linked';
+    end if;
+
+    -- Link identity
+    insert into user_identity (
+        provider,
+        subject,
+        user_id,
+        email
+    ) values (
+        v_provider,
+        v_subject,
+        v_user_id,
+        nullif(p_identity->>'email', '')
+    );
+end
+$$ language plpgsql;
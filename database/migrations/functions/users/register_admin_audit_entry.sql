@@ -0,0 +1,55 @@
+-- register_admin_audit_entry registers an entry in the admin audit log for the
+-- action performed by a site admin on the provided user (if any). The admin
+-- that performed the action is taken from the audit info provided. Entries are
+-- chained using the hash of the previous entry, so that any modification of
+-- the log can be detected.
+create or replace function register_admin_audit_entry(p_action text, p_user_id uuid, p_audit jsonb)
+returns void as $$
+declare
+    v_entry admin_audit_log;
+begin
+    -- Entries must be chained one at a time
+    lock table admin_audit_log in share row exclusive mode;
+
+    v_entry.admin_audit_log_id := gen_random_uuid();
+    v_entry.action := p_action;
+    v_entry.user_id := p_user_id;
+    v_entry.admin_user_id := nullif(p_audit->>'admin_user_id', '')::uuid;
+    v_entry.reason := nullif(p_audit->>'reason', '');
+    v_entry.ip := nullif(p_audit->>'ip', '')::inet;
+    v_entry.user_agent := nullif(p_audit->>'user_agent', '');
+    v_entry.details := nullif(p_audit->'details', 'null');
+    v_entry.created_at := current_timestamp;
+    select hash into v_entry.previous_hash
+    from admin_audit_log
+    order by entry_number desc
+    limit 1;
+    v_entry.hash := get_admin_audit_entry_hash(v_entry);
+
+    insert into admin_audit_log (
+        admin_audit_log_id,
+        action,
+        user_id,
+        admin_user_id,
+        reason,
+        ip,
+        user_agent,
+        details,
+        created_at,
+        previous_hash,
+        hash
+    ) values (
+        v_entry.admin_audit_log_id,
+        v_entry.action,
+        v_entry.user_id,
+        v_entry.admin_user_id,
+        v_entry.reason,
+        v_entry.ip,
+        v_entry.user_agent,
+        v_entry.details,
+        v_entry.created_at,
+        v_entry.previous_hash,
+        v_entry.hash
+    );
+end
+$$ language plpgsql;
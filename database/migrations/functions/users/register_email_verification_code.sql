@@ -0,0 +1,36 @@
+-- register_email_verification_code registers a new email verification code
+-- for the user identified by the email provided, replacing the previous one.
+-- Only users whose email is pending of verification can request a new code.
+-- It returns false when the request has been rejected because a code was
+-- registered recently.
+create or replace function register_email_verification_code(p_email text, p_code text)
+returns boolean as $$
+declare
+    v_user_id uuid;
+    v_code_created_at timestamptz;
+begin
+    -- Get user's pending email verification details
+    select u.user_id, c.created_at into v_user_id, v_code_created_at
+    from "user" u
+    join email_verification_code c using (user_id)
+    where u.email = p_email
+    and u.email_verified = false
+    and c.created_at + '1 day'::interval > current_timestamp;
+    if not found then
+        raise 'invalid email';
+    end if;
+
+    -- Reject request if a code was registered recently
+    if v_code_created_at > current_timestamp - '5 minute'::interval then
+        return false;
+    end if;
+
+    -- Register new email verification code
+    update email_verification_code set
+        email_verification_code_id = p_code,
+        created_at = current_timestamp
+    where user_id = v_user_id;
+
+    return true;
+end
+$$ language plpgsql;
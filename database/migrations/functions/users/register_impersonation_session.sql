@@ -0,0 +1,32 @@
+-- register_impersonation_session registers a session that allows a site admin
+-- to impersonate the provided user. Impersonation sessions do not require to
+-- be approved, even if the user has enabled TFA.
+create or replace function register_impersonation_session(p_session jsonb, p_audit jsonb)
+returns void as $$
+declare
+    v_user_id uuid := (p_session->>'user_id')::uuid;
+begin
+    perform from "user" where user_id = v_user_id and disabled = false;
+    if not found then
+        raise exception 'user not found';
+    end if;
+
+    insert into session (
+        session_id,
+        user_id,
+        ip,
+        user_agent,
+        approved,
+        impersonated
+    ) values (
+        p_session->>'session_id',
+        v_user_id,
+        nullif(p_session->>'ip', '')::inet,
+        nullif(p_session->>'user_agent', ''),
+        true,
+        true
+    );
+
+    perform register_admin_audit_entry('impersonate_user', v_user_id, p_audit);
+end
+$$ language plpgsql;
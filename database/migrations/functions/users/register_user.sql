@@ -1,10 +1,11 @@
--- register_user registers the provided user in the database, creating an email
--- verification code that should be used to confirm email ownership.
-create or replace function register_user(p_user jsonb)
-returns uuid as $$
+-- register_user registers the provided user in the database. When the user's
+-- email hasn't been verified yet, the email verification code provided (which
+-- is expected to be hashed) is registered as well, so that it can be used to
+-- confirm email ownership.
+create or replace function register_user(p_user jsonb, p_email_verification_code text)
+returns void as $$
 declare
     v_user_id uuid;
-    v_email_verification_code uuid;
 begin
     -- If there is a user already registered with the email provided and the
     -- email wasn't verified within the allowed period, delete both the user
@@ -25,7 +26,8 @@ begin
         email,
         email_verified,
         password,
-        profile_image_id
+        profile_image_id,
+        locale
     ) values (
         p_user->>'alias',
         nullif(p_user->>'first_name', ''),
@@ -33,16 +35,17 @@ begin
         p_user->>'email',
         (p_user->>'email_verified')::boolean,
         nullif(p_user->>'password', ''),
-        nullif(p_user->>'profile_image_id', '')::uuid
+        nullif(p_user->>'profile_image_id', '')::uuid,
+        nullif(p_user->>'locale', '')
     ) returning user_id into v_user_id;
 
     -- Register email verification code if email isn't already verified
     if (p_user->>'email_verified')::boolean = false then
-        insert into email_verification_code (user_id)
-        values (v_user_id)
-        returning email_verification_code_id into v_email_verification_code;
+        if p_email_verification_code is null then
+            raise 'email verification code not provided';
+        end if;
+        insert into email_verification_code (email_verification_code_id, user_id)
+        values (p_email_verification_code, v_user_id);
     end if;
-
-    return v_email_verification_code;
 end
 $$ language plpgsql;
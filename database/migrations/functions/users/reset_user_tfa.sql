@@ -0,0 +1,17 @@
+-- reset_user_tfa disables TFA for the provided user, removing its TFA
+-- configuration, so that they can set it up again.
+create or replace function reset_user_tfa(p_user_id uuid, p_audit jsonb)
+returns void as $$
+begin
+    update "user" set
+        tfa_enabled = false,
+        tfa_url = null,
+        tfa_recovery_codes = null
+    where user_id = p_user_id;
+    if not found then
+        raise exception 'user not found';
+    end if;
+
+    perform register_admin_audit_entry('reset_user_tfa', p_user_id, p_audit);
+end
+$$ language plpgsql;
@@ -0,0 +1,60 @@
+-- search_users searchs users in the database that match the criteria in the
+-- query provided.
+create or replace function search_users(p_input jsonb)
+returns table(data json, total_count bigint) as $$
+declare
+    v_ts_query_web text := p_input->>'ts_query_web';
+    v_pattern text;
+begin
+    -- Wildcards in the query are matched literally
+    if v_ts_query_web is not null and v_ts_query_web <> '' then
+        v_pattern := '%' || replace(replace(replace(
+            v_ts_query_web, '\', '\\'), '%', '\%'), '_', '\_'
+        ) || '%';
+    end if;
+
+    return query
+    with users_found as (
+        select
+            u.user_id,
+            u.alias,
+            u.first_name,
+            u.last_name,
+            u.email,
+            u.email_verified,
+            coalesce(u.tfa_enabled, false) as tfa_enabled,
+            u.disabled,
+            u.created_at
+        from "user" u
+        where
+            case when v_pattern is not null then
+                u.alias ilike v_pattern escape '\'
+                or u.email ilike v_pattern escape '\'
+                or u.first_name ilike v_pattern escape '\'
+                or u.last_name ilike v_pattern escape '\'
+            else true end
+    )
+    select
+        (
+            select coalesce(json_agg(json_strip_nulls(json_build_object(
+                'user_id', user_id,
+                'alias', alias,
+                'first_name', first_name,
+                'last_name', last_name,
+                'email', email,
+                'email_verified', email_verified,
+                'tfa_enabled', tfa_enabled,
+                'disabled', disabled,
+                'created_at', floor(extract(epoch from created_at))
+            ))), '[]')
+            from (
+                select *
+                from users_found
+                order by alias asc
+                limit (p_input->>'limit')::int
+                offset (p_input->>'offset')::int
+            ) uf
+        ),
+        (select count(*) from users_found);
+end
+$$ language plpgsql;
@@ -0,0 +1,21 @@
+-- set_user_disabled disables or enables the provided user. When a user is
+-- disabled, all their sessions are deleted.
+create or replace function set_user_disabled(p_user_id uuid, p_disabled boolean, p_audit jsonb)
+returns void as $$
+begin
+    update "user" set disabled = p_disabled where user_id = p_user_id;
+    if not found then
+        raise exception 'user not found';
+    end if;
+
+    if p_disabled then
+        delete from session where user_id = p_user_id;
+    end if;
+
+    perform register_admin_audit_entry(
+        case when p_disabled then 'disable_user' else 'enable_user' end,
+        p_user_id,
+        p_audit
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,25 @@
+-- unlink_user_identity unlinks the identity of the provider given from the
+-- account of the user provided. The last sign in method available for the
+-- account cannot be unlinked.
+create or replace function unlink_user_identity(p_user_id uuid, p_provider text)
+returns void as $$
+begin
+    -- Make sure the user will still be able to sign in
+    perform from "user"
+    where user_id = p_user_id
+    and password is not null;
+    if not found then
+        perform from user_identity
+        where user_id = p_user_id
+        and provider <> p_provider;
+        if not found then
+            raise 'last sign in method cannot be unlinked';
+        end if;
+    end if;
+
+    -- Unlink identity
+    delete from user_identity
+    where user_id = p_user_id
+    and provider = p_provider;
+end
+$$ language plpgsql;
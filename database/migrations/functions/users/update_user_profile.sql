@@ -2,10 +2,23 @@
 -- user in the database.
 create or replace function update_user_profile(p_requesting_user_id uuid, p_user jsonb)
 returns void as $$
+declare
+    v_version integer;
+begin
+    -- Check the profile has not been modified since the version provided
+    select version into v_version from "user" where user_id = p_requesting_user_id for update;
+    if p_user->>'version' is not null and (p_user->>'version')::int <> v_version then
+        raise 'version conflict';
+    end if;
+
     update "user" set
         alias = p_user->>'alias',
         first_name = nullif(p_user->>'first_name', ''),
         last_name = nullif(p_user->>'last_name', ''),
-        profile_image_id = nullif(p_user->>'profile_image_id', '')::uuid
+        profile_image_id = nullif(p_user->>'profile_image_id', '')::uuid,
+        locale = nullif(p_user->>'locale', ''),
+        public_profile = coalesce((p_user->>'public_profile')::boolean, public_profile),
+        version = version + 1
     where user_id = p_requesting_user_id;
-$$ language sql;
+end
+$$ language plpgsql;
@@ -0,0 +1,20 @@
+-- verify_admin_audit_log checks that the entries of the admin audit log have
+-- not been tampered with, recomputing the hash of each of them. It returns the
+-- id of the first entry whose hash does not match, or null when the log is
+-- valid.
+create or replace function verify_admin_audit_log()
+returns uuid as $$
+declare
+    v_entry admin_audit_log;
+    v_previous_hash text;
+begin
+    for v_entry in select * from admin_audit_log order by entry_number asc loop
+        if v_entry.previous_hash is distinct from v_previous_hash
+        or v_entry.hash <> get_admin_audit_entry_hash(v_entry) then
+            return v_entry.admin_audit_log_id;
+        end if;
+        v_previous_hash := v_entry.hash;
+    end loop;
+    return null;
+end
+$$ language plpgsql;
@@ -1,12 +1,16 @@
 -- verify_email verifies an email using the provided email verification code,
 -- returning true if the email was verified successfully or false otherwise.
-create or replace function verify_email(p_code uuid)
+-- Codes can only be used once.
+create or replace function verify_email(p_code text)
 returns boolean as $$
+declare
+    v_user_id uuid;
 begin
-    -- Check if email verification code exists and is not expired
-    perform from email_verification_code
+    -- Get and delete email verification code (only if it has not expired)
+    delete from email_verification_code
     where email_verification_code_id = p_code
-    and created_at + '1 day'::interval > current_timestamp;
+    and created_at + '1 day'::interval > current_timestamp
+    returning user_id into v_user_id;
     if not found then
         return false;
     end if;
@@ -14,14 +18,7 @@ begin
     -- Mark email as verified in user record
     update "user"
     set email_verified = true
-    where user_id = (
-        select user_id from email_verification_code
-        where email_verification_code_id = p_code
-    );
-
-    -- Delete email verification code
-    delete from email_verification_code
-    where email_verification_code_id = p_code;
+    where user_id = v_user_id;
 
     return true;
 end
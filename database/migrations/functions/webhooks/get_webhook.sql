@@ -16,6 +16,7 @@ begin
         'content_type', wh.content_type,
         'template', wh.template,
         'active', wh.active,
+        'version', wh.version,
         'event_kinds', (
             select json_agg(event_kind_id)
             from webhook__event_kind wek
@@ -0,0 +1,45 @@
+-- replay_webhook_events queues again the notifications of the events that
+-- occurred in the time range provided and that the webhook is subscribed to,
+-- so that they are delivered once more. Notifications already delivered are
+-- marked as pending again, and the missing ones are created. The number of
+-- notifications queued is returned. Inactive webhooks are not replayed.
+create or replace function replay_webhook_events(
+    p_user_id uuid,
+    p_webhook_id uuid,
+    p_from timestamptz,
+    p_to timestamptz
+) returns integer as $$
+declare
+    v_notifications_queued integer;
+begin
+    -- Check if the user doing the request has access to the webhook
+    if not user_has_access_to_webhook(p_user_id, p_webhook_id) then
+        raise insufficient_privilege;
+    end if;
+
+    -- Queue notifications for the events in the time range provided
+    insert into notification (
+        event_id,
+        event_created_at,
+        webhook_id
+    )
+    select e.event_id, e.created_at, wh.webhook_id
+    from event e
+    join webhook__event_kind wek using (event_kind_id)
+    join webhook__package wp using (webhook_id, package_id)
+    join webhook wh using (webhook_id)
+    where wh.webhook_id = p_webhook_id
+    and wh.active = true
+    and e.processed = true
+    and e.created_at >= p_from
+    and e.created_at <= p_to
+    on conflict (event_id, event_created_at, webhook_id) do update
+    set
+        processed = false,
+        processed_at = null,
+        error = null;
+    get diagnostics v_notifications_queued = row_count;
+
+    return v_notifications_queued;
+end
+$$ language plpgsql;
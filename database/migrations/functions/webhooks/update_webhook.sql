@@ -7,11 +7,18 @@ declare
     v_owner_organization_name text;
     v_event_kind integer;
     v_package jsonb;
+    v_version integer;
 begin
     if not user_has_access_to_webhook(p_user_id, v_webhook_id) then
         raise insufficient_privilege;
     end if;
 
+    -- Check the webhook has not been modified since the version provided
+    select version into v_version from webhook where webhook_id = v_webhook_id for update;
+    if p_webhook->>'version' is not null and (p_webhook->>'version')::int <> v_version then
+        raise 'version conflict';
+    end if;
+
     -- Webhook
     update webhook set
         name = p_webhook->>'name',
@@ -20,7 +27,8 @@ begin
         secret = nullif(p_webhook->>'secret', ''),
         content_type = nullif(p_webhook->>'content_type', ''),
         template = nullif(p_webhook->>'template', ''),
-        active = (p_webhook->>'active')::boolean
+        active = (p_webhook->>'active')::boolean,
+        version = version + 1
     where webhook_id = v_webhook_id;
 
     -- Bind webhook with event kinds if needed
@@ -0,0 +1,5 @@
+alter table snapshot add column replaced_by text;
+
+---- create above / drop below ----
+
+alter table snapshot drop column replaced_by;
@@ -0,0 +1,5 @@
+alter table snapshot add column security_scan_requested_at timestamptz;
+
+---- create above / drop below ----
+
+alter table snapshot drop column security_scan_requested_at;
@@ -0,0 +1,17 @@
+create table if not exists vulnerability_statement (
+    package_id uuid not null,
+    version text not null,
+    vulnerability_id text not null check (vulnerability_id <> ''),
+    status text not null check (status in ('acknowledged', 'not_affected')),
+    justification text check (justification <> ''),
+    created_at timestamptz default current_timestamp not null,
+    primary key (package_id, version, vulnerability_id),
+    foreign key (package_id, version) references snapshot (package_id, version) on delete cascade
+);
+
+alter table snapshot add column security_report_suppressed jsonb;
+
+---- create above / drop below ----
+
+alter table snapshot drop column security_report_suppressed;
+drop table if exists vulnerability_statement;
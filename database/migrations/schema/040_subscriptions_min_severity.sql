@@ -0,0 +1,6 @@
+alter table subscription add column min_severity text check (min_severity in ('low', 'medium', 'high', 'critical'));
+drop function if exists get_package_subscriptors(uuid, int);
+
+---- create above / drop below ----
+
+alter table subscription drop column min_severity;
@@ -0,0 +1,5 @@
+alter table snapshot add column signature_verified boolean;
+
+---- create above / drop below ----
+
+alter table snapshot drop column signature_verified;
@@ -0,0 +1,5 @@
+alter table snapshot add column images_licenses jsonb;
+
+---- create above / drop below ----
+
+alter table snapshot drop column images_licenses;
@@ -0,0 +1,10 @@
+create table if not exists image_scan (
+    digest text primary key check (digest <> ''),
+    report jsonb not null,
+    sboms jsonb,
+    created_at timestamptz default current_timestamp not null
+);
+
+---- create above / drop below ----
+
+drop table if exists image_scan;
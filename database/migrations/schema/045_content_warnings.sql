@@ -0,0 +1,9 @@
+alter table snapshot add column content_warnings jsonb;
+
+insert into event_kind values (5, 'Package content warnings');
+
+---- create above / drop below ----
+
+delete from event_kind where event_kind_id = 5;
+
+alter table snapshot drop column content_warnings;
@@ -0,0 +1,15 @@
+create table if not exists package_downloads (
+    package_id uuid not null references package on delete cascade,
+    version text not null check (version <> ''),
+    day date not null,
+    total integer not null check (total >= 0),
+    primary key (package_id, version, day)
+);
+
+alter table package add column downloads bigint not null default 0;
+
+---- create above / drop below ----
+
+alter table package drop column downloads;
+
+drop table if exists package_downloads;
@@ -0,0 +1,15 @@
+alter table user_starred_package add column created_at timestamptz;
+alter table user_starred_package alter column created_at set default current_timestamp;
+
+create table if not exists package_ranking (
+    ranking text not null check (ranking <> ''),
+    package_id uuid not null references package on delete cascade,
+    score real not null,
+    primary key (ranking, package_id)
+);
+
+---- create above / drop below ----
+
+drop table if exists package_ranking;
+
+alter table user_starred_package drop column created_at;
@@ -0,0 +1,5 @@
+alter table snapshot add column logo_dark_image_id uuid;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists logo_dark_image_id;
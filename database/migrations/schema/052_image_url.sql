@@ -0,0 +1,9 @@
+create table if not exists image_url (
+    url_digest bytea primary key,
+    image_id uuid not null references image on delete cascade,
+    updated_at timestamptz default current_timestamp not null
+);
+
+---- create above / drop below ----
+
+drop table if exists image_url;
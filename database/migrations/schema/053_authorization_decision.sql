@@ -0,0 +1,16 @@
+create table if not exists authorization_decision (
+    authorization_decision_id uuid primary key default gen_random_uuid(),
+    organization_id uuid not null references organization on delete cascade,
+    user_id uuid not null references "user" on delete cascade,
+    action text not null check (action <> ''),
+    allowed boolean not null,
+    policy_digest text not null check (policy_digest <> ''),
+    error text,
+    created_at timestamptz default current_timestamp not null
+);
+
+create index authorization_decision_organization_id_created_at_idx on authorization_decision (organization_id, created_at);
+
+---- create above / drop below ----
+
+drop table if exists authorization_decision;
@@ -0,0 +1,19 @@
+alter table organization add column repository_changes_approval boolean not null default false;
+
+create table if not exists repository_change (
+    repository_change_id uuid primary key default gen_random_uuid(),
+    organization_id uuid not null references organization on delete cascade,
+    kind text not null check (kind in ('add', 'update', 'delete')),
+    repository_name text not null check (repository_name <> ''),
+    repository jsonb,
+    requested_by uuid not null references "user" on delete cascade,
+    created_at timestamptz default current_timestamp not null
+);
+
+create index repository_change_organization_id_idx on repository_change (organization_id);
+create index repository_change_requested_by_idx on repository_change (requested_by);
+
+---- create above / drop below ----
+
+drop table if exists repository_change;
+alter table organization drop column if exists repository_changes_approval;
@@ -0,0 +1,137 @@
+-- Events and notifications tables are converted into partitioned tables, so
+-- that old data can be archived and removed efficiently. Notifications are
+-- partitioned by the creation time of the event they belong to, so that the
+-- partitions of both tables cover the same periods of time.
+create table event_old as select * from event;
+create table notification_old as select * from notification;
+drop table notification;
+drop table event;
+
+create table event (
+    event_id uuid not null default gen_random_uuid(),
+    created_at timestamptz default current_timestamp not null,
+    processed boolean not null default false,
+    processed_at timestamptz,
+    event_kind_id integer not null references event_kind on delete restrict,
+    repository_id uuid references repository on delete cascade,
+    package_id uuid references package on delete cascade,
+    package_version text check (package_version <> ''),
+    data jsonb,
+    primary key (event_id, created_at)
+) partition by range (created_at);
+
+create index event_not_processed_idx on event (event_id) where processed = 'false';
+
+create table notification (
+    notification_id uuid not null default gen_random_uuid(),
+    created_at timestamptz default current_timestamp not null,
+    processed boolean not null default false,
+    processed_at timestamptz,
+    error text check (error <> ''),
+    event_id uuid not null,
+    event_created_at timestamptz not null,
+    user_id uuid references "user" on delete cascade,
+    webhook_id uuid references webhook on delete cascade,
+    check (user_id is null or webhook_id is null),
+    primary key (notification_id, event_created_at),
+    foreign key (event_id, event_created_at) references event (event_id, created_at) on delete cascade,
+    unique (event_id, event_created_at, user_id),
+    unique (event_id, event_created_at, webhook_id)
+) partition by range (event_created_at);
+
+create index notification_not_processed_idx on notification (notification_id) where processed = 'false';
+create index notification_webhook_id_created_at_idx on notification (webhook_id, created_at);
+
+select partman.create_parent(
+    'public.event', 'created_at', 'native', 'monthly',
+    p_start_partition := (select coalesce(min(created_at), current_timestamp)::text from event_old)
+);
+select partman.create_parent(
+    'public.notification', 'event_created_at', 'native', 'monthly',
+    p_start_partition := (select coalesce(min(created_at), current_timestamp)::text from event_old)
+);
+
+insert into event select * from event_old;
+insert into notification (
+    notification_id,
+    created_at,
+    processed,
+    processed_at,
+    error,
+    event_id,
+    event_created_at,
+    user_id,
+    webhook_id
+)
+select
+    n.notification_id,
+    n.created_at,
+    n.processed,
+    n.processed_at,
+    n.error,
+    n.event_id,
+    e.created_at,
+    n.user_id,
+    n.webhook_id
+from notification_old n
+join event_old e using (event_id);
+
+drop table notification_old;
+drop table event_old;
+
+---- create above / drop below ----
+
+create table event_old as select * from event;
+create table notification_old as select * from notification;
+drop table notification;
+drop table event;
+delete from partman.part_config where parent_table in ('public.event', 'public.notification');
+drop table if exists partman.template_public_event;
+drop table if exists partman.template_public_notification;
+
+create table event (
+    event_id uuid primary key default gen_random_uuid(),
+    created_at timestamptz default current_timestamp not null,
+    processed boolean not null default false,
+    processed_at timestamptz,
+    event_kind_id integer not null references event_kind on delete restrict,
+    repository_id uuid references repository on delete cascade,
+    package_id uuid references package on delete cascade,
+    package_version text check (package_version <> ''),
+    data jsonb
+);
+
+create index event_not_processed_idx on event (event_id) where processed = 'false';
+
+create table notification (
+    notification_id uuid primary key default gen_random_uuid(),
+    created_at timestamptz default current_timestamp not null,
+    processed boolean not null default false,
+    processed_at timestamptz,
+    error text check (error <> ''),
+    event_id uuid not null references event on delete cascade,
+    user_id uuid references "user" on delete cascade,
+    webhook_id uuid references webhook on delete cascade,
+    check (user_id is null or webhook_id is null),
+    unique (event_id, user_id),
+    unique (event_id, webhook_id)
+);
+
+create index notification_not_processed_idx on notification (notification_id) where processed = 'false';
+create index notification_webhook_id_created_at_idx on notification (webhook_id, created_at);
+
+insert into event select * from event_old;
+insert into notification
+select
+    notification_id,
+    created_at,
+    processed,
+    processed_at,
+    error,
+    event_id,
+    user_id,
+    webhook_id
+from notification_old;
+
+drop table notification_old;
+drop table event_old;
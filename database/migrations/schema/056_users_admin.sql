@@ -0,0 +1,20 @@
+alter table "user" add column disabled boolean not null default false;
+alter table session add column impersonated boolean not null default false;
+
+create table if not exists admin_audit_log (
+    admin_audit_log_id uuid primary key default gen_random_uuid(),
+    action text not null check (action <> ''),
+    user_id uuid references "user" on delete set null,
+    reason text check (reason <> ''),
+    ip inet,
+    user_agent text check (user_agent <> ''),
+    created_at timestamptz default current_timestamp not null
+);
+
+create index admin_audit_log_user_id_idx on admin_audit_log (user_id);
+
+---- create above / drop below ----
+
+drop table if exists admin_audit_log;
+alter table session drop column if exists impersonated;
+alter table "user" drop column if exists disabled;
@@ -0,0 +1,12 @@
+create table if not exists blocked_content (
+    blocked_content_id uuid primary key default gen_random_uuid(),
+    repository_id uuid unique references repository on delete cascade,
+    package_id uuid unique references package on delete cascade,
+    reason text not null check (reason <> ''),
+    created_at timestamptz default current_timestamp not null,
+    check ((repository_id is null) <> (package_id is null))
+);
+
+---- create above / drop below ----
+
+drop table if exists blocked_content;
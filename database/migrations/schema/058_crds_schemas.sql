@@ -0,0 +1,5 @@
+alter table snapshot add column crds_schemas jsonb;
+
+---- create above / drop below ----
+
+alter table snapshot drop column crds_schemas;
@@ -0,0 +1,11 @@
+alter table snapshot add column support jsonb;
+alter table snapshot add column eol_event_registered boolean not null default false;
+
+insert into event_kind values (6, 'Package version end of life');
+
+---- create above / drop below ----
+
+delete from event_kind where event_kind_id = 6;
+
+alter table snapshot drop column eol_event_registered;
+alter table snapshot drop column support;
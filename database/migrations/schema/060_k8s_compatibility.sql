@@ -0,0 +1,5 @@
+alter table snapshot add column k8s_compatibility jsonb;
+
+---- create above / drop below ----
+
+alter table snapshot drop column k8s_compatibility;
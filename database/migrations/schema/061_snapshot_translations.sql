@@ -0,0 +1,5 @@
+alter table snapshot add column translations jsonb;
+
+---- create above / drop below ----
+
+alter table snapshot drop column translations;
@@ -0,0 +1,7 @@
+alter table "user" add column public_profile boolean not null default false;
+alter table organization add column public_profile boolean not null default false;
+
+---- create above / drop below ----
+
+alter table "user" drop column if exists public_profile;
+alter table organization drop column if exists public_profile;
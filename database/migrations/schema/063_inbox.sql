@@ -0,0 +1,16 @@
+create table if not exists inbox_notification (
+    inbox_notification_id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references "user" on delete cascade,
+    kind text not null check (kind <> ''),
+    data jsonb,
+    read boolean not null default false,
+    read_at timestamptz,
+    created_at timestamptz default current_timestamp not null
+);
+
+create index inbox_notification_user_id_created_at_idx on inbox_notification (user_id, created_at);
+create index inbox_notification_user_id_unread_idx on inbox_notification (user_id) where read = false;
+
+---- create above / drop below ----
+
+drop table if exists inbox_notification;
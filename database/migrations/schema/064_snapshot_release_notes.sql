@@ -0,0 +1,5 @@
+alter table snapshot add column release_notes jsonb;
+
+---- create above / drop below ----
+
+alter table snapshot drop column release_notes;
@@ -0,0 +1,33 @@
+create table if not exists issue_tracker (
+    issue_tracker_id uuid primary key default gen_random_uuid(),
+    organization_id uuid not null references organization on delete cascade,
+    kind text not null check (kind in ('github', 'jira')),
+    url text check (url <> ''),
+    project text not null check (project <> ''),
+    labels text[],
+    username text check (username <> ''),
+    token text not null check (token <> ''),
+    active boolean not null default true,
+    created_at timestamptz default current_timestamp not null,
+    updated_at timestamptz default current_timestamp not null,
+    unique (organization_id, kind, project)
+);
+
+create table if not exists issue_tracker_issue (
+    issue_tracker_id uuid not null references issue_tracker on delete cascade,
+    package_id uuid not null references package on delete cascade,
+    vulnerability_id text not null check (vulnerability_id <> ''),
+    url text check (url <> ''),
+    created_at timestamptz default current_timestamp not null,
+    primary key (issue_tracker_id, package_id, vulnerability_id)
+);
+
+alter table notification add column issue_tracker_id uuid references issue_tracker on delete cascade;
+alter table notification add check (issue_tracker_id is null or (user_id is null and webhook_id is null));
+alter table notification add unique (event_id, event_created_at, issue_tracker_id);
+
+---- create above / drop below ----
+
+alter table notification drop column issue_tracker_id;
+drop table if exists issue_tracker_issue;
+drop table if exists issue_tracker;
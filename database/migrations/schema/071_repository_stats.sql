@@ -0,0 +1,14 @@
+create table if not exists repository_tracking_run (
+    repository_id uuid not null references repository on delete cascade,
+    duration real not null check (duration >= 0),
+    outcome text not null check (outcome in ('success', 'error', 'timeout')),
+    created_at timestamptz default current_timestamp not null
+);
+create index repository_tracking_run_repository_id_created_at_idx on repository_tracking_run (repository_id, created_at);
+
+alter table subscription add column created_at timestamptz default current_timestamp not null;
+
+---- create above / drop below ----
+
+alter table subscription drop column created_at;
+drop table if exists repository_tracking_run;
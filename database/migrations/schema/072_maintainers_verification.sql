@@ -0,0 +1,21 @@
+alter table maintainer add column verified boolean not null default false;
+
+create table if not exists maintainer_verification_code (
+    maintainer_verification_code_id text primary key,
+    maintainer_id uuid not null unique references maintainer on delete cascade,
+    created_at timestamptz default current_timestamp not null
+);
+
+create table if not exists maintainer_contact (
+    user_id uuid not null references "user" on delete cascade,
+    maintainer_id uuid not null references maintainer on delete cascade,
+    created_at timestamptz default current_timestamp not null
+);
+create index maintainer_contact_user_id_created_at_idx on maintainer_contact (user_id, created_at);
+create index maintainer_contact_maintainer_id_idx on maintainer_contact (maintainer_id);
+
+---- create above / drop below ----
+
+drop table if exists maintainer_contact;
+drop table if exists maintainer_verification_code;
+alter table maintainer drop column verified;
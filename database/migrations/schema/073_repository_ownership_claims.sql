@@ -0,0 +1,27 @@
+create table if not exists repository_ownership_claim (
+    repository_ownership_claim_id uuid primary key default gen_random_uuid(),
+    repository_id uuid not null references repository on delete cascade,
+    method text not null check (method in ('maintainer', 'owners', 'token')),
+    user_id uuid references "user" on delete set null,
+    organization_id uuid references organization on delete set null,
+    previous_user_id uuid references "user" on delete set null,
+    previous_organization_id uuid references organization on delete set null,
+    created_at timestamptz default current_timestamp not null
+);
+create index repository_ownership_claim_repository_id_idx on repository_ownership_claim (repository_id);
+
+create table if not exists repository_ownership_claim_token (
+    repository_id uuid not null references repository on delete cascade,
+    user_id uuid not null references "user" on delete cascade,
+    token text not null check (token <> ''),
+    created_at timestamptz default current_timestamp not null,
+    primary key (repository_id, user_id)
+);
+
+drop function if exists transfer_repository(text, uuid, text, boolean);
+
+---- create above / drop below ----
+
+drop function if exists transfer_repository(text, uuid, text, text);
+drop table if exists repository_ownership_claim_token;
+drop table if exists repository_ownership_claim;
@@ -0,0 +1,7 @@
+create index event_repository_id_created_at_idx on event (repository_id, created_at);
+create index event_package_id_created_at_idx on event (package_id, created_at);
+
+---- create above / drop below ----
+
+drop index if exists event_repository_id_created_at_idx;
+drop index if exists event_package_id_created_at_idx;
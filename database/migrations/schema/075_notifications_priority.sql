@@ -0,0 +1,3 @@
+drop function if exists get_pending_notification();
+
+---- create above / drop below ----
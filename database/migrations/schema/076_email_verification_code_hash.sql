@@ -0,0 +1,10 @@
+alter table email_verification_code alter column email_verification_code_id drop default;
+alter table email_verification_code alter column email_verification_code_id type text
+    using encode(sha512(email_verification_code_id::text::bytea), 'hex');
+drop function if exists register_user(jsonb);
+drop function if exists verify_email(uuid);
+
+---- create above / drop below ----
+
+alter table email_verification_code alter column email_verification_code_id type uuid using gen_random_uuid();
+alter table email_verification_code alter column email_verification_code_id set default gen_random_uuid();
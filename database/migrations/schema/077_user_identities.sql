@@ -0,0 +1,13 @@
+create table if not exists user_identity (
+    provider text not null check (provider in ('github', 'google', 'oidc')),
+    subject text not null check (subject <> ''),
+    user_id uuid not null references "user" on delete cascade,
+    email text,
+    created_at timestamptz default current_timestamp not null,
+    primary key (provider, subject),
+    unique (user_id, provider)
+);
+
+---- create above / drop below ----
+
+drop table if exists user_identity;
@@ -0,0 +1,22 @@
+create table if not exists organization_domain (
+    organization_id uuid not null references organization on delete cascade,
+    domain text not null check (domain <> ''),
+    verification_token text not null check (verification_token <> ''),
+    verified_at timestamptz,
+    join_mode text default 'disabled' not null check (join_mode in ('disabled', 'auto', 'request')),
+    created_at timestamptz default current_timestamp not null,
+    primary key (organization_id, domain)
+);
+create unique index organization_domain_verified_domain_idx on organization_domain (domain) where verified_at is not null;
+
+create table if not exists organization_join_request (
+    organization_id uuid not null references organization on delete cascade,
+    user_id uuid not null references "user" on delete cascade,
+    created_at timestamptz default current_timestamp not null,
+    primary key (organization_id, user_id)
+);
+
+---- create above / drop below ----
+
+drop table if exists organization_join_request;
+drop table if exists organization_domain;
@@ -0,0 +1,9 @@
+alter table repository add column version integer not null default 1;
+alter table webhook add column version integer not null default 1;
+alter table "user" add column version integer not null default 1;
+
+---- create above / drop below ----
+
+alter table repository drop column version;
+alter table webhook drop column version;
+alter table "user" drop column version;
@@ -0,0 +1,14 @@
+alter table api_key add column last_used_at timestamptz;
+
+create table if not exists api_key_usage (
+    api_key_id uuid not null references api_key on delete cascade,
+    day date not null,
+    requests integer not null default 0,
+    errors integer not null default 0,
+    primary key (api_key_id, day)
+);
+
+---- create above / drop below ----
+
+drop table if exists api_key_usage;
+alter table api_key drop column last_used_at;
@@ -0,0 +1,14 @@
+create table if not exists github_app_installation (
+    installation_id bigint primary key,
+    account text not null check (account <> ''),
+    created_at timestamptz default current_timestamp not null
+);
+
+alter table repository add column github_app_installation_id bigint references github_app_installation on delete set null;
+alter table repository add column tracking_requested_at timestamptz;
+
+---- create above / drop below ----
+
+alter table repository drop column tracking_requested_at;
+alter table repository drop column github_app_installation_id;
+drop table if exists github_app_installation;
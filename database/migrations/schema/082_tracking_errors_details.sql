@@ -0,0 +1,8 @@
+alter table repository add column last_tracking_errors_details jsonb;
+
+drop function if exists set_last_tracking_results(uuid, text, boolean);
+
+---- create above / drop below ----
+
+drop function if exists set_last_tracking_results(uuid, text, jsonb, boolean);
+alter table repository drop column last_tracking_errors_details;
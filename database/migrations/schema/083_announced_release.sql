@@ -0,0 +1,21 @@
+create table if not exists announced_release (
+    repository_id uuid not null references repository on delete cascade,
+    package_name text not null check (package_name <> ''),
+    version text not null check (version <> ''),
+    digest text not null default '',
+    created_at timestamptz default current_timestamp not null,
+    primary key (repository_id, package_name, version, digest)
+);
+
+insert into announced_release (repository_id, package_name, version, digest)
+select distinct p.repository_id, p.name, e.package_version, coalesce(s.digest, '')
+from event e
+join package p using (package_id)
+join repository r using (repository_id)
+left join snapshot s on s.package_id = e.package_id and s.version = e.package_version and r.repository_kind_id = 12
+where e.event_kind_id = 0
+on conflict do nothing;
+
+---- create above / drop below ----
+
+drop table if exists announced_release;
@@ -0,0 +1,5 @@
+alter table api_key add column allowed_cidrs cidr[];
+
+---- create above / drop below ----
+
+alter table api_key drop column allowed_cidrs;
@@ -0,0 +1,12 @@
+create table if not exists maintenance_mode (
+    maintenance_mode_id boolean primary key default true check (maintenance_mode_id),
+    enabled boolean not null default false,
+    retry_after integer not null default 300 check (retry_after > 0),
+    updated_at timestamptz default current_timestamp not null
+);
+
+insert into maintenance_mode default values;
+
+---- create above / drop below ----
+
+drop table if exists maintenance_mode;
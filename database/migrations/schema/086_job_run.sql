@@ -0,0 +1,16 @@
+create table if not exists job_run (
+    job_run_id uuid primary key default gen_random_uuid(),
+    job_name text not null check (job_name <> ''),
+    started_at timestamptz not null,
+    duration integer not null check (duration >= 0),
+    attempts integer not null check (attempts > 0),
+    succeeded boolean not null,
+    error text
+);
+
+create index job_run_job_name_started_at_idx on job_run (job_name, started_at);
+create index job_run_started_at_idx on job_run (started_at);
+
+---- create above / drop below ----
+
+drop table if exists job_run;
@@ -0,0 +1,9 @@
+create table if not exists job_lease (
+    job_name text primary key check (job_name <> ''),
+    holder text not null check (holder <> ''),
+    expires_at timestamptz not null
+);
+
+---- create above / drop below ----
+
+drop table if exists job_lease;
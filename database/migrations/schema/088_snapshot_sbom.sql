@@ -0,0 +1,36 @@
+create table if not exists snapshot_sbom (
+    package_id uuid not null,
+    version text not null,
+    format text not null check (format <> ''),
+    image text not null check (image <> ''),
+    sbom jsonb not null,
+    created_at timestamptz default current_timestamp not null,
+    primary key (package_id, version, format, image),
+    foreign key (package_id, version) references snapshot (package_id, version) on delete cascade
+);
+
+insert into snapshot_sbom (package_id, version, format, image, sbom)
+select s.package_id, s.version, f.key, i.key, i.value
+from snapshot s
+cross join jsonb_each(s.sbom) f
+cross join jsonb_each(f.value) i
+where s.sbom is not null;
+
+alter table snapshot drop column sbom;
+
+---- create above / drop below ----
+
+alter table snapshot add column sbom jsonb;
+
+update snapshot s set sbom = (
+    select jsonb_object_agg(format, images)
+    from (
+        select format, jsonb_object_agg(image, sbom) as images
+        from snapshot_sbom ss
+        where ss.package_id = s.package_id
+        and ss.version = s.version
+        group by format
+    ) f
+);
+
+drop table if exists snapshot_sbom;
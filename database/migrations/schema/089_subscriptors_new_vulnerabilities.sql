@@ -0,0 +1,5 @@
+drop function if exists get_package_subscriptors(uuid, int, text);
+
+---- create above / drop below ----
+
+drop function if exists get_package_subscriptors(uuid, int, jsonb);
@@ -0,0 +1,81 @@
+alter table admin_audit_log drop constraint if exists admin_audit_log_user_id_fkey;
+alter table admin_audit_log add column details jsonb;
+alter table admin_audit_log add column entry_number bigint generated by default as identity;
+alter table admin_audit_log add column previous_hash text;
+alter table admin_audit_log add column hash text;
+
+-- get_admin_audit_entry_hash returns the hash of the admin audit log entry
+-- provided, which includes the hash of the previous entry in the log.
+create or replace function get_admin_audit_entry_hash(p_entry admin_audit_log)
+returns text as $$
+    select encode(digest(
+        coalesce(p_entry.previous_hash, '') || jsonb_build_object(
+            'admin_audit_log_id', p_entry.admin_audit_log_id,
+            'action', p_entry.action,
+            'user_id', p_entry.user_id,
+            'reason', p_entry.reason,
+            'ip', p_entry.ip::text,
+            'user_agent', p_entry.user_agent,
+            'details', p_entry.details,
+            'created_at', to_char(p_entry.created_at at time zone 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')
+        )::text,
+        'sha256'
+    ), 'hex');
+$$ language sql stable;
+
+-- Chain the entries registered so far
+do $$
+declare
+    v_entry admin_audit_log;
+    v_previous_hash text;
+begin
+    for v_entry in select * from admin_audit_log order by created_at asc, entry_number asc loop
+        v_entry.previous_hash := v_previous_hash;
+        v_previous_hash := get_admin_audit_entry_hash(v_entry);
+        update admin_audit_log set
+            previous_hash = v_entry.previous_hash,
+            hash = v_previous_hash
+        where admin_audit_log_id = v_entry.admin_audit_log_id;
+    end loop;
+end
+$$;
+
+alter table admin_audit_log alter column hash set not null;
+create unique index admin_audit_log_entry_number_idx on admin_audit_log (entry_number);
+
+-- Entries in the admin audit log cannot be modified or deleted
+create or replace function prevent_admin_audit_log_changes()
+returns trigger as $$
+begin
+    raise 'admin audit log entries cannot be modified';
+end
+$$ language plpgsql;
+
+create trigger trigger_admin_audit_log_append_only
+before update or delete on admin_audit_log
+for each row
+execute function prevent_admin_audit_log_changes();
+
+create trigger trigger_admin_audit_log_no_truncate
+before truncate on admin_audit_log
+for each statement
+execute function prevent_admin_audit_log_changes();
+
+drop function if exists block_content(jsonb);
+drop function if exists unblock_content(uuid);
+
+---- create above / drop below ----
+
+drop trigger if exists trigger_admin_audit_log_no_truncate on admin_audit_log;
+drop trigger if exists trigger_admin_audit_log_append_only on admin_audit_log;
+drop function if exists prevent_admin_audit_log_changes;
+drop index if exists admin_audit_log_entry_number_idx;
+drop function if exists get_admin_audit_entry_hash;
+alter table admin_audit_log drop column hash;
+alter table admin_audit_log drop column previous_hash;
+alter table admin_audit_log drop column entry_number;
+alter table admin_audit_log drop column details;
+update admin_audit_log set user_id = null where user_id not in (select user_id from "user");
+alter table admin_audit_log add foreign key (user_id) references "user" on delete set null;
+drop function if exists block_content(jsonb, jsonb);
+drop function if exists unblock_content(uuid, jsonb);
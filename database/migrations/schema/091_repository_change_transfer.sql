@@ -0,0 +1,16 @@
+alter table repository_change drop constraint repository_change_kind_check;
+alter table repository_change add constraint repository_change_kind_check
+    check (kind in ('add', 'update', 'delete', 'transfer'));
+alter table repository_change add column transfer_organization_id uuid references organization on delete cascade;
+alter table repository_change add column ownership_claim_method text;
+
+create index repository_change_transfer_organization_id_idx on repository_change (transfer_organization_id);
+
+---- create above / drop below ----
+
+delete from repository_change where kind = 'transfer';
+alter table repository_change drop column if exists ownership_claim_method;
+alter table repository_change drop column if exists transfer_organization_id;
+alter table repository_change drop constraint repository_change_kind_check;
+alter table repository_change add constraint repository_change_kind_check
+    check (kind in ('add', 'update', 'delete'));
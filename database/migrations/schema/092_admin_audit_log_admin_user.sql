@@ -0,0 +1,44 @@
+alter table admin_audit_log add column admin_user_id uuid;
+create index admin_audit_log_admin_user_id_idx on admin_audit_log (admin_user_id);
+
+-- The admin user is only included in the hash when available, so that the
+-- hashes of the entries registered before it was recorded remain valid.
+create or replace function get_admin_audit_entry_hash(p_entry admin_audit_log)
+returns text as $$
+    select encode(digest(
+        coalesce(p_entry.previous_hash, '') || (jsonb_build_object(
+            'admin_audit_log_id', p_entry.admin_audit_log_id,
+            'action', p_entry.action,
+            'user_id', p_entry.user_id,
+            'reason', p_entry.reason,
+            'ip', p_entry.ip::text,
+            'user_agent', p_entry.user_agent,
+            'details', p_entry.details,
+            'created_at', to_char(p_entry.created_at at time zone 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')
+        ) || case when p_entry.admin_user_id is not null then
+            jsonb_build_object('admin_user_id', p_entry.admin_user_id)
+        else '{}'::jsonb end)::text,
+        'sha256'
+    ), 'hex');
+$$ language sql stable;
+
+---- create above / drop below ----
+
+create or replace function get_admin_audit_entry_hash(p_entry admin_audit_log)
+returns text as $$
+    select encode(digest(
+        coalesce(p_entry.previous_hash, '') || jsonb_build_object(
+            'admin_audit_log_id', p_entry.admin_audit_log_id,
+            'action', p_entry.action,
+            'user_id', p_entry.user_id,
+            'reason', p_entry.reason,
+            'ip', p_entry.ip::text,
+            'user_agent', p_entry.user_agent,
+            'details', p_entry.details,
+            'created_at', to_char(p_entry.created_at at time zone 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')
+        )::text,
+        'sha256'
+    ), 'hex');
+$$ language sql stable;
+drop index if exists admin_audit_log_admin_user_id_idx;
+alter table admin_audit_log drop column admin_user_id;
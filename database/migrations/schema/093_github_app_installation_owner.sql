@@ -0,0 +1,11 @@
+alter table github_app_installation add column user_id uuid references "user" on delete cascade;
+alter table github_app_installation add column organization_id uuid references organization on delete cascade;
+alter table github_app_installation add constraint github_app_installation_single_owner_chk
+    check (user_id is null or organization_id is null);
+create index github_app_installation_user_id_idx on github_app_installation (user_id);
+create index github_app_installation_organization_id_idx on github_app_installation (organization_id);
+
+---- create above / drop below ----
+
+alter table github_app_installation drop column organization_id;
+alter table github_app_installation drop column user_id;
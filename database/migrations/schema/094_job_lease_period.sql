@@ -0,0 +1,10 @@
+drop function if exists acquire_job_lease(text, text, int);
+delete from job_lease;
+alter table job_lease drop column expires_at;
+alter table job_lease add column period bigint not null;
+
+---- create above / drop below ----
+
+delete from job_lease;
+alter table job_lease drop column period;
+alter table job_lease add column expires_at timestamptz not null;
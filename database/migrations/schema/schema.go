@@ -0,0 +1,10 @@
+// Package schema provides access to the database schema migrations, so that
+// the hub can check if all of them have been applied.
+package schema
+
+import "embed"
+
+// Migrations contains the database schema migrations files.
+//
+//go:embed *.sql
+var Migrations embed.FS
@@ -14,6 +14,7 @@ select add_api_key('
 {
     "name": "apikey1",
     "secret": "hashed-secret",
+    "allowed_cidrs": ["10.0.0.0/8", "192.168.1.1/32"],
     "user_id": "00000000-0000-0000-0000-000000000001"
 }
 '::jsonb);
@@ -24,6 +25,7 @@ select results_eq(
         select
             name,
             secret,
+            allowed_cidrs,
             user_id
         from api_key
     $$,
@@ -31,6 +33,7 @@ select results_eq(
         values (
             'apikey1',
             'hashed-secret',
+            '{10.0.0.0/8, 192.168.1.1/32}'::cidr[],
             '00000000-0000-0000-0000-000000000001'::uuid
         )
     $$,
@@ -0,0 +1,67 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set apikey1ID '00000000-0000-0000-0000-000000000001'
+\set apikey2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into api_key (api_key_id, name, secret, last_used_at, user_id)
+values (:'apikey1ID', 'apikey1', 'hashedSecret', '2020-06-01 10:00:00+02', :'user1ID');
+insert into api_key (api_key_id, name, secret, user_id)
+values (:'apikey2ID', 'apikey2', 'hashedSecret', :'user1ID');
+insert into api_key_usage (api_key_id, day, requests, errors)
+values (:'apikey1ID', current_date - '60 days'::interval, 100, 50);
+insert into api_key_usage (api_key_id, day, requests, errors)
+values (:'apikey1ID', current_date - '1 day'::interval, 10, 2);
+insert into api_key_usage (api_key_id, day, requests, errors)
+values (:'apikey1ID', current_date, 5, 1);
+
+-- Run some tests
+select is(
+    get_api_key_usage(:'user1ID', :'apikey1ID')::jsonb,
+    jsonb_build_object(
+        'api_key_id', '00000000-0000-0000-0000-000000000001',
+        'last_used_at', 1590998400,
+        'requests', 15,
+        'errors', 3,
+        'daily', jsonb_build_array(
+            jsonb_build_object(
+                'day', current_date - 1,
+                'requests', 10,
+                'errors', 2
+            ),
+            jsonb_build_object(
+                'day', current_date,
+                'requests', 5,
+                'errors', 1
+            )
+        )
+    ),
+    'Usage of the last 30 days should be returned'
+);
+select is(
+    get_api_key_usage(:'user1ID', :'apikey2ID')::jsonb,
+    '{
+        "api_key_id": "00000000-0000-0000-0000-000000000002",
+        "requests": 0,
+        "errors": 0,
+        "daily": []
+    }'::jsonb,
+    'Api key never used should return empty usage'
+);
+select is_empty(
+    $$ select get_api_key_usage('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001') $$,
+    'Usage of api keys owned by other users should not be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -17,6 +17,7 @@ select update_api_key('
 {
     "api_key_id": "00000000-0000-0000-0000-000000000001",
     "name": "apikey1-updated",
+    "allowed_cidrs": ["10.0.0.0/8"],
     "user_id": "00000000-0000-0000-0000-000000000001"
 }
 '::jsonb);
@@ -24,12 +25,12 @@ select update_api_key('
 -- Check if api key was updated successfully
 select results_eq(
     $$
-        select name from api_key where api_key_id = '00000000-0000-0000-0000-000000000001'
+        select name, allowed_cidrs from api_key where api_key_id = '00000000-0000-0000-0000-000000000001'
     $$,
     $$
-        values ('apikey1-updated')
+        values ('apikey1-updated', '{10.0.0.0/8}'::cidr[])
     $$,
-    'Api key name should have been updated'
+    'Api key name and allowed cidrs should have been updated'
 );
 
 -- Finish tests and rollback transaction
@@ -0,0 +1,65 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set lockKey 5
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set apikey1ID '00000000-0000-0000-0000-000000000001'
+\set apikey2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into api_key (api_key_id, name, secret, user_id)
+values (:'apikey1ID', 'apikey1', 'hashedSecret', :'user1ID');
+insert into api_key (api_key_id, name, secret, user_id)
+values (:'apikey2ID', 'apikey2', 'hashedSecret', :'user1ID');
+
+-- Run some tests
+select update_api_keys_usage(:lockKey, '[
+    ["00000000-0000-0000-0000-000000000001", "2021-12-3", 10, 1, 1638525600]
+]');
+select results_eq(
+    'select * from api_key_usage',
+    $$ values
+        ('00000000-0000-0000-0000-000000000001'::uuid, '2021-12-3'::date, 10, 1)
+    $$,
+    'First run: one insert'
+);
+select update_api_keys_usage(:lockKey, '[
+    ["00000000-0000-0000-0000-000000000001", "2021-12-3", 10, 2, 1638529200]
+]');
+select results_eq(
+    'select * from api_key_usage',
+    $$ values
+        ('00000000-0000-0000-0000-000000000001'::uuid, '2021-12-3'::date, 20, 3)
+    $$,
+    'Second run: one update'
+);
+select update_api_keys_usage(:lockKey, '[
+    ["00000000-0000-0000-0000-000000000001", "2021-12-5", 5, 0, 1638698400],
+    ["00000000-0000-0000-0000-000000000002", "2021-12-5", 3, 3, 1638702000],
+    ["00000000-0000-0000-0000-000000000003", "2021-12-5", 1, 0, 1638702000]
+]');
+select results_eq(
+    'select * from api_key_usage order by api_key_id, day',
+    $$ values
+        ('00000000-0000-0000-0000-000000000001'::uuid, '2021-12-3'::date, 20, 3),
+        ('00000000-0000-0000-0000-000000000001'::uuid, '2021-12-5'::date, 5, 0),
+        ('00000000-0000-0000-0000-000000000002'::uuid, '2021-12-5'::date, 3, 3)
+    $$,
+    'Third run: two inserts, usage of unknown api keys ignored'
+);
+select results_eq(
+    'select api_key_id, floor(extract(epoch from last_used_at))::bigint from api_key order by api_key_id',
+    $$ values
+        ('00000000-0000-0000-0000-000000000001'::uuid, 1638698400::bigint),
+        ('00000000-0000-0000-0000-000000000002'::uuid, 1638702000::bigint)
+    $$,
+    'Api keys last time used should have been updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
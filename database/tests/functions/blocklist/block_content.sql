@@ -0,0 +1,71 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select block_content('{"repository_name": "repo2", "reason": "spam"}', '{}') $$,
+    'P0001',
+    'content not found',
+    'Blocking a repository that does not exist should fail'
+);
+select throws_ok(
+    $$ select block_content('{"repository_name": "repo1", "package_name": "package-2", "reason": "spam"}', '{}') $$,
+    'P0001',
+    'content not found',
+    'Blocking a package that does not exist should fail'
+);
+select block_content(
+    '{"repository_name": "repo1", "package_name": "package-1", "reason": "copyright"}',
+    '{"ip": "192.168.1.100", "user_agent": "Safari 13.0.5"}'
+);
+select results_eq(
+    $$ select repository_id, package_id, reason from blocked_content $$,
+    $$ values (null::uuid, '00000000-0000-0000-0000-000000000001'::uuid, 'copyright') $$,
+    'Package should have been blocked'
+);
+select block_content('{"repository_name": "repo1", "reason": "spam"}', '{}');
+select results_eq(
+    $$
+        select repository_id, package_id, reason
+        from blocked_content
+        where repository_id is not null
+    $$,
+    $$ values ('00000000-0000-0000-0000-000000000001'::uuid, null::uuid, 'spam') $$,
+    'Repository should have been blocked'
+);
+select is(
+    get_blocked_reason(:'repo1ID', :'package1ID'),
+    'spam',
+    'Repository block reason should take precedence'
+);
+select results_eq(
+    $$
+        select action, reason, ip, user_agent, details->>'repository_name', details->>'package_name'
+        from admin_audit_log
+        order by entry_number asc
+    $$,
+    $$
+        values
+            ('block_content', 'copyright', '192.168.1.100'::inet, 'Safari 13.0.5', 'repo1', 'package-1'),
+            ('block_content', 'spam', null, null, 'repo1', null)
+    $$,
+    'Blocking operations should have been recorded in the admin audit log'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,57 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set blockedContent1ID '00000000-0000-0000-0000-000000000001'
+\set blockedContent2ID '00000000-0000-0000-0000-000000000002'
+
+-- No blocked content at this point
+select is(
+    get_blocked_content()::jsonb,
+    '[]'::jsonb,
+    'No blocked content should be returned'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into blocked_content (blocked_content_id, package_id, reason, created_at)
+values (:'blockedContent1ID', :'package1ID', 'copyright', '2021-01-01 00:00:00+00');
+insert into blocked_content (blocked_content_id, repository_id, reason, created_at)
+values (:'blockedContent2ID', :'repo2ID', 'spam', '2021-01-02 00:00:00+00');
+
+-- Run some tests
+select is(
+    get_blocked_content()::jsonb,
+    '[
+        {
+            "blocked_content_id": "00000000-0000-0000-0000-000000000002",
+            "repository_name": "repo2",
+            "reason": "spam",
+            "created_at": 1609545600
+        },
+        {
+            "blocked_content_id": "00000000-0000-0000-0000-000000000001",
+            "repository_name": "repo1",
+            "package_name": "package-1",
+            "reason": "copyright",
+            "created_at": 1609459200
+        }
+    ]'::jsonb,
+    'Blocked content should be returned, newest first'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
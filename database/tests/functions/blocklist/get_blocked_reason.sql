@@ -0,0 +1,42 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into blocked_content (package_id, reason)
+values (:'package1ID', 'copyright');
+
+-- Run some tests
+select is(
+    get_blocked_reason(:'repo1ID', :'package1ID'),
+    'copyright',
+    'Blocked package reason should be returned'
+);
+select is(
+    get_blocked_reason(:'repo1ID', :'package2ID'),
+    null,
+    'Null should be returned for packages not blocked'
+);
+select is(
+    get_blocked_reason(:'repo1ID', null),
+    null,
+    'Null should be returned for repositories not blocked'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,38 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set blockedContent1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into blocked_content (blocked_content_id, repository_id, reason)
+values (:'blockedContent1ID', :'repo1ID', 'spam');
+
+-- Run some tests
+select throws_ok(
+    $$ select unblock_content('00000000-0000-0000-0000-000000000002', '{}') $$,
+    'P0001',
+    'content not found',
+    'Unblocking content that does not exist should fail'
+);
+select unblock_content(:'blockedContent1ID', '{"reason": "appeal accepted"}');
+select is_empty(
+    $$ select * from blocked_content $$,
+    'Repository should have been unblocked'
+);
+select results_eq(
+    $$ select action, reason, details->>'repository_name' from admin_audit_log $$,
+    $$ values ('unblock_content', 'appeal accepted', 'repo1') $$,
+    'Unblocking operation should have been recorded in the admin audit log'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
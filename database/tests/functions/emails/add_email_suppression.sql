@@ -0,0 +1,40 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Add email to the suppression list
+select add_email_suppression('User1@Email.com', 'bounce', 'ses');
+select results_eq(
+    $$
+        select email, reason, provider
+        from email_suppression
+    $$,
+    $$
+        values ('user1@email.com', 'bounce', 'ses')
+    $$,
+    'Email should have been added to the suppression list'
+);
+
+-- Add the same email again with a different reason
+select add_email_suppression('user1@email.com', 'complaint', 'sendgrid');
+select results_eq(
+    $$
+        select email, reason, provider
+        from email_suppression
+    $$,
+    $$
+        values ('user1@email.com', 'complaint', 'sendgrid')
+    $$,
+    'Existing suppression entry should have been updated'
+);
+
+-- Try adding an entry with an invalid reason
+select throws_ok(
+    $$ select add_email_suppression('user2@email.com', 'invalid', 'ses') $$,
+    23514,
+    'new row for relation "email_suppression" violates check constraint "email_suppression_reason_check"'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,28 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Seed some data
+insert into email_suppression (email, reason, provider)
+values ('user1@email.com', 'bounce', 'mailgun');
+
+-- Run some tests
+select is(
+    is_email_suppressed('user1@email.com'),
+    true,
+    'Email in suppression list'
+);
+select is(
+    is_email_suppressed('USER1@email.com'),
+    true,
+    'Email in suppression list (case insensitive)'
+);
+select is(
+    is_email_suppressed('user2@email.com'),
+    false,
+    'Email not in suppression list'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,37 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+select partman.create_partition_time('public.event', array['2020-01-01'::timestamptz]);
+select partman.create_partition_time('public.notification', array['2020-01-01'::timestamptz]);
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into event (event_id, created_at, repository_id, event_kind_id)
+values (:'event1ID', '2020-01-15 10:00:00+00', :'repo1ID', 2);
+insert into notification (event_id, event_created_at, user_id)
+values (:'event1ID', '2020-01-15 10:00:00+00', :'user1ID');
+
+-- Run some tests
+select lives_ok(
+    $$ select drop_events_partition('2020-01-01') $$,
+    'Partitions should be dropped without errors'
+);
+select is_empty(
+    $$ select * from get_events_partitions() where start_date = '2020-01-01' $$,
+    'Partitions starting on 2020-01-01 should not exist anymore'
+);
+select is_empty(
+    $$ select * from event where event_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Event should not exist anymore'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
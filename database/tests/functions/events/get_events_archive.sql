@@ -0,0 +1,46 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+
+-- Partition not found
+select ok(
+    get_events_archive('2020-01-01') is null,
+    'Null should be returned when the partition does not exist'
+);
+
+-- Seed some data
+select partman.create_partition_time('public.event', array['2020-01-01'::timestamptz]);
+select partman.create_partition_time('public.notification', array['2020-01-01'::timestamptz]);
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into event (event_id, created_at, repository_id, event_kind_id)
+values (:'event1ID', '2020-01-15 10:00:00+00', :'repo1ID', 2);
+insert into notification (notification_id, created_at, event_id, event_created_at, user_id)
+values (:'notification1ID', '2020-01-15 10:01:00+00', :'event1ID', '2020-01-15 10:00:00+00', :'user1ID');
+
+-- Run some tests
+select is(
+    (
+        select jsonb_build_object(
+            'events', jsonb_path_query_array(a, '$.events[*].event_id'),
+            'notifications', jsonb_path_query_array(a, '$.notifications[*].notification_id')
+        )
+        from (select get_events_archive('2020-01-01')::jsonb as a) archive
+    ),
+    '{
+        "events": ["00000000-0000-0000-0000-000000000001"],
+        "notifications": ["00000000-0000-0000-0000-000000000001"]
+    }'::jsonb,
+    'Events and notifications in the partition should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
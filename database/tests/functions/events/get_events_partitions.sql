@@ -0,0 +1,31 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Create some partitions for a past period
+select partman.create_partition_time('public.event', array['2020-01-01'::timestamptz]);
+select partman.create_partition_time('public.notification', array['2020-01-01'::timestamptz]);
+
+-- Run some tests
+select results_eq(
+    $$
+        select parent_table, start_date, end_date
+        from get_events_partitions()
+        where start_date = '2020-01-01'
+        order by parent_table
+    $$,
+    $$
+        values
+            ('public.event', '2020-01-01'::date, '2020-02-01'::date),
+            ('public.notification', '2020-01-01'::date, '2020-02-01'::date)
+    $$,
+    'Events and notifications partitions should be returned'
+);
+select ok(
+    (select count(*) from get_events_partitions() where start_date = date_trunc('month', current_date)::date) = 2,
+    'Partitions for the current month should exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
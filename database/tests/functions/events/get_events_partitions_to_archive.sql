@@ -0,0 +1,23 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- No partitions to archive yet
+select is(
+    get_events_partitions_to_archive(90)::jsonb,
+    '[]'::jsonb,
+    'No partitions should be returned'
+);
+
+-- Create some partitions for a past period and check they are returned
+select partman.create_partition_time('public.event', array['2020-01-01'::timestamptz]);
+select partman.create_partition_time('public.notification', array['2020-01-01'::timestamptz]);
+select is(
+    get_events_partitions_to_archive(90)::jsonb,
+    '["2020-01-01"]'::jsonb,
+    'Partition starting on 2020-01-01 should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
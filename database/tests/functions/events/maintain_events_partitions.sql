@@ -0,0 +1,13 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Run some tests
+select lives_ok(
+    $$ select maintain_events_partitions() $$,
+    'Events partitions should be maintained without errors'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,57 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into github_app_installation (installation_id, account) values (1, 'ghorg1');
+insert into github_app_installation (installation_id, account) values (2, 'ghuser1');
+
+-- Run some tests
+select throws_ok(
+    $$ select link_github_app_installation(1, '00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'Link should fail because requesting user does not belong to the organization'
+);
+select throws_ok(
+    $$ select link_github_app_installation(3, '00000000-0000-0000-0000-000000000001', null) $$,
+    'installation not found or already linked',
+    'Link should fail because the installation does not exist'
+);
+select link_github_app_installation(1, :'user1ID', 'org1');
+select link_github_app_installation(2, :'user1ID', null);
+select results_eq(
+    $$
+        select installation_id, user_id, organization_id
+        from github_app_installation
+        order by installation_id
+    $$,
+    $$ values
+        (1::bigint, null::uuid, '00000000-0000-0000-0000-000000000001'::uuid),
+        (2::bigint, '00000000-0000-0000-0000-000000000001'::uuid, null::uuid)
+    $$,
+    'Installations should have been linked to the organization and the user'
+);
+select throws_ok(
+    $$ select link_github_app_installation(2, '00000000-0000-0000-0000-000000000002', null) $$,
+    'installation not found or already linked',
+    'Link should fail because the installation has already been linked'
+);
+select results_eq(
+    'select user_id from github_app_installation where installation_id = 2',
+    $$ values ('00000000-0000-0000-0000-000000000001'::uuid) $$,
+    'Installation owner should not have changed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
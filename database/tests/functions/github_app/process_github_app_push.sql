@@ -0,0 +1,79 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+\set repo4ID '00000000-0000-0000-0000-000000000004'
+\set repo5ID '00000000-0000-0000-0000-000000000005'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into github_app_installation (installation_id, account, user_id)
+values (1, 'org1', :'user1ID');
+insert into github_app_installation (installation_id, account)
+values (3, 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://github.com/org1/repo1', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://github.com/org1/repo1/path/to/pkgs', 1, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://github.com/org1/repo10', 1, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo4ID', 'repo4', 'Repo 4', 'https://github.com/org2/repo1', 1, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo5ID', 'repo5', 'Repo 5', 'https://github.com/org1/repo1', 1, :'user2ID');
+
+-- Run some tests
+select is(
+    process_github_app_push(2, 'https://github.com/org1/repo1'),
+    0,
+    'Push from unknown installation should not update any repository'
+);
+select is(
+    process_github_app_push(3, 'https://github.com/org1/repo1'),
+    0,
+    'Push from installation not linked to any owner should not update any repository'
+);
+select is(
+    process_github_app_push(1, 'https://github.com/org2/repo1'),
+    0,
+    'Push of repository owned by another account should not update any repository'
+);
+select is(
+    process_github_app_push(1, 'https://github.com/org1/repo1'),
+    2,
+    'Two repositories should have been updated'
+);
+select results_eq(
+    $$
+        select repository_id, github_app_installation_id, verified_publisher, tracking_requested_at is not null
+        from repository
+        order by repository_id
+    $$,
+    $$ values
+        ('00000000-0000-0000-0000-000000000001'::uuid, 1::bigint, true, true),
+        ('00000000-0000-0000-0000-000000000002'::uuid, 1::bigint, true, true),
+        ('00000000-0000-0000-0000-000000000003'::uuid, null::bigint, false, false),
+        ('00000000-0000-0000-0000-000000000004'::uuid, null::bigint, false, false),
+        ('00000000-0000-0000-0000-000000000005'::uuid, null::bigint, false, false)
+    $$,
+    'Only repositories located in the pushed GitHub repository and owned by the installation owner should have been updated'
+);
+select set_verified_publisher(:'repo1ID', false);
+select is(
+    verified_publisher,
+    true,
+    'Repositories linked to a GitHub App installation should remain verified'
+) from repository where repository_id = :'repo1ID';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,23 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Register installation and run some tests
+select register_github_app_installation(1, 'org1');
+select results_eq(
+    'select installation_id, account from github_app_installation',
+    $$ values (1::bigint, 'org1') $$,
+    'Installation should have been registered'
+);
+
+-- Register same installation again and run some tests
+select register_github_app_installation(1, 'org1-renamed');
+select results_eq(
+    'select installation_id, account from github_app_installation',
+    $$ values (1::bigint, 'org1-renamed') $$,
+    'Installation account should have been updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
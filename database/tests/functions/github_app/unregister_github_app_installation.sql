@@ -0,0 +1,31 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into github_app_installation (installation_id, account)
+values (1, 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, github_app_installation_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://github.com/org1/repo1', 0, :'user1ID', 1);
+
+-- Unregister installation and run some tests
+select unregister_github_app_installation(1);
+select is_empty(
+    'select * from github_app_installation',
+    'Installation should have been unregistered'
+);
+select is(
+    github_app_installation_id,
+    null,
+    'Repository should not be linked to the installation anymore'
+) from repository where repository_id = :'repo1ID';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
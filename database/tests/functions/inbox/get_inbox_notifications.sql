@@ -0,0 +1,95 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set notification2ID '00000000-0000-0000-0000-000000000002'
+\set notification3ID '00000000-0000-0000-0000-000000000003'
+
+-- No notifications at this point
+select is(
+    data::jsonb,
+    '[]'::jsonb,
+    'No notifications in inbox'
+) from get_inbox_notifications(:'user1ID', false, 0, 0);
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into inbox_notification (inbox_notification_id, user_id, kind, data, read, created_at)
+values (:'notification1ID', :'user1ID', 'event', '{"event_kind": 0}', true, '2020-06-16 11:20:34+02');
+insert into inbox_notification (inbox_notification_id, user_id, kind, data, created_at)
+values (:'notification2ID', :'user1ID', 'organization-invitation', '{"organization_name": "org1"}', '2020-06-17 11:20:34+02');
+insert into inbox_notification (inbox_notification_id, user_id, kind, created_at)
+values (:'notification3ID', :'user2ID', 'event', '2020-06-18 11:20:34+02');
+
+-- Run some tests
+select is(
+    data::jsonb,
+    '[
+        {
+            "inbox_notification_id": "00000000-0000-0000-0000-000000000002",
+            "kind": "organization-invitation",
+            "data": {"organization_name": "org1"},
+            "read": false,
+            "created_at": 1592385634
+        },
+        {
+            "inbox_notification_id": "00000000-0000-0000-0000-000000000001",
+            "kind": "event",
+            "data": {"event_kind": 0},
+            "read": true,
+            "created_at": 1592299234
+        }
+    ]'::jsonb,
+    'Only notifications in user1 inbox should be returned, most recent first'
+) from get_inbox_notifications(:'user1ID', false, 0, 0);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_inbox_notifications('00000000-0000-0000-0000-000000000001', false, 1, 1)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "inbox_notification_id": "00000000-0000-0000-0000-000000000001",
+                    "kind": "event",
+                    "data": {"event_kind": 0},
+                    "read": true,
+                    "created_at": 1592299234
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Notifications should be paginated'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_inbox_notifications('00000000-0000-0000-0000-000000000001', true, 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "inbox_notification_id": "00000000-0000-0000-0000-000000000002",
+                    "kind": "organization-invitation",
+                    "data": {"organization_name": "org1"},
+                    "read": false,
+                    "created_at": 1592385634
+                }
+            ]'::jsonb,
+            1
+        )
+    $$,
+    'Only unread notifications should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
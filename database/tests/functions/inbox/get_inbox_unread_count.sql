@@ -0,0 +1,30 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into inbox_notification (user_id, kind, read) values (:'user1ID', 'event', true);
+insert into inbox_notification (user_id, kind) values (:'user1ID', 'event');
+insert into inbox_notification (user_id, kind) values (:'user1ID', 'organization-invitation');
+
+-- Run some tests
+select is(
+    get_inbox_unread_count(:'user1ID')::jsonb,
+    '{"count": 2}'::jsonb,
+    'User1 should have 2 unread notifications'
+);
+select is(
+    get_inbox_unread_count(:'user2ID')::jsonb,
+    '{"count": 0}'::jsonb,
+    'User2 should not have unread notifications'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
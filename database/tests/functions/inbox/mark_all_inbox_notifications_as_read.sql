@@ -0,0 +1,31 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into inbox_notification (user_id, kind) values (:'user1ID', 'event');
+insert into inbox_notification (user_id, kind) values (:'user1ID', 'organization-invitation');
+insert into inbox_notification (user_id, kind) values (:'user2ID', 'event');
+
+-- Run some tests
+select mark_all_inbox_notifications_as_read(:'user1ID');
+select results_eq(
+    $$ select user_id, read from inbox_notification order by user_id $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, true),
+            ('00000000-0000-0000-0000-000000000001'::uuid, true),
+            ('00000000-0000-0000-0000-000000000002'::uuid, false)
+    $$,
+    'Only notifications in user1 inbox should have been marked as read'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
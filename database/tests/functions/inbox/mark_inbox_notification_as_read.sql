@@ -0,0 +1,35 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set notification2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into inbox_notification (inbox_notification_id, user_id, kind)
+values (:'notification1ID', :'user1ID', 'event');
+insert into inbox_notification (inbox_notification_id, user_id, kind)
+values (:'notification2ID', :'user1ID', 'event');
+
+-- Run some tests
+select mark_inbox_notification_as_read(:'user2ID', :'notification1ID');
+select results_eq(
+    $$ select read from inbox_notification order by inbox_notification_id $$,
+    $$ values (false), (false) $$,
+    'User2 should not be able to mark notifications in user1 inbox as read'
+);
+select mark_inbox_notification_as_read(:'user1ID', :'notification1ID');
+select results_eq(
+    $$ select read, read_at is not null from inbox_notification order by inbox_notification_id $$,
+    $$ values (true, true), (false, false) $$,
+    'Only notification1 should have been marked as read'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,61 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+
+-- Run some tests
+select throws_ok(
+    $$
+        select add_issue_tracker('00000000-0000-0000-0000-000000000002', 'org1', '{
+            "kind": "github",
+            "project": "org1/repo1",
+            "token": "token1",
+            "active": true
+        }')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Issue tracker add should fail because requesting user does not belong to the organization'
+);
+select add_issue_tracker(:'user1ID', 'org1', '{
+    "kind": "jira",
+    "url": "https://org1.atlassian.net",
+    "project": "SEC",
+    "labels": ["security", "artifacthub"],
+    "username": "user1@email.com",
+    "token": "token1",
+    "active": true
+}');
+select results_eq(
+    $$
+        select organization_id, kind, url, project, labels, username, token, active
+        from issue_tracker
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'jira',
+            'https://org1.atlassian.net',
+            'SEC',
+            '{security,artifacthub}'::text[],
+            'user1@email.com',
+            'token1',
+            true
+        )
+    $$,
+    'Issue tracker should have been added to the organization'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
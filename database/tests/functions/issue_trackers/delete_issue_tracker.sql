@@ -0,0 +1,41 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into issue_tracker (issue_tracker_id, organization_id, kind, project, token)
+values (:'issueTracker1ID', :'org1ID', 'github', 'org1/repo1', 'token1');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select delete_issue_tracker(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Issue tracker delete should fail because requesting user does not belong to owning organization'
+);
+select delete_issue_tracker(:'user1ID', :'issueTracker1ID');
+select is_empty(
+    $$
+        select * from issue_tracker
+    $$,
+    'Issue tracker should have been deleted by user who belongs to owning organization'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,116 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, security_report)
+values (:'package1ID', '1.0.0', '{
+    "quay.io/org/img1:1.0.0": {
+        "Results": [
+            {
+                "Target": "target1",
+                "Vulnerabilities": [
+                    {
+                        "VulnerabilityID": "CVE-2022-0001",
+                        "PkgName": "openssl",
+                        "InstalledVersion": "1.1.1",
+                        "FixedVersion": "1.1.2",
+                        "Title": "openssl issue",
+                        "PrimaryURL": "https://avd.aquasec.com/nvd/cve-2022-0001",
+                        "Severity": "CRITICAL"
+                    },
+                    {
+                        "VulnerabilityID": "CVE-2022-0002",
+                        "PkgName": "zlib",
+                        "InstalledVersion": "1.2.11",
+                        "Severity": "CRITICAL"
+                    },
+                    {
+                        "VulnerabilityID": "CVE-2022-0003",
+                        "PkgName": "curl",
+                        "InstalledVersion": "7.0.0",
+                        "Severity": "HIGH"
+                    }
+                ]
+            },
+            {
+                "Target": "target2"
+            }
+        ]
+    },
+    "quay.io/org/img2:1.0.0": {
+        "Results": [
+            {
+                "Target": "target1",
+                "Vulnerabilities": [
+                    {
+                        "VulnerabilityID": "CVE-2022-0001",
+                        "PkgName": "openssl",
+                        "InstalledVersion": "1.1.1",
+                        "FixedVersion": "1.1.2",
+                        "Title": "openssl issue",
+                        "PrimaryURL": "https://avd.aquasec.com/nvd/cve-2022-0001",
+                        "Severity": "CRITICAL"
+                    }
+                ]
+            }
+        ]
+    },
+    "quay.io/org/img3:1.0.0": {
+        "Results": null
+    }
+}');
+insert into issue_tracker (issue_tracker_id, organization_id, kind, project, token)
+values (:'issueTracker1ID', :'org1ID', 'github', 'org1/repo1', 'token1');
+
+-- Run some tests
+select is(
+    get_issue_tracker_pending_vulnerabilities(:'issueTracker1ID', :'package1ID', '1.0.0')::jsonb,
+    '[
+        {
+            "vulnerability_id": "CVE-2022-0001",
+            "pkg_name": "openssl",
+            "installed_version": "1.1.1",
+            "fixed_version": "1.1.2",
+            "title": "openssl issue",
+            "primary_url": "https://avd.aquasec.com/nvd/cve-2022-0001",
+            "images": ["quay.io/org/img1:1.0.0", "quay.io/org/img2:1.0.0"]
+        },
+        {
+            "vulnerability_id": "CVE-2022-0002",
+            "pkg_name": "zlib",
+            "installed_version": "1.2.11",
+            "images": ["quay.io/org/img1:1.0.0"]
+        }
+    ]'::jsonb,
+    'Critical vulnerabilities without an issue should be returned'
+);
+insert into issue_tracker_issue (issue_tracker_id, package_id, vulnerability_id, url)
+values (:'issueTracker1ID', :'package1ID', 'CVE-2022-0001', 'https://github.com/org1/repo1/issues/1');
+select is(
+    get_issue_tracker_pending_vulnerabilities(:'issueTracker1ID', :'package1ID', '1.0.0')::jsonb,
+    '[
+        {
+            "vulnerability_id": "CVE-2022-0002",
+            "pkg_name": "zlib",
+            "installed_version": "1.2.11",
+            "images": ["quay.io/org/img1:1.0.0"]
+        }
+    ]'::jsonb,
+    'Vulnerabilities that already have an issue should not be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
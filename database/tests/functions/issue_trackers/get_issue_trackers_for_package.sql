@@ -0,0 +1,50 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, security_report_summary)
+values (:'package1ID', '1.0.0', '{"critical": 2, "high": 1}');
+insert into snapshot (package_id, version, security_report_summary)
+values (:'package1ID', '0.9.0', '{"high": 1}');
+insert into issue_tracker (issue_tracker_id, organization_id, kind, project, labels, token)
+values (:'issueTracker1ID', :'org1ID', 'github', 'org1/repo1', '{"security"}', 'token1');
+insert into issue_tracker (issue_tracker_id, organization_id, kind, project, token, active)
+values (:'issueTracker2ID', :'org1ID', 'github', 'org1/repo2', 'token2', false);
+
+-- Run some tests
+select is(
+    get_issue_trackers_for_package(:'package1ID', '1.0.0')::jsonb,
+    '[
+        {
+            "issue_tracker_id": "00000000-0000-0000-0000-000000000001",
+            "kind": "github",
+            "project": "org1/repo1",
+            "labels": ["security"],
+            "token": "token1",
+            "active": true
+        }
+    ]'::jsonb,
+    'Active issue trackers of the organization owning the package should be returned'
+);
+select is(
+    get_issue_trackers_for_package(:'package1ID', '0.9.0')::jsonb,
+    '[]'::jsonb,
+    'No issue trackers expected as the package version has no critical vulnerabilities'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,60 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+
+-- Run some tests
+select is(
+    get_org_issue_trackers(:'user1ID', 'org1')::jsonb,
+    '[]'::jsonb,
+    'No issue trackers expected'
+);
+insert into issue_tracker (issue_tracker_id, organization_id, kind, url, project, labels, username, token)
+values (:'issueTracker1ID', :'org1ID', 'jira', 'https://org1.atlassian.net', 'SEC', '{"security"}', 'user1@email.com', 'token1');
+insert into issue_tracker (issue_tracker_id, organization_id, kind, project, token, active)
+values (:'issueTracker2ID', :'org1ID', 'github', 'org1/repo1', 'token2', false);
+select is(
+    get_org_issue_trackers(:'user1ID', 'org1')::jsonb,
+    '[
+        {
+            "issue_tracker_id": "00000000-0000-0000-0000-000000000002",
+            "kind": "github",
+            "project": "org1/repo1",
+            "active": false
+        },
+        {
+            "issue_tracker_id": "00000000-0000-0000-0000-000000000001",
+            "kind": "jira",
+            "url": "https://org1.atlassian.net",
+            "project": "SEC",
+            "labels": ["security"],
+            "username": "user1@email.com",
+            "active": true
+        }
+    ]'::jsonb,
+    'Issue trackers of org1 should be returned without their tokens'
+);
+select throws_ok(
+    $$
+        select get_org_issue_trackers('00000000-0000-0000-0000-000000000002', 'org1')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Get should fail because requesting user does not belong to the organization'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
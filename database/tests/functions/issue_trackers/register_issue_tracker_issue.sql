@@ -0,0 +1,41 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into issue_tracker (issue_tracker_id, organization_id, kind, project, token)
+values (:'issueTracker1ID', :'org1ID', 'github', 'org1/repo1', 'token1');
+
+-- Run some tests
+select register_issue_tracker_issue(:'issueTracker1ID', :'package1ID', 'CVE-2022-0001', 'https://github.com/org1/repo1/issues/1');
+select register_issue_tracker_issue(:'issueTracker1ID', :'package1ID', 'CVE-2022-0001', 'https://github.com/org1/repo1/issues/2');
+select results_eq(
+    $$
+        select issue_tracker_id, package_id, vulnerability_id, url
+        from issue_tracker_issue
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'CVE-2022-0001',
+            'https://github.com/org1/repo1/issues/1'
+        )
+    $$,
+    'Only the first issue registered for a package vulnerability should be kept'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,70 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into issue_tracker (issue_tracker_id, organization_id, kind, project, token)
+values (:'issueTracker1ID', :'org1ID', 'github', 'org1/repo1', 'token1');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select update_issue_tracker('00000000-0000-0000-0000-000000000002', '{
+            "issue_tracker_id": "00000000-0000-0000-0000-000000000001",
+            "kind": "github",
+            "project": "org1/repo2",
+            "active": true
+        }')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Issue tracker update should fail because requesting user does not belong to owning organization'
+);
+select update_issue_tracker(:'user1ID', '{
+    "issue_tracker_id": "00000000-0000-0000-0000-000000000001",
+    "kind": "github",
+    "project": "org1/repo2",
+    "labels": ["security"],
+    "active": false
+}');
+select results_eq(
+    $$
+        select kind, project, labels, token, active
+        from issue_tracker
+    $$,
+    $$
+        values ('github', 'org1/repo2', '{security}'::text[], 'token1', false)
+    $$,
+    'Issue tracker should have been updated keeping the existing token'
+);
+select update_issue_tracker(:'user1ID', '{
+    "issue_tracker_id": "00000000-0000-0000-0000-000000000001",
+    "kind": "github",
+    "project": "org1/repo2",
+    "token": "token2",
+    "active": true
+}');
+select results_eq(
+    $$
+        select project, labels, token, active
+        from issue_tracker
+    $$,
+    $$
+        values ('org1/repo2', null::text[], 'token2', true)
+    $$,
+    'Issue tracker token should have been updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,40 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Run some tests
+select is(
+    acquire_job_lease('job1', 'holder1', 3600),
+    true,
+    'Lease never acquired before should be acquired'
+);
+select is(
+    acquire_job_lease('job1', 'holder2', 3600),
+    false,
+    'Lease acquired by other holder for the current period should not be acquired'
+);
+select is(
+    acquire_job_lease('job1', 'holder1', 3600),
+    false,
+    'Lease should not be acquired again for the current period by the same holder'
+);
+update job_lease set period = period - 1;
+select is(
+    acquire_job_lease('job1', 'holder2', 3600),
+    true,
+    'Lease acquired for a previous period should be acquired by other holder'
+);
+select results_eq(
+    $$ select holder from job_lease where job_name = 'job1' $$,
+    $$ values ('holder2') $$,
+    'Lease holder should have been updated'
+);
+select results_eq(
+    $$ select period from job_lease where job_name = 'job1' $$,
+    $$ values (floor(extract(epoch from current_timestamp) / 3600)::bigint) $$,
+    'Lease period should have been updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,124 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set jobRun1ID '00000000-0000-0000-0000-000000000001'
+\set jobRun2ID '00000000-0000-0000-0000-000000000002'
+\set jobRun3ID '00000000-0000-0000-0000-000000000003'
+
+-- No job runs at this point
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_job_runs('{
+            "limit": 10,
+            "offset": 0
+        }')
+    $$,
+    $$
+        values ('[]'::jsonb, 0)
+    $$,
+    'No job runs should be returned'
+);
+
+-- Seed some data
+insert into job_run (job_run_id, job_name, started_at, duration, attempts, succeeded, error)
+values (:'jobRun1ID', 'job1', '2022-01-01 00:00:00+00', 100, 1, true, null);
+insert into job_run (job_run_id, job_name, started_at, duration, attempts, succeeded, error)
+values (:'jobRun2ID', 'job1', '2022-01-02 00:00:00+00', 200, 3, false, 'fake error');
+insert into job_run (job_run_id, job_name, started_at, duration, attempts, succeeded, error)
+values (:'jobRun3ID', 'job2', '2022-01-03 00:00:00+00', 300, 1, true, null);
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_job_runs('{
+            "limit": 2,
+            "offset": 0
+        }')
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "job_run_id": "00000000-0000-0000-0000-000000000003",
+                    "job_name": "job2",
+                    "started_at": 1641168000,
+                    "duration": 300,
+                    "attempts": 1,
+                    "succeeded": true
+                },
+                {
+                    "job_run_id": "00000000-0000-0000-0000-000000000002",
+                    "job_name": "job1",
+                    "started_at": 1641081600,
+                    "duration": 200,
+                    "attempts": 3,
+                    "succeeded": false,
+                    "error": "fake error"
+                }
+            ]'::jsonb,
+            3
+        )
+    $$,
+    'Most recent job runs should be returned first'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_job_runs('{
+            "job_name": "job1",
+            "limit": 10,
+            "offset": 1
+        }')
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "job_run_id": "00000000-0000-0000-0000-000000000001",
+                    "job_name": "job1",
+                    "started_at": 1640995200,
+                    "duration": 100,
+                    "attempts": 1,
+                    "succeeded": true
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Job runs of job1 should be returned, skipping the first one'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_job_runs('{
+            "only_failed": true,
+            "limit": 10,
+            "offset": 0
+        }')
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "job_run_id": "00000000-0000-0000-0000-000000000002",
+                    "job_name": "job1",
+                    "started_at": 1641081600,
+                    "duration": 200,
+                    "attempts": 3,
+                    "succeeded": false,
+                    "error": "fake error"
+                }
+            ]'::jsonb,
+            1
+        )
+    $$,
+    'Only failed job runs should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,44 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Seed some data
+insert into job_run (job_name, started_at, duration, attempts, succeeded)
+values ('job1', current_timestamp - '31 days'::interval, 100, 1, true);
+
+-- Register job run
+select register_job_run('
+{
+    "job_name": "job1",
+    "started_at": 1592299234,
+    "duration": 150,
+    "attempts": 2,
+    "succeeded": false,
+    "error": "fake error"
+}
+');
+
+-- Run some tests
+select results_eq(
+    $$
+        select job_name, started_at, duration, attempts, succeeded, error
+        from job_run
+    $$,
+    $$
+        values ('job1', to_timestamp(1592299234), 150, 2, false, 'fake error')
+    $$,
+    'Job run should have been registered'
+);
+select is_empty(
+    $$
+        select * from job_run
+        where started_at < current_timestamp - '30 days'::interval
+        and job_name = 'job1'
+        and duration = 100
+    $$,
+    'Old job runs should have been removed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
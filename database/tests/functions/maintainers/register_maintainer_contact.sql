@@ -0,0 +1,47 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set maintainer1ID '00000000-0000-0000-0000-000000000001'
+\set maintainer2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into maintainer (maintainer_id, name, email, verified) values (:'maintainer1ID', 'name1', 'email1', true);
+insert into maintainer (maintainer_id, name, email, verified) values (:'maintainer2ID', 'name2', 'email2', true);
+insert into maintainer_contact (user_id, maintainer_id)
+select :'user2ID', :'maintainer2ID' from generate_series(1, 5);
+
+-- Run some tests
+select is(
+    register_maintainer_contact(:'user1ID', :'maintainer1ID'),
+    true,
+    'Contact should be registered'
+);
+select results_eq(
+    $$
+        select count(*) from maintainer_contact
+        where user_id = '00000000-0000-0000-0000-000000000001'
+        and maintainer_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values (1::bigint) $$,
+    'One contact should have been registered'
+);
+select is(
+    register_maintainer_contact(:'user1ID', :'maintainer1ID'),
+    false,
+    'Maintainer was contacted recently by the user, new request should be rejected'
+);
+select is(
+    register_maintainer_contact(:'user2ID', :'maintainer1ID'),
+    false,
+    'User has reached the daily contacts limit, new request should be rejected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,78 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set maintainer1ID '00000000-0000-0000-0000-000000000001'
+\set maintainer2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo2ID');
+insert into maintainer (maintainer_id, name, email) values (:'maintainer1ID', 'name1', 'email1');
+insert into maintainer (maintainer_id, name, email) values (:'maintainer2ID', 'name2', 'email2');
+insert into package__maintainer (package_id, maintainer_id) values (:'package1ID', :'maintainer1ID');
+insert into package__maintainer (package_id, maintainer_id) values (:'package2ID', :'maintainer2ID');
+
+-- Run some tests
+select is(
+    register_maintainer_verification_code(:'user1ID', :'maintainer1ID', 'code1'),
+    true,
+    'Verification code for maintainer of package owned by user should be registered'
+);
+select results_eq(
+    $$
+        select maintainer_verification_code_id from maintainer_verification_code
+        where maintainer_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values ('code1') $$,
+    'Maintainer verification code should have been registered'
+);
+select is(
+    register_maintainer_verification_code(:'user1ID', :'maintainer1ID', 'code2'),
+    false,
+    'Verification code was registered recently, new request should be rejected'
+);
+select is(
+    register_maintainer_verification_code(:'user1ID', :'maintainer2ID', 'code3'),
+    true,
+    'Verification code for maintainer of package owned by organization user belongs to should be registered'
+);
+select throws_ok(
+    $$
+        select register_maintainer_verification_code('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', 'code4')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User does not own any package maintained by the maintainer, request should be rejected'
+);
+select throws_ok(
+    $$
+        select register_maintainer_verification_code('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003', 'code5')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Maintainer does not exist, request should be rejected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
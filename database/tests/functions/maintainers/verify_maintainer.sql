@@ -0,0 +1,41 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set maintainer1ID '00000000-0000-0000-0000-000000000001'
+\set maintainer2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into maintainer (maintainer_id, name, email) values (:'maintainer1ID', 'name1', 'email1');
+insert into maintainer (maintainer_id, name, email) values (:'maintainer2ID', 'name2', 'email2');
+insert into maintainer_verification_code (maintainer_verification_code_id, maintainer_id)
+values ('code1', :'maintainer1ID');
+insert into maintainer_verification_code (maintainer_verification_code_id, maintainer_id, created_at)
+values ('code2', :'maintainer2ID', current_timestamp - '2 day'::interval);
+
+-- Run some tests
+select is(
+    verify_maintainer('code1'),
+    true,
+    'Maintainer should be verified'
+);
+select results_eq(
+    $$ select verified from maintainer where maintainer_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (true) $$,
+    'Maintainer should be marked as verified'
+);
+select is(
+    verify_maintainer('code2'),
+    false,
+    'Verification code has expired, maintainer should not be verified'
+);
+select is(
+    verify_maintainer('code3'),
+    false,
+    'Verification code does not exist, maintainer should not be verified'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,30 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Maintenance mode is disabled by default
+select is(
+    get_maintenance_mode()::jsonb - 'updated_at',
+    '{
+        "enabled": false,
+        "retry_after": 300
+    }'::jsonb,
+    'Maintenance mode should be disabled by default'
+);
+
+-- Enable maintenance mode
+update maintenance_mode set enabled = true, retry_after = 600;
+
+-- Run some tests
+select is(
+    get_maintenance_mode()::jsonb - 'updated_at',
+    '{
+        "enabled": true,
+        "retry_after": 600
+    }'::jsonb,
+    'Maintenance mode should be enabled'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,40 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Enable maintenance mode
+select set_maintenance_mode('
+{
+    "enabled": true,
+    "retry_after": 600
+}
+');
+select results_eq(
+    $$ select enabled, retry_after from maintenance_mode $$,
+    $$ values (true, 600) $$,
+    'Maintenance mode should be enabled'
+);
+
+-- Disable maintenance mode keeping the current retry after value
+select set_maintenance_mode('
+{
+    "enabled": false
+}
+');
+select results_eq(
+    $$ select enabled, retry_after from maintenance_mode $$,
+    $$ values (false, 600) $$,
+    'Maintenance mode should be disabled and retry after should not change'
+);
+
+-- Invalid retry after value
+select throws_ok(
+    $$ select set_maintenance_mode('{"enabled": true, "retry_after": -1}') $$,
+    '23514',
+    'new row for relation "maintenance_mode" violates check constraint "maintenance_mode_retry_after_check"',
+    'Negative retry after values should not be allowed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
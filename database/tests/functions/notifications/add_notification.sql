@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(3);
+select plan(6);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -8,6 +8,8 @@ select plan(3);
 \set package1ID '00000000-0000-0000-0000-000000000001'
 \set event1ID '00000000-0000-0000-0000-000000000001'
 \set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker1ID '00000000-0000-0000-0000-000000000001'
 
 -- Seed some data
 insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
@@ -38,6 +40,9 @@ insert into webhook (
     true,
     :'user1ID'
 );
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into issue_tracker (issue_tracker_id, organization_id, kind, project, token)
+values (:'issueTracker1ID', :'org1ID', 'github', 'org1/repo1', 'token1');
 
 -- Run some tests
 select add_notification('
@@ -66,6 +71,29 @@ select results_eq(
     $$,
     'Notification for event1 and user1 should exist'
 );
+select results_eq(
+    $$
+        select kind, data, read
+        from inbox_notification
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            'event',
+            '{
+                "event_kind": 0,
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "package_name": "Package 1",
+                "package_normalized_name": "package-1",
+                "package_version": "1.0.0",
+                "repository_name": "repo1",
+                "repository_kind_id": 0
+            }'::jsonb,
+            false
+        )
+    $$,
+    'Notification for event1 should have been added to user1 inbox'
+);
 select add_notification('
 {
     "event": {
@@ -92,6 +120,38 @@ select results_eq(
     $$,
     'Notification for event1 and webhook1 should exist'
 );
+select is(
+    (select count(*) from inbox_notification),
+    1::bigint,
+    'Webhooks notifications should not be added to any inbox'
+);
+select add_notification('
+{
+    "event": {
+        "event_id": "00000000-0000-0000-0000-000000000001"
+    },
+    "issue_tracker": {
+        "issue_tracker_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+'::jsonb);
+select results_eq(
+    $$
+        select event_id, user_id, webhook_id, issue_tracker_id
+        from notification
+        where event_id = '00000000-0000-0000-0000-000000000001'
+        and issue_tracker_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            null::uuid,
+            null::uuid,
+            '00000000-0000-0000-0000-000000000001'::uuid
+        )
+    $$,
+    'Notification for event1 and issueTracker1 should exist'
+);
 select throws_ok(
     $$
         select add_notification('
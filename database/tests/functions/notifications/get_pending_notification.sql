@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(3);
+select plan(7);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -8,17 +8,24 @@ select plan(3);
 \set webhook1ID '00000000-0000-0000-0000-000000000001'
 \set package1ID '00000000-0000-0000-0000-000000000001'
 \set event1ID '00000000-0000-0000-0000-000000000001'
+\set event2ID '00000000-0000-0000-0000-000000000002'
+\set event3ID '00000000-0000-0000-0000-000000000003'
 \set notification1ID '00000000-0000-0000-0000-000000000001'
 \set notification2ID '00000000-0000-0000-0000-000000000002'
+\set notification3ID '00000000-0000-0000-0000-000000000003'
+\set notification4ID '00000000-0000-0000-0000-000000000004'
+\set notification5ID '00000000-0000-0000-0000-000000000005'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set issueTracker1ID '00000000-0000-0000-0000-000000000001'
 
 -- No pending events available yet
 select is_empty(
-    $$ select get_pending_notification()::jsonb $$,
+    $$ select get_pending_notification('email')::jsonb $$,
     'Should not return a notification'
 );
 
 -- Seed some data
-insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email, locale) values (:'user1ID', 'user1', 'user1@email.com', 'es');
 insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
 insert into package (package_id, name, latest_version, repository_id)
@@ -42,14 +49,17 @@ insert into webhook (
     true,
     :'user1ID'
 );
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into issue_tracker (issue_tracker_id, organization_id, kind, project, labels, token)
+values (:'issueTracker1ID', :'org1ID', 'github', 'org1/repo1', '{"security"}', 'token1');
 insert into event (event_id, package_version, package_id, event_kind_id)
 values (:'event1ID', '1.0.0', :'package1ID', 0);
 
 -- Add notification for user1 and check we get it successfully
-insert into notification (notification_id, event_id, user_id)
-values (:'notification1ID', :'event1ID', :'user1ID');
+insert into notification (notification_id, event_id, event_created_at, user_id)
+select :'notification1ID', event_id, created_at, :'user1ID' from event where event_id = :'event1ID';
 select is(
-    get_pending_notification()::jsonb,
+    get_pending_notification('email')::jsonb,
     '{
         "notification_id": "00000000-0000-0000-0000-000000000001",
         "event": {
@@ -59,7 +69,8 @@ select is(
             "package_version": "1.0.0"
         },
         "user": {
-            "email": "user1@email.com"
+            "email": "user1@email.com",
+            "locale": "es"
         }
 	}'::jsonb,
     'A notification for user1 should be returned'
@@ -67,10 +78,14 @@ select is(
 update notification set processed=true where notification_id=:'notification1ID';
 
 -- Add notification for webhook1 and check we get it successfully
-insert into notification (notification_id, event_id, webhook_id)
-values (:'notification2ID', :'event1ID', :'webhook1ID');
+insert into notification (notification_id, event_id, event_created_at, webhook_id)
+select :'notification2ID', event_id, created_at, :'webhook1ID' from event where event_id = :'event1ID';
+select is_empty(
+    $$ select get_pending_notification('email')::jsonb $$,
+    'Should not return a notification for the email channel'
+);
 select is(
-    get_pending_notification()::jsonb,
+    get_pending_notification('webhook')::jsonb,
     '{
         "notification_id": "00000000-0000-0000-0000-000000000002",
         "event": {
@@ -89,6 +104,54 @@ select is(
 	}'::jsonb,
     'A notification for webhook1 should be returned'
 );
+update notification set processed=true where notification_id=:'notification2ID';
+
+-- Add notification for issueTracker1 and check we get it successfully
+insert into notification (notification_id, event_id, event_created_at, issue_tracker_id)
+select :'notification3ID', event_id, created_at, :'issueTracker1ID' from event where event_id = :'event1ID';
+select is(
+    get_pending_notification('issue-tracker')::jsonb,
+    '{
+        "notification_id": "00000000-0000-0000-0000-000000000003",
+        "event": {
+            "event_id": "00000000-0000-0000-0000-000000000001",
+            "event_kind": 0,
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "package_version": "1.0.0"
+        },
+        "issue_tracker": {
+            "issue_tracker_id": "00000000-0000-0000-0000-000000000001",
+            "kind": "github",
+            "project": "org1/repo1",
+            "labels": ["security"],
+            "token": "token1"
+        }
+	}'::jsonb,
+    'A notification for issueTracker1 should be returned'
+);
+update notification set processed=true where notification_id=:'notification3ID';
+
+-- Add a new release notification and a security alert one for user1 and check
+-- the security alert is returned first
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event2ID', '1.0.0', :'package1ID', 1);
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event3ID', '1.1.0', :'package1ID', 0);
+insert into notification (notification_id, event_id, event_created_at, user_id, created_at)
+select :'notification4ID', event_id, created_at, :'user1ID', '2022-01-01' from event where event_id = :'event3ID';
+insert into notification (notification_id, event_id, event_created_at, user_id, created_at)
+select :'notification5ID', event_id, created_at, :'user1ID', '2022-01-02' from event where event_id = :'event2ID';
+select is(
+    get_pending_notification('email')::jsonb->>'notification_id',
+    :'notification5ID',
+    'The security alert notification should be returned first'
+);
+update notification set processed=true where notification_id=:'notification5ID';
+select is(
+    get_pending_notification('email')::jsonb->>'notification_id',
+    :'notification4ID',
+    'The new release notification should be returned next'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -17,8 +17,8 @@ insert into package (package_id, name, latest_version, repository_id)
 values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
 insert into event (event_id, package_version, package_id, event_kind_id)
 values (:'event1ID', '1.0.0', :'package1ID', 0);
-insert into notification (notification_id, event_id, user_id)
-values (:'notification1ID', :'event1ID', :'user1ID');
+insert into notification (notification_id, event_id, event_created_at, user_id)
+select :'notification1ID', event_id, created_at, :'user1ID' from event where event_id = :'event1ID';
 
 -- Run some tests
 select results_eq(
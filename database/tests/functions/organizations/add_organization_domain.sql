@@ -0,0 +1,49 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@org1.com', true);
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@org1.com', true);
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+
+-- Run some tests
+select add_organization_domain(:'user1ID', 'org1', 'org1.com', 'token1');
+select results_eq(
+    $$
+        select organization_id, domain, verification_token, verified_at is null, join_mode
+        from organization_domain
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001'::uuid, 'org1.com', 'token1', true, 'disabled')
+    $$,
+    'Domain should have been added to org1 pending of verification'
+);
+select throws_ok(
+    $$ select add_organization_domain('00000000-0000-0000-0000-000000000001', 'org1', 'org1.com', 'token2') $$,
+    23505,
+    'duplicate key value violates unique constraint "organization_domain_pkey"',
+    'Domain cannot be added twice to the same organization'
+);
+select throws_ok(
+    $$ select add_organization_domain('00000000-0000-0000-0000-000000000002', 'org1', 'other.com', 'token2') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to add domains to org1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
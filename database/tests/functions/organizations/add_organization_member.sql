@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(3);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -33,6 +33,25 @@ select results_eq(
     $$,
     'User2 should have been added to organization1'
 );
+select results_eq(
+    $$
+        select kind, data, read
+        from inbox_notification
+        where user_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values (
+            'organization-invitation',
+            '{
+                "organization_name": "org1",
+                "organization_display_name": "Organization 1",
+                "invited_by": "user1"
+            }'::jsonb,
+            false
+        )
+    $$,
+    'An invitation should have been added to user2 inbox'
+);
 
 -- Try adding an organization member without the required privileges
 select throws_ok(
@@ -0,0 +1,53 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@org1.com', true);
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@org1.com', true);
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into organization_join_request (organization_id, user_id)
+values (:'org1ID', :'user2ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select approve_organization_join_request('00000000-0000-0000-0000-000000000002', 'org1', 'user2') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to approve its own join request'
+);
+select approve_organization_join_request(:'user1ID', 'org1', 'user2');
+select results_eq(
+    $$
+        select confirmed from user__organization
+        where user_id = '00000000-0000-0000-0000-000000000002'
+        and organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values (true) $$,
+    'User2 should be a confirmed member of org1'
+);
+select is_empty(
+    $$ select * from organization_join_request $$,
+    'Join request should have been deleted'
+);
+select throws_ok(
+    $$ select approve_organization_join_request('00000000-0000-0000-0000-000000000001', 'org1', 'user2') $$,
+    'join request not found',
+    'Join request cannot be approved twice'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(4);
+select plan(5);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -12,6 +12,8 @@ values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@email.com');
 insert into organization (organization_id, name, display_name, description, home_url)
 values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
 insert into user__organization (user_id, organization_id) values(:'user1ID', :'org1ID');
+insert into inbox_notification (user_id, kind, data)
+values (:'user1ID', 'organization-invitation', '{"organization_name": "org1"}');
 
 -- User and organization have been seeded
 select results_eq(
@@ -57,6 +59,15 @@ select results_eq(
     $$ values (true) $$,
     'User1 membership in organization1 should have been confirmed'
 );
+select results_eq(
+    $$
+        select read
+        from inbox_notification
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values (true) $$,
+    'User1 invitation to join organization1 should have been marked as read'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
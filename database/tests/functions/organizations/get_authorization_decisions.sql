@@ -0,0 +1,145 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set decision1ID '00000000-0000-0000-0000-000000000001'
+\set decision2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', true);
+insert into authorization_decision (
+    authorization_decision_id,
+    organization_id,
+    user_id,
+    action,
+    allowed,
+    policy_digest,
+    created_at
+) values (
+    :'decision1ID',
+    :'org1ID',
+    :'user1ID',
+    'updateOrganization',
+    true,
+    'digest',
+    '2022-01-01 00:00:00+00'
+);
+insert into authorization_decision (
+    authorization_decision_id,
+    organization_id,
+    user_id,
+    action,
+    allowed,
+    policy_digest,
+    error,
+    created_at
+) values (
+    :'decision2ID',
+    :'org1ID',
+    :'user2ID',
+    'deleteOrganization',
+    false,
+    'digest',
+    'allowed actions query returned no results',
+    '2022-01-02 00:00:00+00'
+);
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_authorization_decisions('00000000-0000-0000-0000-000000000001', 'org1', false, 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "authorization_decision_id": "00000000-0000-0000-0000-000000000002",
+                    "user_alias": "user2",
+                    "action": "deleteOrganization",
+                    "allowed": false,
+                    "policy_digest": "digest",
+                    "error": "allowed actions query returned no results",
+                    "created_at": 1641081600
+                },
+                {
+                    "authorization_decision_id": "00000000-0000-0000-0000-000000000001",
+                    "user_alias": "user1",
+                    "action": "updateOrganization",
+                    "allowed": true,
+                    "policy_digest": "digest",
+                    "created_at": 1640995200
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'All decisions should be returned, most recent first'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_authorization_decisions('00000000-0000-0000-0000-000000000001', 'org1', true, 0, 0)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "authorization_decision_id": "00000000-0000-0000-0000-000000000002",
+                    "user_alias": "user2",
+                    "action": "deleteOrganization",
+                    "allowed": false,
+                    "policy_digest": "digest",
+                    "error": "allowed actions query returned no results",
+                    "created_at": 1641081600
+                }
+            ]'::jsonb,
+            1
+        )
+    $$,
+    'Only denied decisions should be returned'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from get_authorization_decisions('00000000-0000-0000-0000-000000000001', 'org1', false, 1, 1)
+    $$,
+    $$
+        values (
+            '[
+                {
+                    "authorization_decision_id": "00000000-0000-0000-0000-000000000001",
+                    "user_alias": "user1",
+                    "action": "updateOrganization",
+                    "allowed": true,
+                    "policy_digest": "digest",
+                    "created_at": 1640995200
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Limit and offset of 1 used, oldest decision returned'
+);
+select throws_ok(
+    $$ select * from get_authorization_decisions('00000000-0000-0000-0000-000000000001', 'org2', false, 0, 0) $$,
+    42501,
+    'insufficient_privilege',
+    'User1 should not be able to get organization2 decisions'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,63 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@org1.com', true);
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@org1.com', true);
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into organization_domain (organization_id, domain, verification_token, verified_at, join_mode, created_at)
+values (:'org1ID', 'org1.com', 'token1', '2020-06-16 11:20:35+02', 'auto', '2020-06-16 11:20:34+02');
+insert into organization_domain (organization_id, domain, verification_token, created_at)
+values (:'org1ID', 'org1.io', 'token2', '2020-06-16 11:20:34+02');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org2ID', true);
+
+-- Run some tests
+select is(
+    get_organization_domains(:'user1ID', 'org1')::jsonb,
+    '[
+        {
+            "domain": "org1.com",
+            "verification_token": "token1",
+            "verified": true,
+            "verified_at": 1592299235,
+            "join_mode": "auto",
+            "created_at": 1592299234
+        },
+        {
+            "domain": "org1.io",
+            "verification_token": "token2",
+            "verified": false,
+            "join_mode": "disabled",
+            "created_at": 1592299234
+        }
+    ]'::jsonb,
+    'Org1 domains should be returned'
+);
+select is(
+    get_organization_domains(:'user1ID', 'org2')::jsonb,
+    '[]'::jsonb,
+    'Org2 does not have any domains'
+);
+select throws_ok(
+    $$ select get_organization_domains('00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to get org1 domains'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
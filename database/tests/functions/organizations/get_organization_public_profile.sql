@@ -0,0 +1,71 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, profile_image_id, public_profile)
+values (:'user1ID', 'user1', 'user1@email.com', '00000000-0000-0000-0000-000000000001', true);
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url, logo_image_id, public_profile)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com', '00000000-0000-0000-0000-000000000001', true);
+insert into organization (organization_id, name)
+values (:'org2ID', 'org2');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+
+-- Run some tests
+select is(
+    get_organization_public_profile('org1')::jsonb,
+    '{
+        "name": "org1",
+        "display_name": "Organization 1",
+        "description": "Description 1",
+        "home_url": "https://org1.com",
+        "logo_image_id": "00000000-0000-0000-0000-000000000001",
+        "members": [
+            {
+                "alias": "user1",
+                "profile_image_id": "00000000-0000-0000-0000-000000000001"
+            }
+        ],
+        "repositories": [
+            {
+                "name": "repo1",
+                "display_name": "Repo 1",
+                "url": "https://repo1.com",
+                "private": false,
+                "kind": 0,
+                "verified_publisher": false,
+                "official": false,
+                "packages_count": 0
+            }
+        ],
+        "packages": [],
+        "activity": [],
+        "packages_count": 0,
+        "stars": 0
+    }'::jsonb,
+    'Org1 public profile should be returned, including only members with a public profile'
+);
+select is_empty(
+    $$ select get_organization_public_profile('org2') $$,
+    'Org2 public profile should not be returned as it has not been enabled'
+);
+select is_empty(
+    $$ select get_organization_public_profile('org3') $$,
+    'Org3 does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,123 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'package3', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, security_report_summary, security_report_created_at, ts)
+values (:'package1ID', '1.0.0', '{"high": 2, "medium": 1}', '2021-06-16 11:20:34+02', current_timestamp);
+insert into snapshot (package_id, version, security_report_summary, security_report_created_at, ts)
+values (:'package2ID', '1.0.0', '{"critical": 1, "low": 3}', '2021-06-16 11:20:34+02', current_timestamp);
+insert into snapshot (package_id, version, security_report_summary, security_report_created_at, ts)
+values (:'package3ID', '1.0.0', '{}', '2021-06-16 11:20:34+02', current_timestamp);
+
+-- Run some tests
+select is(
+    get_organization_security_overview(:'user1ID', 'org1')::jsonb->'summary',
+    '{
+        "packages": 3,
+        "affected_packages": 2,
+        "critical": 1,
+        "high": 2,
+        "medium": 1,
+        "low": 3,
+        "unknown": 0
+    }'::jsonb,
+    'Summary should include the vulnerabilities of the latest version of all packages'
+);
+select is(
+    get_organization_security_overview(:'user1ID', 'org1')::jsonb->'packages',
+    '[
+        {
+            "package_id": "00000000-0000-0000-0000-000000000002",
+            "name": "package2",
+            "normalized_name": "package2",
+            "version": "1.0.0",
+            "security_report_summary": {"critical": 1, "low": 3},
+            "security_report_created_at": 1623835234,
+            "repository": {
+                "repository_id": "00000000-0000-0000-0000-000000000001",
+                "name": "repo1",
+                "display_name": "Repo 1",
+                "url": "https://repo1.com",
+                "private": false,
+                "kind": 0,
+                "verified_publisher": false,
+                "official": false,
+                "scanner_disabled": false,
+                "organization_name": "org1",
+                "organization_display_name": "Organization 1"
+            }
+        },
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "name": "package1",
+            "normalized_name": "package1",
+            "version": "1.0.0",
+            "security_report_summary": {"high": 2, "medium": 1},
+            "security_report_created_at": 1623835234,
+            "repository": {
+                "repository_id": "00000000-0000-0000-0000-000000000001",
+                "name": "repo1",
+                "display_name": "Repo 1",
+                "url": "https://repo1.com",
+                "private": false,
+                "kind": 0,
+                "verified_publisher": false,
+                "official": false,
+                "scanner_disabled": false,
+                "organization_name": "org1",
+                "organization_display_name": "Organization 1"
+            }
+        }
+    ]'::jsonb,
+    'Only affected packages should be returned, sorted by severity'
+);
+select is(
+    jsonb_array_length(get_organization_security_overview(:'user1ID', 'org1')::jsonb->'trends'),
+    12,
+    'Trends should cover the last 12 months'
+);
+select is(
+    get_organization_security_overview(:'user1ID', 'org1')::jsonb->'trends'->11,
+    jsonb_build_object(
+        'month', floor(extract(epoch from date_trunc('month', current_date))),
+        'critical', 1,
+        'high', 2,
+        'medium', 1,
+        'low', 3,
+        'unknown', 0
+    ),
+    'Current month trend should include the vulnerabilities of all packages'
+);
+select throws_ok(
+    $$ select get_organization_security_overview('00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to get org1 security overview'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,74 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@org1.com', true);
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@org1.com', true);
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into organization_domain (organization_id, domain, verification_token, verified_at, join_mode)
+values (:'org1ID', 'org1.com', 'token1', current_timestamp, 'auto');
+insert into organization_domain (organization_id, domain, verification_token, verified_at, join_mode)
+values (:'org2ID', 'org1.com', 'token2', null, 'disabled');
+
+-- Run some tests
+select throws_ok(
+    $$ select join_organization('00000000-0000-0000-0000-000000000001', 'org1') $$,
+    'user is already a member of the organization',
+    'User1 cannot join org1 again'
+);
+select throws_ok(
+    $$ select join_organization('00000000-0000-0000-0000-000000000002', 'org2') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 cannot join org2 (domain not verified)'
+);
+select is(
+    join_organization(:'user2ID', 'org1'),
+    'auto',
+    'User2 joins org1 automatically'
+);
+select results_eq(
+    $$
+        select confirmed from user__organization
+        where user_id = '00000000-0000-0000-0000-000000000002'
+        and organization_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values (true) $$,
+    'User2 should be a confirmed member of org1'
+);
+delete from user__organization where user_id = :'user2ID';
+update organization_domain set join_mode = 'request' where organization_id = :'org1ID';
+select is(
+    join_organization(:'user2ID', 'org1'),
+    'request',
+    'User2 requests to join org1'
+);
+select results_eq(
+    $$ select organization_id, user_id from organization_join_request $$,
+    $$ values ('00000000-0000-0000-0000-000000000001'::uuid, '00000000-0000-0000-0000-000000000002'::uuid) $$,
+    'Join request should have been registered'
+);
+update "user" set email_verified = false where user_id = :'user2ID';
+select throws_ok(
+    $$ select join_organization('00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'Users whose email has not been verified cannot join'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
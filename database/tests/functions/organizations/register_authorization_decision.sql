@@ -0,0 +1,79 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into authorization_decision (organization_id, user_id, action, allowed, policy_digest, created_at)
+values (:'org1ID', :'user1ID', 'deleteOrganization', false, 'digest', current_timestamp - '2 weeks'::interval);
+
+-- Register some decisions
+select register_authorization_decision('
+{
+    "organization_name": "org1",
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "action": "updateOrganization",
+    "allowed": false,
+    "policy_digest": "digest",
+    "error": "allowed actions query returned no results"
+}
+');
+select register_authorization_decision('
+{
+    "organization_name": "org2",
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "action": "updateOrganization",
+    "allowed": true,
+    "policy_digest": "digest"
+}
+');
+
+-- Run some tests
+select results_eq(
+    $$
+        select user_id, action, allowed, policy_digest, error
+        from authorization_decision
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'updateOrganization',
+            false,
+            'digest',
+            'allowed actions query returned no results'
+        )
+    $$,
+    'Decision should have been registered'
+);
+select is_empty(
+    $$
+        select * from authorization_decision
+        where created_at < current_timestamp - '1 week'::interval
+    $$,
+    'Decisions older than one week should have been deleted'
+);
+select lives_ok(
+    $$
+        select register_authorization_decision('
+        {
+            "organization_name": "org1",
+            "user_id": "00000000-0000-0000-0000-000000000001",
+            "action": "updateOrganization",
+            "allowed": true,
+            "policy_digest": "digest",
+            "error": ""
+        }
+        ')
+    $$,
+    'Decision without error should be registered'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
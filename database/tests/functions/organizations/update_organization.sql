@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(3);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -19,7 +19,9 @@ select update_organization(:'user1ID', 'org1', '
     "display_name": "Organization 1 updated",
     "description": "Description 1 updated",
     "home_url": "https://org1.com/updated",
-    "logo_image_id": "00000000-0000-0000-0000-000000000001"
+    "logo_image_id": "00000000-0000-0000-0000-000000000001",
+    "repository_changes_approval": true,
+    "public_profile": true
 }
 '::jsonb);
 
@@ -31,7 +33,9 @@ select results_eq(
             display_name,
             description,
             home_url,
-            logo_image_id
+            logo_image_id,
+            repository_changes_approval,
+            public_profile
         from organization
     $$,
     $$
@@ -40,12 +44,32 @@ select results_eq(
             'Organization 1 updated',
             'Description 1 updated',
             'https://org1.com/updated',
-            '00000000-0000-0000-0000-000000000001'::uuid
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            true,
+            true
         )
     $$,
     'Organization should have been updated'
 );
 
+-- Update organization again without providing the repository changes approval
+-- and public profile settings
+select update_organization(:'user1ID', 'org1-updated', '
+{
+    "name": "org1-updated",
+    "display_name": "Organization 1 updated"
+}
+'::jsonb);
+select results_eq(
+    $$
+        select repository_changes_approval, public_profile from organization
+    $$,
+    $$
+        values (true, true)
+    $$,
+    'Repository changes approval and public profile settings should have been kept'
+);
+
 -- Try again using a user not belonging to the organization
 select throws_ok(
     $$
@@ -0,0 +1,51 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@org1.com', true);
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@org1.com', true);
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into organization_domain (organization_id, domain, verification_token, verified_at)
+values (:'org1ID', 'org1.com', 'token1', current_timestamp);
+insert into organization_domain (organization_id, domain, verification_token)
+values (:'org1ID', 'org1.io', 'token2');
+
+-- Run some tests
+select update_organization_domain(:'user1ID', 'org1', 'org1.com', 'request');
+select results_eq(
+    $$ select join_mode from organization_domain where domain = 'org1.com' $$,
+    $$ values ('request') $$,
+    'Join mode of verified domain should have been updated'
+);
+select throws_ok(
+    $$ select update_organization_domain('00000000-0000-0000-0000-000000000001', 'org1', 'org1.io', 'auto') $$,
+    'domain not found or not verified',
+    'Joining cannot be enabled for domains not verified'
+);
+select lives_ok(
+    $$ select update_organization_domain('00000000-0000-0000-0000-000000000001', 'org1', 'org1.io', 'disabled') $$,
+    'Joining can be disabled for domains not verified'
+);
+select throws_ok(
+    $$ select update_organization_domain('00000000-0000-0000-0000-000000000002', 'org1', 'org1.com', 'auto') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to update org1 domains'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
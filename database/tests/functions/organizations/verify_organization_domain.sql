@@ -0,0 +1,56 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user1ID', 'user1', 'firstname1', 'lastname1', 'user1@org1.com', true);
+insert into "user" (user_id, alias, first_name, last_name, email, email_verified)
+values (:'user2ID', 'user2', 'firstname2', 'lastname2', 'user2@org1.com', true);
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org2ID', true);
+insert into organization_domain (organization_id, domain, verification_token)
+values (:'org1ID', 'org1.com', 'token1');
+insert into organization_domain (organization_id, domain, verification_token)
+values (:'org2ID', 'org1.com', 'token2');
+
+-- Run some tests
+select verify_organization_domain(:'user1ID', 'org1', 'org1.com');
+select isnt_empty(
+    $$
+        select * from organization_domain
+        where organization_id = '00000000-0000-0000-0000-000000000001'
+        and verified_at is not null
+    $$,
+    'Org1 domain should have been verified'
+);
+select throws_ok(
+    $$ select verify_organization_domain('00000000-0000-0000-0000-000000000002', 'org2', 'org1.com') $$,
+    'domain already verified by another organization',
+    'Domain already verified by org1 cannot be verified by org2'
+);
+select throws_ok(
+    $$ select verify_organization_domain('00000000-0000-0000-0000-000000000001', 'org1', 'org1.io') $$,
+    'domain not found',
+    'Domain not added to org1 cannot be verified'
+);
+select throws_ok(
+    $$ select verify_organization_domain('00000000-0000-0000-0000-000000000002', 'org1', 'org1.com') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to verify org1 domains'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
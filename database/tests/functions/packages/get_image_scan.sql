@@ -0,0 +1,35 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set digest1 'sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71'
+
+-- Seed some data
+insert into image_scan (digest, report, sboms, created_at)
+values (
+    :'digest1',
+    '{"SchemaVersion": 2}',
+    '{"cyclonedx": {"bomFormat": "CycloneDX"}}',
+    '2020-06-16 11:20:34+02'
+);
+
+-- Run some tests
+select is(
+    get_image_scan(:'digest1')::jsonb,
+    '{
+        "digest": "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71",
+        "report": {"SchemaVersion": 2},
+        "sboms": {"cyclonedx": {"bomFormat": "CycloneDX"}},
+        "created_at": 1592299234
+    }'::jsonb,
+    'Image scan should be returned'
+);
+select is_empty(
+    $$ select get_image_scan('sha256:5e6adef17f723fff566994a8e35003066b700e80962ab9674d979a41672ca521') $$,
+    'No image scan expected for unknown digest'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,17 @@
+-- Start transaction and plan tests
+begin;
+select plan(8);
+
+-- Run some tests
+select is(get_license_family(null), 'unknown', 'Null license should be unknown');
+select is(get_license_family('MIT'), 'permissive', 'MIT should be permissive');
+select is(get_license_family('Apache-2.0'), 'permissive', 'Apache-2.0 should be permissive');
+select is(get_license_family('LGPL-2.1-only'), 'weak-copyleft', 'LGPL-2.1-only should be weak copyleft');
+select is(get_license_family('MPL-2.0'), 'weak-copyleft', 'MPL-2.0 should be weak copyleft');
+select is(get_license_family('GPL-3.0-or-later'), 'strong-copyleft', 'GPL-3.0-or-later should be strong copyleft');
+select is(get_license_family('AGPL-3.0-only'), 'network-copyleft', 'AGPL-3.0-only should be network copyleft');
+select is(get_license_family('Custom license'), 'unknown', 'Custom license should be unknown');
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(5);
+select plan(7);
 
 -- Declare some variables
 \set org1ID '00000000-0000-0000-0000-000000000001'
@@ -15,6 +15,7 @@ select plan(5);
 \set image1ID '00000000-0000-0000-0000-000000000001'
 \set image2ID '00000000-0000-0000-0000-000000000002'
 \set image3ID '00000000-0000-0000-0000-000000000003'
+\set image4ID '00000000-0000-0000-0000-000000000004'
 \set webhook1ID '00000000-0000-0000-0000-000000000001'
 
 -- No packages at this point
@@ -80,6 +81,7 @@ insert into snapshot (
     display_name,
     description,
     logo_image_id,
+    logo_dark_image_id,
     keywords,
     home_url,
     app_version,
@@ -94,6 +96,7 @@ insert into snapshot (
     security_report_created_at,
     data,
     deprecated,
+    replaced_by,
     license,
     signed,
     signatures,
@@ -106,7 +109,10 @@ insert into snapshot (
     prerelease,
     recommendations,
     screenshots,
+    videos,
     sign_key,
+    provenance,
+    release_notes,
     ts
 ) values (
     :'package1ID',
@@ -114,6 +120,7 @@ insert into snapshot (
     'Package 1',
     'description',
     :'image1ID',
+    :'image4ID',
     '{"kw1", "kw2"}',
     'home_url',
     '12.1.0',
@@ -128,6 +135,7 @@ insert into snapshot (
     '2020-06-16 11:20:34+02',
     '{"key": "value"}',
     true,
+    'https://artifacthub.io/packages/helm/repo1/package2',
     'Apache-2.0',
     true,
     '{"prov","cosign"}',
@@ -151,7 +159,15 @@ insert into snapshot (
             "url": "https://artifacthub.io/screenshot1.jpg"
         }
     ]'::jsonb,
+    '[
+        {
+            "title": "Video 1",
+            "url": "https://artifacthub.io/video1.mp4"
+        }
+    ]'::jsonb,
     '{"fingerprint": "0011223344", "url": "https://key.url"}',
+    '{"builder_id": "https://github.com/actions/runner", "source_repo": "https://github.com/org/repo"}',
+    '{"source": "github", "url": "https://github.com/org/repo/releases/tag/v1.0.0", "tag_name": "v1.0.0", "body": "release notes"}',
     '2020-06-16 11:20:34+02'
 );
 insert into snapshot (
@@ -214,6 +230,7 @@ insert into snapshot (
     readme,
     install,
     data,
+    translations,
     ts
 ) values (
     :'package2ID',
@@ -225,6 +242,10 @@ insert into snapshot (
     'readme-version-1.0.0',
     'install-version-1.0.0',
     '{"key": "value"}',
+    '{
+        "es": {"description": "descripción", "readme": "readme-es"},
+        "pt-br": {"readme": "readme-pt-br"}
+    }',
     '2020-06-16 11:20:34+02'
 );
 insert into subscription (user_id, package_id, event_kind_id)
@@ -260,6 +281,7 @@ select is(
         "display_name": "Package 1",
         "description": "description",
         "logo_image_id": "00000000-0000-0000-0000-000000000001",
+        "logo_dark_image_id": "00000000-0000-0000-0000-000000000004",
         "keywords": ["kw1", "kw2"],
         "home_url": "home_url",
         "readme": "readme-version-1.0.0",
@@ -307,6 +329,7 @@ select is(
         "app_version": "12.1.0",
         "digest": "digest-package1-1.0.0",
         "deprecated": true,
+        "replaced_by": "https://artifacthub.io/packages/helm/repo1/package2",
         "contains_security_updates": true,
         "prerelease": true,
         "license": "Apache-2.0",
@@ -322,6 +345,7 @@ select is(
         "all_containers_images_whitelisted": true,
         "provider": "Org Inc",
         "has_values_schema": true,
+        "has_crds_schemas": false,
         "has_changelog": true,
         "changes": [
             {
@@ -335,15 +359,25 @@ select is(
                 ]
             }
         ],
+        "release_notes": {
+            "source": "github",
+            "url": "https://github.com/org/repo/releases/tag/v1.0.0",
+            "tag_name": "v1.0.0",
+            "body": "release notes"
+        },
         "ts": 1592299234,
         "maintainers": [
             {
+                "maintainer_id": "00000000-0000-0000-0000-000000000001",
                 "name": "name1",
-                "email": "email1"
+                "email": "email1",
+                "verified": false
             },
             {
+                "maintainer_id": "00000000-0000-0000-0000-000000000002",
                 "name": "name2",
-                "email": "email2"
+                "email": "email2",
+                "verified": false
             }
         ],
         "recommendations": [
@@ -357,10 +391,20 @@ select is(
                 "url": "https://artifacthub.io/screenshot1.jpg"
             }
         ],
+        "videos": [
+            {
+                "title": "Video 1",
+                "url": "https://artifacthub.io/video1.mp4"
+            }
+        ],
         "sign_key": {
             "fingerprint": "0011223344",
             "url": "https://key.url"
         },
+        "provenance": {
+            "builder_id": "https://github.com/actions/runner",
+            "source_repo": "https://github.com/org/repo"
+        },
         "repository": {
             "repository_id": "00000000-0000-0000-0000-000000000001",
             "kind": 0,
@@ -413,6 +457,7 @@ select is(
         "display_name": "Package 1",
         "description": "description",
         "logo_image_id": "00000000-0000-0000-0000-000000000001",
+        "logo_dark_image_id": "00000000-0000-0000-0000-000000000004",
         "keywords": ["kw1", "kw2"],
         "home_url": "home_url",
         "readme": "readme-version-1.0.0",
@@ -460,6 +505,7 @@ select is(
         "app_version": "12.1.0",
         "digest": "digest-package1-1.0.0",
         "deprecated": true,
+        "replaced_by": "https://artifacthub.io/packages/helm/repo1/package2",
         "contains_security_updates": true,
         "prerelease": true,
         "license": "Apache-2.0",
@@ -475,6 +521,7 @@ select is(
         "all_containers_images_whitelisted": true,
         "provider": "Org Inc",
         "has_values_schema": true,
+        "has_crds_schemas": false,
         "has_changelog": true,
         "changes": [
             {
@@ -488,15 +535,25 @@ select is(
                 ]
             }
         ],
+        "release_notes": {
+            "source": "github",
+            "url": "https://github.com/org/repo/releases/tag/v1.0.0",
+            "tag_name": "v1.0.0",
+            "body": "release notes"
+        },
         "ts": 1592299234,
         "maintainers": [
             {
+                "maintainer_id": "00000000-0000-0000-0000-000000000001",
                 "name": "name1",
-                "email": "email1"
+                "email": "email1",
+                "verified": false
             },
             {
+                "maintainer_id": "00000000-0000-0000-0000-000000000002",
                 "name": "name2",
-                "email": "email2"
+                "email": "email2",
+                "verified": false
             }
         ],
         "recommendations": [
@@ -510,10 +567,20 @@ select is(
                 "url": "https://artifacthub.io/screenshot1.jpg"
             }
         ],
+        "videos": [
+            {
+                "title": "Video 1",
+                "url": "https://artifacthub.io/video1.mp4"
+            }
+        ],
         "sign_key": {
             "fingerprint": "0011223344",
             "url": "https://key.url"
         },
+        "provenance": {
+            "builder_id": "https://github.com/actions/runner",
+            "source_repo": "https://github.com/org/repo"
+        },
         "repository": {
             "repository_id": "00000000-0000-0000-0000-000000000001",
             "kind": 0,
@@ -605,16 +672,21 @@ select is(
         "contains_security_updates": false,
         "prerelease": false,
         "has_values_schema": false,
+        "has_crds_schemas": false,
         "has_changelog": true,
         "ts": 1592299233,
         "maintainers": [
             {
+                "maintainer_id": "00000000-0000-0000-0000-000000000001",
                 "name": "name1",
-                "email": "email1"
+                "email": "email1",
+                "verified": false
             },
             {
+                "maintainer_id": "00000000-0000-0000-0000-000000000002",
                 "name": "name2",
-                "email": "email2"
+                "email": "email2",
+                "verified": false
             }
         ],
         "repository": {
@@ -663,7 +735,9 @@ select is(
             "key": "value"
         },
         "has_values_schema": false,
+        "has_crds_schemas": false,
         "has_changelog": false,
+        "available_locales": ["es", "pt-br"],
         "ts": 1592299234,
         "version": "1.0.0",
         "available_versions": [
@@ -692,6 +766,34 @@ select is(
     }'::jsonb,
     'Last package2 version is returned as a json object'
 );
+select results_eq(
+    $$
+        select p->>'description', p->>'readme', p->>'locale'
+        from get_package('{
+            "package_name": "package2",
+            "repository_name": "repo2",
+            "locale": "es-es"
+        }') p
+    $$,
+    $$
+        values ('descripción', 'readme-es', 'es')
+    $$,
+    'Translation for the locale language is returned when there is no translation for the locale'
+);
+select results_eq(
+    $$
+        select p->>'description', p->>'readme', p->>'locale'
+        from get_package('{
+            "package_name": "package2",
+            "repository_name": "repo2",
+            "locale": "pt-br"
+        }') p
+    $$,
+    $$
+        values ('description', 'readme-pt-br', 'pt-br')
+    $$,
+    'Translated readme is returned, description is not translated'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -0,0 +1,58 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id, downloads)
+values (:'package1ID', 'pkg1', '1.0.1', :'repo1ID', 45);
+insert into package (package_id, name, latest_version, repository_id, downloads)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo1ID', 10);
+insert into package_downloads values (:'package1ID', '1.0.0', '2021-10-08', 10);
+insert into package_downloads values (:'package1ID', '1.0.0', '2021-12-08', 10);
+insert into package_downloads values (:'package1ID', '1.0.1', '2021-12-08', 20);
+insert into package_downloads values (:'package1ID', '1.0.1', '2021-12-09', 5);
+insert into package_downloads values (:'package2ID', '1.0.0', '2021-10-08', 10);
+
+-- Run some tests
+select is(
+    get_package_downloads(:'package1ID', '2021-12-01', '2021-12-31')::jsonb,
+    '{
+        "total": 45,
+        "versions": {
+            "1.0.0": {
+                "2021-12-08": 10
+            },
+            "1.0.1": {
+                "2021-12-08": 20,
+                "2021-12-09": 5
+            }
+        }
+    }'::jsonb,
+    'Package1 downloads should be returned as a json object'
+);
+select is(
+    get_package_downloads(:'package2ID', '2021-12-01', '2021-12-31')::jsonb,
+    '{
+        "total": 10,
+        "versions": {}
+    }'::jsonb,
+    'Package2 has no downloads during the last month, only total expected'
+);
+select is_empty(
+    $$ select get_package_downloads('00000000-0000-0000-0000-000000000003', '2021-12-01', '2021-12-31') $$,
+    'Package3 does not exist, no rows expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -69,6 +69,7 @@ select is(
         "name": "package1",
         "normalized_name": "package1",
         "stars": 10,
+        "downloads": 0,
         "official": true,
         "display_name": "Package 1",
         "description": "description",
@@ -112,6 +113,7 @@ select is(
         "name": "package1",
         "normalized_name": "package1",
         "stars": 10,
+        "downloads": 0,
         "official": true,
         "display_name": "Package 1",
         "description": "description",
@@ -0,0 +1,82 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 3, :'user1ID');
+insert into package (
+    package_id,
+    name,
+    latest_version,
+    repository_id,
+    channels,
+    default_channel
+) values (
+    :'package1ID',
+    'package1',
+    '1.0.0',
+    :'repo1ID',
+    '[{"name": "stable", "version": "1.0.0"}, {"name": "alpha", "version": "0.9.0"}]',
+    'stable'
+);
+insert into snapshot (
+    package_id,
+    version,
+    ts,
+    data
+) values (
+    :'package1ID',
+    '1.0.0',
+    '2020-06-16 11:20:34+02',
+    '{"replaces": "0.9.0", "skips": ["0.9.1"], "skipRange": ">=0.8.0 <1.0.0"}'
+);
+insert into snapshot (
+    package_id,
+    version,
+    ts,
+    data
+) values (
+    :'package1ID',
+    '0.9.0',
+    '2020-06-16 11:20:33+02',
+    '{"format": "bundle"}'
+);
+
+-- Run some tests
+select is(
+    get_package_upgrade_graph(:'package1ID')::jsonb,
+    '{
+        "default_channel": "stable",
+        "channels": [
+            {"name": "stable", "version": "1.0.0"},
+            {"name": "alpha", "version": "0.9.0"}
+        ],
+        "versions": [
+            {
+                "version": "1.0.0",
+                "replaces": "0.9.0",
+                "skips": ["0.9.1"],
+                "skip_range": ">=0.8.0 <1.0.0"
+            },
+            {
+                "version": "0.9.0"
+            }
+        ]
+    }'::jsonb,
+    'Package upgrade graph should be returned'
+);
+select is_empty(
+    $$ select get_package_upgrade_graph('00000000-0000-0000-0000-000000000002') $$,
+    'No upgrade graph should be returned for inexistent package'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,44 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version) values (:'package1ID', '1.0.0');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version) values (:'package2ID', '1.0.0');
+insert into package_ranking (ranking, package_id, score) values ('trending-7d', :'package1ID', 10);
+insert into package_ranking (ranking, package_id, score) values ('trending-7d', :'package2ID', 20);
+insert into package_ranking (ranking, package_id, score) values ('new', :'package1ID', 5);
+
+-- Run some tests
+select is(
+    jsonb_path_query_array(get_packages_ranking('trending-7d')::jsonb, '$[*].package_id'),
+    '["00000000-0000-0000-0000-000000000002", "00000000-0000-0000-0000-000000000001"]'::jsonb,
+    'Trending packages in the last 7 days should be returned sorted by score'
+);
+select is(
+    jsonb_path_query_array(get_packages_ranking('new')::jsonb, '$[*].package_id'),
+    '["00000000-0000-0000-0000-000000000001"]'::jsonb,
+    'New and noteworthy packages should be returned'
+);
+select is(
+    get_packages_ranking('trending-30d')::jsonb,
+    '[]'::jsonb,
+    'Ranking has not been computed yet, empty list expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
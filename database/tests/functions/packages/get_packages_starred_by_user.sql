@@ -165,6 +165,7 @@ select results_eq(
                     "name": "package1",
                     "normalized_name": "package1",
                     "stars": 10,
+                    "downloads": 0,
                     "display_name": "Package 1",
                     "description": "description",
                     "logo_image_id": "00000000-0000-0000-0000-000000000001",
@@ -190,6 +191,7 @@ select results_eq(
                     "name": "package3",
                     "normalized_name": "package3",
                     "stars": 9,
+                    "downloads": 0,
                     "display_name": "Package 3",
                     "description": "description",
                     "logo_image_id": "00000000-0000-0000-0000-000000000003",
@@ -228,6 +230,7 @@ select results_eq(
                 "name": "package3",
                 "normalized_name": "package3",
                 "stars": 9,
+                "downloads": 0,
                 "display_name": "Package 3",
                 "description": "description",
                 "logo_image_id": "00000000-0000-0000-0000-000000000003",
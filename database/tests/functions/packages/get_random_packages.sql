@@ -188,6 +188,7 @@ select jsonb_set('[
         "name": "package1",
         "normalized_name": "package1",
         "stars": 10,
+        "downloads": 0,
         "display_name": "Package 1",
         "description": "description",
         "logo_image_id": "00000000-0000-0000-0000-000000000001",
@@ -0,0 +1,102 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, auth_user, auth_pass)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', 'user', 'pass');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, content_url, containers_images)
+values (:'package1ID', '1.0.0', 'https://repo1.com/pkg1-1.0.0.tgz', '[
+    {"name": "app", "image": "quay.io/org/img:1.0.0"}
+]');
+insert into snapshot_sbom (package_id, version, format, image, sbom)
+values (:'package1ID', '1.0.0', 'cyclonedx', 'quay.io/org/img:1.0.0', '{"bomFormat": "CycloneDX"}');
+insert into snapshot_sbom (package_id, version, format, image, sbom)
+values (:'package1ID', '1.0.0', 'spdx', 'quay.io/org/img:1.0.0', '{"spdxVersion": "SPDX-2.3"}');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version) values (:'package2ID', '1.0.0');
+
+-- Run some tests
+select is(
+    get_snapshot_bundle_data(:'user1ID', :'package1ID', '1.0.0')::jsonb,
+    '{
+        "name": "pkg1",
+        "version": "1.0.0",
+        "content_url": "https://repo1.com/pkg1-1.0.0.tgz",
+        "containers_images": [
+            {"name": "app", "image": "quay.io/org/img:1.0.0"}
+        ],
+        "sboms": {
+            "quay.io/org/img:1.0.0": {"bomFormat": "CycloneDX"}
+        },
+        "repository_id": "00000000-0000-0000-0000-000000000001",
+        "repository_kind_id": 0,
+        "private": true
+    }'::jsonb,
+    'Bundle data of snapshot owned by user should be returned'
+);
+select is(
+    get_snapshot_bundle_data(:'user1ID', :'package2ID', '1.0.0')::jsonb,
+    '{
+        "name": "pkg2",
+        "version": "1.0.0",
+        "repository_id": "00000000-0000-0000-0000-000000000002",
+        "repository_kind_id": 0
+    }'::jsonb,
+    'Bundle data of snapshot owned by organization user belongs to should be returned'
+);
+select is(
+    get_snapshot_bundle_data(null, :'package2ID', '1.0.0')::jsonb,
+    '{
+        "name": "pkg2",
+        "version": "1.0.0",
+        "repository_id": "00000000-0000-0000-0000-000000000002",
+        "repository_kind_id": 0
+    }'::jsonb,
+    'Bundle data should be returned when no requesting user is provided'
+);
+select is_empty(
+    $$
+        select get_snapshot_bundle_data('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '2.0.0')
+    $$,
+    'Snapshot does not exist, no data should be returned'
+);
+select throws_ok(
+    $$
+        select get_snapshot_bundle_data('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '1.0.0')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not own repo1, request should fail'
+);
+select throws_ok(
+    $$
+        select get_snapshot_bundle_data('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', '1.0.0')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not belong to org1, request should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,75 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into repository (repository_id, name, display_name, url, repository_kind_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0);
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '2.0.0', :'repo1ID');
+insert into snapshot (package_id, version, containers_images, security_report)
+values (:'package1ID', '1.0.0', '[
+    {"name": "app", "image": "quay.io/org/img:1.0.0"},
+    {"image": "docker.io/org/sidecar:1.0.0", "whitelisted": true}
+]', '{
+    "quay.io/org/img:1.0.0": {
+        "Results": [
+            {
+                "Target": "target1",
+                "Vulnerabilities": [
+                    {"VulnerabilityID": "CVE-1", "Severity": "CRITICAL"},
+                    {"VulnerabilityID": "CVE-2", "Severity": "HIGH"}
+                ]
+            },
+            {
+                "Target": "target2",
+                "Vulnerabilities": [
+                    {"VulnerabilityID": "CVE-3", "Severity": "HIGH"}
+                ]
+            }
+        ]
+    }
+}');
+insert into snapshot (package_id, version)
+values (:'package1ID', '2.0.0');
+
+-- Run some tests
+select is(
+    get_snapshot_images(:'package1ID', '1.0.0')::jsonb,
+    '[
+        {
+            "image": "docker.io/org/sidecar:1.0.0",
+            "whitelisted": true
+        },
+        {
+            "image": "quay.io/org/img:1.0.0",
+            "name": "app",
+            "whitelisted": false,
+            "security_report_summary": {
+                "critical": 1,
+                "high": 2,
+                "medium": 0,
+                "low": 0,
+                "unknown": 0
+            }
+        }
+    ]'::jsonb,
+    'Images with their security report summary should be returned'
+);
+select is(
+    get_snapshot_images(:'package1ID', '2.0.0')::jsonb,
+    '[]'::jsonb,
+    'No images expected for snapshot without containers images'
+);
+select is_empty(
+    $$ select get_snapshot_images('00000000-0000-0000-0000-000000000001', '3.0.0') $$,
+    'Nothing expected for snapshot that does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,79 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into repository (repository_id, name, display_name, url, repository_kind_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0);
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, license, images_licenses)
+values (:'package1ID', '1.0.0', 'Apache-2.0', '{
+    "quay.io/org/img:1.0.0": [
+        {"license": "GPL-2.0-only", "packages": ["busybox"]},
+        {"license": "MIT", "packages": ["musl", "zlib"]}
+    ]
+}');
+
+-- Run some tests
+select is(
+    get_snapshot_license_inventory(:'package1ID', '1.0.0', false)::jsonb,
+    '{
+        "package": {
+            "license": "Apache-2.0",
+            "family": "permissive",
+            "problematic": false
+        },
+        "images": [
+            {
+                "image": "quay.io/org/img:1.0.0",
+                "licenses": [
+                    {
+                        "license": "GPL-2.0-only",
+                        "family": "strong-copyleft",
+                        "problematic": true,
+                        "packages": ["busybox"]
+                    },
+                    {
+                        "license": "MIT",
+                        "family": "permissive",
+                        "problematic": false,
+                        "packages": ["musl", "zlib"]
+                    }
+                ]
+            }
+        ]
+    }'::jsonb,
+    'Full license inventory should be returned'
+);
+select is(
+    get_snapshot_license_inventory(:'package1ID', '1.0.0', true)::jsonb,
+    '{
+        "images": [
+            {
+                "image": "quay.io/org/img:1.0.0",
+                "licenses": [
+                    {
+                        "license": "GPL-2.0-only",
+                        "family": "strong-copyleft",
+                        "problematic": true,
+                        "packages": ["busybox"]
+                    }
+                ]
+            }
+        ]
+    }'::jsonb,
+    'Only problematic licenses should be returned'
+);
+select is_empty(
+    $$ select get_snapshot_license_inventory('00000000-0000-0000-0000-000000000001', '2.0.0', false) $$,
+    'No inventory expected for snapshot that does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(3);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -191,6 +191,8 @@ insert into snapshot (
     '2010-06-16 11:20:33+02',
     '2010-06-16 11:20:33+02'
 );
+insert into vulnerability_statement (package_id, version, vulnerability_id, status, justification)
+values (:'package1ID', '1.0.0', 'CVE-2022-0001', 'not_affected', 'Vulnerable code not in execute path');
 
 -- Run some tests
 select is(
@@ -205,6 +207,13 @@ select is(
                 {
                     "image": "quay.io/org/pkg1:1.0.0"
                 }
+            ],
+            "vulnerability_statements": [
+                {
+                    "vulnerability_id": "CVE-2022-0001",
+                    "status": "not_affected",
+                    "justification": "Vulnerable code not in execute path"
+                }
             ]
         },
         {
@@ -256,6 +265,25 @@ select is(
     'Some snapshots to scan were expected'
 );
 
+-- Request scan of a snapshot that would not be scanned otherwise
+update snapshot set security_scan_requested_at = current_timestamp - '1 hour'::interval
+where package_id = :'package3ID' and version = '0.0.9';
+select is(
+    get_snapshots_to_scan()::jsonb->0,
+    '{
+        "repository_id": "00000000-0000-0000-0000-000000000002",
+        "package_id": "00000000-0000-0000-0000-000000000003",
+        "package_name": "package3",
+        "version": "0.0.9",
+        "containers_images": [
+            {
+                "image": "quay.io/org/pkg3:0.0.9"
+            }
+        ]
+    }'::jsonb,
+    'Snapshot with a pending scan request should be the first one to be scanned'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
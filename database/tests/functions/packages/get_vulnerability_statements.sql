@@ -0,0 +1,48 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version) values (:'package1ID', '1.0.0');
+insert into snapshot (package_id, version) values (:'package1ID', '0.0.9');
+insert into vulnerability_statement (package_id, version, vulnerability_id, status, justification)
+values (:'package1ID', '1.0.0', 'CVE-2022-0002', 'acknowledged', null);
+insert into vulnerability_statement (package_id, version, vulnerability_id, status, justification)
+values (:'package1ID', '1.0.0', 'CVE-2022-0001', 'not_affected', 'Vulnerable code not present');
+
+-- Run some tests
+select is(
+    get_vulnerability_statements(:'package1ID', '1.0.0')::jsonb,
+    '[
+        {
+            "vulnerability_id": "CVE-2022-0001",
+            "status": "not_affected",
+            "justification": "Vulnerable code not present"
+        },
+        {
+            "vulnerability_id": "CVE-2022-0002",
+            "status": "acknowledged",
+            "justification": null
+        }
+    ]'::jsonb,
+    'Two vulnerability statements expected'
+);
+select is(
+    get_vulnerability_statements(:'package1ID', '0.0.9')::jsonb,
+    '[]'::jsonb,
+    'No vulnerability statements expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,86 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+\set package4ID '00000000-0000-0000-0000-000000000004'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id, stars, created_at)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID', 2, current_timestamp - '1 year'::interval);
+insert into snapshot (package_id, version, readme) values (:'package1ID', '1.0.0', 'readme');
+insert into package (package_id, name, latest_version, repository_id, stars, created_at)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo1ID', 0, current_timestamp - '1 year'::interval);
+insert into snapshot (package_id, version, readme) values (:'package2ID', '1.0.0', 'readme');
+insert into package (package_id, name, latest_version, repository_id, stars)
+values (:'package3ID', 'pkg3', '1.0.0', :'repo1ID', 0);
+insert into snapshot (package_id, version, readme) values (:'package3ID', '1.0.0', 'readme');
+insert into package (package_id, name, latest_version, repository_id, stars)
+values (:'package4ID', 'pkg4', '1.0.0', :'repo1ID', 0);
+insert into snapshot (package_id, version, deprecated) values (:'package4ID', '1.0.0', true);
+insert into user_starred_package (user_id, package_id, created_at)
+values (:'user1ID', :'package1ID', current_timestamp - '1 day'::interval);
+insert into user_starred_package (user_id, package_id, created_at)
+values (:'user2ID', :'package1ID', current_timestamp - '20 days'::interval);
+insert into package_views values (:'package2ID', '1.0.0', current_date - 2, 15);
+insert into package_views values (:'package2ID', '1.0.0', current_date - 15, 100);
+insert into package_views values (:'package3ID', '1.0.0', current_date, 5);
+insert into package_views values (:'package4ID', '1.0.0', current_date, 50);
+insert into package_ranking (ranking, package_id, score) values ('trending-7d', :'package4ID', 1000);
+
+-- Run some tests
+select refresh_packages_rankings(2);
+select results_eq(
+    $$
+        select package_id::text, score from package_ranking
+        where ranking = 'trending-7d'
+        order by score desc
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000002', 15::real),
+            ('00000000-0000-0000-0000-000000000001', 10::real),
+            ('00000000-0000-0000-0000-000000000003', 5::real)
+    $$,
+    'Trending packages in the last 7 days should be package2, package1 and package3'
+);
+select results_eq(
+    $$
+        select package_id::text, score from package_ranking
+        where ranking = 'trending-30d'
+        order by score desc
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000002', 115::real),
+            ('00000000-0000-0000-0000-000000000001', 20::real),
+            ('00000000-0000-0000-0000-000000000003', 5::real)
+    $$,
+    'Trending packages in the last 30 days should be package2, package1 and package3'
+);
+select results_eq(
+    $$
+        select package_id::text, score from package_ranking
+        where ranking = 'new'
+        order by score desc
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000003', 5::real)
+    $$,
+    'Only package3 is new and noteworthy (package4 is deprecated)'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,56 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '4.0.0', :'repo1ID');
+insert into snapshot (package_id, version, support)
+values (:'package1ID', '1.0.0', '{"status": "eol"}');
+insert into snapshot (package_id, version, support)
+values (:'package1ID', '2.0.0', jsonb_build_object('status', 'maintenance', 'eol_date', current_date - 1));
+insert into snapshot (package_id, version, support)
+values (:'package1ID', '3.0.0', jsonb_build_object('status', 'supported', 'eol_date', current_date + 30));
+insert into snapshot (package_id, version)
+values (:'package1ID', '4.0.0');
+
+-- Run some tests
+select register_eol_events(1);
+select results_eq(
+    $$
+        select package_version from event
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and event_kind_id = 6
+        order by package_version asc
+    $$,
+    $$ values ('1.0.0'), ('2.0.0') $$,
+    'Events should be registered for the versions that have reached their end of life'
+);
+select results_eq(
+    $$
+        select version from snapshot
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and eol_event_registered = true
+        order by version asc
+    $$,
+    $$ values ('1.0.0'), ('2.0.0') $$,
+    'Snapshots should be marked as having their end of life event registered'
+);
+select register_eol_events(1);
+select is(
+    (select count(*) from event where event_kind_id = 6),
+    2::bigint,
+    'Events should only be registered once per version'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,70 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set digest1 'sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71'
+\set digest2 'sha256:5e6adef17f723fff566994a8e35003066b700e80962ab9674d979a41672ca521'
+
+-- Seed some data
+insert into image_scan (digest, report, created_at)
+values (:'digest2', '{"SchemaVersion": 2}', current_timestamp - '2 months'::interval);
+
+-- Register image scan and check it succeeded
+select register_image_scan('{
+    "digest": "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71",
+    "report": {"SchemaVersion": 2},
+    "sboms": {"cyclonedx": {"bomFormat": "CycloneDX"}}
+}');
+select results_eq(
+    $$
+        select digest, report, sboms
+        from image_scan
+        where digest = 'sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71'
+    $$,
+    $$
+        values (
+            'sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71',
+            '{"SchemaVersion": 2}'::jsonb,
+            '{"cyclonedx": {"bomFormat": "CycloneDX"}}'::jsonb
+        )
+    $$,
+    'Image scan should be registered'
+);
+select is_empty(
+    $$ select * from image_scan where digest = 'sha256:5e6adef17f723fff566994a8e35003066b700e80962ab9674d979a41672ca521' $$,
+    'Stale image scan should have been deleted'
+);
+
+-- Register image scan again and check it was updated
+update image_scan set created_at = current_timestamp - '1 day'::interval;
+select register_image_scan('{
+    "digest": "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71",
+    "report": {"SchemaVersion": 2, "Results": []}
+}');
+select results_eq(
+    $$
+        select report, sboms
+        from image_scan
+        where digest = 'sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71'
+    $$,
+    $$
+        values (
+            '{"SchemaVersion": 2, "Results": []}'::jsonb,
+            null::jsonb
+        )
+    $$,
+    'Image scan should be updated'
+);
+select isnt_empty(
+    $$
+        select * from image_scan
+        where digest = 'sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71'
+        and created_at = current_timestamp
+    $$,
+    'Image scan creation timestamp should be refreshed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(14);
+select plan(18);
 
 -- Declare some variables
 \set org1ID '00000000-0000-0000-0000-000000000001'
@@ -20,6 +20,7 @@ select register_package('
     "name": "package1",
     "logo_url": "logo_url",
     "logo_image_id": "00000000-0000-0000-0000-000000000001",
+    "logo_dark_image_id": "00000000-0000-0000-0000-000000000002",
     "channels": [
         {
             "name": "stable",
@@ -107,7 +108,14 @@ select register_package('
     "screenshots": [
         {
             "title": "Screenshot 1",
-            "url": "https://artifacthub.io/screenshot1.jpg"
+            "url": "https://artifacthub.io/screenshot1.jpg",
+            "image_id": "00000000-0000-0000-0000-000000000003"
+        }
+    ],
+    "videos": [
+        {
+            "title": "Video 1",
+            "url": "https://artifacthub.io/video1.mp4"
         }
     ],
     "sign_key": {
@@ -160,6 +168,7 @@ select results_eq(
             s.description,
             s.logo_url,
             s.logo_image_id,
+            s.logo_dark_image_id,
             s.keywords,
             s.home_url,
             s.app_version,
@@ -183,6 +192,7 @@ select results_eq(
             s.prerelease,
             s.recommendations,
             s.screenshots,
+            s.videos,
             s.sign_key,
             s.ts
         from snapshot s
@@ -197,6 +207,7 @@ select results_eq(
             'description',
             'logo_url',
             '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000002'::uuid,
             '{kw1,kw2}'::text[],
             'home_url',
             '12.1.0',
@@ -228,7 +239,14 @@ select results_eq(
             '[
                 {
                     "title": "Screenshot 1",
-                    "url": "https://artifacthub.io/screenshot1.jpg"
+                    "url": "https://artifacthub.io/screenshot1.jpg",
+                    "image_id": "00000000-0000-0000-0000-000000000003"
+                }
+            ]'::jsonb,
+            '[
+                {
+                    "title": "Video 1",
+                    "url": "https://artifacthub.io/video1.mp4"
                 }
             ]'::jsonb,
             '{"fingerprint": "0011223344", "url": "https://key.url"}'::jsonb,
@@ -281,8 +299,24 @@ select register_package('
     "app_version": "13.0.0",
     "digest": "digest-package1-2.0.0",
     "deprecated": true,
+    "replaced_by": "https://artifacthub.io/packages/helm/repo1/package2",
     "signed": true,
     "signatures": ["prov", "cosign"],
+    "signature_verified": true,
+    "provenance": {
+        "builder_id": "https://github.com/actions/runner",
+        "source_repo": "https://github.com/org/repo",
+        "commit": "0123456789abcdef0123456789abcdef01234567"
+    },
+    "content_warnings": [
+        {
+            "kind": "secret",
+            "rule": "private-key",
+            "file": "files/key.pem",
+            "line": 1,
+            "description": "Private key embedded in package content"
+        }
+    ],
     "is_operator": false,
     "capabilities": "seamless upgrades",
     "containers_images": [
@@ -330,8 +364,12 @@ select results_eq(
             s.links,
             s.capabilities,
             s.deprecated,
+            s.replaced_by,
             s.signed,
             s.signatures,
+            s.signature_verified,
+            s.provenance,
+            s.content_warnings,
             s.containers_images,
             s.provider,
             s.values_schema,
@@ -360,8 +398,22 @@ select results_eq(
             null::jsonb,
             'seamless upgrades',
             true,
+            'https://artifacthub.io/packages/helm/repo1/package2',
             true,
             '{"prov","cosign"}'::text[],
+            true,
+            '{
+                "builder_id": "https://github.com/actions/runner",
+                "source_repo": "https://github.com/org/repo",
+                "commit": "0123456789abcdef0123456789abcdef01234567"
+            }'::jsonb,
+            '[{
+                "kind": "secret",
+                "rule": "private-key",
+                "file": "files/key.pem",
+                "line": 1,
+                "description": "Private key embedded in package content"
+            }]'::jsonb,
             '[{"image": "quay.io/org/img:2.0.0"}]'::jsonb,
             'Org Inc 2',
             null::jsonb,
@@ -407,6 +459,17 @@ select isnt_empty(
     $$,
     'New release event should exist for package1 version 2.0.0'
 );
+select isnt_empty(
+    $$
+        select *
+        from event e
+        join package p using (package_id)
+        where p.name = 'package1'
+        and e.package_version = '2.0.0'
+        and e.event_kind_id = 5
+    $$,
+    'Content warnings event should exist for package1 version 2.0.0'
+);
 
 -- Register an old version of the package previously registered
 select register_package('
@@ -538,6 +601,92 @@ select is_empty(
     'No new release event should exist for package1 version 0.0.9'
 );
 
+-- Unregister the latest version of the package and register it again
+select unregister_package('
+{
+    "name": "package1",
+    "version": "2.0.0",
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select register_package('
+{
+    "name": "package1",
+    "version": "2.0.0",
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select results_eq(
+    $$
+        select count(*)
+        from event e
+        join package p using (package_id)
+        where p.name = 'package1'
+        and e.package_version = '2.0.0'
+        and e.event_kind_id = 0
+    $$,
+    $$ values (1::bigint) $$,
+    'New release event for package1 version 2.0.0 should not have been registered again'
+);
+
+-- Register several versions of a package not in order and check the latest
+-- version is the highest one
+select register_package('
+{
+    "name": "package3",
+    "description": "description-version-1.0.0",
+    "version": "1.0.0",
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select register_package('
+{
+    "name": "package3",
+    "description": "description-version-3.0.0",
+    "version": "3.0.0",
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select register_package('
+{
+    "name": "package3",
+    "description": "description-version-2.0.0",
+    "version": "2.0.0",
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select results_eq(
+    $$
+        select p.latest_version, s.description
+        from package p
+        join snapshot s on s.package_id = p.package_id and s.version = p.latest_version
+        where p.name = 'package3'
+    $$,
+    $$ values ('3.0.0', 'description-version-3.0.0') $$,
+    'Package3 latest version should be 3.0.0 regardless of the registration order'
+);
+select results_eq(
+    $$
+        select e.package_version
+        from event e
+        join package p using (package_id)
+        where p.name = 'package3'
+        and e.event_kind_id = 0
+    $$,
+    $$ values ('3.0.0') $$,
+    'Only a new release event for package3 version 3.0.0 should exist'
+);
+
 -- Disable repository and check that trying to register a package raises an error
 update repository set disabled = true where repository_id = :'repo1ID';
 select throws_ok(
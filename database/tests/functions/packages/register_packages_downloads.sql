@@ -0,0 +1,99 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo2ID');
+insert into package_downloads values (:'package1ID', '1.0.0', '2021-12-07', 5);
+
+-- Run some tests
+select register_packages_downloads(:'user1ID', 'repo1', '
+[
+    {"package_name": "pkg1", "version": "1.0.0", "day": "2021-12-08", "total": 10},
+    {"package_name": "pkg1", "version": "1.0.0", "day": "2021-12-08", "total": 2},
+    {"package_name": "pkg1", "version": "0.9.0", "day": "2021-12-08", "total": 3},
+    {"package_name": "pkg2", "version": "1.0.0", "day": "2021-12-08", "total": 100},
+    {"package_name": "pkg3", "version": "1.0.0", "day": "2021-12-08", "total": 100}
+]
+');
+select results_eq(
+    $$
+        select version, day::text, total
+        from package_downloads
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        order by version, day
+    $$,
+    $$
+        values
+            ('0.9.0', '2021-12-08', 3),
+            ('1.0.0', '2021-12-07', 5),
+            ('1.0.0', '2021-12-08', 12)
+    $$,
+    'Package1 downloads should have been registered'
+);
+select is(
+    (select downloads from package where package_id = :'package1ID'),
+    20::bigint,
+    'Package1 total downloads should have been updated'
+);
+select is_empty(
+    $$ select * from package_downloads where package_id = '00000000-0000-0000-0000-000000000002' $$,
+    'Package2 does not belong to repo1, downloads should not have been registered'
+);
+select register_packages_downloads(:'user1ID', 'repo1', '
+[
+    {"package_name": "pkg1", "version": "1.0.0", "day": "2021-12-08", "total": 7}
+]
+');
+select is(
+    (select downloads from package where package_id = :'package1ID'),
+    15::bigint,
+    'Package1 downloads for 2021-12-08 should have been replaced'
+);
+select lives_ok(
+    $$
+        select register_packages_downloads('00000000-0000-0000-0000-000000000001', 'repo2', '[]')
+    $$,
+    'User1 belongs to org1, request should succeed'
+);
+select throws_ok(
+    $$
+        select register_packages_downloads('00000000-0000-0000-0000-000000000002', 'repo1', '[]')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not own repo1, request should fail'
+);
+select throws_ok(
+    $$
+        select register_packages_downloads('00000000-0000-0000-0000-000000000001', 'repo3', '[]')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repository does not exist, request should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
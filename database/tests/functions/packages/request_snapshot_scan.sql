@@ -0,0 +1,121 @@
+-- Start transaction and plan tests
+begin;
+select plan(10);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, scanner_disabled)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'user1ID', true);
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, containers_images)
+values (:'package1ID', '1.0.0', '[{"image": "quay.io/org/pkg1:1.0.0"}]');
+insert into snapshot (package_id, version, containers_images)
+values (:'package1ID', '0.9.0', '[{"image": "quay.io/org/pkg1:0.9.0", "whitelisted": true}]');
+insert into snapshot (package_id, version, containers_images, ts)
+values (:'package1ID', '0.8.0', '[{"image": "quay.io/org/pkg1:0.8.0"}]', current_timestamp - '2 years'::interval);
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version, containers_images)
+values (:'package2ID', '1.0.0', '[{"image": "quay.io/org/pkg2:1.0.0"}]');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'pkg3', '1.0.0', :'repo3ID');
+insert into snapshot (package_id, version, containers_images)
+values (:'package3ID', '1.0.0', '[{"image": "quay.io/org/pkg3:1.0.0"}]');
+
+-- Run some tests
+select is(
+    request_snapshot_scan(:'user1ID', :'package1ID', '1.0.0'),
+    true,
+    'Scan of snapshot owned by user should be requested'
+);
+select isnt_empty(
+    $$
+        select * from snapshot
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and version = '1.0.0'
+        and security_scan_requested_at is not null
+    $$,
+    'Snapshot scan request should have been registered'
+);
+select is(
+    request_snapshot_scan(:'user1ID', :'package1ID', '1.0.0'),
+    false,
+    'Scan was requested recently, new request should be rejected'
+);
+select is(
+    request_snapshot_scan(:'user1ID', :'package2ID', '1.0.0'),
+    true,
+    'Scan of snapshot owned by organization user belongs to should be requested'
+);
+select throws_ok(
+    $$
+        select request_snapshot_scan('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '1.0.0')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not own repo1, request should fail'
+);
+select throws_ok(
+    $$
+        select request_snapshot_scan('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', '1.0.0')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not belong to org1, request should fail'
+);
+select throws_ok(
+    $$
+        select request_snapshot_scan('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '2.0.0')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Snapshot does not exist, request should fail'
+);
+select throws_ok(
+    $$
+        select request_snapshot_scan('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '0.9.0')
+    $$,
+    'P0001',
+    'snapshot not scannable: no containers images to scan',
+    'Snapshot has no images to scan, request should fail'
+);
+select throws_ok(
+    $$
+        select request_snapshot_scan('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '0.8.0')
+    $$,
+    'P0001',
+    'snapshot not scannable: versions older than one year are not scanned',
+    'Snapshot is older than one year, request should fail'
+);
+select throws_ok(
+    $$
+        select request_snapshot_scan('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003', '1.0.0')
+    $$,
+    'P0001',
+    'snapshot not scannable: security scanning is disabled for this repository',
+    'Scanner is disabled for repo3, request should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(29);
+select plan(32);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -246,6 +246,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -276,6 +277,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -302,6 +304,7 @@ select results_eq(
                         "name": "package3",
                         "normalized_name": "package3",
                         "stars": 0,
+                        "downloads": 0,
                         "display_name": "Package 3",
                         "description": "description",
                         "logo_image_id": "00000000-0000-0000-0000-000000000003",
@@ -414,6 +417,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -444,6 +448,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -470,6 +475,7 @@ select results_eq(
                         "name": "package3",
                         "normalized_name": "package3",
                         "stars": 0,
+                        "downloads": 0,
                         "display_name": "Package 3",
                         "description": "description",
                         "logo_image_id": "00000000-0000-0000-0000-000000000003",
@@ -516,6 +522,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -559,6 +566,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -603,6 +611,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -633,6 +642,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -678,6 +688,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -708,6 +719,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -753,6 +765,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -779,6 +792,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -884,6 +898,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -995,6 +1010,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -1043,6 +1059,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -1374,6 +1391,7 @@ select results_eq(
                         "name": "package3",
                         "normalized_name": "package3",
                         "stars": 0,
+                        "downloads": 0,
                         "display_name": "Package 3",
                         "description": "description",
                         "logo_image_id": "00000000-0000-0000-0000-000000000003",
@@ -1422,6 +1440,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -1470,6 +1489,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -1496,6 +1516,7 @@ select results_eq(
                         "name": "package3",
                         "normalized_name": "package3",
                         "stars": 0,
+                        "downloads": 0,
                         "display_name": "Package 3",
                         "description": "description",
                         "logo_image_id": "00000000-0000-0000-0000-000000000003",
@@ -1546,6 +1567,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -1593,6 +1615,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -1641,6 +1664,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -1667,6 +1691,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -1717,6 +1742,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -1782,6 +1808,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -1929,6 +1956,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -1955,6 +1983,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -2004,6 +2033,7 @@ select results_eq(
                         "name": "package2",
                         "normalized_name": "package2",
                         "stars": 11,
+                        "downloads": 0,
                         "official": true,
                         "display_name": "Package 2",
                         "description": "description",
@@ -2034,6 +2064,7 @@ select results_eq(
                         "name": "package1",
                         "normalized_name": "package1",
                         "stars": 10,
+                        "downloads": 0,
                         "official": false,
                         "display_name": "Package 1",
                         "description": "description",
@@ -2063,6 +2094,161 @@ select results_eq(
     'Sort: stars TSQueryWeb: kw1 | Packages 2 and 1 expected'
 );
 
+update snapshot set signature_verified = true where package_id = :'package2ID';
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer from search_packages('{
+            "limit": 10,
+            "offset": 0,
+            "ts_query_web": "kw1",
+            "deprecated": true,
+            "signature_verified": true
+        }')
+    $$,
+    $$
+        values (
+            '{
+                "packages": [
+                    {
+                        "package_id": "00000000-0000-0000-0000-000000000002",
+                        "name": "package2",
+                        "normalized_name": "package2",
+                        "stars": 11,
+                        "downloads": 0,
+                        "official": true,
+                        "display_name": "Package 2",
+                        "description": "description",
+                        "logo_image_id": "00000000-0000-0000-0000-000000000002",
+                        "version": "1.0.0",
+                        "app_version": "12.1.0",
+                        "deprecated": true,
+                        "signed": true,
+                        "signatures": ["cosign"],
+                        "signature_verified": true,
+                        "all_containers_images_whitelisted": false,
+                        "production_organizations_count": 0,
+                        "ts": 1592299234,
+                        "repository": {
+                            "repository_id": "00000000-0000-0000-0000-000000000002",
+                            "kind": 0,
+                            "name": "repo2",
+                            "display_name": "Repo 2",
+                            "url": "https://repo2.com",
+                            "verified_publisher": false,
+                            "official": false,
+                            "scanner_disabled": false,
+                            "organization_name": "org1",
+                            "organization_display_name": "Organization 1"
+                        }
+                    }
+                ]
+            }'::jsonb,
+            1
+        )
+    $$,
+    'TSQueryWeb: kw1 SignatureVerified: true | Package 2 expected'
+);
+
+update snapshot set k8s_compatibility = '[{"min": "1.29"}]' where package_id = :'package1ID';
+update snapshot set k8s_compatibility = '[{"min": "1.20", "max": "1.28"}]' where package_id = :'package2ID';
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer from search_packages('{
+            "limit": 10,
+            "offset": 0,
+            "ts_query_web": "kw1",
+            "deprecated": true,
+            "k8s_version": "1.29"
+        }')
+    $$,
+    $$
+        values (
+            '{
+                "packages": [
+                    {
+                        "package_id": "00000000-0000-0000-0000-000000000001",
+                        "name": "package1",
+                        "normalized_name": "package1",
+                        "stars": 10,
+                        "downloads": 0,
+                        "official": false,
+                        "display_name": "Package 1",
+                        "description": "description",
+                        "logo_image_id": "00000000-0000-0000-0000-000000000001",
+                        "version": "1.0.0",
+                        "app_version": "12.1.0",
+                        "license": "Apache-2.0",
+                        "production_organizations_count": 1,
+                        "ts": 1592299234,
+                        "repository": {
+                            "repository_id": "00000000-0000-0000-0000-000000000001",
+                            "kind": 0,
+                            "name": "repo1",
+                            "display_name": "Repo 1",
+                            "url": "https://repo1.com",
+                            "verified_publisher": true,
+                            "official": true,
+                            "scanner_disabled": false,
+                            "user_alias": "user1"
+                        }
+                    }
+                ]
+            }'::jsonb,
+            1
+        )
+    $$,
+    'TSQueryWeb: kw1 K8sVersion: 1.29 | Package 1 expected'
+);
+
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer from search_packages('{
+            "limit": 10,
+            "offset": 0,
+            "ts_query_web": "kw1",
+            "deprecated": true,
+            "license_families": ["permissive"]
+        }')
+    $$,
+    $$
+        values (
+            '{
+                "packages": [
+                    {
+                        "package_id": "00000000-0000-0000-0000-000000000001",
+                        "name": "package1",
+                        "normalized_name": "package1",
+                        "stars": 10,
+                        "downloads": 0,
+                        "official": false,
+                        "display_name": "Package 1",
+                        "description": "description",
+                        "logo_image_id": "00000000-0000-0000-0000-000000000001",
+                        "version": "1.0.0",
+                        "app_version": "12.1.0",
+                        "license": "Apache-2.0",
+                        "production_organizations_count": 1,
+                        "ts": 1592299234,
+                        "repository": {
+                            "repository_id": "00000000-0000-0000-0000-000000000001",
+                            "kind": 0,
+                            "name": "repo1",
+                            "display_name": "Repo 1",
+                            "url": "https://repo1.com",
+                            "verified_publisher": true,
+                            "official": true,
+                            "scanner_disabled": false,
+                            "user_alias": "user1"
+                        }
+                    }
+                ]
+            }'::jsonb,
+            1
+        )
+    $$,
+    'TSQueryWeb: kw1 LicenseFamilies: permissive | Package 1 expected'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(14);
+select plan(20);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -67,6 +67,10 @@ select is(security_report_created_at, null, 'Security report created at should b
 from snapshot where package_id = :'package1ID' and version = '1.0.0';
 select is(security_report_summary, null, 'Security report summary should be null')
 from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is_empty(
+    $$ select * from snapshot_sbom where package_id = '00000000-0000-0000-0000-000000000001' $$,
+    'SBOM should not exist'
+);
 select update_snapshot_security_report('{
     "package_id": "00000000-0000-0000-0000-000000000001",
     "version": "1.0.0",
@@ -80,6 +84,21 @@ select update_snapshot_security_report('{
         "quay.io/org/pkg1:1.0.0": [
             {"k": "v"}
         ]
+    },
+    "sboms": {
+        "cyclonedx": {
+            "quay.io/org/pkg1:1.0.0": {"bomFormat": "CycloneDX"}
+        }
+    },
+    "suppressed_vulnerabilities": {
+        "quay.io/org/pkg1:1.0.0": [
+            {"vulnerability_id": "CVE-2022-0001", "status": "not_affected"}
+        ]
+    },
+    "images_licenses": {
+        "quay.io/org/pkg1:1.0.0": [
+            {"license": "MIT", "packages": ["musl"]}
+        ]
     }
 }');
 select is(security_report, '{
@@ -98,6 +117,29 @@ select is(security_report_summary, '{
     "low": 10
 }', 'Security report summary should exist')
 from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select results_eq(
+    $$
+        select format, image, sbom from snapshot_sbom
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and version = '1.0.0'
+    $$,
+    $$
+        values ('cyclonedx', 'quay.io/org/pkg1:1.0.0', '{"bomFormat": "CycloneDX"}'::jsonb)
+    $$,
+    'SBOM should exist'
+);
+select is(images_licenses, '{
+    "quay.io/org/pkg1:1.0.0": [
+        {"license": "MIT", "packages": ["musl"]}
+    ]
+}', 'Images licenses should exist')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is(security_report_suppressed, '{
+    "quay.io/org/pkg1:1.0.0": [
+        {"vulnerability_id": "CVE-2022-0001", "status": "not_affected"}
+    ]
+}', 'Security report suppressed vulnerabilities should exist')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
 
 -- Test security alert events
 select update_snapshot_security_report('{
@@ -192,6 +234,41 @@ from event e
 join package p using (package_id)
 where p.name = 'package2' and e.package_version = '1.1.0';
 
+select update_snapshot_security_report('{
+    "package_id": "00000000-0000-0000-0000-000000000002",
+    "version": "1.1.0",
+    "alert_digest": "digest-d",
+    "images_reports": {
+        "quay.io/org/pkg2:1.1.0": {
+            "Results": [{"Vulnerabilities": [{"VulnerabilityID": "CVE-1", "Severity": "HIGH"}]}]
+        }
+    }
+}');
+select is(
+    (select data from event where package_version = '1.1.0' and data->'new_vulnerabilities' ? 'high'),
+    '{"new_vulnerabilities": {"high": 1}}'::jsonb,
+    'Security alert event for digest-d should include the new high vulnerability'
+);
+
+select update_snapshot_security_report('{
+    "package_id": "00000000-0000-0000-0000-000000000002",
+    "version": "1.1.0",
+    "alert_digest": "digest-e",
+    "images_reports": {
+        "quay.io/org/pkg2:1.1.0": {
+            "Results": [{"Vulnerabilities": [
+                {"VulnerabilityID": "CVE-1", "Severity": "HIGH"},
+                {"VulnerabilityID": "CVE-2", "Severity": "CRITICAL"}
+            ]}]
+        }
+    }
+}');
+select is(
+    (select data from event where package_version = '1.1.0' and data->'new_vulnerabilities' ? 'critical'),
+    '{"new_vulnerabilities": {"critical": 1}}'::jsonb,
+    'Security alert event for digest-e should only include the new critical vulnerability'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
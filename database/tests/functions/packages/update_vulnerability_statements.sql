@@ -0,0 +1,123 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version) values (:'package1ID', '1.0.0');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version) values (:'package2ID', '1.0.0');
+insert into vulnerability_statement (package_id, version, vulnerability_id, status)
+values (:'package1ID', '1.0.0', 'CVE-2022-0000', 'acknowledged');
+
+-- Run some tests
+select update_vulnerability_statements(:'user1ID', :'package1ID', '1.0.0', '[
+    {
+        "vulnerability_id": "CVE-2022-0001",
+        "status": "not_affected",
+        "justification": "Vulnerable code not present"
+    },
+    {
+        "vulnerability_id": "CVE-2022-0002",
+        "status": "acknowledged"
+    }
+]');
+select results_eq(
+    $$
+        select vulnerability_id, status, justification
+        from vulnerability_statement
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and version = '1.0.0'
+        order by vulnerability_id
+    $$,
+    $$
+        values
+            ('CVE-2022-0001', 'not_affected', 'Vulnerable code not present'),
+            ('CVE-2022-0002', 'acknowledged', null)
+    $$,
+    'Vulnerability statements should have been replaced'
+);
+select isnt_empty(
+    $$
+        select * from snapshot
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and version = '1.0.0'
+        and security_scan_requested_at is not null
+    $$,
+    'Snapshot scan should have been requested'
+);
+select update_vulnerability_statements(:'user1ID', :'package2ID', '1.0.0', '[
+    {
+        "vulnerability_id": "CVE-2022-0003",
+        "status": "acknowledged"
+    }
+]');
+select results_eq(
+    $$
+        select vulnerability_id, status
+        from vulnerability_statement
+        where package_id = '00000000-0000-0000-0000-000000000002'
+        and version = '1.0.0'
+    $$,
+    $$
+        values ('CVE-2022-0003', 'acknowledged')
+    $$,
+    'Vulnerability statements of snapshot owned by organization user belongs to should have been updated'
+);
+select update_vulnerability_statements(:'user1ID', :'package2ID', '1.0.0', '[]');
+select is_empty(
+    $$
+        select * from vulnerability_statement
+        where package_id = '00000000-0000-0000-0000-000000000002'
+        and version = '1.0.0'
+    $$,
+    'Vulnerability statements should have been removed'
+);
+select throws_ok(
+    $$
+        select update_vulnerability_statements('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '1.0.0', '[]')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not own repo1, update should fail'
+);
+select throws_ok(
+    $$
+        select update_vulnerability_statements('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', '1.0.0', '[]')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not belong to org1, update should fail'
+);
+select throws_ok(
+    $$
+        select update_vulnerability_statements('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '2.0.0', '[]')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Snapshot does not exist, update should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,103 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set change1ID '00000000-0000-0000-0000-000000000001'
+\set change2ID '00000000-0000-0000-0000-000000000002'
+\set change3ID '00000000-0000-0000-0000-000000000003'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, repository_changes_approval)
+values (:'org1ID', 'org1', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into repository_change (repository_change_id, organization_id, kind, repository_name, repository, requested_by)
+values (:'change1ID', :'org1ID', 'add', 'repo2', '
+{
+    "name": "repo2",
+    "display_name": "Repo 2",
+    "url": "https://repo2.com",
+    "kind": 0,
+    "disabled": false,
+    "scanner_disabled": false
+}
+', :'user1ID');
+insert into repository_change (repository_change_id, organization_id, kind, repository_name, requested_by)
+values (:'change2ID', :'org1ID', 'delete', 'repo1', :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'org1ID');
+insert into repository_change (repository_change_id, organization_id, kind, repository_name, requested_by)
+values (:'change3ID', :'org1ID', 'transfer', 'repo3', :'user1ID');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select approve_repository_change(
+            '00000000-0000-0000-0000-000000000001',
+            'org1',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Change should not be approved by the user who requested it'
+);
+select approve_repository_change(:'user2ID', 'org1', :'change1ID');
+select results_eq(
+    $$
+        select name, url, organization_id from repository where name = 'repo2'
+    $$,
+    $$
+        values ('repo2', 'https://repo2.com', '00000000-0000-0000-0000-000000000001'::uuid)
+    $$,
+    'Repository should have been added'
+);
+select approve_repository_change(:'user2ID', 'org1', :'change2ID');
+select is_empty(
+    $$
+        select * from repository where name = 'repo1'
+    $$,
+    'Repository should have been deleted'
+);
+select approve_repository_change(:'user2ID', 'org1', :'change3ID');
+select results_eq(
+    $$
+        select user_id, organization_id from repository where name = 'repo3'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001'::uuid, null::uuid)
+    $$,
+    'Repository should have been transferred to the user who requested it'
+);
+select is_empty(
+    $$
+        select * from repository_change
+    $$,
+    'Changes should have been deleted once applied'
+);
+select throws_ok(
+    $$
+        select approve_repository_change(
+            '00000000-0000-0000-0000-000000000002',
+            'org1',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    'P0001',
+    'repository change not found',
+    'Change already applied should not be found'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,59 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set change1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository_change (
+    repository_change_id,
+    organization_id,
+    kind,
+    repository_name,
+    repository,
+    requested_by,
+    created_at
+) values (
+    :'change1ID',
+    :'org1ID',
+    'add',
+    'repo1',
+    '{"name": "repo1", "url": "https://repo1.com", "auth_user": "user", "auth_pass": "pass"}',
+    :'user1ID',
+    '2022-01-01 00:00:00+00'
+);
+
+-- Run some tests
+select throws_ok(
+    $$
+        select get_pending_repository_changes('00000000-0000-0000-0000-000000000002', 'org1')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Pending changes should not be returned to users not belonging to the organization'
+);
+select is(
+    get_pending_repository_changes(:'user1ID', 'org1')::jsonb,
+    '[{
+        "repository_change_id": "00000000-0000-0000-0000-000000000001",
+        "kind": "add",
+        "repository_name": "repo1",
+        "repository": {
+            "name": "repo1",
+            "url": "https://repo1.com"
+        },
+        "requested_by": "user1",
+        "created_at": 1640995200
+    }]'::jsonb,
+    'Pending changes should be returned without credentials'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
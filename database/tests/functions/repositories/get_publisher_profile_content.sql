@@ -0,0 +1,150 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+\set image1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, verified_publisher)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', true);
+insert into repository (repository_id, name, url, repository_kind_id, user_id, disabled)
+values (:'repo2ID', 'repo2', 'https://repo2.com', 0, :'user1ID', true);
+insert into repository (repository_id, name, url, repository_kind_id, organization_id, auth_user, auth_pass)
+values (:'repo3ID', 'repo3', 'https://repo3.com', 1, :'org1ID', 'user', 'pass');
+insert into package (package_id, name, latest_version, logo_image_id, stars, repository_id)
+values (:'package1ID', 'package1', '2.0.0', :'image1ID', 5, :'repo1ID');
+insert into snapshot (package_id, version, display_name, description, ts)
+values (:'package1ID', '1.0.0', 'Package 1', 'description', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, display_name, description, ts)
+values (:'package1ID', '2.0.0', 'Package 1', 'description v2', '2020-06-18 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version, ts)
+values (:'package2ID', '1.0.0', '2020-06-17 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'package3', '1.0.0', :'repo3ID');
+insert into snapshot (package_id, version, ts)
+values (:'package3ID', '1.0.0', '2020-06-17 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_publisher_profile_content(:'user1ID', null),
+    '{
+        "repositories": [
+            {
+                "name": "repo1",
+                "display_name": "Repo 1",
+                "url": "https://repo1.com",
+                "private": false,
+                "kind": 0,
+                "verified_publisher": true,
+                "official": false,
+                "packages_count": 1
+            }
+        ],
+        "packages": [
+            {
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "name": "package1",
+                "normalized_name": "package1",
+                "logo_image_id": "00000000-0000-0000-0000-000000000001",
+                "stars": 5,
+                "version": "2.0.0",
+                "display_name": "Package 1",
+                "description": "description v2",
+                "repository_name": "repo1",
+                "repository_kind_id": 0
+            }
+        ],
+        "activity": [
+            {
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "name": "package1",
+                "normalized_name": "package1",
+                "version": "2.0.0",
+                "repository_name": "repo1",
+                "repository_kind_id": 0,
+                "ts": 1592472034
+            },
+            {
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "name": "package1",
+                "normalized_name": "package1",
+                "version": "1.0.0",
+                "repository_name": "repo1",
+                "repository_kind_id": 0,
+                "ts": 1592299234
+            }
+        ],
+        "packages_count": 1,
+        "stars": 5
+    }'::jsonb,
+    'User content should not include disabled repositories'
+);
+select is(
+    get_publisher_profile_content(null, :'org1ID'),
+    '{
+        "repositories": [
+            {
+                "name": "repo3",
+                "url": "https://repo3.com",
+                "private": true,
+                "kind": 1,
+                "verified_publisher": false,
+                "official": false,
+                "packages_count": 1
+            }
+        ],
+        "packages": [
+            {
+                "package_id": "00000000-0000-0000-0000-000000000003",
+                "name": "package3",
+                "normalized_name": "package3",
+                "stars": 0,
+                "version": "1.0.0",
+                "repository_name": "repo3",
+                "repository_kind_id": 1
+            }
+        ],
+        "activity": [
+            {
+                "package_id": "00000000-0000-0000-0000-000000000003",
+                "name": "package3",
+                "normalized_name": "package3",
+                "version": "1.0.0",
+                "repository_name": "repo3",
+                "repository_kind_id": 1,
+                "ts": 1592385634
+            }
+        ],
+        "packages_count": 1,
+        "stars": 0
+    }'::jsonb,
+    'Organization content should be returned'
+);
+select is(
+    get_publisher_profile_content('00000000-0000-0000-0000-000000000002', null),
+    '{
+        "repositories": [],
+        "packages": [],
+        "activity": [],
+        "packages_count": 0,
+        "stars": 0
+    }'::jsonb,
+    'Empty content should be returned for publishers without repositories'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
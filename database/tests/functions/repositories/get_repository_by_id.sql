@@ -31,6 +31,7 @@ insert into repository (
     last_scanning_errors,
     last_tracking_ts,
     last_tracking_errors,
+    last_tracking_errors_details,
     data
 )
 values (
@@ -48,6 +49,7 @@ values (
     'error1\nerror2\n',
     '2020-06-16 11:20:34+02',
     'error1\nerror2\n',
+    '[{"code": "network", "message": "error1"}, {"code": "unknown", "message": "error2"}]',
     '{"k1": "v1"}'
 );
 insert into repository (
@@ -94,11 +96,16 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "version": 1,
         "digest": "digest",
         "last_scanning_ts": 1592299234,
         "last_scanning_errors": "error1\\nerror2\\n",
         "last_tracking_ts": 1592299234,
         "last_tracking_errors": "error1\\nerror2\\n",
+        "last_tracking_errors_details": [
+            {"code": "network", "message": "error1"},
+            {"code": "unknown", "message": "error2"}
+        ],
         "user_alias": "user1",
         "data": {"k1": "v1"}
     }'::jsonb,
@@ -120,11 +127,16 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "version": 1,
         "digest": "digest",
         "last_scanning_ts": 1592299234,
         "last_scanning_errors": "error1\\nerror2\\n",
         "last_tracking_ts": 1592299234,
         "last_tracking_errors": "error1\\nerror2\\n",
+        "last_tracking_errors_details": [
+            {"code": "network", "message": "error1"},
+            {"code": "unknown", "message": "error2"}
+        ],
         "user_alias": "user1",
         "data": {"k1": "v1"}
     }'::jsonb,
@@ -143,6 +155,7 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "version": 1,
         "digest": "digest",
         "last_scanning_ts": 1592299234,
         "last_scanning_errors": "error1\\nerror2\\n",
@@ -165,6 +178,7 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "version": 1,
         "digest": "digest",
         "last_scanning_ts": 1592299234,
         "last_scanning_errors": "error1\\nerror2\\n",
@@ -31,6 +31,7 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "version": 1,
         "user_alias": "user1"
     }'::jsonb,
     'Repository just seeded is returned as a json object'
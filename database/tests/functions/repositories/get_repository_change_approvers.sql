@@ -0,0 +1,34 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set change1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email, locale) values (:'user2ID', 'user2', 'user2@email.com', 'es');
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email) values (:'user4ID', 'user4', 'user4@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user3ID', :'org1ID', false);
+insert into repository_change (repository_change_id, organization_id, kind, repository_name, requested_by)
+values (:'change1ID', :'org1ID', 'delete', 'repo1', :'user1ID');
+
+-- Run some tests
+select is(
+    get_repository_change_approvers(:'change1ID')::jsonb,
+    '[{"email": "user2@email.com", "locale": "es"}]'::jsonb,
+    'Only confirmed members other than the requester should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,137 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set event2ID '00000000-0000-0000-0000-000000000002'
+\set event3ID '00000000-0000-0000-0000-000000000003'
+\set event4ID '00000000-0000-0000-0000-000000000004'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo2ID');
+insert into event (event_id, created_at, package_version, package_id, event_kind_id)
+values (:'event1ID', current_timestamp - '3 seconds'::interval, '1.0.0', :'package1ID', 0);
+insert into event (event_id, created_at, repository_id, event_kind_id, data)
+values (:'event2ID', current_timestamp - '2 seconds'::interval, :'repo1ID', 2, '{"errors": "errors"}');
+insert into event (event_id, created_at, package_version, package_id, event_kind_id)
+values (:'event3ID', current_timestamp - '1 seconds'::interval, '1.0.0', :'package1ID', 1);
+insert into event (event_id, created_at, package_version, package_id, event_kind_id)
+values (:'event4ID', current_timestamp, '1.0.0', :'package2ID', 0);
+
+-- Run some tests
+select throws_ok(
+    $$ select get_repository_events('00000000-0000-0000-0000-000000000002', 'repo1', '{}') $$,
+    42501,
+    'insufficient_privilege',
+    'Users who do not own the repository should not be able to get its events'
+);
+select throws_ok(
+    $$ select get_repository_events('00000000-0000-0000-0000-000000000001', 'repo3', '{}') $$,
+    42501,
+    'insufficient_privilege',
+    'Requests for repositories that do not exist should be rejected'
+);
+select is(
+    (
+        select jsonb_path_query_array(e::jsonb, '$.events[*].event_id')
+        from get_repository_events(:'user1ID', 'repo1', '{}') e
+    ),
+    '[
+        "00000000-0000-0000-0000-000000000003",
+        "00000000-0000-0000-0000-000000000002",
+        "00000000-0000-0000-0000-000000000001"
+    ]'::jsonb,
+    'Repository and packages events should be returned from the most recent to the oldest'
+);
+select is(
+    (
+        select (e::jsonb->'events'->1) - 'created_at'
+        from get_repository_events(:'user1ID', 'repo1', '{}') e
+    ),
+    '{
+        "event_id": "00000000-0000-0000-0000-000000000002",
+        "event_kind": 2,
+        "data": {"errors": "errors"}
+    }'::jsonb,
+    'Repository events should include their data'
+);
+select is(
+    (
+        select jsonb_build_object(
+            'events', jsonb_path_query_array(e::jsonb, '$.events[*].event_id'),
+            'next_event_id', e::jsonb->'next'->'event_id'
+        )
+        from get_repository_events(:'user1ID', 'repo1', '{"limit": 2}') e
+    ),
+    '{
+        "events": [
+            "00000000-0000-0000-0000-000000000003",
+            "00000000-0000-0000-0000-000000000002"
+        ],
+        "next_event_id": "00000000-0000-0000-0000-000000000002"
+    }'::jsonb,
+    'First page of events should be returned including the next cursor'
+);
+select is(
+    (
+        select e::jsonb
+        from get_repository_events(
+            :'user1ID',
+            'repo1',
+            jsonb_build_object(
+                'limit', 2,
+                'cursor', jsonb_build_object(
+                    'created_at', (current_timestamp - '2 seconds'::interval)::text,
+                    'event_id', :'event2ID'
+                )
+            )
+        ) e
+    ) #- '{events,0,created_at}',
+    '{
+        "events": [
+            {
+                "event_id": "00000000-0000-0000-0000-000000000001",
+                "event_kind": 0,
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "package_name": "pkg1",
+                "package_version": "1.0.0"
+            }
+        ]
+    }'::jsonb,
+    'Last page of events should be returned without next cursor'
+);
+select is(
+    (
+        select jsonb_path_query_array(e::jsonb, '$.events[*].event_id')
+        from get_repository_events(:'user1ID', 'repo1', '{"event_kinds": [0, 1], "package_name": "pkg1"}') e
+    ),
+    '[
+        "00000000-0000-0000-0000-000000000003",
+        "00000000-0000-0000-0000-000000000001"
+    ]'::jsonb,
+    'Only the events matching the filters provided should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
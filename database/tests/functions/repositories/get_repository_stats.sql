@@ -0,0 +1,125 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id, created_at)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID', '2021-11-20');
+insert into snapshot (package_id, version) values (:'package1ID', '1.0.0');
+insert into package (package_id, name, latest_version, repository_id, created_at)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo1ID', '2021-12-02');
+insert into snapshot (package_id, version) values (:'package2ID', '1.0.0');
+insert into package_views values (:'package1ID', '1.0.0', '2021-12-01', 10);
+insert into package_views values (:'package1ID', '1.0.0', '2021-12-02', 5);
+insert into package_views values (:'package2ID', '1.0.0', '2021-12-02', 3);
+insert into user_starred_package (user_id, package_id, created_at) values (:'user1ID', :'package1ID', '2021-12-01');
+insert into user_starred_package (user_id, package_id, created_at) values (:'user2ID', :'package1ID', '2021-12-03');
+insert into subscription (user_id, package_id, event_kind_id, created_at) values (:'user1ID', :'package1ID', 0, '2021-12-02');
+insert into subscription (user_id, package_id, event_kind_id, created_at) values (:'user2ID', :'package2ID', 0, '2021-12-02');
+insert into repository_tracking_run (repository_id, duration, outcome, created_at)
+values (:'repo1ID', 10, 'success', '2021-12-01 10:00:00');
+insert into repository_tracking_run (repository_id, duration, outcome, created_at)
+values (:'repo1ID', 20, 'success', '2021-12-01 11:00:00');
+insert into repository_tracking_run (repository_id, duration, outcome, created_at)
+values (:'repo1ID', 30, 'error', '2021-12-02 10:00:00');
+insert into repository_tracking_run (repository_id, duration, outcome, created_at)
+values (:'repo1ID', 40, 'timeout', '2021-12-03 10:00:00');
+
+-- Run some tests
+select is(
+    get_repository_stats(:'user1ID', 'repo1', '2021-12-01', '2021-12-03')::jsonb,
+    '{
+        "packages": {
+            "2021-12-01": 1,
+            "2021-12-02": 2,
+            "2021-12-03": 2
+        },
+        "views": {
+            "2021-12-01": 10,
+            "2021-12-02": 8
+        },
+        "stars": {
+            "2021-12-01": 1,
+            "2021-12-03": 1
+        },
+        "subscriptions": {
+            "2021-12-02": 2
+        },
+        "tracking": {
+            "runs": 4,
+            "errors": 2,
+            "error_rate": 0.5,
+            "avg_duration": 25,
+            "max_duration": 40,
+            "durations": {
+                "2021-12-01": 15,
+                "2021-12-02": 30,
+                "2021-12-03": 40
+            },
+            "last_tracking_ts": null,
+            "last_tracking_errors": false
+        }
+    }'::jsonb,
+    'Repo1 stats should be returned as a json object'
+);
+select is(
+    get_repository_stats(:'user1ID', 'repo2', '2021-12-01', '2021-12-02')::jsonb,
+    '{
+        "packages": {
+            "2021-12-01": 0,
+            "2021-12-02": 0
+        },
+        "views": {},
+        "stars": {},
+        "subscriptions": {},
+        "tracking": {
+            "runs": 0,
+            "errors": 0,
+            "error_rate": 0,
+            "avg_duration": 0,
+            "max_duration": 0,
+            "durations": {},
+            "last_tracking_ts": null,
+            "last_tracking_errors": false
+        }
+    }'::jsonb,
+    'Repo2 has no activity during the period, empty stats expected'
+);
+select throws_ok(
+    $$
+        select get_repository_stats('00000000-0000-0000-0000-000000000002', 'repo1', '2021-12-01', '2021-12-31')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not own repo1, request should fail'
+);
+select throws_ok(
+    $$
+        select get_repository_stats('00000000-0000-0000-0000-000000000002', 'repo2', '2021-12-01', '2021-12-31')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not belong to org1, request should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
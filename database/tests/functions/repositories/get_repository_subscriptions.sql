@@ -0,0 +1,104 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'pkg3', '1.0.0', :'repo2ID');
+insert into subscription (user_id, package_id, event_kind_id) values (:'user2ID', :'package1ID', 0);
+insert into subscription (user_id, package_id, event_kind_id) values (:'user2ID', :'package1ID', 1);
+insert into subscription (user_id, package_id, event_kind_id) values (:'user3ID', :'package1ID', 0);
+insert into subscription (user_id, package_id, event_kind_id) values (:'user3ID', :'package2ID', 1);
+insert into subscription (user_id, package_id, event_kind_id) values (:'user3ID', :'package3ID', 0);
+
+-- Run some tests
+select is(
+    get_repository_subscriptions(:'user1ID', 'repo1')::jsonb,
+    '{
+        "total": 2,
+        "packages": {
+            "pkg1": {
+                "total": 2,
+                "event_kinds": {
+                    "0": 2,
+                    "1": 1
+                }
+            },
+            "pkg2": {
+                "total": 1,
+                "event_kinds": {
+                    "1": 1
+                }
+            }
+        }
+    }'::jsonb,
+    'Repo1 subscriptions should be returned as a json object'
+);
+select is(
+    get_repository_subscriptions(:'user1ID', 'repo2')::jsonb,
+    '{
+        "total": 1,
+        "packages": {
+            "pkg3": {
+                "total": 1,
+                "event_kinds": {
+                    "0": 1
+                }
+            }
+        }
+    }'::jsonb,
+    'Repo2 subscriptions should be returned as a json object'
+);
+select throws_ok(
+    $$
+        select get_repository_subscriptions('00000000-0000-0000-0000-000000000002', 'repo1')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not own repo1, request should fail'
+);
+select throws_ok(
+    $$
+        select get_repository_subscriptions('00000000-0000-0000-0000-000000000002', 'repo2')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not belong to org1, request should fail'
+);
+select throws_ok(
+    $$
+        select get_repository_subscriptions('00000000-0000-0000-0000-000000000001', 'repo3')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repository does not exist, request should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
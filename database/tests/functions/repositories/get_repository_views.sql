@@ -0,0 +1,97 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.1', :'repo1ID');
+insert into snapshot (package_id, version) values (:'package1ID', '1.0.0');
+insert into snapshot (package_id, version) values (:'package1ID', '1.0.1');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'pkg2', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version) values (:'package2ID', '1.0.0');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'pkg3', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version) values (:'package3ID', '1.0.0');
+insert into package_views values (:'package1ID', '1.0.0', '2021-10-08', 10);
+insert into package_views values (:'package1ID', '1.0.0', '2021-12-08', 10);
+insert into package_views values (:'package1ID', '1.0.1', '2021-12-08', 20);
+insert into package_views values (:'package1ID', '1.0.1', '2021-12-09', 5);
+insert into package_views values (:'package2ID', '1.0.0', '2021-12-09', 3);
+insert into package_views values (:'package3ID', '1.0.0', '2021-12-09', 7);
+
+-- Run some tests
+select is(
+    get_repository_views(:'user1ID', 'repo1', '2021-12-01', '2021-12-31')::jsonb,
+    '{
+        "total": {
+            "2021-12-08": 30,
+            "2021-12-09": 8
+        },
+        "packages": {
+            "pkg1": {
+                "2021-12-08": 30,
+                "2021-12-09": 5
+            },
+            "pkg2": {
+                "2021-12-09": 3
+            }
+        }
+    }'::jsonb,
+    'Repo1 views should be returned as a json object'
+);
+select is(
+    get_repository_views(:'user1ID', 'repo2', '2022-01-01', '2022-01-31')::jsonb,
+    '{
+        "total": {},
+        "packages": {}
+    }'::jsonb,
+    'Repo2 has no views during the period, empty objects expected'
+);
+select throws_ok(
+    $$
+        select get_repository_views('00000000-0000-0000-0000-000000000002', 'repo1', '2021-12-01', '2021-12-31')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not own repo1, request should fail'
+);
+select throws_ok(
+    $$
+        select get_repository_views('00000000-0000-0000-0000-000000000002', 'repo2', '2021-12-01', '2021-12-31')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not belong to org1, request should fail'
+);
+select throws_ok(
+    $$
+        select get_repository_views('00000000-0000-0000-0000-000000000001', 'repo3', '2021-12-01', '2021-12-31')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repository does not exist, request should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
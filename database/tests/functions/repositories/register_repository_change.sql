@@ -0,0 +1,103 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, repository_changes_approval)
+values (:'org1ID', 'org1', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+
+-- Try to register a change using a user not belonging to the organization
+select throws_ok(
+    $$
+        select register_repository_change(
+            '00000000-0000-0000-0000-000000000002',
+            'org1',
+            '{"kind": "delete", "repository_name": "repo1"}'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Change should not be registered as requesting user does not belong to the organization'
+);
+
+-- Register change
+select register_repository_change(:'user1ID', 'org1', '
+{
+    "kind": "add",
+    "repository_name": "repo1",
+    "repository": {
+        "name": "repo1",
+        "url": "https://repo1.com",
+        "kind": 0
+    }
+}
+'::jsonb);
+select results_eq(
+    $$
+        select organization_id, kind, repository_name, repository->>'url', requested_by
+        from repository_change
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'add',
+            'repo1',
+            'https://repo1.com',
+            '00000000-0000-0000-0000-000000000001'::uuid
+        )
+    $$,
+    'Change should have been registered'
+);
+
+-- Register transfer requested as part of an ownership claim by a user not
+-- belonging to the organization
+select register_repository_change(:'user2ID', 'org1', '
+{
+    "kind": "transfer",
+    "repository_name": "repo2",
+    "ownership_claim_method": "maintainer"
+}
+'::jsonb);
+select results_eq(
+    $$
+        select kind, repository_name, transfer_organization_id, ownership_claim_method, requested_by
+        from repository_change
+        where kind = 'transfer'
+    $$,
+    $$
+        values (
+            'transfer',
+            'repo2',
+            null::uuid,
+            'maintainer',
+            '00000000-0000-0000-0000-000000000002'::uuid
+        )
+    $$,
+    'Transfer change should have been registered'
+);
+
+-- Try to register a transfer to an organization the user does not belong to
+select throws_ok(
+    $$
+        select register_repository_change(
+            '00000000-0000-0000-0000-000000000002',
+            'org1',
+            '{"kind": "transfer", "repository_name": "repo2", "transfer_organization_name": "org1", "ownership_claim_method": "maintainer"}'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Transfer should not be registered as requesting user does not belong to the destination organization'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
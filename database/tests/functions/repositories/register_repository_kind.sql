@@ -0,0 +1,38 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Register custom kind and run some tests
+select register_repository_kind(100, 'Custom packages');
+select results_eq(
+    $$
+        select name
+        from repository_kind
+        where repository_kind_id = 100
+    $$,
+    $$
+        values ('Custom packages')
+    $$,
+    'Custom kind should have been registered'
+);
+select register_repository_kind(100, 'Custom packages updated');
+select results_eq(
+    $$
+        select name
+        from repository_kind
+        where repository_kind_id = 100
+    $$,
+    $$
+        values ('Custom packages updated')
+    $$,
+    'Custom kind name should have been updated'
+);
+select throws_ok(
+    $$ select register_repository_kind(0, 'Other') $$,
+    'invalid custom repository kind: 0 is reserved for built-in kinds',
+    'Built-in kinds cannot be modified'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,30 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0);
+
+-- Run some tests
+select register_repository_ownership_claim_token(:'user1ID', :'repo1ID', 'token1');
+select results_eq(
+    $$ select token from repository_ownership_claim_token $$,
+    $$ values ('token1') $$,
+    'Token should have been registered'
+);
+select register_repository_ownership_claim_token(:'user1ID', :'repo1ID', 'token2');
+select results_eq(
+    $$ select token from repository_ownership_claim_token $$,
+    $$ values ('token2') $$,
+    'Token should have been replaced'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
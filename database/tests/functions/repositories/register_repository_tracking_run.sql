@@ -0,0 +1,49 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository_tracking_run (repository_id, duration, outcome, created_at)
+values (:'repo1ID', 10, 'success', current_timestamp - '91 days'::interval);
+
+-- Register tracking run and run some tests
+select register_repository_tracking_run(:'repo1ID', 12.5, 'error');
+select results_eq(
+    $$
+        select duration, outcome
+        from repository_tracking_run
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (12.5::real, 'error')
+    $$,
+    'Tracking run should have been registered and old runs removed'
+);
+select throws_ok(
+    $$
+        select register_repository_tracking_run('00000000-0000-0000-0000-000000000001', 1, 'invalid')
+    $$,
+    23514,
+    'new row for relation "repository_tracking_run" violates check constraint "repository_tracking_run_outcome_check"',
+    'Invalid outcome should fail'
+);
+select throws_ok(
+    $$
+        select register_repository_tracking_run('00000000-0000-0000-0000-000000000001', -1, 'success')
+    $$,
+    23514,
+    'new row for relation "repository_tracking_run" violates check constraint "repository_tracking_run_duration_check"',
+    'Negative duration should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,40 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set change1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository_change (repository_change_id, organization_id, kind, repository_name, requested_by)
+values (:'change1ID', :'org1ID', 'delete', 'repo1', :'user1ID');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select reject_repository_change(
+            '00000000-0000-0000-0000-000000000002',
+            'org1',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Change should not be rejected by users not belonging to the organization'
+);
+select reject_repository_change(:'user1ID', 'org1', :'change1ID');
+select is_empty(
+    $$
+        select * from repository_change
+    $$,
+    'Change should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
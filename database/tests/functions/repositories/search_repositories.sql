@@ -121,6 +121,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "last_tracking_ts": 0,
                     "last_tracking_errors": "error1\\nerror2\\nerror3",
                     "organization_name": "org1",
@@ -136,6 +137,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "organization_name": "org1",
                     "organization_display_name": "Organization 1"
                 },
@@ -149,6 +151,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "user_alias": "user1"
                 },
                 {
@@ -161,6 +164,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "organization_name": "org2",
                     "organization_display_name": "Organization 2"
                 }
@@ -189,6 +193,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "organization_name": "org1",
                     "organization_display_name": "Organization 1"
                 }
@@ -216,6 +221,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "organization_name": "org1",
                     "organization_display_name": "Organization 1"
                 }
@@ -243,6 +249,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "user_alias": "user1"
                 },
                 {
@@ -255,6 +262,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "organization_name": "org2",
                     "organization_display_name": "Organization 2"
                 }
@@ -283,6 +291,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "organization_name": "org2",
                     "organization_display_name": "Organization 2"
                 }
@@ -310,6 +319,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "user_alias": "user1"
                 }
             ]'::jsonb,
@@ -352,6 +362,7 @@ select results_eq(
                     "official": false,
                     "disabled": false,
                     "scanner_disabled": false,
+                    "version": 1,
                     "last_tracking_ts": 0,
                     "last_tracking_errors": "error1\\nerror2\\nerror3",
                     "organization_name": "org1",
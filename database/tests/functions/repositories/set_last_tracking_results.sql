@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(15);
+select plan(17);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -21,7 +21,7 @@ select is(count(*), 0::bigint, 'No tracking errors events should have been regis
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results and run some more tests
-select set_last_tracking_results(:'repo1ID', '', true);
+select set_last_tracking_results(:'repo1ID', '', null, true);
 select isnt(last_tracking_ts, null, 'Last tracking ts should have been set')
 from repository where name = 'repo1';
 select is(last_tracking_errors, null, 'Last tracking errors should have been set to null')
@@ -30,33 +30,41 @@ select is(count(*), 0::bigint, 'No tracking errors events should have been regis
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some errors', true);
+select set_last_tracking_results(:'repo1ID', 'some errors', '[{"code": "network", "message": "some errors"}]', true);
 select is(last_tracking_errors, 'some errors', 'Last tracking errors should have been set to some errors')
 from repository where name = 'repo1';
+select is(
+    last_tracking_errors_details,
+    '[{"code": "network", "message": "some errors"}]'::jsonb,
+    'Last tracking errors details should have been set'
+)
+from repository where name = 'repo1';
 select is(count(*), 1::bigint, 'One tracking error event should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with the same error and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some errors', true);
+select set_last_tracking_results(:'repo1ID', 'some errors', '[{"code": "network", "message": "some errors"}]', true);
 select is(count(*), 1::bigint, 'No more tracking error events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with another error and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some new errors', true);
+select set_last_tracking_results(:'repo1ID', 'some new errors', '[{"code": "unknown", "message": "some new errors"}]', true);
 select is(last_tracking_errors, 'some new errors', 'Last tracking errors should have been set to some new errors')
 from repository where name = 'repo1';
 select is(count(*), 2::bigint, 'One more tracking error event should have been registered (total 2 now)')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with no errors and run some more tests
-select set_last_tracking_results(:'repo1ID', '', true);
+select set_last_tracking_results(:'repo1ID', '', null, true);
 select is(last_tracking_errors, null, 'Last tracking errors should have been set to null')
 from repository where name = 'repo1';
+select is(last_tracking_errors_details, null, 'Last tracking errors details should have been set to null')
+from repository where name = 'repo1';
 select is(count(*), 2::bigint, 'No more tracking error events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with another error and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some new errors', false);
+select set_last_tracking_results(:'repo1ID', 'some new errors', '[{"code": "unknown", "message": "some new errors"}]', false);
 select is(last_tracking_errors, 'some new errors', 'Last tracking errors should have been set to some new errors')
 from repository where name = 'repo1';
 select is(count(*), 2::bigint, 'No more tracking error events should have been registered')
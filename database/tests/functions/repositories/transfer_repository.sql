@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(16);
+select plan(19);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -88,7 +88,7 @@ select throws_ok(
             'repo1',
             '00000000-0000-0000-0000-000000000002',
             null,
-            false
+            null
         )
     $$,
     42501,
@@ -103,7 +103,7 @@ select throws_ok(
             'repo2',
             '00000000-0000-0000-0000-000000000002',
             null,
-            false
+            null
         )
     $$,
     42501,
@@ -119,7 +119,7 @@ select throws_ok(
             'repo1',
             '00000000-0000-0000-0000-000000000001',
             'org2',
-            false
+            null
         )
     $$,
     42501,
@@ -132,7 +132,7 @@ select transfer_repository(
     'repo2',
     '00000000-0000-0000-0000-000000000001',
     null,
-    false
+    null
 );
 select results_eq(
     $$
@@ -163,7 +163,7 @@ select transfer_repository(
     'repo2',
     '00000000-0000-0000-0000-000000000001',
     'org1',
-    false
+    null
 );
 select is(
     tsdoc,
@@ -177,7 +177,7 @@ select transfer_repository(
     'repo2',
     '00000000-0000-0000-0000-000000000001',
     'org3',
-    false
+    null
 );
 select results_eq(
     $$
@@ -198,7 +198,7 @@ select transfer_repository(
     'repo1',
     '00000000-0000-0000-0000-000000000001',
     'org1',
-    false
+    null
 );
 select results_eq(
     $$
@@ -215,13 +215,15 @@ select is(count(*), 0::bigint, 'No repository ownership claim events should have
 from event where repository_id=:'repo1ID' and event_kind_id = 3;
 
 -- Transfers part of an ownership claim request
+insert into repository_ownership_claim_token (repository_id, user_id, token)
+values (:'repo1ID', :'user2ID', 'token');
 
 -- Transfer repository owned by organization to user not belonging to it
 select transfer_repository(
     'repo1',
     '00000000-0000-0000-0000-000000000002',
     null,
-    true
+    'owners'
 );
 select results_eq(
     $$
@@ -248,13 +250,34 @@ select results_eq(
     $$,
     'Repository ownership claim event should have been registered'
 );
+select results_eq(
+    $$
+        select method, user_id, organization_id, previous_user_id, previous_organization_id
+        from repository_ownership_claim
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            'owners',
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            null::uuid,
+            null::uuid,
+            '00000000-0000-0000-0000-000000000001'::uuid
+        )
+    $$,
+    'Repository ownership claim audit record should have been registered'
+);
+select is_empty(
+    $$ select * from repository_ownership_claim_token $$,
+    'Repository ownership claim tokens should have been deleted'
+);
 
 -- Transfer repository owned by a user to other user
 select transfer_repository(
     'repo1',
     '00000000-0000-0000-0000-000000000001',
     null,
-    true
+    'owners'
 );
 select results_eq(
     $$
@@ -269,6 +292,8 @@ select results_eq(
 );
 select is(count(*), 2::bigint, 'Another repository ownership claim event should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 3;
+select is(count(*), 2::bigint, 'Another repository ownership claim audit record should have been registered')
+from repository_ownership_claim where repository_id=:'repo1ID';
 
 -- Finish tests and rollback transaction
 select * from finish();
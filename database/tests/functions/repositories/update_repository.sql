@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(10);
+select plan(12);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -263,6 +263,30 @@ select results_eq(
     'Security reports in packages belonging to repo2 should have been deleted'
 );
 
+-- Try to update repository using an outdated version
+select throws_ok(
+    $$
+        select update_repository('00000000-0000-0000-0000-000000000001', '
+        {
+            "name": "repo1",
+            "display_name": "Repo 1 updated again",
+            "url": "https://repo1.com/updated",
+            "disabled": false,
+            "scanner_disabled": false,
+            "version": 1
+        }
+        '::jsonb)
+    $$,
+    'P0001',
+    'version conflict',
+    'Repository update should fail because it has been modified since version provided'
+);
+select results_eq(
+    $$ select display_name, version from repository where name = 'repo1' $$,
+    $$ values ('Repo 1 updated', 5) $$,
+    'Repository should not have been updated (version incremented on each previous update)'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
@@ -0,0 +1,83 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- No packages at this point
+select is(
+    get_sitemap_packages(10, 0)::jsonb,
+    '[]'::jsonb,
+    'No packages in database, empty array expected'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id, created_at)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '0.0.9', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-06-17 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id, created_at)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID', '2020-06-18 11:20:34+02');
+insert into snapshot (package_id, version, ts)
+values (:'package2ID', '1.0.0', '2020-06-18 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_sitemap_packages(10, 0)::jsonb,
+    '[
+        {
+            "normalized_name": "package-1",
+            "repository_name": "repo1",
+            "repository_kind_id": 0,
+            "ts": 1592385634
+        },
+        {
+            "normalized_name": "package2",
+            "repository_name": "repo1",
+            "repository_kind_id": 0,
+            "ts": 1592472034
+        }
+    ]'::jsonb,
+    'Packages with their latest release ts are returned'
+);
+select is(
+    get_sitemap_packages(1, 1)::jsonb,
+    '[
+        {
+            "normalized_name": "package2",
+            "repository_name": "repo1",
+            "repository_kind_id": 0,
+            "ts": 1592472034
+        }
+    ]'::jsonb,
+    'Packages are paginated using the limit and offset provided'
+);
+
+-- Block one of the packages
+insert into blocked_content (package_id, reason)
+values (:'package1ID', 'malware');
+select is(
+    get_sitemap_packages(10, 0)::jsonb,
+    '[
+        {
+            "normalized_name": "package2",
+            "repository_name": "repo1",
+            "repository_kind_id": 0,
+            "ts": 1592472034
+        }
+    ]'::jsonb,
+    'Blocked packages are not returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
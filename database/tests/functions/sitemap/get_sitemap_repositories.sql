@@ -0,0 +1,78 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- No repositories at this point
+select is(
+    get_sitemap_repositories()::jsonb,
+    '[]'::jsonb,
+    'No repositories in database, empty array expected'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-06-16 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts)
+values (:'package2ID', '0.0.9', '2020-06-19 11:20:34+02');
+insert into snapshot (package_id, version, ts)
+values (:'package2ID', '1.0.0', '2020-06-17 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'package3', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version, ts)
+values (:'package3ID', '1.0.0', '2020-06-18 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_sitemap_repositories()::jsonb,
+    '[
+        {
+            "name": "repo1",
+            "ts": 1592385634
+        },
+        {
+            "name": "repo2",
+            "ts": 1592472034
+        }
+    ]'::jsonb,
+    'Repositories with packages are returned with their most recent release ts'
+);
+
+-- Block one of the repositories and one package of the other
+insert into blocked_content (repository_id, reason)
+values (:'repo2ID', 'malware');
+insert into blocked_content (package_id, reason)
+values (:'package2ID', 'malware');
+select is(
+    get_sitemap_repositories()::jsonb,
+    '[
+        {
+            "name": "repo1",
+            "ts": 1592299234
+        }
+    ]'::jsonb,
+    'Blocked repositories and packages are not taken into account'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,74 @@
+-- Start transaction and plan tests
+begin;
+select plan(8);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- No data seeded yet
+select is(
+    get_stats_time_series('month')::jsonb - '{generated_at}'::text[],
+    '{
+        "granularity": "month",
+        "counts": "currently_existing",
+        "packages": [],
+        "repositories": [],
+        "organizations": [],
+        "users": []
+    }'::jsonb,
+    'Empty series expected when no data has been registered'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email, created_at)
+values (:'user1ID', 'user1', 'user1@email.com', '2020-06-16 11:20:34+02');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, created_at)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', '2020-06-16 11:20:34+02');
+insert into package (package_id, name, latest_version, created_at, repository_id)
+values (:'package1ID', 'package1', '1.0.0', '2020-06-16 11:20:34+02', :'repo1ID');
+insert into package (package_id, name, latest_version, created_at, repository_id)
+values (:'package2ID', 'package2', '1.0.0', '2020-07-17 11:20:34+02', :'repo1ID');
+
+-- Run some tests
+select is(
+    get_stats_time_series('month')::jsonb->'packages'->0,
+    '[1590969600000, 1]'::jsonb,
+    'First month of packages series starts with one package'
+);
+select is(
+    get_stats_time_series('month')::jsonb->'packages'->1,
+    '[1593561600000, 2]'::jsonb,
+    'Second month of packages series includes the package added that month'
+);
+select is(
+    get_stats_time_series('day')::jsonb->'packages'->1,
+    '[1592352000000, 1]'::jsonb,
+    'Days with no new packages are included in the series'
+);
+select is(
+    get_stats_time_series('week')::jsonb->'users'->0,
+    '[1592179200000, 1]'::jsonb,
+    'Weeks are used as periods when requested'
+);
+select is(
+    get_stats_time_series('month')::jsonb->'organizations',
+    '[]'::jsonb,
+    'Organizations series is empty'
+);
+delete from package where package_id = :'package1ID';
+select is(
+    get_stats_time_series('month')::jsonb->'packages'->0,
+    '[1593561600000, 1]'::jsonb,
+    'Deleted packages are not counted in any period'
+);
+select throws_ok(
+    $$ select get_stats_time_series('year') $$,
+    'invalid granularity'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(1);
+select plan(2);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -42,6 +42,35 @@ select results_eq(
     'Subscription should exist'
 );
 
+-- Add same subscription providing a minimum severity
+select add_subscription('
+{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "event_kind": 0,
+    "min_severity": "high"
+}
+'::jsonb);
+select results_eq(
+    $$
+        select
+            user_id,
+            package_id,
+            event_kind_id,
+            min_severity
+        from subscription
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            0,
+            'high'
+        )
+    $$,
+    'Subscription minimum severity should have been updated'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
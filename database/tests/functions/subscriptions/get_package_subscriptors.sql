@@ -1,11 +1,12 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(5);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set user2ID '00000000-0000-0000-0000-000000000002'
 \set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
 \set package1ID '00000000-0000-0000-0000-000000000001'
 \set package2ID '00000000-0000-0000-0000-000000000002'
@@ -17,6 +18,8 @@ insert into "user" (user_id, alias, email)
 values (:'user2ID', 'user2', 'user2@email.com');
 insert into "user" (user_id, alias, email)
 values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user4ID', 'user4', 'user4@email.com');
 insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
 insert into package (package_id, name, latest_version, repository_id)
@@ -27,10 +30,12 @@ insert into subscription (user_id, package_id, event_kind_id)
 values (:'user2ID', :'package1ID', 0);
 insert into subscription (user_id, package_id, event_kind_id)
 values (:'user3ID', :'package1ID', 1);
+insert into subscription (user_id, package_id, event_kind_id, min_severity)
+values (:'user4ID', :'package1ID', 1, 'critical');
 
 -- Run some tests
 select is(
-    get_package_subscriptors(:'package1ID', 0)::jsonb,
+    get_package_subscriptors(:'package1ID', 0, null)::jsonb,
     '[
         {
             "user_id": "00000000-0000-0000-0000-000000000001"
@@ -42,10 +47,40 @@ select is(
     'Two subscriptors expected for package1 and kind new releases'
 );
 select is(
-    get_package_subscriptors(:'package2ID', 0)::jsonb,
+    get_package_subscriptors(:'package2ID', 0, null)::jsonb,
     '[]'::jsonb,
     'No subscriptors expected for package2 and kind new releases'
 );
+select is(
+    get_package_subscriptors(:'package1ID', 1, '{"new_vulnerabilities": {"high": 2, "medium": 1}}')::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000003"
+        }
+    ]'::jsonb,
+    'Only subscriptor without minimum severity expected (no new critical vulnerabilities)'
+);
+select is(
+    get_package_subscriptors(:'package1ID', 1, '{"new_vulnerabilities": {"critical": 1}}')::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000003"
+        },
+        {
+            "user_id": "00000000-0000-0000-0000-000000000004"
+        }
+    ]'::jsonb,
+    'Two subscriptors expected (new critical vulnerabilities found)'
+);
+select is(
+    get_package_subscriptors(:'package1ID', 1, null)::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000003"
+        }
+    ]'::jsonb,
+    'Only subscriptor without minimum severity expected when no new vulnerabilities are reported'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -20,14 +20,22 @@ insert into package (package_id, name, latest_version, repository_id)
 values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
 insert into subscription (user_id, package_id, event_kind_id)
 values (:'user1ID', :'package1ID', 0);
+insert into subscription (user_id, package_id, event_kind_id, min_severity)
+values (:'user1ID', :'package1ID', 1, 'critical');
 
 -- Run some tests
 select is(
     get_user_package_subscriptions(:'user1ID', :'package1ID')::jsonb,
-    '[{
-        "event_kind": 0
-    }]'::jsonb,
-    'A subscription with event kind 0 should be returned'
+    '[
+        {
+            "event_kind": 0
+        },
+        {
+            "event_kind": 1,
+            "min_severity": "critical"
+        }
+    ]'::jsonb,
+    'Two subscriptions should be returned'
 );
 select is(
     get_user_package_subscriptions(:'user2ID', :'package1ID')::jsonb,
@@ -0,0 +1,32 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'User1@email.com');
+insert into email_suppression (email, reason, provider)
+values ('user1@email.com', 'complaint', 'sendgrid');
+insert into email_suppression (email, reason, provider)
+values ('user2@email.com', 'bounce', 'sendgrid');
+
+-- Delete user email suppression
+select delete_user_email_suppression(:'user1ID');
+
+-- Run some tests
+select is_empty(
+    $$ select * from email_suppression where email = 'user1@email.com' $$,
+    'User email should have been removed from the suppression list'
+);
+select results_eq(
+    $$ select email from email_suppression $$,
+    $$ values ('user2@email.com') $$,
+    'Other suppression entries should not have been removed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
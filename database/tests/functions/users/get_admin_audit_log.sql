@@ -0,0 +1,81 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- No entries registered yet
+select is(
+    get_admin_audit_log('{}')::jsonb,
+    '[]'::jsonb,
+    'No entries expected'
+);
+
+-- Register some admin audit entries
+select register_admin_audit_entry('disable_user', :'user1ID', '
+{
+    "reason": "spam",
+    "ip": "192.168.1.100",
+    "user_agent": "Safari 13.0.5"
+}
+');
+select register_admin_audit_entry('enable_user', :'user1ID', '
+{
+    "reason": "appeal accepted"
+}
+');
+
+-- Run some tests
+select is(
+    (
+        select jsonb_agg(entry order by entry_number)
+        from (
+            select
+                (e->>'entry_number')::bigint as entry_number,
+                jsonb_build_object(
+                    'entry_number', e->'entry_number',
+                    'action', e->'action',
+                    'user_id', e->'user_id',
+                    'reason', e->'reason',
+                    'ip', e->'ip',
+                    'user_agent', e->'user_agent',
+                    'chained', e->'previous_hash' = lag(e->'hash') over (order by (e->>'entry_number')::bigint)
+                ) as entry
+            from jsonb_array_elements(get_admin_audit_log('{}')::jsonb) e
+        ) entries
+    ),
+    (
+        select jsonb_build_array(
+            jsonb_build_object(
+                'entry_number', min(entry_number),
+                'action', 'disable_user',
+                'user_id', :'user1ID',
+                'reason', 'spam',
+                'ip', '192.168.1.100',
+                'user_agent', 'Safari 13.0.5',
+                'chained', null
+            ),
+            jsonb_build_object(
+                'entry_number', max(entry_number),
+                'action', 'enable_user',
+                'user_id', :'user1ID',
+                'reason', 'appeal accepted',
+                'ip', null,
+                'user_agent', null,
+                'chained', true
+            )
+        )
+        from admin_audit_log
+    ),
+    'Entries should be returned in order including their hashes'
+);
+select is(
+    get_admin_audit_log('{"from": "2000-01-01T00:00:00Z", "to": "2000-01-02T00:00:00Z"}')::jsonb,
+    '[]'::jsonb,
+    'No entries expected in the time range provided'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,41 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'User1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into email_suppression (email, reason, provider, created_at)
+values ('user1@email.com', 'bounce', 'ses', '2020-06-16 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_user_email_suppression(:'user1ID')::jsonb,
+    '{
+        "suppressed": true,
+        "reason": "bounce",
+        "created_at": 1592299234
+    }'::jsonb,
+    'User email is suppressed'
+);
+select is(
+    get_user_email_suppression(:'user2ID')::jsonb,
+    '{
+        "suppressed": false
+    }'::jsonb,
+    'User email is not suppressed'
+);
+select is_empty(
+    $$ select get_user_email_suppression('00000000-0000-0000-0000-000000000003') $$,
+    'No rows returned for a user that does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
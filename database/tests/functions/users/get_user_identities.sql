@@ -0,0 +1,43 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into user_identity (provider, subject, user_id, email, created_at)
+values ('google', 'people/1', :'user1ID', 'user1@gmail.com', '2020-06-16 11:20:34+02');
+insert into user_identity (provider, subject, user_id, created_at)
+values ('github', '1', :'user1ID', '2020-06-16 11:20:35+02');
+
+-- Run some tests
+select is(
+    get_user_identities(:'user1ID')::jsonb,
+    '[
+        {
+            "provider": "github",
+            "created_at": 1592299235
+        },
+        {
+            "provider": "google",
+            "email": "user1@gmail.com",
+            "created_at": 1592299234
+        }
+    ]'::jsonb,
+    'Identities linked to user1 are returned'
+);
+select is(
+    get_user_identities(:'user2ID')::jsonb,
+    '[]'::jsonb,
+    'No identities linked to user2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
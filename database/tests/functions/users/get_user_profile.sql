@@ -14,7 +14,9 @@ insert into "user" (
     email,
     password,
     profile_image_id,
-    tfa_enabled
+    tfa_enabled,
+    locale,
+    public_profile
 ) values (
     :'user1ID',
     'user1',
@@ -23,6 +25,8 @@ insert into "user" (
     'user1@email.com',
     'password',
     '00000000-0000-0000-0000-000000000001',
+    true,
+    'es',
     true
 );
 
@@ -34,9 +38,13 @@ select is(
         "first_name": "firstname",
         "last_name": "lastname",
         "email": "user1@email.com",
+        "email_verified": false,
         "profile_image_id": "00000000-0000-0000-0000-000000000001",
         "password_set": true,
-        "tfa_enabled": true
+        "tfa_enabled": true,
+        "locale": "es",
+        "public_profile": true,
+        "version": 1
     }
     '::jsonb,
     'User1 should exist'
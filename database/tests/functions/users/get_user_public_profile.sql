@@ -0,0 +1,101 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, first_name, last_name, email, profile_image_id, public_profile)
+values (:'user1ID', 'user1', 'firstname', 'lastname', 'user1@email.com', '00000000-0000-0000-0000-000000000001', true);
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email, public_profile, disabled)
+values (:'user3ID', 'user3', 'user3@email.com', true, true);
+insert into organization (organization_id, name, display_name, public_profile)
+values (:'org1ID', 'org1', 'Organization 1', true);
+insert into organization (organization_id, name, public_profile)
+values (:'org2ID', 'org2', false);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org2ID', true);
+insert into repository (repository_id, name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, ts)
+values (:'package1ID', '1.0.0', '2020-06-16 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_user_public_profile('user1')::jsonb,
+    '{
+        "alias": "user1",
+        "first_name": "firstname",
+        "last_name": "lastname",
+        "profile_image_id": "00000000-0000-0000-0000-000000000001",
+        "organizations": [
+            {
+                "name": "org1",
+                "display_name": "Organization 1"
+            }
+        ],
+        "repositories": [
+            {
+                "name": "repo1",
+                "url": "https://repo1.com",
+                "private": false,
+                "kind": 0,
+                "verified_publisher": false,
+                "official": false,
+                "packages_count": 1
+            }
+        ],
+        "packages": [
+            {
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "name": "package1",
+                "normalized_name": "package1",
+                "stars": 0,
+                "version": "1.0.0",
+                "repository_name": "repo1",
+                "repository_kind_id": 0
+            }
+        ],
+        "activity": [
+            {
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "name": "package1",
+                "normalized_name": "package1",
+                "version": "1.0.0",
+                "repository_name": "repo1",
+                "repository_kind_id": 0,
+                "ts": 1592299234
+            }
+        ],
+        "packages_count": 1,
+        "stars": 0
+    }'::jsonb,
+    'User1 public profile should be returned, including only public organizations'
+);
+select is_empty(
+    $$ select get_user_public_profile('user2') $$,
+    'User2 public profile should not be returned as it has not been enabled'
+);
+select is_empty(
+    $$ select get_user_public_profile('user3') $$,
+    'User3 public profile should not be returned as the user is disabled'
+);
+select is_empty(
+    $$ select get_user_public_profile('user4') $$,
+    'User4 does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
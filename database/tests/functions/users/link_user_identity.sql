@@ -0,0 +1,80 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+
+-- Run some tests
+select lives_ok(
+    $$
+        select link_user_identity('
+        {
+            "user_id": "00000000-0000-0000-0000-000000000001",
+            "provider": "github",
+            "subject": "1",
+            "email": "user1@email.com"
+        }
+        '::jsonb)
+    $$,
+    'Identity should be linked to user1'
+);
+select results_eq(
+    $$
+        select provider, subject, user_id, email
+        from user_identity
+    $$,
+    $$
+        values ('github', '1', '00000000-0000-0000-0000-000000000001'::uuid, 'user1@email.com')
+    $$,
+    'Identity linked to user1 should exist'
+);
+select lives_ok(
+    $$
+        select link_user_identity('
+        {
+            "user_id": "00000000-0000-0000-0000-000000000001",
+            "provider": "github",
+            "subject": "1"
+        }
+        '::jsonb)
+    $$,
+    'Linking the same identity to user1 again should succeed'
+);
+select throws_ok(
+    $$
+        select link_user_identity('
+        {
+            "user_id": "00000000-0000-0000-0000-000000000002",
+            "provider": "github",
+            "subject": "1"
+        }
+        '::jsonb)
+    $$,
+    'identity already linked to another user',
+    'Identity linked to user1 cannot be linked to user2'
+);
+select throws_ok(
+    $$
+        select link_user_identity('
+        {
+            "user_id": "00000000-0000-0000-0000-000000000001",
+            "provider": "github",
+            "subject": "2"
+        }
+        '::jsonb)
+    $$,
+    'provider already linked',
+    'A second github identity cannot be linked to user1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
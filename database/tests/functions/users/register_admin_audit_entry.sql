@@ -0,0 +1,95 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set admin1ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed user
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+
+-- Register admin audit entry
+select register_admin_audit_entry('disable_user', :'user1ID', '
+{
+    "admin_user_id": "00000000-0000-0000-0000-000000000002",
+    "reason": "spam",
+    "ip": "192.168.1.100",
+    "user_agent": "Safari 13.0.5"
+}
+');
+
+-- Check if the entry was registered
+select results_eq(
+    $$
+        select action, user_id, admin_user_id, reason, ip, user_agent
+        from admin_audit_log
+    $$,
+    $$
+        values (
+            'disable_user',
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            'spam',
+            '192.168.1.100'::inet,
+            'Safari 13.0.5'
+        )
+    $$,
+    'Admin audit entry should exist'
+);
+select results_eq(
+    $$
+        select previous_hash is null, hash = get_admin_audit_entry_hash(admin_audit_log)
+        from admin_audit_log
+    $$,
+    $$
+        values (true, true)
+    $$,
+    'First entry should not have a previous hash and its hash should be valid'
+);
+
+-- Register another admin audit entry
+select register_admin_audit_entry('block_content', null, '
+{
+    "reason": "malware",
+    "details": {
+        "repository_name": "repo1"
+    }
+}
+');
+
+-- Check if the new entry was chained to the previous one
+select results_eq(
+    $$
+        select action, user_id, reason, details
+        from admin_audit_log
+        order by entry_number desc
+        limit 1
+    $$,
+    $$
+        values (
+            'block_content',
+            null::uuid,
+            'malware',
+            '{"repository_name": "repo1"}'::jsonb
+        )
+    $$,
+    'Admin audit entry with details should exist'
+);
+select is(
+    (select previous_hash from admin_audit_log order by entry_number desc limit 1),
+    (select hash from admin_audit_log order by entry_number asc limit 1),
+    'Second entry should be chained to the first one'
+);
+
+-- Entries cannot be modified
+select throws_ok(
+    $$ update admin_audit_log set reason = 'other' $$,
+    'admin audit log entries cannot be modified',
+    'Admin audit log entries cannot be modified'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,60 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, email_verified)
+values (:'user1ID', 'user1', 'user1@email.com', false);
+insert into email_verification_code (email_verification_code_id, user_id, created_at)
+values ('codeHash1', :'user1ID', current_timestamp - '1 hour'::interval);
+insert into "user" (user_id, alias, email, email_verified)
+values (:'user2ID', 'user2', 'user2@email.com', true);
+insert into "user" (user_id, alias, email, email_verified)
+values (:'user3ID', 'user3', 'user3@email.com', false);
+insert into email_verification_code (email_verification_code_id, user_id, created_at)
+values ('codeHash3', :'user3ID', current_timestamp - '2 days'::interval);
+
+-- Run some tests
+select throws_ok(
+    $$ select register_email_verification_code('user2@email.com', 'newCodeHash') $$,
+    'invalid email',
+    'Email already verified: should fail'
+);
+select throws_ok(
+    $$ select register_email_verification_code('user3@email.com', 'newCodeHash') $$,
+    'invalid email',
+    'Email verification period expired: should fail'
+);
+select throws_ok(
+    $$ select register_email_verification_code('user4@email.com', 'newCodeHash') $$,
+    'invalid email',
+    'User does not exist: should fail'
+);
+select is(
+    register_email_verification_code('user1@email.com', 'newCodeHash'),
+    true,
+    'New email verification code should be registered'
+);
+select results_eq(
+    $$
+        select email_verification_code_id
+        from email_verification_code
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values ('newCodeHash') $$,
+    'Previous email verification code should have been replaced'
+);
+select is(
+    register_email_verification_code('user1@email.com', 'newCodeHash2'),
+    false,
+    'Code was registered recently: request should be rejected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,87 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed users
+insert into "user" (user_id, alias, email, tfa_enabled)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email, disabled)
+values (:'user2ID', 'user2', 'user2@email.com', true);
+
+-- Run some tests
+select throws_ok(
+    $$
+        select register_impersonation_session(
+            '{"session_id": "hashed-session-id-user2", "user_id": "00000000-0000-0000-0000-000000000002"}',
+            '{"reason": "support"}'
+        )
+    $$,
+    'P0001',
+    'user not found',
+    'Impersonation session registration should fail for disabled users'
+);
+select throws_ok(
+    $$
+        select register_impersonation_session(
+            '{"session_id": "hashed-session-id-user3", "user_id": "00000000-0000-0000-0000-000000000003"}',
+            '{"reason": "support"}'
+        )
+    $$,
+    'P0001',
+    'user not found',
+    'Impersonation session registration should fail for users that do not exist'
+);
+select register_impersonation_session('
+{
+    "session_id": "hashed-session-id-user1",
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "ip": "192.168.1.100",
+    "user_agent": "Safari 13.0.5"
+}
+', '
+{
+    "reason": "support",
+    "ip": "192.168.1.100",
+    "user_agent": "Safari 13.0.5"
+}
+');
+select results_eq(
+    $$
+        select session_id, ip, user_agent, approved, impersonated
+        from session
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (
+            'hashed-session-id-user1',
+            '192.168.1.100'::inet,
+            'Safari 13.0.5',
+            true,
+            true
+        )
+    $$,
+    'Approved impersonation session should exist even though user has enabled tfa'
+);
+select results_eq(
+    $$
+        select action, user_id, reason
+        from admin_audit_log
+    $$,
+    $$
+        values (
+            'impersonate_user',
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'support'
+        )
+    $$,
+    'Impersonation should be recorded in the admin audit log'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(6);
+select plan(7);
 
 -- Register user
 select register_user('
@@ -11,9 +11,10 @@ select register_user('
     "email": "email",
     "email_verified": false,
     "password": "password",
-    "profile_image_id": "00000000-0000-0000-0000-000000000001"
+    "profile_image_id": "00000000-0000-0000-0000-000000000001",
+    "locale": "es"
 }
-') as code \gset
+', 'codeHash');
 
 -- Check if user registration succeeded
 select results_eq(
@@ -25,7 +26,8 @@ select results_eq(
             email,
             email_verified,
             password,
-            profile_image_id
+            profile_image_id,
+            locale
         from "user"
         where alias = 'alias'
     $$,
@@ -37,15 +39,16 @@ select results_eq(
             'email',
             false,
             'password',
-            '00000000-0000-0000-0000-000000000001'::uuid
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'es'
         )
     $$,
     'User should exist'
 );
 select is(
     email_verification_code_id,
-    :'code',
-    'Returned email verification code returned should be registered'
+    'codeHash',
+    'Email verification code provided should be registered'
 )
 from email_verification_code
 join "user" using (user_id)
@@ -64,7 +67,7 @@ select throws_ok(
             "password": "password",
             "profile_image_id": "00000000-0000-0000-0000-000000000001"
         }
-        ')
+        ', 'codeHash2')
     $$,
     23505,
     'duplicate key value violates unique constraint "user_email_key"',
@@ -74,7 +77,7 @@ select throws_ok(
 -- Set email verification code created_at timestamp to two days ago
 update email_verification_code
 set created_at = created_at - '2 days'::interval
-where email_verification_code_id = :'code';
+where email_verification_code_id = 'codeHash';
 
 -- Try registering user using the same email again
 select lives_ok(
@@ -89,11 +92,27 @@ select lives_ok(
             "password": "password",
             "profile_image_id": "00000000-0000-0000-0000-000000000001"
         }
-        ')
+        ', 'codeHash2')
     $$,
     'Registering the same user again should work as the email was not verified on time'
 );
 
+-- Try registering a user whose email is not verified without a code
+select throws_ok(
+    $$
+        select register_user('
+        {
+            "alias": "alias4",
+            "email": "email4",
+            "email_verified": false,
+            "password": "password"
+        }
+        ', null)
+    $$,
+    'email verification code not provided',
+    'Registering a user without an email verification code should fail'
+);
+
 -- Register new user (email already verified, oauth registration)
 select register_user('
 {
@@ -104,7 +123,7 @@ select register_user('
     "email_verified": true,
     "profile_image_id": "00000000-0000-0000-0000-000000000001"
 }
-');
+', null);
 
 -- Check if user registration succeeded
 select results_eq(
@@ -0,0 +1,48 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed user
+insert into "user" (user_id, alias, email, tfa_enabled, tfa_url, tfa_recovery_codes)
+values (:'user1ID', 'user1', 'user1@email.com', true, 'url', '{"code1", "code2"}');
+
+-- Run some tests
+select throws_ok(
+    $$ select reset_user_tfa('00000000-0000-0000-0000-000000000002', '{}') $$,
+    'P0001',
+    'user not found',
+    'TFA reset should fail for users that do not exist'
+);
+select reset_user_tfa(:'user1ID', '{"reason": "device lost"}');
+select results_eq(
+    $$
+        select tfa_enabled, tfa_url, tfa_recovery_codes
+        from "user"
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (false, null::text, null::text[])
+    $$,
+    'TFA should be disabled and its configuration removed'
+);
+select results_eq(
+    $$
+        select action, user_id, reason
+        from admin_audit_log
+    $$,
+    $$
+        values (
+            'reset_user_tfa',
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'device lost'
+        )
+    $$,
+    'TFA reset should be recorded in the admin audit log'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,114 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Seed users
+insert into "user" (user_id, alias, first_name, email, email_verified, created_at)
+values ('00000000-0000-0000-0000-000000000001', 'user1', 'John', 'user1@email.com', true, '2021-01-01 00:00:00+00');
+insert into "user" (user_id, alias, email, disabled, created_at)
+values ('00000000-0000-0000-0000-000000000002', 'user2', 'user2@other.com', true, '2021-01-02 00:00:00+00');
+
+-- Run some tests
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from search_users('{"offset": 0, "limit": 10}')
+    $$,
+    $$
+        values(
+            '[
+                {
+                    "user_id": "00000000-0000-0000-0000-000000000001",
+                    "alias": "user1",
+                    "first_name": "John",
+                    "email": "user1@email.com",
+                    "email_verified": true,
+                    "tfa_enabled": false,
+                    "disabled": false,
+                    "created_at": 1609459200
+                },
+                {
+                    "user_id": "00000000-0000-0000-0000-000000000002",
+                    "alias": "user2",
+                    "email": "user2@other.com",
+                    "email_verified": false,
+                    "tfa_enabled": false,
+                    "disabled": true,
+                    "created_at": 1609545600
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'All users should be returned when no query is provided'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from search_users('{"ts_query_web": "OTHER.com", "offset": 0, "limit": 10}')
+    $$,
+    $$
+        values(
+            '[
+                {
+                    "user_id": "00000000-0000-0000-0000-000000000002",
+                    "alias": "user2",
+                    "email": "user2@other.com",
+                    "email_verified": false,
+                    "tfa_enabled": false,
+                    "disabled": true,
+                    "created_at": 1609545600
+                }
+            ]'::jsonb,
+            1
+        )
+    $$,
+    'Only users matching the query should be returned'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from search_users('{"offset": 1, "limit": 1}')
+    $$,
+    $$
+        values(
+            '[
+                {
+                    "user_id": "00000000-0000-0000-0000-000000000002",
+                    "alias": "user2",
+                    "email": "user2@other.com",
+                    "email_verified": false,
+                    "tfa_enabled": false,
+                    "disabled": true,
+                    "created_at": 1609545600
+                }
+            ]'::jsonb,
+            2
+        )
+    $$,
+    'Pagination should be applied and total count should include all users'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from search_users('{"ts_query_web": "%", "offset": 0, "limit": 10}')
+    $$,
+    $$
+        values('[]'::jsonb, 0)
+    $$,
+    'Percent sign in the query should be matched literally'
+);
+select results_eq(
+    $$
+        select data::jsonb, total_count::integer
+        from search_users('{"ts_query_web": "user_@", "offset": 0, "limit": 10}')
+    $$,
+    $$
+        values('[]'::jsonb, 0)
+    $$,
+    'Underscore in the query should be matched literally'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
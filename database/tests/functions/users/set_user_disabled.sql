@@ -0,0 +1,59 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed user and session
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into session (session_id, user_id)
+values ('hashed-session-id-user1', :'user1ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select set_user_disabled('00000000-0000-0000-0000-000000000002', true, '{}') $$,
+    'P0001',
+    'user not found',
+    'Disabling a user that does not exist should fail'
+);
+select set_user_disabled(:'user1ID', true, '{"reason": "spam"}');
+select results_eq(
+    $$ select disabled from "user" where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (true) $$,
+    'User should be disabled'
+);
+select is_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'User sessions should have been deleted'
+);
+select set_user_disabled(:'user1ID', false, '{}');
+select results_eq(
+    $$ select disabled from "user" where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (false) $$,
+    'User should be enabled'
+);
+select results_eq(
+    $$
+        select action, reason
+        from admin_audit_log
+        where user_id = '00000000-0000-0000-0000-000000000001'
+        order by action asc
+    $$,
+    $$
+        values
+            ('disable_user', 'spam'),
+            ('enable_user', null)
+    $$,
+    'Both actions should be recorded in the admin audit log'
+);
+select is(
+    (select count(*) from admin_audit_log)::int,
+    2,
+    'Only two entries should exist in the admin audit log'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,43 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email, password)
+values (:'user2ID', 'user2', 'user2@email.com', 'password');
+insert into user_identity (provider, subject, user_id)
+values ('github', '1', :'user1ID');
+insert into user_identity (provider, subject, user_id)
+values ('google', 'people/1', :'user1ID');
+insert into user_identity (provider, subject, user_id)
+values ('github', '2', :'user2ID');
+
+-- Run some tests
+select lives_ok(
+    $$ select unlink_user_identity('00000000-0000-0000-0000-000000000001', 'github') $$,
+    'Github identity should be unlinked from user1'
+);
+select throws_ok(
+    $$ select unlink_user_identity('00000000-0000-0000-0000-000000000001', 'google') $$,
+    'last sign in method cannot be unlinked',
+    'Last identity of user1 (no password set) cannot be unlinked'
+);
+select lives_ok(
+    $$ select unlink_user_identity('00000000-0000-0000-0000-000000000002', 'github') $$,
+    'Last identity of user2 (password set) should be unlinked'
+);
+select results_eq(
+    $$ select provider, subject from user_identity $$,
+    $$ values ('google', 'people/1') $$,
+    'Only the google identity of user1 should remain'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(1);
+select plan(3);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -30,7 +30,9 @@ select update_user_profile(:'user1ID', '
     "alias": "user1 updated",
     "first_name": "firstname updated",
     "last_name": "lastname updated",
-    "profile_image_id": "00000000-0000-0000-0000-000000000002"
+    "profile_image_id": "00000000-0000-0000-0000-000000000002",
+    "locale": "fr",
+    "public_profile": true
 }
 '::jsonb);
 
@@ -43,7 +45,9 @@ select results_eq(
             last_name,
             email,
             password,
-            profile_image_id
+            profile_image_id,
+            locale,
+            public_profile
         from "user"
     $$,
     $$
@@ -53,10 +57,32 @@ select results_eq(
             'lastname updated',
             'user1@email.com',
             'password',
-            '00000000-0000-0000-0000-000000000002'::uuid
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            'fr',
+            true
         )
     $$,
-    'User first and last name should have been updated'
+    'User profile should have been updated'
+);
+
+-- Try to update user profile using an outdated version
+select throws_ok(
+    $$
+        select update_user_profile('00000000-0000-0000-0000-000000000001', '
+        {
+            "alias": "user1 updated again",
+            "version": 1
+        }
+        '::jsonb)
+    $$,
+    'P0001',
+    'version conflict',
+    'User profile update should fail because it has been modified since version provided'
+);
+select results_eq(
+    $$ select alias, version from "user" $$,
+    $$ values ('user1 updated', 2) $$,
+    'User profile should not have been updated (version incremented on previous update)'
 );
 
 -- Finish tests and rollback transaction
@@ -0,0 +1,39 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Register some admin audit entries
+select register_admin_audit_entry('disable_user', null, '{"reason": "spam"}');
+select register_admin_audit_entry('enable_user', null, '{"reason": "appeal accepted"}');
+
+-- Run some tests
+select is(
+    verify_admin_audit_log(),
+    null,
+    'Valid log expected'
+);
+
+-- Tamper with the second entry (triggers must be disabled to do it)
+alter table admin_audit_log disable trigger trigger_admin_audit_log_append_only;
+update admin_audit_log set reason = 'tampered'
+where entry_number = (select max(entry_number) from admin_audit_log);
+select is(
+    verify_admin_audit_log(),
+    (select admin_audit_log_id from admin_audit_log order by entry_number desc limit 1),
+    'Tampered entry expected'
+);
+
+-- Remove the first entry
+update admin_audit_log set reason = 'appeal accepted'
+where entry_number = (select max(entry_number) from admin_audit_log);
+delete from admin_audit_log
+where entry_number = (select min(entry_number) from admin_audit_log);
+select is(
+    verify_admin_audit_log(),
+    (select admin_audit_log_id from admin_audit_log order by entry_number desc limit 1),
+    'Entry following the removed one expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
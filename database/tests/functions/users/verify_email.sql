@@ -12,7 +12,7 @@ select register_user('
     "email_verified": false,
     "password": "password"
 }
-') as code \gset
+', 'codeHash');
 
 -- User has been registered
 select results_eq(
@@ -23,7 +23,7 @@ select results_eq(
 
 -- Verify email
 select is(
-    verify_email(:'code'),
+    verify_email('codeHash'),
     true,
     'Email should be verified succesfully'
 );
@@ -37,7 +37,7 @@ select is_empty(
     'Email verification should have been deleted'
 );
 select is(
-    verify_email(:'code'),
+    verify_email('codeHash'),
     false,
     'Trying to verify the same email again should not succeed'
 );
@@ -52,16 +52,16 @@ select register_user('
     "email_verified": false,
     "password": "password"
 }
-') as code2 \gset
+', 'codeHash2');
 
 -- Set email verification code created_at timestamp to two days ago
 update email_verification_code
 set created_at = created_at - '2 days'::interval
-where email_verification_code_id = :'code2';
+where email_verification_code_id = 'codeHash2';
 
 -- Verify new user's email
 select is(
-    verify_email(:'code2'),
+    verify_email('codeHash2'),
     false,
     'Email verification should not succeed as code is expired'
 );
@@ -106,6 +106,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "version": 1,
                     "event_kinds": [0],
                     "packages": [
                         {
@@ -113,6 +114,7 @@ select results_eq(
                             "name": "Package 1",
                             "normalized_name": "package-1",
                             "stars": 0,
+                            "downloads": 0,
                             "version": "1.0.0",
                             "logo_image_id": "00000000-0000-0000-0000-000000000001",
                             "ts": 1592299234,
@@ -140,6 +142,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "version": 1,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -147,6 +150,7 @@ select results_eq(
                             "name": "Package 1",
                             "normalized_name": "package-1",
                             "stars": 0,
+                            "downloads": 0,
                             "version": "1.0.0",
                             "logo_image_id": "00000000-0000-0000-0000-000000000001",
                             "ts": 1592299234,
@@ -187,6 +191,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "version": 1,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -194,6 +199,7 @@ select results_eq(
                             "name": "Package 1",
                             "normalized_name": "package-1",
                             "stars": 0,
+                            "downloads": 0,
                             "version": "1.0.0",
                             "logo_image_id": "00000000-0000-0000-0000-000000000001",
                             "ts": 1592299234,
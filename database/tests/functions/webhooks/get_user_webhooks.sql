@@ -102,6 +102,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "version": 1,
                     "event_kinds": [0],
                     "packages": [
                         {
@@ -109,6 +110,7 @@ select results_eq(
                             "name": "Package 1",
                             "normalized_name": "package-1",
                             "stars": 0,
+                            "downloads": 0,
                             "version": "1.0.0",
                             "logo_image_id": "00000000-0000-0000-0000-000000000001",
                             "ts": 1592299234,
@@ -136,6 +138,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "version": 1,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -143,6 +146,7 @@ select results_eq(
                             "name": "Package 1",
                             "normalized_name": "package-1",
                             "stars": 0,
+                            "downloads": 0,
                             "version": "1.0.0",
                             "logo_image_id": "00000000-0000-0000-0000-000000000001",
                             "ts": 1592299234,
@@ -183,6 +187,7 @@ select results_eq(
                     "content_type": "application/json",
                     "template": "custom payload",
                     "active": true,
+                    "version": 1,
                     "event_kinds": [1],
                     "packages": [
                         {
@@ -190,6 +195,7 @@ select results_eq(
                             "name": "Package 1",
                             "normalized_name": "package-1",
                             "stars": 0,
+                            "downloads": 0,
                             "version": "1.0.0",
                             "logo_image_id": "00000000-0000-0000-0000-000000000001",
                             "ts": 1592299234,
@@ -74,6 +74,7 @@ insert into notification (
     processed_at,
     error,
     event_id,
+    event_created_at,
     webhook_id
 ) values (
     :'notification1ID',
@@ -82,6 +83,7 @@ insert into notification (
     '2020-05-29 13:57:00+02',
     null,
     :'event1ID',
+    (select created_at from event where event_id = :'event1ID'),
     :'webhook1ID'
 );
 insert into notification (
@@ -91,6 +93,7 @@ insert into notification (
     processed_at,
     error,
     event_id,
+    event_created_at,
     webhook_id
 ) values (
     :'notification2ID',
@@ -99,6 +102,7 @@ insert into notification (
     '2020-05-29 13:58:00+02',
     'fake error',
     :'event2ID',
+    (select created_at from event where event_id = :'event2ID'),
     :'webhook1ID'
 );
 
@@ -130,6 +134,7 @@ select is(
         "content_type": "application/json",
         "template": "custom payload",
         "active": true,
+        "version": 1,
         "event_kinds": [0],
         "packages": [
             {
@@ -137,6 +142,7 @@ select is(
                 "name": "Package 1",
                 "normalized_name": "package-1",
                 "stars": 0,
+                "downloads": 0,
                 "version": "1.0.0",
                 "logo_image_id": "00000000-0000-0000-0000-000000000001",
                 "ts": 1592299234,
@@ -90,6 +90,7 @@ select is(
             "content_type": "application/json",
             "template": "custom payload",
             "active": true,
+            "version": 1,
             "event_kinds": [0],
             "packages": [
                 {
@@ -97,6 +98,7 @@ select is(
                     "name": "Package 1",
                     "normalized_name": "package-1",
                     "stars": 0,
+                    "downloads": 0,
                     "version": "1.0.0",
                     "logo_image_id": "00000000-0000-0000-0000-000000000001",
                     "ts": 1592299234,
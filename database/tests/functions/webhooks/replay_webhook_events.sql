@@ -0,0 +1,83 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set event2ID '00000000-0000-0000-0000-000000000002'
+\set event3ID '00000000-0000-0000-0000-000000000003'
+\set event4ID '00000000-0000-0000-0000-000000000004'
+\set event5ID '00000000-0000-0000-0000-000000000005'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'pkg1', '1.0.0', :'repo1ID');
+insert into webhook (webhook_id, name, url, active, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', true, :'user1ID');
+insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook1ID', 0);
+insert into webhook__package (webhook_id, package_id) values (:'webhook1ID', :'package1ID');
+insert into event (event_id, created_at, processed, package_version, package_id, event_kind_id)
+values (:'event1ID', current_timestamp - '10 minutes'::interval, true, '1.0.0', :'package1ID', 0);
+insert into event (event_id, created_at, processed, package_version, package_id, event_kind_id)
+values (:'event2ID', current_timestamp - '5 minutes'::interval, true, '1.0.1', :'package1ID', 0);
+insert into event (event_id, created_at, processed, package_version, package_id, event_kind_id)
+values (:'event3ID', current_timestamp - '5 minutes'::interval, true, '1.0.1', :'package1ID', 1);
+insert into event (event_id, created_at, processed, package_version, package_id, event_kind_id)
+values (:'event4ID', current_timestamp - '1 minutes'::interval, false, '1.0.2', :'package1ID', 0);
+insert into event (event_id, created_at, processed, package_version, package_id, event_kind_id)
+values (:'event5ID', current_timestamp - '1 hour'::interval, true, '0.9.0', :'package1ID', 0);
+insert into notification (event_id, event_created_at, processed, processed_at, error, webhook_id)
+values (:'event1ID', current_timestamp - '10 minutes'::interval, true, current_timestamp, 'error', :'webhook1ID');
+
+-- Run some tests
+select throws_ok(
+    $$
+        select replay_webhook_events(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001',
+            current_timestamp - '30 minutes'::interval,
+            current_timestamp
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Users without access to the webhook should not be able to replay its events'
+);
+select is(
+    replay_webhook_events(:'user1ID', :'webhook1ID', current_timestamp - '30 minutes'::interval, current_timestamp),
+    2,
+    'Two notifications should be queued'
+);
+select results_eq(
+    $$
+        select event_id, processed, processed_at, error
+        from notification
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+        order by event_id asc
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, false, null::timestamptz, null::text),
+            ('00000000-0000-0000-0000-000000000002'::uuid, false, null::timestamptz, null::text)
+    $$,
+    'Existing notification should be pending again and the missing one should have been created'
+);
+update webhook set active = false where webhook_id = :'webhook1ID';
+select is(
+    replay_webhook_events(:'user1ID', :'webhook1ID', current_timestamp - '30 minutes'::interval, current_timestamp),
+    0,
+    'No notifications should be queued for inactive webhooks'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
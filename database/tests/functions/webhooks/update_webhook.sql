@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(6);
+select plan(8);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -162,6 +162,29 @@ select results_eq(
     'Webhook2 owned by org1 should have been updated'
 );
 
+-- Try to update webhook using an outdated version
+select throws_ok(
+    $$
+        select update_webhook('00000000-0000-0000-0000-000000000001', '
+        {
+            "webhook_id": "00000000-0000-0000-0000-000000000002",
+            "name": "webhook2 updated again",
+            "url": "http://webhook2.url/updated",
+            "active": false,
+            "version": 1
+        }
+        '::jsonb)
+    $$,
+    'P0001',
+    'version conflict',
+    'Webhook update should fail because it has been modified since version provided'
+);
+select results_eq(
+    $$ select name, version from webhook where webhook_id = '00000000-0000-0000-0000-000000000002' $$,
+    $$ values ('webhook2 updated', 2) $$,
+    'Webhook2 should not have been updated (version incremented on previous update)'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
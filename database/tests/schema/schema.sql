@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(186);
+select plan(368);
 
 -- Check default_text_search_config is correct
 select results_eq(
@@ -16,42 +16,116 @@ select has_extension('tsm_system_rows');
 select has_extension('pg_partman');
 
 -- Check expected tables exist
+select has_table('admin_audit_log');
+select has_table('announced_release');
 select has_table('api_key');
+select has_table('api_key_usage');
+select has_table('authorization_decision');
+select has_table('blocked_content');
 select has_table('delete_user_code');
+select has_table('email_suppression');
 select has_table('email_verification_code');
 select has_table('event');
 select has_table('event_kind');
+select has_table('github_app_installation');
 select has_table('image');
+select has_table('image_scan');
+select has_table('image_url');
 select has_table('image_version');
+select has_table('inbox_notification');
+select has_table('issue_tracker');
+select has_table('issue_tracker_issue');
+select has_table('job_lease');
+select has_table('job_run');
 select has_table('maintainer');
+select has_table('maintainer_contact');
+select has_table('maintainer_verification_code');
+select has_table('maintenance_mode');
 select has_table('notification');
 select has_table('opt_out');
 select has_table('organization');
+select has_table('organization_domain');
+select has_table('organization_join_request');
 select has_table('package');
+select has_table('package_downloads');
+select has_table('package_ranking');
 select has_table('package_views');
 select has_table('package__maintainer');
 select has_table('password_reset_code');
 select has_table('production_usage');
 select has_table('repository');
+select has_table('repository_change');
 select has_table('repository_kind');
+select has_table('repository_ownership_claim');
+select has_table('repository_ownership_claim_token');
+select has_table('repository_tracking_run');
 select has_table('session');
 select has_table('snapshot');
+select has_table('snapshot_sbom');
 select has_table('subscription');
 select has_table('user');
+select has_table('user_identity');
 select has_table('user_starred_package');
 select has_table('user__organization');
 select has_table('version_functions');
 select has_table('version_schema');
+select has_table('vulnerability_statement');
 select has_table('webhook');
 select has_table('webhook__event_kind');
 select has_table('webhook__package');
 
 -- Check tables have expected columns
+select columns_are('admin_audit_log', array[
+    'admin_audit_log_id',
+    'action',
+    'user_id',
+    'reason',
+    'ip',
+    'user_agent',
+    'created_at',
+    'details',
+    'entry_number',
+    'previous_hash',
+    'hash',
+    'admin_user_id'
+]);
+select columns_are('announced_release', array[
+    'repository_id',
+    'package_name',
+    'version',
+    'digest',
+    'created_at'
+]);
 select columns_are('api_key', array[
     'api_key_id',
     'name',
     'secret',
     'user_id',
+    'created_at',
+    'last_used_at',
+    'allowed_cidrs'
+]);
+select columns_are('api_key_usage', array[
+    'api_key_id',
+    'day',
+    'requests',
+    'errors'
+]);
+select columns_are('authorization_decision', array[
+    'authorization_decision_id',
+    'organization_id',
+    'user_id',
+    'action',
+    'allowed',
+    'policy_digest',
+    'error',
+    'created_at'
+]);
+select columns_are('blocked_content', array[
+    'blocked_content_id',
+    'repository_id',
+    'package_id',
+    'reason',
     'created_at'
 ]);
 select columns_are('delete_user_code', array[
@@ -59,6 +133,12 @@ select columns_are('delete_user_code', array[
     'user_id',
     'created_at'
 ]);
+select columns_are('email_suppression', array[
+    'email',
+    'reason',
+    'provider',
+    'created_at'
+]);
 select columns_are('email_verification_code', array[
     'email_verification_code_id',
     'user_id',
@@ -79,19 +159,97 @@ select columns_are('event_kind', array[
     'event_kind_id',
     'name'
 ]);
+select columns_are('github_app_installation', array[
+    'installation_id',
+    'account',
+    'created_at',
+    'user_id',
+    'organization_id'
+]);
 select columns_are('image', array[
     'image_id',
     'original_hash'
 ]);
+select columns_are('image_scan', array[
+    'digest',
+    'report',
+    'sboms',
+    'created_at'
+]);
+select columns_are('image_url', array[
+    'url_digest',
+    'image_id',
+    'updated_at'
+]);
 select columns_are('image_version', array[
     'image_id',
     'version',
     'data'
 ]);
+select columns_are('inbox_notification', array[
+    'inbox_notification_id',
+    'user_id',
+    'kind',
+    'data',
+    'read',
+    'read_at',
+    'created_at'
+]);
+select columns_are('issue_tracker', array[
+    'issue_tracker_id',
+    'organization_id',
+    'kind',
+    'url',
+    'project',
+    'labels',
+    'username',
+    'token',
+    'active',
+    'created_at',
+    'updated_at'
+]);
+select columns_are('issue_tracker_issue', array[
+    'issue_tracker_id',
+    'package_id',
+    'vulnerability_id',
+    'url',
+    'created_at'
+]);
+select columns_are('job_lease', array[
+    'job_name',
+    'holder',
+    'period'
+]);
+select columns_are('job_run', array[
+    'job_run_id',
+    'job_name',
+    'started_at',
+    'duration',
+    'attempts',
+    'succeeded',
+    'error'
+]);
 select columns_are('maintainer', array[
     'maintainer_id',
     'name',
-    'email'
+    'email',
+    'verified'
+]);
+select columns_are('maintainer_contact', array[
+    'user_id',
+    'maintainer_id',
+    'created_at'
+]);
+select columns_are('maintainer_verification_code', array[
+    'maintainer_verification_code_id',
+    'maintainer_id',
+    'created_at'
+]);
+select columns_are('maintenance_mode', array[
+    'maintenance_mode_id',
+    'enabled',
+    'retry_after',
+    'updated_at'
 ]);
 select columns_are('notification', array[
     'notification_id',
@@ -100,8 +258,10 @@ select columns_are('notification', array[
     'processed_at',
     'error',
     'event_id',
+    'event_created_at',
     'user_id',
-    'webhook_id'
+    'webhook_id',
+    'issue_tracker_id'
 ]);
 select columns_are('opt_out', array[
     'opt_out_id',
@@ -120,7 +280,22 @@ select columns_are('organization', array[
     'authorization_enabled',
     'predefined_policy',
     'custom_policy',
-    'policy_data'
+    'policy_data',
+    'repository_changes_approval',
+    'public_profile'
+]);
+select columns_are('organization_domain', array[
+    'organization_id',
+    'domain',
+    'verification_token',
+    'verified_at',
+    'join_mode',
+    'created_at'
+]);
+select columns_are('organization_join_request', array[
+    'organization_id',
+    'user_id',
+    'created_at'
 ]);
 select columns_are('production_usage', array[
     'package_id',
@@ -138,7 +313,19 @@ select columns_are('package', array[
     'channels',
     'default_channel',
     'created_at',
-    'repository_id'
+    'repository_id',
+    'downloads'
+]);
+select columns_are('package_downloads', array[
+    'package_id',
+    'version',
+    'day',
+    'total'
+]);
+select columns_are('package_ranking', array[
+    'ranking',
+    'package_id',
+    'score'
 ]);
 select columns_are('package_views', array[
     'package_id',
@@ -176,19 +363,57 @@ select columns_are('repository', array[
     'data',
     'repository_kind_id',
     'user_id',
-    'organization_id'
+    'organization_id',
+    'version',
+    'github_app_installation_id',
+    'tracking_requested_at',
+    'last_tracking_errors_details'
+]);
+select columns_are('repository_change', array[
+    'repository_change_id',
+    'organization_id',
+    'kind',
+    'repository_name',
+    'repository',
+    'requested_by',
+    'created_at',
+    'transfer_organization_id',
+    'ownership_claim_method'
 ]);
 select columns_are('repository_kind', array[
     'repository_kind_id',
     'name'
 ]);
+select columns_are('repository_ownership_claim', array[
+    'repository_ownership_claim_id',
+    'repository_id',
+    'method',
+    'user_id',
+    'organization_id',
+    'previous_user_id',
+    'previous_organization_id',
+    'created_at'
+]);
+select columns_are('repository_ownership_claim_token', array[
+    'repository_id',
+    'user_id',
+    'token',
+    'created_at'
+]);
+select columns_are('repository_tracking_run', array[
+    'repository_id',
+    'duration',
+    'outcome',
+    'created_at'
+]);
 select columns_are('session', array[
     'session_id',
     'user_id',
     'ip',
     'user_agent',
     'approved',
-    'created_at'
+    'created_at',
+    'impersonated'
 ]);
 select columns_are('snapshot', array[
     'package_id',
@@ -197,6 +422,7 @@ select columns_are('snapshot', array[
     'description',
     'logo_url',
     'logo_image_id',
+    'logo_dark_image_id',
     'keywords',
     'home_url',
     'app_version',
@@ -226,13 +452,37 @@ select columns_are('snapshot', array[
     'created_at',
     'recommendations',
     'screenshots',
+    'videos',
     'sign_key',
-    'signatures'
+    'signatures',
+    'replaced_by',
+    'security_scan_requested_at',
+    'security_report_suppressed',
+    'signature_verified',
+    'provenance',
+    'images_licenses',
+    'content_warnings',
+    'crds_schemas',
+    'support',
+    'eol_event_registered',
+    'k8s_compatibility',
+    'translations',
+    'release_notes'
+]);
+select columns_are('snapshot_sbom', array[
+    'package_id',
+    'version',
+    'format',
+    'image',
+    'sbom',
+    'created_at'
 ]);
 select columns_are('subscription', array[
     'user_id',
     'package_id',
-    'event_kind_id'
+    'event_kind_id',
+    'min_severity',
+    'created_at'
 ]);
 select columns_are('user', array[
     'user_id',
@@ -246,11 +496,23 @@ select columns_are('user', array[
     'created_at',
     'tfa_enabled',
     'tfa_recovery_codes',
-    'tfa_url'
+    'tfa_url',
+    'locale',
+    'disabled',
+    'public_profile',
+    'version'
+]);
+select columns_are('user_identity', array[
+    'provider',
+    'subject',
+    'user_id',
+    'email',
+    'created_at'
 ]);
 select columns_are('user_starred_package', array[
     'user_id',
-    'package_id'
+    'package_id',
+    'created_at'
 ]);
 select columns_are('user__organization', array[
     'user_id',
@@ -263,6 +525,14 @@ select columns_are('version_functions', array[
 select columns_are('version_schema', array[
     'version'
 ]);
+select columns_are('vulnerability_statement', array[
+    'package_id',
+    'version',
+    'vulnerability_id',
+    'status',
+    'justification',
+    'created_at'
+]);
 select columns_are('webhook', array[
     'webhook_id',
     'name',
@@ -275,7 +545,8 @@ select columns_are('webhook', array[
     'created_at',
     'updated_at',
     'user_id',
-    'organization_id'
+    'organization_id',
+    'version'
 ]);
 select columns_are('webhook__event_kind', array[
     'webhook_id',
@@ -287,37 +558,106 @@ select columns_are('webhook__package', array[
 ]);
 
 -- Check tables have expected indexes
+select indexes_are('admin_audit_log', array[
+    'admin_audit_log_pkey',
+    'admin_audit_log_user_id_idx',
+    'admin_audit_log_entry_number_idx',
+    'admin_audit_log_admin_user_id_idx'
+]);
+select indexes_are('announced_release', array[
+    'announced_release_pkey'
+]);
 select indexes_are('api_key', array[
     'api_key_pkey'
 ]);
+select indexes_are('api_key_usage', array[
+    'api_key_usage_pkey'
+]);
+select indexes_are('authorization_decision', array[
+    'authorization_decision_pkey',
+    'authorization_decision_organization_id_created_at_idx'
+]);
+select indexes_are('blocked_content', array[
+    'blocked_content_pkey',
+    'blocked_content_repository_id_key',
+    'blocked_content_package_id_key'
+]);
 select indexes_are('delete_user_code', array[
     'delete_user_code_pkey',
     'delete_user_code_user_id_key'
 ]);
+select indexes_are('email_suppression', array[
+    'email_suppression_pkey'
+]);
 select indexes_are('email_verification_code', array[
     'email_verification_code_pkey',
     'email_verification_code_user_id_key'
 ]);
 select indexes_are('event', array[
     'event_pkey',
-    'event_not_processed_idx'
+    'event_not_processed_idx',
+    'event_repository_id_created_at_idx',
+    'event_package_id_created_at_idx'
+]);
+select indexes_are('github_app_installation', array[
+    'github_app_installation_pkey',
+    'github_app_installation_user_id_idx',
+    'github_app_installation_organization_id_idx'
 ]);
 select indexes_are('image', array[
     'image_pkey',
     'image_original_hash_key'
 ]);
+select indexes_are('image_scan', array[
+    'image_scan_pkey'
+]);
+select indexes_are('image_url', array[
+    'image_url_pkey'
+]);
 select indexes_are('image_version', array[
     'image_version_pkey'
 ]);
+select indexes_are('inbox_notification', array[
+    'inbox_notification_pkey',
+    'inbox_notification_user_id_created_at_idx',
+    'inbox_notification_user_id_unread_idx'
+]);
+select indexes_are('issue_tracker', array[
+    'issue_tracker_pkey',
+    'issue_tracker_organization_id_kind_project_key'
+]);
+select indexes_are('issue_tracker_issue', array[
+    'issue_tracker_issue_pkey'
+]);
+select indexes_are('job_lease', array[
+    'job_lease_pkey'
+]);
+select indexes_are('job_run', array[
+    'job_run_pkey',
+    'job_run_job_name_started_at_idx',
+    'job_run_started_at_idx'
+]);
 select indexes_are('maintainer', array[
     'maintainer_pkey',
     'maintainer_email_key'
 ]);
+select indexes_are('maintainer_contact', array[
+    'maintainer_contact_user_id_created_at_idx',
+    'maintainer_contact_maintainer_id_idx'
+]);
+select indexes_are('maintainer_verification_code', array[
+    'maintainer_verification_code_pkey',
+    'maintainer_verification_code_maintainer_id_key'
+]);
+select indexes_are('maintenance_mode', array[
+    'maintenance_mode_pkey'
+]);
 select indexes_are('notification', array[
     'notification_pkey',
     'notification_not_processed_idx',
-    'notification_event_id_user_id_key',
-    'notification_event_id_webhook_id_key',
+    'notification_event_id_event_created_at_user_id_key',
+    'notification_event_id_event_created_at_webhook_id_key',
+    'notification_event_id_event_created_at_issue_tracker_id_key',
     'notification_webhook_id_created_at_idx'
 ]);
 select indexes_are('opt_out', array[
@@ -328,6 +668,13 @@ select indexes_are('organization', array[
     'organization_pkey',
     'organization_name_key'
 ]);
+select indexes_are('organization_domain', array[
+    'organization_domain_pkey',
+    'organization_domain_verified_domain_idx'
+]);
+select indexes_are('organization_join_request', array[
+    'organization_join_request_pkey'
+]);
 select indexes_are('production_usage', array[
     'production_usage_pkey'
 ]);
@@ -337,6 +684,12 @@ select indexes_are('package', array[
     'package_repository_id_idx',
     'package_repository_id_name_key'
 ]);
+select indexes_are('package_downloads', array[
+    'package_downloads_pkey'
+]);
+select indexes_are('package_ranking', array[
+    'package_ranking_pkey'
+]);
 select indexes_are('package_views', array[
     'package_views_package_id_version_day_key'
 ]);
@@ -356,9 +709,25 @@ select indexes_are('repository', array[
     'repository_user_id_idx',
     'repository_organization_id_idx'
 ]);
+select indexes_are('repository_change', array[
+    'repository_change_pkey',
+    'repository_change_organization_id_idx',
+    'repository_change_requested_by_idx',
+    'repository_change_transfer_organization_id_idx'
+]);
 select indexes_are('repository_kind', array[
     'repository_kind_pkey'
 ]);
+select indexes_are('repository_ownership_claim', array[
+    'repository_ownership_claim_pkey',
+    'repository_ownership_claim_repository_id_idx'
+]);
+select indexes_are('repository_ownership_claim_token', array[
+    'repository_ownership_claim_token_pkey'
+]);
+select indexes_are('repository_tracking_run', array[
+    'repository_tracking_run_repository_id_created_at_idx'
+]);
 select indexes_are('session', array[
     'session_pkey'
 ]);
@@ -366,6 +735,9 @@ select indexes_are('snapshot', array[
     'snapshot_pkey',
     'snapshot_not_deprecated_with_readme_idx'
 ]);
+select indexes_are('snapshot_sbom', array[
+    'snapshot_sbom_pkey'
+]);
 select indexes_are('subscription', array[
     'subscription_pkey',
     'subscription_package_id_idx'
@@ -375,12 +747,19 @@ select indexes_are('user', array[
     'user_alias_key',
     'user_email_key'
 ]);
+select indexes_are('user_identity', array[
+    'user_identity_pkey',
+    'user_identity_user_id_provider_key'
+]);
 select indexes_are('user__organization', array[
     'user__organization_pkey'
 ]);
 select indexes_are('user_starred_package', array[
     'user_starred_package_pkey'
 ]);
+select indexes_are('vulnerability_statement', array[
+    'vulnerability_statement_pkey'
+]);
 select indexes_are('webhook', array[
     'webhook_pkey',
     'webhook_user_id_idx',
@@ -399,33 +778,90 @@ select indexes_are('webhook__package', array[
 select has_function('add_api_key');
 select has_function('delete_api_key');
 select has_function('get_api_key');
+select has_function('get_api_key_usage');
 select has_function('get_user_api_keys');
 select has_function('update_api_key');
+select has_function('update_api_keys_usage');
 -- Authz
 select has_function('notify_authorization_policies_updates');
+-- Blocklist
+select has_function('block_content');
+select has_function('get_blocked_content');
+select has_function('get_blocked_reason');
+select has_function('unblock_content');
+-- Emails
+select has_function('add_email_suppression');
+select has_function('is_email_suppressed');
 -- Events
+select has_function('drop_events_partition');
+select has_function('get_events_archive');
+select has_function('get_events_partitions');
+select has_function('get_events_partitions_to_archive');
 select has_function('get_pending_event');
+select has_function('maintain_events_partitions');
+-- GitHub App
+select has_function('link_github_app_installation');
+select has_function('process_github_app_push');
+select has_function('register_github_app_installation');
+select has_function('unregister_github_app_installation');
 -- Images
 select has_function('get_image');
 select has_function('register_image');
+-- Inbox
+select has_function('get_inbox_notifications');
+select has_function('get_inbox_unread_count');
+select has_function('mark_all_inbox_notifications_as_read');
+select has_function('mark_inbox_notification_as_read');
+-- Issue trackers
+select has_function('add_issue_tracker');
+select has_function('delete_issue_tracker');
+select has_function('get_issue_tracker_pending_vulnerabilities');
+select has_function('get_issue_trackers_for_package');
+select has_function('get_org_issue_trackers');
+select has_function('register_issue_tracker_issue');
+select has_function('update_issue_tracker');
+-- Jobs
+select has_function('acquire_job_lease');
+select has_function('get_job_runs');
+select has_function('register_job_run');
+-- Maintainers
+select has_function('register_maintainer_contact');
+select has_function('register_maintainer_verification_code');
+select has_function('verify_maintainer');
+-- Maintenance
+select has_function('get_maintenance_mode');
+select has_function('set_maintenance_mode');
 -- Notifications
 select has_function('add_notification');
 select has_function('get_pending_notification');
 select has_function('update_notification_status');
 -- Organizations
 select has_function('add_organization');
+select has_function('add_organization_domain');
 select has_function('add_organization_member');
+select has_function('approve_organization_join_request');
 select has_function('confirm_organization_membership');
 select has_function('delete_organization');
+select has_function('delete_organization_domain');
+select has_function('delete_organization_join_request');
 select has_function('delete_organization_member');
+select has_function('get_authorization_decisions');
 select has_function('get_authorization_policies');
 select has_function('get_authorization_policy');
 select has_function('get_organization');
+select has_function('get_organization_domains');
+select has_function('get_organization_join_requests');
 select has_function('get_organization_members');
+select has_function('get_organization_public_profile');
+select has_function('get_organization_security_overview');
 select has_function('get_user_organizations');
+select has_function('join_organization');
+select has_function('register_authorization_decision');
 select has_function('update_authorization_policy');
 select has_function('update_organization');
+select has_function('update_organization_domain');
 select has_function('user_belongs_to_organization');
+select has_function('verify_organization_domain');
 -- Packages
 select has_function('add_production_usage');
 select has_function('are_all_containers_images_whitelisted');
@@ -434,40 +870,73 @@ select has_function('enrich_package_data');
 select has_function('generate_package_tsdoc');
 select has_function('get_harbor_replication_dump');
 select has_function('get_helm_exporter_dump');
+select has_function('get_image_scan');
+select has_function('get_license_family');
 select has_function('get_package');
 select has_function('get_package_changelog');
+select has_function('get_package_downloads');
 select has_function('get_package_summary');
+select has_function('get_packages_ranking');
 select has_function('get_packages_starred_by_user');
 select has_function('get_package_stars');
+select has_function('get_package_upgrade_graph');
 select has_function('get_package_views');
 select has_function('get_packages_stats');
 select has_function('get_production_usage');
 select has_function('get_random_packages');
+select has_function('get_snapshot_bundle_data');
+select has_function('get_snapshot_images');
+select has_function('get_snapshot_license_inventory');
 select has_function('get_snapshots_to_scan');
+select has_function('get_vulnerability_statements');
 select has_function('is_latest');
+select has_function('register_eol_events');
+select has_function('register_image_scan');
 select has_function('register_package');
+select has_function('register_packages_downloads');
+select has_function('refresh_packages_rankings');
+select has_function('request_snapshot_scan');
 select has_function('search_packages');
 select has_function('search_packages_monocular');
 select has_function('semver_gt');
 select has_function('semver_gte');
 select has_function('toggle_star');
 select has_function('update_snapshot_security_report');
+select has_function('update_vulnerability_statements');
 select has_function('unregister_package');
 -- Repositories
 select has_function('add_repository');
+select has_function('approve_repository_change');
 select has_function('delete_repository');
+select has_function('get_pending_repository_changes');
+select has_function('get_publisher_profile_content');
 select has_function('get_repository_by_id');
 select has_function('get_repository_by_name');
+select has_function('get_repository_change_approvers');
+select has_function('get_repository_events');
 select has_function('get_repository_packages_digest');
+select has_function('get_repository_stats');
+select has_function('get_repository_subscriptions');
 select has_function('get_repository_summary');
+select has_function('get_repository_views');
+select has_function('register_repository_change');
+select has_function('register_repository_kind');
+select has_function('register_repository_ownership_claim_token');
+select has_function('register_repository_tracking_run');
+select has_function('reject_repository_change');
 select has_function('search_repositories');
 select has_function('set_last_scanning_results');
 select has_function('set_last_tracking_results');
 select has_function('set_verified_publisher');
 select has_function('transfer_repository');
 select has_function('update_repository');
+-- Sitemap
+select has_function('get_sitemap_packages');
+select has_function('get_sitemap_repositories');
 -- Stats
 select has_function('get_stats');
+select has_function('get_stats_running_total');
+select has_function('get_stats_time_series');
 -- Subscriptions
 select has_function('add_opt_out');
 select has_function('add_subscription');
@@ -482,15 +951,31 @@ select has_function('get_user_subscriptions');
 select has_function('approve_session');
 select has_function('check_user_alias_availability');
 select has_function('delete_user');
+select has_function('delete_user_email_suppression');
+select has_function('get_admin_audit_entry_hash');
+select has_function('get_admin_audit_log');
+select has_function('get_user_email_suppression');
+select has_function('get_user_identities');
 select has_function('get_user_profile');
+select has_function('get_user_public_profile');
 select has_function('get_user_tfa_config');
+select has_function('link_user_identity');
+select has_function('register_admin_audit_entry');
 select has_function('register_delete_user_code');
+select has_function('register_email_verification_code');
+select has_function('register_impersonation_session');
 select has_function('register_password_reset_code');
 select has_function('register_session');
 select has_function('register_user');
 select has_function('reset_user_password');
+select has_function('reset_user_tfa');
+select has_function('search_users');
+select has_function('set_user_disabled');
+select has_function('unlink_user_identity');
 select has_function('update_user_password');
 select has_function('update_user_profile');
+select has_function('prevent_admin_audit_log_changes');
+select has_function('verify_admin_audit_log');
 select has_function('verify_email');
 select has_function('verify_password_reset_code');
 -- Webhooks
@@ -500,6 +985,7 @@ select has_function('get_webhook');
 select has_function('get_org_webhooks');
 select has_function('get_user_webhooks');
 select has_function('get_webhooks_subscribed_to_package');
+select has_function('replay_webhook_events');
 select has_function('update_webhook');
 select has_function('user_has_access_to_webhook');
 
@@ -519,7 +1005,12 @@ select results_eq(
         (9, 'CoreDNS plugins'),
         (10, 'Keptn integrations'),
         (11, 'Tekton pipelines'),
-        (12, 'Containers images')
+        (12, 'Containers images'),
+        (13, 'Terraform modules'),
+        (14, 'Crossplane packages'),
+        (15, 'Kyverno policies'),
+        (16, 'Knative function templates'),
+        (17, 'Headlamp plugins')
     $$,
     'Repository kinds should exist'
 );
@@ -532,7 +1023,8 @@ select results_eq(
         (1, 'Security alert'),
         (2, 'Repository tracking errors'),
         (3, 'Repository ownership claim'),
-        (4, 'Repository scanning errors')
+        (4, 'Repository scanning errors'),
+        (5, 'Package content warnings')
     $$,
     'Event kinds should exist'
 );
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
@@ -20,7 +21,8 @@ const (
 	addAPIKeyDBQ       = `select add_api_key($1::jsonb)`
 	deleteAPIKeyDBQ    = `select delete_api_key($1::uuid, $2::uuid)`
 	getAPIKeyDBQ       = `select get_api_key($1::uuid, $2::uuid)`
-	getAPIKeyUserIDDBQ = `select user_id, secret from api_key where api_key_id = $1`
+	getAPIKeyUsageDBQ  = `select get_api_key_usage($1::uuid, $2::uuid)`
+	getAPIKeyUserIDDBQ = `select ak.user_id, ak.secret, coalesce(ak.allowed_cidrs::text[], '{}') from api_key ak join "user" u using (user_id) where ak.api_key_id = $1 and u.disabled = false`
 	getUserAPIKeysDBQ  = `select * from get_user_api_keys($1::uuid, $2::int, $3::int)`
 	updateAPIKeyDBQ    = `select update_api_key($1::jsonb)`
 )
@@ -45,6 +47,9 @@ func (m *Manager) Add(ctx context.Context, ak *hub.APIKey) (*hub.APIKey, error)
 	if ak.Name == "" {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
 	}
+	if err := validateAllowedCIDRs(ak.AllowedCIDRs); err != nil {
+		return nil, err
+	}
 
 	// Generate API key secret
 	randomBytes := make([]byte, 32)
@@ -68,16 +73,18 @@ func (m *Manager) Add(ctx context.Context, ak *hub.APIKey) (*hub.APIKey, error)
 	}, nil
 }
 
-// Check checks if the api key provided is valid.
-func (m *Manager) Check(ctx context.Context, apiKeyID, apiKeySecret string) (*hub.CheckAPIKeyOutput, error) {
+// Check checks if the api key provided is valid and if it can be used from the
+// ip address given.
+func (m *Manager) Check(ctx context.Context, apiKeyID, apiKeySecret, ip string) (*hub.CheckAPIKeyOutput, error) {
 	// Validate input
 	if apiKeyID == "" || apiKeySecret == "" {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "api key id or secret not provided")
 	}
 
-	// Get key's user id and secret from database
+	// Get key's user id, secret and allowed cidrs from database
 	var userID, apiKeySecretHashed string
-	err := m.db.QueryRow(ctx, getAPIKeyUserIDDBQ, apiKeyID).Scan(&userID, &apiKeySecretHashed)
+	var allowedCIDRs []string
+	err := m.db.QueryRow(ctx, getAPIKeyUserIDDBQ, apiKeyID).Scan(&userID, &apiKeySecretHashed, &allowedCIDRs)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return &hub.CheckAPIKeyOutput{Valid: false}, nil
@@ -90,6 +97,11 @@ func (m *Manager) Check(ctx context.Context, apiKeyID, apiKeySecret string) (*hu
 		return &hub.CheckAPIKeyOutput{Valid: false}, nil
 	}
 
+	// Check if the key can be used from the ip provided
+	if !isIPAllowed(ip, allowedCIDRs) {
+		return &hub.CheckAPIKeyOutput{Valid: false, IPNotAllowed: true}, nil
+	}
+
 	return &hub.CheckAPIKeyOutput{
 		Valid:  true,
 		UserID: userID,
@@ -132,6 +144,20 @@ func (m *Manager) GetOwnedByUserJSON(ctx context.Context, p *hub.Pagination) (*h
 	return util.DBQueryJSONWithPagination(ctx, m.db, getUserAPIKeysDBQ, userID, p.Limit, p.Offset)
 }
 
+// GetUsageJSON returns the usage of the requested api key during the last 30
+// days as a json object.
+func (m *Manager) GetUsageJSON(ctx context.Context, apiKeyID string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(apiKeyID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid api key id")
+	}
+
+	// Get api key usage from database
+	return util.DBQueryJSON(ctx, m.db, getAPIKeyUsageDBQ, userID, apiKeyID)
+}
+
 // Update updates the provided api key in the database.
 func (m *Manager) Update(ctx context.Context, ak *hub.APIKey) error {
 	ak.UserID = ctx.Value(hub.UserIDKey).(string)
@@ -143,6 +169,9 @@ func (m *Manager) Update(ctx context.Context, ak *hub.APIKey) error {
 	if ak.Name == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
 	}
+	if err := validateAllowedCIDRs(ak.AllowedCIDRs); err != nil {
+		return err
+	}
 
 	// Update api key in database
 	akJSON, _ := json.Marshal(ak)
@@ -150,6 +179,35 @@ func (m *Manager) Update(ctx context.Context, ak *hub.APIKey) error {
 	return err
 }
 
+// validateAllowedCIDRs checks the allowed CIDR ranges provided are valid.
+func validateAllowedCIDRs(allowedCIDRs []string) error {
+	for _, cidr := range allowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("%w: invalid cidr: %s", hub.ErrInvalidInput, cidr)
+		}
+	}
+	return nil
+}
+
+// isIPAllowed checks if the ip provided belongs to any of the allowed CIDR
+// ranges given. All ips are allowed when no CIDR ranges are provided.
+func isIPAllowed(ip string, allowedCIDRs []string) bool {
+	if len(allowedCIDRs) == 0 {
+		return true
+	}
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	for _, cidr := range allowedCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err == nil && ipNet.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
+
 // hash is a helper function that creates a sha512 hash of the text provided.
 func hash(text string) string {
 	return fmt.Sprintf("%x", sha512.Sum512([]byte(text)))
@@ -43,6 +43,13 @@ func TestAdd(t *testing.T) {
 					Name: "",
 				},
 			},
+			{
+				"invalid cidr",
+				&hub.APIKey{
+					Name:         "apikey1",
+					AllowedCIDRs: []string{"10.0.0.0/8", "10.0.0.1"},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -117,7 +124,7 @@ func TestCheck(t *testing.T) {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
 				m := NewManager(nil)
-				_, err := m.Check(ctx, tc.apiKeyID, tc.apiKeySecret)
+				_, err := m.Check(ctx, tc.apiKeyID, tc.apiKeySecret, "1.1.1.1")
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
@@ -130,7 +137,7 @@ func TestCheck(t *testing.T) {
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return(nil, pgx.ErrNoRows)
 		m := NewManager(db)
 
-		output, err := m.Check(ctx, "keyID", "secret")
+		output, err := m.Check(ctx, "keyID", "secret", "1.1.1.1")
 		assert.NoError(t, err)
 		assert.False(t, output.Valid)
 		assert.Empty(t, output.UserID)
@@ -143,7 +150,7 @@ func TestCheck(t *testing.T) {
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		output, err := m.Check(ctx, "keyID", "secret")
+		output, err := m.Check(ctx, "keyID", "secret", "1.1.1.1")
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, output)
 		db.AssertExpectations(t)
@@ -156,7 +163,7 @@ func TestCheck(t *testing.T) {
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return([]interface{}{"userID", secretHashed}, nil)
 		m := NewManager(db)
 
-		output, err := m.Check(ctx, "keyID", "invalid-secret")
+		output, err := m.Check(ctx, "keyID", "invalid-secret", "1.1.1.1")
 		assert.NoError(t, err)
 		assert.False(t, output.Valid)
 		assert.Empty(t, output.UserID)
@@ -170,12 +177,44 @@ func TestCheck(t *testing.T) {
 		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return([]interface{}{"userID", secretHashed}, nil)
 		m := NewManager(db)
 
-		output, err := m.Check(ctx, "keyID", "secret")
+		output, err := m.Check(ctx, "keyID", "secret", "1.1.1.1")
 		assert.NoError(t, err)
 		assert.True(t, output.Valid)
 		assert.Equal(t, "userID", output.UserID)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("key used from an ip not allowed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
+		allowedCIDRs := []string{"10.0.0.0/8", "192.168.1.0/24"}
+		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return([]interface{}{"userID", secretHashed, allowedCIDRs}, nil)
+		m := NewManager(db)
+
+		output, err := m.Check(ctx, "keyID", "secret", "192.168.2.1")
+		assert.NoError(t, err)
+		assert.False(t, output.Valid)
+		assert.True(t, output.IPNotAllowed)
+		assert.Empty(t, output.UserID)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("valid key used from an ip allowed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
+		allowedCIDRs := []string{"10.0.0.0/8", "192.168.1.0/24"}
+		db.On("QueryRow", ctx, getAPIKeyUserIDDBQ, "keyID").Return([]interface{}{"userID", secretHashed, allowedCIDRs}, nil)
+		m := NewManager(db)
+
+		output, err := m.Check(ctx, "keyID", "secret", "192.168.1.10")
+		assert.NoError(t, err)
+		assert.True(t, output.Valid)
+		assert.False(t, output.IPNotAllowed)
+		assert.Equal(t, "userID", output.UserID)
+		db.AssertExpectations(t)
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -300,6 +339,49 @@ func TestGetOwnedByUserJSON(t *testing.T) {
 	})
 }
 
+func TestGetUsageJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetUsageJSON(context.Background(), apiKeyID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetUsageJSON(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAPIKeyUsageDBQ, "userID", apiKeyID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetUsageJSON(ctx, apiKeyID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("api key usage data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAPIKeyUsageDBQ, "userID", apiKeyID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetUsageJSON(ctx, apiKeyID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -334,6 +416,14 @@ func TestUpdate(t *testing.T) {
 					Name:     "",
 				},
 			},
+			{
+				"invalid cidr",
+				&hub.APIKey{
+					APIKeyID:     apiKeyID,
+					Name:         "apikey1",
+					AllowedCIDRs: []string{"invalid"},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -20,8 +20,8 @@ func (m *ManagerMock) Add(ctx context.Context, ak *hub.APIKey) (*hub.APIKey, err
 }
 
 // Check implements the UserManager interface.
-func (m *ManagerMock) Check(ctx context.Context, apiKeyID, apiKeySecret string) (*hub.CheckAPIKeyOutput, error) {
-	args := m.Called(ctx, apiKeyID, apiKeySecret)
+func (m *ManagerMock) Check(ctx context.Context, apiKeyID, apiKeySecret, ip string) (*hub.CheckAPIKeyOutput, error) {
+	args := m.Called(ctx, apiKeyID, apiKeySecret, ip)
 	data, _ := args.Get(0).(*hub.CheckAPIKeyOutput)
 	return data, args.Error(1)
 }
@@ -46,8 +46,26 @@ func (m *ManagerMock) GetJSON(ctx context.Context, apiKeyID string) ([]byte, err
 	return data, args.Error(1)
 }
 
+// GetUsageJSON implements the APIKeyManager interface.
+func (m *ManagerMock) GetUsageJSON(ctx context.Context, apiKeyID string) ([]byte, error) {
+	args := m.Called(ctx, apiKeyID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // Update implements the APIKeyManager interface.
 func (m *ManagerMock) Update(ctx context.Context, ak *hub.APIKey) error {
 	args := m.Called(ctx, ak)
 	return args.Error(0)
 }
+
+// UsageTrackerMock is a mock implementation of the APIKeyUsageTracker
+// interface.
+type UsageTrackerMock struct {
+	mock.Mock
+}
+
+// TrackUsage implements the APIKeyUsageTracker interface.
+func (m *UsageTrackerMock) TrackUsage(apiKeyID string, statusCode int) {
+	m.Called(apiKeyID, statusCode)
+}
@@ -0,0 +1,158 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Database queries
+	updateAPIKeysUsageDBQ = `select update_api_keys_usage($1::bigint, $2::jsonb)`
+
+	// defaultFlushFrequency represents how often api keys usage will be
+	// written to the database.
+	defaultFlushFrequency = 5 * time.Minute
+
+	// sep is the separator used in the usage map keys.
+	sep = "##"
+)
+
+// usage represents the usage of an api key during a given day.
+type usage struct {
+	requests   int
+	errors     int
+	lastUsedAt int64
+}
+
+// UsageTracker aggregates api keys usage that is periodically flushed to the
+// database.
+type UsageTracker struct {
+	db             hub.DB
+	flushFrequency time.Duration
+	mm             hub.MaintenanceManager
+	now            func() time.Time
+
+	mu    sync.Mutex
+	usage map[string]*usage
+}
+
+// NewUsageTracker creates a new UsageTracker instance.
+func NewUsageTracker(db hub.DB, opts ...func(t *UsageTracker)) *UsageTracker {
+	t := &UsageTracker{
+		db:             db,
+		flushFrequency: defaultFlushFrequency,
+		now:            time.Now,
+		usage:          make(map[string]*usage),
+	}
+	for _, o := range opts {
+		o(t)
+	}
+	return t
+}
+
+// WithFlushFrequency allows configuring the usage tracker flush frequency.
+func WithFlushFrequency(d time.Duration) func(t *UsageTracker) {
+	return func(t *UsageTracker) {
+		t.flushFrequency = d
+	}
+}
+
+// WithMaintenanceManager allows providing a maintenance manager, used to skip
+// flushes while the maintenance mode is enabled. The api keys usage will keep being
+// aggregated and will be flushed once the maintenance mode is disabled.
+func WithMaintenanceManager(mm hub.MaintenanceManager) func(t *UsageTracker) {
+	return func(t *UsageTracker) {
+		t.mm = mm
+	}
+}
+
+// Flusher handles the periodic flushes of api keys usage. It'll keep running
+// until the context provided is done.
+func (t *UsageTracker) Flusher(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	doFlush := func() {
+		t.mu.Lock()
+		if len(t.usage) == 0 {
+			t.mu.Unlock()
+			return
+		}
+		t.mu.Unlock()
+		if t.mm != nil && maintenance.IsEnabled(context.Background(), t.mm) {
+			log.Info().Msg("maintenance mode enabled, skipping api keys usage flush")
+			return
+		}
+		if err := t.flush(); err != nil {
+			log.Error().Err(err).Msg("error flushing api keys usage")
+		}
+	}
+	for {
+		select {
+		case <-time.After(t.flushFrequency):
+			doFlush()
+		case <-ctx.Done():
+			doFlush()
+			return
+		}
+	}
+}
+
+// TrackUsage tracks a single request made using the api key provided. Requests
+// that resulted in a status code >= 400 are counted as errors.
+func (t *UsageTracker) TrackUsage(apiKeyID string, statusCode int) {
+	now := t.now()
+	key := fmt.Sprintf("%s%s%s", apiKeyID, sep, now.Format("2006-01-02"))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	u, ok := t.usage[key]
+	if !ok {
+		u = &usage{}
+		t.usage[key] = u
+	}
+	u.requests++
+	if statusCode >= http.StatusBadRequest {
+		u.errors++
+	}
+	u.lastUsedAt = now.Unix()
+}
+
+// flush writes the aggregated api keys usage to the database.
+func (t *UsageTracker) flush() error {
+	// Prepare data for database update
+	t.mu.Lock()
+	keys := make([]string, 0, len(t.usage))
+	for k := range t.usage {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	data := make([][]interface{}, 0, len(t.usage))
+	for _, key := range keys {
+		parts := strings.Split(key, sep)
+		u := t.usage[key]
+		data = append(data, []interface{}{parts[0], parts[1], u.requests, u.errors, u.lastUsedAt})
+	}
+	t.usage = make(map[string]*usage)
+	t.mu.Unlock()
+	dataJSON, _ := json.Marshal(data)
+
+	// Write data to database
+	_, err := t.db.Exec(
+		context.Background(),
+		updateAPIKeysUsageDBQ,
+		util.DBLockKeyUpdateAPIKeysUsage,
+		dataJSON,
+	)
+	return err
+}
@@ -0,0 +1,127 @@
+package apikey
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageTracker(t *testing.T) {
+	apiKey1ID := "00000000-0000-0000-0000-000000000001"
+	apiKey2ID := "00000000-0000-0000-0000-000000000002"
+	now := time.Date(2021, 12, 3, 10, 0, 0, 0, time.UTC)
+	withFixedTime := func(ut *UsageTracker) {
+		ut.now = func() time.Time { return now }
+	}
+
+	t.Run("custom flushing frequency", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+
+		ut := NewUsageTracker(db, WithFlushFrequency(2*time.Second))
+		assert.NotNil(t, ut)
+		assert.Equal(t, 2*time.Second, ut.flushFrequency)
+	})
+
+	t.Run("nothing to flush", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+
+		ut := NewUsageTracker(db)
+		wg.Add(1)
+		go ut.Flusher(ctx, &wg)
+		cancel()
+		wg.Wait()
+		db.AssertExpectations(t)
+	})
+
+	t.Run("ctx cancelled, flush and stop", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", context.Background(), updateAPIKeysUsageDBQ,
+			util.DBLockKeyUpdateAPIKeysUsage,
+			[]byte(`[["00000000-0000-0000-0000-000000000001","2021-12-03",1,0,1638525600]]`),
+		).Return(nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+
+		ut := NewUsageTracker(db, withFixedTime)
+		wg.Add(1)
+		go ut.Flusher(ctx, &wg)
+		ut.TrackUsage(apiKey1ID, http.StatusOK)
+		cancel()
+		wg.Wait()
+		db.AssertExpectations(t)
+	})
+
+	t.Run("maintenance mode enabled, flush skipped", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		mm := &maintenance.ManagerMock{}
+		mm.On("Get", context.Background()).Return(&hub.MaintenanceMode{Enabled: true}, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+
+		ut := NewUsageTracker(db, withFixedTime, WithMaintenanceManager(mm))
+		wg.Add(1)
+		go ut.Flusher(ctx, &wg)
+		ut.TrackUsage(apiKey1ID, http.StatusOK)
+		cancel()
+		wg.Wait()
+		db.AssertExpectations(t)
+		mm.AssertExpectations(t)
+		assert.Len(t, ut.usage, 1)
+	})
+
+	t.Run("db error flushing", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", context.Background(), updateAPIKeysUsageDBQ,
+			util.DBLockKeyUpdateAPIKeysUsage,
+			[]byte(`[["00000000-0000-0000-0000-000000000001","2021-12-03",1,1,1638525600]]`),
+		).Return(tests.ErrFakeDB)
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+
+		ut := NewUsageTracker(db, withFixedTime)
+		wg.Add(1)
+		go ut.Flusher(ctx, &wg)
+		ut.TrackUsage(apiKey1ID, http.StatusInternalServerError)
+		cancel()
+		wg.Wait()
+		db.AssertExpectations(t)
+	})
+
+	t.Run("some api keys usage flushed by timer successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", context.Background(), updateAPIKeysUsageDBQ,
+			util.DBLockKeyUpdateAPIKeysUsage,
+			[]byte(`[["00000000-0000-0000-0000-000000000001","2021-12-03",3,1,1638525600],["00000000-0000-0000-0000-000000000002","2021-12-03",1,1,1638525600]]`),
+		).Return(nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+
+		ut := NewUsageTracker(db, WithFlushFrequency(1*time.Second), withFixedTime)
+		wg.Add(1)
+		go ut.Flusher(ctx, &wg)
+		ut.TrackUsage(apiKey1ID, http.StatusOK)
+		ut.TrackUsage(apiKey1ID, http.StatusNotFound)
+		ut.TrackUsage(apiKey1ID, http.StatusNoContent)
+		ut.TrackUsage(apiKey2ID, http.StatusForbidden)
+		time.Sleep(1500 * time.Millisecond)
+		db.AssertExpectations(t)
+		cancel()
+		wg.Wait()
+	})
+}
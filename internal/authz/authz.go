@@ -3,10 +3,13 @@ package authz
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -25,10 +28,15 @@ const (
 	AllowedActionsQuery = "data.artifacthub.authz.allowed_actions"
 
 	// Database queries
-	getAuthzPoliciesDBQ = `select get_authorization_policies()`
-	getUserAliasDBQ     = `select alias from "user" where user_id = $1`
+	getAuthzPoliciesDBQ      = `select get_authorization_policies()`
+	getUserAliasDBQ          = `select alias from "user" where user_id = $1`
+	registerAuthzDecisionDBQ = `select register_authorization_decision($1::jsonb)`
 
 	pauseOnError = 10 * time.Second
+
+	// policyEvalTimeout represents the maximum amount of time allowed to
+	// evaluate an authorization policy query.
+	policyEvalTimeout = 5 * time.Second
 )
 
 var (
@@ -37,11 +45,19 @@ var (
 
 	validPredefinedPolicies = []string{
 		"rbac.v1",
+		"read-only-members.v1",
+		"repo-maintainers.v1",
+		"approval-required.v1",
 	}
 	policyMgmtActions = []hub.Action{
 		hub.GetAuthorizationPolicy,
 		hub.UpdateAuthorizationPolicy,
 	}
+
+	// policyCapabilities represents the capabilities available to the
+	// authorization policies. Builtins that can reach the network are not
+	// allowed, as policies are provided by the organizations.
+	policyCapabilities = restrictedCapabilities()
 )
 
 // Authorizer is in charge of authorizing actions that users intend to perform.
@@ -51,6 +67,17 @@ type Authorizer struct {
 
 	mu                    sync.RWMutex
 	allowedActionsQueries map[string]rego.PreparedEvalQuery
+	customPoliciesDigests map[string]string
+}
+
+// decision represents an authorization decision made using a custom policy.
+type decision struct {
+	OrganizationName string     `json:"organization_name"`
+	UserID           string     `json:"user_id"`
+	Action           hub.Action `json:"action"`
+	Allowed          bool       `json:"allowed"`
+	PolicyDigest     string     `json:"policy_digest"`
+	Error            string     `json:"error,omitempty"`
 }
 
 // NewAuthorizer creates a new Authorizer instance.
@@ -59,6 +86,7 @@ func NewAuthorizer(db hub.DB) (*Authorizer, error) {
 		db:                    db,
 		logger:                log.With().Str("svc", "authorizer").Logger(),
 		allowedActionsQueries: make(map[string]rego.PreparedEvalQuery),
+		customPoliciesDigests: make(map[string]string),
 	}
 
 	// Prepare policies queries and setup a database listener so that they are
@@ -90,6 +118,7 @@ func (a *Authorizer) preparePoliciesQueries() error {
 
 	// Prepare authorization policies queries
 	allowedActionsQueries := make(map[string]rego.PreparedEvalQuery)
+	customPoliciesDigests := make(map[string]string)
 	for organizationName, policy := range policies {
 		if !policy.AuthorizationEnabled {
 			continue
@@ -104,14 +133,22 @@ func (a *Authorizer) preparePoliciesQueries() error {
 			rego.Query(AllowedActionsQuery),
 			rego.Module(fmt.Sprintf("%s.rego", organizationName), rules),
 			rego.Store(inmem.NewFromReader(bytes.NewBuffer(policy.PolicyData))),
+			rego.Capabilities(policyCapabilities),
 		).PrepareForEval(context.Background())
 		if err == nil {
 			allowedActionsQueries[organizationName] = allowedActionsPreparedEvalQuery
 		}
+
+		// Decisions are only recorded for custom policies, so we keep track of
+		// the version of the policy used to make them
+		if policy.CustomPolicy != "" {
+			customPoliciesDigests[organizationName] = policyDigest(policy)
+		}
 	}
 
 	a.mu.Lock()
 	a.allowedActionsQueries = allowedActionsQueries
+	a.customPoliciesDigests = customPoliciesDigests
 	a.mu.Unlock()
 
 	return nil
@@ -153,14 +190,45 @@ func (a *Authorizer) listenForPoliciesUpdates() {
 func (a *Authorizer) Authorize(ctx context.Context, input *hub.AuthorizeInput) error {
 	allowedActions, err := a.GetAllowedActions(ctx, input.UserID, input.OrganizationName)
 	if err != nil {
+		a.registerDecision(ctx, input, false, err)
 		return fmt.Errorf("%w: error getting allowed actions: %s", hub.ErrInsufficientPrivilege, err.Error())
 	}
 	if !IsActionAllowed(allowedActions, input.Action) {
+		a.registerDecision(ctx, input, false, nil)
 		return hub.ErrInsufficientPrivilege
 	}
+	a.registerDecision(ctx, input, true, nil)
 	return nil
 }
 
+// registerDecision records the authorization decision made for the input
+// provided when the organization uses a custom policy, so that it can be
+// inspected later to debug it. Errors registering the decision are logged but
+// not returned, as they must not affect the decision itself.
+func (a *Authorizer) registerDecision(ctx context.Context, input *hub.AuthorizeInput, allowed bool, err error) {
+	a.mu.RLock()
+	digest, ok := a.customPoliciesDigests[input.OrganizationName]
+	a.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	d := &decision{
+		OrganizationName: input.OrganizationName,
+		UserID:           input.UserID,
+		Action:           input.Action,
+		Allowed:          allowed,
+		PolicyDigest:     digest,
+	}
+	if err != nil {
+		d.Error = err.Error()
+	}
+	decisionJSON, _ := json.Marshal(d)
+	if _, err := a.db.Exec(ctx, registerAuthzDecisionDBQ, decisionJSON); err != nil {
+		a.logger.Error().Err(err).Str("org", input.OrganizationName).Msg("error registering authorization decision")
+	}
+}
+
 // GetAllowedActions returns the actions a given user is allowed to perform in
 // the provided organization. We'll obtain them querying the organization
 // authorization policy.
@@ -184,30 +252,76 @@ func (a *Authorizer) GetAllowedActions(ctx context.Context, userID, orgName stri
 	}
 
 	// Evaluate authorization policy allowed actions query
-	queryInput := map[string]interface{}{
-		"user": userAlias,
+	return evalAllowedActionsQuery(ctx, query, userAlias)
+}
+
+// TestPolicy evaluates the authorization policy provided against the sample
+// inputs given, without saving it. Errors found preparing the policy or
+// evaluating each of the inputs are part of the test output, so they are not
+// returned as an error.
+func (a *Authorizer) TestPolicy(
+	ctx context.Context,
+	test *hub.AuthorizationPolicyTest,
+) (*hub.AuthorizationPolicyTestOutput, error) {
+	output := &hub.AuthorizationPolicyTestOutput{
+		Errors:  []string{},
+		Results: make([]*hub.AuthorizationPolicyTestResult, 0, len(test.Inputs)),
 	}
-	results, err := query.Eval(ctx, rego.EvalInput(queryInput))
+
+	// Prepare policy rules and data
+	var rules string
+	if test.Policy.PredefinedPolicy != "" {
+		rules = predefinedPolicies[test.Policy.PredefinedPolicy]
+	} else {
+		rules = test.Policy.CustomPolicy
+	}
+	policyDataJSON, err := strconv.Unquote(string(test.Policy.PolicyData))
 	if err != nil {
-		return nil, err
-	} else if len(results) != 1 || len(results[0].Expressions) != 1 {
-		return nil, errors.New("allowed actions query returned no results")
+		policyDataJSON = string(test.Policy.PolicyData)
+	}
+	var policyData map[string]interface{}
+	if err := json.Unmarshal([]byte(policyDataJSON), &policyData); err != nil {
+		output.Errors = append(output.Errors, fmt.Sprintf("invalid policy data: %s", err.Error()))
+		return output, nil
 	}
 
-	// Prepare allowed actions and return them
-	values, ok := results[0].Expressions[0].Value.([]interface{})
-	if !ok {
-		return nil, errors.New("invalid allowed actions output")
+	// Prepare policy query
+	query, err := rego.New(
+		rego.Query(AllowedActionsQuery),
+		rego.Module("policy.rego", rules),
+		rego.Store(inmem.NewFromObject(policyData)),
+		rego.Capabilities(policyCapabilities),
+	).PrepareForEval(ctx)
+	if err != nil {
+		var astErrs ast.Errors
+		if errors.As(err, &astErrs) {
+			for _, astErr := range astErrs {
+				output.Errors = append(output.Errors, astErr.Error())
+			}
+		} else {
+			output.Errors = append(output.Errors, err.Error())
+		}
+		return output, nil
 	}
-	allowedActions := make([]hub.Action, 0, len(values))
-	for _, v := range values {
-		action, ok := v.(string)
-		if !ok {
-			return nil, errors.New("invalid allowed action value")
+
+	// Evaluate policy query for each of the inputs provided
+	for _, input := range test.Inputs {
+		result := &hub.AuthorizationPolicyTestResult{
+			User:           input.User,
+			Action:         input.Action,
+			AllowedActions: []hub.Action{},
 		}
-		allowedActions = append(allowedActions, hub.Action(action))
+		allowedActions, err := evalAllowedActionsQuery(ctx, query, input.User)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.AllowedActions = allowedActions
+			result.Allowed = IsActionAllowed(allowedActions, input.Action)
+		}
+		output.Results = append(output.Results, result)
 	}
-	return allowedActions, nil
+
+	return output, nil
 }
 
 // WillUserBeLockedOut checks if the user will be locked out if the new policy
@@ -238,6 +352,7 @@ func (a *Authorizer) WillUserBeLockedOut(
 		rego.Query(AllowedActionsQuery),
 		rego.Module("", rules),
 		rego.Store(inmem.NewFromReader(bytes.NewBufferString(policyDataJSON))),
+		rego.Capabilities(policyCapabilities),
 	).PrepareForEval(context.Background())
 	if err != nil {
 		return true, err
@@ -245,6 +360,8 @@ func (a *Authorizer) WillUserBeLockedOut(
 	queryInput := map[string]interface{}{
 		"user": userAlias,
 	}
+	ctx, cancel := context.WithTimeout(ctx, policyEvalTimeout)
+	defer cancel()
 	results, err := allowedActionsPreparedEvalQuery.Eval(ctx, rego.EvalInput(queryInput))
 	if err != nil {
 		return true, err
@@ -283,6 +400,66 @@ func (a *Authorizer) getUserAlias(ctx context.Context, userID string) (string, e
 	return userAlias, nil
 }
 
+// evalAllowedActionsQuery evaluates the allowed actions query provided for the
+// given user, returning the actions the user is allowed to perform. The
+// evaluation is cancelled if it takes longer than policyEvalTimeout.
+func evalAllowedActionsQuery(
+	ctx context.Context,
+	query rego.PreparedEvalQuery,
+	userAlias string,
+) ([]hub.Action, error) {
+	queryInput := map[string]interface{}{
+		"user": userAlias,
+	}
+	ctx, cancel := context.WithTimeout(ctx, policyEvalTimeout)
+	defer cancel()
+	results, err := query.Eval(ctx, rego.EvalInput(queryInput))
+	if err != nil {
+		return nil, err
+	} else if len(results) != 1 || len(results[0].Expressions) != 1 {
+		return nil, errors.New("allowed actions query returned no results")
+	}
+
+	// Prepare allowed actions and return them
+	values, ok := results[0].Expressions[0].Value.([]interface{})
+	if !ok {
+		return nil, errors.New("invalid allowed actions output")
+	}
+	allowedActions := make([]hub.Action, 0, len(values))
+	for _, v := range values {
+		action, ok := v.(string)
+		if !ok {
+			return nil, errors.New("invalid allowed action value")
+		}
+		allowedActions = append(allowedActions, hub.Action(action))
+	}
+	return allowedActions, nil
+}
+
+// restrictedCapabilities returns the capabilities of the policy engine in use
+// without the builtins that can reach the network (http.send and net.*).
+func restrictedCapabilities() *ast.Capabilities {
+	c := ast.CapabilitiesForThisVersion()
+	builtins := make([]*ast.Builtin, 0, len(c.Builtins))
+	for _, b := range c.Builtins {
+		if b.Name == "http.send" || strings.HasPrefix(b.Name, "net.") {
+			continue
+		}
+		builtins = append(builtins, b)
+	}
+	c.Builtins = builtins
+	return c
+}
+
+// policyDigest returns a digest of the custom policy provided, including its
+// data, that can be used to identify the version of the policy in use.
+func policyDigest(policy *hub.AuthorizationPolicy) string {
+	h := sha256.New()
+	h.Write([]byte(policy.CustomPolicy))
+	h.Write(policy.PolicyData)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // IsPredefinedPolicyValid checks if the provided predefined policy is valid.
 func IsPredefinedPolicyValid(predefinedPolicy string) bool {
 	for _, validPredefinedPolicy := range validPredefinedPolicies {
@@ -6,12 +6,14 @@ import (
 	"errors"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -118,6 +120,7 @@ func TestAuthorize(t *testing.T) {
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user2ID).Return(user2Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user3ID).Return(user3Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user5ID).Return("", tests.ErrFakeDB).Maybe()
+	db.On("Exec", context.Background(), registerAuthzDecisionDBQ, mock.Anything).Return(nil).Maybe()
 	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
 	az, err := NewAuthorizer(db)
 	require.NoError(t, err)
@@ -247,6 +250,55 @@ func TestAuthorize(t *testing.T) {
 	db.AssertExpectations(t)
 }
 
+func TestAuthorizeRegisterDecision(t *testing.T) {
+	t.Run("decision registered for organization using a custom policy", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
+		db.On("QueryRow", context.Background(), getUserAliasDBQ, user1ID).Return(user1Alias, nil)
+		db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
+		var policies map[string]*hub.AuthorizationPolicy
+		_ = json.Unmarshal(testsAuthorizationPoliciesJSON, &policies)
+		expectedDecisionJSON, _ := json.Marshal(&decision{
+			OrganizationName: org2Name,
+			UserID:           user1ID,
+			Action:           hub.UpdateOrganization,
+			Allowed:          false,
+			PolicyDigest:     policyDigest(policies[org2Name]),
+		})
+		db.On("Exec", context.Background(), registerAuthzDecisionDBQ, expectedDecisionJSON).Return(tests.ErrFakeDB)
+		az, err := NewAuthorizer(db)
+		require.NoError(t, err)
+
+		err = az.Authorize(context.Background(), &hub.AuthorizeInput{
+			OrganizationName: org2Name,
+			UserID:           user1ID,
+			Action:           hub.UpdateOrganization,
+		})
+		assert.True(t, errors.Is(err, hub.ErrInsufficientPrivilege))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("decision not registered for organization using a predefined policy", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
+		db.On("QueryRow", context.Background(), getUserAliasDBQ, user1ID).Return(user1Alias, nil)
+		db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
+		az, err := NewAuthorizer(db)
+		require.NoError(t, err)
+
+		err = az.Authorize(context.Background(), &hub.AuthorizeInput{
+			OrganizationName: org1Name,
+			UserID:           user1ID,
+			Action:           hub.UpdateOrganization,
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		db.AssertNotCalled(t, "Exec", context.Background(), registerAuthzDecisionDBQ, mock.Anything)
+	})
+}
+
 func TestGetAllowedActions(t *testing.T) {
 	db := &tests.DBMock{}
 	db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
@@ -333,6 +385,259 @@ func TestGetAllowedActions(t *testing.T) {
 	db.AssertExpectations(t)
 }
 
+func TestPredefinedPolicies(t *testing.T) {
+	db := &tests.DBMock{}
+	db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
+	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
+	az, err := NewAuthorizer(db)
+	require.NoError(t, err)
+
+	policyData := `{"roles": {"owner": {"users": ["user1"]}, "maintainer": {"users": ["user2"]}, "approver": {"users": ["user2"]}}}`
+	testCases := []struct {
+		predefinedPolicy       string
+		user                   string
+		expectedAllowedActions []hub.Action
+	}{
+		{
+			"read-only-members.v1",
+			user1Alias,
+			[]hub.Action{"all", hub.GetAuthorizationPolicy},
+		},
+		{
+			"read-only-members.v1",
+			user2Alias,
+			[]hub.Action{hub.GetAuthorizationPolicy},
+		},
+		{
+			"repo-maintainers.v1",
+			user1Alias,
+			[]hub.Action{"all", hub.GetAuthorizationPolicy},
+		},
+		{
+			"repo-maintainers.v1",
+			user2Alias,
+			[]hub.Action{
+				hub.AddOrganizationRepository,
+				hub.DeleteOrganizationRepository,
+				hub.GetAuthorizationPolicy,
+				hub.TransferOrganizationRepository,
+				hub.UpdateOrganizationRepository,
+			},
+		},
+		{
+			"repo-maintainers.v1",
+			user3Alias,
+			[]hub.Action{hub.GetAuthorizationPolicy},
+		},
+		{
+			"approval-required.v1",
+			user2Alias,
+			[]hub.Action{
+				hub.AddOrganizationMember,
+				hub.AddOrganizationRepository,
+				hub.DeleteOrganization,
+				hub.DeleteOrganizationMember,
+				hub.DeleteOrganizationRepository,
+				hub.GetAuthorizationPolicy,
+				hub.TransferOrganizationRepository,
+				hub.UpdateOrganization,
+				hub.UpdateOrganizationRepository,
+			},
+		},
+		{
+			"approval-required.v1",
+			user3Alias,
+			[]hub.Action{
+				hub.AddOrganizationMember,
+				hub.AddOrganizationRepository,
+				hub.GetAuthorizationPolicy,
+				hub.UpdateOrganization,
+				hub.UpdateOrganizationRepository,
+			},
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			policyDataJSON, _ := json.Marshal(policyData)
+			output, err := az.TestPolicy(context.Background(), &hub.AuthorizationPolicyTest{
+				Policy: &hub.AuthorizationPolicy{
+					PredefinedPolicy: tc.predefinedPolicy,
+					PolicyData:       policyDataJSON,
+				},
+				Inputs: []*hub.AuthorizationPolicyTestInput{
+					{User: tc.user, Action: hub.UpdateAuthorizationPolicy},
+				},
+			})
+			require.NoError(t, err)
+			require.Empty(t, output.Errors)
+			require.Len(t, output.Results, 1)
+			assert.Empty(t, output.Results[0].Error)
+			assert.ElementsMatch(t, tc.expectedAllowedActions, output.Results[0].AllowedActions)
+		})
+	}
+
+	db.AssertExpectations(t)
+}
+
+func TestTestPolicy(t *testing.T) {
+	db := &tests.DBMock{}
+	db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
+	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
+	az, err := NewAuthorizer(db)
+	require.NoError(t, err)
+
+	inputs := []*hub.AuthorizationPolicyTestInput{
+		{User: user1Alias, Action: hub.UpdateAuthorizationPolicy},
+		{User: user2Alias, Action: hub.AddOrganizationMember},
+		{User: user2Alias, Action: hub.DeleteOrganization},
+	}
+	testCases := []struct {
+		predefinedPolicy string
+		customPolicy     string
+		policyData       string
+		expectedOutput   *hub.AuthorizationPolicyTestOutput
+	}{
+		{
+			"rbac.v1",
+			"",
+			`{"roles": {"owner": {"users": ["user1"]}, "admin": {"users": ["user2"], "allowed_actions": ["addOrganizationMember"]}}}`,
+			&hub.AuthorizationPolicyTestOutput{
+				Errors: []string{},
+				Results: []*hub.AuthorizationPolicyTestResult{
+					{
+						User:           user1Alias,
+						Action:         hub.UpdateAuthorizationPolicy,
+						Allowed:        true,
+						AllowedActions: []hub.Action{"all"},
+					},
+					{
+						User:           user2Alias,
+						Action:         hub.AddOrganizationMember,
+						Allowed:        true,
+						AllowedActions: []hub.Action{hub.AddOrganizationMember},
+					},
+					{
+						User:           user2Alias,
+						Action:         hub.DeleteOrganization,
+						Allowed:        false,
+						AllowedActions: []hub.Action{hub.AddOrganizationMember},
+					},
+				},
+			},
+		},
+		{
+			"rbac.v1",
+			"",
+			`[]`,
+			&hub.AuthorizationPolicyTestOutput{
+				Errors: []string{
+					"invalid policy data: json: cannot unmarshal array into Go value of type map[string]interface {}",
+				},
+				Results: []*hub.AuthorizationPolicyTestResult{},
+			},
+		},
+		{
+			"",
+			`
+			package artifacthub.authz
+
+			allowed_actions = [action
+			`,
+			`{}`,
+			nil,
+		},
+		{
+			"",
+			`
+			package artifacthub.authz
+
+			allowed_actions = http.send({"method": "get", "url": "http://169.254.169.254/"}).body
+			`,
+			`{}`,
+			nil,
+		},
+		{
+			"",
+			`
+			package artifacthub.authz
+
+			allowed_actions = [ip | net.lookup_ip_addr("localhost")[ip]]
+			`,
+			`{}`,
+			nil,
+		},
+		{
+			"",
+			`
+			package artifacthub.authz
+
+			allowed_actions = "all"
+			`,
+			`{}`,
+			&hub.AuthorizationPolicyTestOutput{
+				Errors: []string{},
+				Results: []*hub.AuthorizationPolicyTestResult{
+					{
+						User:           user1Alias,
+						Action:         hub.UpdateAuthorizationPolicy,
+						AllowedActions: []hub.Action{},
+						Error:          "invalid allowed actions output",
+					},
+					{
+						User:           user2Alias,
+						Action:         hub.AddOrganizationMember,
+						AllowedActions: []hub.Action{},
+						Error:          "invalid allowed actions output",
+					},
+					{
+						User:           user2Alias,
+						Action:         hub.DeleteOrganization,
+						AllowedActions: []hub.Action{},
+						Error:          "invalid allowed actions output",
+					},
+				},
+			},
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			policyDataJSON, _ := json.Marshal(tc.policyData)
+			test := &hub.AuthorizationPolicyTest{
+				Policy: &hub.AuthorizationPolicy{
+					PredefinedPolicy: tc.predefinedPolicy,
+					CustomPolicy:     tc.customPolicy,
+					PolicyData:       policyDataJSON,
+				},
+				Inputs: inputs,
+			}
+			output, err := az.TestPolicy(context.Background(), test)
+			require.NoError(t, err)
+			if tc.expectedOutput != nil {
+				assert.Equal(t, tc.expectedOutput, output)
+			} else {
+				// Policy compilation errors are reported, but their exact
+				// messages depend on the policy engine version
+				assert.NotEmpty(t, output.Errors)
+				assert.Empty(t, output.Results)
+			}
+		})
+	}
+
+	db.AssertExpectations(t)
+}
+
+func TestPolicyCapabilities(t *testing.T) {
+	for _, b := range policyCapabilities.Builtins {
+		assert.NotEqual(t, "http.send", b.Name)
+		assert.False(t, strings.HasPrefix(b.Name, "net."), b.Name)
+	}
+	assert.NotEmpty(t, policyCapabilities.Builtins)
+}
+
 func TestWillUserBeLockedOut(t *testing.T) {
 	db := &tests.DBMock{}
 	db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
@@ -486,6 +791,18 @@ func TestIsPredefinedPolicyValid(t *testing.T) {
 			"rbac.v1",
 			true,
 		},
+		{
+			"read-only-members.v1",
+			true,
+		},
+		{
+			"repo-maintainers.v1",
+			true,
+		},
+		{
+			"approval-required.v1",
+			true,
+		},
 		{
 			"rbac.v2",
 			false,
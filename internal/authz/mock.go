@@ -25,6 +25,16 @@ func (m *AuthorizerMock) GetAllowedActions(ctx context.Context, userID, orgName
 	return data, args.Error(1)
 }
 
+// TestPolicy implements the Authorizer interface.
+func (m *AuthorizerMock) TestPolicy(
+	ctx context.Context,
+	test *hub.AuthorizationPolicyTest,
+) (*hub.AuthorizationPolicyTestOutput, error) {
+	args := m.Called(ctx, test)
+	data, _ := args.Get(0).(*hub.AuthorizationPolicyTestOutput)
+	return data, args.Error(1)
+}
+
 // WillUserBeLockedOut implements the Authorizer interface.
 func (m *AuthorizerMock) WillUserBeLockedOut(
 	ctx context.Context,
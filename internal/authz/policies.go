@@ -16,6 +16,104 @@ var predefinedPolicies = map[string]string{
 			user_roles[_] == role
 		}
 
+		# Get user roles
+		user_roles[role] {
+			data.roles[role].users[_] == input.user
+		}
+	`,
+	"read-only-members.v1": `
+		package artifacthub.authz
+
+		# Actions that don't modify the organization
+		read_actions := {"getAuthorizationPolicy"}
+
+		# Get user allowed actions
+		allowed_actions[action] {
+			# Owner can perform all actions
+			user_roles[_] == "owner"
+			action := "all"
+		}
+		allowed_actions[action] {
+			# Other members can only perform read actions
+			action := read_actions[_]
+		}
+
+		# Get user roles
+		user_roles[role] {
+			data.roles[role].users[_] == input.user
+		}
+	`,
+	"repo-maintainers.v1": `
+		package artifacthub.authz
+
+		# Actions that don't modify the organization
+		read_actions := {"getAuthorizationPolicy"}
+
+		# Actions that manage the organization repositories
+		repository_actions := {
+			"addOrganizationRepository",
+			"deleteOrganizationRepository",
+			"transferOrganizationRepository",
+			"updateOrganizationRepository"
+		}
+
+		# Get user allowed actions
+		allowed_actions[action] {
+			# Owner can perform all actions
+			user_roles[_] == "owner"
+			action := "all"
+		}
+		allowed_actions[action] {
+			# Maintainers can manage the organization repositories
+			user_roles[_] == "maintainer"
+			action := repository_actions[_]
+		}
+		allowed_actions[action] {
+			# Other members can only perform read actions
+			action := read_actions[_]
+		}
+
+		# Get user roles
+		user_roles[role] {
+			data.roles[role].users[_] == input.user
+		}
+	`,
+	"approval-required.v1": `
+		package artifacthub.authz
+
+		# Actions that any member can perform
+		member_actions := {
+			"addOrganizationMember",
+			"addOrganizationRepository",
+			"getAuthorizationPolicy",
+			"updateOrganization",
+			"updateOrganizationRepository"
+		}
+
+		# Actions with a high impact, that require an approver
+		sensitive_actions := {
+			"deleteOrganization",
+			"deleteOrganizationMember",
+			"deleteOrganizationRepository",
+			"transferOrganizationRepository"
+		}
+
+		# Get user allowed actions
+		allowed_actions[action] {
+			# Owner can perform all actions
+			user_roles[_] == "owner"
+			action := "all"
+		}
+		allowed_actions[action] {
+			# Approvers can perform sensitive actions
+			user_roles[_] == "approver"
+			action := sensitive_actions[_]
+		}
+		allowed_actions[action] {
+			# Other members can only perform non sensitive actions
+			action := member_actions[_]
+		}
+
 		# Get user roles
 		user_roles[role] {
 			data.roles[role].users[_] == input.user
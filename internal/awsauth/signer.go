@@ -0,0 +1,87 @@
+package awsauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/spf13/viper"
+)
+
+// Signer signs the requests sent to an AWS service (or to any other service
+// compatible with it) using the AWS Signature Version 4 process.
+type Signer struct {
+	creds   aws.CredentialsProvider
+	signer  *v4.Signer
+	region  string
+	service string
+	now     func() time.Time
+}
+
+// NewSigner creates a new Signer instance for the service and region provided.
+// When credentials are available in the configuration under the key provided
+// (i.e. images.s3), they will be used. Otherwise they will be obtained from
+// the AWS SDK default credentials chain, which supports environment variables,
+// shared configuration files, web identity tokens (i.e. IRSA) and ECS or EC2
+// instance roles.
+func NewSigner(cfg *viper.Viper, key, region, service string) (*Signer, error) {
+	var creds aws.CredentialsProvider
+	accessKeyID := cfg.GetString(key + ".accessKeyID")
+	secretAccessKey := cfg.GetString(key + ".secretAccessKey")
+	if accessKeyID != "" || secretAccessKey != "" {
+		creds = credentials.NewStaticCredentialsProvider(
+			accessKeyID,
+			secretAccessKey,
+			cfg.GetString(key+".sessionToken"),
+		)
+	} else {
+		awsCfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
+		if err != nil {
+			return nil, err
+		}
+		creds = awsCfg.Credentials
+	}
+	return NewSignerWithCredentials(creds, region, service), nil
+}
+
+// NewSignerWithCredentials creates a new Signer instance for the service and
+// region provided that uses the credentials provider given.
+func NewSignerWithCredentials(creds aws.CredentialsProvider, region, service string) *Signer {
+	return &Signer{
+		creds:   creds,
+		signer:  v4.NewSigner(),
+		region:  region,
+		service: service,
+		now:     time.Now,
+	}
+}
+
+// Sign signs the request provided, which will send the payload given.
+func (s *Signer) Sign(ctx context.Context, req *http.Request, payload []byte) error {
+	creds, err := s.creds.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	return s.signer.SignHTTP(
+		ctx,
+		creds,
+		req,
+		PayloadHash(payload),
+		s.service,
+		s.region,
+		s.now().UTC(),
+	)
+}
+
+// PayloadHash returns the hex encoded SHA256 hash of the payload provided, as
+// expected in the X-Amz-Content-Sha256 header by some services like S3.
+func PayloadHash(payload []byte) string {
+	h := sha256.Sum256(payload)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,99 @@
+package awsauth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSigner(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("credentials provided in the configuration", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.s3.accessKeyID", "AKID")
+		cfg.Set("images.s3.secretAccessKey", "secret")
+		cfg.Set("images.s3.sessionToken", "token")
+
+		s, err := NewSigner(cfg, "images.s3", "us-east-1", "s3")
+		require.NoError(t, err)
+		creds, err := s.creds.Retrieve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "AKID", creds.AccessKeyID)
+		assert.Equal(t, "secret", creds.SecretAccessKey)
+		assert.Equal(t, "token", creds.SessionToken)
+	})
+
+	t.Run("credentials obtained from the default chain", func(t *testing.T) {
+		t.Setenv("AWS_ACCESS_KEY_ID", "ENVAKID")
+		t.Setenv("AWS_SECRET_ACCESS_KEY", "envsecret")
+		t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+		t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent")
+
+		s, err := NewSigner(viper.New(), "images.s3", "us-east-1", "s3")
+		require.NoError(t, err)
+		creds, err := s.creds.Retrieve(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "ENVAKID", creds.AccessKeyID)
+		assert.Equal(t, "envsecret", creds.SecretAccessKey)
+	})
+}
+
+func TestSignerSign(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("request signed", func(t *testing.T) {
+		t.Parallel()
+		s := newTestSigner(credentials.NewStaticCredentialsProvider("AKID", "secret", "token"))
+		req, _ := http.NewRequest("PUT", "https://bucket1.s3.us-east-1.amazonaws.com/key", nil)
+
+		err := s.Sign(ctx, req, []byte("payload"))
+		require.NoError(t, err)
+		assert.Equal(t, "20200913T122640Z", req.Header.Get("X-Amz-Date"))
+		assert.Equal(t, "token", req.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(
+			req.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/20200913/us-east-1/s3/aws4_request, SignedHeaders=",
+		))
+	})
+
+	t.Run("signature depends on the payload", func(t *testing.T) {
+		t.Parallel()
+		s := newTestSigner(credentials.NewStaticCredentialsProvider("AKID", "secret", ""))
+		req1, _ := http.NewRequest("POST", "https://email.us-east-1.amazonaws.com", nil)
+		require.NoError(t, s.Sign(ctx, req1, []byte("payload1")))
+		req2, _ := http.NewRequest("POST", "https://email.us-east-1.amazonaws.com", nil)
+		require.NoError(t, s.Sign(ctx, req2, []byte("payload2")))
+		assert.NotEqual(t, req1.Header.Get("Authorization"), req2.Header.Get("Authorization"))
+	})
+
+	t.Run("error retrieving credentials", func(t *testing.T) {
+		t.Parallel()
+		s := newTestSigner(aws.AnonymousCredentials{})
+		req, _ := http.NewRequest("GET", "https://bucket1.s3.us-east-1.amazonaws.com/key", nil)
+
+		err := s.Sign(ctx, req, nil)
+		assert.Error(t, err)
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+}
+
+func TestPayloadHash(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", PayloadHash(nil))
+}
+
+func newTestSigner(creds aws.CredentialsProvider) *Signer {
+	s := NewSignerWithCredentials(creds, "us-east-1", "s3")
+	s.now = func() time.Time { return time.Unix(1600000000, 0) }
+	return s
+}
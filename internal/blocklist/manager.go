@@ -0,0 +1,115 @@
+package blocklist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/cache"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	blockContentDBQ      = `select block_content($1::jsonb, $2::jsonb)`
+	getBlockedContentDBQ = `select get_blocked_content()`
+	unblockContentDBQ    = `select unblock_content($1::uuid, $2::jsonb)`
+)
+
+var (
+	// errContentNotFoundDB represents the error returned by the database
+	// functions when the content requested cannot be found.
+	errContentNotFoundDB = errors.New("ERROR: content not found (SQLSTATE P0001)")
+)
+
+// Manager provides an API to manage the repositories and packages blocked by
+// the site admins.
+type Manager struct {
+	db    hub.DB
+	cache hub.Cache
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB, opts ...func(m *Manager)) *Manager {
+	m := &Manager{
+		db: db,
+	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// WithCache allows providing a Cache implementation for a Manager instance,
+// so that the entries affected by the content blocked can be invalidated.
+func WithCache(c hub.Cache) func(m *Manager) {
+	return func(m *Manager) {
+		m.cache = c
+	}
+}
+
+// Block blocks the repository or package provided. When no package name is
+// provided, the whole repository is blocked. The operation is recorded in the
+// admin audit log.
+func (m *Manager) Block(ctx context.Context, bc *hub.BlockedContent, info *hub.AdminAuditInfo) (string, error) {
+	// Validate input
+	if bc.RepositoryName == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+	if bc.Reason == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "reason not provided")
+	}
+
+	// Block content in database
+	var blockedContentID string
+	bcJSON, _ := json.Marshal(bc)
+	infoJSON, _ := json.Marshal(info)
+	if err := m.db.QueryRow(ctx, blockContentDBQ, bcJSON, infoJSON).Scan(&blockedContentID); err != nil {
+		if err.Error() == errContentNotFoundDB.Error() {
+			return "", hub.ErrNotFound
+		}
+		return "", err
+	}
+
+	// Invalidate cache entries that may include the content blocked
+	tags := []string{cache.SearchTag, cache.StatsTag}
+	if bc.PackageName != "" {
+		tags = append(tags, cache.PackageTag(bc.RepositoryName, bc.PackageName))
+	} else {
+		tags = append(tags, cache.RepositoryTag(bc.RepositoryName))
+	}
+	cache.Invalidate(ctx, m.cache, tags...)
+
+	return blockedContentID, nil
+}
+
+// GetJSON returns all the repositories and packages blocked as a json array.
+func (m *Manager) GetJSON(ctx context.Context) ([]byte, error) {
+	return util.DBQueryJSON(ctx, m.db, getBlockedContentDBQ)
+}
+
+// Unblock unblocks the repository or package identified by the blocked
+// content id provided. The operation is recorded in the admin audit log.
+func (m *Manager) Unblock(ctx context.Context, blockedContentID string, info *hub.AdminAuditInfo) error {
+	// Validate input
+	if _, err := uuid.FromString(blockedContentID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid blocked content id")
+	}
+
+	// Unblock content in database
+	infoJSON, _ := json.Marshal(info)
+	if _, err := m.db.Exec(ctx, unblockContentDBQ, blockedContentID, infoJSON); err != nil {
+		if err.Error() == errContentNotFoundDB.Error() {
+			return hub.ErrNotFound
+		}
+		return err
+	}
+
+	// Search results may include now the content unblocked
+	cache.Invalidate(ctx, m.cache, cache.SearchTag, cache.StatsTag)
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/cache"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const blockedContentID = "00000000-0000-0000-0000-000000000001"
+
+var info = &hub.AdminAuditInfo{
+	Reason:    "reason",
+	IP:        "1.1.1.1",
+	UserAgent: "ua",
+}
+
+func TestBlock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			bc     *hub.BlockedContent
+		}{
+			{
+				"repository name not provided",
+				&hub.BlockedContent{},
+			},
+			{
+				"reason not provided",
+				&hub.BlockedContent{RepositoryName: "repo1"},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.Block(ctx, tc.bc, info)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("content not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, blockContentDBQ, mock.Anything, mock.Anything).Return(nil, errContentNotFoundDB)
+		m := NewManager(db)
+
+		_, err := m.Block(ctx, &hub.BlockedContent{RepositoryName: "repo1", Reason: "spam"}, info)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, blockContentDBQ, mock.Anything, mock.Anything).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		_, err := m.Block(ctx, &hub.BlockedContent{RepositoryName: "repo1", Reason: "spam"}, info)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("repository blocked, cache entries invalidated", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, blockContentDBQ, mock.Anything, mock.Anything).Return(blockedContentID, nil)
+		c := &cache.Mock{}
+		c.On("Invalidate", ctx, []string{
+			cache.SearchTag,
+			cache.StatsTag,
+			cache.RepositoryTag("repo1"),
+		}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		id, err := m.Block(ctx, &hub.BlockedContent{RepositoryName: "repo1", Reason: "spam"}, info)
+		assert.NoError(t, err)
+		assert.Equal(t, blockedContentID, id)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("package blocked, cache entries invalidated", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, blockContentDBQ, mock.Anything, mock.Anything).Return(blockedContentID, nil)
+		c := &cache.Mock{}
+		c.On("Invalidate", ctx, []string{
+			cache.SearchTag,
+			cache.StatsTag,
+			cache.PackageTag("repo1", "pkg1"),
+		}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		id, err := m.Block(ctx, &hub.BlockedContent{
+			RepositoryName: "repo1",
+			PackageName:    "pkg1",
+			Reason:         "spam",
+		}, info)
+		assert.NoError(t, err)
+		assert.Equal(t, blockedContentID, id)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getBlockedContentDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("blocked content returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getBlockedContentDBQ).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUnblock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.Unblock(ctx, "invalid", info)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("content not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unblockContentDBQ, blockedContentID, mock.Anything).Return(errContentNotFoundDB)
+		m := NewManager(db)
+
+		err := m.Unblock(ctx, blockedContentID, info)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unblockContentDBQ, blockedContentID, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.Unblock(ctx, blockedContentID, info)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("content unblocked, cache entries invalidated", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unblockContentDBQ, blockedContentID, mock.Anything).Return(nil)
+		c := &cache.Mock{}
+		c.On("Invalidate", ctx, []string{cache.SearchTag, cache.StatsTag}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		err := m.Unblock(ctx, blockedContentID, info)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
+}
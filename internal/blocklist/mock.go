@@ -0,0 +1,36 @@
+package blocklist
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the BlocklistManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Block implements the BlocklistManager interface.
+func (m *ManagerMock) Block(
+	ctx context.Context,
+	bc *hub.BlockedContent,
+	info *hub.AdminAuditInfo,
+) (string, error) {
+	args := m.Called(ctx, bc, info)
+	return args.String(0), args.Error(1)
+}
+
+// GetJSON implements the BlocklistManager interface.
+func (m *ManagerMock) GetJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Unblock implements the BlocklistManager interface.
+func (m *ManagerMock) Unblock(ctx context.Context, blockedContentID string, info *hub.AdminAuditInfo) error {
+	args := m.Called(ctx, blockedContentID, info)
+	return args.Error(0)
+}
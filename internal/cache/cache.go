@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog/log"
+)
+
+// Tags used to group cache entries so that they can be invalidated together.
+const (
+	// SearchTag represents the tag associated with all packages search
+	// results entries.
+	SearchTag = "search"
+
+	// StatsTag represents the tag associated with all stats entries.
+	StatsTag = "stats"
+
+	// SitemapTag represents the tag associated with all sitemap entries.
+	SitemapTag = "sitemap"
+)
+
+// Key returns the key used to store in the cache the data of the given kind
+// produced by the input provided.
+func Key(kind string, input []byte) string {
+	sum := sha256.Sum256(input)
+	return kind + ":" + hex.EncodeToString(sum[:])
+}
+
+// PackageTag returns the tag associated with the cache entries of the package
+// provided.
+func PackageTag(repoName, pkgName string) string {
+	return "pkg:" + repoName + "/" + pkgName
+}
+
+// RepositoryTag returns the tag associated with the cache entries of the
+// packages that belong to the repository provided.
+func RepositoryTag(repoName string) string {
+	return "repo:" + repoName
+}
+
+// RepositoryIDTag returns the tag associated with the cache entries of the
+// packages that belong to the repository identified by the id provided.
+func RepositoryIDTag(repositoryID string) string {
+	return "repo-id:" + repositoryID
+}
+
+// PackageIDTag returns the tag associated with the cache entries of the
+// package identified by the id provided.
+func PackageIDTag(pkgID string) string {
+	return "pkg-id:" + pkgID
+}
+
+// Load returns the value stored in the cache provided under the key given, if
+// available. The cache is optional, so it can be nil. Errors are logged, as
+// they should never prevent the data from being read from its source.
+func Load(ctx context.Context, c hub.Cache, key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	value, err := c.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, hub.ErrNotFound) {
+			log.Warn().Err(err).Str("key", key).Msg("error getting entry from cache")
+		}
+		return nil, false
+	}
+	return value, true
+}
+
+// Store stores the value provided in the cache given, associating it with the
+// tags provided. The cache is optional, so it can be nil. Errors are logged.
+func Store(ctx context.Context, c hub.Cache, key string, value []byte, tags ...string) {
+	if c == nil {
+		return
+	}
+	if err := c.Set(ctx, key, value, tags...); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("error storing entry in cache")
+	}
+}
+
+// Invalidate removes from the cache provided the entries associated with any
+// of the tags given. The cache is optional, so it can be nil. Errors are
+// logged, and entries will expire anyway once their ttl is reached.
+func Invalidate(ctx context.Context, c hub.Cache, tags ...string) {
+	if c == nil {
+		return
+	}
+	if err := c.Invalidate(ctx, tags...); err != nil {
+		log.Warn().Err(err).Strs("tags", tags).Msg("error invalidating cache entries")
+	}
+}
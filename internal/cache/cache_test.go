@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, Key("kind", []byte("input")), Key("kind", []byte("input")))
+	assert.NotEqual(t, Key("kind", []byte("input1")), Key("kind", []byte("input2")))
+	assert.NotEqual(t, Key("kind1", []byte("input")), Key("kind2", []byte("input")))
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cache not provided", func(t *testing.T) {
+		t.Parallel()
+		value, ok := Load(ctx, nil, "key")
+		assert.False(t, ok)
+		assert.Nil(t, value)
+	})
+
+	t.Run("entry not found", func(t *testing.T) {
+		t.Parallel()
+		c := &Mock{}
+		c.On("Get", ctx, "key").Return(nil, hub.ErrNotFound)
+		value, ok := Load(ctx, c, "key")
+		assert.False(t, ok)
+		assert.Nil(t, value)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("error getting entry", func(t *testing.T) {
+		t.Parallel()
+		c := &Mock{}
+		c.On("Get", ctx, "key").Return(nil, tests.ErrFake)
+		value, ok := Load(ctx, c, "key")
+		assert.False(t, ok)
+		assert.Nil(t, value)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("entry found", func(t *testing.T) {
+		t.Parallel()
+		c := &Mock{}
+		c.On("Get", ctx, "key").Return([]byte("value"), nil)
+		value, ok := Load(ctx, c, "key")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("value"), value)
+		c.AssertExpectations(t)
+	})
+}
+
+func TestStoreAndInvalidate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cache not provided", func(t *testing.T) {
+		t.Parallel()
+		assert.NotPanics(t, func() {
+			Store(ctx, nil, "key", []byte("value"), "tag")
+			Invalidate(ctx, nil, "tag")
+		})
+	})
+
+	t.Run("errors are not propagated", func(t *testing.T) {
+		t.Parallel()
+		c := &Mock{}
+		c.On("Set", ctx, "key", []byte("value"), []string{"tag"}).Return(tests.ErrFake)
+		c.On("Invalidate", ctx, []string{"tag"}).Return(tests.ErrFake)
+		Store(ctx, c, "key", []byte("value"), "tag")
+		Invalidate(ctx, c, "tag")
+		c.AssertExpectations(t)
+	})
+}
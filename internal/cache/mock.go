@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock is a mock implementation of the hub Cache interface.
+type Mock struct {
+	mock.Mock
+}
+
+// Get implements the Cache interface.
+func (m *Mock) Get(ctx context.Context, key string) ([]byte, error) {
+	args := m.Called(ctx, key)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Invalidate implements the Cache interface.
+func (m *Mock) Invalidate(ctx context.Context, tags ...string) error {
+	args := m.Called(ctx, tags)
+	return args.Error(0)
+}
+
+// Set implements the Cache interface.
+func (m *Mock) Set(ctx context.Context, key string, value []byte, tags ...string) error {
+	args := m.Called(ctx, key, value, tags)
+	return args.Error(0)
+}
@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
+)
+
+const (
+	// defaultTTL represents the period during which entries are kept in the
+	// cache when no ttl has been configured.
+	defaultTTL = 5 * time.Minute
+
+	// keysPrefix represents the prefix of all the keys stored in Redis.
+	keysPrefix = "hub:"
+
+	// tagsPrefix represents the prefix of the keys of the sets used to keep
+	// track of the entries associated with each tag.
+	tagsPrefix = keysPrefix + "tag:"
+
+	// timeout represents the maximum duration of the operations (dial, read
+	// and write) performed against Redis.
+	timeout = 2 * time.Second
+)
+
+// RedisCache is a hub.Cache implementation backed by Redis. Entries expire
+// after the configured ttl, so stale data will only be served for a limited
+// period of time even if an invalidation is missed.
+type RedisCache struct {
+	rdb *redis.Client
+	ttl time.Duration
+}
+
+// NewRedisCache creates a new RedisCache instance.
+func NewRedisCache(cfg *viper.Viper) (*RedisCache, error) {
+	addr := cfg.GetString("cache.redis.addr")
+	if addr == "" {
+		return nil, errors.New("redis address not provided")
+	}
+	ttl := cfg.GetDuration("cache.ttl")
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	opts := &redis.Options{
+		Addr:         addr,
+		Username:     cfg.GetString("cache.redis.username"),
+		Password:     cfg.GetString("cache.redis.password"),
+		DB:           cfg.GetInt("cache.redis.db"),
+		PoolSize:     cfg.GetInt("cache.redis.poolSize"),
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+	}
+	if cfg.GetBool("cache.redis.tls.enabled") {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		opts.TLSConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			ServerName:         host,
+			InsecureSkipVerify: cfg.GetBool("cache.redis.tls.insecureSkipVerify"), // #nosec
+		}
+	}
+	return &RedisCache{
+		rdb: redis.NewClient(opts),
+		ttl: ttl,
+	}, nil
+}
+
+// Get implements the hub.Cache interface.
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.rdb.Get(ctx, keysPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, hub.ErrNotFound
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+// Invalidate implements the hub.Cache interface.
+func (c *RedisCache) Invalidate(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	// Get the keys of the entries associated with the tags provided
+	cmds := make([]*redis.StringSliceCmd, 0, len(tags))
+	_, err := c.rdb.Pipelined(ctx, func(p redis.Pipeliner) error {
+		for _, tag := range tags {
+			cmds = append(cmds, p.SMembers(ctx, tagsPrefix+tag))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Delete those entries along with the tags sets
+	keys := make([]string, 0, len(tags))
+	for i, tag := range tags {
+		keys = append(keys, cmds[i].Val()...)
+		keys = append(keys, tagsPrefix+tag)
+	}
+	return c.rdb.Del(ctx, keys...).Err()
+}
+
+// Set implements the hub.Cache interface.
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, tags ...string) error {
+	_, err := c.rdb.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, keysPrefix+key, value, c.ttl)
+		for _, tag := range tags {
+			p.SAdd(ctx, tagsPrefix+tag, keysPrefix+key)
+			p.Expire(ctx, tagsPrefix+tag, c.ttl)
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,270 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedisCache(t *testing.T) {
+	t.Run("redis address not provided", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewRedisCache(viper.New())
+		assert.Error(t, err)
+	})
+
+	t.Run("default ttl used when none is provided", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("cache.redis.addr", "localhost:6379")
+		c, err := NewRedisCache(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, defaultTTL, c.ttl)
+	})
+
+	t.Run("tls enabled", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("cache.redis.addr", "redis.example.com:6380")
+		cfg.Set("cache.redis.username", "user1")
+		cfg.Set("cache.redis.tls.enabled", true)
+		c, err := NewRedisCache(cfg)
+		require.NoError(t, err)
+		opts := c.rdb.Options()
+		assert.Equal(t, "user1", opts.Username)
+		require.NotNil(t, opts.TLSConfig)
+		assert.Equal(t, "redis.example.com", opts.TLSConfig.ServerName)
+		assert.Equal(t, uint16(tls.VersionTLS12), opts.TLSConfig.MinVersion)
+	})
+}
+
+func TestRedisCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("get, set and invalidate entries", func(t *testing.T) {
+		t.Parallel()
+		s := newFakeRedis(t, "pass")
+		c := newTestRedisCache(t, s.addr, "pass")
+
+		// Entry not available yet
+		_, err := c.Get(ctx, "key1")
+		assert.True(t, errors.Is(err, hub.ErrNotFound))
+
+		// Set some entries and get them
+		require.NoError(t, c.Set(ctx, "key1", []byte("value1"), "tag1"))
+		require.NoError(t, c.Set(ctx, "key2", []byte("value2"), "tag1", "tag2"))
+		require.NoError(t, c.Set(ctx, "key3", []byte("value\r\n3"), "tag3"))
+		value, err := c.Get(ctx, "key1")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value1"), value)
+		value, err = c.Get(ctx, "key3")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value\r\n3"), value)
+		assert.Equal(t, "300", s.ttl(keysPrefix+"key1"))
+
+		// Invalidate some entries
+		require.NoError(t, c.Invalidate(ctx, "tag2"))
+		_, err = c.Get(ctx, "key2")
+		assert.True(t, errors.Is(err, hub.ErrNotFound))
+		value, err = c.Get(ctx, "key1")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value1"), value)
+		require.NoError(t, c.Invalidate(ctx, "tag1", "tag3"))
+		_, err = c.Get(ctx, "key1")
+		assert.True(t, errors.Is(err, hub.ErrNotFound))
+		_, err = c.Get(ctx, "key3")
+		assert.True(t, errors.Is(err, hub.ErrNotFound))
+	})
+
+	t.Run("invalid password", func(t *testing.T) {
+		t.Parallel()
+		s := newFakeRedis(t, "pass")
+		c := newTestRedisCache(t, s.addr, "invalid")
+		_, err := c.Get(ctx, "key1")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "WRONGPASS")
+	})
+
+	t.Run("redis not available", func(t *testing.T) {
+		t.Parallel()
+		s := newFakeRedis(t, "")
+		s.l.Close()
+		c := newTestRedisCache(t, s.addr, "")
+		_, err := c.Get(ctx, "key1")
+		assert.Error(t, err)
+	})
+}
+
+func newTestRedisCache(t *testing.T, addr, password string) *RedisCache {
+	t.Helper()
+	cfg := viper.New()
+	cfg.Set("cache.redis.addr", addr)
+	cfg.Set("cache.redis.password", password)
+	cfg.Set("cache.redis.db", 1)
+	c, err := NewRedisCache(cfg)
+	require.NoError(t, err)
+	return c
+}
+
+// fakeRedis is a minimal in-process Redis server that supports the commands
+// used by the RedisCache.
+type fakeRedis struct {
+	l        net.Listener
+	addr     string
+	password string
+
+	mu   sync.Mutex
+	data map[string]string
+	ttls map[string]string
+	sets map[string]map[string]struct{}
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeRedis{
+		l:        l,
+		addr:     l.Addr().String(),
+		password: password,
+		data:     make(map[string]string),
+		ttls:     make(map[string]string),
+		sets:     make(map[string]map[string]struct{}),
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authenticated := s.password == ""
+	for {
+		cmd, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		cmd[0] = strings.ToUpper(cmd[0])
+		var reply string
+		if cmd[0] != "AUTH" && !authenticated {
+			reply = "-NOAUTH Authentication required\r\n"
+		} else {
+			reply = s.exec(cmd, &authenticated)
+		}
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeRedis) exec(cmd []string, authenticated *bool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch cmd[0] {
+	case "AUTH":
+		if cmd[1] != s.password {
+			return "-WRONGPASS invalid password\r\n"
+		}
+		*authenticated = true
+		return "+OK\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		value, ok := s.data[cmd[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		s.data[cmd[1]] = cmd[2]
+		if len(cmd) == 5 && strings.ToUpper(cmd[3]) == "EX" {
+			s.ttls[cmd[1]] = cmd[4]
+		}
+		return "+OK\r\n"
+	case "SADD":
+		if _, ok := s.sets[cmd[1]]; !ok {
+			s.sets[cmd[1]] = make(map[string]struct{})
+		}
+		for _, member := range cmd[2:] {
+			s.sets[cmd[1]][member] = struct{}{}
+		}
+		return fmt.Sprintf(":%d\r\n", len(cmd)-2)
+	case "SMEMBERS":
+		reply := fmt.Sprintf("*%d\r\n", len(s.sets[cmd[1]]))
+		for member := range s.sets[cmd[1]] {
+			reply += fmt.Sprintf("$%d\r\n%s\r\n", len(member), member)
+		}
+		return reply
+	case "EXPIRE":
+		s.ttls[cmd[1]] = cmd[2]
+		return ":1\r\n"
+	case "DEL":
+		for _, key := range cmd[1:] {
+			delete(s.data, key)
+			delete(s.sets, key)
+		}
+		return fmt.Sprintf(":%d\r\n", len(cmd)-1)
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+func (s *fakeRedis) ttl(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ttls[key]
+}
+
+// readCommand reads a command sent by a client using the RESP protocol (an
+// array of bulk strings).
+func readCommand(r *bufio.Reader) ([]string, error) {
+	readLine := func(prefix byte) (int, error) {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		if len(line) < 3 || line[0] != prefix {
+			return 0, fmt.Errorf("invalid line: %q", line)
+		}
+		return strconv.Atoi(strings.TrimSuffix(line[1:], "\r\n"))
+	}
+	n, err := readLine('*')
+	if err != nil {
+		return nil, err
+	}
+	cmd := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		size, err := readLine('$')
+		if err != nil {
+			return nil, err
+		}
+		arg := make([]byte, size+2)
+		if _, err := io.ReadFull(r, arg); err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, string(arg[:size]))
+	}
+	return cmd, nil
+}
@@ -0,0 +1,143 @@
+package contentcheck
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// maxFileSize represents the maximum size of the files that will be checked.
+// Larger files are skipped, as they are unlikely to be scripts or manifests.
+const maxFileSize = 1024 * 1024
+
+// File represents a file in a package's content to be checked.
+type File struct {
+	// Name represents the path of the file in the package.
+	Name string
+
+	// Data represents the content of the file.
+	Data []byte
+
+	// Hook indicates whether the file defines some code to be run when the
+	// package is installed, upgraded or deleted (i.e. Helm chart hooks).
+	Hook bool
+}
+
+// rule represents a check applied to the lines of a package's files.
+type rule struct {
+	id          string
+	kind        string
+	description string
+	re          *regexp.Regexp
+	hooksOnly   bool
+}
+
+// rules represents the list of rules applied to the package's files.
+var rules = []*rule{
+	// Embedded secrets
+	{
+		id:          "private-key",
+		kind:        hub.ContentWarningSecret,
+		description: "Private key embedded in package content",
+		re:          regexp.MustCompile(`-----BEGIN (RSA |EC |DSA |OPENSSH |ENCRYPTED |PGP )?PRIVATE KEY( BLOCK)?-----`),
+	},
+	{
+		id:          "aws-access-key-id",
+		kind:        hub.ContentWarningSecret,
+		description: "AWS access key id embedded in package content",
+		re:          regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+	{
+		id:          "github-token",
+		kind:        hub.ContentWarningSecret,
+		description: "GitHub token embedded in package content",
+		re:          regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`),
+	},
+	{
+		id:          "gitlab-token",
+		kind:        hub.ContentWarningSecret,
+		description: "GitLab personal access token embedded in package content",
+		re:          regexp.MustCompile(`\bglpat-[A-Za-z0-9_\-]{20}\b`),
+	},
+	{
+		id:          "google-api-key",
+		kind:        hub.ContentWarningSecret,
+		description: "Google API key embedded in package content",
+		re:          regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`),
+	},
+	{
+		id:          "slack-token",
+		kind:        hub.ContentWarningSecret,
+		description: "Slack token embedded in package content",
+		re:          regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z\-]{10,}`),
+	},
+
+	// Suspicious scripts in hooks
+	{
+		id:          "remote-script-execution",
+		kind:        hub.ContentWarningSuspiciousScript,
+		description: "Hook downloads a remote script and pipes it to a shell",
+		re:          regexp.MustCompile(`\b(curl|wget)\b[^|]*\|\s*(sudo\s+)?(ba|z|da)?sh\b`),
+		hooksOnly:   true,
+	},
+	{
+		id:          "encoded-script-execution",
+		kind:        hub.ContentWarningSuspiciousScript,
+		description: "Hook decodes a base64 encoded payload and pipes it to a shell",
+		re:          regexp.MustCompile(`\bbase64\s+(-d|--decode)\b[^|]*\|\s*(ba|z|da)?sh\b`),
+		hooksOnly:   true,
+	},
+
+	// Known malicious patterns
+	{
+		id:          "reverse-shell",
+		kind:        hub.ContentWarningMaliciousPattern,
+		description: "Reverse shell pattern found",
+		re:          regexp.MustCompile(`(/dev/tcp/[^\s/]+/\d+|\bnc(at)?\b.*\s-e\s+/bin/(ba)?sh\b)`),
+	},
+	{
+		id:          "crypto-miner",
+		kind:        hub.ContentWarningMaliciousPattern,
+		description: "Crypto currency miner reference found",
+		re:          regexp.MustCompile(`(?i)(stratum\+(tcp|ssl)://|\bxmrig\b|\bminerd\b|\bcryptonight\b)`),
+	},
+}
+
+// Check runs the static checks on the files provided, returning the warnings
+// found. Each rule is reported at most once per file, pointing to the first
+// line where it matched. Matched content is never included in the warnings,
+// as it may contain sensitive information.
+func Check(files []*File) []*hub.ContentWarning {
+	var warnings []*hub.ContentWarning
+	for _, f := range files {
+		if len(f.Data) > maxFileSize {
+			continue
+		}
+		matched := make(map[string]bool)
+		s := bufio.NewScanner(bytes.NewReader(f.Data))
+		s.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+		lineNumber := 0
+		for s.Scan() {
+			lineNumber++
+			line := s.Bytes()
+			for _, r := range rules {
+				if matched[r.id] || (r.hooksOnly && !f.Hook) {
+					continue
+				}
+				if r.re.Match(line) {
+					matched[r.id] = true
+					warnings = append(warnings, &hub.ContentWarning{
+						Kind:        r.kind,
+						Rule:        r.id,
+						File:        f.Name,
+						Line:        lineNumber,
+						Description: r.description,
+					})
+				}
+			}
+		}
+	}
+	return warnings
+}
@@ -1,26 +1,65 @@
 package email
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"net/smtp"
+	"io/ioutil"
+	"net/http"
+	"net/mail"
+	"sync"
 
 	_ "embed" // Used by templates
 
-	"github.com/domodwyer/mailyak"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
-	"github.com/versine/loginauth"
+)
+
+const (
+	// Database queries
+	addEmailSuppressionDBQ = `select add_email_suppression($1::text, $2::text, $3::text)`
+	isEmailSuppressedDBQ   = `select is_email_suppressed($1::text)`
 )
 
 // BaseTmpl represents the base template used by emails.
 //go:embed template/base.tmpl
 var BaseTmpl string
 
-// ErrSenderNotAvailable error indicates that there is not a mail sender
-// available. This usually happens when the email configuration hasn't been
-// set up.
-var ErrSenderNotAvailable = errors.New("email sender not available")
+var (
+	// ErrSenderNotAvailable error indicates that there is not a mail sender
+	// available. This usually happens when the email configuration hasn't been
+	// set up.
+	ErrSenderNotAvailable = errors.New("email sender not available")
+
+	// ErrRecipientSuppressed error indicates that the email was not sent
+	// because the recipient is in the suppression list.
+	ErrRecipientSuppressed = errors.New("email recipient in suppression list")
+
+	// ErrRecipientRejected error indicates that the provider permanently
+	// rejected the recipient of the email (i.e. the mailbox does not exist).
+	ErrRecipientRejected = errors.New("email recipient rejected")
+
+	// ErrInvalidWebhookRequest error indicates that the provider webhook
+	// request received is not valid.
+	ErrInvalidWebhookRequest = errors.New("invalid webhook request")
+
+	// ErrWebhookNotSupported error indicates that the provider in use does not
+	// support webhooks or that the webhook request received does not belong
+	// to it.
+	ErrWebhookNotSupported = errors.New("webhook not supported")
+
+	// ErrWebhookUnauthorized error indicates that the authenticity of the
+	// provider webhook request received could not be verified.
+	ErrWebhookUnauthorized = errors.New("webhook request unauthorized")
+)
+
+// Suppression reasons.
+const (
+	Bounce    = "bounce"
+	Complaint = "complaint"
+)
 
 // Data describes the different pieces of data used to compose an email.
 type Data struct {
@@ -29,64 +68,180 @@ type Data struct {
 	Body    []byte
 }
 
-// Sender is in charge of sending emails.
+// Message represents an email ready to be delivered by a provider.
+type Message struct {
+	From    mail.Address
+	ReplyTo string
+	To      string
+	Subject string
+	HTML    []byte
+}
+
+// Suppression represents an email address that should not receive more
+// emails, as reported by the provider.
+type Suppression struct {
+	Email  string
+	Reason string
+}
+
+// Provider describes the methods an email delivery provider must implement.
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, m *Message) error
+}
+
+// WebhookProvider describes the methods a provider able to notify about
+// bounces and complaints via webhooks must implement.
+type WebhookProvider interface {
+	Provider
+	ProcessWebhook(r *http.Request) ([]*Suppression, error)
+}
+
+// DB defines the methods the database handler must provide.
+type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// HTTPClient defines the methods an HTTPClient implementation must provide.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Sender is in charge of sending emails using the provider configured,
+// skipping recipients in the suppression list.
 type Sender struct {
-	smtpAddr string
-	smtpAuth smtp.Auth
-	fromName string
-	from     string
+	db DB
+	hc HTTPClient
+
+	mu       sync.RWMutex
+	provider Provider
+	from     mail.Address
 	replyTo  string
 }
 
 // NewSender creates a new Sender instance and returns it.
-func NewSender(cfg *viper.Viper) *Sender {
-	requiredConfigFields := []string{
-		"from",
-		"smtp.host",
-		"smtp.port",
+func NewSender(cfg *viper.Viper, db DB, hc HTTPClient) *Sender {
+	if !cfg.IsSet("email.from") {
+		log.Warn().Msg("email not setup properly, some required configuration fields are missing")
+		return nil
 	}
-	for _, f := range requiredConfigFields {
-		if !cfg.IsSet("email." + f) {
-			log.Warn().Msg("email not setup properly, some required configuration fields are missing")
-			return nil
-		}
+	s := &Sender{
+		db: db,
+		hc: hc,
 	}
+	if err := s.Reload(cfg); err != nil {
+		log.Warn().Err(err).Msg("email not setup properly")
+		return nil
+	}
+	return s
+}
 
-	s := &Sender{
-		fromName: cfg.GetString("email.fromName"),
-		from:     cfg.GetString("email.from"),
-		replyTo:  cfg.GetString("email.replyTo"),
-		smtpAddr: fmt.Sprintf(
-			"%s:%d",
-			cfg.GetString("email.smtp.host"),
-			cfg.GetInt("email.smtp.port"),
-		),
+// Reload updates the sender settings (provider, from address, etc) using the
+// configuration provided. When the new configuration is not valid, an error
+// is returned and the previous settings are kept.
+func (s *Sender) Reload(cfg *viper.Viper) error {
+	if !cfg.IsSet("email.from") {
+		return errors.New("email from address not provided")
+	}
+	var provider Provider
+	var err error
+	switch p := cfg.GetString("email.provider"); p {
+	case "", "smtp":
+		provider, err = NewSMTPProvider(cfg)
+	case "ses":
+		provider, err = NewSESProvider(cfg, s.hc)
+	case "sendgrid":
+		provider, err = NewSendGridProvider(cfg, s.hc)
+	case "mailgun":
+		provider, err = NewMailgunProvider(cfg, s.hc)
+	default:
+		err = fmt.Errorf("invalid email provider: %s", p)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.provider = provider
+	s.from = mail.Address{
+		Name:    cfg.GetString("email.fromName"),
+		Address: cfg.GetString("email.from"),
+	}
+	s.replyTo = cfg.GetString("email.replyTo")
+	return nil
+}
+
+// ProcessWebhook processes the webhook request provided, adding the email
+// addresses reported as bounced or that complained to the suppression list.
+func (s *Sender) ProcessWebhook(ctx context.Context, provider string, r *http.Request) error {
+	s.mu.RLock()
+	wp, ok := s.provider.(WebhookProvider)
+	s.mu.RUnlock()
+	if !ok || wp.Name() != provider {
+		return ErrWebhookNotSupported
+	}
+	suppressions, err := wp.ProcessWebhook(r)
+	if err != nil {
+		return err
 	}
-	username := cfg.GetString("email.smtp.username")
-	password := cfg.GetString("email.smtp.password")
-	if username != "" && password != "" {
-		switch cfg.GetString("email.smtp.auth") {
-		case "login":
-			s.smtpAuth = loginauth.New(username, password, cfg.GetString("email.smtp.host"))
-		case "plain":
-			s.smtpAuth = smtp.PlainAuth("", username, password, cfg.GetString("email.smtp.host"))
-		default:
-			s.smtpAuth = smtp.PlainAuth("", username, password, cfg.GetString("email.smtp.host"))
+	for _, sp := range suppressions {
+		_, err := s.db.Exec(ctx, addEmailSuppressionDBQ, sp.Email, sp.Reason, provider)
+		if err != nil {
+			return err
 		}
 	}
-	return s
+	return nil
 }
 
 // SendEmail creates an email using the data provided and sends it.
 func (s *Sender) SendEmail(d *Data) error {
-	email := mailyak.New(s.smtpAddr, s.smtpAuth)
-	email.FromName(s.fromName)
-	email.From(s.from)
-	email.ReplyTo(s.replyTo)
-	email.To(d.To)
-	email.Subject(d.Subject)
-	if _, err := email.HTML().Write(d.Body); err != nil {
+	ctx := context.Background()
+
+	// Check recipient is not in the suppression list
+	var suppressed bool
+	if err := s.db.QueryRow(ctx, isEmailSuppressedDBQ, d.To).Scan(&suppressed); err != nil {
 		return err
 	}
-	return email.Send()
+	if suppressed {
+		return ErrRecipientSuppressed
+	}
+
+	// Send email
+	s.mu.RLock()
+	provider, from, replyTo := s.provider, s.from, s.replyTo
+	s.mu.RUnlock()
+	err := provider.Send(ctx, &Message{
+		From:    from,
+		ReplyTo: replyTo,
+		To:      d.To,
+		Subject: d.Subject,
+		HTML:    d.Body,
+	})
+
+	// Add recipient to the suppression list when it was rejected permanently
+	if errors.Is(err, ErrRecipientRejected) {
+		_, dbErr := s.db.Exec(ctx, addEmailSuppressionDBQ, d.To, Bounce, provider.Name())
+		if dbErr != nil {
+			log.Error().Err(dbErr).Str("email", d.To).Msg("error adding email to suppression list")
+		}
+	}
+
+	return err
+}
+
+// doProviderRequest sends the request provided to the email provider API,
+// returning an error if the request does not succeed.
+func doProviderRequest(hc HTTPClient, req *http.Request) error {
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code received: %d (%s)", resp.StatusCode, body)
+	}
+	return nil
 }
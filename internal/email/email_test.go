@@ -0,0 +1,181 @@
+package email
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	t.Run("invalid configuration, previous settings kept", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("email.from", "new@email.com")
+		cfg.Set("email.provider", "sendgrid")
+		p := &providerMock{}
+		s := &Sender{provider: p, from: mail.Address{Address: "hub@email.com"}}
+
+		err := s.Reload(cfg)
+		assert.Error(t, err)
+		assert.Equal(t, p, s.provider)
+		assert.Equal(t, "hub@email.com", s.from.Address)
+	})
+
+	t.Run("settings updated", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("email.from", "new@email.com")
+		cfg.Set("email.fromName", "Hub")
+		cfg.Set("email.replyTo", "reply@email.com")
+		cfg.Set("email.provider", "sendgrid")
+		cfg.Set("email.sendgrid.apiKey", "key")
+		s := &Sender{provider: &providerMock{}, from: mail.Address{Address: "hub@email.com"}}
+
+		err := s.Reload(cfg)
+		require.NoError(t, err)
+		assert.IsType(t, &SendGridProvider{}, s.provider)
+		assert.Equal(t, mail.Address{Name: "Hub", Address: "new@email.com"}, s.from)
+		assert.Equal(t, "reply@email.com", s.replyTo)
+	})
+}
+
+func TestSendEmail(t *testing.T) {
+	ctx := context.Background()
+	d := &Data{
+		To:      "user1@email.com",
+		Subject: "subject",
+		Body:    []byte("body"),
+	}
+
+	t.Run("error checking suppression list", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isEmailSuppressedDBQ, "user1@email.com").Return(nil, tests.ErrFakeDB)
+		s := &Sender{db: db, provider: &providerMock{}}
+
+		err := s.SendEmail(d)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("recipient in suppression list", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isEmailSuppressedDBQ, "user1@email.com").Return(true, nil)
+		p := &providerMock{}
+		s := &Sender{db: db, provider: p}
+
+		err := s.SendEmail(d)
+		assert.Equal(t, ErrRecipientSuppressed, err)
+		db.AssertExpectations(t)
+		p.AssertExpectations(t)
+	})
+
+	t.Run("recipient rejected, added to the suppression list", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isEmailSuppressedDBQ, "user1@email.com").Return(false, nil)
+		db.On("Exec", ctx, addEmailSuppressionDBQ, "user1@email.com", Bounce, "mock").Return(nil)
+		p := &providerMock{}
+		p.On("Send", ctx, mock.Anything).Return(ErrRecipientRejected)
+		s := &Sender{db: db, provider: p}
+
+		err := s.SendEmail(d)
+		assert.Equal(t, ErrRecipientRejected, err)
+		db.AssertExpectations(t)
+		p.AssertExpectations(t)
+	})
+
+	t.Run("email sent using the provider", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isEmailSuppressedDBQ, "user1@email.com").Return(false, nil)
+		p := &providerMock{}
+		p.On("Send", ctx, &Message{
+			From:    mail.Address{Name: "Artifact Hub", Address: "hub@email.com"},
+			ReplyTo: "reply@email.com",
+			To:      "user1@email.com",
+			Subject: "subject",
+			HTML:    []byte("body"),
+		}).Return(nil)
+		s := &Sender{
+			db:       db,
+			provider: p,
+			from:     mail.Address{Name: "Artifact Hub", Address: "hub@email.com"},
+			replyTo:  "reply@email.com",
+		}
+
+		err := s.SendEmail(d)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		p.AssertExpectations(t)
+	})
+}
+
+func TestProcessWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("provider does not support webhooks", func(t *testing.T) {
+		t.Parallel()
+		s := &Sender{provider: &SMTPProvider{}}
+
+		r, _ := http.NewRequest("POST", "/", nil)
+		err := s.ProcessWebhook(ctx, "smtp", r)
+		assert.Equal(t, ErrWebhookNotSupported, err)
+	})
+
+	t.Run("webhook does not belong to the provider in use", func(t *testing.T) {
+		t.Parallel()
+		s := &Sender{provider: &MailgunProvider{webhookSigningKey: "key"}}
+
+		r, _ := http.NewRequest("POST", "/", nil)
+		err := s.ProcessWebhook(ctx, "ses", r)
+		assert.Equal(t, ErrWebhookNotSupported, err)
+	})
+
+	t.Run("suppressions registered", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addEmailSuppressionDBQ, "user1@email.com", Complaint, "mailgun").Return(nil)
+		s := &Sender{db: db, provider: &MailgunProvider{webhookSigningKey: "key"}}
+
+		r := httptest.NewRequest("POST", "/", strings.NewReader(mailgunWebhookPayload(t, "key", "complained", "")))
+		err := s.ProcessWebhook(ctx, "mailgun", r)
+		require.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error registering suppression", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addEmailSuppressionDBQ, "user1@email.com", Bounce, "mailgun").Return(tests.ErrFakeDB)
+		s := &Sender{db: db, provider: &MailgunProvider{webhookSigningKey: "key"}}
+
+		r := httptest.NewRequest("POST", "/", strings.NewReader(mailgunWebhookPayload(t, "key", "failed", "permanent")))
+		err := s.ProcessWebhook(ctx, "mailgun", r)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
+
+type providerMock struct {
+	mock.Mock
+}
+
+func (m *providerMock) Name() string {
+	return "mock"
+}
+
+func (m *providerMock) Send(ctx context.Context, msg *Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
@@ -0,0 +1,152 @@
+package email
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/sandbox"
+)
+
+// DefaultLocale represents the locale used to compose emails when the user
+// hasn't selected one, or when the requested locale or message translation
+// isn't available.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// catalogs contains the messages used in emails for each of the supported
+// locales, indexed by locale and message key.
+var catalogs = loadCatalogs()
+
+// loadCatalogs loads the messages catalogs embedded in the locales directory.
+func loadCatalogs() map[string]map[string]string {
+	entries, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	catalogs := make(map[string]map[string]string, len(entries))
+	for _, e := range entries {
+		data, err := localesFS.ReadFile(path.Join("locales", e.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Errorf("invalid locale catalog %s: %w", e.Name(), err))
+		}
+		catalogs[strings.TrimSuffix(e.Name(), ".json")] = messages
+	}
+	return catalogs
+}
+
+// IsLocaleSupported checks if the locale provided is supported.
+func IsLocaleSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// NormalizeLocale returns the supported locale that better matches the one
+// provided (i.e. es-ES -> es), falling back to the default locale.
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if IsLocaleSupported(locale) {
+		return locale
+	}
+	if i := strings.IndexAny(locale, "-_"); i > 0 && IsLocaleSupported(locale[:i]) {
+		return locale[:i]
+	}
+	return DefaultLocale
+}
+
+// Translate returns the message identified by the key provided translated to
+// the locale given, formatted using the arguments provided. When the message
+// isn't available in the requested locale, the default locale is used.
+func Translate(locale, key string, args ...interface{}) string {
+	msg, ok := catalogs[NormalizeLocale(locale)][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLocale][key]
+		if !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// FormatDate formats the date provided using the conventions of the locale
+// given. The date can be provided as a time.Time or as a unix timestamp.
+func FormatDate(locale string, date interface{}) string {
+	var t time.Time
+	switch v := date.(type) {
+	case time.Time:
+		t = v
+	case int64:
+		t = time.Unix(v, 0)
+	case int:
+		t = time.Unix(int64(v), 0)
+	default:
+		return ""
+	}
+	t = t.UTC()
+	r := strings.NewReplacer(
+		"{day}", strconv.Itoa(t.Day()),
+		"{month}", Translate(locale, "date.month."+strconv.Itoa(int(t.Month()))),
+		"{year}", strconv.Itoa(t.Year()),
+	)
+	return r.Replace(Translate(locale, "date.format"))
+}
+
+// TemplateFuncs returns the functions available in the emails templates to
+// translate messages and format dates using the locale provided.
+func TemplateFuncs(locale string) template.FuncMap {
+	locale = NormalizeLocale(locale)
+	return template.FuncMap{
+		"date": func(date interface{}) string {
+			return FormatDate(locale, date)
+		},
+		"locale": func() string {
+			return locale
+		},
+		"t": func(key string, args ...interface{}) template.HTML {
+			// Messages in the catalogs are trusted and may contain some markup,
+			// but the arguments provided must be escaped
+			escapedArgs := make([]interface{}, 0, len(args))
+			for _, arg := range args {
+				escapedArgs = append(escapedArgs, template.HTMLEscapeString(fmt.Sprint(arg)))
+			}
+			return template.HTML(Translate(locale, key, escapedArgs...)) // #nosec
+		},
+	}
+}
+
+// ParseTemplate parses the email template provided on top of the base
+// template, registering the functions used to localize them.
+func ParseTemplate(tmpl string) *template.Template {
+	return template.Must(template.New("").Funcs(TemplateFuncs(DefaultLocale)).Parse(BaseTmpl + tmpl))
+}
+
+// ExecuteTemplate applies the template provided to the data given using the
+// locale requested, writing the output to w. The execution is subject to the
+// same time and output size limits as the rest of the templates.
+func ExecuteTemplate(w io.Writer, tmpl *template.Template, locale string, data interface{}) error {
+	lt, err := tmpl.Clone()
+	if err != nil {
+		return err
+	}
+	output, err := sandbox.ExecuteTemplate(lt.Funcs(TemplateFuncs(locale)), data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(output)
+	return err
+}
@@ -0,0 +1,130 @@
+package email
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogs(t *testing.T) {
+	verbRE := regexp.MustCompile(`%(\[\d+\])?[sd]`)
+
+	require.Contains(t, catalogs, DefaultLocale)
+	for locale, messages := range catalogs {
+		for key, msg := range messages {
+			defaultMsg, ok := catalogs[DefaultLocale][key]
+			assert.True(t, ok, "%s: unknown key %s", locale, key)
+			assert.Equal(t, len(verbRE.FindAllString(defaultMsg, -1)), len(verbRE.FindAllString(msg, -1)),
+				"%s: arguments mismatch in key %s", locale, key)
+		}
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	testCases := []struct {
+		locale         string
+		expectedLocale string
+	}{
+		{"", DefaultLocale},
+		{"en", "en"},
+		{"es", "es"},
+		{"ES", "es"},
+		{"es-ES", "es"},
+		{"fr_FR", "fr"},
+		{"xx", DefaultLocale},
+		{"xx-ES", DefaultLocale},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.locale, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedLocale, NormalizeLocale(tc.locale))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	t.Run("message translated", func(t *testing.T) {
+		t.Parallel()
+		msg := Translate("es", "verification.preheader", "Artifact Hub")
+		assert.Equal(t, "¡Bienvenido a Artifact Hub!", msg)
+	})
+
+	t.Run("unsupported locale falls back to default locale", func(t *testing.T) {
+		t.Parallel()
+		msg := Translate("xx", "verification.preheader", "Artifact Hub")
+		assert.Equal(t, "Welcome to Artifact Hub!", msg)
+	})
+
+	t.Run("message not translated falls back to default locale", func(t *testing.T) {
+		catalogs["test"] = map[string]string{}
+		defer delete(catalogs, "test")
+		msg := Translate("test", "verification.preheader", "Artifact Hub")
+		assert.Equal(t, "Welcome to Artifact Hub!", msg)
+	})
+
+	t.Run("unknown message key", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, "unknown.key", Translate("es", "unknown.key"))
+	})
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2021, time.August, 5, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		locale       string
+		date         interface{}
+		expectedDate string
+	}{
+		{"en", date, "August 5, 2021"},
+		{"es", date, "5 de agosto de 2021"},
+		{"fr", date.Unix(), "5 août 2021"},
+		{"xx", date.Unix(), "August 5, 2021"},
+		{"en", "invalid", ""},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.locale, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedDate, FormatDate(tc.locale, tc.date))
+		})
+	}
+}
+
+func TestExecuteTemplate(t *testing.T) {
+	tmpl := ParseTemplate(`
+{{ define "title" }}{{ t "verification.title" }}{{ end }}
+{{ define "content" }}<p>{{ t "invitation.intro" .OrgName .SiteName }}</p><p>{{ date .TS }}</p>{{ end }}
+`)
+	data := map[string]interface{}{
+		"OrgName":  "<org1>",
+		"SiteName": "Artifact Hub",
+		"TS":       int64(1628157600),
+		"Theme":    map[string]string{},
+	}
+
+	t.Run("template executed using the locale provided", func(t *testing.T) {
+		t.Parallel()
+		var b bytes.Buffer
+		err := ExecuteTemplate(&b, tmpl, "es", data)
+		require.NoError(t, err)
+		assert.Contains(t, b.String(), `<html lang="es">`)
+		assert.Contains(t, b.String(), "<title>Confirmación de correo</title>")
+		assert.Contains(t, b.String(), "<p>Has sido invitado a unirte a la organización <b>&lt;org1&gt;</b> en Artifact Hub.</p>")
+		assert.Contains(t, b.String(), "<p>5 de agosto de 2021</p>")
+	})
+
+	t.Run("template executed using the default locale", func(t *testing.T) {
+		t.Parallel()
+		var b bytes.Buffer
+		err := ExecuteTemplate(&b, tmpl, "", data)
+		require.NoError(t, err)
+		assert.Contains(t, b.String(), `<html lang="en">`)
+		assert.Contains(t, b.String(), "<p>You have been invited to join <b>&lt;org1&gt;</b> organization on Artifact Hub.</p>")
+		assert.Contains(t, b.String(), "<p>August 5, 2021</p>")
+	})
+}
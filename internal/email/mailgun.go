@@ -0,0 +1,116 @@
+package email
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// mailgunDefaultAPIBase represents the default Mailgun API base url. European
+// accounts must use https://api.eu.mailgun.net instead.
+const mailgunDefaultAPIBase = "https://api.mailgun.net"
+
+// MailgunProvider is an email provider that delivers emails using the Mailgun
+// API.
+type MailgunProvider struct {
+	hc                HTTPClient
+	apiBase           string
+	apiKey            string
+	domain            string
+	webhookSigningKey string
+}
+
+// NewMailgunProvider creates a new MailgunProvider instance.
+func NewMailgunProvider(cfg *viper.Viper, hc HTTPClient) (*MailgunProvider, error) {
+	apiKey := cfg.GetString("email.mailgun.apiKey")
+	domain := cfg.GetString("email.mailgun.domain")
+	if apiKey == "" || domain == "" {
+		return nil, errors.New("mailgun api key and domain not provided")
+	}
+	apiBase := cfg.GetString("email.mailgun.apiBase")
+	if apiBase == "" {
+		apiBase = mailgunDefaultAPIBase
+	}
+	return &MailgunProvider{
+		hc:                hc,
+		apiBase:           strings.TrimSuffix(apiBase, "/"),
+		apiKey:            apiKey,
+		domain:            domain,
+		webhookSigningKey: cfg.GetString("email.mailgun.webhookSigningKey"),
+	}, nil
+}
+
+// Name implements the Provider interface.
+func (p *MailgunProvider) Name() string {
+	return "mailgun"
+}
+
+// Send implements the Provider interface.
+func (p *MailgunProvider) Send(ctx context.Context, m *Message) error {
+	form := url.Values{}
+	form.Set("from", m.From.String())
+	form.Set("to", m.To)
+	form.Set("subject", m.Subject)
+	form.Set("html", string(m.HTML))
+	if m.ReplyTo != "" {
+		form.Set("h:Reply-To", m.ReplyTo)
+	}
+
+	u := fmt.Sprintf("%s/v3/%s/messages", p.apiBase, p.domain)
+	req, _ := http.NewRequestWithContext(ctx, "POST", u, strings.NewReader(form.Encode()))
+	req.SetBasicAuth("api", p.apiKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doProviderRequest(p.hc, req)
+}
+
+// ProcessWebhook implements the WebhookProvider interface. Permanent failures
+// and complaints are reported as suppressions.
+func (p *MailgunProvider) ProcessWebhook(r *http.Request) ([]*Suppression, error) {
+	if p.webhookSigningKey == "" {
+		return nil, ErrWebhookNotSupported
+	}
+
+	// Parse payload
+	var payload struct {
+		Signature struct {
+			Timestamp string `json:"timestamp"`
+			Token     string `json:"token"`
+			Signature string `json:"signature"`
+		} `json:"signature"`
+		EventData struct {
+			Event     string `json:"event"`
+			Severity  string `json:"severity"`
+			Recipient string `json:"recipient"`
+		} `json:"event-data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookRequest, err)
+	}
+
+	// Verify request signature
+	mac := hmac.New(sha256.New, []byte(p.webhookSigningKey))
+	mac.Write([]byte(payload.Signature.Timestamp + payload.Signature.Token))
+	expectedSig := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expectedSig), []byte(payload.Signature.Signature)) {
+		return nil, ErrWebhookUnauthorized
+	}
+
+	// Extract suppression from event
+	e := payload.EventData
+	switch {
+	case e.Event == "failed" && e.Severity == "permanent":
+		return []*Suppression{{Email: e.Recipient, Reason: Bounce}}, nil
+	case e.Event == "complained":
+		return []*Suppression{{Email: e.Recipient, Reason: Complaint}}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,140 @@
+package email
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMailgunProviderSend(t *testing.T) {
+	ctx := context.Background()
+	m := &Message{
+		From:    mail.Address{Name: "Artifact Hub", Address: "hub@email.com"},
+		ReplyTo: "reply@email.com",
+		To:      "user1@email.com",
+		Subject: "subject",
+		HTML:    []byte("<p>body</p>"),
+	}
+
+	t.Run("message sent", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			username, password, _ := req.BasicAuth()
+			_ = req.ParseForm()
+			return req.Method == "POST" &&
+				req.URL.String() == "https://api.mailgun.net/v3/example.com/messages" &&
+				username == "api" &&
+				password == "apiKey" &&
+				req.PostForm.Get("from") == `"Artifact Hub" <hub@email.com>` &&
+				req.PostForm.Get("to") == "user1@email.com" &&
+				req.PostForm.Get("h:Reply-To") == "reply@email.com" &&
+				req.PostForm.Get("html") == "<p>body</p>"
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil)
+		p := &MailgunProvider{hc: hc, apiBase: mailgunDefaultAPIBase, apiKey: "apiKey", domain: "example.com"}
+
+		err := p.Send(ctx, m)
+		assert.NoError(t, err)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusUnauthorized,
+			Body:       ioutil.NopCloser(strings.NewReader("Forbidden")),
+		}, nil)
+		p := &MailgunProvider{hc: hc, apiBase: mailgunDefaultAPIBase, apiKey: "apiKey", domain: "example.com"}
+
+		err := p.Send(ctx, m)
+		assert.Error(t, err)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestMailgunProviderProcessWebhook(t *testing.T) {
+	testCases := []struct {
+		desc                 string
+		payload              string
+		expectedSuppressions []*Suppression
+		expectedErr          error
+	}{
+		{
+			"invalid payload",
+			"{",
+			nil,
+			ErrInvalidWebhookRequest,
+		},
+		{
+			"invalid signature",
+			mailgunWebhookPayload(t, "otherKey", "complained", ""),
+			nil,
+			ErrWebhookUnauthorized,
+		},
+		{
+			"permanent failure",
+			mailgunWebhookPayload(t, "key", "failed", "permanent"),
+			[]*Suppression{{Email: "user1@email.com", Reason: Bounce}},
+			nil,
+		},
+		{
+			"temporary failure",
+			mailgunWebhookPayload(t, "key", "failed", "temporary"),
+			nil,
+			nil,
+		},
+		{
+			"complaint",
+			mailgunWebhookPayload(t, "key", "complained", ""),
+			[]*Suppression{{Email: "user1@email.com", Reason: Complaint}},
+			nil,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			p := &MailgunProvider{webhookSigningKey: "key"}
+			r := httptest.NewRequest("POST", "/", strings.NewReader(tc.payload))
+
+			suppressions, err := p.ProcessWebhook(r)
+			assert.True(t, errors.Is(err, tc.expectedErr))
+			assert.Equal(t, tc.expectedSuppressions, suppressions)
+		})
+	}
+}
+
+func mailgunWebhookPayload(t *testing.T, key, event, severity string) string {
+	t.Helper()
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte("1600000000" + "token"))
+	return fmt.Sprintf(`{
+		"signature": {
+			"timestamp": "1600000000",
+			"token": "token",
+			"signature": "%s"
+		},
+		"event-data": {
+			"event": "%s",
+			"severity": "%s",
+			"recipient": "user1@email.com"
+		}
+	}`, hex.EncodeToString(mac.Sum(nil)), event, severity)
+}
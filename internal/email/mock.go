@@ -1,7 +1,9 @@
 package email
 
 import (
+	"context"
 	"errors"
+	"net/http"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -19,3 +21,15 @@ func (m *SenderMock) SendEmail(data *Data) error {
 	args := m.Called(data)
 	return args.Error(0)
 }
+
+// WebhooksProcessorMock is a mock implementation of the hub
+// EmailWebhooksProcessor interface.
+type WebhooksProcessorMock struct {
+	mock.Mock
+}
+
+// ProcessWebhook implements the EmailWebhooksProcessor interface.
+func (m *WebhooksProcessorMock) ProcessWebhook(ctx context.Context, provider string, r *http.Request) error {
+	args := m.Called(ctx, provider, r)
+	return args.Error(0)
+}
@@ -0,0 +1,168 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// sendGridAPIURL represents the SendGrid v3 mail send API endpoint.
+	sendGridAPIURL = "https://api.sendgrid.com/v3/mail/send"
+
+	// sendGridSignatureHeader represents the header used by SendGrid to
+	// provide the signature of the event webhook payload.
+	sendGridSignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+
+	// sendGridTimestampHeader represents the header used by SendGrid to
+	// provide the timestamp included in the event webhook signature.
+	sendGridTimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+)
+
+// SendGridProvider is an email provider that delivers emails using the
+// SendGrid API.
+type SendGridProvider struct {
+	hc           HTTPClient
+	apiURL       string
+	apiKey       string
+	verification *ecdsa.PublicKey
+}
+
+// NewSendGridProvider creates a new SendGridProvider instance.
+func NewSendGridProvider(cfg *viper.Viper, hc HTTPClient) (*SendGridProvider, error) {
+	apiKey := cfg.GetString("email.sendgrid.apiKey")
+	if apiKey == "" {
+		return nil, errors.New("sendgrid api key not provided")
+	}
+	p := &SendGridProvider{
+		hc:     hc,
+		apiURL: sendGridAPIURL,
+		apiKey: apiKey,
+	}
+
+	// Event webhook verification key is optional, as webhooks may not be used
+	if k := cfg.GetString("email.sendgrid.webhookVerificationKey"); k != "" {
+		key, err := parseSendGridVerificationKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sendgrid webhook verification key: %w", err)
+		}
+		p.verification = key
+	}
+
+	return p, nil
+}
+
+// Name implements the Provider interface.
+func (p *SendGridProvider) Name() string {
+	return "sendgrid"
+}
+
+// Send implements the Provider interface.
+func (p *SendGridProvider) Send(ctx context.Context, m *Message) error {
+	type address struct {
+		Email string `json:"email"`
+		Name  string `json:"name,omitempty"`
+	}
+	type content struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	type personalization struct {
+		To []address `json:"to"`
+	}
+	body := struct {
+		Personalizations []personalization `json:"personalizations"`
+		From             address           `json:"from"`
+		ReplyTo          *address          `json:"reply_to,omitempty"`
+		Subject          string            `json:"subject"`
+		Content          []content         `json:"content"`
+	}{
+		Personalizations: []personalization{{To: []address{{Email: m.To}}}},
+		From:             address{Email: m.From.Address, Name: m.From.Name},
+		Subject:          m.Subject,
+		Content:          []content{{Type: "text/html", Value: string(m.HTML)}},
+	}
+	if m.ReplyTo != "" {
+		body.ReplyTo = &address{Email: m.ReplyTo}
+	}
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", p.apiURL, bytes.NewReader(bodyJSON))
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	return doProviderRequest(p.hc, req)
+}
+
+// ProcessWebhook implements the WebhookProvider interface. Bounces (blocked
+// messages are ignored as they are usually temporary) and spam reports are
+// reported as suppressions.
+func (p *SendGridProvider) ProcessWebhook(r *http.Request) ([]*Suppression, error) {
+	if p.verification == nil {
+		return nil, ErrWebhookNotSupported
+	}
+
+	// Verify request signature
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.StdEncoding.DecodeString(r.Header.Get(sendGridSignatureHeader))
+	if err != nil || len(sig) == 0 {
+		return nil, ErrWebhookUnauthorized
+	}
+	digest := sha256.Sum256(append([]byte(r.Header.Get(sendGridTimestampHeader)), payload...))
+	if !ecdsa.VerifyASN1(p.verification, digest[:], sig) {
+		return nil, ErrWebhookUnauthorized
+	}
+
+	// Extract suppressions from events
+	var events []struct {
+		Email string `json:"email"`
+		Event string `json:"event"`
+		Type  string `json:"type"`
+	}
+	if err := json.Unmarshal(payload, &events); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookRequest, err)
+	}
+	var suppressions []*Suppression
+	for _, e := range events {
+		switch {
+		case e.Event == "bounce" && e.Type != "blocked":
+			suppressions = append(suppressions, &Suppression{Email: e.Email, Reason: Bounce})
+		case e.Event == "spamreport":
+			suppressions = append(suppressions, &Suppression{Email: e.Email, Reason: Complaint})
+		}
+	}
+	return suppressions, nil
+}
+
+// parseSendGridVerificationKey parses the base64 encoded ECDSA public key
+// provided by SendGrid to verify the event webhook requests.
+func parseSendGridVerificationKey(k string) (*ecdsa.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(k)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an ecdsa public key")
+	}
+	return ecKey, nil
+}
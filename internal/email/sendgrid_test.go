@@ -0,0 +1,135 @@
+package email
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendGridProviderSend(t *testing.T) {
+	ctx := context.Background()
+	m := &Message{
+		From:    mail.Address{Name: "Artifact Hub", Address: "hub@email.com"},
+		ReplyTo: "reply@email.com",
+		To:      "user1@email.com",
+		Subject: "subject",
+		HTML:    []byte("<p>body</p>"),
+	}
+
+	t.Run("message sent", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			var body map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			return req.Method == "POST" &&
+				req.URL.String() == sendGridAPIURL &&
+				req.Header.Get("Authorization") == "Bearer apiKey" &&
+				body["subject"] == "subject" &&
+				body["reply_to"].(map[string]interface{})["email"] == "reply@email.com"
+		})).Return(&http.Response{
+			StatusCode: http.StatusAccepted,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil)
+		p := &SendGridProvider{hc: hc, apiURL: sendGridAPIURL, apiKey: "apiKey"}
+
+		err := p.Send(ctx, m)
+		assert.NoError(t, err)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("http client error", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
+		p := &SendGridProvider{hc: hc, apiURL: sendGridAPIURL, apiKey: "apiKey"}
+
+		err := p.Send(ctx, m)
+		assert.Equal(t, tests.ErrFake, err)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestSendGridProviderProcessWebhook(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	verificationKey, err := parseSendGridVerificationKey(base64.StdEncoding.EncodeToString(der))
+	require.NoError(t, err)
+
+	newRequest := func(payload string, sign bool) *http.Request {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(payload))
+		r.Header.Set(sendGridTimestampHeader, "1600000000")
+		if sign {
+			digest := sha256.Sum256([]byte("1600000000" + payload))
+			sig, _ := ecdsa.SignASN1(rand.Reader, key, digest[:])
+			r.Header.Set(sendGridSignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		}
+		return r
+	}
+
+	t.Run("webhook verification key not configured", func(t *testing.T) {
+		t.Parallel()
+		p := &SendGridProvider{}
+		_, err := p.ProcessWebhook(newRequest("[]", true))
+		assert.Equal(t, ErrWebhookNotSupported, err)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		t.Parallel()
+		p := &SendGridProvider{verification: verificationKey}
+		_, err := p.ProcessWebhook(newRequest("[]", false))
+		assert.Equal(t, ErrWebhookUnauthorized, err)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+		p := &SendGridProvider{verification: verificationKey}
+		r := newRequest("[]", true)
+		r.Header.Set(sendGridTimestampHeader, "1600000001")
+		_, err := p.ProcessWebhook(r)
+		assert.Equal(t, ErrWebhookUnauthorized, err)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		t.Parallel()
+		p := &SendGridProvider{verification: verificationKey}
+		_, err := p.ProcessWebhook(newRequest("{", true))
+		assert.True(t, errors.Is(err, ErrInvalidWebhookRequest))
+	})
+
+	t.Run("suppressions extracted from events", func(t *testing.T) {
+		t.Parallel()
+		p := &SendGridProvider{verification: verificationKey}
+		payload := `[
+			{"email": "user1@email.com", "event": "bounce", "type": "bounce"},
+			{"email": "user2@email.com", "event": "bounce", "type": "blocked"},
+			{"email": "user3@email.com", "event": "spamreport"},
+			{"email": "user4@email.com", "event": "delivered"}
+		]`
+		suppressions, err := p.ProcessWebhook(newRequest(payload, true))
+		require.NoError(t, err)
+		assert.Equal(t, []*Suppression{
+			{Email: "user1@email.com", Reason: Bounce},
+			{Email: "user3@email.com", Reason: Complaint},
+		}, suppressions)
+	})
+}
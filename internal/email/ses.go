@@ -0,0 +1,305 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/artifacthub/hub/internal/awsauth"
+	"github.com/spf13/viper"
+)
+
+const (
+	// sesSendEmailPath represents the path of the SES v2 send email endpoint.
+	sesSendEmailPath = "/v2/email/outbound-emails"
+
+	// sesService represents the service name used to sign SES requests.
+	sesService = "ses"
+)
+
+// snsHostRE is a regexp used to check that the urls provided in the SNS
+// messages received belong to the SNS service.
+var snsHostRE = regexp.MustCompile(`^sns\.[a-z0-9\-]+\.amazonaws\.com(\.cn)?$`)
+
+// SESProvider is an email provider that delivers emails using the AWS SES v2
+// API. Bounces and complaints notifications are received from SES through
+// a SNS topic subscription.
+type SESProvider struct {
+	hc       HTTPClient
+	endpoint string
+	signer   *awsauth.Signer
+	topicARN string
+
+	mu    sync.RWMutex
+	certs map[string]*x509.Certificate
+}
+
+// NewSESProvider creates a new SESProvider instance. When no credentials are
+// provided in the configuration, the AWS SDK default credentials chain will
+// be used.
+func NewSESProvider(cfg *viper.Viper, hc HTTPClient) (*SESProvider, error) {
+	region := cfg.GetString("email.ses.region")
+	if region == "" {
+		return nil, errors.New("ses region not provided")
+	}
+	signer, err := awsauth.NewSigner(cfg, "email.ses", region, sesService)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up ses signer: %w", err)
+	}
+	return &SESProvider{
+		hc:       hc,
+		endpoint: fmt.Sprintf("https://email.%s.amazonaws.com", region),
+		signer:   signer,
+		topicARN: cfg.GetString("email.ses.topicARN"),
+		certs:    make(map[string]*x509.Certificate),
+	}, nil
+}
+
+// Name implements the Provider interface.
+func (p *SESProvider) Name() string {
+	return "ses"
+}
+
+// Send implements the Provider interface.
+func (p *SESProvider) Send(ctx context.Context, m *Message) error {
+	type content struct {
+		Data    string `json:"Data"`
+		Charset string `json:"Charset"`
+	}
+	body := map[string]interface{}{
+		"FromEmailAddress": m.From.String(),
+		"Destination": map[string]interface{}{
+			"ToAddresses": []string{m.To},
+		},
+		"Content": map[string]interface{}{
+			"Simple": map[string]interface{}{
+				"Subject": content{Data: m.Subject, Charset: "UTF-8"},
+				"Body": map[string]interface{}{
+					"Html": content{Data: string(m.HTML), Charset: "UTF-8"},
+				},
+			},
+		},
+	}
+	if m.ReplyTo != "" {
+		body["ReplyToAddresses"] = []string{m.ReplyTo}
+	}
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", p.endpoint+sesSendEmailPath, bytes.NewReader(bodyJSON))
+	req.Header.Set("Content-Type", "application/json")
+	if err := p.signer.Sign(ctx, req, bodyJSON); err != nil {
+		return err
+	}
+	return doProviderRequest(p.hc, req)
+}
+
+// snsMessage represents a message delivered by SNS to a http endpoint.
+type snsMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// stringToSign returns the string that SNS signed for the message.
+func (m *snsMessage) stringToSign() string {
+	var fields [][2]string
+	switch m.Type {
+	case "Notification":
+		fields = append(fields, [2]string{"Message", m.Message}, [2]string{"MessageId", m.MessageID})
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields,
+			[2]string{"Timestamp", m.Timestamp},
+			[2]string{"TopicArn", m.TopicArn},
+			[2]string{"Type", m.Type},
+		)
+	default:
+		fields = append(fields,
+			[2]string{"Message", m.Message},
+			[2]string{"MessageId", m.MessageID},
+			[2]string{"SubscribeURL", m.SubscribeURL},
+			[2]string{"Timestamp", m.Timestamp},
+			[2]string{"Token", m.Token},
+			[2]string{"TopicArn", m.TopicArn},
+			[2]string{"Type", m.Type},
+		)
+	}
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteString(f[0] + "\n" + f[1] + "\n")
+	}
+	return b.String()
+}
+
+// ProcessWebhook implements the WebhookProvider interface. SNS subscription
+// confirmations are handled automatically. Permanent bounces and complaints
+// notifications are reported as suppressions.
+func (p *SESProvider) ProcessWebhook(r *http.Request) ([]*Suppression, error) {
+	if p.topicARN == "" {
+		return nil, ErrWebhookNotSupported
+	}
+
+	// Parse and verify SNS message
+	var m snsMessage
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookRequest, err)
+	}
+	if m.TopicArn != p.topicARN {
+		return nil, ErrWebhookUnauthorized
+	}
+	if err := p.verifySNSMessage(r.Context(), &m); err != nil {
+		return nil, err
+	}
+
+	switch m.Type {
+	case "SubscriptionConfirmation":
+		if !isSNSURL(m.SubscribeURL) {
+			return nil, fmt.Errorf("%w: invalid subscribe url", ErrInvalidWebhookRequest)
+		}
+		req, _ := http.NewRequestWithContext(r.Context(), "GET", m.SubscribeURL, nil)
+		return nil, doProviderRequest(p.hc, req)
+	case "Notification":
+		return parseSESNotification(m.Message)
+	}
+	return nil, nil
+}
+
+// verifySNSMessage verifies the signature of the SNS message provided.
+func (p *SESProvider) verifySNSMessage(ctx context.Context, m *snsMessage) error {
+	var alg x509.SignatureAlgorithm
+	switch m.SignatureVersion {
+	case "1":
+		alg = x509.SHA1WithRSA
+	case "2":
+		alg = x509.SHA256WithRSA
+	default:
+		return ErrWebhookUnauthorized
+	}
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return ErrWebhookUnauthorized
+	}
+	cert, err := p.getSNSCertificate(ctx, m.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	if err := cert.CheckSignature(alg, []byte(m.stringToSign()), sig); err != nil {
+		return ErrWebhookUnauthorized
+	}
+	return nil
+}
+
+// getSNSCertificate returns the SNS signing certificate available at the url
+// provided. Certificates are cached once they have been fetched.
+func (p *SESProvider) getSNSCertificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	p.mu.RLock()
+	cert, ok := p.certs[certURL]
+	p.mu.RUnlock()
+	if ok {
+		return cert, nil
+	}
+
+	if !isSNSURL(certURL) {
+		return nil, ErrWebhookUnauthorized
+	}
+	req, _ := http.NewRequestWithContext(ctx, "GET", certURL, nil)
+	resp, err := p.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid sns signing certificate")
+	}
+	cert, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.certs[certURL] = cert
+	p.mu.Unlock()
+	return cert, nil
+}
+
+// parseSESNotification extracts the suppressions from the SES notification
+// provided. Both notifications and event publishing formats are supported.
+func parseSESNotification(data string) ([]*Suppression, error) {
+	type recipient struct {
+		EmailAddress string `json:"emailAddress"`
+	}
+	var n struct {
+		NotificationType string `json:"notificationType"`
+		EventType        string `json:"eventType"`
+		Bounce           struct {
+			BounceType        string      `json:"bounceType"`
+			BouncedRecipients []recipient `json:"bouncedRecipients"`
+		} `json:"bounce"`
+		Complaint struct {
+			ComplainedRecipients []recipient `json:"complainedRecipients"`
+		} `json:"complaint"`
+	}
+	if err := json.Unmarshal([]byte(data), &n); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookRequest, err)
+	}
+
+	kind := n.NotificationType
+	if kind == "" {
+		kind = n.EventType
+	}
+	var suppressions []*Suppression
+	switch kind {
+	case "Bounce":
+		if n.Bounce.BounceType != "Permanent" {
+			return nil, nil
+		}
+		for _, r := range n.Bounce.BouncedRecipients {
+			suppressions = append(suppressions, &Suppression{Email: r.EmailAddress, Reason: Bounce})
+		}
+	case "Complaint":
+		for _, r := range n.Complaint.ComplainedRecipients {
+			suppressions = append(suppressions, &Suppression{Email: r.EmailAddress, Reason: Complaint})
+		}
+	}
+	return suppressions, nil
+}
+
+// isSNSURL checks if the url provided belongs to the SNS service.
+func isSNSURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "https" && snsHostRE.MatchString(u.Host)
+}
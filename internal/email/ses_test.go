@@ -0,0 +1,275 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/awsauth"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTopicARN = "arn:aws:sns:us-east-1:123456789012:ses-notifications"
+	testCertURL  = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+)
+
+func TestSESProviderSend(t *testing.T) {
+	ctx := context.Background()
+	m := &Message{
+		From:    mail.Address{Name: "Artifact Hub", Address: "hub@email.com"},
+		ReplyTo: "reply@email.com",
+		To:      "user1@email.com",
+		Subject: "subject",
+		HTML:    []byte("<p>body</p>"),
+	}
+
+	t.Run("signed message sent", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			var body map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			return req.Method == "POST" &&
+				req.URL.String() == "https://email.us-east-1.amazonaws.com/v2/email/outbound-emails" &&
+				req.Header.Get("X-Amz-Date") != "" &&
+				strings.HasPrefix(req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") &&
+				strings.Contains(req.Header.Get("Authorization"), "/us-east-1/ses/aws4_request, ") &&
+				body["FromEmailAddress"] == `"Artifact Hub" <hub@email.com>`
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+		}, nil)
+		p := newTestSESProvider(hc)
+
+		err := p.Send(ctx, m)
+		assert.NoError(t, err)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("error retrieving credentials", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		p := newTestSESProvider(hc)
+		p.signer = awsauth.NewSignerWithCredentials(aws.AnonymousCredentials{}, "us-east-1", sesService)
+
+		err := p.Send(ctx, m)
+		assert.Error(t, err)
+		hc.AssertNotCalled(t, "Do", mock.Anything)
+	})
+}
+
+func TestSESProviderProcessWebhook(t *testing.T) {
+	key, certPEM := generateTestSNSCertificate(t)
+
+	newRequest := func(m *snsMessage, sign bool) *http.Request {
+		m.TopicArn = testTopicARN
+		m.SigningCertURL = testCertURL
+		m.SignatureVersion = "2"
+		if sign {
+			digest := sha256.Sum256([]byte(m.stringToSign()))
+			sig, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+			m.Signature = base64.StdEncoding.EncodeToString(sig)
+		}
+		data, _ := json.Marshal(m)
+		return httptest.NewRequest("POST", "/", bytes.NewReader(data))
+	}
+	newCertHTTPClient := func() *tests.HTTPClientMock {
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == testCertURL
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(certPEM)),
+		}, nil)
+		return hc
+	}
+
+	t.Run("topic arn not configured", func(t *testing.T) {
+		t.Parallel()
+		p := newTestSESProvider(nil)
+		p.topicARN = ""
+		_, err := p.ProcessWebhook(newRequest(&snsMessage{Type: "Notification"}, true))
+		assert.Equal(t, ErrWebhookNotSupported, err)
+	})
+
+	t.Run("unexpected topic arn", func(t *testing.T) {
+		t.Parallel()
+		p := newTestSESProvider(nil)
+		p.topicARN = "arn:aws:sns:us-east-1:123456789012:other"
+		_, err := p.ProcessWebhook(newRequest(&snsMessage{Type: "Notification"}, true))
+		assert.Equal(t, ErrWebhookUnauthorized, err)
+	})
+
+	t.Run("signing certificate not hosted by sns", func(t *testing.T) {
+		t.Parallel()
+		p := newTestSESProvider(nil)
+		m := &snsMessage{Type: "Notification"}
+		r := newRequest(m, true)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		body["SigningCertURL"] = "https://attacker.example.com/cert.pem"
+		data, _ := json.Marshal(body)
+		_, err := p.ProcessWebhook(httptest.NewRequest("POST", "/", bytes.NewReader(data)))
+		assert.Equal(t, ErrWebhookUnauthorized, err)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+		hc := newCertHTTPClient()
+		p := newTestSESProvider(hc)
+		m := &snsMessage{Type: "Notification", Message: "{}"}
+		r := newRequest(m, false)
+		_, err := p.ProcessWebhook(r)
+		assert.Equal(t, ErrWebhookUnauthorized, err)
+	})
+
+	t.Run("subscription confirmed", func(t *testing.T) {
+		t.Parallel()
+		hc := newCertHTTPClient()
+		subscribeURL := "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&Token=token"
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == subscribeURL
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil)
+		p := newTestSESProvider(hc)
+		m := &snsMessage{
+			Type:         "SubscriptionConfirmation",
+			MessageID:    "id",
+			Token:        "token",
+			Message:      "confirm",
+			SubscribeURL: subscribeURL,
+			Timestamp:    "2020-09-13T12:26:40.000Z",
+		}
+
+		suppressions, err := p.ProcessWebhook(newRequest(m, true))
+		require.NoError(t, err)
+		assert.Nil(t, suppressions)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("suppressions extracted from notification", func(t *testing.T) {
+		t.Parallel()
+		hc := newCertHTTPClient()
+		p := newTestSESProvider(hc)
+		m := &snsMessage{
+			Type:      "Notification",
+			MessageID: "id",
+			Message: `{
+				"notificationType": "Bounce",
+				"bounce": {
+					"bounceType": "Permanent",
+					"bouncedRecipients": [{"emailAddress": "user1@email.com"}]
+				}
+			}`,
+			Timestamp: "2020-09-13T12:26:40.000Z",
+		}
+
+		suppressions, err := p.ProcessWebhook(newRequest(m, true))
+		require.NoError(t, err)
+		assert.Equal(t, []*Suppression{{Email: "user1@email.com", Reason: Bounce}}, suppressions)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestParseSESNotification(t *testing.T) {
+	testCases := []struct {
+		desc                 string
+		notification         string
+		expectedSuppressions []*Suppression
+		expectedErr          error
+	}{
+		{
+			"invalid notification",
+			"{",
+			nil,
+			ErrInvalidWebhookRequest,
+		},
+		{
+			"transient bounce",
+			`{"notificationType": "Bounce", "bounce": {"bounceType": "Transient", "bouncedRecipients": [{"emailAddress": "user1@email.com"}]}}`,
+			nil,
+			nil,
+		},
+		{
+			"complaint",
+			`{"notificationType": "Complaint", "complaint": {"complainedRecipients": [{"emailAddress": "user1@email.com"}]}}`,
+			[]*Suppression{{Email: "user1@email.com", Reason: Complaint}},
+			nil,
+		},
+		{
+			"complaint (event publishing format)",
+			`{"eventType": "Complaint", "complaint": {"complainedRecipients": [{"emailAddress": "user1@email.com"}]}}`,
+			[]*Suppression{{Email: "user1@email.com", Reason: Complaint}},
+			nil,
+		},
+		{
+			"delivery",
+			`{"notificationType": "Delivery"}`,
+			nil,
+			nil,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			suppressions, err := parseSESNotification(tc.notification)
+			assert.True(t, errors.Is(err, tc.expectedErr))
+			assert.Equal(t, tc.expectedSuppressions, suppressions)
+		})
+	}
+}
+
+func newTestSESProvider(hc HTTPClient) *SESProvider {
+	return &SESProvider{
+		hc:       hc,
+		endpoint: "https://email.us-east-1.amazonaws.com",
+		signer: awsauth.NewSignerWithCredentials(
+			credentials.NewStaticCredentialsProvider("AKID", "secret", ""),
+			"us-east-1",
+			sesService,
+		),
+		topicARN: testTopicARN,
+		certs:    make(map[string]*x509.Certificate),
+	}
+}
+
+func generateTestSNSCertificate(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,84 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"net/textproto"
+
+	"github.com/domodwyer/mailyak"
+	"github.com/spf13/viper"
+	"github.com/versine/loginauth"
+)
+
+// SMTPProvider is an email provider that delivers emails using a SMTP server.
+type SMTPProvider struct {
+	addr string
+	auth smtp.Auth
+}
+
+// NewSMTPProvider creates a new SMTPProvider instance.
+func NewSMTPProvider(cfg *viper.Viper) (*SMTPProvider, error) {
+	if !cfg.IsSet("email.smtp.host") || !cfg.IsSet("email.smtp.port") {
+		return nil, errors.New("smtp host and port not provided")
+	}
+
+	host := cfg.GetString("email.smtp.host")
+	p := &SMTPProvider{
+		addr: fmt.Sprintf("%s:%d", host, cfg.GetInt("email.smtp.port")),
+	}
+	username := cfg.GetString("email.smtp.username")
+	password := cfg.GetString("email.smtp.password")
+	if username != "" && password != "" {
+		switch cfg.GetString("email.smtp.auth") {
+		case "login":
+			p.auth = loginauth.New(username, password, host)
+		case "plain":
+			p.auth = smtp.PlainAuth("", username, password, host)
+		default:
+			p.auth = smtp.PlainAuth("", username, password, host)
+		}
+	}
+	return p, nil
+}
+
+// Name implements the Provider interface.
+func (p *SMTPProvider) Name() string {
+	return "smtp"
+}
+
+// Send implements the Provider interface.
+func (p *SMTPProvider) Send(ctx context.Context, m *Message) error {
+	email := mailyak.New(p.addr, p.auth)
+	email.FromName(m.From.Name)
+	email.From(m.From.Address)
+	email.ReplyTo(m.ReplyTo)
+	email.To(m.To)
+	email.Subject(m.Subject)
+	if _, err := email.HTML().Write(m.HTML); err != nil {
+		return err
+	}
+	if err := email.Send(); err != nil {
+		if isSMTPRecipientRejected(err) {
+			return fmt.Errorf("%w: %v", ErrRecipientRejected, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// isSMTPRecipientRejected checks if the error provided is a SMTP error that
+// indicates that the recipient was rejected permanently.
+func isSMTPRecipientRejected(err error) bool {
+	var tpErr *textproto.Error
+	if !errors.As(err, &tpErr) {
+		return false
+	}
+	switch tpErr.Code {
+	case 550, 551, 553:
+		return true
+	default:
+		return false
+	}
+}
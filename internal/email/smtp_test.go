@@ -0,0 +1,32 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSMTPRecipientRejected(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{errors.New("connection refused"), false},
+		{&textproto.Error{Code: 421, Msg: "Service not available"}, false},
+		{&textproto.Error{Code: 452, Msg: "Insufficient system storage"}, false},
+		{&textproto.Error{Code: 550, Msg: "Mailbox unavailable"}, true},
+		{&textproto.Error{Code: 551, Msg: "User not local"}, true},
+		{&textproto.Error{Code: 553, Msg: "Mailbox name not allowed"}, true},
+		{fmt.Errorf("rcpt: %w", &textproto.Error{Code: 550, Msg: "No such user"}), true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, isSMTPRecipientRejected(tc.err))
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package event
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	dropEventsPartitionDBQ          = `select drop_events_partition($1::date)`
+	getEventsArchiveDBQ             = `select get_events_archive($1::date)`
+	getEventsPartitionsToArchiveDBQ = `select get_events_partitions_to_archive($1::int)`
+	lockEventsArchiveDBQ            = `select pg_try_advisory_xact_lock($1::bigint)`
+	maintainEventsPartitionsDBQ     = `select maintain_events_partitions()`
+
+	// ArchiveFrequency represents how often the events partitions should be
+	// maintained and archived.
+	ArchiveFrequency = 6 * time.Hour
+
+	// archivesPrefix represents the prefix of the keys of the objects used to
+	// store the events archives.
+	archivesPrefix = "events/"
+)
+
+// Bucket describes the methods an object storage bucket implementation must
+// provide to store the events archives.
+type Bucket interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+}
+
+// Archiver makes sure the partitions needed to store upcoming events and
+// notifications are available, and removes the ones older than the configured
+// retention period. When a bucket is provided, the events and notifications
+// removed are exported to it first. It is expected to be run periodically as
+// a background job.
+type Archiver struct {
+	db            hub.DB
+	bucket        Bucket
+	retentionDays int
+}
+
+// NewArchiver creates a new Archiver instance. The bucket is optional.
+func NewArchiver(cfg *viper.Viper, db hub.DB, bucket Bucket) *Archiver {
+	return &Archiver{
+		db:            db,
+		bucket:        bucket,
+		retentionDays: cfg.GetInt("events.archive.retentionDays"),
+	}
+}
+
+// Archive maintains the events partitions and archives the ones that only
+// contain events older than the retention period.
+func (a *Archiver) Archive(ctx context.Context) error {
+	// Make sure partitions for upcoming events are available
+	if _, err := a.db.Exec(ctx, maintainEventsPartitionsDBQ); err != nil {
+		return fmt.Errorf("error maintaining events partitions: %w", err)
+	}
+	if a.retentionDays <= 0 {
+		return nil
+	}
+
+	// Archive partitions with events older than the retention period
+	var partitions []string
+	err := util.DBQueryUnmarshal(ctx, a.db, &partitions, getEventsPartitionsToArchiveDBQ, a.retentionDays)
+	if err != nil {
+		return fmt.Errorf("error getting events partitions to archive: %w", err)
+	}
+	for _, partition := range partitions {
+		if err := a.archivePartition(ctx, partition); err != nil {
+			return fmt.Errorf("error archiving events partition %s: %w", partition, err)
+		}
+		log.Info().Str("partition", partition).Msg("events partition archived")
+	}
+	return nil
+}
+
+// archivePartition exports the events and notifications of the partition
+// provided to the bucket, when available, and drops it.
+func (a *Archiver) archivePartition(ctx context.Context, partition string) error {
+	return util.DBTransact(ctx, a.db, func(tx pgx.Tx) error {
+		// Make sure only one instance archives events at the same time
+		var locked bool
+		if err := tx.QueryRow(ctx, lockEventsArchiveDBQ, util.DBLockKeyArchiveEvents).Scan(&locked); err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		// Export partition data when a bucket is available (the partition may
+		// have been archived already by other instance, in which case there
+		// is nothing else to do)
+		if a.bucket != nil {
+			var dataJSON []byte
+			if err := tx.QueryRow(ctx, getEventsArchiveDBQ, partition).Scan(&dataJSON); err != nil {
+				return err
+			}
+			if dataJSON == nil {
+				return nil
+			}
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf)
+			if _, err := zw.Write(dataJSON); err != nil {
+				return err
+			}
+			if err := zw.Close(); err != nil {
+				return err
+			}
+			key := archivesPrefix + partition + ".json.gz"
+			if err := a.bucket.Put(ctx, key, buf.Bytes(), "application/gzip"); err != nil {
+				return fmt.Errorf("error exporting events: %w", err)
+			}
+		}
+
+		// Drop partition
+		_, err := tx.Exec(ctx, dropEventsPartitionDBQ, partition)
+		return err
+	})
+}
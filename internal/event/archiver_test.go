@@ -0,0 +1,172 @@
+package event
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiver(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("events.archive.retentionDays", 90)
+	partition := "2020-01-01"
+	archiveJSON := []byte(`{"events": [], "notifications": []}`)
+
+	t.Run("error maintaining events partitions", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, maintainEventsPartitionsDBQ).Return(tests.ErrFakeDB)
+
+		a := NewArchiver(cfg, db, nil)
+		err := a.Archive(ctx)
+		assert.True(t, errors.Is(err, tests.ErrFakeDB))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("retention not configured, partitions are only maintained", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, maintainEventsPartitionsDBQ).Return(nil)
+
+		a := NewArchiver(viper.New(), db, nil)
+		err := a.Archive(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error getting partitions to archive", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, maintainEventsPartitionsDBQ).Return(nil)
+		db.On("QueryRow", ctx, getEventsPartitionsToArchiveDBQ, 90).Return(nil, tests.ErrFakeDB)
+
+		a := NewArchiver(cfg, db, nil)
+		err := a.Archive(ctx)
+		assert.True(t, errors.Is(err, tests.ErrFakeDB))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("partition dropped without exporting it", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		db.On("Exec", ctx, maintainEventsPartitionsDBQ).Return(nil)
+		db.On("QueryRow", ctx, getEventsPartitionsToArchiveDBQ, 90).Return([]byte(`["2020-01-01"]`), nil)
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, lockEventsArchiveDBQ, util.DBLockKeyArchiveEvents).Return(true, nil)
+		tx.On("Exec", ctx, dropEventsPartitionDBQ, partition).Return(nil)
+		tx.On("Commit", ctx).Return(nil)
+
+		a := NewArchiver(cfg, db, nil)
+		err := a.Archive(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("partition exported and dropped", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		b := &BucketMock{}
+		db.On("Exec", ctx, maintainEventsPartitionsDBQ).Return(nil)
+		db.On("QueryRow", ctx, getEventsPartitionsToArchiveDBQ, 90).Return([]byte(`["2020-01-01"]`), nil)
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, lockEventsArchiveDBQ, util.DBLockKeyArchiveEvents).Return(true, nil)
+		tx.On("QueryRow", ctx, getEventsArchiveDBQ, partition).Return(archiveJSON, nil)
+		b.On("Put", ctx, "events/2020-01-01.json.gz", mock.Anything, "application/gzip").
+			Run(func(args mock.Arguments) {
+				zr, err := gzip.NewReader(bytes.NewReader(args.Get(2).([]byte)))
+				require.NoError(t, err)
+				data, err := ioutil.ReadAll(zr)
+				require.NoError(t, err)
+				assert.Equal(t, archiveJSON, data)
+			}).
+			Return(nil)
+		tx.On("Exec", ctx, dropEventsPartitionDBQ, partition).Return(nil)
+		tx.On("Commit", ctx).Return(nil)
+
+		a := NewArchiver(cfg, db, b)
+		err := a.Archive(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("error exporting partition, it is not dropped", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		b := &BucketMock{}
+		db.On("Exec", ctx, maintainEventsPartitionsDBQ).Return(nil)
+		db.On("QueryRow", ctx, getEventsPartitionsToArchiveDBQ, 90).Return([]byte(`["2020-01-01"]`), nil)
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, lockEventsArchiveDBQ, util.DBLockKeyArchiveEvents).Return(true, nil)
+		tx.On("QueryRow", ctx, getEventsArchiveDBQ, partition).Return(archiveJSON, nil)
+		b.On("Put", ctx, "events/2020-01-01.json.gz", mock.Anything, "application/gzip").Return(tests.ErrFake)
+		tx.On("Rollback", ctx).Return(nil)
+
+		a := NewArchiver(cfg, db, b)
+		err := a.Archive(ctx)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("partition already archived by other instance", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		b := &BucketMock{}
+		db.On("Exec", ctx, maintainEventsPartitionsDBQ).Return(nil)
+		db.On("QueryRow", ctx, getEventsPartitionsToArchiveDBQ, 90).Return([]byte(`["2020-01-01"]`), nil)
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, lockEventsArchiveDBQ, util.DBLockKeyArchiveEvents).Return(true, nil)
+		tx.On("QueryRow", ctx, getEventsArchiveDBQ, partition).Return(nil, nil)
+		tx.On("Commit", ctx).Return(nil)
+
+		a := NewArchiver(cfg, db, b)
+		err := a.Archive(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("lock held by other instance", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		tx := &tests.TXMock{}
+		db.On("Exec", ctx, maintainEventsPartitionsDBQ).Return(nil)
+		db.On("QueryRow", ctx, getEventsPartitionsToArchiveDBQ, 90).Return([]byte(`["2020-01-01"]`), nil)
+		db.On("Begin", ctx).Return(tx, nil)
+		tx.On("QueryRow", ctx, lockEventsArchiveDBQ, util.DBLockKeyArchiveEvents).Return(false, nil)
+		tx.On("Commit", ctx).Return(nil)
+
+		a := NewArchiver(cfg, db, nil)
+		err := a.Archive(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+}
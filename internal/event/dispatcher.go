@@ -18,6 +18,7 @@ type Services struct {
 	EventManager        hub.EventManager
 	SubscriptionManager hub.SubscriptionManager
 	WebhookManager      hub.WebhookManager
+	IssueTrackerManager hub.IssueTrackerManager
 	NotificationManager hub.NotificationManager
 }
 
@@ -25,6 +26,8 @@ type Services struct {
 // happen in the Hub.
 type Dispatcher struct {
 	numWorkers int
+	heartbeat  func()
+	mm         hub.MaintenanceManager
 	workers    []*Worker
 }
 
@@ -38,7 +41,10 @@ func NewDispatcher(svc *Services, opts ...func(d *Dispatcher)) *Dispatcher {
 	}
 	d.workers = make([]*Worker, 0, d.numWorkers)
 	for i := 0; i < d.numWorkers; i++ {
-		d.workers = append(d.workers, NewWorker(svc))
+		w := NewWorker(svc)
+		w.heartbeat = d.heartbeat
+		w.mm = d.mm
+		d.workers = append(d.workers, w)
 	}
 	return d
 }
@@ -51,6 +57,22 @@ func WithNumWorkers(n int) func(d *Dispatcher) {
 	}
 }
 
+// WithHeartbeat allows providing a function that will be called periodically
+// by the dispatcher workers, so that their liveness can be tracked.
+func WithHeartbeat(beat func()) func(d *Dispatcher) {
+	return func(d *Dispatcher) {
+		d.heartbeat = beat
+	}
+}
+
+// WithMaintenanceManager allows providing a maintenance manager, used by the
+// dispatcher workers to pause while the maintenance mode is enabled.
+func WithMaintenanceManager(mm hub.MaintenanceManager) func(d *Dispatcher) {
+	return func(d *Dispatcher) {
+		d.mm = mm
+	}
+}
+
 // Run starts the workers and lets them run until the dispatcher is asked to
 // stop via the context provided.
 func (d *Dispatcher) Run(ctx context.Context, wg *sync.WaitGroup) {
@@ -28,3 +28,12 @@ func TestDispatcher(t *testing.T) {
 		return true
 	}, 2*time.Second, 100*time.Millisecond)
 }
+
+func TestDispatcherHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher(&Services{}, WithNumWorkers(2), WithHeartbeat(func() {}))
+	for _, w := range d.workers {
+		assert.NotNil(t, w.heartbeat)
+	}
+}
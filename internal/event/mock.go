@@ -19,3 +19,14 @@ func (m *ManagerMock) GetPending(ctx context.Context, tx pgx.Tx) (*hub.Event, er
 	data, _ := args.Get(0).(*hub.Event)
 	return data, args.Error(1)
 }
+
+// BucketMock is a mock implementation of the Bucket interface.
+type BucketMock struct {
+	mock.Mock
+}
+
+// Put implements the Bucket interface.
+func (m *BucketMock) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	args := m.Called(ctx, key, data, contentType)
+	return args.Error(0)
+}
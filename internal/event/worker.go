@@ -7,19 +7,23 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/jackc/pgx/v4"
 	"github.com/rs/zerolog/log"
 )
 
 const (
-	pauseOnEmptyQueue = 30 * time.Second
-	pauseOnError      = 10 * time.Second
+	pauseOnEmptyQueue  = 30 * time.Second
+	pauseOnError       = 10 * time.Second
+	pauseOnMaintenance = 30 * time.Second
 )
 
 // Worker is in charge of handling events that happen in the Hub.
 type Worker struct {
-	svc *Services
+	svc       *Services
+	heartbeat func()
+	mm        hub.MaintenanceManager
 }
 
 // NewWorker creates a new Worker instance.
@@ -35,6 +39,17 @@ func (w *Worker) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for {
+		if w.heartbeat != nil {
+			w.heartbeat()
+		}
+		if w.mm != nil && maintenance.IsEnabled(ctx, w.mm) {
+			select {
+			case <-time.After(pauseOnMaintenance):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
 		err := w.processEvent(ctx)
 		switch {
 		case err == nil:
@@ -105,6 +120,23 @@ func (w *Worker) processEvent(ctx context.Context) error {
 				return err
 			}
 		}
+		// Issue trackers notifications
+		issueTrackers, err := w.svc.IssueTrackerManager.GetSubscribedTo(ctx, e)
+		if err != nil {
+			log.Error().Err(err).Msg("error getting issue trackers")
+			return err
+		}
+		for _, it := range issueTrackers {
+			n := &hub.Notification{
+				Event:        e,
+				IssueTracker: it,
+			}
+			err := w.svc.NotificationManager.Add(ctx, tx, n)
+			if err != nil {
+				log.Error().Err(err).Msg("error adding notification")
+				return err
+			}
+		}
 
 		return nil
 	})
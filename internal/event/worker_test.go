@@ -7,11 +7,14 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/issuetracker"
+	"github.com/artifacthub/hub/internal/maintenance"
 	"github.com/artifacthub/hub/internal/notification"
 	"github.com/artifacthub/hub/internal/subscription"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/webhook"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestWorker(t *testing.T) {
@@ -32,6 +35,27 @@ func TestWorker(t *testing.T) {
 	wh2 := &hub.Webhook{
 		WebhookID: "webhook2ID",
 	}
+	it1 := &hub.IssueTracker{
+		IssueTrackerID: "issueTracker1ID",
+	}
+
+	t.Run("maintenance mode enabled, events are not processed", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		mm := &maintenance.ManagerMock{}
+		checked := make(chan struct{})
+		mm.On("Get", sw.ctx).
+			Return(&hub.MaintenanceMode{Enabled: true}, nil).
+			Run(func(args mock.Arguments) { close(checked) }).
+			Once()
+
+		w := NewWorker(sw.svc)
+		w.mm = mm
+		go w.Run(sw.ctx, sw.wg)
+		<-checked
+		sw.assertExpectations(t)
+		mm.AssertExpectations(t)
+	})
 
 	t.Run("error getting pending event", func(t *testing.T) {
 		t.Parallel()
@@ -65,6 +89,7 @@ func TestWorker(t *testing.T) {
 		sw.em.On("GetPending", sw.ctx, sw.tx).Return(e, nil)
 		sw.sm.On("GetSubscriptors", sw.ctx, e).Return([]*hub.User{}, nil)
 		sw.wm.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.Webhook{}, nil)
+		sw.im.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.IssueTracker{}, nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc)
@@ -94,6 +119,7 @@ func TestWorker(t *testing.T) {
 		sw.sm.On("GetSubscriptors", sw.ctx, e).Return([]*hub.User{u1}, nil)
 		sw.nm.On("Add", sw.ctx, sw.tx, &hub.Notification{Event: e, User: u1}).Return(nil)
 		sw.wm.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.Webhook{}, nil)
+		sw.im.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.IssueTracker{}, nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc)
@@ -110,6 +136,7 @@ func TestWorker(t *testing.T) {
 		sw.nm.On("Add", sw.ctx, sw.tx, &hub.Notification{Event: e, User: u1}).Return(nil)
 		sw.nm.On("Add", sw.ctx, sw.tx, &hub.Notification{Event: e, User: u2}).Return(nil)
 		sw.wm.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.Webhook{}, nil)
+		sw.im.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.IssueTracker{}, nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc)
@@ -140,6 +167,7 @@ func TestWorker(t *testing.T) {
 		sw.sm.On("GetSubscriptors", sw.ctx, e).Return([]*hub.User{}, nil)
 		sw.wm.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.Webhook{wh1}, nil)
 		sw.nm.On("Add", sw.ctx, sw.tx, &hub.Notification{Event: e, Webhook: wh1}).Return(nil)
+		sw.im.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.IssueTracker{}, nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc)
@@ -156,6 +184,54 @@ func TestWorker(t *testing.T) {
 		sw.wm.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.Webhook{wh1, wh2}, nil)
 		sw.nm.On("Add", sw.ctx, sw.tx, &hub.Notification{Event: e, Webhook: wh1}).Return(nil)
 		sw.nm.On("Add", sw.ctx, sw.tx, &hub.Notification{Event: e, Webhook: wh2}).Return(nil)
+		sw.im.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.IssueTracker{}, nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error getting issue trackers", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.em.On("GetPending", sw.ctx, sw.tx).Return(e, nil)
+		sw.sm.On("GetSubscriptors", sw.ctx, e).Return([]*hub.User{}, nil)
+		sw.wm.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.Webhook{}, nil)
+		sw.im.On("GetSubscribedTo", sw.ctx, e).Return(nil, tests.ErrFake)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error adding issue tracker notification", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.em.On("GetPending", sw.ctx, sw.tx).Return(e, nil)
+		sw.sm.On("GetSubscriptors", sw.ctx, e).Return([]*hub.User{}, nil)
+		sw.wm.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.Webhook{}, nil)
+		sw.im.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.IssueTracker{it1}, nil)
+		sw.nm.On("Add", sw.ctx, sw.tx, &hub.Notification{Event: e, IssueTracker: it1}).Return(tests.ErrFake)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("adding one issue tracker notification succeeded", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.em.On("GetPending", sw.ctx, sw.tx).Return(e, nil)
+		sw.sm.On("GetSubscriptors", sw.ctx, e).Return([]*hub.User{}, nil)
+		sw.wm.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.Webhook{}, nil)
+		sw.im.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.IssueTracker{it1}, nil)
+		sw.nm.On("Add", sw.ctx, sw.tx, &hub.Notification{Event: e, IssueTracker: it1}).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc)
@@ -173,6 +249,7 @@ type servicesWrapper struct {
 	em         *ManagerMock
 	sm         *subscription.ManagerMock
 	wm         *webhook.ManagerMock
+	im         *issuetracker.ManagerMock
 	nm         *notification.ManagerMock
 	svc        *Services
 }
@@ -188,6 +265,7 @@ func newServicesWrapper() *servicesWrapper {
 	em := &ManagerMock{}
 	sm := &subscription.ManagerMock{}
 	wm := &webhook.ManagerMock{}
+	im := &issuetracker.ManagerMock{}
 	nm := &notification.ManagerMock{}
 
 	return &servicesWrapper{
@@ -199,12 +277,14 @@ func newServicesWrapper() *servicesWrapper {
 		em:         em,
 		sm:         sm,
 		wm:         wm,
+		im:         im,
 		nm:         nm,
 		svc: &Services{
 			DB:                  db,
 			EventManager:        em,
 			SubscriptionManager: sm,
 			WebhookManager:      wm,
+			IssueTrackerManager: im,
 			NotificationManager: nm,
 		},
 	}
@@ -222,5 +302,6 @@ func (sw *servicesWrapper) assertExpectations(t *testing.T) {
 	sw.em.AssertExpectations(t)
 	sw.sm.AssertExpectations(t)
 	sw.wm.AssertExpectations(t)
+	sw.im.AssertExpectations(t)
 	sw.nm.AssertExpectations(t)
 }
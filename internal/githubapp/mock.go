@@ -0,0 +1,32 @@
+package githubapp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// WebhooksProcessorMock is a mock implementation of the hub
+// GitHubAppWebhooksProcessor interface.
+type WebhooksProcessorMock struct {
+	mock.Mock
+}
+
+// GetInstallURL implements the GitHubAppWebhooksProcessor interface.
+func (m *WebhooksProcessorMock) GetInstallURL(ctx context.Context, orgName string) (string, error) {
+	args := m.Called(ctx, orgName)
+	return args.String(0), args.Error(1)
+}
+
+// LinkInstallation implements the GitHubAppWebhooksProcessor interface.
+func (m *WebhooksProcessorMock) LinkInstallation(ctx context.Context, installationID int64, state string) error {
+	args := m.Called(ctx, installationID, state)
+	return args.Error(0)
+}
+
+// ProcessWebhook implements the GitHubAppWebhooksProcessor interface.
+func (m *WebhooksProcessorMock) ProcessWebhook(ctx context.Context, r *http.Request) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
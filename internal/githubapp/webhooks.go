@@ -0,0 +1,250 @@
+package githubapp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	linkInstallationDBQ       = `select link_github_app_installation($1::bigint, $2::uuid, nullif($3::text, ''))`
+	processPushDBQ            = `select process_github_app_push($1::bigint, $2::text)`
+	registerInstallationDBQ   = `select register_github_app_installation($1::bigint, $2::text)`
+	unregisterInstallationDBQ = `select unregister_github_app_installation($1::bigint)`
+
+	// Webhooks headers
+	eventHeader     = "X-GitHub-Event"
+	signatureHeader = "X-Hub-Signature-256"
+	signaturePrefix = "sha256="
+
+	// webhookMaxBodySize represents the maximum size of the webhooks payloads
+	// that will be processed.
+	webhookMaxBodySize = 5 << 20 // 5MB
+
+	// installURLFormat represents the format of the url used to install the
+	// GitHub App.
+	installURLFormat = "https://github.com/apps/%s/installations/new?state=%s"
+
+	// setupStateTTL represents how long the state provided when installing
+	// the app remains valid.
+	setupStateTTL = 1 * time.Hour
+
+	// setupStateSigPrefix is prepended to the setup state before signing it,
+	// so that its signature cannot be mistaken for a webhook payload one.
+	setupStateSigPrefix = "setup-state:"
+)
+
+var (
+	// ErrInvalidWebhookRequest indicates that the webhook request received is
+	// not valid.
+	ErrInvalidWebhookRequest = errors.New("invalid webhook request")
+
+	// ErrWebhookUnauthorized indicates that the authenticity of the webhook
+	// request received could not be verified.
+	ErrWebhookUnauthorized = errors.New("webhook request unauthorized")
+
+	// errDBInstallationNotLinkable indicates that the installation could not
+	// be linked because it does not exist or it has already been linked.
+	errDBInstallationNotLinkable = errors.New("ERROR: installation not found or already linked (SQLSTATE P0001)")
+)
+
+// WebhooksProcessor is in charge of processing the webhooks sent by GitHub
+// on behalf of the Artifact Hub GitHub App installations, as well as linking
+// those installations to the users or organizations that own them.
+type WebhooksProcessor struct {
+	db     hub.DB
+	secret string
+	slug   string
+}
+
+// NewWebhooksProcessor creates a new WebhooksProcessor instance.
+func NewWebhooksProcessor(cfg *viper.Viper, db hub.DB) *WebhooksProcessor {
+	return &WebhooksProcessor{
+		db:     db,
+		secret: cfg.GetString("githubApp.webhookSecret"),
+		slug:   cfg.GetString("githubApp.slug"),
+	}
+}
+
+// GetInstallURL returns the url used to install the GitHub App on behalf of
+// the user doing the request or the organization provided. The url includes
+// a signed state that GitHub will send back to the setup url once the app has
+// been installed, allowing the installation to be linked to its owner.
+func (p *WebhooksProcessor) GetInstallURL(ctx context.Context, orgName string) (string, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	if p.slug == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrUnavailable, "github app slug not configured")
+	}
+	state := p.signSetupState(&setupState{
+		UserID:    userID,
+		OrgName:   orgName,
+		ExpiresAt: time.Now().Add(setupStateTTL).Unix(),
+	})
+	return fmt.Sprintf(installURLFormat, url.PathEscape(p.slug), url.QueryEscape(state)), nil
+}
+
+// LinkInstallation links the GitHub App installation provided to the owner
+// included in the setup state given. The state must have been issued to the
+// user doing the request and must not have expired. Only the repositories
+// owned by the owner the installation is linked to will be verified using it.
+func (p *WebhooksProcessor) LinkInstallation(ctx context.Context, installationID int64, state string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if installationID <= 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid installation id")
+	}
+	s, err := p.verifySetupState(state)
+	if err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if s.UserID != userID {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "state issued to a different user")
+	}
+
+	// Link installation in the database
+	_, err = p.db.Exec(ctx, linkInstallationDBQ, installationID, userID, s.OrgName)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errDBInstallationNotLinkable.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "installation not found or already linked")
+		}
+	}
+	return err
+}
+
+// ProcessWebhook processes the webhook request provided. Installations of the
+// app are registered (or unregistered when the app is uninstalled), and push
+// events verify and request the tracking of the repositories located in the
+// GitHub repository pushed. Other events are ignored.
+func (p *WebhooksProcessor) ProcessWebhook(ctx context.Context, r *http.Request) error {
+	// Read payload and verify request signature
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, webhookMaxBodySize))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhookRequest, err)
+	}
+	if !p.validSignature(body, r.Header.Get(signatureHeader)) {
+		return ErrWebhookUnauthorized
+	}
+
+	// Parse payload
+	var payload struct {
+		Action       string `json:"action"`
+		Installation struct {
+			ID      int64 `json:"id"`
+			Account struct {
+				Login string `json:"login"`
+			} `json:"account"`
+		} `json:"installation"`
+		Repository struct {
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhookRequest, err)
+	}
+	installationID := payload.Installation.ID
+
+	// Process event
+	switch r.Header.Get(eventHeader) {
+	case "installation":
+		if installationID == 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidWebhookRequest, "installation not provided")
+		}
+		switch payload.Action {
+		case "created":
+			account := payload.Installation.Account.Login
+			if account == "" {
+				return fmt.Errorf("%w: %s", ErrInvalidWebhookRequest, "installation account not provided")
+			}
+			_, err = p.db.Exec(ctx, registerInstallationDBQ, installationID, account)
+		case "deleted":
+			_, err = p.db.Exec(ctx, unregisterInstallationDBQ, installationID)
+		}
+	case "push":
+		if installationID == 0 || payload.Repository.HTMLURL == "" {
+			return fmt.Errorf("%w: %s", ErrInvalidWebhookRequest, "installation or repository not provided")
+		}
+		_, err = p.db.Exec(ctx, processPushDBQ, installationID, payload.Repository.HTMLURL)
+	}
+	return err
+}
+
+// setupState represents the state provided when installing the GitHub App,
+// used to link the installation to its owner once GitHub redirects the user
+// to the setup url.
+type setupState struct {
+	UserID    string `json:"user_id"`
+	OrgName   string `json:"org_name,omitempty"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// signSetupState returns the signed representation of the setup state
+// provided.
+func (p *WebhooksProcessor) signSetupState(s *setupState) string {
+	dataJSON, _ := json.Marshal(s)
+	data := base64.RawURLEncoding.EncodeToString(dataJSON)
+	return data + "." + base64.RawURLEncoding.EncodeToString(p.setupStateSig(data))
+}
+
+// verifySetupState verifies the signature and expiration of the setup state
+// provided, returning it when valid.
+func (p *WebhooksProcessor) verifySetupState(state string) (*setupState, error) {
+	parts := strings.Split(state, ".")
+	if len(parts) != 2 {
+		return nil, errors.New("invalid state")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(sig, p.setupStateSig(parts[0])) {
+		return nil, errors.New("invalid state signature")
+	}
+	dataJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.New("invalid state")
+	}
+	var s *setupState
+	if err := json.Unmarshal(dataJSON, &s); err != nil || s == nil || s.UserID == "" {
+		return nil, errors.New("invalid state")
+	}
+	if time.Now().Unix() > s.ExpiresAt {
+		return nil, errors.New("state expired")
+	}
+	return s, nil
+}
+
+// setupStateSig returns the signature of the setup state data provided.
+func (p *WebhooksProcessor) setupStateSig(data string) []byte {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write([]byte(setupStateSigPrefix + data))
+	return mac.Sum(nil)
+}
+
+// validSignature checks if the signature provided matches the one expected
+// for the payload received.
+func (p *WebhooksProcessor) validSignature(payload []byte, signature string) bool {
+	if p.secret == "" || !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(payload)
+	expectedSig := signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expectedSig), []byte(signature))
+}
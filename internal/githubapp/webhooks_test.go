@@ -0,0 +1,327 @@
+package githubapp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+func TestGetInstallURL(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("github app slug not configured", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("githubApp.webhookSecret", secret)
+		p := NewWebhooksProcessor(cfg, nil)
+
+		_, err := p.GetInstallURL(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrUnavailable))
+	})
+
+	t.Run("install url returned successfully", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("githubApp.webhookSecret", secret)
+		cfg.Set("githubApp.slug", "artifact-hub")
+		p := NewWebhooksProcessor(cfg, nil)
+
+		installURL, err := p.GetInstallURL(ctx, "org1")
+		require.NoError(t, err)
+		u, err := url.Parse(installURL)
+		require.NoError(t, err)
+		assert.Equal(t, "/apps/artifact-hub/installations/new", u.Path)
+		s, err := p.verifySetupState(u.Query().Get("state"))
+		require.NoError(t, err)
+		assert.Equal(t, "userID", s.UserID)
+		assert.Equal(t, "org1", s.OrgName)
+	})
+}
+
+func TestLinkInstallation(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	cfg := viper.New()
+	cfg.Set("githubApp.webhookSecret", secret)
+	p := NewWebhooksProcessor(cfg, nil)
+	validState := p.signSetupState(&setupState{
+		UserID:    "userID",
+		OrgName:   "org1",
+		ExpiresAt: time.Now().Add(setupStateTTL).Unix(),
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		otherKeyCfg := viper.New()
+		otherKeyCfg.Set("githubApp.webhookSecret", "other")
+		testCases := []struct {
+			installationID int64
+			state          string
+			errMsg         string
+		}{
+			{
+				0,
+				validState,
+				"invalid installation id",
+			},
+			{
+				1,
+				"",
+				"invalid state",
+			},
+			{
+				1,
+				validState + "x",
+				"invalid state signature",
+			},
+			{
+				1,
+				NewWebhooksProcessor(otherKeyCfg, nil).signSetupState(&setupState{
+					UserID:    "userID",
+					ExpiresAt: time.Now().Add(setupStateTTL).Unix(),
+				}),
+				"invalid state signature",
+			},
+			{
+				1,
+				p.signSetupState(&setupState{
+					UserID:    "userID",
+					ExpiresAt: time.Now().Add(-1 * time.Minute).Unix(),
+				}),
+				"state expired",
+			},
+			{
+				1,
+				p.signSetupState(&setupState{
+					UserID:    "otherUserID",
+					ExpiresAt: time.Now().Add(setupStateTTL).Unix(),
+				}),
+				"state issued to a different user",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				p := NewWebhooksProcessor(cfg, db)
+
+				err := p.LinkInstallation(ctx, tc.installationID, tc.state)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errDBInstallationNotLinkable,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, linkInstallationDBQ, int64(1), "userID", "org1").Return(tc.dbErr)
+				p := NewWebhooksProcessor(cfg, db)
+
+				err := p.LinkInstallation(ctx, 1, validState)
+				assert.True(t, errors.Is(err, tc.expectedError))
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("installation linked successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, linkInstallationDBQ, int64(1), "userID", "org1").Return(nil)
+		p := NewWebhooksProcessor(cfg, db)
+
+		err := p.LinkInstallation(ctx, 1, validState)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestProcessWebhook(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	cfg.Set("githubApp.webhookSecret", secret)
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+		testCases := []string{
+			"",
+			"invalid",
+			"sha256=invalid",
+		}
+		for _, signature := range testCases {
+			r := newWebhookRequest("push", `{}`)
+			r.Header.Set(signatureHeader, signature)
+			db := &tests.DBMock{}
+			p := NewWebhooksProcessor(cfg, db)
+
+			err := p.ProcessWebhook(ctx, r)
+			assert.True(t, errors.Is(err, ErrWebhookUnauthorized))
+			db.AssertExpectations(t)
+		}
+	})
+
+	t.Run("webhook secret not configured", func(t *testing.T) {
+		t.Parallel()
+		r := newWebhookRequest("push", `{}`)
+		db := &tests.DBMock{}
+		p := NewWebhooksProcessor(viper.New(), db)
+
+		err := p.ProcessWebhook(ctx, r)
+		assert.True(t, errors.Is(err, ErrWebhookUnauthorized))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("payload too large", func(t *testing.T) {
+		t.Parallel()
+		r := newWebhookRequest("push", `{"padding": "`+strings.Repeat("a", webhookMaxBodySize)+`"}`)
+		db := &tests.DBMock{}
+		p := NewWebhooksProcessor(cfg, db)
+
+		err := p.ProcessWebhook(ctx, r)
+		assert.True(t, errors.Is(err, ErrInvalidWebhookRequest))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			event   string
+			payload string
+		}{
+			{"push", `{`},
+			{"push", `{"installation": {"id": 1}}`},
+			{"push", `{"repository": {"html_url": "https://github.com/org1/repo1"}}`},
+			{"installation", `{"action": "created"}`},
+			{"installation", `{"action": "created", "installation": {"id": 1}}`},
+		}
+		for _, tc := range testCases {
+			r := newWebhookRequest(tc.event, tc.payload)
+			db := &tests.DBMock{}
+			p := NewWebhooksProcessor(cfg, db)
+
+			err := p.ProcessWebhook(ctx, r)
+			assert.True(t, errors.Is(err, ErrInvalidWebhookRequest))
+			db.AssertExpectations(t)
+		}
+	})
+
+	t.Run("installation created", func(t *testing.T) {
+		t.Parallel()
+		r := newWebhookRequest("installation", `{
+			"action": "created",
+			"installation": {"id": 1, "account": {"login": "org1"}}
+		}`)
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerInstallationDBQ, int64(1), "org1").Return(nil)
+		p := NewWebhooksProcessor(cfg, db)
+
+		err := p.ProcessWebhook(ctx, r)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("installation deleted", func(t *testing.T) {
+		t.Parallel()
+		r := newWebhookRequest("installation", `{
+			"action": "deleted",
+			"installation": {"id": 1, "account": {"login": "org1"}}
+		}`)
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unregisterInstallationDBQ, int64(1)).Return(nil)
+		p := NewWebhooksProcessor(cfg, db)
+
+		err := p.ProcessWebhook(ctx, r)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		testCases := []struct {
+			dbErr       error
+			expectedErr error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				nil,
+				nil,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run("", func(t *testing.T) {
+				t.Parallel()
+				r := newWebhookRequest("push", `{
+					"installation": {"id": 1},
+					"repository": {"html_url": "https://github.com/org1/repo1"}
+				}`)
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, processPushDBQ, int64(1), "https://github.com/org1/repo1").Return(tc.dbErr)
+				p := NewWebhooksProcessor(cfg, db)
+
+				err := p.ProcessWebhook(ctx, r)
+				assert.Equal(t, tc.expectedErr, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("other events are ignored", func(t *testing.T) {
+		t.Parallel()
+		r := newWebhookRequest("ping", `{"installation": {"id": 1}}`)
+		db := &tests.DBMock{}
+		p := NewWebhooksProcessor(cfg, db)
+
+		err := p.ProcessWebhook(ctx, r)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func newWebhookRequest(event, payload string) *http.Request {
+	r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+	r.Header.Set(eventHeader, event)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	r.Header.Set(signatureHeader, signaturePrefix+hex.EncodeToString(mac.Sum(nil)))
+	return r
+}
@@ -89,6 +89,18 @@ func (h *Handlers) GetOwnedByUser(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
 
+// GetUsage is an http handler that returns the usage of the requested api key.
+func (h *Handlers) GetUsage(w http.ResponseWriter, r *http.Request) {
+	apiKeyID := chi.URLParam(r, "apiKeyID")
+	dataJSON, err := h.apiKeyManager.GetUsageJSON(r.Context(), apiKeyID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetUsage").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // Update is an http handler that updates the provided api key in the database.
 func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 	ak := &hub.APIKey{}
@@ -0,0 +1,87 @@
+package blocklist
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling the
+// blocklist operations.
+type Handlers struct {
+	blocklistManager hub.BlocklistManager
+	logger           zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(blocklistManager hub.BlocklistManager) *Handlers {
+	return &Handlers{
+		blocklistManager: blocklistManager,
+		logger:           log.With().Str("handlers", "blocklist").Logger(),
+	}
+}
+
+// Block is an http handler used by site admins to block a repository or
+// package.
+func (h *Handlers) Block(w http.ResponseWriter, r *http.Request) {
+	bc := &hub.BlockedContent{}
+	if err := json.NewDecoder(r.Body).Decode(&bc); err != nil {
+		h.logger.Error().Err(err).Str("method", "Block").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	info := &hub.AdminAuditInfo{
+		IP:        helpers.GetClientIP(r),
+		UserAgent: r.UserAgent(),
+	}
+	blockedContentID, err := h.blocklistManager.Block(r.Context(), bc, info)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Block").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	h.logger.Info().
+		Str("repository", bc.RepositoryName).
+		Str("package", bc.PackageName).
+		Msg("content blocked by admin")
+	dataJSON, _ := json.Marshal(map[string]string{
+		"blocked_content_id": blockedContentID,
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// GetAll is an http handler that returns all the repositories and packages
+// blocked.
+func (h *Handlers) GetAll(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.blocklistManager.GetJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetAll").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Unblock is an http handler used by site admins to unblock a repository or
+// package.
+func (h *Handlers) Unblock(w http.ResponseWriter, r *http.Request) {
+	blockedContentID := chi.URLParam(r, "blockedContentID")
+	info, err := helpers.GetAdminAuditInfo(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Unblock").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.blocklistManager.Unblock(r.Context(), blockedContentID, info); err != nil {
+		h.logger.Error().Err(err).Str("method", "Unblock").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	h.logger.Info().Str("blockedContentID", blockedContentID).Msg("content unblocked by admin")
+	w.WriteHeader(http.StatusNoContent)
+}
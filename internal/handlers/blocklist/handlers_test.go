@@ -0,0 +1,225 @@
+package blocklist
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/blocklist"
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+const blockedContentID = "00000000-0000-0000-0000-000000000001"
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestBlock(t *testing.T) {
+	bcJSON := `{"repository_name": "repo1", "package_name": "pkg1", "reason": "spam"}`
+	bc := &hub.BlockedContent{
+		RepositoryName: "repo1",
+		PackageName:    "pkg1",
+		Reason:         "spam",
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+
+		hw := newHandlersWrapper()
+		hw.h.Block(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.bm.AssertExpectations(t)
+	})
+
+	t.Run("error blocking content", func(t *testing.T) {
+		testCases := []struct {
+			bmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.bmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(bcJSON))
+
+				hw := newHandlersWrapper()
+				hw.bm.On("Block", r.Context(), bc, &hub.AdminAuditInfo{}).Return("", tc.bmErr)
+				hw.h.Block(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.bm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("content blocked successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(bcJSON))
+
+		hw := newHandlersWrapper()
+		hw.bm.On("Block", r.Context(), bc, &hub.AdminAuditInfo{}).Return(blockedContentID, nil)
+		hw.h.Block(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"blocked_content_id": "`+blockedContentID+`"}`, string(data))
+		hw.bm.AssertExpectations(t)
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("error getting blocked content", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.bm.On("GetJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetAll(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.bm.AssertExpectations(t)
+	})
+
+	t.Run("blocked content returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.bm.On("GetJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetAll(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.bm.AssertExpectations(t)
+	})
+}
+
+func TestUnblock(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"blockedContentID"},
+			Values: []string{blockedContentID},
+		},
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Unblock(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.bm.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		description        string
+		bmErr              error
+		expectedStatusCode int
+	}{
+		{
+			"invalid input",
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			"content not found",
+			hub.ErrNotFound,
+			http.StatusNotFound,
+		},
+		{
+			"database error",
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+		{
+			"content unblocked successfully",
+			nil,
+			http.StatusNoContent,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("DELETE", "/", strings.NewReader(`{"reason": "appeal accepted"}`))
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			info := &hub.AdminAuditInfo{Reason: "appeal accepted"}
+			hw.bm.On("Unblock", r.Context(), blockedContentID, info).Return(tc.bmErr)
+			hw.h.Unblock(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.bm.AssertExpectations(t)
+		})
+	}
+}
+
+type handlersWrapper struct {
+	bm *blocklist.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	bm := &blocklist.ManagerMock{}
+
+	return &handlersWrapper{
+		bm: bm,
+		h:  NewHandlers(bm),
+	}
+}
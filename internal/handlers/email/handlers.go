@@ -0,0 +1,54 @@
+package email
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/email"
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling emails
+// operations.
+type Handlers struct {
+	webhooksProcessor hub.EmailWebhooksProcessor
+	logger            zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(webhooksProcessor hub.EmailWebhooksProcessor) *Handlers {
+	return &Handlers{
+		webhooksProcessor: webhooksProcessor,
+		logger:            log.With().Str("handlers", "email").Logger(),
+	}
+}
+
+// ProcessWebhook is an http handler used to process the bounces and
+// complaints notifications sent by the email provider.
+func (h *Handlers) ProcessWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.webhooksProcessor == nil {
+		helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		return
+	}
+	provider := chi.URLParam(r, "provider")
+	err := h.webhooksProcessor.ProcessWebhook(r.Context(), provider, r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "ProcessWebhook").Str("provider", provider).Send()
+		switch {
+		case errors.Is(err, email.ErrWebhookNotSupported):
+			helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		case errors.Is(err, email.ErrWebhookUnauthorized):
+			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Is(err, email.ErrInvalidWebhookRequest):
+			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		default:
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,106 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/email"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestProcessWebhook(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"provider"},
+			Values: []string{"ses"},
+		},
+	}
+
+	t.Run("email not setup", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{}"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		h := NewHandlers(nil)
+		h.ProcessWebhook(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("error processing webhook", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				email.ErrWebhookNotSupported,
+				http.StatusNotFound,
+			},
+			{
+				email.ErrWebhookUnauthorized,
+				http.StatusUnauthorized,
+			},
+			{
+				fmt.Errorf("%w: %s", email.ErrInvalidWebhookRequest, "invalid json"),
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader("{}"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				ep := &email.WebhooksProcessorMock{}
+				ep.On("ProcessWebhook", r.Context(), "ses", mock.Anything).Return(tc.err)
+				h := NewHandlers(ep)
+				h.ProcessWebhook(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				ep.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook processed successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{}"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		ep := &email.WebhooksProcessorMock{}
+		ep.On("ProcessWebhook", r.Context(), "ses", mock.Anything).Return(nil)
+		h := NewHandlers(ep)
+		h.ProcessWebhook(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		ep.AssertExpectations(t)
+	})
+}
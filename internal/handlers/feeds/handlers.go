@@ -0,0 +1,258 @@
+package feeds
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/handlers/pkg"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/feeds"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// CacheMaxAge represents the cache duration used by the feeds endpoints.
+	CacheMaxAge = 15 * time.Minute
+
+	// itemsLimit represents the maximum number of items included in the
+	// repository and search feeds.
+	itemsLimit = 50
+
+	rss  = "rss"
+	atom = "atom"
+)
+
+// Handlers represents a group of http handlers in charge of handling the feeds
+// that allow users to follow new releases.
+type Handlers struct {
+	pkgManager hub.PackageManager
+	cfg        *viper.Viper
+	logger     zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(pkgManager hub.PackageManager, cfg *viper.Viper) *Handlers {
+	return &Handlers{
+		pkgManager: pkgManager,
+		cfg:        cfg,
+		logger:     log.With().Str("handlers", "feeds").Logger(),
+	}
+}
+
+// Package is an http handler used to get the feed of a given package, which
+// contains an item per package version available.
+func (h *Handlers) Package(w http.ResponseWriter, r *http.Request) {
+	// Get package details
+	input := &hub.GetPackageInput{
+		PackageName:    chi.URLParam(r, "packageName"),
+		RepositoryName: chi.URLParam(r, "repoName"),
+	}
+	p, err := h.pkgManager.Get(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "Package").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Build feed
+	baseURL := h.cfg.GetString("server.baseURL")
+	publisher := getPublisher(p)
+	feed := &feeds.Feed{
+		Title:       fmt.Sprintf("%s/%s (Artifact Hub)", publisher, p.NormalizedName),
+		Description: p.Description,
+		Link:        &feeds.Link{Href: baseURL},
+		Image: &feeds.Image{
+			Title: "logo",
+			Url:   fmt.Sprintf("%s/image/%s@4x", baseURL, p.LogoImageID),
+			Link:  baseURL,
+		},
+	}
+	if len(p.Maintainers) > 0 {
+		feed.Author = &feeds.Author{
+			Name:  p.Maintainers[0].Name,
+			Email: p.Maintainers[0].Email,
+		}
+	}
+	for _, s := range p.AvailableVersions {
+		feed.Items = append(feed.Items, &feeds.Item{
+			Id:          fmt.Sprintf("%s#%s", p.PackageID, s.Version),
+			Title:       s.Version,
+			Description: fmt.Sprintf("%s %s", p.NormalizedName, s.Version),
+			Created:     time.Unix(s.TS, 0),
+			Link:        &feeds.Link{Href: pkg.BuildURL(baseURL, p, s.Version)},
+		})
+	}
+	if p.Repository.Kind != hub.Container {
+		sort.Slice(feed.Items, func(i, j int) bool {
+			vi, _ := semver.NewVersion(feed.Items[i].Title)
+			vj, _ := semver.NewVersion(feed.Items[j].Title)
+			return vj.LessThan(vi)
+		})
+	}
+
+	h.renderFeed(w, r, feed)
+}
+
+// Repository is an http handler used to get the feed of a given repository,
+// which contains an item per package with the latest release available.
+func (h *Handlers) Repository(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	input := &hub.SearchPackageInput{
+		Limit:        itemsLimit,
+		Repositories: []string{repoName},
+		Deprecated:   true,
+		Sort:         "last_updated",
+	}
+	pkgs, err := h.searchPackages(r, input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("repo", repoName).Str("method", "Repository").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if len(pkgs) == 0 {
+		helpers.RenderErrorJSON(w, fmt.Errorf("repository %w", hub.ErrNotFound))
+		return
+	}
+
+	// Build feed
+	baseURL := h.cfg.GetString("server.baseURL")
+	repoDisplayName := pkgs[0].Repository.DisplayName
+	if repoDisplayName == "" {
+		repoDisplayName = repoName
+	}
+	feed := &feeds.Feed{
+		Title:       fmt.Sprintf("%s/%s (Artifact Hub)", getPublisher(pkgs[0]), repoName),
+		Description: fmt.Sprintf("Latest releases of the packages in the %s repository", repoDisplayName),
+		Link:        &feeds.Link{Href: baseURL},
+		Items:       buildReleasesItems(baseURL, pkgs),
+	}
+
+	h.renderFeed(w, r, feed)
+}
+
+// Search is an http handler used to get the feed of a given search, which
+// contains an item per package matching the search criteria with the latest
+// release available. The search criteria are provided using the same query
+// string parameters supported by the packages search endpoint.
+func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
+	input, err := pkg.BuildSearchInput(r.URL.Query())
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Msg("invalid query")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	input.Limit = itemsLimit
+	input.Offset = 0
+	input.Facets = false
+	input.Sort = "last_updated"
+	pkgs, err := h.searchPackages(r, input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Build feed
+	baseURL := h.cfg.GetString("server.baseURL")
+	feed := &feeds.Feed{
+		Title:       "Artifact Hub search",
+		Description: "Latest releases of the packages matching the search criteria",
+		Link:        &feeds.Link{Href: fmt.Sprintf("%s/packages/search?%s", baseURL, r.URL.RawQuery)},
+		Items:       buildReleasesItems(baseURL, pkgs),
+	}
+
+	h.renderFeed(w, r, feed)
+}
+
+// searchPackages is a helper used to get the packages matching the search
+// input provided.
+func (h *Handlers) searchPackages(r *http.Request, input *hub.SearchPackageInput) ([]*hub.Package, error) {
+	result, err := h.pkgManager.SearchJSON(r.Context(), input)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Packages []*hub.Package `json:"packages"`
+	}
+	if err := json.Unmarshal(result.Data, &data); err != nil {
+		return nil, err
+	}
+	return data.Packages, nil
+}
+
+// renderFeed is a helper used to write the feed provided to the response
+// writer using the format requested. The Last-Modified header is set using the
+// most recent item in the feed, allowing clients to make conditional requests.
+func (h *Handlers) renderFeed(w http.ResponseWriter, r *http.Request, feed *feeds.Feed) {
+	// Set feed updated time from its most recent item
+	for _, item := range feed.Items {
+		if item.Created.After(feed.Updated) {
+			feed.Updated = item.Created
+		}
+	}
+	if feed.Updated.IsZero() {
+		feed.Updated = time.Now()
+	}
+
+	// Check if the client already has the latest version of the feed
+	lastModified := feed.Updated.UTC().Truncate(time.Second)
+	if v := r.Header.Get("If-Modified-Since"); v != "" {
+		if t, err := http.ParseTime(v); err == nil && !lastModified.After(t) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	// Render feed in the requested format
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(CacheMaxAge))
+	w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	var err error
+	switch chi.URLParam(r, "format") {
+	case atom:
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+		err = feed.WriteAtom(w)
+	default:
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		err = feed.WriteRss(w)
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "renderFeed").Send()
+	}
+}
+
+// buildReleasesItems builds a list of feed items from the packages provided,
+// using the latest release available of each of them.
+func buildReleasesItems(baseURL string, pkgs []*hub.Package) []*feeds.Item {
+	items := make([]*feeds.Item, 0, len(pkgs))
+	for _, p := range pkgs {
+		description := p.Description
+		if description == "" {
+			description = fmt.Sprintf("%s %s", p.NormalizedName, p.Version)
+		}
+		items = append(items, &feeds.Item{
+			Id:          fmt.Sprintf("%s#%s", p.PackageID, p.Version),
+			Title:       fmt.Sprintf("%s %s", p.NormalizedName, p.Version),
+			Description: description,
+			Created:     time.Unix(p.TS, 0),
+			Link:        &feeds.Link{Href: pkg.BuildURL(baseURL, p, p.Version)},
+		})
+	}
+	return items
+}
+
+// getPublisher returns the name of the publisher of the package provided.
+func getPublisher(p *hub.Package) string {
+	if p.Repository.OrganizationName != "" {
+		return p.Repository.OrganizationName
+	}
+	return p.Repository.UserAlias
+}
@@ -0,0 +1,377 @@
+package feeds
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestPackage(t *testing.T) {
+	p := &hub.Package{
+		PackageID:      "0001",
+		NormalizedName: "pkg1",
+		Description:    "description",
+		Version:        "1.0.0",
+		LogoImageID:    "0001",
+		TS:             1592299234,
+		AvailableVersions: []*hub.Version{
+			{
+				Version: "0.0.9",
+				TS:      1592299233,
+			},
+			{
+				Version: "1.0.0",
+				TS:      1592299234,
+			},
+		},
+		Maintainers: []*hub.Maintainer{
+			{
+				Name:  "name1",
+				Email: "email1",
+			},
+		},
+		Repository: &hub.Repository{
+			Name:             "repo1",
+			OrganizationName: "org1",
+		},
+	}
+
+	t.Run("error getting package", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+
+				hw := newHandlersWrapper()
+				hw.pm.On("Get", r.Context(), mock.Anything).Return(nil, tc.pmErr)
+				hw.h.Package(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("rss feed built successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("Get", r.Context(), mock.Anything).Return(p, nil)
+		hw.h.Package(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/rss+xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(CacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, "Tue, 16 Jun 2020 09:20:34 GMT", h.Get("Last-Modified"))
+		assert.Equal(t, []byte(`<?xml version="1.0" encoding="UTF-8"?><rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>org1/pkg1 (Artifact Hub)</title>
+    <link>baseURL</link>
+    <description>description</description>
+    <managingEditor>email1 (name1)</managingEditor>
+    <pubDate>Tue, 16 Jun 2020 09:20:34 +0000</pubDate>
+    <lastBuildDate>Tue, 16 Jun 2020 09:20:34 +0000</lastBuildDate>
+    <image>
+      <url>baseURL/image/0001@4x</url>
+      <title>logo</title>
+      <link>baseURL</link>
+    </image>
+    <item>
+      <title>1.0.0</title>
+      <link>baseURL/packages/helm/repo1/pkg1/1.0.0</link>
+      <description>pkg1 1.0.0</description>
+      <guid>0001#1.0.0</guid>
+      <pubDate>Tue, 16 Jun 2020 09:20:34 +0000</pubDate>
+    </item>
+    <item>
+      <title>0.0.9</title>
+      <link>baseURL/packages/helm/repo1/pkg1/0.0.9</link>
+      <description>pkg1 0.0.9</description>
+      <guid>0001#0.0.9</guid>
+      <pubDate>Tue, 16 Jun 2020 09:20:33 +0000</pubDate>
+    </item>
+  </channel>
+</rss>`), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("atom feed built successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		rctx := &chi.Context{
+			URLParams: chi.RouteParams{
+				Keys:   []string{"format"},
+				Values: []string{"atom"},
+			},
+		}
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("Get", r.Context(), mock.Anything).Return(p, nil)
+		hw.h.Package(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/atom+xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Contains(t, string(data), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+		assert.Contains(t, string(data), `<title>org1/pkg1 (Artifact Hub)</title>`)
+		assert.Contains(t, string(data), `<link href="baseURL/packages/helm/repo1/pkg1/0.0.9" rel="alternate"></link>`)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("feed not modified", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("If-Modified-Since", "Tue, 16 Jun 2020 09:20:34 GMT")
+
+		hw := newHandlersWrapper()
+		hw.pm.On("Get", r.Context(), mock.Anything).Return(p, nil)
+		hw.h.Package(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestRepository(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+	expectedInput := &hub.SearchPackageInput{
+		Limit:        itemsLimit,
+		Repositories: []string{"repo1"},
+		Deprecated:   true,
+		Sort:         "last_updated",
+	}
+
+	t.Run("error searching packages", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("SearchJSON", r.Context(), expectedInput).Return(nil, tests.ErrFakeDB)
+		hw.h.Repository(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("SearchJSON", r.Context(), expectedInput).Return(&hub.JSONQueryResult{
+			Data: []byte(`{"packages": []}`),
+		}, nil)
+		hw.h.Repository(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("feed built successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("SearchJSON", r.Context(), expectedInput).Return(&hub.JSONQueryResult{
+			Data: []byte(searchResultsJSON),
+		}, nil)
+		hw.h.Repository(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/rss+xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(CacheMaxAge), h.Get("Cache-Control"))
+		assert.Contains(t, string(data), "<title>org1/repo1 (Artifact Hub)</title>")
+		assert.Contains(t, string(data), "<link>baseURL/packages/helm/repo1/pkg1/1.0.0</link>")
+		assert.Contains(t, string(data), "<link>baseURL/packages/helm/repo1/pkg2/2.0.0</link>")
+		hw.assertExpectations(t)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []string{
+			"kind=z",
+			"deprecated=z",
+			"official=z",
+		}
+		for i, tc := range testCases {
+			tc := tc
+			t.Run(strconv.Itoa(i), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?"+tc, nil)
+
+				hw := newHandlersWrapper()
+				hw.h.Search(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error searching packages", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?ts_query_web=pkg", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("SearchJSON", r.Context(), mock.Anything).Return(nil, tests.ErrFakeDB)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("feed built successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?ts_query_web=pkg&kind=0&limit=5&facets=true", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("SearchJSON", r.Context(), &hub.SearchPackageInput{
+			Limit:           itemsLimit,
+			TSQueryWeb:      "pkg",
+			RepositoryKinds: []hub.RepositoryKind{hub.Helm},
+			Sort:            "last_updated",
+		}).Return(&hub.JSONQueryResult{
+			Data: []byte(searchResultsJSON),
+		}, nil)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(data), "<title>Artifact Hub search</title>")
+		assert.Contains(t, string(data), "<title>pkg2 2.0.0</title>")
+		hw.assertExpectations(t)
+	})
+}
+
+var searchResultsJSON = `
+{
+	"packages": [
+		{
+			"package_id": "0002",
+			"normalized_name": "pkg2",
+			"version": "2.0.0",
+			"ts": 1592299235,
+			"repository": {
+				"kind": 0,
+				"name": "repo1",
+				"organization_name": "org1"
+			}
+		},
+		{
+			"package_id": "0001",
+			"normalized_name": "pkg1",
+			"description": "description",
+			"version": "1.0.0",
+			"ts": 1592299234,
+			"repository": {
+				"kind": 0,
+				"name": "repo1",
+				"organization_name": "org1"
+			}
+		}
+	]
+}
+`
+
+type handlersWrapper struct {
+	pm *pkg.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "baseURL")
+	pm := &pkg.ManagerMock{}
+
+	return &handlersWrapper{
+		pm: pm,
+		h:  NewHandlers(pm, cfg),
+	}
+}
+
+func (hw *handlersWrapper) assertExpectations(t *testing.T) {
+	hw.pm.AssertExpectations(t)
+}
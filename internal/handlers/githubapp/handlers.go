@@ -0,0 +1,96 @@
+package githubapp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/githubapp"
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling GitHub
+// App operations.
+type Handlers struct {
+	webhooksProcessor hub.GitHubAppWebhooksProcessor
+	logger            zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(webhooksProcessor hub.GitHubAppWebhooksProcessor) *Handlers {
+	return &Handlers{
+		webhooksProcessor: webhooksProcessor,
+		logger:            log.With().Str("handlers", "githubapp").Logger(),
+	}
+}
+
+// setupCompletedURL represents the url users are redirected to once the GitHub
+// App installation has been linked to its owner.
+const setupCompletedURL = "/control-panel/repositories"
+
+// GetInstallURL is an http handler that returns the url used to install the
+// GitHub App on behalf of the user doing the request or the organization
+// provided.
+func (h *Handlers) GetInstallURL(w http.ResponseWriter, r *http.Request) {
+	if h.webhooksProcessor == nil {
+		helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		return
+	}
+	installURL, err := h.webhooksProcessor.GetInstallURL(r.Context(), r.FormValue("org"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetInstallURL").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string]string{"url": installURL})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Setup is an http handler used to process the requests GitHub redirects users
+// to once they have installed the GitHub App, linking the installation to the
+// owner included in the state provided.
+func (h *Handlers) Setup(w http.ResponseWriter, r *http.Request) {
+	if h.webhooksProcessor == nil {
+		helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		return
+	}
+	installationID, err := strconv.ParseInt(r.FormValue("installation_id"), 10, 64)
+	if err != nil {
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid installation id"))
+		return
+	}
+	err = h.webhooksProcessor.LinkInstallation(r.Context(), installationID, r.FormValue("state"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Setup").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	http.Redirect(w, r, setupCompletedURL, http.StatusSeeOther)
+}
+
+// ProcessWebhook is an http handler used to process the events sent by GitHub
+// on behalf of the GitHub App installations.
+func (h *Handlers) ProcessWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.webhooksProcessor == nil {
+		helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		return
+	}
+	if err := h.webhooksProcessor.ProcessWebhook(r.Context(), r); err != nil {
+		h.logger.Error().Err(err).Str("method", "ProcessWebhook").Send()
+		switch {
+		case errors.Is(err, githubapp.ErrWebhookUnauthorized):
+			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Is(err, githubapp.ErrInvalidWebhookRequest):
+			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		default:
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
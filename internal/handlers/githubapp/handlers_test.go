@@ -0,0 +1,226 @@
+package githubapp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/githubapp"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestGetInstallURL(t *testing.T) {
+	t.Run("github app not setup", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		h := NewHandlers(nil)
+		h.GetInstallURL(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("error getting install url", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?org=org1", nil)
+
+		wp := &githubapp.WebhooksProcessorMock{}
+		wp.On("GetInstallURL", r.Context(), "org1").Return("", hub.ErrUnavailable)
+		h := NewHandlers(wp)
+		h.GetInstallURL(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		wp.AssertExpectations(t)
+	})
+
+	t.Run("install url returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?org=org1", nil)
+
+		wp := &githubapp.WebhooksProcessorMock{}
+		wp.On("GetInstallURL", r.Context(), "org1").Return("https://github.com/apps/ah/installations/new", nil)
+		h := NewHandlers(wp)
+		h.GetInstallURL(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{"url": "https://github.com/apps/ah/installations/new"}`, string(data))
+		wp.AssertExpectations(t)
+	})
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("github app not setup", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?installation_id=1&state=state", nil)
+
+		h := NewHandlers(nil)
+		h.Setup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("invalid installation id", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?installation_id=invalid&state=state", nil)
+
+		wp := &githubapp.WebhooksProcessorMock{}
+		h := NewHandlers(wp)
+		h.Setup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		wp.AssertExpectations(t)
+	})
+
+	t.Run("error linking installation", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?installation_id=1&state=state", nil)
+
+				wp := &githubapp.WebhooksProcessorMock{}
+				wp.On("LinkInstallation", r.Context(), int64(1), "state").Return(tc.err)
+				h := NewHandlers(wp)
+				h.Setup(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				wp.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("installation linked successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?installation_id=1&state=state", nil)
+
+		wp := &githubapp.WebhooksProcessorMock{}
+		wp.On("LinkInstallation", r.Context(), int64(1), "state").Return(nil)
+		h := NewHandlers(wp)
+		h.Setup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+		assert.Equal(t, setupCompletedURL, resp.Header.Get("Location"))
+		wp.AssertExpectations(t)
+	})
+}
+
+func TestProcessWebhook(t *testing.T) {
+	t.Run("github app not setup", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{}"))
+
+		h := NewHandlers(nil)
+		h.ProcessWebhook(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("error processing webhook", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				githubapp.ErrWebhookUnauthorized,
+				http.StatusUnauthorized,
+			},
+			{
+				fmt.Errorf("%w: %s", githubapp.ErrInvalidWebhookRequest, "invalid json"),
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader("{}"))
+
+				wp := &githubapp.WebhooksProcessorMock{}
+				wp.On("ProcessWebhook", r.Context(), mock.Anything).Return(tc.err)
+				h := NewHandlers(wp)
+				h.ProcessWebhook(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				wp.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook processed successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{}"))
+
+		wp := &githubapp.WebhooksProcessorMock{}
+		wp.On("ProcessWebhook", r.Context(), mock.Anything).Return(nil)
+		h := NewHandlers(wp)
+		h.ProcessWebhook(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		wp.AssertExpectations(t)
+	})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -10,10 +11,22 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/handlers/apikey"
+	"github.com/artifacthub/hub/internal/handlers/blocklist"
+	"github.com/artifacthub/hub/internal/handlers/email"
+	"github.com/artifacthub/hub/internal/handlers/feeds"
+	"github.com/artifacthub/hub/internal/handlers/githubapp"
+	"github.com/artifacthub/hub/internal/handlers/health"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/handlers/inbox"
+	"github.com/artifacthub/hub/internal/handlers/issuetracker"
+	"github.com/artifacthub/hub/internal/handlers/job"
+	"github.com/artifacthub/hub/internal/handlers/maintainer"
+	"github.com/artifacthub/hub/internal/handlers/maintenance"
+	"github.com/artifacthub/hub/internal/handlers/metadata"
 	"github.com/artifacthub/hub/internal/handlers/org"
 	"github.com/artifacthub/hub/internal/handlers/pkg"
 	"github.com/artifacthub/hub/internal/handlers/repo"
+	"github.com/artifacthub/hub/internal/handlers/sitemap"
 	"github.com/artifacthub/hub/internal/handlers/static"
 	"github.com/artifacthub/hub/internal/handlers/stats"
 	"github.com/artifacthub/hub/internal/handlers/subscription"
@@ -33,11 +46,33 @@ import (
 	"github.com/unrolled/secure"
 )
 
-const csrfHeader = "X-CSRF-Token"
+const (
+	csrfHeader = "X-CSRF-Token"
+
+	// compressionLevel represents the compression level used when compressing
+	// responses.
+	compressionLevel = 5
+)
 
 var (
 	xForwardedFor = http.CanonicalHeaderKey("X-Forwarded-For")
 
+	// compressibleContentTypes represents the content types of the responses
+	// that will be compressed when the client supports it.
+	compressibleContentTypes = []string{
+		"application/atom+xml",
+		"application/javascript",
+		"application/json",
+		"application/rss+xml",
+		"application/xml",
+		"image/svg+xml",
+		"text/css",
+		"text/html",
+		"text/javascript",
+		"text/markdown",
+		"text/plain",
+	}
+
 	// WebhooksHTTPClientTimeout represents the timeout of the http client used
 	// to handle the webhooks requests.
 	WebhooksHTTPClientTimeout = 60 * time.Second
@@ -52,12 +87,23 @@ type Services struct {
 	SubscriptionManager hub.SubscriptionManager
 	WebhookManager      hub.WebhookManager
 	APIKeyManager       hub.APIKeyManager
+	APIKeyUsageTracker  hub.APIKeyUsageTracker
+	EmailProcessor      hub.EmailWebhooksProcessor
+	GitHubAppProcessor  hub.GitHubAppWebhooksProcessor
 	StatsManager        hub.StatsManager
+	SitemapManager      hub.SitemapManager
 	ImageStore          img.Store
 	Authorizer          hub.Authorizer
 	HTTPClient          hub.HTTPClient
 	OCIPuller           hub.OCIPuller
 	ViewsTracker        hub.ViewsTracker
+	HealthChecker       hub.HealthChecker
+	BlocklistManager    hub.BlocklistManager
+	InboxManager        hub.InboxManager
+	IssueTrackerManager hub.IssueTrackerManager
+	MaintainerManager   hub.MaintainerManager
+	MaintenanceManager  hub.MaintenanceManager
+	JobManager          hub.JobManager
 }
 
 // Metrics groups some metrics collected from a Handlers instance.
@@ -68,11 +114,13 @@ type Metrics struct {
 // Handlers groups all the http handlers defined for the hub, including the
 // router in charge of sending requests to the right handler.
 type Handlers struct {
-	cfg     *viper.Viper
-	svc     *Services
-	metrics *Metrics
-	logger  zerolog.Logger
-	Router  http.Handler
+	cfg            *viper.Viper
+	svc            *Services
+	metrics        *Metrics
+	logger         zerolog.Logger
+	trustedProxies []*net.IPNet
+	rateLimiter    *util.KeyRateLimiter
+	Router         http.Handler
 
 	Organizations *org.Handlers
 	Users         *user.Handlers
@@ -81,21 +129,47 @@ type Handlers struct {
 	Subscriptions *subscription.Handlers
 	Webhooks      *webhook.Handlers
 	APIKeys       *apikey.Handlers
+	Email         *email.Handlers
+	GitHubApp     *githubapp.Handlers
 	Static        *static.Handlers
 	Stats         *stats.Handlers
+	Feeds         *feeds.Handlers
+	Sitemap       *sitemap.Handlers
+	Health        *health.Handlers
+	Blocklist     *blocklist.Handlers
+	Inbox         *inbox.Handlers
+	IssueTrackers *issuetracker.Handlers
+	Maintainers   *maintainer.Handlers
+	Maintenance   *maintenance.Handlers
+	Jobs          *job.Handlers
+	Metadata      *metadata.Handlers
 }
 
 // Setup creates a new Handlers instance.
 func Setup(ctx context.Context, cfg *viper.Viper, svc *Services) (*Handlers, error) {
-	userHandlers, err := user.NewHandlers(ctx, svc.UserManager, svc.APIKeyManager, cfg)
+	userHandlers, err := user.NewHandlers(ctx, svc.UserManager, svc.APIKeyManager, svc.APIKeyUsageTracker, cfg)
 	if err != nil {
 		return nil, err
 	}
+	webhooksHC, err := util.SetupWebhooksHTTPClient(cfg, WebhooksHTTPClientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	trustedProxies, err := util.ParseCIDRs(cfg.GetStringSlice("server.trustedProxies"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	if cfg.GetBool("server.cors.enabled") {
+		if err := validateCORSConfig(cfg); err != nil {
+			return nil, fmt.Errorf("invalid cors configuration: %w", err)
+		}
+	}
 	h := &Handlers{
-		cfg:     cfg,
-		svc:     svc,
-		metrics: setupMetrics(),
-		logger:  log.With().Str("handlers", "root").Logger(),
+		cfg:            cfg,
+		svc:            svc,
+		metrics:        setupMetrics(),
+		logger:         log.With().Str("handlers", "root").Logger(),
+		trustedProxies: trustedProxies,
 
 		Organizations: org.NewHandlers(svc.OrganizationManager, svc.Authorizer, cfg),
 		Users:         userHandlers,
@@ -107,15 +181,28 @@ func Setup(ctx context.Context, cfg *viper.Viper, svc *Services) (*Handlers, err
 			svc.HTTPClient,
 			svc.OCIPuller,
 			svc.ViewsTracker,
+			svc.ImageStore,
 		),
 		Subscriptions: subscription.NewHandlers(svc.SubscriptionManager),
-		Webhooks: webhook.NewHandlers(
-			svc.WebhookManager,
-			util.SetupHTTPClient(cfg.GetBool("restrictedHTTPClient"), WebhooksHTTPClientTimeout),
-		),
-		APIKeys: apikey.NewHandlers(svc.APIKeyManager),
-		Static:  static.NewHandlers(cfg, svc.ImageStore),
-		Stats:   stats.NewHandlers(svc.StatsManager),
+		Webhooks:      webhook.NewHandlers(svc.WebhookManager, cfg, webhooksHC),
+		APIKeys:       apikey.NewHandlers(svc.APIKeyManager),
+		Email:         email.NewHandlers(svc.EmailProcessor),
+		GitHubApp:     githubapp.NewHandlers(svc.GitHubAppProcessor),
+		Static:        static.NewHandlers(cfg, svc.ImageStore),
+		Stats:         stats.NewHandlers(svc.StatsManager),
+		Feeds:         feeds.NewHandlers(svc.PackageManager, cfg),
+		Sitemap:       sitemap.NewHandlers(svc.SitemapManager, cfg),
+		Health:        health.NewHandlers(svc.HealthChecker, cfg),
+		Blocklist:     blocklist.NewHandlers(svc.BlocklistManager),
+		Inbox:         inbox.NewHandlers(svc.InboxManager),
+		IssueTrackers: issuetracker.NewHandlers(svc.IssueTrackerManager),
+		Maintainers:   maintainer.NewHandlers(svc.MaintainerManager),
+		Maintenance:   maintenance.NewHandlers(svc.MaintenanceManager),
+		Jobs:          job.NewHandlers(svc.JobManager),
+		Metadata:      metadata.NewHandlers(),
+	}
+	if rps := cfg.GetFloat64("server.rateLimit.requestsPerSecond"); rps > 0 {
+		h.rateLimiter = util.NewKeyRateLimiter(rps, cfg.GetInt("server.rateLimit.burst"))
 	}
 	h.setupRouter()
 	return h, nil
@@ -148,23 +235,41 @@ func (h *Handlers) setupRouter() {
 		AllowedMethods:   []string{"GET"},
 		AllowCredentials: false,
 	}).Handler
+	noCache := helpers.WithCacheTier(helpers.CacheTierNone)
+	shortCache := helpers.WithCacheTier(helpers.CacheTierShort)
+	immutableCache := helpers.WithCacheTier(helpers.CacheTierImmutable)
 	r.Use(middleware.Recoverer)
-	r.Use(realIP(h.cfg.GetInt("server.xffIndex")))
+	r.Use(realIP(h.cfg.GetInt("server.xffIndex"), h.trustedProxies))
 	r.Use(logger)
 	r.Use(h.MetricsCollector)
-	r.Use(secure.New(secure.Options{
-		SSLProxyHeaders:      map[string]string{"X-Forwarded-Proto": "https"},
-		STSSeconds:           31536000,
-		STSIncludeSubdomains: true,
-		STSPreload:           true,
-	}).Handler)
+	r.Use(middleware.Compress(compressionLevel, compressibleContentTypes...))
+	r.Use(secure.New(securityHeadersOptions(h.cfg)).Handler)
+	if h.cfg.GetBool("server.cors.enabled") {
+		r.Use(configurableCORS(h.cfg))
+	}
 	if h.cfg.GetBool("server.basicAuth.enabled") {
 		r.Use(h.Users.BasicAuth)
 	}
 	r.NotFound(h.Static.Index)
 
+	// Private mode
+	//
+	// When the hub runs in private mode, anonymous users can only access the
+	// endpoints needed to sign up and log in, as well as the static files
+	// required to render the web application.
+	private := h.cfg.GetBool("server.private.enabled")
+	var privateMW []func(http.Handler) http.Handler
+	if private {
+		privateMW = append(privateMW, h.Users.RequireLogin)
+	}
+
 	// API
 	r.Route("/api/v1", func(r chi.Router) {
+		// Rate limit
+		if h.rateLimiter != nil {
+			r.Use(rateLimit(h.rateLimiter))
+		}
+
 		// CSRF
 		r.Use(csrfSkipper)
 		r.Use(csrf.Protect(
@@ -173,8 +278,11 @@ func (h *Handlers) setupRouter() {
 			csrf.Path("/api/v1"),
 			csrf.CookieName("csrf"),
 		))
-		r.Get("/csrf", func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Cache-Control", "no-store")
+		r.Use(h.Maintenance.ReadOnly)
+		if private {
+			r.Use(h.privateModeAPI)
+		}
+		r.With(noCache).Get("/csrf", func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set(csrfHeader, csrf.Token(r))
 		})
 
@@ -184,14 +292,24 @@ func (h *Handlers) setupRouter() {
 			r.Post("/check-password-strength", h.Users.CheckPasswordStrength)
 			r.Post("/login", h.Users.Login)
 			r.Put("/approve-session", h.Users.ApproveSession)
+			r.Post("/email-verification-code", h.Users.RegisterEmailVerificationCode)
 			r.Post("/password-reset-code", h.Users.RegisterPasswordResetCode)
 			r.Put("/reset-password", h.Users.ResetPassword)
 			r.Post("/verify-email", h.Users.VerifyEmail)
 			r.Post("/verify-password-reset-code", h.Users.VerifyPasswordResetCode)
+			r.Get("/{userAlias}/public-profile", h.Users.GetPublicProfile)
 			r.Group(func(r chi.Router) {
-				r.Use(h.Users.RequireLogin)
+				r.Use(h.Users.RequireLogin, noCache)
 				r.Delete("/", h.Users.DeleteUser)
 				r.Post("/delete-user-code", h.Users.RegisterDeleteUserCode)
+				r.Route("/email-suppression", func(r chi.Router) {
+					r.Get("/", h.Users.GetEmailSuppression)
+					r.Delete("/", h.Users.DeleteEmailSuppression)
+				})
+				r.Route("/identities", func(r chi.Router) {
+					r.Get("/", h.Users.GetIdentities)
+					r.Delete("/{provider}", h.Users.UnlinkIdentity)
+				})
 				r.Route("/tfa", func(r chi.Router) {
 					r.Put("/disable", h.Users.DisableTFA)
 					r.Put("/enable", h.Users.EnableTFA)
@@ -207,26 +325,50 @@ func (h *Handlers) setupRouter() {
 		// Organizations
 		r.Route("/orgs", func(r chi.Router) {
 			r.Group(func(r chi.Router) {
-				r.Use(h.Users.RequireLogin)
+				r.Use(h.Users.RequireLogin, noCache)
 				r.Post("/", h.Organizations.Add)
 				r.Get("/user", h.Organizations.GetByUser)
 			})
 			r.Route("/{orgName}", func(r chi.Router) {
 				r.Get("/", h.Organizations.Get)
+				r.Get("/public-profile", h.Organizations.GetPublicProfile)
 				r.Group(func(r chi.Router) {
-					r.Use(h.Users.RequireLogin)
+					r.Use(h.Users.RequireLogin, noCache)
 					r.Delete("/", h.Organizations.Delete)
 					r.Put("/", h.Organizations.Update)
 					r.Route("/authorization-policy", func(r chi.Router) {
 						r.Get("/", h.Organizations.GetAuthorizationPolicy)
 						r.Put("/", h.Organizations.UpdateAuthorizationPolicy)
+						r.Get("/decisions", h.Organizations.GetAuthorizationDecisions)
+						r.Post("/test", h.Organizations.TestAuthorizationPolicy)
 					})
 					r.Get("/accept-invitation", h.Organizations.ConfirmMembership)
+					r.Route("/domains", func(r chi.Router) {
+						r.Get("/", h.Organizations.GetDomains)
+						r.Post("/", h.Organizations.AddDomain)
+						r.Route("/{domain}", func(r chi.Router) {
+							r.Put("/", h.Organizations.UpdateDomain)
+							r.Delete("/", h.Organizations.DeleteDomain)
+							r.Put("/verify", h.Organizations.VerifyDomain)
+						})
+					})
+					r.Post("/join", h.Organizations.Join)
+					r.Route("/join-requests", func(r chi.Router) {
+						r.Get("/", h.Organizations.GetJoinRequests)
+						r.Put("/{userAlias}", h.Organizations.ApproveJoinRequest)
+						r.Delete("/{userAlias}", h.Organizations.DeleteJoinRequest)
+					})
 					r.Get("/members", h.Organizations.GetMembers)
 					r.Route("/member/{userAlias}", func(r chi.Router) {
 						r.Post("/", h.Organizations.AddMember)
 						r.Delete("/", h.Organizations.DeleteMember)
 					})
+					r.Route("/repository-changes", func(r chi.Router) {
+						r.Get("/", h.Repositories.GetPendingChanges)
+						r.Put("/{changeID}/approve", h.Repositories.ApproveChange)
+						r.Put("/{changeID}/reject", h.Repositories.RejectChange)
+					})
+					r.Get("/security-overview", h.Organizations.GetSecurityOverview)
 					r.Get("/user-allowed-actions", h.Organizations.GetUserAllowedActions)
 				})
 			})
@@ -236,12 +378,18 @@ func (h *Handlers) setupRouter() {
 		r.Route("/repositories", func(r chi.Router) {
 			r.With(h.Users.InjectUserID).Get("/search", h.Repositories.Search)
 			r.Group(func(r chi.Router) {
-				r.Use(h.Users.RequireLogin)
+				r.Use(h.Users.RequireLogin, noCache)
 				r.Route("/user", func(r chi.Router) {
 					r.Post("/", h.Repositories.Add)
 					r.Route("/{repoName}", func(r chi.Router) {
 						r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
+						r.Post("/claim-ownership-token", h.Repositories.RegisterOwnershipClaimToken)
+						r.Post("/downloads", h.Repositories.RegisterPackagesDownloads)
+						r.Get("/events", h.Repositories.GetEvents)
+						r.Get("/stats", h.Repositories.GetStats)
+						r.Get("/subscriptions", h.Repositories.GetSubscriptions)
 						r.Put("/transfer", h.Repositories.Transfer)
+						r.Get("/views", h.Repositories.GetViews)
 						r.Put("/", h.Repositories.Update)
 						r.Delete("/", h.Repositories.Delete)
 					})
@@ -250,7 +398,13 @@ func (h *Handlers) setupRouter() {
 					r.Post("/", h.Repositories.Add)
 					r.Route("/{repoName}", func(r chi.Router) {
 						r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
+						r.Post("/claim-ownership-token", h.Repositories.RegisterOwnershipClaimToken)
+						r.Post("/downloads", h.Repositories.RegisterPackagesDownloads)
+						r.Get("/events", h.Repositories.GetEvents)
+						r.Get("/stats", h.Repositories.GetStats)
+						r.Get("/subscriptions", h.Repositories.GetSubscriptions)
 						r.Put("/transfer", h.Repositories.Transfer)
+						r.Get("/views", h.Repositories.GetViews)
 						r.Put("/", h.Repositories.Update)
 						r.Delete("/", h.Repositories.Delete)
 					})
@@ -260,17 +414,23 @@ func (h *Handlers) setupRouter() {
 
 		// Packages
 		r.Route("/packages", func(r chi.Router) {
+			r.Get("/new", h.Packages.GetNew)
 			r.Get("/random", h.Packages.GetRandom)
 			r.Get("/stats", h.Packages.GetStats)
-			r.With(corsMW).Get("/search", h.Packages.Search)
-			r.With(h.Users.RequireLogin).Get("/starred", h.Packages.GetStarredByUser)
-			r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^coredns|^keptn|^tekton-pipeline|^container$}/{repoName}/{packageName}", func(r chi.Router) {
-				r.Get("/feed/rss", h.Packages.RssFeed)
+			r.Get("/trending", h.Packages.GetTrending)
+			r.With(corsMW, h.requireLoginToExport, shortCache).Get("/search", h.Packages.Search)
+			r.With(h.Users.RequireLogin, noCache).Get("/starred", h.Packages.GetStarredByUser)
+			r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^coredns|^keptn|^tekton-pipeline|^container$|^terraform$|^crossplane$|^kyverno$|^knative-func$|^headlamp$}/{repoName}/{packageName}", func(r chi.Router) {
+				r.Get("/feed/{format:^rss$|^atom$}", h.Feeds.Package)
 				r.With(corsMW).Get("/summary", h.Packages.GetSummary)
+				r.Get("/social-image.png", h.Packages.GetSocialImage)
+				r.With(corsMW).Get("/install-snippets", h.Packages.GetInstallSnippets)
+				r.With(corsMW).Get("/{version}/install-snippets", h.Packages.GetInstallSnippets)
 				r.Get("/{version}", h.Packages.Get)
+				r.Post("/{version}/gitops-manifests", h.Packages.GenerateGitOpsManifests)
 				r.Get("/changelog.md", h.Packages.GenerateChangelogMD)
 				r.Route("/production-usage", func(r chi.Router) {
-					r.Use(h.Users.RequireLogin)
+					r.Use(h.Users.RequireLogin, noCache)
 					r.Get("/", h.Packages.GetProductionUsage)
 					r.Post("/{orgName}", h.Packages.AddProductionUsage)
 					r.Delete("/{orgName}", h.Packages.DeleteProductionUsage)
@@ -279,20 +439,42 @@ func (h *Handlers) setupRouter() {
 			})
 			r.Route("/{packageID}/stars", func(r chi.Router) {
 				r.With(h.Users.InjectUserID).Get("/", h.Packages.GetStars)
-				r.With(h.Users.RequireLogin).Put("/", h.Packages.ToggleStar)
+				r.With(h.Users.RequireLogin, noCache).Put("/", h.Packages.ToggleStar)
 			})
+			r.Get("/{packageID}/downloads", h.Packages.GetDownloads)
+			r.With(h.Users.RequireLogin, noCache).Get("/{packageID}/{version}/bundle", h.Packages.GetSnapshotBundle)
+			r.Get("/{packageID}/{version}/content-warnings", h.Packages.GetSnapshotContentWarnings)
+			r.Get("/{packageID}/{version}/crds", h.Packages.GetCRDs)
+			r.Get("/{packageID}/{version}/crds/{crdName}", h.Packages.GetCRD)
+			r.Get("/{packageID}/{version}/images", h.Packages.GetSnapshotImages)
+			r.Get("/{packageID}/{version}/licenses", h.Packages.GetSnapshotLicenseInventory)
+			r.Get("/{packageID}/{version}/readme", h.Packages.GetReadme)
+			r.Get("/{packageID}/{version}/sbom", h.Packages.GetSnapshotSBOM)
 			r.Get("/{packageID}/{version}/security-report", h.Packages.GetSnapshotSecurityReport)
+			r.Get("/{packageID}/{version}/security-report/suppressed", h.Packages.GetSnapshotSecurityReportSuppressed)
+			r.Route("/{packageID}/{version}/vulnerability-statements", func(r chi.Router) {
+				r.Get("/", h.Packages.GetVulnerabilityStatements)
+				r.With(h.Users.RequireLogin, noCache).Put("/", h.Packages.UpdateVulnerabilityStatements)
+			})
+			r.With(h.Users.RequireLogin, noCache).Post("/{packageID}/{version}/scan", h.Packages.RequestSnapshotScan)
 			r.Get("/{packageID}/{version}/values", h.Packages.GetChartValues)
 			r.Get("/{packageID}/{version}/values-schema", h.Packages.GetValuesSchema)
 			r.Get("/{packageID}/{version}/templates", h.Packages.GetChartTemplates)
 			r.Post("/{packageID}/{version}/views", h.Packages.TrackView)
 			r.Get("/{packageID}/views", h.Packages.GetViews)
 			r.Get("/{packageID}/changelog", h.Packages.GetChangelog)
+			r.Get("/{packageID}/upgrade-graph", h.Packages.GetUpgradeGraph)
+		})
+
+		// Feeds
+		r.Route("/feeds", func(r chi.Router) {
+			r.Get("/repository/{repoName}/{format:^rss$|^atom$}", h.Feeds.Repository)
+			r.Get("/search/{format:^rss$|^atom$}", h.Feeds.Search)
 		})
 
 		// Subscriptions
 		r.Route("/subscriptions", func(r chi.Router) {
-			r.Use(h.Users.RequireLogin)
+			r.Use(h.Users.RequireLogin, noCache)
 			r.Route("/opt-out", func(r chi.Router) {
 				r.Get("/", h.Subscriptions.GetOptOutList)
 				r.Post("/", h.Subscriptions.AddOptOut)
@@ -304,9 +486,18 @@ func (h *Handlers) setupRouter() {
 			r.Delete("/", h.Subscriptions.Delete)
 		})
 
+		// Inbox
+		r.Route("/inbox", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin, noCache)
+			r.Get("/", h.Inbox.Get)
+			r.Get("/unread-count", h.Inbox.GetUnreadCount)
+			r.Put("/read", h.Inbox.MarkAllAsRead)
+			r.Put("/{inboxNotificationID}/read", h.Inbox.MarkAsRead)
+		})
+
 		// Webhooks
 		r.Route("/webhooks", func(r chi.Router) {
-			r.Use(h.Users.RequireLogin)
+			r.Use(h.Users.RequireLogin, noCache)
 			r.Route("/user", func(r chi.Router) {
 				r.Get("/", h.Webhooks.GetOwnedByUser)
 				r.Post("/", h.Webhooks.Add)
@@ -314,6 +505,8 @@ func (h *Handlers) setupRouter() {
 					r.Get("/", h.Webhooks.Get)
 					r.Put("/", h.Webhooks.Update)
 					r.Delete("/", h.Webhooks.Delete)
+					r.Post("/replay", h.Webhooks.Replay)
+					r.Post("/test", h.Webhooks.SendTestEvent)
 				})
 			})
 			r.Route("/org/{orgName}", func(r chi.Router) {
@@ -323,20 +516,45 @@ func (h *Handlers) setupRouter() {
 					r.Get("/", h.Webhooks.Get)
 					r.Put("/", h.Webhooks.Update)
 					r.Delete("/", h.Webhooks.Delete)
+					r.Post("/replay", h.Webhooks.Replay)
+					r.Post("/test", h.Webhooks.SendTestEvent)
 				})
 			})
+			r.Post("/preview", h.Webhooks.Preview)
 			r.Post("/test", h.Webhooks.TriggerTest)
 		})
 
+		// Issue trackers
+		r.Route("/issue-trackers/org/{orgName}", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin, noCache)
+			r.Get("/", h.IssueTrackers.GetOwnedByOrg)
+			r.Post("/", h.IssueTrackers.Add)
+			r.Route("/{issueTrackerID}", func(r chi.Router) {
+				r.Put("/", h.IssueTrackers.Update)
+				r.Delete("/", h.IssueTrackers.Delete)
+			})
+		})
+
+		// Maintainers
+		r.Route("/maintainers", func(r chi.Router) {
+			r.Post("/verify", h.Maintainers.Verify)
+			r.Group(func(r chi.Router) {
+				r.Use(h.Users.RequireLogin, noCache)
+				r.Post("/{maintainerID}/contact", h.Maintainers.Contact)
+				r.Post("/{maintainerID}/verification-code", h.Maintainers.RegisterVerificationCode)
+			})
+		})
+
 		// API keys
 		r.Route("/api-keys", func(r chi.Router) {
-			r.Use(h.Users.RequireLogin)
+			r.Use(h.Users.RequireLogin, noCache)
 			r.Get("/", h.APIKeys.GetOwnedByUser)
 			r.Post("/", h.APIKeys.Add)
 			r.Route("/{apiKeyID}", func(r chi.Router) {
 				r.Get("/", h.APIKeys.Get)
 				r.Put("/", h.APIKeys.Update)
 				r.Delete("/", h.APIKeys.Delete)
+				r.Get("/usage", h.APIKeys.GetUsage)
 			})
 		})
 
@@ -348,10 +566,30 @@ func (h *Handlers) setupRouter() {
 		})
 
 		// Images
-		r.With(h.Users.RequireLogin).Post("/images", h.Static.SaveImage)
+		r.With(h.Users.RequireLogin, noCache).Post("/images", h.Static.SaveImage)
+
+		// Metadata files validation
+		r.Post("/metadata/validate/{kind:^package$|^repository$}", h.Metadata.Validate)
+
+		// Content security policy violations reports
+		r.Post("/csp-report", h.Static.CSPReport)
+
+		// Email provider webhooks
+		r.Post("/email/webhooks/{provider:^ses$|^sendgrid$|^mailgun$}", h.Email.ProcessWebhook)
+
+		// GitHub App
+		r.Route("/github-app", func(r chi.Router) {
+			r.Post("/webhook", h.GitHubApp.ProcessWebhook)
+			r.Group(func(r chi.Router) {
+				r.Use(h.Users.RequireLogin, noCache)
+				r.Get("/install-url", h.GitHubApp.GetInstallURL)
+				r.Get("/setup", h.GitHubApp.Setup)
+			})
+		})
 
 		// Stats
 		r.Get("/stats", h.Stats.Get)
+		r.Get("/stats/time-series", h.Stats.GetTimeSeries)
 
 		// Harbor replication
 		//
@@ -370,6 +608,39 @@ func (h *Handlers) setupRouter() {
 		//
 		// (*) https://github.com/sstarcher/helm-exporter
 		r.Get("/helm-exporter", h.Packages.GetHelmExporterDump)
+
+		// Admin
+		//
+		// Besides the admin token, admins must be logged in so that the
+		// operations they perform can be attributed to them in the audit log.
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(h.Health.RequireAdminToken, h.Users.RequireLogin, noCache)
+			r.Route("/audit-log", func(r chi.Router) {
+				r.Get("/", h.Users.GetAdminAuditLog)
+				r.Get("/verify", h.Users.VerifyAdminAuditLog)
+			})
+			r.Get("/jobs/runs", h.Jobs.GetRuns)
+			r.Get("/migrations", h.Health.GetMigrations)
+			r.Route("/maintenance", func(r chi.Router) {
+				r.Get("/", h.Maintenance.Get)
+				r.Put("/", h.Maintenance.Set)
+			})
+			r.Get("/packages/{packageID}/{version}/bundle", h.Packages.GetSnapshotBundleAsAdmin)
+			r.Route("/blocklist", func(r chi.Router) {
+				r.Get("/", h.Blocklist.GetAll)
+				r.Post("/", h.Blocklist.Block)
+				r.Delete("/{blockedContentID}", h.Blocklist.Unblock)
+			})
+			r.Route("/users", func(r chi.Router) {
+				r.Get("/", h.Users.Search)
+				r.Route("/{userID}", func(r chi.Router) {
+					r.Put("/disable", h.Users.Disable)
+					r.Put("/enable", h.Users.Enable)
+					r.Post("/impersonate", h.Users.Impersonate)
+					r.Put("/reset-tfa", h.Users.ResetTFA)
+				})
+			})
+		})
 	})
 
 	// Monocular compatible search API
@@ -379,7 +650,7 @@ func (h *Handlers) setupRouter() {
 	// from the Helm Hub to Artifact Hub, allowing the existing Helm tooling to
 	// continue working without modifications. This is a temporary solution and
 	// future Helm CLI versions should use the generic Artifact Hub search API.
-	r.Get("/api/chartsvc/v1/charts/search", h.Packages.SearchMonocular)
+	r.With(privateMW...).With(shortCache).Get("/api/chartsvc/v1/charts/search", h.Packages.SearchMonocular)
 
 	// Monocular charts url redirect endpoint
 	//
@@ -419,25 +690,34 @@ func (h *Handlers) setupRouter() {
 		})
 	}
 
-	// Index special entry points
-	r.Route("/packages", func(r chi.Router) {
-		r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^coredns|^keptn|^tekton-pipeline|^container$}/{repoName}/{packageName}", func(r chi.Router) {
-			r.With(h.Packages.InjectIndexMeta).Get("/{version}", h.Static.Index)
-			r.With(h.Packages.InjectIndexMeta).Get("/", h.Static.Index)
+	// Index special entry points (packages metadata is not injected in the
+	// index in private mode, as it's served to anonymous users)
+	if !private {
+		r.Route("/packages", func(r chi.Router) {
+			r.Route("/{kind}/{repoName}/{packageName}", func(r chi.Router) {
+				r.With(h.Packages.InjectIndexMeta).Get("/{version}", h.Static.Index)
+				r.With(h.Packages.InjectIndexMeta).Get("/", h.Static.Index)
+			})
 		})
-	})
+	}
 
 	// Badges
-	r.Get("/badge/repository/{repoName}", h.Repositories.Badge)
+	r.With(privateMW...).Get("/badge/repository/{repoName}", h.Repositories.Badge)
+	r.With(privateMW...).Get("/badge/package/{repoName}/{packageName}/{badgeKind:^version$|^verified$|^security$|^stars$|^downloads$}", h.Packages.Badge)
+
+	// Sitemap
+	r.With(privateMW...).Get("/sitemap.xml", h.Sitemap.Index)
+	r.With(privateMW...).Get("/sitemaps/repositories.xml", h.Sitemap.Repositories)
+	r.With(privateMW...).Get("/sitemaps/packages/{page}.xml", h.Sitemap.Packages)
 
 	// Static files and index
 	webBuildPath := h.cfg.GetString("server.webBuildPath")
 	webStaticFilesPath := path.Join(webBuildPath, "static")
 	widgetBuildPath := h.cfg.GetString("server.widgetBuildPath")
 	docsFilesPath := path.Join(webBuildPath, "docs")
-	static.FileServer(r, "/static", webStaticFilesPath, static.StaticCacheMaxAge)
+	static.FileServer(r.With(immutableCache), "/static", webStaticFilesPath, static.StaticCacheMaxAge)
 	static.FileServer(r, "/docs", docsFilesPath, static.DocsCacheMaxAge)
-	r.Get("/image/{image}", h.Static.Image)
+	r.With(privateMW...).Get("/image/{image}", h.Static.Image)
 	r.Get("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(5*time.Minute))
 		http.ServeFile(w, r, path.Join(webBuildPath, "manifest.json"))
@@ -448,7 +728,16 @@ func (h *Handlers) setupRouter() {
 	})
 	r.Get("/", h.Static.Index)
 
-	h.Router = r
+	// Health checks
+	//
+	// These endpoints are used as liveness and readiness probes, so they are
+	// handled before any of the middleware defined above (i.e. basic auth).
+	root := chi.NewRouter()
+	root.Get("/healthz", h.Health.Liveness)
+	root.Get("/readyz", h.Health.Readiness)
+	root.Mount("/", r)
+
+	h.Router = root
 }
 
 // MetricsCollector is an http middleware that collects some metrics about
@@ -469,6 +758,53 @@ func (h *Handlers) MetricsCollector(next http.Handler) http.Handler {
 	})
 }
 
+// privateModeAPIPublicPaths represents the API endpoints that anonymous users
+// can still access when the hub runs in private mode.
+var privateModeAPIPublicPaths = map[string]struct{}{
+	"/api/v1/check-availability/userAlias":     {},
+	"/api/v1/csp-report":                       {},
+	"/api/v1/csrf":                             {},
+	"/api/v1/github-app/webhook":               {},
+	"/api/v1/maintainers/verify":               {},
+	"/api/v1/users":                            {},
+	"/api/v1/users/approve-session":            {},
+	"/api/v1/users/check-password-strength":    {},
+	"/api/v1/users/email-verification-code":    {},
+	"/api/v1/users/login":                      {},
+	"/api/v1/users/password-reset-code":        {},
+	"/api/v1/users/reset-password":             {},
+	"/api/v1/users/verify-email":               {},
+	"/api/v1/users/verify-password-reset-code": {},
+}
+
+// privateModeAPI is an http middleware that requires users to be logged in to
+// access the API, except for the endpoints needed to sign up and log in.
+func (h *Handlers) privateModeAPI(next http.Handler) http.Handler {
+	requireLogin := h.Users.RequireLogin(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := strings.TrimSuffix(r.URL.Path, "/")
+		_, public := privateModeAPIPublicPaths[p]
+		if public || strings.HasPrefix(p, "/api/v1/email/webhooks/") || strings.HasPrefix(p, "/api/v1/admin/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		requireLogin.ServeHTTP(w, r)
+	})
+}
+
+// requireLoginToExport is an http middleware that requires users to be logged
+// in to export the packages matching a search. Exports are never cached.
+func (h *Handlers) requireLoginToExport(next http.Handler) http.Handler {
+	export := h.Users.RequireLogin(helpers.WithCacheTier(helpers.CacheTierNone)(next))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("export") != "" {
+			export.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // csrfSkipper is an http middleware that skips CSRF checks for requests that
 // match certain criteria.
 func csrfSkipper(next http.Handler) http.Handler {
@@ -483,6 +819,31 @@ func csrfSkipper(next http.Handler) http.Handler {
 		if (r.Method == "GET" && r.URL.Path != "/api/v1/csrf") || r.Method == "HEAD" {
 			r = csrf.UnsafeSkipCheck(r)
 		}
+		// Skip checks for content security policy violations reports, which
+		// are sent by browsers without any token
+		if r.URL.Path == "/api/v1/csp-report" {
+			r = csrf.UnsafeSkipCheck(r)
+		}
+		// Skip checks for email provider webhooks requests, which are verified
+		// by the webhooks processor
+		if strings.HasPrefix(r.URL.Path, "/api/v1/email/webhooks/") {
+			r = csrf.UnsafeSkipCheck(r)
+		}
+		// Skip checks for GitHub App webhooks requests, which are verified
+		// using the webhook secret
+		if r.URL.Path == "/api/v1/github-app/webhook" {
+			r = csrf.UnsafeSkipCheck(r)
+		}
+		// Skip checks for admin requests, which are authenticated using the
+		// admin token provided in the authorization header
+		if strings.HasPrefix(r.URL.Path, "/api/v1/admin/") {
+			r = csrf.UnsafeSkipCheck(r)
+		}
+		// Skip checks for metadata files validation requests, which do not
+		// modify any state and are usually sent from CI pipelines
+		if strings.HasPrefix(r.URL.Path, "/api/v1/metadata/validate/") {
+			r = csrf.UnsafeSkipCheck(r)
+		}
 		next.ServeHTTP(w, r)
 	})
 }
@@ -522,23 +883,164 @@ func logger(next http.Handler) http.Handler {
 	})
 }
 
-// realIP is an http middleware that sets the request remote addr to the result
-// of extracting the IP in the requested index from the X-Forwarded-For header.
-// Positives indexes start by 0 and work like usual slice indexes. Negative
-// indexes are allowed being -1 the last entry in the slice, -2 the next, etc.
-func realIP(i int) func(next http.Handler) http.Handler {
+// configurableCORS is an http middleware that handles CORS requests, including
+// preflight ones, based on the CORS settings available in the configuration
+// provided. This allows third party front-ends to call the API from browsers.
+func configurableCORS(cfg *viper.Viper) func(next http.Handler) http.Handler {
+	allowedHeaders := []string{
+		"Accept",
+		"Content-Type",
+		csrfHeader,
+		user.APIKeyIDHeader,
+		user.APIKeySecretHeader,
+	}
+	allowedHeaders = append(allowedHeaders, cfg.GetStringSlice("server.cors.allowedHeaders")...)
+	return cors.New(cors.Options{
+		AllowedOrigins: cfg.GetStringSlice("server.cors.allowedOrigins"),
+		AllowedMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodDelete,
+		},
+		AllowedHeaders:   allowedHeaders,
+		ExposedHeaders:   []string{helpers.PaginationTotalCount},
+		AllowCredentials: cfg.GetBool("server.cors.allowCredentials"),
+		MaxAge:           cfg.GetInt("server.cors.maxAge"),
+	}).Handler
+}
+
+// validateCORSConfig checks that the CORS settings available in the
+// configuration provided are safe. Credentials can only be allowed when an
+// explicit list of origins is provided, and wildcards cannot be used in it.
+func validateCORSConfig(cfg *viper.Viper) error {
+	if !cfg.GetBool("server.cors.allowCredentials") {
+		return nil
+	}
+	allowedOrigins := cfg.GetStringSlice("server.cors.allowedOrigins")
+	if len(allowedOrigins) == 0 {
+		return errors.New("allowed origins must be provided when credentials are allowed")
+	}
+	for _, origin := range allowedOrigins {
+		if strings.Contains(origin, "*") {
+			return fmt.Errorf("wildcard origin %q not allowed when credentials are allowed", origin)
+		}
+	}
+	return nil
+}
+
+// securityHeadersOptions returns the options used to set the security related
+// headers (i.e. HSTS) in the responses, applying the overrides available in
+// the configuration provided. The content security policy is set when serving
+// the web application, as other content (i.e. docs) may require a different
+// one.
+func securityHeadersOptions(cfg *viper.Viper) secure.Options {
+	stsSeconds := int64(31536000)
+	if cfg.IsSet("server.securityHeaders.hsts.maxAge") {
+		stsSeconds = cfg.GetInt64("server.securityHeaders.hsts.maxAge")
+	}
+	stsIncludeSubdomains := true
+	if cfg.IsSet("server.securityHeaders.hsts.includeSubdomains") {
+		stsIncludeSubdomains = cfg.GetBool("server.securityHeaders.hsts.includeSubdomains")
+	}
+	stsPreload := true
+	if cfg.IsSet("server.securityHeaders.hsts.preload") {
+		stsPreload = cfg.GetBool("server.securityHeaders.hsts.preload")
+	}
+	return secure.Options{
+		SSLProxyHeaders:         map[string]string{"X-Forwarded-Proto": "https"},
+		STSSeconds:              stsSeconds,
+		STSIncludeSubdomains:    stsIncludeSubdomains,
+		STSPreload:              stsPreload,
+		ContentTypeNosniff:      true,
+		ReferrerPolicy:          cfg.GetString("server.securityHeaders.referrerPolicy"),
+		CustomFrameOptionsValue: cfg.GetString("server.securityHeaders.frameOptions"),
+	}
+}
+
+// realIP is an http middleware that derives the real client ip of the request
+// and makes it available in the request context (hub.ClientIPKey) and remote
+// addr. It is used to rate limit API requests, when registering and checking
+// user sessions (to detect the ones used from a different ip), recording admin
+// audit entries and blocklist decisions, and checking the CIDR ranges API keys
+// are restricted to.
+//
+// When some trusted proxies are provided, the X-Forwarded-For header is only
+// considered if the request comes from one of them. In that case, the header
+// entries are processed from right to left, skipping the ones belonging to
+// trusted proxies, and the first one that does not is used as the client ip.
+//
+// Otherwise, the ip in the requested index of the X-Forwarded-For header is
+// used. Positives indexes start by 0 and work like usual slice indexes.
+// Negative indexes are allowed being -1 the last entry in the slice, -2 the
+// next, etc.
+func realIP(i int, trustedProxies []*net.IPNet) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if xff := r.Header.Get(xForwardedFor); xff != "" {
-				ips := strings.Split(xff, ",")
+			peerIP, _, _ := net.SplitHostPort(r.RemoteAddr)
+			ip := peerIP
+			var ips []string
+			for _, xff := range r.Header.Values(xForwardedFor) {
+				for _, entry := range strings.Split(xff, ",") {
+					ips = append(ips, strings.TrimSpace(entry))
+				}
+			}
+			if len(trustedProxies) > 0 {
+				if isTrustedProxy(ip, trustedProxies) {
+					for j := len(ips) - 1; j >= 0; j-- {
+						if net.ParseIP(ips[j]) == nil {
+							break
+						}
+						ip = ips[j]
+						if !isTrustedProxy(ip, trustedProxies) {
+							break
+						}
+					}
+				}
+			} else if len(ips) > 0 && ips[0] != "" {
 				if i >= 0 && len(ips) > i {
-					r.RemoteAddr = strings.TrimSpace(ips[i]) + ":"
+					ip = ips[i]
 				}
 				if i < 0 && len(ips)+i >= 0 {
-					r.RemoteAddr = strings.TrimSpace(ips[len(ips)+i]) + ":"
+					ip = ips[len(ips)+i]
 				}
 			}
+			if ip != peerIP {
+				r.RemoteAddr = ip + ":"
+			}
+			r = r.WithContext(context.WithValue(r.Context(), hub.ClientIPKey, ip))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimit is an http middleware that limits the rate of requests allowed for
+// each client ip, as derived by the real ip middleware.
+func rateLimit(l *util.KeyRateLimiter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !l.Allow(helpers.GetClientIP(r)) {
+				w.Header().Set("Retry-After", "1")
+				helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrTooManyRequests, "rate limit exceeded"))
+				return
+			}
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// isTrustedProxy checks if the ip provided belongs to any of the trusted
+// proxies networks given.
+func isTrustedProxy(ip string, trustedProxies []*net.IPNet) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	for _, ipNet := range trustedProxies {
+		if ipNet.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
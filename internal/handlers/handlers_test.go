@@ -1,18 +1,175 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/artifacthub/hub/internal/apikey"
+	"github.com/artifacthub/hub/internal/handlers/user"
+	"github.com/artifacthub/hub/internal/hub"
+	usermgr "github.com/artifacthub/hub/internal/user"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/gorilla/csrf"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func TestConfigurableCORS(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("server.cors.allowedOrigins", []string{"https://frontend.example.com"})
+	cfg.Set("server.cors.allowedHeaders", []string{"X-Custom"})
+	cfg.Set("server.cors.allowCredentials", true)
+	cfg.Set("server.cors.maxAge", 600)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("preflight request from allowed origin", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("OPTIONS", "/api/v1/packages/starred", nil)
+		r.Header.Set("Origin", "https://frontend.example.com")
+		r.Header.Set("Access-Control-Request-Method", "PUT")
+		r.Header.Set("Access-Control-Request-Headers", "X-API-KEY-ID, X-Custom")
+		configurableCORS(cfg)(next).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "https://frontend.example.com", h.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "PUT", h.Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "X-Api-Key-Id, X-Custom", h.Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "true", h.Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "600", h.Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("preflight request from origin not allowed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("OPTIONS", "/api/v1/packages/starred", nil)
+		r.Header.Set("Origin", "https://other.example.com")
+		r.Header.Set("Access-Control-Request-Method", "PUT")
+		configurableCORS(cfg)(next).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("actual request from allowed origin", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/api/v1/packages/starred", nil)
+		r.Header.Set("Origin", "https://frontend.example.com")
+		configurableCORS(cfg)(next).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "https://frontend.example.com", h.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "Pagination-Total-Count", h.Get("Access-Control-Expose-Headers"))
+	})
+}
+
+func TestValidateCORSConfig(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		allowedOrigins   []string
+		allowCredentials bool
+		errMsg           string
+	}{
+		{
+			"credentials not allowed, no origins",
+			nil,
+			false,
+			"",
+		},
+		{
+			"credentials allowed, explicit origins",
+			[]string{"https://frontend.example.com"},
+			true,
+			"",
+		},
+		{
+			"credentials allowed, no origins",
+			nil,
+			true,
+			"allowed origins must be provided",
+		},
+		{
+			"credentials allowed, wildcard origin",
+			[]string{"https://frontend.example.com", "*"},
+			true,
+			"wildcard origin",
+		},
+		{
+			"credentials allowed, wildcard subdomain origin",
+			[]string{"https://*.example.com"},
+			true,
+			"wildcard origin",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			cfg := viper.New()
+			cfg.Set("server.cors.allowedOrigins", tc.allowedOrigins)
+			cfg.Set("server.cors.allowCredentials", tc.allowCredentials)
+			err := validateCORSConfig(cfg)
+			if tc.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestSecurityHeadersOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+		opts := securityHeadersOptions(viper.New())
+
+		assert.Equal(t, int64(31536000), opts.STSSeconds)
+		assert.True(t, opts.STSIncludeSubdomains)
+		assert.True(t, opts.STSPreload)
+		assert.True(t, opts.ContentTypeNosniff)
+		assert.Empty(t, opts.ReferrerPolicy)
+		assert.Empty(t, opts.CustomFrameOptionsValue)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.securityHeaders.hsts.maxAge", 3600)
+		cfg.Set("server.securityHeaders.hsts.includeSubdomains", false)
+		cfg.Set("server.securityHeaders.hsts.preload", false)
+		cfg.Set("server.securityHeaders.referrerPolicy", "strict-origin-when-cross-origin")
+		cfg.Set("server.securityHeaders.frameOptions", "SAMEORIGIN")
+		opts := securityHeadersOptions(cfg)
+
+		assert.Equal(t, int64(3600), opts.STSSeconds)
+		assert.False(t, opts.STSIncludeSubdomains)
+		assert.False(t, opts.STSPreload)
+		assert.Equal(t, "strict-origin-when-cross-origin", opts.ReferrerPolicy)
+		assert.Equal(t, "SAMEORIGIN", opts.CustomFrameOptionsValue)
+	})
+}
+
 func TestRealIP(t *testing.T) {
 	checkRemoteAddr := func(expectedRemoteAddr string) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
 			assert.Equal(t, expectedRemoteAddr, r.RemoteAddr)
+			assert.Equal(t, strings.TrimSuffix(expectedRemoteAddr, ":"), r.Context().Value(hub.ClientIPKey))
 		}
 	}
 
@@ -104,7 +261,240 @@ func TestRealIP(t *testing.T) {
 					xForwardedFor: []string{tc.xForwardedFor},
 				},
 			}
-			realIP(tc.xffIndex)(checkRemoteAddr(tc.expectedRemoteAddr)).ServeHTTP(w, r)
+			realIP(tc.xffIndex, nil)(checkRemoteAddr(tc.expectedRemoteAddr)).ServeHTTP(w, r)
+		})
+	}
+
+	t.Run("trusted proxies", func(t *testing.T) {
+		trustedProxies, err := util.ParseCIDRs([]string{"10.0.0.0/8", "1.1.1.1/32"})
+		require.NoError(t, err)
+
+		testCases := []struct {
+			remoteAddr         string
+			xForwardedFor      string
+			expectedRemoteAddr string
+		}{
+			{
+				"1.1.1.1:",
+				"",
+				"1.1.1.1:",
+			},
+			{
+				"8.8.8.8:",
+				"2.2.2.2",
+				"8.8.8.8:",
+			},
+			{
+				"1.1.1.1:",
+				"2.2.2.2",
+				"2.2.2.2:",
+			},
+			{
+				"1.1.1.1:",
+				"2.2.2.2, 3.3.3.3",
+				"3.3.3.3:",
+			},
+			{
+				"1.1.1.1:",
+				"2.2.2.2, 3.3.3.3, 10.0.0.1",
+				"3.3.3.3:",
+			},
+			{
+				"1.1.1.1:",
+				"10.0.0.2, 10.0.0.1",
+				"10.0.0.2:",
+			},
+			{
+				"1.1.1.1:",
+				"2.2.2.2, invalid, 10.0.0.1",
+				"10.0.0.1:",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			desc := fmt.Sprintf("Remote addr: %s XFF: %s", tc.remoteAddr, tc.xForwardedFor)
+			t.Run(desc, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r := &http.Request{
+					RemoteAddr: tc.remoteAddr,
+					Header: http.Header{
+						xForwardedFor: []string{tc.xForwardedFor},
+					},
+				}
+				realIP(0, trustedProxies)(checkRemoteAddr(tc.expectedRemoteAddr)).ServeHTTP(w, r)
+			})
+		}
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	l := util.NewKeyRateLimiter(1, 1)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	testCases := []struct {
+		clientIP           string
+		expectedStatusCode int
+	}{
+		{"1.1.1.1", http.StatusOK},
+		{"1.1.1.1", http.StatusTooManyRequests},
+		{"2.2.2.2", http.StatusOK},
+	}
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.ClientIPKey, tc.clientIP))
+		rateLimit(l)(next).ServeHTTP(w, r)
+		resp := w.Result()
+		resp.Body.Close()
+
+		assert.Equal(t, tc.expectedStatusCode, resp.StatusCode, tc.clientIP)
+		if tc.expectedStatusCode == http.StatusTooManyRequests {
+			assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+		}
+	}
+}
+
+func TestRequireLoginToExport(t *testing.T) {
+	uh, err := user.NewHandlers(context.Background(), &usermgr.ManagerMock{}, &apikey.ManagerMock{}, &apikey.UsageTrackerMock{}, viper.New())
+	require.NoError(t, err)
+	h := &Handlers{Users: uh}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := []struct {
+		path               string
+		expectedStatusCode int
+	}{
+		{"/api/v1/packages/search?ts_query_web=test", http.StatusOK},
+		{"/api/v1/packages/search?export=csv", http.StatusUnauthorized},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", tc.path, nil)
+			h.requireLoginToExport(next).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestCSRFSkipper(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	protect := csrf.Protect([]byte("authKey"), csrf.Path("/api/v1"), csrf.CookieName("csrf"))
+	h := csrfSkipper(protect(next))
+
+	testCases := []struct {
+		method             string
+		path               string
+		apiKey             bool
+		expectedStatusCode int
+	}{
+		{"GET", "/api/v1/packages/search", false, http.StatusOK},
+		{"HEAD", "/api/v1/check-availability/userAlias", false, http.StatusOK},
+		{"POST", "/api/v1/email/webhooks/ses", false, http.StatusOK},
+		{"PUT", "/api/v1/admin/users/userID/disable", false, http.StatusOK},
+		{"POST", "/api/v1/metadata/validate/package", false, http.StatusOK},
+		{"POST", "/api/v1/csp-report", false, http.StatusOK},
+		{"POST", "/api/v1/github-app/webhook", false, http.StatusOK},
+		{"POST", "/api/v1/repositories/user", true, http.StatusOK},
+		{"PUT", "/api/v1/users/profile", true, http.StatusOK},
+		{"POST", "/api/v1/repositories/user", false, http.StatusForbidden},
+		{"PUT", "/api/v1/users/profile", false, http.StatusForbidden},
+		{"DELETE", "/api/v1/api-keys/keyID", false, http.StatusForbidden},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("%s %s (api key: %t)", tc.method, tc.path, tc.apiKey), func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, tc.path, nil)
+			if tc.apiKey {
+				r.Header.Set(user.APIKeyIDHeader, "keyID")
+				r.Header.Set(user.APIKeySecretHeader, "secret")
+			}
+			h.ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+		})
+	}
+
+	t.Run("session based request providing a valid token", func(t *testing.T) {
+		t.Parallel()
+
+		// Get token
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/api/v1/csrf", nil)
+		var token string
+		csrfSkipper(protect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token = csrf.Token(r)
+		}))).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		require.NotEmpty(t, token)
+		require.NotEmpty(t, resp.Cookies())
+
+		// Use token in a mutating request
+		w = httptest.NewRecorder()
+		r, _ = http.NewRequest("PUT", "/api/v1/users/profile", nil)
+		r.Header.Set(csrfHeader, token)
+		for _, c := range resp.Cookies() {
+			r.AddCookie(c)
+		}
+		h.ServeHTTP(w, r)
+		resp2 := w.Result()
+		defer resp2.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp2.StatusCode)
+	})
+}
+
+func TestPrivateModeAPI(t *testing.T) {
+	uh, err := user.NewHandlers(context.Background(), &usermgr.ManagerMock{}, &apikey.ManagerMock{}, &apikey.UsageTrackerMock{}, viper.New())
+	require.NoError(t, err)
+	h := &Handlers{Users: uh}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := []struct {
+		method             string
+		path               string
+		expectedStatusCode int
+	}{
+		{"GET", "/api/v1/csrf", http.StatusOK},
+		{"POST", "/api/v1/csp-report", http.StatusOK},
+		{"POST", "/api/v1/users", http.StatusOK},
+		{"POST", "/api/v1/users/", http.StatusOK},
+		{"POST", "/api/v1/users/login", http.StatusOK},
+		{"HEAD", "/api/v1/check-availability/userAlias", http.StatusOK},
+		{"POST", "/api/v1/email/webhooks/ses", http.StatusOK},
+		{"POST", "/api/v1/github-app/webhook", http.StatusOK},
+		{"PUT", "/api/v1/admin/users/userID/disable", http.StatusOK},
+		{"HEAD", "/api/v1/check-availability/repositoryName", http.StatusUnauthorized},
+		{"GET", "/api/v1/packages/search", http.StatusUnauthorized},
+		{"GET", "/api/v1/stats", http.StatusUnauthorized},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(tc.method, tc.path, nil)
+			h.privateModeAPI(next).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
 		})
 	}
 }
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// Handlers represents a group of http handlers in charge of handling the hub
+// health checks and the related admin operations.
+type Handlers struct {
+	healthChecker hub.HealthChecker
+	adminToken    string
+	logger        zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(healthChecker hub.HealthChecker, cfg *viper.Viper) *Handlers {
+	return &Handlers{
+		healthChecker: healthChecker,
+		adminToken:    cfg.GetString("server.admin.token"),
+		logger:        log.With().Str("handlers", "health").Logger(),
+	}
+}
+
+// GetMigrations is an http handler that returns the database schema
+// migrations that have been applied and the ones still pending.
+func (h *Handlers) GetMigrations(w http.ResponseWriter, r *http.Request) {
+	status, err := h.healthChecker.GetMigrationsStatus(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetMigrations").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(status)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Liveness is an http handler used to check if the hub is alive. It does not
+// check any of the hub dependencies, as a failure on any of them should not
+// cause the hub to be restarted.
+func (h *Handlers) Liveness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(0))
+	_, _ = w.Write([]byte("OK"))
+}
+
+// Readiness is an http handler used to check if the hub is ready to serve
+// requests. The result of each of the checks performed is returned.
+func (h *Handlers) Readiness(w http.ResponseWriter, r *http.Request) {
+	status := h.healthChecker.CheckReadiness(r.Context())
+	code := http.StatusOK
+	if !status.Healthy {
+		h.logger.Warn().Interface("checks", status.Checks).Msg("hub not ready")
+		code = http.StatusServiceUnavailable
+	}
+	dataJSON, _ := json.Marshal(status)
+	helpers.RenderJSON(w, dataJSON, 0, code)
+}
+
+// RequireAdminToken is an http middleware that checks the request provides
+// the configured admin token as a bearer token. When no admin token has been
+// configured, the admin endpoints are not available.
+func (h *Handlers) RequireAdminToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.adminToken == "" {
+			helpers.RenderErrorJSON(w, hub.ErrNotFound)
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,169 @@
+package health
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/health"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestGetMigrations(t *testing.T) {
+	t.Run("error getting migrations status", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper("")
+		hw.hc.On("GetMigrationsStatus", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetMigrations(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.hc.AssertExpectations(t)
+	})
+
+	t.Run("get migrations status succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper("")
+		hw.hc.On("GetMigrationsStatus", r.Context()).Return(&hub.MigrationsStatus{
+			CurrentVersion:  1,
+			ExpectedVersion: 2,
+			Applied:         []*hub.Migration{{Version: 1, Name: "initial"}},
+			Pending:         []*hub.Migration{{Version: 2, Name: "users"}},
+		}, nil)
+		hw.h.GetMigrations(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{
+			"current_version": 1,
+			"expected_version": 2,
+			"applied": [{"version": 1, "name": "initial"}],
+			"pending": [{"version": 2, "name": "users"}]
+		}`, string(data))
+		hw.hc.AssertExpectations(t)
+	})
+}
+
+func TestLiveness(t *testing.T) {
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest("GET", "/", nil)
+
+	hw := newHandlersWrapper("")
+	hw.h.Liveness(w, r)
+	resp := w.Result()
+	defer resp.Body.Close()
+	data, _ := ioutil.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "OK", string(data))
+}
+
+func TestReadiness(t *testing.T) {
+	testCases := []struct {
+		status             *hub.HealthStatus
+		expectedStatusCode int
+	}{
+		{
+			&hub.HealthStatus{
+				Healthy: true,
+				Checks:  []*hub.HealthCheck{{Name: "database", Healthy: true}},
+			},
+			http.StatusOK,
+		},
+		{
+			&hub.HealthStatus{
+				Healthy: false,
+				Checks:  []*hub.HealthCheck{{Name: "database", Healthy: false, Error: "fake"}},
+			},
+			http.StatusServiceUnavailable,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(http.StatusText(tc.expectedStatusCode), func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+
+			hw := newHandlersWrapper("")
+			hw.hc.On("CheckReadiness", r.Context()).Return(tc.status)
+			hw.h.Readiness(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			hw.hc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRequireAdminToken(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		adminToken         string
+		authHeader         string
+		expectedStatusCode int
+	}{
+		{"admin token not configured", "", "Bearer token", http.StatusNotFound},
+		{"token not provided", "token", "", http.StatusUnauthorized},
+		{"invalid token provided", "token", "Bearer invalid", http.StatusUnauthorized},
+		{"valid token provided", "token", "Bearer token", http.StatusOK},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.Header.Set("Authorization", tc.authHeader)
+
+			hw := newHandlersWrapper(tc.adminToken)
+			hw.h.RequireAdminToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+type handlersWrapper struct {
+	hc *health.CheckerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper(adminToken string) *handlersWrapper {
+	cfg := viper.New()
+	cfg.Set("server.admin.token", adminToken)
+	hc := &health.CheckerMock{}
+
+	return &handlersWrapper{
+		hc: hc,
+		h:  NewHandlers(hc, cfg),
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
@@ -28,14 +30,125 @@ const (
 	// PaginationTotalCount represents a header used to indicate the number of
 	// entries available for pagination purposes.
 	PaginationTotalCount = "Pagination-Total-Count"
+
+	// ShortCacheMaxAge represents the cache duration used by the endpoints
+	// in the short cache tier.
+	ShortCacheMaxAge = 1 * time.Minute
+
+	// ImmutableCacheMaxAge represents the cache duration used by the
+	// endpoints in the immutable cache tier.
+	ImmutableCacheMaxAge = 365 * 24 * time.Hour
 )
 
+// CacheTier represents a caching policy that can be applied to a group of
+// routes.
+type CacheTier int
+
+const (
+	// CacheTierNone disables caching. It's used for user specific data.
+	CacheTierNone CacheTier = iota
+
+	// CacheTierShort allows caching responses for a short period of time. It's
+	// used for data that changes frequently, like search results.
+	CacheTierShort
+
+	// CacheTierImmutable allows caching responses forever. It's used for
+	// static assets, whose names change when their content does.
+	CacheTierImmutable
+)
+
+// CacheControlHeader returns the Cache-Control header value of the tier.
+func (t CacheTier) CacheControlHeader() string {
+	switch t {
+	case CacheTierShort:
+		return BuildCacheControlHeader(ShortCacheMaxAge)
+	case CacheTierImmutable:
+		return "public, " + BuildCacheControlHeader(ImmutableCacheMaxAge) + ", immutable"
+	default:
+		return "private, no-store"
+	}
+}
+
 // BuildCacheControlHeader builds an http cache header using the max age
 // duration provided.
 func BuildCacheControlHeader(cacheMaxAge time.Duration) string {
 	return fmt.Sprintf("max-age=%d", int64(cacheMaxAge.Seconds()))
 }
 
+// WithCacheTier is an http middleware that applies the cache tier provided to
+// the responses of the routes it's used on, overriding the cache header set by
+// the handlers. Error responses are only affected by the none tier, so that
+// they are never cached for longer than the handlers decided.
+func WithCacheTier(tier CacheTier) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(&cacheTierWriter{ResponseWriter: w, tier: tier}, r)
+		})
+	}
+}
+
+// cacheTierWriter is an http response writer that sets the cache header of a
+// given cache tier just before the response headers are written.
+type cacheTierWriter struct {
+	http.ResponseWriter
+	tier        CacheTier
+	wroteHeader bool
+}
+
+// WriteHeader implements the http.ResponseWriter interface.
+func (w *cacheTierWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if w.tier == CacheTierNone || code < http.StatusBadRequest {
+			w.Header().Set("Cache-Control", w.tier.CacheControlHeader())
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements the http.ResponseWriter interface.
+func (w *cacheTierWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// EscapeCSVCell prefixes the value provided with a single quote when it starts
+// with a character that spreadsheet applications may interpret as the start
+// of a formula, preventing CSV formula injection.
+func EscapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// GetAdminAuditInfo builds the admin audit info of the request provided. The
+// reason of the operation can be optionally provided in the request body. The
+// admin performing the operation is the user authenticated in the request.
+func GetAdminAuditInfo(r *http.Request) (*hub.AdminAuditInfo, error) {
+	info := &hub.AdminAuditInfo{}
+	if err := json.NewDecoder(r.Body).Decode(info); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	info.AdminUserID, _ = r.Context().Value(hub.UserIDKey).(string)
+	info.IP = GetClientIP(r)
+	info.UserAgent = r.UserAgent()
+	return info, nil
+}
+
+// GetClientIP returns the client ip of the request provided, as derived by the
+// real ip middleware taking into account the trusted proxies configured. When
+// it is not available, the ip is extracted from the request remote address.
+func GetClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(hub.ClientIPKey).(string); ok {
+		return ip
+	}
+	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+	return ip
+}
+
 // GetPagination is a helper that extracts the pagination information from the
 // query string values provided.
 func GetPagination(qs url.Values, defaultLimit, maxLimit int) (*hub.Pagination, error) {
@@ -94,12 +207,39 @@ func RenderErrorJSON(w http.ResponseWriter, err error) {
 		w.WriteHeader(http.StatusForbidden)
 	case errors.Is(err, hub.ErrNotFound):
 		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, hub.ErrTooManyRequests):
+		w.WriteHeader(http.StatusTooManyRequests)
+		errMsg = err.Error()
+	case errors.Is(err, hub.ErrBlocked):
+		w.WriteHeader(http.StatusUnavailableForLegalReasons)
+		errMsg = err.Error()
+	case errors.Is(err, hub.ErrConflict):
+		w.WriteHeader(http.StatusConflict)
+		errMsg = err.Error()
+	case errors.Is(err, hub.ErrUnavailable):
+		w.WriteHeader(http.StatusServiceUnavailable)
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 	writeErrorJSON(w, errMsg)
 }
 
+// RenderBlockedJSON is a helper to write the error provided, returned when
+// the content requested has been blocked, to the given http response writer
+// as json. The contact provided, if any, is included in the payload so that
+// the requester knows how to appeal the decision.
+func RenderBlockedJSON(w http.ResponseWriter, err error, appealContact string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnavailableForLegalReasons)
+	data := map[string]interface{}{
+		"message": err.Error(),
+	}
+	if appealContact != "" {
+		data["appeal_contact"] = appealContact
+	}
+	_ = json.NewEncoder(w).Encode(data)
+}
+
 // RenderErrorWithCodeJSON is a helper to write the error provided to the given
 // http response writer as json setting the appropriate content type. Unlike
 // RenderErrorJSON, which decides what status code to use based on the type of
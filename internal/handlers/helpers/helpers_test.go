@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -9,6 +10,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,6 +47,187 @@ func TestBuildCacheControlHeader(t *testing.T) {
 	}
 }
 
+func TestCacheTier(t *testing.T) {
+	testCases := []struct {
+		tier                       CacheTier
+		expectedCacheControlHeader string
+	}{
+		{
+			CacheTierNone,
+			"private, no-store",
+		},
+		{
+			CacheTierShort,
+			"max-age=60",
+		},
+		{
+			CacheTierImmutable,
+			"public, max-age=31536000, immutable",
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedCacheControlHeader, tc.tier.CacheControlHeader())
+		})
+	}
+}
+
+func TestWithCacheTier(t *testing.T) {
+	testCases := []struct {
+		tier                       CacheTier
+		handlerCacheMaxAge         time.Duration
+		handlerStatusCode          int
+		expectedCacheControlHeader string
+	}{
+		{
+			CacheTierNone,
+			DefaultAPICacheMaxAge,
+			http.StatusOK,
+			"private, no-store",
+		},
+		{
+			CacheTierNone,
+			0,
+			http.StatusInternalServerError,
+			"private, no-store",
+		},
+		{
+			CacheTierShort,
+			DefaultAPICacheMaxAge,
+			http.StatusOK,
+			"max-age=60",
+		},
+		{
+			CacheTierShort,
+			0,
+			http.StatusBadRequest,
+			"max-age=0",
+		},
+		{
+			CacheTierImmutable,
+			0,
+			http.StatusNotModified,
+			"public, max-age=31536000, immutable",
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				RenderJSON(w, []byte("dataJSON"), tc.handlerCacheMaxAge, tc.handlerStatusCode)
+			})
+			WithCacheTier(tc.tier)(next).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.handlerStatusCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedCacheControlHeader, resp.Header.Get("Cache-Control"))
+		})
+	}
+
+	t.Run("header set on implicit write header", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("data"))
+		})
+		WithCacheTier(CacheTierImmutable)(next).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "public, max-age=31536000, immutable", resp.Header.Get("Cache-Control"))
+		assert.Equal(t, []byte("data"), data)
+	})
+}
+
+func TestEscapeCSVCell(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expectedValue string
+	}{
+		{"", ""},
+		{"value", "value"},
+		{"1", "1"},
+		{"=1+1", "'=1+1"},
+		{"+1", "'+1"},
+		{"-1", "'-1"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\tvalue", "'\tvalue"},
+		{"\rvalue", "'\rvalue"},
+		{"value=1", "value=1"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedValue, EscapeCSVCell(tc.value))
+		})
+	}
+}
+
+func TestGetAdminAuditInfo(t *testing.T) {
+	t.Run("reason provided in body", func(t *testing.T) {
+		t.Parallel()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"reason": "spam"}`))
+		r.RemoteAddr = "1.1.1.1:1234"
+		r.Header.Set("User-Agent", "ua")
+		info, err := GetAdminAuditInfo(r)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.AdminAuditInfo{Reason: "spam", IP: "1.1.1.1", UserAgent: "ua"}, info)
+	})
+
+	t.Run("admin user provided in body is ignored", func(t *testing.T) {
+		t.Parallel()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"admin_user_id": "other", "reason": "spam"}`))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "adminUserID"))
+		r.RemoteAddr = "1.1.1.1:1234"
+		info, err := GetAdminAuditInfo(r)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.AdminAuditInfo{AdminUserID: "adminUserID", Reason: "spam", IP: "1.1.1.1"}, info)
+	})
+
+	t.Run("no body provided", func(t *testing.T) {
+		t.Parallel()
+		r, _ := http.NewRequest("PUT", "/", http.NoBody)
+		r.RemoteAddr = "1.1.1.1:1234"
+		info, err := GetAdminAuditInfo(r)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.AdminAuditInfo{IP: "1.1.1.1"}, info)
+	})
+
+	t.Run("invalid body provided", func(t *testing.T) {
+		t.Parallel()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"reason"`))
+		_, err := GetAdminAuditInfo(r)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetClientIP(t *testing.T) {
+	t.Run("client ip available in context", func(t *testing.T) {
+		t.Parallel()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "1.1.1.1:1234"
+		r = r.WithContext(context.WithValue(r.Context(), hub.ClientIPKey, "2.2.2.2"))
+		assert.Equal(t, "2.2.2.2", GetClientIP(r))
+	})
+
+	t.Run("client ip not available in context", func(t *testing.T) {
+		t.Parallel()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "1.1.1.1:1234"
+		assert.Equal(t, "1.1.1.1", GetClientIP(r))
+	})
+}
+
 func TestGetPagination(t *testing.T) {
 	testCases := []struct {
 		qs                 url.Values
@@ -171,6 +354,38 @@ func TestRenderJSON(t *testing.T) {
 	}
 }
 
+func TestRenderBlockedJSON(t *testing.T) {
+	testCases := []struct {
+		appealContact string
+		expectedBody  string
+	}{
+		{
+			"",
+			`{"message": "content blocked: test reason"}`,
+		},
+		{
+			"appeals@artifacthub.io",
+			`{"message": "content blocked: test reason", "appeal_contact": "appeals@artifacthub.io"}`,
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			RenderBlockedJSON(w, fmt.Errorf("%w: test reason", hub.ErrBlocked), tc.appealContact)
+			resp := w.Result()
+			defer resp.Body.Close()
+			h := resp.Header
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusUnavailableForLegalReasons, resp.StatusCode)
+			assert.Equal(t, "application/json", h.Get("Content-Type"))
+			assert.JSONEq(t, tc.expectedBody, string(data))
+		})
+	}
+}
+
 func TestRenderErrorJSON(t *testing.T) {
 	testCases := []struct {
 		err                error
@@ -197,6 +412,26 @@ func TestRenderErrorJSON(t *testing.T) {
 			http.StatusNotFound,
 			"",
 		},
+		{
+			fmt.Errorf("%w: test error", hub.ErrTooManyRequests),
+			http.StatusTooManyRequests,
+			"too many requests: test error",
+		},
+		{
+			fmt.Errorf("%w: test reason", hub.ErrBlocked),
+			http.StatusUnavailableForLegalReasons,
+			"content blocked: test reason",
+		},
+		{
+			fmt.Errorf("%w: test error", hub.ErrConflict),
+			http.StatusConflict,
+			"conflict: test error",
+		},
+		{
+			fmt.Errorf("%w: test error", hub.ErrUnavailable),
+			http.StatusServiceUnavailable,
+			"",
+		},
 		{
 			tests.ErrFakeDB,
 			http.StatusInternalServerError,
@@ -0,0 +1,94 @@
+package inbox
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling the
+// notifications in the users' inbox.
+type Handlers struct {
+	inboxManager hub.InboxManager
+	logger       zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(inboxManager hub.InboxManager) *Handlers {
+	return &Handlers{
+		inboxManager: inboxManager,
+		logger:       log.With().Str("handlers", "inbox").Logger(),
+	}
+}
+
+// Get is an http handler that returns the notifications in the inbox of the
+// user doing the request.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	p, err := helpers.GetPagination(qs, helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	var unreadOnly bool
+	if qs.Get("unread_only") != "" {
+		unreadOnly, err = strconv.ParseBool(qs.Get("unread_only"))
+		if err != nil {
+			err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid unread only value")
+			h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Get").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+	}
+	result, err := h.inboxManager.GetJSON(r.Context(), unreadOnly, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
+// GetUnreadCount is an http handler that returns the number of notifications
+// not read yet in the inbox of the user doing the request.
+func (h *Handlers) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.inboxManager.GetUnreadCountJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetUnreadCount").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// MarkAllAsRead is an http handler that marks all the notifications in the
+// inbox of the user doing the request as read.
+func (h *Handlers) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
+	if err := h.inboxManager.MarkAllAsRead(r.Context()); err != nil {
+		h.logger.Error().Err(err).Str("method", "MarkAllAsRead").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAsRead is an http handler that marks the provided notification in the
+// inbox of the user doing the request as read.
+func (h *Handlers) MarkAsRead(w http.ResponseWriter, r *http.Request) {
+	inboxNotificationID := chi.URLParam(r, "inboxNotificationID")
+	if err := h.inboxManager.MarkAsRead(r.Context(), inboxNotificationID); err != nil {
+		h.logger.Error().Err(err).Str("method", "MarkAsRead").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,89 @@
+package issuetracker
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling issue
+// trackers integrations operations.
+type Handlers struct {
+	issueTrackerManager hub.IssueTrackerManager
+	logger              zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(issueTrackerManager hub.IssueTrackerManager) *Handlers {
+	return &Handlers{
+		issueTrackerManager: issueTrackerManager,
+		logger:              log.With().Str("handlers", "issueTracker").Logger(),
+	}
+}
+
+// Add is an http handler that adds the provided issue tracker integration to
+// the database.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	it := &hub.IssueTracker{}
+	if err := json.NewDecoder(r.Body).Decode(&it); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.issueTrackerManager.Add(r.Context(), orgName, it); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// Delete is an http handler that deletes the provided issue tracker
+// integration from the database.
+func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	issueTrackerID := chi.URLParam(r, "issueTrackerID")
+	if err := h.issueTrackerManager.Delete(r.Context(), issueTrackerID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetOwnedByOrg is an http handler that returns the issue trackers
+// integrations owned by the organization provided. The user doing the request
+// must belong to the organization.
+func (h *Handlers) GetOwnedByOrg(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.issueTrackerManager.GetOwnedByOrgJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetOwnedByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Update is an http handler that updates the provided issue tracker
+// integration in the database.
+func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
+	it := &hub.IssueTracker{}
+	if err := json.NewDecoder(r.Body).Decode(&it); err != nil {
+		h.logger.Error().Err(err).Str("method", "Update").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	it.IssueTrackerID = chi.URLParam(r, "issueTrackerID")
+	if err := h.issueTrackerManager.Update(r.Context(), it); err != nil {
+		h.logger.Error().Err(err).Str("method", "Update").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
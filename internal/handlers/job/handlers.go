@@ -0,0 +1,62 @@
+package job
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling the
+// background jobs operations.
+type Handlers struct {
+	jobManager hub.JobManager
+	logger     zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(jobManager hub.JobManager) *Handlers {
+	return &Handlers{
+		jobManager: jobManager,
+		logger:     log.With().Str("handlers", "job").Logger(),
+	}
+}
+
+// GetRuns is an http handler used by site admins to list the background jobs
+// runs, including their durations and failures.
+func (h *Handlers) GetRuns(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	p, err := helpers.GetPagination(qs, helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetRuns").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	input := &hub.GetJobRunsInput{
+		JobName: qs.Get("job_name"),
+		Limit:   p.Limit,
+		Offset:  p.Offset,
+	}
+	if v := qs.Get("only_failed"); v != "" {
+		input.OnlyFailed, err = strconv.ParseBool(v)
+		if err != nil {
+			err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid only failed value")
+			h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetRuns").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+	}
+	result, err := h.jobManager.GetRunsJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetRuns").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
@@ -0,0 +1,108 @@
+package job
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/job"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestGetRuns(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []string{
+			"limit=a",
+			"offset=a",
+			"only_failed=abc",
+		}
+		for _, qs := range testCases {
+			qs := qs
+			t.Run(qs, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?"+qs, nil)
+
+				hw := newHandlersWrapper()
+				hw.h.GetRuns(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.jm.AssertExpectations(t)
+			})
+		}
+	})
+
+	input := &hub.GetJobRunsInput{
+		JobName:    "job1",
+		OnlyFailed: true,
+		Limit:      10,
+		Offset:     1,
+	}
+
+	t.Run("error getting job runs", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?job_name=job1&only_failed=true&limit=10&offset=1", nil)
+
+		hw := newHandlersWrapper()
+		hw.jm.On("GetRunsJSON", r.Context(), input).Return(nil, tests.ErrFakeDB)
+		hw.h.GetRuns(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.jm.AssertExpectations(t)
+	})
+
+	t.Run("job runs returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?job_name=job1&only_failed=true&limit=10&offset=1", nil)
+
+		hw := newHandlersWrapper()
+		result := &hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}
+		hw.jm.On("GetRunsJSON", r.Context(), input).Return(result, nil)
+		hw.h.GetRuns(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, "1", h.Get(helpers.PaginationTotalCount))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.jm.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	jm *job.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	jm := &job.ManagerMock{}
+
+	return &handlersWrapper{
+		jm: jm,
+		h:  NewHandlers(jm),
+	}
+}
@@ -0,0 +1,80 @@
+package maintainer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling the
+// packages maintainers operations.
+type Handlers struct {
+	maintainerManager hub.MaintainerManager
+	logger            zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(maintainerManager hub.MaintainerManager) *Handlers {
+	return &Handlers{
+		maintainerManager: maintainerManager,
+		logger:            log.With().Str("handlers", "maintainer").Logger(),
+	}
+}
+
+// Contact is an http handler that relays the message provided to the
+// maintainer identified by the id given.
+func (h *Handlers) Contact(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Contact").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	maintainerID := chi.URLParam(r, "maintainerID")
+	if err := h.maintainerManager.Contact(r.Context(), maintainerID, input["message"]); err != nil {
+		h.logger.Error().Err(err).Str("method", "Contact").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterVerificationCode is an http handler that requests the verification
+// of the maintainer identified by the id provided.
+func (h *Handlers) RegisterVerificationCode(w http.ResponseWriter, r *http.Request) {
+	maintainerID := chi.URLParam(r, "maintainerID")
+	if err := h.maintainerManager.RegisterVerificationCode(r.Context(), maintainerID); err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterVerificationCode").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// Verify is an http handler used to verify a maintainer's email address.
+func (h *Handlers) Verify(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Verify").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	verified, err := h.maintainerManager.Verify(r.Context(), input["code"])
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Verify").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if !verified {
+		err := errors.New("maintainer verification code has expired")
+		helpers.RenderErrorWithCodeJSON(w, err, http.StatusGone)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,228 @@
+package maintainer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintainer"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+const maintainerID = "00000000-0000-0000-0000-000000000001"
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestContact(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"maintainerID"},
+			Values: []string{maintainerID},
+		},
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("message"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Contact(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.mm.AssertExpectations(t)
+	})
+
+	t.Run("contact maintainer", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"maintainer not found",
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				"contacts limit reached",
+				hub.ErrTooManyRequests,
+				http.StatusTooManyRequests,
+			},
+			{
+				"database error",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+			{
+				"message relayed successfully",
+				nil,
+				http.StatusNoContent,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"message": "hi"}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.mm.On("Contact", r.Context(), maintainerID, "hi").Return(tc.err)
+				hw.h.Contact(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.mm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRegisterVerificationCode(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"maintainerID"},
+			Values: []string{maintainerID},
+		},
+	}
+
+	testCases := []struct {
+		description        string
+		err                error
+		expectedStatusCode int
+	}{
+		{
+			"insufficient privilege",
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			"verification requested recently",
+			hub.ErrTooManyRequests,
+			http.StatusTooManyRequests,
+		},
+		{
+			"database error",
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+		{
+			"verification code registered successfully",
+			nil,
+			http.StatusCreated,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.mm.On("RegisterVerificationCode", r.Context(), maintainerID).Return(tc.err)
+			hw.h.RegisterVerificationCode(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.mm.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("code"))
+
+		hw := newHandlersWrapper()
+		hw.h.Verify(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.mm.AssertExpectations(t)
+	})
+
+	t.Run("verify maintainer", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			response           []interface{}
+			expectedStatusCode int
+		}{
+			{
+				"code not provided",
+				[]interface{}{false, hub.ErrInvalidInput},
+				http.StatusBadRequest,
+			},
+			{
+				"code not verified",
+				[]interface{}{false, nil},
+				http.StatusGone,
+			},
+			{
+				"code verified",
+				[]interface{}{true, nil},
+				http.StatusNoContent,
+			},
+			{
+				"database error",
+				[]interface{}{false, tests.ErrFakeDB},
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"code": "1234"}`))
+
+				hw := newHandlersWrapper()
+				hw.mm.On("Verify", r.Context(), "1234").Return(tc.response...)
+				hw.h.Verify(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.mm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+type handlersWrapper struct {
+	mm *maintainer.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	mm := &maintainer.ManagerMock{}
+
+	return &handlersWrapper{
+		mm: mm,
+		h:  NewHandlers(mm),
+	}
+}
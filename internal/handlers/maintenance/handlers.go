@@ -0,0 +1,94 @@
+package maintenance
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// errReadOnly represents the error returned when a request that may modify
+// some data is received while the maintenance mode is enabled.
+var errReadOnly = errors.New("the hub is in read-only mode due to maintenance, please try again later")
+
+// Handlers represents a group of http handlers in charge of handling the
+// maintenance mode operations.
+type Handlers struct {
+	maintenanceManager hub.MaintenanceManager
+	logger             zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(maintenanceManager hub.MaintenanceManager) *Handlers {
+	return &Handlers{
+		maintenanceManager: maintenanceManager,
+		logger:             log.With().Str("handlers", "maintenance").Logger(),
+	}
+}
+
+// Get is an http handler that returns the maintenance mode status.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	mm, err := h.maintenanceManager.Get(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(mm)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Set is an http handler used by site admins to enable or disable the
+// maintenance mode.
+func (h *Handlers) Set(w http.ResponseWriter, r *http.Request) {
+	mm := &hub.MaintenanceMode{}
+	if err := json.NewDecoder(r.Body).Decode(&mm); err != nil {
+		h.logger.Error().Err(err).Str("method", "Set").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.maintenanceManager.Set(r.Context(), mm); err != nil {
+		h.logger.Error().Err(err).Str("method", "Set").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	h.logger.Info().Bool("enabled", mm.Enabled).Msg("maintenance mode set by admin")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReadOnly is an http middleware that rejects the requests that may modify
+// some data while the maintenance mode is enabled. The admin endpoints are
+// not affected, so that the maintenance mode can be disabled.
+func (h *Handlers) ReadOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/v1/admin/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		mm, err := h.maintenanceManager.Get(r.Context())
+		if err != nil {
+			// We don't want the API to become unavailable if the maintenance
+			// mode status cannot be checked
+			h.logger.Error().Err(err).Str("method", "ReadOnly").Send()
+			next.ServeHTTP(w, r)
+			return
+		}
+		if mm.Enabled {
+			w.Header().Set("Retry-After", strconv.Itoa(mm.RetryAfter))
+			helpers.RenderErrorWithCodeJSON(w, errReadOnly, http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,227 @@
+package maintenance
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestGet(t *testing.T) {
+	t.Run("error getting maintenance mode status", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.mm.On("Get", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.Get(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.mm.AssertExpectations(t)
+	})
+
+	t.Run("maintenance mode status returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.mm.On("Get", r.Context()).Return(&hub.MaintenanceMode{Enabled: true, RetryAfter: 600}, nil)
+		hw.h.Get(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{"enabled": true, "retry_after": 600}`, string(data))
+		hw.mm.AssertExpectations(t)
+	})
+}
+
+func TestSet(t *testing.T) {
+	mmJSON := `{"enabled": true, "retry_after": 600}`
+	mm := &hub.MaintenanceMode{Enabled: true, RetryAfter: 600}
+
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("-"))
+
+		hw := newHandlersWrapper()
+		hw.h.Set(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.mm.AssertExpectations(t)
+	})
+
+	t.Run("error setting maintenance mode", func(t *testing.T) {
+		testCases := []struct {
+			mmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.mmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(mmJSON))
+
+				hw := newHandlersWrapper()
+				hw.mm.On("Set", r.Context(), mm).Return(tc.mmErr)
+				hw.h.Set(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.mm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("maintenance mode set successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(mmJSON))
+
+		hw := newHandlersWrapper()
+		hw.mm.On("Set", r.Context(), mm).Return(nil)
+		hw.h.Set(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.mm.AssertExpectations(t)
+	})
+}
+
+func TestReadOnly(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("requests that do not modify data are always allowed", func(t *testing.T) {
+		t.Parallel()
+		for _, method := range []string{"GET", "HEAD", "OPTIONS"} {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(method, "/api/v1/packages/search", nil)
+
+			hw := newHandlersWrapper()
+			hw.h.ReadOnly(next).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			hw.mm.AssertExpectations(t)
+		}
+	})
+
+	t.Run("admin requests are always allowed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/api/v1/admin/maintenance", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.ReadOnly(next).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.mm.AssertExpectations(t)
+	})
+
+	t.Run("error getting maintenance mode status", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/api/v1/repositories/user", nil)
+
+		hw := newHandlersWrapper()
+		hw.mm.On("Get", mock.Anything).Return(nil, tests.ErrFakeDB)
+		hw.h.ReadOnly(next).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.mm.AssertExpectations(t)
+	})
+
+	t.Run("maintenance mode disabled", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/api/v1/repositories/user", nil)
+
+		hw := newHandlersWrapper()
+		hw.mm.On("Get", mock.Anything).Return(&hub.MaintenanceMode{RetryAfter: 300}, nil)
+		hw.h.ReadOnly(next).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.mm.AssertExpectations(t)
+	})
+
+	t.Run("maintenance mode enabled", func(t *testing.T) {
+		t.Parallel()
+		for _, method := range []string{"POST", "PUT", "PATCH", "DELETE"} {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(method, "/api/v1/repositories/user", nil)
+
+			hw := newHandlersWrapper()
+			hw.mm.On("Get", mock.Anything).Return(&hub.MaintenanceMode{Enabled: true, RetryAfter: 600}, nil)
+			hw.h.ReadOnly(next).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			assert.Equal(t, "600", resp.Header.Get("Retry-After"))
+			assert.JSONEq(t, `{"message": "`+errReadOnly.Error()+`"}`, string(data))
+			hw.mm.AssertExpectations(t)
+		}
+	})
+}
+
+type handlersWrapper struct {
+	mm *maintenance.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	mm := &maintenance.ManagerMock{}
+
+	return &handlersWrapper{
+		mm: mm,
+		h:  NewHandlers(mm),
+	}
+}
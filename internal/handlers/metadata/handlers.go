@@ -0,0 +1,61 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/metadata"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxMetadataFileSize represents the maximum size of the metadata files
+	// that can be validated.
+	maxMetadataFileSize = 1 << 20 // 1MB
+)
+
+// Handlers represents a group of http handlers in charge of handling metadata
+// files operations.
+type Handlers struct {
+	logger zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers() *Handlers {
+	return &Handlers{
+		logger: log.With().Str("handlers", "metadata").Logger(),
+	}
+}
+
+// Validate is an http handler that validates the metadata file provided in
+// the request body, returning the problems found on it (if any).
+func (h *Handlers) Validate(w http.ResponseWriter, r *http.Request) {
+	kind := metadata.Kind(chi.URLParam(r, "kind"))
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxMetadataFileSize))
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "error reading metadata file (max size: 1MB)")
+		h.logger.Error().Err(err).Str("method", "Validate").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	result, err := metadata.Validate(kind, data)
+	if err != nil {
+		err = fmt.Errorf("%w: %v", hub.ErrInvalidInput, err)
+		h.logger.Error().Err(err).Str("method", "Validate").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, err := json.Marshal(result)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Validate").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
@@ -0,0 +1,93 @@
+package metadata
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("unknown metadata file kind", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("name: pkg1"))
+		rctx := &chi.Context{URLParams: chi.RouteParams{}}
+		rctx.URLParams.Add("kind", "unknown")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		NewHandlers().Validate(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("metadata file too big", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(strings.Repeat("a", maxMetadataFileSize+1))
+		r, _ := http.NewRequest("POST", "/", body)
+		rctx := &chi.Context{URLParams: chi.RouteParams{}}
+		rctx.URLParams.Add("kind", "package")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		NewHandlers().Validate(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("metadata file validated", func(t *testing.T) {
+		testCases := []struct {
+			kind         string
+			body         string
+			expectedJSON string
+		}{
+			{
+				"package",
+				"name: pkg1\ndisplayName: Package 1\ncreatedAt: 2020-01-20T00:00:00Z\ndescription: Sample description\n",
+				`{"kind":"package","errors":["invalid metadata: version not provided"]}`,
+			},
+			{
+				"repository",
+				"repositoryID: 00000000-0000-0000-0000-000000000001\n",
+				`{"kind":"repository","errors":[]}`,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.kind, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.body))
+				rctx := &chi.Context{URLParams: chi.RouteParams{}}
+				rctx.URLParams.Add("kind", tc.kind)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				NewHandlers().Validate(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				h := resp.Header
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "application/json", h.Get("Content-Type"))
+				assert.JSONEq(t, tc.expectedJSON, string(data))
+			})
+		}
+	})
+}
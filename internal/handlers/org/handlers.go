@@ -49,6 +49,24 @@ func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// AddDomain is an http handler that adds a domain to the provided
+// organization.
+func (h *Handlers) AddDomain(w http.ResponseWriter, r *http.Request) {
+	d := &hub.OrganizationDomain{}
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddDomain").Msg("invalid domain")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	if err := h.orgManager.AddDomain(r.Context(), orgName, d.Domain); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddDomain").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
 // AddMember is an http handler that adds a member to the provided organization.
 func (h *Handlers) AddMember(w http.ResponseWriter, r *http.Request) {
 	orgName := chi.URLParam(r, "orgName")
@@ -62,6 +80,19 @@ func (h *Handlers) AddMember(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// ApproveJoinRequest is an http handler that approves the request of a user to
+// join the provided organization.
+func (h *Handlers) ApproveJoinRequest(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.orgManager.ApproveJoinRequest(r.Context(), orgName, userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "ApproveJoinRequest").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // CheckAvailability is an http handler that checks the availability of a given
 // value for the provided resource kind.
 func (h *Handlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
@@ -104,6 +135,32 @@ func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// DeleteDomain is an http handler that deletes a domain from the provided
+// organization.
+func (h *Handlers) DeleteDomain(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	domain := chi.URLParam(r, "domain")
+	if err := h.orgManager.DeleteDomain(r.Context(), orgName, domain); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteDomain").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteJoinRequest is an http handler that rejects the request of a user to
+// join the provided organization.
+func (h *Handlers) DeleteJoinRequest(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.orgManager.DeleteJoinRequest(r.Context(), orgName, userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteJoinRequest").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // DeleteMember is an http handler that deletes a member from the provided
 // organization.
 func (h *Handlers) DeleteMember(w http.ResponseWriter, r *http.Request) {
@@ -129,6 +186,38 @@ func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
+// GetAuthorizationDecisions is an http handler that returns the most recent
+// authorization decisions made using the organization's custom policy.
+func (h *Handlers) GetAuthorizationDecisions(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	p, err := helpers.GetPagination(qs, helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetAuthorizationDecisions").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	var deniedOnly bool
+	if qs.Get("denied_only") != "" {
+		deniedOnly, err = strconv.ParseBool(qs.Get("denied_only"))
+		if err != nil {
+			err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid denied only value")
+			h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetAuthorizationDecisions").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+	}
+	orgName := chi.URLParam(r, "orgName")
+	result, err := h.orgManager.GetAuthorizationDecisionsJSON(r.Context(), orgName, deniedOnly, p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetAuthorizationDecisions").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
 // GetAuthorizationPolicy is an http handler that returns the organization's
 // authorization policy.
 func (h *Handlers) GetAuthorizationPolicy(w http.ResponseWriter, r *http.Request) {
@@ -162,6 +251,32 @@ func (h *Handlers) GetByUser(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
 
+// GetDomains is an http handler that returns the domains of the provided
+// organization.
+func (h *Handlers) GetDomains(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.orgManager.GetDomainsJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetDomains").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetJoinRequests is an http handler that returns the pending join requests of
+// the provided organization.
+func (h *Handlers) GetJoinRequests(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.orgManager.GetJoinRequestsJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetJoinRequests").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // GetMembers is an http handler that returns the members of the provided
 // organization.
 func (h *Handlers) GetMembers(w http.ResponseWriter, r *http.Request) {
@@ -183,6 +298,70 @@ func (h *Handlers) GetMembers(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
 
+// GetPublicProfile is an http handler that returns the public profile of the
+// organization requested.
+func (h *Handlers) GetPublicProfile(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.orgManager.GetPublicProfileJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetPublicProfile").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetSecurityOverview is an http handler that returns an overview of the
+// security reports of the packages owned by the provided organization.
+func (h *Handlers) GetSecurityOverview(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.orgManager.GetSecurityOverviewJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSecurityOverview").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Join is an http handler that allows the user doing the request to join the
+// provided organization using one of its verified domains. When the domain
+// requires the join request to be approved, an accepted status is returned.
+func (h *Handlers) Join(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	joinMode, err := h.orgManager.Join(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Join").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if joinMode == hub.RequestJoinMode {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestAuthorizationPolicy is an http handler that evaluates the authorization
+// policy provided against some sample inputs, without saving it.
+func (h *Handlers) TestAuthorizationPolicy(w http.ResponseWriter, r *http.Request) {
+	test := &hub.AuthorizationPolicyTest{}
+	if err := json.NewDecoder(r.Body).Decode(&test); err != nil {
+		h.logger.Error().Err(err).Str("method", "TestAuthorizationPolicy").Msg("invalid authorization policy test")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	output, err := h.orgManager.TestAuthorizationPolicy(r.Context(), orgName, test)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "TestAuthorizationPolicy").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(output)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // Update is an http handler that updates the provided organization in the
 // database.
 func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
@@ -219,6 +398,38 @@ func (h *Handlers) UpdateAuthorizationPolicy(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpdateDomain is an http handler that updates the join mode of the provided
+// organization domain.
+func (h *Handlers) UpdateDomain(w http.ResponseWriter, r *http.Request) {
+	d := &hub.OrganizationDomain{}
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateDomain").Msg("invalid domain")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	domain := chi.URLParam(r, "domain")
+	if err := h.orgManager.UpdateDomain(r.Context(), orgName, domain, d.JoinMode); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateDomain").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyDomain is an http handler that verifies the ownership of the provided
+// organization domain.
+func (h *Handlers) VerifyDomain(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	domain := chi.URLParam(r, "domain")
+	if err := h.orgManager.VerifyDomain(r.Context(), orgName, domain); err != nil {
+		h.logger.Error().Err(err).Str("method", "VerifyDomain").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // GetUserAllowedActions is an http handler that returns the actions the
 // requesting user is allowed to perform in the provided organization.
 func (h *Handlers) GetUserAllowedActions(w http.ResponseWriter, r *http.Request) {
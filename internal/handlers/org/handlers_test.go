@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -126,6 +127,96 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestAddDomain(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid domain provided", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			domainJSON  string
+		}{
+			{
+				"no domain provided",
+				"",
+			},
+			{
+				"invalid json",
+				"-",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.domainJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.h.AddDomain(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid domain provided", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				nil,
+				http.StatusCreated,
+			},
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			var desc string
+			if tc.omErr != nil {
+				desc = tc.omErr.Error()
+			}
+			t.Run(desc, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"domain": "example.com"}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("AddDomain", r.Context(), "org1", "example.com").Return(tc.omErr)
+				hw.h.AddDomain(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestAddMember(t *testing.T) {
 	testCases := []struct {
 		omErr              error
@@ -179,6 +270,59 @@ func TestAddMember(t *testing.T) {
 	}
 }
 
+func TestApproveJoinRequest(t *testing.T) {
+	testCases := []struct {
+		omErr              error
+		expectedStatusCode int
+	}{
+		{
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		var desc string
+		if tc.omErr != nil {
+			desc = tc.omErr.Error()
+		}
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("PUT", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"orgName", "userAlias"},
+					Values: []string{"org1", "user1"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("ApproveJoinRequest", r.Context(), "org1", "user1").Return(tc.omErr)
+			hw.h.ApproveJoinRequest(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCheckAvailability(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -375,6 +519,112 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestDeleteDomain(t *testing.T) {
+	testCases := []struct {
+		omErr              error
+		expectedStatusCode int
+	}{
+		{
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		var desc string
+		if tc.omErr != nil {
+			desc = tc.omErr.Error()
+		}
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("DELETE", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"orgName", "domain"},
+					Values: []string{"org1", "example.com"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("DeleteDomain", r.Context(), "org1", "example.com").Return(tc.omErr)
+			hw.h.DeleteDomain(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteJoinRequest(t *testing.T) {
+	testCases := []struct {
+		omErr              error
+		expectedStatusCode int
+	}{
+		{
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		var desc string
+		if tc.omErr != nil {
+			desc = tc.omErr.Error()
+		}
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("DELETE", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"orgName", "userAlias"},
+					Values: []string{"org1", "user1"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("DeleteJoinRequest", r.Context(), "org1", "user1").Return(tc.omErr)
+			hw.h.DeleteJoinRequest(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
+}
+
 func TestDeleteMember(t *testing.T) {
 	testCases := []struct {
 		omErr              error
@@ -494,7 +744,7 @@ func TestGet(t *testing.T) {
 	})
 }
 
-func TestGetAuthorizationPolicy(t *testing.T) {
+func TestGetAuthorizationDecisions(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
 			Keys:   []string{"orgName"},
@@ -502,58 +752,168 @@ func TestGetAuthorizationPolicy(t *testing.T) {
 		},
 	}
 
-	t.Run("error getting authorization policy", func(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
-			omErr              error
-			expectedStatusCode int
+			description string
+			qs          string
 		}{
 			{
-				hub.ErrInvalidInput,
-				http.StatusBadRequest,
+				"invalid limit",
+				"limit=a",
 			},
 			{
-				tests.ErrFakeDB,
-				http.StatusInternalServerError,
+				"invalid denied only value",
+				"denied_only=a",
 			},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.omErr.Error(), func(t *testing.T) {
+			t.Run(tc.description, func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("GET", "/", nil)
+				r, _ := http.NewRequest("GET", "/?"+tc.qs, nil)
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.om.On("GetAuthorizationPolicyJSON", r.Context(), "org1").Return(nil, tc.omErr)
-				hw.h.GetAuthorizationPolicy(w, r)
+				hw.h.GetAuthorizationDecisions(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
-				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 				hw.om.AssertExpectations(t)
 			})
 		}
 	})
 
-	t.Run("get authorization policy succeeded", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
-		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
-
-		hw := newHandlersWrapper()
-		hw.om.On("GetAuthorizationPolicyJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
-		hw.h.GetAuthorizationPolicy(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
-		h := resp.Header
-		data, _ := ioutil.ReadAll(resp.Body)
-
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
+	t.Run("error getting authorization decisions", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetAuthorizationDecisionsJSON", r.Context(), "org1", false, &hub.Pagination{
+					Limit:  10,
+					Offset: 1,
+				}).Return(nil, tc.omErr)
+				hw.h.GetAuthorizationDecisions(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get authorization decisions succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1&denied_only=true", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetAuthorizationDecisionsJSON", r.Context(), "org1", true, &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetAuthorizationDecisions(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestGetAuthorizationPolicy(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting authorization policy", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetAuthorizationPolicyJSON", r.Context(), "org1").Return(nil, tc.omErr)
+				hw.h.GetAuthorizationPolicy(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get authorization policy succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetAuthorizationPolicyJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetAuthorizationPolicy(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
 		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
 		assert.Equal(t, []byte("dataJSON"), data)
 		hw.om.AssertExpectations(t)
@@ -595,21 +955,434 @@ func TestGetByUser(t *testing.T) {
 		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 
-		hw := newHandlersWrapper()
-		hw.om.On("GetByUserJSON", r.Context(), &hub.Pagination{
-			Limit:  10,
-			Offset: 1,
-		}).Return(nil, tests.ErrFakeDB)
-		hw.h.GetByUser(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
+		hw := newHandlersWrapper()
+		hw.om.On("GetByUserJSON", r.Context(), &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(nil, tests.ErrFakeDB)
+		hw.h.GetByUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestGetDomains(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting domains", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetDomainsJSON", r.Context(), "org1").Return(nil, tc.omErr)
+				hw.h.GetDomains(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get domains succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetDomainsJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetDomains(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestGetJoinRequests(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting join requests", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetJoinRequestsJSON", r.Context(), "org1").Return(nil, tc.omErr)
+				hw.h.GetJoinRequests(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get join requests succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetJoinRequestsJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetJoinRequests(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestGetMembers(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting organization members", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetMembersJSON", r.Context(), "org1", &hub.Pagination{
+					Limit:  10,
+					Offset: 1,
+				}).Return(nil, tc.omErr)
+				hw.h.GetMembers(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get organization members succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetMembersJSON", r.Context(), "org1", &hub.Pagination{
+			Limit:  10,
+			Offset: 1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.GetMembers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestGetPublicProfile(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting public profile", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetPublicProfileJSON", r.Context(), "org1").Return(nil, tc.err)
+				hw.h.GetPublicProfile(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get public profile succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetPublicProfileJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetPublicProfile(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestGetSecurityOverview(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("error getting organization security overview", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.omErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("GetSecurityOverviewJSON", r.Context(), "org1").Return(nil, tc.omErr)
+				hw.h.GetSecurityOverview(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get organization security overview succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.om.On("GetSecurityOverviewJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetSecurityOverview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.om.AssertExpectations(t)
+	})
+}
+
+func TestJoin(t *testing.T) {
+	testCases := []struct {
+		joinMode           hub.OrganizationJoinMode
+		omErr              error
+		expectedStatusCode int
+	}{
+		{
+			hub.AutoJoinMode,
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			hub.RequestJoinMode,
+			nil,
+			http.StatusAccepted,
+		},
+		{
+			"",
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			"",
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			"",
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"orgName"},
+					Values: []string{"org1"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("Join", r.Context(), "org1").Return(tc.joinMode, tc.omErr)
+			hw.h.Join(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-		hw.om.AssertExpectations(t)
-	})
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
 }
 
-func TestGetMembers(t *testing.T) {
+func TestTestAuthorizationPolicy(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
 			Keys:   []string{"orgName"},
@@ -617,71 +1390,135 @@ func TestGetMembers(t *testing.T) {
 		},
 	}
 
-	t.Run("error getting organization members", func(t *testing.T) {
+	t.Run("invalid authorization policy test provided", func(t *testing.T) {
 		testCases := []struct {
-			omErr              error
-			expectedStatusCode int
+			description string
+			testJSON    string
+			omErr       error
 		}{
 			{
-				hub.ErrInvalidInput,
-				http.StatusBadRequest,
+				"no authorization policy test provided",
+				"",
+				nil,
 			},
 			{
-				tests.ErrFakeDB,
-				http.StatusInternalServerError,
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"no inputs provided",
+				`{"policy": {"predefined_policy": "rbac.v1"}}`,
+				hub.ErrInvalidInput,
 			},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.omErr.Error(), func(t *testing.T) {
+			t.Run(tc.description, func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.testJSON))
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.om.On("GetMembersJSON", r.Context(), "org1", &hub.Pagination{
-					Limit:  10,
-					Offset: 1,
-				}).Return(nil, tc.omErr)
-				hw.h.GetMembers(w, r)
+				if tc.omErr != nil {
+					hw.om.On("TestAuthorizationPolicy", r.Context(), "org1", mock.Anything).Return(nil, tc.omErr)
+				}
+				hw.h.TestAuthorizationPolicy(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
-				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 				hw.om.AssertExpectations(t)
 			})
 		}
 	})
 
-	t.Run("get organization members succeeded", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/?limit=10&offset=1", nil)
-		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	t.Run("valid authorization policy test provided", func(t *testing.T) {
+		testJSON := `
+		{
+			"policy": {
+				"predefined_policy": "rbac.v1",
+				"policy_data": "{\"k\": \"v\"}"
+			},
+			"inputs": [
+				{
+					"user": "user1",
+					"action": "updateOrganization"
+				}
+			]
+		}
+		`
+		test := &hub.AuthorizationPolicyTest{}
+		_ = json.Unmarshal([]byte(testJSON), &test)
 
-		hw := newHandlersWrapper()
-		hw.om.On("GetMembersJSON", r.Context(), "org1", &hub.Pagination{
-			Limit:  10,
-			Offset: 1,
-		}).Return(&hub.JSONQueryResult{
-			Data:       []byte("dataJSON"),
-			TotalCount: 1,
-		}, nil)
-		hw.h.GetMembers(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
-		h := resp.Header
-		data, _ := ioutil.ReadAll(resp.Body)
+		t.Run("error testing authorization policy", func(t *testing.T) {
+			testCases := []struct {
+				err                error
+				expectedStatusCode int
+			}{
+				{
+					hub.ErrInsufficientPrivilege,
+					http.StatusForbidden,
+				},
+				{
+					tests.ErrFake,
+					http.StatusInternalServerError,
+				},
+			}
+			for _, tc := range testCases {
+				tc := tc
+				t.Run(tc.err.Error(), func(t *testing.T) {
+					t.Parallel()
+					w := httptest.NewRecorder()
+					r, _ := http.NewRequest("POST", "/", strings.NewReader(testJSON))
+					r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+					r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, h.Get(helpers.PaginationTotalCount), "1")
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
-		assert.Equal(t, []byte("dataJSON"), data)
-		hw.om.AssertExpectations(t)
+					hw := newHandlersWrapper()
+					hw.om.On("TestAuthorizationPolicy", r.Context(), "org1", test).Return(nil, tc.err)
+					hw.h.TestAuthorizationPolicy(w, r)
+					resp := w.Result()
+					defer resp.Body.Close()
+
+					assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+					hw.om.AssertExpectations(t)
+				})
+			}
+		})
+
+		t.Run("authorization policy tested successfully", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("POST", "/", strings.NewReader(testJSON))
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("TestAuthorizationPolicy", r.Context(), "org1", test).Return(&hub.AuthorizationPolicyTestOutput{
+				Errors: []string{},
+				Results: []*hub.AuthorizationPolicyTestResult{
+					{
+						User:           "user1",
+						Action:         hub.UpdateOrganization,
+						Allowed:        false,
+						AllowedActions: []hub.Action{},
+					},
+				},
+			}, nil)
+			hw.h.TestAuthorizationPolicy(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			h := resp.Header
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "application/json", h.Get("Content-Type"))
+			expectedData := `{"errors":[],"results":[{"user":"user1","action":"updateOrganization","allowed":false,"allowed_actions":[]}]}`
+			assert.Equal(t, expectedData, string(data))
+			hw.om.AssertExpectations(t)
+		})
 	})
 }
 
@@ -906,6 +1743,153 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 	})
 }
 
+func TestUpdateDomain(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "domain"},
+			Values: []string{"org1", "example.com"},
+		},
+	}
+
+	t.Run("invalid domain provided", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			domainJSON  string
+		}{
+			{
+				"no domain provided",
+				"",
+			},
+			{
+				"invalid json",
+				"-",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.domainJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.h.UpdateDomain(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid domain provided", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			var desc string
+			if tc.omErr != nil {
+				desc = tc.omErr.Error()
+			}
+			t.Run(desc, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"join_mode": "auto"}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("UpdateDomain", r.Context(), "org1", "example.com", hub.AutoJoinMode).Return(tc.omErr)
+				hw.h.UpdateDomain(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestVerifyDomain(t *testing.T) {
+	testCases := []struct {
+		omErr              error
+		expectedStatusCode int
+	}{
+		{
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			hub.ErrNotFound,
+			http.StatusNotFound,
+		},
+		{
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		var desc string
+		if tc.omErr != nil {
+			desc = tc.omErr.Error()
+		}
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("PUT", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"orgName", "domain"},
+					Values: []string{"org1", "example.com"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("VerifyDomain", r.Context(), "org1", "example.com").Return(tc.omErr)
+			hw.h.VerifyDomain(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
+}
+
 func TestGetUserAllowedActions(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -1,23 +1,25 @@
 package pkg
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
-	"sort"
 	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/tracker/source/helm"
 	"github.com/go-chi/chi/v5"
-	"github.com/gorilla/feeds"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
@@ -26,8 +28,54 @@ import (
 
 const (
 	searchDefaultLimit = 20
+
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+	exportPageLimit    = 60
+	exportMaxPackages  = 1000
+
+	badgeColorGreen = "28a745"
+	badgeColorGrey  = "9e9e9e"
+	badgeColorRed   = "dc3545"
 )
 
+// badgeSecurityColors represents the color used in the security badge
+// depending on the highest severity of the vulnerabilities found.
+var badgeSecurityColors = map[string]string{
+	"critical": badgeColorRed,
+	"high":     "fd7e14",
+	"medium":   "ffc107",
+	"low":      "6c757d",
+	"unknown":  "6c757d",
+}
+
+// exportFields represents the fields that can be selected when exporting the
+// packages matching a search, along with the function used to get their value.
+var exportFields = map[string]func(baseURL string, p *hub.Package) interface{}{
+	"app_version":        func(_ string, p *hub.Package) interface{} { return p.AppVersion },
+	"deprecated":         func(_ string, p *hub.Package) interface{} { return p.Deprecated },
+	"description":        func(_ string, p *hub.Package) interface{} { return p.Description },
+	"display_name":       func(_ string, p *hub.Package) interface{} { return p.DisplayName },
+	"kind":               func(_ string, p *hub.Package) interface{} { return hub.GetKindName(p.Repository.Kind) },
+	"license":            func(_ string, p *hub.Package) interface{} { return p.License },
+	"name":               func(_ string, p *hub.Package) interface{} { return p.NormalizedName },
+	"official":           func(_ string, p *hub.Package) interface{} { return p.Official || p.Repository.Official },
+	"organization":       func(_ string, p *hub.Package) interface{} { return p.Repository.OrganizationName },
+	"package_id":         func(_ string, p *hub.Package) interface{} { return p.PackageID },
+	"repository":         func(_ string, p *hub.Package) interface{} { return p.Repository.Name },
+	"repository_url":     func(_ string, p *hub.Package) interface{} { return p.Repository.URL },
+	"signed":             func(_ string, p *hub.Package) interface{} { return p.Signed },
+	"ts":                 func(_ string, p *hub.Package) interface{} { return p.TS },
+	"url":                func(baseURL string, p *hub.Package) interface{} { return BuildURL(baseURL, p, "") },
+	"user":               func(_ string, p *hub.Package) interface{} { return p.Repository.UserAlias },
+	"verified_publisher": func(_ string, p *hub.Package) interface{} { return p.Repository.VerifiedPublisher },
+	"version":            func(_ string, p *hub.Package) interface{} { return p.Version },
+}
+
+// exportDefaultFields represents the fields exported when no fields have been
+// selected explicitly.
+var exportDefaultFields = []string{"name", "version", "kind", "repository", "license", "url"}
+
 // Handlers represents a group of http handlers in charge of handling packages
 // operations.
 type Handlers struct {
@@ -38,7 +86,9 @@ type Handlers struct {
 	hc              hub.HTTPClient
 	op              hub.OCIPuller
 	vt              hub.ViewsTracker
+	is              img.Store
 	tmplChangelogMD *template.Template
+	tmplBadgeSVG    *template.Template
 }
 
 // NewHandlers creates a new Handlers instance.
@@ -49,6 +99,7 @@ func NewHandlers(
 	hc hub.HTTPClient,
 	op hub.OCIPuller,
 	vt hub.ViewsTracker,
+	is img.Store,
 ) *Handlers {
 	return &Handlers{
 		pkgManager:      pkgManager,
@@ -58,10 +109,31 @@ func NewHandlers(
 		hc:              hc,
 		op:              op,
 		vt:              vt,
+		is:              is,
 		tmplChangelogMD: setupChangelogMDTmpl(),
+		tmplBadgeSVG:    setupBadgeSVGTmpl(),
 	}
 }
 
+// setupBadgeSVGTmpl prepares the template used to render the packages badges
+// in SVG format.
+func setupBadgeSVGTmpl() *template.Template {
+	return template.Must(template.New("").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{ .Width }}" height="20" role="img" aria-label="{{ html .Label }}: {{ html .Message }}">` +
+		`<title>{{ html .Label }}: {{ html .Message }}</title>` +
+		`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` +
+		`<clipPath id="r"><rect width="{{ .Width }}" height="20" rx="3" fill="#fff"/></clipPath>` +
+		`<g clip-path="url(#r)">` +
+		`<rect width="{{ .LabelWidth }}" height="20" fill="#{{ .LabelColor }}"/>` +
+		`<rect x="{{ .LabelWidth }}" width="{{ .MessageWidth }}" height="20" fill="#{{ .Color }}"/>` +
+		`<rect width="{{ .Width }}" height="20" fill="url(#s)"/>` +
+		`</g>` +
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` +
+		`<text x="{{ .LabelX }}" y="14">{{ html .Label }}</text>` +
+		`<text x="{{ .MessageX }}" y="14">{{ html .Message }}</text>` +
+		`</g>` +
+		`</svg>`))
+}
+
 // setupChangelogMDTmpl prepares the template used to generate a package's
 // changelog in markdown format.
 func setupChangelogMDTmpl() *template.Template {
@@ -123,6 +195,84 @@ func (h *Handlers) AddProductionUsage(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// Badge is an http handler that returns the information needed to render the
+// requested package badge. The badge is returned in the shields.io endpoint
+// format by default, or as an SVG image when the svg format is requested.
+func (h *Handlers) Badge(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetPackageInput{
+		RepositoryName: chi.URLParam(r, "repoName"),
+		PackageName:    chi.URLParam(r, "packageName"),
+	}
+	summary, err := h.getSummary(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "Badge").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Prepare badge
+	b := &badge{
+		LabelColor: strings.TrimPrefix(h.cfg.GetString("theme.colors.primary"), "#"),
+	}
+	switch chi.URLParam(r, "badgeKind") {
+	case "version":
+		b.Label = "version"
+		b.Message = summary.Version
+		b.Color = strings.TrimPrefix(h.cfg.GetString("theme.colors.secondary"), "#")
+	case "verified":
+		b.Label = "verified publisher"
+		if summary.Repository != nil && summary.Repository.VerifiedPublisher {
+			b.Message = "yes"
+			b.Color = badgeColorGreen
+		} else {
+			b.Message = "no"
+			b.Color = badgeColorGrey
+		}
+	case "security":
+		b.Label = "security"
+		b.Message, b.Color = getSecurityBadgeMessage(summary.SecurityReportSummary)
+	case "stars":
+		b.Label = "stars"
+		b.Message = strconv.Itoa(summary.Stars)
+		b.Color = strings.TrimPrefix(h.cfg.GetString("theme.colors.secondary"), "#")
+	case "downloads":
+		b.Label = "downloads"
+		b.Message = strconv.FormatInt(summary.Downloads, 10)
+		b.Color = strings.TrimPrefix(h.cfg.GetString("theme.colors.secondary"), "#")
+	default:
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid badge kind"))
+		return
+	}
+
+	// Render badge in the requested format
+	if r.FormValue("format") == "svg" {
+		var buf bytes.Buffer
+		if err := h.tmplBadgeSVG.Execute(&buf, b.prepareSVG()); err != nil {
+			h.logger.Error().Err(err).Str("method", "Badge").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+		w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge))
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = w.Write(buf.Bytes())
+		return
+	}
+	badgeJSON, err := json.Marshal(map[string]interface{}{
+		"color":         b.Color,
+		"label":         b.Label,
+		"labelColor":    b.LabelColor,
+		"message":       b.Message,
+		"schemaVersion": 1,
+		"style":         "flat",
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Badge").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, badgeJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // DeleteProductionUsage is an http handler used to add the given organization
 // from the list of production users for the provided package.
 func (h *Handlers) DeleteProductionUsage(w http.ResponseWriter, r *http.Request) {
@@ -174,19 +324,139 @@ func (h *Handlers) GenerateChangelogMD(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GenerateGitOpsManifests is an http handler used to generate the manifests
+// needed to deploy a package version using the GitOps tool and values
+// provided.
+func (h *Handlers) GenerateGitOpsManifests(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GitOpsManifestsInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "GenerateGitOpsManifests").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+
+	// Get package version and its values schema
+	getPkgInput := &hub.GetPackageInput{
+		RepositoryName: chi.URLParam(r, "repoName"),
+		PackageName:    chi.URLParam(r, "packageName"),
+		Version:        chi.URLParam(r, "version"),
+	}
+	dataJSON, err := h.pkgManager.GetJSON(r.Context(), getPkgInput)
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", getPkgInput).Str("method", "GenerateGitOpsManifests").Send()
+		if errors.Is(err, hub.ErrBlocked) {
+			helpers.RenderBlockedJSON(w, err, h.cfg.GetString("server.blocklist.appealContact"))
+		} else {
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+	var p *hub.Package
+	if err := json.Unmarshal(dataJSON, &p); err != nil {
+		h.logger.Error().Err(err).Str("method", "GenerateGitOpsManifests").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	var valuesSchema []byte
+	if p.HasValuesSchema {
+		valuesSchema, err = h.pkgManager.GetValuesSchemaJSON(r.Context(), p.PackageID, p.Version)
+		if err != nil {
+			h.logger.Error().Err(err).Str("method", "GenerateGitOpsManifests").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+	}
+
+	// Generate manifests
+	manifests, err := pkg.BuildGitOpsManifests(p, input, valuesSchema)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GenerateGitOpsManifests").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(0))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(manifests)
+}
+
 // Get is an http handler used to get a package details.
 func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	input := &hub.GetPackageInput{
 		RepositoryName: chi.URLParam(r, "repoName"),
 		PackageName:    chi.URLParam(r, "packageName"),
 		Version:        chi.URLParam(r, "version"),
+		Locale:         getRequestLocale(r),
 	}
 	dataJSON, err := h.pkgManager.GetJSON(r.Context(), input)
 	if err != nil {
 		h.logger.Error().Err(err).Interface("input", input).Str("method", "Get").Send()
+		if errors.Is(err, hub.ErrBlocked) {
+			helpers.RenderBlockedJSON(w, err, h.cfg.GetString("server.blocklist.appealContact"))
+		} else {
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+	w.Header().Set("Vary", "Accept-Language")
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetCRD is an http handler used to get the schema of one of the CRDs of a
+// package's snapshot, including the documentation of its fields.
+func (h *Handlers) GetCRD(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	crdName := chi.URLParam(r, "crdName")
+	crds, err := h.pkgManager.GetCRDsSchemas(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetCRD").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	var crd *hub.CRDSchema
+	for _, c := range crds {
+		if c.Name == crdName {
+			crd = c
+			break
+		}
+	}
+	if crd == nil {
+		helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		return
+	}
+	for _, v := range crd.Versions {
+		v.Fields, err = pkg.GetCRDFields(v.Schema)
+		if err != nil {
+			h.logger.Error().Err(err).Str("method", "GetCRD").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+	}
+	dataJSON, _ := json.Marshal(crd)
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetCRDs is an http handler used to get the CRDs of a package's snapshot.
+// The schemas of the CRDs versions are not included in the response.
+func (h *Handlers) GetCRDs(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	crds, err := h.pkgManager.GetCRDsSchemas(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetCRDs").Send()
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
+	for _, crd := range crds {
+		for _, v := range crd.Versions {
+			v.Schema = nil
+		}
+	}
+	if crds == nil {
+		crds = []*hub.CRDSchema{}
+	}
+	dataJSON, _ := json.Marshal(crds)
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
@@ -258,6 +528,20 @@ func (h *Handlers) GetChartValues(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(data)
 }
 
+// GetDownloads is an http handler used to get the total downloads of the
+// provided package, as well as the downloads of the last month organized by
+// version and day.
+func (h *Handlers) GetDownloads(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	dataJSON, err := h.pkgManager.GetDownloadsJSON(r.Context(), packageID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetDownloads").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 1*time.Hour, http.StatusOK)
+}
+
 // GetHarborReplicationDump is an http handler used to get a summary of all
 // available packages versions of kind Helm in the hub database so that they
 // can be synchronized in Harbor.
@@ -284,6 +568,50 @@ func (h *Handlers) GetHelmExporterDump(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 1*time.Hour, http.StatusOK)
 }
 
+// GetInstallSnippets is an http handler used to get the snippets that can be
+// used to install a package using some popular tools.
+func (h *Handlers) GetInstallSnippets(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetPackageInput{
+		RepositoryName: chi.URLParam(r, "repoName"),
+		PackageName:    chi.URLParam(r, "packageName"),
+		Version:        chi.URLParam(r, "version"),
+	}
+	dataJSON, err := h.pkgManager.GetJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "GetInstallSnippets").Send()
+		if errors.Is(err, hub.ErrBlocked) {
+			helpers.RenderBlockedJSON(w, err, h.cfg.GetString("server.blocklist.appealContact"))
+		} else {
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+	var p *hub.Package
+	if err := json.Unmarshal(dataJSON, &p); err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "GetInstallSnippets").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if len(p.InstallSnippets) == 0 {
+		helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		return
+	}
+	snippetsJSON, _ := json.Marshal(p.InstallSnippets)
+	helpers.RenderJSON(w, snippetsJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetNew is an http handler used to get the new and noteworthy packages, i.e.
+// the most popular packages added recently.
+func (h *Handlers) GetNew(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.pkgManager.GetRankingJSON(r.Context(), "new")
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetNew").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // GetProductionUsage is an http handler used to get a summary of which of the
 // organizations the user belongs to are using the package in production.
 func (h *Handlers) GetProductionUsage(w http.ResponseWriter, r *http.Request) {
@@ -310,6 +638,134 @@ func (h *Handlers) GetRandom(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetReadme is an http handler used to get the README of a package version
+// rendered as sanitized HTML, so that all clients display it consistently.
+// Relative links are rewritten to point to the package's source, if available.
+func (h *Handlers) GetReadme(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetPackageInput{
+		PackageID: chi.URLParam(r, "packageID"),
+		Version:   chi.URLParam(r, "version"),
+		Locale:    getRequestLocale(r),
+	}
+	p, err := h.pkgManager.Get(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "GetReadme").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if p.Readme == "" {
+		helpers.RenderErrorJSON(w, fmt.Errorf("readme %w", hub.ErrNotFound))
+		return
+	}
+	var sourceURL string
+	for _, link := range p.Links {
+		if strings.EqualFold(link.Name, "source") {
+			sourceURL = link.URL
+			break
+		}
+	}
+	html, err := pkg.RenderReadme(p.Readme, sourceURL)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetReadme").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge))
+	w.Header().Set("Vary", "Accept-Language")
+	_, _ = w.Write([]byte(html))
+}
+
+// GetSnapshotBundle is an http handler used to get the offline bundle of a
+// package's snapshot, to support air-gapped installations. Only the owner of
+// the repository (or the members of the organization owning it) can get it.
+func (h *Handlers) GetSnapshotBundle(w http.ResponseWriter, r *http.Request) {
+	h.getSnapshotBundle(w, r, true)
+}
+
+// GetSnapshotBundleAsAdmin is an http handler used to get the offline bundle
+// of any package's snapshot. It's meant to be used from the admin endpoints.
+func (h *Handlers) GetSnapshotBundleAsAdmin(w http.ResponseWriter, r *http.Request) {
+	h.getSnapshotBundle(w, r, false)
+}
+
+// GetSnapshotContentWarnings is an http handler used to get the warnings found
+// by the content static checks in a package's snapshot.
+func (h *Handlers) GetSnapshotContentWarnings(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	dataJSON, err := h.pkgManager.GetSnapshotContentWarningsJSON(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSnapshotContentWarnings").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetSnapshotImages is an http handler used to get the containers images used
+// by a package's snapshot.
+func (h *Handlers) GetSnapshotImages(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	images, err := h.pkgManager.GetSnapshotImages(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSnapshotImages").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if images == nil {
+		images = []*hub.SnapshotImage{}
+	}
+	dataJSON, _ := json.Marshal(images)
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetSnapshotLicenseInventory is an http handler used to get the license
+// inventory of a package's snapshot. Only problematic licenses are included
+// when the problematic query parameter is set to true.
+func (h *Handlers) GetSnapshotLicenseInventory(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	var problematicOnly bool
+	if r.FormValue("problematic") != "" {
+		var err error
+		problematicOnly, err = strconv.ParseBool(r.FormValue("problematic"))
+		if err != nil {
+			err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid problematic value")
+			h.logger.Error().Err(err).Str("method", "GetSnapshotLicenseInventory").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+	}
+	dataJSON, err := h.pkgManager.GetSnapshotLicenseInventoryJSON(r.Context(), packageID, version, problematicOnly)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSnapshotLicenseInventory").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetSnapshotSBOM is an http handler used to get the SBOMs of the images used
+// by a package's snapshot. The format can be selected using the format query
+// parameter (cyclonedx or spdx), defaulting to CycloneDX.
+func (h *Handlers) GetSnapshotSBOM(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	format := r.FormValue("format")
+	if format == "" {
+		format = hub.SBOMFormatCycloneDX
+	}
+	dataJSON, err := h.pkgManager.GetSnapshotSBOMJSON(r.Context(), packageID, version, format)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSnapshotSBOMJSON").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // GetSnapshotSecurityReport is an http handler used to get the security report
 // of a package's snapshot.
 func (h *Handlers) GetSnapshotSecurityReport(w http.ResponseWriter, r *http.Request) {
@@ -324,6 +780,75 @@ func (h *Handlers) GetSnapshotSecurityReport(w http.ResponseWriter, r *http.Requ
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetSnapshotSecurityReportSuppressed is an http handler used to get the
+// vulnerabilities suppressed from the security report of a package's snapshot
+// by the vulnerability statements provided by the publisher.
+func (h *Handlers) GetSnapshotSecurityReportSuppressed(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	dataJSON, err := h.pkgManager.GetSnapshotSecurityReportSuppressedJSON(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSnapshotSecurityReportSuppressed").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetSocialImage is an http handler used to get the social preview image of a
+// package, which is used as the Open Graph image of the package's page.
+func (h *Handlers) GetSocialImage(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetPackageInput{
+		RepositoryName: chi.URLParam(r, "repoName"),
+		PackageName:    chi.URLParam(r, "packageName"),
+	}
+	summary, err := h.getSummary(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "GetSocialImage").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Get package logo (we proceed without it if it's not available)
+	var logo []byte
+	if summary.LogoImageID != "" {
+		logo, err = h.is.GetImage(r.Context(), summary.LogoImageID, "4x")
+		if err != nil {
+			h.logger.Warn().Err(err).Str("imageID", summary.LogoImageID).Str("method", "GetSocialImage").Send()
+		}
+	}
+
+	// Generate social image
+	title := summary.DisplayName
+	if title == "" {
+		title = summary.NormalizedName
+	}
+	var subtitle string
+	if summary.Repository != nil {
+		publisher := summary.Repository.OrganizationName
+		if publisher == "" {
+			publisher = summary.Repository.UserAlias
+		}
+		subtitle = fmt.Sprintf("%s/%s", publisher, summary.Repository.Name)
+	}
+	data, err := img.GenerateSocialImage(&img.SocialImageInput{
+		Title:           title,
+		Subtitle:        subtitle,
+		Version:         summary.Version,
+		Stars:           summary.Stars,
+		Logo:            logo,
+		BackgroundColor: h.cfg.GetString("theme.colors.primary"),
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "GetSocialImage").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge))
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(data)
+}
+
 // GetStarredByUser is an http handler used to get the packages starred by the
 // user doing the request.
 func (h *Handlers) GetStarredByUser(w http.ResponseWriter, r *http.Request) {
@@ -384,6 +909,36 @@ func (h *Handlers) GetSummary(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetTrending is an http handler used to get the trending packages during the
+// period provided (7d or 30d, defaults to 7d).
+func (h *Handlers) GetTrending(w http.ResponseWriter, r *http.Request) {
+	period := r.FormValue("period")
+	if period == "" {
+		period = "7d"
+	}
+	dataJSON, err := h.pkgManager.GetRankingJSON(r.Context(), "trending-"+period)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetTrending").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// GetUpgradeGraph is an http handler used to get the channels and upgrade
+// paths of an operator package.
+func (h *Handlers) GetUpgradeGraph(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	g, err := h.pkgManager.GetUpgradeGraph(r.Context(), packageID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetUpgradeGraph").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(g)
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // GetValuesSchema is an http handler used to get the values schema of a
 // package's snapshot.
 func (h *Handlers) GetValuesSchema(w http.ResponseWriter, r *http.Request) {
@@ -410,10 +965,31 @@ func (h *Handlers) GetViews(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 1*time.Hour, http.StatusOK)
 }
 
+// GetVulnerabilityStatements is an http handler used to get the vulnerability
+// statements of a package's snapshot.
+func (h *Handlers) GetVulnerabilityStatements(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	dataJSON, err := h.pkgManager.GetVulnerabilityStatementsJSON(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetVulnerabilityStatements").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // InjectIndexMeta is a middleware that injects the some index metadata related
-// to a given package,
+// to a given package. Metadata is only injected when the kind provided is a
+// registered repository kind (built-in or custom).
 func (h *Handlers) InjectIndexMeta(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Check the kind provided has been registered
+		if _, err := hub.GetKindFromName(chi.URLParam(r, "kind")); err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		// Prepare index metadata from package details
 		input := &hub.GetPackageInput{
 			PackageName: chi.URLParam(r, "packageName"),
@@ -436,83 +1012,42 @@ func (h *Handlers) InjectIndexMeta(next http.Handler) http.Handler {
 		}
 		title := fmt.Sprintf("%s %s · %s/%s", p.NormalizedName, p.Version, publisher, p.Repository.Name)
 		description := p.Description
+		openGraphImage := BuildURL(h.cfg.GetString("server.baseURL")+"/api/v1", p, "") + "/social-image.png"
 
 		// Inject index metadata in context and call next handler
 		ctx := context.WithValue(r.Context(), hub.IndexMetaTitleKey, title)
 		ctx = context.WithValue(ctx, hub.IndexMetaDescriptionKey, description)
+		ctx = context.WithValue(ctx, hub.IndexMetaOpenGraphImageKey, openGraphImage)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-// RssFeed is an http handler used to get the RSS feed of a given package.
-func (h *Handlers) RssFeed(w http.ResponseWriter, r *http.Request) {
-	// Get package details
-	input := &hub.GetPackageInput{
-		PackageName: chi.URLParam(r, "packageName"),
-	}
-	repoName := chi.URLParam(r, "repoName")
-	if repoName != "" {
-		input.RepositoryName = repoName
-	}
-	p, err := h.pkgManager.Get(r.Context(), input)
-	if err != nil {
-		h.logger.Error().Err(err).Interface("input", input).Str("method", "RssFeed").Send()
+// RequestSnapshotScan is an http handler used to request a new security scan
+// of a package's snapshot.
+func (h *Handlers) RequestSnapshotScan(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	if err := h.pkgManager.RequestSnapshotScan(r.Context(), packageID, version); err != nil {
+		h.logger.Error().Err(err).Str("method", "RequestSnapshotScan").Send()
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
-
-	// Build RSS feed
-	baseURL := h.cfg.GetString("server.baseURL")
-	publisher := p.Repository.OrganizationName
-	if publisher == "" {
-		publisher = p.Repository.UserAlias
-	}
-	feed := &feeds.Feed{
-		Title:       fmt.Sprintf("%s/%s (Artifact Hub)", publisher, p.NormalizedName),
-		Description: p.Description,
-		Link:        &feeds.Link{Href: baseURL},
-		Image: &feeds.Image{
-			Title: "logo",
-			Url:   fmt.Sprintf("%s/image/%s@4x", baseURL, p.LogoImageID),
-			Link:  baseURL,
-		},
-	}
-	if len(p.Maintainers) > 0 {
-		feed.Author = &feeds.Author{
-			Name:  p.Maintainers[0].Name,
-			Email: p.Maintainers[0].Email,
-		}
-	}
-	for _, s := range p.AvailableVersions {
-		feed.Items = append(feed.Items, &feeds.Item{
-			Id:          fmt.Sprintf("%s#%s", p.PackageID, s.Version),
-			Title:       s.Version,
-			Description: fmt.Sprintf("%s %s", p.NormalizedName, s.Version),
-			Created:     time.Unix(s.TS, 0),
-			Link:        &feeds.Link{Href: BuildURL(baseURL, p, s.Version)},
-		})
-	}
-	if p.Repository.Kind != hub.Container {
-		sort.Slice(feed.Items, func(i, j int) bool {
-			vi, _ := semver.NewVersion(feed.Items[i].Title)
-			vj, _ := semver.NewVersion(feed.Items[j].Title)
-			return vj.LessThan(vi)
-		})
-	}
-
-	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge))
-	_ = feed.WriteRss(w)
+	w.WriteHeader(http.StatusAccepted)
 }
 
 // Search is an http handler used to search for packages in the hub database.
 func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
-	input, err := buildSearchInput(r.URL.Query())
+	input, err := BuildSearchInput(r.URL.Query())
 	if err != nil {
 		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
 		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Msg("invalid query")
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
+	if r.URL.Query().Get("export") != "" {
+		h.export(w, r, input)
+		return
+	}
 	result, err := h.pkgManager.SearchJSON(r.Context(), input)
 	if err != nil {
 		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
@@ -523,6 +1058,129 @@ func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// export streams the packages matching the search input provided as CSV or
+// NDJSON, depending on the format requested. Only the selected fields are
+// included, and at most exportMaxPackages packages are exported. The search
+// results are fetched one page at a time, so the first page is requested
+// before writing anything to be able to report errors.
+func (h *Handlers) export(w http.ResponseWriter, r *http.Request, input *hub.SearchPackageInput) {
+	qs := r.URL.Query()
+
+	// Validate format and fields requested
+	format := qs.Get("export")
+	if format != exportFormatCSV && format != exportFormatNDJSON {
+		err := fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid export format (csv|ndjson)")
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	fields := exportDefaultFields
+	if qs.Get("fields") != "" {
+		fields = strings.Split(qs.Get("fields"), ",")
+		for _, field := range fields {
+			if _, ok := exportFields[field]; !ok {
+				err := fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid export field", field)
+				h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
+				helpers.RenderErrorJSON(w, err)
+				return
+			}
+		}
+	}
+
+	// Get first page of search results
+	input.Limit = exportPageLimit
+	input.Offset = 0
+	input.Facets = false
+	packages, total, err := h.searchPackages(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Write packages in the format requested
+	baseURL := h.cfg.GetString("server.baseURL")
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(0))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=packages.%s", format))
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(total))
+	var writeRow func(p *hub.Package) error
+	var flush func() error
+	switch format {
+	case exportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv")
+		cw := csv.NewWriter(w)
+		_ = cw.Write(fields)
+		writeRow = func(p *hub.Package) error {
+			record := make([]string, 0, len(fields))
+			for _, field := range fields {
+				record = append(record, helpers.EscapeCSVCell(fmt.Sprint(exportFields[field](baseURL, p))))
+			}
+			return cw.Write(record)
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case exportFormatNDJSON:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(w)
+		writeRow = func(p *hub.Package) error {
+			obj := make(map[string]interface{}, len(fields))
+			for _, field := range fields {
+				obj[field] = exportFields[field](baseURL, p)
+			}
+			return enc.Encode(obj)
+		}
+		flush = func() error { return nil }
+	}
+	w.WriteHeader(http.StatusOK)
+	for {
+		for _, p := range packages {
+			if err := writeRow(p); err != nil {
+				h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Msg("error writing export")
+				return
+			}
+		}
+		if err := flush(); err != nil {
+			h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Msg("error writing export")
+			return
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		input.Offset += input.Limit
+		if len(packages) < input.Limit || input.Offset >= total || input.Offset >= exportMaxPackages {
+			break
+		}
+		if input.Offset+input.Limit > exportMaxPackages {
+			input.Limit = exportMaxPackages - input.Offset
+		}
+		packages, _, err = h.searchPackages(r.Context(), input)
+		if err != nil {
+			// Headers have already been sent at this point, so the export is
+			// truncated and the error is only logged
+			h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
+			return
+		}
+	}
+}
+
+// searchPackages returns the packages matching the search input provided, as
+// well as the total number of packages found.
+func (h *Handlers) searchPackages(ctx context.Context, input *hub.SearchPackageInput) ([]*hub.Package, int, error) {
+	result, err := h.pkgManager.SearchJSON(ctx, input)
+	if err != nil {
+		return nil, 0, err
+	}
+	var data struct {
+		Packages []*hub.Package `json:"packages"`
+	}
+	if err := json.Unmarshal(result.Data, &data); err != nil {
+		return nil, 0, err
+	}
+	return data.Packages, result.TotalCount, nil
+}
+
 // SearchMonocular is an http handler used to search for packages in the hub
 // database that is compatible with the Monocular search API.
 func (h *Handlers) SearchMonocular(w http.ResponseWriter, r *http.Request) {
@@ -560,6 +1218,28 @@ func (h *Handlers) TrackView(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpdateVulnerabilityStatements is an http handler used to replace the
+// vulnerability statements of a package's snapshot.
+func (h *Handlers) UpdateVulnerabilityStatements(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Statements []*hub.VulnerabilityStatement `json:"statements"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateVulnerabilityStatements").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	err := h.pkgManager.UpdateVulnerabilityStatements(r.Context(), packageID, version, input.Statements)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateVulnerabilityStatements").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // getChartArchive is a helper function used to download a chart's archive from
 // the original source.
 func (h *Handlers) getChartArchive(ctx context.Context, packageID, version string) (*chart.Chart, error) {
@@ -607,9 +1287,117 @@ func (h *Handlers) getChartArchive(ctx context.Context, packageID, version strin
 	return chrt, nil
 }
 
-// buildSearchInput builds a packages search query from a map of query string
+// getRequestLocale returns the locale requested by the client, using the
+// locale query parameter or the Accept-Language header, in that order. The
+// locale provided in the query parameter is returned as is, so that it can be
+// validated later. Invalid locales in the header are ignored.
+func getRequestLocale(r *http.Request) string {
+	if locale := r.FormValue("locale"); locale != "" {
+		return locale
+	}
+	var locale string
+	var maxQ float64
+	for _, entry := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ";", 2)
+		q := 1.0
+		if len(parts) == 2 {
+			var err error
+			q, err = strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(parts[1]), "q="), 64)
+			if err != nil {
+				continue
+			}
+		}
+		normalizedLocale, err := pkg.NormalizeLocale(parts[0])
+		if err != nil || q <= maxQ {
+			continue
+		}
+		locale, maxQ = normalizedLocale, q
+	}
+	return locale
+}
+
+// getSnapshotBundle is a helper used to assemble and write the offline bundle
+// of a package's snapshot. The chart archive is downloaded from the original
+// source and included in the bundle for Helm charts packages.
+func (h *Handlers) getSnapshotBundle(w http.ResponseWriter, r *http.Request, checkOwnership bool) {
+	ctx := r.Context()
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+
+	// Get bundle data from database
+	data, err := h.pkgManager.GetSnapshotBundleData(ctx, packageID, version, checkOwnership)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSnapshotBundle").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Download chart archive from remote source
+	var chartArchive []byte
+	if data.RepositoryKind == hub.Helm && data.ContentURL != "" {
+		var username, password string
+		if data.Private {
+			// Get credentials if the repository is private
+			repo, err := h.repoManager.GetByID(ctx, data.RepositoryID, true)
+			if err != nil {
+				h.logger.Error().Err(err).Str("method", "GetSnapshotBundle").Send()
+				helpers.RenderErrorJSON(w, err)
+				return
+			}
+			username = repo.AuthUser
+			password = repo.AuthPass
+		}
+		u, _ := url.Parse(data.ContentURL)
+		chartArchive, err = helm.GetChartArchive(
+			ctx,
+			u,
+			&helm.LoadChartArchiveOptions{
+				Hc:       h.hc,
+				Op:       h.op,
+				Username: username,
+				Password: password,
+			},
+		)
+		if err != nil {
+			h.logger.Error().Err(err).Str("method", "GetSnapshotBundle").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+	}
+
+	// Assemble bundle and write it
+	var buf bytes.Buffer
+	if err := pkg.WriteBundle(&buf, data, chartArchive); err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSnapshotBundle").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(0))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-%s-bundle.tgz", data.Name, data.Version))
+	_, _ = w.Write(buf.Bytes())
+}
+
+// getSummary is a helper that returns the summary of the package identified
+// by the input provided.
+func (h *Handlers) getSummary(ctx context.Context, input *hub.GetPackageInput) (*pkgSummary, error) {
+	dataJSON, err := h.pkgManager.GetSummaryJSON(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	var summary *pkgSummary
+	if err := json.Unmarshal(dataJSON, &summary); err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return nil, fmt.Errorf("package %w", hub.ErrNotFound)
+	}
+	return summary, nil
+}
+
+// BuildSearchInput builds a packages search query from a map of query string
 // values, validating them as they are extracted.
-func buildSearchInput(qs url.Values) (*hub.SearchPackageInput, error) {
+func BuildSearchInput(qs url.Values) (*hub.SearchPackageInput, error) {
 	// Limit
 	var limit int
 	if qs.Get("limit") != "" {
@@ -692,6 +1480,16 @@ func buildSearchInput(qs url.Values) (*hub.SearchPackageInput, error) {
 		}
 	}
 
+	// Only display packages with verified signatures
+	var signatureVerified bool
+	if qs.Get("signature_verified") != "" {
+		var err error
+		signatureVerified, err = strconv.ParseBool(qs.Get("signature_verified"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature verified: %s", qs.Get("signature_verified"))
+		}
+	}
+
 	return &hub.SearchPackageInput{
 		Limit:             limit,
 		Offset:            offset,
@@ -706,8 +1504,11 @@ func buildSearchInput(qs url.Values) (*hub.SearchPackageInput, error) {
 		Official:          official,
 		Operators:         operators,
 		Deprecated:        deprecated,
+		SignatureVerified: signatureVerified,
 		Licenses:          qs["license"],
+		LicenseFamilies:   qs["license_family"],
 		Capabilities:      qs["capabilities"],
+		K8sVersion:        qs.Get("k8s_version"),
 		Sort:              qs.Get("sort"),
 	}, nil
 }
@@ -725,6 +1526,87 @@ func BuildURL(baseURL string, p *hub.Package, version string) string {
 	return baseURL + pkgPath
 }
 
+// badge represents the information needed to render a package badge.
+type badge struct {
+	Label      string
+	LabelColor string
+	Message    string
+	Color      string
+}
+
+// badgeSVG represents the information used by the SVG badge template, which
+// includes some dimensions computed from the badge texts.
+type badgeSVG struct {
+	*badge
+	Width        int
+	LabelWidth   int
+	MessageWidth int
+	LabelX       int
+	MessageX     int
+}
+
+// prepareSVG computes the dimensions needed to render the badge as an SVG
+// image. Text widths are approximated using the average width of a character
+// in the font used.
+func (b *badge) prepareSVG() *badgeSVG {
+	const charWidth, padding = 7, 10
+	labelWidth := len(b.Label)*charWidth + padding
+	messageWidth := len(b.Message)*charWidth + padding
+	return &badgeSVG{
+		badge:        b,
+		Width:        labelWidth + messageWidth,
+		LabelWidth:   labelWidth,
+		MessageWidth: messageWidth,
+		LabelX:       labelWidth / 2,
+		MessageX:     labelWidth + messageWidth/2,
+	}
+}
+
+// pkgSummary represents the subset of the package summary fields used to
+// build the packages badges and social images.
+type pkgSummary struct {
+	NormalizedName        string                     `json:"normalized_name"`
+	DisplayName           string                     `json:"display_name"`
+	LogoImageID           string                     `json:"logo_image_id"`
+	Version               string                     `json:"version"`
+	Stars                 int                        `json:"stars"`
+	Downloads             int64                      `json:"downloads"`
+	SecurityReportSummary *hub.SecurityReportSummary `json:"security_report_summary"`
+	Repository            *hub.Repository            `json:"repository"`
+}
+
+// getSecurityBadgeMessage returns the message and color that should be used
+// in the security badge for the security report summary provided.
+func getSecurityBadgeMessage(s *hub.SecurityReportSummary) (string, string) {
+	if s == nil {
+		return "not scanned", badgeColorGrey
+	}
+	var parts []string
+	var color string
+	for _, severity := range []struct {
+		name  string
+		count int
+	}{
+		{"critical", s.Critical},
+		{"high", s.High},
+		{"medium", s.Medium},
+		{"low", s.Low},
+		{"unknown", s.Unknown},
+	} {
+		if severity.count == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", severity.count, severity.name))
+		if color == "" {
+			color = badgeSecurityColors[severity.name]
+		}
+	}
+	if len(parts) == 0 {
+		return "no vulnerabilities", badgeColorGreen
+	}
+	return strings.Join(parts, ", "), color
+}
+
 // contains is a helper to check if a list contains the string provided.
 func contains(l []string, e string) bool {
 	for _, x := range l {
@@ -1,9 +1,12 @@
 package pkg
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -16,6 +19,7 @@ import (
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
 	"github.com/artifacthub/hub/internal/oci"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
@@ -27,6 +31,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"helm.sh/helm/v3/pkg/chart/loader"
 )
 
@@ -78,6 +83,147 @@ func TestAddProductionUsage(t *testing.T) {
 	})
 }
 
+func TestBadge(t *testing.T) {
+	input := &hub.GetPackageInput{
+		RepositoryName: "repo1",
+		PackageName:    "pkg1",
+	}
+	summaryJSON := []byte(`{
+		"version": "1.0.0",
+		"stars": 10,
+		"downloads": 1234,
+		"security_report_summary": {"critical": 0, "high": 2, "medium": 1, "low": 0, "unknown": 0},
+		"repository": {"verified_publisher": true}
+	}`)
+
+	t.Run("get package summary failed", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"repoName", "packageName", "badgeKind"},
+						Values: []string{"repo1", "pkg1", "version"},
+					},
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSummaryJSON", r.Context(), input).Return(nil, tc.pmErr)
+				hw.h.Badge(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("badge info returned successfully", func(t *testing.T) {
+		testCases := []struct {
+			badgeKind    string
+			expectedJSON string
+		}{
+			{
+				"version",
+				`{"color":"2D4857","label":"version","labelColor":"417598","message":"1.0.0","schemaVersion":1,"style":"flat"}`,
+			},
+			{
+				"verified",
+				`{"color":"28a745","label":"verified publisher","labelColor":"417598","message":"yes","schemaVersion":1,"style":"flat"}`,
+			},
+			{
+				"security",
+				`{"color":"fd7e14","label":"security","labelColor":"417598","message":"2 high, 1 medium","schemaVersion":1,"style":"flat"}`,
+			},
+			{
+				"stars",
+				`{"color":"2D4857","label":"stars","labelColor":"417598","message":"10","schemaVersion":1,"style":"flat"}`,
+			},
+			{
+				"downloads",
+				`{"color":"2D4857","label":"downloads","labelColor":"417598","message":"1234","schemaVersion":1,"style":"flat"}`,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.badgeKind, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"repoName", "packageName", "badgeKind"},
+						Values: []string{"repo1", "pkg1", tc.badgeKind},
+					},
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSummaryJSON", r.Context(), input).Return(summaryJSON, nil)
+				hw.h.Badge(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				h := resp.Header
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "application/json", h.Get("Content-Type"))
+				assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+				assert.Equal(t, []byte(tc.expectedJSON), data)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("svg badge returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?format=svg", nil)
+		rctx := &chi.Context{
+			URLParams: chi.RouteParams{
+				Keys:   []string{"repoName", "packageName", "badgeKind"},
+				Values: []string{"repo1", "pkg1", "version"},
+			},
+		}
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSummaryJSON", r.Context(), input).Return(summaryJSON, nil)
+		hw.h.Badge(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/svg+xml", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Contains(t, string(data), `width="104"`)
+		assert.Contains(t, string(data), `<text x="29" y="14">version</text>`)
+		assert.Contains(t, string(data), `<text x="81" y="14">1.0.0</text>`)
+		assert.Contains(t, string(data), `fill="#2D4857"`)
+		hw.assertExpectations(t)
+	})
+}
+
 func TestDeleteProductionUsage(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -310,6 +456,123 @@ func TestGenerateChangelogMD(t *testing.T) {
 	})
 }
 
+func TestGenerateGitOpsManifests(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName", "packageName", "version"},
+			Values: []string{"repo1", "pkg1", "1.0.0"},
+		},
+	}
+	getPkgInput := &hub.GetPackageInput{
+		RepositoryName: "repo1",
+		PackageName:    "pkg1",
+		Version:        "1.0.0",
+	}
+	inputJSON := `{"tool": "flux", "release_name": "release1", "namespace": "ns1", "values": {"replicas": 2}}`
+	pkgJSON := []byte(`{
+		"package_id": "pkgID",
+		"name": "pkg1",
+		"version": "1.0.0",
+		"has_values_schema": true,
+		"repository": {"kind": 0, "name": "repo1", "url": "https://repo1.url"}
+	}`)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.GenerateGitOpsManifests(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting package", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(inputJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetJSON", r.Context(), getPkgInput).Return(nil, hub.ErrNotFound)
+		hw.h.GenerateGitOpsManifests(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting values schema", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(inputJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetJSON", r.Context(), getPkgInput).Return(pkgJSON, nil)
+		hw.pm.On("GetValuesSchemaJSON", r.Context(), "pkgID", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GenerateGitOpsManifests(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("values do not match the schema", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(inputJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetJSON", r.Context(), getPkgInput).Return(pkgJSON, nil)
+		hw.pm.On("GetValuesSchemaJSON", r.Context(), "pkgID", "1.0.0").Return([]byte(`{
+			"type": "object",
+			"properties": {"replicas": {"type": "string"}}
+		}`), nil)
+		hw.h.GenerateGitOpsManifests(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("manifests generated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(inputJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetJSON", r.Context(), getPkgInput).Return(pkgJSON, nil)
+		hw.pm.On("GetValuesSchemaJSON", r.Context(), "pkgID", "1.0.0").Return([]byte(`{
+			"type": "object",
+			"properties": {"replicas": {"type": "integer"}}
+		}`), nil)
+		hw.h.GenerateGitOpsManifests(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/yaml", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Contains(t, string(data), "kind: HelmRepository\n")
+		assert.Contains(t, string(data), "kind: HelmRelease\n")
+		assert.Contains(t, string(data), "  values:\n    replicas: 2\n")
+		hw.assertExpectations(t)
+	})
+}
+
 func TestGet(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -361,120 +624,335 @@ func TestGet(t *testing.T) {
 		}
 	})
 
-	t.Run("get package succeeded", func(t *testing.T) {
+	t.Run("get package blocked", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetJSON", r.Context(), getPkgInput).Return([]byte("dataJSON"), nil)
+		pmErr := fmt.Errorf("%w: %s", hub.ErrBlocked, "spam")
+		hw.pm.On("GetJSON", r.Context(), getPkgInput).Return(nil, pmErr)
 		hw.h.Get(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
-		h := resp.Header
 		data, _ := ioutil.ReadAll(resp.Body)
 
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
-		assert.Equal(t, []byte("dataJSON"), data)
+		assert.Equal(t, http.StatusUnavailableForLegalReasons, resp.StatusCode)
+		assert.JSONEq(t, `{
+			"message": "content blocked: spam",
+			"appeal_contact": "appeals@artifacthub.io"
+		}`, string(data))
 		hw.assertExpectations(t)
 	})
-}
-
-func TestGetChangelog(t *testing.T) {
-	rctx := &chi.Context{
-		URLParams: chi.RouteParams{
-			Keys:   []string{"packageID"},
-			Values: []string{"pkg1"},
-		},
-	}
 
-	t.Run("get changelog succeeded", func(t *testing.T) {
+	t.Run("get package succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		changelog := &hub.Changelog{
-			{
-				Version: "1.0.0",
-				TS:      1592299234,
-				Changes: []*hub.Change{
-					{
-						Kind:        "added",
-						Description: "feature 3",
-						Links: []*hub.Link{
-							{
-								Name: "github issue",
-								URL:  "https://issue.url",
-							},
-						},
-					},
-					{
-						Kind:        "fixed",
-						Description: "fix 3",
-						Links: []*hub.Link{
-							{
-								Name: "github issue",
-								URL:  "https://issue.url",
-							},
-						},
-					},
-				},
-				ContainsSecurityUpdates: true,
-				Prerelease:              true,
-			},
-			{
-				Version: "0.0.9",
-				TS:      1592299233,
-				Changes: []*hub.Change{
-					{
-						Kind:        "added",
-						Description: "feature 2",
-						Links: []*hub.Link{
-							{
-								Name: "github issue",
-								URL:  "https://issue.url",
-							},
-						},
-					},
-					{
-						Kind:        "fixed",
-						Description: "fix 2",
-						Links: []*hub.Link{
-							{
-								Name: "github issue",
-								URL:  "https://issue.url",
-							},
-						},
-					},
-				},
-				ContainsSecurityUpdates: false,
-				Prerelease:              false,
-			},
-		}
-		hw.pm.On("GetChangelog", r.Context(), "pkg1").Return(changelog, nil)
-		hw.h.GetChangelog(w, r)
+		hw.pm.On("GetJSON", r.Context(), getPkgInput).Return([]byte("dataJSON"), nil)
+		hw.h.Get(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
 		data, _ := ioutil.ReadAll(resp.Body)
 
-		expectedData, _ := json.Marshal(changelog)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 		assert.Equal(t, "application/json", h.Get("Content-Type"))
 		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
-		assert.Equal(t, expectedData, data)
+		assert.Equal(t, []byte("dataJSON"), data)
 		hw.assertExpectations(t)
 	})
 
-	t.Run("error getting changelog", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
+	t.Run("get package in the requested locale", func(t *testing.T) {
+		testCases := []struct {
+			query          string
+			acceptLanguage string
+			expectedLocale string
+		}{
+			{"?locale=zh_CN", "es", "zh_CN"},
+			{"", "pt-BR,pt;q=0.9,en;q=0.8", "pt-br"},
+			{"", "en;q=0.5, es;q=0.8, *;q=0.9", "es"},
+			{"", "invalid;q=1, fr;q=invalid, de;q=0.1", "de"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.expectedLocale, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/"+tc.query, nil)
+				r.Header.Set("Accept-Language", tc.acceptLanguage)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetJSON", r.Context(), &hub.GetPackageInput{
+					RepositoryName: "repo1",
+					PackageName:    "pkg1",
+					Version:        "1.0.0",
+					Locale:         tc.expectedLocale,
+				}).Return([]byte("dataJSON"), nil)
+				hw.h.Get(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				h := resp.Header
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "Accept-Language", h.Get("Vary"))
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetCRD(t *testing.T) {
+	newRctx := func(crdName string) *chi.Context {
+		return &chi.Context{
+			URLParams: chi.RouteParams{
+				Keys:   []string{"packageID", "version", "crdName"},
+				Values: []string{"pkg1", "1.0.0", crdName},
+			},
+		}
+	}
+	newCRDs := func() []*hub.CRDSchema {
+		return []*hub.CRDSchema{
+			{
+				Name:  "crontabs.stable.example.com",
+				Group: "stable.example.com",
+				Kind:  "CronTab",
+				Versions: []*hub.CRDSchemaVersion{
+					{
+						Name:    "v1",
+						Served:  true,
+						Storage: true,
+						Schema:  json.RawMessage(`{"properties":{"spec":{"type":"object","description":"Spec"}}}`),
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("error getting crds schemas", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, newRctx("crontabs.stable.example.com")))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetCRDsSchemas", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GetCRD(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("crd not found", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, newRctx("other.example.com")))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetCRDsSchemas", r.Context(), "pkg1", "1.0.0").Return(newCRDs(), nil)
+		hw.h.GetCRD(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("get crd succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, newRctx("crontabs.stable.example.com")))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetCRDsSchemas", r.Context(), "pkg1", "1.0.0").Return(newCRDs(), nil)
+		hw.h.GetCRD(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{
+			"name": "crontabs.stable.example.com",
+			"group": "stable.example.com",
+			"kind": "CronTab",
+			"versions": [{
+				"name": "v1",
+				"served": true,
+				"storage": true,
+				"schema": {"properties": {"spec": {"type": "object", "description": "Spec"}}},
+				"fields": [{"path": "spec", "type": "object", "description": "Spec", "required": false}]
+			}]
+		}`, string(data))
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetCRDs(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("error getting crds schemas", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetCRDsSchemas", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GetCRDs(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("get crds succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetCRDsSchemas", r.Context(), "pkg1", "1.0.0").Return([]*hub.CRDSchema{
+			{
+				Name:  "crontabs.stable.example.com",
+				Group: "stable.example.com",
+				Kind:  "CronTab",
+				Scope: "Namespaced",
+				Versions: []*hub.CRDSchemaVersion{
+					{Name: "v1", Served: true, Storage: true, Schema: json.RawMessage(`{"type":"object"}`)},
+				},
+			},
+		}, nil)
+		hw.h.GetCRDs(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.JSONEq(t, `[{
+			"name": "crontabs.stable.example.com",
+			"group": "stable.example.com",
+			"kind": "CronTab",
+			"scope": "Namespaced",
+			"versions": [{"name": "v1", "served": true, "storage": true}]
+		}]`, string(data))
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetChangelog(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID"},
+			Values: []string{"pkg1"},
+		},
+	}
+
+	t.Run("get changelog succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		changelog := &hub.Changelog{
+			{
+				Version: "1.0.0",
+				TS:      1592299234,
+				Changes: []*hub.Change{
+					{
+						Kind:        "added",
+						Description: "feature 3",
+						Links: []*hub.Link{
+							{
+								Name: "github issue",
+								URL:  "https://issue.url",
+							},
+						},
+					},
+					{
+						Kind:        "fixed",
+						Description: "fix 3",
+						Links: []*hub.Link{
+							{
+								Name: "github issue",
+								URL:  "https://issue.url",
+							},
+						},
+					},
+				},
+				ContainsSecurityUpdates: true,
+				Prerelease:              true,
+			},
+			{
+				Version: "0.0.9",
+				TS:      1592299233,
+				Changes: []*hub.Change{
+					{
+						Kind:        "added",
+						Description: "feature 2",
+						Links: []*hub.Link{
+							{
+								Name: "github issue",
+								URL:  "https://issue.url",
+							},
+						},
+					},
+					{
+						Kind:        "fixed",
+						Description: "fix 2",
+						Links: []*hub.Link{
+							{
+								Name: "github issue",
+								URL:  "https://issue.url",
+							},
+						},
+					},
+				},
+				ContainsSecurityUpdates: false,
+				Prerelease:              false,
+			},
+		}
+		hw.pm.On("GetChangelog", r.Context(), "pkg1").Return(changelog, nil)
+		hw.h.GetChangelog(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		expectedData, _ := json.Marshal(changelog)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, expectedData, data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting changelog", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
@@ -642,15 +1120,23 @@ func TestGetChartTemplates(t *testing.T) {
 	})
 }
 
-func TestGetHarborReplicationDump(t *testing.T) {
-	t.Run("get harbor replication dump succeeded", func(t *testing.T) {
+func TestGetDownloads(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID"},
+			Values: []string{"pkg1"},
+		},
+	}
+
+	t.Run("get downloads succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetHarborReplicationDumpJSON", r.Context()).Return([]byte("dataJSON"), nil)
-		hw.h.GetHarborReplicationDump(w, r)
+		hw.pm.On("GetDownloadsJSON", r.Context(), "pkg1").Return([]byte("dataJSON"), nil)
+		hw.h.GetDownloads(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
@@ -663,18 +1149,78 @@ func TestGetHarborReplicationDump(t *testing.T) {
 		hw.assertExpectations(t)
 	})
 
-	t.Run("error getting harbor replication dump", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
-
-		hw := newHandlersWrapper()
-		hw.pm.On("GetHarborReplicationDumpJSON", r.Context()).Return(nil, tests.ErrFakeDB)
-		hw.h.GetHarborReplicationDump(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
+	t.Run("error getting downloads", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+				hw := newHandlersWrapper()
+				hw.pm.On("GetDownloadsJSON", r.Context(), "pkg1").Return(nil, tc.pmErr)
+				hw.h.GetDownloads(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetHarborReplicationDump(t *testing.T) {
+	t.Run("get harbor replication dump succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetHarborReplicationDumpJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetHarborReplicationDump(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(1*time.Hour), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting harbor replication dump", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetHarborReplicationDumpJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetHarborReplicationDump(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 		hw.assertExpectations(t)
 	})
 }
@@ -693,46 +1239,808 @@ func TestGetHelmExporterDump(t *testing.T) {
 		h := resp.Header
 		data, _ := ioutil.ReadAll(resp.Body)
 
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(1*time.Hour), h.Get("Cache-Control"))
-		assert.Equal(t, []byte("dataJSON"), data)
-		hw.assertExpectations(t)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(1*time.Hour), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting helm exporter dump", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetHelmExporterDumpJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetHelmExporterDump(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetInstallSnippets(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName", "packageName", "version"},
+			Values: []string{"repo1", "pkg1", "1.0.0"},
+		},
+	}
+	getPkgInput := &hub.GetPackageInput{
+		RepositoryName: "repo1",
+		PackageName:    "pkg1",
+		Version:        "1.0.0",
+	}
+
+	t.Run("error getting package", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				hub.ErrBlocked,
+				http.StatusUnavailableForLegalReasons,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetJSON", r.Context(), getPkgInput).Return(nil, tc.pmErr)
+				hw.h.GetInstallSnippets(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("install snippets not available", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetJSON", r.Context(), getPkgInput).Return([]byte(`{"name": "pkg1"}`), nil)
+		hw.h.GetInstallSnippets(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("install snippets returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetJSON", r.Context(), getPkgInput).Return([]byte(`{
+			"name": "pkg1",
+			"install_snippets": {"helm": "helm install pkg1"}
+		}`), nil)
+		hw.h.GetInstallSnippets(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{"helm": "helm install pkg1"}`, string(data))
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetNew(t *testing.T) {
+	t.Run("get new packages succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRankingJSON", r.Context(), "new").Return([]byte("dataJSON"), nil)
+		hw.h.GetNew(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting new packages", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRankingJSON", r.Context(), "new").Return(nil, tests.ErrFakeDB)
+		hw.h.GetNew(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetProductionUsage(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName", "packageName"},
+			Values: []string{"repo1", "pkg1"},
+		},
+	}
+
+	t.Run("get production usage succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetProductionUsageJSON", r.Context(), "repo1", "pkg1").Return([]byte("dataJSON"), nil)
+		hw.h.GetProductionUsage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting production usage", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetProductionUsageJSON", r.Context(), "repo1", "pkg1").Return(nil, tests.ErrFakeDB)
+		hw.h.GetProductionUsage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetRandom(t *testing.T) {
+	t.Run("get random packages succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRandomJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetRandom(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting random packages", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRandomJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetRandom(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetReadme(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+	getPkgInput := &hub.GetPackageInput{
+		PackageID: "pkg1",
+		Version:   "1.0.0",
+	}
+
+	t.Run("error getting package", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("Get", r.Context(), getPkgInput).Return(nil, tc.pmErr)
+				hw.h.GetReadme(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("package has no readme", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("Get", r.Context(), getPkgInput).Return(&hub.Package{}, nil)
+		hw.h.GetReadme(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("readme rendered successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("Get", r.Context(), getPkgInput).Return(&hub.Package{
+			Readme: "# Package 1\n\n[doc](docs/doc.md)<script>alert(1)</script>\n",
+			Links: []*hub.Link{
+				{Name: "homepage", URL: "https://pkg1.io"},
+				{Name: "source", URL: "https://github.com/org/repo"},
+			},
+		}, nil)
+		hw.h.GetReadme(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/html; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		expectedHTML := "<h1 id=\"package-1\">Package 1</h1>\n" +
+			"<p><a href=\"https://github.com/org/repo/blob/HEAD/docs/doc.md\" rel=\"nofollow\">doc</a></p>\n"
+		assert.Equal(t, expectedHTML, string(data))
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetSnapshotBundle(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+	contentURL := "https://content.url/pkg1-1.0.0.tgz"
+	data := &hub.SnapshotBundleData{
+		Name:       "pkg1",
+		Version:    "1.0.0",
+		ContentURL: contentURL,
+		ContainersImages: []*hub.ContainerImage{
+			{Image: "quay.io/org/img:1.0.0"},
+		},
+		RepositoryID:   "repo1",
+		RepositoryKind: hub.Helm,
+		Private:        true,
+	}
+
+	t.Run("error getting bundle data", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSnapshotBundleData", r.Context(), "pkg1", "1.0.0", true).Return(nil, tc.pmErr)
+				hw.h.GetSnapshotBundle(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error getting repository credentials", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotBundleData", r.Context(), "pkg1", "1.0.0", true).Return(data, nil)
+		hw.rm.On("GetByID", r.Context(), "repo1", true).Return(nil, tests.ErrFake)
+		hw.h.GetSnapshotBundle(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting chart archive", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotBundleData", r.Context(), "pkg1", "1.0.0", true).Return(data, nil)
+		hw.rm.On("GetByID", r.Context(), "repo1", true).Return(&hub.Repository{
+			AuthUser: "user",
+			AuthPass: "pass",
+		}, nil)
+		tgzReq, _ := http.NewRequest("GET", contentURL, nil)
+		tgzReq = tgzReq.WithContext(r.Context())
+		tgzReq.Header.Set("Accept-Encoding", "*")
+		tgzReq.SetBasicAuth("user", "pass")
+		hw.hc.On("Do", tgzReq).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusNotFound,
+		}, nil)
+		hw.h.GetSnapshotBundle(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("bundle returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotBundleData", r.Context(), "pkg1", "1.0.0", true).Return(data, nil)
+		hw.rm.On("GetByID", r.Context(), "repo1", true).Return(&hub.Repository{
+			AuthUser: "user",
+			AuthPass: "pass",
+		}, nil)
+		tgzReq, _ := http.NewRequest("GET", contentURL, nil)
+		tgzReq = tgzReq.WithContext(r.Context())
+		tgzReq.Header.Set("Accept-Encoding", "*")
+		tgzReq.SetBasicAuth("user", "pass")
+		f, _ := os.Open("testdata/pkg1-1.0.0.tgz")
+		hw.hc.On("Do", tgzReq).Return(&http.Response{
+			Body:       f,
+			StatusCode: http.StatusOK,
+		}, nil)
+		hw.h.GetSnapshotBundle(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/gzip", h.Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=pkg1-1.0.0-bundle.tgz", h.Get("Content-Disposition"))
+		assert.Equal(t, []string{
+			"pkg1-1.0.0/pkg1-1.0.0.tgz",
+			"pkg1-1.0.0/images.txt",
+			"pkg1-1.0.0/images.lock.yml",
+		}, getBundleFilesNames(t, resp.Body))
+		hw.assertExpectations(t)
+	})
+
+	t.Run("bundle returned successfully (as admin)", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotBundleData", r.Context(), "pkg1", "1.0.0", false).Return(&hub.SnapshotBundleData{
+			Name:           "pkg1",
+			Version:        "1.0.0",
+			RepositoryKind: hub.OLM,
+		}, nil)
+		hw.h.GetSnapshotBundleAsAdmin(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{
+			"pkg1-1.0.0/images.txt",
+			"pkg1-1.0.0/images.lock.yml",
+		}, getBundleFilesNames(t, resp.Body))
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetSnapshotContentWarnings(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get snapshot content warnings succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotContentWarningsJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		hw.h.GetSnapshotContentWarnings(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting snapshot content warnings", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotContentWarningsJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GetSnapshotContentWarnings(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetSnapshotImages(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get snapshot images succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotImages", r.Context(), "pkg1", "1.0.0").Return([]*hub.SnapshotImage{
+			{
+				Image:      "quay.io/org/img:1.0.0",
+				Registry:   "quay.io",
+				Repository: "org/img",
+				Tag:        "1.0.0",
+			},
+		}, nil)
+		hw.h.GetSnapshotImages(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.JSONEq(t, `[{
+			"image": "quay.io/org/img:1.0.0",
+			"whitelisted": false,
+			"registry": "quay.io",
+			"repository": "org/img",
+			"tag": "1.0.0"
+		}]`, string(data))
+		hw.assertExpectations(t)
+	})
+
+	t.Run("no images found", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotImages", r.Context(), "pkg1", "1.0.0").Return(nil, nil)
+		hw.h.GetSnapshotImages(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []byte("[]"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting snapshot images", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSnapshotImages", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GetSnapshotImages(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetSnapshotLicenseInventory(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("invalid problematic value", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?problematic=z", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.GetSnapshotLicenseInventory(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("get snapshot license inventory succeeded", func(t *testing.T) {
+		testCases := []struct {
+			query                   string
+			expectedProblematicOnly bool
+		}{
+			{"", false},
+			{"?problematic=false", false},
+			{"?problematic=true", true},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.query, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/"+tc.query, nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSnapshotLicenseInventoryJSON", r.Context(), "pkg1", "1.0.0", tc.expectedProblematicOnly).
+					Return([]byte("dataJSON"), nil)
+				hw.h.GetSnapshotLicenseInventory(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				h := resp.Header
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "application/json", h.Get("Content-Type"))
+				assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+				assert.Equal(t, []byte("dataJSON"), data)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error getting snapshot license inventory", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{hub.ErrInvalidInput, http.StatusBadRequest},
+			{hub.ErrNotFound, http.StatusNotFound},
+			{tests.ErrFakeDB, http.StatusInternalServerError},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSnapshotLicenseInventoryJSON", r.Context(), "pkg1", "1.0.0", false).
+					Return(nil, tc.pmErr)
+				hw.h.GetSnapshotLicenseInventory(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetSnapshotSBOM(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get snapshot sbom succeeded", func(t *testing.T) {
+		testCases := []struct {
+			query          string
+			expectedFormat string
+		}{
+			{"", hub.SBOMFormatCycloneDX},
+			{"?format=cyclonedx", hub.SBOMFormatCycloneDX},
+			{"?format=spdx", hub.SBOMFormatSPDX},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.expectedFormat+tc.query, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/"+tc.query, nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSnapshotSBOMJSON", r.Context(), "pkg1", "1.0.0", tc.expectedFormat).
+					Return([]byte("dataJSON"), nil)
+				hw.h.GetSnapshotSBOM(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				h := resp.Header
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "application/json", h.Get("Content-Type"))
+				assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+				assert.Equal(t, []byte("dataJSON"), data)
+				hw.assertExpectations(t)
+			})
+		}
 	})
 
-	t.Run("error getting helm exporter dump", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
+	t.Run("error getting snapshot sbom", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{hub.ErrInvalidInput, http.StatusBadRequest},
+			{tests.ErrFakeDB, http.StatusInternalServerError},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?format=spdx", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-		hw := newHandlersWrapper()
-		hw.pm.On("GetHelmExporterDumpJSON", r.Context()).Return(nil, tests.ErrFakeDB)
-		hw.h.GetHelmExporterDump(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSnapshotSBOMJSON", r.Context(), "pkg1", "1.0.0", hub.SBOMFormatSPDX).
+					Return(nil, tc.pmErr)
+				hw.h.GetSnapshotSBOM(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-		hw.assertExpectations(t)
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
 	})
 }
 
-func TestGetProductionUsage(t *testing.T) {
+func TestGetSnapshotSecurityReport(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
-			Keys:   []string{"repoName", "packageName"},
-			Values: []string{"repo1", "pkg1"},
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
 		},
 	}
 
-	t.Run("get production usage succeeded", func(t *testing.T) {
+	t.Run("get snapshot security report succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetProductionUsageJSON", r.Context(), "repo1", "pkg1").Return([]byte("dataJSON"), nil)
-		hw.h.GetProductionUsage(w, r)
+		hw.pm.On("GetSnapshotSecurityReportJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		hw.h.GetSnapshotSecurityReport(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
@@ -740,20 +2048,20 @@ func TestGetProductionUsage(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
 		assert.Equal(t, []byte("dataJSON"), data)
 		hw.assertExpectations(t)
 	})
 
-	t.Run("error getting production usage", func(t *testing.T) {
+	t.Run("error getting snapshot security report", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetProductionUsageJSON", r.Context(), "repo1", "pkg1").Return(nil, tests.ErrFakeDB)
-		hw.h.GetProductionUsage(w, r)
+		hw.pm.On("GetSnapshotSecurityReportJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GetSnapshotSecurityReport(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
@@ -762,15 +2070,23 @@ func TestGetProductionUsage(t *testing.T) {
 	})
 }
 
-func TestGetRandom(t *testing.T) {
-	t.Run("get random packages succeeded", func(t *testing.T) {
+func TestGetSnapshotSecurityReportSuppressed(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get snapshot security report suppressed vulnerabilities succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetRandomJSON", r.Context()).Return([]byte("dataJSON"), nil)
-		hw.h.GetRandom(w, r)
+		hw.pm.On("GetSnapshotSecurityReportSuppressedJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		hw.h.GetSnapshotSecurityReportSuppressed(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
@@ -783,14 +2099,15 @@ func TestGetRandom(t *testing.T) {
 		hw.assertExpectations(t)
 	})
 
-	t.Run("error getting random packages", func(t *testing.T) {
+	t.Run("error getting snapshot security report suppressed vulnerabilities", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetRandomJSON", r.Context()).Return(nil, tests.ErrFakeDB)
-		hw.h.GetRandom(w, r)
+		hw.pm.On("GetSnapshotSecurityReportSuppressedJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		hw.h.GetSnapshotSecurityReportSuppressed(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
@@ -799,49 +2116,91 @@ func TestGetRandom(t *testing.T) {
 	})
 }
 
-func TestGetSnapshotSecurityReport(t *testing.T) {
+func TestGetSocialImage(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
-			Keys:   []string{"packageID", "version"},
-			Values: []string{"pkg1", "1.0.0"},
+			Keys:   []string{"repoName", "packageName"},
+			Values: []string{"repo1", "pkg1"},
 		},
 	}
+	input := &hub.GetPackageInput{
+		RepositoryName: "repo1",
+		PackageName:    "pkg1",
+	}
 
-	t.Run("get snapshot security report succeeded", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
-		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	t.Run("get package summary failed", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-		hw := newHandlersWrapper()
-		hw.pm.On("GetSnapshotSecurityReportJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
-		hw.h.GetSnapshotSecurityReport(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
-		h := resp.Header
-		data, _ := ioutil.ReadAll(resp.Body)
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSummaryJSON", r.Context(), input).Return(nil, tc.pmErr)
+				hw.h.GetSocialImage(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
-		assert.Equal(t, []byte("dataJSON"), data)
-		hw.assertExpectations(t)
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
 	})
 
-	t.Run("error getting snapshot security report", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
-		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	t.Run("social image generated successfully", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			logo    []byte
+			logoErr error
+		}{
+			{"logo available", []byte("logo"), nil},
+			{"logo not available", nil, hub.ErrNotFound},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-		hw := newHandlersWrapper()
-		hw.pm.On("GetSnapshotSecurityReportJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
-		hw.h.GetSnapshotSecurityReport(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSummaryJSON", r.Context(), input).Return([]byte(`{
+					"normalized_name": "pkg1",
+					"logo_image_id": "logoImageID",
+					"version": "1.0.0",
+					"stars": 10,
+					"repository": {"name": "repo1", "organization_name": "org1"}
+				}`), nil)
+				hw.is.On("GetImage", r.Context(), "logoImageID", "4x").Return(tc.logo, tc.logoErr)
+				hw.h.GetSocialImage(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				h := resp.Header
+				data, _ := ioutil.ReadAll(resp.Body)
 
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-		hw.assertExpectations(t)
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "image/png", h.Get("Content-Type"))
+				assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+				assert.Equal(t, "image/png", http.DetectContentType(data))
+				hw.assertExpectations(t)
+			})
+		}
 	})
 }
 
@@ -1029,15 +2388,139 @@ func TestGetSummary(t *testing.T) {
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSummaryJSON", r.Context(), input).Return(nil, tc.pmErr)
+				hw.h.GetSummary(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get package summary succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSummaryJSON", r.Context(), input).Return([]byte("dataJSON"), nil)
+		hw.h.GetSummary(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetTrending(t *testing.T) {
+	t.Run("invalid period", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?period=1y", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRankingJSON", r.Context(), "trending-1y").Return(nil, hub.ErrInvalidInput)
+		hw.h.GetTrending(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("get trending packages succeeded", func(t *testing.T) {
+		testCases := []struct {
+			query           string
+			expectedRanking string
+		}{
+			{"", "trending-7d"},
+			{"?period=7d", "trending-7d"},
+			{"?period=30d", "trending-30d"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.expectedRanking+tc.query, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/"+tc.query, nil)
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetRankingJSON", r.Context(), tc.expectedRanking).Return([]byte("dataJSON"), nil)
+				hw.h.GetTrending(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				h := resp.Header
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "application/json", h.Get("Content-Type"))
+				assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+				assert.Equal(t, []byte("dataJSON"), data)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error getting trending packages", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRankingJSON", r.Context(), "trending-7d").Return(nil, tests.ErrFakeDB)
+		hw.h.GetTrending(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetUpgradeGraph(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID"},
+			Values: []string{"pkg1"},
+		},
+	}
+
+	t.Run("error getting upgrade graph", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{hub.ErrInvalidInput, http.StatusBadRequest},
+			{hub.ErrNotFound, http.StatusNotFound},
+			{tests.ErrFakeDB, http.StatusInternalServerError},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
 				r, _ := http.NewRequest("GET", "/", nil)
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.pm.On("GetSummaryJSON", r.Context(), input).Return(nil, tc.pmErr)
-				hw.h.GetSummary(w, r)
+				hw.pm.On("GetUpgradeGraph", r.Context(), "pkg1").Return(nil, tc.err)
+				hw.h.GetUpgradeGraph(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -1047,15 +2530,24 @@ func TestGetSummary(t *testing.T) {
 		}
 	})
 
-	t.Run("get package summary succeeded", func(t *testing.T) {
+	t.Run("get upgrade graph succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.pm.On("GetSummaryJSON", r.Context(), input).Return([]byte("dataJSON"), nil)
-		hw.h.GetSummary(w, r)
+		hw.pm.On("GetUpgradeGraph", r.Context(), "pkg1").Return(&hub.UpgradeGraph{
+			DefaultChannel: "stable",
+			Channels: []*hub.UpgradeGraphChannel{
+				{Name: "stable", Head: "1.0.0", Entries: []string{"1.0.0", "0.9.0"}},
+			},
+			Versions: []*hub.UpgradeGraphVersion{
+				{Version: "1.0.0", Replaces: "0.9.0", Skips: []string{"0.9.1"}},
+				{Version: "0.9.0"},
+			},
+		}, nil)
+		hw.h.GetUpgradeGraph(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 		h := resp.Header
@@ -1064,7 +2556,14 @@ func TestGetSummary(t *testing.T) {
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 		assert.Equal(t, "application/json", h.Get("Content-Type"))
 		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
-		assert.Equal(t, []byte("dataJSON"), data)
+		assert.JSONEq(t, `{
+			"default_channel": "stable",
+			"channels": [{"name": "stable", "head": "1.0.0", "entries": ["1.0.0", "0.9.0"]}],
+			"versions": [
+				{"version": "1.0.0", "replaces": "0.9.0", "skips": ["0.9.1"]},
+				{"version": "0.9.0"}
+			]
+		}`, string(data))
 		hw.assertExpectations(t)
 	})
 }
@@ -1183,22 +2682,91 @@ func TestGetViews(t *testing.T) {
 	})
 }
 
+func TestGetVulnerabilityStatements(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get vulnerability statements succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetVulnerabilityStatementsJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		hw.h.GetVulnerabilityStatements(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting vulnerability statements", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{hub.ErrInvalidInput, http.StatusBadRequest},
+			{tests.ErrFakeDB, http.StatusInternalServerError},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetVulnerabilityStatementsJSON", r.Context(), "pkg1", "1.0.0").Return(nil, tc.pmErr)
+				hw.h.GetVulnerabilityStatements(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestInjectIndexMeta(t *testing.T) {
-	checkIndexMeta := func(expectedTitle, expectedDescription interface{}) http.HandlerFunc {
+	checkIndexMeta := func(expectedTitle, expectedDescription, expectedOpenGraphImage interface{}) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
 			title, _ := r.Context().Value(hub.IndexMetaTitleKey).(string)
 			description, _ := r.Context().Value(hub.IndexMetaDescriptionKey).(string)
+			openGraphImage, _ := r.Context().Value(hub.IndexMetaOpenGraphImageKey).(string)
 			assert.Equal(t, expectedTitle, title)
 			assert.Equal(t, expectedDescription, description)
+			assert.Equal(t, expectedOpenGraphImage, openGraphImage)
 		}
 	}
+	err := hub.RegisterCustomRepositoryKind(&hub.CustomRepositoryKind{
+		Kind:        hub.MinCustomRepositoryKind + 10,
+		Name:        "index-meta-kind",
+		DisplayName: "Index meta kind",
+	})
+	require.NoError(t, err)
 	testCases := []struct {
-		p                   *hub.Package
-		err                 error
-		expectedTitle       string
-		expectedDescription string
+		kind                   string
+		p                      *hub.Package
+		err                    error
+		expectedTitle          string
+		expectedDescription    string
+		expectedOpenGraphImage string
 	}{
 		{
+			"helm",
 			&hub.Package{
 				NormalizedName: "pkg1",
 				Version:        "1.0.0",
@@ -1211,12 +2779,30 @@ func TestInjectIndexMeta(t *testing.T) {
 			nil,
 			"pkg1 1.0.0 · org1/repo1",
 			"description",
+			"baseURL/api/v1/packages/helm/repo1/pkg1/social-image.png",
+		},
+		{
+			"helm",
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Version:        "1.0.0",
+				Repository: &hub.Repository{
+					Name:      "repo1",
+					UserAlias: "user1",
+				},
+			},
+			nil,
+			"pkg1 1.0.0 · user1/repo1",
+			"",
+			"baseURL/api/v1/packages/helm/repo1/pkg1/social-image.png",
 		},
 		{
+			"index-meta-kind",
 			&hub.Package{
 				NormalizedName: "pkg1",
 				Version:        "1.0.0",
 				Repository: &hub.Repository{
+					Kind:      hub.MinCustomRepositoryKind + 10,
 					Name:      "repo1",
 					UserAlias: "user1",
 				},
@@ -1224,12 +2810,23 @@ func TestInjectIndexMeta(t *testing.T) {
 			nil,
 			"pkg1 1.0.0 · user1/repo1",
 			"",
+			"baseURL/api/v1/packages/index-meta-kind/repo1/pkg1/social-image.png",
 		},
 		{
+			"helm",
 			nil,
 			tests.ErrFake,
 			"",
 			"",
+			"",
+		},
+		{
+			"unknown-kind",
+			nil,
+			nil,
+			"",
+			"",
+			"",
 		},
 	}
 	for i, tc := range testCases {
@@ -1238,14 +2835,22 @@ func TestInjectIndexMeta(t *testing.T) {
 			t.Parallel()
 			w := httptest.NewRecorder()
 			r, _ := http.NewRequest("GET", "/", nil)
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"kind"},
+					Values: []string{tc.kind},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 			hw := newHandlersWrapper()
-			if tc.err == nil {
+			switch {
+			case tc.p != nil:
 				hw.pm.On("Get", r.Context(), mock.Anything).Return(tc.p, nil)
-			} else {
+			case tc.err != nil:
 				hw.pm.On("Get", r.Context(), mock.Anything).Return(nil, tc.err)
 			}
-			hw.h.InjectIndexMeta(checkIndexMeta(tc.expectedTitle, tc.expectedDescription)).ServeHTTP(w, r)
+			hw.h.InjectIndexMeta(checkIndexMeta(tc.expectedTitle, tc.expectedDescription, tc.expectedOpenGraphImage)).ServeHTTP(w, r)
 			resp := w.Result()
 			defer resp.Body.Close()
 
@@ -1255,8 +2860,15 @@ func TestInjectIndexMeta(t *testing.T) {
 	}
 }
 
-func TestRssFeed(t *testing.T) {
-	t.Run("error getting rss feed package", func(t *testing.T) {
+func TestRequestSnapshotScan(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("error requesting snapshot scan", func(t *testing.T) {
 		testCases := []struct {
 			pmErr              error
 			expectedStatusCode int
@@ -1266,8 +2878,12 @@ func TestRssFeed(t *testing.T) {
 				http.StatusBadRequest,
 			},
 			{
-				hub.ErrNotFound,
-				http.StatusNotFound,
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrTooManyRequests,
+				http.StatusTooManyRequests,
 			},
 			{
 				tests.ErrFakeDB,
@@ -1279,11 +2895,12 @@ func TestRssFeed(t *testing.T) {
 			t.Run(tc.pmErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("GET", "/", nil)
+				r, _ := http.NewRequest("POST", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.pm.On("Get", r.Context(), mock.Anything).Return(nil, tc.pmErr)
-				hw.h.RssFeed(w, r)
+				hw.pm.On("RequestSnapshotScan", r.Context(), "pkg1", "1.0.0").Return(tc.pmErr)
+				hw.h.RequestSnapshotScan(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -1293,91 +2910,20 @@ func TestRssFeed(t *testing.T) {
 		}
 	})
 
-	t.Run("rss feed built successfully", func(t *testing.T) {
-		testCases := []struct {
-			p                   *hub.Package
-			expectedRssFeedData []byte
-		}{
-			{
-				&hub.Package{
-					PackageID:      "0001",
-					NormalizedName: "pkg1",
-					Description:    "description",
-					Version:        "1.0.0",
-					LogoImageID:    "0001",
-					TS:             1592299234,
-					AvailableVersions: []*hub.Version{
-						{
-							Version: "0.0.9",
-							TS:      1592299233,
-						},
-						{
-							Version: "1.0.0",
-							TS:      1592299234,
-						},
-					},
-					Maintainers: []*hub.Maintainer{
-						{
-							Name:  "name1",
-							Email: "email1",
-						},
-					},
-					Repository: &hub.Repository{
-						Name:             "repo1",
-						OrganizationName: "org1",
-					},
-				},
-				[]byte(`<?xml version="1.0" encoding="UTF-8"?><rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
-  <channel>
-    <title>org1/pkg1 (Artifact Hub)</title>
-    <link>baseURL</link>
-    <description>description</description>
-    <managingEditor>email1 (name1)</managingEditor>
-    <image>
-      <url>baseURL/image/0001@4x</url>
-      <title>logo</title>
-      <link>baseURL</link>
-    </image>
-    <item>
-      <title>1.0.0</title>
-      <link>baseURL/packages/helm/repo1/pkg1/1.0.0</link>
-      <description>pkg1 1.0.0</description>
-      <guid>0001#1.0.0</guid>
-      <pubDate>Tue, 16 Jun 2020 09:20:34 +0000</pubDate>
-    </item>
-    <item>
-      <title>0.0.9</title>
-      <link>baseURL/packages/helm/repo1/pkg1/0.0.9</link>
-      <description>pkg1 0.0.9</description>
-      <guid>0001#0.0.9</guid>
-      <pubDate>Tue, 16 Jun 2020 09:20:33 +0000</pubDate>
-    </item>
-  </channel>
-</rss>`),
-			},
-		}
-		for i, tc := range testCases {
-			tc := tc
-			t.Run(strconv.Itoa(i), func(t *testing.T) {
-				t.Parallel()
-				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("GET", "/", nil)
+	t.Run("snapshot scan requested successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-				hw := newHandlersWrapper()
-				hw.pm.On("Get", r.Context(), mock.Anything).Return(tc.p, nil)
-				hw.h.RssFeed(w, r)
-				resp := w.Result()
-				defer resp.Body.Close()
-				h := resp.Header
-				data, _ := ioutil.ReadAll(resp.Body)
+		hw := newHandlersWrapper()
+		hw.pm.On("RequestSnapshotScan", r.Context(), "pkg1", "1.0.0").Return(nil)
+		hw.h.RequestSnapshotScan(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
 
-				assert.Equal(t, http.StatusOK, resp.StatusCode)
-				assert.Equal(t, "text/xml; charset=utf-8", h.Get("Content-Type"))
-				assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
-				assert.Equal(t, tc.expectedRssFeedData, data)
-				hw.assertExpectations(t)
-			})
-		}
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		hw.assertExpectations(t)
 	})
 }
 
@@ -1396,6 +2942,7 @@ func TestSearch(t *testing.T) {
 			{"invalid official", "official=z"},
 			{"invalid operators", "operators=z"},
 			{"invalid deprecated", "deprecated=z"},
+			{"invalid signature verified", "signature_verified=z"},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -1449,10 +2996,13 @@ func TestSearch(t *testing.T) {
 		v.Set("official", "true")
 		v.Set("operators", "true")
 		v.Set("deprecated", "true")
+		v.Set("signature_verified", "true")
 		v.Add("license", "l1")
 		v.Add("license", "l2")
+		v.Add("license_family", "permissive")
 		v.Add("capabilities", "c1")
 		v.Add("capabilities", "c2")
+		v.Set("k8s_version", "1.29")
 		v.Set("sort", "stars")
 		r, _ := http.NewRequest("GET", "/?"+v.Encode(), nil)
 
@@ -1471,8 +3021,11 @@ func TestSearch(t *testing.T) {
 			Official:          true,
 			Operators:         true,
 			Deprecated:        true,
+			SignatureVerified: true,
 			Licenses:          []string{"l1", "l2"},
+			LicenseFamilies:   []string{"permissive"},
 			Capabilities:      []string{"c1", "c2"},
+			K8sVersion:        "1.29",
 			Sort:              "stars",
 		}).Return(&hub.JSONQueryResult{
 			Data:       []byte("dataJSON"),
@@ -1506,6 +3059,165 @@ func TestSearch(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 		hw.assertExpectations(t)
 	})
+
+	t.Run("export", func(t *testing.T) {
+		pkg1JSON := `{
+			"package_id": "pkgID1",
+			"name": "Package 1",
+			"normalized_name": "package-1",
+			"version": "1.0.0",
+			"license": "Apache-2.0",
+			"repository": {"kind": 0, "name": "repo1"}
+		}`
+		pkg2JSON := `{
+			"package_id": "pkgID2",
+			"name": "package2",
+			"normalized_name": "package2",
+			"version": "2.0.0",
+			"license": "@SUM(1)",
+			"repository": {"kind": 3, "name": "repo2", "verified_publisher": true}
+		}`
+		offsetIs := func(offset int) interface{} {
+			return mock.MatchedBy(func(input *hub.SearchPackageInput) bool {
+				return input.Limit == exportPageLimit && input.Offset == offset && !input.Facets
+			})
+		}
+
+		t.Run("invalid export params", func(t *testing.T) {
+			testCases := []struct {
+				desc   string
+				params string
+			}{
+				{"invalid format", "export=xml"},
+				{"invalid field", "export=csv&fields=name,invalid"},
+			}
+			for _, tc := range testCases {
+				tc := tc
+				t.Run(tc.desc, func(t *testing.T) {
+					t.Parallel()
+					w := httptest.NewRecorder()
+					r, _ := http.NewRequest("GET", "/?"+tc.params, nil)
+
+					hw := newHandlersWrapper()
+					hw.h.Search(w, r)
+					resp := w.Result()
+					defer resp.Body.Close()
+
+					assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+					hw.assertExpectations(t)
+				})
+			}
+		})
+
+		t.Run("error searching packages", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/?export=csv", nil)
+
+			hw := newHandlersWrapper()
+			hw.pm.On("SearchJSON", r.Context(), offsetIs(0)).Return(nil, tests.ErrFakeDB)
+			hw.h.Search(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+			hw.assertExpectations(t)
+		})
+
+		t.Run("csv export succeeded", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/?export=csv&limit=1&offset=10", nil)
+
+			hw := newHandlersWrapper()
+			page1 := make([]string, 0, exportPageLimit)
+			for i := 0; i < exportPageLimit; i++ {
+				page1 = append(page1, pkg1JSON)
+			}
+			hw.pm.On("SearchJSON", r.Context(), offsetIs(0)).Return(&hub.JSONQueryResult{
+				Data:       []byte(`{"packages": [` + strings.Join(page1, ",") + `]}`),
+				TotalCount: exportPageLimit + 1,
+			}, nil)
+			hw.pm.On("SearchJSON", r.Context(), offsetIs(exportPageLimit)).Return(&hub.JSONQueryResult{
+				Data:       []byte(`{"packages": [` + pkg2JSON + `]}`),
+				TotalCount: exportPageLimit + 1,
+			}, nil)
+			hw.h.Search(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			h := resp.Header
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "text/csv", h.Get("Content-Type"))
+			assert.Equal(t, "attachment; filename=packages.csv", h.Get("Content-Disposition"))
+			assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+			assert.Equal(t, strconv.Itoa(exportPageLimit+1), h.Get(helpers.PaginationTotalCount))
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			require.Len(t, lines, exportPageLimit+2)
+			assert.Equal(t, "name,version,kind,repository,license,url", lines[0])
+			assert.Equal(t, "package-1,1.0.0,helm,repo1,Apache-2.0,baseURL/packages/helm/repo1/package-1", lines[1])
+			assert.Equal(t, "package2,2.0.0,olm,repo2,'@SUM(1),baseURL/packages/olm/repo2/package2", lines[exportPageLimit+1])
+			hw.assertExpectations(t)
+		})
+
+		t.Run("export limited to max packages", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/?export=csv", nil)
+
+			hw := newHandlersWrapper()
+			page := func(n int) *hub.JSONQueryResult {
+				pkgs := make([]string, 0, n)
+				for i := 0; i < n; i++ {
+					pkgs = append(pkgs, pkg1JSON)
+				}
+				return &hub.JSONQueryResult{
+					Data:       []byte(`{"packages": [` + strings.Join(pkgs, ",") + `]}`),
+					TotalCount: exportMaxPackages * 2,
+				}
+			}
+			lastPageLimit := exportMaxPackages % exportPageLimit
+			hw.pm.On("SearchJSON", r.Context(), mock.MatchedBy(func(input *hub.SearchPackageInput) bool {
+				return input.Limit == exportPageLimit
+			})).Return(page(exportPageLimit), nil)
+			hw.pm.On("SearchJSON", r.Context(), mock.MatchedBy(func(input *hub.SearchPackageInput) bool {
+				return input.Limit == lastPageLimit && input.Offset == exportMaxPackages-lastPageLimit
+			})).Return(page(lastPageLimit), nil).Once()
+			hw.h.Search(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			assert.Len(t, lines, exportMaxPackages+1)
+			hw.assertExpectations(t)
+		})
+
+		t.Run("ndjson export succeeded", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/?export=ndjson&fields=name,verified_publisher", nil)
+
+			hw := newHandlersWrapper()
+			hw.pm.On("SearchJSON", r.Context(), offsetIs(0)).Return(&hub.JSONQueryResult{
+				Data:       []byte(`{"packages": [` + pkg1JSON + `,` + pkg2JSON + `]}`),
+				TotalCount: 2,
+			}, nil)
+			hw.h.Search(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+			assert.Equal(t, `{"name":"package-1","verified_publisher":false}
+{"name":"package2","verified_publisher":true}
+`, string(data))
+			hw.assertExpectations(t)
+		})
+	})
 }
 
 func TestSearchMonocular(t *testing.T) {
@@ -1649,6 +3361,82 @@ func TestTrackView(t *testing.T) {
 	})
 }
 
+func TestUpdateVulnerabilityStatements(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+	body := `{"statements": [{"vulnerability_id": "CVE-2022-0001", "status": "not_affected"}]}`
+	statements := []*hub.VulnerabilityStatement{
+		{
+			VulnerabilityID: "CVE-2022-0001",
+			Status:          hub.VulnerabilityStatementNotAffected,
+		},
+	}
+
+	t.Run("invalid body", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("{invalid"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.UpdateVulnerabilityStatements(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error updating vulnerability statements", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{hub.ErrInvalidInput, http.StatusBadRequest},
+			{hub.ErrInsufficientPrivilege, http.StatusForbidden},
+			{tests.ErrFakeDB, http.StatusInternalServerError},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(body))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("UpdateVulnerabilityStatements", r.Context(), "pkg1", "1.0.0", statements).Return(tc.pmErr)
+				hw.h.UpdateVulnerabilityStatements(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("vulnerability statements updated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("UpdateVulnerabilityStatements", r.Context(), "pkg1", "1.0.0", statements).Return(nil)
+		hw.h.UpdateVulnerabilityStatements(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
 func TestGetChartArchive(t *testing.T) {
 	ctx := context.Background()
 	packageID := "packageID"
@@ -1774,7 +3562,7 @@ func TestGetChartArchive(t *testing.T) {
 		hw := newHandlersWrapper()
 		hw.pm.On("Get", ctx, getPkgInput).Return(p3, nil)
 		ref := strings.TrimPrefix(p3ContentURL, hub.RepositoryOCIPrefix)
-		hw.op.On("PullLayer", ctx, ref, helm.ChartContentLayerMediaType, "", "").
+		hw.op.On("PullLayer", mock.Anything, ref, helm.ChartContentLayerMediaType, "", "").
 			Return(ocispec.Descriptor{}, nil, tests.ErrFake)
 		chrt, err := hw.h.getChartArchive(ctx, packageID, version)
 
@@ -1854,7 +3642,7 @@ func TestGetChartArchive(t *testing.T) {
 		hw.pm.On("Get", ctx, getPkgInput).Return(p3, nil)
 		layerData, _ := os.ReadFile("testdata/pkg1-1.0.0.tgz")
 		ref := strings.TrimPrefix(p3ContentURL, hub.RepositoryOCIPrefix)
-		hw.op.On("PullLayer", ctx, ref, helm.ChartContentLayerMediaType, "", "").
+		hw.op.On("PullLayer", mock.Anything, ref, helm.ChartContentLayerMediaType, "", "").
 			Return(ocispec.Descriptor{}, layerData, nil)
 		chrt, err := hw.h.getChartArchive(ctx, packageID, version)
 
@@ -2041,17 +3829,22 @@ type handlersWrapper struct {
 	hc *tests.HTTPClientMock
 	op *oci.PullerMock
 	vt *pkg.ViewsTrackerMock
+	is *img.StoreMock
 	h  *Handlers
 }
 
 func newHandlersWrapper() *handlersWrapper {
 	cfg := viper.New()
 	cfg.Set("server.baseURL", "baseURL")
+	cfg.Set("theme.colors.primary", "#417598")
+	cfg.Set("theme.colors.secondary", "#2D4857")
+	cfg.Set("server.blocklist.appealContact", "appeals@artifacthub.io")
 	pm := &pkg.ManagerMock{}
 	rm := &repo.ManagerMock{}
 	hc := &tests.HTTPClientMock{}
 	op := &oci.PullerMock{}
 	vt := &pkg.ViewsTrackerMock{}
+	is := &img.StoreMock{}
 
 	return &handlersWrapper{
 		pm: pm,
@@ -2059,7 +3852,8 @@ func newHandlersWrapper() *handlersWrapper {
 		hc: hc,
 		op: op,
 		vt: vt,
-		h:  NewHandlers(pm, rm, cfg, hc, op, vt),
+		is: is,
+		h:  NewHandlers(pm, rm, cfg, hc, op, vt, is),
 	}
 }
 
@@ -2068,4 +3862,24 @@ func (hw *handlersWrapper) assertExpectations(t *testing.T) {
 	hw.rm.AssertExpectations(t)
 	hw.hc.AssertExpectations(t)
 	hw.op.AssertExpectations(t)
+	hw.is.AssertExpectations(t)
+}
+
+// getBundleFilesNames is a helper that returns the names of the files in the
+// bundle provided.
+func getBundleFilesNames(t *testing.T, r io.Reader) []string {
+	t.Helper()
+	gzr, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gzr)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+	}
+	return names
 }
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -50,6 +51,10 @@ func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.repoManager.Add(r.Context(), orgName, repo); err != nil {
+		if errors.Is(err, hub.ErrPendingApproval) {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
 		h.logger.Error().Err(err).Str("method", "Add").Send()
 		helpers.RenderErrorJSON(w, err)
 		return
@@ -57,6 +62,19 @@ func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// ApproveChange is an http handler used to approve a pending change in a
+// repository owned by an organization.
+func (h *Handlers) ApproveChange(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	changeID := chi.URLParam(r, "changeID")
+	if err := h.repoManager.ApproveChange(r.Context(), orgName, changeID); err != nil {
+		h.logger.Error().Err(err).Str("method", "ApproveChange").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Badge is an http handler that returns the information needed to render the
 // repository badge.
 func (h *Handlers) Badge(w http.ResponseWriter, r *http.Request) {
@@ -105,6 +123,10 @@ func (h *Handlers) ClaimOwnership(w http.ResponseWriter, r *http.Request) {
 	repoName := chi.URLParam(r, "repoName")
 	orgName := r.FormValue("org")
 	if err := h.repoManager.ClaimOwnership(r.Context(), repoName, orgName); err != nil {
+		if errors.Is(err, hub.ErrPendingApproval) {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
 		h.logger.Error().Err(err).Str("method", "ClaimOwnership").Send()
 		helpers.RenderErrorJSON(w, err)
 		return
@@ -117,6 +139,10 @@ func (h *Handlers) ClaimOwnership(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	repoName := chi.URLParam(r, "repoName")
 	if err := h.repoManager.Delete(r.Context(), repoName); err != nil {
+		if errors.Is(err, hub.ErrPendingApproval) {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
 		h.logger.Error().Err(err).Str("method", "Delete").Send()
 		helpers.RenderErrorJSON(w, err)
 		return
@@ -124,6 +150,124 @@ func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// GetEvents is an http handler used to get the events registered for the
+// provided repository and its packages.
+func (h *Handlers) GetEvents(w http.ResponseWriter, r *http.Request) {
+	input, err := buildGetEventsInput(r.URL.Query())
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetEvents").Msg("invalid query")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	dataJSON, err := h.repoManager.GetEventsJSON(r.Context(), repoName, input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetEvents").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetPendingChanges is an http handler used to get the repositories changes
+// pending of approval in the provided organization.
+func (h *Handlers) GetPendingChanges(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.repoManager.GetPendingChangesJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetPendingChanges").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetStats is an http handler used to get some stats about the provided
+// repository.
+func (h *Handlers) GetStats(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	dataJSON, err := h.repoManager.GetStatsJSON(r.Context(), repoName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetStats").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetSubscriptions is an http handler used to get the number of subscribers
+// of the packages in the provided repository.
+func (h *Handlers) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	dataJSON, err := h.repoManager.GetSubscriptionsJSON(r.Context(), repoName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSubscriptions").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetViews is an http handler used to get the views of the packages in the
+// provided repository.
+func (h *Handlers) GetViews(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	dataJSON, err := h.repoManager.GetViewsJSON(r.Context(), repoName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetViews").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// RegisterOwnershipClaimToken is an http handler used to register a token that
+// allows the requesting user to claim the ownership of the provided repository
+// by adding it to the repository metadata file.
+func (h *Handlers) RegisterOwnershipClaimToken(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	token, err := h.repoManager.RegisterOwnershipClaimToken(r.Context(), repoName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterOwnershipClaimToken").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string]string{"token": token})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// RegisterPackagesDownloads is an http handler used to register the downloads
+// counters of the packages in the provided repository.
+func (h *Handlers) RegisterPackagesDownloads(w http.ResponseWriter, r *http.Request) {
+	var downloads []*hub.PackageDownloads
+	if err := json.NewDecoder(r.Body).Decode(&downloads); err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterPackagesDownloads").Msg("invalid downloads")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.RegisterPackagesDownloads(r.Context(), repoName, downloads); err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterPackagesDownloads").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RejectChange is an http handler used to reject a pending change in a
+// repository owned by an organization.
+func (h *Handlers) RejectChange(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	changeID := chi.URLParam(r, "changeID")
+	if err := h.repoManager.RejectChange(r.Context(), orgName, changeID); err != nil {
+		h.logger.Error().Err(err).Str("method", "RejectChange").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Search is an http handler used to search for repositories in the hub
 // database.
 func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
@@ -153,7 +297,11 @@ func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) Transfer(w http.ResponseWriter, r *http.Request) {
 	repoName := chi.URLParam(r, "repoName")
 	orgName := r.FormValue("org")
-	if err := h.repoManager.Transfer(r.Context(), repoName, orgName, false); err != nil {
+	if err := h.repoManager.Transfer(r.Context(), repoName, orgName); err != nil {
+		if errors.Is(err, hub.ErrPendingApproval) {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
 		h.logger.Error().Err(err).Str("method", "Transfer").Send()
 		helpers.RenderErrorJSON(w, err)
 		return
@@ -172,6 +320,10 @@ func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 	}
 	repo.Name = chi.URLParam(r, "repoName")
 	if err := h.repoManager.Update(r.Context(), repo); err != nil {
+		if errors.Is(err, hub.ErrPendingApproval) {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
 		h.logger.Error().Err(err).Str("method", "Update").Send()
 		helpers.RenderErrorJSON(w, err)
 		return
@@ -179,6 +331,37 @@ func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// buildGetEventsInput builds a repository events query from a map of query
+// string values, validating them as they are extracted.
+func buildGetEventsInput(qs url.Values) (*hub.GetRepositoryEventsInput, error) {
+	// Event kinds
+	eventKinds := make([]hub.EventKind, 0, len(qs["event_kind"]))
+	for _, eventKindStr := range qs["event_kind"] {
+		eventKind, err := strconv.Atoi(eventKindStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event kind: %s", eventKindStr)
+		}
+		eventKinds = append(eventKinds, hub.EventKind(eventKind))
+	}
+
+	// Limit
+	var limit int
+	if qs.Get("limit") != "" {
+		var err error
+		limit, err = strconv.Atoi(qs.Get("limit"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", qs.Get("limit"))
+		}
+	}
+
+	return &hub.GetRepositoryEventsInput{
+		Cursor:      qs.Get("cursor"),
+		EventKinds:  eventKinds,
+		Limit:       limit,
+		PackageName: qs.Get("package"),
+	}, nil
+}
+
 // buildSearchInput builds a packages search query from a map of query string
 // values, validating them as they are extracted.
 func buildSearchInput(qs url.Values) (*hub.SearchRepositoryInput, error) {
@@ -103,6 +103,11 @@ func TestAdd(t *testing.T) {
 				nil,
 				http.StatusCreated,
 			},
+			{
+				"add repository pending approval",
+				hub.ErrPendingApproval,
+				http.StatusAccepted,
+			},
 			{
 				"error adding repository (insufficient privilege)",
 				hub.ErrInsufficientPrivilege,
@@ -136,6 +141,66 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestApproveChange(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "changeID"},
+			Values: []string{"org1", "changeID"},
+		},
+	}
+
+	testCases := []struct {
+		description        string
+		err                error
+		expectedStatusCode int
+	}{
+		{
+			"change approved successfully",
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			"error approving change (invalid input)",
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			"error approving change (insufficient privilege)",
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			"error approving change (not found)",
+			hub.ErrNotFound,
+			http.StatusNotFound,
+		},
+		{
+			"error approving change (db error)",
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("PUT", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.rm.On("ApproveChange", r.Context(), "org1", "changeID").Return(tc.err)
+			hw.h.ApproveChange(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.rm.AssertExpectations(t)
+		})
+	}
+}
+
 func TestBadge(t *testing.T) {
 	t.Run("badge info returned successfully", func(t *testing.T) {
 		t.Parallel()
@@ -249,76 +314,474 @@ func TestCheckAvailability(t *testing.T) {
 			}
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-			hw := newHandlersWrapper()
-			hw.rm.On("CheckAvailability", r.Context(), "repositoryName", "value").
-				Return(false, tests.ErrFakeDB)
-			hw.h.CheckAvailability(w, r)
-			resp := w.Result()
-			defer resp.Body.Close()
+			hw := newHandlersWrapper()
+			hw.rm.On("CheckAvailability", r.Context(), "repositoryName", "value").
+				Return(false, tests.ErrFakeDB)
+			hw.h.CheckAvailability(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+			hw.rm.AssertExpectations(t)
+		})
+	})
+}
+
+func TestClaimOwnership(t *testing.T) {
+	t.Run("invalid input - missing repo name", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("ClaimOwnership", r.Context(), "", "").Return(hub.ErrInvalidInput)
+		hw.h.ClaimOwnership(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"repository ownership claim succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"repository ownership claim pending approval",
+				hub.ErrPendingApproval,
+				http.StatusAccepted,
+			},
+			{
+				"error claiming repository ownership (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error claiming repository ownership (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/?org=org1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"repoName"},
+						Values: []string{"repo1"},
+					},
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("ClaimOwnership", r.Context(), "repo1", "org1").Return(tc.err)
+				hw.h.ClaimOwnership(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestDelete(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("delete repository succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("Delete", r.Context(), "repo1").Return(nil)
+		hw.h.Delete(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("delete repository pending approval", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("Delete", r.Context(), "repo1").Return(hub.ErrPendingApproval)
+		hw.h.Delete(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error deleting repository", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("Delete", r.Context(), "repo1").Return(tc.rmErr)
+				hw.h.Delete(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetPendingChanges(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("get pending changes succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetPendingChangesJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetPendingChanges(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error getting pending changes", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetPendingChangesJSON", r.Context(), "org1").Return(nil, tc.rmErr)
+				hw.h.GetPendingChanges(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetEvents(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("invalid query", func(t *testing.T) {
+		testCases := []string{
+			"event_kind=z",
+			"limit=z",
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?"+tc, nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.h.GetEvents(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get events succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?cursor=cursor1&event_kind=0&event_kind=1&limit=10&package=pkg1", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		input := &hub.GetRepositoryEventsInput{
+			Cursor:      "cursor1",
+			EventKinds:  []hub.EventKind{hub.NewRelease, hub.SecurityAlert},
+			Limit:       10,
+			PackageName: "pkg1",
+		}
+		hw.rm.On("GetEventsJSON", r.Context(), "repo1", input).Return([]byte("dataJSON"), nil)
+		hw.h.GetEvents(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error getting events", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				input := &hub.GetRepositoryEventsInput{EventKinds: []hub.EventKind{}}
+				hw.rm.On("GetEventsJSON", r.Context(), "repo1", input).Return(nil, tc.rmErr)
+				hw.h.GetEvents(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetStats(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("get stats succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetStatsJSON", r.Context(), "repo1").Return([]byte("dataJSON"), nil)
+		hw.h.GetStats(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error getting stats", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("GetStatsJSON", r.Context(), "repo1").Return(nil, tc.rmErr)
+				hw.h.GetStats(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
 
-			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-			hw.rm.AssertExpectations(t)
-		})
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
 	})
 }
 
-func TestClaimOwnership(t *testing.T) {
-	t.Run("invalid input - missing repo name", func(t *testing.T) {
+func TestGetSubscriptions(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("get subscriptions succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("PUT", "/", nil)
+		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.rm.On("ClaimOwnership", r.Context(), "", "").Return(hub.ErrInvalidInput)
-		hw.h.ClaimOwnership(w, r)
+		hw.rm.On("GetSubscriptionsJSON", r.Context(), "repo1").Return([]byte("dataJSON"), nil)
+		hw.h.GetSubscriptions(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
 
-		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
 		hw.rm.AssertExpectations(t)
 	})
 
-	t.Run("valid input", func(t *testing.T) {
+	t.Run("error getting subscriptions", func(t *testing.T) {
 		testCases := []struct {
-			description        string
-			err                error
+			rmErr              error
 			expectedStatusCode int
 		}{
 			{
-				"repository ownership claim succeeded",
-				nil,
-				http.StatusNoContent,
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
 			},
 			{
-				"error claiming repository ownership (insufficient privilege)",
 				hub.ErrInsufficientPrivilege,
 				http.StatusForbidden,
 			},
 			{
-				"error claiming repository ownership (db error)",
 				tests.ErrFakeDB,
 				http.StatusInternalServerError,
 			},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.description, func(t *testing.T) {
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("PUT", "/?org=org1", nil)
+				r, _ := http.NewRequest("GET", "/", nil)
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-				rctx := &chi.Context{
-					URLParams: chi.RouteParams{
-						Keys:   []string{"repoName"},
-						Values: []string{"repo1"},
-					},
-				}
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.rm.On("ClaimOwnership", r.Context(), "repo1", "org1").Return(tc.err)
-				hw.h.ClaimOwnership(w, r)
+				hw.rm.On("GetSubscriptionsJSON", r.Context(), "repo1").Return(nil, tc.rmErr)
+				hw.h.GetSubscriptions(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -329,7 +792,7 @@ func TestClaimOwnership(t *testing.T) {
 	})
 }
 
-func TestDelete(t *testing.T) {
+func TestGetViews(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
 			Keys:   []string{"repoName"},
@@ -337,24 +800,29 @@ func TestDelete(t *testing.T) {
 		},
 	}
 
-	t.Run("delete repository succeeded", func(t *testing.T) {
+	t.Run("get views succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("DELETE", "/", nil)
+		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.rm.On("Delete", r.Context(), "repo1").Return(nil)
-		hw.h.Delete(w, r)
+		hw.rm.On("GetViewsJSON", r.Context(), "repo1").Return([]byte("dataJSON"), nil)
+		hw.h.GetViews(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
 
-		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
 		hw.rm.AssertExpectations(t)
 	})
 
-	t.Run("error deleting repository", func(t *testing.T) {
+	t.Run("error getting views", func(t *testing.T) {
 		testCases := []struct {
 			rmErr              error
 			expectedStatusCode int
@@ -377,13 +845,173 @@ func TestDelete(t *testing.T) {
 			t.Run(tc.rmErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("DELETE", "/", nil)
+				r, _ := http.NewRequest("GET", "/", nil)
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.rm.On("Delete", r.Context(), "repo1").Return(tc.rmErr)
-				hw.h.Delete(w, r)
+				hw.rm.On("GetViewsJSON", r.Context(), "repo1").Return(nil, tc.rmErr)
+				hw.h.GetViews(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRegisterOwnershipClaimToken(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("error registering ownership claim token", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("RegisterOwnershipClaimToken", r.Context(), "repo1").Return("", tc.rmErr)
+				hw.h.RegisterOwnershipClaimToken(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("ownership claim token registered successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("RegisterOwnershipClaimToken", r.Context(), "repo1").Return("token1", nil)
+		hw.h.RegisterOwnershipClaimToken(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte(`{"token":"token1"}`), data)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestRegisterPackagesDownloads(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			description   string
+			downloadsJSON string
+			rmErr         error
+		}{
+			{
+				"no downloads provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"missing version",
+				`[{"package_name": "pkg1", "day": "2021-12-08", "total": 10}]`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.downloadsJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				if tc.rmErr != nil {
+					hw.rm.On("RegisterPackagesDownloads", r.Context(), "", mock.Anything).Return(tc.rmErr)
+				}
+				hw.h.RegisterPackagesDownloads(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid downloads provided", func(t *testing.T) {
+		downloadsJSON := `[{"package_name": "pkg1", "version": "1.0.0", "day": "2021-12-08", "total": 10}]`
+		var downloads []*hub.PackageDownloads
+		_ = json.Unmarshal([]byte(downloadsJSON), &downloads)
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"downloads registration succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error registering downloads (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error registering downloads (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(downloadsJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("RegisterPackagesDownloads", r.Context(), "", downloads).Return(tc.err)
+				hw.h.RegisterPackagesDownloads(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -394,6 +1022,66 @@ func TestDelete(t *testing.T) {
 	})
 }
 
+func TestRejectChange(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "changeID"},
+			Values: []string{"org1", "changeID"},
+		},
+	}
+
+	testCases := []struct {
+		description        string
+		err                error
+		expectedStatusCode int
+	}{
+		{
+			"change rejected successfully",
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			"error rejecting change (invalid input)",
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			"error rejecting change (insufficient privilege)",
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			"error rejecting change (not found)",
+			hub.ErrNotFound,
+			http.StatusNotFound,
+		},
+		{
+			"error rejecting change (db error)",
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("PUT", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.rm.On("RejectChange", r.Context(), "org1", "changeID").Return(tc.err)
+			hw.h.RejectChange(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.rm.AssertExpectations(t)
+		})
+	}
+}
+
 func TestSearch(t *testing.T) {
 	t.Run("invalid request params", func(t *testing.T) {
 		testCases := []struct {
@@ -533,7 +1221,7 @@ func TestTransfer(t *testing.T) {
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 
 		hw := newHandlersWrapper()
-		hw.rm.On("Transfer", r.Context(), "", "", false).Return(hub.ErrInvalidInput)
+		hw.rm.On("Transfer", r.Context(), "", "").Return(hub.ErrInvalidInput)
 		hw.h.Transfer(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
@@ -553,6 +1241,11 @@ func TestTransfer(t *testing.T) {
 				nil,
 				http.StatusNoContent,
 			},
+			{
+				"repository transfer pending approval",
+				hub.ErrPendingApproval,
+				http.StatusAccepted,
+			},
 			{
 				"error transferring repository (insufficient privilege)",
 				hub.ErrInsufficientPrivilege,
@@ -580,7 +1273,7 @@ func TestTransfer(t *testing.T) {
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.rm.On("Transfer", r.Context(), "repo1", "org1", false).Return(tc.err)
+				hw.rm.On("Transfer", r.Context(), "repo1", "org1").Return(tc.err)
 				hw.h.Transfer(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
@@ -657,6 +1350,11 @@ func TestUpdate(t *testing.T) {
 				nil,
 				http.StatusNoContent,
 			},
+			{
+				"repository update pending approval",
+				hub.ErrPendingApproval,
+				http.StatusAccepted,
+			},
 			{
 				"error updating repository (insufficient privilege)",
 				hub.ErrInsufficientPrivilege,
@@ -0,0 +1,185 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/handlers/pkg"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// CacheMaxAge represents the cache duration used by the sitemap
+	// endpoints. The sitemap data is regenerated every time the tracker
+	// finishes a run, so this matches the interval at which it runs.
+	CacheMaxAge = 30 * time.Minute
+
+	// PackagesPerSitemap represents the maximum number of packages included in
+	// each of the packages sitemaps.
+	PackagesPerSitemap = 10000
+
+	xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// sitemapIndex represents a sitemap index file, which contains a list of
+// sitemaps.
+type sitemapIndex struct {
+	XMLName  xml.Name      `xml:"sitemapindex"`
+	XMLNS    string        `xml:"xmlns,attr"`
+	Sitemaps []*sitemapURL `xml:"sitemap"`
+}
+
+// urlSet represents a sitemap file, which contains a list of urls.
+type urlSet struct {
+	XMLName xml.Name      `xml:"urlset"`
+	XMLNS   string        `xml:"xmlns,attr"`
+	URLs    []*sitemapURL `xml:"url"`
+}
+
+// sitemapURL represents an entry in a sitemap or sitemap index file.
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Handlers represents a group of http handlers in charge of handling the
+// sitemap files.
+type Handlers struct {
+	sitemapManager hub.SitemapManager
+	cfg            *viper.Viper
+	logger         zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(sitemapManager hub.SitemapManager, cfg *viper.Viper) *Handlers {
+	return &Handlers{
+		sitemapManager: sitemapManager,
+		cfg:            cfg,
+		logger:         log.With().Str("handlers", "sitemap").Logger(),
+	}
+}
+
+// Index is an http handler that returns the sitemap index, which links to the
+// repositories sitemap and to as many packages sitemaps as needed.
+func (h *Handlers) Index(w http.ResponseWriter, r *http.Request) {
+	count, err := h.sitemapManager.GetPackagesCount(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Index").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	baseURL := h.cfg.GetString("server.baseURL")
+	index := &sitemapIndex{
+		XMLNS: xmlns,
+		Sitemaps: []*sitemapURL{
+			{Loc: fmt.Sprintf("%s/sitemaps/repositories.xml", baseURL)},
+		},
+	}
+	pages := (count + PackagesPerSitemap - 1) / PackagesPerSitemap
+	for page := 1; page <= pages; page++ {
+		index.Sitemaps = append(index.Sitemaps, &sitemapURL{
+			Loc: fmt.Sprintf("%s/sitemaps/packages/%d.xml", baseURL, page),
+		})
+	}
+
+	h.renderXML(w, index)
+}
+
+// Packages is an http handler that returns the packages sitemap for the
+// requested page.
+func (h *Handlers) Packages(w http.ResponseWriter, r *http.Request) {
+	page, err := strconv.Atoi(chi.URLParam(r, "page"))
+	if err != nil || page < 1 {
+		helpers.RenderErrorJSON(w, fmt.Errorf("sitemap %w", hub.ErrNotFound))
+		return
+	}
+	pkgs, err := h.sitemapManager.GetPackages(r.Context(), PackagesPerSitemap, (page-1)*PackagesPerSitemap)
+	if err != nil {
+		h.logger.Error().Err(err).Int("page", page).Str("method", "Packages").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if len(pkgs) == 0 {
+		helpers.RenderErrorJSON(w, fmt.Errorf("sitemap %w", hub.ErrNotFound))
+		return
+	}
+
+	baseURL := h.cfg.GetString("server.baseURL")
+	set := &urlSet{
+		XMLNS: xmlns,
+		URLs:  make([]*sitemapURL, 0, len(pkgs)),
+	}
+	for _, p := range pkgs {
+		loc := pkg.BuildURL(baseURL, &hub.Package{
+			NormalizedName: p.NormalizedName,
+			Repository: &hub.Repository{
+				Kind: p.RepositoryKind,
+				Name: p.RepositoryName,
+			},
+		}, "")
+		set.URLs = append(set.URLs, &sitemapURL{
+			Loc:     loc,
+			LastMod: formatLastMod(p.TS),
+		})
+	}
+
+	h.renderXML(w, set)
+}
+
+// Repositories is an http handler that returns the repositories sitemap.
+func (h *Handlers) Repositories(w http.ResponseWriter, r *http.Request) {
+	repos, err := h.sitemapManager.GetRepositories(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Repositories").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	baseURL := h.cfg.GetString("server.baseURL")
+	set := &urlSet{
+		XMLNS: xmlns,
+		URLs:  make([]*sitemapURL, 0, len(repos)),
+	}
+	for _, repo := range repos {
+		set.URLs = append(set.URLs, &sitemapURL{
+			Loc:     fmt.Sprintf("%s/packages/search?repo=%s", baseURL, url.QueryEscape(repo.Name)),
+			LastMod: formatLastMod(repo.TS),
+		})
+	}
+
+	h.renderXML(w, set)
+}
+
+// renderXML is a helper used to write the sitemap provided to the response
+// writer as an xml document.
+func (h *Handlers) renderXML(w http.ResponseWriter, v interface{}) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "renderXML").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(CacheMaxAge))
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(data)
+}
+
+// formatLastMod formats the timestamp provided using the W3C datetime format
+// expected by the lastmod field.
+func formatLastMod(ts int64) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,213 @@
+package sitemap
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/sitemap"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestIndex(t *testing.T) {
+	t.Run("error getting packages count", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetPackagesCount", r.Context()).Return(0, tests.ErrFakeDB)
+		hw.h.Index(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("sitemap index returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetPackagesCount", r.Context()).Return(PackagesPerSitemap+1, nil)
+		hw.h.Index(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(CacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+			`<sitemap><loc>baseURL/sitemaps/repositories.xml</loc></sitemap>`+
+			`<sitemap><loc>baseURL/sitemaps/packages/1.xml</loc></sitemap>`+
+			`<sitemap><loc>baseURL/sitemaps/packages/2.xml</loc></sitemap>`+
+			`</sitemapindex>`, string(data))
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestPackages(t *testing.T) {
+	t.Run("invalid page", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, buildPageRouteCtx("invalid")))
+
+		hw := newHandlersWrapper()
+		hw.h.Packages(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("error getting packages", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, buildPageRouteCtx("2")))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetPackages", r.Context(), PackagesPerSitemap, PackagesPerSitemap).Return(nil, tests.ErrFakeDB)
+		hw.h.Packages(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("page out of range", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, buildPageRouteCtx("2")))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetPackages", r.Context(), PackagesPerSitemap, PackagesPerSitemap).Return([]*hub.SitemapPackage{}, nil)
+		hw.h.Packages(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("packages sitemap returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, buildPageRouteCtx("1")))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetPackages", r.Context(), PackagesPerSitemap, 0).Return([]*hub.SitemapPackage{
+			{
+				NormalizedName: "pkg1",
+				RepositoryName: "repo1",
+				RepositoryKind: hub.Helm,
+				TS:             1592299234,
+			},
+		}, nil)
+		hw.h.Packages(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(CacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+			`<url><loc>baseURL/packages/helm/repo1/pkg1</loc><lastmod>2020-06-16T09:20:34Z</lastmod></url>`+
+			`</urlset>`, string(data))
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestRepositories(t *testing.T) {
+	t.Run("error getting repositories", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetRepositories", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.Repositories(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("repositories sitemap returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetRepositories", r.Context()).Return([]*hub.SitemapRepository{
+			{
+				Name: "repo1",
+				TS:   1592299234,
+			},
+		}, nil)
+		hw.h.Repositories(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+			`<url><loc>baseURL/packages/search?repo=repo1</loc><lastmod>2020-06-16T09:20:34Z</lastmod></url>`+
+			`</urlset>`, string(data))
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func buildPageRouteCtx(page string) *chi.Context {
+	return &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"page"},
+			Values: []string{page},
+		},
+	}
+}
+
+type handlersWrapper struct {
+	sm *sitemap.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "baseURL")
+	sm := &sitemap.ManagerMock{}
+
+	return &handlersWrapper{
+		sm: sm,
+		h:  NewHandlers(sm, cfg),
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -24,7 +25,9 @@ import (
 )
 
 const (
-	cspPolicy = `
+	// defaultCSPPolicy is the content security policy used when serving the
+	// web application, unless a custom one is provided in the configuration.
+	defaultCSPPolicy = `
 	default-src 'none';
 	connect-src 'self' https://play.openpolicyagent.org https://www.google-analytics.com https://kubernetesjsonschema.dev https://raw.githubusercontent.com/yannh/kubernetes-json-schema/;
 	font-src 'self';
@@ -34,6 +37,15 @@ const (
 	style-src 'self' 'unsafe-inline'
 	`
 
+	// cspReportMaxSize represents the maximum size of the CSP violations
+	// reports accepted.
+	cspReportMaxSize = 64 * 1024
+
+	// svgCSPPolicy is the content security policy used when serving svg
+	// images, to prevent them from running scripts or loading external
+	// resources when opened directly in the browser.
+	svgCSPPolicy = "default-src 'none'; img-src data:; style-src 'unsafe-inline'; sandbox"
+
 	indexCacheMaxAge = 5 * time.Minute
 
 	// DocsCacheMaxAge is the cache max age used when serving the docs.
@@ -50,6 +62,8 @@ type Handlers struct {
 	imageStore img.Store
 	logger     zerolog.Logger
 	indexTmpl  *template.Template
+	cspHeader  string
+	cspPolicy  string
 
 	mu          sync.RWMutex
 	imagesCache map[string][]byte
@@ -64,9 +78,44 @@ func NewHandlers(cfg *viper.Viper, imageStore img.Store) *Handlers {
 		logger:      log.With().Str("handlers", "static").Logger(),
 	}
 	h.setupIndexTemplate()
+	h.setupCSP()
 	return h
 }
 
+// setupCSP prepares the content security policy header used when serving the
+// web application, based on the security headers configuration. In report
+// only mode violations are reported, but the policy is not enforced.
+func (h *Handlers) setupCSP() {
+	policy := defaultCSPPolicy
+	if customPolicy := h.cfg.GetString("server.securityHeaders.csp.policy"); customPolicy != "" {
+		policy = customPolicy
+	}
+	policy = strings.Join(strings.Fields(policy), " ")
+	if reportURI := h.cfg.GetString("server.securityHeaders.csp.reportURI"); reportURI != "" {
+		policy = fmt.Sprintf("%s; report-uri %s", strings.TrimSuffix(policy, ";"), reportURI)
+	}
+	h.cspPolicy = policy
+	h.cspHeader = "Content-Security-Policy"
+	if h.cfg.GetBool("server.securityHeaders.csp.reportOnly") {
+		h.cspHeader = "Content-Security-Policy-Report-Only"
+	}
+}
+
+// CSPReport is an http handler that ingests the content security policy
+// violations reports sent by browsers, logging them so that they can be
+// reviewed before enforcing a new policy.
+func (h *Handlers) CSPReport(w http.ResponseWriter, r *http.Request) {
+	var report map[string]interface{}
+	err := json.NewDecoder(io.LimitReader(r.Body, cspReportMaxSize)).Decode(&report)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "CSPReport").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	h.logger.Warn().Interface("report", report).Str("userAgent", r.UserAgent()).Msg("csp violation reported")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // setupIndexTemplate parses the index.html template for later use.
 func (h *Handlers) setupIndexTemplate() {
 	path := path.Join(h.cfg.GetString("server.webBuildPath"), "index.html")
@@ -77,7 +126,10 @@ func (h *Handlers) setupIndexTemplate() {
 	h.indexTmpl = template.Must(template.New("").Parse(string(text)))
 }
 
-// Image is an http handler that serves images stored in the database.
+// Image is an http handler that serves images from the image store. When the
+// store is able to serve images from a public location (i.e. a CDN), requests
+// are redirected to it. Variants of the image in additional formats (i.e.
+// AVIF or WebP) are served when available and accepted by the client.
 func (h *Handlers) Image(w http.ResponseWriter, r *http.Request) {
 	// Extract image id and version
 	image := chi.URLParam(r, "image")
@@ -90,14 +142,46 @@ func (h *Handlers) Image(w http.ResponseWriter, r *http.Request) {
 		imageID = image
 	}
 
+	// Versions to try, in order of preference, based on the formats accepted
+	formats := acceptedImageFormats(r)
+	versions := make([]string, 0, len(formats)+1)
+	for _, format := range formats {
+		versions = append(versions, img.VariantVersion(version, format))
+	}
+	versions = append(versions, version)
+	w.Header().Set("Vary", "Accept")
+
+	// Redirect to the image public url when available
+	if ur, ok := h.imageStore.(img.URLResolver); ok {
+		for _, v := range versions {
+			imageURL, err := ur.ImageURL(r.Context(), imageID, v)
+			if err != nil {
+				h.logger.Error().Err(err).Str("method", "Image").Str("imageID", imageID).Send()
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if imageURL != "" {
+				w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(StaticCacheMaxAge))
+				http.Redirect(w, r, imageURL, http.StatusMovedPermanently)
+				return
+			}
+		}
+	}
+
 	// Check if image version data is cached
+	cacheKey := image + "|" + strings.Join(formats, ",")
 	h.mu.RLock()
-	data, ok := h.imagesCache[image]
+	data, ok := h.imagesCache[cacheKey]
 	h.mu.RUnlock()
 	if !ok {
-		// Get image data from database
+		// Get image data from the image store
 		var err error
-		data, err = h.imageStore.GetImage(r.Context(), imageID, version)
+		for _, v := range versions {
+			data, err = h.imageStore.GetImage(r.Context(), imageID, v)
+			if !errors.Is(err, hub.ErrNotFound) {
+				break
+			}
+		}
 		if err != nil {
 			if errors.Is(err, hub.ErrNotFound) {
 				w.WriteHeader(http.StatusNotFound)
@@ -110,25 +194,50 @@ func (h *Handlers) Image(w http.ResponseWriter, r *http.Request) {
 
 		// Save image data in cache
 		h.mu.Lock()
-		h.imagesCache[image] = data
+		h.imagesCache[cacheKey] = data
 		h.mu.Unlock()
 	}
 
 	// Set headers and write image data to response writer
+	contentType := imageContentType(data)
 	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(StaticCacheMaxAge))
-	if svg.Is(data) {
-		w.Header().Set("Content-Type", "image/svg+xml")
-	} else {
-		w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.Header().Set("Content-Type", contentType)
+	if contentType == "image/svg+xml" {
+		w.Header().Set("Content-Security-Policy", svgCSPPolicy)
 	}
 	_, _ = w.Write(data)
 }
 
+// acceptedImageFormats returns the additional image formats accepted by the
+// client, in order of preference.
+func acceptedImageFormats(r *http.Request) []string {
+	accept := r.Header.Get("Accept")
+	var formats []string
+	for _, format := range []string{img.AVIF, img.WebP} {
+		if strings.Contains(accept, img.ContentType(format)) {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
+
+// imageContentType returns the content type of the image data provided.
+func imageContentType(data []byte) string {
+	switch {
+	case svg.Is(data):
+		return "image/svg+xml"
+	case len(data) >= 12 && string(data[4:8]) == "ftyp" && (string(data[8:12]) == "avif" || string(data[8:12]) == "avis"):
+		return img.ContentType(img.AVIF)
+	default:
+		return http.DetectContentType(data)
+	}
+}
+
 // Index is an http handler that serves the index.html file.
 func (h *Handlers) Index(w http.ResponseWriter, r *http.Request) {
 	// Set headers
 	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(indexCacheMaxAge))
-	w.Header().Set("Content-Security-Policy", cspPolicy)
+	w.Header().Set(h.cspHeader, h.cspPolicy)
 
 	// Execute index template
 	title, _ := r.Context().Value(hub.IndexMetaTitleKey).(string)
@@ -139,9 +248,12 @@ func (h *Handlers) Index(w http.ResponseWriter, r *http.Request) {
 	if description == "" {
 		description = "Find, install and publish Kubernetes packages"
 	}
-	openGraphImage := h.cfg.GetString("theme.images.openGraphImage")
-	if !strings.HasPrefix(openGraphImage, "http") {
-		openGraphImage = h.cfg.GetString("server.baseURL") + openGraphImage
+	openGraphImage, _ := r.Context().Value(hub.IndexMetaOpenGraphImageKey).(string)
+	if openGraphImage == "" {
+		openGraphImage = h.cfg.GetString("theme.images.openGraphImage")
+		if !strings.HasPrefix(openGraphImage, "http") {
+			openGraphImage = h.cfg.GetString("server.baseURL") + openGraphImage
+		}
 	}
 	data := map[string]interface{}{
 		"allowPrivateRepositories": h.cfg.GetBool("server.allowPrivateRepositories"),
@@ -156,6 +268,7 @@ func (h *Handlers) Index(w http.ResponseWriter, r *http.Request) {
 		"oidcAuth":                 h.cfg.IsSet("server.oauth.oidc"),
 		"openGraphImage":           openGraphImage,
 		"primaryColor":             h.cfg.GetString("theme.colors.primary"),
+		"privateMode":              h.cfg.GetBool("server.private.enabled"),
 		"reportURL":                h.cfg.GetString("theme.reportURL"),
 		"secondaryColor":           h.cfg.GetString("theme.colors.secondary"),
 		"shortcutIcon":             h.cfg.GetString("theme.images.shortcutIcon"),
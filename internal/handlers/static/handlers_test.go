@@ -71,9 +71,10 @@ func TestImage(t *testing.T) {
 		testCases := []struct {
 			imgPath             string
 			expectedContentType string
+			expectedCSP         string
 		}{
-			{"testdata/image.png", "image/png"},
-			{"testdata/image.svg", "image/svg+xml"},
+			{"testdata/image.png", "image/png", ""},
+			{"testdata/image.svg", "image/svg+xml", svgCSPPolicy},
 		}
 		for i, tc := range testCases {
 			i := i
@@ -96,12 +97,153 @@ func TestImage(t *testing.T) {
 
 				assert.Equal(t, http.StatusOK, resp.StatusCode)
 				assert.Equal(t, tc.expectedContentType, h.Get("Content-Type"))
+				assert.Equal(t, tc.expectedCSP, h.Get("Content-Security-Policy"))
 				assert.Equal(t, helpers.BuildCacheControlHeader(StaticCacheMaxAge), h.Get("Cache-Control"))
 				assert.Equal(t, imgData, data)
 				hw.is.AssertExpectations(t)
 			})
 		}
 	})
+
+	t.Run("accepted variant available, variant served", func(t *testing.T) {
+		t.Parallel()
+		webpData := []byte("RIFF\x00\x00\x00\x00WEBPVP8 ")
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", "image/avif,image/webp,image/*,*/*;q=0.8")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.is.On("GetImage", r.Context(), "imageID", "2x.avif").Return(nil, hub.ErrNotFound)
+		hw.is.On("GetImage", r.Context(), "imageID", "2x.webp").Return(webpData, nil)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/webp", h.Get("Content-Type"))
+		assert.Equal(t, "Accept", h.Get("Vary"))
+		assert.Equal(t, webpData, data)
+		hw.is.AssertExpectations(t)
+	})
+
+	t.Run("accepted variants not available, original version served", func(t *testing.T) {
+		t.Parallel()
+		imgData, err := ioutil.ReadFile("testdata/image.png")
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", "image/webp,*/*")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.is.On("GetImage", r.Context(), "imageID", "2x.webp").Return(nil, hub.ErrNotFound)
+		hw.is.On("GetImage", r.Context(), "imageID", "2x").Return(imgData, nil)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, imgData, data)
+		hw.is.AssertExpectations(t)
+	})
+
+	t.Run("image public url available, request redirected", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		is := &urlResolverStoreMock{}
+		is.On("ImageURL", r.Context(), "imageID", "2x").Return("https://cdn.artifacthub.io/imageID/2x", nil)
+		hw := newHandlersWrapper()
+		hw.h.imageStore = is
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "https://cdn.artifacthub.io/imageID/2x", resp.Header.Get("Location"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(StaticCacheMaxAge), resp.Header.Get("Cache-Control"))
+		is.AssertExpectations(t)
+	})
+
+	t.Run("image public url not available, image served from store", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		is := &urlResolverStoreMock{}
+		is.On("ImageURL", r.Context(), "imageID", "2x").Return("", nil)
+		is.On("GetImage", r.Context(), "imageID", "2x").Return([]byte("imageData"), nil)
+		hw := newHandlersWrapper()
+		hw.h.imageStore = is
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []byte("imageData"), data)
+		is.AssertExpectations(t)
+	})
+
+	t.Run("accepted variant public url available, request redirected", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", "image/avif,image/webp,*/*")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		is := &urlResolverStoreMock{}
+		is.On("ImageURL", r.Context(), "imageID", "2x.avif").Return("https://cdn.artifacthub.io/imageID/2x.avif", nil)
+		hw := newHandlersWrapper()
+		hw.h.imageStore = is
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "https://cdn.artifacthub.io/imageID/2x.avif", resp.Header.Get("Location"))
+		assert.Equal(t, "Accept", resp.Header.Get("Vary"))
+		is.AssertExpectations(t)
+	})
+
+	t.Run("error getting image public url", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		is := &urlResolverStoreMock{}
+		is.On("ImageURL", r.Context(), "imageID", "2x").Return("", errors.New("internal error"))
+		hw := newHandlersWrapper()
+		hw.h.imageStore = is
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		is.AssertExpectations(t)
+	})
+}
+
+func TestImageContentType(t *testing.T) {
+	t.Parallel()
+	pngData, err := ioutil.ReadFile("testdata/image.png")
+	require.NoError(t, err)
+	svgData, err := ioutil.ReadFile("testdata/image.svg")
+	require.NoError(t, err)
+
+	assert.Equal(t, "image/png", imageContentType(pngData))
+	assert.Equal(t, "image/svg+xml", imageContentType(svgData))
+	assert.Equal(t, "image/webp", imageContentType([]byte("RIFF\x00\x00\x00\x00WEBPVP8 ")))
+	assert.Equal(t, "image/avif", imageContentType([]byte("\x00\x00\x00\x1cftypavif\x00\x00\x00\x00")))
 }
 
 func TestIndex(t *testing.T) {
@@ -118,9 +260,78 @@ func TestIndex(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	assert.Equal(t, helpers.BuildCacheControlHeader(indexCacheMaxAge), h.Get("Cache-Control"))
+	assert.Equal(t, strings.Join(strings.Fields(defaultCSPPolicy), " "), h.Get("Content-Security-Policy"))
 	assert.Equal(t, []byte("title:Artifact Hub\ndescription:Find, install and publish Kubernetes packages\ngaTrackingID:1234\n"), data)
 }
 
+func TestIndexCustomCSP(t *testing.T) {
+	t.Run("custom policy enforced", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		cfg := viper.New()
+		cfg.Set("server.webBuildPath", "testdata")
+		cfg.Set("server.securityHeaders.csp.policy", "default-src 'self';")
+		cfg.Set("server.securityHeaders.csp.reportURI", "/api/v1/csp-report")
+		h := NewHandlers(cfg, &img.StoreMock{})
+		h.Index(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, "default-src 'self'; report-uri /api/v1/csp-report", resp.Header.Get("Content-Security-Policy"))
+		assert.Empty(t, resp.Header.Get("Content-Security-Policy-Report-Only"))
+	})
+
+	t.Run("report only mode", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		cfg := viper.New()
+		cfg.Set("server.webBuildPath", "testdata")
+		cfg.Set("server.securityHeaders.csp.policy", "default-src 'self'")
+		cfg.Set("server.securityHeaders.csp.reportOnly", true)
+		h := NewHandlers(cfg, &img.StoreMock{})
+		h.Index(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, "default-src 'self'", resp.Header.Get("Content-Security-Policy-Report-Only"))
+		assert.Empty(t, resp.Header.Get("Content-Security-Policy"))
+	})
+}
+
+func TestCSPReport(t *testing.T) {
+	t.Run("invalid report", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{invalid"))
+
+		hw := newHandlersWrapper()
+		hw.h.CSPReport(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("report ingested", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"csp-report": {"document-uri": "https://hub/", "violated-directive": "script-src"}}`
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/csp-report")
+
+		hw := newHandlersWrapper()
+		hw.h.CSPReport(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+}
+
 func TestSaveImage(t *testing.T) {
 	fakeSaveImageError := errors.New("fake save image error")
 
@@ -210,3 +421,15 @@ func newHandlersWrapper() *handlersWrapper {
 		h:   NewHandlers(cfg, is),
 	}
 }
+
+// urlResolverStoreMock is a mock image store able to provide images public
+// urls.
+type urlResolverStoreMock struct {
+	img.StoreMock
+}
+
+// ImageURL implements the img.URLResolver interface.
+func (m *urlResolverStoreMock) ImageURL(ctx context.Context, imageID, version string) (string, error) {
+	args := m.Called(ctx, imageID, version)
+	return args.String(0), args.Error(1)
+}
@@ -35,3 +35,19 @@ func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	}
 	helpers.RenderJSON(w, dataJSON, 6*time.Hour, http.StatusOK)
 }
+
+// GetTimeSeries is an http handler that returns the evolution over time of
+// some stats, aggregated using the granularity provided (defaults to month).
+func (h *Handlers) GetTimeSeries(w http.ResponseWriter, r *http.Request) {
+	granularity := r.FormValue("granularity")
+	if granularity == "" {
+		granularity = "month"
+	}
+	dataJSON, err := h.statsManager.GetTimeSeriesJSON(r.Context(), granularity)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetTimeSeries").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 6*time.Hour, http.StatusOK)
+}
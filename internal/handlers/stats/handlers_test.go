@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/stats"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/rs/zerolog"
@@ -57,6 +58,58 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetTimeSeries(t *testing.T) {
+	t.Run("invalid granularity", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?granularity=year", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetTimeSeriesJSON", r.Context(), "year").Return(nil, hub.ErrInvalidInput)
+		hw.h.GetTimeSeries(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("error getting stats time series", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetTimeSeriesJSON", r.Context(), "month").Return(nil, tests.ErrFakeDB)
+		hw.h.GetTimeSeries(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("get stats time series succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?granularity=day", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetTimeSeriesJSON", r.Context(), "day").Return([]byte("dataJSON"), nil)
+		hw.h.GetTimeSeries(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(6*time.Hour), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
 type handlersWrapper struct {
 	sm *stats.ManagerMock
 	h  *Handlers
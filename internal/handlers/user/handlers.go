@@ -1,10 +1,12 @@
 package user
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/subtle"
 	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +23,7 @@ import (
 	"github.com/artifacthub/hub/internal/user"
 	"github.com/coreos/go-oidc"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/go-github/github"
 	"github.com/gorilla/securecookie"
 	"github.com/rs/zerolog"
@@ -59,6 +62,10 @@ var (
 	// errInvalidAPIKey error indicates that the API key provided is not valid.
 	errInvalidAPIKey = errors.New("invalid api key")
 
+	// errAPIKeyIPNotAllowed error indicates that the API key provided cannot
+	// be used from the address the request was made from.
+	errAPIKeyIPNotAllowed = errors.New("api key not allowed from this ip address")
+
 	// errInvalidSession error indicates that the session provided is not valid.
 	errInvalidSession = errors.New("invalid session")
 )
@@ -66,13 +73,14 @@ var (
 // Handlers represents a group of http handlers in charge of handling
 // users operations.
 type Handlers struct {
-	userManager   hub.UserManager
-	apiKeyManager hub.APIKeyManager
-	cfg           *viper.Viper
-	sc            *securecookie.SecureCookie
-	oauthConfig   map[string]*oauth2.Config
-	oidcProvider  *oidc.Provider
-	logger        zerolog.Logger
+	userManager        hub.UserManager
+	apiKeyManager      hub.APIKeyManager
+	apiKeyUsageTracker hub.APIKeyUsageTracker
+	cfg                *viper.Viper
+	sc                 *securecookie.SecureCookie
+	oauthConfig        map[string]*oauth2.Config
+	oidcProvider       *oidc.Provider
+	logger             zerolog.Logger
 }
 
 // NewHandlers creates a new Handlers instance.
@@ -80,6 +88,7 @@ func NewHandlers(
 	ctx context.Context,
 	userManager hub.UserManager,
 	apiKeyManager hub.APIKeyManager,
+	apiKeyUsageTracker hub.APIKeyUsageTracker,
 	cfg *viper.Viper,
 ) (*Handlers, error) {
 	// Setup secure cookie instance
@@ -118,13 +127,14 @@ func NewHandlers(
 	}
 
 	return &Handlers{
-		userManager:   userManager,
-		apiKeyManager: apiKeyManager,
-		cfg:           cfg,
-		sc:            sc,
-		oauthConfig:   oauthConfig,
-		oidcProvider:  oidcProvider,
-		logger:        log.With().Str("handlers", "user").Logger(),
+		userManager:        userManager,
+		apiKeyManager:      apiKeyManager,
+		apiKeyUsageTracker: apiKeyUsageTracker,
+		cfg:                cfg,
+		sc:                 sc,
+		oauthConfig:        oauthConfig,
+		oidcProvider:       oidcProvider,
+		logger:             log.With().Str("handlers", "user").Logger(),
 	}, nil
 }
 
@@ -231,6 +241,17 @@ func (h *Handlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// DeleteEmailSuppression is an http handler used to remove the email address
+// of the user doing the request from the suppression list.
+func (h *Handlers) DeleteEmailSuppression(w http.ResponseWriter, r *http.Request) {
+	if err := h.userManager.DeleteEmailSuppression(r.Context()); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteEmailSuppression").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // DeleteUser is an http handler used to delete the account of the user doing
 // the request.
 func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
@@ -262,6 +283,11 @@ func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Disable is an http handler used by site admins to disable the provided user.
+func (h *Handlers) Disable(w http.ResponseWriter, r *http.Request) {
+	h.setDisabled(w, r, true)
+}
+
 // DisableTFA is an http handler used to disable two-factor authentication.
 func (h *Handlers) DisableTFA(w http.ResponseWriter, r *http.Request) {
 	var input map[string]string
@@ -279,6 +305,29 @@ func (h *Handlers) DisableTFA(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Enable is an http handler used by site admins to enable the provided user.
+func (h *Handlers) Enable(w http.ResponseWriter, r *http.Request) {
+	h.setDisabled(w, r, false)
+}
+
+// setDisabled disables or enables the user provided in the url.
+func (h *Handlers) setDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	info, err := helpers.GetAdminAuditInfo(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "SetDisabled").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	userID := chi.URLParam(r, "userID")
+	if err := h.userManager.SetDisabled(r.Context(), userID, disabled, info); err != nil {
+		h.logger.Error().Err(err).Str("method", "SetDisabled").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	h.logger.Info().Str("userID", userID).Bool("disabled", disabled).Msg("user disabled status updated by admin")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // EnableTFA is an http handler used to enable two-factor authentication.
 func (h *Handlers) EnableTFA(w http.ResponseWriter, r *http.Request) {
 	var input map[string]string
@@ -296,6 +345,118 @@ func (h *Handlers) EnableTFA(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// GetAdminAuditLog is an http handler used by site admins to export the
+// entries of the admin audit log, optionally limited to a given time range.
+// Entries can be exported as json (default) or csv.
+func (h *Handlers) GetAdminAuditLog(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	format := qs.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		err := fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid format (json|csv)")
+		h.logger.Error().Err(err).Str("method", "GetAdminAuditLog").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	input := &hub.GetAdminAuditLogInput{
+		From: qs.Get("from"),
+		To:   qs.Get("to"),
+	}
+	dataJSON, err := h.userManager.GetAdminAuditLogJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetAdminAuditLog").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if format != "csv" {
+		w.Header().Set("Content-Disposition", "attachment; filename=admin-audit-log.json")
+		helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+		return
+	}
+
+	// Render entries as csv
+	var entries []*hub.AdminAuditLogEntry
+	if err := json.Unmarshal(dataJSON, &entries); err != nil {
+		h.logger.Error().Err(err).Str("method", "GetAdminAuditLog").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	_ = cw.Write([]string{
+		"entry_number",
+		"admin_audit_log_id",
+		"created_at",
+		"action",
+		"user_id",
+		"admin_user_id",
+		"reason",
+		"ip",
+		"user_agent",
+		"details",
+		"previous_hash",
+		"hash",
+	})
+	for _, e := range entries {
+		var details string
+		if len(e.Details) > 0 && string(e.Details) != "null" {
+			details = string(e.Details)
+		}
+		record := []string{
+			strconv.FormatInt(e.EntryNumber, 10),
+			e.AdminAuditLogID,
+			time.Unix(e.CreatedAt, 0).UTC().Format(time.RFC3339),
+			e.Action,
+			e.UserID,
+			e.AdminUserID,
+			e.Reason,
+			e.IP,
+			e.UserAgent,
+			details,
+			e.PreviousHash,
+			e.Hash,
+		}
+		for i := range record {
+			record[i] = helpers.EscapeCSVCell(record[i])
+		}
+		_ = cw.Write(record)
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		h.logger.Error().Err(err).Str("method", "GetAdminAuditLog").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=admin-audit-log.csv")
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(0))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// GetEmailSuppression is an http handler used to get the suppression status of
+// the email address of the user doing the request.
+func (h *Handlers) GetEmailSuppression(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.userManager.GetEmailSuppressionJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetEmailSuppression").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetIdentities is an http handler used to get the external identities linked
+// to the account of the user doing the request.
+func (h *Handlers) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.userManager.GetIdentitiesJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetIdentities").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // GetProfile is an http handler used to get a logged in user profile.
 func (h *Handlers) GetProfile(w http.ResponseWriter, r *http.Request) {
 	dataJSON, err := h.userManager.GetProfileJSON(r.Context())
@@ -307,6 +468,60 @@ func (h *Handlers) GetProfile(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
+// GetPublicProfile is an http handler that returns the public profile of the
+// user requested.
+func (h *Handlers) GetPublicProfile(w http.ResponseWriter, r *http.Request) {
+	userAlias := chi.URLParam(r, "userAlias")
+	dataJSON, err := h.userManager.GetPublicProfileJSON(r.Context(), userAlias)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetPublicProfile").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// Impersonate is an http handler used by site admins to impersonate the
+// provided user for support purposes. A session cookie for the user is set,
+// valid for a limited period of time.
+func (h *Handlers) Impersonate(w http.ResponseWriter, r *http.Request) {
+	info, err := helpers.GetAdminAuditInfo(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Impersonate").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	userID := chi.URLParam(r, "userID")
+	session, err := h.userManager.Impersonate(r.Context(), userID, info)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Impersonate").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	h.logger.Info().Str("userID", userID).Str("reason", info.Reason).Msg("user impersonated by admin")
+
+	// Generate and set session cookie
+	encodedSessionID, err := h.sc.Encode(sessionCookieName, session.SessionID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Impersonate").Msg("sessionID encoding failed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	cookie := &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    encodedSessionID,
+		Path:     "/",
+		Expires:  time.Now().Add(user.ImpersonationSessionDuration),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if h.cfg.GetBool("server.cookie.secure") {
+		cookie.Secure = true
+	}
+	http.SetCookie(w, cookie)
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // InjectUserID is a middleware that injects the id of the user doing the
 // request into the request context when a valid session id is provided.
 func (h *Handlers) InjectUserID(next http.Handler) http.Handler {
@@ -369,7 +584,7 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Register user session
-	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+	ip := helpers.GetClientIP(r)
 	session, err := h.userManager.RegisterSession(r.Context(), &hub.Session{
 		UserID:    checkCredentialsOutput.UserID,
 		IP:        ip,
@@ -465,7 +680,18 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 	}
 	http.SetCookie(w, stateCookie)
 
-	// Register user if needed, or return his id if already registered
+	// Identities can only be linked to the account of a logged in user
+	var linkToUserID string
+	if state.Link {
+		linkToUserID, err = h.getUserIDFromSessionCookie(r)
+		if err != nil {
+			logger.Error().Err(err).Msg("valid session required to link identity")
+			http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
+			return
+		}
+	}
+
+	// Get the user the identity belongs to, registering or linking it if needed
 	provider := chi.URLParam(r, "provider")
 	providerConfig := h.oauthConfig[provider]
 	oauthToken, err := providerConfig.Exchange(r.Context(), code)
@@ -474,15 +700,21 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
 		return
 	}
-	userID, err := h.registerUserWithOauth(r.Context(), provider, providerConfig, oauthToken)
+	userID, err := h.getOauthUserID(r.Context(), provider, providerConfig, oauthToken, linkToUserID)
 	if err != nil {
-		logger.Error().Err(err).Msg("oauth code exchange failed")
+		logger.Error().Err(err).Msg("getOauthUserID failed")
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
 		return
 	}
 
+	// The user is already logged in when linking an identity
+	if state.Link {
+		http.Redirect(w, r, state.RedirectURL, http.StatusSeeOther)
+		return
+	}
+
 	// Register user session and set session cookie
-	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+	ip := helpers.GetClientIP(r)
 	session, err := h.userManager.RegisterSession(r.Context(), &hub.Session{
 		UserID:    userID,
 		IP:        ip,
@@ -543,6 +775,7 @@ func (h *Handlers) OauthRedirect(w http.ResponseWriter, r *http.Request) {
 	state := &OauthState{
 		Random:      random,
 		RedirectURL: redirectURL,
+		Link:        r.FormValue("link") == "true",
 	}
 	authCodeURL := providerConfig.AuthCodeURL(state.String())
 	http.Redirect(w, r, authCodeURL, http.StatusSeeOther)
@@ -560,6 +793,25 @@ func (h *Handlers) RegisterDeleteUserCode(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusCreated)
 }
 
+// RegisterEmailVerificationCode is an http handler used to register a new
+// code to verify the email of a user. The code will be emailed to the address
+// provided. Errors other than rate limiting ones are not reported, to avoid
+// disclosing which emails are registered.
+func (h *Handlers) RegisterEmailVerificationCode(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterEmailVerificationCode").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	err := h.userManager.RegisterEmailVerificationCode(r.Context(), input["email"])
+	if errors.Is(err, hub.ErrTooManyRequests) {
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
 // RegisterPasswordResetCode is an http handler used to register a code to
 // reset the password. The code will be emailed to the address provided.
 func (h *Handlers) RegisterPasswordResetCode(w http.ResponseWriter, r *http.Request) {
@@ -598,25 +850,29 @@ func (h *Handlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
-// registerUserWithOauth is a helper function that registers a user using the
-// details from his oauth provider if he's not already registered, returning
-// the user id.
-func (h *Handlers) registerUserWithOauth(
+// getOauthUserID is a helper function that returns the id of the user the
+// identity from the oauth provider belongs to. When a user id to link the
+// identity to is provided, the identity is linked to that user. Otherwise, the
+// identity is linked to the user with the same email, who will be registered
+// using the details from the oauth provider if needed.
+func (h *Handlers) getOauthUserID(
 	ctx context.Context,
 	provider string,
 	providerConfig *oauth2.Config,
 	oauthToken *oauth2.Token,
+	linkToUserID string,
 ) (string, error) {
-	// Build user from profile from oauth provider
+	// Build user and identity from profile from oauth provider
 	var u *hub.User
+	var identity *hub.UserIdentity
 	var err error
 	switch provider {
 	case "github":
-		u, err = h.newUserFromGithubProfile(ctx, oauthToken)
+		u, identity, err = h.newUserFromGithubProfile(ctx, oauthToken)
 	case "google":
-		u, err = h.newUserFromGoogleProfile(ctx, providerConfig, oauthToken)
+		u, identity, err = h.newUserFromGoogleProfile(ctx, providerConfig, oauthToken)
 	case "oidc":
-		u, err = h.newUserFromOIDProfile(ctx, oauthToken)
+		u, identity, err = h.newUserFromOIDProfile(ctx, oauthToken)
 	default:
 		err = fmt.Errorf("invalid provider: %s", provider)
 	}
@@ -624,57 +880,101 @@ func (h *Handlers) registerUserWithOauth(
 		return "", err
 	}
 
-	// Check user alias availability and append suffix to it if needed
-	available, err := h.userManager.CheckAvailability(ctx, "userAlias", u.Alias)
-	if err != nil {
-		return "", err
-	}
-	if !available {
-		randomSuffix, err := getRandomSuffix()
-		if err != nil {
+	// Link identity to the user provided if requested
+	if linkToUserID != "" {
+		identity.UserID = linkToUserID
+		if err := h.userManager.LinkIdentity(ctx, identity); err != nil {
 			return "", err
 		}
-		u.Alias += randomSuffix
+		return linkToUserID, nil
 	}
 
-	// Check if user exists
-	userID, err := h.userManager.GetUserID(ctx, u.Email)
+	// Check if the identity has already been linked to a user
+	userID, err := h.userManager.GetUserIDFromIdentity(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		return userID, nil
+	}
+	if !errors.Is(err, user.ErrNotFound) {
+		return "", err
+	}
+
+	// Check if a user with the same email exists
+	userID, err = h.userManager.GetUserID(ctx, u.Email)
 	if err != nil && !errors.Is(err, user.ErrNotFound) {
 		return "", err
 	}
 
 	// Register user if needed
 	if userID == "" {
-		u.EmailVerified = true
-		err := h.userManager.RegisterUser(ctx, u)
+		// Check user alias availability and append suffix to it if needed
+		available, err := h.userManager.CheckAvailability(ctx, "userAlias", u.Alias)
 		if err != nil {
 			return "", err
 		}
+		if !available {
+			randomSuffix, err := getRandomSuffix()
+			if err != nil {
+				return "", err
+			}
+			u.Alias += randomSuffix
+		}
+
+		u.EmailVerified = true
+		if err := h.userManager.RegisterUser(ctx, u); err != nil {
+			return "", err
+		}
 		userID, err = h.userManager.GetUserID(ctx, u.Email)
 		if err != nil {
 			return "", err
 		}
 	}
 
+	// Link identity to the user
+	identity.UserID = userID
+	if err := h.userManager.LinkIdentity(ctx, identity); err != nil {
+		return "", err
+	}
+
 	return userID, nil
 }
 
+// getUserIDFromSessionCookie is a helper function that returns the id of the
+// user owning the session provided in the request cookie, if it is valid.
+func (h *Handlers) getUserIDFromSessionCookie(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return "", errInvalidSession
+	}
+	var sessionID string
+	if err := h.sc.Decode(sessionCookieName, cookie.Value, &sessionID); err != nil {
+		return "", errInvalidSession
+	}
+	checkSessionOutput, err := h.userManager.CheckSession(r.Context(), sessionID, sessionDuration)
+	if err != nil {
+		return "", err
+	}
+	if !checkSessionOutput.Valid {
+		return "", errInvalidSession
+	}
+	return checkSessionOutput.UserID, nil
+}
+
 // newUserFromGithubProfile builds a new hub.User instance from the user's
-// Github profile.
+// Github profile, as well as the corresponding identity.
 func (h *Handlers) newUserFromGithubProfile(
 	ctx context.Context,
 	oauthToken *oauth2.Token,
-) (*hub.User, error) {
+) (*hub.User, *hub.UserIdentity, error) {
 	// Get user profile and emails
 	httpClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(oauthToken))
 	githubClient := github.NewClient(httpClient)
 	profile, _, err := githubClient.Users.Get(ctx, "")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	emails, _, err := githubClient.Users.ListEmails(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Get user's primary email and check if it has been verified
@@ -685,35 +985,41 @@ func (h *Handlers) newUserFromGithubProfile(
 		}
 	}
 	if email == "" {
-		return nil, errors.New("no valid email available for use")
+		return nil, nil, errors.New("no valid email available for use")
 	}
 
-	return &hub.User{
+	u := &hub.User{
 		Alias:     profile.GetLogin(),
 		Email:     email,
 		FirstName: profile.GetName(),
-	}, nil
+	}
+	identity := &hub.UserIdentity{
+		Provider: "github",
+		Subject:  strconv.FormatInt(profile.GetID(), 10),
+		Email:    email,
+	}
+	return u, identity, nil
 }
 
 // newUserFromGoogleProfile builds a new hub.User instance from the user's
-// Google profile.
+// Google profile, as well as the corresponding identity.
 func (h *Handlers) newUserFromGoogleProfile(
 	ctx context.Context,
 	providerConfig *oauth2.Config,
 	oauthToken *oauth2.Token,
-) (*hub.User, error) {
+) (*hub.User, *hub.UserIdentity, error) {
 	// Get user profile
 	opt := option.WithTokenSource(providerConfig.TokenSource(ctx, oauthToken))
 	peopleService, err := people.NewService(ctx, opt)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	profile, err := peopleService.People.
 		Get("people/me").
 		PersonFields("names,emailAddresses").
 		Do()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Get user's primary email and check if it has been verified
@@ -724,27 +1030,33 @@ func (h *Handlers) newUserFromGoogleProfile(
 		}
 	}
 	if email == "" {
-		return nil, errors.New("no valid email available for use")
+		return nil, nil, errors.New("no valid email available for use")
 	}
 
-	return &hub.User{
+	u := &hub.User{
 		Alias:     strings.Split(email, "@")[0],
 		Email:     email,
 		FirstName: profile.Names[0].GivenName,
 		LastName:  profile.Names[0].FamilyName,
-	}, nil
+	}
+	identity := &hub.UserIdentity{
+		Provider: "google",
+		Subject:  profile.ResourceName,
+		Email:    email,
+	}
+	return u, identity, nil
 }
 
 // newUserFromOIDProfile builds a new hub.User instance from the user's OpenID
-// profile.
+// profile, as well as the corresponding identity.
 func (h *Handlers) newUserFromOIDProfile(
 	ctx context.Context,
 	oauthToken *oauth2.Token,
-) (*hub.User, error) {
+) (*hub.User, *hub.UserIdentity, error) {
 	// Extract the id token from oauth token
 	rawIDToken, ok := oauthToken.Extra("id_token").(string)
 	if !ok {
-		return nil, errors.New("id token not available")
+		return nil, nil, errors.New("id token not available")
 	}
 
 	// Parse and verify id token payload
@@ -753,7 +1065,7 @@ func (h *Handlers) newUserFromOIDProfile(
 	})
 	idToken, err := verifier.Verify(ctx, rawIDToken)
 	if err != nil {
-		return nil, fmt.Errorf("invalid id token: %w", err)
+		return nil, nil, fmt.Errorf("invalid id token: %w", err)
 	}
 
 	// Extract claims
@@ -765,29 +1077,36 @@ func (h *Handlers) newUserFromOIDProfile(
 		PreferredUsername string `json:"preferred_username"`
 	}
 	if err := idToken.Claims(&claims); err != nil {
-		return nil, fmt.Errorf("error extracting claims from id token: %w", err)
+		return nil, nil, fmt.Errorf("error extracting claims from id token: %w", err)
 	}
 	skipEmailVerifiedCheck := h.cfg.GetBool("server.oauth.oidc.skipEmailVerifiedCheck")
 	if claims.Email == "" || (!skipEmailVerifiedCheck && !claims.EmailVerified) {
-		return nil, errors.New("no valid email available for use")
+		return nil, nil, errors.New("no valid email available for use")
 	}
 	alias := claims.PreferredUsername
 	if alias == "" {
 		alias = strings.Split(claims.Email, "@")[0]
 	}
 
-	return &hub.User{
+	u := &hub.User{
 		Alias:     alias,
 		Email:     claims.Email,
 		FirstName: claims.GivenName,
 		LastName:  claims.FamilyName,
-	}, nil
+	}
+	identity := &hub.UserIdentity{
+		Provider: "oidc",
+		Subject:  idToken.Subject,
+		Email:    claims.Email,
+	}
+	return u, identity, nil
 }
 
 // RequireLogin is a middleware that verifies if a user is logged in.
 func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var userID string
+		var apiKeyUsed bool
 
 		// Extract API key id and secret from header
 		apiKeyID := r.Header.Get(APIKeyIDHeader)
@@ -796,18 +1115,24 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 		// Use API key based authentication if API key is provided
 		if apiKeyID != "" && apiKeySecret != "" {
 			// Check the API key provided is valid
-			checkAPIKeyOutput, err := h.apiKeyManager.Check(r.Context(), apiKeyID, apiKeySecret)
+			ip := helpers.GetClientIP(r)
+			checkAPIKeyOutput, err := h.apiKeyManager.Check(r.Context(), apiKeyID, apiKeySecret, ip)
 			if err != nil {
 				h.logger.Error().Err(err).Str("method", "RequireLogin").Msg("checkAPIKey failed")
 				helpers.RenderErrorWithCodeJSON(w, nil, http.StatusInternalServerError)
 				return
 			}
+			if checkAPIKeyOutput.IPNotAllowed {
+				helpers.RenderErrorWithCodeJSON(w, errAPIKeyIPNotAllowed, http.StatusForbidden)
+				return
+			}
 			if !checkAPIKeyOutput.Valid {
 				helpers.RenderErrorWithCodeJSON(w, errInvalidAPIKey, http.StatusUnauthorized)
 				return
 			}
 
 			userID = checkAPIKeyOutput.UserID
+			apiKeyUsed = true
 		} else {
 			// Use cookie based authentication
 			cookie, err := r.Cookie(sessionCookieName)
@@ -832,6 +1157,25 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 					return
 				}
 
+				// Check if the session is being used from an ip different
+				// than the one it was registered from. This may happen
+				// legitimately (i.e. mobile networks), so by default it's
+				// only logged, unless sessions are configured to be bound
+				// to their ip.
+				ip := helpers.GetClientIP(r)
+				if ipChanged(checkSessionOutput.IP, ip) {
+					h.logger.Warn().
+						Str("method", "RequireLogin").
+						Str("userID", checkSessionOutput.UserID).
+						Str("sessionIP", checkSessionOutput.IP).
+						Str("clientIP", ip).
+						Msg("session used from a different ip")
+					if h.cfg.GetBool("server.sessions.bindToIP") {
+						helpers.RenderErrorWithCodeJSON(w, errInvalidSession, http.StatusUnauthorized)
+						return
+					}
+				}
+
 				userID = checkSessionOutput.UserID
 			}
 		}
@@ -844,7 +1188,19 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 
 		// Inject userID in context and call next handler
 		ctx := context.WithValue(r.Context(), hub.UserIDKey, userID)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		if !apiKeyUsed || h.apiKeyUsageTracker == nil {
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		// Track API key usage, counting as errors the requests that failed
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+		statusCode := ww.Status()
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+		h.apiKeyUsageTracker.TrackUsage(apiKeyID, statusCode)
 	})
 }
 
@@ -869,6 +1225,50 @@ func (h *Handlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ResetTFA is an http handler used by site admins to reset the two-factor
+// authentication of the provided user.
+func (h *Handlers) ResetTFA(w http.ResponseWriter, r *http.Request) {
+	info, err := helpers.GetAdminAuditInfo(r)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "ResetTFA").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	userID := chi.URLParam(r, "userID")
+	if err := h.userManager.ResetTFA(r.Context(), userID, info); err != nil {
+		h.logger.Error().Err(err).Str("method", "ResetTFA").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	h.logger.Info().Str("userID", userID).Msg("user tfa reset by admin")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Search is an http handler used by site admins to search for users.
+func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+	p, err := helpers.GetPagination(qs, helpers.PaginationDefaultLimit, helpers.PaginationMaxLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	input := &hub.SearchUsersInput{
+		TSQueryWeb: qs.Get("ts_query_web"),
+		Limit:      p.Limit,
+		Offset:     p.Offset,
+	}
+	result, err := h.userManager.SearchJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set(helpers.PaginationTotalCount, strconv.Itoa(result.TotalCount))
+	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
+}
+
 // SetupTFA is an http handler used to setup two-factor authentication.
 func (h *Handlers) SetupTFA(w http.ResponseWriter, r *http.Request) {
 	dataJSON, err := h.userManager.SetupTFA(r.Context())
@@ -880,6 +1280,18 @@ func (h *Handlers) SetupTFA(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
 }
 
+// UnlinkIdentity is an http handler used to unlink an external identity from
+// the account of the user doing the request.
+func (h *Handlers) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	provider := chi.URLParam(r, "provider")
+	if err := h.userManager.UnlinkIdentity(r.Context(), provider); err != nil {
+		h.logger.Error().Err(err).Str("method", "UnlinkIdentity").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // UpdatePassword is an http handler used to update the password in the hub
 // database.
 func (h *Handlers) UpdatePassword(w http.ResponseWriter, r *http.Request) {
@@ -920,6 +1332,26 @@ func (h *Handlers) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// VerifyAdminAuditLog is an http handler used by site admins to check that
+// the entries of the admin audit log have not been tampered with.
+func (h *Handlers) VerifyAdminAuditLog(w http.ResponseWriter, r *http.Request) {
+	invalidEntryID, err := h.userManager.VerifyAdminAuditLog(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "VerifyAdminAuditLog").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	result := map[string]interface{}{
+		"valid": invalidEntryID == "",
+	}
+	if invalidEntryID != "" {
+		h.logger.Warn().Str("entryID", invalidEntryID).Msg("admin audit log verification failed")
+		result["invalid_entry_id"] = invalidEntryID
+	}
+	dataJSON, _ := json.Marshal(result)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // VerifyEmail is an http handler used to verify a user's email address.
 func (h *Handlers) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	var input map[string]string
@@ -969,6 +1401,10 @@ func (h *Handlers) VerifyPasswordResetCode(w http.ResponseWriter, r *http.Reques
 type OauthState struct {
 	Random      string
 	RedirectURL string
+
+	// Link indicates that the identity must be linked to the account of the
+	// user logged in, instead of being used to sign in.
+	Link bool
 }
 
 // String returns an OauthState instance as a string.
@@ -1000,3 +1436,17 @@ func getRandomSuffix() (string, error) {
 	}
 	return strconv.FormatInt(nBig.Int64(), 10), nil
 }
+
+// ipChanged checks if the client ip provided is different than the one the
+// session was registered from. When any of them is not available, it's not
+// considered a change.
+func ipChanged(sessionIP, clientIP string) bool {
+	if sessionIP == "" || clientIP == "" {
+		return false
+	}
+	ip1, ip2 := net.ParseIP(sessionIP), net.ParseIP(clientIP)
+	if ip1 == nil || ip2 == nil {
+		return sessionIP != clientIP
+	}
+	return !ip1.Equal(ip2)
+}
@@ -326,6 +326,40 @@ func TestCheckAvailability(t *testing.T) {
 	})
 }
 
+func TestDeleteEmailSuppression(t *testing.T) {
+	t.Run("error deleting email suppression", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("DeleteEmailSuppression", r.Context()).Return(tests.ErrFakeDB)
+		hw.h.DeleteEmailSuppression(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("email suppression deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("DeleteEmailSuppression", r.Context()).Return(nil)
+		hw.h.DeleteEmailSuppression(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestDeleteUser(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -398,6 +432,84 @@ func TestDeleteUser(t *testing.T) {
 	})
 }
 
+func TestDisable(t *testing.T) {
+	userID := "00000000-0000-0000-0000-000000000001"
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userID"},
+			Values: []string{userID},
+		},
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"reason": "spam" ...`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Disable(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error disabling user", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"reason": "spam"}`))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.um.On("SetDisabled", r.Context(), userID, true, &hub.AdminAuditInfo{Reason: "spam"}).Return(tc.err)
+				hw.h.Disable(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user disabled successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"reason": "spam"}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("SetDisabled", r.Context(), userID, true, &hub.AdminAuditInfo{Reason: "spam"}).Return(nil)
+		hw.h.Disable(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestDisableTFA(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
@@ -463,6 +575,33 @@ func TestDisableTFA(t *testing.T) {
 	})
 }
 
+func TestEnable(t *testing.T) {
+	userID := "00000000-0000-0000-0000-000000000001"
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userID"},
+			Values: []string{userID},
+		},
+	}
+
+	t.Run("user enabled successfully, no reason provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(""))
+		r.RemoteAddr = "192.168.1.100:30000"
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("SetDisabled", r.Context(), userID, false, &hub.AdminAuditInfo{IP: "192.168.1.100"}).Return(nil)
+		hw.h.Enable(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestEnableTFA(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
@@ -528,6 +667,190 @@ func TestEnableTFA(t *testing.T) {
 	})
 }
 
+func TestGetAdminAuditLog(t *testing.T) {
+	t.Run("invalid format", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?format=xml", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.GetAdminAuditLog(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("error getting admin audit log", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?from=2021-01-01T00:00:00Z", nil)
+
+		hw := newHandlersWrapper()
+		input := &hub.GetAdminAuditLogInput{From: "2021-01-01T00:00:00Z"}
+		hw.um.On("GetAdminAuditLogJSON", r.Context(), input).Return(nil, tests.ErrFakeDB)
+		hw.h.GetAdminAuditLog(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("admin audit log exported as json", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetAdminAuditLogJSON", r.Context(), &hub.GetAdminAuditLogInput{}).Return([]byte("dataJSON"), nil)
+		hw.h.GetAdminAuditLog(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=admin-audit-log.json", h.Get("Content-Disposition"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("admin audit log exported as csv", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?format=csv", nil)
+
+		hw := newHandlersWrapper()
+		dataJSON := []byte(`[
+			{
+				"admin_audit_log_id": "00000000-0000-0000-0000-000000000001",
+				"entry_number": 1,
+				"action": "disable_user",
+				"user_id": "00000000-0000-0000-0000-000000000002",
+				"admin_user_id": "00000000-0000-0000-0000-000000000004",
+				"reason": "=HYPERLINK(\"http://evil\")",
+				"ip": "1.1.1.1",
+				"user_agent": "ua",
+				"details": null,
+				"created_at": 1609459200,
+				"previous_hash": null,
+				"hash": "hash1"
+			},
+			{
+				"admin_audit_log_id": "00000000-0000-0000-0000-000000000003",
+				"entry_number": 2,
+				"action": "block_content",
+				"user_id": null,
+				"reason": "spam",
+				"ip": null,
+				"user_agent": null,
+				"details": {"repository_name": "repo1"},
+				"created_at": 1609459200,
+				"previous_hash": "hash1",
+				"hash": "hash2"
+			}
+		]`)
+		hw.um.On("GetAdminAuditLogJSON", r.Context(), &hub.GetAdminAuditLogInput{}).Return(dataJSON, nil)
+		hw.h.GetAdminAuditLog(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv", h.Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=admin-audit-log.csv", h.Get("Content-Disposition"))
+		assert.Equal(t, strings.Join([]string{
+			"entry_number,admin_audit_log_id,created_at,action,user_id,admin_user_id,reason,ip,user_agent,details,previous_hash,hash",
+			`1,00000000-0000-0000-0000-000000000001,2021-01-01T00:00:00Z,disable_user,00000000-0000-0000-0000-000000000002,00000000-0000-0000-0000-000000000004,"'=HYPERLINK(""http://evil"")",1.1.1.1,ua,,,hash1`,
+			`2,00000000-0000-0000-0000-000000000003,2021-01-01T00:00:00Z,block_content,,,spam,,,"{""repository_name"": ""repo1""}",hash1,hash2`,
+			"",
+		}, "\n"), string(data))
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestGetEmailSuppression(t *testing.T) {
+	t.Run("error getting email suppression", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetEmailSuppressionJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetEmailSuppression(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("email suppression get succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetEmailSuppressionJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetEmailSuppression(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestGetIdentities(t *testing.T) {
+	t.Run("error getting identities", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetIdentitiesJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetIdentities(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("identities get succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetIdentitiesJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetIdentities(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestGetProfile(t *testing.T) {
 	t.Run("error getting profile", func(t *testing.T) {
 		t.Parallel()
@@ -567,6 +890,150 @@ func TestGetProfile(t *testing.T) {
 	})
 }
 
+func TestGetPublicProfile(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userAlias"},
+			Values: []string{"user1"},
+		},
+	}
+
+	t.Run("error getting public profile", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.um.On("GetPublicProfileJSON", r.Context(), "user1").Return(nil, tc.err)
+				hw.h.GetPublicProfile(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get public profile succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("GetPublicProfileJSON", r.Context(), "user1").Return([]byte("dataJSON"), nil)
+		hw.h.GetPublicProfile(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestImpersonate(t *testing.T) {
+	userID := "00000000-0000-0000-0000-000000000001"
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userID"},
+			Values: []string{userID},
+		},
+	}
+
+	t.Run("error impersonating user", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"reason": "support"}`))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.um.On("Impersonate", r.Context(), userID, &hub.AdminAuditInfo{Reason: "support"}).
+					Return(nil, tc.err)
+				hw.h.Impersonate(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				assert.Empty(t, resp.Cookies())
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user impersonated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"reason": "support"}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("Impersonate", r.Context(), userID, &hub.AdminAuditInfo{Reason: "support"}).
+			Return(&hub.Session{SessionID: "sessionID", UserID: userID, Approved: true}, nil)
+		hw.h.Impersonate(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Len(t, resp.Cookies(), 1)
+		cookie := resp.Cookies()[0]
+		assert.Equal(t, sessionCookieName, cookie.Name)
+		assert.True(t, cookie.HttpOnly)
+		assert.WithinDuration(t, time.Now().Add(user.ImpersonationSessionDuration), cookie.Expires, 5*time.Second)
+		var sessionID string
+		err := hw.h.sc.Decode(sessionCookieName, cookie.Value, &sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, "sessionID", sessionID)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestInjectUserID(t *testing.T) {
 	sessionID := "sessionID"
 
@@ -972,6 +1439,32 @@ func TestOauthCallback(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("link identity without a valid session", func(t *testing.T) {
+		t.Parallel()
+		state := &OauthState{
+			Random:      "abcd",
+			RedirectURL: "/",
+			Link:        true,
+		}
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?code=1234&state="+state.String(), nil)
+		r.AddCookie(&http.Cookie{
+			Name:  oauthStateCookieName,
+			Value: "abcd",
+		})
+
+		hw := newHandlersWrapper()
+		hw.h.OauthCallback(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+		redirectURL, err := resp.Location()
+		require.NoError(t, err)
+		assert.Equal(t, oauthFailedURL, redirectURL.String())
+		hw.um.AssertExpectations(t)
+	})
 }
 
 func TestOauthRedirect(t *testing.T) {
@@ -1008,32 +1501,99 @@ func TestOauthRedirect(t *testing.T) {
 	assert.Equal(t, expectedRedirectURL, redirectURL.String())
 }
 
-func TestRegisterDeleteUserCode(t *testing.T) {
-	t.Run("register delete user code failed", func(t *testing.T) {
+func TestRegisterDeleteUserCode(t *testing.T) {
+	t.Run("register delete user code failed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(""))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("RegisterDeleteUserCode", r.Context()).Return(tests.ErrFakeDB)
+		hw.h.RegisterDeleteUserCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("register delete user code succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(""))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.um.On("RegisterDeleteUserCode", r.Context()).Return(nil)
+		hw.h.RegisterDeleteUserCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestRegisterEmailVerificationCode(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("POST", "/", strings.NewReader(""))
-		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		body := strings.NewReader(`email`)
+		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
-		hw.um.On("RegisterDeleteUserCode", r.Context()).Return(tests.ErrFakeDB)
-		hw.h.RegisterDeleteUserCode(w, r)
+		hw.h.RegisterEmailVerificationCode(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 		hw.um.AssertExpectations(t)
 	})
 
-	t.Run("register delete user code succeeded", func(t *testing.T) {
+	t.Run("register email verification code failed", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				tests.ErrFakeDB,
+				http.StatusCreated,
+			},
+			{
+				hub.ErrTooManyRequests,
+				http.StatusTooManyRequests,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				body := strings.NewReader(`{"email": "email"}`)
+				r, _ := http.NewRequest("POST", "/", body)
+
+				hw := newHandlersWrapper()
+				hw.um.On("RegisterEmailVerificationCode", r.Context(), "email").Return(tc.err)
+				hw.h.RegisterEmailVerificationCode(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("register email verification code succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("POST", "/", strings.NewReader(""))
-		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		body := strings.NewReader(`{"email": "email"}`)
+		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
-		hw.um.On("RegisterDeleteUserCode", r.Context()).Return(nil)
-		hw.h.RegisterDeleteUserCode(w, r)
+		hw.um.On("RegisterEmailVerificationCode", r.Context(), "email").Return(nil)
+		hw.h.RegisterEmailVerificationCode(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
@@ -1254,7 +1814,7 @@ func TestRequireLogin(t *testing.T) {
 			r.Header.Add(APIKeySecretHeader, apiKeySecret)
 
 			hw := newHandlersWrapper()
-			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret).Return(nil, tests.ErrFakeDB)
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "").Return(nil, tests.ErrFakeDB)
 			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
 			resp := w.Result()
 			defer resp.Body.Close()
@@ -1275,7 +1835,7 @@ func TestRequireLogin(t *testing.T) {
 			r.Header.Add(APIKeySecretHeader, apiKeySecret)
 
 			hw := newHandlersWrapper()
-			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret).
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "").
 				Return(&hub.CheckAPIKeyOutput{UserID: "", Valid: false}, nil)
 			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
 			resp := w.Result()
@@ -1289,6 +1849,29 @@ func TestRequireLogin(t *testing.T) {
 			hw.um.AssertExpectations(t)
 		})
 
+		t.Run("api key used from an ip not allowed", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.RemoteAddr = "10.0.0.1:1234"
+			r.Header.Add(APIKeyIDHeader, apiKeyID)
+			r.Header.Add(APIKeySecretHeader, apiKeySecret)
+
+			hw := newHandlersWrapper()
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "10.0.0.1").
+				Return(&hub.CheckAPIKeyOutput{UserID: "", Valid: false, IPNotAllowed: true}, nil)
+			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			h := resp.Header
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+			assert.Equal(t, "application/json", h.Get("Content-Type"))
+			assert.Equal(t, buildError(errAPIKeyIPNotAllowed.Error()), data)
+			hw.am.AssertExpectations(t)
+		})
+
 		t.Run("api key based authentication succeeded", func(t *testing.T) {
 			t.Parallel()
 			w := httptest.NewRecorder()
@@ -1297,14 +1880,35 @@ func TestRequireLogin(t *testing.T) {
 			r.Header.Add(APIKeySecretHeader, apiKeySecret)
 
 			hw := newHandlersWrapper()
-			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret).
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "").
 				Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true}, nil)
+			hw.ut.On("TrackUsage", apiKeyID, http.StatusOK)
 			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
 			resp := w.Result()
 			defer resp.Body.Close()
 
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
 			hw.um.AssertExpectations(t)
+			hw.ut.AssertExpectations(t)
+		})
+
+		t.Run("api key usage tracked as error when next handler fails", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.Header.Add(APIKeyIDHeader, apiKeyID)
+			r.Header.Add(APIKeySecretHeader, apiKeySecret)
+
+			hw := newHandlersWrapper()
+			hw.am.On("Check", r.Context(), apiKeyID, apiKeySecret, "").
+				Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true}, nil)
+			hw.ut.On("TrackUsage", apiKeyID, http.StatusNotFound)
+			hw.h.RequireLogin(http.NotFoundHandler()).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			hw.ut.AssertExpectations(t)
 		})
 	})
 
@@ -1400,6 +2004,59 @@ func TestRequireLogin(t *testing.T) {
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
 			hw.um.AssertExpectations(t)
 		})
+
+		t.Run("session used from a different ip", func(t *testing.T) {
+			testCases := []struct {
+				desc               string
+				bindToIP           bool
+				clientIP           string
+				expectedStatusCode int
+			}{
+				{
+					"same ip, sessions bound to ip",
+					true,
+					"192.168.1.1",
+					http.StatusOK,
+				},
+				{
+					"different ip, sessions not bound to ip",
+					false,
+					"192.168.1.2",
+					http.StatusOK,
+				},
+				{
+					"different ip, sessions bound to ip",
+					true,
+					"192.168.1.2",
+					http.StatusUnauthorized,
+				},
+			}
+			for _, tc := range testCases {
+				tc := tc
+				t.Run(tc.desc, func(t *testing.T) {
+					t.Parallel()
+					w := httptest.NewRecorder()
+					r, _ := http.NewRequest("GET", "/", nil)
+					r = r.WithContext(context.WithValue(r.Context(), hub.ClientIPKey, tc.clientIP))
+
+					hw := newHandlersWrapper()
+					hw.cfg.Set("server.sessions.bindToIP", tc.bindToIP)
+					hw.um.On("CheckSession", r.Context(), sessionID, sessionDuration).
+						Return(&hub.CheckSessionOutput{UserID: "userID", Valid: true, IP: "192.168.1.1"}, nil)
+					encodedSessionID, _ := hw.h.sc.Encode(sessionCookieName, sessionID)
+					r.AddCookie(&http.Cookie{
+						Name:  sessionCookieName,
+						Value: encodedSessionID,
+					})
+					hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+					resp := w.Result()
+					defer resp.Body.Close()
+
+					assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+					hw.um.AssertExpectations(t)
+				})
+			}
+		})
 	})
 
 	t.Run("no authentication method used", func(t *testing.T) {
@@ -1487,6 +2144,110 @@ func TestResetPassword(t *testing.T) {
 	})
 }
 
+func TestResetTFA(t *testing.T) {
+	userID := "00000000-0000-0000-0000-000000000001"
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userID"},
+			Values: []string{userID},
+		},
+	}
+
+	t.Run("error resetting tfa", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"reason": "device lost"}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("ResetTFA", r.Context(), userID, &hub.AdminAuditInfo{Reason: "device lost"}).Return(tests.ErrFakeDB)
+		hw.h.ResetTFA(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("tfa reset successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"reason": "device lost"}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("ResetTFA", r.Context(), userID, &hub.AdminAuditInfo{Reason: "device lost"}).Return(nil)
+		hw.h.ResetTFA(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("invalid pagination", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=100", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error searching users", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?ts_query_web=user1&limit=10&offset=1", nil)
+
+		hw := newHandlersWrapper()
+		hw.um.On("SearchJSON", r.Context(), &hub.SearchUsersInput{
+			TSQueryWeb: "user1",
+			Limit:      10,
+			Offset:     1,
+		}).Return(nil, tests.ErrFakeDB)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("users search succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?ts_query_web=user1&limit=10&offset=1", nil)
+
+		hw := newHandlersWrapper()
+		hw.um.On("SearchJSON", r.Context(), &hub.SearchUsersInput{
+			TSQueryWeb: "user1",
+			Limit:      10,
+			Offset:     1,
+		}).Return(&hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, nil)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, "1", h.Get(helpers.PaginationTotalCount))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestSetupTFA(t *testing.T) {
 	t.Run("tfa setup failed", func(t *testing.T) {
 		t.Parallel()
@@ -1523,6 +2284,67 @@ func TestSetupTFA(t *testing.T) {
 	})
 }
 
+func TestUnlinkIdentity(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"provider"},
+			Values: []string{"github"},
+		},
+	}
+
+	t.Run("error unlinking identity", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.um.On("UnlinkIdentity", r.Context(), "github").Return(tc.err)
+				hw.h.UnlinkIdentity(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("identity unlinked successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.um.On("UnlinkIdentity", r.Context(), "github").Return(nil)
+		hw.h.UnlinkIdentity(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestUpdatePassword(t *testing.T) {
 	t.Run("no old password provided", func(t *testing.T) {
 		t.Parallel()
@@ -1691,6 +2513,57 @@ func TestUpdateProfile(t *testing.T) {
 	})
 }
 
+func TestVerifyAdminAuditLog(t *testing.T) {
+	t.Run("error verifying admin audit log", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.um.On("VerifyAdminAuditLog", r.Context()).Return("", tests.ErrFakeDB)
+		hw.h.VerifyAdminAuditLog(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("admin audit log is valid", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.um.On("VerifyAdminAuditLog", r.Context()).Return("", nil)
+		hw.h.VerifyAdminAuditLog(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{"valid": true}`, string(data))
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("admin audit log has been tampered with", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.um.On("VerifyAdminAuditLog", r.Context()).Return("entryID", nil)
+		hw.h.VerifyAdminAuditLog(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{"valid": false, "invalid_entry_id": "entryID"}`, string(data))
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestVerifyEmail(t *testing.T) {
 	testCases := []struct {
 		description        string
@@ -1801,6 +2674,7 @@ type handlersWrapper struct {
 	cfg *viper.Viper
 	um  *user.ManagerMock
 	am  *apikey.ManagerMock
+	ut  *apikey.UsageTrackerMock
 	h   *Handlers
 }
 
@@ -1810,12 +2684,14 @@ func newHandlersWrapper() *handlersWrapper {
 	cfg.Set("server.oauth.github", map[string]string{})
 	um := &user.ManagerMock{}
 	am := &apikey.ManagerMock{}
-	h, _ := NewHandlers(context.Background(), um, am, cfg)
+	ut := &apikey.UsageTrackerMock{}
+	h, _ := NewHandlers(context.Background(), um, am, ut, cfg)
 
 	return &handlersWrapper{
 		cfg: cfg,
 		um:  um,
 		am:  am,
+		ut:  ut,
 		h:   h,
 	}
 }
@@ -7,27 +7,33 @@ import (
 	"net/http"
 	"strconv"
 	"text/template"
+	"time"
 
+	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/notification"
+	"github.com/artifacthub/hub/internal/sandbox"
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
 )
 
 // Handlers represents a group of http handlers in charge of handling webhooks
 // operations.
 type Handlers struct {
 	webhookManager hub.WebhookManager
+	cfg            *viper.Viper
 	logger         zerolog.Logger
 	hc             hub.HTTPClient
 }
 
 // NewHandlers creates a new Handlers instance.
-func NewHandlers(webhookManager hub.WebhookManager, hc hub.HTTPClient) *Handlers {
+func NewHandlers(webhookManager hub.WebhookManager, cfg *viper.Viper, hc hub.HTTPClient) *Handlers {
 	return &Handlers{
 		webhookManager: webhookManager,
+		cfg:            cfg,
 		logger:         log.With().Str("handlers", "webhook").Logger(),
 		hc:             hc,
 	}
@@ -115,44 +121,117 @@ func (h *Handlers) GetOwnedByUser(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, result.Data, 0, http.StatusOK)
 }
 
-// TriggerTest is an http handler used to test a webhook before adding or
-// updating it.
-func (h *Handlers) TriggerTest(w http.ResponseWriter, r *http.Request) {
-	// Read webhook from request body
+// Preview is an http handler that renders the notifications templates of the
+// provided webhook against some sample data, so that the notifications
+// subscribers will receive can be previewed before adding or updating it.
+func (h *Handlers) Preview(w http.ResponseWriter, r *http.Request) {
 	wh := &hub.Webhook{}
 	if err := json.NewDecoder(r.Body).Decode(&wh); err != nil {
+		h.logger.Error().Err(err).Str("method", "Preview").Msg(hub.ErrInvalidInput.Error())
 		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
 		return
 	}
+	locale := r.FormValue("locale")
+	if locale == "" {
+		locale = email.DefaultLocale
+	}
+	previews, err := notification.RenderPreviews(h.cfg, wh, locale)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Preview").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(previews)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
 
-	// Prepare payload
-	var tmpl *template.Template
-	if wh.Template != "" {
-		var err error
-		tmpl, err = template.New("").Parse(wh.Template)
-		if err != nil {
-			err = fmt.Errorf("error parsing template: %w", err)
-			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
-			return
-		}
-	} else {
-		tmpl = notification.DefaultWebhookPayloadTmpl
+// Replay is an http handler that queues again for delivery to the provided
+// webhook the events that happened within the time range given.
+func (h *Handlers) Replay(w http.ResponseWriter, r *http.Request) {
+	input := &hub.WebhookReplayInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Replay").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
 	}
-	var payload bytes.Buffer
-	if err := tmpl.Execute(&payload, webhookTestTemplateData); err != nil {
-		err = fmt.Errorf("error executing template: %w", err)
+	webhookID := chi.URLParam(r, "webhookID")
+	notificationsQueued, err := h.webhookManager.Replay(r.Context(), webhookID, input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Replay").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(map[string]int{"notifications_queued": notificationsQueued})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusAccepted)
+}
+
+// SendTestEvent is an http handler that delivers a test event to the provided
+// webhook, using a synthetic payload built with the webhook's template. The
+// status code received from the webhook endpoint and the latency of the
+// request are returned to the caller, so that they can be used to debug the
+// webhook. The response headers and body are not returned, to avoid exposing
+// the content of the endpoints the webhook may be pointed at.
+func (h *Handlers) SendTestEvent(w http.ResponseWriter, r *http.Request) {
+	// Get webhook
+	webhookID := chi.URLParam(r, "webhookID")
+	dataJSON, err := h.webhookManager.GetJSON(r.Context(), webhookID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "SendTestEvent").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	wh := &hub.Webhook{}
+	if err := json.Unmarshal(dataJSON, &wh); err != nil {
+		h.logger.Error().Err(err).Str("method", "SendTestEvent").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Deliver test event and capture the response received
+	eventKind := hub.NewRelease
+	if len(wh.EventKinds) > 0 {
+		eventKind = wh.EventKinds[0]
+	}
+	req, err := newTestRequest(wh, notification.SamplePkgNotificationTemplateData(
+		eventKind,
+		h.cfg.GetString("server.baseURL"),
+	))
+	if err != nil {
+		helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		return
+	}
+	start := time.Now()
+	resp, err := h.hc.Do(req)
+	if err != nil {
+		err = fmt.Errorf("error doing request: %w", err)
 		helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
 		return
 	}
+	resp.Body.Close()
+	result := &testDelivery{
+		StatusCode: resp.StatusCode,
+		LatencyMs:  time.Since(start).Milliseconds(),
+	}
+	resultJSON, _ := json.Marshal(result)
+	helpers.RenderJSON(w, resultJSON, 0, http.StatusOK)
+}
+
+// TriggerTest is an http handler used to test a webhook before adding or
+// updating it.
+func (h *Handlers) TriggerTest(w http.ResponseWriter, r *http.Request) {
+	// Read webhook from request body
+	wh := &hub.Webhook{}
+	if err := json.NewDecoder(r.Body).Decode(&wh); err != nil {
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
 
 	// Call webhook endpoint
-	req, _ := http.NewRequest("POST", wh.URL, &payload)
-	contentType := wh.ContentType
-	if contentType == "" {
-		contentType = notification.DefaultPayloadContentType
+	req, err := newTestRequest(wh, webhookTestTemplateData)
+	if err != nil {
+		helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		return
 	}
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("X-ArtifactHub-Secret", wh.Secret)
 	resp, err := h.hc.Do(req)
 	if err != nil {
 		err = fmt.Errorf("error doing request: %w", err)
@@ -188,30 +267,44 @@ func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 
 // webhookTestTemplateData represents the notification template data used by
 // TriggerTest handler.
-var webhookTestTemplateData = &hub.PackageNotificationTemplateData{
-	BaseURL: "https://baseURL",
-	Event: map[string]interface{}{
-		"ID":   "00000000-0000-0000-0000-000000000001",
-		"Kind": "package.new-release",
-	},
-	Package: map[string]interface{}{
-		"Name":    "sample-package",
-		"Version": "1.0.0",
-		"URL":     "https://artifacthub.io/packages/helm/artifacthub/sample-package/1.0.0",
-		"Changes": []*hub.Change{
-			{
-				Description: "Cool feature",
-			},
-			{
-				Description: "Bug fixed",
-			},
-		},
-		"ContainsSecurityUpdates": true,
-		"Prerelease":              true,
-		"Repository": map[string]interface{}{
-			"Kind":      "helm",
-			"Name":      "repo1",
-			"Publisher": "org1",
-		},
-	},
+var webhookTestTemplateData = notification.SamplePkgNotificationTemplateData(hub.NewRelease, "https://baseURL")
+
+// testDelivery represents the result of delivering a test event to a webhook
+// endpoint.
+type testDelivery struct {
+	StatusCode int   `json:"status_code"`
+	LatencyMs  int64 `json:"latency_ms"`
+}
+
+// newTestRequest prepares the request used to deliver a test event to the
+// webhook provided, building its payload from the template data given.
+func newTestRequest(wh *hub.Webhook, tmplData *hub.PackageNotificationTemplateData) (*http.Request, error) {
+	// Prepare payload
+	var tmpl *template.Template
+	if wh.Template != "" {
+		var err error
+		tmpl, err = sandbox.ParseTemplate(wh.Template)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing template: %w", err)
+		}
+	} else {
+		tmpl = notification.DefaultWebhookPayloadTmpl
+	}
+	payload, err := sandbox.ExecuteTemplate(tmpl, tmplData)
+	if err != nil {
+		return nil, fmt.Errorf("error executing template: %w", err)
+	}
+
+	// Prepare request
+	req, err := http.NewRequest("POST", wh.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error preparing request: %w", err)
+	}
+	contentType := wh.ContentType
+	if contentType == "" {
+		contentType = notification.DefaultPayloadContentType
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-ArtifactHub-Secret", wh.Secret)
+	return req, nil
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/artifacthub/hub/internal/webhook"
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -398,6 +399,279 @@ func TestGetOwnedByUser(t *testing.T) {
 	})
 }
 
+func TestReplay(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID"},
+			Values: []string{"000000001"},
+		},
+	}
+	inputJSON := `{"from": 1640995200, "to": 1641081600}`
+	input := &hub.WebhookReplayInput{From: 1640995200, To: 1641081600}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Replay(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("error replaying webhook events", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(inputJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("Replay", r.Context(), "000000001", input).Return(0, tc.err)
+				hw.h.Replay(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook events replay succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(inputJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("Replay", r.Context(), "000000001", input).Return(3, nil)
+		hw.h.Replay(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, []byte(`{"notifications_queued":3}`), data)
+		hw.wm.AssertExpectations(t)
+	})
+}
+
+func TestPreview(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			webhookJSON string
+		}{
+			{
+				"invalid json",
+				"-",
+			},
+			{
+				"invalid event kind",
+				`{"event_kinds": [2]}`,
+			},
+			{
+				"invalid template",
+				`{"template": "{{ .."}`,
+			},
+			{
+				"error executing template",
+				`{"template": "{{ .nonExistent }}"}`,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.webhookJSON))
+
+				hw := newHandlersWrapper()
+				hw.h.Preview(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				assert.True(t, strings.HasPrefix(getErrorMessage(t, data), hub.ErrInvalidInput.Error()))
+			})
+		}
+	})
+
+	t.Run("previews rendered successfully", func(t *testing.T) {
+		t.Parallel()
+		webhookJSON := `
+		{
+			"content_type": "custom/type",
+			"template": "Package {{ .Package.Name }} {{ .Package.Version}} ({{ .Event.Kind }})",
+			"event_kinds": [0, 1]
+		}
+		`
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/?locale=es", strings.NewReader(webhookJSON))
+
+		hw := newHandlersWrapper()
+		hw.h.Preview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var previews []*notification.Preview
+		require.NoError(t, json.Unmarshal(data, &previews))
+		require.Len(t, previews, 2)
+		assert.Equal(t, hub.NewRelease, previews[0].EventKind)
+		assert.Equal(t, "custom/type", previews[0].Webhook.ContentType)
+		assert.Equal(t, "Package sample-package 1.0.0 (package.new-release)", previews[0].Webhook.Payload)
+		assert.Contains(t, previews[0].Email.Subject, "sample-package")
+		assert.Contains(t, previews[0].Email.Body, "sample-package")
+		assert.Equal(t, hub.SecurityAlert, previews[1].EventKind)
+		assert.Equal(t, "Package sample-package 1.0.0 (package.security-alert)", previews[1].Webhook.Payload)
+		assert.NotEqual(t, previews[0].Email.Subject, previews[1].Email.Subject)
+	})
+}
+
+func TestSendTestEvent(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID"},
+			Values: []string{"000000001"},
+		},
+	}
+
+	t.Run("error getting webhook", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("GetJSON", r.Context(), "000000001").Return(nil, tc.err)
+				hw.h.SendTestEvent(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error executing template", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		webhookJSON := []byte(`{"url": "http://webhook1.url", "template": "{{ .nonExistent }}"}`)
+		hw.wm.On("GetJSON", r.Context(), "000000001").Return(webhookJSON, nil)
+		hw.h.SendTestEvent(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.True(t, strings.HasPrefix(getErrorMessage(t, data), "error executing template"))
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("test event delivered, status code and latency returned", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+			assert.Equal(t, "very", r.Header.Get("X-ArtifactHub-Secret"))
+			payload, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, []byte("package.security-alert: sample-package"), payload)
+			w.Header().Set("X-Custom", "value")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte("secret content"))
+		}))
+		defer ts.Close()
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		webhookJSON, _ := json.Marshal(&hub.Webhook{
+			URL:         ts.URL,
+			Secret:      "very",
+			ContentType: "text/plain",
+			Template:    "{{ .Event.Kind }}: {{ .Package.Name }}",
+			EventKinds:  []hub.EventKind{hub.SecurityAlert},
+		})
+		hw.wm.On("GetJSON", r.Context(), "000000001").Return(webhookJSON, nil)
+		hw.h.SendTestEvent(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &result))
+		assert.Equal(t, float64(http.StatusUnprocessableEntity), result["status_code"])
+		assert.Contains(t, result, "latency_ms")
+		assert.Len(t, result, 2)
+		assert.NotContains(t, string(data), "secret content")
+		hw.wm.AssertExpectations(t)
+	})
+}
+
 func TestTriggerTest(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
@@ -445,6 +719,14 @@ func TestTriggerTest(t *testing.T) {
 				}`,
 				"error parsing template",
 			},
+			{
+				`{
+					"name": "webhook1",
+					"url": "http://webhook1.url",
+					"template": "{{ call .Package.Name }}"
+				}`,
+				"error parsing template: template not allowed",
+			},
 			{
 				`{
 					"name": "webhook1",
@@ -722,7 +1004,7 @@ func newHandlersWrapper() *handlersWrapper {
 
 	return &handlersWrapper{
 		wm: wm,
-		h:  NewHandlers(wm, http.DefaultClient),
+		h:  NewHandlers(wm, viper.New(), http.DefaultClient),
 	}
 }
 
@@ -0,0 +1,202 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// Database queries
+	getSchemaVersionDBQ = `select version from version_schema`
+	pingDBQ             = `select 1`
+
+	// Checks names
+	databaseCheck   = "database"
+	migrationsCheck = "migrations"
+	workersCheck    = "workers"
+
+	// checkTimeout represents the maximum duration of the checks that depend
+	// on the database.
+	checkTimeout = 5 * time.Second
+)
+
+// migrationFileRE is a regexp used to extract the version and name of the
+// database schema migrations from their files names.
+var migrationFileRE = regexp.MustCompile(`^(\d+)_(.+)\.sql$`)
+
+// worker represents a background worker whose liveness is being tracked.
+type worker struct {
+	maxInterval time.Duration
+	lastBeat    time.Time
+}
+
+// Checker provides some methods to check the health of the hub and its
+// dependencies, like the database or the background workers.
+type Checker struct {
+	db         hub.DB
+	migrations []*hub.Migration
+
+	mu      sync.RWMutex
+	workers map[string]*worker
+}
+
+// NewChecker creates a new Checker instance. The database schema migrations
+// the hub expects to have been applied are read from the filesystem provided.
+func NewChecker(db hub.DB, migrationsFS fs.FS) (*Checker, error) {
+	migrations, err := readMigrations(migrationsFS)
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+	return &Checker{
+		db:         db,
+		migrations: migrations,
+		workers:    make(map[string]*worker),
+	}, nil
+}
+
+// RegisterWorker registers a background worker whose liveness will be taken
+// into account when checking the hub readiness. The function returned must be
+// called by the worker periodically, at least once every maxInterval.
+func (c *Checker) RegisterWorker(name string, maxInterval time.Duration) func() {
+	c.mu.Lock()
+	c.workers[name] = &worker{
+		maxInterval: maxInterval,
+		lastBeat:    time.Now(),
+	}
+	c.mu.Unlock()
+
+	return func() {
+		c.mu.Lock()
+		c.workers[name].lastBeat = time.Now()
+		c.mu.Unlock()
+	}
+}
+
+// CheckReadiness checks if the hub is ready to serve requests. For that, the
+// database must be reachable, all the expected schema migrations must have
+// been applied and the background workers must be alive.
+func (c *Checker) CheckReadiness(ctx context.Context) *hub.HealthStatus {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	checks := []*hub.HealthCheck{
+		newHealthCheck(databaseCheck, c.checkDatabase(ctx)),
+		newHealthCheck(migrationsCheck, c.checkMigrations(ctx)),
+		newHealthCheck(workersCheck, c.checkWorkers()),
+	}
+	status := &hub.HealthStatus{
+		Healthy: true,
+		Checks:  checks,
+	}
+	for _, check := range checks {
+		if !check.Healthy {
+			status.Healthy = false
+		}
+	}
+	return status
+}
+
+// GetMigrationsStatus returns the database schema migrations that have been
+// applied and the ones still pending.
+func (c *Checker) GetMigrationsStatus(ctx context.Context) (*hub.MigrationsStatus, error) {
+	var version int
+	if err := c.db.QueryRow(ctx, getSchemaVersionDBQ).Scan(&version); err != nil {
+		return nil, err
+	}
+	s := &hub.MigrationsStatus{
+		CurrentVersion: version,
+		Applied:        make([]*hub.Migration, 0, len(c.migrations)),
+		Pending:        make([]*hub.Migration, 0),
+	}
+	for _, m := range c.migrations {
+		if m.Version <= version {
+			s.Applied = append(s.Applied, m)
+		} else {
+			s.Pending = append(s.Pending, m)
+		}
+		s.ExpectedVersion = m.Version
+	}
+	return s, nil
+}
+
+// checkDatabase checks if the database is reachable.
+func (c *Checker) checkDatabase(ctx context.Context) error {
+	_, err := c.db.Exec(ctx, pingDBQ)
+	return err
+}
+
+// checkMigrations checks if all the expected database schema migrations have
+// been applied.
+func (c *Checker) checkMigrations(ctx context.Context) error {
+	s, err := c.GetMigrationsStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if len(s.Pending) > 0 {
+		return fmt.Errorf("schema version %d, expected %d", s.CurrentVersion, s.ExpectedVersion)
+	}
+	return nil
+}
+
+// checkWorkers checks if all the registered background workers are alive.
+func (c *Checker) checkWorkers() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var stalled []string
+	for name, w := range c.workers {
+		if time.Since(w.lastBeat) > w.maxInterval {
+			stalled = append(stalled, name)
+		}
+	}
+	if len(stalled) > 0 {
+		sort.Strings(stalled)
+		return fmt.Errorf("stalled workers: %v", stalled)
+	}
+	return nil
+}
+
+// newHealthCheck creates a new health check instance from the error provided.
+func newHealthCheck(name string, err error) *hub.HealthCheck {
+	check := &hub.HealthCheck{
+		Name:    name,
+		Healthy: err == nil,
+	}
+	if err != nil {
+		check.Error = err.Error()
+	}
+	return check
+}
+
+// readMigrations reads the database schema migrations available in the
+// filesystem provided, returning them sorted by version.
+func readMigrations(fsys fs.FS) ([]*hub.Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var migrations []*hub.Migration
+	for _, e := range entries {
+		matches := migrationFileRE.FindStringSubmatch(e.Name())
+		if e.IsDir() || matches == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(matches[1])
+		migrations = append(migrations, &hub.Migration{
+			Version: version,
+			Name:    matches[2],
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
@@ -0,0 +1,146 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var migrationsFS = fstest.MapFS{
+	"001_initial.sql":        {},
+	"003_packages_views.sql": {},
+	"002_users.sql":          {},
+	"schema.go":              {},
+}
+
+func TestNewChecker(t *testing.T) {
+	db := &tests.DBMock{}
+	c, err := NewChecker(db, migrationsFS)
+	require.NoError(t, err)
+	assert.Equal(t, []*hub.Migration{
+		{Version: 1, Name: "initial"},
+		{Version: 2, Name: "users"},
+		{Version: 3, Name: "packages_views"},
+	}, c.migrations)
+}
+
+func TestCheckReadiness(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all checks succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", mock.Anything, pingDBQ).Return(nil)
+		db.On("QueryRow", mock.Anything, getSchemaVersionDBQ).Return(3, nil)
+		c, _ := NewChecker(db, migrationsFS)
+		c.RegisterWorker("worker1", time.Minute)
+
+		status := c.CheckReadiness(ctx)
+		assert.Equal(t, &hub.HealthStatus{
+			Healthy: true,
+			Checks: []*hub.HealthCheck{
+				{Name: databaseCheck, Healthy: true},
+				{Name: migrationsCheck, Healthy: true},
+				{Name: workersCheck, Healthy: true},
+			},
+		}, status)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database not available", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", mock.Anything, pingDBQ).Return(tests.ErrFakeDB)
+		db.On("QueryRow", mock.Anything, getSchemaVersionDBQ).Return(nil, tests.ErrFakeDB)
+		c, _ := NewChecker(db, migrationsFS)
+
+		status := c.CheckReadiness(ctx)
+		assert.Equal(t, &hub.HealthStatus{
+			Healthy: false,
+			Checks: []*hub.HealthCheck{
+				{Name: databaseCheck, Healthy: false, Error: tests.ErrFakeDB.Error()},
+				{Name: migrationsCheck, Healthy: false, Error: tests.ErrFakeDB.Error()},
+				{Name: workersCheck, Healthy: true},
+			},
+		}, status)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("pending migrations and stalled workers", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", mock.Anything, pingDBQ).Return(nil)
+		db.On("QueryRow", mock.Anything, getSchemaVersionDBQ).Return(2, nil)
+		c, _ := NewChecker(db, migrationsFS)
+		c.RegisterWorker("worker1", time.Nanosecond)
+		c.RegisterWorker("worker2", time.Hour)
+		time.Sleep(time.Millisecond)
+
+		status := c.CheckReadiness(ctx)
+		assert.Equal(t, &hub.HealthStatus{
+			Healthy: false,
+			Checks: []*hub.HealthCheck{
+				{Name: databaseCheck, Healthy: true},
+				{Name: migrationsCheck, Healthy: false, Error: "schema version 2, expected 3"},
+				{Name: workersCheck, Healthy: false, Error: "stalled workers: [worker1]"},
+			},
+		}, status)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("worker alive again after beat", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		c, _ := NewChecker(db, migrationsFS)
+		beat := c.RegisterWorker("worker1", 50*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+		assert.Error(t, c.checkWorkers())
+		beat()
+		assert.NoError(t, c.checkWorkers())
+	})
+}
+
+func TestGetMigrationsStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSchemaVersionDBQ).Return(nil, tests.ErrFakeDB)
+		c, _ := NewChecker(db, migrationsFS)
+
+		status, err := c.GetMigrationsStatus(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, status)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("migrations status returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSchemaVersionDBQ).Return(1, nil)
+		c, _ := NewChecker(db, migrationsFS)
+
+		status, err := c.GetMigrationsStatus(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &hub.MigrationsStatus{
+			CurrentVersion:  1,
+			ExpectedVersion: 3,
+			Applied: []*hub.Migration{
+				{Version: 1, Name: "initial"},
+			},
+			Pending: []*hub.Migration{
+				{Version: 2, Name: "users"},
+				{Version: 3, Name: "packages_views"},
+			},
+		}, status)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,27 @@
+package health
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// CheckerMock is a mock implementation of the HealthChecker interface.
+type CheckerMock struct {
+	mock.Mock
+}
+
+// CheckReadiness implements the HealthChecker interface.
+func (m *CheckerMock) CheckReadiness(ctx context.Context) *hub.HealthStatus {
+	args := m.Called(ctx)
+	status, _ := args.Get(0).(*hub.HealthStatus)
+	return status
+}
+
+// GetMigrationsStatus implements the HealthChecker interface.
+func (m *CheckerMock) GetMigrationsStatus(ctx context.Context) (*hub.MigrationsStatus, error) {
+	args := m.Called(ctx)
+	status, _ := args.Get(0).(*hub.MigrationsStatus)
+	return status, args.Error(1)
+}
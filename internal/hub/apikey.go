@@ -9,21 +9,33 @@ type APIKey struct {
 	Secret    string `json:"secret"`
 	CreatedAt int64  `json:"created_at"`
 	UserID    string `json:"user_id"`
+
+	// AllowedCIDRs restricts the addresses the key can be used from. When no
+	// CIDR ranges are provided, the key can be used from any address.
+	AllowedCIDRs []string `json:"allowed_cidrs"`
+}
+
+// APIKeyUsageTracker describes the methods an APIKeyUsageTracker
+// implementation must provide.
+type APIKeyUsageTracker interface {
+	TrackUsage(apiKeyID string, statusCode int)
 }
 
 // APIKeyManager describes the methods an APIKeyManager implementation must
 // provide.
 type APIKeyManager interface {
 	Add(ctx context.Context, ak *APIKey) (*APIKey, error)
-	Check(ctx context.Context, apiKeyID, apiKeySecret string) (*CheckAPIKeyOutput, error)
+	Check(ctx context.Context, apiKeyID, apiKeySecret, ip string) (*CheckAPIKeyOutput, error)
 	Delete(ctx context.Context, apiKeyID string) error
 	GetJSON(ctx context.Context, apiKeyID string) ([]byte, error)
 	GetOwnedByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
+	GetUsageJSON(ctx context.Context, apiKeyID string) ([]byte, error)
 	Update(ctx context.Context, ak *APIKey) error
 }
 
 // CheckAPIKeyOutput represents the output returned by the CheckApiKey method.
 type CheckAPIKeyOutput struct {
-	Valid  bool   `json:"valid"`
-	UserID string `json:"user_id"`
+	Valid        bool   `json:"valid"`
+	UserID       string `json:"user_id"`
+	IPNotAllowed bool   `json:"ip_not_allowed"`
 }
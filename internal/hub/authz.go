@@ -58,10 +58,43 @@ type AuthorizationPolicy struct {
 	PolicyData           json.RawMessage `json:"policy_data"`
 }
 
+// AuthorizationPolicyTest represents a dry run of an authorization policy
+// against some sample inputs, used to check how it behaves before saving it.
+type AuthorizationPolicyTest struct {
+	Policy *AuthorizationPolicy            `json:"policy"`
+	Inputs []*AuthorizationPolicyTestInput `json:"inputs"`
+}
+
+// AuthorizationPolicyTestInput represents a sample input used to test an
+// authorization policy.
+type AuthorizationPolicyTestInput struct {
+	User   string `json:"user"`
+	Action Action `json:"action"`
+}
+
+// AuthorizationPolicyTestOutput represents the output of an authorization
+// policy test. Errors contains the errors found preparing the policy, if any,
+// in which case no inputs will be evaluated.
+type AuthorizationPolicyTestOutput struct {
+	Errors  []string                         `json:"errors"`
+	Results []*AuthorizationPolicyTestResult `json:"results"`
+}
+
+// AuthorizationPolicyTestResult represents the result of evaluating an
+// authorization policy for a given sample input.
+type AuthorizationPolicyTestResult struct {
+	User           string   `json:"user"`
+	Action         Action   `json:"action"`
+	Allowed        bool     `json:"allowed"`
+	AllowedActions []Action `json:"allowed_actions"`
+	Error          string   `json:"error,omitempty"`
+}
+
 // Authorizer describes the methods an Authorizer implementation must provide.
 type Authorizer interface {
 	Authorize(ctx context.Context, input *AuthorizeInput) error
 	GetAllowedActions(ctx context.Context, userID, orgName string) ([]Action, error)
+	TestPolicy(ctx context.Context, test *AuthorizationPolicyTest) (*AuthorizationPolicyTestOutput, error)
 	WillUserBeLockedOut(ctx context.Context, newPolicy *AuthorizationPolicy, userID string) (bool, error)
 }
 
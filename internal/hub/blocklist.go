@@ -0,0 +1,20 @@
+package hub
+
+import "context"
+
+// BlockedContent represents a repository or package that has been blocked by
+// the site admins, usually as a result of a takedown request.
+type BlockedContent struct {
+	BlockedContentID string `json:"blocked_content_id"`
+	RepositoryName   string `json:"repository_name"`
+	PackageName      string `json:"package_name,omitempty"`
+	Reason           string `json:"reason"`
+}
+
+// BlocklistManager describes the methods a BlocklistManager implementation
+// must provide.
+type BlocklistManager interface {
+	Block(ctx context.Context, bc *BlockedContent, info *AdminAuditInfo) (string, error)
+	GetJSON(ctx context.Context) ([]byte, error)
+	Unblock(ctx context.Context, blockedContentID string, info *AdminAuditInfo) error
+}
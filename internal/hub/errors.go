@@ -3,6 +3,14 @@ package hub
 import "errors"
 
 var (
+	// ErrConflict indicates that the resource being updated has been modified
+	// by someone else since it was read.
+	ErrConflict = errors.New("conflict")
+
+	// ErrBlocked indicates that the content requested has been blocked by
+	// the site admins.
+	ErrBlocked = errors.New("content blocked")
+
 	// ErrInvalidInput indicates that the input provided is not valid.
 	ErrInvalidInput = errors.New("invalid input")
 
@@ -12,6 +20,18 @@ var (
 
 	// ErrNotFound indicates that the requested item was not found.
 	ErrNotFound = errors.New("not found")
+
+	// ErrPendingApproval indicates that the operation requested has been
+	// registered, but it won't take effect until it's approved.
+	ErrPendingApproval = errors.New("pending approval")
+
+	// ErrTooManyRequests indicates that the operation has been requested too
+	// many times recently and it cannot be performed at the moment.
+	ErrTooManyRequests = errors.New("too many requests")
+
+	// ErrUnavailable indicates that the operation cannot be performed at the
+	// moment because a service it depends on is not available.
+	ErrUnavailable = errors.New("service unavailable")
 )
 
 // ErrorsCollector interface defines the methods that an errors collector
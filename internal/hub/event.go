@@ -37,6 +37,14 @@ const (
 	// RepositoryScanningErrors represents an event for errors that occur while
 	// a repository is being scanned.
 	RepositoryScanningErrors EventKind = 4
+
+	// ContentWarnings represents an event for warnings found in the content
+	// of a package by the static checks run while tracking it.
+	ContentWarnings EventKind = 5
+
+	// PackageVersionEOL represents an event for a package version that has
+	// reached its end of life.
+	PackageVersionEOL EventKind = 6
 )
 
 // EventManager describes the methods an EventManager implementation must
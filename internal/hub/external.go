@@ -11,6 +11,21 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
+// Cache defines the methods a Cache implementation must provide.
+type Cache interface {
+	// Get returns the value stored under the key provided. When the key is not
+	// available in the cache, hub.ErrNotFound is returned.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Invalidate removes from the cache all the entries associated with any of
+	// the tags provided.
+	Invalidate(ctx context.Context, tags ...string) error
+
+	// Set stores the value provided under the key given, associating it with
+	// the tags provided so that it can be invalidated later.
+	Set(ctx context.Context, key string, value []byte, tags ...string) error
+}
+
 // DB defines the methods the database handler must provide.
 type DB interface {
 	Acquire(ctx context.Context) (*pgxpool.Conn, error)
@@ -24,6 +39,20 @@ type EmailSender interface {
 	SendEmail(data *email.Data) error
 }
 
+// EmailWebhooksProcessor defines the methods the email webhooks processor must
+// provide.
+type EmailWebhooksProcessor interface {
+	ProcessWebhook(ctx context.Context, provider string, r *http.Request) error
+}
+
+// GitHubAppWebhooksProcessor defines the methods the GitHub App webhooks
+// processor must provide.
+type GitHubAppWebhooksProcessor interface {
+	GetInstallURL(ctx context.Context, orgName string) (string, error)
+	LinkInstallation(ctx context.Context, installationID int64, state string) error
+	ProcessWebhook(ctx context.Context, r *http.Request) error
+}
+
 // HTTPClient defines the methods an HTTPClient implementation must provide.
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -53,11 +82,26 @@ type OCIPuller interface {
 	) (ocispec.Descriptor, []byte, error)
 }
 
-// SignatureChecker is the interface that wraps the HasCosignSignature method,
-// used to check if the OCI artifact identified by the reference provided has a
-// cosign (sigstore) signature.
+// OCIAnnotationsGetter is the interface that wraps the GetAnnotations method,
+// used to get the annotations in the manifest of the OCI artifact identified
+// by the reference provided.
+type OCIAnnotationsGetter interface {
+	GetAnnotations(ctx context.Context, ref, username, password string) (map[string]string, error)
+}
+
+// OCIProvenanceGetter is the interface that wraps the GetSLSAProvenance
+// method, used to get the build provenance from the SLSA provenance
+// attestation attached to the OCI artifact identified by the reference
+// provided.
+type OCIProvenanceGetter interface {
+	GetSLSAProvenance(ctx context.Context, ref, username, password string) (*Provenance, error)
+}
+
+// OCISignatureChecker defines the methods an OCISignatureChecker
+// implementation must provide.
 type OCISignatureChecker interface {
 	HasCosignSignature(ctx context.Context, ref, username, password string) (bool, error)
+	VerifyCosignSignature(ctx context.Context, ref, username, password string, cfg *CosignConfig) (bool, error)
 }
 
 // OCITagsGetter is the interface that wraps the Tags method, used to get all
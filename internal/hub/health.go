@@ -0,0 +1,37 @@
+package hub
+
+import "context"
+
+// HealthChecker describes the methods a HealthChecker implementation must
+// provide.
+type HealthChecker interface {
+	CheckReadiness(ctx context.Context) *HealthStatus
+	GetMigrationsStatus(ctx context.Context) (*MigrationsStatus, error)
+}
+
+// HealthCheck represents the result of checking one of the hub dependencies.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// HealthStatus represents the result of checking all the hub dependencies.
+type HealthStatus struct {
+	Healthy bool           `json:"healthy"`
+	Checks  []*HealthCheck `json:"checks"`
+}
+
+// Migration represents a database schema migration.
+type Migration struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+}
+
+// MigrationsStatus represents the status of the database schema migrations.
+type MigrationsStatus struct {
+	CurrentVersion  int          `json:"current_version"`
+	ExpectedVersion int          `json:"expected_version"`
+	Applied         []*Migration `json:"applied"`
+	Pending         []*Migration `json:"pending"`
+}
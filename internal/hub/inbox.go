@@ -0,0 +1,12 @@
+package hub
+
+import "context"
+
+// InboxManager describes the methods an InboxManager implementation must
+// provide.
+type InboxManager interface {
+	GetJSON(ctx context.Context, unreadOnly bool, p *Pagination) (*JSONQueryResult, error)
+	GetUnreadCountJSON(ctx context.Context) ([]byte, error)
+	MarkAllAsRead(ctx context.Context) error
+	MarkAsRead(ctx context.Context, inboxNotificationID string) error
+}
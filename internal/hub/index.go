@@ -10,3 +10,9 @@ type indexMetaDescriptionKey struct{}
 // IndexMetaDescriptionKey represents the key used for the description in the
 // index metadata.
 var IndexMetaDescriptionKey = indexMetaDescriptionKey{}
+
+type indexMetaOpenGraphImageKey struct{}
+
+// IndexMetaOpenGraphImageKey represents the key used for the Open Graph image
+// in the index metadata.
+var IndexMetaOpenGraphImageKey = indexMetaOpenGraphImageKey{}
@@ -0,0 +1,52 @@
+package hub
+
+import "context"
+
+const (
+	// GitHubIssueTracker represents an issue tracker integration that opens
+	// issues in a GitHub repository.
+	GitHubIssueTracker = "github"
+
+	// JiraIssueTracker represents an issue tracker integration that opens
+	// tickets in a Jira project.
+	JiraIssueTracker = "jira"
+)
+
+// IssueTracker represents the configuration of an organization's integration
+// with an issue tracker, used to open issues automatically when new critical
+// vulnerabilities are found in the organization's packages. The project is
+// the repository (owner/repo) for GitHub and the project key for Jira.
+type IssueTracker struct {
+	IssueTrackerID string   `json:"issue_tracker_id"`
+	Kind           string   `json:"kind"`
+	URL            string   `json:"url,omitempty"`
+	Project        string   `json:"project"`
+	Labels         []string `json:"labels"`
+	Username       string   `json:"username,omitempty"`
+	Token          string   `json:"token,omitempty"`
+	Active         bool     `json:"active"`
+}
+
+// IssueTrackerManager describes the methods an IssueTrackerManager
+// implementation must provide.
+type IssueTrackerManager interface {
+	Add(ctx context.Context, orgName string, it *IssueTracker) error
+	Delete(ctx context.Context, issueTrackerID string) error
+	GetOwnedByOrgJSON(ctx context.Context, orgName string) ([]byte, error)
+	GetPendingVulnerabilities(ctx context.Context, issueTrackerID string, e *Event) ([]*IssueTrackerVulnerability, error)
+	GetSubscribedTo(ctx context.Context, e *Event) ([]*IssueTracker, error)
+	RegisterIssue(ctx context.Context, issueTrackerID, packageID, vulnerabilityID, issueURL string) error
+	Update(ctx context.Context, it *IssueTracker) error
+}
+
+// IssueTrackerVulnerability represents a critical vulnerability found in a
+// package version for which an issue should be opened.
+type IssueTrackerVulnerability struct {
+	VulnerabilityID  string   `json:"vulnerability_id"`
+	PkgName          string   `json:"pkg_name"`
+	InstalledVersion string   `json:"installed_version"`
+	FixedVersion     string   `json:"fixed_version"`
+	Title            string   `json:"title"`
+	PrimaryURL       string   `json:"primary_url"`
+	Images           []string `json:"images"`
+}
@@ -0,0 +1,32 @@
+package hub
+
+import (
+	"context"
+	"time"
+)
+
+// JobRun represents a run of a background job.
+type JobRun struct {
+	JobRunID  string `json:"job_run_id"`
+	JobName   string `json:"job_name"`
+	StartedAt int64  `json:"started_at"`
+	Duration  int64  `json:"duration"`
+	Attempts  int    `json:"attempts"`
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error,omitempty"`
+}
+
+// GetJobRunsInput represents the input used to get the background jobs runs.
+type GetJobRunsInput struct {
+	JobName    string `json:"job_name,omitempty"`
+	OnlyFailed bool   `json:"only_failed"`
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+}
+
+// JobManager describes the methods a JobManager implementation must provide.
+type JobManager interface {
+	AcquireLease(ctx context.Context, jobName, holder string, frequency time.Duration) (bool, error)
+	GetRunsJSON(ctx context.Context, input *GetJobRunsInput) (*JSONQueryResult, error)
+	RegisterRun(ctx context.Context, run *JobRun) error
+}
@@ -0,0 +1,11 @@
+package hub
+
+import "context"
+
+// MaintainerManager describes the methods a MaintainerManager implementation
+// must provide.
+type MaintainerManager interface {
+	Contact(ctx context.Context, maintainerID, message string) error
+	RegisterVerificationCode(ctx context.Context, maintainerID string) error
+	Verify(ctx context.Context, code string) (bool, error)
+}
@@ -0,0 +1,18 @@
+package hub
+
+import "context"
+
+// MaintenanceMode represents the maintenance mode status. While it is enabled
+// the API is read-only and the tracker and scanner pause writes, so that the
+// database maintenance tasks can be done safely.
+type MaintenanceMode struct {
+	Enabled    bool `json:"enabled"`
+	RetryAfter int  `json:"retry_after"`
+}
+
+// MaintenanceManager describes the methods a MaintenanceManager
+// implementation must provide.
+type MaintenanceManager interface {
+	Get(ctx context.Context) (*MaintenanceMode, error)
+	Set(ctx context.Context, mm *MaintenanceMode) error
+}
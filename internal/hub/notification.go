@@ -6,19 +6,37 @@ import (
 	"github.com/jackc/pgx/v4"
 )
 
+// NotificationChannel represents a channel used to deliver notifications.
+type NotificationChannel string
+
+const (
+	// EmailChannel represents the channel used to deliver notifications to
+	// users via email.
+	EmailChannel NotificationChannel = "email"
+
+	// IssueTrackerChannel represents the channel used to deliver
+	// notifications to issue trackers.
+	IssueTrackerChannel NotificationChannel = "issue-tracker"
+
+	// WebhookChannel represents the channel used to deliver notifications to
+	// webhooks.
+	WebhookChannel NotificationChannel = "webhook"
+)
+
 // Notification represents the details of a notification pending to be delivered.
 type Notification struct {
-	NotificationID string   `json:"notification_id"`
-	Event          *Event   `json:"event"`
-	User           *User    `json:"user"`
-	Webhook        *Webhook `json:"webhook"`
+	NotificationID string        `json:"notification_id"`
+	Event          *Event        `json:"event"`
+	User           *User         `json:"user"`
+	Webhook        *Webhook      `json:"webhook"`
+	IssueTracker   *IssueTracker `json:"issue_tracker"`
 }
 
 // NotificationManager describes the methods an NotificationManager
 // implementation must provide.
 type NotificationManager interface {
 	Add(ctx context.Context, tx pgx.Tx, n *Notification) error
-	GetPending(ctx context.Context, tx pgx.Tx) (*Notification, error)
+	GetPending(ctx context.Context, tx pgx.Tx, channel NotificationChannel) (*Notification, error)
 	UpdateStatus(
 		ctx context.Context,
 		tx pgx.Tx,
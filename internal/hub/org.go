@@ -13,21 +13,85 @@ type Organization struct {
 	Description    string `json:"description"`
 	HomeURL        string `json:"home_url"`
 	LogoImageID    string `json:"logo_image_id"`
+
+	// RepositoryChangesApproval indicates whether changes in the organization
+	// repositories must be approved by another member before taking effect.
+	// When not provided, the current value is kept.
+	RepositoryChangesApproval *bool `json:"repository_changes_approval,omitempty"`
+
+	// PublicProfile indicates whether the organization's public profile page
+	// is enabled. When not provided, the current value is kept.
+	PublicProfile *bool `json:"public_profile,omitempty"`
+}
+
+// OrganizationJoinMode represents the way users whose verified email belongs to
+// an organization's verified domain can join it.
+type OrganizationJoinMode string
+
+const (
+	// DisabledJoinMode represents the join mode used when users cannot join
+	// the organization on their own.
+	DisabledJoinMode OrganizationJoinMode = "disabled"
+
+	// AutoJoinMode represents the join mode used when users join the
+	// organization directly.
+	AutoJoinMode OrganizationJoinMode = "auto"
+
+	// RequestJoinMode represents the join mode used when users can request to
+	// join the organization, which must be approved by one of its members.
+	RequestJoinMode OrganizationJoinMode = "request"
+)
+
+// OrganizationDomain represents a domain owned by an organization. Domains must
+// be verified using a DNS TXT record before they can be used to join.
+type OrganizationDomain struct {
+	Domain            string               `json:"domain"`
+	VerificationToken string               `json:"verification_token"`
+	Verified          bool                 `json:"verified"`
+	JoinMode          OrganizationJoinMode `json:"join_mode"`
 }
 
 // OrganizationManager describes the methods an OrganizationManager
 // implementation must provide.
 type OrganizationManager interface {
 	Add(ctx context.Context, org *Organization) error
+	AddDomain(ctx context.Context, orgName, domain string) error
 	AddMember(ctx context.Context, orgName, userAlias string) error
+	ApproveJoinRequest(ctx context.Context, orgName, userAlias string) error
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
 	ConfirmMembership(ctx context.Context, orgName string) error
 	Delete(ctx context.Context, orgName string) error
+	DeleteDomain(ctx context.Context, orgName, domain string) error
+	DeleteJoinRequest(ctx context.Context, orgName, userAlias string) error
 	DeleteMember(ctx context.Context, orgName, userAlias string) error
 	GetJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
+	GetAuthorizationDecisionsJSON(
+		ctx context.Context,
+		orgName string,
+		deniedOnly bool,
+		p *Pagination,
+	) (*JSONQueryResult, error)
 	GetAuthorizationPolicyJSON(ctx context.Context, orgName string) ([]byte, error)
+	GetDomainsJSON(ctx context.Context, orgName string) ([]byte, error)
+	GetJoinRequestsJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetMembersJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
+	GetPublicProfileJSON(ctx context.Context, orgName string) ([]byte, error)
+	GetSecurityOverviewJSON(ctx context.Context, orgName string) ([]byte, error)
+	Join(ctx context.Context, orgName string) (OrganizationJoinMode, error)
+	TestAuthorizationPolicy(
+		ctx context.Context,
+		orgName string,
+		test *AuthorizationPolicyTest,
+	) (*AuthorizationPolicyTestOutput, error)
 	Update(ctx context.Context, orgName string, org *Organization) error
 	UpdateAuthorizationPolicy(ctx context.Context, orgName string, policy *AuthorizationPolicy) error
+	UpdateDomain(ctx context.Context, orgName, domain string, joinMode OrganizationJoinMode) error
+	VerifyDomain(ctx context.Context, orgName, domain string) error
+}
+
+// TXTResolver describes the methods a TXTResolver implementation must provide.
+// It's used to look up the DNS TXT records of a domain.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
 }
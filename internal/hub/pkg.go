@@ -8,9 +8,77 @@ import (
 )
 
 const (
+	// ContentWarningMaliciousPattern represents the kind of the content
+	// warnings raised when a known malicious pattern is found in a package
+	// (i.e. crypto miners or reverse shells).
+	ContentWarningMaliciousPattern = "malicious-pattern"
+
+	// ContentWarningSecret represents the kind of the content warnings raised
+	// when a secret (i.e. a private key or an access token) is found embedded
+	// in a package.
+	ContentWarningSecret = "secret"
+
+	// ContentWarningSuspiciousScript represents the kind of the content
+	// warnings raised when a suspicious script is found in a package's hooks.
+	ContentWarningSuspiciousScript = "suspicious-script"
+
+	// LicenseFamilyNetworkCopyleft represents the family of copyleft licenses
+	// whose obligations are also triggered by network use (i.e. AGPL).
+	LicenseFamilyNetworkCopyleft = "network-copyleft"
+
+	// LicenseFamilyPermissive represents the family of permissive licenses.
+	LicenseFamilyPermissive = "permissive"
+
+	// LicenseFamilyStrongCopyleft represents the family of strong copyleft
+	// licenses (i.e. GPL).
+	LicenseFamilyStrongCopyleft = "strong-copyleft"
+
+	// LicenseFamilyUnknown represents the family assigned to the licenses
+	// that could not be classified.
+	LicenseFamilyUnknown = "unknown"
+
+	// LicenseFamilyWeakCopyleft represents the family of weak copyleft
+	// licenses (i.e. LGPL or MPL).
+	LicenseFamilyWeakCopyleft = "weak-copyleft"
+
 	// PackageMetadataFile represents the name of the file where the Artifact
 	// Hub metadata for a given package is stored.
 	PackageMetadataFile = "artifacthub-pkg"
+
+	// SBOMFormatCycloneDX represents the CycloneDX SBOM format.
+	SBOMFormatCycloneDX = "cyclonedx"
+
+	// SBOMFormatSPDX represents the SPDX SBOM format.
+	SBOMFormatSPDX = "spdx"
+
+	// VulnerabilityStatementAcknowledged represents the status used by
+	// publishers to acknowledge a vulnerability affecting a package.
+	VulnerabilityStatementAcknowledged = "acknowledged"
+
+	// VulnerabilityStatementNotAffected represents the status used by
+	// publishers to indicate that a package is not affected by a vulnerability.
+	VulnerabilityStatementNotAffected = "not_affected"
+)
+
+var (
+	// LicenseFamilies represents the list of license families supported.
+	LicenseFamilies = []string{
+		LicenseFamilyNetworkCopyleft,
+		LicenseFamilyPermissive,
+		LicenseFamilyStrongCopyleft,
+		LicenseFamilyUnknown,
+		LicenseFamilyWeakCopyleft,
+	}
+
+	// SBOMFormats represents the list of SBOM formats supported.
+	SBOMFormats = []string{SBOMFormatCycloneDX, SBOMFormatSPDX}
+
+	// VulnerabilityStatementStatuses represents the list of statuses that can
+	// be used in a vulnerability statement.
+	VulnerabilityStatementStatuses = []string{
+		VulnerabilityStatementAcknowledged,
+		VulnerabilityStatementNotAffected,
+	}
 )
 
 // Change represents a change introduced in a package version.
@@ -29,12 +97,52 @@ type Channel struct {
 	Version string `json:"version"`
 }
 
+// CRDField represents a field of a custom resource, along with the
+// documentation available for it in the CRD's OpenAPI schema.
+type CRDField struct {
+	Path        string   `json:"path"`
+	Type        string   `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// CRDSchema represents a custom resource definition provided by a package,
+// including the OpenAPI schema of each of its versions.
+type CRDSchema struct {
+	Name     string              `json:"name"`
+	Group    string              `json:"group"`
+	Kind     string              `json:"kind"`
+	Scope    string              `json:"scope,omitempty"`
+	Versions []*CRDSchemaVersion `json:"versions"`
+}
+
+// CRDSchemaVersion represents a version of a custom resource definition.
+type CRDSchemaVersion struct {
+	Name    string          `json:"name"`
+	Served  bool            `json:"served"`
+	Storage bool            `json:"storage"`
+	Schema  json.RawMessage `json:"schema,omitempty"`
+	Fields  []*CRDField     `json:"fields,omitempty"`
+}
+
+// GitOpsManifestsInput represents the input used to generate the manifests
+// needed to deploy a package using a GitOps tool.
+type GitOpsManifestsInput struct {
+	Tool        string                 `json:"tool"`
+	ReleaseName string                 `json:"release_name"`
+	Namespace   string                 `json:"namespace"`
+	Values      map[string]interface{} `json:"values"`
+}
+
 // GetPackageInput represents the input used to get a specific package.
 type GetPackageInput struct {
 	PackageID      string `json:"package_id"`
 	RepositoryName string `json:"repository_name"`
 	PackageName    string `json:"package_name"`
 	Version        string `json:"version"`
+	Locale         string `json:"locale,omitempty"`
 }
 
 // Link represents a url associated with a package.
@@ -43,6 +151,16 @@ type Link struct {
 	URL  string `json:"url" yaml:"url"`
 }
 
+// ContentWarning represents a potential issue found in the content of a
+// package by the static checks run while it's being tracked.
+type ContentWarning struct {
+	Kind        string `json:"kind"`
+	Rule        string `json:"rule"`
+	File        string `json:"file"`
+	Line        int    `json:"line,omitempty"`
+	Description string `json:"description"`
+}
+
 // ContainerImage represents a container image associated with a package.
 type ContainerImage struct {
 	Name        string `json:"name" yaml:"name"`
@@ -50,6 +168,31 @@ type ContainerImage struct {
 	Whitelisted bool   `json:"whitelisted" yaml:"whitelisted"`
 }
 
+// ImageLicense represents a license found in the packages bundled in a
+// container image, along with the packages using it.
+type ImageLicense struct {
+	License  string   `json:"license"`
+	Packages []string `json:"packages"`
+}
+
+// ImageScan represents the cached results of scanning a container image,
+// identified by its digest. As many packages usually share the same images,
+// caching the results per digest allows reusing them across packages.
+type ImageScan struct {
+	Digest    string                     `json:"digest"`
+	Report    json.RawMessage            `json:"report"`
+	SBOMs     map[string]json.RawMessage `json:"sboms,omitempty"`
+	CreatedAt int64                      `json:"created_at,omitempty"`
+}
+
+// K8sVersionsRange represents a range of Kubernetes minor versions (i.e.
+// 1.29) a package version is compatible with. When the range has no upper
+// bound Max is empty.
+type K8sVersionsRange struct {
+	Min string `json:"min"`
+	Max string `json:"max,omitempty"`
+}
+
 // Maintainer represents a package's maintainer.
 type Maintainer struct {
 	MaintainerID string `json:"maintainer_id"`
@@ -64,6 +207,7 @@ type Package struct {
 	NormalizedName                 string                 `json:"normalized_name"`
 	LogoURL                        string                 `json:"logo_url"`
 	LogoImageID                    string                 `json:"logo_image_id"`
+	LogoDarkImageID                string                 `json:"logo_dark_image_id"`
 	IsOperator                     bool                   `json:"is_operator"`
 	Official                       bool                   `json:"official"`
 	Channels                       []*Channel             `json:"channels"`
@@ -78,6 +222,8 @@ type Package struct {
 	Capabilities                   string                 `json:"capabilities"`
 	CRDs                           []interface{}          `json:"crds"`
 	CRDsExamples                   []interface{}          `json:"crds_examples"`
+	HasCRDsSchemas                 bool                   `json:"has_crds_schemas"`
+	CRDsSchemas                    []*CRDSchema           `json:"crds_schemas,omitempty"`
 	SecurityReportSummary          *SecurityReportSummary `json:"security_report_summary"`
 	SecurityReportCreatedAt        int64                  `json:"security_report_created_at,omitempty"`
 	Data                           map[string]interface{} `json:"data"`
@@ -86,9 +232,11 @@ type Package struct {
 	AppVersion                     string                 `json:"app_version"`
 	Digest                         string                 `json:"digest"`
 	Deprecated                     bool                   `json:"deprecated"`
+	ReplacedBy                     string                 `json:"replaced_by,omitempty"`
 	License                        string                 `json:"license"`
 	Signed                         bool                   `json:"signed"`
 	Signatures                     []string               `json:"signatures"`
+	SignatureVerified              bool                   `json:"signature_verified"`
 	ContentURL                     string                 `json:"content_url"`
 	ContainersImages               []*ContainerImage      `json:"containers_images"`
 	AllContainersImagesWhitelisted bool                   `json:"all_containers_images_whitelisted"`
@@ -97,16 +245,26 @@ type Package struct {
 	ValuesSchema                   json.RawMessage        `json:"values_schema,omitempty"`
 	HasChangelog                   bool                   `json:"has_changelog"`
 	Changes                        []*Change              `json:"changes"`
+	ReleaseNotes                   *ReleaseNotes          `json:"release_notes,omitempty"`
 	ContainsSecurityUpdates        bool                   `json:"contains_security_updates"`
 	Prerelease                     bool                   `json:"prerelease"`
 	Maintainers                    []*Maintainer          `json:"maintainers"`
 	Recommendations                []*Recommendation      `json:"recommendations"`
 	Screenshots                    []*Screenshot          `json:"screenshots"`
+	Videos                         []*Video               `json:"videos"`
 	SignKey                        *SignKey               `json:"sign_key"`
+	Provenance                     *Provenance            `json:"provenance,omitempty"`
+	ContentWarnings                []*ContentWarning      `json:"content_warnings,omitempty"`
+	Support                        *VersionSupport        `json:"support,omitempty"`
+	K8sCompatibility               []*K8sVersionsRange    `json:"k8s_compatibility,omitempty"`
+	Translations                   PackageTranslations    `json:"translations,omitempty"`
+	Locale                         string                 `json:"locale,omitempty"`
+	AvailableLocales               []string               `json:"available_locales,omitempty"`
 	Repository                     *Repository            `json:"repository"`
 	TS                             int64                  `json:"ts,omitempty"`
 	Stats                          *PackageStats          `json:"stats"`
 	ProductionOrganizations        []*Organization        `json:"production_organizations"`
+	InstallSnippets                map[string]string      `json:"install_snippets,omitempty"`
 }
 
 // PackageManager describes the methods a PackageManager implementation must
@@ -116,24 +274,39 @@ type PackageManager interface {
 	DeleteProductionUsage(ctx context.Context, repoName, pkgName, orgName string) error
 	Get(ctx context.Context, input *GetPackageInput) (*Package, error)
 	GetChangelog(ctx context.Context, pkgID string) (*Changelog, error)
+	GetCRDsSchemas(ctx context.Context, pkgID, version string) ([]*CRDSchema, error)
+	GetDownloadsJSON(ctx context.Context, packageID string) ([]byte, error)
 	GetHarborReplicationDumpJSON(ctx context.Context) ([]byte, error)
 	GetHelmExporterDumpJSON(ctx context.Context) ([]byte, error)
+	GetImageScan(ctx context.Context, digest string) (*ImageScan, error)
 	GetJSON(ctx context.Context, input *GetPackageInput) ([]byte, error)
 	GetProductionUsageJSON(ctx context.Context, repoName, pkgName string) ([]byte, error)
 	GetRandomJSON(ctx context.Context) ([]byte, error)
+	GetRankingJSON(ctx context.Context, ranking string) ([]byte, error)
+	GetSnapshotBundleData(ctx context.Context, pkgID, version string, checkOwnership bool) (*SnapshotBundleData, error)
+	GetSnapshotContentWarningsJSON(ctx context.Context, pkgID, version string) ([]byte, error)
+	GetSnapshotImages(ctx context.Context, pkgID, version string) ([]*SnapshotImage, error)
+	GetSnapshotLicenseInventoryJSON(ctx context.Context, pkgID, version string, problematicOnly bool) ([]byte, error)
+	GetSnapshotSBOMJSON(ctx context.Context, pkgID, version, format string) ([]byte, error)
 	GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error)
+	GetSnapshotSecurityReportSuppressedJSON(ctx context.Context, pkgID, version string) ([]byte, error)
 	GetSnapshotsToScan(ctx context.Context) ([]*SnapshotToScan, error)
 	GetStarredByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetStarsJSON(ctx context.Context, packageID string) ([]byte, error)
 	GetStatsJSON(ctx context.Context) ([]byte, error)
 	GetSummaryJSON(ctx context.Context, input *GetPackageInput) ([]byte, error)
+	GetUpgradeGraph(ctx context.Context, pkgID string) (*UpgradeGraph, error)
 	GetValuesSchemaJSON(ctx context.Context, pkgID, version string) ([]byte, error)
 	GetViewsJSON(ctx context.Context, packageID string) ([]byte, error)
+	GetVulnerabilityStatementsJSON(ctx context.Context, pkgID, version string) ([]byte, error)
 	Register(ctx context.Context, pkg *Package) error
+	RegisterImageScan(ctx context.Context, s *ImageScan) error
+	RequestSnapshotScan(ctx context.Context, pkgID, version string) error
 	SearchJSON(ctx context.Context, input *SearchPackageInput) (*JSONQueryResult, error)
 	SearchMonocularJSON(ctx context.Context, baseURL, tsQueryWeb string) ([]byte, error)
 	ToggleStar(ctx context.Context, packageID string) error
 	UpdateSnapshotSecurityReport(ctx context.Context, r *SnapshotSecurityReport) error
+	UpdateVulnerabilityStatements(ctx context.Context, pkgID, version string, statements []*VulnerabilityStatement) error
 	Unregister(ctx context.Context, pkg *Package) error
 }
 
@@ -141,36 +314,64 @@ type PackageManager interface {
 // provided by repositories publishers, to provide the required information
 // about the content they'd like to be indexed.
 type PackageMetadata struct {
-	Version                 string            `yaml:"version"`
-	Name                    string            `yaml:"name"`
-	DisplayName             string            `yaml:"displayName"`
-	CreatedAt               string            `yaml:"createdAt"`
-	Description             string            `yaml:"description"`
-	LogoPath                string            `yaml:"logoPath"`
-	LogoURL                 string            `yaml:"logoURL"`
-	Digest                  string            `yaml:"digest"`
-	License                 string            `yaml:"license"`
-	HomeURL                 string            `yaml:"homeURL"`
-	AppVersion              string            `yaml:"appVersion"`
-	PublisherID             string            `yaml:"publisherID"`
-	ContainersImages        []*ContainerImage `yaml:"containersImages"`
-	Operator                bool              `yaml:"operator"`
-	Deprecated              bool              `yaml:"deprecated"`
-	Keywords                []string          `yaml:"keywords"`
-	Links                   []*Link           `yaml:"links"`
-	Readme                  string            `yaml:"readme"`
-	Install                 string            `yaml:"install"`
-	Changes                 []*Change         `yaml:"changes"`
-	ContainsSecurityUpdates bool              `yaml:"containsSecurityUpdates"`
-	Prerelease              bool              `yaml:"prerelease"`
-	Maintainers             []*Maintainer     `yaml:"maintainers"`
-	Provider                *Provider         `yaml:"provider"`
-	Ignore                  []string          `yaml:"ignore"`
-	Recommendations         []*Recommendation `yaml:"recommendations"`
-	Screenshots             []*Screenshot     `yaml:"screenshots"`
-	Annotations             map[string]string `yaml:"annotations"`
+	Version                 string              `yaml:"version"`
+	Name                    string              `yaml:"name"`
+	DisplayName             string              `yaml:"displayName"`
+	CreatedAt               string              `yaml:"createdAt"`
+	Description             string              `yaml:"description"`
+	LogoPath                string              `yaml:"logoPath"`
+	LogoURL                 string              `yaml:"logoURL"`
+	LogoDarkPath            string              `yaml:"logoDarkPath"`
+	LogoDarkURL             string              `yaml:"logoDarkURL"`
+	Digest                  string              `yaml:"digest"`
+	License                 string              `yaml:"license"`
+	HomeURL                 string              `yaml:"homeURL"`
+	AppVersion              string              `yaml:"appVersion"`
+	PublisherID             string              `yaml:"publisherID"`
+	ContainersImages        []*ContainerImage   `yaml:"containersImages"`
+	Operator                bool                `yaml:"operator"`
+	Deprecated              bool                `yaml:"deprecated"`
+	ReplacedBy              string              `yaml:"replacedBy"`
+	Keywords                []string            `yaml:"keywords"`
+	Links                   []*Link             `yaml:"links"`
+	Readme                  string              `yaml:"readme"`
+	Install                 string              `yaml:"install"`
+	Changes                 []*Change           `yaml:"changes"`
+	ContainsSecurityUpdates bool                `yaml:"containsSecurityUpdates"`
+	Prerelease              bool                `yaml:"prerelease"`
+	Maintainers             []*Maintainer       `yaml:"maintainers"`
+	Provider                *Provider           `yaml:"provider"`
+	Ignore                  []string            `yaml:"ignore"`
+	Recommendations         []*Recommendation   `yaml:"recommendations"`
+	Screenshots             []*Screenshot       `yaml:"screenshots"`
+	Videos                  []*Video            `yaml:"videos"`
+	Support                 *VersionSupport     `yaml:"support"`
+	KubeVersion             string              `yaml:"kubeVersion"`
+	K8sVersions             []string            `yaml:"k8sVersions"`
+	Translations            PackageTranslations `yaml:"translations"`
+	Annotations             map[string]string   `yaml:"annotations"`
+}
+
+// PackageDownloads represents the number of times a package version was
+// downloaded during a given day, as reported by the repository publisher.
+type PackageDownloads struct {
+	PackageName string `json:"package_name"`
+	Version     string `json:"version"`
+	Day         string `json:"day"`
+	Total       int    `json:"total"`
+}
+
+// PackageTranslation represents the translation of some of the package
+// version's metadata to a given locale.
+type PackageTranslation struct {
+	Description string `json:"description,omitempty" yaml:"description"`
+	Readme      string `json:"readme,omitempty" yaml:"readme"`
 }
 
+// PackageTranslations represents the translations of some of the package
+// version's metadata, keyed by locale (i.e. zh, pt-br).
+type PackageTranslations map[string]*PackageTranslation
+
 // PackageStats represents some statistics about a package.
 type PackageStats struct {
 	Subscriptions int `json:"subscriptions"`
@@ -187,20 +388,84 @@ type Recommendation struct {
 	URL string `json:"url" yaml:"url"`
 }
 
-// Screenshot represents a screenshot associated with a package.
+// ReleaseNotes represents the release notes of a package version, obtained
+// from an external source like the GitHub releases of the package's source
+// repository. They can be used as an alternative changelog source.
+type ReleaseNotes struct {
+	Source      string `json:"source"`
+	URL         string `json:"url"`
+	TagName     string `json:"tag_name"`
+	Body        string `json:"body"`
+	PublishedAt int64  `json:"published_at,omitempty"`
+}
+
+// ReleaseNotesFetcher describes the methods a ReleaseNotesFetcher
+// implementation must provide.
+type ReleaseNotesFetcher interface {
+	Fetch(ctx context.Context, p *Package) (*ReleaseNotes, error)
+}
+
+// Provenance represents the build provenance of a package version, extracted
+// from the SLSA provenance attestation attached to the OCI artifact.
+type Provenance struct {
+	BuilderID  string `json:"builder_id"`
+	BuildType  string `json:"build_type,omitempty"`
+	SourceRepo string `json:"source_repo,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+	EntryPoint string `json:"entry_point,omitempty"`
+}
+
+// Screenshot represents a screenshot associated with a package. The image id
+// is set by the tracker once the screenshot has been stored.
 type Screenshot struct {
-	Title string `json:"title" yaml:"title"`
-	URL   string `json:"url" yaml:"url"`
+	Title   string `json:"title" yaml:"title"`
+	URL     string `json:"url" yaml:"url"`
+	ImageID string `json:"image_id,omitempty" yaml:"-"`
+}
+
+// SnapshotBundleData represents the information about a package version
+// needed to assemble its offline bundle, used in air-gapped installations.
+// SBOMs are in CycloneDX format, keyed by image.
+type SnapshotBundleData struct {
+	Name             string                     `json:"name"`
+	Version          string                     `json:"version"`
+	ContentURL       string                     `json:"content_url"`
+	ContainersImages []*ContainerImage          `json:"containers_images"`
+	SBOMs            map[string]json.RawMessage `json:"sboms"`
+	RepositoryID     string                     `json:"repository_id"`
+	RepositoryKind   RepositoryKind             `json:"repository_kind_id"`
+	Private          bool                       `json:"private"`
+}
+
+// SnapshotImage represents a container image used by a package version,
+// including some details about its reference and a summary of the
+// vulnerabilities found in it when the package version has been scanned.
+type SnapshotImage struct {
+	Image                 string                 `json:"image"`
+	Name                  string                 `json:"name,omitempty"`
+	Whitelisted           bool                   `json:"whitelisted"`
+	Registry              string                 `json:"registry,omitempty"`
+	Repository            string                 `json:"repository,omitempty"`
+	Tag                   string                 `json:"tag,omitempty"`
+	Digest                string                 `json:"digest,omitempty"`
+	SecurityReportSummary *SecurityReportSummary `json:"security_report_summary,omitempty"`
 }
 
 // SnapshotSecurityReport represents some information about the security
-// vulnerabilities the images used by a given package's snapshot may have.
+// vulnerabilities the images used by a given package's snapshot may have. It
+// also includes the SBOMs of those images, organized by format and image, and
+// the licenses of the packages bundled in them.
+// Vulnerabilities suppressed by the publisher using vulnerability statements
+// are not included in the images reports, they are listed separately.
 type SnapshotSecurityReport struct {
-	PackageID     string                   `json:"package_id"`
-	Version       string                   `json:"version"`
-	AlertDigest   string                   `json:"alert_digest"`
-	ImagesReports map[string]*trivy.Report `json:"images_reports"`
-	Summary       *SecurityReportSummary   `json:"summary"`
+	PackageID                 string                                `json:"package_id"`
+	Version                   string                                `json:"version"`
+	AlertDigest               string                                `json:"alert_digest"`
+	ImagesReports             map[string]*trivy.Report              `json:"images_reports"`
+	Summary                   *SecurityReportSummary                `json:"summary"`
+	SBOMs                     map[string]map[string]json.RawMessage `json:"sboms,omitempty"`
+	SuppressedVulnerabilities map[string][]*SuppressedVulnerability `json:"suppressed_vulnerabilities,omitempty"`
+	ImagesLicenses            map[string][]*ImageLicense            `json:"images_licenses,omitempty"`
 }
 
 // SecurityReportSummary represents a summary of the security report.
@@ -221,11 +486,23 @@ type SignKey struct {
 // SnapshotToScan represents some information about a package's snapshot that
 // needs to be scanned for security vulnerabilities.
 type SnapshotToScan struct {
-	RepositoryID     string            `json:"repository_id"`
-	PackageID        string            `json:"package_id"`
-	PackageName      string            `json:"package_name"`
-	Version          string            `json:"version"`
-	ContainersImages []*ContainerImage `json:"containers_images"`
+	RepositoryID            string                    `json:"repository_id"`
+	PackageID               string                    `json:"package_id"`
+	PackageName             string                    `json:"package_name"`
+	Version                 string                    `json:"version"`
+	ContainersImages        []*ContainerImage         `json:"containers_images"`
+	VulnerabilityStatements []*VulnerabilityStatement `json:"vulnerability_statements"`
+}
+
+// SuppressedVulnerability represents a vulnerability found in a container
+// image that has been suppressed by a vulnerability statement.
+type SuppressedVulnerability struct {
+	VulnerabilityID  string `json:"vulnerability_id"`
+	PkgName          string `json:"pkg_name"`
+	InstalledVersion string `json:"installed_version"`
+	Severity         string `json:"severity"`
+	Status           string `json:"status"`
+	Justification    string `json:"justification,omitempty"`
 }
 
 // SearchPackageInput represents the query input when searching for packages.
@@ -243,15 +520,60 @@ type SearchPackageInput struct {
 	Official          bool             `json:"official"`
 	Operators         bool             `json:"operators"`
 	Deprecated        bool             `json:"deprecated"`
+	SignatureVerified bool             `json:"signature_verified"`
 	Licenses          []string         `json:"licenses,omitempty"`
+	LicenseFamilies   []string         `json:"license_families,omitempty"`
 	Capabilities      []string         `json:"capabilities,omitempty"`
+	K8sVersion        string           `json:"k8s_version,omitempty"`
 	Sort              string           `json:"sort,omitempty"`
 }
 
+// UpgradeGraph represents the channels of an operator package and the upgrade
+// edges declared by each of its versions.
+type UpgradeGraph struct {
+	DefaultChannel string                 `json:"default_channel,omitempty"`
+	Channels       []*UpgradeGraphChannel `json:"channels"`
+	Versions       []*UpgradeGraphVersion `json:"versions"`
+}
+
+// UpgradeGraphChannel represents a channel in an operator's upgrade graph. The
+// entries are the versions in the channel, starting from its head and
+// following the replaces edges.
+type UpgradeGraphChannel struct {
+	Name    string   `json:"name"`
+	Head    string   `json:"head"`
+	Entries []string `json:"entries"`
+}
+
+// UpgradeGraphVersion represents an operator version along with the upgrade
+// edges it declares.
+type UpgradeGraphVersion struct {
+	Version   string   `json:"version"`
+	Replaces  string   `json:"replaces,omitempty"`
+	Skips     []string `json:"skips,omitempty"`
+	SkipRange string   `json:"skip_range,omitempty"`
+}
+
 // Version represents a package's version.
 type Version struct {
-	Version string `json:"version"`
-	TS      int64  `json:"ts"`
+	Version          string              `json:"version"`
+	TS               int64               `json:"ts"`
+	Support          *VersionSupport     `json:"support,omitempty"`
+	K8sCompatibility []*K8sVersionsRange `json:"k8s_compatibility,omitempty"`
+}
+
+// Video represents a video associated with a package.
+type Video struct {
+	Title string `json:"title" yaml:"title"`
+	URL   string `json:"url" yaml:"url"`
+}
+
+// VersionSupport represents the support status of a package version, as
+// declared by the publisher. The end of life date is optional and may be
+// provided for versions that haven't reached it yet.
+type VersionSupport struct {
+	Status  string `json:"status" yaml:"status"`
+	EOLDate string `json:"eol_date,omitempty" yaml:"eolDate"`
 }
 
 // VersionChanges represents the changes introduced by a given package's
@@ -264,6 +586,15 @@ type VersionChanges struct {
 	Prerelease              bool      `json:"prerelease"`
 }
 
+// VulnerabilityStatement represents a statement made by a package publisher
+// about a vulnerability affecting a given package version, similar to a VEX
+// statement.
+type VulnerabilityStatement struct {
+	VulnerabilityID string `json:"vulnerability_id"`
+	Status          string `json:"status"`
+	Justification   string `json:"justification,omitempty"`
+}
+
 // ViewsTracker describes the methods a ViewsTracker implementation must
 // provide.
 type ViewsTracker interface {
@@ -4,6 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
 
 	helmrepo "helm.sh/helm/v3/pkg/repo"
 )
@@ -73,8 +78,99 @@ const (
 
 	// Container represents a repository with containers images.
 	Container RepositoryKind = 12
+
+	// Terraform represents a repository with a Terraform (or OpenTofu) module.
+	Terraform RepositoryKind = 13
+
+	// Crossplane represents a repository with Crossplane packages
+	// (configurations and providers).
+	Crossplane RepositoryKind = 14
+
+	// Kyverno represents a repository with Kyverno policies.
+	Kyverno RepositoryKind = 15
+
+	// KnativeFunc represents a repository with Knative function templates.
+	KnativeFunc RepositoryKind = 16
+
+	// Headlamp represents a repository with Headlamp (Kubernetes dashboard)
+	// plugins.
+	Headlamp RepositoryKind = 17
+
+	// MinCustomRepositoryKind represents the lowest value that can be used
+	// for custom repository kinds. Values below it are reserved for the
+	// built-in kinds.
+	MinCustomRepositoryKind RepositoryKind = 100
+)
+
+// CustomRepositoryKind represents a repository kind that is not built into the
+// hub. The packages of custom kinds are processed by external tracker source
+// plugins.
+type CustomRepositoryKind struct {
+	Kind        RepositoryKind `mapstructure:"kind"`
+	Name        string         `mapstructure:"name"`
+	DisplayName string         `mapstructure:"displayName"`
+}
+
+var (
+	customKindsMu sync.RWMutex
+	customKinds   = make(map[RepositoryKind]*CustomRepositoryKind)
+
+	// customKindNameRE is a regexp used to validate custom kinds names, which
+	// are used in the packages urls.
+	customKindNameRE = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
 )
 
+// RegisterCustomRepositoryKind registers the custom repository kind provided,
+// so that it's recognized as a valid kind across the hub.
+func RegisterCustomRepositoryKind(k *CustomRepositoryKind) error {
+	if k.Kind < MinCustomRepositoryKind {
+		return fmt.Errorf("invalid custom kind %d: must be greater than or equal to %d", k.Kind, MinCustomRepositoryKind)
+	}
+	if !customKindNameRE.MatchString(k.Name) {
+		return fmt.Errorf("invalid custom kind %d: invalid name", k.Kind)
+	}
+	if k.DisplayName == "" {
+		return fmt.Errorf("invalid custom kind %d: display name not provided", k.Kind)
+	}
+	if _, err := getBuiltInKindFromName(k.Name); err == nil {
+		return fmt.Errorf("invalid custom kind %d: name already used by a built-in kind", k.Kind)
+	}
+
+	customKindsMu.Lock()
+	defer customKindsMu.Unlock()
+	for _, ck := range customKinds {
+		if ck.Name == k.Name && ck.Kind != k.Kind {
+			return fmt.Errorf("invalid custom kind %d: name already used by kind %d", k.Kind, ck.Kind)
+		}
+	}
+	customKinds[k.Kind] = k
+	return nil
+}
+
+// GetCustomRepositoryKinds returns the custom repository kinds registered,
+// sorted by kind.
+func GetCustomRepositoryKinds() []*CustomRepositoryKind {
+	customKindsMu.RLock()
+	defer customKindsMu.RUnlock()
+	kinds := make([]*CustomRepositoryKind, 0, len(customKinds))
+	for _, k := range customKinds {
+		kinds = append(kinds, k)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i].Kind < kinds[j].Kind
+	})
+	return kinds
+}
+
+// IsCustomRepositoryKind checks if the kind provided is a custom repository
+// kind that has been registered.
+func IsCustomRepositoryKind(kind RepositoryKind) bool {
+	customKindsMu.RLock()
+	defer customKindsMu.RUnlock()
+	_, ok := customKinds[kind]
+	return ok
+}
+
 // GetKindName returns the name of the provided repository kind.
 func GetKindName(kind RepositoryKind) string {
 	switch kind {
@@ -104,7 +200,22 @@ func GetKindName(kind RepositoryKind) string {
 		return "tekton-pipeline"
 	case Container:
 		return "container"
+	case Terraform:
+		return "terraform"
+	case Crossplane:
+		return "crossplane"
+	case Kyverno:
+		return "kyverno"
+	case KnativeFunc:
+		return "knative-func"
+	case Headlamp:
+		return "headlamp"
 	default:
+		customKindsMu.RLock()
+		defer customKindsMu.RUnlock()
+		if k, ok := customKinds[kind]; ok {
+			return k.Name
+		}
 		return ""
 	}
 }
@@ -112,6 +223,22 @@ func GetKindName(kind RepositoryKind) string {
 // GetKindFromName returns the kind of the provided repository from the name
 // provided.
 func GetKindFromName(kind string) (RepositoryKind, error) {
+	if k, err := getBuiltInKindFromName(kind); err == nil {
+		return k, nil
+	}
+	customKindsMu.RLock()
+	defer customKindsMu.RUnlock()
+	for _, k := range customKinds {
+		if k.Name == kind {
+			return k.Kind, nil
+		}
+	}
+	return -1, errors.New("invalid kind name")
+}
+
+// getBuiltInKindFromName returns the built-in repository kind from the name
+// provided.
+func getBuiltInKindFromName(kind string) (RepositoryKind, error) {
 	switch kind {
 	case "coredns":
 		return CoreDNS, nil
@@ -139,6 +266,16 @@ func GetKindFromName(kind string) (RepositoryKind, error) {
 		return TektonPipeline, nil
 	case "container":
 		return Container, nil
+	case "terraform":
+		return Terraform, nil
+	case "crossplane":
+		return Crossplane, nil
+	case "kyverno":
+		return Kyverno, nil
+	case "knative-func":
+		return KnativeFunc, nil
+	case "headlamp":
+		return Headlamp, nil
 	default:
 		return -1, errors.New("invalid kind name")
 	}
@@ -164,30 +301,55 @@ type Owner struct {
 
 // Repository represents a packages repository.
 type Repository struct {
-	RepositoryID            string          `json:"repository_id"`
-	Name                    string          `json:"name"`
-	DisplayName             string          `json:"display_name"`
-	URL                     string          `json:"url"`
-	Branch                  string          `json:"branch"`
-	Private                 bool            `json:"private"`
-	AuthUser                string          `json:"auth_user"`
-	AuthPass                string          `json:"auth_pass"`
-	Digest                  string          `json:"digest"`
-	Kind                    RepositoryKind  `json:"kind"`
-	UserID                  string          `json:"user_id"`
-	UserAlias               string          `json:"user_alias"`
-	OrganizationID          string          `json:"organization_id"`
-	OrganizationName        string          `json:"organization_name"`
-	OrganizationDisplayName string          `json:"organization_display_name"`
-	LastScanningErrors      string          `json:"last_scanning_errors"`
-	LastTrackingErrors      string          `json:"last_tracking_errors"`
-	VerifiedPublisher       bool            `json:"verified_publisher"`
-	Official                bool            `json:"official"`
-	Disabled                bool            `json:"disabled"`
-	ScannerDisabled         bool            `json:"scanner_disabled"`
-	Data                    json.RawMessage `json:"data,omitempty"`
+	RepositoryID              string           `json:"repository_id"`
+	Name                      string           `json:"name"`
+	DisplayName               string           `json:"display_name"`
+	URL                       string           `json:"url"`
+	Branch                    string           `json:"branch"`
+	Private                   bool             `json:"private"`
+	AuthUser                  string           `json:"auth_user"`
+	AuthPass                  string           `json:"auth_pass"`
+	Digest                    string           `json:"digest"`
+	Kind                      RepositoryKind   `json:"kind"`
+	UserID                    string           `json:"user_id"`
+	UserAlias                 string           `json:"user_alias"`
+	OrganizationID            string           `json:"organization_id"`
+	OrganizationName          string           `json:"organization_name"`
+	OrganizationDisplayName   string           `json:"organization_display_name"`
+	LastScanningErrors        string           `json:"last_scanning_errors"`
+	LastTrackingErrors        string           `json:"last_tracking_errors"`
+	LastTrackingErrorsDetails []*TrackingError `json:"last_tracking_errors_details,omitempty"`
+	TrackingRequested         bool             `json:"tracking_requested"`
+	VerifiedPublisher         bool             `json:"verified_publisher"`
+	Official                  bool             `json:"official"`
+	Disabled                  bool             `json:"disabled"`
+	ScannerDisabled           bool             `json:"scanner_disabled"`
+	Blocked                   bool             `json:"blocked"`
+	Data                      json.RawMessage  `json:"data,omitempty"`
+
+	// Version is incremented every time the repository is updated. When
+	// provided on update, it must match the current one.
+	Version int `json:"version,omitempty"`
 }
 
+// RepositoryChangeKind represents the kind of a change in a repository owned
+// by an organization that requires approval.
+type RepositoryChangeKind string
+
+const (
+	// RepositoryChangeAdd represents a repository addition.
+	RepositoryChangeAdd RepositoryChangeKind = "add"
+
+	// RepositoryChangeDelete represents a repository deletion.
+	RepositoryChangeDelete RepositoryChangeKind = "delete"
+
+	// RepositoryChangeTransfer represents a repository ownership transfer.
+	RepositoryChangeTransfer RepositoryChangeKind = "transfer"
+
+	// RepositoryChangeUpdate represents a repository update.
+	RepositoryChangeUpdate RepositoryChangeKind = "update"
+)
+
 // RepositoryCloner describes the methods a RepositoryCloner implementation
 // must provide.
 type RepositoryCloner interface {
@@ -202,20 +364,30 @@ type RepositoryCloner interface {
 // implementation must provide.
 type RepositoryManager interface {
 	Add(ctx context.Context, orgName string, r *Repository) error
+	ApproveChange(ctx context.Context, orgName, changeID string) error
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
 	ClaimOwnership(ctx context.Context, name, orgName string) error
 	Delete(ctx context.Context, name string) error
 	GetByID(ctx context.Context, repositoryID string, includeCredentials bool) (*Repository, error)
 	GetByName(ctx context.Context, name string, includeCredentials bool) (*Repository, error)
+	GetEventsJSON(ctx context.Context, name string, input *GetRepositoryEventsInput) ([]byte, error)
 	GetMetadata(r *Repository, basePath string) (*RepositoryMetadata, error)
 	GetPackagesDigest(ctx context.Context, repositoryID string) (map[string]string, error)
+	GetPendingChangesJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetRemoteDigest(ctx context.Context, r *Repository) (string, error)
+	GetStatsJSON(ctx context.Context, name string) ([]byte, error)
+	GetSubscriptionsJSON(ctx context.Context, name string) ([]byte, error)
+	GetViewsJSON(ctx context.Context, name string) ([]byte, error)
+	RegisterOwnershipClaimToken(ctx context.Context, name string) (string, error)
+	RegisterPackagesDownloads(ctx context.Context, name string, downloads []*PackageDownloads) error
+	RegisterTrackingRun(ctx context.Context, repositoryID string, duration time.Duration, outcome string) error
+	RejectChange(ctx context.Context, orgName, changeID string) error
 	Search(ctx context.Context, input *SearchRepositoryInput) (*SearchRepositoryResult, error)
 	SearchJSON(ctx context.Context, input *SearchRepositoryInput) (*JSONQueryResult, error)
 	SetLastScanningResults(ctx context.Context, repositoryID, errs string) error
-	SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error
+	SetLastTrackingResults(ctx context.Context, repositoryID string, errs []*TrackingError) error
 	SetVerifiedPublisher(ctx context.Context, repositoryID string, verified bool) error
-	Transfer(ctx context.Context, name, orgName string, ownershipClaim bool) error
+	Transfer(ctx context.Context, name, orgName string) error
 	Update(ctx context.Context, r *Repository) error
 	UpdateDigest(ctx context.Context, repositoryID, digest string) error
 }
@@ -224,9 +396,28 @@ type RepositoryManager interface {
 // usually provided by repositories publishers, to provide some extra context
 // about the repository they'd like to publish.
 type RepositoryMetadata struct {
-	RepositoryID string                   `yaml:"repositoryID"`
-	Owners       []*Owner                 `yaml:"owners,omitempty"`
-	Ignore       []*RepositoryIgnoreEntry `yaml:"ignore,omitempty"`
+	RepositoryID        string                   `yaml:"repositoryID"`
+	Owners              []*Owner                 `yaml:"owners,omitempty"`
+	Ignore              []*RepositoryIgnoreEntry `yaml:"ignore,omitempty"`
+	Cosign              *CosignConfig            `yaml:"cosign,omitempty"`
+	OwnershipClaimToken string                   `yaml:"ownershipClaimToken,omitempty"`
+}
+
+// CosignConfig represents the cosign (sigstore) configuration provided by the
+// publisher in the repository metadata file. The public keys and keyless
+// identities declared will be used to verify the signatures of the charts and
+// containers images in the repository.
+type CosignConfig struct {
+	Keys       []string          `yaml:"keys,omitempty"`
+	Identities []*CosignIdentity `yaml:"identities,omitempty"`
+}
+
+// CosignIdentity represents an identity used to sign artifacts with cosign in
+// keyless mode. The subject corresponds to the email or URI in the signing
+// certificate, and the issuer to the OIDC provider that authenticated it.
+type CosignIdentity struct {
+	Issuer  string `yaml:"issuer"`
+	Subject string `yaml:"subject"`
 }
 
 // RepositoryIgnoreEntry represents an entry in the ignore list. This list is
@@ -238,6 +429,17 @@ type RepositoryIgnoreEntry struct {
 	Version string `yaml:"version"`
 }
 
+// GetRepositoryEventsInput represents the input used to get the events of a
+// repository. Events are returned from the most recent to the oldest one, and
+// the cursor returned with each page of results can be used to get the next
+// one.
+type GetRepositoryEventsInput struct {
+	Cursor      string      `json:"cursor,omitempty"`
+	EventKinds  []EventKind `json:"event_kinds,omitempty"`
+	Limit       int         `json:"limit,omitempty"`
+	PackageName string      `json:"package_name,omitempty"`
+}
+
 // SearchRepositoryInput represents the query input when searching for repositories.
 type SearchRepositoryInput struct {
 	Name               string           `json:"name,omitempty"`
@@ -0,0 +1,27 @@
+package hub
+
+import "context"
+
+// SitemapManager describes the methods a SitemapManager implementation must
+// provide.
+type SitemapManager interface {
+	GetPackages(ctx context.Context, limit, offset int) ([]*SitemapPackage, error)
+	GetPackagesCount(ctx context.Context) (int, error)
+	GetRepositories(ctx context.Context) ([]*SitemapRepository, error)
+}
+
+// SitemapPackage represents the information about a package needed to include
+// it in the sitemap.
+type SitemapPackage struct {
+	NormalizedName string         `json:"normalized_name"`
+	RepositoryName string         `json:"repository_name"`
+	RepositoryKind RepositoryKind `json:"repository_kind_id"`
+	TS             int64          `json:"ts"`
+}
+
+// SitemapRepository represents the information about a repository needed to
+// include it in the sitemap.
+type SitemapRepository struct {
+	Name string `json:"name"`
+	TS   int64  `json:"ts"`
+}
@@ -6,4 +6,5 @@ import "context"
 // provide.
 type StatsManager interface {
 	GetJSON(ctx context.Context) ([]byte, error)
+	GetTimeSeriesJSON(ctx context.Context, granularity string) ([]byte, error)
 }
@@ -12,11 +12,14 @@ type OptOut struct {
 }
 
 // Subscription represents a user's subscription to receive notifications about
-// a given package and event kind.
+// a given package and event kind. Security alerts subscriptions can define a
+// minimum severity, so that notifications are only sent when vulnerabilities
+// at or above it are found.
 type Subscription struct {
-	UserID    string    `json:"user_id"`
-	PackageID string    `json:"package_id"`
-	EventKind EventKind `json:"event_kind"`
+	UserID      string    `json:"user_id"`
+	PackageID   string    `json:"package_id"`
+	EventKind   EventKind `json:"event_kind"`
+	MinSeverity string    `json:"min_severity,omitempty"`
 }
 
 // SubscriptionManager describes the methods a SubscriptionManager
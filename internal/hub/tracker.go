@@ -14,6 +14,36 @@ const (
 	HasNotChanged = "has-not-changed"
 )
 
+// TrackingErrorCode represents the category a tracking error belongs to.
+type TrackingErrorCode string
+
+const (
+	// TrackingErrorAuth represents errors caused by missing or invalid
+	// credentials, or by not having permissions to access a resource.
+	TrackingErrorAuth TrackingErrorCode = "auth"
+
+	// TrackingErrorContent represents errors found in the content of the
+	// packages, like invalid archives or files that cannot be processed.
+	TrackingErrorContent TrackingErrorCode = "content"
+
+	// TrackingErrorNetwork represents errors that happened while connecting
+	// to remote locations, like timeouts or unexpected status codes.
+	TrackingErrorNetwork TrackingErrorCode = "network"
+
+	// TrackingErrorSchemaValidation represents errors caused by metadata that
+	// does not conform to the expected schema.
+	TrackingErrorSchemaValidation TrackingErrorCode = "schema_validation"
+
+	// TrackingErrorUnknown represents errors that could not be classified.
+	TrackingErrorUnknown TrackingErrorCode = "unknown"
+)
+
+// TrackingError represents an error that happened while tracking a repository.
+type TrackingError struct {
+	Code    TrackingErrorCode `json:"code"`
+	Message string            `json:"message"`
+}
+
 // TrackerServices represents a set of services that must be provided to a
 // Tracker instance so that it can perform its tasks.
 type TrackerServices struct {
@@ -27,6 +57,7 @@ type TrackerServices struct {
 	Hc                 HTTPClient
 	Op                 OCIPuller
 	Is                 img.Store
+	Rn                 ReleaseNotesFetcher
 	SetupTrackerSource TrackerSourceLoader
 }
 
@@ -48,6 +79,7 @@ type TrackerSourceInput struct {
 	Repository         *Repository
 	PackagesRegistered map[string]string
 	BasePath           string
+	Metadata           *RepositoryMetadata
 	Svc                *TrackerSourceServices
 }
 
@@ -2,9 +2,37 @@ package hub
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 )
 
+// AdminAuditInfo represents some information about an operation performed by
+// a site admin on a user, which is recorded in the admin audit log.
+type AdminAuditInfo struct {
+	AdminUserID string `json:"admin_user_id"`
+	Reason      string `json:"reason"`
+	IP          string `json:"ip"`
+	UserAgent   string `json:"user_agent"`
+}
+
+// AdminAuditLogEntry represents an entry of the admin audit log. Entries are
+// chained using the hash of the previous entry, so that any modification of
+// the log can be detected.
+type AdminAuditLogEntry struct {
+	AdminAuditLogID string          `json:"admin_audit_log_id"`
+	EntryNumber     int64           `json:"entry_number"`
+	Action          string          `json:"action"`
+	UserID          string          `json:"user_id"`
+	AdminUserID     string          `json:"admin_user_id"`
+	Reason          string          `json:"reason"`
+	IP              string          `json:"ip"`
+	UserAgent       string          `json:"user_agent"`
+	Details         json.RawMessage `json:"details"`
+	CreatedAt       int64           `json:"created_at"`
+	PreviousHash    string          `json:"previous_hash"`
+	Hash            string          `json:"hash"`
+}
+
 // CheckCredentialsOutput represents the output returned by the
 // CheckCredentials method.
 type CheckCredentialsOutput struct {
@@ -16,6 +44,21 @@ type CheckCredentialsOutput struct {
 type CheckSessionOutput struct {
 	Valid  bool   `json:"valid"`
 	UserID string `json:"user_id"`
+	IP     string `json:"ip"`
+}
+
+// GetAdminAuditLogInput represents the input used to get the entries of the
+// admin audit log. The time range limits are expected in RFC3339 format.
+type GetAdminAuditLogInput struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// SearchUsersInput represents the query input when searching for users.
+type SearchUsersInput struct {
+	TSQueryWeb string `json:"ts_query_web,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
 }
 
 // Session represents some information about a user session.
@@ -53,6 +96,24 @@ type User struct {
 	ProfileImageID string `json:"profile_image_id"`
 	PasswordSet    bool   `json:"password_set"`
 	TFAEnabled     bool   `json:"tfa_enabled"`
+	Locale         string `json:"locale"`
+
+	// PublicProfile indicates whether the user's public profile page is
+	// enabled. When not provided, the current value is kept.
+	PublicProfile *bool `json:"public_profile,omitempty"`
+
+	// Version is incremented every time the user's profile is updated. When
+	// provided on update, it must match the current one.
+	Version int `json:"version,omitempty"`
+}
+
+// UserIdentity represents an identity from an external oauth provider linked
+// to a user account, which can be used to sign in to it.
+type UserIdentity struct {
+	UserID   string `json:"user_id"`
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+	Email    string `json:"email"`
 }
 
 type userIDKey struct{}
@@ -60,27 +121,48 @@ type userIDKey struct{}
 // UserIDKey represents the key used for the userID value inside a context.
 var UserIDKey = userIDKey{}
 
+type clientIPKey struct{}
+
+// ClientIPKey represents the key used for the client ip value inside a
+// context. The client ip is derived from the request by the http server,
+// taking into account the trusted proxies configured.
+var ClientIPKey = clientIPKey{}
+
 // UserManager describes the methods a UserManager implementation must provide.
 type UserManager interface {
 	ApproveSession(ctx context.Context, sessionID, passcode string) error
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
 	CheckCredentials(ctx context.Context, email, password string) (*CheckCredentialsOutput, error)
 	CheckSession(ctx context.Context, sessionID string, duration time.Duration) (*CheckSessionOutput, error)
+	DeleteEmailSuppression(ctx context.Context) error
 	DeleteSession(ctx context.Context, sessionID string) error
 	DeleteUser(ctx context.Context, code string) error
 	DisableTFA(ctx context.Context, passcode string) error
 	EnableTFA(ctx context.Context, passcode string) error
+	GetAdminAuditLogJSON(ctx context.Context, input *GetAdminAuditLogInput) ([]byte, error)
+	GetEmailSuppressionJSON(ctx context.Context) ([]byte, error)
+	GetIdentitiesJSON(ctx context.Context) ([]byte, error)
 	GetProfile(ctx context.Context) (*User, error)
 	GetProfileJSON(ctx context.Context) ([]byte, error)
+	GetPublicProfileJSON(ctx context.Context, userAlias string) ([]byte, error)
 	GetUserID(ctx context.Context, email string) (string, error)
+	GetUserIDFromIdentity(ctx context.Context, provider, subject string) (string, error)
+	Impersonate(ctx context.Context, userID string, info *AdminAuditInfo) (*Session, error)
+	LinkIdentity(ctx context.Context, identity *UserIdentity) error
 	RegisterDeleteUserCode(ctx context.Context) error
+	RegisterEmailVerificationCode(ctx context.Context, userEmail string) error
 	RegisterPasswordResetCode(ctx context.Context, userEmail string) error
 	RegisterSession(ctx context.Context, session *Session) (*Session, error)
 	RegisterUser(ctx context.Context, user *User) error
 	ResetPassword(ctx context.Context, code, newPassword string) error
+	ResetTFA(ctx context.Context, userID string, info *AdminAuditInfo) error
+	SearchJSON(ctx context.Context, input *SearchUsersInput) (*JSONQueryResult, error)
+	SetDisabled(ctx context.Context, userID string, disabled bool, info *AdminAuditInfo) error
 	SetupTFA(ctx context.Context) ([]byte, error)
+	UnlinkIdentity(ctx context.Context, provider string) error
 	UpdatePassword(ctx context.Context, old, new string) error
 	UpdateProfile(ctx context.Context, user *User) error
+	VerifyAdminAuditLog(ctx context.Context) (string, error)
 	VerifyEmail(ctx context.Context, code string) (bool, error)
 	VerifyPasswordResetCode(ctx context.Context, code string) error
 }
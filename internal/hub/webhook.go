@@ -15,6 +15,17 @@ type Webhook struct {
 	Active      bool        `json:"active"`
 	EventKinds  []EventKind `json:"event_kinds"`
 	Packages    []*Package  `json:"packages"`
+
+	// Version is incremented every time the webhook is updated. When provided
+	// on update, it must match the current one.
+	Version int `json:"version,omitempty"`
+}
+
+// WebhookReplayInput represents the time range of the events to replay for a
+// given webhook. Times are expressed as unix timestamps.
+type WebhookReplayInput struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
 }
 
 // WebhookManager describes the methods a WebhookManager implementation must
@@ -26,5 +37,6 @@ type WebhookManager interface {
 	GetOwnedByOrgJSON(ctx context.Context, orgName string, p *Pagination) (*JSONQueryResult, error)
 	GetOwnedByUserJSON(ctx context.Context, p *Pagination) (*JSONQueryResult, error)
 	GetSubscribedTo(ctx context.Context, e *Event) ([]*Webhook, error)
+	Replay(ctx context.Context, webhookID string, input *WebhookReplayInput) (int, error)
 	Update(ctx context.Context, wh *Webhook) error
 }
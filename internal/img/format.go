@@ -0,0 +1,179 @@
+package img
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// Additional formats in which image versions can be generated.
+const (
+	AVIF = "avif"
+	WebP = "webp"
+)
+
+// DefaultVersion represents the image version used when none is requested.
+const DefaultVersion = "1x"
+
+// encodersSpec represents the external tools used to encode images in each
+// of the additional formats supported. The {in} and {out} placeholders in the
+// arguments are replaced by the input and output files paths.
+var encodersSpec = map[string]struct {
+	cmd  string
+	args []string
+}{
+	AVIF: {"avifenc", []string{"--speed", "6", "{in}", "{out}"}},
+	WebP: {"cwebp", []string{"-quiet", "-q", "80", "{in}", "-o", "{out}"}},
+}
+
+// Encoder describes the methods an Encoder implementation must provide. An
+// encoder transcodes PNG images to an additional format.
+type Encoder interface {
+	// Encode encodes the PNG image provided in the encoder's format.
+	Encode(ctx context.Context, pngData []byte) ([]byte, error)
+
+	// Format returns the format in which images are encoded.
+	Format() string
+}
+
+// CmdEncoder is an Encoder implementation that relies on an external tool to
+// encode the images.
+type CmdEncoder struct {
+	format string
+	cmd    string
+	args   []string
+}
+
+// NewCmdEncoder creates a new CmdEncoder instance for the format provided.
+func NewCmdEncoder(format string) (*CmdEncoder, error) {
+	spec, ok := encodersSpec[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported image format: %s", format)
+	}
+	return &CmdEncoder{
+		format: format,
+		cmd:    spec.cmd,
+		args:   spec.args,
+	}, nil
+}
+
+// Encode implements the Encoder interface.
+func (e *CmdEncoder) Encode(ctx context.Context, pngData []byte) ([]byte, error) {
+	// Setup temporary directory to store the input and output files
+	tmpDir, err := ioutil.TempDir("", "artifact-hub")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	in := filepath.Join(tmpDir, "image.png")
+	out := filepath.Join(tmpDir, "image."+e.format)
+	if err := ioutil.WriteFile(in, pngData, 0600); err != nil {
+		return nil, err
+	}
+
+	// Encode image using the external tool
+	r := strings.NewReplacer("{in}", in, "{out}", out)
+	args := make([]string, 0, len(e.args))
+	for _, arg := range e.args {
+		args = append(args, r.Replace(arg))
+	}
+	cmd := exec.CommandContext(ctx, e.cmd, args...) // #nosec
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + os.Getenv("HOME"),
+	}
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running %s: %w: %s", e.cmd, err, stderr.String())
+	}
+
+	return ioutil.ReadFile(out)
+}
+
+// Format implements the Encoder interface.
+func (e *CmdEncoder) Format() string {
+	return e.format
+}
+
+// SetupEncoders returns the encoders for the additional formats enabled in the
+// configuration provided. Formats whose encoding tool is not available will be
+// ignored.
+func SetupEncoders(cfg *viper.Viper) []Encoder {
+	if cfg == nil {
+		return nil
+	}
+	var encoders []Encoder
+	for _, format := range cfg.GetStringSlice("images.formats") {
+		e, err := NewCmdEncoder(format)
+		if err != nil {
+			log.Warn().Err(err).Msg("image format ignored")
+			continue
+		}
+		if _, err := exec.LookPath(e.cmd); err != nil {
+			log.Warn().Str("format", format).Msgf("image format ignored: %s not available", e.cmd)
+			continue
+		}
+		encoders = append(encoders, e)
+	}
+	return encoders
+}
+
+// GenerateVariants generates a variant of each of the image versions provided
+// in the formats of the encoders given. Variants that cannot be generated are
+// skipped, as the original versions can always be used instead.
+func GenerateVariants(ctx context.Context, versions []*Version, encoders []Encoder) []*Version {
+	var variants []*Version
+	for _, e := range encoders {
+		for _, v := range versions {
+			data, err := e.Encode(ctx, v.Data)
+			if err != nil {
+				log.Warn().Err(err).Str("format", e.Format()).Msg("error generating image variant")
+				continue
+			}
+			variants = append(variants, &Version{
+				Version: VariantVersion(v.Version, e.Format()),
+				Data:    data,
+			})
+		}
+	}
+	return variants
+}
+
+// VariantVersion returns the name of the variant of the image version
+// provided in the format given (i.e. 2x.webp).
+func VariantVersion(version, format string) string {
+	if version == "" {
+		version = DefaultVersion
+	}
+	return version + "." + format
+}
+
+// IsVariant checks if the image version provided is a variant in an
+// additional format.
+func IsVariant(version string) bool {
+	return path.Ext(version) != ""
+}
+
+// ContentType returns the content type of the additional format provided.
+func ContentType(format string) string {
+	return "image/" + format
+}
+
+// VersionContentType returns the content type of the image version provided.
+// Image versions are encoded in PNG unless they are variants.
+func VersionContentType(version string) string {
+	if IsVariant(version) {
+		return ContentType(strings.TrimPrefix(path.Ext(version), "."))
+	}
+	return "image/png"
+}
@@ -0,0 +1,109 @@
+package img
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestCmdEncoder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unsupported format", func(t *testing.T) {
+		t.Parallel()
+		e, err := NewCmdEncoder("gif")
+		assert.Error(t, err)
+		assert.Nil(t, e)
+	})
+
+	t.Run("supported formats", func(t *testing.T) {
+		t.Parallel()
+		for _, format := range []string{AVIF, WebP} {
+			e, err := NewCmdEncoder(format)
+			require.NoError(t, err)
+			assert.Equal(t, format, e.Format())
+		}
+	})
+
+	t.Run("image encoded successfully", func(t *testing.T) {
+		t.Parallel()
+		e := &CmdEncoder{format: WebP, cmd: "cp", args: []string{"{in}", "{out}"}}
+		data, err := e.Encode(ctx, []byte("pngData"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("pngData"), data)
+	})
+
+	t.Run("error running encoding tool", func(t *testing.T) {
+		t.Parallel()
+		e := &CmdEncoder{format: WebP, cmd: "false"}
+		data, err := e.Encode(ctx, []byte("pngData"))
+		assert.Error(t, err)
+		assert.Nil(t, data)
+	})
+}
+
+func TestSetupEncoders(t *testing.T) {
+	t.Parallel()
+
+	// Check no encoders are set up by default
+	assert.Empty(t, SetupEncoders(nil))
+	assert.Empty(t, SetupEncoders(viper.New()))
+
+	// Check unsupported formats are ignored
+	cfg := viper.New()
+	cfg.Set("images.formats", []string{"gif"})
+	assert.Empty(t, SetupEncoders(cfg))
+}
+
+func TestGenerateVariants(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	versions := []*Version{
+		{Version: "1x", Data: []byte("1xData")},
+		{Version: "2x", Data: []byte("2xData")},
+	}
+	e1 := &EncoderMock{}
+	e1.On("Format").Return(WebP)
+	e1.On("Encode", ctx, []byte("1xData")).Return([]byte("1xWebPData"), nil)
+	e1.On("Encode", ctx, []byte("2xData")).Return([]byte("2xWebPData"), nil)
+	e2 := &EncoderMock{}
+	e2.On("Format").Return(AVIF)
+	e2.On("Encode", ctx, []byte("1xData")).Return([]byte("1xAVIFData"), nil)
+	e2.On("Encode", ctx, []byte("2xData")).Return(nil, tests.ErrFake)
+
+	variants := GenerateVariants(ctx, versions, []Encoder{e1, e2})
+	assert.Equal(t, []*Version{
+		{Version: "1x.webp", Data: []byte("1xWebPData")},
+		{Version: "2x.webp", Data: []byte("2xWebPData")},
+		{Version: "1x.avif", Data: []byte("1xAVIFData")},
+	}, variants)
+	e1.AssertExpectations(t)
+	e2.AssertExpectations(t)
+}
+
+func TestVariantVersion(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "2x.webp", VariantVersion("2x", WebP))
+	assert.Equal(t, "1x.avif", VariantVersion("", AVIF))
+	assert.True(t, IsVariant("2x.webp"))
+	assert.False(t, IsVariant("2x"))
+	assert.False(t, IsVariant(""))
+}
+
+func TestVersionContentType(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "image/png", VersionContentType("2x"))
+	assert.Equal(t, "image/webp", VersionContentType("2x.webp"))
+	assert.Equal(t, "image/avif", VersionContentType("2x.avif"))
+}
@@ -3,16 +3,43 @@ package img
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"image"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/disintegration/imaging"
+	svg "github.com/h2non/go-is-svg"
+	"github.com/spf13/viper"
 	"github.com/vincent-petithory/dataurl"
 )
 
+const (
+	// DefaultMaxSize represents the maximum size of the images that can be
+	// downloaded when no other limit has been set in the configuration.
+	DefaultMaxSize = 10 * 1024 * 1024
+
+	// maxPixels represents the maximum number of pixels images can have, to
+	// avoid processing images that would use too much memory once decoded.
+	maxPixels = 50 * 1000 * 1000
+)
+
+var (
+	// ErrInvalidURL indicates that the image url provided is not valid or
+	// that it uses an unsupported scheme.
+	ErrInvalidURL = errors.New("invalid image url")
+
+	// ErrNotImage indicates that the content downloaded is not an image.
+	ErrNotImage = errors.New("content is not an image")
+
+	// ErrTooLarge indicates that the image exceeds the size limits allowed.
+	ErrTooLarge = errors.New("image too large")
+)
+
 // HTTPClient defines the methods an HTTPClient implementation must provide.
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -31,59 +58,112 @@ type Store interface {
 	SaveImage(ctx context.Context, data []byte) (imageID string, err error)
 }
 
+// URLResolver describes the methods an image.Store implementation able to
+// serve images from a public location (i.e. a CDN) must provide.
+type URLResolver interface {
+	// ImageURL returns the public url of the image identified by the ID and
+	// version provided, or an empty string if it is not available.
+	ImageURL(ctx context.Context, imageID, version string) (string, error)
+}
+
+// versionsSpec represents the size specific versions generated for images.
+var versionsSpec = []struct {
+	version string
+	width   int
+	height  int
+}{
+	{"1x", 80, 80},
+	{"2x", 160, 160},
+	{"3x", 240, 240},
+	{"4x", 320, 320},
+}
+
 // Version represents a specific size version of an image.
 type Version struct {
 	Version string
 	Data    []byte
 }
 
+// MaxSize returns the maximum size of the images that can be downloaded, as
+// set in the configuration provided.
+func MaxSize(cfg *viper.Viper) int64 {
+	if cfg != nil && cfg.GetInt64("images.fetch.maxSize") > 0 {
+		return cfg.GetInt64("images.fetch.maxSize")
+	}
+	return DefaultMaxSize
+}
+
 // Download downloads the image located at the url provided. If it's a data url
-// the image is extracted from it. Otherwise it's downloaded using the url.
+// the image is extracted from it. Otherwise it's downloaded using the url. Only
+// http(s) urls are supported, and the content downloaded must be an image not
+// exceeding the maximum size provided.
 func Download(
 	ctx context.Context,
 	hc HTTPClient,
 	imageURL string,
+	maxSize int64,
 ) ([]byte, error) {
-	// Image in data url
+	var data []byte
 	if strings.HasPrefix(imageURL, "data:") {
+		// Image in data url
 		dataURL, err := dataurl.DecodeString(imageURL)
 		if err != nil {
 			return nil, err
 		}
-		return dataURL.Data, nil
+		data = dataURL.Data
+	} else {
+		// Download image using url provided
+		u, err := url.Parse(imageURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, ErrInvalidURL
+		}
+		req, _ := http.NewRequest("GET", imageURL, nil)
+		resp, err := hc.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+		}
+		if resp.ContentLength > maxSize {
+			return nil, ErrTooLarge
+		}
+		data, err = ioutil.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Download image using url provided
-	req, _ := http.NewRequest("GET", imageURL, nil)
-	if _, err := url.Parse(imageURL); err != nil {
-		return nil, err
+	// Check the content obtained is an image we can process
+	if int64(len(data)) > maxSize {
+		return nil, ErrTooLarge
 	}
-	resp, err := hc.Do(req)
-	if err != nil {
+	if err := checkImage(data); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusOK {
-		return ioutil.ReadAll(resp.Body)
+	return data, nil
+}
+
+// checkImage checks if the data provided looks like an image, and that its
+// dimensions do not exceed the limits allowed.
+func checkImage(data []byte) error {
+	if svg.Is(data) {
+		return nil
+	}
+	if !strings.HasPrefix(http.DetectContentType(data), "image/") {
+		return ErrNotImage
 	}
-	return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err == nil && cfg.Width*cfg.Height > maxPixels {
+		return ErrTooLarge
+	}
+	return nil
 }
 
 // GenerateVersions generates multiple versions of different sizes for the
 // image provided.
 func GenerateVersions(data []byte) ([]*Version, error) {
-	// Define versions spec
-	spec := []struct {
-		version string
-		width   int
-		height  int
-	}{
-		{"1x", 80, 80},
-		{"2x", 160, 160},
-		{"3x", 240, 240},
-		{"4x", 320, 320},
-	}
-
 	// Decode original image data
 	img, err := imaging.Decode(bytes.NewReader(data))
 	if err != nil {
@@ -91,8 +171,8 @@ func GenerateVersions(data []byte) ([]*Version, error) {
 	}
 
 	// Generate image versions
-	imgVersions := make([]*Version, 0, len(spec))
-	for _, e := range spec {
+	imgVersions := make([]*Version, 0, len(versionsSpec))
+	for _, e := range versionsSpec {
 		imgVersion := imaging.Fit(img, e.width, e.height, imaging.Lanczos)
 		var buf bytes.Buffer
 		if err := imaging.Encode(&buf, imgVersion, imaging.PNG); err != nil {
@@ -106,3 +186,13 @@ func GenerateVersions(data []byte) ([]*Version, error) {
 
 	return imgVersions, nil
 }
+
+// VersionsNames returns the names of the size specific versions generated for
+// images.
+func VersionsNames() []string {
+	names := make([]string, 0, len(versionsSpec))
+	for _, e := range versionsSpec {
+		names = append(names, e.version)
+	}
+	return names
+}
@@ -1,6 +1,7 @@
 package img
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"flag"
@@ -11,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -49,15 +51,22 @@ func TestGenerateVersions(t *testing.T) {
 	}
 }
 
+func TestVersionsNames(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"1x", "2x", "3x", "4x"}, VersionsNames())
+}
+
 func TestDownload(t *testing.T) {
 	ctx := context.Background()
 	imageURL := "https://raw.githubusercontent.com/image1.png"
+	imageData, err := ioutil.ReadFile("testdata/valid.png")
+	require.NoError(t, err)
 
 	t.Run("invalid data url", func(t *testing.T) {
 		t.Parallel()
 		hc := &tests.HTTPClientMock{}
 
-		data, err := Download(ctx, hc, "data:invalid")
+		data, err := Download(ctx, hc, "data:invalid", DefaultMaxSize)
 		assert.Nil(t, data)
 		assert.Error(t, err)
 		hc.AssertExpectations(t)
@@ -67,19 +76,88 @@ func TestDownload(t *testing.T) {
 		t.Parallel()
 		hc := &tests.HTTPClientMock{}
 
-		data, err := Download(ctx, hc, "invalid \n url")
+		data, err := Download(ctx, hc, "invalid \n url", DefaultMaxSize)
 		assert.Nil(t, data)
 		assert.Error(t, err)
 		hc.AssertExpectations(t)
 	})
 
+	t.Run("unsupported image url scheme", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+
+		for _, u := range []string{"file:///etc/passwd", "ftp://host/image.png", "/image.png"} {
+			data, err := Download(ctx, hc, u, DefaultMaxSize)
+			assert.Nil(t, data)
+			assert.True(t, errors.Is(err, ErrInvalidURL))
+		}
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("image too large (content length)", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		req, _ := http.NewRequest("GET", imageURL, nil)
+		hc.On("Do", req).Return(&http.Response{
+			Body:          ioutil.NopCloser(bytes.NewReader(imageData)),
+			StatusCode:    http.StatusOK,
+			ContentLength: int64(len(imageData)),
+		}, nil)
+
+		data, err := Download(ctx, hc, imageURL, 10)
+		assert.Nil(t, data)
+		assert.True(t, errors.Is(err, ErrTooLarge))
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("image too large (body)", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		req, _ := http.NewRequest("GET", imageURL, nil)
+		hc.On("Do", req).Return(&http.Response{
+			Body:          ioutil.NopCloser(bytes.NewReader(imageData)),
+			StatusCode:    http.StatusOK,
+			ContentLength: -1,
+		}, nil)
+
+		data, err := Download(ctx, hc, imageURL, 10)
+		assert.Nil(t, data)
+		assert.True(t, errors.Is(err, ErrTooLarge))
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("content is not an image", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		req, _ := http.NewRequest("GET", imageURL, nil)
+		hc.On("Do", req).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("<html><body>Not found</body></html>")),
+			StatusCode: http.StatusOK,
+		}, nil)
+
+		data, err := Download(ctx, hc, imageURL, DefaultMaxSize)
+		assert.Nil(t, data)
+		assert.True(t, errors.Is(err, ErrNotImage))
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("data url content is not an image", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+
+		data, err := Download(ctx, hc, "data:text/plain;base64,aGVsbG8=", DefaultMaxSize)
+		assert.Nil(t, data)
+		assert.True(t, errors.Is(err, ErrNotImage))
+		hc.AssertExpectations(t)
+	})
+
 	t.Run("request failed", func(t *testing.T) {
 		t.Parallel()
 		hc := &tests.HTTPClientMock{}
 		req, _ := http.NewRequest("GET", imageURL, nil)
 		hc.On("Do", req).Return(nil, tests.ErrFake)
 
-		data, err := Download(ctx, hc, imageURL)
+		data, err := Download(ctx, hc, imageURL, DefaultMaxSize)
 		assert.Nil(t, data)
 		assert.Equal(t, tests.ErrFake, err)
 		hc.AssertExpectations(t)
@@ -94,7 +172,7 @@ func TestDownload(t *testing.T) {
 			StatusCode: http.StatusNotFound,
 		}, nil)
 
-		data, err := Download(ctx, hc, imageURL)
+		data, err := Download(ctx, hc, imageURL, DefaultMaxSize)
 		assert.Nil(t, data)
 		assert.Equal(t, errors.New("unexpected status code received: 404"), err)
 		hc.AssertExpectations(t)
@@ -105,13 +183,23 @@ func TestDownload(t *testing.T) {
 		hc := &tests.HTTPClientMock{}
 		req, _ := http.NewRequest("GET", imageURL, nil)
 		hc.On("Do", req).Return(&http.Response{
-			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Body:       ioutil.NopCloser(bytes.NewReader(imageData)),
 			StatusCode: http.StatusOK,
 		}, nil)
 
-		data, err := Download(ctx, hc, imageURL)
-		assert.NotNil(t, data)
+		data, err := Download(ctx, hc, imageURL, DefaultMaxSize)
+		assert.Equal(t, imageData, data)
 		assert.Nil(t, err)
 		hc.AssertExpectations(t)
 	})
 }
+
+func TestMaxSize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int64(DefaultMaxSize), MaxSize(nil))
+	cfg := viper.New()
+	assert.Equal(t, int64(DefaultMaxSize), MaxSize(cfg))
+	cfg.Set("images.fetch.maxSize", 1024)
+	assert.Equal(t, int64(1024), MaxSize(cfg))
+}
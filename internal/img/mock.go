@@ -29,3 +29,21 @@ func (m *StoreMock) SaveImage(ctx context.Context, data []byte) (string, error)
 	args := m.Called(ctx, data)
 	return args.String(0), args.Error(1)
 }
+
+// EncoderMock is a mock implementation of the img.Encoder interface.
+type EncoderMock struct {
+	mock.Mock
+}
+
+// Encode implements the img.Encoder interface.
+func (m *EncoderMock) Encode(ctx context.Context, pngData []byte) ([]byte, error) {
+	args := m.Called(ctx, pngData)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Format implements the img.Encoder interface.
+func (m *EncoderMock) Format() string {
+	args := m.Called()
+	return args.String(0)
+}
@@ -0,0 +1,185 @@
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	// gcsAPIURL represents the Google Cloud Storage JSON API base url.
+	gcsAPIURL = "https://storage.googleapis.com/storage/v1"
+
+	// gcsUploadURL represents the Google Cloud Storage JSON API upload url.
+	gcsUploadURL = "https://storage.googleapis.com/upload/storage/v1"
+
+	// gcsScope represents the OAuth2 scope required to read and write objects.
+	gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+)
+
+// GCSBucket is a Bucket implementation that stores objects in Google Cloud
+// Storage.
+type GCSBucket struct {
+	hc        img.HTTPClient
+	apiURL    string
+	uploadURL string
+	bucket    string
+	ts        oauth2.TokenSource
+}
+
+// NewGCSBucket creates a new GCSBucket instance using the configuration
+// available under the key provided (i.e. images.gcs). When no service account
+// credentials are provided in the configuration, the application default
+// credentials will be used.
+func NewGCSBucket(cfg *viper.Viper, key string, hc img.HTTPClient) (*GCSBucket, error) {
+	bucket := cfg.GetString(key + ".bucket")
+	if bucket == "" {
+		return nil, errors.New("gcs bucket not provided")
+	}
+	var ts oauth2.TokenSource
+	if credentials := cfg.GetString(key + ".credentials"); credentials != "" {
+		creds, err := google.CredentialsFromJSON(context.Background(), []byte(credentials), gcsScope)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gcs credentials: %w", err)
+		}
+		ts = creds.TokenSource
+	} else {
+		var err error
+		ts, err = google.DefaultTokenSource(context.Background(), gcsScope)
+		if err != nil {
+			return nil, fmt.Errorf("gcs credentials not available: %w", err)
+		}
+	}
+	return &GCSBucket{
+		hc:        hc,
+		apiURL:    gcsAPIURL,
+		uploadURL: gcsUploadURL,
+		bucket:    bucket,
+		ts:        ts,
+	}, nil
+}
+
+// Exists implements the Bucket interface.
+func (b *GCSBucket) Exists(ctx context.Context, key string) (bool, error) {
+	resp, err := b.do(ctx, "GET", b.objectURL(key), nil, "")
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+}
+
+// Get implements the Bucket interface.
+func (b *GCSBucket) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := b.do(ctx, "GET", b.objectURL(key)+"?alt=media", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return ioutil.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, hub.ErrNotFound
+	default:
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+}
+
+// Put implements the Bucket interface. Objects are uploaded using a multipart
+// request, so that their metadata can be set along with the data.
+func (b *GCSBucket) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	// Prepare multipart body
+	metadata, err := json.Marshal(map[string]string{
+		"name":         key,
+		"contentType":  contentType,
+		"cacheControl": cacheControl,
+	})
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	parts := []struct {
+		contentType string
+		data        []byte
+	}{
+		{"application/json; charset=UTF-8", metadata},
+		{contentType, data},
+	}
+	for _, p := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {p.contentType}})
+		if err != nil {
+			return err
+		}
+		if _, err := pw.Write(p.data); err != nil {
+			return err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	// Upload object
+	u := fmt.Sprintf("%s/b/%s/o?uploadType=multipart", b.uploadURL, url.PathEscape(b.bucket))
+	resp, err := b.do(ctx, "POST", u, &body, "multipart/related; boundary="+mw.Boundary())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code received: %d (%s)", resp.StatusCode, respBody)
+	}
+	return nil
+}
+
+// objectURL returns the url of the object identified by the key provided.
+func (b *GCSBucket) objectURL(key string) string {
+	return fmt.Sprintf("%s/b/%s/o/%s", b.apiURL, url.PathEscape(b.bucket), url.PathEscape(key))
+}
+
+// do sends an authorized request to the Google Cloud Storage API.
+func (b *GCSBucket) do(
+	ctx context.Context,
+	method string,
+	u string,
+	body *bytes.Buffer,
+	contentType string,
+) (*http.Response, error) {
+	token, err := b.ts.Token()
+	if err != nil {
+		return nil, err
+	}
+	var req *http.Request
+	if body != nil {
+		req, _ = http.NewRequestWithContext(ctx, method, u, body)
+	} else {
+		req, _ = http.NewRequestWithContext(ctx, method, u, nil)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	token.SetAuthHeader(req)
+	return b.hc.Do(req)
+}
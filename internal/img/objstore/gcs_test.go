@@ -0,0 +1,182 @@
+package objstore
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/oauth2"
+)
+
+func TestNewGCSBucket(t *testing.T) {
+	t.Run("bucket not provided", func(t *testing.T) {
+		t.Parallel()
+		b, err := NewGCSBucket(viper.New(), "images.gcs", nil)
+		assert.Error(t, err)
+		assert.Nil(t, b)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.gcs.bucket", "bucket1")
+		cfg.Set("images.gcs.credentials", "invalid")
+
+		b, err := NewGCSBucket(cfg, "images.gcs", nil)
+		assert.Error(t, err)
+		assert.Nil(t, b)
+	})
+}
+
+func TestGCSBucketExists(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		statusCode     int
+		expectedExists bool
+		expectedError  bool
+	}{
+		{http.StatusOK, true, false},
+		{http.StatusNotFound, false, false},
+		{http.StatusForbidden, false, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(http.StatusText(tc.statusCode), func(t *testing.T) {
+			t.Parallel()
+			hc := &tests.HTTPClientMock{}
+			hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.Method == "GET" &&
+					req.URL.String() == "https://storage.googleapis.com/storage/v1/b/bucket1/o/imageID%2F1x" &&
+					req.Header.Get("Authorization") == "Bearer token"
+			})).Return(&http.Response{
+				StatusCode: tc.statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			}, nil)
+			b := newTestGCSBucket(hc)
+
+			exists, err := b.Exists(ctx, "imageID/1x")
+			assert.Equal(t, tc.expectedExists, exists)
+			assert.Equal(t, tc.expectedError, err != nil)
+			hc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGCSBucketGet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("object returned", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == "GET" &&
+				req.URL.String() == "https://storage.googleapis.com/storage/v1/b/bucket1/o/imageID%2F1x?alt=media" &&
+				req.Header.Get("Authorization") == "Bearer token"
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("imageData")),
+		}, nil)
+		b := newTestGCSBucket(hc)
+
+		data, err := b.Get(ctx, "imageID/1x")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("imageData"), data)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("object not found", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+		}, nil)
+		b := newTestGCSBucket(hc)
+
+		data, err := b.Get(ctx, "imageID/1x")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, data)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestGCSBucketPut(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("object uploaded along with its metadata", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			if req.Method != "POST" ||
+				req.URL.String() != "https://storage.googleapis.com/upload/storage/v1/b/bucket1/o?uploadType=multipart" ||
+				req.Header.Get("Authorization") != "Bearer token" {
+				return false
+			}
+			mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+			if err != nil || mediaType != "multipart/related" {
+				return false
+			}
+			mr := multipart.NewReader(req.Body, params["boundary"])
+			p, err := mr.NextPart()
+			if err != nil {
+				return false
+			}
+			var metadata map[string]string
+			if err := json.NewDecoder(p).Decode(&metadata); err != nil {
+				return false
+			}
+			p, err = mr.NextPart()
+			if err != nil {
+				return false
+			}
+			data, _ := ioutil.ReadAll(p)
+			return metadata["name"] == "imageID/1x" &&
+				metadata["contentType"] == "image/png" &&
+				metadata["cacheControl"] == cacheControl &&
+				p.Header.Get("Content-Type") == "image/png" &&
+				string(data) == "imageData"
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+		}, nil)
+		b := newTestGCSBucket(hc)
+
+		err := b.Put(ctx, "imageID/1x", []byte("imageData"), "image/png")
+		assert.NoError(t, err)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusForbidden,
+			Body:       ioutil.NopCloser(strings.NewReader("forbidden")),
+		}, nil)
+		b := newTestGCSBucket(hc)
+
+		err := b.Put(ctx, "imageID/1x", []byte("imageData"), "image/png")
+		assert.EqualError(t, err, "unexpected status code received: 403 (forbidden)")
+		hc.AssertExpectations(t)
+	})
+}
+
+func newTestGCSBucket(hc *tests.HTTPClientMock) *GCSBucket {
+	return &GCSBucket{
+		hc:        hc,
+		apiURL:    gcsAPIURL,
+		uploadURL: gcsUploadURL,
+		bucket:    "bucket1",
+		ts:        oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+	}
+}
@@ -0,0 +1,31 @@
+package objstore
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// BucketMock is a mock implementation of the Bucket interface.
+type BucketMock struct {
+	mock.Mock
+}
+
+// Exists implements the Bucket interface.
+func (m *BucketMock) Exists(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+// Get implements the Bucket interface.
+func (m *BucketMock) Get(ctx context.Context, key string) ([]byte, error) {
+	args := m.Called(ctx, key)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Put implements the Bucket interface.
+func (m *BucketMock) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	args := m.Called(ctx, key, data, contentType)
+	return args.Error(0)
+}
@@ -0,0 +1,309 @@
+package objstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	svg "github.com/h2non/go-is-svg"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/satori/uuid"
+	"github.com/spf13/viper"
+)
+
+const (
+	// cacheSize represents the number of entries kept in the caches.
+	cacheSize = 250
+
+	// cacheControl represents the cache control value set on the objects
+	// stored. Images are immutable, so they can be cached indefinitely.
+	cacheControl = "public, max-age=31536000, immutable"
+
+	// urlsPrefix represents the prefix of the keys of the objects used to keep
+	// track of the images downloaded from remote urls.
+	urlsPrefix = "urls/"
+)
+
+// imageIDNamespace is the namespace used to generate the images ids from the
+// hash of their original data.
+var imageIDNamespace = uuid.NewV5(uuid.NamespaceURL, "https://artifacthub.io/images")
+
+// Bucket describes the methods an object storage bucket implementation must
+// provide.
+type Bucket interface {
+	// Exists checks if an object with the key provided exists in the bucket.
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Get returns the data of the object identified by the key provided. When
+	// the object does not exist, hub.ErrNotFound is returned.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Put stores an object in the bucket using the key provided.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+}
+
+// urlImage represents an image downloaded from a remote url.
+type urlImage struct {
+	ImageID   string `json:"image_id"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// ImageStore is an image.Store implementation that uses an object storage
+// bucket (i.e. S3 or GCS) as the underlying storage. Images ids are derived
+// from the hash of the original image, so no state needs to be kept in the
+// database. Images registered before the object storage was set up can still
+// be served from the legacy store provided.
+type ImageStore struct {
+	cfg         *viper.Viper
+	bucket      Bucket
+	hc          img.HTTPClient
+	maxSize     int64
+	cacheTTL    time.Duration
+	encoders    []img.Encoder
+	legacy      img.Store
+	publicURL   string
+	imagesCache *lru.Cache
+	errorsCache *lru.Cache
+	keysCache   *lru.Cache
+	mutexes     sync.Map
+}
+
+// NewImageStore creates a new ImageStore instance. The legacy store is
+// optional.
+func NewImageStore(
+	cfg *viper.Viper,
+	bucket Bucket,
+	hc img.HTTPClient,
+	legacy img.Store,
+) *ImageStore {
+	imagesCache, _ := lru.New(cacheSize)
+	errorsCache, _ := lru.New(cacheSize)
+	keysCache, _ := lru.New(cacheSize * 4)
+	return &ImageStore{
+		cfg:         cfg,
+		bucket:      bucket,
+		hc:          hc,
+		maxSize:     img.MaxSize(cfg),
+		cacheTTL:    cfg.GetDuration("images.fetch.cacheTTL"),
+		encoders:    img.SetupEncoders(cfg),
+		legacy:      legacy,
+		publicURL:   strings.TrimSuffix(cfg.GetString("images.publicURL"), "/"),
+		imagesCache: imagesCache,
+		errorsCache: errorsCache,
+		keysCache:   keysCache,
+	}
+}
+
+// DownloadAndSaveImage implements the image.Store interface.
+func (s *ImageStore) DownloadAndSaveImage(ctx context.Context, imageURL string) (string, error) {
+	// Make sure we only process the same image once at a time
+	cachedImage, _ := s.mutexes.LoadOrStore(imageURL, &sync.Mutex{})
+	imageMu := cachedImage.(*sync.Mutex)
+	imageMu.Lock()
+	defer imageMu.Unlock()
+
+	// Check if the image was downloaded recently, even by a previous run, to
+	// avoid hitting the source
+	imageID, err := s.getURLImage(ctx, imageURL)
+	if err != nil {
+		return "", err
+	}
+	if imageID != "" {
+		return imageID, nil
+	}
+
+	// Try to get image data from the cache to avoid hitting the source
+	var data []byte
+	cachedImage, ok := s.imagesCache.Get(imageURL)
+	if ok {
+		data = cachedImage.([]byte)
+	} else {
+		// Image not found in the cache. Check if we've tried downloading it
+		// already, returning the cached error if available.
+		cachedError, ok := s.errorsCache.Get(imageURL)
+		if ok {
+			return "", cachedError.(error)
+		}
+
+		// Download it from source and store it in the cache.
+		data, err = img.Download(ctx, s.hc, imageURL, s.maxSize)
+		if err != nil {
+			s.errorsCache.Add(imageURL, err)
+			return "", err
+		}
+		s.imagesCache.Add(imageURL, data)
+	}
+
+	// Store image in the bucket
+	imageID, err = s.SaveImage(ctx, data)
+	if err != nil {
+		return "", err
+	}
+	if err := s.setURLImage(ctx, imageURL, imageID); err != nil {
+		return "", err
+	}
+	return imageID, nil
+}
+
+// GetImage implements the image.Store interface.
+func (s *ImageStore) GetImage(ctx context.Context, imageID, version string) ([]byte, error) {
+	for _, key := range imageKeys(imageID, version) {
+		data, err := s.bucket.Get(ctx, key)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, hub.ErrNotFound) {
+			return nil, err
+		}
+	}
+	if s.legacy != nil {
+		return s.legacy.GetImage(ctx, imageID, version)
+	}
+	return nil, hub.ErrNotFound
+}
+
+// ImageURL implements the img.URLResolver interface. An empty url is returned
+// when no public url has been configured or the image is not available in the
+// bucket.
+func (s *ImageStore) ImageURL(ctx context.Context, imageID, version string) (string, error) {
+	if s.publicURL == "" {
+		return "", nil
+	}
+	for _, key := range imageKeys(imageID, version) {
+		if _, ok := s.keysCache.Get(key); !ok {
+			exists, err := s.bucket.Exists(ctx, key)
+			if err != nil {
+				return "", err
+			}
+			if !exists {
+				continue
+			}
+			s.keysCache.Add(key, struct{}{})
+		}
+		return s.publicURL + "/" + key, nil
+	}
+	return "", nil
+}
+
+// SaveImage implements the image.Store interface.
+func (s *ImageStore) SaveImage(ctx context.Context, data []byte) (string, error) {
+	// Compute image id from its hash
+	sum := sha256.Sum256(data)
+	imageID := uuid.NewV5(imageIDNamespace, hex.EncodeToString(sum[:])).String()
+
+	// If image is already stored we just return its id
+	exists, err := s.bucket.Exists(ctx, imageKey(imageID, img.DefaultVersion))
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return imageID, nil
+	}
+
+	// If image format is svg we store it once sanitized, as this format
+	// doesn't require additional size specific versions. It's stored using all
+	// versions names so that it can be requested like any other image.
+	var versions []*img.Version
+	isSVG := svg.Is(data)
+	if isSVG {
+		data, err = img.SanitizeSVG(data)
+		if err != nil {
+			return "", err
+		}
+		for _, v := range img.VersionsNames() {
+			versions = append(versions, &img.Version{Version: v, Data: data})
+		}
+	} else {
+		versions, err = img.GenerateVersions(data)
+		if err != nil {
+			return "", err
+		}
+		versions = append(versions, img.GenerateVariants(ctx, versions, s.encoders)...)
+	}
+
+	// Store image versions. The default version is stored last, as it's used
+	// to check if the image has already been stored.
+	for i := len(versions) - 1; i >= 0; i-- {
+		contentType := img.VersionContentType(versions[i].Version)
+		if isSVG {
+			contentType = "image/svg+xml"
+		}
+		key := imageKey(imageID, versions[i].Version)
+		if err := s.bucket.Put(ctx, key, versions[i].Data, contentType); err != nil {
+			return "", err
+		}
+	}
+
+	return imageID, nil
+}
+
+// getURLImage returns the id of the image downloaded from the url provided, as
+// long as it was downloaded within the configured cache ttl. An empty string
+// is returned when it's not available.
+func (s *ImageStore) getURLImage(ctx context.Context, imageURL string) (string, error) {
+	if s.cacheTTL == 0 || strings.HasPrefix(imageURL, "data:") {
+		return "", nil
+	}
+	data, err := s.bucket.Get(ctx, urlKey(imageURL))
+	if err != nil {
+		if errors.Is(err, hub.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	var ui *urlImage
+	if err := json.Unmarshal(data, &ui); err != nil || ui == nil {
+		return "", nil
+	}
+	if time.Unix(ui.UpdatedAt, 0).Before(time.Now().Add(-s.cacheTTL)) {
+		return "", nil
+	}
+	return ui.ImageID, nil
+}
+
+// setURLImage records the id of the image downloaded from the url provided,
+// so that it can be reused until the configured cache ttl expires.
+func (s *ImageStore) setURLImage(ctx context.Context, imageURL, imageID string) error {
+	if s.cacheTTL == 0 || strings.HasPrefix(imageURL, "data:") {
+		return nil
+	}
+	data, _ := json.Marshal(&urlImage{
+		ImageID:   imageID,
+		UpdatedAt: time.Now().Unix(),
+	})
+	return s.bucket.Put(ctx, urlKey(imageURL), data, "application/json")
+}
+
+// imageKey returns the key of the object that stores the image version
+// provided.
+func imageKey(imageID, version string) string {
+	return fmt.Sprintf("%s/%s", imageID, version)
+}
+
+// imageKeys returns the keys that should be tried, in order, to get the image
+// version provided. Variants in additional formats have no fallback version.
+func imageKeys(imageID, version string) []string {
+	switch {
+	case version == "" || version == img.DefaultVersion:
+		return []string{imageKey(imageID, img.DefaultVersion)}
+	case img.IsVariant(version):
+		return []string{imageKey(imageID, version)}
+	default:
+		return []string{imageKey(imageID, version), imageKey(imageID, img.DefaultVersion)}
+	}
+}
+
+// urlKey returns the key of the object that keeps track of the image
+// downloaded from the url provided.
+func urlKey(imageURL string) string {
+	sum := sha256.Sum256([]byte(imageURL))
+	return urlsPrefix + hex.EncodeToString(sum[:])
+}
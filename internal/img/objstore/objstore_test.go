@@ -0,0 +1,418 @@
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/satori/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewImageStore(t *testing.T) {
+	t.Parallel()
+	cfg := viper.New()
+	cfg.Set("images.publicURL", "https://cdn.artifacthub.io/")
+	b := &BucketMock{}
+	hc := &tests.HTTPClientMock{}
+	legacy := &img.StoreMock{}
+	s := NewImageStore(cfg, b, hc, legacy)
+
+	assert.IsType(t, &ImageStore{}, s)
+	assert.Equal(t, b, s.bucket)
+	assert.Equal(t, hc, s.hc)
+	assert.Equal(t, legacy, s.legacy)
+	assert.Equal(t, "https://cdn.artifacthub.io", s.publicURL)
+}
+
+func TestDownloadAndSaveImage(t *testing.T) {
+	ctx := context.Background()
+	svgImgURL := "https://raw.githubusercontent.com/image.svg"
+	svgImgData, err := ioutil.ReadFile("testdata/image.svg")
+	require.NoError(t, err)
+	svgImgID := testImageID(svgImgData)
+
+	t.Run("image not found in cache, it needs to be downloaded", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, svgImgID+"/1x").Return(false, nil)
+		b.On("Put", ctx, mock.Anything, svgImgData, "image/svg+xml").Return(nil).Times(4)
+		hc := &tests.HTTPClientMock{}
+		req, _ := http.NewRequest("GET", svgImgURL, nil)
+		hc.On("Do", req).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(svgImgData)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		s := NewImageStore(viper.New(), b, hc, nil)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, svgImgID, imageID)
+		b.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("error downloading image", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		hc := &tests.HTTPClientMock{}
+		req, _ := http.NewRequest("GET", svgImgURL, nil)
+		hc.On("Do", req).Return(nil, tests.ErrFake)
+		s := NewImageStore(viper.New(), b, hc, nil)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Equal(t, "", imageID)
+		b.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("image found in cache, no need to download it", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, svgImgID+"/1x").Return(true, nil)
+		hc := &tests.HTTPClientMock{}
+		s := NewImageStore(viper.New(), b, hc, nil)
+		s.imagesCache.Add(svgImgURL, svgImgData)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, svgImgID, imageID)
+		b.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("image found in persistent cache, no need to download it", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.fetch.cacheTTL", "24h")
+		b := &BucketMock{}
+		ui := fmt.Sprintf(`{"image_id": "%s", "updated_at": %d}`, svgImgID, time.Now().Unix())
+		b.On("Get", ctx, urlKey(svgImgURL)).Return([]byte(ui), nil)
+		hc := &tests.HTTPClientMock{}
+		s := NewImageStore(cfg, b, hc, nil)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, svgImgID, imageID)
+		b.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("image in persistent cache expired, it's downloaded and cached again", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.fetch.cacheTTL", "24h")
+		b := &BucketMock{}
+		ui := fmt.Sprintf(`{"image_id": "%s", "updated_at": %d}`, svgImgID, time.Now().Add(-48*time.Hour).Unix())
+		b.On("Get", ctx, urlKey(svgImgURL)).Return([]byte(ui), nil)
+		b.On("Exists", ctx, svgImgID+"/1x").Return(true, nil)
+		b.On("Put", ctx, urlKey(svgImgURL), mock.Anything, "application/json").Return(nil)
+		hc := &tests.HTTPClientMock{}
+		req, _ := http.NewRequest("GET", svgImgURL, nil)
+		hc.On("Do", req).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(svgImgData)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		s := NewImageStore(cfg, b, hc, nil)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, svgImgID, imageID)
+		b.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestGetImage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("existing image version", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Get", ctx, "imageID/2x").Return([]byte("image2xData"), nil)
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		data, err := s.GetImage(ctx, "imageID", "2x")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte("image2xData"), data)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("version not provided, default version returned", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Get", ctx, "imageID/1x").Return([]byte("image1xData"), nil)
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		data, err := s.GetImage(ctx, "imageID", "")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte("image1xData"), data)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("version not available, default version returned", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Get", ctx, "imageID/svg").Return(nil, hub.ErrNotFound)
+		b.On("Get", ctx, "imageID/1x").Return([]byte("image1xData"), nil)
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		data, err := s.GetImage(ctx, "imageID", "svg")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte("image1xData"), data)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("image variant not available, no fallback version", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Get", ctx, "imageID/2x.webp").Return(nil, hub.ErrNotFound)
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		data, err := s.GetImage(ctx, "imageID", "2x.webp")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, data)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("image not found in bucket, legacy store used", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Get", ctx, "imageID/2x").Return(nil, hub.ErrNotFound)
+		b.On("Get", ctx, "imageID/1x").Return(nil, hub.ErrNotFound)
+		legacy := &img.StoreMock{}
+		legacy.On("GetImage", ctx, "imageID", "2x").Return([]byte("legacyData"), nil)
+		s := NewImageStore(viper.New(), b, nil, legacy)
+
+		data, err := s.GetImage(ctx, "imageID", "2x")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte("legacyData"), data)
+		b.AssertExpectations(t)
+		legacy.AssertExpectations(t)
+	})
+
+	t.Run("image not found", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Get", ctx, "imageID/1x").Return(nil, hub.ErrNotFound)
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		data, err := s.GetImage(ctx, "imageID", "1x")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, data)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("bucket error", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Get", ctx, "imageID/2x").Return(nil, tests.ErrFake)
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		data, err := s.GetImage(ctx, "imageID", "2x")
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, data)
+		b.AssertExpectations(t)
+	})
+}
+
+func TestImageURL(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	cfg.Set("images.publicURL", "https://cdn.artifacthub.io")
+
+	t.Run("public url not configured", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		u, err := s.ImageURL(ctx, "imageID", "2x")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "", u)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("image available in bucket, existence check cached", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, "imageID/2x").Return(true, nil).Once()
+		s := NewImageStore(cfg, b, nil, nil)
+
+		for i := 0; i < 2; i++ {
+			u, err := s.ImageURL(ctx, "imageID", "2x")
+			assert.Equal(t, nil, err)
+			assert.Equal(t, "https://cdn.artifacthub.io/imageID/2x", u)
+		}
+		b.AssertExpectations(t)
+	})
+
+	t.Run("version not available, default version used", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, "imageID/svg").Return(false, nil)
+		b.On("Exists", ctx, "imageID/1x").Return(true, nil)
+		s := NewImageStore(cfg, b, nil, nil)
+
+		u, err := s.ImageURL(ctx, "imageID", "svg")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "https://cdn.artifacthub.io/imageID/1x", u)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("image not available in bucket", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, "imageID/1x").Return(false, nil)
+		s := NewImageStore(cfg, b, nil, nil)
+
+		u, err := s.ImageURL(ctx, "imageID", "")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "", u)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("bucket error", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, "imageID/1x").Return(false, tests.ErrFake)
+		s := NewImageStore(cfg, b, nil, nil)
+
+		u, err := s.ImageURL(ctx, "imageID", "1x")
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Equal(t, "", u)
+		b.AssertExpectations(t)
+	})
+}
+
+func TestSaveImage(t *testing.T) {
+	ctx := context.Background()
+	pngImgData, err := ioutil.ReadFile("testdata/image.png")
+	require.NoError(t, err)
+	pngImgID := testImageID(pngImgData)
+	svgImgData, err := ioutil.ReadFile("testdata/image.svg")
+	require.NoError(t, err)
+	svgImgID := testImageID(svgImgData)
+
+	t.Run("successful png image registration", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, pngImgID+"/1x").Return(false, nil)
+		for _, version := range []string{"1x", "2x", "3x", "4x"} {
+			b.On("Put", ctx, pngImgID+"/"+version, mock.Anything, "image/png").Return(nil)
+		}
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		imageID, err := s.SaveImage(ctx, pngImgData)
+		require.NoError(t, err)
+		assert.Equal(t, pngImgID, imageID)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("successful png image registration including variants", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, pngImgID+"/1x").Return(false, nil)
+		for _, version := range []string{"1x", "2x", "3x", "4x"} {
+			b.On("Put", ctx, pngImgID+"/"+version, mock.Anything, "image/png").Return(nil)
+			b.On("Put", ctx, pngImgID+"/"+version+".avif", []byte("avifData"), "image/avif").Return(nil)
+		}
+		e := &img.EncoderMock{}
+		e.On("Format").Return(img.AVIF)
+		e.On("Encode", ctx, mock.Anything).Return([]byte("avifData"), nil)
+		s := NewImageStore(viper.New(), b, nil, nil)
+		s.encoders = []img.Encoder{e}
+
+		imageID, err := s.SaveImage(ctx, pngImgData)
+		require.NoError(t, err)
+		assert.Equal(t, pngImgID, imageID)
+		b.AssertExpectations(t)
+		e.AssertExpectations(t)
+	})
+
+	t.Run("successful svg image registration", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, svgImgID+"/1x").Return(false, nil)
+		for _, version := range []string{"1x", "2x", "3x", "4x"} {
+			b.On("Put", ctx, svgImgID+"/"+version, svgImgData, "image/svg+xml").Return(nil)
+		}
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		imageID, err := s.SaveImage(ctx, svgImgData)
+		require.NoError(t, err)
+		assert.Equal(t, svgImgID, imageID)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("svg image sanitized before being stored", func(t *testing.T) {
+		t.Parallel()
+		unsafeSVGImgData := []byte(`<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"><g/></svg>`)
+		unsafeSVGImgID := testImageID(unsafeSVGImgData)
+		b := &BucketMock{}
+		b.On("Exists", ctx, unsafeSVGImgID+"/1x").Return(false, nil)
+		for _, version := range []string{"1x", "2x", "3x", "4x"} {
+			b.On("Put", ctx, unsafeSVGImgID+"/"+version, []byte(`<svg xmlns="http://www.w3.org/2000/svg"><g></g></svg>`), "image/svg+xml").Return(nil)
+		}
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		imageID, err := s.SaveImage(ctx, unsafeSVGImgData)
+		require.NoError(t, err)
+		assert.Equal(t, unsafeSVGImgID, imageID)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("try to register existing png image", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, pngImgID+"/1x").Return(true, nil)
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		imageID, err := s.SaveImage(ctx, pngImgData)
+		require.NoError(t, err)
+		assert.Equal(t, pngImgID, imageID)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("bucket error checking if image exists", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, pngImgID+"/1x").Return(false, tests.ErrFake)
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		imageID, err := s.SaveImage(ctx, pngImgData)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Empty(t, imageID)
+		b.AssertExpectations(t)
+	})
+
+	t.Run("bucket error storing image version", func(t *testing.T) {
+		t.Parallel()
+		b := &BucketMock{}
+		b.On("Exists", ctx, pngImgID+"/1x").Return(false, nil)
+		b.On("Put", ctx, pngImgID+"/4x", mock.Anything, "image/png").Return(tests.ErrFake)
+		s := NewImageStore(viper.New(), b, nil, nil)
+
+		imageID, err := s.SaveImage(ctx, pngImgData)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Empty(t, imageID)
+		b.AssertExpectations(t)
+	})
+}
+
+func testImageID(data []byte) string {
+	sum := sha256.Sum256(data)
+	return uuid.NewV5(imageIDNamespace, hex.EncodeToString(sum[:])).String()
+}
@@ -0,0 +1,125 @@
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/awsauth"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/spf13/viper"
+)
+
+// s3Service represents the service name used to sign S3 requests.
+const s3Service = "s3"
+
+// S3Bucket is a Bucket implementation that stores objects in AWS S3 or in
+// any other S3 compatible service.
+type S3Bucket struct {
+	hc      img.HTTPClient
+	baseURL string
+	signer  *awsauth.Signer
+}
+
+// NewS3Bucket creates a new S3Bucket instance using the configuration
+// available under the key provided (i.e. images.s3). When no credentials are
+// provided in the configuration, the AWS SDK default credentials chain will
+// be used. A custom endpoint can be provided to use S3 compatible services.
+func NewS3Bucket(cfg *viper.Viper, key string, hc img.HTTPClient) (*S3Bucket, error) {
+	bucket := cfg.GetString(key + ".bucket")
+	if bucket == "" {
+		return nil, errors.New("s3 bucket not provided")
+	}
+	region := cfg.GetString(key + ".region")
+	if region == "" {
+		return nil, errors.New("s3 region not provided")
+	}
+	signer, err := awsauth.NewSigner(cfg, key, region, s3Service)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up s3 signer: %w", err)
+	}
+	b := &S3Bucket{
+		hc:     hc,
+		signer: signer,
+	}
+	if endpoint := cfg.GetString(key + ".endpoint"); endpoint != "" {
+		b.baseURL = fmt.Sprintf("%s/%s", strings.TrimSuffix(endpoint, "/"), bucket)
+	} else {
+		b.baseURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, region)
+	}
+	return b, nil
+}
+
+// Exists implements the Bucket interface.
+func (b *S3Bucket) Exists(ctx context.Context, key string) (bool, error) {
+	req, _ := http.NewRequestWithContext(ctx, "HEAD", b.baseURL+"/"+key, nil)
+	if err := b.sign(req, nil); err != nil {
+		return false, err
+	}
+	resp, err := b.hc.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+}
+
+// Get implements the Bucket interface.
+func (b *S3Bucket) Get(ctx context.Context, key string) ([]byte, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", b.baseURL+"/"+key, nil)
+	if err := b.sign(req, nil); err != nil {
+		return nil, err
+	}
+	resp, err := b.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return ioutil.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, hub.ErrNotFound
+	default:
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+}
+
+// Put implements the Bucket interface.
+func (b *S3Bucket) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	req, _ := http.NewRequestWithContext(ctx, "PUT", b.baseURL+"/"+key, bytes.NewReader(data))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Cache-Control", cacheControl)
+	if err := b.sign(req, data); err != nil {
+		return err
+	}
+	resp, err := b.hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code received: %d (%s)", resp.StatusCode, body)
+	}
+	return nil
+}
+
+// sign signs the request provided, which will send the payload given. S3
+// requires the payload hash to be provided in its own header as well.
+func (b *S3Bucket) sign(req *http.Request, payload []byte) error {
+	req.Header.Set("X-Amz-Content-Sha256", awsauth.PayloadHash(payload))
+	return b.signer.Sign(req.Context(), req, payload)
+}
@@ -0,0 +1,231 @@
+package objstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/awsauth"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewS3Bucket(t *testing.T) {
+	t.Run("bucket not provided", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.s3.region", "us-east-1")
+
+		b, err := NewS3Bucket(cfg, "images.s3", nil)
+		assert.Error(t, err)
+		assert.Nil(t, b)
+	})
+
+	t.Run("region not provided", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.s3.bucket", "bucket1")
+
+		b, err := NewS3Bucket(cfg, "images.s3", nil)
+		assert.Error(t, err)
+		assert.Nil(t, b)
+	})
+
+	t.Run("aws bucket", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.s3.bucket", "bucket1")
+		cfg.Set("images.s3.region", "us-east-1")
+		cfg.Set("images.s3.accessKeyID", "AKID")
+		cfg.Set("images.s3.secretAccessKey", "secret")
+
+		b, err := NewS3Bucket(cfg, "images.s3", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "https://bucket1.s3.us-east-1.amazonaws.com", b.baseURL)
+	})
+
+	t.Run("s3 compatible service bucket", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.s3.bucket", "bucket1")
+		cfg.Set("images.s3.region", "us-east-1")
+		cfg.Set("images.s3.endpoint", "http://minio:9000/")
+		cfg.Set("images.s3.accessKeyID", "AKID")
+		cfg.Set("images.s3.secretAccessKey", "secret")
+
+		b, err := NewS3Bucket(cfg, "images.s3", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "http://minio:9000/bucket1", b.baseURL)
+	})
+}
+
+func TestS3BucketExists(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		statusCode     int
+		expectedExists bool
+		expectedError  bool
+	}{
+		{http.StatusOK, true, false},
+		{http.StatusNotFound, false, false},
+		{http.StatusForbidden, false, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(http.StatusText(tc.statusCode), func(t *testing.T) {
+			t.Parallel()
+			hc := &tests.HTTPClientMock{}
+			hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.Method == "HEAD" &&
+					req.URL.String() == "https://bucket1.s3.us-east-1.amazonaws.com/imageID/1x"
+			})).Return(&http.Response{
+				StatusCode: tc.statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil)
+			b := newTestS3Bucket(hc)
+
+			exists, err := b.Exists(ctx, "imageID/1x")
+			assert.Equal(t, tc.expectedExists, exists)
+			assert.Equal(t, tc.expectedError, err != nil)
+			hc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestS3BucketGet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("object returned", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Method == "GET" &&
+				req.URL.String() == "https://bucket1.s3.us-east-1.amazonaws.com/imageID/1x"
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("imageData")),
+		}, nil)
+		b := newTestS3Bucket(hc)
+
+		data, err := b.Get(ctx, "imageID/1x")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("imageData"), data)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("object not found", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil)
+		b := newTestS3Bucket(hc)
+
+		data, err := b.Get(ctx, "imageID/1x")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, data)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("request failed", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
+		b := newTestS3Bucket(hc)
+
+		data, err := b.Get(ctx, "imageID/1x")
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, data)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestS3BucketPut(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("imageData")
+	dataHash := sha256.Sum256(data)
+
+	t.Run("signed object uploaded", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			body, _ := ioutil.ReadAll(req.Body)
+			return req.Method == "PUT" &&
+				req.URL.String() == "https://bucket1.s3.us-east-1.amazonaws.com/imageID/1x" &&
+				req.Header.Get("Content-Type") == "image/png" &&
+				req.Header.Get("Cache-Control") == cacheControl &&
+				req.Header.Get("X-Amz-Date") != "" &&
+				req.Header.Get("X-Amz-Content-Sha256") == hex.EncodeToString(dataHash[:]) &&
+				strings.HasPrefix(req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") &&
+				strings.Contains(req.Header.Get("Authorization"), "/us-east-1/s3/aws4_request, ") &&
+				strings.Contains(req.Header.Get("Authorization"), "x-amz-content-sha256;x-amz-date") &&
+				string(body) == "imageData"
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil)
+		b := newTestS3Bucket(hc)
+
+		err := b.Put(ctx, "imageID/1x", data, "image/png")
+		assert.NoError(t, err)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			StatusCode: http.StatusForbidden,
+			Body:       ioutil.NopCloser(strings.NewReader("AccessDenied")),
+		}, nil)
+		b := newTestS3Bucket(hc)
+
+		err := b.Put(ctx, "imageID/1x", data, "image/png")
+		assert.EqualError(t, err, "unexpected status code received: 403 (AccessDenied)")
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("session token included in signature", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Header.Get("X-Amz-Security-Token") == "token" &&
+				strings.Contains(req.Header.Get("Authorization"), "x-amz-security-token")
+		})).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil)
+		b := newTestS3Bucket(hc)
+		b.signer = awsauth.NewSignerWithCredentials(
+			credentials.NewStaticCredentialsProvider("AKID", "secret", "token"),
+			"us-east-1",
+			s3Service,
+		)
+
+		err := b.Put(ctx, "imageID/1x", data, "image/png")
+		assert.NoError(t, err)
+		hc.AssertExpectations(t)
+	})
+}
+
+func newTestS3Bucket(hc *tests.HTTPClientMock) *S3Bucket {
+	return &S3Bucket{
+		hc:      hc,
+		baseURL: "https://bucket1.s3.us-east-1.amazonaws.com",
+		signer: awsauth.NewSignerWithCredentials(
+			credentials.NewStaticCredentialsProvider("AKID", "secret", ""),
+			"us-east-1",
+			s3Service,
+		),
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"crypto/sha256"
 	"errors"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/img"
 	svg "github.com/h2non/go-is-svg"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/viper"
 )
@@ -18,7 +21,15 @@ const (
 	// Database queries
 	getImageDBQ      = `select get_image($1::uuid, $2::text)`
 	getImageIDDBQ    = `select image_id from image where original_hash = $1`
+	getURLImageDBQ   = `select image_id from image_url where url_digest = $1 and updated_at > $2`
+	getVariantDBQ    = `select data from image_version where image_id = $1::uuid and version = $2::text`
 	registerImageDBQ = `select register_image($1::bytea, $2::text, $3::bytea)`
+	setURLImageDBQ   = `
+	insert into image_url (url_digest, image_id) values ($1, $2::uuid)
+	on conflict (url_digest) do update set
+		image_id = excluded.image_id,
+		updated_at = current_timestamp
+	`
 
 	// Cache
 	cacheSize = 250
@@ -26,6 +37,7 @@ const (
 
 // DB defines the methods the database handler must provide.
 type DB interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 }
 
@@ -35,6 +47,9 @@ type ImageStore struct {
 	cfg         *viper.Viper
 	db          DB
 	hc          img.HTTPClient
+	maxSize     int64
+	cacheTTL    time.Duration
+	encoders    []img.Encoder
 	imagesCache *lru.Cache
 	errorsCache *lru.Cache
 	mutexes     sync.Map
@@ -48,10 +63,17 @@ func NewImageStore(
 ) *ImageStore {
 	imagesCache, _ := lru.New(cacheSize)
 	errorsCache, _ := lru.New(cacheSize)
+	var cacheTTL time.Duration
+	if cfg != nil {
+		cacheTTL = cfg.GetDuration("images.fetch.cacheTTL")
+	}
 	return &ImageStore{
 		cfg:         cfg,
 		db:          db,
 		hc:          hc,
+		maxSize:     img.MaxSize(cfg),
+		cacheTTL:    cacheTTL,
+		encoders:    img.SetupEncoders(cfg),
 		imagesCache: imagesCache,
 		errorsCache: errorsCache,
 	}
@@ -65,9 +87,18 @@ func (s *ImageStore) DownloadAndSaveImage(ctx context.Context, imageURL string)
 	imageMu.Lock()
 	defer imageMu.Unlock()
 
+	// Check if the image was downloaded recently, even by a previous run, to
+	// avoid hitting the source
+	imageID, err := s.getURLImage(ctx, imageURL)
+	if err != nil {
+		return "", err
+	}
+	if imageID != "" {
+		return imageID, nil
+	}
+
 	// Try to get image data from the cache to avoid hitting the source
 	var data []byte
-	var err error
 	cachedImage, ok := s.imagesCache.Get(imageURL)
 	if ok {
 		data = cachedImage.([]byte)
@@ -80,7 +111,7 @@ func (s *ImageStore) DownloadAndSaveImage(ctx context.Context, imageURL string)
 		}
 
 		// Download it from source and store it in the cache.
-		data, err = img.Download(ctx, s.hc, imageURL)
+		data, err = img.Download(ctx, s.hc, imageURL, s.maxSize)
 		if err != nil {
 			s.errorsCache.Add(imageURL, err)
 			return "", err
@@ -89,13 +120,25 @@ func (s *ImageStore) DownloadAndSaveImage(ctx context.Context, imageURL string)
 	}
 
 	// Store image in the database
-	return s.SaveImage(ctx, data)
+	imageID, err = s.SaveImage(ctx, data)
+	if err != nil {
+		return "", err
+	}
+	if err := s.setURLImage(ctx, imageURL, imageID); err != nil {
+		return "", err
+	}
+	return imageID, nil
 }
 
-// GetImage returns an image stored in the database.
+// GetImage returns an image stored in the database. Variants in additional
+// formats are only returned when available, as they have no fallback version.
 func (s *ImageStore) GetImage(ctx context.Context, imageID, version string) ([]byte, error) {
+	query := getImageDBQ
+	if img.IsVariant(version) {
+		query = getVariantDBQ
+	}
 	var data []byte
-	err := s.db.QueryRow(ctx, getImageDBQ, imageID, version).Scan(&data)
+	err := s.db.QueryRow(ctx, query, imageID, version).Scan(&data)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, hub.ErrNotFound
@@ -120,17 +163,23 @@ func (s *ImageStore) SaveImage(ctx context.Context, data []byte) (string, error)
 		return imageID, nil
 	}
 
-	// If image format is svg register it as is in database, as this format
-	// doesn't require to store additional size specific versions
+	// If image format is svg register it in database once sanitized, as this
+	// format doesn't require to store additional size specific versions
 	if svg.Is(data) {
+		data, err = img.SanitizeSVG(data)
+		if err != nil {
+			return "", err
+		}
 		return s.registerImage(ctx, originalHash, "svg", data)
 	}
 
-	// Generate image versions of different sizes and store them
+	// Generate image versions of different sizes, as well as their variants in
+	// the additional formats enabled, and store them
 	imageVersions, err := img.GenerateVersions(data)
 	if err != nil {
 		return "", err
 	}
+	imageVersions = append(imageVersions, img.GenerateVariants(ctx, imageVersions, s.encoders)...)
 	for _, v := range imageVersions {
 		imageID, err = s.registerImage(ctx, originalHash, v.Version, v.Data)
 		if err != nil {
@@ -164,3 +213,30 @@ func (s *ImageStore) registerImage(ctx context.Context, hash []byte, version str
 	}
 	return imageID, nil
 }
+
+// getURLImage returns the id of the image downloaded from the url provided, as
+// long as it was downloaded within the configured cache ttl. An empty string
+// is returned when it's not available.
+func (s *ImageStore) getURLImage(ctx context.Context, imageURL string) (string, error) {
+	if s.cacheTTL == 0 || strings.HasPrefix(imageURL, "data:") {
+		return "", nil
+	}
+	var imageID string
+	urlDigest := sha256.Sum256([]byte(imageURL))
+	err := s.db.QueryRow(ctx, getURLImageDBQ, urlDigest[:], time.Now().Add(-s.cacheTTL)).Scan(&imageID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", err
+	}
+	return imageID, nil
+}
+
+// setURLImage records the id of the image downloaded from the url provided,
+// so that it can be reused until the configured cache ttl expires.
+func (s *ImageStore) setURLImage(ctx context.Context, imageURL, imageID string) error {
+	if s.cacheTTL == 0 || strings.HasPrefix(imageURL, "data:") {
+		return nil
+	}
+	urlDigest := sha256.Sum256([]byte(imageURL))
+	_, err := s.db.Exec(ctx, setURLImageDBQ, urlDigest[:], imageID)
+	return err
+}
@@ -9,6 +9,8 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/viper"
@@ -36,6 +38,8 @@ func TestDownloadAndSaveImage(t *testing.T) {
 	require.NoError(t, err)
 	sumSvgImg := sha256.Sum256(svgImgData)
 	svgImgHash := sumSvgImg[:]
+	sumSvgImgURL := sha256.Sum256([]byte(svgImgURL))
+	svgImgURLDigest := sumSvgImgURL[:]
 
 	t.Run("image not found in cache, it needs to be downloaded", func(t *testing.T) {
 		t.Parallel()
@@ -88,6 +92,62 @@ func TestDownloadAndSaveImage(t *testing.T) {
 		hc.AssertExpectations(t)
 	})
 
+	t.Run("image found in persistent cache, no need to download it", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.fetch.cacheTTL", "24h")
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getURLImageDBQ, svgImgURLDigest, mock.Anything).Return("svgImgID", nil)
+		hc := &tests.HTTPClientMock{}
+		s := NewImageStore(cfg, db, hc)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "svgImgID", imageID)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("image not found in persistent cache, it's downloaded and cached", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.fetch.cacheTTL", "24h")
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getURLImageDBQ, svgImgURLDigest, mock.Anything).Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, getImageIDDBQ, svgImgHash).Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, registerImageDBQ, svgImgHash, "svg", svgImgData).Return("svgImgID", nil)
+		db.On("Exec", ctx, setURLImageDBQ, svgImgURLDigest, "svgImgID").Return(nil)
+		hc := &tests.HTTPClientMock{}
+		req, _ := http.NewRequest("GET", svgImgURL, nil)
+		hc.On("Do", req).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(svgImgData)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		s := NewImageStore(cfg, db, hc)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "svgImgID", imageID)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("database error checking persistent cache", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.fetch.cacheTTL", "24h")
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getURLImageDBQ, svgImgURLDigest, mock.Anything).Return(nil, tests.ErrFakeDB)
+		hc := &tests.HTTPClientMock{}
+		s := NewImageStore(cfg, db, hc)
+
+		imageID, err := s.DownloadAndSaveImage(ctx, svgImgURL)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Equal(t, "", imageID)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
 	t.Run("multiple goroutines calling simultaneously, image is downloaded and saved once", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
@@ -156,6 +216,30 @@ func TestGetImage(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("existing image variant", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getVariantDBQ, "imageID", "2x.webp").Return([]byte("image2xWebPData"), nil)
+		s := NewImageStore(nil, db, nil)
+
+		data, err := s.GetImage(ctx, "imageID", "2x.webp")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte("image2xWebPData"), data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("image variant not available", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getVariantDBQ, "imageID", "2x.webp").Return(nil, pgx.ErrNoRows)
+		s := NewImageStore(nil, db, nil)
+
+		data, err := s.GetImage(ctx, "imageID", "2x.webp")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, data)
+		db.AssertExpectations(t)
+	})
+
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
@@ -195,6 +279,28 @@ func TestSaveImage(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("successful png image registration including variants", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageIDDBQ, pngImgHash).Return(nil, pgx.ErrNoRows)
+		for _, version := range []string{"1x", "2x", "3x", "4x"} {
+			db.On("QueryRow", ctx, registerImageDBQ, pngImgHash, version, mock.Anything).Return("pngImgID", nil)
+			db.On("QueryRow", ctx, registerImageDBQ, pngImgHash, version+".webp", []byte("webpData")).
+				Return("pngImgID", nil)
+		}
+		e := &img.EncoderMock{}
+		e.On("Format").Return(img.WebP)
+		e.On("Encode", ctx, mock.Anything).Return([]byte("webpData"), nil)
+		s := NewImageStore(nil, db, nil)
+		s.encoders = []img.Encoder{e}
+
+		imageID, err := s.SaveImage(ctx, pngImgData)
+		require.NoError(t, err)
+		assert.Equal(t, "pngImgID", imageID)
+		db.AssertExpectations(t)
+		e.AssertExpectations(t)
+	})
+
 	t.Run("successful svg image registration", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
@@ -208,6 +314,23 @@ func TestSaveImage(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("svg image sanitized before being registered", func(t *testing.T) {
+		t.Parallel()
+		unsafeSVGImgData := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script><g/></svg>`)
+		sumUnsafeSVGImg := sha256.Sum256(unsafeSVGImgData)
+		unsafeSVGImgHash := sumUnsafeSVGImg[:]
+		sanitizedSVGImgData := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><g></g></svg>`)
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageIDDBQ, unsafeSVGImgHash).Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, registerImageDBQ, unsafeSVGImgHash, "svg", sanitizedSVGImgData).Return("svgImgID", nil)
+		s := NewImageStore(nil, db, nil)
+
+		imageID, err := s.SaveImage(ctx, unsafeSVGImgData)
+		require.NoError(t, err)
+		assert.Equal(t, "svgImgID", imageID)
+		db.AssertExpectations(t)
+	})
+
 	t.Run("try to register existing png image", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
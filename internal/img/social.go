@@ -0,0 +1,163 @@
+package img
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// SocialImageWidth represents the width of the social preview images.
+	SocialImageWidth = 1200
+
+	// SocialImageHeight represents the height of the social preview images.
+	SocialImageHeight = 630
+
+	socialImagePadding  = 80
+	socialImageLogoSize = 240
+)
+
+var (
+	boldFont    = mustParseFont(gobold.TTF)
+	regularFont = mustParseFont(goregular.TTF)
+)
+
+// SocialImageInput represents the information used to render a social preview
+// image.
+type SocialImageInput struct {
+	Title           string
+	Subtitle        string
+	Version         string
+	Stars           int
+	Logo            []byte
+	BackgroundColor string
+}
+
+// GenerateSocialImage renders a social preview image in PNG format using the
+// information provided. The logo is optional and it'll be omitted when it
+// cannot be decoded (i.e. SVG logos).
+func GenerateSocialImage(input *SocialImageInput) ([]byte, error) {
+	bg, err := parseHexColor(input.BackgroundColor)
+	if err != nil {
+		return nil, err
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, SocialImageWidth, SocialImageHeight))
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{C: bg}, image.Point{}, draw.Src)
+
+	// Draw logo (if available) on a white square
+	textX := socialImagePadding
+	if len(input.Logo) > 0 {
+		if logo, err := imaging.Decode(bytes.NewReader(input.Logo)); err == nil {
+			y := (SocialImageHeight - socialImageLogoSize) / 2
+			box := image.Rect(socialImagePadding, y, socialImagePadding+socialImageLogoSize, y+socialImageLogoSize)
+			draw.Draw(dst, box, image.White, image.Point{}, draw.Src)
+			logo = imaging.Fit(logo, socialImageLogoSize-40, socialImageLogoSize-40, imaging.Lanczos)
+			offset := image.Pt(
+				box.Min.X+(socialImageLogoSize-logo.Bounds().Dx())/2,
+				box.Min.Y+(socialImageLogoSize-logo.Bounds().Dy())/2,
+			)
+			draw.Draw(dst, logo.Bounds().Add(offset), logo, logo.Bounds().Min, draw.Over)
+			textX = box.Max.X + socialImagePadding
+		}
+	}
+
+	// Draw texts
+	maxWidth := SocialImageWidth - textX - socialImagePadding
+	texts := []struct {
+		f    *opentype.Font
+		size float64
+		text string
+		y    int
+	}{
+		{boldFont, 64, input.Title, 250},
+		{regularFont, 36, input.Subtitle, 320},
+		{regularFont, 36, buildSocialImageDetails(input), 420},
+	}
+	for _, t := range texts {
+		face, err := opentype.NewFace(t.f, &opentype.FaceOptions{
+			Size:    t.size,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			return nil, err
+		}
+		d := &font.Drawer{
+			Dst:  dst,
+			Src:  image.White,
+			Face: face,
+			Dot:  fixed.P(textX, t.y),
+		}
+		d.DrawString(truncateText(d, t.text, maxWidth))
+		face.Close()
+	}
+
+	// Encode image
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// buildSocialImageDetails builds the details line displayed in the social
+// preview image from the input provided.
+func buildSocialImageDetails(input *SocialImageInput) string {
+	var details []string
+	if input.Version != "" {
+		details = append(details, "v"+strings.TrimPrefix(input.Version, "v"))
+	}
+	details = append(details, fmt.Sprintf("%d stars", input.Stars))
+	return strings.Join(details, "  ·  ")
+}
+
+// truncateText truncates the text provided so that it fits in the maximum
+// width using the drawer's face.
+func truncateText(d *font.Drawer, text string, maxWidth int) string {
+	if d.MeasureString(text).Ceil() <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		truncated := string(runes) + "…"
+		if d.MeasureString(truncated).Ceil() <= maxWidth {
+			return truncated
+		}
+	}
+	return ""
+}
+
+// parseHexColor parses the hex color provided (i.e. #417598).
+func parseHexColor(s string) (color.Color, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return nil, fmt.Errorf("invalid hex color: %s", s)
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex color: %s", s)
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
+
+// mustParseFont parses the font provided, panicking if it's not valid.
+func mustParseFont(data []byte) *opentype.Font {
+	f, err := opentype.Parse(data)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
@@ -0,0 +1,58 @@
+package img
+
+import (
+	"bytes"
+	"image/png"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSocialImage(t *testing.T) {
+	t.Run("invalid background color", func(t *testing.T) {
+		t.Parallel()
+		data, err := GenerateSocialImage(&SocialImageInput{
+			Title:           "pkg1",
+			BackgroundColor: "invalid",
+		})
+		assert.Error(t, err)
+		assert.Nil(t, data)
+	})
+
+	t.Run("social image generated successfully", func(t *testing.T) {
+		logo, err := ioutil.ReadFile("testdata/valid.png")
+		require.NoError(t, err)
+		invalidLogo, err := ioutil.ReadFile("testdata/invalid.png")
+		require.NoError(t, err)
+
+		testCases := []struct {
+			name string
+			logo []byte
+		}{
+			{"without logo", nil},
+			{"with valid logo", logo},
+			{"with invalid logo", invalidLogo},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				data, err := GenerateSocialImage(&SocialImageInput{
+					Title:           "a-package-with-a-really-long-name-that-will-not-fit-in-the-image",
+					Subtitle:        "org1/repo1",
+					Version:         "1.0.0",
+					Stars:           10,
+					Logo:            tc.logo,
+					BackgroundColor: "#417598",
+				})
+				require.NoError(t, err)
+				img, err := png.Decode(bytes.NewReader(data))
+				require.NoError(t, err)
+				assert.Equal(t, SocialImageWidth, img.Bounds().Dx())
+				assert.Equal(t, SocialImageHeight, img.Bounds().Dy())
+			})
+		}
+	})
+}
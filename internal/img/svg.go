@@ -0,0 +1,126 @@
+package img
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// svgUnsafeElements represents the svg elements that will be removed, along
+// with all their content, when sanitizing svg images.
+var svgUnsafeElements = map[string]struct{}{
+	"embed":         {},
+	"foreignobject": {},
+	"handler":       {},
+	"iframe":        {},
+	"listener":      {},
+	"object":        {},
+	"script":        {},
+}
+
+// svgSafeHrefRE represents a regular expression used to check if the value of
+// an href attribute is safe, allowing only local references and embedded
+// raster images.
+var svgSafeHrefRE = regexp.MustCompile(`^(#|data:image/(png|jpeg|gif|webp)[;,])`)
+
+// SanitizeSVG removes from the svg image provided any content that could be
+// used to run scripts or load external resources when the image is rendered
+// by a browser. When no unsafe content is found, the original data is
+// returned untouched.
+func SanitizeSVG(data []byte) ([]byte, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+
+	var out bytes.Buffer
+	var modified bool
+	var skipDepth int
+	for {
+		token, err := d.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing svg image: %w", err)
+		}
+
+		// Skip unsafe elements content
+		if skipDepth > 0 {
+			switch token.(type) {
+			case xml.StartElement:
+				skipDepth++
+			case xml.EndElement:
+				skipDepth--
+			}
+			continue
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if _, ok := svgUnsafeElements[strings.ToLower(t.Name.Local)]; ok {
+				modified = true
+				skipDepth = 1
+				continue
+			}
+			out.WriteString("<" + svgName(t.Name))
+			for _, attr := range t.Attr {
+				if !isSafeSVGAttr(attr) {
+					modified = true
+					continue
+				}
+				out.WriteString(" " + svgName(attr.Name) + `="`)
+				_ = xml.EscapeText(&out, []byte(attr.Value))
+				out.WriteString(`"`)
+			}
+			out.WriteString(">")
+		case xml.EndElement:
+			out.WriteString("</" + svgName(t.Name) + ">")
+		case xml.CharData:
+			_ = xml.EscapeText(&out, t)
+		case xml.ProcInst:
+			if t.Target != "xml" {
+				modified = true
+				continue
+			}
+			out.WriteString("<?xml " + string(t.Inst) + "?>")
+		case xml.Comment, xml.Directive:
+			// Comments are dropped as they are not needed to render the image,
+			// and directives may declare entities pointing to external
+			// resources.
+			modified = true
+		}
+	}
+
+	if !modified {
+		return data, nil
+	}
+	return out.Bytes(), nil
+}
+
+// isSafeSVGAttr checks if the svg attribute provided is safe to be kept when
+// sanitizing svg images.
+func isSafeSVGAttr(attr xml.Attr) bool {
+	name := strings.ToLower(attr.Name.Local)
+	value := strings.ToLower(strings.Join(strings.Fields(attr.Value), ""))
+	switch {
+	case strings.HasPrefix(name, "on"):
+		return false
+	case name == "href":
+		return svgSafeHrefRE.MatchString(value)
+	case strings.Contains(value, "javascript:"):
+		return false
+	}
+	return true
+}
+
+// svgName returns the qualified name of the svg element or attribute provided.
+func svgName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	return name.Local
+}
@@ -0,0 +1,67 @@
+package img
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeSVG(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		input       string
+		expectedSVG string
+	}{
+		{
+			"safe image returned untouched",
+			`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 8 8"><path d="M0 0h8v8H0z" fill="#fff"/></svg>`,
+			`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 8 8"><path d="M0 0h8v8H0z" fill="#fff"/></svg>`,
+		},
+		{
+			"script elements removed",
+			`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script><path d="M0 0"/></svg>`,
+			`<svg xmlns="http://www.w3.org/2000/svg"><path d="M0 0"></path></svg>`,
+		},
+		{
+			"foreign objects removed along with their content",
+			`<svg xmlns="http://www.w3.org/2000/svg"><foreignObject><div><iframe src="https://x.y"></iframe></div></foreignObject><g/></svg>`,
+			`<svg xmlns="http://www.w3.org/2000/svg"><g></g></svg>`,
+		},
+		{
+			"event handlers removed",
+			`<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"><rect OnClick="alert(1)" width="1"/></svg>`,
+			`<svg xmlns="http://www.w3.org/2000/svg"><rect width="1"></rect></svg>`,
+		},
+		{
+			"unsafe references removed",
+			`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><a xlink:href="java&#x09;script:alert(1)"><use href="#a"/><image href="https://x.y/img.png"/><image href="data:image/png;base64,AA=="/></a></svg>`,
+			`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><a><use href="#a"></use><image></image><image href="data:image/png;base64,AA=="></image></a></svg>`,
+		},
+		{
+			"javascript urls removed from other attributes",
+			`<svg xmlns="http://www.w3.org/2000/svg"><set attributeName="href" to="javascript:alert(1)"/></svg>`,
+			`<svg xmlns="http://www.w3.org/2000/svg"><set attributeName="href"></set></svg>`,
+		},
+		{
+			"comments and directives removed",
+			`<?xml version="1.0"?><!DOCTYPE svg [<!ENTITY x SYSTEM "file:///etc/passwd">]><!-- comment --><svg xmlns="http://www.w3.org/2000/svg"><text>a &lt; b</text></svg>`,
+			`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"><text>a &lt; b</text></svg>`,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			data, err := SanitizeSVG([]byte(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSVG, string(data))
+		})
+	}
+
+	t.Run("invalid svg image", func(t *testing.T) {
+		t.Parallel()
+		_, err := SanitizeSVG([]byte(`<svg xmlns="http://www.w3.org/2000/svg"><path d="M0 0`))
+		assert.Error(t, err)
+	})
+}
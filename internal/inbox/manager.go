@@ -0,0 +1,68 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	getInboxNotificationsDBQ = `select * from get_inbox_notifications($1::uuid, $2::boolean, $3::int, $4::int)`
+	getInboxUnreadCountDBQ   = `select get_inbox_unread_count($1::uuid)`
+	markAllAsReadDBQ         = `select mark_all_inbox_notifications_as_read($1::uuid)`
+	markAsReadDBQ            = `select mark_inbox_notification_as_read($1::uuid, $2::uuid)`
+)
+
+// Manager provides an API to manage the notifications in the users' inbox.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// GetJSON returns the notifications in the inbox of the user doing the request
+// as a json array, most recent first. When unreadOnly is set, only the
+// notifications not read yet are returned.
+func (m *Manager) GetJSON(ctx context.Context, unreadOnly bool, p *hub.Pagination) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSONWithPagination(ctx, m.db, getInboxNotificationsDBQ, userID, unreadOnly, p.Limit, p.Offset)
+}
+
+// GetUnreadCountJSON returns the number of notifications not read yet in the
+// inbox of the user doing the request as a json object.
+func (m *Manager) GetUnreadCountJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSON(ctx, m.db, getInboxUnreadCountDBQ, userID)
+}
+
+// MarkAllAsRead marks all the notifications in the inbox of the user doing the
+// request as read.
+func (m *Manager) MarkAllAsRead(ctx context.Context) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	_, err := m.db.Exec(ctx, markAllAsReadDBQ, userID)
+	return err
+}
+
+// MarkAsRead marks the provided notification in the inbox of the user doing
+// the request as read.
+func (m *Manager) MarkAsRead(ctx context.Context, inboxNotificationID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(inboxNotificationID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid inbox notification id")
+	}
+
+	// Mark notification as read in database
+	_, err := m.db.Exec(ctx, markAsReadDBQ, userID, inboxNotificationID)
+	return err
+}
@@ -0,0 +1,39 @@
+package inbox
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the InboxManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// GetJSON implements the InboxManager interface.
+func (m *ManagerMock) GetJSON(ctx context.Context, unreadOnly bool, p *hub.Pagination) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, unreadOnly, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// GetUnreadCountJSON implements the InboxManager interface.
+func (m *ManagerMock) GetUnreadCountJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// MarkAllAsRead implements the InboxManager interface.
+func (m *ManagerMock) MarkAllAsRead(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// MarkAsRead implements the InboxManager interface.
+func (m *ManagerMock) MarkAsRead(ctx context.Context, inboxNotificationID string) error {
+	args := m.Called(ctx, inboxNotificationID)
+	return args.Error(0)
+}
@@ -0,0 +1,132 @@
+package issuetracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	defaultGitHubAPIURL = "https://api.github.com"
+	jiraIssueType       = "Bug"
+)
+
+// Issue represents an issue to be opened in an issue tracker.
+type Issue struct {
+	Title string
+	Body  string
+}
+
+// BuildIssue builds the issue that will be opened for the vulnerability
+// provided, found in the package described by the template data given.
+func BuildIssue(d *hub.PackageNotificationTemplateData, v *hub.IssueTrackerVulnerability) *Issue {
+	pkgName := fmt.Sprintf("%v", d.Package["Name"])
+	pkgVersion := fmt.Sprintf("%v", d.Package["Version"])
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "A critical vulnerability (%s) has been found in %s version %s.\n\n", v.VulnerabilityID, pkgName, pkgVersion)
+	if v.Title != "" {
+		fmt.Fprintf(&body, "%s\n\n", v.Title)
+	}
+	fmt.Fprintf(&body, "- Affected package: %s %s\n", v.PkgName, v.InstalledVersion)
+	if v.FixedVersion != "" {
+		fmt.Fprintf(&body, "- Fixed version: %s\n", v.FixedVersion)
+	}
+	for _, image := range v.Images {
+		fmt.Fprintf(&body, "- Image: %s\n", image)
+	}
+	if v.PrimaryURL != "" {
+		fmt.Fprintf(&body, "- Details: %s\n", v.PrimaryURL)
+	}
+	fmt.Fprintf(&body, "\nSecurity report: %v?modal=security-report\n", d.Package["URL"])
+
+	return &Issue{
+		Title: fmt.Sprintf("%s: critical vulnerability found in %s", v.VulnerabilityID, pkgName),
+		Body:  body.String(),
+	}
+}
+
+// CreateIssue opens the issue provided in the issue tracker given, returning
+// the url of the issue created.
+func CreateIssue(ctx context.Context, hc hub.HTTPClient, it *hub.IssueTracker, issue *Issue) (string, error) {
+	switch it.Kind {
+	case hub.GitHubIssueTracker:
+		return createGitHubIssue(ctx, hc, it, issue)
+	case hub.JiraIssueTracker:
+		return createJiraIssue(ctx, hc, it, issue)
+	default:
+		return "", fmt.Errorf("invalid issue tracker kind: %s", it.Kind)
+	}
+}
+
+// createGitHubIssue opens the issue provided in the GitHub repository
+// configured in the issue tracker given.
+func createGitHubIssue(ctx context.Context, hc hub.HTTPClient, it *hub.IssueTracker, issue *Issue) (string, error) {
+	baseURL := defaultGitHubAPIURL
+	if it.URL != "" {
+		baseURL = strings.TrimSuffix(it.URL, "/")
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"title":  issue.Title,
+		"body":   issue.Body,
+		"labels": it.Labels,
+	})
+	req, _ := http.NewRequestWithContext(ctx, "POST", baseURL+"/repos/"+it.Project+"/issues", bytes.NewReader(payload))
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Authorization", "token "+it.Token)
+	req.Header.Set("Content-Type", "application/json")
+	var resp struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := doRequest(hc, req, &resp); err != nil {
+		return "", err
+	}
+	return resp.HTMLURL, nil
+}
+
+// createJiraIssue opens the issue provided in the Jira project configured in
+// the issue tracker given.
+func createJiraIssue(ctx context.Context, hc hub.HTTPClient, it *hub.IssueTracker, issue *Issue) (string, error) {
+	baseURL := strings.TrimSuffix(it.URL, "/")
+	fields := map[string]interface{}{
+		"project":     map[string]string{"key": it.Project},
+		"summary":     issue.Title,
+		"description": issue.Body,
+		"issuetype":   map[string]string{"name": jiraIssueType},
+	}
+	if len(it.Labels) > 0 {
+		fields["labels"] = it.Labels
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"fields": fields,
+	})
+	req, _ := http.NewRequestWithContext(ctx, "POST", baseURL+"/rest/api/2/issue", bytes.NewReader(payload))
+	req.SetBasicAuth(it.Username, it.Token)
+	req.Header.Set("Content-Type", "application/json")
+	var resp struct {
+		Key string `json:"key"`
+	}
+	if err := doRequest(hc, req, &resp); err != nil {
+		return "", err
+	}
+	return baseURL + "/browse/" + resp.Key, nil
+}
+
+// doRequest sends the request provided, decoding the response body into the
+// value given when it succeeds.
+func doRequest(hc hub.HTTPClient, req *http.Request, v interface{}) error {
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,148 @@
+package issuetracker
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildIssue(t *testing.T) {
+	d := &hub.PackageNotificationTemplateData{
+		Package: map[string]interface{}{
+			"Name":    "pkg1",
+			"Version": "1.0.0",
+			"URL":     "http://localhost:8000/packages/helm/repo1/pkg1/1.0.0",
+		},
+	}
+	v := &hub.IssueTrackerVulnerability{
+		VulnerabilityID:  "CVE-2022-0001",
+		PkgName:          "openssl",
+		InstalledVersion: "1.1.1",
+		FixedVersion:     "1.1.2",
+		Title:            "title",
+		PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2022-0001",
+		Images:           []string{"image1"},
+	}
+
+	issue := BuildIssue(d, v)
+	assert.Equal(t, "CVE-2022-0001: critical vulnerability found in pkg1", issue.Title)
+	assert.Equal(t, `A critical vulnerability (CVE-2022-0001) has been found in pkg1 version 1.0.0.
+
+title
+
+- Affected package: openssl 1.1.1
+- Fixed version: 1.1.2
+- Image: image1
+- Details: https://avd.aquasec.com/nvd/cve-2022-0001
+
+Security report: http://localhost:8000/packages/helm/repo1/pkg1/1.0.0?modal=security-report
+`, issue.Body)
+}
+
+func TestCreateIssue(t *testing.T) {
+	ctx := context.Background()
+	issue := &Issue{Title: "title", Body: "body"}
+
+	t.Run("invalid kind", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+
+		issueURL, err := CreateIssue(ctx, hc, &hub.IssueTracker{Kind: "gitlab"}, issue)
+		assert.Error(t, err)
+		assert.Empty(t, issueURL)
+	})
+
+	t.Run("error sending request", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
+		it := &hub.IssueTracker{Kind: hub.GitHubIssueTracker, Project: "org/repo", Token: "token"}
+
+		issueURL, err := CreateIssue(ctx, hc, it, issue)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Empty(t, issueURL)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(newResponse(http.StatusUnauthorized, ""), nil)
+		it := &hub.IssueTracker{Kind: hub.GitHubIssueTracker, Project: "org/repo", Token: "token"}
+
+		issueURL, err := CreateIssue(ctx, hc, it, issue)
+		assert.EqualError(t, err, "unexpected status code received: 401")
+		assert.Empty(t, issueURL)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("github issue created", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			var payload map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&payload)
+			return req.Method == "POST" &&
+				req.URL.String() == "https://api.github.com/repos/org/repo/issues" &&
+				req.Header.Get("Authorization") == "token ghToken" &&
+				payload["title"] == "title" &&
+				payload["body"] == "body"
+		})).Return(newResponse(http.StatusCreated, `{"html_url": "https://github.com/org/repo/issues/1"}`), nil)
+		it := &hub.IssueTracker{
+			Kind:    hub.GitHubIssueTracker,
+			Project: "org/repo",
+			Labels:  []string{"security"},
+			Token:   "ghToken",
+		}
+
+		issueURL, err := CreateIssue(ctx, hc, it, issue)
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/org/repo/issues/1", issueURL)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("jira issue created", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			username, password, ok := req.BasicAuth()
+			var payload struct {
+				Fields map[string]interface{} `json:"fields"`
+			}
+			_ = json.NewDecoder(req.Body).Decode(&payload)
+			return req.Method == "POST" &&
+				req.URL.String() == "https://org.atlassian.net/rest/api/2/issue" &&
+				ok && username == "user" && password == "jiraToken" &&
+				payload.Fields["summary"] == "title" &&
+				payload.Fields["description"] == "body"
+		})).Return(newResponse(http.StatusCreated, `{"key": "PRJ-1"}`), nil)
+		it := &hub.IssueTracker{
+			Kind:     hub.JiraIssueTracker,
+			URL:      "https://org.atlassian.net/",
+			Project:  "PRJ",
+			Username: "user",
+			Token:    "jiraToken",
+		}
+
+		issueURL, err := CreateIssue(ctx, hc, it, issue)
+		require.NoError(t, err)
+		assert.Equal(t, "https://org.atlassian.net/browse/PRJ-1", issueURL)
+		hc.AssertExpectations(t)
+	})
+}
+
+func newResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
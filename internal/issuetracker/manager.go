@@ -0,0 +1,211 @@
+package issuetracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addIssueTrackerDBQ           = `select add_issue_tracker($1::uuid, $2::text, $3::jsonb)`
+	deleteIssueTrackerDBQ        = `select delete_issue_tracker($1::uuid, $2::uuid)`
+	getIssueTrackersForPkgDBQ    = `select get_issue_trackers_for_package($1::uuid, $2::text)`
+	getOrgIssueTrackersDBQ       = `select get_org_issue_trackers($1::uuid, $2::text)`
+	getPendingVulnerabilitiesDBQ = `select get_issue_tracker_pending_vulnerabilities($1::uuid, $2::uuid, $3::text)`
+	registerIssueTrackerIssueDBQ = `select register_issue_tracker_issue($1::uuid, $2::uuid, $3::text, $4::text)`
+	updateIssueTrackerDBQ        = `select update_issue_tracker($1::uuid, $2::jsonb)`
+)
+
+// githubProjectRE is a regexp used to validate GitHub projects (owner/repo).
+var githubProjectRE = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// Manager provides an API to manage issue trackers integrations.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// Add adds the provided issue tracker integration to the organization given.
+func (m *Manager) Add(ctx context.Context, orgName string, it *hub.IssueTracker) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if err := validateIssueTracker(it); err != nil {
+		return err
+	}
+	if it.Token == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "token not provided")
+	}
+
+	// Add issue tracker to the database
+	itJSON, _ := json.Marshal(it)
+	_, err := m.db.Exec(ctx, addIssueTrackerDBQ, userID, orgName, itJSON)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// Delete deletes the provided issue tracker integration from the database.
+func (m *Manager) Delete(ctx context.Context, issueTrackerID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(issueTrackerID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid issue tracker id")
+	}
+
+	// Delete issue tracker from database
+	_, err := m.db.Exec(ctx, deleteIssueTrackerDBQ, userID, issueTrackerID)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// GetOwnedByOrgJSON returns the issue trackers integrations belonging to the
+// provided organization as a json array. The tokens are not included.
+func (m *Manager) GetOwnedByOrgJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get issue trackers from database
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getOrgIssueTrackersDBQ, userID, orgName)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return nil, hub.ErrInsufficientPrivilege
+		}
+		return nil, err
+	}
+	return dataJSON, nil
+}
+
+// GetPendingVulnerabilities returns the critical vulnerabilities found in the
+// package version of the event provided that don't have an issue opened yet
+// in the given issue tracker.
+func (m *Manager) GetPendingVulnerabilities(
+	ctx context.Context,
+	issueTrackerID string,
+	e *hub.Event,
+) ([]*hub.IssueTrackerVulnerability, error) {
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getPendingVulnerabilitiesDBQ, issueTrackerID, e.PackageID, e.PackageVersion)
+	if err != nil {
+		return nil, err
+	}
+	var vulnerabilities []*hub.IssueTrackerVulnerability
+	if err := json.Unmarshal(dataJSON, &vulnerabilities); err != nil {
+		return nil, err
+	}
+	return vulnerabilities, nil
+}
+
+// GetSubscribedTo returns the issue trackers integrations that should open
+// issues for the event provided. Only security alerts of packages versions
+// with critical vulnerabilities are handled.
+func (m *Manager) GetSubscribedTo(ctx context.Context, e *hub.Event) ([]*hub.IssueTracker, error) {
+	if e.EventKind != hub.SecurityAlert {
+		return nil, nil
+	}
+	if _, err := uuid.FromString(e.PackageID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getIssueTrackersForPkgDBQ, e.PackageID, e.PackageVersion)
+	if err != nil {
+		return nil, err
+	}
+	var issueTrackers []*hub.IssueTracker
+	if err := json.Unmarshal(dataJSON, &issueTrackers); err != nil {
+		return nil, err
+	}
+	return issueTrackers, nil
+}
+
+// RegisterIssue registers the issue opened in the issue tracker provided for
+// the given package and vulnerability, so that it's not opened again.
+func (m *Manager) RegisterIssue(
+	ctx context.Context,
+	issueTrackerID,
+	packageID,
+	vulnerabilityID,
+	issueURL string,
+) error {
+	_, err := m.db.Exec(ctx, registerIssueTrackerIssueDBQ, issueTrackerID, packageID, vulnerabilityID, issueURL)
+	return err
+}
+
+// Update updates the provided issue tracker integration in the database. The
+// existing token is kept when no new one is provided.
+func (m *Manager) Update(ctx context.Context, it *hub.IssueTracker) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(it.IssueTrackerID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid issue tracker id")
+	}
+	if err := validateIssueTracker(it); err != nil {
+		return err
+	}
+
+	// Update issue tracker in database
+	itJSON, _ := json.Marshal(it)
+	_, err := m.db.Exec(ctx, updateIssueTrackerDBQ, userID, itJSON)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// validateIssueTracker checks if the issue tracker provided is valid to be
+// used as input for some database functions calls.
+func validateIssueTracker(it *hub.IssueTracker) error {
+	switch it.Kind {
+	case hub.GitHubIssueTracker:
+		if !githubProjectRE.MatchString(it.Project) {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid project (owner/repo expected)")
+		}
+	case hub.JiraIssueTracker:
+		if it.URL == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "url not provided")
+		}
+		if it.Project == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "project not provided")
+		}
+		if it.Username == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "username not provided")
+		}
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid kind")
+	}
+	if it.URL != "" {
+		u, err := url.Parse(it.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid url")
+		}
+	}
+	for _, label := range it.Labels {
+		if label == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid label")
+		}
+	}
+	return nil
+}
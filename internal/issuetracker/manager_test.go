@@ -0,0 +1,534 @@
+package issuetracker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const validUUID = "00000000-0000-0000-0000-000000000001"
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	it := &hub.IssueTracker{
+		Kind:    hub.GitHubIssueTracker,
+		Project: "org/repo",
+		Labels:  []string{"security"},
+		Token:   "token",
+		Active:  true,
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Add(context.Background(), "orgName", it)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			it      *hub.IssueTracker
+		}{
+			{
+				"organization name not provided",
+				"",
+				it,
+			},
+			{
+				"invalid kind",
+				"org1",
+				&hub.IssueTracker{
+					Kind: "gitlab",
+				},
+			},
+			{
+				"invalid project (owner/repo expected)",
+				"org1",
+				&hub.IssueTracker{
+					Kind:    hub.GitHubIssueTracker,
+					Project: "repo",
+				},
+			},
+			{
+				"url not provided",
+				"org1",
+				&hub.IssueTracker{
+					Kind:    hub.JiraIssueTracker,
+					Project: "PRJ",
+				},
+			},
+			{
+				"project not provided",
+				"org1",
+				&hub.IssueTracker{
+					Kind: hub.JiraIssueTracker,
+					URL:  "https://org.atlassian.net",
+				},
+			},
+			{
+				"username not provided",
+				"org1",
+				&hub.IssueTracker{
+					Kind:    hub.JiraIssueTracker,
+					URL:     "https://org.atlassian.net",
+					Project: "PRJ",
+				},
+			},
+			{
+				"invalid url",
+				"org1",
+				&hub.IssueTracker{
+					Kind:     hub.JiraIssueTracker,
+					URL:      "invalidurl",
+					Project:  "PRJ",
+					Username: "user",
+				},
+			},
+			{
+				"invalid label",
+				"org1",
+				&hub.IssueTracker{
+					Kind:    hub.GitHubIssueTracker,
+					Project: "org/repo",
+					Labels:  []string{""},
+				},
+			},
+			{
+				"token not provided",
+				"org1",
+				&hub.IssueTracker{
+					Kind:    hub.GitHubIssueTracker,
+					Project: "org/repo",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+
+				err := m.Add(ctx, tc.orgName, tc.it)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, addIssueTrackerDBQ, "userID", "orgName", mock.Anything).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Add(ctx, "orgName", it)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("add issue tracker succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addIssueTrackerDBQ, "userID", "orgName", mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.Add(ctx, "orgName", it)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.Delete(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteIssueTrackerDBQ, "userID", validUUID).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Delete(ctx, validUUID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("delete issue tracker succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteIssueTrackerDBQ, "userID", validUUID).Return(nil)
+		m := NewManager(db)
+
+		err := m.Delete(ctx, validUUID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetOwnedByOrgJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetOwnedByOrgJSON(context.Background(), "orgName")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetOwnedByOrgJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgIssueTrackersDBQ, "userID", "orgName").Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				dataJSON, err := m.GetOwnedByOrgJSON(ctx, "orgName")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgIssueTrackersDBQ, "userID", "orgName").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetOwnedByOrgJSON(ctx, "orgName")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetPendingVulnerabilities(t *testing.T) {
+	ctx := context.Background()
+	e := &hub.Event{
+		EventKind:      hub.SecurityAlert,
+		PackageID:      validUUID,
+		PackageVersion: "1.0.0",
+	}
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPendingVulnerabilitiesDBQ, "itID", validUUID, "1.0.0").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		vulnerabilities, err := m.GetPendingVulnerabilities(ctx, "itID", e)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, vulnerabilities)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPendingVulnerabilitiesDBQ, "itID", validUUID, "1.0.0").Return([]byte(`
+		[
+			{
+				"vulnerability_id": "CVE-2022-0001",
+				"pkg_name": "openssl",
+				"installed_version": "1.1.1",
+				"fixed_version": "1.1.2",
+				"title": "title",
+				"primary_url": "https://avd.aquasec.com/nvd/cve-2022-0001",
+				"images": ["image1"]
+			}
+		]
+		`), nil)
+		m := NewManager(db)
+
+		vulnerabilities, err := m.GetPendingVulnerabilities(ctx, "itID", e)
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.IssueTrackerVulnerability{
+			{
+				VulnerabilityID:  "CVE-2022-0001",
+				PkgName:          "openssl",
+				InstalledVersion: "1.1.1",
+				FixedVersion:     "1.1.2",
+				Title:            "title",
+				PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2022-0001",
+				Images:           []string{"image1"},
+			},
+		}, vulnerabilities)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetSubscribedTo(t *testing.T) {
+	ctx := context.Background()
+	e := &hub.Event{
+		EventKind:      hub.SecurityAlert,
+		PackageID:      validUUID,
+		PackageVersion: "1.0.0",
+	}
+
+	t.Run("event kind not handled", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		issueTrackers, err := m.GetSubscribedTo(ctx, &hub.Event{EventKind: hub.NewRelease})
+		assert.NoError(t, err)
+		assert.Nil(t, issueTrackers)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		_, err := m.GetSubscribedTo(ctx, &hub.Event{EventKind: hub.SecurityAlert})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getIssueTrackersForPkgDBQ, validUUID, "1.0.0").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		issueTrackers, err := m.GetSubscribedTo(ctx, e)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, issueTrackers)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getIssueTrackersForPkgDBQ, validUUID, "1.0.0").Return([]byte(`
+		[
+			{
+				"issue_tracker_id": "00000000-0000-0000-0000-000000000001",
+				"kind": "github",
+				"project": "org/repo",
+				"labels": ["security"],
+				"token": "token",
+				"active": true
+			}
+		]
+		`), nil)
+		m := NewManager(db)
+
+		issueTrackers, err := m.GetSubscribedTo(ctx, e)
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.IssueTracker{
+			{
+				IssueTrackerID: validUUID,
+				Kind:           hub.GitHubIssueTracker,
+				Project:        "org/repo",
+				Labels:         []string{"security"},
+				Token:          "token",
+				Active:         true,
+			},
+		}, issueTrackers)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegisterIssue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerIssueTrackerIssueDBQ, "itID", "pkgID", "CVE-2022-0001", "issueURL").
+			Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.RegisterIssue(ctx, "itID", "pkgID", "CVE-2022-0001", "issueURL")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("register issue succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerIssueTrackerIssueDBQ, "itID", "pkgID", "CVE-2022-0001", "issueURL").
+			Return(nil)
+		m := NewManager(db)
+
+		err := m.RegisterIssue(ctx, "itID", "pkgID", "CVE-2022-0001", "issueURL")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	it := &hub.IssueTracker{
+		IssueTrackerID: validUUID,
+		Kind:           hub.JiraIssueTracker,
+		URL:            "https://org.atlassian.net",
+		Project:        "PRJ",
+		Username:       "user",
+		Active:         true,
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Update(context.Background(), it)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			it     *hub.IssueTracker
+		}{
+			{
+				"invalid issue tracker id",
+				&hub.IssueTracker{
+					IssueTrackerID: "",
+				},
+			},
+			{
+				"invalid kind",
+				&hub.IssueTracker{
+					IssueTrackerID: validUUID,
+					Kind:           "gitlab",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+
+				err := m.Update(ctx, tc.it)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, updateIssueTrackerDBQ, "userID", mock.Anything).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Update(ctx, it)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("update issue tracker succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateIssueTrackerDBQ, "userID", mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.Update(ctx, it)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
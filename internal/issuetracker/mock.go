@@ -0,0 +1,68 @@
+package issuetracker
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the IssueTrackerManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the IssueTrackerManager interface.
+func (m *ManagerMock) Add(ctx context.Context, orgName string, it *hub.IssueTracker) error {
+	args := m.Called(ctx, orgName, it)
+	return args.Error(0)
+}
+
+// Delete implements the IssueTrackerManager interface.
+func (m *ManagerMock) Delete(ctx context.Context, issueTrackerID string) error {
+	args := m.Called(ctx, issueTrackerID)
+	return args.Error(0)
+}
+
+// GetOwnedByOrgJSON implements the IssueTrackerManager interface.
+func (m *ManagerMock) GetOwnedByOrgJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetPendingVulnerabilities implements the IssueTrackerManager interface.
+func (m *ManagerMock) GetPendingVulnerabilities(
+	ctx context.Context,
+	issueTrackerID string,
+	e *hub.Event,
+) ([]*hub.IssueTrackerVulnerability, error) {
+	args := m.Called(ctx, issueTrackerID, e)
+	data, _ := args.Get(0).([]*hub.IssueTrackerVulnerability)
+	return data, args.Error(1)
+}
+
+// GetSubscribedTo implements the IssueTrackerManager interface.
+func (m *ManagerMock) GetSubscribedTo(ctx context.Context, e *hub.Event) ([]*hub.IssueTracker, error) {
+	args := m.Called(ctx, e)
+	data, _ := args.Get(0).([]*hub.IssueTracker)
+	return data, args.Error(1)
+}
+
+// RegisterIssue implements the IssueTrackerManager interface.
+func (m *ManagerMock) RegisterIssue(
+	ctx context.Context,
+	issueTrackerID,
+	packageID,
+	vulnerabilityID,
+	issueURL string,
+) error {
+	args := m.Called(ctx, issueTrackerID, packageID, vulnerabilityID, issueURL)
+	return args.Error(0)
+}
+
+// Update implements the IssueTrackerManager interface.
+func (m *ManagerMock) Update(ctx context.Context, it *hub.IssueTracker) error {
+	args := m.Called(ctx, it)
+	return args.Error(0)
+}
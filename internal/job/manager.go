@@ -0,0 +1,86 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const (
+	// Database queries
+	acquireJobLeaseDBQ = `select acquire_job_lease($1::text, $2::text, $3::int)`
+	getJobRunsDBQ      = `select * from get_job_runs($1::jsonb)`
+	registerJobRunDBQ  = `select register_job_run($1::jsonb)`
+)
+
+// Manager provides an API to manage the background jobs runs.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// AcquireLease tries to acquire the lease of the job provided for the current
+// period on behalf of the holder. Periods are derived from the job frequency
+// and are the same for all holders, so the lease of a job can only be acquired
+// once per period. This allows running each job only once per period when
+// there are multiple hub replicas. It returns true when the lease has been
+// acquired.
+func (m *Manager) AcquireLease(ctx context.Context, jobName, holder string, frequency time.Duration) (bool, error) {
+	// Validate input
+	if jobName == "" {
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "job name not provided")
+	}
+	if holder == "" {
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "holder not provided")
+	}
+	if frequency < time.Second {
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid frequency")
+	}
+
+	// Try to acquire lease in database
+	var acquired bool
+	err := m.db.QueryRow(ctx, acquireJobLeaseDBQ, jobName, holder, int(frequency.Seconds())).Scan(&acquired)
+	return acquired, err
+}
+
+// GetRunsJSON returns the background jobs runs that match the input provided
+// as a json array, most recent first.
+func (m *Manager) GetRunsJSON(ctx context.Context, input *hub.GetJobRunsInput) (*hub.JSONQueryResult, error) {
+	// Validate input
+	if input.Limit <= 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid limit")
+	}
+	if input.Offset < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset")
+	}
+
+	// Get job runs from database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSONWithPagination(ctx, m.db, getJobRunsDBQ, inputJSON)
+}
+
+// RegisterRun registers the background job run provided.
+func (m *Manager) RegisterRun(ctx context.Context, run *hub.JobRun) error {
+	// Validate input
+	if run.JobName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "job name not provided")
+	}
+	if run.Attempts <= 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid attempts")
+	}
+
+	// Register job run in database
+	runJSON, _ := json.Marshal(run)
+	_, err := m.db.Exec(ctx, registerJobRunDBQ, runJSON)
+	return err
+}
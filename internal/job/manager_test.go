@@ -0,0 +1,212 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAcquireLease(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			jobName   string
+			holder    string
+			frequency time.Duration
+		}{
+			{
+				"job name not provided",
+				"",
+				"holder1",
+				time.Hour,
+			},
+			{
+				"holder not provided",
+				"job1",
+				"",
+				time.Hour,
+			},
+			{
+				"invalid frequency",
+				"job1",
+				"holder1",
+				time.Millisecond,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.AcquireLease(ctx, tc.jobName, tc.holder, tc.frequency)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, acquireJobLeaseDBQ, "job1", "holder1", 3600).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		acquired, err := m.AcquireLease(ctx, "job1", "holder1", time.Hour)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.False(t, acquired)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("lease acquisition attempted successfully", func(t *testing.T) {
+		testCases := []bool{true, false}
+		for _, expectedAcquired := range testCases {
+			expectedAcquired := expectedAcquired
+			t.Run(fmt.Sprintf("acquired: %t", expectedAcquired), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, acquireJobLeaseDBQ, "job1", "holder1", 3600).Return(expectedAcquired, nil)
+				m := NewManager(db)
+
+				acquired, err := m.AcquireLease(ctx, "job1", "holder1", time.Hour)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedAcquired, acquired)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetRunsJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.GetJobRunsInput
+		}{
+			{
+				"invalid limit",
+				&hub.GetJobRunsInput{},
+			},
+			{
+				"invalid offset",
+				&hub.GetJobRunsInput{Limit: 10, Offset: -1},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetRunsJSON(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	input := &hub.GetJobRunsInput{
+		JobName:    "job1",
+		OnlyFailed: true,
+		Limit:      10,
+		Offset:     1,
+	}
+	inputJSON, _ := json.Marshal(input)
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getJobRunsDBQ, inputJSON).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		result, err := m.GetRunsJSON(ctx, input)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, result)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("job runs returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getJobRunsDBQ, inputJSON).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(db)
+
+		result, err := m.GetRunsJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.JSONQueryResult{
+			Data:       []byte("dataJSON"),
+			TotalCount: 1,
+		}, result)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegisterRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			run    *hub.JobRun
+		}{
+			{
+				"job name not provided",
+				&hub.JobRun{},
+			},
+			{
+				"invalid attempts",
+				&hub.JobRun{JobName: "job1"},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.RegisterRun(ctx, tc.run)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	run := &hub.JobRun{
+		JobName:   "job1",
+		StartedAt: 1592299234,
+		Duration:  150,
+		Attempts:  1,
+		Succeeded: true,
+	}
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerJobRunDBQ, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.RegisterRun(ctx, run)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("job run registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerJobRunDBQ, mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.RegisterRun(ctx, run)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,33 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the JobManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// AcquireLease implements the JobManager interface.
+func (m *ManagerMock) AcquireLease(ctx context.Context, jobName, holder string, frequency time.Duration) (bool, error) {
+	args := m.Called(ctx, jobName, holder, frequency)
+	return args.Bool(0), args.Error(1)
+}
+
+// GetRunsJSON implements the JobManager interface.
+func (m *ManagerMock) GetRunsJSON(ctx context.Context, input *hub.GetJobRunsInput) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// RegisterRun implements the JobManager interface.
+func (m *ManagerMock) RegisterRun(ctx context.Context, run *hub.JobRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
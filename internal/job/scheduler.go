@@ -0,0 +1,193 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
+	"github.com/rs/zerolog/log"
+	"github.com/satori/uuid"
+)
+
+const (
+	// defaultMaxRetries represents the number of times a job run will be
+	// retried when it fails.
+	defaultMaxRetries = 2
+
+	// defaultRetryDelay represents how long the scheduler will wait before
+	// retrying a job run that failed.
+	defaultRetryDelay = 1 * time.Minute
+)
+
+// Job represents a background job that will be run periodically.
+type Job struct {
+	// Name identifies the job in the jobs runs registered.
+	Name string
+
+	// Frequency represents how often the job will be run.
+	Frequency time.Duration
+
+	// Run is the function in charge of doing the job's work.
+	Run func(ctx context.Context) error
+
+	// Heartbeat is an optional function that will be called each time the
+	// job runs, so that its liveness can be tracked.
+	Heartbeat func()
+}
+
+// Scheduler runs periodically the background jobs registered, retrying them
+// when they fail and registering the result of each of their runs.
+//
+// When the hub is deployed with multiple replicas, each of them runs its own
+// scheduler. To make sure each job is only run once per period across all of
+// them, schedulers must acquire the job's lease for the current period before
+// running it. Periods are aligned to the job's frequency, so they are the same
+// for all replicas.
+type Scheduler struct {
+	jm         hub.JobManager
+	mm         hub.MaintenanceManager
+	holder     string
+	maxRetries int
+	retryDelay time.Duration
+	jobs       []*Job
+}
+
+// NewScheduler creates a new Scheduler instance.
+func NewScheduler(jm hub.JobManager, opts ...func(s *Scheduler)) *Scheduler {
+	s := &Scheduler{
+		jm:         jm,
+		holder:     uuid.NewV4().String(),
+		maxRetries: defaultMaxRetries,
+		retryDelay: defaultRetryDelay,
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// WithRetries allows configuring how many times the jobs runs that fail will
+// be retried, as well as how long to wait between attempts.
+func WithRetries(maxRetries int, retryDelay time.Duration) func(s *Scheduler) {
+	return func(s *Scheduler) {
+		s.maxRetries = maxRetries
+		s.retryDelay = retryDelay
+	}
+}
+
+// WithMaintenanceManager allows providing a maintenance manager, used to skip
+// the jobs runs while the maintenance mode is enabled.
+func WithMaintenanceManager(mm hub.MaintenanceManager) func(s *Scheduler) {
+	return func(s *Scheduler) {
+		s.mm = mm
+	}
+}
+
+// Register registers the job provided in the scheduler. Jobs must be
+// registered before launching the scheduler.
+func (s *Scheduler) Register(j *Job) {
+	s.jobs = append(s.jobs, j)
+}
+
+// Run launches all the jobs registered. Each of them is run when the
+// scheduler is launched and then periodically. The scheduler will keep
+// running until the context provided is done.
+func (s *Scheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	var jobsWG sync.WaitGroup
+	for _, j := range s.jobs {
+		jobsWG.Add(1)
+		go func(j *Job) {
+			defer jobsWG.Done()
+			s.runPeriodically(ctx, j)
+		}(j)
+	}
+	jobsWG.Wait()
+}
+
+// runPeriodically runs the job provided when it's called and then
+// periodically, until the context provided is done. A ticker is used so that
+// runs are exactly one frequency apart regardless of how long they take, so
+// each of them falls into a different lease period.
+func (s *Scheduler) runPeriodically(ctx context.Context, j *Job) {
+	ticker := time.NewTicker(j.Frequency)
+	defer ticker.Stop()
+	for {
+		if j.Heartbeat != nil {
+			j.Heartbeat()
+		}
+		s.run(ctx, j)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run runs the job provided, retrying it if it fails, and registers the
+// result of the run. The job is only run if its lease can be acquired, which
+// won't be possible if it has already been run by other instance during the
+// current period. Runs interrupted because the context provided is done are
+// not registered. While the maintenance mode is enabled runs are skipped.
+func (s *Scheduler) run(ctx context.Context, j *Job) {
+	logger := log.With().Str("job", j.Name).Logger()
+
+	// Skip run while in maintenance mode
+	if s.mm != nil && maintenance.IsEnabled(ctx, s.mm) {
+		logger.Info().Msg("maintenance mode enabled, run skipped")
+		return
+	}
+
+	// Acquire job lease for the current period
+	acquired, err := s.jm.AcquireLease(ctx, j.Name, s.holder, j.Frequency)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error().Err(err).Msg("error acquiring job lease")
+		}
+		return
+	}
+	if !acquired {
+		logger.Debug().Msg("job lease held by other instance, run skipped")
+		return
+	}
+
+	// Run job, retrying it if needed
+	start := time.Now()
+	var attempts int
+	for {
+		attempts++
+		err = j.Run(ctx)
+		if err == nil || ctx.Err() != nil || attempts > s.maxRetries {
+			break
+		}
+		logger.Warn().Err(err).Int("attempt", attempts).Msg("job run failed, retrying")
+		select {
+		case <-time.After(s.retryDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Register job run
+	run := &hub.JobRun{
+		JobName:   j.Name,
+		StartedAt: start.Unix(),
+		Duration:  time.Since(start).Milliseconds(),
+		Attempts:  attempts,
+		Succeeded: err == nil,
+	}
+	if err != nil {
+		run.Error = err.Error()
+		logger.Error().Err(err).Int("attempts", attempts).Msg("job run failed")
+	}
+	if err := s.jm.RegisterRun(ctx, run); err != nil {
+		logger.Error().Err(err).Msg("error registering job run")
+	}
+}
@@ -0,0 +1,244 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var errFakeJob = errors.New("fake job error")
+
+func TestScheduler(t *testing.T) {
+	t.Run("custom retries", func(t *testing.T) {
+		t.Parallel()
+		s := NewScheduler(nil, WithRetries(5, 2*time.Second))
+		assert.NotEmpty(t, s.holder)
+		assert.Equal(t, 5, s.maxRetries)
+		assert.Equal(t, 2*time.Second, s.retryDelay)
+	})
+
+	t.Run("maintenance mode enabled, job not run", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		jm := &ManagerMock{}
+		mm := &maintenance.ManagerMock{}
+		mm.On("Get", ctx).
+			Run(func(args mock.Arguments) { cancel() }).
+			Return(&hub.MaintenanceMode{Enabled: true}, nil)
+		var wg sync.WaitGroup
+		var calls int
+
+		s := NewScheduler(jm, WithMaintenanceManager(mm))
+		s.Register(&Job{
+			Name:      "job1",
+			Frequency: time.Hour,
+			Run: func(ctx context.Context) error {
+				calls++
+				return nil
+			},
+		})
+		wg.Add(1)
+		go s.Run(ctx, &wg)
+		wg.Wait()
+		assert.Equal(t, 0, calls)
+		jm.AssertExpectations(t)
+		mm.AssertExpectations(t)
+	})
+
+	t.Run("error acquiring job lease, job not run", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		jm := &ManagerMock{}
+		jm.On("AcquireLease", ctx, "job1", mock.Anything, time.Hour).
+			Run(func(args mock.Arguments) { cancel() }).
+			Return(false, tests.ErrFakeDB)
+		var wg sync.WaitGroup
+		var calls int
+
+		s := NewScheduler(jm)
+		s.Register(&Job{
+			Name:      "job1",
+			Frequency: time.Hour,
+			Run: func(ctx context.Context) error {
+				calls++
+				return nil
+			},
+		})
+		wg.Add(1)
+		go s.Run(ctx, &wg)
+		wg.Wait()
+		assert.Equal(t, 0, calls)
+		jm.AssertExpectations(t)
+	})
+
+	t.Run("job lease held by other instance, job not run", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		jm := &ManagerMock{}
+		jm.On("AcquireLease", ctx, "job1", mock.Anything, time.Hour).
+			Run(func(args mock.Arguments) { cancel() }).
+			Return(false, nil)
+		var wg sync.WaitGroup
+		var calls int
+
+		s := NewScheduler(jm)
+		s.Register(&Job{
+			Name:      "job1",
+			Frequency: time.Hour,
+			Run: func(ctx context.Context) error {
+				calls++
+				return nil
+			},
+		})
+		wg.Add(1)
+		go s.Run(ctx, &wg)
+		wg.Wait()
+		assert.Equal(t, 0, calls)
+		jm.AssertExpectations(t)
+	})
+
+	t.Run("job run succeeded on launch and registered", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		jm := &ManagerMock{}
+		jm.On("AcquireLease", ctx, "job1", mock.Anything, time.Hour).Return(true, nil)
+		jm.On("RegisterRun", ctx, mock.MatchedBy(func(run *hub.JobRun) bool {
+			return run.JobName == "job1" && run.Attempts == 1 && run.Succeeded && run.Error == ""
+		})).
+			Run(func(args mock.Arguments) { cancel() }).
+			Return(nil).
+			Once()
+		var wg sync.WaitGroup
+
+		s := NewScheduler(jm)
+		s.Register(&Job{
+			Name:      "job1",
+			Frequency: time.Hour,
+			Run:       func(ctx context.Context) error { return nil },
+		})
+		wg.Add(1)
+		go s.Run(ctx, &wg)
+		wg.Wait()
+		jm.AssertExpectations(t)
+	})
+
+	t.Run("job run failed after retrying and registered", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		jm := &ManagerMock{}
+		jm.On("AcquireLease", ctx, "job1", mock.Anything, time.Hour).Return(true, nil)
+		jm.On("RegisterRun", ctx, mock.MatchedBy(func(run *hub.JobRun) bool {
+			return run.Attempts == 3 && !run.Succeeded && run.Error == errFakeJob.Error()
+		})).
+			Run(func(args mock.Arguments) { cancel() }).
+			Return(tests.ErrFakeDB).
+			Once()
+		var wg sync.WaitGroup
+		var calls int
+
+		s := NewScheduler(jm, WithRetries(2, time.Millisecond))
+		s.Register(&Job{
+			Name:      "job1",
+			Frequency: time.Hour,
+			Run: func(ctx context.Context) error {
+				calls++
+				return errFakeJob
+			},
+		})
+		wg.Add(1)
+		go s.Run(ctx, &wg)
+		wg.Wait()
+		assert.Equal(t, 3, calls)
+		jm.AssertExpectations(t)
+	})
+
+	t.Run("job run succeeded after retrying", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		jm := &ManagerMock{}
+		jm.On("AcquireLease", ctx, "job1", mock.Anything, time.Hour).Return(true, nil)
+		jm.On("RegisterRun", ctx, mock.MatchedBy(func(run *hub.JobRun) bool {
+			return run.Attempts == 2 && run.Succeeded
+		})).
+			Run(func(args mock.Arguments) { cancel() }).
+			Return(nil).
+			Once()
+		var wg sync.WaitGroup
+		var calls int
+
+		s := NewScheduler(jm, WithRetries(2, time.Millisecond))
+		s.Register(&Job{
+			Name:      "job1",
+			Frequency: time.Hour,
+			Run: func(ctx context.Context) error {
+				calls++
+				if calls == 1 {
+					return errFakeJob
+				}
+				return nil
+			},
+		})
+		wg.Add(1)
+		go s.Run(ctx, &wg)
+		wg.Wait()
+		assert.Equal(t, 2, calls)
+		jm.AssertExpectations(t)
+	})
+
+	t.Run("job run interrupted is not registered", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		jm := &ManagerMock{}
+		jm.On("AcquireLease", ctx, "job1", mock.Anything, time.Hour).Return(true, nil)
+		var wg sync.WaitGroup
+
+		s := NewScheduler(jm)
+		s.Register(&Job{
+			Name:      "job1",
+			Frequency: time.Hour,
+			Run: func(ctx context.Context) error {
+				cancel()
+				return ctx.Err()
+			},
+		})
+		wg.Add(1)
+		go s.Run(ctx, &wg)
+		wg.Wait()
+		jm.AssertExpectations(t)
+	})
+
+	t.Run("jobs run periodically and heartbeat called", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		jm := &ManagerMock{}
+		jm.On("AcquireLease", ctx, "job1", mock.Anything, 10*time.Millisecond).Return(true, nil)
+		jm.On("RegisterRun", ctx, mock.Anything).Return(nil).Once()
+		jm.On("RegisterRun", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { cancel() }).
+			Return(nil).
+			Once()
+		var wg sync.WaitGroup
+		var beats int
+
+		s := NewScheduler(jm)
+		s.Register(&Job{
+			Name:      "job1",
+			Frequency: 10 * time.Millisecond,
+			Run:       func(ctx context.Context) error { return nil },
+			Heartbeat: func() { beats++ },
+		})
+		wg.Add(1)
+		go s.Run(ctx, &wg)
+		wg.Wait()
+		assert.Equal(t, 2, beats)
+		jm.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,231 @@
+package maintainer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+
+	_ "embed" // Used by templates
+
+	"github.com/artifacthub/hub/internal/email"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
+	"github.com/satori/uuid"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	getContactDetailsDBQ        = `select m.email, u.alias from maintainer m, "user" u where m.maintainer_id = $1 and m.verified = true and u.user_id = $2`
+	getMaintainerEmailDBQ       = `select email from maintainer where maintainer_id = $1`
+	registerContactDBQ          = `select register_maintainer_contact($1::uuid, $2::uuid)`
+	registerVerificationCodeDBQ = `select register_maintainer_verification_code($1::uuid, $2::uuid, $3::text)`
+	verifyMaintainerDBQ         = `select verify_maintainer($1::text)`
+)
+
+const (
+	// MessageMaxLength represents the maximum length of the messages sent to
+	// maintainers using the contact relay.
+	MessageMaxLength = 2000
+)
+
+type templateID int
+
+const (
+	contactEmail templateID = iota
+	verificationEmail
+)
+
+var (
+	//go:embed template/contact_email.tmpl
+	contactEmailTmpl string
+
+	//go:embed template/verification_email.tmpl
+	verificationEmailTmpl string
+)
+
+// Manager provides an API to manage packages maintainers.
+type Manager struct {
+	cfg  *viper.Viper
+	db   hub.DB
+	es   hub.EmailSender
+	tmpl map[templateID]*template.Template
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(cfg *viper.Viper, db hub.DB, es hub.EmailSender) *Manager {
+	return &Manager{
+		cfg: cfg,
+		db:  db,
+		es:  es,
+		tmpl: map[templateID]*template.Template{
+			contactEmail:      email.ParseTemplate(contactEmailTmpl),
+			verificationEmail: email.ParseTemplate(verificationEmailTmpl),
+		},
+	}
+}
+
+// Contact relays the message provided to the maintainer identified by the id
+// given on behalf of the user doing the request. Only verified maintainers
+// can be contacted, and their email address is never disclosed to the user.
+func (m *Manager) Contact(ctx context.Context, maintainerID, message string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateMaintainerID(maintainerID); err != nil {
+		return err
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "message not provided")
+	}
+	if len(message) > MessageMaxLength {
+		return fmt.Errorf("%w: %s (max: %d)", hub.ErrInvalidInput, "message too long", MessageMaxLength)
+	}
+
+	// Get maintainer email and user alias
+	var maintainerEmail, userAlias string
+	err := m.db.QueryRow(ctx, getContactDetailsDBQ, maintainerID, userID).Scan(&maintainerEmail, &userAlias)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return hub.ErrNotFound
+		}
+		return err
+	}
+
+	// Register contact in database
+	var registered bool
+	if err := m.db.QueryRow(ctx, registerContactDBQ, userID, maintainerID).Scan(&registered); err != nil {
+		return err
+	}
+	if !registered {
+		return fmt.Errorf("%w: %s", hub.ErrTooManyRequests, "contacts limit reached")
+	}
+
+	// Relay message to the maintainer
+	if m.es != nil {
+		templateData := m.baseTemplateData()
+		templateData["Message"] = message
+		templateData["UserAlias"] = userAlias
+		var emailBody bytes.Buffer
+		err := email.ExecuteTemplate(&emailBody, m.tmpl[contactEmail], email.DefaultLocale, templateData)
+		if err != nil {
+			return err
+		}
+		siteName := m.cfg.GetString("theme.siteName")
+		emailData := &email.Data{
+			To:      maintainerEmail,
+			Subject: email.Translate(email.DefaultLocale, "maintainer_contact.subject", siteName, userAlias),
+			Body:    emailBody.Bytes(),
+		}
+		if err := m.es.SendEmail(emailData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegisterVerificationCode registers a code that allows verifying the email
+// of the maintainer identified by the id provided. A link containing the code
+// will be emailed to the maintainer, who will need to confirm it to opt in.
+func (m *Manager) RegisterVerificationCode(ctx context.Context, maintainerID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateMaintainerID(maintainerID); err != nil {
+		return err
+	}
+
+	// Register maintainer verification code in database
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return err
+	}
+	code := base64.URLEncoding.EncodeToString(randomBytes)
+	var registered bool
+	err := m.db.QueryRow(ctx, registerVerificationCodeDBQ, userID, maintainerID, hash(code)).Scan(&registered)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return hub.ErrInsufficientPrivilege
+		}
+		return err
+	}
+	if !registered {
+		return fmt.Errorf("%w: %s", hub.ErrTooManyRequests, "verification already requested recently")
+	}
+
+	// Send maintainer verification email
+	if m.es != nil {
+		var maintainerEmail string
+		if err := m.db.QueryRow(ctx, getMaintainerEmailDBQ, maintainerID).Scan(&maintainerEmail); err != nil {
+			return err
+		}
+		templateData := m.baseTemplateData()
+		templateData["Link"] = fmt.Sprintf("%s/verify-maintainer?code=%s", templateData["BaseURL"], code)
+		var emailBody bytes.Buffer
+		err := email.ExecuteTemplate(&emailBody, m.tmpl[verificationEmail], email.DefaultLocale, templateData)
+		if err != nil {
+			return err
+		}
+		emailData := &email.Data{
+			To:      maintainerEmail,
+			Subject: email.Translate(email.DefaultLocale, "maintainer_verification.subject"),
+			Body:    emailBody.Bytes(),
+		}
+		if err := m.es.SendEmail(emailData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Verify verifies the email of a maintainer using the code provided. It
+// returns false when the code is not valid or has expired.
+func (m *Manager) Verify(ctx context.Context, code string) (bool, error) {
+	// Validate input
+	if code == "" {
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "code not provided")
+	}
+
+	// Verify maintainer in database
+	var verified bool
+	err := m.db.QueryRow(ctx, verifyMaintainerDBQ, hash(code)).Scan(&verified)
+	return verified, err
+}
+
+// baseTemplateData returns the data common to all the emails templates.
+func (m *Manager) baseTemplateData() map[string]interface{} {
+	return map[string]interface{}{
+		"BaseURL": m.cfg.GetString("server.baseURL"),
+		"Theme": map[string]string{
+			"PrimaryColor":   m.cfg.GetString("theme.colors.primary"),
+			"SecondaryColor": m.cfg.GetString("theme.colors.secondary"),
+			"SiteName":       m.cfg.GetString("theme.siteName"),
+		},
+	}
+}
+
+// validateMaintainerID checks that the maintainer id provided is valid.
+func validateMaintainerID(maintainerID string) error {
+	if maintainerID == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "maintainer id not provided")
+	}
+	if _, err := uuid.FromString(maintainerID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid maintainer id")
+	}
+	return nil
+}
+
+// hash is a helper function that creates a sha512 hash of the text provided.
+func hash(text string) string {
+	return fmt.Sprintf("%x", sha512.Sum512([]byte(text)))
+}
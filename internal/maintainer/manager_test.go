@@ -0,0 +1,316 @@
+package maintainer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/email"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const maintainerID = "00000000-0000-0000-0000-000000000001"
+
+var cfg *viper.Viper
+
+func init() {
+	cfg = viper.New()
+	cfg.Set("server.baseURL", "http://localhost:8000")
+	cfg.Set("theme.siteName", "Artifact Hub")
+}
+
+func TestContact(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Contact(context.Background(), maintainerID, "message")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg       string
+			maintainerID string
+			message      string
+		}{
+			{
+				"maintainer id not provided",
+				"",
+				"message",
+			},
+			{
+				"invalid maintainer id",
+				"maintainerID",
+				"message",
+			},
+			{
+				"message not provided",
+				maintainerID,
+				"  ",
+			},
+			{
+				"message too long",
+				maintainerID,
+				strings.Repeat("a", MessageMaxLength+1),
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil)
+				err := m.Contact(ctx, tc.maintainerID, tc.message)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("maintainer not found or not verified", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getContactDetailsDBQ, maintainerID, "userID").Return(nil, pgx.ErrNoRows)
+		m := NewManager(cfg, db, nil)
+
+		err := m.Contact(ctx, maintainerID, "message")
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error getting contact details", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getContactDetailsDBQ, maintainerID, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.Contact(ctx, maintainerID, "message")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error registering contact", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getContactDetailsDBQ, maintainerID, "userID").
+			Return([]interface{}{"maintainer@email.com", "user1"}, nil)
+		db.On("QueryRow", ctx, registerContactDBQ, "userID", maintainerID).Return(false, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.Contact(ctx, maintainerID, "message")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("contacts limit reached", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getContactDetailsDBQ, maintainerID, "userID").
+			Return([]interface{}{"maintainer@email.com", "user1"}, nil)
+		db.On("QueryRow", ctx, registerContactDBQ, "userID", maintainerID).Return(false, nil)
+		m := NewManager(cfg, db, nil)
+
+		err := m.Contact(ctx, maintainerID, "message")
+		assert.True(t, errors.Is(err, hub.ErrTooManyRequests))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("message relayed to the maintainer", func(t *testing.T) {
+		testCases := []struct {
+			description         string
+			emailSenderResponse error
+		}{
+			{
+				"message sent successfully",
+				nil,
+			},
+			{
+				"error sending message",
+				email.ErrFakeSenderFailure,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getContactDetailsDBQ, maintainerID, "userID").
+					Return([]interface{}{"maintainer@email.com", "user1"}, nil)
+				db.On("QueryRow", ctx, registerContactDBQ, "userID", maintainerID).Return(true, nil)
+				es := &email.SenderMock{}
+				es.On("SendEmail", mock.MatchedBy(func(d *email.Data) bool {
+					return d.To == "maintainer@email.com" &&
+						d.Subject == "Message from Artifact Hub user user1" &&
+						strings.Contains(string(d.Body), "&lt;b&gt;message&lt;/b&gt;")
+				})).Return(tc.emailSenderResponse)
+				m := NewManager(cfg, db, es)
+
+				err := m.Contact(ctx, maintainerID, " <b>message</b> ")
+				assert.Equal(t, tc.emailSenderResponse, err)
+				db.AssertExpectations(t)
+				es.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRegisterVerificationCode(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.RegisterVerificationCode(context.Background(), maintainerID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg       string
+			maintainerID string
+		}{
+			{
+				"maintainer id not provided",
+				"",
+			},
+			{
+				"invalid maintainer id",
+				"maintainerID",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil)
+				err := m.RegisterVerificationCode(ctx, tc.maintainerID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error registering verification code", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, registerVerificationCodeDBQ, "userID", maintainerID, mock.Anything).
+					Return(false, tc.dbErr)
+				m := NewManager(cfg, db, nil)
+
+				err := m.RegisterVerificationCode(ctx, maintainerID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("verification already requested recently", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerVerificationCodeDBQ, "userID", maintainerID, mock.Anything).
+			Return(false, nil)
+		m := NewManager(cfg, db, nil)
+
+		err := m.RegisterVerificationCode(ctx, maintainerID)
+		assert.True(t, errors.Is(err, hub.ErrTooManyRequests))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("verification code registered, email sent", func(t *testing.T) {
+		testCases := []struct {
+			description         string
+			emailSenderResponse error
+		}{
+			{
+				"verification email sent successfully",
+				nil,
+			},
+			{
+				"error sending verification email",
+				email.ErrFakeSenderFailure,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, registerVerificationCodeDBQ, "userID", maintainerID, mock.Anything).
+					Return(true, nil)
+				db.On("QueryRow", ctx, getMaintainerEmailDBQ, maintainerID).Return("maintainer@email.com", nil)
+				es := &email.SenderMock{}
+				es.On("SendEmail", mock.MatchedBy(func(d *email.Data) bool {
+					return d.To == "maintainer@email.com" &&
+						strings.Contains(string(d.Body), "http://localhost:8000/verify-maintainer?code=")
+				})).Return(tc.emailSenderResponse)
+				m := NewManager(cfg, db, es)
+
+				err := m.RegisterVerificationCode(ctx, maintainerID)
+				assert.Equal(t, tc.emailSenderResponse, err)
+				db.AssertExpectations(t)
+				es.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		_, err := m.Verify(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("successful verification", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, verifyMaintainerDBQ, hash("code")).Return(true, nil)
+		m := NewManager(cfg, db, nil)
+
+		verified, err := m.Verify(ctx, "code")
+		assert.NoError(t, err)
+		assert.True(t, verified)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, verifyMaintainerDBQ, hash("code")).Return(false, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		verified, err := m.Verify(ctx, "code")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.False(t, verified)
+		db.AssertExpectations(t)
+	})
+}
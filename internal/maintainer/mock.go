@@ -0,0 +1,30 @@
+package maintainer
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the MaintainerManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Contact implements the MaintainerManager interface.
+func (m *ManagerMock) Contact(ctx context.Context, maintainerID, message string) error {
+	args := m.Called(ctx, maintainerID, message)
+	return args.Error(0)
+}
+
+// RegisterVerificationCode implements the MaintainerManager interface.
+func (m *ManagerMock) RegisterVerificationCode(ctx context.Context, maintainerID string) error {
+	args := m.Called(ctx, maintainerID)
+	return args.Error(0)
+}
+
+// Verify implements the MaintainerManager interface.
+func (m *ManagerMock) Verify(ctx context.Context, code string) (bool, error) {
+	args := m.Called(ctx, code)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,131 @@
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Database queries
+	getMaintenanceModeDBQ = `select get_maintenance_mode()`
+	setMaintenanceModeDBQ = `select set_maintenance_mode($1::jsonb)`
+
+	// cacheTTL represents how long the maintenance mode status is cached
+	// before fetching it again from the database.
+	cacheTTL = 10 * time.Second
+
+	// checkInterval represents how often the maintenance mode status is
+	// checked to cancel the work in progress when it is enabled.
+	checkInterval = 10 * time.Second
+)
+
+// Manager provides an API to manage the maintenance mode.
+type Manager struct {
+	db hub.DB
+
+	mu        sync.RWMutex
+	mm        *hub.MaintenanceMode
+	fetchedAt time.Time
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// Get returns the maintenance mode status. The status is cached for a short
+// period of time, as it is checked quite often (i.e. on every request that
+// may modify some data).
+func (m *Manager) Get(ctx context.Context) (*hub.MaintenanceMode, error) {
+	m.mu.RLock()
+	mm, fetchedAt := m.mm, m.fetchedAt
+	m.mu.RUnlock()
+	if mm != nil && time.Since(fetchedAt) < cacheTTL {
+		return mm, nil
+	}
+
+	mm = &hub.MaintenanceMode{}
+	if err := util.DBQueryUnmarshal(ctx, m.db, mm, getMaintenanceModeDBQ); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	m.mm, m.fetchedAt = mm, time.Now()
+	m.mu.Unlock()
+	return mm, nil
+}
+
+// Set enables or disables the maintenance mode. When the retry after value is
+// not provided, the current one is kept.
+func (m *Manager) Set(ctx context.Context, mm *hub.MaintenanceMode) error {
+	// Validate input
+	if mm.RetryAfter < 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid retry after")
+	}
+
+	// Update maintenance mode in database
+	mmJSON, _ := json.Marshal(mm)
+	if _, err := m.db.Exec(ctx, setMaintenanceModeDBQ, mmJSON); err != nil {
+		return err
+	}
+
+	// Invalidate cached status
+	m.mu.Lock()
+	m.mm = nil
+	m.mu.Unlock()
+
+	return nil
+}
+
+// IsEnabled is a helper that checks if the maintenance mode is enabled using
+// the maintenance manager provided. Errors getting the maintenance mode status
+// are logged and the maintenance mode is considered disabled in that case.
+func IsEnabled(ctx context.Context, m hub.MaintenanceManager) bool {
+	mm, err := m.Get(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error getting maintenance mode status")
+		return false
+	}
+	return mm.Enabled
+}
+
+// CancelOnEnabled returns a copy of the context provided that is canceled as
+// soon as the maintenance mode is enabled. This allows aborting the work in
+// progress (i.e. tracking a repository or scanning a snapshot) instead of
+// waiting for it to finish before pausing.
+func CancelOnEnabled(ctx context.Context, m hub.MaintenanceManager) (context.Context, context.CancelFunc) {
+	return cancelOnEnabled(ctx, m, checkInterval)
+}
+
+// cancelOnEnabled implements CancelOnEnabled, checking the maintenance mode
+// status using the interval provided.
+func cancelOnEnabled(
+	ctx context.Context,
+	m hub.MaintenanceManager,
+	interval time.Duration,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		for {
+			if IsEnabled(ctx, m) {
+				log.Info().Msg("maintenance mode enabled, cancelling work in progress")
+				cancel()
+				return
+			}
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ctx, cancel
+}
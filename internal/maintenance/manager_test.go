@@ -0,0 +1,152 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getMaintenanceModeDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		mm, err := m.Get(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, mm)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("status returned successfully and cached", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getMaintenanceModeDBQ).Return([]byte(`
+		{
+			"enabled": true,
+			"retry_after": 600,
+			"updated_at": 1592299234
+		}
+		`), nil).Once()
+		m := NewManager(db)
+
+		for i := 0; i < 2; i++ {
+			mm, err := m.Get(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, &hub.MaintenanceMode{Enabled: true, RetryAfter: 600}, mm)
+		}
+		db.AssertExpectations(t)
+	})
+}
+
+func TestSet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		err := m.Set(ctx, &hub.MaintenanceMode{Enabled: true, RetryAfter: -1})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid retry after")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setMaintenanceModeDBQ, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.Set(ctx, &hub.MaintenanceMode{Enabled: true})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("maintenance mode set successfully and cached status invalidated", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getMaintenanceModeDBQ).Return([]byte(`{"enabled": false, "retry_after": 300}`), nil).Once()
+		db.On("Exec", ctx, setMaintenanceModeDBQ, mock.Anything).Return(nil)
+		db.On("QueryRow", ctx, getMaintenanceModeDBQ).Return([]byte(`{"enabled": true, "retry_after": 300}`), nil).Once()
+		m := NewManager(db)
+
+		mm, err := m.Get(ctx)
+		assert.NoError(t, err)
+		assert.False(t, mm.Enabled)
+		err = m.Set(ctx, &hub.MaintenanceMode{Enabled: true})
+		assert.NoError(t, err)
+		mm, err = m.Get(ctx)
+		assert.NoError(t, err)
+		assert.True(t, mm.Enabled)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestIsEnabled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error getting maintenance mode status", func(t *testing.T) {
+		t.Parallel()
+		mm := &ManagerMock{}
+		mm.On("Get", ctx).Return(nil, tests.ErrFakeDB)
+
+		assert.False(t, IsEnabled(ctx, mm))
+		mm.AssertExpectations(t)
+	})
+
+	t.Run("maintenance mode disabled", func(t *testing.T) {
+		t.Parallel()
+		mm := &ManagerMock{}
+		mm.On("Get", ctx).Return(&hub.MaintenanceMode{}, nil)
+
+		assert.False(t, IsEnabled(ctx, mm))
+		mm.AssertExpectations(t)
+	})
+
+	t.Run("maintenance mode enabled", func(t *testing.T) {
+		t.Parallel()
+		mm := &ManagerMock{}
+		mm.On("Get", ctx).Return(&hub.MaintenanceMode{Enabled: true}, nil)
+
+		assert.True(t, IsEnabled(ctx, mm))
+		mm.AssertExpectations(t)
+	})
+}
+
+func TestCancelOnEnabled(t *testing.T) {
+	t.Run("context canceled when maintenance mode is enabled", func(t *testing.T) {
+		t.Parallel()
+		mm := &ManagerMock{}
+		mm.On("Get", mock.Anything).Return(&hub.MaintenanceMode{}, nil).Twice()
+		mm.On("Get", mock.Anything).Return(&hub.MaintenanceMode{Enabled: true}, nil).Once()
+
+		ctx, cancel := cancelOnEnabled(context.Background(), mm, 10*time.Millisecond)
+		defer cancel()
+		select {
+		case <-ctx.Done():
+		case <-time.After(1 * time.Second):
+			t.Fatal("context should have been canceled")
+		}
+		mm.AssertExpectations(t)
+	})
+
+	t.Run("context not canceled while maintenance mode is disabled", func(t *testing.T) {
+		t.Parallel()
+		mm := &ManagerMock{}
+		mm.On("Get", mock.Anything).Return(&hub.MaintenanceMode{}, nil)
+
+		ctx, cancel := cancelOnEnabled(context.Background(), mm, 10*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		assert.NoError(t, ctx.Err())
+		cancel()
+	})
+}
@@ -0,0 +1,26 @@
+package maintenance
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the MaintenanceManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Get implements the MaintenanceManager interface.
+func (m *ManagerMock) Get(ctx context.Context) (*hub.MaintenanceMode, error) {
+	args := m.Called(ctx)
+	mm, _ := args.Get(0).(*hub.MaintenanceMode)
+	return mm, args.Error(1)
+}
+
+// Set implements the MaintenanceManager interface.
+func (m *ManagerMock) Set(ctx context.Context, mm *hub.MaintenanceMode) error {
+	args := m.Called(ctx, mm)
+	return args.Error(0)
+}
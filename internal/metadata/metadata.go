@@ -0,0 +1,177 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/hashicorp/go-multierror"
+	"github.com/satori/uuid"
+	"gopkg.in/yaml.v2"
+)
+
+// Kind represents the kind of a metadata file.
+type Kind string
+
+const (
+	// Package represents the package metadata file (artifacthub-pkg.yml).
+	Package Kind = "package"
+
+	// Repository represents the repository metadata file
+	// (artifacthub-repo.yml).
+	Repository Kind = "repository"
+)
+
+var (
+	// ErrInvalidMetadata indicates that the repository metadata is not valid.
+	ErrInvalidMetadata = errors.New("invalid metadata")
+
+	// ErrUnknownKind indicates that the kind of the metadata file provided
+	// could not be determined or is not supported.
+	ErrUnknownKind = errors.New("unknown metadata file kind")
+
+	// errEmptyFile indicates that the metadata file provided is empty.
+	errEmptyFile = errors.New("metadata file is empty")
+)
+
+// ValidationResult represents the result of validating a metadata file. Each
+// of the problems found is reported as a separate entry in the errors list,
+// so that they can be fixed one by one.
+type ValidationResult struct {
+	Kind   Kind     `json:"kind"`
+	Errors []string `json:"errors"`
+}
+
+// Valid returns true if no errors were found validating the metadata file.
+func (r *ValidationResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Validate validates the metadata file content provided, which is expected to
+// be of the kind provided.
+func Validate(kind Kind, data []byte) (*ValidationResult, error) {
+	var err error
+	switch kind {
+	case Package:
+		// Unknown fields are reported, but they don't prevent the rest of the
+		// metadata from being validated
+		var md *hub.PackageMetadata
+		err = yaml.UnmarshalStrict(data, &md)
+		var terr *yaml.TypeError
+		if err == nil || errors.As(err, &terr) {
+			if md == nil {
+				err = multierror.Append(err, errEmptyFile).ErrorOrNil()
+			} else {
+				err = multierror.Append(err, pkg.ValidatePackageMetadata(md)).ErrorOrNil()
+			}
+		}
+	case Repository:
+		_, err = ParseRepositoryMetadata(data)
+	default:
+		return nil, ErrUnknownKind
+	}
+
+	return &ValidationResult{
+		Kind:   kind,
+		Errors: flattenErrors(err),
+	}, nil
+}
+
+// ValidateFile validates the metadata file located at the path provided. The
+// kind of the metadata file is inferred from its name.
+func ValidateFile(path string) (*ValidationResult, error) {
+	kind := GetKind(path)
+	if kind == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKind, path)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading metadata file: %w", err)
+	}
+	return Validate(kind, data)
+}
+
+// GetKind returns the kind of the metadata file located at the path provided
+// based on its name. An empty kind is returned when the file is not a
+// metadata file.
+func GetKind(path string) Kind {
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+	if ext != ".yml" && ext != ".yaml" {
+		return ""
+	}
+	switch strings.TrimSuffix(name, ext) {
+	case hub.PackageMetadataFile:
+		return Package
+	case hub.RepositoryMetadataFile:
+		return Repository
+	default:
+		return ""
+	}
+}
+
+// ParseRepositoryMetadata parses and validates the repository metadata file
+// content provided.
+func ParseRepositoryMetadata(data []byte) (*hub.RepositoryMetadata, error) {
+	var md *hub.RepositoryMetadata
+	if err := yaml.Unmarshal(data, &md); err != nil {
+		return nil, fmt.Errorf("error unmarshaling repository metadata file: %w", err)
+	}
+	if md == nil {
+		return nil, fmt.Errorf("error unmarshaling repository metadata file: %w", errEmptyFile)
+	}
+	if err := ValidateRepositoryMetadata(md); err != nil {
+		return nil, err
+	}
+	return md, nil
+}
+
+// ValidateRepositoryMetadata validates if the repository metadata provided is
+// valid.
+func ValidateRepositoryMetadata(md *hub.RepositoryMetadata) error {
+	var errs *multierror.Error
+
+	if md.RepositoryID != "" {
+		if _, err := uuid.FromString(md.RepositoryID); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: %s", ErrInvalidMetadata, "invalid repository id"))
+		}
+	}
+	if md.Cosign != nil {
+		for _, identity := range md.Cosign.Identities {
+			if identity == nil || identity.Issuer == "" || identity.Subject == "" {
+				errs = multierror.Append(errs, fmt.Errorf("%w: %s", ErrInvalidMetadata, "cosign identity issuer and subject are required"))
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// flattenErrors returns a list with the messages of the errors wrapped in the
+// error provided, so that each problem found is reported individually.
+func flattenErrors(err error) []string {
+	if err == nil {
+		return []string{}
+	}
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		msgs := make([]string, 0, len(merr.Errors))
+		for _, e := range merr.Errors {
+			msgs = append(msgs, flattenErrors(e)...)
+		}
+		return msgs
+	}
+	var terr *yaml.TypeError
+	if errors.As(err, &terr) {
+		msgs := make([]string, 0, len(terr.Errors))
+		for _, e := range terr.Errors {
+			msgs = append(msgs, "invalid yaml: "+e)
+		}
+		return msgs
+	}
+	return []string{err.Error()}
+}
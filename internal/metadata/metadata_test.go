@@ -0,0 +1,168 @@
+package metadata
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		kind           Kind
+		data           string
+		expectedErrors []string
+	}{
+		{
+			"package: empty file",
+			Package,
+			"",
+			[]string{"metadata file is empty"},
+		},
+		{
+			"package: invalid yaml",
+			Package,
+			"name: [",
+			[]string{"yaml: line 1: did not find expected node content"},
+		},
+		{
+			"package: unknown field",
+			Package,
+			"version: 1.0.0\nname: pkg1\ndisplayName: Package 1\ncreatedAt: 2020-01-20T00:00:00Z\ndescription: Sample description\nunknown: value\n",
+			[]string{"invalid yaml: line 6: field unknown not found in type hub.PackageMetadata"},
+		},
+		{
+			"package: required fields not provided",
+			Package,
+			"name: pkg1\n",
+			[]string{
+				"invalid metadata: version not provided",
+				"invalid metadata: display name not provided",
+				"invalid metadata: createdAt not provided",
+				"invalid metadata: description not provided",
+			},
+		},
+		{
+			"package: valid",
+			Package,
+			"version: 1.0.0\nname: pkg1\ndisplayName: Package 1\ncreatedAt: 2020-01-20T00:00:00Z\ndescription: Sample description\n",
+			[]string{},
+		},
+		{
+			"repository: empty file",
+			Repository,
+			"",
+			[]string{"error unmarshaling repository metadata file: metadata file is empty"},
+		},
+		{
+			"repository: invalid repository id and cosign identity",
+			Repository,
+			"repositoryID: invalid\ncosign:\n  identities:\n    - issuer: https://token.actions.githubusercontent.com\n",
+			[]string{
+				"invalid metadata: invalid repository id",
+				"invalid metadata: cosign identity issuer and subject are required",
+			},
+		},
+		{
+			"repository: valid",
+			Repository,
+			"repositoryID: 00000000-0000-0000-0000-000000000001\n",
+			[]string{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			result, err := Validate(tc.kind, []byte(tc.data))
+			require.NoError(t, err)
+			assert.Equal(t, tc.kind, result.Kind)
+			assert.Equal(t, tc.expectedErrors, result.Errors)
+			assert.Equal(t, len(tc.expectedErrors) == 0, result.Valid())
+		})
+	}
+
+	t.Run("unknown kind", func(t *testing.T) {
+		t.Parallel()
+		_, err := Validate("unknown", nil)
+		assert.Equal(t, ErrUnknownKind, err)
+	})
+}
+
+func TestValidateFile(t *testing.T) {
+	t.Run("unknown metadata file kind", func(t *testing.T) {
+		t.Parallel()
+		_, err := ValidateFile("testdata/invalid/other.yml")
+		assert.True(t, errors.Is(err, ErrUnknownKind))
+	})
+
+	t.Run("error reading metadata file", func(t *testing.T) {
+		t.Parallel()
+		_, err := ValidateFile("testdata/not-exists/artifacthub-pkg.yml")
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+
+	t.Run("invalid package metadata file", func(t *testing.T) {
+		t.Parallel()
+		result, err := ValidateFile("testdata/invalid/artifacthub-pkg.yml")
+		require.NoError(t, err)
+		assert.Equal(t, &ValidationResult{
+			Kind: Package,
+			Errors: []string{
+				"invalid yaml: line 5: field unknownField not found in type hub.PackageMetadata",
+				"invalid metadata: invalid version (semver expected): Invalid Semantic Version",
+				"invalid metadata: display name not provided",
+				"invalid metadata: invalid createdAt (RFC3339 expected): parsing time \"2020-01-20\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"\" as \"T\"",
+			},
+		}, result)
+	})
+
+	t.Run("invalid repository metadata file", func(t *testing.T) {
+		t.Parallel()
+		result, err := ValidateFile("testdata/invalid/artifacthub-repo.yml")
+		require.NoError(t, err)
+		assert.Equal(t, &ValidationResult{
+			Kind: Repository,
+			Errors: []string{
+				"invalid metadata: invalid repository id",
+				"invalid metadata: cosign identity issuer and subject are required",
+			},
+		}, result)
+	})
+
+	t.Run("valid metadata files", func(t *testing.T) {
+		t.Parallel()
+		for _, path := range []string{
+			"testdata/valid/artifacthub-pkg.yml",
+			"testdata/valid/artifacthub-repo.yaml",
+		} {
+			result, err := ValidateFile(path)
+			require.NoError(t, err)
+			assert.True(t, result.Valid())
+		}
+	})
+}
+
+func TestGetKind(t *testing.T) {
+	testCases := []struct {
+		path         string
+		expectedKind Kind
+	}{
+		{"artifacthub-pkg.yml", Package},
+		{"path/artifacthub-pkg.yaml", Package},
+		{"artifacthub-repo.yml", Repository},
+		{"/path/artifacthub-repo.yaml", Repository},
+		{"artifacthub-pkg.json", ""},
+		{"Chart.yaml", ""},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedKind, GetKind(tc.path))
+		})
+	}
+}
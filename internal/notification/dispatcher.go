@@ -2,8 +2,8 @@ package notification
 
 import (
 	"context"
+	"html/template"
 	"sync"
-	"text/template"
 	"time"
 
 	_ "embed" // Used by templates
@@ -15,28 +15,60 @@ import (
 )
 
 const (
-	defaultNumWorkers      = 2
 	cacheDefaultExpiration = 5 * time.Minute
 	cacheCleanupInterval   = 10 * time.Minute
 )
 
+// channels represents the channels used to deliver notifications. Each of
+// them gets its own pool of workers, so that a slow channel doesn't delay the
+// notifications to be delivered through the others.
+var channels = []hub.NotificationChannel{
+	hub.EmailChannel,
+	hub.IssueTrackerChannel,
+	hub.WebhookChannel,
+}
+
+// defaultNumWorkers represents the number of workers launched by default for
+// each of the channels.
+var defaultNumWorkers = map[hub.NotificationChannel]int{
+	hub.EmailChannel:        2,
+	hub.IssueTrackerChannel: 1,
+	hub.WebhookChannel:      2,
+}
+
+// numWorkersCfgKey represents the configuration key that allows setting the
+// number of workers for each of the channels.
+var numWorkersCfgKey = map[hub.NotificationChannel]string{
+	hub.EmailChannel:        "notifications.workers.email",
+	hub.IssueTrackerChannel: "notifications.workers.issueTracker",
+	hub.WebhookChannel:      "notifications.workers.webhook",
+}
+
 type templateID int
 
 const (
-	newReleaseEmail templateID = iota
+	contentWarningsEmail templateID = iota
+	newReleaseEmail
 	ownershipClaimEmail
+	packageVersionEOLEmail
 	scanningErrorsEmail
 	securityAlertEmail
 	trackingErrorsEmail
 )
 
 var (
+	//go:embed template/content_warnings_email.tmpl
+	contentWarningsEmailTmpl string
+
 	//go:embed template/new_release_email.tmpl
 	newReleaseEmailTmpl string
 
 	//go:embed template/ownership_claim_email.tmpl
 	ownershipClaimEmailTmpl string
 
+	//go:embed template/package_version_eol_email.tmpl
+	packageVersionEOLEmailTmpl string
+
 	//go:embed template/scanning_errors_email.tmpl
 	scanningErrorsEmailTmpl string
 
@@ -57,49 +89,85 @@ type Services struct {
 	SubscriptionManager hub.SubscriptionManager
 	RepositoryManager   hub.RepositoryManager
 	PackageManager      hub.PackageManager
+	IssueTrackerManager hub.IssueTrackerManager
 	HTTPClient          hub.HTTPClient
 }
 
 // Dispatcher handles a group of workers in charge of delivering notifications.
+// Workers are organized in pools, one per delivery channel, which limits the
+// number of notifications that can be delivered concurrently through each of
+// them.
 type Dispatcher struct {
-	numWorkers int
+	numWorkers map[hub.NotificationChannel]int
+	heartbeat  func()
+	mm         hub.MaintenanceManager
 	workers    []*Worker
 }
 
-// NewDispatcher creates a new Dispatcher instance.
+// NewDispatcher creates a new Dispatcher instance. The number of workers of
+// each channel can be set in the configuration, falling back to the defaults
+// when not provided.
 func NewDispatcher(svc *Services, opts ...func(d *Dispatcher)) *Dispatcher {
 	// Setup dispatcher
 	d := &Dispatcher{
-		numWorkers: defaultNumWorkers,
+		numWorkers: make(map[hub.NotificationChannel]int, len(channels)),
+	}
+	for _, channel := range channels {
+		d.numWorkers[channel] = defaultNumWorkers[channel]
+		if svc.Cfg != nil && svc.Cfg.IsSet(numWorkersCfgKey[channel]) {
+			d.numWorkers[channel] = svc.Cfg.GetInt(numWorkersCfgKey[channel])
+		}
 	}
 	for _, o := range opts {
 		o(d)
 	}
 
-	// Setup templates
-	tmpl := map[templateID]*template.Template{
-		newReleaseEmail:     template.Must(template.New("").Parse(email.BaseTmpl + newReleaseEmailTmpl)),
-		ownershipClaimEmail: template.Must(template.New("").Parse(email.BaseTmpl + ownershipClaimEmailTmpl)),
-		scanningErrorsEmail: template.Must(template.New("").Parse(email.BaseTmpl + scanningErrorsEmailTmpl)),
-		securityAlertEmail:  template.Must(template.New("").Parse(email.BaseTmpl + securityAlertEmailTmpl)),
-		trackingErrorsEmail: template.Must(template.New("").Parse(email.BaseTmpl + trackingErrorsEmailTmpl)),
-	}
-
 	// Setup and launch workers
+	tmpl := parseEmailTemplates()
 	c := cache.New(cacheDefaultExpiration, cacheCleanupInterval)
-	d.workers = make([]*Worker, 0, d.numWorkers)
-	for i := 0; i < d.numWorkers; i++ {
-		d.workers = append(d.workers, NewWorker(svc, c, tmpl))
+	for _, channel := range channels {
+		for i := 0; i < d.numWorkers[channel]; i++ {
+			w := NewWorker(svc, c, tmpl, channel)
+			w.heartbeat = d.heartbeat
+			w.mm = d.mm
+			d.workers = append(d.workers, w)
+		}
 	}
 
 	return d
 }
 
-// WithNumWorkers allows providing a specific number of workers for a
-// Dispatcher instance.
+// WithNumWorkers allows providing a specific number of workers for each of
+// the channels of a Dispatcher instance.
 func WithNumWorkers(n int) func(d *Dispatcher) {
 	return func(d *Dispatcher) {
-		d.numWorkers = n
+		for _, channel := range channels {
+			d.numWorkers[channel] = n
+		}
+	}
+}
+
+// WithChannelNumWorkers allows providing a specific number of workers for the
+// channel provided of a Dispatcher instance.
+func WithChannelNumWorkers(channel hub.NotificationChannel, n int) func(d *Dispatcher) {
+	return func(d *Dispatcher) {
+		d.numWorkers[channel] = n
+	}
+}
+
+// WithHeartbeat allows providing a function that will be called periodically
+// by the dispatcher workers, so that their liveness can be tracked.
+func WithHeartbeat(beat func()) func(d *Dispatcher) {
+	return func(d *Dispatcher) {
+		d.heartbeat = beat
+	}
+}
+
+// WithMaintenanceManager allows providing a maintenance manager, used by the
+// dispatcher workers to pause while the maintenance mode is enabled.
+func WithMaintenanceManager(mm hub.MaintenanceManager) func(d *Dispatcher) {
+	return func(d *Dispatcher) {
+		d.mm = mm
 	}
 }
 
@@ -121,3 +189,17 @@ func (d *Dispatcher) Run(ctx context.Context, wg *sync.WaitGroup) {
 	stopWorkers()
 	wwg.Wait()
 }
+
+// parseEmailTemplates parses the templates used to build the notifications
+// emails.
+func parseEmailTemplates() map[templateID]*template.Template {
+	return map[templateID]*template.Template{
+		contentWarningsEmail:   email.ParseTemplate(contentWarningsEmailTmpl),
+		newReleaseEmail:        email.ParseTemplate(newReleaseEmailTmpl),
+		ownershipClaimEmail:    email.ParseTemplate(ownershipClaimEmailTmpl),
+		packageVersionEOLEmail: email.ParseTemplate(packageVersionEOLEmailTmpl),
+		scanningErrorsEmail:    email.ParseTemplate(scanningErrorsEmailTmpl),
+		securityAlertEmail:     email.ParseTemplate(securityAlertEmailTmpl),
+		trackingErrorsEmail:    email.ParseTemplate(trackingErrorsEmailTmpl),
+	}
+}
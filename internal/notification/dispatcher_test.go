@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/artifacthub/hub/internal/hub"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -31,3 +32,23 @@ func TestDispatcher(t *testing.T) {
 		return true
 	}, 2*time.Second, 100*time.Millisecond)
 }
+
+func TestDispatcherWorkersPools(t *testing.T) {
+	t.Parallel()
+
+	// Setup dispatcher
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "http://localhost:8000")
+	cfg.Set("notifications.workers.webhook", 4)
+	d := NewDispatcher(&Services{Cfg: cfg}, WithChannelNumWorkers(hub.IssueTrackerChannel, 0))
+
+	// Check the workers pools were set up as expected
+	numWorkers := make(map[hub.NotificationChannel]int)
+	for _, w := range d.workers {
+		numWorkers[w.channel]++
+	}
+	assert.Equal(t, map[hub.NotificationChannel]int{
+		hub.EmailChannel:   defaultNumWorkers[hub.EmailChannel],
+		hub.WebhookChannel: 4,
+	}, numWorkers)
+}
@@ -13,7 +13,7 @@ import (
 const (
 	// Database queries
 	addNotificationDBQ          = `select add_notification($1::jsonb)`
-	getPendingNotificationDBQ   = `select get_pending_notification()`
+	getPendingNotificationDBQ   = `select get_pending_notification($1::text)`
 	updateNotificationStatusDBQ = `select update_notification_status($1::uuid, $2::boolean, $3::text)`
 )
 
@@ -51,10 +51,16 @@ func (m *Manager) Add(ctx context.Context, tx pgx.Tx, n *hub.Notification) error
 	return err
 }
 
-// GetPending returns a pending notification to be delivered if available.
-func (m *Manager) GetPending(ctx context.Context, tx pgx.Tx) (*hub.Notification, error) {
+// GetPending returns a pending notification to be delivered through the
+// channel provided if available. Notifications for urgent events, like
+// security alerts, are returned first.
+func (m *Manager) GetPending(
+	ctx context.Context,
+	tx pgx.Tx,
+	channel hub.NotificationChannel,
+) (*hub.Notification, error) {
 	var dataJSON []byte
-	if err := tx.QueryRow(ctx, getPendingNotificationDBQ).Scan(&dataJSON); err != nil {
+	if err := tx.QueryRow(ctx, getPendingNotificationDBQ, string(channel)).Scan(&dataJSON); err != nil {
 		return nil, err
 	}
 	var n *hub.Notification
@@ -110,10 +110,10 @@ func TestGetPending(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		tx := &tests.TXMock{}
-		tx.On("QueryRow", ctx, getPendingNotificationDBQ).Return(nil, tests.ErrFakeDB)
+		tx.On("QueryRow", ctx, getPendingNotificationDBQ, "email").Return(nil, tests.ErrFakeDB)
 		m := NewManager()
 
-		dataJSON, err := m.GetPending(ctx, tx)
+		dataJSON, err := m.GetPending(ctx, tx, hub.EmailChannel)
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
 		tx.AssertExpectations(t)
@@ -134,7 +134,7 @@ func TestGetPending(t *testing.T) {
 		}
 
 		tx := &tests.TXMock{}
-		tx.On("QueryRow", ctx, getPendingNotificationDBQ).Return([]byte(`
+		tx.On("QueryRow", ctx, getPendingNotificationDBQ, "email").Return([]byte(`
 		{
 			"notification_id": "notificationID",
 			"event": {
@@ -149,7 +149,7 @@ func TestGetPending(t *testing.T) {
 		`), nil)
 		m := NewManager()
 
-		n, err := m.GetPending(ctx, tx)
+		n, err := m.GetPending(ctx, tx, hub.EmailChannel)
 		require.NoError(t, err)
 		assert.Equal(t, expectedNotification, n)
 		tx.AssertExpectations(t)
@@ -20,8 +20,12 @@ func (m *ManagerMock) Add(ctx context.Context, tx pgx.Tx, n *hub.Notification) e
 }
 
 // GetPending implements the NotificationManager interface.
-func (m *ManagerMock) GetPending(ctx context.Context, tx pgx.Tx) (*hub.Notification, error) {
-	args := m.Called(ctx, tx)
+func (m *ManagerMock) GetPending(
+	ctx context.Context,
+	tx pgx.Tx,
+	channel hub.NotificationChannel,
+) (*hub.Notification, error) {
+	args := m.Called(ctx, tx, channel)
 	data, _ := args.Get(0).(*hub.Notification)
 	return data, args.Error(1)
 }
@@ -0,0 +1,136 @@
+package notification
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/sandbox"
+	"github.com/spf13/viper"
+)
+
+// previewEmailTmpl represents the templates used to render the emails
+// notifications previews.
+var previewEmailTmpl = parseEmailTemplates()
+
+// Preview represents how a notification about an event of a given kind will
+// look like when delivered to subscribers.
+type Preview struct {
+	EventKind hub.EventKind   `json:"event_kind"`
+	Email     *EmailPreview   `json:"email"`
+	Webhook   *WebhookPreview `json:"webhook"`
+}
+
+// EmailPreview represents the email subscribers will receive.
+type EmailPreview struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// WebhookPreview represents the request the webhook endpoint will receive.
+type WebhookPreview struct {
+	ContentType string `json:"content_type"`
+	Payload     string `json:"payload"`
+}
+
+// RenderPreviews renders the email and webhook notifications templates against
+// some sample data, for each of the event kinds the provided webhook is
+// subscribed to. When the webhook does not specify any event kinds, the
+// previews for new releases events are returned.
+func RenderPreviews(cfg *viper.Viper, wh *hub.Webhook, locale string) ([]*Preview, error) {
+	// Validate input and parse webhook template
+	eventKinds := wh.EventKinds
+	if len(eventKinds) == 0 {
+		eventKinds = []hub.EventKind{hub.NewRelease}
+	}
+	for _, eventKind := range eventKinds {
+		if pkgEventKindName(eventKind) == "" {
+			return nil, fmt.Errorf("%w: invalid event kind: %d", hub.ErrInvalidInput, eventKind)
+		}
+	}
+	var tmpl *template.Template
+	if wh.Template != "" {
+		var err error
+		tmpl, err = sandbox.ParseTemplate(wh.Template)
+		if err != nil {
+			return nil, fmt.Errorf("%w: error parsing template: %v", hub.ErrInvalidInput, err)
+		}
+	} else {
+		tmpl = DefaultWebhookPayloadTmpl
+	}
+	contentType := wh.ContentType
+	if contentType == "" {
+		contentType = DefaultPayloadContentType
+	}
+
+	// Render previews
+	previews := make([]*Preview, 0, len(eventKinds))
+	for _, eventKind := range eventKinds {
+		tmplData := SamplePkgNotificationTemplateData(eventKind, cfg.GetString("server.baseURL"))
+		tmplData.Theme = map[string]string{
+			"PrimaryColor":   cfg.GetString("theme.colors.primary"),
+			"SecondaryColor": cfg.GetString("theme.colors.secondary"),
+			"SiteName":       cfg.GetString("theme.siteName"),
+		}
+		emailData, err := renderEmail(previewEmailTmpl, eventKind, locale, tmplData)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering email: %w", err)
+		}
+		payload, err := sandbox.ExecuteTemplate(tmpl, tmplData)
+		if err != nil {
+			return nil, fmt.Errorf("%w: error executing template: %v", hub.ErrInvalidInput, err)
+		}
+		previews = append(previews, &Preview{
+			EventKind: eventKind,
+			Email: &EmailPreview{
+				Subject: emailData.Subject,
+				Body:    string(emailData.Body),
+			},
+			Webhook: &WebhookPreview{
+				ContentType: contentType,
+				Payload:     string(payload),
+			},
+		})
+	}
+
+	return previews, nil
+}
+
+// SamplePkgNotificationTemplateData returns some sample package notification
+// template data for the event kind provided, which can be used to test or
+// preview notifications templates.
+func SamplePkgNotificationTemplateData(
+	eventKind hub.EventKind,
+	baseURL string,
+) *hub.PackageNotificationTemplateData {
+	return &hub.PackageNotificationTemplateData{
+		BaseURL: baseURL,
+		Event: map[string]interface{}{
+			"ID":   "00000000-0000-0000-0000-000000000001",
+			"Kind": pkgEventKindName(eventKind),
+		},
+		Package: map[string]interface{}{
+			"Name":        "sample-package",
+			"Version":     "1.0.0",
+			"LogoImageID": "",
+			"URL":         "https://artifacthub.io/packages/helm/artifacthub/sample-package/1.0.0",
+			"Changes": []*hub.Change{
+				{
+					Description: "Cool feature",
+				},
+				{
+					Description: "Bug fixed",
+				},
+			},
+			"ContainsSecurityUpdates": true,
+			"Prerelease":              true,
+			"TS":                      time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+			"Repository": map[string]interface{}{
+				"Kind":      "helm",
+				"Name":      "repo1",
+				"Publisher": "org1",
+			},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderPreviews(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "http://baseurl.com")
+	cfg.Set("theme.siteName", "Artifact Hub")
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			wh          *hub.Webhook
+			expectedErr string
+		}{
+			{
+				&hub.Webhook{EventKinds: []hub.EventKind{hub.RepositoryTrackingErrors}},
+				"invalid event kind",
+			},
+			{
+				&hub.Webhook{Template: "{{ .."},
+				"error parsing template",
+			},
+			{
+				&hub.Webhook{Template: "{{ call .Package.Name }}"},
+				"error parsing template",
+			},
+			{
+				&hub.Webhook{Template: "{{ .nonExistent }}"},
+				"error executing template",
+			},
+		}
+		for _, tc := range testCases {
+			previews, err := RenderPreviews(cfg, tc.wh, "en")
+			assert.ErrorIs(t, err, hub.ErrInvalidInput)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+			assert.Nil(t, previews)
+		}
+	})
+
+	t.Run("default template and event kind", func(t *testing.T) {
+		t.Parallel()
+		previews, err := RenderPreviews(cfg, &hub.Webhook{}, "en")
+		require.NoError(t, err)
+		require.Len(t, previews, 1)
+		assert.Equal(t, hub.NewRelease, previews[0].EventKind)
+		assert.Equal(t, DefaultPayloadContentType, previews[0].Webhook.ContentType)
+		assert.Contains(t, previews[0].Webhook.Payload, `"type" : "io.artifacthub.package.new-release"`)
+		assert.Contains(t, previews[0].Webhook.Payload, `"source" : "http://baseurl.com"`)
+		assert.Contains(t, previews[0].Email.Subject, "sample-package")
+		assert.Contains(t, previews[0].Email.Body, "http://baseurl.com")
+	})
+
+	t.Run("custom template and several event kinds", func(t *testing.T) {
+		t.Parallel()
+		wh := &hub.Webhook{
+			ContentType: "text/plain",
+			Template:    "{{ .Event.Kind }}: {{ .Package.Name }} {{ .Package.Version }}",
+			EventKinds:  []hub.EventKind{hub.SecurityAlert, hub.ContentWarnings, hub.PackageVersionEOL},
+		}
+		previews, err := RenderPreviews(cfg, wh, "en")
+		require.NoError(t, err)
+		require.Len(t, previews, 3)
+		for i, eventKind := range wh.EventKinds {
+			assert.Equal(t, eventKind, previews[i].EventKind)
+			assert.Equal(t, "text/plain", previews[i].Webhook.ContentType)
+			assert.Equal(t, pkgEventKindName(eventKind)+": sample-package 1.0.0", previews[i].Webhook.Payload)
+			assert.NotEmpty(t, previews[i].Email.Subject)
+			assert.NotEmpty(t, previews[i].Email.Body)
+		}
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	htmltemplate "html/template"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
@@ -14,15 +16,21 @@ import (
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/handlers/pkg"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/issuetracker"
+	"github.com/artifacthub/hub/internal/maintenance"
+	"github.com/artifacthub/hub/internal/sandbox"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/jackc/pgx/v4"
 	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/rs/zerolog/log"
 )
 
 const (
-	pauseOnEmptyQueue = 30 * time.Second
-	pauseOnError      = 10 * time.Second
+	pauseOnEmptyQueue  = 30 * time.Second
+	pauseOnError       = 10 * time.Second
+	pauseOnMaintenance = 30 * time.Second
 
 	// DefaultPayloadContentType represents the default content type used for
 	// webhooks notifications.
@@ -33,25 +41,40 @@ var (
 	// ErrRetryable is meant to be used as a wrapper for other errors to
 	// indicate the error is not final and the operation should be retried.
 	ErrRetryable = errors.New("retryable error")
+
+	// deliveries tracks the outcome of the notifications delivered.
+	deliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "notifications_deliveries_total",
+		Help: "Number of notifications delivery attempts by channel, event kind and outcome.",
+	},
+		[]string{"channel", "event_kind", "outcome"},
+	)
 )
 
-// Worker is in charge of delivering notifications to their intended recipients.
+// Worker is in charge of delivering notifications to their intended
+// recipients. Each worker only processes notifications to be delivered
+// through the channel it has been assigned.
 type Worker struct {
-	svc   *Services
-	cache *cache.Cache
-	tmpl  map[templateID]*template.Template
+	svc       *Services
+	cache     *cache.Cache
+	tmpl      map[templateID]*htmltemplate.Template
+	channel   hub.NotificationChannel
+	heartbeat func()
+	mm        hub.MaintenanceManager
 }
 
 // NewWorker creates a new Worker instance.
 func NewWorker(
 	svc *Services,
 	c *cache.Cache,
-	tmpl map[templateID]*template.Template,
+	tmpl map[templateID]*htmltemplate.Template,
+	channel hub.NotificationChannel,
 ) *Worker {
 	return &Worker{
-		svc:   svc,
-		cache: c,
-		tmpl:  tmpl,
+		svc:     svc,
+		cache:   c,
+		tmpl:    tmpl,
+		channel: channel,
 	}
 }
 
@@ -61,6 +84,17 @@ func (w *Worker) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for {
+		if w.heartbeat != nil {
+			w.heartbeat()
+		}
+		if w.mm != nil && maintenance.IsEnabled(ctx, w.mm) {
+			select {
+			case <-time.After(pauseOnMaintenance):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
 		err := w.processNotification(ctx)
 		switch {
 		case err == nil:
@@ -85,12 +119,12 @@ func (w *Worker) Run(ctx context.Context, wg *sync.WaitGroup) {
 	}
 }
 
-// processNotification gets a pending notification from the database and
-// delivers it.
+// processNotification gets a pending notification for the worker's channel
+// from the database and delivers it.
 func (w *Worker) processNotification(ctx context.Context) error {
 	return util.DBTransact(ctx, w.svc.DB, func(tx pgx.Tx) error {
 		// Get pending notification to process
-		n, err := w.svc.NotificationManager.GetPending(ctx, tx)
+		n, err := w.svc.NotificationManager.GetPending(ctx, tx, w.channel)
 		if err != nil {
 			if !errors.Is(err, pgx.ErrNoRows) {
 				log.Error().Err(err).Msg("processNotification: error getting pending notification")
@@ -108,7 +142,23 @@ func (w *Worker) processNotification(ctx context.Context) error {
 			}
 		case n.Webhook != nil:
 			err = w.deliverWebhookNotification(ctx, n)
+		case n.IssueTracker != nil:
+			err = w.deliverIssueTrackerNotification(ctx, n)
+		}
+		outcome := "success"
+		switch {
+		case errors.Is(err, ErrRetryable):
+			outcome = "retry"
+		case errors.Is(err, email.ErrRecipientSuppressed):
+			outcome = "suppressed"
+		case err != nil:
+			outcome = "error"
 		}
+		deliveries.WithLabelValues(
+			string(w.channel),
+			strconv.Itoa(int(n.Event.EventKind)),
+			outcome,
+		).Inc()
 		if errors.Is(err, ErrRetryable) {
 			log.Error().Err(err).Msg("processNotification: error delivering notification")
 			return err
@@ -127,13 +177,14 @@ func (w *Worker) processNotification(ctx context.Context) error {
 func (w *Worker) deliverEmailNotification(ctx context.Context, n *hub.Notification) error {
 	// Prepare email data
 	var emailData email.Data
-	cKey := "emailData.%" + n.Event.EventID
+	locale := email.NormalizeLocale(n.User.Locale)
+	cKey := "emailData.%" + n.Event.EventID + "." + locale
 	cValue, ok := w.cache.Get(cKey)
 	if ok {
 		emailData = cValue.(email.Data)
 	} else {
 		var err error
-		emailData, err = w.prepareEmailData(ctx, n.Event)
+		emailData, err = w.prepareEmailData(ctx, n.Event, locale)
 		if err != nil {
 			return fmt.Errorf("%w: error preparing email data: %v", ErrRetryable, err)
 		}
@@ -157,15 +208,15 @@ func (w *Worker) deliverWebhookNotification(ctx context.Context, n *hub.Notifica
 	var tmpl *template.Template
 	if n.Webhook.Template != "" {
 		var err error
-		tmpl, err = template.New("").Parse(n.Webhook.Template)
+		tmpl, err = sandbox.ParseTemplate(n.Webhook.Template)
 		if err != nil {
 			return err
 		}
 	} else {
 		tmpl = DefaultWebhookPayloadTmpl
 	}
-	var payload bytes.Buffer
-	if err := tmpl.Execute(&payload, tmplData); err != nil {
+	payload, err := sandbox.ExecuteTemplate(tmpl, tmplData)
+	if err != nil {
 		return err
 	}
 	contentType := n.Webhook.ContentType
@@ -174,7 +225,7 @@ func (w *Worker) deliverWebhookNotification(ctx context.Context, n *hub.Notifica
 	}
 
 	// Call webhook endpoint
-	req, _ := http.NewRequest("POST", n.Webhook.URL, &payload)
+	req, _ := http.NewRequest("POST", n.Webhook.URL, bytes.NewReader(payload))
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("X-ArtifactHub-Secret", n.Webhook.Secret)
 	resp, err := w.svc.HTTPClient.Do(req)
@@ -188,60 +239,114 @@ func (w *Worker) deliverWebhookNotification(ctx context.Context, n *hub.Notifica
 	return nil
 }
 
-// prepareEmailData prepares the email data corresponding to the event provided.
-func (w *Worker) prepareEmailData(ctx context.Context, e *hub.Event) (email.Data, error) {
-	var subject string
-	var emailBody bytes.Buffer
+// deliverIssueTrackerNotification opens an issue in the notification's issue
+// tracker for each of the critical vulnerabilities found in the package that
+// don't have one yet. Issues are registered as soon as they are opened, so
+// that they are not opened again if the notification delivery is retried.
+func (w *Worker) deliverIssueTrackerNotification(ctx context.Context, n *hub.Notification) error {
+	// Get vulnerabilities pending to be reported
+	it := n.IssueTracker
+	vulnerabilities, err := w.svc.IssueTrackerManager.GetPendingVulnerabilities(ctx, it.IssueTrackerID, n.Event)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRetryable, err)
+	}
+	if len(vulnerabilities) == 0 {
+		return nil
+	}
 
-	switch e.EventKind {
-	case hub.NewRelease:
-		tmplData, err := w.preparePkgNotificationTemplateData(ctx, e)
+	// Get template data
+	tmplData, err := w.preparePkgNotificationTemplateData(ctx, n.Event)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRetryable, err)
+	}
+
+	// Open an issue for each vulnerability
+	for _, v := range vulnerabilities {
+		issue := issuetracker.BuildIssue(tmplData, v)
+		issueURL, err := issuetracker.CreateIssue(ctx, w.svc.HTTPClient, it, issue)
 		if err != nil {
-			return email.Data{}, err
-		}
-		subject = fmt.Sprintf("%s version %s released", tmplData.Package["Name"], tmplData.Package["Version"])
-		if err := w.tmpl[newReleaseEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+			return err
 		}
-	case hub.SecurityAlert:
-		tmplData, err := w.preparePkgNotificationTemplateData(ctx, e)
+		err = w.svc.IssueTrackerManager.RegisterIssue(ctx, it.IssueTrackerID, n.Event.PackageID, v.VulnerabilityID, issueURL)
 		if err != nil {
-			return email.Data{}, err
+			return err
 		}
-		subject = fmt.Sprintf("Security vulnerabilities found in %s version %s images",
-			tmplData.Package["Name"], tmplData.Package["Version"])
-		if err := w.tmpl[securityAlertEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+	}
+	return nil
+}
+
+// prepareEmailData prepares the email data corresponding to the event provided
+// using the locale given.
+func (w *Worker) prepareEmailData(ctx context.Context, e *hub.Event, locale string) (email.Data, error) {
+	var tmplData interface{}
+	var err error
+	switch e.EventKind {
+	case hub.NewRelease, hub.SecurityAlert, hub.ContentWarnings, hub.PackageVersionEOL:
+		tmplData, err = w.preparePkgNotificationTemplateData(ctx, e)
+	case hub.RepositoryScanningErrors, hub.RepositoryTrackingErrors, hub.RepositoryOwnershipClaim:
+		tmplData, err = w.prepareRepoNotificationTemplateData(ctx, e)
+	}
+	if err != nil {
+		return email.Data{}, err
+	}
+	return renderEmail(w.tmpl, e.EventKind, locale, tmplData)
+}
+
+// renderEmail renders the email corresponding to the event kind provided
+// using the template data and locale given.
+func renderEmail(
+	tmpl map[templateID]*htmltemplate.Template,
+	eventKind hub.EventKind,
+	locale string,
+	tmplData interface{},
+) (email.Data, error) {
+	var subject string
+	var tmplID templateID
+
+	switch eventKind {
+	case hub.NewRelease:
+		pkgData := tmplData.(*hub.PackageNotificationTemplateData)
+		key := "new_release.subject_version"
+		if pkgData.Package["Repository"].(map[string]interface{})["Kind"] == "container" {
+			key = "new_release.subject_tag"
 		}
+		subject = email.Translate(locale, key, pkgData.Package["Name"], pkgData.Package["Version"])
+		tmplID = newReleaseEmail
+	case hub.SecurityAlert:
+		pkgData := tmplData.(*hub.PackageNotificationTemplateData)
+		subject = email.Translate(locale, "security_alert.subject",
+			pkgData.Package["Name"], pkgData.Package["Version"])
+		tmplID = securityAlertEmail
+	case hub.ContentWarnings:
+		pkgData := tmplData.(*hub.PackageNotificationTemplateData)
+		subject = email.Translate(locale, "content_warnings.subject",
+			pkgData.Package["Name"], pkgData.Package["Version"])
+		tmplID = contentWarningsEmail
+	case hub.PackageVersionEOL:
+		pkgData := tmplData.(*hub.PackageNotificationTemplateData)
+		subject = email.Translate(locale, "package_version_eol.subject",
+			pkgData.Package["Name"], pkgData.Package["Version"])
+		tmplID = packageVersionEOLEmail
 	case hub.RepositoryScanningErrors:
-		tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
-		if err != nil {
-			return email.Data{}, err
-		}
-		subject = fmt.Sprintf("Something went wrong scanning repository %s", tmplData.Repository["Name"])
-		if err := w.tmpl[scanningErrorsEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
-		}
+		repoData := tmplData.(*hub.RepositoryNotificationTemplateData)
+		subject = email.Translate(locale, "scanning_errors.subject", repoData.Repository["Name"])
+		tmplID = scanningErrorsEmail
 	case hub.RepositoryTrackingErrors:
-		tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
-		if err != nil {
-			return email.Data{}, err
-		}
-		subject = fmt.Sprintf("Something went wrong tracking repository %s", tmplData.Repository["Name"])
-		if err := w.tmpl[trackingErrorsEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
-		}
+		repoData := tmplData.(*hub.RepositoryNotificationTemplateData)
+		subject = email.Translate(locale, "tracking_errors.subject", repoData.Repository["Name"])
+		tmplID = trackingErrorsEmail
 	case hub.RepositoryOwnershipClaim:
-		tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
-		if err != nil {
-			return email.Data{}, err
-		}
-		subject = fmt.Sprintf("%s repository ownership has been claimed", tmplData.Repository["Name"])
-		if err := w.tmpl[ownershipClaimEmail].Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
-		}
+		repoData := tmplData.(*hub.RepositoryNotificationTemplateData)
+		subject = email.Translate(locale, "ownership_claim.subject", repoData.Repository["Name"])
+		tmplID = ownershipClaimEmail
+	default:
+		return email.Data{}, nil
 	}
 
+	var emailBody bytes.Buffer
+	if err := email.ExecuteTemplate(&emailBody, tmpl[tmplID], locale, tmplData); err != nil {
+		return email.Data{}, err
+	}
 	return email.Data{
 		Subject: subject,
 		Body:    emailBody.Bytes(),
@@ -273,13 +378,6 @@ func (w *Worker) preparePkgNotificationTemplateData(
 	}
 
 	// Prepare template data
-	var eventKindStr string
-	switch e.EventKind {
-	case hub.NewRelease:
-		eventKindStr = "package.new-release"
-	case hub.SecurityAlert:
-		eventKindStr = "package.security-alert"
-	}
 	publisher := p.Repository.OrganizationName
 	if publisher == "" {
 		publisher = p.Repository.UserAlias
@@ -290,7 +388,7 @@ func (w *Worker) preparePkgNotificationTemplateData(
 		BaseURL: baseURL,
 		Event: map[string]interface{}{
 			"ID":   e.EventID,
-			"Kind": eventKindStr,
+			"Kind": pkgEventKindName(e.EventKind),
 		},
 		Package: map[string]interface{}{
 			"Name":                    p.Name,
@@ -300,6 +398,7 @@ func (w *Worker) preparePkgNotificationTemplateData(
 			"Changes":                 p.Changes,
 			"ContainsSecurityUpdates": p.ContainsSecurityUpdates,
 			"Prerelease":              p.Prerelease,
+			"TS":                      p.TS,
 			"Repository": map[string]interface{}{
 				"Kind":      hub.GetKindName(p.Repository.Kind),
 				"Name":      p.Repository.Name,
@@ -377,6 +476,23 @@ func (w *Worker) prepareRepoNotificationTemplateData(
 	}, nil
 }
 
+// pkgEventKindName returns the name of the package event kind provided, as
+// exposed to notifications templates.
+func pkgEventKindName(eventKind hub.EventKind) string {
+	switch eventKind {
+	case hub.NewRelease:
+		return "package.new-release"
+	case hub.SecurityAlert:
+		return "package.security-alert"
+	case hub.ContentWarnings:
+		return "package.content-warnings"
+	case hub.PackageVersionEOL:
+		return "package.version-eol"
+	default:
+		return ""
+	}
+}
+
 // DefaultWebhookPayloadTmpl is the template used for the webhook payload when
 // the webhook uses the default template.
 var DefaultWebhookPayloadTmpl = template.Must(template.New("").Parse(`
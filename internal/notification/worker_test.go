@@ -2,17 +2,19 @@ package notification
 
 import (
 	"context"
+	"html/template"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
-	"text/template"
 	"time"
 
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/issuetracker"
+	"github.com/artifacthub/hub/internal/maintenance"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
 	"github.com/artifacthub/hub/internal/subscription"
@@ -57,6 +59,36 @@ func TestWorker(t *testing.T) {
 		Event:          e2,
 		User:           u,
 	}
+	n4 := &hub.Notification{
+		NotificationID: "notificationID",
+		Event:          e1,
+		User: &hub.User{
+			Email:  "user2@email.com",
+			Locale: "es",
+		},
+	}
+	e3 := &hub.Event{
+		EventID:        "eventID",
+		EventKind:      hub.SecurityAlert,
+		PackageID:      "packageID",
+		PackageVersion: "1.0.0",
+	}
+	it := &hub.IssueTracker{
+		IssueTrackerID: "issueTrackerID",
+		Kind:           hub.GitHubIssueTracker,
+		Project:        "org/repo",
+		Token:          "token",
+	}
+	n5 := &hub.Notification{
+		NotificationID: "notificationID",
+		Event:          e3,
+		IssueTracker:   it,
+	}
+	v := &hub.IssueTrackerVulnerability{
+		VulnerabilityID:  "CVE-2022-0001",
+		PkgName:          "openssl",
+		InstalledVersion: "1.1.1",
+	}
 	gpi := &hub.GetPackageInput{
 		PackageID: e1.PackageID,
 		Version:   e1.PackageVersion,
@@ -89,21 +121,41 @@ func TestWorker(t *testing.T) {
 		OrganizationName: "org1",
 	}
 	tmpl := map[templateID]*template.Template{
-		newReleaseEmail:     template.Must(template.New("").Parse(email.BaseTmpl + newReleaseEmailTmpl)),
-		ownershipClaimEmail: template.Must(template.New("").Parse(email.BaseTmpl + ownershipClaimEmailTmpl)),
-		scanningErrorsEmail: template.Must(template.New("").Parse(email.BaseTmpl + scanningErrorsEmailTmpl)),
-		securityAlertEmail:  template.Must(template.New("").Parse(email.BaseTmpl + securityAlertEmailTmpl)),
-		trackingErrorsEmail: template.Must(template.New("").Parse(email.BaseTmpl + trackingErrorsEmailTmpl)),
+		contentWarningsEmail:   email.ParseTemplate(contentWarningsEmailTmpl),
+		newReleaseEmail:        email.ParseTemplate(newReleaseEmailTmpl),
+		ownershipClaimEmail:    email.ParseTemplate(ownershipClaimEmailTmpl),
+		packageVersionEOLEmail: email.ParseTemplate(packageVersionEOLEmailTmpl),
+		scanningErrorsEmail:    email.ParseTemplate(scanningErrorsEmailTmpl),
+		securityAlertEmail:     email.ParseTemplate(securityAlertEmailTmpl),
+		trackingErrorsEmail:    email.ParseTemplate(trackingErrorsEmailTmpl),
 	}
 
+	t.Run("maintenance mode enabled, notifications are not processed", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		mm := &maintenance.ManagerMock{}
+		checked := make(chan struct{})
+		mm.On("Get", sw.ctx).
+			Return(&hub.MaintenanceMode{Enabled: true}, nil).
+			Run(func(args mock.Arguments) { close(checked) }).
+			Once()
+
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
+		w.mm = mm
+		go w.Run(sw.ctx, sw.wg)
+		<-checked
+		sw.assertExpectations(t)
+		mm.AssertExpectations(t)
+	})
+
 	t.Run("error getting pending notification", func(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(nil, tests.ErrFake)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.EmailChannel).Return(nil, tests.ErrFake)
 		sw.tx.On("Rollback", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -112,11 +164,11 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n1, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.EmailChannel).Return(n1, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(nil, tests.ErrFake)
 		sw.tx.On("Rollback", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -125,11 +177,11 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n3, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.EmailChannel).Return(n3, nil)
 		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(nil, tests.ErrFake)
 		sw.tx.On("Rollback", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -138,13 +190,13 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n1, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.EmailChannel).Return(n1, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.es.On("SendEmail", mock.Anything).Return(tests.ErrFake)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, tests.ErrFake).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -153,13 +205,28 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n3, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.EmailChannel).Return(n3, nil)
 		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
 		sw.es.On("SendEmail", mock.Anything).Return(tests.ErrFake)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n3.NotificationID, true, tests.ErrFake).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("package notification email not sent (recipient suppressed)", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.EmailChannel).Return(n1, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.es.On("SendEmail", mock.Anything).Return(email.ErrRecipientSuppressed)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, email.ErrRecipientSuppressed).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -168,13 +235,30 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n1, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.EmailChannel).Return(n1, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.es.On("SendEmail", mock.Anything).Return(nil)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("package email notification delivered successfully using user locale", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.EmailChannel).Return(n4, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.es.On("SendEmail", mock.MatchedBy(func(d *email.Data) bool {
+			return d.To == "user2@email.com" && d.Subject == "Publicada la versión 1.0.0 de package1"
+		})).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n4.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -183,13 +267,13 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n3, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.EmailChannel).Return(n3, nil)
 		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
 		sw.es.On("SendEmail", mock.Anything).Return(nil)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n3.NotificationID, true, nil).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.EmailChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -198,11 +282,11 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.WebhookChannel).Return(n2, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(nil, tests.ErrFake)
 		sw.tx.On("Rollback", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.WebhookChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -211,13 +295,13 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.WebhookChannel).Return(n2, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, tests.ErrFake).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.WebhookChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -226,7 +310,7 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.WebhookChannel).Return(n2, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.hc.On("Do", mock.Anything).Return(&http.Response{
 			Body:       ioutil.NopCloser(strings.NewReader("")),
@@ -235,7 +319,7 @@ func TestWorker(t *testing.T) {
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, mock.Anything).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.WebhookChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -244,7 +328,7 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.WebhookChannel).Return(n2, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.hc.On("Do", mock.Anything).Return(&http.Response{
 			Body:       ioutil.NopCloser(strings.NewReader("")),
@@ -253,7 +337,7 @@ func TestWorker(t *testing.T) {
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
-		w := NewWorker(sw.svc, sw.cache, tmpl)
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.WebhookChannel)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
 	})
@@ -324,7 +408,7 @@ func TestWorker(t *testing.T) {
 				sw := newServicesWrapper()
 				sw.svc.HTTPClient = &http.Client{}
 				sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-				sw.nm.On("GetPending", sw.ctx, sw.tx).Return(&hub.Notification{
+				sw.nm.On("GetPending", sw.ctx, sw.tx, hub.WebhookChannel).Return(&hub.Notification{
 					NotificationID: "notificationID",
 					Event:          e1,
 					Webhook: &hub.Webhook{
@@ -338,12 +422,79 @@ func TestWorker(t *testing.T) {
 				sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
 				sw.tx.On("Commit", sw.ctx).Return(nil)
 
-				w := NewWorker(sw.svc, sw.cache, tmpl)
+				w := NewWorker(sw.svc, sw.cache, tmpl, hub.WebhookChannel)
 				go w.Run(sw.ctx, sw.wg)
 				sw.assertExpectations(t)
 			})
 		}
 	})
+
+	t.Run("error getting pending vulnerabilities", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.IssueTrackerChannel).Return(n5, nil)
+		sw.im.On("GetPendingVulnerabilities", sw.ctx, it.IssueTrackerID, e3).Return(nil, tests.ErrFake)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.IssueTrackerChannel)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("no pending vulnerabilities to open issues for", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.IssueTrackerChannel).Return(n5, nil)
+		sw.im.On("GetPendingVulnerabilities", sw.ctx, it.IssueTrackerID, e3).
+			Return([]*hub.IssueTrackerVulnerability{}, nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n5.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.IssueTrackerChannel)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error opening issue", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.IssueTrackerChannel).Return(n5, nil)
+		sw.im.On("GetPendingVulnerabilities", sw.ctx, it.IssueTrackerID, e3).
+			Return([]*hub.IssueTrackerVulnerability{v}, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n5.NotificationID, true, tests.ErrFake).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.IssueTrackerChannel)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("issue tracker notification delivered successfully", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, hub.IssueTrackerChannel).Return(n5, nil)
+		sw.im.On("GetPendingVulnerabilities", sw.ctx, it.IssueTrackerID, e3).
+			Return([]*hub.IssueTrackerVulnerability{v}, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"html_url": "https://github.com/org/repo/issues/1"}`)),
+			StatusCode: http.StatusCreated,
+		}, nil)
+		sw.im.On("RegisterIssue", sw.ctx, it.IssueTrackerID, e3.PackageID, v.VulnerabilityID,
+			"https://github.com/org/repo/issues/1").Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n5.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, tmpl, hub.IssueTrackerChannel)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
 }
 
 type servicesWrapper struct {
@@ -358,6 +509,7 @@ type servicesWrapper struct {
 	sm         *subscription.ManagerMock
 	rm         *repo.ManagerMock
 	pm         *pkg.ManagerMock
+	im         *issuetracker.ManagerMock
 	cache      *cache.Cache
 	hc         *tests.HTTPClientMock
 	svc        *Services
@@ -378,6 +530,7 @@ func newServicesWrapper() *servicesWrapper {
 	sm := &subscription.ManagerMock{}
 	rm := &repo.ManagerMock{}
 	pm := &pkg.ManagerMock{}
+	im := &issuetracker.ManagerMock{}
 	cache := cache.New(1*time.Minute, 5*time.Minute)
 	hc := &tests.HTTPClientMock{}
 
@@ -393,6 +546,7 @@ func newServicesWrapper() *servicesWrapper {
 		sm:         sm,
 		rm:         rm,
 		pm:         pm,
+		im:         im,
 		cache:      cache,
 		hc:         hc,
 		svc: &Services{
@@ -403,6 +557,7 @@ func newServicesWrapper() *servicesWrapper {
 			SubscriptionManager: sm,
 			RepositoryManager:   rm,
 			PackageManager:      pm,
+			IssueTrackerManager: im,
 			HTTPClient:          hc,
 		},
 	}
@@ -422,5 +577,6 @@ func (sw *servicesWrapper) assertExpectations(t *testing.T) {
 	sw.sm.AssertExpectations(t)
 	sw.rm.AssertExpectations(t)
 	sw.pm.AssertExpectations(t)
+	sw.im.AssertExpectations(t)
 	sw.hc.AssertExpectations(t)
 }
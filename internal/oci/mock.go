@@ -27,6 +27,42 @@ func (m *PullerMock) PullLayer(
 	return desc, data, args.Error(2)
 }
 
+// AnnotationsGetterMock is a mock implementation of the
+// hub.OCIAnnotationsGetter interface.
+type AnnotationsGetterMock struct {
+	mock.Mock
+}
+
+// GetAnnotations implements the OCIAnnotationsGetter interface.
+func (m *AnnotationsGetterMock) GetAnnotations(
+	ctx context.Context,
+	ref,
+	username,
+	password string,
+) (map[string]string, error) {
+	args := m.Called(ctx, ref, username, password)
+	annotations, _ := args.Get(0).(map[string]string)
+	return annotations, args.Error(1)
+}
+
+// ProvenanceGetterMock is a mock implementation of the hub.OCIProvenanceGetter
+// interface.
+type ProvenanceGetterMock struct {
+	mock.Mock
+}
+
+// GetSLSAProvenance implements the OCIProvenanceGetter interface.
+func (m *ProvenanceGetterMock) GetSLSAProvenance(
+	ctx context.Context,
+	ref,
+	username,
+	password string,
+) (*hub.Provenance, error) {
+	args := m.Called(ctx, ref, username, password)
+	provenance, _ := args.Get(0).(*hub.Provenance)
+	return provenance, args.Error(1)
+}
+
 // SignatureCheckerMock is a mock implementation of the hub.OCISignatureChecker
 // interface.
 type SignatureCheckerMock struct {
@@ -44,6 +80,18 @@ func (m *SignatureCheckerMock) HasCosignSignature(
 	return args.Bool(0), args.Error(1)
 }
 
+// VerifyCosignSignature implements the OCISignatureChecker interface.
+func (m *SignatureCheckerMock) VerifyCosignSignature(
+	ctx context.Context,
+	ref,
+	username,
+	password string,
+	cfg *hub.CosignConfig,
+) (bool, error) {
+	args := m.Called(ctx, ref, username, password, cfg)
+	return args.Bool(0), args.Error(1)
+}
+
 // TagsGetterMock is a mock implementation of the hub.OCITagsGetter interface.
 type TagsGetterMock struct {
 	mock.Mock
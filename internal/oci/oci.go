@@ -1,8 +1,13 @@
 package oci
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -11,15 +16,27 @@ import (
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sigstore/cosign/cmd/cosign/cli/fulcio"
+	"github.com/sigstore/cosign/pkg/cosign"
 	csremote "github.com/sigstore/cosign/pkg/oci/remote"
 	"github.com/sigstore/cosign/pkg/types"
+	rekor "github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
 	"oras.land/oras-go/pkg/content"
 	ctxo "oras.land/oras-go/pkg/context"
 	"oras.land/oras-go/pkg/oras"
 )
 
+const (
+	// rekorURL represents the url of the public Rekor transparency log
+	// instance, used when verifying keyless signatures.
+	rekorURL = "https://rekor.sigstore.dev"
+)
+
 var (
 	// ErrArtifactNotFound indicates that the requested artifact was not found
 	// in the registry.
@@ -28,6 +45,10 @@ var (
 	// ErrLayerNotFound indicates that the requested layer was not found in the
 	// OCI artifact provided.
 	ErrLayerNotFound = errors.New("layer not found")
+
+	// oidcIssuerOID represents the object identifier of the signing
+	// certificates extension that contains the OIDC issuer.
+	oidcIssuerOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
 )
 
 // Puller is a hub.OCIPuller implementation.
@@ -116,6 +137,107 @@ func (c *SignatureChecker) HasCosignSignature(
 	return true, nil
 }
 
+// VerifyCosignSignature checks if the OCI artifact identified by the reference
+// provided has a cosign (sigstore) signature that can be verified using any of
+// the public keys or keyless identities in the configuration provided.
+func (c *SignatureChecker) VerifyCosignSignature(
+	ctx context.Context,
+	ref,
+	username,
+	password string,
+	cfg *hub.CosignConfig,
+) (bool, error) {
+	if cfg == nil || (len(cfg.Keys) == 0 && len(cfg.Identities) == 0) {
+		return false, nil
+	}
+
+	// Prepare verification options
+	artifactRef, err := name.ParseReference(ref)
+	if err != nil {
+		return false, err
+	}
+	co := &cosign.CheckOpts{
+		ClaimVerifier: cosign.SimpleClaimVerifier,
+	}
+	if username != "" || password != "" {
+		co.RegistryClientOpts = []csremote.Option{
+			csremote.WithRemoteOptions(remote.WithAuth(&authn.Basic{
+				Username: username,
+				Password: password,
+			})),
+		}
+	}
+
+	// Verify signature using the public keys provided
+	for _, key := range cfg.Keys {
+		pk, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(key))
+		if err != nil {
+			return false, fmt.Errorf("invalid cosign public key: %w", err)
+		}
+		co.SigVerifier, err = signature.LoadVerifier(pk, crypto.SHA256)
+		if err != nil {
+			return false, fmt.Errorf("error loading cosign public key: %w", err)
+		}
+		if _, _, err := cosign.VerifyImageSignatures(ctx, artifactRef, co); err == nil {
+			return true, nil
+		}
+	}
+
+	// Verify signature using the keyless identities provided
+	if len(cfg.Identities) > 0 {
+		rekorClient, err := rekor.GetRekorClient(rekorURL)
+		if err != nil {
+			return false, fmt.Errorf("error setting up rekor client: %w", err)
+		}
+		co.SigVerifier = nil
+		co.RekorClient = rekorClient
+		co.RootCerts = fulcio.GetRoots()
+		signatures, _, err := cosign.VerifyImageSignatures(ctx, artifactRef, co)
+		if err != nil {
+			return false, nil
+		}
+		for _, sig := range signatures {
+			cert, err := sig.Cert()
+			if err != nil || cert == nil {
+				continue
+			}
+			for _, identity := range cfg.Identities {
+				if certMatchesIdentity(cert, identity) {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// certMatchesIdentity checks if the signing certificate provided was issued
+// to the keyless identity given.
+func certMatchesIdentity(cert *x509.Certificate, identity *hub.CosignIdentity) bool {
+	var issuer string
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidcIssuerOID) {
+			issuer = string(ext.Value)
+			break
+		}
+	}
+	if issuer != identity.Issuer {
+		return false
+	}
+	for _, email := range cert.EmailAddresses {
+		if email == identity.Subject {
+			return true
+		}
+	}
+	for _, uri := range cert.URIs {
+		if uri.String() == identity.Subject {
+			return true
+		}
+	}
+	return false
+}
+
 // OCITagsGetter provides a mechanism to get all the version tags available for
 // a given repository in a OCI registry. Tags that aren't valid semver versions
 // will be filtered out.
@@ -157,3 +279,38 @@ func (tg *TagsGetter) Tags(ctx context.Context, r *hub.Repository, onlySemver bo
 	}
 	return tags, nil
 }
+
+// AnnotationsGetter is a hub.OCIAnnotationsGetter implementation.
+type AnnotationsGetter struct{}
+
+// GetAnnotations returns the annotations available in the manifest of the OCI
+// artifact identified by the reference provided.
+func (ag *AnnotationsGetter) GetAnnotations(
+	ctx context.Context,
+	ref,
+	username,
+	password string,
+) (map[string]string, error) {
+	artifactRef, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	options := []remote.Option{
+		remote.WithContext(ctx),
+	}
+	if username != "" || password != "" {
+		options = append(options, remote.WithAuth(&authn.Basic{
+			Username: username,
+			Password: password,
+		}))
+	}
+	desc, err := remote.Get(artifactRef, options...)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(desc.Manifest))
+	if err != nil {
+		return nil, err
+	}
+	return manifest.Annotations, nil
+}
@@ -0,0 +1,148 @@
+package oci
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	csremote "github.com/sigstore/cosign/pkg/oci/remote"
+)
+
+const (
+	// dsseEnvelopeMediaType represents the media type of the layers that
+	// contain attestations in DSSE envelopes.
+	dsseEnvelopeMediaType = "application/vnd.dsse.envelope.v1+json"
+
+	// slsaProvenancePredicateTypePrefix represents the prefix of the
+	// predicate type used by SLSA provenance attestations.
+	slsaProvenancePredicateTypePrefix = "https://slsa.dev/provenance/"
+)
+
+// ProvenanceGetter is a hub.OCIProvenanceGetter implementation.
+type ProvenanceGetter struct{}
+
+// GetSLSAProvenance returns the build provenance available in the SLSA
+// provenance attestation attached to the OCI artifact identified by the
+// reference provided. When no provenance attestation is found, a nil value
+// will be returned.
+func (pg *ProvenanceGetter) GetSLSAProvenance(
+	ctx context.Context,
+	ref,
+	username,
+	password string,
+) (*hub.Provenance, error) {
+	// Locate OCI artifact containing attestations
+	artifactRef, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	attestationRef, err := csremote.AttestationTag(artifactRef)
+	if err != nil {
+		return nil, err
+	}
+	options := []remote.Option{
+		remote.WithContext(ctx),
+	}
+	if username != "" || password != "" {
+		options = append(options, remote.WithAuth(&authn.Basic{
+			Username: username,
+			Password: password,
+		}))
+	}
+	img, err := remote.Image(attestationRef, options...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Look for a SLSA provenance attestation in the artifact layers
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, err
+	}
+	for _, layer := range layers {
+		mediaType, err := layer.MediaType()
+		if err != nil || string(mediaType) != dsseEnvelopeMediaType {
+			continue
+		}
+		rc, err := layer.Uncompressed()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		provenance, err := parseSLSAProvenance(data)
+		if err != nil {
+			return nil, err
+		}
+		if provenance != nil {
+			return provenance, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseSLSAProvenance extracts the build provenance from the DSSE envelope
+// provided. A nil value is returned if the envelope does not contain a SLSA
+// provenance attestation.
+func parseSLSAProvenance(data []byte) (*hub.Provenance, error) {
+	// Decode envelope and in-toto statement
+	var envelope struct {
+		Payload string `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid attestation envelope: %w", err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation payload: %w", err)
+	}
+	var statement struct {
+		PredicateType string `json:"predicateType"`
+		Predicate     struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+			BuildType  string `json:"buildType"`
+			Invocation struct {
+				ConfigSource struct {
+					URI        string            `json:"uri"`
+					Digest     map[string]string `json:"digest"`
+					EntryPoint string            `json:"entryPoint"`
+				} `json:"configSource"`
+			} `json:"invocation"`
+		} `json:"predicate"`
+	}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, fmt.Errorf("invalid attestation statement: %w", err)
+	}
+	if !strings.HasPrefix(statement.PredicateType, slsaProvenancePredicateTypePrefix) {
+		return nil, nil
+	}
+
+	// Prepare provenance from predicate
+	predicate := statement.Predicate
+	return &hub.Provenance{
+		BuilderID:  predicate.Builder.ID,
+		BuildType:  predicate.BuildType,
+		SourceRepo: strings.TrimPrefix(predicate.Invocation.ConfigSource.URI, "git+"),
+		Commit:     predicate.Invocation.ConfigSource.Digest["sha1"],
+		EntryPoint: predicate.Invocation.ConfigSource.EntryPoint,
+	}, nil
+}
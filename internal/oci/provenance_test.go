@@ -0,0 +1,75 @@
+package oci
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSLSAProvenance(t *testing.T) {
+	t.Parallel()
+
+	buildEnvelope := func(statement string) []byte {
+		data, _ := json.Marshal(map[string]string{
+			"payloadType": "application/vnd.in-toto+json",
+			"payload":     base64.StdEncoding.EncodeToString([]byte(statement)),
+		})
+		return data
+	}
+
+	t.Run("invalid envelope", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseSLSAProvenance([]byte("{"))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		t.Parallel()
+		_, err := parseSLSAProvenance([]byte(`{"payload": "%%%"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("attestation is not a slsa provenance", func(t *testing.T) {
+		t.Parallel()
+		provenance, err := parseSLSAProvenance(buildEnvelope(`{
+			"predicateType": "cosign.sigstore.dev/attestation/vuln/v1"
+		}`))
+		require.NoError(t, err)
+		assert.Nil(t, provenance)
+	})
+
+	t.Run("slsa provenance parsed successfully", func(t *testing.T) {
+		t.Parallel()
+		provenance, err := parseSLSAProvenance(buildEnvelope(`{
+			"_type": "https://in-toto.io/Statement/v0.1",
+			"predicateType": "https://slsa.dev/provenance/v0.2",
+			"predicate": {
+				"builder": {
+					"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.2.0"
+				},
+				"buildType": "https://github.com/slsa-framework/slsa-github-generator/container@v1",
+				"invocation": {
+					"configSource": {
+						"uri": "git+https://github.com/org/repo@refs/heads/main",
+						"digest": {
+							"sha1": "0123456789abcdef0123456789abcdef01234567"
+						},
+						"entryPoint": ".github/workflows/release.yml"
+					}
+				}
+			}
+		}`))
+		require.NoError(t, err)
+		assert.Equal(t, &hub.Provenance{
+			BuilderID:  "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.2.0",
+			BuildType:  "https://github.com/slsa-framework/slsa-github-generator/container@v1",
+			SourceRepo: "https://github.com/org/repo@refs/heads/main",
+			Commit:     "0123456789abcdef0123456789abcdef01234567",
+			EntryPoint: ".github/workflows/release.yml",
+		}, provenance)
+	})
+}
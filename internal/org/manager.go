@@ -3,11 +3,16 @@ package org
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"net"
 	"regexp"
 	"strconv"
+	"strings"
 
 	_ "embed" // Used by templates
 
@@ -15,6 +20,7 @@ import (
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/satori/uuid"
 	"github.com/spf13/viper"
@@ -22,22 +28,43 @@ import (
 
 const (
 	// Database queries
-	addOrgDBQ            = `select add_organization($1::uuid, $2::jsonb)`
-	addOrgMemberDBQ      = `select add_organization_member($1::uuid, $2::text, $3::text)`
-	checkOrgNameAvailDBQ = `select organization_id from organization where name = $1`
-	confirmMembershipDBQ = `select confirm_organization_membership($1::uuid, $2::text)`
-	deleteOrgDBQ         = `select delete_organization($1::uuid, $2::text)`
-	deleteOrgMemberDBQ   = `select delete_organization_member($1::uuid, $2::text, $3::text)`
-	getAuthzPolicyDBQ    = `select get_authorization_policy($1::uuid, $2::text)`
-	getOrgDBQ            = `select get_organization($1::text)`
-	getOrgMembersDBQ     = `select * from get_organization_members($1::uuid, $2::text, $3::int, $4::int)`
-	getUserAliasDBQ      = `select alias from "user" where user_id = $1`
-	getUserEmailDBQ      = `select email from "user" where alias = $1`
-	getUserOrgsDBQ       = `select * from get_user_organizations($1::uuid, $2::int, $3::int)`
-	updateAuthzPolicyDBQ = `select update_authorization_policy($1::uuid, $2::text, $3::jsonb)`
-	updateOrgDBQ         = `select update_organization($1::uuid, $2::text, $3::jsonb)`
+	addOrgDBQ             = `select add_organization($1::uuid, $2::jsonb)`
+	addOrgDomainDBQ       = `select add_organization_domain($1::uuid, $2::text, $3::text, $4::text)`
+	addOrgMemberDBQ       = `select add_organization_member($1::uuid, $2::text, $3::text)`
+	approveJoinRequestDBQ = `select approve_organization_join_request($1::uuid, $2::text, $3::text)`
+	checkOrgNameAvailDBQ  = `select organization_id from organization where name = $1`
+	confirmMembershipDBQ  = `select confirm_organization_membership($1::uuid, $2::text)`
+	deleteJoinRequestDBQ  = `select delete_organization_join_request($1::uuid, $2::text, $3::text)`
+	deleteOrgDBQ          = `select delete_organization($1::uuid, $2::text)`
+	deleteOrgDomainDBQ    = `select delete_organization_domain($1::uuid, $2::text, $3::text)`
+	deleteOrgMemberDBQ    = `select delete_organization_member($1::uuid, $2::text, $3::text)`
+	getAuthzDecisionsDBQ  = `select * from get_authorization_decisions($1::uuid, $2::text, $3::boolean, $4::int, $5::int)`
+	getAuthzPolicyDBQ     = `select get_authorization_policy($1::uuid, $2::text)`
+	getDomainTokenDBQ     = `select verification_token from organization_domain d join organization o using (organization_id) where o.name = $1 and d.domain = $2`
+	getJoinRequestsDBQ    = `select get_organization_join_requests($1::uuid, $2::text)`
+	getOrgDBQ             = `select get_organization($1::text)`
+	getOrgDomainsDBQ      = `select get_organization_domains($1::uuid, $2::text)`
+	getOrgMembersDBQ      = `select * from get_organization_members($1::uuid, $2::text, $3::int, $4::int)`
+	getOrgPublicProfDBQ   = `select get_organization_public_profile($1::text)`
+	getOrgSecOverviewDBQ  = `select get_organization_security_overview($1::uuid, $2::text)`
+	getUserAliasDBQ       = `select alias from "user" where user_id = $1`
+	getUserEmailDBQ       = `select email, coalesce(locale, '') from "user" where alias = $1`
+	getUserOrgsDBQ        = `select * from get_user_organizations($1::uuid, $2::int, $3::int)`
+	joinOrgDBQ            = `select join_organization($1::uuid, $2::text)`
+	updateAuthzPolicyDBQ  = `select update_authorization_policy($1::uuid, $2::text, $3::jsonb)`
+	updateOrgDBQ          = `select update_organization($1::uuid, $2::text, $3::jsonb)`
+	updateOrgDomainDBQ    = `select update_organization_domain($1::uuid, $2::text, $3::text, $4::text)`
+	verifyOrgDomainDBQ    = `select verify_organization_domain($1::uuid, $2::text, $3::text)`
 )
 
+// maxPolicyTestInputs represents the maximum number of sample inputs that can
+// be provided when testing an authorization policy.
+const maxPolicyTestInputs = 50
+
+// domainVerificationRecordPrefix represents the prefix of the DNS TXT record
+// value used to verify the ownership of an organization domain.
+const domainVerificationRecordPrefix = "artifacthub-domain-verification="
+
 type templateID int
 
 const (
@@ -47,8 +74,21 @@ const (
 //go:embed template/invitation_email.tmpl
 var invitationEmailTmpl string
 
-// organizationNameRE is a regexp used to validate an organization name.
-var organizationNameRE = regexp.MustCompile(`^[a-z0-9-]+$`)
+var (
+	// domainRE is a regexp used to validate an organization domain.
+	domainRE = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+	// organizationNameRE is a regexp used to validate an organization name.
+	organizationNameRE = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+	// errDomainAlreadyVerifiedDB represents the error returned from the
+	// database when the domain has been verified by another organization.
+	errDomainAlreadyVerifiedDB = errors.New("ERROR: domain already verified by another organization (SQLSTATE P0001)")
+
+	// errAlreadyMemberDB represents the error returned from the database when
+	// the user joining the organization is already a member of it.
+	errAlreadyMemberDB = errors.New("ERROR: user is already a member of the organization (SQLSTATE P0001)")
+)
 
 // Manager provides an API to manage organizations.
 type Manager struct {
@@ -56,20 +96,40 @@ type Manager struct {
 	db   hub.DB
 	es   hub.EmailSender
 	az   hub.Authorizer
+	tr   hub.TXTResolver
 	tmpl map[templateID]*template.Template
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(cfg *viper.Viper, db hub.DB, es hub.EmailSender, az hub.Authorizer) *Manager {
-	return &Manager{
+func NewManager(
+	cfg *viper.Viper,
+	db hub.DB,
+	es hub.EmailSender,
+	az hub.Authorizer,
+	opts ...func(m *Manager),
+) *Manager {
+	m := &Manager{
 		cfg: cfg,
 		db:  db,
 		es:  es,
 		az:  az,
+		tr:  net.DefaultResolver,
 		tmpl: map[templateID]*template.Template{
-			invitationEmail: template.Must(template.New("").Parse(email.BaseTmpl + invitationEmailTmpl)),
+			invitationEmail: email.ParseTemplate(invitationEmailTmpl),
 		},
 	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// WithTXTResolver allows providing a specific TXTResolver implementation for a
+// Manager instance.
+func WithTXTResolver(tr hub.TXTResolver) func(m *Manager) {
+	return func(m *Manager) {
+		m.tr = tr
+	}
 }
 
 // Add adds the provided organization to the database.
@@ -87,6 +147,42 @@ func (m *Manager) Add(ctx context.Context, org *hub.Organization) error {
 	return err
 }
 
+// AddDomain adds a domain to the provided organization. The domain must be
+// verified before users whose email belongs to it can join the organization.
+func (m *Manager) AddDomain(ctx context.Context, orgName, domain string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	domain, err := validateDomain(domain)
+	if err != nil {
+		return err
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganization,
+	}); err != nil {
+		return err
+	}
+
+	// Add organization domain to database
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return err
+	}
+	token := hex.EncodeToString(randomBytes)
+	_, err = m.db.Exec(ctx, addOrgDomainDBQ, userID, orgName, domain, token)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
 // AddMember adds a new member to the provided organization. The new member
 // must be a registered user. The user will receive an email to confirm her
 // willingness to join the organization. The user doing the request must be a
@@ -121,8 +217,8 @@ func (m *Manager) AddMember(ctx context.Context, orgName, userAlias string) erro
 
 	// Send organization invitation email
 	if m.es != nil {
-		var userEmail string
-		if err := m.db.QueryRow(ctx, getUserEmailDBQ, userAlias).Scan(&userEmail); err != nil {
+		var userEmail, locale string
+		if err := m.db.QueryRow(ctx, getUserEmailDBQ, userAlias).Scan(&userEmail, &locale); err != nil {
 			return err
 		}
 		baseURL := m.cfg.GetString("server.baseURL")
@@ -137,12 +233,12 @@ func (m *Manager) AddMember(ctx context.Context, orgName, userAlias string) erro
 			},
 		}
 		var emailBody bytes.Buffer
-		if err := m.tmpl[invitationEmail].Execute(&emailBody, templateData); err != nil {
+		if err := email.ExecuteTemplate(&emailBody, m.tmpl[invitationEmail], locale, templateData); err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
-			Subject: fmt.Sprintf("Invitation to join %s on Artifact Hub", orgName),
+			Subject: email.Translate(locale, "invitation.subject", orgName, m.cfg.GetString("theme.siteName")),
 			Body:    emailBody.Bytes(),
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
@@ -153,6 +249,36 @@ func (m *Manager) AddMember(ctx context.Context, orgName, userAlias string) erro
 	return nil
 }
 
+// ApproveJoinRequest approves the request to join the provided organization of
+// the user given, who becomes a member of it.
+func (m *Manager) ApproveJoinRequest(ctx context.Context, orgName, userAlias string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.AddOrganizationMember,
+	}); err != nil {
+		return err
+	}
+
+	// Approve join request in database
+	_, err := m.db.Exec(ctx, approveJoinRequestDBQ, userID, orgName, userAlias)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
 // CheckAvailability checks the availability of a given value for the provided
 // resource kind.
 func (m *Manager) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
@@ -229,6 +355,65 @@ func (m *Manager) Delete(ctx context.Context, orgName string) error {
 	return err
 }
 
+// DeleteDomain deletes a domain from the provided organization.
+func (m *Manager) DeleteDomain(ctx context.Context, orgName, domain string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if domain == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "domain not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganization,
+	}); err != nil {
+		return err
+	}
+
+	// Delete organization domain from database
+	_, err := m.db.Exec(ctx, deleteOrgDomainDBQ, userID, orgName, domain)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// DeleteJoinRequest deletes the request to join the provided organization of
+// the user given, rejecting it.
+func (m *Manager) DeleteJoinRequest(ctx context.Context, orgName, userAlias string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.AddOrganizationMember,
+	}); err != nil {
+		return err
+	}
+
+	// Delete join request from database
+	_, err := m.db.Exec(ctx, deleteJoinRequestDBQ, userID, orgName, userAlias)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
 // DeleteMember removes a member from the provided organization. The user doing
 // the request must be a member of the organization.
 func (m *Manager) DeleteMember(ctx context.Context, orgName, userAlias string) error {
@@ -265,6 +450,44 @@ func (m *Manager) DeleteMember(ctx context.Context, orgName, userAlias string) e
 	return err
 }
 
+// GetAuthorizationDecisionsJSON returns the most recent authorization
+// decisions made using the organization's custom authorization policy as a
+// json object. When deniedOnly is set, only the denied actions are returned.
+func (m *Manager) GetAuthorizationDecisionsJSON(
+	ctx context.Context,
+	orgName string,
+	deniedOnly bool,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.GetAuthorizationPolicy,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Get authorization decisions from database
+	return util.DBQueryJSONWithPagination(
+		ctx,
+		m.db,
+		getAuthzDecisionsDBQ,
+		userID,
+		orgName,
+		deniedOnly,
+		p.Limit,
+		p.Offset,
+	)
+}
+
 // GetAuthorizationPolicyJSON returns the organization's authorization policy
 // as a json object.
 func (m *Manager) GetAuthorizationPolicyJSON(ctx context.Context, orgName string) ([]byte, error) {
@@ -295,6 +518,34 @@ func (m *Manager) GetByUserJSON(ctx context.Context, p *hub.Pagination) (*hub.JS
 	return util.DBQueryJSONWithPagination(ctx, m.db, getUserOrgsDBQ, userID, p.Limit, p.Offset)
 }
 
+// GetDomainsJSON returns the domains of the provided organization as a json
+// array.
+func (m *Manager) GetDomainsJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization domains from database
+	return util.DBQueryJSON(ctx, m.db, getOrgDomainsDBQ, userID, orgName)
+}
+
+// GetJoinRequestsJSON returns the pending join requests of the provided
+// organization as a json array.
+func (m *Manager) GetJoinRequestsJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization join requests from database
+	return util.DBQueryJSON(ctx, m.db, getJoinRequestsDBQ, userID, orgName)
+}
+
 // GetJSON returns the organization requested as a json object.
 func (m *Manager) GetJSON(ctx context.Context, orgName string) ([]byte, error) {
 	// Validate input
@@ -324,6 +575,118 @@ func (m *Manager) GetMembersJSON(
 	return util.DBQueryJSONWithPagination(ctx, m.db, getOrgMembersDBQ, userID, orgName, p.Limit, p.Offset)
 }
 
+// GetPublicProfileJSON returns the public profile of the provided organization
+// as a json object. Organizations that haven't enabled their public profile
+// are not found.
+func (m *Manager) GetPublicProfileJSON(ctx context.Context, orgName string) ([]byte, error) {
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization public profile from database
+	return util.DBQueryJSON(ctx, m.db, getOrgPublicProfDBQ, orgName)
+}
+
+// GetSecurityOverviewJSON returns an overview of the security reports of the
+// packages in the repositories owned by the provided organization as a json
+// object.
+func (m *Manager) GetSecurityOverviewJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization security overview from database
+	return util.DBQueryJSON(ctx, m.db, getOrgSecOverviewDBQ, userID, orgName)
+}
+
+// Join allows the user doing the request to join the provided organization when
+// the user's verified email belongs to one of the organization's verified
+// domains that allow joining. Depending on the domain's join mode, the user
+// joins the organization directly or a join request is registered, which will
+// need to be approved by one of its members. The join mode applied is
+// returned.
+func (m *Manager) Join(ctx context.Context, orgName string) (hub.OrganizationJoinMode, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Join organization in database
+	var joinMode string
+	err := m.db.QueryRow(ctx, joinOrgDBQ, userID, orgName).Scan(&joinMode)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return "", hub.ErrInsufficientPrivilege
+		case errAlreadyMemberDB.Error():
+			return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "already a member of the organization")
+		}
+		return "", err
+	}
+	return hub.OrganizationJoinMode(joinMode), nil
+}
+
+// TestAuthorizationPolicy evaluates the authorization policy provided against
+// some sample inputs, allowing organization admins to check how it behaves
+// before saving it.
+func (m *Manager) TestAuthorizationPolicy(
+	ctx context.Context,
+	orgName string,
+	test *hub.AuthorizationPolicyTest,
+) (*hub.AuthorizationPolicyTestOutput, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if test == nil || test.Policy == nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "authorization policy not provided")
+	}
+	p := test.Policy
+	if p.PredefinedPolicy != "" && p.CustomPolicy != "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "both predefined and custom policies were provided")
+	}
+	if p.PredefinedPolicy == "" && p.CustomPolicy == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "a predefined or custom policy must be provided")
+	}
+	if p.PredefinedPolicy != "" && !authz.IsPredefinedPolicyValid(p.PredefinedPolicy) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid predefined policy")
+	}
+	if len(test.Inputs) == 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no inputs provided")
+	}
+	if len(test.Inputs) > maxPolicyTestInputs {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "too many inputs provided")
+	}
+	for _, input := range test.Inputs {
+		if input == nil || input.User == "" {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "input user not provided")
+		}
+		if input.Action == "" {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "input action not provided")
+		}
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateAuthorizationPolicy,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Test authorization policy
+	return m.az.TestPolicy(ctx, test)
+}
+
 // Update updates the provided organization in the database.
 func (m *Manager) Update(ctx context.Context, orgName string, org *hub.Organization) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -418,6 +781,119 @@ func (m *Manager) UpdateAuthorizationPolicy(
 	return err
 }
 
+// UpdateDomain updates the join mode of the provided organization domain. Only
+// verified domains can allow users to join the organization.
+func (m *Manager) UpdateDomain(
+	ctx context.Context,
+	orgName string,
+	domain string,
+	joinMode hub.OrganizationJoinMode,
+) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if domain == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "domain not provided")
+	}
+	switch joinMode {
+	case hub.DisabledJoinMode, hub.AutoJoinMode, hub.RequestJoinMode:
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid join mode")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganization,
+	}); err != nil {
+		return err
+	}
+
+	// Update organization domain in database
+	_, err := m.db.Exec(ctx, updateOrgDomainDBQ, userID, orgName, domain, joinMode)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// VerifyDomain verifies the ownership of the provided organization domain. To
+// verify it, a DNS TXT record must be added to the domain containing the
+// verification token provided when the domain was added.
+func (m *Manager) VerifyDomain(ctx context.Context, orgName, domain string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if domain == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "domain not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganization,
+	}); err != nil {
+		return err
+	}
+
+	// Check the domain DNS TXT records include the verification token
+	var token string
+	if err := m.db.QueryRow(ctx, getDomainTokenDBQ, orgName, domain).Scan(&token); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return hub.ErrNotFound
+		}
+		return err
+	}
+	records, err := m.tr.LookupTXT(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "error looking up domain TXT records")
+	}
+	var found bool
+	for _, record := range records {
+		if strings.TrimSpace(record) == domainVerificationRecordPrefix+token {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "verification TXT record not found")
+	}
+
+	// Verify organization domain in database
+	_, err = m.db.Exec(ctx, verifyOrgDomainDBQ, userID, orgName, domain)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errDomainAlreadyVerifiedDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "domain already verified by another organization")
+		}
+		return err
+	}
+	return nil
+}
+
+// validateDomain checks if the domain provided is valid, returning it in
+// lowercase.
+func validateDomain(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "domain not provided")
+	}
+	if len(domain) > 253 || !domainRE.MatchString(domain) {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid domain")
+	}
+	return domain, nil
+}
+
 // validateOrg checks if the organization provided is valid.
 func validateOrg(org *hub.Organization) error {
 	if org.Name == "" {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/authz"
@@ -11,6 +12,7 @@ import (
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -95,6 +97,123 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestAddDomain(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.AddDomain(context.Background(), "org1", "example.com")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			domain  string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"example.com",
+			},
+			{
+				"domain not provided",
+				"org1",
+				" ",
+			},
+			{
+				"invalid domain",
+				"org1",
+				"example",
+			},
+			{
+				"invalid domain",
+				"org1",
+				"-example.com",
+			},
+			{
+				"invalid domain",
+				"org1",
+				"user@example.com",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.AddDomain(ctx, tc.orgName, tc.domain)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganization,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.AddDomain(ctx, "org1", "example.com")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addOrgDomainDBQ, "userID", "org1", "example.com", mock.Anything).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.AddDomain(ctx, "org1", " Example.COM ")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, addOrgDomainDBQ, "userID", "org1", "example.com", mock.Anything).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				err := m.AddDomain(ctx, "org1", "example.com")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestAddMember(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -170,7 +289,7 @@ func TestAddMember(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
 				db.On("Exec", ctx, addOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
-				db.On("QueryRow", ctx, getUserEmailDBQ, mock.Anything).Return("email", nil)
+				db.On("QueryRow", ctx, getUserEmailDBQ, mock.Anything).Return([]interface{}{"email", "es"}, nil)
 				es := &email.SenderMock{}
 				es.On("SendEmail", mock.Anything).Return(tc.emailSenderResponse)
 				az := &authz.AuthorizerMock{}
@@ -227,6 +346,108 @@ func TestAddMember(t *testing.T) {
 	})
 }
 
+func TestApproveJoinRequest(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.ApproveJoinRequest(context.Background(), "org1", "user1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			orgName   string
+			userAlias string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"user1",
+			},
+			{
+				"user alias not provided",
+				"org1",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.ApproveJoinRequest(ctx, tc.orgName, tc.userAlias)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationMember,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.ApproveJoinRequest(ctx, "org1", "user1")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, approveJoinRequestDBQ, "userID", "org1", "user1").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.ApproveJoinRequest(ctx, "org1", "user1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, approveJoinRequestDBQ, "userID", "org1", "user1").Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				err := m.ApproveJoinRequest(ctx, "org1", "user1")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestCheckAvailability(t *testing.T) {
 	ctx := context.Background()
 
@@ -413,30 +634,30 @@ func TestDelete(t *testing.T) {
 	})
 }
 
-func TestDeleteMember(t *testing.T) {
+func TestDeleteDomain(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 		assert.Panics(t, func() {
-			_ = m.DeleteMember(context.Background(), "orgName", "userAlias")
+			_ = m.DeleteDomain(context.Background(), "org1", "example.com")
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
-			errMsg    string
-			orgName   string
-			userAlias string
+			errMsg  string
+			orgName string
+			domain  string
 		}{
 			{
 				"organization name not provided",
 				"",
-				"user1",
+				"example.com",
 			},
 			{
-				"user alias not provided",
+				"domain not provided",
 				"org1",
 				"",
 			},
@@ -446,73 +667,43 @@ func TestDeleteMember(t *testing.T) {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
 				m := NewManager(cfg, nil, nil, nil)
-				err := m.DeleteMember(ctx, tc.orgName, tc.userAlias)
+				err := m.DeleteDomain(ctx, tc.orgName, tc.domain)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
 		}
 	})
 
-	t.Run("get requesting user alias failed", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("", tests.ErrFakeDB)
-		m := NewManager(cfg, db, nil, nil)
-
-		err := m.DeleteMember(ctx, "orgName", "userAlias")
-		assert.Error(t, err)
-		db.AssertExpectations(t)
-	})
-
 	t.Run("authorization failed", func(t *testing.T) {
 		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
 		az := &authz.AuthorizerMock{}
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "orgName",
+			OrganizationName: "org1",
 			UserID:           "userID",
-			Action:           hub.DeleteOrganizationMember,
+			Action:           hub.UpdateOrganization,
 		}).Return(tests.ErrFake)
-		m := NewManager(cfg, db, nil, az)
+		m := NewManager(cfg, nil, nil, az)
 
-		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		err := m.DeleteDomain(ctx, "org1", "example.com")
 		assert.Equal(t, tests.ErrFake, err)
 		az.AssertExpectations(t)
 	})
 
-	t.Run("member deleted successfully", func(t *testing.T) {
+	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
-		db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
+		db.On("Exec", ctx, deleteOrgDomainDBQ, "userID", "org1", "example.com").Return(nil)
 		az := &authz.AuthorizerMock{}
-		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "orgName",
-			UserID:           "userID",
-			Action:           hub.DeleteOrganizationMember,
-		}).Return(nil)
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
 		m := NewManager(cfg, db, nil, az)
 
-		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		err := m.DeleteDomain(ctx, "org1", "example.com")
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 		az.AssertExpectations(t)
 	})
 
-	t.Run("user left organization successfully", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("userAlias", nil)
-		db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
-		m := NewManager(cfg, db, nil, nil)
-
-		err := m.DeleteMember(ctx, "orgName", "userAlias")
-		assert.NoError(t, err)
-		db.AssertExpectations(t)
-	})
-
-	t.Run("error deleting member", func(t *testing.T) {
+	t.Run("database error", func(t *testing.T) {
 		testCases := []struct {
 			dbErr         error
 			expectedError error
@@ -531,17 +722,12 @@ func TestDeleteMember(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
-				db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(tc.dbErr)
+				db.On("Exec", ctx, deleteOrgDomainDBQ, "userID", "org1", "example.com").Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
-				az.On("Authorize", ctx, &hub.AuthorizeInput{
-					OrganizationName: "orgName",
-					UserID:           "userID",
-					Action:           hub.DeleteOrganizationMember,
-				}).Return(nil)
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
 				m := NewManager(cfg, db, nil, az)
 
-				err := m.DeleteMember(ctx, "orgName", "userAlias")
+				err := m.DeleteDomain(ctx, "org1", "example.com")
 				assert.Equal(t, tc.expectedError, err)
 				db.AssertExpectations(t)
 				az.AssertExpectations(t)
@@ -550,57 +736,602 @@ func TestDeleteMember(t *testing.T) {
 	})
 }
 
-func TestGetAuthorizationPolicyJSON(t *testing.T) {
+func TestDeleteJoinRequest(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 		assert.Panics(t, func() {
-			_, _ = m.GetAuthorizationPolicyJSON(context.Background(), "org1")
+			_ = m.DeleteJoinRequest(context.Background(), "org1", "user1")
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		_, err := m.GetAuthorizationPolicyJSON(ctx, "")
+		testCases := []struct {
+			errMsg    string
+			orgName   string
+			userAlias string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"user1",
+			},
+			{
+				"user alias not provided",
+				"org1",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.DeleteJoinRequest(ctx, tc.orgName, tc.userAlias)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationMember,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.DeleteJoinRequest(ctx, "org1", "user1")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteJoinRequestDBQ, "userID", "org1", "user1").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.DeleteJoinRequest(ctx, "org1", "user1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteJoinRequestDBQ, "userID", "org1", "user1").Return(tests.ErrFakeDB)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.DeleteJoinRequest(ctx, "org1", "user1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestDeleteMember(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.DeleteMember(context.Background(), "orgName", "userAlias")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			orgName   string
+			userAlias string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"user1",
+			},
+			{
+				"user alias not provided",
+				"org1",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.DeleteMember(ctx, tc.orgName, tc.userAlias)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("get requesting user alias failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("", tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.Error(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationMember,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("member deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
+		db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationMember,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("user left organization successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("userAlias", nil)
+		db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.DeleteMember(ctx, "orgName", "userAlias")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("error deleting member", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getUserAliasDBQ, "userID").Return("requestingUserAlias", nil)
+				db.On("Exec", ctx, deleteOrgMemberDBQ, "userID", "orgName", "userAlias").Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "orgName",
+					UserID:           "userID",
+					Action:           hub.DeleteOrganizationMember,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				err := m.DeleteMember(ctx, "orgName", "userAlias")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetAuthorizationDecisionsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetAuthorizationDecisionsJSON(context.Background(), "org1", false, p)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetAuthorizationDecisionsJSON(ctx, "", false, p)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuthorizationPolicy,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		result, err := m.GetAuthorizationDecisionsJSON(ctx, "org1", false, p)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, result)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAuthzDecisionsDBQ, "userID", "org1", true, 10, 1).
+			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuthorizationPolicy,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		result, err := m.GetAuthorizationDecisionsJSON(ctx, "org1", true, p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getAuthzDecisionsDBQ, "userID", "org1", false, 10, 1).Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.GetAuthorizationPolicy,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				result, err := m.GetAuthorizationDecisionsJSON(ctx, "org1", false, p)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, result)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetAuthorizationPolicyJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetAuthorizationPolicyJSON(context.Background(), "org1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetAuthorizationPolicyJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuthorizationPolicy,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, dataJSON)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAuthzPolicyDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuthorizationPolicy,
+		}).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getAuthzPolicyDBQ, "userID", "org1").Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.GetAuthorizationPolicy,
+				}).Return(nil)
+				m := NewManager(cfg, db, nil, az)
+
+				dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetByUserJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByUserJSON(context.Background(), p)
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserOrgsDBQ, "userID", 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		result, err := m.GetByUserJSON(ctx, p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserOrgsDBQ, "userID", 10, 1).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetByUserJSON(ctx, p)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetDomainsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetDomainsJSON(context.Background(), "org1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetDomainsJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgDomainsDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetDomainsJSON(ctx, "org1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgDomainsDBQ, "userID", "org1").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetDomainsJSON(ctx, "org1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetJoinRequestsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetJoinRequestsJSON(context.Background(), "org1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetJoinRequestsJSON(ctx, "")
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
 
-	t.Run("authorization failed", func(t *testing.T) {
+	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
-		az := &authz.AuthorizerMock{}
-		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "org1",
-			UserID:           "userID",
-			Action:           hub.GetAuthorizationPolicy,
-		}).Return(tests.ErrFake)
-		m := NewManager(cfg, nil, nil, az)
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getJoinRequestsDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
 
-		dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
-		assert.Equal(t, tests.ErrFake, err)
+		dataJSON, err := m.GetJoinRequestsJSON(ctx, "org1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getJoinRequestsDBQ, "userID", "org1").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetJoinRequestsJSON(ctx, "org1")
+		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
-		az.AssertExpectations(t)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetJSON(context.Background(), "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getAuthzPolicyDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
-		az := &authz.AuthorizerMock{}
-		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "org1",
-			UserID:           "userID",
-			Action:           hub.GetAuthorizationPolicy,
-		}).Return(nil)
-		m := NewManager(cfg, db, nil, az)
+		db.On("QueryRow", ctx, getOrgDBQ, "orgName").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
 
-		dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+		dataJSON, err := m.GetJSON(ctx, "orgName")
 		assert.NoError(t, err)
 		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
-		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgDBQ, "orgName").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetJSON(ctx, "orgName")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetMembersJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	p := &hub.Pagination{Limit: 10, Offset: 1}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetMembersJSON(context.Background(), "orgName", p)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetMembersJSON(ctx, "", p)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgMembersDBQ, "userID", "orgName", 10, 1).
+			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		result, err := m.GetMembersJSON(ctx, "orgName", p)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
 	})
 
 	t.Run("database error", func(t *testing.T) {
@@ -622,128 +1353,141 @@ func TestGetAuthorizationPolicyJSON(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("QueryRow", ctx, getAuthzPolicyDBQ, "userID", "org1").Return(nil, tc.dbErr)
-				az := &authz.AuthorizerMock{}
-				az.On("Authorize", ctx, &hub.AuthorizeInput{
-					OrganizationName: "org1",
-					UserID:           "userID",
-					Action:           hub.GetAuthorizationPolicy,
-				}).Return(nil)
-				m := NewManager(cfg, db, nil, az)
+				db.On("QueryRow", ctx, getOrgMembersDBQ, "userID", "orgName", 10, 1).Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
 
-				dataJSON, err := m.GetAuthorizationPolicyJSON(ctx, "org1")
+				result, err := m.GetMembersJSON(ctx, "orgName", p)
 				assert.Equal(t, tc.expectedError, err)
-				assert.Nil(t, dataJSON)
+				assert.Nil(t, result)
 				db.AssertExpectations(t)
-				az.AssertExpectations(t)
 			})
 		}
 	})
 }
 
-func TestGetByUserJSON(t *testing.T) {
-	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
-	p := &hub.Pagination{Limit: 10, Offset: 1}
+func TestGetPublicProfileJSON(t *testing.T) {
+	ctx := context.Background()
 
-	t.Run("user id not found in ctx", func(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
-		assert.Panics(t, func() {
-			_, _ = m.GetByUserJSON(context.Background(), p)
-		})
+		_, err := m.GetPublicProfileJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserOrgsDBQ, "userID", 10, 1).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		db.On("QueryRow", ctx, getOrgPublicProfDBQ, "orgName").Return([]byte("dataJSON"), nil)
 		m := NewManager(cfg, db, nil, nil)
 
-		result, err := m.GetByUserJSON(ctx, p)
+		dataJSON, err := m.GetPublicProfileJSON(ctx, "orgName")
 		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), result.Data)
-		assert.Equal(t, 1, result.TotalCount)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
 	})
 
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserOrgsDBQ, "userID", 10, 1).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getOrgPublicProfDBQ, "orgName").Return(nil, tests.ErrFakeDB)
 		m := NewManager(cfg, db, nil, nil)
 
-		dataJSON, err := m.GetByUserJSON(ctx, p)
+		dataJSON, err := m.GetPublicProfileJSON(ctx, "orgName")
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
 	})
 }
 
-func TestGetJSON(t *testing.T) {
-	ctx := context.Background()
+func TestGetSecurityOverviewJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetSecurityOverviewJSON(context.Background(), "orgName")
+		})
+	})
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
-		_, err := m.GetJSON(context.Background(), "")
+		_, err := m.GetSecurityOverviewJSON(ctx, "")
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getOrgDBQ, "orgName").Return([]byte("dataJSON"), nil)
+		db.On("QueryRow", ctx, getOrgSecOverviewDBQ, "userID", "orgName").Return([]byte("dataJSON"), nil)
 		m := NewManager(cfg, db, nil, nil)
 
-		dataJSON, err := m.GetJSON(ctx, "orgName")
+		dataJSON, err := m.GetSecurityOverviewJSON(ctx, "orgName")
 		assert.NoError(t, err)
 		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
 	})
 
 	t.Run("database error", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getOrgDBQ, "orgName").Return(nil, tests.ErrFakeDB)
-		m := NewManager(cfg, db, nil, nil)
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgSecOverviewDBQ, "userID", "orgName").Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
 
-		dataJSON, err := m.GetJSON(ctx, "orgName")
-		assert.Equal(t, tests.ErrFakeDB, err)
-		assert.Nil(t, dataJSON)
-		db.AssertExpectations(t)
+				dataJSON, err := m.GetSecurityOverviewJSON(ctx, "orgName")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
 	})
 }
 
-func TestGetMembersJSON(t *testing.T) {
+func TestJoin(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
-	p := &hub.Pagination{Limit: 10, Offset: 1}
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 		assert.Panics(t, func() {
-			_, _ = m.GetMembersJSON(context.Background(), "orgName", p)
+			_, _ = m.Join(context.Background(), "org1")
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
-		_, err := m.GetMembersJSON(ctx, "", p)
+		_, err := m.Join(ctx, "")
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getOrgMembersDBQ, "userID", "orgName", 10, 1).
-			Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		db.On("QueryRow", ctx, joinOrgDBQ, "userID", "org1").Return("request", nil)
 		m := NewManager(cfg, db, nil, nil)
 
-		result, err := m.GetMembersJSON(ctx, "orgName", p)
+		joinMode, err := m.Join(ctx, "org1")
 		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), result.Data)
-		assert.Equal(t, 1, result.TotalCount)
+		assert.Equal(t, hub.RequestJoinMode, joinMode)
 		db.AssertExpectations(t)
 	})
 
@@ -753,29 +1497,197 @@ func TestGetMembersJSON(t *testing.T) {
 			expectedError error
 		}{
 			{
-				tests.ErrFakeDB,
-				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errAlreadyMemberDB,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, joinOrgDBQ, "userID", "org1").Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				joinMode, err := m.Join(ctx, "org1")
+				assert.True(t, errors.Is(err, tc.expectedError))
+				assert.Empty(t, joinMode)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestTestAuthorizationPolicy(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	validTest := &hub.AuthorizationPolicyTest{
+		Policy: &hub.AuthorizationPolicy{
+			PredefinedPolicy: "rbac.v1",
+			PolicyData:       []byte(`"{\"k\": \"v\"}"`),
+		},
+		Inputs: []*hub.AuthorizationPolicyTestInput{
+			{User: "user1", Action: hub.UpdateOrganization},
+		},
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.TestAuthorizationPolicy(context.Background(), "org1", validTest)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		tooManyInputs := make([]*hub.AuthorizationPolicyTestInput, maxPolicyTestInputs+1)
+		for i := range tooManyInputs {
+			tooManyInputs[i] = &hub.AuthorizationPolicyTestInput{User: "user1", Action: hub.UpdateOrganization}
+		}
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			test    *hub.AuthorizationPolicyTest
+		}{
+			{
+				"organization name not provided",
+				"",
+				nil,
+			},
+			{
+				"authorization policy not provided",
+				"org1",
+				nil,
+			},
+			{
+				"authorization policy not provided",
+				"org1",
+				&hub.AuthorizationPolicyTest{},
+			},
+			{
+				"both predefined and custom policies were provided",
+				"org1",
+				&hub.AuthorizationPolicyTest{
+					Policy: &hub.AuthorizationPolicy{
+						PredefinedPolicy: "policy",
+						CustomPolicy:     "policy",
+					},
+				},
+			},
+			{
+				"a predefined or custom policy must be provided",
+				"org1",
+				&hub.AuthorizationPolicyTest{
+					Policy: &hub.AuthorizationPolicy{},
+				},
+			},
+			{
+				"invalid predefined policy",
+				"org1",
+				&hub.AuthorizationPolicyTest{
+					Policy: &hub.AuthorizationPolicy{
+						PredefinedPolicy: "invalid",
+					},
+				},
 			},
 			{
-				util.ErrDBInsufficientPrivilege,
-				hub.ErrInsufficientPrivilege,
+				"no inputs provided",
+				"org1",
+				&hub.AuthorizationPolicyTest{
+					Policy: validTest.Policy,
+				},
+			},
+			{
+				"too many inputs provided",
+				"org1",
+				&hub.AuthorizationPolicyTest{
+					Policy: validTest.Policy,
+					Inputs: tooManyInputs,
+				},
+			},
+			{
+				"input user not provided",
+				"org1",
+				&hub.AuthorizationPolicyTest{
+					Policy: validTest.Policy,
+					Inputs: []*hub.AuthorizationPolicyTestInput{
+						{Action: hub.UpdateOrganization},
+					},
+				},
+			},
+			{
+				"input action not provided",
+				"org1",
+				&hub.AuthorizationPolicyTest{
+					Policy: validTest.Policy,
+					Inputs: []*hub.AuthorizationPolicyTestInput{
+						{User: "user1"},
+					},
+				},
 			},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				db := &tests.DBMock{}
-				db.On("QueryRow", ctx, getOrgMembersDBQ, "userID", "orgName", 10, 1).Return(nil, tc.dbErr)
-				m := NewManager(cfg, db, nil, nil)
-
-				result, err := m.GetMembersJSON(ctx, "orgName", p)
-				assert.Equal(t, tc.expectedError, err)
-				assert.Nil(t, result)
-				db.AssertExpectations(t)
+				m := NewManager(cfg, nil, nil, nil)
+				_, err := m.TestAuthorizationPolicy(ctx, tc.orgName, tc.test)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
 			})
 		}
 	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateAuthorizationPolicy,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		output, err := m.TestAuthorizationPolicy(ctx, "org1", validTest)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, output)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("authorization policy tested successfully", func(t *testing.T) {
+		t.Parallel()
+		expectedOutput := &hub.AuthorizationPolicyTestOutput{
+			Errors: []string{},
+			Results: []*hub.AuthorizationPolicyTestResult{
+				{
+					User:           "user1",
+					Action:         hub.UpdateOrganization,
+					Allowed:        true,
+					AllowedActions: []hub.Action{"all"},
+				},
+			},
+		}
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateAuthorizationPolicy,
+		}).Return(nil)
+		az.On("TestPolicy", ctx, validTest).Return(expectedOutput, nil)
+		m := NewManager(cfg, nil, nil, az)
+
+		output, err := m.TestAuthorizationPolicy(ctx, "org1", validTest)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedOutput, output)
+		az.AssertExpectations(t)
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -1105,3 +2017,259 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateDomain(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.UpdateDomain(context.Background(), "org1", "example.com", hub.AutoJoinMode)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			orgName  string
+			domain   string
+			joinMode hub.OrganizationJoinMode
+		}{
+			{
+				"organization name not provided",
+				"",
+				"example.com",
+				hub.AutoJoinMode,
+			},
+			{
+				"domain not provided",
+				"org1",
+				"",
+				hub.AutoJoinMode,
+			},
+			{
+				"invalid join mode",
+				"org1",
+				"example.com",
+				hub.OrganizationJoinMode("invalid"),
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.UpdateDomain(ctx, tc.orgName, tc.domain, tc.joinMode)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganization,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.UpdateDomain(ctx, "org1", "example.com", hub.AutoJoinMode)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateOrgDomainDBQ, "userID", "org1", "example.com", hub.AutoJoinMode).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.UpdateDomain(ctx, "org1", "example.com", hub.AutoJoinMode)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateOrgDomainDBQ, "userID", "org1", "example.com", hub.AutoJoinMode).Return(tests.ErrFakeDB)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.UpdateDomain(ctx, "org1", "example.com", hub.AutoJoinMode)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestVerifyDomain(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.VerifyDomain(context.Background(), "org1", "example.com")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			domain  string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"example.com",
+			},
+			{
+				"domain not provided",
+				"org1",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.VerifyDomain(ctx, tc.orgName, tc.domain)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganization,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, nil, nil, az)
+
+		err := m.VerifyDomain(ctx, "org1", "example.com")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("domain not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getDomainTokenDBQ, "org1", "example.com").Return(nil, pgx.ErrNoRows)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil, az)
+
+		err := m.VerifyDomain(ctx, "org1", "example.com")
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("verification record not found", func(t *testing.T) {
+		testCases := []struct {
+			records []string
+			err     error
+		}{
+			{
+				nil,
+				errors.New("no such host"),
+			},
+			{
+				[]string{"v=spf1 -all", "artifacthub-domain-verification=other"},
+				nil,
+			},
+		}
+		for i, tc := range testCases {
+			tc := tc
+			t.Run(strconv.Itoa(i), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getDomainTokenDBQ, "org1", "example.com").Return("token", nil)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				tr := &TXTResolverMock{}
+				tr.On("LookupTXT", ctx, "example.com").Return(tc.records, tc.err)
+				m := NewManager(cfg, db, nil, az, WithTXTResolver(tr))
+
+				err := m.VerifyDomain(ctx, "org1", "example.com")
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+				tr.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("domain verified successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getDomainTokenDBQ, "org1", "example.com").Return("token", nil)
+		db.On("Exec", ctx, verifyOrgDomainDBQ, "userID", "org1", "example.com").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, mock.Anything).Return(nil)
+		tr := &TXTResolverMock{}
+		tr.On("LookupTXT", ctx, "example.com").Return([]string{"artifacthub-domain-verification=token"}, nil)
+		m := NewManager(cfg, db, nil, az, WithTXTResolver(tr))
+
+		err := m.VerifyDomain(ctx, "org1", "example.com")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+		tr.AssertExpectations(t)
+	})
+
+	t.Run("database error verifying domain", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errDomainAlreadyVerifiedDB,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getDomainTokenDBQ, "org1", "example.com").Return("token", nil)
+				db.On("Exec", ctx, verifyOrgDomainDBQ, "userID", "org1", "example.com").Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, mock.Anything).Return(nil)
+				tr := &TXTResolverMock{}
+				tr.On("LookupTXT", ctx, "example.com").Return([]string{"artifacthub-domain-verification=token"}, nil)
+				m := NewManager(cfg, db, nil, az, WithTXTResolver(tr))
+
+				err := m.VerifyDomain(ctx, "org1", "example.com")
+				assert.True(t, errors.Is(err, tc.expectedError))
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+				tr.AssertExpectations(t)
+			})
+		}
+	})
+}
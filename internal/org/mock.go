@@ -18,12 +18,24 @@ func (m *ManagerMock) Add(ctx context.Context, org *hub.Organization) error {
 	return args.Error(0)
 }
 
+// AddDomain implements the OrganizationManager interface.
+func (m *ManagerMock) AddDomain(ctx context.Context, orgName, domain string) error {
+	args := m.Called(ctx, orgName, domain)
+	return args.Error(0)
+}
+
 // AddMember implements the OrganizationManager interface.
 func (m *ManagerMock) AddMember(ctx context.Context, orgName, userAlias string) error {
 	args := m.Called(ctx, orgName, userAlias)
 	return args.Error(0)
 }
 
+// ApproveJoinRequest implements the OrganizationManager interface.
+func (m *ManagerMock) ApproveJoinRequest(ctx context.Context, orgName, userAlias string) error {
+	args := m.Called(ctx, orgName, userAlias)
+	return args.Error(0)
+}
+
 // CheckAvailability implements the OrganizationManager interface.
 func (m *ManagerMock) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
 	args := m.Called(ctx, resourceKind, value)
@@ -42,12 +54,38 @@ func (m *ManagerMock) Delete(ctx context.Context, orgName string) error {
 	return args.Error(0)
 }
 
+// DeleteDomain implements the OrganizationManager interface.
+func (m *ManagerMock) DeleteDomain(ctx context.Context, orgName, domain string) error {
+	args := m.Called(ctx, orgName, domain)
+	return args.Error(0)
+}
+
+// DeleteJoinRequest implements the OrganizationManager interface.
+func (m *ManagerMock) DeleteJoinRequest(ctx context.Context, orgName, userAlias string) error {
+	args := m.Called(ctx, orgName, userAlias)
+	return args.Error(0)
+}
+
 // DeleteMember implements the OrganizationManager interface.
 func (m *ManagerMock) DeleteMember(ctx context.Context, orgName, userAlias string) error {
 	args := m.Called(ctx, orgName, userAlias)
 	return args.Error(0)
 }
 
+// GetDomainsJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetDomainsJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetJoinRequestsJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetJoinRequestsJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetJSON implements the OrganizationManager interface.
 func (m *ManagerMock) GetJSON(ctx context.Context, orgName string) ([]byte, error) {
 	args := m.Called(ctx, orgName)
@@ -55,6 +93,18 @@ func (m *ManagerMock) GetJSON(ctx context.Context, orgName string) ([]byte, erro
 	return data, args.Error(1)
 }
 
+// GetAuthorizationDecisionsJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetAuthorizationDecisionsJSON(
+	ctx context.Context,
+	orgName string,
+	deniedOnly bool,
+	p *hub.Pagination,
+) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, orgName, deniedOnly, p)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
 // GetAuthorizationPolicyJSON implements the OrganizationManager interface.
 func (m *ManagerMock) GetAuthorizationPolicyJSON(ctx context.Context, orgName string) ([]byte, error) {
 	args := m.Called(ctx, orgName)
@@ -80,6 +130,38 @@ func (m *ManagerMock) GetMembersJSON(
 	return data, args.Error(1)
 }
 
+// GetPublicProfileJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetPublicProfileJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetSecurityOverviewJSON implements the OrganizationManager interface.
+func (m *ManagerMock) GetSecurityOverviewJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Join implements the OrganizationManager interface.
+func (m *ManagerMock) Join(ctx context.Context, orgName string) (hub.OrganizationJoinMode, error) {
+	args := m.Called(ctx, orgName)
+	joinMode, _ := args.Get(0).(hub.OrganizationJoinMode)
+	return joinMode, args.Error(1)
+}
+
+// TestAuthorizationPolicy implements the OrganizationManager interface.
+func (m *ManagerMock) TestAuthorizationPolicy(
+	ctx context.Context,
+	orgName string,
+	test *hub.AuthorizationPolicyTest,
+) (*hub.AuthorizationPolicyTestOutput, error) {
+	args := m.Called(ctx, orgName, test)
+	data, _ := args.Get(0).(*hub.AuthorizationPolicyTestOutput)
+	return data, args.Error(1)
+}
+
 // Update implements the OrganizationManager interface.
 func (m *ManagerMock) Update(ctx context.Context, orgName string, org *hub.Organization) error {
 	args := m.Called(ctx, orgName, org)
@@ -95,3 +177,32 @@ func (m *ManagerMock) UpdateAuthorizationPolicy(
 	args := m.Called(ctx, orgName, policy)
 	return args.Error(0)
 }
+
+// UpdateDomain implements the OrganizationManager interface.
+func (m *ManagerMock) UpdateDomain(
+	ctx context.Context,
+	orgName string,
+	domain string,
+	joinMode hub.OrganizationJoinMode,
+) error {
+	args := m.Called(ctx, orgName, domain, joinMode)
+	return args.Error(0)
+}
+
+// VerifyDomain implements the OrganizationManager interface.
+func (m *ManagerMock) VerifyDomain(ctx context.Context, orgName, domain string) error {
+	args := m.Called(ctx, orgName, domain)
+	return args.Error(0)
+}
+
+// TXTResolverMock is a mock implementation of the TXTResolver interface.
+type TXTResolverMock struct {
+	mock.Mock
+}
+
+// LookupTXT implements the TXTResolver interface.
+func (m *TXTResolverMock) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	args := m.Called(ctx, name)
+	records, _ := args.Get(0).([]string)
+	return records, args.Error(1)
+}
@@ -0,0 +1,130 @@
+package pkg
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// carvelImagesLockAPIVersion represents the api version of the Carvel
+	// ImagesLock resource included in the bundles.
+	carvelImagesLockAPIVersion = "imgpkg.carvel.dev/v1alpha1"
+
+	// carvelImagesLockKind represents the kind of the Carvel ImagesLock
+	// resource included in the bundles.
+	carvelImagesLockKind = "ImagesLock"
+)
+
+// sbomFileNameReplacer is used to build the name of the SBOM files from the
+// images references.
+var sbomFileNameReplacer = strings.NewReplacer("/", "_", ":", "_", "@", "_")
+
+// carvelImagesLock represents a Carvel ImagesLock resource, used by imgpkg to
+// relocate the images referenced by a bundle.
+type carvelImagesLock struct {
+	APIVersion string                   `json:"apiVersion"`
+	Kind       string                   `json:"kind"`
+	Images     []*carvelImagesLockImage `json:"images"`
+}
+
+// carvelImagesLockImage represents an image in a Carvel ImagesLock resource.
+type carvelImagesLockImage struct {
+	Image       string            `json:"image"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// WriteBundle writes to the writer provided a gzipped tarball with the
+// offline bundle of the package version, to support air-gapped installations.
+// The bundle contains the chart archive (when provided), the list of images
+// referenced by the package version (images.txt and images.lock.yml in Carvel
+// ImagesLock format) and the CycloneDX SBOMs available for those images. All
+// files are placed in a directory named after the package name and version.
+func WriteBundle(w io.Writer, data *hub.SnapshotBundleData, chartArchive []byte) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	baseDir := fmt.Sprintf("%s-%s", data.Name, data.Version)
+	modTime := time.Now()
+	addFile := func(name string, content []byte) error {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Join(baseDir, name),
+			Mode:     0644,
+			Size:     int64(len(content)),
+			ModTime:  modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+
+	// Chart archive
+	if len(chartArchive) > 0 {
+		if err := addFile(baseDir+".tgz", chartArchive); err != nil {
+			return err
+		}
+	}
+
+	// Images list
+	images := make([]string, 0, len(data.ContainersImages))
+	for _, image := range data.ContainersImages {
+		images = append(images, image.Image)
+	}
+	sort.Strings(images)
+	var imagesTXT strings.Builder
+	for _, image := range images {
+		imagesTXT.WriteString(image + "\n")
+	}
+	if err := addFile("images.txt", []byte(imagesTXT.String())); err != nil {
+		return err
+	}
+
+	// Images list (Carvel ImagesLock format)
+	imagesLock := &carvelImagesLock{
+		APIVersion: carvelImagesLockAPIVersion,
+		Kind:       carvelImagesLockKind,
+		Images:     make([]*carvelImagesLockImage, 0, len(images)),
+	}
+	for _, image := range images {
+		imagesLock.Images = append(imagesLock.Images, &carvelImagesLockImage{
+			Image: image,
+			Annotations: map[string]string{
+				"kbld.carvel.dev/id": image,
+			},
+		})
+	}
+	imagesLockYAML, err := yaml.Marshal(imagesLock)
+	if err != nil {
+		return err
+	}
+	if err := addFile("images.lock.yml", imagesLockYAML); err != nil {
+		return err
+	}
+
+	// SBOMs
+	for _, image := range images {
+		sbom, ok := data.SBOMs[image]
+		if !ok {
+			continue
+		}
+		name := path.Join("sboms", sbomFileNameReplacer.Replace(image)+".cdx.json")
+		if err := addFile(name, sbom); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
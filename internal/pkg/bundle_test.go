@@ -0,0 +1,85 @@
+package pkg
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBundle(t *testing.T) {
+	data := &hub.SnapshotBundleData{
+		Name:    "pkg1",
+		Version: "1.0.0",
+		ContainersImages: []*hub.ContainerImage{
+			{Name: "app", Image: "quay.io/org/img:1.0.0"},
+			{Image: "docker.io/org/sidecar@sha256:0000"},
+		},
+		SBOMs: map[string]json.RawMessage{
+			"quay.io/org/img:1.0.0": json.RawMessage(`{"bomFormat":"CycloneDX"}`),
+		},
+	}
+
+	t.Run("bundle with chart archive", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		err := WriteBundle(&buf, data, []byte("chart"))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"pkg1-1.0.0/pkg1-1.0.0.tgz": "chart",
+			"pkg1-1.0.0/images.txt":     "docker.io/org/sidecar@sha256:0000\nquay.io/org/img:1.0.0\n",
+			"pkg1-1.0.0/images.lock.yml": `apiVersion: imgpkg.carvel.dev/v1alpha1
+images:
+- annotations:
+    kbld.carvel.dev/id: docker.io/org/sidecar@sha256:0000
+  image: docker.io/org/sidecar@sha256:0000
+- annotations:
+    kbld.carvel.dev/id: quay.io/org/img:1.0.0
+  image: quay.io/org/img:1.0.0
+kind: ImagesLock
+`,
+			"pkg1-1.0.0/sboms/quay.io_org_img_1.0.0.cdx.json": `{"bomFormat":"CycloneDX"}`,
+		}, readBundle(t, &buf))
+	})
+
+	t.Run("bundle without chart archive nor images", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		err := WriteBundle(&buf, &hub.SnapshotBundleData{Name: "pkg2", Version: "2.0.0"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"pkg2-2.0.0/images.txt": "",
+			"pkg2-2.0.0/images.lock.yml": `apiVersion: imgpkg.carvel.dev/v1alpha1
+images: []
+kind: ImagesLock
+`,
+		}, readBundle(t, &buf))
+	})
+}
+
+// readBundle is a helper that returns the content of the files in the bundle
+// provided, keyed by name.
+func readBundle(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+	gzr, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gzr)
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
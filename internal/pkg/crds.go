@@ -0,0 +1,101 @@
+package pkg
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// crdSchema represents the subset of a CRD version OpenAPI v3 schema used to
+// document its fields.
+type crdSchema struct {
+	Type        string                `json:"type"`
+	Description string                `json:"description"`
+	Default     json.RawMessage       `json:"default"`
+	Enum        []json.RawMessage     `json:"enum"`
+	Required    []string              `json:"required"`
+	Properties  map[string]*crdSchema `json:"properties"`
+	Items       *crdSchema            `json:"items"`
+}
+
+// GetCRDFields returns the fields defined in the CRD version OpenAPI v3 schema
+// provided, flattened and including their documentation. Fields are returned
+// depth first, sorted by name at each level. The path of the fields nested in
+// arrays items includes the [] suffix in the array field (i.e. spec.ports[].name).
+func GetCRDFields(schema json.RawMessage) ([]*hub.CRDField, error) {
+	if len(schema) == 0 {
+		return nil, nil
+	}
+	var s *crdSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, err
+	}
+	var fields []*hub.CRDField
+	addCRDFields(&fields, "", s)
+	return fields, nil
+}
+
+// addCRDFields adds the fields defined in the properties of the schema
+// provided to the fields list, recursing into nested objects and arrays.
+func addCRDFields(fields *[]*hub.CRDField, parentPath string, s *crdSchema) {
+	if s == nil {
+		return
+	}
+	if s.Items != nil {
+		addCRDFields(fields, parentPath+"[]", s.Items)
+		return
+	}
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := s.Properties[name]
+		if prop == nil {
+			continue
+		}
+		path := name
+		if parentPath != "" {
+			path = parentPath + "." + name
+		}
+		f := &hub.CRDField{
+			Path:        path,
+			Type:        prop.Type,
+			Description: prop.Description,
+			Required:    contains(s.Required, name),
+			Default:     rawToString(prop.Default),
+		}
+		for _, v := range prop.Enum {
+			f.Enum = append(f.Enum, rawToString(v))
+		}
+		*fields = append(*fields, f)
+		addCRDFields(fields, path, prop)
+	}
+}
+
+// contains checks if the slice of strings provided contains the value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// rawToString returns the string representation of the raw json value
+// provided. Strings are unquoted, other values are returned as json.
+func rawToString(v json.RawMessage) string {
+	if len(v) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err == nil {
+		return s
+	}
+	return strings.TrimSpace(string(v))
+}
@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCRDFields(t *testing.T) {
+	t.Run("no schema provided", func(t *testing.T) {
+		t.Parallel()
+		fields, err := GetCRDFields(nil)
+		require.NoError(t, err)
+		assert.Nil(t, fields)
+	})
+
+	t.Run("invalid schema provided", func(t *testing.T) {
+		t.Parallel()
+		fields, err := GetCRDFields(json.RawMessage(`[`))
+		assert.Error(t, err)
+		assert.Nil(t, fields)
+	})
+
+	t.Run("fields extracted successfully", func(t *testing.T) {
+		t.Parallel()
+		schema := json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"spec": {
+					"type": "object",
+					"description": "Desired state",
+					"required": ["replicas"],
+					"properties": {
+						"replicas": {"type": "integer", "description": "Number of replicas", "default": 1},
+						"mode": {"type": "string", "enum": ["fast", "safe"], "default": "safe"},
+						"ports": {
+							"type": "array",
+							"items": {
+								"type": "object",
+								"required": ["port"],
+								"properties": {
+									"port": {"type": "integer"}
+								}
+							}
+						}
+					}
+				},
+				"apiVersion": {"type": "string"}
+			}
+		}`)
+		fields, err := GetCRDFields(schema)
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.CRDField{
+			{Path: "apiVersion", Type: "string"},
+			{Path: "spec", Type: "object", Description: "Desired state"},
+			{Path: "spec.mode", Type: "string", Default: "safe", Enum: []string{"fast", "safe"}},
+			{Path: "spec.ports", Type: "array"},
+			{Path: "spec.ports[].port", Type: "integer", Required: true},
+			{Path: "spec.replicas", Type: "integer", Description: "Number of replicas", Required: true, Default: "1"},
+		}, fields)
+	})
+}
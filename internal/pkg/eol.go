@@ -0,0 +1,39 @@
+package pkg
+
+import (
+	"context"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const (
+	// Database queries
+	registerEOLEventsDBQ = `select register_eol_events($1::bigint)`
+
+	// EOLEventsCheckFrequency represents how often the packages versions
+	// should be checked to find the ones that have reached their end of life.
+	EOLEventsCheckFrequency = 1 * time.Hour
+)
+
+// EOLEventsRegisterer registers an event for each package version that has
+// reached its end of life, so that the users subscribed to the package can be
+// notified. It is expected to be run periodically as a background job.
+type EOLEventsRegisterer struct {
+	db hub.DB
+}
+
+// NewEOLEventsRegisterer creates a new EOLEventsRegisterer instance.
+func NewEOLEventsRegisterer(db hub.DB) *EOLEventsRegisterer {
+	return &EOLEventsRegisterer{
+		db: db,
+	}
+}
+
+// Register registers the end of life events of the packages versions that
+// have reached their end of life.
+func (r *EOLEventsRegisterer) Register(ctx context.Context) error {
+	_, err := r.db.Exec(ctx, registerEOLEventsDBQ, util.DBLockKeyRegisterEOLEvents)
+	return err
+}
@@ -0,0 +1,36 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEOLEventsRegisterer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerEOLEventsDBQ, util.DBLockKeyRegisterEOLEvents).Return(tests.ErrFakeDB)
+
+		r := NewEOLEventsRegisterer(db)
+		err := r.Register(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("events registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerEOLEventsDBQ, util.DBLockKeyRegisterEOLEvents).Return(nil)
+
+		r := NewEOLEventsRegisterer(db)
+		err := r.Register(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
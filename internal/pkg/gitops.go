@@ -0,0 +1,163 @@
+package pkg
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// gitOpsReconcileInterval represents the interval used by the Flux
+	// resources generated to reconcile the release.
+	gitOpsReconcileInterval = "1h"
+
+	// argoCDNamespace represents the namespace where Argo CD is usually
+	// installed, which is where its applications must be created.
+	argoCDNamespace = "argocd"
+)
+
+// BuildGitOpsManifests generates the manifests needed to deploy the Helm chart
+// provided using the GitOps tool and values requested. When a values schema
+// is provided, the values are validated against it. The manifests are
+// returned as a multi-document yaml ready to be committed.
+func BuildGitOpsManifests(
+	p *hub.Package,
+	input *hub.GitOpsManifestsInput,
+	valuesSchema []byte,
+) ([]byte, error) {
+	// Validate input
+	if p.Repository == nil || p.Repository.Kind != hub.Helm {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "manifests are only available for helm charts")
+	}
+	if input.Tool != InstallToolFlux && input.Tool != InstallToolArgoCD {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid tool (flux|argocd)")
+	}
+	if err := chartutil.ValidateReleaseName(input.ReleaseName); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid release name")
+	}
+	if errs := validation.IsDNS1123Label(input.Namespace); len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid namespace", strings.Join(errs, ", "))
+	}
+	if len(valuesSchema) > 0 && !bytes.Equal(valuesSchema, []byte("{}")) {
+		if err := chartutil.ValidateAgainstSingleSchema(input.Values, valuesSchema); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", hub.ErrInvalidInput, "invalid values", err)
+		}
+	}
+
+	// Build manifests for the tool requested
+	var manifests []interface{}
+	switch input.Tool {
+	case InstallToolFlux:
+		manifests = buildFluxManifests(p, input)
+	case InstallToolArgoCD:
+		argoApp, err := buildArgoCDManifest(p, input)
+		if err != nil {
+			return nil, err
+		}
+		manifests = []interface{}{argoApp}
+	}
+	var docs [][]byte
+	for _, manifest := range manifests {
+		doc, err := yaml.Marshal(manifest)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return bytes.Join(docs, []byte("---\n")), nil
+}
+
+// buildFluxManifests builds the Flux HelmRepository and HelmRelease needed to
+// deploy the Helm chart provided.
+func buildFluxManifests(p *hub.Package, input *hub.GitOpsManifestsInput) []interface{} {
+	repoSpec := map[string]interface{}{
+		"interval": gitOpsReconcileInterval,
+		"url":      p.Repository.URL,
+	}
+	if strings.HasPrefix(p.Repository.URL, ociPrefix) {
+		repoSpec["type"] = "oci"
+		repoSpec["url"] = ociPrefix + path.Dir(strings.TrimPrefix(p.Repository.URL, ociPrefix))
+	}
+	helmRepository := map[string]interface{}{
+		"apiVersion": "source.toolkit.fluxcd.io/v1beta2",
+		"kind":       "HelmRepository",
+		"metadata": map[string]interface{}{
+			"name":      p.Repository.Name,
+			"namespace": input.Namespace,
+		},
+		"spec": repoSpec,
+	}
+	releaseSpec := map[string]interface{}{
+		"interval": gitOpsReconcileInterval,
+		"chart": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"chart":   p.Name,
+				"version": p.Version,
+				"sourceRef": map[string]interface{}{
+					"kind":      "HelmRepository",
+					"name":      p.Repository.Name,
+					"namespace": input.Namespace,
+				},
+			},
+		},
+	}
+	if len(input.Values) > 0 {
+		releaseSpec["values"] = input.Values
+	}
+	helmRelease := map[string]interface{}{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2beta1",
+		"kind":       "HelmRelease",
+		"metadata": map[string]interface{}{
+			"name":      input.ReleaseName,
+			"namespace": input.Namespace,
+		},
+		"spec": releaseSpec,
+	}
+	return []interface{}{helmRepository, helmRelease}
+}
+
+// buildArgoCDManifest builds the Argo CD Application needed to deploy the
+// Helm chart provided.
+func buildArgoCDManifest(p *hub.Package, input *hub.GitOpsManifestsInput) (interface{}, error) {
+	repoURL := p.Repository.URL
+	if strings.HasPrefix(repoURL, ociPrefix) {
+		repoURL = path.Dir(strings.TrimPrefix(repoURL, ociPrefix))
+	}
+	helmSource := map[string]interface{}{
+		"releaseName": input.ReleaseName,
+	}
+	if len(input.Values) > 0 {
+		values, err := yaml.Marshal(input.Values)
+		if err != nil {
+			return nil, err
+		}
+		helmSource["values"] = string(values)
+	}
+	return map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]interface{}{
+			"name":      input.ReleaseName,
+			"namespace": argoCDNamespace,
+		},
+		"spec": map[string]interface{}{
+			"project": "default",
+			"source": map[string]interface{}{
+				"repoURL":        repoURL,
+				"chart":          p.Name,
+				"targetRevision": p.Version,
+				"helm":           helmSource,
+			},
+			"destination": map[string]interface{}{
+				"server":    "https://kubernetes.default.svc",
+				"namespace": input.Namespace,
+			},
+		},
+	}, nil
+}
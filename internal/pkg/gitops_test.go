@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGitOpsManifests(t *testing.T) {
+	p := &hub.Package{
+		Name:    "pkg1",
+		Version: "1.0.0",
+		Repository: &hub.Repository{
+			Kind: hub.Helm,
+			Name: "repo1",
+			URL:  "https://repo1.url",
+		},
+	}
+	valuesSchema := []byte(`{
+		"type": "object",
+		"properties": {
+			"replicas": {"type": "integer"}
+		}
+	}`)
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			p      *hub.Package
+			input  *hub.GitOpsManifestsInput
+		}{
+			{
+				"manifests are only available for helm charts",
+				&hub.Package{Repository: &hub.Repository{Kind: hub.OLM}},
+				&hub.GitOpsManifestsInput{},
+			},
+			{
+				"invalid tool",
+				p,
+				&hub.GitOpsManifestsInput{Tool: "invalid"},
+			},
+			{
+				"invalid release name",
+				p,
+				&hub.GitOpsManifestsInput{Tool: "flux", ReleaseName: "Invalid_Name"},
+			},
+			{
+				"invalid namespace",
+				p,
+				&hub.GitOpsManifestsInput{Tool: "flux", ReleaseName: "release1", Namespace: "Invalid_NS"},
+			},
+			{
+				"invalid values",
+				p,
+				&hub.GitOpsManifestsInput{
+					Tool:        "flux",
+					ReleaseName: "release1",
+					Namespace:   "default",
+					Values:      map[string]interface{}{"replicas": "two"},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				_, err := BuildGitOpsManifests(tc.p, tc.input, valuesSchema)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("flux manifests", func(t *testing.T) {
+		t.Parallel()
+		manifests, err := BuildGitOpsManifests(p, &hub.GitOpsManifestsInput{
+			Tool:        "flux",
+			ReleaseName: "release1",
+			Namespace:   "ns1",
+			Values:      map[string]interface{}{"replicas": 2},
+		}, valuesSchema)
+		require.NoError(t, err)
+		assert.Equal(t, `apiVersion: source.toolkit.fluxcd.io/v1beta2
+kind: HelmRepository
+metadata:
+  name: repo1
+  namespace: ns1
+spec:
+  interval: 1h
+  url: https://repo1.url
+---
+apiVersion: helm.toolkit.fluxcd.io/v2beta1
+kind: HelmRelease
+metadata:
+  name: release1
+  namespace: ns1
+spec:
+  chart:
+    spec:
+      chart: pkg1
+      sourceRef:
+        kind: HelmRepository
+        name: repo1
+        namespace: ns1
+      version: 1.0.0
+  interval: 1h
+  values:
+    replicas: 2
+`, string(manifests))
+	})
+
+	t.Run("argo cd manifest", func(t *testing.T) {
+		t.Parallel()
+		manifests, err := BuildGitOpsManifests(p, &hub.GitOpsManifestsInput{
+			Tool:        "argocd",
+			ReleaseName: "release1",
+			Namespace:   "ns1",
+			Values:      map[string]interface{}{"replicas": 2},
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, `apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: release1
+  namespace: argocd
+spec:
+  destination:
+    namespace: ns1
+    server: https://kubernetes.default.svc
+  project: default
+  source:
+    chart: pkg1
+    helm:
+      releaseName: release1
+      values: |
+        replicas: 2
+    repoURL: https://repo1.url
+    targetRevision: 1.0.0
+`, string(manifests))
+	})
+}
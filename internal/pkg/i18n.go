@@ -0,0 +1,109 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+var (
+	// localeRE is a regexp used to validate locales once normalized (i.e. zh,
+	// pt-br).
+	localeRE = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+	// readmeTranslationRE is a regexp used to match the names of the files
+	// containing translated READMEs (i.e. README_zh.md).
+	readmeTranslationRE = regexp.MustCompile(`^README_([a-zA-Z0-9_-]+)\.md$`)
+)
+
+// NormalizeLocale normalizes the locale provided (i.e. pt_BR -> pt-br),
+// returning an error if it's not valid.
+func NormalizeLocale(locale string) (string, error) {
+	normalizedLocale := strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if !localeRE.MatchString(normalizedLocale) {
+		return "", fmt.Errorf("invalid locale: %s", locale)
+	}
+	return normalizedLocale, nil
+}
+
+// NormalizeTranslations returns a copy of the translations provided with the
+// locales normalized. Empty translations are ignored.
+func NormalizeTranslations(translations hub.PackageTranslations) (hub.PackageTranslations, error) {
+	if len(translations) == 0 {
+		return nil, nil
+	}
+	normalizedTranslations := make(hub.PackageTranslations, len(translations))
+	for locale, t := range translations {
+		normalizedLocale, err := NormalizeLocale(locale)
+		if err != nil {
+			return nil, fmt.Errorf("invalid translation: %w", err)
+		}
+		if t == nil || (t.Description == "" && t.Readme == "") {
+			continue
+		}
+		normalizedTranslations[normalizedLocale] = t
+	}
+	if len(normalizedTranslations) == 0 {
+		return nil, nil
+	}
+	return normalizedTranslations, nil
+}
+
+// GetReadmeTranslationLocale returns the normalized locale of the translated
+// README file provided (i.e. README_zh.md). An empty string is returned when
+// the file name does not match the translated READMEs pattern.
+func GetReadmeTranslationLocale(fileName string) string {
+	m := readmeTranslationRE.FindStringSubmatch(fileName)
+	if m == nil {
+		return ""
+	}
+	locale, err := NormalizeLocale(m[1])
+	if err != nil {
+		return ""
+	}
+	return locale
+}
+
+// AddReadmeTranslation adds the translated README provided to the package for
+// the given locale. Translated READMEs already set (i.e. provided in the
+// package metadata) are not overwritten.
+func AddReadmeTranslation(p *hub.Package, locale, readme string) {
+	if p.Translations == nil {
+		p.Translations = make(hub.PackageTranslations)
+	}
+	t, ok := p.Translations[locale]
+	if !ok {
+		t = &hub.PackageTranslation{}
+		p.Translations[locale] = t
+	}
+	if t.Readme == "" {
+		t.Readme = readme
+	}
+}
+
+// AddReadmeTranslationsFromPath adds to the package the translated READMEs
+// (i.e. README_zh.md) found in the path provided.
+func AddReadmeTranslationsFromPath(p *hub.Package, pkgPath string) {
+	entries, err := os.ReadDir(pkgPath)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		locale := GetReadmeTranslationLocale(entry.Name())
+		if locale == "" {
+			continue
+		}
+		readme, err := os.ReadFile(filepath.Join(pkgPath, entry.Name()))
+		if err != nil {
+			continue
+		}
+		AddReadmeTranslation(p, locale, string(readme))
+	}
+}
@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	testCases := []struct {
+		locale           string
+		expectedLocale   string
+		expectedErrorMsg string
+	}{
+		{"zh", "zh", ""},
+		{"pt-BR", "pt-br", ""},
+		{"pt_BR", "pt-br", ""},
+		{"zh-Hant-TW", "zh-hant-tw", ""},
+		{"", "", "invalid locale: "},
+		{"chinese", "", "invalid locale: chinese"},
+		{"en-", "", "invalid locale: en-"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.locale, func(t *testing.T) {
+			t.Parallel()
+			locale, err := NormalizeLocale(tc.locale)
+			if tc.expectedErrorMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedLocale, locale)
+		})
+	}
+}
+
+func TestGetReadmeTranslationLocale(t *testing.T) {
+	testCases := []struct {
+		fileName       string
+		expectedLocale string
+	}{
+		{"README.md", ""},
+		{"README_zh.md", "zh"},
+		{"README_pt_BR.md", "pt-br"},
+		{"README_zh-TW.md", "zh-tw"},
+		{"README_chinese.md", ""},
+		{"readme_zh.md", ""},
+		{"README_zh.txt", ""},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.fileName, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedLocale, GetReadmeTranslationLocale(tc.fileName))
+		})
+	}
+}
+
+func TestAddReadmeTranslation(t *testing.T) {
+	t.Parallel()
+	p := &hub.Package{
+		Translations: hub.PackageTranslations{
+			"es": {Description: "descripción"},
+			"fr": {Readme: "readme (fr, metadata)"},
+		},
+	}
+	AddReadmeTranslation(p, "es", "readme (es)")
+	AddReadmeTranslation(p, "fr", "readme (fr)")
+	AddReadmeTranslation(p, "zh", "readme (zh)")
+	assert.Equal(t, hub.PackageTranslations{
+		"es": {Description: "descripción", Readme: "readme (es)"},
+		"fr": {Readme: "readme (fr, metadata)"},
+		"zh": {Readme: "readme (zh)"},
+	}, p.Translations)
+}
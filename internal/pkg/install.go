@@ -0,0 +1,171 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// Tools the install snippets are generated for.
+const (
+	InstallToolArgoCD    = "argocd"
+	InstallToolFlux      = "flux"
+	InstallToolHelm      = "helm"
+	InstallToolHelmfile  = "helmfile"
+	InstallToolTerraform = "terraform"
+)
+
+// ociPrefix represents the prefix used by the urls of the Helm repositories
+// stored in OCI registries.
+const ociPrefix = "oci://"
+
+// installSnippetsTmpls represents the templates used to generate the install
+// snippets of Helm charts for each of the tools supported.
+var installSnippetsTmpls = map[string]*template.Template{
+	InstallToolArgoCD: template.Must(template.New("").Parse(`apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: {{ .Release }}
+  namespace: argocd
+spec:
+  project: default
+  source:
+    repoURL: {{ if .OCI }}{{ .OCIRepository }}{{ else }}{{ .RepositoryURL }}{{ end }}
+    chart: {{ .Chart }}
+    targetRevision: {{ .Version }}
+  destination:
+    server: https://kubernetes.default.svc
+    namespace: default
+`)),
+	InstallToolFlux: template.Must(template.New("").Parse(`apiVersion: source.toolkit.fluxcd.io/v1beta2
+kind: HelmRepository
+metadata:
+  name: {{ .Repository }}
+  namespace: flux-system
+spec:
+  interval: 1h
+{{- if .OCI }}
+  type: oci
+  url: oci://{{ .OCIRepository }}
+{{- else }}
+  url: {{ .RepositoryURL }}
+{{- end }}
+---
+apiVersion: helm.toolkit.fluxcd.io/v2beta1
+kind: HelmRelease
+metadata:
+  name: {{ .Release }}
+  namespace: default
+spec:
+  interval: 1h
+  chart:
+    spec:
+      chart: {{ .Chart }}
+      version: "{{ .Version }}"
+      sourceRef:
+        kind: HelmRepository
+        name: {{ .Repository }}
+        namespace: flux-system
+`)),
+	InstallToolHelm: template.Must(template.New("").Parse(`
+{{- if .OCI -}}
+helm install {{ .Release }} {{ .RepositoryURL }} --version {{ .Version }}
+{{- else -}}
+helm repo add {{ .Repository }} {{ .RepositoryURL }}
+helm install {{ .Release }} {{ .Repository }}/{{ .Chart }} --version {{ .Version }}
+{{- end }}
+`)),
+	InstallToolHelmfile: template.Must(template.New("").Parse(`repositories:
+  - name: {{ .Repository }}
+{{- if .OCI }}
+    url: {{ .OCIRepository }}
+    oci: true
+{{- else }}
+    url: {{ .RepositoryURL }}
+{{- end }}
+releases:
+  - name: {{ .Release }}
+    namespace: default
+    chart: {{ .Repository }}/{{ .Chart }}
+    version: {{ .Version }}
+`)),
+	InstallToolTerraform: template.Must(template.New("").Parse(`resource "helm_release" "{{ .Release }}" {
+  name       = "{{ .Release }}"
+  repository = "{{ if .OCI }}oci://{{ .OCIRepository }}{{ else }}{{ .RepositoryURL }}{{ end }}"
+  chart      = "{{ .Chart }}"
+  version    = "{{ .Version }}"
+  namespace  = "default"
+}
+`)),
+}
+
+// installSnippetsData represents the information used by the install
+// snippets templates.
+type installSnippetsData struct {
+	Release       string
+	Chart         string
+	Version       string
+	Repository    string
+	RepositoryURL string
+	OCI           bool
+	OCIRepository string
+}
+
+// BuildInstallSnippets generates the snippets that can be used to install the
+// package provided using some popular tools. Snippets are only available for
+// Helm charts, so nil is returned for any other kind of package.
+func BuildInstallSnippets(p *hub.Package) map[string]string {
+	if p.Repository == nil || p.Repository.Kind != hub.Helm || p.Version == "" {
+		return nil
+	}
+	data := &installSnippetsData{
+		Release:       p.NormalizedName,
+		Chart:         p.Name,
+		Version:       p.Version,
+		Repository:    p.Repository.Name,
+		RepositoryURL: p.Repository.URL,
+	}
+	if strings.HasPrefix(p.Repository.URL, ociPrefix) {
+		// The url of Helm repositories stored in OCI registries includes the
+		// chart name, so it's removed to get the registry's repository
+		data.OCI = true
+		data.OCIRepository = path.Dir(strings.TrimPrefix(p.Repository.URL, ociPrefix))
+	}
+	snippets := make(map[string]string, len(installSnippetsTmpls))
+	for tool, tmpl := range installSnippetsTmpls {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			continue
+		}
+		snippets[tool] = buf.String()
+	}
+	return snippets
+}
+
+// addInstallSnippets adds the install snippets of the package to the package
+// json data provided. The data provided is returned unmodified when there are
+// no snippets available for the package or it cannot be processed.
+func addInstallSnippets(dataJSON []byte) []byte {
+	var p *hub.Package
+	if err := json.Unmarshal(dataJSON, &p); err != nil || p == nil {
+		return dataJSON
+	}
+	snippets := BuildInstallSnippets(p)
+	if snippets == nil {
+		return dataJSON
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(dataJSON, &obj); err != nil {
+		return dataJSON
+	}
+	obj["install_snippets"], _ = json.Marshal(snippets)
+	newDataJSON, err := json.Marshal(obj)
+	if err != nil {
+		return dataJSON
+	}
+	return newDataJSON
+}
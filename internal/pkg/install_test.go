@@ -0,0 +1,125 @@
+package pkg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInstallSnippets(t *testing.T) {
+	t.Run("snippets not available", func(t *testing.T) {
+		testCases := []struct {
+			desc string
+			p    *hub.Package
+		}{
+			{
+				"repository not provided",
+				&hub.Package{Name: "pkg1", Version: "1.0.0"},
+			},
+			{
+				"not a helm chart",
+				&hub.Package{Name: "pkg1", Version: "1.0.0", Repository: &hub.Repository{Kind: hub.OLM}},
+			},
+			{
+				"version not provided",
+				&hub.Package{Name: "pkg1", Repository: &hub.Repository{Kind: hub.Helm}},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.desc, func(t *testing.T) {
+				t.Parallel()
+				assert.Nil(t, BuildInstallSnippets(tc.p))
+			})
+		}
+	})
+
+	t.Run("helm repository", func(t *testing.T) {
+		t.Parallel()
+		snippets := BuildInstallSnippets(&hub.Package{
+			Name:           "pkg1",
+			NormalizedName: "pkg1",
+			Version:        "1.0.0",
+			Repository: &hub.Repository{
+				Kind: hub.Helm,
+				Name: "repo1",
+				URL:  "https://repo1.url",
+			},
+		})
+		require.Len(t, snippets, 5)
+		assert.Equal(t, `helm repo add repo1 https://repo1.url
+helm install pkg1 repo1/pkg1 --version 1.0.0
+`, snippets[InstallToolHelm])
+		assert.Equal(t, `repositories:
+  - name: repo1
+    url: https://repo1.url
+releases:
+  - name: pkg1
+    namespace: default
+    chart: repo1/pkg1
+    version: 1.0.0
+`, snippets[InstallToolHelmfile])
+		assert.Equal(t, `resource "helm_release" "pkg1" {
+  name       = "pkg1"
+  repository = "https://repo1.url"
+  chart      = "pkg1"
+  version    = "1.0.0"
+  namespace  = "default"
+}
+`, snippets[InstallToolTerraform])
+		assert.Contains(t, snippets[InstallToolFlux], "  url: https://repo1.url\n")
+		assert.Contains(t, snippets[InstallToolArgoCD], "    repoURL: https://repo1.url\n")
+	})
+
+	t.Run("helm repository stored in oci registry", func(t *testing.T) {
+		t.Parallel()
+		snippets := BuildInstallSnippets(&hub.Package{
+			Name:           "pkg1",
+			NormalizedName: "pkg1",
+			Version:        "1.0.0",
+			Repository: &hub.Repository{
+				Kind: hub.Helm,
+				Name: "repo1",
+				URL:  "oci://registry.io/org/pkg1",
+			},
+		})
+		require.Len(t, snippets, 5)
+		assert.Equal(t, "helm install pkg1 oci://registry.io/org/pkg1 --version 1.0.0\n", snippets[InstallToolHelm])
+		assert.Contains(t, snippets[InstallToolHelmfile], "    url: registry.io/org\n    oci: true\n")
+		assert.Contains(t, snippets[InstallToolTerraform], `repository = "oci://registry.io/org"`)
+		assert.Contains(t, snippets[InstallToolFlux], "  type: oci\n  url: oci://registry.io/org\n")
+		assert.Contains(t, snippets[InstallToolArgoCD], "    repoURL: registry.io/org\n")
+	})
+}
+
+func TestAddInstallSnippets(t *testing.T) {
+	t.Run("invalid json data is returned unmodified", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []byte("dataJSON"), addInstallSnippets([]byte("dataJSON")))
+	})
+
+	t.Run("data of packages without snippets is returned unmodified", func(t *testing.T) {
+		t.Parallel()
+		dataJSON := []byte(`{"name": "pkg1", "version": "1.0.0", "repository": {"kind": 3}}`)
+		assert.Equal(t, dataJSON, addInstallSnippets(dataJSON))
+	})
+
+	t.Run("snippets added to helm charts data", func(t *testing.T) {
+		t.Parallel()
+		dataJSON := []byte(`{
+			"name": "pkg1",
+			"normalized_name": "pkg1",
+			"version": "1.0.0",
+			"repository": {"kind": 0, "name": "repo1", "url": "https://repo1.url"}
+		}`)
+		var p *hub.Package
+		err := json.Unmarshal(addInstallSnippets(dataJSON), &p)
+		require.NoError(t, err)
+		assert.Equal(t, "pkg1", p.Name)
+		assert.Equal(t, "https://repo1.url", p.Repository.URL)
+		assert.Len(t, p.InstallSnippets, 5)
+	})
+}
@@ -0,0 +1,85 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// maxK8sMinorVersion represents the highest Kubernetes minor version that
+// will be considered when checking the compatibility of a package version
+// with the Kubernetes releases.
+const maxK8sMinorVersion = 99
+
+// k8sVersionRE is a regexp used to validate Kubernetes minor versions.
+var k8sVersionRE = regexp.MustCompile(`^v?1\.(0|[1-9]\d?)$`)
+
+// ParseK8sVersion parses the Kubernetes minor version provided (i.e. 1.29 or
+// v1.29), returning its minor number.
+func ParseK8sVersion(v string) (int, error) {
+	m := k8sVersionRE.FindStringSubmatch(v)
+	if m == nil {
+		return 0, fmt.Errorf("invalid kubernetes version: %s (1.MINOR expected)", v)
+	}
+	minor, _ := strconv.Atoi(m[1])
+	return minor, nil
+}
+
+// GetK8sCompatibility returns the ranges of Kubernetes minor versions a
+// package version is compatible with. When the publisher has declared
+// explicitly the compatible versions they take precedence over the kubeVersion
+// constraint. A minor version is considered compatible with the constraint
+// when its first or any of its later patch releases satisfy it.
+func GetK8sCompatibility(kubeVersion string, k8sVersions []string) ([]*hub.K8sVersionsRange, error) {
+	compatible := make(map[int]bool)
+	switch {
+	case len(k8sVersions) > 0:
+		for _, v := range k8sVersions {
+			minor, err := ParseK8sVersion(v)
+			if err != nil {
+				return nil, err
+			}
+			compatible[minor] = true
+		}
+	case kubeVersion != "":
+		c, err := semver.NewConstraint(kubeVersion)
+		if err != nil {
+			return nil, errors.New("invalid kubeVersion constraint")
+		}
+		for minor := 0; minor <= maxK8sMinorVersion; minor++ {
+			first := semver.MustParse(fmt.Sprintf("1.%d.0", minor))
+			last := semver.MustParse(fmt.Sprintf("1.%d.999", minor))
+			if c.Check(first) || c.Check(last) {
+				compatible[minor] = true
+			}
+		}
+	default:
+		return nil, nil
+	}
+
+	// Group the compatible minor versions in contiguous ranges
+	minors := make([]int, 0, len(compatible))
+	for minor := range compatible {
+		minors = append(minors, minor)
+	}
+	sort.Ints(minors)
+	var ranges []*hub.K8sVersionsRange
+	for i := 0; i < len(minors); {
+		j := i
+		for j+1 < len(minors) && minors[j+1] == minors[j]+1 {
+			j++
+		}
+		r := &hub.K8sVersionsRange{Min: fmt.Sprintf("1.%d", minors[i])}
+		if minors[j] != maxK8sMinorVersion || len(k8sVersions) > 0 {
+			r.Max = fmt.Sprintf("1.%d", minors[j])
+		}
+		ranges = append(ranges, r)
+		i = j + 1
+	}
+	return ranges, nil
+}
@@ -0,0 +1,120 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseK8sVersion(t *testing.T) {
+	testCases := []struct {
+		v             string
+		expectedMinor int
+		expectedErr   bool
+	}{
+		{"1.29", 29, false},
+		{"v1.9", 9, false},
+		{"1.0", 0, false},
+		{"1.29.1", 0, true},
+		{"2.1", 0, true},
+		{"1.029", 0, true},
+		{"", 0, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.v, func(t *testing.T) {
+			t.Parallel()
+			minor, err := ParseK8sVersion(tc.v)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedMinor, minor)
+			}
+		})
+	}
+}
+
+func TestGetK8sCompatibility(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		kubeVersion    string
+		k8sVersions    []string
+		expectedRanges []*hub.K8sVersionsRange
+		expectedErr    bool
+	}{
+		{
+			"no compatibility information provided",
+			"",
+			nil,
+			nil,
+			false,
+		},
+		{
+			"invalid kubeVersion constraint",
+			">=invalid",
+			nil,
+			nil,
+			true,
+		},
+		{
+			"invalid explicit version",
+			"",
+			[]string{"1.29", "latest"},
+			nil,
+			true,
+		},
+		{
+			"constraint without upper bound",
+			">=1.20.0-0",
+			nil,
+			[]*hub.K8sVersionsRange{
+				{Min: "1.20"},
+			},
+			false,
+		},
+		{
+			"constraint with upper bound and patch level",
+			">=1.21.3 <1.25.2",
+			nil,
+			[]*hub.K8sVersionsRange{
+				{Min: "1.21", Max: "1.25"},
+			},
+			false,
+		},
+		{
+			"constraint with several ranges",
+			"~1.19.0 || >=1.22.0 <1.24.0",
+			nil,
+			[]*hub.K8sVersionsRange{
+				{Min: "1.19", Max: "1.19"},
+				{Min: "1.22", Max: "1.23"},
+			},
+			false,
+		},
+		{
+			"explicit versions take precedence over constraint",
+			">=1.20.0",
+			[]string{"1.29", "v1.27", "1.28", "1.25"},
+			[]*hub.K8sVersionsRange{
+				{Min: "1.25", Max: "1.25"},
+				{Min: "1.27", Max: "1.29"},
+			},
+			false,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ranges, err := GetK8sCompatibility(tc.kubeVersion, tc.k8sVersions)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedRanges, ranges)
+		})
+	}
+}
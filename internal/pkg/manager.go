@@ -10,35 +10,52 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/cache"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/satori/uuid"
 )
 
 const (
 	// Database queries
-	addProductionUsageDBQ           = `select add_production_usage($1::uuid, $2::text, $3::text, $4::text)`
-	deleteProductionUsageDBQ        = `select delete_production_usage($1::uuid, $2::text, $3::text, $4::text)`
-	getHarborReplicationDumpDBQ     = `select get_harbor_replication_dump()`
-	getHelmExporterDumpDBQ          = `select get_helm_exporter_dump()`
-	getPkgDBQ                       = `select get_package($1::jsonb)`
-	getPkgChangelogDBQ              = `select get_package_changelog($1::uuid)`
-	getPkgStarsDBQ                  = `select get_package_stars($1::uuid, $2::uuid)`
-	getPkgSummaryDBQ                = `select get_package_summary($1::jsonb)`
-	getPkgViewsDBQ                  = `select get_package_views($1::uuid, $2::date, $3::date)`
-	getPkgsStarredByUserDBQ         = `select * from get_packages_starred_by_user($1::uuid, $2::int, $3::int)`
-	getPkgsStatsDBQ                 = `select get_packages_stats()`
-	getProductionUsageDBQ           = `select get_production_usage($1::uuid, $2::text, $3::text)`
-	getSnapshotSecurityReportDBQ    = `select security_report from snapshot where package_id = $1 and version = $2`
-	getSnapshotsToScanDBQ           = `select get_snapshots_to_scan()`
-	getRandomPkgsDBQ                = `select get_random_packages()`
-	getValuesSchemaDBQ              = `select values_schema from snapshot where package_id = $1 and version = $2`
-	registerPkgDBQ                  = `select register_package($1::jsonb)`
-	searchPkgsDBQ                   = `select * from search_packages($1::jsonb)`
-	searchPkgsMonocularDBQ          = `select search_packages_monocular($1::text, $2::text)`
-	togglePkgStarDBQ                = `select toggle_star($1::uuid, $2::uuid)`
-	updateSnapshotSecurityReportDBQ = `select update_snapshot_security_report($1::jsonb)`
-	unregisterPkgDBQ                = `select unregister_package($1::jsonb)`
+	addProductionUsageDBQ                  = `select add_production_usage($1::uuid, $2::text, $3::text, $4::text)`
+	deleteProductionUsageDBQ               = `select delete_production_usage($1::uuid, $2::text, $3::text, $4::text)`
+	getCRDsSchemasDBQ                      = `select coalesce(crds_schemas, '[]') from snapshot where package_id = $1 and version = $2`
+	getHarborReplicationDumpDBQ            = `select get_harbor_replication_dump()`
+	getHelmExporterDumpDBQ                 = `select get_helm_exporter_dump()`
+	getImageScanDBQ                        = `select get_image_scan($1::text)`
+	getPkgDBQ                              = `select get_package($1::jsonb)`
+	getPkgChangelogDBQ                     = `select get_package_changelog($1::uuid)`
+	getPkgStarsDBQ                         = `select get_package_stars($1::uuid, $2::uuid)`
+	getPkgSummaryDBQ                       = `select get_package_summary($1::jsonb)`
+	getPkgUpgradeGraphDBQ                  = `select get_package_upgrade_graph($1::uuid)`
+	getPkgDownloadsDBQ                     = `select get_package_downloads($1::uuid, $2::date, $3::date)`
+	getPkgViewsDBQ                         = `select get_package_views($1::uuid, $2::date, $3::date)`
+	getPkgsStarredByUserDBQ                = `select * from get_packages_starred_by_user($1::uuid, $2::int, $3::int)`
+	getPkgsStatsDBQ                        = `select get_packages_stats()`
+	getProductionUsageDBQ                  = `select get_production_usage($1::uuid, $2::text, $3::text)`
+	getSnapshotBundleDataDBQ               = `select get_snapshot_bundle_data($1::uuid, $2::uuid, $3::text)`
+	getSnapshotContentWarningsDBQ          = `select content_warnings from snapshot where package_id = $1 and version = $2`
+	getSnapshotImagesDBQ                   = `select get_snapshot_images($1::uuid, $2::text)`
+	getSnapshotLicenseInventoryDBQ         = `select get_snapshot_license_inventory($1::uuid, $2::text, $3::boolean)`
+	getSnapshotSBOMDBQ                     = `select json_object_agg(image, sbom) from snapshot_sbom where package_id = $1 and version = $2 and format = $3`
+	getSnapshotSecurityReportDBQ           = `select security_report from snapshot where package_id = $1 and version = $2`
+	getSnapshotSecurityReportSuppressedDBQ = `select security_report_suppressed from snapshot where package_id = $1 and version = $2`
+	getSnapshotsToScanDBQ                  = `select get_snapshots_to_scan()`
+	getRandomPkgsDBQ                       = `select get_random_packages()`
+	getPkgsRankingDBQ                      = `select get_packages_ranking($1::text)`
+	getValuesSchemaDBQ                     = `select values_schema from snapshot where package_id = $1 and version = $2`
+	getVulnerabilityStatementsDBQ          = `select get_vulnerability_statements($1::uuid, $2::text)`
+	registerImageScanDBQ                   = `select register_image_scan($1::jsonb)`
+	registerPkgDBQ                         = `select register_package($1::jsonb)`
+	requestSnapshotScanDBQ                 = `select request_snapshot_scan($1::uuid, $2::uuid, $3::text)`
+	searchPkgsDBQ                          = `select * from search_packages($1::jsonb)`
+	searchPkgsMonocularDBQ                 = `select search_packages_monocular($1::text, $2::text)`
+	togglePkgStarDBQ                       = `select toggle_star($1::uuid, $2::uuid)`
+	updateSnapshotSecurityReportDBQ        = `select update_snapshot_security_report($1::jsonb)`
+	updateVulnerabilityStatementsDBQ       = `select update_vulnerability_statements($1::uuid, $2::uuid, $3::text, $4::jsonb)`
+	unregisterPkgDBQ                       = `select unregister_package($1::jsonb)`
 )
 
 var (
@@ -49,17 +66,48 @@ var (
 		"deep insights",
 		"auto pilot",
 	}
+
+	validRankings = []string{
+		"new",
+		"trending-7d",
+		"trending-30d",
+	}
 )
 
 // Manager provides an API to manage packages.
 type Manager struct {
-	db hub.DB
+	db    hub.DB
+	rdb   hub.DB
+	cache hub.Cache
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(db hub.DB) *Manager {
-	return &Manager{
-		db: db,
+func NewManager(db hub.DB, opts ...func(m *Manager)) *Manager {
+	m := &Manager{
+		db:  db,
+		rdb: db,
+	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// WithReplicaDB allows providing a read-only database replica for a Manager
+// instance. Some read paths will be served from it, keeping the load on the
+// primary database lower.
+func WithReplicaDB(rdb hub.DB) func(m *Manager) {
+	return func(m *Manager) {
+		m.rdb = rdb
+	}
+}
+
+// WithCache allows providing a Cache implementation for a Manager instance,
+// used to serve the packages and search results most requested without
+// hitting the database.
+func WithCache(c hub.Cache) func(m *Manager) {
+	return func(m *Manager) {
+		m.cache = c
 	}
 }
 
@@ -108,6 +156,34 @@ func (m *Manager) GetChangelog(ctx context.Context, pkgID string) (*hub.Changelo
 	return changelog, err
 }
 
+// GetCRDsSchemas returns the CRDs schemas of the package's snapshot
+// identified by the package id and version provided.
+func (m *Manager) GetCRDsSchemas(ctx context.Context, pkgID, version string) ([]*hub.CRDSchema, error) {
+	var crds []*hub.CRDSchema
+	if err := util.DBQueryUnmarshal(ctx, m.db, &crds, getCRDsSchemasDBQ, pkgID, version); err != nil {
+		return nil, err
+	}
+	return crds, nil
+}
+
+// GetDownloadsJSON returns a json object with the package total downloads and
+// the downloads of the last month organized by version and day. The json
+// object is built by the database.
+func (m *Manager) GetDownloadsJSON(ctx context.Context, pkgID string) ([]byte, error) {
+	// Validate input
+	if pkgID == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id not provided")
+	}
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+
+	// Get package downloads from database
+	end := time.Now().Format("2006-01-02")
+	start := time.Now().AddDate(0, -1, 0).Format("2006-01-02")
+	return util.DBQueryJSON(ctx, m.db, getPkgDownloadsDBQ, pkgID, start, end)
+}
+
 // GetHarborReplicationDumpJSON returns a json list with all packages versions
 // of kind Helm available so that they can be synchronized in Harbor.
 func (m *Manager) GetHarborReplicationDumpJSON(ctx context.Context) ([]byte, error) {
@@ -120,17 +196,56 @@ func (m *Manager) GetHelmExporterDumpJSON(ctx context.Context) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getHelmExporterDumpDBQ)
 }
 
+// GetImageScan returns the cached scan results of the container image
+// identified by the digest provided.
+func (m *Manager) GetImageScan(ctx context.Context, digest string) (*hub.ImageScan, error) {
+	// Validate input
+	if digest == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "digest not provided")
+	}
+
+	// Get image scan from database
+	var s *hub.ImageScan
+	if err := util.DBQueryUnmarshal(ctx, m.db, &s, getImageScanDBQ, digest); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
 // GetJSON returns the package identified by the input provided as a json
-// object. The json object is built by the database.
+// object. The json object is built by the database, and the install snippets
+// of the package are added to it when available.
 func (m *Manager) GetJSON(ctx context.Context, input *hub.GetPackageInput) ([]byte, error) {
 	// Validate input
 	if input.PackageID == "" && (input.PackageName == "" || input.RepositoryName == "") {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package name not provided")
 	}
+	if input.Locale != "" {
+		locale, err := NormalizeLocale(input.Locale)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", hub.ErrInvalidInput, err)
+		}
+		input.Locale = locale
+	}
 
-	// Get package from database
+	// Try to get package from cache
 	inputJSON, _ := json.Marshal(input)
-	return util.DBQueryJSON(ctx, m.db, getPkgDBQ, inputJSON)
+	key := cache.Key("pkg", inputJSON)
+	if dataJSON, ok := cache.Load(ctx, m.cache, key); ok {
+		return dataJSON, nil
+	}
+
+	// Get package from database and store it in the cache
+	dataJSON, err := util.DBQueryJSON(ctx, m.rdb, getPkgDBQ, inputJSON)
+	if err != nil {
+		if reason, ok := util.IsDBContentBlocked(err); ok {
+			return nil, fmt.Errorf("%w: %s", hub.ErrBlocked, reason)
+		}
+		return nil, err
+	}
+	dataJSON = addInstallSnippets(dataJSON)
+	cache.Store(ctx, m.cache, key, dataJSON, pkgCacheTags(dataJSON)...)
+	return dataJSON, nil
 }
 
 // GetProductionUsageJSON returns a json object describing which of the
@@ -141,18 +256,182 @@ func (m *Manager) GetProductionUsageJSON(ctx context.Context, repoName, pkgName
 	return util.DBQueryJSON(ctx, m.db, getProductionUsageDBQ, userID, repoName, pkgName)
 }
 
+// GetRankingJSON returns a json list with the packages in the ranking
+// provided (i.e. trending-7d, new). Rankings are refreshed periodically by the
+// RankingsRefresher. The json object is built by the database.
+func (m *Manager) GetRankingJSON(ctx context.Context, ranking string) ([]byte, error) {
+	// Validate input
+	isValid := false
+	for _, validRanking := range validRankings {
+		if ranking == validRanking {
+			isValid = true
+			break
+		}
+	}
+	if !isValid {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid ranking")
+	}
+
+	// Get packages ranking from database
+	return util.DBQueryJSON(ctx, m.db, getPkgsRankingDBQ, ranking)
+}
+
 // GetRandomJSON returns a json object with some random packages. The json
 // object is built by the database.
 func (m *Manager) GetRandomJSON(ctx context.Context) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getRandomPkgsDBQ)
 }
 
+// GetSnapshotBundleData returns the information needed to assemble the
+// offline bundle of the package's snapshot identified by the package id and
+// version provided. When checkOwnership is true, only the owner of the
+// repository (or the members of the organization owning it) can get it.
+func (m *Manager) GetSnapshotBundleData(
+	ctx context.Context,
+	pkgID,
+	version string,
+	checkOwnership bool,
+) (*hub.SnapshotBundleData, error) {
+	var userID interface{}
+	if checkOwnership {
+		userID = ctx.Value(hub.UserIDKey).(string)
+	}
+
+	// Validate input
+	if pkgID == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id not provided")
+	}
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+
+	// Get snapshot bundle data from database
+	var data *hub.SnapshotBundleData
+	if err := util.DBQueryUnmarshal(ctx, m.db, &data, getSnapshotBundleDataDBQ, userID, pkgID, version); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetSnapshotContentWarningsJSON returns the warnings found by the content
+// static checks in the package's snapshot identified by the package id and
+// version provided.
+func (m *Manager) GetSnapshotContentWarningsJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	return util.DBQueryJSON(ctx, m.db, getSnapshotContentWarningsDBQ, pkgID, version)
+}
+
+// GetSnapshotImages returns the containers images used by the package's
+// snapshot identified by the package id and version provided. The registry,
+// repository, tag and digest of each image are obtained from its reference.
+func (m *Manager) GetSnapshotImages(ctx context.Context, pkgID, version string) ([]*hub.SnapshotImage, error) {
+	// Validate input
+	if pkgID == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id not provided")
+	}
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+
+	// Get snapshot images from database
+	var images []*hub.SnapshotImage
+	if err := util.DBQueryUnmarshal(ctx, m.db, &images, getSnapshotImagesDBQ, pkgID, version); err != nil {
+		return nil, err
+	}
+
+	// Add details from the images references
+	for _, image := range images {
+		ref, err := name.ParseReference(image.Image)
+		if err != nil {
+			continue
+		}
+		image.Registry = ref.Context().RegistryStr()
+		image.Repository = ref.Context().RepositoryStr()
+		switch r := ref.(type) {
+		case name.Tag:
+			image.Tag = r.TagStr()
+		case name.Digest:
+			image.Digest = r.DigestStr()
+		}
+	}
+
+	return images, nil
+}
+
+// GetSnapshotLicenseInventoryJSON returns the license inventory of the
+// package's snapshot identified by the package id and version provided. The
+// inventory includes the package license as well as the licenses of the
+// packages bundled in its containers images. When requested, only licenses
+// considered problematic will be included.
+func (m *Manager) GetSnapshotLicenseInventoryJSON(
+	ctx context.Context,
+	pkgID,
+	version string,
+	problematicOnly bool,
+) ([]byte, error) {
+	// Validate input
+	if pkgID == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id not provided")
+	}
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+
+	// Get snapshot license inventory from database
+	return util.DBQueryJSON(ctx, m.db, getSnapshotLicenseInventoryDBQ, pkgID, version, problematicOnly)
+}
+
+// GetSnapshotSBOMJSON returns the SBOMs, in the format provided, of the images
+// used by the package's snapshot identified by the package id and version
+// provided. SBOMs are returned as a json object keyed by image. When the
+// snapshot has no SBOMs, hub.ErrNotFound is returned.
+func (m *Manager) GetSnapshotSBOMJSON(ctx context.Context, pkgID, version, format string) ([]byte, error) {
+	// Validate input
+	if pkgID == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id not provided")
+	}
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+	if !isValidSBOMFormat(format) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid sbom format")
+	}
+
+	// Get snapshot SBOM from database
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getSnapshotSBOMDBQ, pkgID, version, format)
+	if err != nil {
+		return nil, err
+	}
+	if dataJSON == nil {
+		return nil, fmt.Errorf("sbom %w", hub.ErrNotFound)
+	}
+	return dataJSON, nil
+}
+
 // GetSnapshotSecurityReportJSON returns the security report of the package's
 // snapshot identified by the package id and version provided.
 func (m *Manager) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getSnapshotSecurityReportDBQ, pkgID, version)
 }
 
+// GetSnapshotSecurityReportSuppressedJSON returns the vulnerabilities that
+// have been suppressed from the security report of the package's snapshot
+// identified by the package id and version provided, organized by image.
+func (m *Manager) GetSnapshotSecurityReportSuppressedJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	return util.DBQueryJSON(ctx, m.db, getSnapshotSecurityReportSuppressedDBQ, pkgID, version)
+}
+
 // GetSnapshotsToScan returns the packages' snapshots that need to be scanned
 // for security vulnerabilities.
 func (m *Manager) GetSnapshotsToScan(ctx context.Context) ([]*hub.SnapshotToScan, error) {
@@ -203,6 +482,71 @@ func (m *Manager) GetSummaryJSON(ctx context.Context, input *hub.GetPackageInput
 	return util.DBQueryJSON(ctx, m.db, getPkgSummaryDBQ, inputJSON)
 }
 
+// GetUpgradeGraph returns the upgrade graph of the operator package identified
+// by the id provided. The entries of each channel are obtained by following the
+// replaces edges declared by the versions, starting from the channel's head.
+func (m *Manager) GetUpgradeGraph(ctx context.Context, pkgID string) (*hub.UpgradeGraph, error) {
+	// Validate input
+	if pkgID == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id not provided")
+	}
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+
+	// Get channels and upgrade edges from database
+	var data *struct {
+		DefaultChannel string                     `json:"default_channel"`
+		Channels       []*hub.Channel             `json:"channels"`
+		Versions       []*hub.UpgradeGraphVersion `json:"versions"`
+	}
+	if err := util.DBQueryUnmarshal(ctx, m.db, &data, getPkgUpgradeGraphDBQ, pkgID); err != nil {
+		return nil, err
+	}
+	if len(data.Channels) == 0 {
+		return nil, hub.ErrNotFound
+	}
+
+	// Build graph
+	versions := make(map[string]*hub.UpgradeGraphVersion, len(data.Versions))
+	for _, v := range data.Versions {
+		versions[v.Version] = v
+	}
+	g := &hub.UpgradeGraph{
+		DefaultChannel: data.DefaultChannel,
+		Channels:       make([]*hub.UpgradeGraphChannel, 0, len(data.Channels)),
+		Versions:       data.Versions,
+	}
+	for _, c := range data.Channels {
+		entries := make([]string, 0)
+		visited := make(map[string]bool)
+		for next := c.Version; next != "" && !visited[next]; {
+			v, ok := versions[next]
+			if !ok {
+				break
+			}
+			entries = append(entries, v.Version)
+			visited[next] = true
+			next = v.Replaces
+		}
+		g.Channels = append(g.Channels, &hub.UpgradeGraphChannel{
+			Name:    c.Name,
+			Head:    c.Version,
+			Entries: entries,
+		})
+	}
+	sort.Slice(g.Versions, func(i, j int) bool {
+		vi, _ := semver.NewVersion(g.Versions[i].Version)
+		vj, _ := semver.NewVersion(g.Versions[j].Version)
+		if vi == nil || vj == nil {
+			return g.Versions[i].Version > g.Versions[j].Version
+		}
+		return vj.LessThan(vi)
+	})
+
+	return g, nil
+}
+
 // GetValuesSchemaJSON returns the values schema of the package's snapshot
 // identified by the package id and version provided.
 func (m *Manager) GetValuesSchemaJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
@@ -226,6 +570,25 @@ func (m *Manager) GetViewsJSON(ctx context.Context, pkgID string) ([]byte, error
 	return util.DBQueryJSON(ctx, m.db, getPkgViewsDBQ, pkgID, start, end)
 }
 
+// GetVulnerabilityStatementsJSON returns the vulnerability statements of the
+// package's snapshot identified by the package id and version provided. The
+// json array is built by the database.
+func (m *Manager) GetVulnerabilityStatementsJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	// Validate input
+	if pkgID == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id not provided")
+	}
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+
+	// Get vulnerability statements from database
+	return util.DBQueryJSON(ctx, m.db, getVulnerabilityStatementsDBQ, pkgID, version)
+}
+
 // Register registers the package provided in the database.
 func (m *Manager) Register(ctx context.Context, pkg *hub.Package) error {
 	// Validate input
@@ -291,10 +654,69 @@ func (m *Manager) Register(ctx context.Context, pkg *hub.Package) error {
 	if err != nil {
 		return err
 	}
-	_, err = m.db.Exec(ctx, registerPkgDBQ, pkgJSON)
+	if _, err = m.db.Exec(ctx, registerPkgDBQ, pkgJSON); err != nil {
+		return err
+	}
+	m.invalidateCache(ctx, pkg)
+	return nil
+}
+
+// RegisterImageScan registers the scan results of a container image in the
+// database, so that they can be reused when scanning other snapshots that
+// include the same image.
+func (m *Manager) RegisterImageScan(ctx context.Context, s *hub.ImageScan) error {
+	// Validate input
+	if s == nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "image scan not provided")
+	}
+	if s.Digest == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "digest not provided")
+	}
+	if len(s.Report) == 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "report not provided")
+	}
+
+	// Register image scan in database
+	sJSON, _ := json.Marshal(s)
+	_, err := m.db.Exec(ctx, registerImageScanDBQ, sJSON)
 	return err
 }
 
+// RequestSnapshotScan requests a new security scan of the package's snapshot
+// identified by the package id and version provided. Scans can only be
+// requested once per hour for each snapshot.
+func (m *Manager) RequestSnapshotScan(ctx context.Context, pkgID, version string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if pkgID == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id not provided")
+	}
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+
+	// Request snapshot scan in database
+	var requested bool
+	err := m.db.QueryRow(ctx, requestSnapshotScanDBQ, userID, pkgID, version).Scan(&requested)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return hub.ErrInsufficientPrivilege
+		}
+		if reason, ok := util.IsDBSnapshotNotScannable(err); ok {
+			return fmt.Errorf("%w: %s", hub.ErrConflict, reason)
+		}
+		return err
+	}
+	if !requested {
+		return fmt.Errorf("%w: %s", hub.ErrTooManyRequests, "scan already requested recently")
+	}
+	return nil
+}
+
 // SearchJSON returns a json object with the search results produced by the
 // input provided. The json object is built by the database.
 func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchPackageInput) (*hub.JSONQueryResult, error) {
@@ -305,8 +727,12 @@ func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchPackageInput)
 	if input.Offset < 0 {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
 	}
-	if input.Sort != "" && input.Sort != "relevance" && input.Sort != "stars" {
-		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid sort (relevance|stars)")
+	if input.Sort != "" &&
+		input.Sort != "relevance" &&
+		input.Sort != "stars" &&
+		input.Sort != "downloads" &&
+		input.Sort != "last_updated" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid sort (relevance|stars|downloads|last_updated)")
 	}
 	for _, alias := range input.Users {
 		if alias == "" {
@@ -323,17 +749,44 @@ func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchPackageInput)
 			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository name")
 		}
 	}
+	for _, family := range input.LicenseFamilies {
+		if !isValidLicenseFamily(family) {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid license family")
+		}
+	}
+	if input.K8sVersion != "" {
+		minor, err := ParseK8sVersion(input.K8sVersion)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		}
+		input.K8sVersion = fmt.Sprintf("1.%d", minor)
+	}
 
-	// Search packages in database
+	// Try to get search results from cache
 	inputJSON, _ := json.Marshal(input)
-	return util.DBQueryJSONWithPagination(ctx, m.db, searchPkgsDBQ, inputJSON)
+	key := cache.Key("search", inputJSON)
+	if resultJSON, ok := cache.Load(ctx, m.cache, key); ok {
+		var result *hub.JSONQueryResult
+		if err := json.Unmarshal(resultJSON, &result); err == nil && result != nil {
+			return result, nil
+		}
+	}
+
+	// Search packages in database and store results in the cache
+	result, err := util.DBQueryJSONWithPagination(ctx, m.rdb, searchPkgsDBQ, inputJSON)
+	if err != nil {
+		return nil, err
+	}
+	resultJSON, _ := json.Marshal(result)
+	cache.Store(ctx, m.cache, key, resultJSON, cache.SearchTag)
+	return result, nil
 }
 
 // SearchMonocularJSON returns a json object with the search results produced
 // by the input provided that is compatible with the Monocular search API. The
 // json object is built by the database.
 func (m *Manager) SearchMonocularJSON(ctx context.Context, baseURL, tsQueryWeb string) ([]byte, error) {
-	return util.DBQueryJSON(ctx, m.db, searchPkgsMonocularDBQ, baseURL, tsQueryWeb)
+	return util.DBQueryJSON(ctx, m.rdb, searchPkgsMonocularDBQ, baseURL, tsQueryWeb)
 }
 
 // ToggleStar stars or unstars a given package for the provided user.
@@ -349,8 +802,11 @@ func (m *Manager) ToggleStar(ctx context.Context, packageID string) error {
 	}
 
 	// Toggle star in database
-	_, err := m.db.Exec(ctx, togglePkgStarDBQ, userID, packageID)
-	return err
+	if _, err := m.db.Exec(ctx, togglePkgStarDBQ, userID, packageID); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, m.cache, cache.PackageIDTag(packageID))
+	return nil
 }
 
 // UpdateSnapshotSecurityReport updates the security report for the snapshot
@@ -369,8 +825,56 @@ func (m *Manager) UpdateSnapshotSecurityReport(ctx context.Context, r *hub.Snaps
 
 	// Update snapshot security report in database
 	rJSON, _ := json.Marshal(r)
-	_, err := m.db.Exec(ctx, updateSnapshotSecurityReportDBQ, rJSON)
-	return err
+	if _, err := m.db.Exec(ctx, updateSnapshotSecurityReportDBQ, rJSON); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, m.cache, cache.PackageIDTag(r.PackageID))
+	return nil
+}
+
+// UpdateVulnerabilityStatements replaces the vulnerability statements of the
+// package's snapshot identified by the package id and version provided.
+func (m *Manager) UpdateVulnerabilityStatements(
+	ctx context.Context,
+	pkgID,
+	version string,
+	statements []*hub.VulnerabilityStatement,
+) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if pkgID == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id not provided")
+	}
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+	for _, s := range statements {
+		if s == nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid statement")
+		}
+		if s.VulnerabilityID == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "vulnerability id not provided")
+		}
+		if !isValidVulnerabilityStatementStatus(s.Status) {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid status")
+		}
+	}
+
+	// Update vulnerability statements in database
+	statementsJSON, _ := json.Marshal(statements)
+	_, err := m.db.Exec(ctx, updateVulnerabilityStatementsDBQ, userID, pkgID, version, statementsJSON)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return hub.ErrInsufficientPrivilege
+		}
+		return err
+	}
+	cache.Invalidate(ctx, m.cache, cache.PackageIDTag(pkgID))
+	return nil
 }
 
 // Unregister unregisters the package provided from the database.
@@ -390,8 +894,24 @@ func (m *Manager) Unregister(ctx context.Context, pkg *hub.Package) error {
 
 	// Unregister package from database
 	pkgJSON, _ := json.Marshal(pkg)
-	_, err := m.db.Exec(ctx, unregisterPkgDBQ, pkgJSON)
-	return err
+	if _, err := m.db.Exec(ctx, unregisterPkgDBQ, pkgJSON); err != nil {
+		return err
+	}
+	m.invalidateCache(ctx, pkg)
+	return nil
+}
+
+// invalidateCache invalidates the cache entries that may include data of the
+// package provided, which has just been registered or unregistered.
+func (m *Manager) invalidateCache(ctx context.Context, pkg *hub.Package) {
+	tags := []string{cache.SearchTag, cache.StatsTag}
+	if pkg.Repository != nil {
+		tags = append(tags, cache.PackageTag(pkg.Repository.Name, pkg.Name))
+	}
+	if pkg.PackageID != "" {
+		tags = append(tags, cache.PackageIDTag(pkg.PackageID))
+	}
+	cache.Invalidate(ctx, m.cache, tags...)
 }
 
 // BuildKey returns a key that identifies a concrete package version.
@@ -425,3 +945,56 @@ func areValidCapabilities(capabilities string) bool {
 	}
 	return false
 }
+
+// isValidLicenseFamily checks if the provided license family is valid.
+func isValidLicenseFamily(family string) bool {
+	for _, validFamily := range hub.LicenseFamilies {
+		if family == validFamily {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidSBOMFormat checks if the provided SBOM format is valid.
+func isValidSBOMFormat(format string) bool {
+	for _, validFormat := range hub.SBOMFormats {
+		if format == validFormat {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidVulnerabilityStatementStatus checks if the provided vulnerability
+// statement status is valid.
+func isValidVulnerabilityStatementStatus(status string) bool {
+	for _, validStatus := range hub.VulnerabilityStatementStatuses {
+		if status == validStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// pkgCacheTags returns the tags that should be associated with the cache entry
+// of the package json data provided.
+func pkgCacheTags(dataJSON []byte) []string {
+	var p struct {
+		PackageID  string `json:"package_id"`
+		Name       string `json:"name"`
+		Repository struct {
+			RepositoryID string `json:"repository_id"`
+			Name         string `json:"name"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(dataJSON, &p); err != nil {
+		return nil
+	}
+	return []string{
+		cache.PackageTag(p.Repository.Name, p.Name),
+		cache.PackageIDTag(p.PackageID),
+		cache.RepositoryTag(p.Repository.Name),
+		cache.RepositoryIDTag(p.Repository.RepositoryID),
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"time"
 
 	trivy "github.com/aquasecurity/trivy/pkg/types"
+	"github.com/artifacthub/hub/internal/cache"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -225,6 +228,7 @@ func TestGet(t *testing.T) {
 				Webhooks:      1,
 			},
 		}
+		expectedPackage.InstallSnippets = BuildInstallSnippets(expectedPackage)
 
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return([]byte(`
@@ -467,16 +471,589 @@ func TestGetChangelog(t *testing.T) {
 	})
 }
 
+func TestGetDownloadsJSON(t *testing.T) {
+	ctx := context.Background()
+	pkgID := "00000000-0000-0000-0000-000000000001"
+	end := time.Now().Format("2006-01-02")
+	start := time.Now().AddDate(0, -1, 0).Format("2006-01-02")
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			errMsg    string
+			packageID string
+		}{
+			{"package id not provided", ""},
+			{"invalid package id", "pkgID"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				m := NewManager(nil)
+				_, err := m.GetDownloadsJSON(ctx, tc.packageID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgDownloadsDBQ, pkgID, start, end).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		_, err := m.GetDownloadsJSON(ctx, pkgID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgDownloadsDBQ, pkgID, start, end).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetDownloadsJSON(ctx, pkgID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetCRDsSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getCRDsSchemasDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		crds, err := m.GetCRDsSchemas(ctx, "pkg1", "1.0.0")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, crds)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getCRDsSchemasDBQ, "pkg1", "1.0.0").Return([]byte(`
+		[{
+			"name": "crontabs.stable.example.com",
+			"group": "stable.example.com",
+			"kind": "CronTab",
+			"scope": "Namespaced",
+			"versions": [{"name": "v1", "served": true, "storage": true, "schema": {"type": "object"}}]
+		}]
+		`), nil)
+		m := NewManager(db)
+
+		crds, err := m.GetCRDsSchemas(ctx, "pkg1", "1.0.0")
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.CRDSchema{
+			{
+				Name:  "crontabs.stable.example.com",
+				Group: "stable.example.com",
+				Kind:  "CronTab",
+				Scope: "Namespaced",
+				Versions: []*hub.CRDSchemaVersion{
+					{Name: "v1", Served: true, Storage: true, Schema: json.RawMessage(`{"type": "object"}`)},
+				},
+			},
+		}, crds)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetHarborReplicationDumpJSON(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getHarborReplicationDumpDBQ).Return([]byte("dataJSON"), nil)
+		db.On("QueryRow", ctx, getHarborReplicationDumpDBQ).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetHarborReplicationDumpJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getHarborReplicationDumpDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetHarborReplicationDumpJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetHelmExporterDumpJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getHelmExporterDumpDBQ).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetHelmExporterDumpJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getHelmExporterDumpDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetHelmExporterDumpJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetImageScan(t *testing.T) {
+	ctx := context.Background()
+	digest := "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71"
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetImageScan(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "digest not provided")
+	})
+
+	t.Run("image scan not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageScanDBQ, digest).Return(nil, pgx.ErrNoRows)
+		m := NewManager(db)
+
+		s, err := m.GetImageScan(ctx, digest)
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, s)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageScanDBQ, digest).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		s, err := m.GetImageScan(ctx, digest)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, s)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("image scan returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImageScanDBQ, digest).Return([]byte(`
+		{
+			"digest": "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71",
+			"report": {"SchemaVersion": 2},
+			"sboms": {"cyclonedx": {"bomFormat": "CycloneDX"}},
+			"created_at": 1592299234
+		}
+		`), nil)
+		m := NewManager(db)
+
+		s, err := m.GetImageScan(ctx, digest)
+		require.NoError(t, err)
+		assert.Equal(t, digest, s.Digest)
+		assert.JSONEq(t, `{"SchemaVersion": 2}`, string(s.Report))
+		assert.JSONEq(t, `{"bomFormat": "CycloneDX"}`, string(s.SBOMs[hub.SBOMFormatCycloneDX]))
+		assert.Equal(t, int64(1592299234), s.CreatedAt)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.Background()
+	input := &hub.GetPackageInput{
+		RepositoryName: "repo1",
+		PackageName:    "pkg1",
+	}
+	inputJSON, _ := json.Marshal(input)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetJSON(ctx, &hub.GetPackageInput{})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("invalid locale", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetJSON(ctx, &hub.GetPackageInput{
+			RepositoryName: "repo1",
+			PackageName:    "pkg1",
+			Locale:         "chinese",
+		})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid locale")
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (locale normalized)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgDBQ, []byte(`{"package_id":"","repository_name":"repo1","package_name":"pkg1","version":"","locale":"zh-cn"}`)).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, &hub.GetPackageInput{
+			RepositoryName: "repo1",
+			PackageName:    "pkg1",
+			Locale:         "zh_CN",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, input)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("package blocked", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		dbErr := errors.New("ERROR: content blocked: copyright infringement (SQLSTATE P0001)")
+		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return(nil, dbErr)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, input)
+		assert.True(t, errors.Is(err, hub.ErrBlocked))
+		assert.Equal(t, "content blocked: copyright infringement", err.Error())
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database replica query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		rdb := &tests.DBMock{}
+		rdb.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, WithReplicaDB(rdb))
+
+		dataJSON, err := m.GetJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		rdb.AssertExpectations(t)
+	})
+
+	t.Run("package returned from cache", func(t *testing.T) {
+		t.Parallel()
+		c := &cache.Mock{}
+		c.On("Get", ctx, cache.Key("pkg", inputJSON)).Return([]byte("cachedJSON"), nil)
+		m := NewManager(nil, WithCache(c))
+
+		dataJSON, err := m.GetJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("cachedJSON"), dataJSON)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("package not in cache, stored after being read from database", func(t *testing.T) {
+		t.Parallel()
+		pkgJSON := []byte(`{"package_id": "pkgID", "name": "pkg1", "repository": {"repository_id": "repoID", "name": "repo1"}}`)
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return(pkgJSON, nil)
+		c := &cache.Mock{}
+		key := cache.Key("pkg", inputJSON)
+		c.On("Get", ctx, key).Return(nil, tests.ErrFake)
+		c.On("Set", ctx, key, pkgJSON, []string{
+			cache.PackageTag("repo1", "pkg1"),
+			cache.PackageIDTag("pkgID"),
+			cache.RepositoryTag("repo1"),
+			cache.RepositoryIDTag("repoID"),
+		}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		dataJSON, err := m.GetJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, pkgJSON, dataJSON)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
+}
+
+func TestGetProductionUsageJSON(t *testing.T) {
+	userID := "userID"
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+	repoName := "repo1"
+	pkgName := "pkg1"
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetProductionUsageJSON(context.Background(), repoName, pkgName)
+		})
+	})
+
+	t.Run("production usage data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getProductionUsageDBQ, userID, repoName, pkgName).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetProductionUsageJSON(ctx, repoName, pkgName)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getProductionUsageDBQ, userID, repoName, pkgName).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetProductionUsageJSON(ctx, repoName, pkgName)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetRandomJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRandomPkgsDBQ).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetRandomJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRandomPkgsDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetRandomJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetRankingJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetRankingJSON(ctx, "trending-1y")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid ranking")
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgsRankingDBQ, "trending-7d").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetRankingJSON(ctx, "trending-7d")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgsRankingDBQ, "new").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetRankingJSON(ctx, "new")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetSnapshotBundleData(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetSnapshotBundleData(context.Background(), pkgID, "1.0.0", true)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			version   string
+		}{
+			{"package id not provided", "", "1.0.0"},
+			{"invalid package id", "pkgID", "1.0.0"},
+			{"version not provided", pkgID, ""},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetSnapshotBundleData(ctx, tc.packageID, tc.version, true)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getSnapshotBundleDataDBQ, "userID", pkgID, "1.0.0").Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				data, err := m.GetSnapshotBundleData(ctx, pkgID, "1.0.0", true)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, data)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotBundleDataDBQ, "userID", pkgID, "1.0.0").Return([]byte(`
+		{
+			"name": "pkg1",
+			"version": "1.0.0",
+			"content_url": "https://repo1.com/pkg1-1.0.0.tgz",
+			"containers_images": [
+				{"name": "app", "image": "quay.io/org/img:1.0.0"}
+			],
+			"sboms": {
+				"quay.io/org/img:1.0.0": {"bomFormat": "CycloneDX"}
+			},
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"repository_kind_id": 0,
+			"private": true
+		}
+		`), nil)
+		m := NewManager(db)
+
+		data, err := m.GetSnapshotBundleData(ctx, pkgID, "1.0.0", true)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.SnapshotBundleData{
+			Name:       "pkg1",
+			Version:    "1.0.0",
+			ContentURL: "https://repo1.com/pkg1-1.0.0.tgz",
+			ContainersImages: []*hub.ContainerImage{
+				{Name: "app", Image: "quay.io/org/img:1.0.0"},
+			},
+			SBOMs: map[string]json.RawMessage{
+				"quay.io/org/img:1.0.0": json.RawMessage(`{"bomFormat": "CycloneDX"}`),
+			},
+			RepositoryID:   "00000000-0000-0000-0000-000000000001",
+			RepositoryKind: hub.Helm,
+			Private:        true,
+		}, data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("ownership not checked", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", context.Background(), getSnapshotBundleDataDBQ, nil, pkgID, "1.0.0").Return([]byte(`
+		{
+			"name": "pkg1",
+			"version": "1.0.0",
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"repository_kind_id": 0
+		}
+		`), nil)
+		m := NewManager(db)
+
+		data, err := m.GetSnapshotBundleData(context.Background(), pkgID, "1.0.0", false)
+		assert.NoError(t, err)
+		assert.Equal(t, &hub.SnapshotBundleData{
+			Name:         "pkg1",
+			Version:      "1.0.0",
+			RepositoryID: "00000000-0000-0000-0000-000000000001",
+		}, data)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetSnapshotContentWarningsJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotContentWarningsDBQ, "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetHarborReplicationDumpJSON(ctx)
+		dataJSON, err := m.GetSnapshotContentWarningsJSON(ctx, "pkg1", "1.0.0")
 		assert.NoError(t, err)
 		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
@@ -485,66 +1062,132 @@ func TestGetHarborReplicationDumpJSON(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getHarborReplicationDumpDBQ).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getSnapshotContentWarningsDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetHarborReplicationDumpJSON(ctx)
+		dataJSON, err := m.GetSnapshotContentWarningsJSON(ctx, "pkg1", "1.0.0")
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
 	})
 }
 
-func TestGetHelmExporterDumpJSON(t *testing.T) {
+func TestGetSnapshotImages(t *testing.T) {
 	ctx := context.Background()
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			version   string
+		}{
+			{"package id not provided", "", "1.0.0"},
+			{"invalid package id", "pkgID", "1.0.0"},
+			{"version not provided", pkgID, ""},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetSnapshotImages(ctx, tc.packageID, tc.version)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getHelmExporterDumpDBQ).Return([]byte("dataJSON"), nil)
+		db.On("QueryRow", ctx, getSnapshotImagesDBQ, pkgID, "1.0.0").Return([]byte(`
+		[
+			{
+				"image": "nginx:1.21",
+				"whitelisted": false
+			},
+			{
+				"image": "quay.io/org/img@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+				"name": "app",
+				"whitelisted": true,
+				"security_report_summary": {"critical": 1, "high": 2, "medium": 0, "low": 0, "unknown": 0}
+			}
+		]
+		`), nil)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetHelmExporterDumpJSON(ctx)
+		images, err := m.GetSnapshotImages(ctx, pkgID, "1.0.0")
 		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		assert.Equal(t, []*hub.SnapshotImage{
+			{
+				Image:      "nginx:1.21",
+				Registry:   "index.docker.io",
+				Repository: "library/nginx",
+				Tag:        "1.21",
+			},
+			{
+				Image:       "quay.io/org/img@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+				Name:        "app",
+				Whitelisted: true,
+				Registry:    "quay.io",
+				Repository:  "org/img",
+				Digest:      "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+				SecurityReportSummary: &hub.SecurityReportSummary{
+					Critical: 1,
+					High:     2,
+				},
+			},
+		}, images)
 		db.AssertExpectations(t)
 	})
 
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getHelmExporterDumpDBQ).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getSnapshotImagesDBQ, pkgID, "1.0.0").Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetHelmExporterDumpJSON(ctx)
+		images, err := m.GetSnapshotImages(ctx, pkgID, "1.0.0")
 		assert.Equal(t, tests.ErrFakeDB, err)
-		assert.Nil(t, dataJSON)
+		assert.Nil(t, images)
 		db.AssertExpectations(t)
 	})
 }
 
-func TestGetJSON(t *testing.T) {
+func TestGetSnapshotLicenseInventoryJSON(t *testing.T) {
 	ctx := context.Background()
-	input := &hub.GetPackageInput{
-		RepositoryName: "repo1",
-		PackageName:    "pkg1",
-	}
-	inputJSON, _ := json.Marshal(input)
+	pkgID := "00000000-0000-0000-0000-000000000001"
 
 	t.Run("invalid input", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(nil)
-		_, err := m.GetJSON(ctx, &hub.GetPackageInput{})
-		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			version   string
+		}{
+			{"package id not provided", "", "1.0.0"},
+			{"invalid package id", "pkgID", "1.0.0"},
+			{"version not provided", pkgID, ""},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetSnapshotLicenseInventoryJSON(ctx, tc.packageID, tc.version, false)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
 	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return([]byte("dataJSON"), nil)
+		db.On("QueryRow", ctx, getSnapshotLicenseInventoryDBQ, pkgID, "1.0.0", true).Return([]byte("dataJSON"), nil)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetJSON(ctx, input)
+		dataJSON, err := m.GetSnapshotLicenseInventoryJSON(ctx, pkgID, "1.0.0", true)
 		assert.NoError(t, err)
 		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
@@ -553,37 +1196,51 @@ func TestGetJSON(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getSnapshotLicenseInventoryDBQ, pkgID, "1.0.0", false).Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetJSON(ctx, input)
+		dataJSON, err := m.GetSnapshotLicenseInventoryJSON(ctx, pkgID, "1.0.0", false)
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
 	})
 }
 
-func TestGetProductionUsageJSON(t *testing.T) {
-	userID := "userID"
-	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
-	repoName := "repo1"
-	pkgName := "pkg1"
+func TestGetSnapshotSBOMJSON(t *testing.T) {
+	ctx := context.Background()
+	pkgID := "00000000-0000-0000-0000-000000000001"
 
-	t.Run("user id not found in ctx", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(nil)
-		assert.Panics(t, func() {
-			_, _ = m.GetProductionUsageJSON(context.Background(), repoName, pkgName)
-		})
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			version   string
+			format    string
+		}{
+			{"package id not provided", "", "1.0.0", hub.SBOMFormatCycloneDX},
+			{"invalid package id", "pkgID", "1.0.0", hub.SBOMFormatCycloneDX},
+			{"version not provided", pkgID, "", hub.SBOMFormatCycloneDX},
+			{"invalid sbom format", pkgID, "1.0.0", "invalid"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetSnapshotSBOMJSON(ctx, tc.packageID, tc.version, tc.format)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
 	})
 
-	t.Run("production usage data returned successfully", func(t *testing.T) {
+	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getProductionUsageDBQ, userID, repoName, pkgName).Return([]byte("dataJSON"), nil)
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, pkgID, "1.0.0", hub.SBOMFormatSPDX).Return([]byte("dataJSON"), nil)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetProductionUsageJSON(ctx, repoName, pkgName)
+		dataJSON, err := m.GetSnapshotSBOMJSON(ctx, pkgID, "1.0.0", hub.SBOMFormatSPDX)
 		assert.NoError(t, err)
 		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
@@ -592,26 +1249,38 @@ func TestGetProductionUsageJSON(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getProductionUsageDBQ, userID, repoName, pkgName).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, pkgID, "1.0.0", hub.SBOMFormatSPDX).Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetProductionUsageJSON(ctx, repoName, pkgName)
+		dataJSON, err := m.GetSnapshotSBOMJSON(ctx, pkgID, "1.0.0", hub.SBOMFormatSPDX)
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("snapshot has no sboms", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSnapshotSBOMDBQ, pkgID, "1.0.0", hub.SBOMFormatSPDX).Return(nil, nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetSnapshotSBOMJSON(ctx, pkgID, "1.0.0", hub.SBOMFormatSPDX)
+		assert.True(t, errors.Is(err, hub.ErrNotFound))
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
 }
 
-func TestGetRandomJSON(t *testing.T) {
+func TestGetSnapshotSecurityReportJSON(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getRandomPkgsDBQ).Return([]byte("dataJSON"), nil)
+		db.On("QueryRow", ctx, getSnapshotSecurityReportDBQ, "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetRandomJSON(ctx)
+		dataJSON, err := m.GetSnapshotSecurityReportJSON(ctx, "pkg1", "1.0.0")
 		assert.NoError(t, err)
 		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
@@ -620,26 +1289,26 @@ func TestGetRandomJSON(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getRandomPkgsDBQ).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getSnapshotSecurityReportDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetRandomJSON(ctx)
+		dataJSON, err := m.GetSnapshotSecurityReportJSON(ctx, "pkg1", "1.0.0")
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
 	})
 }
 
-func TestGetSnapshotSecurityReportJSON(t *testing.T) {
+func TestGetSnapshotSecurityReportSuppressedJSON(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getSnapshotSecurityReportDBQ, "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
+		db.On("QueryRow", ctx, getSnapshotSecurityReportSuppressedDBQ, "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetSnapshotSecurityReportJSON(ctx, "pkg1", "1.0.0")
+		dataJSON, err := m.GetSnapshotSecurityReportSuppressedJSON(ctx, "pkg1", "1.0.0")
 		assert.NoError(t, err)
 		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
@@ -648,10 +1317,10 @@ func TestGetSnapshotSecurityReportJSON(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getSnapshotSecurityReportDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getSnapshotSecurityReportSuppressedDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetSnapshotSecurityReportJSON(ctx, "pkg1", "1.0.0")
+		dataJSON, err := m.GetSnapshotSecurityReportSuppressedJSON(ctx, "pkg1", "1.0.0")
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
@@ -859,6 +1528,93 @@ func TestGetSummaryJSON(t *testing.T) {
 	})
 }
 
+func TestGetUpgradeGraph(t *testing.T) {
+	ctx := context.Background()
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			errMsg    string
+			packageID string
+		}{
+			{"package id not provided", ""},
+			{"invalid package id", "pkgID"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				m := NewManager(nil)
+				_, err := m.GetUpgradeGraph(ctx, tc.packageID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgUpgradeGraphDBQ, pkgID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		g, err := m.GetUpgradeGraph(ctx, pkgID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, g)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("package without channels", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgUpgradeGraphDBQ, pkgID).Return([]byte(`{"channels": [], "versions": []}`), nil)
+		m := NewManager(db)
+
+		g, err := m.GetUpgradeGraph(ctx, pkgID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, g)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("upgrade graph built successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgUpgradeGraphDBQ, pkgID).Return([]byte(`
+		{
+			"default_channel": "stable",
+			"channels": [
+				{"name": "stable", "version": "1.0.0"},
+				{"name": "alpha", "version": "1.1.0"}
+			],
+			"versions": [
+				{"version": "0.9.0"},
+				{"version": "1.0.0", "replaces": "0.9.0", "skips": ["0.9.1"], "skip_range": ">=0.8.0 <1.0.0"},
+				{"version": "1.1.0", "replaces": "1.0.1"},
+				{"version": "1.0.1", "replaces": "1.0.0"}
+			]
+		}
+		`), nil)
+		m := NewManager(db)
+
+		g, err := m.GetUpgradeGraph(ctx, pkgID)
+		require.NoError(t, err)
+		assert.Equal(t, &hub.UpgradeGraph{
+			DefaultChannel: "stable",
+			Channels: []*hub.UpgradeGraphChannel{
+				{Name: "stable", Head: "1.0.0", Entries: []string{"1.0.0", "0.9.0"}},
+				{Name: "alpha", Head: "1.1.0", Entries: []string{"1.1.0", "1.0.1", "1.0.0", "0.9.0"}},
+			},
+			Versions: []*hub.UpgradeGraphVersion{
+				{Version: "1.1.0", Replaces: "1.0.1"},
+				{Version: "1.0.1", Replaces: "1.0.0"},
+				{Version: "1.0.0", Replaces: "0.9.0", Skips: []string{"0.9.1"}, SkipRange: ">=0.8.0 <1.0.0"},
+				{Version: "0.9.0"},
+			},
+		}, g)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetValuesSchemaJSON(t *testing.T) {
 	ctx := context.Background()
 
@@ -887,52 +1643,103 @@ func TestGetValuesSchemaJSON(t *testing.T) {
 	})
 }
 
-func TestGetViewsJSON(t *testing.T) {
+func TestGetViewsJSON(t *testing.T) {
+	ctx := context.Background()
+	pkgID := "00000000-0000-0000-0000-000000000001"
+	end := time.Now().Format("2006-01-02")
+	start := time.Now().AddDate(0, -1, 0).Format("2006-01-02")
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			errMsg    string
+			packageID string
+		}{
+			{"package id not provided", ""},
+			{"invalid package id", "pkgID"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				m := NewManager(nil)
+				_, err := m.GetViewsJSON(ctx, tc.packageID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgViewsDBQ, pkgID, start, end).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		_, err := m.GetViewsJSON(ctx, pkgID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgViewsDBQ, pkgID, start, end).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetViewsJSON(ctx, pkgID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetVulnerabilityStatementsJSON(t *testing.T) {
 	ctx := context.Background()
 	pkgID := "00000000-0000-0000-0000-000000000001"
-	end := time.Now().Format("2006-01-02")
-	start := time.Now().AddDate(0, -1, 0).Format("2006-01-02")
 
 	t.Run("invalid input", func(t *testing.T) {
-		t.Parallel()
 		testCases := []struct {
 			errMsg    string
 			packageID string
+			version   string
 		}{
-			{"package id not provided", ""},
-			{"invalid package id", "pkgID"},
+			{"package id not provided", "", "1.0.0"},
+			{"invalid package id", "pkgID", "1.0.0"},
+			{"version not provided", pkgID, ""},
 		}
 		for _, tc := range testCases {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
 				m := NewManager(nil)
-				_, err := m.GetViewsJSON(ctx, tc.packageID)
+				_, err := m.GetVulnerabilityStatementsJSON(ctx, tc.packageID, tc.version)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
 		}
 	})
 
-	t.Run("database error", func(t *testing.T) {
+	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getPkgViewsDBQ, pkgID, start, end).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getVulnerabilityStatementsDBQ, pkgID, "1.0.0").Return([]byte("dataJSON"), nil)
 		m := NewManager(db)
 
-		_, err := m.GetViewsJSON(ctx, pkgID)
-		assert.Equal(t, tests.ErrFakeDB, err)
+		dataJSON, err := m.GetVulnerabilityStatementsJSON(ctx, pkgID, "1.0.0")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
 	})
 
-	t.Run("database query succeeded", func(t *testing.T) {
+	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getPkgViewsDBQ, pkgID, start, end).Return([]byte("dataJSON"), nil)
+		db.On("QueryRow", ctx, getVulnerabilityStatementsDBQ, pkgID, "1.0.0").Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetViewsJSON(ctx, pkgID)
-		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		dataJSON, err := m.GetVulnerabilityStatementsJSON(ctx, pkgID, "1.0.0")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
 	})
 }
@@ -1130,6 +1937,188 @@ func TestRegister(t *testing.T) {
 		assert.Equal(t, tests.ErrFakeDB, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("successful package registration, cache entries invalidated", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerPkgDBQ, mock.Anything).Return(nil)
+		c := &cache.Mock{}
+		c.On("Invalidate", ctx, []string{
+			cache.SearchTag,
+			cache.StatsTag,
+			cache.PackageTag("", "package1"),
+		}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		err := m.Register(ctx, newTestPkg())
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
+}
+
+func TestRegisterImageScan(t *testing.T) {
+	ctx := context.Background()
+	s := &hub.ImageScan{
+		Digest: "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71",
+		Report: []byte(`{"SchemaVersion":2}`),
+	}
+	sJSON, _ := json.Marshal(s)
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			s      *hub.ImageScan
+		}{
+			{
+				"image scan not provided",
+				nil,
+			},
+			{
+				"digest not provided",
+				&hub.ImageScan{
+					Report: []byte(`{}`),
+				},
+			},
+			{
+				"report not provided",
+				&hub.ImageScan{
+					Digest: "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.RegisterImageScan(ctx, tc.s)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerImageScanDBQ, sJSON).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.RegisterImageScan(ctx, s)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("successful image scan registration", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerImageScanDBQ, sJSON).Return(nil)
+		m := NewManager(db)
+
+		err := m.RegisterImageScan(ctx, s)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRequestSnapshotScan(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.RequestSnapshotScan(context.Background(), pkgID, "1.0.0")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			version   string
+		}{
+			{"package id not provided", "", "1.0.0"},
+			{"invalid package id", "pkgID", "1.0.0"},
+			{"version not provided", pkgID, ""},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.RequestSnapshotScan(ctx, tc.packageID, tc.version)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, requestSnapshotScanDBQ, "userID", pkgID, "1.0.0").Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				err := m.RequestSnapshotScan(ctx, pkgID, "1.0.0")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("scan requested recently", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, requestSnapshotScanDBQ, "userID", pkgID, "1.0.0").Return(false, nil)
+		m := NewManager(db)
+
+		err := m.RequestSnapshotScan(ctx, pkgID, "1.0.0")
+		assert.True(t, errors.Is(err, hub.ErrTooManyRequests))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("snapshot excluded from scanning", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		dbErr := errors.New("ERROR: snapshot not scannable: no containers images to scan (SQLSTATE P0001)")
+		db.On("QueryRow", ctx, requestSnapshotScanDBQ, "userID", pkgID, "1.0.0").Return(nil, dbErr)
+		m := NewManager(db)
+
+		err := m.RequestSnapshotScan(ctx, pkgID, "1.0.0")
+		assert.True(t, errors.Is(err, hub.ErrConflict))
+		assert.Equal(t, "conflict: no containers images to scan", err.Error())
+		db.AssertExpectations(t)
+	})
+
+	t.Run("scan requested successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, requestSnapshotScanDBQ, "userID", pkgID, "1.0.0").Return(true, nil)
+		m := NewManager(db)
+
+		err := m.RequestSnapshotScan(ctx, pkgID, "1.0.0")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
 }
 
 func TestSearchJSON(t *testing.T) {
@@ -1171,7 +2160,7 @@ func TestSearchJSON(t *testing.T) {
 				},
 			},
 			{
-				"invalid sort (relevance|stars)",
+				"invalid sort (relevance|stars|downloads|last_updated)",
 				&hub.SearchPackageInput{
 					Limit: 10,
 					Sort:  "invalid",
@@ -1198,6 +2187,20 @@ func TestSearchJSON(t *testing.T) {
 					Repositories: []string{""},
 				},
 			},
+			{
+				"invalid license family",
+				&hub.SearchPackageInput{
+					Limit:           10,
+					LicenseFamilies: []string{"invalid"},
+				},
+			},
+			{
+				"invalid kubernetes version",
+				&hub.SearchPackageInput{
+					Limit:      10,
+					K8sVersion: "1.29.1",
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -1236,6 +2239,56 @@ func TestSearchJSON(t *testing.T) {
 		assert.Nil(t, result)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("database replica query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		rdb := &tests.DBMock{}
+		rdb.On("QueryRow", ctx, searchPkgsDBQ, mock.Anything).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(db, WithReplicaDB(rdb))
+
+		result, err := m.SearchJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+		rdb.AssertExpectations(t)
+	})
+
+	t.Run("search results returned from cache", func(t *testing.T) {
+		t.Parallel()
+		inputJSON, _ := json.Marshal(input)
+		cachedJSON, _ := json.Marshal(&hub.JSONQueryResult{Data: []byte("dataJSON"), TotalCount: 1})
+		c := &cache.Mock{}
+		c.On("Get", ctx, cache.Key("search", inputJSON)).Return(cachedJSON, nil)
+		m := NewManager(nil, WithCache(c))
+
+		result, err := m.SearchJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("search results not in cache, stored after being read from database", func(t *testing.T) {
+		t.Parallel()
+		inputJSON, _ := json.Marshal(input)
+		resultJSON, _ := json.Marshal(&hub.JSONQueryResult{Data: []byte("dataJSON"), TotalCount: 1})
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchPkgsDBQ, mock.Anything).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		c := &cache.Mock{}
+		key := cache.Key("search", inputJSON)
+		c.On("Get", ctx, key).Return(nil, hub.ErrNotFound)
+		c.On("Set", ctx, key, resultJSON, []string{cache.SearchTag}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		result, err := m.SearchJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
 }
 
 func TestSearchMonocularJSON(t *testing.T) {
@@ -1402,6 +2455,118 @@ func TestUpdateSnapshotSecurityReport(t *testing.T) {
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("database update succeeded, cache entries invalidated", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateSnapshotSecurityReportDBQ, rJSON).Return(nil)
+		c := &cache.Mock{}
+		c.On("Invalidate", ctx, []string{cache.PackageIDTag(r.PackageID)}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		err := m.UpdateSnapshotSecurityReport(ctx, r)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
+}
+
+func TestUpdateVulnerabilityStatements(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	pkgID := "00000000-0000-0000-0000-000000000001"
+	statements := []*hub.VulnerabilityStatement{
+		{
+			VulnerabilityID: "CVE-2022-0001",
+			Status:          hub.VulnerabilityStatementNotAffected,
+			Justification:   "Vulnerable code not present",
+		},
+	}
+	statementsJSON, _ := json.Marshal(statements)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.UpdateVulnerabilityStatements(context.Background(), pkgID, "1.0.0", statements)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg     string
+			packageID  string
+			version    string
+			statements []*hub.VulnerabilityStatement
+		}{
+			{"package id not provided", "", "1.0.0", statements},
+			{"invalid package id", "pkgID", "1.0.0", statements},
+			{"version not provided", pkgID, "", statements},
+			{"invalid statement", pkgID, "1.0.0", []*hub.VulnerabilityStatement{nil}},
+			{
+				"vulnerability id not provided",
+				pkgID,
+				"1.0.0",
+				[]*hub.VulnerabilityStatement{{Status: hub.VulnerabilityStatementAcknowledged}},
+			},
+			{
+				"invalid status",
+				pkgID,
+				"1.0.0",
+				[]*hub.VulnerabilityStatement{{VulnerabilityID: "CVE-2022-0001", Status: "invalid"}},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.UpdateVulnerabilityStatements(ctx, tc.packageID, tc.version, tc.statements)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, updateVulnerabilityStatementsDBQ, "userID", pkgID, "1.0.0", statementsJSON).
+					Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.UpdateVulnerabilityStatements(ctx, pkgID, "1.0.0", statements)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database update succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateVulnerabilityStatementsDBQ, "userID", pkgID, "1.0.0", statementsJSON).Return(nil)
+		m := NewManager(db)
+
+		err := m.UpdateVulnerabilityStatements(ctx, pkgID, "1.0.0", statements)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
 }
 
 func TestUnregister(t *testing.T) {
@@ -1472,4 +2637,22 @@ func TestUnregister(t *testing.T) {
 		assert.Equal(t, tests.ErrFakeDB, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("successful package unregistration, cache entries invalidated", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unregisterPkgDBQ, mock.Anything).Return(nil)
+		c := &cache.Mock{}
+		c.On("Invalidate", ctx, []string{
+			cache.SearchTag,
+			cache.StatsTag,
+			cache.PackageTag("", "package1"),
+		}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		err := m.Unregister(ctx, p)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"strings"
 	"time"
 
@@ -27,6 +28,14 @@ var (
 		"fixed",
 		"security",
 	}
+
+	// validSupportStatuses is the list of valid support statuses that a pkg
+	// version can declare.
+	validSupportStatuses = []string{
+		"supported",
+		"maintenance",
+		"eol",
+	}
 )
 
 // GetPackageMetadata reads, parses and validates the package metadata file provided.
@@ -80,11 +89,14 @@ func PreparePackageFromMetadata(md *hub.PackageMetadata) (*hub.Package, error) {
 		AppVersion:              md.AppVersion,
 		Digest:                  md.Digest,
 		Deprecated:              md.Deprecated,
+		ReplacedBy:              md.ReplacedBy,
 		License:                 md.License,
 		ContainersImages:        md.ContainersImages,
 		Maintainers:             md.Maintainers,
 		Recommendations:         md.Recommendations,
 		Screenshots:             md.Screenshots,
+		Videos:                  md.Videos,
+		Support:                 md.Support,
 	}
 	if p.Data == nil && len(md.Annotations) > 0 {
 		p.Data = make(map[string]interface{})
@@ -95,6 +107,16 @@ func PreparePackageFromMetadata(md *hub.PackageMetadata) (*hub.Package, error) {
 	if md.Provider != nil {
 		p.Provider = md.Provider.Name
 	}
+	k8sCompatibility, err := GetK8sCompatibility(md.KubeVersion, md.K8sVersions)
+	if err != nil {
+		return nil, err
+	}
+	p.K8sCompatibility = k8sCompatibility
+	translations, err := NormalizeTranslations(md.Translations)
+	if err != nil {
+		return nil, err
+	}
+	p.Translations = translations
 	ts, _ := time.Parse(time.RFC3339, md.CreatedAt)
 	p.TS = ts.Unix()
 	return p, nil
@@ -123,6 +145,9 @@ func ValidatePackageMetadata(md *hub.PackageMetadata) error {
 	if md.Description == "" {
 		errs = multierror.Append(errs, fmt.Errorf("%w: %s", ErrInvalidMetadata, "description not provided"))
 	}
+	if err := ValidateReplacedBy(md.Deprecated, md.ReplacedBy); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("%w: %v", ErrInvalidMetadata, err))
+	}
 	for _, maintainer := range md.Maintainers {
 		if maintainer.Email == "" {
 			errs = multierror.Append(errs, fmt.Errorf("%w: %s", ErrInvalidMetadata, "maintainer email not provided"))
@@ -136,6 +161,21 @@ func ValidatePackageMetadata(md *hub.PackageMetadata) error {
 	if err := ValidateContainersImages(md.ContainersImages); err != nil {
 		errs = multierror.Append(errs, fmt.Errorf("%w: %v", ErrInvalidMetadata, err))
 	}
+	if err := ValidateScreenshots(md.Screenshots); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("%w: %v", ErrInvalidMetadata, err))
+	}
+	if err := ValidateVideos(md.Videos); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("%w: %v", ErrInvalidMetadata, err))
+	}
+	if err := ValidateSupport(md.Support); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("%w: %v", ErrInvalidMetadata, err))
+	}
+	if _, err := GetK8sCompatibility(md.KubeVersion, md.K8sVersions); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("%w: %v", ErrInvalidMetadata, err))
+	}
+	if _, err := NormalizeTranslations(md.Translations); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("%w: %v", ErrInvalidMetadata, err))
+	}
 
 	return errs.ErrorOrNil()
 }
@@ -180,6 +220,22 @@ func isValidChangeKind(kind string) bool {
 	return false
 }
 
+// ValidateReplacedBy checks if the replacement package url provided is valid.
+// A replacement can only be declared for deprecated packages.
+func ValidateReplacedBy(deprecated bool, replacedBy string) error {
+	if replacedBy == "" {
+		return nil
+	}
+	if !deprecated {
+		return errors.New("replacedBy can only be provided for deprecated packages")
+	}
+	u, err := url.Parse(replacedBy)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return errors.New("invalid replacedBy url")
+	}
+	return nil
+}
+
 // ValidateContainersImages checks if the provided containers images are valid.
 func ValidateContainersImages(images []*hub.ContainerImage) error {
 	var errs *multierror.Error
@@ -193,3 +249,65 @@ func ValidateContainersImages(images []*hub.ContainerImage) error {
 
 	return errs.ErrorOrNil()
 }
+
+// ValidateScreenshots checks if the provided screenshots are valid.
+func ValidateScreenshots(screenshots []*hub.Screenshot) error {
+	var errs *multierror.Error
+
+	for _, screenshot := range screenshots {
+		if screenshot == nil || !isValidMediaURL(screenshot.URL) {
+			errs = multierror.Append(errs, errors.New("invalid screenshot: invalid url"))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// ValidateVideos checks if the provided videos are valid.
+func ValidateVideos(videos []*hub.Video) error {
+	var errs *multierror.Error
+
+	for _, video := range videos {
+		if video == nil || !isValidMediaURL(video.URL) {
+			errs = multierror.Append(errs, errors.New("invalid video: invalid url"))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// ValidateSupport checks if the provided version support information is
+// valid. The end of life date must use the YYYY-MM-DD format.
+func ValidateSupport(support *hub.VersionSupport) error {
+	if support == nil {
+		return nil
+	}
+	var errs *multierror.Error
+
+	var validStatus bool
+	for _, status := range validSupportStatuses {
+		if support.Status == status {
+			validStatus = true
+			break
+		}
+	}
+	if !validStatus {
+		errs = multierror.Append(errs, fmt.Errorf("invalid support: invalid status: %s", support.Status))
+	}
+	if support.EOLDate != "" {
+		if _, err := time.Parse("2006-01-02", support.EOLDate); err != nil {
+			errs = multierror.Append(errs, errors.New("invalid support: invalid eolDate (YYYY-MM-DD expected)"))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// isValidMediaURL checks if the provided screenshot or video url is valid.
+func isValidMediaURL(mediaURL string) bool {
+	u, err := url.Parse(mediaURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
@@ -115,6 +115,23 @@ func TestPreparePackageFromMetadata(t *testing.T) {
 						URL:   "https://artifacthub.io/screenshot1.jpg",
 					},
 				},
+				Videos: []*hub.Video{
+					{
+						Title: "Video 1",
+						URL:   "https://artifacthub.io/video1.mp4",
+					},
+				},
+				Support: &hub.VersionSupport{
+					Status:  "supported",
+					EOLDate: "2030-01-31",
+				},
+				KubeVersion: ">=1.20.0-0",
+				Translations: hub.PackageTranslations{
+					"zh_CN": {
+						Description: "Package description (zh)",
+					},
+					"es": {},
+				},
 				Annotations: map[string]string{
 					"key": "value",
 				},
@@ -177,6 +194,24 @@ func TestPreparePackageFromMetadata(t *testing.T) {
 						URL:   "https://artifacthub.io/screenshot1.jpg",
 					},
 				},
+				Videos: []*hub.Video{
+					{
+						Title: "Video 1",
+						URL:   "https://artifacthub.io/video1.mp4",
+					},
+				},
+				Support: &hub.VersionSupport{
+					Status:  "supported",
+					EOLDate: "2030-01-31",
+				},
+				K8sCompatibility: []*hub.K8sVersionsRange{
+					{Min: "1.20"},
+				},
+				Translations: hub.PackageTranslations{
+					"zh-cn": {
+						Description: "Package description (zh)",
+					},
+				},
 				Data: map[string]interface{}{
 					"key": "value",
 				},
@@ -414,6 +449,105 @@ func TestValidatePackageMetadata(t *testing.T) {
 					"invalid container image: could not parse reference",
 				},
 			},
+			{
+				&hub.PackageMetadata{
+					Version:     "1.0.0",
+					Name:        "pkg1",
+					DisplayName: "Package 1",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+					Description: "description",
+					ReplacedBy:  "https://artifacthub.io/packages/helm/repo1/pkg2",
+				},
+				[]string{
+					"replacedBy can only be provided for deprecated packages",
+				},
+			},
+			{
+				&hub.PackageMetadata{
+					Version:     "1.0.0",
+					Name:        "pkg1",
+					DisplayName: "Package 1",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+					Description: "description",
+					Deprecated:  true,
+					ReplacedBy:  "pkg2",
+				},
+				[]string{
+					"invalid replacedBy url",
+				},
+			},
+			{
+				&hub.PackageMetadata{
+					Version:     "1.0.0",
+					Name:        "pkg1",
+					DisplayName: "Package 1",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+					Description: "description",
+					Screenshots: []*hub.Screenshot{
+						{
+							Title: "Screenshot 1",
+							URL:   "screenshot1.jpg",
+						},
+					},
+					Videos: []*hub.Video{
+						{
+							Title: "Video 1",
+							URL:   "javascript:alert(1)",
+						},
+					},
+				},
+				[]string{
+					"invalid screenshot: invalid url",
+					"invalid video: invalid url",
+				},
+			},
+			{
+				&hub.PackageMetadata{
+					Version:     "1.0.0",
+					Name:        "pkg1",
+					DisplayName: "Package 1",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+					Description: "description",
+					Support: &hub.VersionSupport{
+						Status:  "unknown",
+						EOLDate: "01/02/2006",
+					},
+				},
+				[]string{
+					"invalid support: invalid status: unknown",
+					"invalid support: invalid eolDate (YYYY-MM-DD expected)",
+				},
+			},
+			{
+				&hub.PackageMetadata{
+					Version:     "1.0.0",
+					Name:        "pkg1",
+					DisplayName: "Package 1",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+					Description: "description",
+					K8sVersions: []string{"1.29", "2.0"},
+				},
+				[]string{
+					"invalid kubernetes version: 2.0 (1.MINOR expected)",
+				},
+			},
+			{
+				&hub.PackageMetadata{
+					Version:     "1.0.0",
+					Name:        "pkg1",
+					DisplayName: "Package 1",
+					CreatedAt:   "2006-01-02T15:04:05Z",
+					Description: "description",
+					Translations: hub.PackageTranslations{
+						"chinese": {
+							Description: "description (zh)",
+						},
+					},
+				},
+				[]string{
+					"invalid translation: invalid locale: chinese",
+				},
+			},
 		}
 		for i, tc := range testCases {
 			tc := tc
@@ -448,6 +582,10 @@ func TestValidatePackageMetadata(t *testing.T) {
 					},
 				},
 			},
+			Support: &hub.VersionSupport{
+				Status:  "maintenance",
+				EOLDate: "2030-01-31",
+			},
 		}
 		err := ValidatePackageMetadata(md)
 		assert.Nil(t, err)
@@ -38,6 +38,20 @@ func (m *ManagerMock) GetChangelog(ctx context.Context, pkgID string) (*hub.Chan
 	return data, args.Error(1)
 }
 
+// GetCRDsSchemas implements the PackageManager interface.
+func (m *ManagerMock) GetCRDsSchemas(ctx context.Context, pkgID, version string) ([]*hub.CRDSchema, error) {
+	args := m.Called(ctx, pkgID, version)
+	data, _ := args.Get(0).([]*hub.CRDSchema)
+	return data, args.Error(1)
+}
+
+// GetDownloadsJSON implements the PackageManager interface.
+func (m *ManagerMock) GetDownloadsJSON(ctx context.Context, packageID string) ([]byte, error) {
+	args := m.Called(ctx, packageID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetHarborReplicationDumpJSON implements the PackageManager interface.
 func (m *ManagerMock) GetHarborReplicationDumpJSON(ctx context.Context) ([]byte, error) {
 	args := m.Called(ctx)
@@ -52,6 +66,13 @@ func (m *ManagerMock) GetHelmExporterDumpJSON(ctx context.Context) ([]byte, erro
 	return data, args.Error(1)
 }
 
+// GetImageScan implements the PackageManager interface.
+func (m *ManagerMock) GetImageScan(ctx context.Context, digest string) (*hub.ImageScan, error) {
+	args := m.Called(ctx, digest)
+	data, _ := args.Get(0).(*hub.ImageScan)
+	return data, args.Error(1)
+}
+
 // GetJSON implements the PackageManager interface.
 func (m *ManagerMock) GetJSON(ctx context.Context, input *hub.GetPackageInput) ([]byte, error) {
 	args := m.Called(ctx, input)
@@ -73,6 +94,58 @@ func (m *ManagerMock) GetRandomJSON(ctx context.Context) ([]byte, error) {
 	return data, args.Error(1)
 }
 
+// GetRankingJSON implements the PackageManager interface.
+func (m *ManagerMock) GetRankingJSON(ctx context.Context, ranking string) ([]byte, error) {
+	args := m.Called(ctx, ranking)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetSnapshotBundleData implements the PackageManager interface.
+func (m *ManagerMock) GetSnapshotBundleData(
+	ctx context.Context,
+	pkgID,
+	version string,
+	checkOwnership bool,
+) (*hub.SnapshotBundleData, error) {
+	args := m.Called(ctx, pkgID, version, checkOwnership)
+	data, _ := args.Get(0).(*hub.SnapshotBundleData)
+	return data, args.Error(1)
+}
+
+// GetSnapshotContentWarningsJSON implements the PackageManager interface.
+func (m *ManagerMock) GetSnapshotContentWarningsJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	args := m.Called(ctx, pkgID, version)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetSnapshotImages implements the PackageManager interface.
+func (m *ManagerMock) GetSnapshotImages(ctx context.Context, pkgID, version string) ([]*hub.SnapshotImage, error) {
+	args := m.Called(ctx, pkgID, version)
+	data, _ := args.Get(0).([]*hub.SnapshotImage)
+	return data, args.Error(1)
+}
+
+// GetSnapshotLicenseInventoryJSON implements the PackageManager interface.
+func (m *ManagerMock) GetSnapshotLicenseInventoryJSON(
+	ctx context.Context,
+	pkgID,
+	version string,
+	problematicOnly bool,
+) ([]byte, error) {
+	args := m.Called(ctx, pkgID, version, problematicOnly)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetSnapshotSBOMJSON implements the PackageManager interface.
+func (m *ManagerMock) GetSnapshotSBOMJSON(ctx context.Context, pkgID, version, format string) ([]byte, error) {
+	args := m.Called(ctx, pkgID, version, format)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetSnapshotSecurityReportJSON implements the PackageManager interface.
 func (m *ManagerMock) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	args := m.Called(ctx, pkgID, version)
@@ -80,6 +153,14 @@ func (m *ManagerMock) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID,
 	return data, args.Error(1)
 }
 
+// GetSnapshotSecurityReportSuppressedJSON implements the PackageManager
+// interface.
+func (m *ManagerMock) GetSnapshotSecurityReportSuppressedJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	args := m.Called(ctx, pkgID, version)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetSnapshotsToScan implements the PackageManager interface.
 func (m *ManagerMock) GetSnapshotsToScan(ctx context.Context) ([]*hub.SnapshotToScan, error) {
 	args := m.Called(ctx)
@@ -115,6 +196,13 @@ func (m *ManagerMock) GetSummaryJSON(ctx context.Context, input *hub.GetPackageI
 	return data, args.Error(1)
 }
 
+// GetUpgradeGraph implements the PackageManager interface.
+func (m *ManagerMock) GetUpgradeGraph(ctx context.Context, pkgID string) (*hub.UpgradeGraph, error) {
+	args := m.Called(ctx, pkgID)
+	data, _ := args.Get(0).(*hub.UpgradeGraph)
+	return data, args.Error(1)
+}
+
 // GetValuesSchemaJSON implements the PackageManager interface.
 func (m *ManagerMock) GetValuesSchemaJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	args := m.Called(ctx, pkgID, version)
@@ -129,12 +217,31 @@ func (m *ManagerMock) GetViewsJSON(ctx context.Context, packageID string) ([]byt
 	return data, args.Error(1)
 }
 
+// GetVulnerabilityStatementsJSON implements the PackageManager interface.
+func (m *ManagerMock) GetVulnerabilityStatementsJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
+	args := m.Called(ctx, pkgID, version)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // Register implements the PackageManager interface.
 func (m *ManagerMock) Register(ctx context.Context, pkg *hub.Package) error {
 	args := m.Called(ctx, pkg)
 	return args.Error(0)
 }
 
+// RegisterImageScan implements the PackageManager interface.
+func (m *ManagerMock) RegisterImageScan(ctx context.Context, s *hub.ImageScan) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+// RequestSnapshotScan implements the PackageManager interface.
+func (m *ManagerMock) RequestSnapshotScan(ctx context.Context, pkgID, version string) error {
+	args := m.Called(ctx, pkgID, version)
+	return args.Error(0)
+}
+
 // SearchJSON implements the PackageManager interface.
 func (m *ManagerMock) SearchJSON(ctx context.Context, input *hub.SearchPackageInput) (*hub.JSONQueryResult, error) {
 	args := m.Called(ctx, input)
@@ -161,6 +268,17 @@ func (m *ManagerMock) UpdateSnapshotSecurityReport(ctx context.Context, r *hub.S
 	return args.Error(0)
 }
 
+// UpdateVulnerabilityStatements implements the PackageManager interface.
+func (m *ManagerMock) UpdateVulnerabilityStatements(
+	ctx context.Context,
+	pkgID,
+	version string,
+	statements []*hub.VulnerabilityStatement,
+) error {
+	args := m.Called(ctx, pkgID, version, statements)
+	return args.Error(0)
+}
+
 // Unregister implements the PackageManager interface.
 func (m *ManagerMock) Unregister(ctx context.Context, pkg *hub.Package) error {
 	args := m.Called(ctx, pkg)
@@ -0,0 +1,39 @@
+package pkg
+
+import (
+	"context"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const (
+	// Database queries
+	refreshPkgsRankingsDBQ = `select refresh_packages_rankings($1::bigint)`
+
+	// RankingsRefreshFrequency represents how often the packages rankings
+	// should be refreshed.
+	RankingsRefreshFrequency = 1 * time.Hour
+)
+
+// RankingsRefresher refreshes the packages rankings (trending packages, new
+// and noteworthy packages, etc) in the database, so that they don't need to
+// be computed on each request. It is expected to be run periodically as a
+// background job.
+type RankingsRefresher struct {
+	db hub.DB
+}
+
+// NewRankingsRefresher creates a new RankingsRefresher instance.
+func NewRankingsRefresher(db hub.DB) *RankingsRefresher {
+	return &RankingsRefresher{
+		db: db,
+	}
+}
+
+// Refresh refreshes the packages rankings.
+func (r *RankingsRefresher) Refresh(ctx context.Context) error {
+	_, err := r.db.Exec(ctx, refreshPkgsRankingsDBQ, util.DBLockKeyRefreshPackagesRankings)
+	return err
+}
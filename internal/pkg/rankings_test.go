@@ -0,0 +1,36 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankingsRefresher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, refreshPkgsRankingsDBQ, util.DBLockKeyRefreshPackagesRankings).Return(tests.ErrFakeDB)
+
+		r := NewRankingsRefresher(db)
+		err := r.Refresh(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("rankings refreshed successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, refreshPkgsRankingsDBQ, util.DBLockKeyRefreshPackagesRankings).Return(nil)
+
+		r := NewRankingsRefresher(db)
+		err := r.Refresh(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
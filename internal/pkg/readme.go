@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"bytes"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	mdutil "github.com/yuin/goldmark/util"
+)
+
+// readmeSanitizer is the policy used to sanitize the HTML generated from the
+// packages READMEs. It's based on the policy for user generated content,
+// allowing also the ids in the headings so that they can be used as anchors.
+var readmeSanitizer = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[\w-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("div", "p")
+	return p
+}()
+
+// RenderReadme renders the markdown README provided as sanitized HTML. The
+// headings get an id so that they can be linked using anchors. When the url of
+// the package's source is provided, relative links and images are rewritten
+// to point to it.
+func RenderReadme(readme, sourceURL string) (string, error) {
+	parserOptions := []parser.Option{
+		parser.WithAutoHeadingID(),
+	}
+	if r := newReadmeLinksResolver(sourceURL); r != nil {
+		parserOptions = append(parserOptions, parser.WithASTTransformers(
+			mdutil.Prioritized(&readmeLinksTransformer{r: r}, 100),
+		))
+	}
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(parserOptions...),
+		goldmark.WithRendererOptions(html.WithUnsafe()),
+	)
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(readme), &buf); err != nil {
+		return "", err
+	}
+	return readmeSanitizer.Sanitize(buf.String()), nil
+}
+
+// readmeLinksTransformer is a markdown AST transformer that rewrites the
+// relative links and images destinations using the resolver provided.
+type readmeLinksTransformer struct {
+	r *readmeLinksResolver
+}
+
+// Transform implements the parser.ASTTransformer interface.
+func (t *readmeLinksTransformer) Transform(doc *ast.Document, _ text.Reader, _ parser.Context) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Link:
+			n.Destination = []byte(t.r.resolve(string(n.Destination), false))
+		case *ast.Image:
+			n.Destination = []byte(t.r.resolve(string(n.Destination), true))
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// readmeLinksResolver resolves the relative links and images found in a README
+// using the package's source url as base. GitHub repositories urls are handled
+// so that links point to the files view and images to their raw content.
+type readmeLinksResolver struct {
+	linksRoot  *url.URL
+	linksDir   *url.URL
+	imagesRoot *url.URL
+	imagesDir  *url.URL
+}
+
+// newReadmeLinksResolver creates a new readmeLinksResolver instance for the
+// source url provided. It returns nil if the url is not a valid http url.
+func newReadmeLinksResolver(sourceURL string) *readmeLinksResolver {
+	u, err := url.Parse(sourceURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil
+	}
+
+	// GitHub repository (i.e. https://github.com/org/repo/tree/main/charts/pkg)
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if u.Host == "github.com" && len(parts) >= 2 {
+		repo := path.Join("/", parts[0], strings.TrimSuffix(parts[1], ".git"))
+		ref, dir := "HEAD", ""
+		if len(parts) >= 4 && (parts[2] == "tree" || parts[2] == "blob") {
+			ref = parts[3]
+			dir = path.Join(parts[4:]...)
+		}
+		baseURLs := func(view string) (*url.URL, *url.URL) {
+			root := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: path.Join(repo, view, ref) + "/"}
+			return root, &url.URL{Scheme: u.Scheme, Host: u.Host, Path: path.Join(root.Path, dir) + "/"}
+		}
+		r := &readmeLinksResolver{}
+		r.linksRoot, r.linksDir = baseURLs("blob")
+		r.imagesRoot, r.imagesDir = baseURLs("raw")
+		return r
+	}
+
+	// Other sources
+	root := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}
+	dir := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: strings.TrimSuffix(u.Path, "/") + "/"}
+	return &readmeLinksResolver{
+		linksRoot:  root,
+		linksDir:   dir,
+		imagesRoot: root,
+		imagesDir:  dir,
+	}
+}
+
+// resolve returns the destination provided resolved using the corresponding
+// base url when it's relative. Absolute urls and anchors are not modified.
+func (r *readmeLinksResolver) resolve(dest string, image bool) string {
+	if dest == "" || strings.HasPrefix(dest, "#") {
+		return dest
+	}
+	u, err := url.Parse(dest)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return dest
+	}
+	root, dir := r.linksRoot, r.linksDir
+	if image {
+		root, dir = r.imagesRoot, r.imagesDir
+	}
+	if strings.HasPrefix(u.Path, "/") {
+		u.Path = strings.TrimPrefix(u.Path, "/")
+		return root.ResolveReference(u).String()
+	}
+	return dir.ResolveReference(u).String()
+}
@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderReadme(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		readme       string
+		sourceURL    string
+		expectedHTML string
+	}{
+		{
+			"headings get an id",
+			"# Package 1\n\n## Getting started\n",
+			"",
+			"<h1 id=\"package-1\">Package 1</h1>\n<h2 id=\"getting-started\">Getting started</h2>\n",
+		},
+		{
+			"unsafe html is sanitized",
+			"Hello <script>alert(1)</script><a href=\"javascript:alert(1)\" onclick=\"alert(1)\">link</a>\n",
+			"",
+			"<p>Hello link</p>\n",
+		},
+		{
+			"relative links are not modified when no source is provided",
+			"[doc](docs/doc.md) ![img](img.png)\n",
+			"",
+			"<p><a href=\"docs/doc.md\" rel=\"nofollow\">doc</a> <img src=\"img.png\" alt=\"img\"></p>\n",
+		},
+		{
+			"relative links are rewritten to the github source",
+			"[doc](docs/doc.md) [root](/LICENSE) [abs](https://artifacthub.io) [anchor](#usage) ![img](img.png)\n",
+			"https://github.com/org/repo/tree/main/charts/pkg1",
+			"<p>" +
+				"<a href=\"https://github.com/org/repo/blob/main/charts/pkg1/docs/doc.md\" rel=\"nofollow\">doc</a> " +
+				"<a href=\"https://github.com/org/repo/blob/main/LICENSE\" rel=\"nofollow\">root</a> " +
+				"<a href=\"https://artifacthub.io\" rel=\"nofollow\">abs</a> " +
+				"<a href=\"#usage\" rel=\"nofollow\">anchor</a> " +
+				"<img src=\"https://github.com/org/repo/raw/main/charts/pkg1/img.png\" alt=\"img\">" +
+				"</p>\n",
+		},
+		{
+			"relative links are rewritten to the github repository default branch",
+			"[doc](./docs/doc.md) ![img](img.png)\n",
+			"https://github.com/org/repo.git",
+			"<p>" +
+				"<a href=\"https://github.com/org/repo/blob/HEAD/docs/doc.md\" rel=\"nofollow\">doc</a> " +
+				"<img src=\"https://github.com/org/repo/raw/HEAD/img.png\" alt=\"img\">" +
+				"</p>\n",
+		},
+		{
+			"relative links are rewritten to other sources",
+			"[doc](../docs/doc.md) ![img](/img.png)\n",
+			"https://gitlab.com/org/repo/pkg1",
+			"<p>" +
+				"<a href=\"https://gitlab.com/org/repo/docs/doc.md\" rel=\"nofollow\">doc</a> " +
+				"<img src=\"https://gitlab.com/img.png\" alt=\"img\">" +
+				"</p>\n",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			html, err := RenderReadme(tc.readme, tc.sourceURL)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHTML, html)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/rs/zerolog/log"
 	"github.com/satori/uuid"
@@ -32,6 +33,7 @@ const (
 type ViewsTracker struct {
 	db             hub.DB
 	flushFrequency time.Duration
+	mm             hub.MaintenanceManager
 
 	mu    sync.Mutex
 	total map[string]int
@@ -57,6 +59,15 @@ func WithFlushFrequency(d time.Duration) func(t *ViewsTracker) {
 	}
 }
 
+// WithMaintenanceManager allows providing a maintenance manager, used to skip
+// flushes while the maintenance mode is enabled. The packages views will keep being
+// aggregated and will be flushed once the maintenance mode is disabled.
+func WithMaintenanceManager(mm hub.MaintenanceManager) func(t *ViewsTracker) {
+	return func(t *ViewsTracker) {
+		t.mm = mm
+	}
+}
+
 // Flusher handles the periodic flushes of packages views. It'll keep running
 // until the context provided is done.
 func (t *ViewsTracker) Flusher(ctx context.Context, wg *sync.WaitGroup) {
@@ -69,6 +80,10 @@ func (t *ViewsTracker) Flusher(ctx context.Context, wg *sync.WaitGroup) {
 			return
 		}
 		t.mu.Unlock()
+		if t.mm != nil && maintenance.IsEnabled(context.Background(), t.mm) {
+			log.Info().Msg("maintenance mode enabled, skipping packages views flush")
+			return
+		}
 		if err := t.flush(); err != nil {
 			log.Error().Err(err).Msg("error flushing packages views")
 		}
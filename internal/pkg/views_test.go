@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/maintenance"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/rs/zerolog"
@@ -75,6 +77,25 @@ func TestViewsTracker(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("maintenance mode enabled, flush skipped", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		mm := &maintenance.ManagerMock{}
+		mm.On("Get", context.Background()).Return(&hub.MaintenanceMode{Enabled: true}, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+
+		vt := NewViewsTracker(db, WithMaintenanceManager(mm))
+		wg.Add(1)
+		go vt.Flusher(ctx, &wg)
+		assert.Nil(t, vt.TrackView(package1ID, version))
+		cancel()
+		wg.Wait()
+		db.AssertExpectations(t)
+		mm.AssertExpectations(t)
+		assert.Len(t, vt.total, 1)
+	})
+
 	t.Run("db error flushing", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
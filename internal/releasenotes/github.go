@@ -0,0 +1,232 @@
+package releasenotes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	// GitHubSource represents the source of the release notes obtained from
+	// the GitHub releases of a repository.
+	GitHubSource = "github"
+
+	defaultGitHubAPIURL    = "https://api.github.com"
+	releasesPerPage        = 100
+	cacheDefaultExpiration = 1 * time.Hour
+	cacheCleanupInterval   = 2 * time.Hour
+)
+
+// ErrRateLimited indicates that the GitHub API rate limit has been exceeded,
+// so no requests will be sent to it until the rate limit is reset.
+var ErrRateLimited = errors.New("github api rate limit exceeded")
+
+// githubRelease represents a release in the GitHub API.
+type githubRelease struct {
+	TagName     string     `json:"tag_name"`
+	HTMLURL     string     `json:"html_url"`
+	Body        string     `json:"body"`
+	Draft       bool       `json:"draft"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// GitHubFetcher is a hub.ReleaseNotesFetcher implementation that gets the
+// release notes of the packages versions from the GitHub releases of their
+// source repositories. The releases of each repository are cached, so only
+// one request per repository is sent to the GitHub API.
+type GitHubFetcher struct {
+	hc      hub.HTTPClient
+	token   string
+	baseURL string
+	cache   *cache.Cache
+
+	mu               sync.RWMutex
+	rateLimitedUntil time.Time
+}
+
+// GitHubFetcherOption represents an option that can be passed when creating
+// a new GitHubFetcher instance.
+type GitHubFetcherOption func(f *GitHubFetcher)
+
+// WithBaseURL allows providing a specific GitHub API base url.
+func WithBaseURL(baseURL string) GitHubFetcherOption {
+	return func(f *GitHubFetcher) {
+		f.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// NewGitHubFetcher creates a new GitHubFetcher instance. The token provided,
+// when not empty, will be used to authenticate the requests to the GitHub API
+// so that a higher rate limit applies.
+func NewGitHubFetcher(hc hub.HTTPClient, token string, opts ...GitHubFetcherOption) *GitHubFetcher {
+	f := &GitHubFetcher{
+		hc:      hc,
+		token:   token,
+		baseURL: defaultGitHubAPIURL,
+		cache:   cache.New(cacheDefaultExpiration, cacheCleanupInterval),
+	}
+	for _, o := range opts {
+		o(f)
+	}
+	return f
+}
+
+// Fetch implements the hub.ReleaseNotesFetcher interface. It returns nil when
+// the package's source is not a GitHub repository or when no release matching
+// the package version is found.
+func (f *GitHubFetcher) Fetch(ctx context.Context, p *hub.Package) (*hub.ReleaseNotes, error) {
+	owner, repo := GetGitHubRepository(p)
+	if owner == "" {
+		return nil, nil
+	}
+	releases, err := f.getReleases(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range getCandidateTags(p) {
+		for _, r := range releases {
+			if r.Draft || !strings.EqualFold(r.TagName, tag) {
+				continue
+			}
+			rn := &hub.ReleaseNotes{
+				Source:  GitHubSource,
+				URL:     r.HTMLURL,
+				TagName: r.TagName,
+				Body:    r.Body,
+			}
+			if r.PublishedAt != nil {
+				rn.PublishedAt = r.PublishedAt.Unix()
+			}
+			return rn, nil
+		}
+	}
+	return nil, nil
+}
+
+// getReleases returns the releases of the GitHub repository provided, using
+// the cached copy when available. Repositories not found are cached as well.
+func (f *GitHubFetcher) getReleases(ctx context.Context, owner, repo string) ([]*githubRelease, error) {
+	key := owner + "/" + repo
+	if v, ok := f.cache.Get(key); ok {
+		return v.([]*githubRelease), nil
+	}
+
+	// Do not send any requests until the rate limit is reset
+	f.mu.RLock()
+	rateLimitedUntil := f.rateLimitedUntil
+	f.mu.RUnlock()
+	if time.Now().Before(rateLimitedUntil) {
+		return nil, ErrRateLimited
+	}
+
+	// Get releases from the GitHub API
+	u := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=%d", f.baseURL, owner, repo, releasesPerPage)
+	req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if f.token != "" {
+		req.Header.Set("Authorization", "token "+f.token)
+	}
+	resp, err := f.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if f.checkRateLimit(resp) {
+		return nil, ErrRateLimited
+	}
+	var releases []*githubRelease
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+			return nil, err
+		}
+	case http.StatusNotFound:
+	default:
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	f.cache.SetDefault(key, releases)
+	return releases, nil
+}
+
+// checkRateLimit checks the rate limit headers of the GitHub API response
+// provided, recording until when requests should not be sent when the limit
+// has been reached. It returns true if the request was rejected because of
+// the rate limit.
+func (f *GitHubFetcher) checkRateLimit(resp *http.Response) bool {
+	var until time.Time
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err == nil {
+			until = time.Unix(reset, 0)
+		} else {
+			until = time.Now().Add(time.Minute)
+		}
+	}
+	rejected := resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests
+	if rejected {
+		// Secondary rate limits provide the time to wait in seconds
+		if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			until = time.Now().Add(time.Duration(retryAfter) * time.Second)
+		}
+	}
+	if until.IsZero() {
+		return false
+	}
+	f.mu.Lock()
+	if until.After(f.rateLimitedUntil) {
+		f.rateLimitedUntil = until
+	}
+	f.mu.Unlock()
+	return rejected
+}
+
+// GetGitHubRepository returns the owner and name of the GitHub repository
+// where the source of the package provided is hosted. The package's source
+// link is checked first, followed by its home url and the url of the
+// repository it belongs to. Empty strings are returned if none of them point
+// to a GitHub repository.
+func GetGitHubRepository(p *hub.Package) (string, string) {
+	urls := make([]string, 0, len(p.Links)+2)
+	for _, link := range p.Links {
+		if strings.EqualFold(link.Name, "source") {
+			urls = append(urls, link.URL)
+		}
+	}
+	urls = append(urls, p.HomeURL)
+	if p.Repository != nil {
+		urls = append(urls, p.Repository.URL)
+	}
+	for _, rawURL := range urls {
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host != "github.com" {
+			continue
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		return parts[0], strings.TrimSuffix(parts[1], ".git")
+	}
+	return "", ""
+}
+
+// getCandidateTags returns the tags that a GitHub release of the package
+// version provided may use, in order of preference.
+func getCandidateTags(p *hub.Package) []string {
+	return []string{
+		"v" + p.Version,
+		p.Version,
+		p.Name + "-" + p.Version,
+		p.Name + "-v" + p.Version,
+	}
+}
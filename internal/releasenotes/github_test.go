@@ -0,0 +1,221 @@
+package releasenotes
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const releasesURL = "https://api.github.test/repos/org/repo/releases?per_page=100"
+
+func TestGitHubFetcherFetch(t *testing.T) {
+	ctx := context.Background()
+	p := &hub.Package{
+		Name:    "pkg1",
+		Version: "1.0.0",
+		Links: []*hub.Link{
+			{Name: "Source", URL: "https://github.com/org/repo/tree/main/charts/pkg1"},
+		},
+	}
+	releases := `[
+		{"tag_name": "v1.0.0", "html_url": "https://github.com/org/repo/releases/tag/v1.0.0", "body": "draft", "draft": true},
+		{"tag_name": "pkg1-1.0.0", "html_url": "https://github.com/org/repo/releases/tag/pkg1-1.0.0", "body": "notes", "published_at": "2022-03-01T10:00:00Z"},
+		{"tag_name": "pkg1-0.9.0", "html_url": "https://github.com/org/repo/releases/tag/pkg1-0.9.0", "body": "old notes"}
+	]`
+
+	t.Run("package source is not a github repository", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		f := NewGitHubFetcher(hc, "", WithBaseURL("https://api.github.test"))
+
+		rn, err := f.Fetch(ctx, &hub.Package{Name: "pkg1", Version: "1.0.0", HomeURL: "https://gitlab.com/org/repo"})
+		assert.NoError(t, err)
+		assert.Nil(t, rn)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("error getting releases", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
+		f := NewGitHubFetcher(hc, "", WithBaseURL("https://api.github.test"))
+
+		rn, err := f.Fetch(ctx, p)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, rn)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("unexpected status code getting releases", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(newResponse(http.StatusInternalServerError, "", nil), nil)
+		f := NewGitHubFetcher(hc, "", WithBaseURL("https://api.github.test"))
+
+		rn, err := f.Fetch(ctx, p)
+		assert.EqualError(t, err, "unexpected status code received: 500")
+		assert.Nil(t, rn)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(newResponse(http.StatusNotFound, "", nil), nil).Once()
+		f := NewGitHubFetcher(hc, "", WithBaseURL("https://api.github.test"))
+
+		for i := 0; i < 2; i++ {
+			rn, err := f.Fetch(ctx, p)
+			assert.NoError(t, err)
+			assert.Nil(t, rn)
+		}
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("release notes found, releases are cached", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == releasesURL &&
+				req.Header.Get("Authorization") == "token ghToken"
+		})).Return(newResponse(http.StatusOK, releases, nil), nil).Once()
+		f := NewGitHubFetcher(hc, "ghToken", WithBaseURL("https://api.github.test"))
+
+		rn, err := f.Fetch(ctx, p)
+		require.NoError(t, err)
+		assert.Equal(t, &hub.ReleaseNotes{
+			Source:      GitHubSource,
+			URL:         "https://github.com/org/repo/releases/tag/pkg1-1.0.0",
+			TagName:     "pkg1-1.0.0",
+			Body:        "notes",
+			PublishedAt: 1646128800,
+		}, rn)
+
+		rn, err = f.Fetch(ctx, &hub.Package{Name: "pkg1", Version: "1.1.0", Links: p.Links})
+		assert.NoError(t, err)
+		assert.Nil(t, rn)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("rate limit exceeded, no more requests until it is reset", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		reset := time.Now().Add(1 * time.Hour).Unix()
+		hc.On("Do", mock.Anything).Return(newResponse(http.StatusForbidden, "", map[string]string{
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     strconv.FormatInt(reset, 10),
+		}), nil).Once()
+		f := NewGitHubFetcher(hc, "", WithBaseURL("https://api.github.test"))
+
+		for i := 0; i < 2; i++ {
+			rn, err := f.Fetch(ctx, p)
+			assert.Equal(t, ErrRateLimited, err)
+			assert.Nil(t, rn)
+		}
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("secondary rate limit exceeded", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(newResponse(http.StatusForbidden, "", map[string]string{
+			"Retry-After": "60",
+		}), nil).Once()
+		f := NewGitHubFetcher(hc, "", WithBaseURL("https://api.github.test"))
+
+		for i := 0; i < 2; i++ {
+			rn, err := f.Fetch(ctx, p)
+			assert.Equal(t, ErrRateLimited, err)
+			assert.Nil(t, rn)
+		}
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestGetGitHubRepository(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		p             *hub.Package
+		expectedOwner string
+		expectedRepo  string
+	}{
+		{
+			"no urls",
+			&hub.Package{},
+			"",
+			"",
+		},
+		{
+			"source link",
+			&hub.Package{
+				HomeURL: "https://github.com/org/home",
+				Links: []*hub.Link{
+					{Name: "docs", URL: "https://github.com/org/docs"},
+					{Name: "source", URL: "https://github.com/org/repo.git"},
+				},
+			},
+			"org",
+			"repo",
+		},
+		{
+			"home url",
+			&hub.Package{
+				HomeURL: "https://github.com/org/home/tree/main/pkg",
+				Links: []*hub.Link{
+					{Name: "source", URL: "https://gitlab.com/org/repo"},
+				},
+			},
+			"org",
+			"home",
+		},
+		{
+			"repository url",
+			&hub.Package{
+				HomeURL:    "https://github.com/org",
+				Repository: &hub.Repository{URL: "https://github.com/org/charts/charts"},
+			},
+			"org",
+			"charts",
+		},
+		{
+			"no github urls",
+			&hub.Package{
+				HomeURL:    "https://artifacthub.io",
+				Repository: &hub.Repository{URL: "https://org.github.io/charts"},
+			},
+			"",
+			"",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			owner, repo := GetGitHubRepository(tc.p)
+			assert.Equal(t, tc.expectedOwner, owner)
+			assert.Equal(t, tc.expectedRepo, repo)
+		})
+	}
+}
+
+func newResponse(statusCode int, body string, headers map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
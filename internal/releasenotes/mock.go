@@ -0,0 +1,21 @@
+package releasenotes
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// FetcherMock is a mock implementation of the hub.ReleaseNotesFetcher
+// interface.
+type FetcherMock struct {
+	mock.Mock
+}
+
+// Fetch implements the hub.ReleaseNotesFetcher interface.
+func (m *FetcherMock) Fetch(ctx context.Context, p *hub.Package) (*hub.ReleaseNotes, error) {
+	args := m.Called(ctx, p)
+	rn, _ := args.Get(0).(*hub.ReleaseNotes)
+	return rn, args.Error(1)
+}
@@ -2,14 +2,25 @@ package repo
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"strings"
+	"os"
+	"path/filepath"
+	"sync"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/filesystem"
 )
 
 const (
@@ -19,44 +30,199 @@ const (
 )
 
 // Cloner is a hub.RepositoryCloner implementation.
-type Cloner struct{}
+//
+// Repositories are cloned shallowly and without a working tree. Only the
+// files located in the packages path are checked out, which reduces the time
+// and disk space needed to process big repositories.
+type Cloner struct {
+	// CacheDir represents the directory where the repositories clones will be
+	// cached, so that only the changes need to be fetched on subsequent runs.
+	// Clones are not cached when it is not provided.
+	CacheDir string
+
+	locks sync.Map
+}
 
 // CloneRepository implements the hub.RepositoryCloner interface.
 func (c *Cloner) CloneRepository(ctx context.Context, r *hub.Repository) (string, string, error) {
 	// Parse repository url
-	var repoBaseURL, packagesPath string
-	matches := GitRepoURLRE.FindStringSubmatch(r.URL)
-	if len(matches) < 3 {
-		return "", "", fmt.Errorf("invalid repository url")
-	}
-	repoBaseURL = matches[1]
-	if len(matches) == 4 {
-		packagesPath = strings.TrimSuffix(matches[3], "/")
+	u, err := ParseGitRepoURL(r.URL)
+	if err != nil {
+		return "", "", err
 	}
 
-	// Clone git repository
+	// Clone git repository (or update the cached clone) and checkout the
+	// packages path
 	tmpDir, err := ioutil.TempDir("", "artifact-hub")
 	if err != nil {
 		return "", "", fmt.Errorf("error creating temp dir: %w", err)
 	}
-	cloneOptions := &git.CloneOptions{
-		URL:           repoBaseURL,
+	var gitRepo *git.Repository
+	if c.CacheDir != "" {
+		key := cacheKey(u.BaseURL, GetBranch(r))
+		unlock := c.lock(key)
+		defer unlock()
+		gitRepo, err = c.getCachedRepository(ctx, r, u, key)
+	} else {
+		gitRepo, err = cloneRepository(ctx, r, u, filepath.Join(tmpDir, git.GitDirName))
+	}
+	if err == nil {
+		err = checkoutPath(gitRepo, GetBranch(r), u.PackagesPath, tmpDir)
+	}
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return "", "", err
+	}
+
+	return tmpDir, u.PackagesPath, nil
+}
+
+// getCachedRepository returns the cached clone of the repository provided,
+// after fetching the latest changes from the remote. When the repository has
+// not been cached yet, or the cached clone cannot be updated, the repository
+// is cloned again into the cache.
+func (c *Cloner) getCachedRepository(
+	ctx context.Context,
+	r *hub.Repository,
+	u *GitRepoURL,
+	key string,
+) (*git.Repository, error) {
+	dir := filepath.Join(c.CacheDir, key)
+	if _, err := os.Stat(dir); err == nil {
+		gitRepo, err := git.Open(newStorage(dir), nil)
+		if err == nil {
+			branch := GetBranch(r)
+			err = gitRepo.FetchContext(ctx, &git.FetchOptions{
+				RefSpecs: []config.RefSpec{
+					config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/heads/%s", branch, branch)),
+				},
+				Depth: 1,
+				Auth:  gitAuthMethod(r),
+				Force: true,
+			})
+			if err == nil || errors.Is(err, git.NoErrAlreadyUpToDate) {
+				if _, err = branchCommit(gitRepo, branch); err == nil {
+					return gitRepo, nil
+				}
+			}
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("error removing cached clone: %w", err)
+		}
+	}
+	return cloneRepository(ctx, r, u, dir)
+}
+
+// lock acquires the lock of the cache entry identified by the key provided,
+// returning a function that releases it.
+func (c *Cloner) lock(key string) func() {
+	mu, _ := c.locks.LoadOrStore(key, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// cloneRepository clones shallowly the repository provided into the directory
+// given. Only the git objects are stored, no working tree is checked out.
+func cloneRepository(ctx context.Context, r *hub.Repository, u *GitRepoURL, dir string) (*git.Repository, error) {
+	return git.CloneContext(ctx, newStorage(dir), nil, &git.CloneOptions{
+		URL:           u.BaseURL,
+		Auth:          gitAuthMethod(r),
 		ReferenceName: plumbing.NewBranchReferenceName(GetBranch(r)),
 		SingleBranch:  true,
 		Depth:         1,
+	})
+}
+
+// checkoutPath writes the files located in the path provided, as of the last
+// commit of the branch given, to the destination directory. Files keep their
+// location relative to the root of the repository.
+func checkoutPath(gitRepo *git.Repository, branch, path, dst string) error {
+	commit, err := branchCommit(gitRepo, branch)
+	if err != nil {
+		return err
 	}
-	if r.AuthPass != "" {
-		cloneOptions.Auth = &http.BasicAuth{
-			Username: "artifact-hub",
-			Password: r.AuthPass,
+	tree, err := commit.Tree()
+	if err != nil {
+		return fmt.Errorf("error getting tree: %w", err)
+	}
+	if path != "" {
+		tree, err = tree.Tree(path)
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error getting path %s: %w", path, err)
+		}
+	}
+	return tree.Files().ForEach(func(f *object.File) error {
+		filePath := filepath.Join(dst, path, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return err
+		}
+		if f.Mode == filemode.Symlink {
+			target, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, filePath)
+		}
+		mode, err := f.Mode.ToOSFileMode()
+		if err != nil {
+			return err
 		}
+		return writeBlob(f, filePath, mode)
+	})
+}
+
+// branchCommit returns the last commit of the branch provided.
+func branchCommit(gitRepo *git.Repository, branch string) (*object.Commit, error) {
+	ref, err := gitRepo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting branch %s: %w", branch, err)
 	}
-	_, err = git.PlainCloneContext(ctx, tmpDir, false, cloneOptions)
+	commit, err := gitRepo.CommitObject(ref.Hash())
 	if err != nil {
-		return "", "", err
+		return nil, fmt.Errorf("error getting commit: %w", err)
 	}
+	return commit, nil
+}
+
+// writeBlob writes the content of the file provided to the path given.
+func writeBlob(f *object.File, path string, mode os.FileMode) error {
+	src, err := f.Reader()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// newStorage returns a git objects storage located in the directory provided.
+func newStorage(dir string) *filesystem.Storage {
+	return filesystem.NewStorage(osfs.New(dir), cache.NewObjectLRUDefault())
+}
+
+// gitAuthMethod returns the credentials that should be used to access the
+// repository provided, if any.
+func gitAuthMethod(r *hub.Repository) transport.AuthMethod {
+	if auth := GitCloneAuth(r); auth != nil {
+		return auth
+	}
+	return nil
+}
 
-	return tmpDir, packagesPath, nil
+// cacheKey returns the key of the cache entry of the repository url and
+// branch provided.
+func cacheKey(repoURL, branch string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(repoURL+"@"+branch)))
 }
 
 // GetBranch returns the branch configured in the repository or the default one
@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckoutPath(t *testing.T) {
+	srcDir := setupGitRepository(t, map[string]string{
+		"README.md":                           "readme",
+		"pkgs/artifacthub-repo.yml":           "repositoryID: id",
+		"pkgs/pkg1/1.0.0/artifacthub-pkg.yml": "name: pkg1",
+		"other/file.txt":                      "other",
+	})
+	gitRepo, err := git.PlainOpen(srcDir)
+	require.NoError(t, err)
+
+	t.Run("only files in the path provided are checked out", func(t *testing.T) {
+		t.Parallel()
+		dst := t.TempDir()
+		err := checkoutPath(gitRepo, "main", "pkgs", dst)
+		require.NoError(t, err)
+
+		assertFileContent(t, filepath.Join(dst, "pkgs", "artifacthub-repo.yml"), "repositoryID: id")
+		assertFileContent(t, filepath.Join(dst, "pkgs", "pkg1", "1.0.0", "artifacthub-pkg.yml"), "name: pkg1")
+		assert.NoFileExists(t, filepath.Join(dst, "README.md"))
+		assert.NoDirExists(t, filepath.Join(dst, "other"))
+	})
+
+	t.Run("all files are checked out when no path is provided", func(t *testing.T) {
+		t.Parallel()
+		dst := t.TempDir()
+		err := checkoutPath(gitRepo, "main", "", dst)
+		require.NoError(t, err)
+
+		assertFileContent(t, filepath.Join(dst, "README.md"), "readme")
+		assertFileContent(t, filepath.Join(dst, "other", "file.txt"), "other")
+	})
+
+	t.Run("path not found", func(t *testing.T) {
+		t.Parallel()
+		dst := t.TempDir()
+		err := checkoutPath(gitRepo, "main", "not-found", dst)
+		require.NoError(t, err)
+		assert.NoDirExists(t, filepath.Join(dst, "not-found"))
+	})
+
+	t.Run("branch not found", func(t *testing.T) {
+		t.Parallel()
+		err := checkoutPath(gitRepo, "not-found", "", t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func TestGetCachedRepository(t *testing.T) {
+	ctx := context.Background()
+	srcDir := setupGitRepository(t, map[string]string{
+		"pkgs/artifacthub-repo.yml": "v1",
+	})
+	r := &hub.Repository{Branch: "main"}
+	u := &GitRepoURL{BaseURL: srcDir, PackagesPath: "pkgs"}
+	c := &Cloner{CacheDir: t.TempDir()}
+	key := cacheKey(u.BaseURL, "main")
+
+	// First run: repository is cloned into the cache
+	gitRepo, err := c.getCachedRepository(ctx, r, u, key)
+	require.NoError(t, err)
+	dst := t.TempDir()
+	require.NoError(t, checkoutPath(gitRepo, "main", "pkgs", dst))
+	assertFileContent(t, filepath.Join(dst, "pkgs", "artifacthub-repo.yml"), "v1")
+	assert.DirExists(t, filepath.Join(c.CacheDir, key))
+
+	// Second run: changes are fetched into the cached clone
+	commitFiles(t, srcDir, map[string]string{
+		"pkgs/artifacthub-repo.yml": "v2",
+	})
+	gitRepo, err = c.getCachedRepository(ctx, r, u, key)
+	require.NoError(t, err)
+	dst = t.TempDir()
+	require.NoError(t, checkoutPath(gitRepo, "main", "pkgs", dst))
+	assertFileContent(t, filepath.Join(dst, "pkgs", "artifacthub-repo.yml"), "v2")
+
+	// Third run: broken cached clone is replaced
+	require.NoError(t, os.RemoveAll(filepath.Join(c.CacheDir, key, "objects")))
+	gitRepo, err = c.getCachedRepository(ctx, r, u, key)
+	require.NoError(t, err)
+	dst = t.TempDir()
+	require.NoError(t, checkoutPath(gitRepo, "main", "pkgs", dst))
+	assertFileContent(t, filepath.Join(dst, "pkgs", "artifacthub-repo.yml"), "v2")
+}
+
+// setupGitRepository creates a git repository in a temporary directory with
+// a commit in the main branch containing the files provided.
+func setupGitRepository(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	_, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0644))
+	commitFiles(t, dir, files)
+	return dir
+}
+
+// commitFiles writes the files provided to the git repository located in the
+// directory given and commits them.
+func commitFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	gitRepo, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+	wt, err := gitRepo.Worktree()
+	require.NoError(t, err)
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		_, err := wt.Add(name)
+		require.NoError(t, err)
+	}
+	_, err = wt.Commit("commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@email.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+}
+
+// assertFileContent checks that the file provided has the content given.
+func assertFileContent(t *testing.T, path, expectedContent string) {
+	t.Helper()
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expectedContent, string(content))
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -16,6 +17,42 @@ const (
 	maxErrorsPerRepository = 100
 )
 
+// trackingErrorsClassifiers represents the rules used to classify tracking
+// errors. They are evaluated in order, and the first one matching the error
+// message determines the error code.
+var trackingErrorsClassifiers = []struct {
+	code hub.TrackingErrorCode
+	re   *regexp.Regexp
+}{
+	{
+		hub.TrackingErrorAuth,
+		regexp.MustCompile(`(?i)\b(401|403)\b|unauthori[sz]ed|forbidden|authentication|authorization|credentials|permission denied|access denied|denied:`),
+	},
+	{
+		hub.TrackingErrorNetwork,
+		regexp.MustCompile(`(?i)dial tcp|no such host|connection (refused|reset)|timeout|timed out|deadline exceeded|tls|x509|certificate|\beof\b|\b(404|429|5\d\d)\b|unexpected status code|failed to fetch|too many requests|rate limit|restricted connection`),
+	},
+	{
+		hub.TrackingErrorContent,
+		regexp.MustCompile(`(?i)archive|tarball|gzip|\btar\b|readme|logo|screenshot|\bcrds?\b|templates?\b|signature|provenance`),
+	},
+	{
+		hub.TrackingErrorSchemaValidation,
+		regexp.MustCompile(`(?i)invalid|validation|required|not provided|must be|unmarshal|yaml:|json:|schema|annotation|metadata`),
+	},
+}
+
+// ClassifyTrackingError returns the code of the category the tracking error
+// message provided belongs to.
+func ClassifyTrackingError(msg string) hub.TrackingErrorCode {
+	for _, c := range trackingErrorsClassifiers {
+		if c.re.MatchString(msg) {
+			return c.code
+		}
+	}
+	return hub.TrackingErrorUnknown
+}
+
 // ErrorsCollectorKind represents the kind of a given errors collector.
 type ErrorsCollectorKind int64
 
@@ -71,19 +108,19 @@ func (c *ErrorsCollector) Flush() {
 		// able to compare the errors produced among executions.
 		sort.Strings(errors)
 
-		var allErrors strings.Builder
-		for i, err := range errors {
-			allErrors.WriteString(err)
-			if i < len(errors)-1 {
-				allErrors.WriteString("\n")
-			}
-		}
 		var err error
 		switch c.kind {
 		case Scanner:
-			err = c.rm.SetLastScanningResults(context.Background(), repositoryID, allErrors.String())
+			err = c.rm.SetLastScanningResults(context.Background(), repositoryID, strings.Join(errors, "\n"))
 		case Tracker:
-			err = c.rm.SetLastTrackingResults(context.Background(), repositoryID, allErrors.String())
+			trackingErrors := make([]*hub.TrackingError, 0, len(errors))
+			for _, msg := range errors {
+				trackingErrors = append(trackingErrors, &hub.TrackingError{
+					Code:    ClassifyTrackingError(msg),
+					Message: msg,
+				})
+			}
+			err = c.rm.SetLastTrackingResults(context.Background(), repositoryID, trackingErrors)
 		}
 		if err != nil {
 			log.Error().Err(err).Str("repoID", repositoryID).Send()
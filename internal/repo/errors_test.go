@@ -3,45 +3,114 @@ package repo
 import (
 	"context"
 	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
 )
 
-func TestCollector(t *testing.T) {
+func TestClassifyTrackingError(t *testing.T) {
 	testCases := []struct {
-		kind         ErrorsCollectorKind
-		expectedCall string
+		msg          string
+		expectedCode hub.TrackingErrorCode
 	}{
 		{
-			Scanner,
-			"SetLastScanningResults",
+			"error loading repository index file: failed to fetch https://repo.url/index.yaml : 401 Unauthorized",
+			hub.TrackingErrorAuth,
+		},
+		{
+			"error cloning repository: authentication required",
+			hub.TrackingErrorAuth,
+		},
+		{
+			"error loading repository index file: dial tcp: lookup repo.url: no such host",
+			hub.TrackingErrorNetwork,
+		},
+		{
+			"error preparing package pkg1 version 1.0.0: unexpected status code received: 500",
+			hub.TrackingErrorNetwork,
+		},
+		{
+			"error preparing package: invalid package version: Invalid Semantic Version",
+			hub.TrackingErrorSchemaValidation,
+		},
+		{
+			"error getting repository metadata: error unmarshaling metadata file",
+			hub.TrackingErrorSchemaValidation,
+		},
+		{
+			"error preparing package: error loading chart archive: gzip: invalid header",
+			hub.TrackingErrorContent,
 		},
 		{
-			Tracker,
-			"SetLastTrackingResults",
+			"error preparing package: error loading chart archive: chart.yaml not found",
+			hub.TrackingErrorContent,
+		},
+		{
+			"something went wrong",
+			hub.TrackingErrorUnknown,
 		},
 	}
 	for _, tc := range testCases {
 		tc := tc
-		t.Run(tc.expectedCall, func(t *testing.T) {
+		t.Run(tc.msg, func(t *testing.T) {
 			t.Parallel()
+			assert.Equal(t, tc.expectedCode, ClassifyTrackingError(tc.msg))
+		})
+	}
+}
 
-			// Setup errors collector
-			rm := &ManagerMock{}
-			ec := NewErrorsCollector(rm, tc.kind)
+func TestCollector(t *testing.T) {
+	t.Run("scanner", func(t *testing.T) {
+		t.Parallel()
 
-			// Initialize list of errors for repo1 (repo2 will be implicitly initialized)
-			ec.Init("repo1")
+		// Setup errors collector
+		rm := &ManagerMock{}
+		ec := NewErrorsCollector(rm, Scanner)
 
-			// Append some errors for both repositories
-			ec.Append("repo1", "error1")
-			ec.Append("repo1", "error2")
-			ec.Append("repo2", "error2")
-			ec.Append("repo2", "error1")
+		// Initialize list of errors for repo1 (repo2 will be implicitly initialized)
+		ec.Init("repo1")
 
-			// Flush errors and check the results were set as expected
-			rm.On(tc.expectedCall, context.Background(), "repo1", "error1\nerror2").Return(nil)
-			rm.On(tc.expectedCall, context.Background(), "repo2", "error1\nerror2").Return(nil)
-			ec.Flush()
-			rm.AssertExpectations(t)
-		})
-	}
+		// Append some errors for both repositories
+		ec.Append("repo1", "error1")
+		ec.Append("repo1", "error2")
+		ec.Append("repo2", "error2")
+		ec.Append("repo2", "error1")
+
+		// Flush errors and check the results were set as expected
+		rm.On("SetLastScanningResults", context.Background(), "repo1", "error1\nerror2").Return(nil)
+		rm.On("SetLastScanningResults", context.Background(), "repo2", "error1\nerror2").Return(nil)
+		ec.Flush()
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("tracker", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup errors collector
+		rm := &ManagerMock{}
+		ec := NewErrorsCollector(rm, Tracker)
+
+		// Initialize list of errors for repo1 and repo3 (repo2 will be implicitly initialized)
+		ec.Init("repo1")
+		ec.Init("repo3")
+
+		// Append some errors for repo1 and repo2
+		ec.Append("repo1", "error1")
+		ec.Append("repo1", "error2: 401 Unauthorized")
+		ec.Append("repo2", "error2: invalid package version")
+		ec.Append("repo2", "error1")
+
+		// Flush errors and check the results were set as expected
+		rm.On("SetLastTrackingResults", context.Background(), "repo1", []*hub.TrackingError{
+			{Code: hub.TrackingErrorUnknown, Message: "error1"},
+			{Code: hub.TrackingErrorAuth, Message: "error2: 401 Unauthorized"},
+		}).Return(nil)
+		rm.On("SetLastTrackingResults", context.Background(), "repo2", []*hub.TrackingError{
+			{Code: hub.TrackingErrorUnknown, Message: "error1"},
+			{Code: hub.TrackingErrorSchemaValidation, Message: "error2: invalid package version"},
+		}).Return(nil)
+		rm.On("SetLastTrackingResults", context.Background(), "repo3", []*hub.TrackingError{}).Return(nil)
+		ec.Flush()
+		rm.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,244 @@
+package repo
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/artifacthub/hub/internal/hub"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/spf13/viper"
+)
+
+// GitProvider represents a git hosting provider.
+type GitProvider string
+
+const (
+	// GitProviderBitbucket represents the Bitbucket git provider.
+	GitProviderBitbucket GitProvider = "bitbucket"
+
+	// GitProviderGitea represents the Gitea git provider.
+	GitProviderGitea GitProvider = "gitea"
+
+	// GitProviderGitHub represents the GitHub git provider.
+	GitProviderGitHub GitProvider = "github"
+
+	// GitProviderGitLab represents the GitLab git provider.
+	GitProviderGitLab GitProvider = "gitlab"
+)
+
+var (
+	// gitProviders maps git hosts to the provider they belong to. It includes
+	// the public instances of the providers supported by default, and it can
+	// be extended with self-hosted instances using RegisterGitProviders.
+	gitProviders = map[string]GitProvider{
+		"bitbucket.org": GitProviderBitbucket,
+		"codeberg.org":  GitProviderGitea,
+		"gitea.com":     GitProviderGitea,
+		"github.com":    GitProviderGitHub,
+		"gitlab.com":    GitProviderGitLab,
+	}
+	gitProvidersMu sync.RWMutex
+)
+
+// GitProviderConfig represents the configuration of a self-hosted instance of
+// a git provider.
+type GitProviderConfig struct {
+	Host     string      `mapstructure:"host"`
+	Provider GitProvider `mapstructure:"provider"`
+}
+
+// RegisterGitProviders registers the self-hosted git providers instances
+// defined in the configuration provided.
+func RegisterGitProviders(cfg *viper.Viper) error {
+	var providers []*GitProviderConfig
+	if err := cfg.UnmarshalKey("gitProviders", &providers); err != nil {
+		return fmt.Errorf("error reading git providers configuration: %w", err)
+	}
+	for _, pc := range providers {
+		if err := RegisterGitProvider(pc.Host, pc.Provider); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterGitProvider registers the host provided as an instance of the git
+// provider given.
+func RegisterGitProvider(host string, provider GitProvider) error {
+	if host == "" {
+		return fmt.Errorf("git provider %s: host not provided", provider)
+	}
+	switch provider {
+	case GitProviderBitbucket, GitProviderGitea, GitProviderGitHub, GitProviderGitLab:
+	default:
+		return fmt.Errorf("git provider for host %s: invalid provider: %s", host, provider)
+	}
+	gitProvidersMu.Lock()
+	gitProviders[strings.ToLower(host)] = provider
+	gitProvidersMu.Unlock()
+	return nil
+}
+
+// GetGitProvider returns the git provider the host provided belongs to. An
+// empty provider is returned when it is unknown.
+func GetGitProvider(host string) GitProvider {
+	gitProvidersMu.RLock()
+	defer gitProvidersMu.RUnlock()
+	return gitProviders[strings.ToLower(host)]
+}
+
+// GitRepoURL represents an http based git repository url, as used by the
+// repositories of git based kinds.
+type GitRepoURL struct {
+	BaseURL      string
+	Host         string
+	Owner        string
+	Name         string
+	PackagesPath string
+	Provider     GitProvider
+}
+
+// ParseGitRepoURL parses the http based git repository url provided.
+func ParseGitRepoURL(rawURL string) (*GitRepoURL, error) {
+	matches := GitRepoURLRE.FindStringSubmatch(rawURL)
+	if len(matches) < 3 {
+		return nil, fmt.Errorf("invalid repository url")
+	}
+	u := &GitRepoURL{
+		BaseURL: matches[1],
+		Host:    matches[2],
+	}
+	parts := strings.Split(strings.TrimPrefix(u.BaseURL, "https://"+u.Host+"/"), "/")
+	u.Owner = parts[0]
+	u.Name = strings.TrimSuffix(parts[1], ".git")
+	if len(matches) == 4 {
+		u.PackagesPath = strings.Trim(matches[3], "/")
+	}
+	u.Provider = GetGitProvider(u.Host)
+	return u, nil
+}
+
+// BlobURL returns the url of the web page of the file provided at the git
+// reference given. An empty string is returned when the provider is unknown.
+func (u *GitRepoURL) BlobURL(ref, filePath string) string {
+	return u.sourceURL("blob", ref, filePath)
+}
+
+// TreeURL returns the url of the web page of the directory provided at the
+// git reference given. An empty string is returned when the provider is
+// unknown.
+func (u *GitRepoURL) TreeURL(ref, dirPath string) string {
+	return u.sourceURL("tree", ref, dirPath)
+}
+
+// sourceURL is a helper used to build the source web pages urls.
+func (u *GitRepoURL) sourceURL(kind, ref, p string) string {
+	baseURL := strings.TrimSuffix(u.BaseURL, ".git")
+	var prefix string
+	switch u.Provider {
+	case GitProviderBitbucket, GitProviderGitea:
+		prefix = "src"
+	case GitProviderGitHub:
+		prefix = kind
+	case GitProviderGitLab:
+		prefix = "-/" + kind
+	default:
+		return ""
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%s/%s/%s/%s", baseURL, prefix, ref, strings.TrimPrefix(p, "/")), "/")
+}
+
+// RawContentURL returns the url that can be used to download the raw content
+// of the file provided at the branch given. An empty string is returned when
+// the provider is unknown.
+func (u *GitRepoURL) RawContentURL(branch, filePath string) string {
+	baseURL := strings.TrimSuffix(u.BaseURL, ".git")
+	filePath = strings.TrimPrefix(filePath, "/")
+	switch u.Provider {
+	case GitProviderBitbucket, GitProviderGitHub:
+		return fmt.Sprintf("%s/raw/%s/%s", baseURL, branch, filePath)
+	case GitProviderGitea:
+		return fmt.Sprintf("%s/raw/branch/%s/%s", baseURL, branch, filePath)
+	case GitProviderGitLab:
+		return fmt.Sprintf("%s/-/raw/%s/%s", baseURL, branch, filePath)
+	default:
+		return ""
+	}
+}
+
+// APIContentURL returns the url of the provider's API endpoint that can be
+// used to download the raw content of the file provided at the branch given.
+// It is used as a fallback when the raw content url is not available (i.e.
+// private repositories in some providers). An empty string is returned when
+// the provider is unknown.
+func (u *GitRepoURL) APIContentURL(branch, filePath string) string {
+	filePath = strings.TrimPrefix(filePath, "/")
+	ref := url.QueryEscape(branch)
+	switch u.Provider {
+	case GitProviderBitbucket:
+		if u.Host == "bitbucket.org" {
+			return fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/src/%s/%s", u.Owner, u.Name, branch, filePath)
+		}
+		return fmt.Sprintf("https://%s/rest/api/1.0/projects/%s/repos/%s/raw/%s?at=%s", u.Host, u.Owner, u.Name, filePath, ref)
+	case GitProviderGitea:
+		return fmt.Sprintf("https://%s/api/v1/repos/%s/%s/raw/%s?ref=%s", u.Host, u.Owner, u.Name, filePath, ref)
+	case GitProviderGitHub:
+		apiBase := "https://api.github.com"
+		if u.Host != "github.com" {
+			apiBase = "https://" + u.Host + "/api/v3"
+		}
+		return fmt.Sprintf("%s/repos/%s/%s/contents/%s?ref=%s", apiBase, u.Owner, u.Name, filePath, ref)
+	case GitProviderGitLab:
+		projectID := url.PathEscape(path.Join(u.Owner, u.Name))
+		return fmt.Sprintf("https://%s/api/v4/projects/%s/repository/files/%s/raw?ref=%s", u.Host, projectID, url.PathEscape(filePath), ref)
+	default:
+		return ""
+	}
+}
+
+// SetAPIRequestAuth sets the credentials of the repository provided in the
+// request to the provider's API given, as well as any other header required.
+func (u *GitRepoURL) SetAPIRequestAuth(req *http.Request, r *hub.Repository) {
+	if u.Provider == GitProviderGitHub {
+		req.Header.Set("Accept", "application/vnd.github.v3.raw")
+	}
+	switch {
+	case r.AuthPass == "":
+	case r.AuthUser != "":
+		req.SetBasicAuth(r.AuthUser, r.AuthPass)
+	case u.Provider == GitProviderGitea:
+		req.Header.Set("Authorization", "token "+r.AuthPass)
+	default:
+		req.Header.Set("Authorization", "Bearer "+r.AuthPass)
+	}
+}
+
+// GitCloneAuth returns the credentials that should be used to access the git
+// repository provided, or nil when no credentials are needed. The token must
+// be provided in the repository password. When no username is provided, the
+// one expected by the provider for tokens is used.
+func GitCloneAuth(r *hub.Repository) *githttp.BasicAuth {
+	if r.AuthPass == "" {
+		return nil
+	}
+	username := r.AuthUser
+	if username == "" {
+		username = "artifact-hub"
+		if u, err := ParseGitRepoURL(r.URL); err == nil {
+			switch u.Provider {
+			case GitProviderBitbucket:
+				username = "x-token-auth"
+			case GitProviderGitLab:
+				username = "oauth2"
+			}
+		}
+	}
+	return &githttp.BasicAuth{
+		Username: username,
+		Password: r.AuthPass,
+	}
+}
@@ -0,0 +1,224 @@
+package repo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterGitProviders(t *testing.T) {
+	t.Run("invalid provider", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("gitProviders", []map[string]string{
+			{"host": "git.invalid.com", "provider": "invalid"},
+		})
+		err := RegisterGitProviders(cfg)
+		assert.Error(t, err)
+		assert.Equal(t, GitProvider(""), GetGitProvider("git.invalid.com"))
+	})
+
+	t.Run("host not provided", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("gitProviders", []map[string]string{
+			{"provider": "gitlab"},
+		})
+		err := RegisterGitProviders(cfg)
+		assert.Error(t, err)
+	})
+
+	t.Run("self-hosted providers registered successfully", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("gitProviders", []map[string]string{
+			{"host": "git.registered.com", "provider": "gitea"},
+			{"host": "GitLab.Registered.com", "provider": "gitlab"},
+		})
+		err := RegisterGitProviders(cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, GitProviderGitea, GetGitProvider("git.registered.com"))
+		assert.Equal(t, GitProviderGitLab, GetGitProvider("gitlab.registered.com"))
+	})
+}
+
+func TestParseGitRepoURL(t *testing.T) {
+	t.Run("invalid url", func(t *testing.T) {
+		t.Parallel()
+		_, err := ParseGitRepoURL("https://github.com/org1")
+		assert.Error(t, err)
+	})
+
+	t.Run("valid urls", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			url      string
+			expected *GitRepoURL
+		}{
+			{
+				"https://github.com/org1/repo1",
+				&GitRepoURL{
+					BaseURL:  "https://github.com/org1/repo1",
+					Host:     "github.com",
+					Owner:    "org1",
+					Name:     "repo1",
+					Provider: GitProviderGitHub,
+				},
+			},
+			{
+				"https://gitlab.com/org1/repo1.git/path/to/pkgs/",
+				&GitRepoURL{
+					BaseURL:      "https://gitlab.com/org1/repo1.git",
+					Host:         "gitlab.com",
+					Owner:        "org1",
+					Name:         "repo1",
+					PackagesPath: "path/to/pkgs",
+					Provider:     GitProviderGitLab,
+				},
+			},
+			{
+				"https://git.unknown.com/org1/repo1/pkgs",
+				&GitRepoURL{
+					BaseURL:      "https://git.unknown.com/org1/repo1",
+					Host:         "git.unknown.com",
+					Owner:        "org1",
+					Name:         "repo1",
+					PackagesPath: "pkgs",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			u, err := ParseGitRepoURL(tc.url)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, u)
+		}
+	})
+}
+
+func TestGitRepoURLs(t *testing.T) {
+	testCases := []struct {
+		url                   string
+		expectedBlobURL       string
+		expectedTreeURL       string
+		expectedRawContentURL string
+		expectedAPIContentURL string
+	}{
+		{
+			"https://bitbucket.org/org1/repo1",
+			"https://bitbucket.org/org1/repo1/src/main/pkgs/file.yml",
+			"https://bitbucket.org/org1/repo1/src/v1.0.0/pkgs",
+			"https://bitbucket.org/org1/repo1/raw/main/pkgs/file.yml",
+			"https://api.bitbucket.org/2.0/repositories/org1/repo1/src/main/pkgs/file.yml",
+		},
+		{
+			"https://codeberg.org/org1/repo1",
+			"https://codeberg.org/org1/repo1/src/main/pkgs/file.yml",
+			"https://codeberg.org/org1/repo1/src/v1.0.0/pkgs",
+			"https://codeberg.org/org1/repo1/raw/branch/main/pkgs/file.yml",
+			"https://codeberg.org/api/v1/repos/org1/repo1/raw/pkgs/file.yml?ref=main",
+		},
+		{
+			"https://github.com/org1/repo1",
+			"https://github.com/org1/repo1/blob/main/pkgs/file.yml",
+			"https://github.com/org1/repo1/tree/v1.0.0/pkgs",
+			"https://github.com/org1/repo1/raw/main/pkgs/file.yml",
+			"https://api.github.com/repos/org1/repo1/contents/pkgs/file.yml?ref=main",
+		},
+		{
+			"https://gitlab.com/org1/repo1.git",
+			"https://gitlab.com/org1/repo1/-/blob/main/pkgs/file.yml",
+			"https://gitlab.com/org1/repo1/-/tree/v1.0.0/pkgs",
+			"https://gitlab.com/org1/repo1/-/raw/main/pkgs/file.yml",
+			"https://gitlab.com/api/v4/projects/org1%2Frepo1/repository/files/pkgs%2Ffile.yml/raw?ref=main",
+		},
+		{
+			"https://git.unknown.com/org1/repo1",
+			"",
+			"",
+			"",
+			"",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.url, func(t *testing.T) {
+			t.Parallel()
+			u, err := ParseGitRepoURL(tc.url)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBlobURL, u.BlobURL("main", "pkgs/file.yml"))
+			assert.Equal(t, tc.expectedTreeURL, u.TreeURL("v1.0.0", "/pkgs/"))
+			assert.Equal(t, tc.expectedRawContentURL, u.RawContentURL("main", "/pkgs/file.yml"))
+			assert.Equal(t, tc.expectedAPIContentURL, u.APIContentURL("main", "pkgs/file.yml"))
+		})
+	}
+
+	t.Run("self-hosted instances api urls", func(t *testing.T) {
+		t.Parallel()
+		u := &GitRepoURL{Host: "git.example.com", Owner: "org1", Name: "repo1"}
+
+		u.Provider = GitProviderBitbucket
+		assert.Equal(t,
+			"https://git.example.com/rest/api/1.0/projects/org1/repos/repo1/raw/file.yml?at=main",
+			u.APIContentURL("main", "file.yml"),
+		)
+		u.Provider = GitProviderGitHub
+		assert.Equal(t,
+			"https://git.example.com/api/v3/repos/org1/repo1/contents/file.yml?ref=main",
+			u.APIContentURL("main", "file.yml"),
+		)
+	})
+}
+
+func TestSetAPIRequestAuth(t *testing.T) {
+	testCases := []struct {
+		provider              GitProvider
+		r                     *hub.Repository
+		expectedAuthorization string
+	}{
+		{GitProviderGitLab, &hub.Repository{}, ""},
+		{GitProviderGitLab, &hub.Repository{AuthPass: "token"}, "Bearer token"},
+		{GitProviderGitea, &hub.Repository{AuthPass: "token"}, "token token"},
+		{GitProviderBitbucket, &hub.Repository{AuthUser: "user", AuthPass: "pass"}, "Basic dXNlcjpwYXNz"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(string(tc.provider), func(t *testing.T) {
+			t.Parallel()
+			req, _ := http.NewRequest("GET", "https://git.example.com", nil)
+			u := &GitRepoURL{Provider: tc.provider}
+			u.SetAPIRequestAuth(req, tc.r)
+			assert.Equal(t, tc.expectedAuthorization, req.Header.Get("Authorization"))
+		})
+	}
+}
+
+func TestGitCloneAuth(t *testing.T) {
+	testCases := []struct {
+		r                *hub.Repository
+		expectedUsername string
+	}{
+		{&hub.Repository{URL: "https://github.com/org1/repo1", AuthPass: "token"}, "artifact-hub"},
+		{&hub.Repository{URL: "https://gitlab.com/org1/repo1", AuthPass: "token"}, "oauth2"},
+		{&hub.Repository{URL: "https://bitbucket.org/org1/repo1", AuthPass: "token"}, "x-token-auth"},
+		{&hub.Repository{URL: "https://gitlab.com/org1/repo1", AuthUser: "user", AuthPass: "token"}, "user"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.r.URL, func(t *testing.T) {
+			t.Parallel()
+			auth := GitCloneAuth(tc.r)
+			require.NotNil(t, auth)
+			assert.Equal(t, tc.expectedUsername, auth.Username)
+			assert.Equal(t, tc.r.AuthPass, auth.Password)
+		})
+	}
+
+	t.Run("no credentials", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, GitCloneAuth(&hub.Repository{URL: "https://github.com/org1/repo1"}))
+	})
+}
@@ -3,98 +3,183 @@ package repo
 import (
 	"crypto/sha256"
 	"encoding/hex"
-	"io/ioutil"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"path"
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
-	"helm.sh/helm/v3/pkg/getter"
+	"github.com/artifacthub/hub/internal/util"
+	yamlv3 "gopkg.in/yaml.v3"
 	helmrepo "helm.sh/helm/v3/pkg/repo"
 	"sigs.k8s.io/yaml"
 )
 
 const (
 	helmRepoIndexFile = "index.yaml"
+
+	// helmIndexFileTimeout represents the maximum time allowed to download and
+	// parse a Helm repository index file.
+	helmIndexFileTimeout = 60 * time.Second
 )
 
 // HelmIndexLoader provides a mechanism to load a Helm repository index file,
 // verifying it is valid.
-type HelmIndexLoader struct{}
+type HelmIndexLoader struct {
+	hc hub.HTTPClient
+}
 
 // LoadIndex downloads and parses the index file of the provided repository.
+//
+// Some repositories have index files that are tens of megabytes in size, so
+// the index file is decoded from the response body as it is being downloaded,
+// one chart at a time, instead of loading it entirely in memory first.
 func (l *HelmIndexLoader) LoadIndex(r *hub.Repository) (*helmrepo.IndexFile, string, error) {
-	repoConfig := &helmrepo.Entry{
-		Name:     r.Name,
-		URL:      r.URL,
-		Username: r.AuthUser,
-		Password: r.AuthPass,
-	}
-	getters := getter.Providers{
-		{
-			Schemes: []string{"http", "https"},
-			New: func(options ...getter.Option) (getter.Getter, error) {
-				return getter.NewHTTPGetter(getter.WithTimeout(10 * time.Second))
-			},
-		},
-	}
-	chartRepository, err := helmrepo.NewChartRepository(repoConfig, getters)
+	// Prepare index file url
+	parsedURL, err := url.Parse(r.URL)
 	if err != nil {
 		return nil, "", err
 	}
-	indexBytes, err := downloadIndexFile(chartRepository)
+	parsedURL.RawPath = path.Join(parsedURL.RawPath, helmRepoIndexFile)
+	parsedURL.Path = path.Join(parsedURL.Path, helmRepoIndexFile)
+	indexURL := parsedURL.String()
+
+	// Fetch index file from remote location
+	req, err := http.NewRequest("GET", indexURL, nil)
 	if err != nil {
 		return nil, "", err
 	}
-	indexFile, err := loadIndexFile(indexBytes)
+	if r.AuthUser != "" && r.AuthPass != "" {
+		req.SetBasicAuth(r.AuthUser, r.AuthPass)
+	}
+	hc := l.hc
+	if hc == nil {
+		hc = util.SetupHTTPClient(false, helmIndexFileTimeout)
+	}
+	resp, err := hc.Do(req)
 	if err != nil {
 		return nil, "", err
 	}
-	return indexFile, getDigest(indexBytes), nil
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to fetch %s : %s", indexURL, resp.Status)
+	}
+
+	// Parse index file
+	return loadIndexFile(resp.Body)
 }
 
-// downloadIndexFile downloads a Helm repository's index file.
-func downloadIndexFile(r *helmrepo.ChartRepository) ([]byte, error) {
-	// Prepare index file url
-	parsedURL, err := url.Parse(r.Config.URL)
-	if err != nil {
-		return nil, err
+// loadIndexFile reads and parses a Helm repository's index file from the
+// reader provided, returning the index file and its digest.
+//
+// The index file is decoded into a YAML nodes tree first, without buffering
+// its raw content. Then the versions of each chart in the entries section are
+// decoded independently, releasing the chart's nodes as soon as they have been
+// decoded, so that the nodes tree and the index file decoded are not entirely
+// held in memory at the same time. The digest is calculated from the index
+// file decoded, ignoring its generation time.
+func loadIndexFile(r io.Reader) (*helmrepo.IndexFile, string, error) {
+	// Decode index file into a nodes tree
+	var doc yamlv3.Node
+	if err := yamlv3.NewDecoder(r).Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, "", err
+	}
+	var root *yamlv3.Node
+	if len(doc.Content) > 0 {
+		root = doc.Content[0]
+	}
+	if root != nil && root.Kind != yamlv3.MappingNode {
+		return nil, "", errors.New("invalid index file: mapping expected")
 	}
-	parsedURL.RawPath = path.Join(parsedURL.RawPath, helmRepoIndexFile)
-	parsedURL.Path = path.Join(parsedURL.Path, helmRepoIndexFile)
-	indexURL := parsedURL.String()
 
-	// Fetch index file content from remote location
-	resp, err := r.Client.Get(indexURL,
-		getter.WithURL(r.Config.URL),
-		getter.WithBasicAuth(r.Config.Username, r.Config.Password),
-	)
-	if err != nil {
-		return nil, err
+	// Split entries section from the rest of the index file
+	rest := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+	var entries *yamlv3.Node
+	if root != nil {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			key, value := root.Content[i], root.Content[i+1]
+			if key.Kind == yamlv3.ScalarNode && key.Value == "entries" {
+				if entries != nil {
+					return nil, "", errors.New("invalid index file: duplicated entries")
+				}
+				entries = resolveAlias(value)
+				continue
+			}
+			rest.Content = append(rest.Content, key, value)
+		}
 	}
-	return ioutil.ReadAll(resp)
-}
 
-// loadIndexFile reads and parses a Helm repository's index file.
-func loadIndexFile(indexBytes []byte) (*helmrepo.IndexFile, error) {
+	// Decode the rest of the index file
 	indexFile := &helmrepo.IndexFile{}
-	if err := yaml.UnmarshalStrict(indexBytes, indexFile); err != nil {
-		return nil, err
+	if err := decodeNode(rest, indexFile); err != nil {
+		return nil, "", err
 	}
 	if indexFile.APIVersion == "" {
-		return nil, helmrepo.ErrNoAPIVersion
+		return nil, "", helmrepo.ErrNoAPIVersion
+	}
+
+	// Decode entries, one chart at a time
+	if entries != nil {
+		switch {
+		case entries.Kind == yamlv3.MappingNode:
+			indexFile.Entries = make(map[string]helmrepo.ChartVersions, len(entries.Content)/2)
+			for i := 0; i+1 < len(entries.Content); i += 2 {
+				name := entries.Content[i].Value
+				if _, ok := indexFile.Entries[name]; ok {
+					return nil, "", fmt.Errorf("invalid index file: duplicated entry %s", name)
+				}
+				var chartVersions helmrepo.ChartVersions
+				if err := decodeNode(entries.Content[i+1], &chartVersions); err != nil {
+					return nil, "", err
+				}
+				indexFile.Entries[name] = chartVersions
+				entries.Content[i+1] = nil
+			}
+		case entries.Tag == "!!null":
+		default:
+			return nil, "", errors.New("invalid index file: entries must be a mapping")
+		}
+	}
+
+	// Calculate digest ignoring the generation time. Entries are sorted
+	// afterwards, as the digest is calculated using their original order.
+	generated := indexFile.Generated
+	indexFile.Generated = time.Time{}
+	indexBytes, err := yaml.Marshal(indexFile)
+	if err != nil {
+		return nil, "", err
 	}
+	indexFile.Generated = generated
+	digest := sha256.Sum256(indexBytes)
 	indexFile.SortEntries()
-	return indexFile, nil
+
+	return indexFile, hex.EncodeToString(digest[:]), nil
 }
 
-// getDigest returns the digest of a Helm repository's index file.
-func getDigest(indexBytes []byte) string {
-	indexFile := &helmrepo.IndexFile{}
-	_ = yaml.UnmarshalStrict(indexBytes, indexFile)
-	indexFile.Generated = time.Time{}
-	indexBytes, _ = yaml.Marshal(indexFile)
-	hash := sha256.Sum256(indexBytes)
-	digest := hex.EncodeToString(hash[:])
-	return digest
+// decodeNode decodes the YAML node provided into the value given. Helm types
+// are meant to be decoded from their JSON representation, so the node is
+// decoded (resolving aliases) and encoded again before being strictly decoded
+// into the value as a standalone document.
+func decodeNode(n *yamlv3.Node, v interface{}) error {
+	var data interface{}
+	if err := n.Decode(&data); err != nil {
+		return err
+	}
+	dataBytes, err := yamlv3.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return yaml.UnmarshalStrict(dataBytes, v)
+}
+
+// resolveAlias returns the node an alias node points to. Any other node is
+// returned as is.
+func resolveAlias(n *yamlv3.Node) *yamlv3.Node {
+	if n.Kind == yamlv3.AliasNode && n.Alias != nil {
+		return n.Alias
+	}
+	return n
 }
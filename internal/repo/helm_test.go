@@ -0,0 +1,224 @@
+package repo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	helmrepo "helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+)
+
+func TestHelmIndexLoaderLoadIndex(t *testing.T) {
+	indexBytes, err := ioutil.ReadFile(filepath.Join("testdata", "helm", "index.yaml"))
+	require.NoError(t, err)
+
+	t.Run("index file downloaded and parsed successfully", func(t *testing.T) {
+		t.Parallel()
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/charts/index.yaml", r.URL.Path)
+			user, pass, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "user", user)
+			assert.Equal(t, "pass", pass)
+			_, _ = w.Write(indexBytes)
+		}))
+		defer s.Close()
+
+		l := &HelmIndexLoader{}
+		indexFile, digest, err := l.LoadIndex(&hub.Repository{
+			URL:      s.URL + "/charts",
+			AuthUser: "user",
+			AuthPass: "pass",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expectedIndexFile(t, indexBytes), indexFile)
+		assert.NotEmpty(t, digest)
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		t.Parallel()
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer s.Close()
+
+		l := &HelmIndexLoader{}
+		indexFile, digest, err := l.LoadIndex(&hub.Repository{URL: s.URL})
+		assert.Error(t, err)
+		assert.Nil(t, indexFile)
+		assert.Empty(t, digest)
+	})
+}
+
+func TestLoadIndexFile(t *testing.T) {
+	t.Run("block and json formatted index files", func(t *testing.T) {
+		t.Parallel()
+		for _, name := range []string{"index.yaml", "index.json"} {
+			indexBytes, err := ioutil.ReadFile(filepath.Join("testdata", "helm", name))
+			require.NoError(t, err)
+			indexFile, _, err := loadIndexFile(bytes.NewReader(indexBytes))
+			require.NoError(t, err)
+			assert.Equal(t, expectedIndexFile(t, indexBytes), indexFile)
+		}
+	})
+
+	t.Run("index files using different yaml styles", func(t *testing.T) {
+		t.Parallel()
+		testCases := map[string]string{
+			"anchors and aliases shared across charts": `
+apiVersion: v1
+entries:
+  pkg1:
+    - &pkg1v1
+      apiVersion: v2
+      name: pkg1
+      version: 1.0.0
+      urls: &urls
+        - https://repo.url/charts.tgz
+  pkg2:
+    - *pkg1v1
+    - apiVersion: v2
+      name: pkg2
+      version: 2.0.0
+      urls: *urls
+`,
+			"flow style entries": `
+apiVersion: v1
+entries: {pkg1: [{apiVersion: v2, name: pkg1, version: 1.0.0}], pkg2: [{apiVersion: v2, name: pkg2, version: 0.1.0}]}
+`,
+			"entries indented differently": `
+apiVersion: v1
+entries:
+        pkg1:
+            -   apiVersion: v2
+                name: pkg1
+                version: 1.0.0
+        pkg2:
+         - apiVersion: v2
+           name: pkg2
+           version: 0.1.0
+`,
+			"quoted keys containing colons": `
+"apiVersion": v1
+"entries":
+  "pkg:1":
+    - apiVersion: v2
+      name: "pkg:1"
+      version: 1.0.0
+      annotations:
+        "artifacthub.io/links: x": "value: y"
+  'pkg2': []
+`,
+		}
+		for name, index := range testCases {
+			index := index
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				indexBytes := []byte(index)
+				indexFile, digest, err := loadIndexFile(bytes.NewReader(indexBytes))
+				require.NoError(t, err)
+				assert.Equal(t, expectedIndexFile(t, indexBytes), indexFile)
+				assert.Equal(t, expectedDigest(t, indexBytes), digest)
+			})
+		}
+	})
+
+	t.Run("digest matches the one of the index file decoded at once", func(t *testing.T) {
+		t.Parallel()
+		for _, name := range []string{"index.yaml", "index.json"} {
+			indexBytes, err := ioutil.ReadFile(filepath.Join("testdata", "helm", name))
+			require.NoError(t, err)
+			_, digest, err := loadIndexFile(bytes.NewReader(indexBytes))
+			require.NoError(t, err)
+			assert.Equal(t, expectedDigest(t, indexBytes), digest)
+		}
+	})
+
+	t.Run("digest does not depend on formatting", func(t *testing.T) {
+		t.Parallel()
+		_, digest1, err := loadIndexFile(strings.NewReader(
+			"apiVersion: v1\nentries:\n  pkg1:\n  - name: pkg1\n    version: 1.0.0\n",
+		))
+		require.NoError(t, err)
+		_, digest2, err := loadIndexFile(strings.NewReader(
+			"# comment\nentries:\n    pkg1:\n        -   version: \"1.0.0\"\n            name: pkg1\n\napiVersion: v1\n",
+		))
+		require.NoError(t, err)
+		assert.Equal(t, digest1, digest2)
+	})
+
+	t.Run("digest does not depend on the generation time", func(t *testing.T) {
+		t.Parallel()
+		indexBytes, err := ioutil.ReadFile(filepath.Join("testdata", "helm", "index.yaml"))
+		require.NoError(t, err)
+		_, digest1, err := loadIndexFile(bytes.NewReader(indexBytes))
+		require.NoError(t, err)
+		indexBytes2 := bytes.Replace(indexBytes, []byte("2021-04-01"), []byte("2021-05-01"), 1)
+		_, digest2, err := loadIndexFile(bytes.NewReader(indexBytes2))
+		require.NoError(t, err)
+		assert.Equal(t, digest1, digest2)
+		indexBytes3 := bytes.Replace(indexBytes, []byte("version: 1.1.0"), []byte("version: 1.2.0"), 1)
+		_, digest3, err := loadIndexFile(bytes.NewReader(indexBytes3))
+		require.NoError(t, err)
+		assert.NotEqual(t, digest1, digest3)
+	})
+
+	t.Run("invalid index files", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			index       string
+			expectedErr error
+		}{
+			{"entries:\n  pkg1: []\n", helmrepo.ErrNoAPIVersion},
+			{"apiVersion: v1\nentries:\n  pkg1:\n    - name: pkg1\n      unknown: value\n", nil},
+			{"apiVersion: v1\nentries:\n  pkg1:\n    - name: [\n", nil},
+			{"apiVersion: v1\nunknown: value\n", nil},
+			{"apiVersion: v1\nentries:\n  pkg1: []\n  pkg1: []\n", nil},
+			{"apiVersion: v1\nentries: [pkg1]\n", nil},
+			{"- apiVersion: v1\n", nil},
+		}
+		for _, tc := range testCases {
+			indexFile, digest, err := loadIndexFile(strings.NewReader(tc.index))
+			assert.Error(t, err)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+			assert.Nil(t, indexFile)
+			assert.Empty(t, digest)
+		}
+	})
+}
+
+// expectedIndexFile returns the index file that results from parsing the
+// content provided at once.
+func expectedIndexFile(t *testing.T, indexBytes []byte) *helmrepo.IndexFile {
+	t.Helper()
+	indexFile := &helmrepo.IndexFile{}
+	require.NoError(t, yaml.UnmarshalStrict(indexBytes, indexFile))
+	indexFile.SortEntries()
+	return indexFile
+}
+
+// expectedDigest returns the digest of the index file provided, calculated
+// from the whole index file decoded at once ignoring its generation time.
+func expectedDigest(t *testing.T, indexBytes []byte) string {
+	t.Helper()
+	indexFile := &helmrepo.IndexFile{}
+	require.NoError(t, yaml.UnmarshalStrict(indexBytes, indexFile))
+	indexFile.Generated = time.Time{}
+	indexBytes, err := yaml.Marshal(indexFile)
+	require.NoError(t, err)
+	hash := sha256.Sum256(indexBytes)
+	return hex.EncodeToString(hash[:])
+}
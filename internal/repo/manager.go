@@ -1,11 +1,16 @@
 package repo
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html/template"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -13,38 +18,61 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	_ "embed" // Used by templates
+
+	"github.com/artifacthub/hub/internal/cache"
+	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/metadata"
 	"github.com/artifacthub/hub/internal/oci"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
-	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/log"
 	"github.com/satori/uuid"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v2"
 )
 
 const (
 	// Database queries
 	addRepoDBQ                = `select add_repository($1::uuid, $2::text, $3::jsonb)`
+	approveRepoChangeDBQ      = `select approve_repository_change($1::uuid, $2::text, $3::uuid)`
 	checkRepoNameAvailDBQ     = `select repository_id from repository where name = $1`
 	checkRepoURLAvailDBQ      = `select repository_id from repository where trim(trailing '/' from url) = $1`
+	checkClaimTokenDBQ        = `select exists (select 1 from repository_ownership_claim_token where repository_id = $1 and user_id = $2 and token = $3 and created_at > current_timestamp - '7 days'::interval)`
 	deleteRepoDBQ             = `select delete_repository($1::uuid, $2::text)`
+	getPendingRepoChangesDBQ  = `select get_pending_repository_changes($1::uuid, $2::text)`
 	getRepoByIDDBQ            = `select get_repository_by_id($1::uuid, $2::boolean)`
+	getRepoEventsDBQ          = `select get_repository_events($1::uuid, $2::text, $3::jsonb)`
 	getRepoByNameDBQ          = `select get_repository_by_name($1::text, $2::boolean)`
+	getRepoChangeDBQ          = `select rc.kind, rc.requested_by from repository_change rc join organization o using (organization_id) where o.name = $1 and rc.repository_change_id = $2`
+	getRepoChangeApproversDBQ = `select get_repository_change_approvers($1::uuid)`
 	getRepoPkgsDigestDBQ      = `select get_repository_packages_digest($1::uuid)`
+	getRepoStatsDBQ           = `select get_repository_stats($1::uuid, $2::text, $3::date, $4::date)`
+	getRepoSubscriptionsDBQ   = `select get_repository_subscriptions($1::uuid, $2::text)`
+	getRepoViewsDBQ           = `select get_repository_views($1::uuid, $2::text, $3::date, $4::date)`
 	getUserEmailDBQ           = `select email from "user" where user_id = $1`
+	isApprovalRequiredDBQ     = `select repository_changes_approval from organization where name = $1`
+	isRepoMaintainerDBQ       = `select exists (select 1 from package p join package__maintainer pm using (package_id) join maintainer m using (maintainer_id) join "user" u on u.email = m.email where p.repository_id = $1 and u.user_id = $2 and u.email_verified = true)`
+	registerClaimTokenDBQ     = `select register_repository_ownership_claim_token($1::uuid, $2::uuid, $3::text)`
+	registerPkgsDownloadsDBQ  = `select register_packages_downloads($1::uuid, $2::text, $3::jsonb)`
+	registerRepoChangeDBQ     = `select register_repository_change($1::uuid, $2::text, $3::jsonb)`
+	registerRepoKindDBQ       = `select register_repository_kind($1::integer, $2::text)`
+	registerTrackingRunDBQ    = `select register_repository_tracking_run($1::uuid, $2::real, $3::text)`
+	rejectRepoChangeDBQ       = `select reject_repository_change($1::uuid, $2::text, $3::uuid)`
 	searchRepositoriesDBQ     = `select * from search_repositories($1::jsonb)`
 	setLastScanningResultsDBQ = `select set_last_scanning_results($1::uuid, $2::text, $3::boolean)`
-	setLastTrackingResultsDBQ = `select set_last_tracking_results($1::uuid, $2::text, $3::boolean)`
+	setLastTrackingResultsDBQ = `select set_last_tracking_results($1::uuid, $2::text, $3::jsonb, $4::boolean)`
 	setVerifiedPublisherDBQ   = `select set_verified_publisher($1::uuid, $2::boolean)`
-	transferRepoDBQ           = `select transfer_repository($1::text, $2::uuid, $3::text, $4::boolean)`
+	transferRepoDBQ           = `select transfer_repository($1::text, $2::uuid, $3::text, $4::text)`
 	updateRepoDBQ             = `select update_repository($1::uuid, $2::jsonb)`
 	updateRepoDigestDBQ       = `update repository set digest = $2 where repository_id = $1`
 )
@@ -56,14 +84,44 @@ const (
 
 	artifacthubTag        = "artifacthub.io"
 	maxContainerImageTags = 10
+
+	// maxPackagesDownloadsEntries represents the maximum number of downloads
+	// entries that can be registered in a single request.
+	maxPackagesDownloadsEntries = 5000
+
+	// defaultEventsLimit represents the number of events returned per page
+	// when no limit is provided.
+	defaultEventsLimit = 20
+
+	// maxEventsLimit represents the maximum number of events that can be
+	// requested per page.
+	maxEventsLimit = 100
+)
+
+const (
+	// claimMethodMaintainer represents the ownership claim method used when
+	// the requesting user is a maintainer of any of the repository packages.
+	claimMethodMaintainer = "maintainer"
+
+	// claimMethodOwners represents the ownership claim method used when the
+	// requesting user is listed as an owner in the repository metadata file.
+	claimMethodOwners = "owners"
+
+	// claimMethodToken represents the ownership claim method used when the
+	// repository metadata file contains an ownership claim token previously
+	// registered by the requesting user.
+	claimMethodToken = "token"
 )
 
+//go:embed template/change_request_email.tmpl
+var changeRequestEmailTmpl string
+
 var (
 	// repositoryNameRE is a regexp used to validate a repository name.
 	repositoryNameRE = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
 
 	// ErrInvalidMetadata indicates that the repository metadata is not valid.
-	ErrInvalidMetadata = errors.New("invalid metadata")
+	ErrInvalidMetadata = metadata.ErrInvalidMetadata
 
 	// ErrMetadataNotFound indicates that the repository metadata was not found.
 	ErrMetadataNotFound = errors.New("metadata not found")
@@ -72,6 +130,10 @@ var (
 	// repository url is not supported.
 	ErrSchemeNotSupported = errors.New("scheme not supported")
 
+	// errRepoVersionConflict indicates that the repository being updated has
+	// been modified since the version provided was read.
+	errRepoVersionConflict = fmt.Errorf("%w: %s", hub.ErrConflict, "repository modified since it was read")
+
 	// GitRepoURLRE is a regexp used to validate and parse an http based git
 	// repository URL.
 	GitRepoURLRE = regexp.MustCompile(`^(https:\/\/([A-Za-z0-9_.-]+)\/[A-Za-z0-9_.-]+\/[A-Za-z0-9_.-]+)\/?(.*)$`)
@@ -91,19 +153,45 @@ var (
 		hub.TBAction,
 		hub.TektonTask,
 		hub.TektonPipeline,
+		hub.Terraform,
+		hub.Crossplane,
+		hub.Kyverno,
+		hub.KnativeFunc,
+		hub.Headlamp,
+	}
+
+	// validTrackingRunOutcomes contains the valid outcomes of a tracker run.
+	validTrackingRunOutcomes = []string{
+		"success",
+		"error",
+		"timeout",
 	}
 )
 
+// repositoryChange represents a change in a repository owned by an
+// organization that must be approved before being applied.
+type repositoryChange struct {
+	Kind                     hub.RepositoryChangeKind `json:"kind"`
+	RepositoryName           string                   `json:"repository_name"`
+	Repository               *hub.Repository          `json:"repository,omitempty"`
+	TransferOrganizationName string                   `json:"transfer_organization_name,omitempty"`
+	OwnershipClaimMethod     string                   `json:"ownership_claim_method,omitempty"`
+}
+
 // Manager provides an API to manage repositories.
 type Manager struct {
-	cfg *viper.Viper
-	db  hub.DB
-	hc  hub.HTTPClient
-	rc  hub.RepositoryCloner
-	il  hub.HelmIndexLoader
-	tg  hub.OCITagsGetter
-	op  hub.OCIPuller
-	az  hub.Authorizer
+	cfg   *viper.Viper
+	db    hub.DB
+	hc    hub.HTTPClient
+	rc    hub.RepositoryCloner
+	il    hub.HelmIndexLoader
+	tg    hub.OCITagsGetter
+	op    hub.OCIPuller
+	az    hub.Authorizer
+	es    hub.EmailSender
+	cache hub.Cache
+
+	changeRequestTmpl *template.Template
 }
 
 // NewManager creates a new Manager instance.
@@ -123,6 +211,8 @@ func NewManager(
 		op:  &oci.Puller{},
 		az:  az,
 		hc:  hc,
+
+		changeRequestTmpl: email.ParseTemplate(changeRequestEmailTmpl),
 	}
 	for _, o := range opts {
 		o(m)
@@ -160,7 +250,27 @@ func WithOCIPuller(p hub.OCIPuller) func(m *Manager) {
 	}
 }
 
-// Add adds the provided repository to the database.
+// WithCache allows providing a Cache implementation for a Manager instance.
+// The cache entries that include data of a repository are invalidated when
+// it is updated.
+func WithCache(c hub.Cache) func(m *Manager) {
+	return func(m *Manager) {
+		m.cache = c
+	}
+}
+
+// WithEmailSender allows providing an EmailSender implementation for a Manager
+// instance, used to notify the approvers of repositories changes.
+func WithEmailSender(es hub.EmailSender) func(m *Manager) {
+	return func(m *Manager) {
+		m.es = es
+	}
+}
+
+// Add adds the provided repository to the database. When the repository will
+// be added to an organization that requires changes to be approved, the
+// addition is registered as a pending change and hub.ErrPendingApproval is
+// returned.
 func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
 
@@ -193,6 +303,19 @@ func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) er
 		}); err != nil {
 			return err
 		}
+
+		// Register change if it must be approved first
+		approvalRequired, err := m.isApprovalRequired(ctx, orgName)
+		if err != nil {
+			return err
+		}
+		if approvalRequired {
+			return m.requestChange(ctx, orgName, &repositoryChange{
+				Kind:           hub.RepositoryChangeAdd,
+				RepositoryName: r.Name,
+				Repository:     r,
+			})
+		}
 	}
 
 	// Add repository to the database
@@ -204,6 +327,49 @@ func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) er
 	return err
 }
 
+// ApproveChange approves the pending repository change provided, applying it.
+// Changes must be approved by a member of the organization other than the one
+// who requested them.
+func (m *Manager) ApproveChange(ctx context.Context, orgName, changeID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateChangeInput(orgName, changeID); err != nil {
+		return err
+	}
+
+	// Get change details
+	kind, requestedBy, err := m.getChange(ctx, orgName, changeID)
+	if err != nil {
+		return err
+	}
+	if requestedBy == userID {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "changes must be approved by a different member")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           changeAction(kind),
+	}); err != nil {
+		return err
+	}
+
+	// Apply change in database
+	_, err = m.db.Exec(ctx, approveRepoChangeDBQ, userID, orgName, changeID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBVersionConflict.Error():
+			return errRepoVersionConflict
+		}
+		return err
+	}
+	return nil
+}
+
 // CheckAvailability checks the availability of a given value for the provided
 // resource kind.
 func (m *Manager) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
@@ -245,7 +411,14 @@ func (m *Manager) CheckAvailability(ctx context.Context, resourceKind, value str
 
 // ClaimOwnership allows a user to claim the ownership of a given repository.
 // The repository will be transferred to the destination entity requested if
-// the user is listed as one of the owners in the repository metadata file.
+// the user is listed as one of the owners in the repository metadata file or
+// the metadata file contains an ownership claim token previously registered
+// by the user. When the repository is owned by an organization that requires
+// changes to be approved, the transfer is registered as a pending change and
+// hub.ErrPendingApproval is returned. Verified maintainers of any of the
+// packages in the repository can also claim repositories owned by
+// organizations, but the transfer must always be approved by a member of the
+// organization.
 func (m *Manager) ClaimOwnership(ctx context.Context, repoName, orgName string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
 
@@ -260,6 +433,25 @@ func (m *Manager) ClaimOwnership(ctx context.Context, repoName, orgName string)
 		return err
 	}
 
+	// Request the repository transfer if the requesting user's verified email
+	// matches the one of any of the maintainers of the repository packages.
+	// Being a maintainer does not prove control over the repository, so the
+	// transfer must always be approved by a member of the owner organization.
+	if r.OrganizationName != "" {
+		var isMaintainer bool
+		if err := m.db.QueryRow(ctx, isRepoMaintainerDBQ, r.RepositoryID, userID).Scan(&isMaintainer); err != nil {
+			return err
+		}
+		if isMaintainer {
+			return m.requestChange(ctx, r.OrganizationName, &repositoryChange{
+				Kind:                     hub.RepositoryChangeTransfer,
+				RepositoryName:           repoName,
+				TransferOrganizationName: orgName,
+				OwnershipClaimMethod:     claimMethodMaintainer,
+			})
+		}
+	}
+
 	// Some extra validation
 	u, _ := url.Parse(r.URL)
 	if r.Kind == hub.OLM && SchemeIsOCI(u) {
@@ -267,7 +459,7 @@ func (m *Manager) ClaimOwnership(ctx context.Context, repoName, orgName string)
 	}
 
 	// Get repository metadata
-	var basePath string
+	var md *hub.RepositoryMetadata
 	switch r.Kind {
 	case
 		hub.CoreDNS,
@@ -280,15 +472,25 @@ func (m *Manager) ClaimOwnership(ctx context.Context, repoName, orgName string)
 		hub.OPA,
 		hub.TBAction,
 		hub.TektonTask,
-		hub.TektonPipeline:
-		tmpDir, packagesPath, err := m.rc.CloneRepository(ctx, r)
-		if err != nil {
-			return err
+		hub.TektonPipeline,
+		hub.Terraform,
+		hub.Crossplane,
+		hub.Kyverno,
+		hub.KnativeFunc,
+		hub.Headlamp:
+		var fetched bool
+		md, fetched, err = m.fetchGitRepositoryMetadata(ctx, r)
+		if !fetched {
+			tmpDir, packagesPath, cloneErr := m.rc.CloneRepository(ctx, r)
+			if cloneErr != nil {
+				return cloneErr
+			}
+			defer os.RemoveAll(tmpDir)
+			md, err = m.GetMetadata(r, filepath.Join(tmpDir, packagesPath))
 		}
-		defer os.RemoveAll(tmpDir)
-		basePath = filepath.Join(tmpDir, packagesPath)
+	default:
+		md, err = m.GetMetadata(r, "")
 	}
-	md, err := m.GetMetadata(r, basePath)
 	if err != nil {
 		return fmt.Errorf("%w: error getting repository metadata: %v", hub.ErrInsufficientPrivilege, err)
 	}
@@ -303,13 +505,30 @@ func (m *Manager) ClaimOwnership(ctx context.Context, repoName, orgName string)
 	// repository owners in the metadata file
 	for _, owner := range md.Owners {
 		if owner.Email == userEmail {
-			return m.Transfer(ctx, repoName, orgName, true)
+			return m.transfer(ctx, repoName, r.OrganizationName, orgName, claimMethodOwners)
+		}
+	}
+
+	// Transfer repository if the metadata file contains a valid ownership
+	// claim token registered by the requesting user
+	if md.OwnershipClaimToken != "" {
+		var validToken bool
+		err := m.db.QueryRow(ctx, checkClaimTokenDBQ, r.RepositoryID, userID, hash(md.OwnershipClaimToken)).Scan(&validToken)
+		if err != nil {
+			return err
+		}
+		if validToken {
+			return m.transfer(ctx, repoName, r.OrganizationName, orgName, claimMethodToken)
 		}
 	}
+
 	return hub.ErrInsufficientPrivilege
 }
 
-// Delete deletes the provided repository from the database.
+// Delete deletes the provided repository from the database. When the
+// repository is owned by an organization that requires changes to be
+// approved, the deletion is registered as a pending change and
+// hub.ErrPendingApproval is returned.
 func (m *Manager) Delete(ctx context.Context, name string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
 
@@ -331,14 +550,30 @@ func (m *Manager) Delete(ctx context.Context, name string) error {
 		}); err != nil {
 			return err
 		}
+
+		// Register change if it must be approved first
+		approvalRequired, err := m.isApprovalRequired(ctx, r.OrganizationName)
+		if err != nil {
+			return err
+		}
+		if approvalRequired {
+			return m.requestChange(ctx, r.OrganizationName, &repositoryChange{
+				Kind:           hub.RepositoryChangeDelete,
+				RepositoryName: name,
+			})
+		}
 	}
 
 	// Delete repository from database
 	_, err = m.db.Exec(ctx, deleteRepoDBQ, userID, name)
-	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
-		return hub.ErrInsufficientPrivilege
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return hub.ErrInsufficientPrivilege
+		}
+		return err
 	}
-	return err
+	cache.Invalidate(ctx, m.cache, cache.RepositoryTag(name), cache.SearchTag, cache.StatsTag)
+	return nil
 }
 
 // GetByID returns the repository identified by the id provided.
@@ -378,6 +613,84 @@ func (m *Manager) GetByName(
 	return r, err
 }
 
+// eventsCursor represents the position of the last event returned in a page
+// of repository events. It's provided to clients as an opaque string.
+type eventsCursor struct {
+	CreatedAt string `json:"created_at"`
+	EventID   string `json:"event_id"`
+}
+
+// GetEventsJSON returns a json object with the events registered for the
+// repository provided (and for its packages), from the most recent to the
+// oldest one. When more events are available, the cursor to get the next page
+// of results is included in the json object.
+func (m *Manager) GetEventsJSON(
+	ctx context.Context,
+	name string,
+	input *hub.GetRepositoryEventsInput,
+) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if input.Limit < 0 || input.Limit > maxEventsLimit {
+		return nil, fmt.Errorf("%w: %s (max: %d)", hub.ErrInvalidInput, "invalid limit", maxEventsLimit)
+	}
+	var cursor *eventsCursor
+	if input.Cursor != "" {
+		var err error
+		cursor, err = decodeEventsCursor(input.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid cursor")
+		}
+	}
+
+	// Get repository events from database
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultEventsLimit
+	}
+	dbInput := map[string]interface{}{
+		"limit": limit,
+	}
+	if cursor != nil {
+		dbInput["cursor"] = cursor
+	}
+	if len(input.EventKinds) > 0 {
+		dbInput["event_kinds"] = input.EventKinds
+	}
+	if input.PackageName != "" {
+		dbInput["package_name"] = input.PackageName
+	}
+	dbInputJSON, _ := json.Marshal(dbInput)
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getRepoEventsDBQ, userID, name, dbInputJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace the position of the last event with an opaque cursor
+	var result struct {
+		Events json.RawMessage `json:"events"`
+		Next   *eventsCursor   `json:"next"`
+	}
+	if err := json.Unmarshal(dataJSON, &result); err != nil {
+		return nil, err
+	}
+	var nextCursor string
+	if result.Next != nil {
+		nextCursor = encodeEventsCursor(result.Next)
+	}
+	return json.Marshal(struct {
+		Events     json.RawMessage `json:"events"`
+		NextCursor string          `json:"next_cursor,omitempty"`
+	}{
+		Events:     result.Events,
+		NextCursor: nextCursor,
+	})
+}
+
 // GetMetadata reads and parses the metadata file of the repository provided.
 // When needed, the repository must be previously cloned and the path pointing
 // to the location of the packages must be provided (basePath).
@@ -410,17 +723,78 @@ func (m *Manager) GetMetadata(r *hub.Repository, basePath string) (*hub.Reposito
 	}
 
 	// Parse and validate metadata
-	var md *hub.RepositoryMetadata
-	if err = yaml.Unmarshal(data, &md); err != nil || md == nil {
-		return nil, fmt.Errorf("error unmarshaling repository metadata file: %w", err)
+	return metadata.ParseRepositoryMetadata(data)
+}
+
+// fetchGitRepositoryMetadata fetches the metadata of the git based repository
+// provided directly from its git provider, avoiding cloning the whole
+// repository. The raw content url is tried first, using the provider's API as
+// a fallback. It returns false when the metadata could not be fetched this
+// way (i.e. unknown provider), in which case the repository must be cloned.
+func (m *Manager) fetchGitRepositoryMetadata(
+	ctx context.Context,
+	r *hub.Repository,
+) (*hub.RepositoryMetadata, bool, error) {
+	u, err := ParseGitRepoURL(r.URL)
+	if err != nil || u.Provider == "" {
+		return nil, false, nil
 	}
-	if md.RepositoryID != "" {
-		if _, err := uuid.FromString(md.RepositoryID); err != nil {
-			return nil, fmt.Errorf("%w: %s", ErrInvalidMetadata, "invalid repository id")
-		}
+	data, err := m.fetchGitMetadataFile(ctx, u, r)
+	switch {
+	case err == nil:
+		md, err := metadata.ParseRepositoryMetadata(data)
+		return md, true, err
+	case errors.Is(err, ErrMetadataNotFound):
+		return nil, true, err
+	default:
+		return nil, false, nil
 	}
+}
 
-	return md, nil
+// fetchGitMetadataFile fetches the metadata file of the repository provided
+// from its git provider. ErrMetadataNotFound is returned when all the urls
+// tried reported that the file does not exist.
+func (m *Manager) fetchGitMetadataFile(
+	ctx context.Context,
+	u *GitRepoURL,
+	r *hub.Repository,
+) ([]byte, error) {
+	branch := GetBranch(r)
+	var lastErr error
+	for _, extension := range []string{".yml", ".yaml"} {
+		mdFile := path.Join(u.PackagesPath, hub.RepositoryMetadataFile+extension)
+		for _, api := range []bool{false, true} {
+			var reqURL string
+			if api {
+				reqURL = u.APIContentURL(branch, mdFile)
+			} else {
+				reqURL = u.RawContentURL(branch, mdFile)
+			}
+			req, _ := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+			if api {
+				u.SetAPIRequestAuth(req, r)
+			}
+			resp, err := m.hc.Do(req)
+			if err != nil {
+				lastErr = fmt.Errorf("error downloading repository metadata file: %w", err)
+				continue
+			}
+			data, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			switch {
+			case resp.StatusCode == http.StatusOK && err == nil:
+				return data, nil
+			case resp.StatusCode == http.StatusOK:
+				lastErr = fmt.Errorf("error reading repository metadata file: %w", err)
+			case resp.StatusCode != http.StatusNotFound:
+				lastErr = fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+			}
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, ErrMetadataNotFound
 }
 
 // locateMetadataFile returns the location of the metadata file for the
@@ -450,7 +824,12 @@ func (m *Manager) locateMetadataFile(r *hub.Repository, basePath string) string
 		hub.OPA,
 		hub.TBAction,
 		hub.TektonTask,
-		hub.TektonPipeline:
+		hub.TektonPipeline,
+		hub.Terraform,
+		hub.Crossplane,
+		hub.Kyverno,
+		hub.KnativeFunc,
+		hub.Headlamp:
 		mdFile = filepath.Join(basePath, hub.RepositoryMetadataFile)
 	}
 	return mdFile
@@ -510,6 +889,21 @@ func (m *Manager) GetPackagesDigest(
 	return pd, err
 }
 
+// GetPendingChangesJSON returns the repositories changes pending of approval
+// in the organization provided as a json array, which is built by the
+// database.
+func (m *Manager) GetPendingChangesJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get pending changes from database
+	return util.DBQueryJSON(ctx, m.db, getPendingRepoChangesDBQ, userID, orgName)
+}
+
 // GetRemoteDigest gets the repository's digest available in the remote.
 func (m *Manager) GetRemoteDigest(ctx context.Context, r *hub.Repository) (string, error) {
 	var digest string
@@ -555,27 +949,254 @@ func (m *Manager) GetRemoteDigest(ctx context.Context, r *hub.Repository) (strin
 			URLs: []string{repoBaseURL},
 		})
 		listOptions := &git.ListOptions{}
-		if r.AuthPass != "" {
-			listOptions.Auth = &githttp.BasicAuth{
-				Username: "artifact-hub",
-				Password: r.AuthPass,
-			}
+		if auth := GitCloneAuth(r); auth != nil {
+			listOptions.Auth = auth
 		}
 		refs, err := remote.List(listOptions)
 		if err != nil {
 			return digest, err
 		}
 		branch := GetBranch(r)
+		var tags []string
 		for _, ref := range refs {
 			if ref.Name().IsBranch() && ref.Name().Short() == branch {
 				digest = ref.Hash().String()
 			}
+			if ref.Name().IsTag() {
+				tags = append(tags, ref.Name().Short()+":"+ref.Hash().String())
+			}
+		}
+
+		// Terraform modules versions are published as tags, so they must be
+		// taken into account as well to detect changes in the repository
+		if r.Kind == hub.Terraform && len(tags) > 0 {
+			sort.Strings(tags)
+			digest = fmt.Sprintf("%x", sha256.Sum256([]byte(digest+","+strings.Join(tags, ","))))
 		}
 	}
 
 	return digest, nil
 }
 
+// GetStatsJSON returns a json object with some stats about the repository
+// provided during the last three months, like the number of packages, views,
+// stars gained, new subscriptions or details about the tracker runs. The json
+// object is built by the database.
+func (m *Manager) GetStatsJSON(ctx context.Context, name string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get repository stats from database
+	end := time.Now().Format("2006-01-02")
+	start := time.Now().AddDate(0, -3, 0).Format("2006-01-02")
+	return util.DBQueryJSON(ctx, m.db, getRepoStatsDBQ, userID, name, start, end)
+}
+
+// GetSubscriptionsJSON returns a json object with the number of subscribers
+// of the packages in the repository provided, aggregated for the whole
+// repository and per package, including a breakdown by event kind. The json
+// object is built by the database.
+func (m *Manager) GetSubscriptionsJSON(ctx context.Context, name string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get repository subscriptions from database
+	return util.DBQueryJSON(ctx, m.db, getRepoSubscriptionsDBQ, userID, name)
+}
+
+// GetViewsJSON returns a json object with the views of the packages in the
+// repository provided during the last month, aggregated by day for the whole
+// repository and per package. The json object is built by the database.
+func (m *Manager) GetViewsJSON(ctx context.Context, name string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get repository views from database
+	end := time.Now().Format("2006-01-02")
+	start := time.Now().AddDate(0, -1, 0).Format("2006-01-02")
+	return util.DBQueryJSON(ctx, m.db, getRepoViewsDBQ, userID, name, start, end)
+}
+
+// RegisterCustomKinds registers the custom repository kinds defined in the
+// configuration, adding them to the database when needed. Repositories of a
+// custom kind can only be added once the kind has been registered.
+func (m *Manager) RegisterCustomKinds(ctx context.Context) error {
+	var kinds []*hub.CustomRepositoryKind
+	if err := m.cfg.UnmarshalKey("customRepositoriesKinds", &kinds); err != nil {
+		return fmt.Errorf("error reading custom repositories kinds: %w", err)
+	}
+	for _, k := range kinds {
+		if err := hub.RegisterCustomRepositoryKind(k); err != nil {
+			return err
+		}
+		if _, err := m.db.Exec(ctx, registerRepoKindDBQ, k.Kind, k.DisplayName); err != nil {
+			return fmt.Errorf("error registering custom kind %d: %w", k.Kind, err)
+		}
+	}
+	return nil
+}
+
+// RegisterOwnershipClaimToken registers a token that allows the requesting
+// user to claim the ownership of the repository provided. To complete the
+// claim, the token must be added to the repository metadata file within the
+// next 7 days.
+func (m *Manager) RegisterOwnershipClaimToken(ctx context.Context, repoName string) (string, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if repoName == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+
+	// Get repository information
+	r, err := m.GetByName(ctx, repoName, false)
+	if err != nil {
+		return "", err
+	}
+
+	// Generate and register ownership claim token
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(randomBytes)
+	if _, err := m.db.Exec(ctx, registerClaimTokenDBQ, userID, r.RepositoryID, hash(token)); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// RegisterPackagesDownloads registers the downloads counters provided for the
+// packages in the repository identified by the name provided. Counters for a
+// given package version and day replace the ones previously registered, so
+// the same downloads logs can be ingested more than once.
+func (m *Manager) RegisterPackagesDownloads(
+	ctx context.Context,
+	name string,
+	downloads []*hub.PackageDownloads,
+) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if len(downloads) == 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "downloads not provided")
+	}
+	if len(downloads) > maxPackagesDownloadsEntries {
+		return fmt.Errorf("%w: %s (max: %d)", hub.ErrInvalidInput, "too many downloads entries", maxPackagesDownloadsEntries)
+	}
+	for _, d := range downloads {
+		if d == nil || d.PackageName == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package name not provided")
+		}
+		if d.Version == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+		}
+		day, err := time.Parse("2006-01-02", d.Day)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid day", d.Day)
+		}
+		if day.After(time.Now()) {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "day is in the future", d.Day)
+		}
+		if d.Total < 0 {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid total (t >= 0)")
+		}
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Register downloads in database
+	downloadsJSON, _ := json.Marshal(downloads)
+	_, err = m.db.Exec(ctx, registerPkgsDownloadsDBQ, userID, name, downloadsJSON)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// RegisterTrackingRun registers the duration and outcome of a tracker run for
+// the repository provided.
+func (m *Manager) RegisterTrackingRun(
+	ctx context.Context,
+	repositoryID string,
+	duration time.Duration,
+	outcome string,
+) error {
+	// Validate input
+	if _, err := uuid.FromString(repositoryID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+	}
+	if duration < 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid duration")
+	}
+	if !isValidTrackingRunOutcome(outcome) {
+		return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid outcome", outcome)
+	}
+
+	// Register tracking run in database
+	_, err := m.db.Exec(ctx, registerTrackingRunDBQ, repositoryID, duration.Seconds(), outcome)
+	return err
+}
+
+// RejectChange rejects the pending repository change provided, discarding it.
+func (m *Manager) RejectChange(ctx context.Context, orgName, changeID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateChangeInput(orgName, changeID); err != nil {
+		return err
+	}
+
+	// Authorize action
+	kind, _, err := m.getChange(ctx, orgName, changeID)
+	if err != nil {
+		return err
+	}
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           changeAction(kind),
+	}); err != nil {
+		return err
+	}
+
+	// Delete change from database
+	_, err = m.db.Exec(ctx, rejectRepoChangeDBQ, userID, orgName, changeID)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
 // Search searches for repositories in the database that the criteria defined
 // in the input provided.
 func (m *Manager) Search(
@@ -636,15 +1257,36 @@ func (m *Manager) SetLastScanningResults(ctx context.Context, repositoryID, errs
 
 // SetLastTrackingResults updates the timestamp and errors of the last tracking
 // of the provided repository in the database.
-func (m *Manager) SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error {
+func (m *Manager) SetLastTrackingResults(
+	ctx context.Context,
+	repositoryID string,
+	errs []*hub.TrackingError,
+) error {
 	// Validate input
 	if _, err := uuid.FromString(repositoryID); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
 	}
 
+	// Prepare errors text and details
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, e.Message)
+	}
+	var errsDetailsJSON []byte
+	if len(errs) > 0 {
+		errsDetailsJSON, _ = json.Marshal(errs)
+	}
+
 	// Update last tracking results in database
 	trackingErrorsEventsEnabled := m.cfg.GetBool("events.trackingErrors")
-	_, err := m.db.Exec(ctx, setLastTrackingResultsDBQ, repositoryID, errs, trackingErrorsEventsEnabled)
+	_, err := m.db.Exec(
+		ctx,
+		setLastTrackingResultsDBQ,
+		repositoryID,
+		strings.Join(msgs, "\n"),
+		errsDetailsJSON,
+		trackingErrorsEventsEnabled,
+	)
 	return err
 }
 
@@ -657,16 +1299,52 @@ func (m *Manager) SetVerifiedPublisher(ctx context.Context, repositoryID string,
 	}
 
 	// Update verified publisher status in database
-	_, err := m.db.Exec(ctx, setVerifiedPublisherDBQ, repositoryID, verified)
-	return err
+	if _, err := m.db.Exec(ctx, setVerifiedPublisherDBQ, repositoryID, verified); err != nil {
+		return err
+	}
+	cache.Invalidate(ctx, m.cache, cache.RepositoryIDTag(repositoryID), cache.SearchTag)
+	return nil
 }
 
 // Transfer transfers the provided repository to a different owner. A user
 // owned repo can be transferred to an organization the requesting user belongs
 // to. An org owned repo can be transfer to the requesting user, provided the
 // user belongs to the owning org, or to a different organization the user
-// belongs to.
-func (m *Manager) Transfer(ctx context.Context, repoName, orgName string, ownershipClaim bool) error {
+// belongs to. When the repository is owned by an organization that requires
+// changes to be approved, the transfer is registered as a pending change and
+// hub.ErrPendingApproval is returned.
+func (m *Manager) Transfer(ctx context.Context, repoName, orgName string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if repoName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, repoName, false)
+	if err != nil {
+		return err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.TransferOrganizationRepository,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return m.transfer(ctx, repoName, r.OrganizationName, orgName, "")
+}
+
+// transfer transfers the provided repository to the requesting user or to the
+// organization provided. When the repository is owned by an organization that
+// requires changes to be approved, the transfer is registered as a pending
+// change instead. When the transfer is part of an ownership claim operation,
+// the method used to verify the claim must be provided.
+func (m *Manager) transfer(ctx context.Context, repoName, ownerOrgName, orgName, claimMethod string) error {
 	var orgNameP *string
 	if orgName != "" {
 		orgNameP = &orgName
@@ -676,39 +1354,38 @@ func (m *Manager) Transfer(ctx context.Context, repoName, orgName string, owners
 	if userID != "" {
 		userIDP = &userID
 	}
-
-	// Validate input
-	if repoName == "" {
-		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	var claimMethodP *string
+	if claimMethod != "" {
+		claimMethodP = &claimMethod
 	}
 
-	// Authorize action if this is not an ownership claim operation and the
-	// repository is owned by an organization
-	if !ownershipClaim {
-		r, err := m.GetByName(ctx, repoName, false)
+	// Register change if it must be approved first
+	if ownerOrgName != "" {
+		approvalRequired, err := m.isApprovalRequired(ctx, ownerOrgName)
 		if err != nil {
 			return err
 		}
-		if r.OrganizationName != "" {
-			if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
-				OrganizationName: r.OrganizationName,
-				UserID:           userID,
-				Action:           hub.TransferOrganizationRepository,
-			}); err != nil {
-				return err
-			}
+		if approvalRequired {
+			return m.requestChange(ctx, ownerOrgName, &repositoryChange{
+				Kind:                     hub.RepositoryChangeTransfer,
+				RepositoryName:           repoName,
+				TransferOrganizationName: orgName,
+				OwnershipClaimMethod:     claimMethod,
+			})
 		}
 	}
 
 	// Update repository owner in database
-	_, err := m.db.Exec(ctx, transferRepoDBQ, repoName, userIDP, orgNameP, ownershipClaim)
+	_, err := m.db.Exec(ctx, transferRepoDBQ, repoName, userIDP, orgNameP, claimMethodP)
 	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
 		return hub.ErrInsufficientPrivilege
 	}
 	return err
 }
 
-// Update updates the provided repository in the database.
+// Update updates the provided repository in the database. When the repository
+// is owned by an organization that requires changes to be approved, the update
+// is registered as a pending change and hub.ErrPendingApproval is returned.
 func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
 
@@ -731,6 +1408,9 @@ func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 	if err != nil {
 		return err
 	}
+	if r.Version != 0 && r.Version != rBefore.Version {
+		return errRepoVersionConflict
+	}
 	if rBefore.OrganizationName != "" {
 		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
 			OrganizationName: rBefore.OrganizationName,
@@ -739,15 +1419,35 @@ func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 		}); err != nil {
 			return err
 		}
+
+		// Register change if it must be approved first
+		approvalRequired, err := m.isApprovalRequired(ctx, rBefore.OrganizationName)
+		if err != nil {
+			return err
+		}
+		if approvalRequired {
+			return m.requestChange(ctx, rBefore.OrganizationName, &repositoryChange{
+				Kind:           hub.RepositoryChangeUpdate,
+				RepositoryName: r.Name,
+				Repository:     r,
+			})
+		}
 	}
 
 	// Update repository in database
 	rJSON, _ := json.Marshal(r)
 	_, err = m.db.Exec(ctx, updateRepoDBQ, userID, rJSON)
-	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
-		return hub.ErrInsufficientPrivilege
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBVersionConflict.Error():
+			return errRepoVersionConflict
+		}
+		return err
 	}
-	return err
+	cache.Invalidate(ctx, m.cache, cache.RepositoryTag(r.Name), cache.SearchTag)
+	return nil
 }
 
 // UpdateDigest updates the digest of the provided repository in the database.
@@ -756,6 +1456,115 @@ func (m *Manager) UpdateDigest(ctx context.Context, repositoryID, digest string)
 	return err
 }
 
+// getChange returns the kind and the id of the user who requested the
+// repository change provided.
+func (m *Manager) getChange(ctx context.Context, orgName, changeID string) (hub.RepositoryChangeKind, string, error) {
+	var kind, requestedBy string
+	if err := m.db.QueryRow(ctx, getRepoChangeDBQ, orgName, changeID).Scan(&kind, &requestedBy); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", hub.ErrNotFound
+		}
+		return "", "", err
+	}
+	return hub.RepositoryChangeKind(kind), requestedBy, nil
+}
+
+// isApprovalRequired checks if the organization provided requires changes in
+// its repositories to be approved before taking effect.
+func (m *Manager) isApprovalRequired(ctx context.Context, orgName string) (bool, error) {
+	var approvalRequired bool
+	if err := m.db.QueryRow(ctx, isApprovalRequiredDBQ, orgName).Scan(&approvalRequired); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return approvalRequired, nil
+}
+
+// requestChange registers a change in a repository owned by the organization
+// provided that must be approved before being applied, notifying the members
+// of the organization who can approve it. On success, hub.ErrPendingApproval
+// is returned.
+func (m *Manager) requestChange(ctx context.Context, orgName string, change *repositoryChange) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Register change in database
+	changeJSON, _ := json.Marshal(change)
+	var changeID string
+	if err := m.db.QueryRow(ctx, registerRepoChangeDBQ, userID, orgName, changeJSON).Scan(&changeID); err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return hub.ErrInsufficientPrivilege
+		}
+		return err
+	}
+
+	// Notify approvers. The change has already been registered at this point,
+	// so errors notifying approvers are only logged.
+	if m.es != nil {
+		if err := m.notifyApprovers(ctx, orgName, changeID, change); err != nil {
+			log.Error().Err(err).Str("changeID", changeID).Msg("error notifying repository change approvers")
+		}
+	}
+
+	return hub.ErrPendingApproval
+}
+
+// notifyApprovers sends an email to the members of the organization who can
+// approve the repository change provided.
+func (m *Manager) notifyApprovers(
+	ctx context.Context,
+	orgName string,
+	changeID string,
+	change *repositoryChange,
+) error {
+	// Get approvers
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getRepoChangeApproversDBQ, changeID)
+	if err != nil {
+		return err
+	}
+	var approvers []*struct {
+		Email  string `json:"email"`
+		Locale string `json:"locale"`
+	}
+	if err := json.Unmarshal(dataJSON, &approvers); err != nil {
+		return err
+	}
+
+	// Send notification email to each of them
+	baseURL := m.cfg.GetString("server.baseURL")
+	siteName := m.cfg.GetString("theme.siteName")
+	for _, a := range approvers {
+		templateData := map[string]interface{}{
+			"BaseURL":  baseURL,
+			"ChangeID": changeID,
+			"Claim":    change.OwnershipClaimMethod != "",
+			"Kind":     string(change.Kind),
+			"OrgName":  orgName,
+			"RepoName": change.RepositoryName,
+			"Theme": map[string]string{
+				"PrimaryColor":   m.cfg.GetString("theme.colors.primary"),
+				"SecondaryColor": m.cfg.GetString("theme.colors.secondary"),
+				"SiteName":       siteName,
+			},
+		}
+		var emailBody bytes.Buffer
+		if err := email.ExecuteTemplate(&emailBody, m.changeRequestTmpl, a.Locale, templateData); err != nil {
+			return err
+		}
+		emailData := &email.Data{
+			To:      a.Email,
+			Subject: email.Translate(a.Locale, "repository_change.subject", change.RepositoryName, orgName),
+			Body:    emailBody.Bytes(),
+		}
+		if err := m.es.SendEmail(emailData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // validateURL validates the url of the repository provided.
 func (m *Manager) validateURL(r *hub.Repository) error {
 	if r.URL == "" {
@@ -793,7 +1602,12 @@ func (m *Manager) validateURL(r *hub.Repository) error {
 		hub.OPA,
 		hub.TBAction,
 		hub.TektonTask,
-		hub.TektonPipeline:
+		hub.TektonPipeline,
+		hub.Terraform,
+		hub.Crossplane,
+		hub.Kyverno,
+		hub.KnativeFunc,
+		hub.Headlamp:
 		if SchemeIsHTTP(u) && !GitRepoURLRE.MatchString(r.URL) {
 			return errors.New("invalid url format")
 		}
@@ -829,6 +1643,21 @@ func validateData(r *hub.Repository) error {
 	}
 }
 
+// validateChangeInput validates the input provided to approve or reject a
+// repository change.
+func validateChangeInput(orgName, changeID string) error {
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if changeID == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "change id not provided")
+	}
+	if _, err := uuid.FromString(changeID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid change id")
+	}
+	return nil
+}
+
 // validateSearchInput validates the search input provided, returning an error
 // in case it's invalid.
 func validateSearchInput(input *hub.SearchRepositoryInput) error {
@@ -845,6 +1674,36 @@ func validateSearchInput(input *hub.SearchRepositoryInput) error {
 	return nil
 }
 
+// encodeEventsCursor encodes the events cursor provided as an opaque string.
+func encodeEventsCursor(c *eventsCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeEventsCursor decodes and validates the events cursor provided.
+func decodeEventsCursor(s string) (*eventsCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c *eventsCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	if c == nil || c.CreatedAt == "" {
+		return nil, errors.New("created at not provided")
+	}
+	if _, err := uuid.FromString(c.EventID); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// hash is a helper function that creates a sha256 hash of the text provided.
+func hash(text string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(text)))
+}
+
 // SchemeIsHTTP is a helper that checks if the scheme of the url provided is
 // http or https.
 func SchemeIsHTTP(u *url.URL) bool {
@@ -862,6 +1721,21 @@ func isSchemeSupported(u *url.URL) bool {
 	return SchemeIsHTTP(u) || SchemeIsOCI(u)
 }
 
+// changeAction returns the action that must be authorized to approve or
+// reject a repository change of the kind provided.
+func changeAction(kind hub.RepositoryChangeKind) hub.Action {
+	switch kind {
+	case hub.RepositoryChangeAdd:
+		return hub.AddOrganizationRepository
+	case hub.RepositoryChangeDelete:
+		return hub.DeleteOrganizationRepository
+	case hub.RepositoryChangeTransfer:
+		return hub.TransferOrganizationRepository
+	default:
+		return hub.UpdateOrganizationRepository
+	}
+}
+
 // isValidKind checks if the provided repository kind is valid.
 func isValidKind(kind hub.RepositoryKind) bool {
 	for _, validKind := range validRepositoryKinds {
@@ -869,5 +1743,16 @@ func isValidKind(kind hub.RepositoryKind) bool {
 			return true
 		}
 	}
+	return hub.IsCustomRepositoryKind(kind)
+}
+
+// isValidTrackingRunOutcome checks if the provided tracker run outcome is
+// valid.
+func isValidTrackingRunOutcome(outcome string) bool {
+	for _, validOutcome := range validTrackingRunOutcomes {
+		if outcome == validOutcome {
+			return true
+		}
+	}
 	return false
 }
@@ -12,12 +12,15 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/oci"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -300,6 +303,7 @@ func TestAdd(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, isApprovalRequiredDBQ, "orgName").Return(false, nil)
 				db.On("Exec", ctx, addRepoDBQ, "userID", "orgName", mock.Anything).Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
 				az.On("Authorize", ctx, &hub.AuthorizeInput{
@@ -346,6 +350,7 @@ func TestAdd(t *testing.T) {
 			t.Run(strconv.Itoa(i), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, isApprovalRequiredDBQ, "orgName").Return(false, nil)
 				db.On("Exec", ctx, addRepoDBQ, "userID", "orgName", mock.Anything).Return(nil)
 				az := &authz.AuthorizerMock{}
 				az.On("Authorize", ctx, &hub.AuthorizeInput{
@@ -367,6 +372,219 @@ func TestAdd(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("add repository pending approval", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{
+			Name:        "repo1",
+			DisplayName: "Repository 1",
+			URL:         "https://repo1.com",
+			Kind:        hub.Helm,
+		}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isApprovalRequiredDBQ, "orgName").Return(true, nil)
+		db.On("QueryRow", ctx, registerRepoChangeDBQ, "userID", "orgName", mock.Anything).Return("changeID", nil)
+		db.On("QueryRow", ctx, getRepoChangeApproversDBQ, "changeID").Return([]byte(`
+		[
+			{"email": "user2@email.com", "locale": "es"}
+		]
+		`), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationRepository,
+		}).Return(nil)
+		es := &email.SenderMock{}
+		es.On("SendEmail", mock.MatchedBy(func(data *email.Data) bool {
+			return data.To == "user2@email.com"
+		})).Return(nil)
+		l := &HelmIndexLoaderMock{}
+		l.On("LoadIndex", r).Return(nil, "", nil)
+		m := NewManager(cfg, db, az, nil, WithHelmIndexLoader(l), WithEmailSender(es))
+
+		err := m.Add(ctx, "orgName", r)
+		assert.True(t, errors.Is(err, hub.ErrPendingApproval))
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+		es.AssertExpectations(t)
+		l.AssertExpectations(t)
+	})
+
+	t.Run("error registering change", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{
+			Name:        "repo1",
+			DisplayName: "Repository 1",
+			URL:         "https://repo1.com",
+			Kind:        hub.Helm,
+		}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, isApprovalRequiredDBQ, "orgName").Return(true, nil)
+		db.On("QueryRow", ctx, registerRepoChangeDBQ, "userID", "orgName", mock.Anything).Return(nil, tests.ErrFakeDB)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationRepository,
+		}).Return(nil)
+		l := &HelmIndexLoaderMock{}
+		l.On("LoadIndex", r).Return(nil, "", nil)
+		m := NewManager(cfg, db, az, nil, WithHelmIndexLoader(l))
+
+		err := m.Add(ctx, "orgName", r)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+		l.AssertExpectations(t)
+	})
+}
+
+func TestApproveChange(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	changeID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.ApproveChange(context.Background(), "orgName", changeID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			orgName  string
+			changeID string
+		}{
+			{
+				"organization name not provided",
+				"",
+				changeID,
+			},
+			{
+				"change id not provided",
+				"orgName",
+				"",
+			},
+			{
+				"invalid change id",
+				"orgName",
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.ApproveChange(ctx, tc.orgName, tc.changeID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("change not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoChangeDBQ, "orgName", changeID).Return(nil, pgx.ErrNoRows)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.ApproveChange(ctx, "orgName", changeID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("change requested by the same user", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoChangeDBQ, "orgName", changeID).Return([]interface{}{"add", "userID"}, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.ApproveChange(ctx, "orgName", changeID)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoChangeDBQ, "orgName", changeID).Return([]interface{}{"delete", "user2ID"}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationRepository,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, db, az, nil)
+
+		err := m.ApproveChange(ctx, "orgName", changeID)
+		assert.Equal(t, tests.ErrFake, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBVersionConflict,
+				errRepoVersionConflict,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoChangeDBQ, "orgName", changeID).Return([]interface{}{"update", "user2ID"}, nil)
+				db.On("Exec", ctx, approveRepoChangeDBQ, "userID", "orgName", changeID).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "orgName",
+					UserID:           "userID",
+					Action:           hub.UpdateOrganizationRepository,
+				}).Return(nil)
+				m := NewManager(cfg, db, az, nil)
+
+				err := m.ApproveChange(ctx, "orgName", changeID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("change approved successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoChangeDBQ, "orgName", changeID).Return([]interface{}{"add", "user2ID"}, nil)
+		db.On("Exec", ctx, approveRepoChangeDBQ, "userID", "orgName", changeID).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationRepository,
+		}).Return(nil)
+		m := NewManager(cfg, db, az, nil)
+
+		err := m.ApproveChange(ctx, "orgName", changeID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
 }
 
 func TestCheckAvailability(t *testing.T) {
@@ -454,9 +672,13 @@ func TestClaimOwnership(t *testing.T) {
 	userIDP := &userID
 	org := "org1"
 	orgP := &org
+	ownersClaimMethod := claimMethodOwners
+	tokenClaimMethod := claimMethodToken
 	helmRepoJSON := []byte(`{"kind": 0, "url": "http://repo.url"}`)
 	opaRepoJSON := []byte(`{"kind": 2, "url": "http://repo.url"}`)
+	opaGitHubRepoJSON := []byte(`{"kind": 2, "url": "https://github.com/org1/repo1/pkgs", "branch": "main", "auth_pass": "token"}`)
 	olmRepoJSON := []byte(`{"kind": 3, "url": "oci://repo.url"}`)
+	orgOLMRepoJSON := []byte(`{"kind": 3, "url": "oci://repo.url", "organization_name": "orgName"}`)
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
 	mdYmlReq, _ := http.NewRequest("GET", "http://repo.url/artifacthub-repo.yml", nil)
 	mdYamlReq, _ := http.NewRequest("GET", "http://repo.url/artifacthub-repo.yaml", nil)
@@ -561,6 +783,97 @@ func TestClaimOwnership(t *testing.T) {
 		hc.AssertExpectations(t)
 	})
 
+	t.Run("ownership claim failed: database error checking maintainers", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(orgOLMRepoJSON, nil)
+		db.On("QueryRow", ctx, isRepoMaintainerDBQ, "", userID).Return(false, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.ClaimOwnership(ctx, "repo1", org)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("ownership claim failed: maintainer of user owned repository", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(olmRepoJSON, nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.ClaimOwnership(ctx, "repo1", org)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("ownership claim pending approval (maintainer)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(orgOLMRepoJSON, nil)
+		db.On("QueryRow", ctx, isRepoMaintainerDBQ, "", userID).Return(true, nil)
+		changeJSON := []byte(`{"kind":"transfer","repository_name":"repo1","transfer_organization_name":"org1","ownership_claim_method":"maintainer"}`)
+		db.On("QueryRow", ctx, registerRepoChangeDBQ, userID, "orgName", changeJSON).Return("changeID", nil)
+		db.On("QueryRow", ctx, getRepoChangeApproversDBQ, "changeID").Return([]byte(`
+		[
+			{"email": "user2@email.com", "locale": "en"}
+		]
+		`), nil)
+		es := &email.SenderMock{}
+		es.On("SendEmail", mock.MatchedBy(func(data *email.Data) bool {
+			return data.To == "user2@email.com" &&
+				strings.Contains(string(data.Body), "has requested to claim its ownership")
+		})).Return(nil)
+		m := NewManager(cfg, db, nil, nil, WithEmailSender(es))
+
+		err := m.ClaimOwnership(ctx, "repo1", org)
+		assert.True(t, errors.Is(err, hub.ErrPendingApproval))
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("ownership claim using token", func(t *testing.T) {
+		testCases := []struct {
+			description   string
+			validToken    bool
+			expectedError error
+		}{
+			{
+				"invalid token",
+				false,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				"valid token",
+				true,
+				nil,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(helmRepoJSON, nil)
+				db.On("QueryRow", ctx, getUserEmailDBQ, userID).Return("user1@email.com", nil)
+				db.On("QueryRow", ctx, checkClaimTokenDBQ, "", userID, hash("token1")).Return(tc.validToken, nil)
+				if tc.validToken {
+					db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, &tokenClaimMethod).Return(nil)
+				}
+				hc := &tests.HTTPClientMock{}
+				hc.On("Do", mdYmlReq).Return(&http.Response{
+					Body:       ioutil.NopCloser(strings.NewReader("ownershipClaimToken: token1")),
+					StatusCode: http.StatusOK,
+				}, nil)
+				m := NewManager(cfg, db, nil, hc)
+
+				err := m.ClaimOwnership(ctx, "repo1", org)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				hc.AssertExpectations(t)
+			})
+		}
+	})
+
 	t.Run("ownership claim failed: olm oci repo", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
@@ -577,7 +890,7 @@ func TestClaimOwnership(t *testing.T) {
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(helmRepoJSON, nil)
 		db.On("QueryRow", ctx, getUserEmailDBQ, userID).Return("owner1@email.com", nil)
-		db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, true).Return(nil)
+		db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, &ownersClaimMethod).Return(nil)
 		mdFile, _ := os.Open("testdata/artifacthub-repo.yml")
 		hc := &tests.HTTPClientMock{}
 		hc.On("Do", mdYmlReq).Return(&http.Response{
@@ -612,7 +925,7 @@ func TestClaimOwnership(t *testing.T) {
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(opaRepoJSON, nil)
 		db.On("QueryRow", ctx, getUserEmailDBQ, userID).Return("owner1@email.com", nil)
-		db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, true).Return(nil)
+		db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, &ownersClaimMethod).Return(nil)
 		rc := &ClonerMock{}
 		var r *hub.Repository
 		_ = json.Unmarshal(opaRepoJSON, &r)
@@ -624,6 +937,37 @@ func TestClaimOwnership(t *testing.T) {
 		db.AssertExpectations(t)
 		rc.AssertExpectations(t)
 	})
+
+	t.Run("ownership claim succeeded (opa, metadata fetched from git provider api)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", true).Return(opaGitHubRepoJSON, nil)
+		db.On("QueryRow", ctx, getUserEmailDBQ, userID).Return("owner1@email.com", nil)
+		db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, &ownersClaimMethod).Return(nil)
+		mdFile, _ := os.Open("testdata/artifacthub-repo.yml")
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://github.com/org1/repo1/raw/main/pkgs/artifacthub-repo.yml"
+		})).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusNotFound,
+		}, nil)
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://api.github.com/repos/org1/repo1/contents/pkgs/artifacthub-repo.yml?ref=main" &&
+				req.Header.Get("Authorization") == "Bearer token"
+		})).Return(&http.Response{
+			Body:       mdFile,
+			StatusCode: http.StatusOK,
+		}, nil)
+		rc := &ClonerMock{}
+		m := NewManager(cfg, db, nil, hc, withRepositoryCloner(rc))
+
+		err := m.ClaimOwnership(ctx, "repo1", org)
+		assert.Nil(t, err)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+		rc.AssertNotCalled(t, "CloneRepository", mock.Anything, mock.Anything)
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -694,6 +1038,7 @@ func TestDelete(t *testing.T) {
 					"organization_name": "orgName"
 				}
 				`), nil)
+				db.On("QueryRow", ctx, isApprovalRequiredDBQ, "orgName").Return(false, nil)
 				db.On("Exec", ctx, deleteRepoDBQ, "userID", "repo1").Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
 				az.On("Authorize", ctx, &hub.AuthorizeInput{
@@ -728,6 +1073,32 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("delete repository pending approval", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(`
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"organization_name": "orgName"
+		}
+		`), nil)
+		db.On("QueryRow", ctx, isApprovalRequiredDBQ, "orgName").Return(true, nil)
+		db.On("QueryRow", ctx, registerRepoChangeDBQ, "userID", "orgName", mock.Anything).Return("changeID", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganizationRepository,
+		}).Return(nil)
+		m := NewManager(cfg, db, az, nil)
+
+		err := m.Delete(ctx, "repo1")
+		assert.True(t, errors.Is(err, hub.ErrPendingApproval))
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
 }
 
 func TestGetByID(t *testing.T) {
@@ -919,15 +1290,27 @@ func TestGetMetadata(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid repository id")
 	})
 
-	t.Run("local file: success fetching .yml", func(t *testing.T) {
+	t.Run("local file: invalid cosign identity", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 
 		r := &hub.Repository{
 			Kind: hub.OPA,
 		}
-		md, err := m.GetMetadata(r, "testdata/valid-yml")
-		assert.Equal(t, expectedMetadata, md)
+		_, err := m.GetMetadata(r, "testdata/invalid-cosign-identity")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cosign identity issuer and subject are required")
+	})
+
+	t.Run("local file: success fetching .yml", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+
+		r := &hub.Repository{
+			Kind: hub.OPA,
+		}
+		md, err := m.GetMetadata(r, "testdata/valid-yml")
+		assert.Equal(t, expectedMetadata, md)
 		assert.NoError(t, err)
 	})
 
@@ -1122,142 +1505,934 @@ func TestGetMetadata(t *testing.T) {
 			Kind: hub.Helm,
 			URL:  ociRepoURL,
 		}
-		md, err := m.GetMetadata(r, "")
-		assert.Equal(t, ErrMetadataNotFound, err)
-		assert.Nil(t, md)
-		op.AssertExpectations(t)
-	})
-
-	t.Run("remote file (oci): success", func(t *testing.T) {
-		t.Parallel()
-		op := &oci.PullerMock{}
-		op.On("PullLayer", mock.Anything, ociRef, MetadataLayerMediaType, "", "").
-			Return(ocispec.Descriptor{}, expectedMetadataYaml, nil)
-		m := NewManager(cfg, nil, nil, nil, WithOCIPuller(op))
+		md, err := m.GetMetadata(r, "")
+		assert.Equal(t, ErrMetadataNotFound, err)
+		assert.Nil(t, md)
+		op.AssertExpectations(t)
+	})
+
+	t.Run("remote file (oci): success", func(t *testing.T) {
+		t.Parallel()
+		op := &oci.PullerMock{}
+		op.On("PullLayer", mock.Anything, ociRef, MetadataLayerMediaType, "", "").
+			Return(ocispec.Descriptor{}, expectedMetadataYaml, nil)
+		m := NewManager(cfg, nil, nil, nil, WithOCIPuller(op))
+
+		r := &hub.Repository{
+			Kind: hub.Helm,
+			URL:  ociRepoURL,
+		}
+		md, err := m.GetMetadata(r, "")
+		assert.Equal(t, expectedMetadata, md)
+		assert.NoError(t, err)
+		op.AssertExpectations(t)
+	})
+
+	t.Run("remote file (oci - with creds): success", func(t *testing.T) {
+		t.Parallel()
+		op := &oci.PullerMock{}
+		op.On("PullLayer", mock.Anything, ociRef, MetadataLayerMediaType, "user", "pass").
+			Return(ocispec.Descriptor{}, expectedMetadataYaml, nil)
+		m := NewManager(cfg, nil, nil, nil, WithOCIPuller(op))
+
+		r := &hub.Repository{
+			Kind:     hub.Helm,
+			URL:      ociRepoURL,
+			AuthUser: "user",
+			AuthPass: "pass",
+		}
+		md, err := m.GetMetadata(r, "")
+		assert.Equal(t, expectedMetadata, md)
+		assert.NoError(t, err)
+		op.AssertExpectations(t)
+	})
+}
+
+func TestGetPackagesDigest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetPackagesDigest(context.Background(), "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoPkgsDigestDBQ, "00000000-0000-0000-0000-000000000001").Return([]byte(`
+		{
+			"package1@1.0.0": "digest-package1-1.0.0",
+			"package1@0.0.9": "digest-package1-0.0.9",
+			"package2@1.0.0": "digest-package2-1.0.0",
+			"package2@0.0.9": "digest-package2-0.0.9"
+		}
+		`), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		pd, err := m.GetPackagesDigest(ctx, "00000000-0000-0000-0000-000000000001")
+		require.NoError(t, err)
+		assert.Len(t, pd, 4)
+		assert.Equal(t, "digest-package1-1.0.0", pd["package1@1.0.0"])
+		assert.Equal(t, "digest-package1-0.0.9", pd["package1@0.0.9"])
+		assert.Equal(t, "digest-package2-1.0.0", pd["package2@1.0.0"])
+		assert.Equal(t, "digest-package2-0.0.9", pd["package2@0.0.9"])
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetPendingChangesJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetPendingChangesJSON(context.Background(), "orgName")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetPendingChangesJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "organization name not provided")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getPendingRepoChangesDBQ, "userID", "orgName").Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				_, err := m.GetPendingChangesJSON(ctx, "orgName")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPendingRepoChangesDBQ, "userID", "orgName").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetPendingChangesJSON(ctx, "orgName")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetRemoteDigest(t *testing.T) {
+	ctx := context.Background()
+	helmHTTP := &hub.Repository{
+		Kind: hub.Helm,
+		Name: "repo1",
+		URL:  "https://myrepo.url",
+	}
+	helmOCI := &hub.Repository{
+		Kind: hub.Helm,
+		Name: "repo1",
+		URL:  "oci://myrepo.url/chart",
+	}
+
+	t.Run("helm-http: error loading index", func(t *testing.T) {
+		t.Parallel()
+		l := &HelmIndexLoaderMock{}
+		l.On("LoadIndex", helmHTTP).Return(nil, "", tests.ErrFake)
+		m := NewManager(cfg, nil, nil, nil, WithHelmIndexLoader(l))
+
+		digest, err := m.GetRemoteDigest(ctx, helmHTTP)
+		assert.Empty(t, digest)
+		assert.Equal(t, tests.ErrFake, err)
+		l.AssertExpectations(t)
+	})
+
+	t.Run("helm-http: success", func(t *testing.T) {
+		t.Parallel()
+		l := &HelmIndexLoaderMock{}
+		l.On("LoadIndex", helmHTTP).Return(nil, "digest", nil)
+		m := NewManager(cfg, nil, nil, nil, WithHelmIndexLoader(l))
+
+		digest, err := m.GetRemoteDigest(ctx, helmHTTP)
+		assert.Equal(t, "digest", digest)
+		assert.Nil(t, err)
+		l.AssertExpectations(t)
+	})
+
+	t.Run("helm-oci: error getting tags", func(t *testing.T) {
+		t.Parallel()
+		tg := &oci.TagsGetterMock{}
+		tg.On("Tags", ctx, helmOCI, true).Return(nil, tests.ErrFake)
+		m := NewManager(cfg, nil, nil, nil, WithOCITagsGetter(tg))
+
+		digest, err := m.GetRemoteDigest(ctx, helmOCI)
+		assert.Empty(t, digest)
+		assert.Equal(t, tests.ErrFake, err)
+		tg.AssertExpectations(t)
+	})
+
+	t.Run("helm-oci: success", func(t *testing.T) {
+		t.Parallel()
+		tg := &oci.TagsGetterMock{}
+		tg.On("Tags", ctx, helmOCI, true).Return([]string{"2.0.0", "1.0.0"}, nil)
+		m := NewManager(cfg, nil, nil, nil, WithOCITagsGetter(tg))
+
+		digest, err := m.GetRemoteDigest(ctx, helmOCI)
+		assert.Equal(t, "32b4478532e3fbd46940cfaa0b288bc328817cec9ef38e81c9d19a803bcff285", digest)
+		assert.Nil(t, err)
+		tg.AssertExpectations(t)
+	})
+}
+
+func TestGetEventsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	cursor := encodeEventsCursor(&eventsCursor{
+		CreatedAt: "2022-01-01 10:00:00.123456+00",
+		EventID:   "00000000-0000-0000-0000-000000000001",
+	})
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetEventsJSON(context.Background(), "repo1", &hub.GetRepositoryEventsInput{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			input  *hub.GetRepositoryEventsInput
+			errMsg string
+		}{
+			{
+				"",
+				&hub.GetRepositoryEventsInput{},
+				"name not provided",
+			},
+			{
+				"repo1",
+				&hub.GetRepositoryEventsInput{Limit: -1},
+				"invalid limit",
+			},
+			{
+				"repo1",
+				&hub.GetRepositoryEventsInput{Limit: 101},
+				"invalid limit",
+			},
+			{
+				"repo1",
+				&hub.GetRepositoryEventsInput{Cursor: "invalid"},
+				"invalid cursor",
+			},
+			{
+				"repo1",
+				&hub.GetRepositoryEventsInput{
+					Cursor: encodeEventsCursor(&eventsCursor{CreatedAt: "2022-01-01", EventID: "invalid"}),
+				},
+				"invalid cursor",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				_, err := m.GetEventsJSON(ctx, tc.name, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoEventsDBQ, "userID", "repo1", []byte(`{"limit":20}`)).
+					Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				_, err := m.GetEventsJSON(ctx, "repo1", &hub.GetRepositoryEventsInput{})
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded, more events available", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoEventsDBQ, "userID", "repo1", []byte(`{"limit":20}`)).
+			Return([]byte(`{
+				"events": [{"event_id": "00000000-0000-0000-0000-000000000001"}],
+				"next": {"created_at": "2022-01-01 10:00:00.123456+00", "event_id": "00000000-0000-0000-0000-000000000001"}
+			}`), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetEventsJSON(ctx, "repo1", &hub.GetRepositoryEventsInput{})
+		assert.NoError(t, err)
+		expectedDataJSON := fmt.Sprintf(
+			`{"events":[{"event_id":"00000000-0000-0000-0000-000000000001"}],"next_cursor":"%s"}`,
+			cursor,
+		)
+		assert.Equal(t, []byte(expectedDataJSON), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded, last page", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		dbInputJSON := `{"cursor":{"created_at":"2022-01-01 10:00:00.123456+00","event_id":"00000000-0000-0000-0000-000000000001"},"event_kinds":[0,1],"limit":10,"package_name":"pkg1"}`
+		db.On("QueryRow", ctx, getRepoEventsDBQ, "userID", "repo1", []byte(dbInputJSON)).
+			Return([]byte(`{"events": []}`), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetEventsJSON(ctx, "repo1", &hub.GetRepositoryEventsInput{
+			Cursor:      cursor,
+			EventKinds:  []hub.EventKind{hub.NewRelease, hub.SecurityAlert},
+			Limit:       10,
+			PackageName: "pkg1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(`{"events":[]}`), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetStatsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	end := time.Now().Format("2006-01-02")
+	start := time.Now().AddDate(0, -3, 0).Format("2006-01-02")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetStatsJSON(context.Background(), "repo1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetStatsJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "name not provided")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoStatsDBQ, "userID", "repo1", start, end).Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				_, err := m.GetStatsJSON(ctx, "repo1")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoStatsDBQ, "userID", "repo1", start, end).Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetStatsJSON(ctx, "repo1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetSubscriptionsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetSubscriptionsJSON(context.Background(), "repo1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetSubscriptionsJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "name not provided")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoSubscriptionsDBQ, "userID", "repo1").Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				_, err := m.GetSubscriptionsJSON(ctx, "repo1")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoSubscriptionsDBQ, "userID", "repo1").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetSubscriptionsJSON(ctx, "repo1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetViewsJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	end := time.Now().Format("2006-01-02")
+	start := time.Now().AddDate(0, -1, 0).Format("2006-01-02")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetViewsJSON(context.Background(), "repo1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.GetViewsJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "name not provided")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoViewsDBQ, "userID", "repo1", start, end).Return(nil, tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
+
+				_, err := m.GetViewsJSON(ctx, "repo1")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoViewsDBQ, "userID", "repo1", start, end).Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		dataJSON, err := m.GetViewsJSON(ctx, "repo1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegisterCustomKinds(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid custom kind", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("customRepositoriesKinds", []map[string]interface{}{
+			{"kind": 1, "name": "custom", "displayName": "Custom packages"},
+		})
+		m := NewManager(cfg, nil, nil, nil)
+
+		err := m.RegisterCustomKinds(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("database error registering custom kind", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("customRepositoriesKinds", []map[string]interface{}{
+			{"kind": 201, "name": "custom-201", "displayName": "Custom packages"},
+		})
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerRepoKindDBQ, hub.RepositoryKind(201), "Custom packages").Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.RegisterCustomKinds(ctx)
+		assert.True(t, errors.Is(err, tests.ErrFakeDB))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("custom kind registered successfully", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("customRepositoriesKinds", []map[string]interface{}{
+			{"kind": 202, "name": "custom-202", "displayName": "Custom packages"},
+		})
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerRepoKindDBQ, hub.RepositoryKind(202), "Custom packages").Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.RegisterCustomKinds(ctx)
+		assert.NoError(t, err)
+		assert.True(t, isValidKind(hub.RepositoryKind(202)))
+		assert.Equal(t, "custom-202", hub.GetKindName(hub.RepositoryKind(202)))
+		kind, err := hub.GetKindFromName("custom-202")
+		assert.NoError(t, err)
+		assert.Equal(t, hub.RepositoryKind(202), kind)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegisterOwnershipClaimToken(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	repoJSON := []byte(`{"repository_id": "00000000-0000-0000-0000-000000000001", "name": "repo1"}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.RegisterOwnershipClaimToken(context.Background(), "repo1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		_, err := m.RegisterOwnershipClaimToken(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error getting repository", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		token, err := m.RegisterOwnershipClaimToken(ctx, "repo1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Empty(t, token)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error registering token", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return(repoJSON, nil)
+		db.On("Exec", ctx, registerClaimTokenDBQ, "userID", "00000000-0000-0000-0000-000000000001", mock.Anything).
+			Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil, nil)
+
+		token, err := m.RegisterOwnershipClaimToken(ctx, "repo1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Empty(t, token)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("token registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return(repoJSON, nil)
+		var tokenHash string
+		db.On("Exec", ctx, registerClaimTokenDBQ, "userID", "00000000-0000-0000-0000-000000000001", mock.Anything).
+			Run(func(args mock.Arguments) { tokenHash = args.String(4) }).
+			Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		token, err := m.RegisterOwnershipClaimToken(ctx, "repo1")
+		assert.NoError(t, err)
+		assert.Len(t, token, 64)
+		assert.Equal(t, hash(token), tokenHash)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegisterPackagesDownloads(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	downloads := []*hub.PackageDownloads{
+		{
+			PackageName: "pkg1",
+			Version:     "1.0.0",
+			Day:         "2021-12-08",
+			Total:       10,
+		},
+	}
+	downloadsJSON, _ := json.Marshal(downloads)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.RegisterPackagesDownloads(context.Background(), "repo1", downloads)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+		testCases := []struct {
+			errMsg    string
+			name      string
+			downloads []*hub.PackageDownloads
+		}{
+			{
+				"name not provided",
+				"",
+				downloads,
+			},
+			{
+				"downloads not provided",
+				"repo1",
+				nil,
+			},
+			{
+				"too many downloads entries",
+				"repo1",
+				make([]*hub.PackageDownloads, maxPackagesDownloadsEntries+1),
+			},
+			{
+				"package name not provided",
+				"repo1",
+				[]*hub.PackageDownloads{{Version: "1.0.0", Day: "2021-12-08"}},
+			},
+			{
+				"version not provided",
+				"repo1",
+				[]*hub.PackageDownloads{{PackageName: "pkg1", Day: "2021-12-08"}},
+			},
+			{
+				"invalid day",
+				"repo1",
+				[]*hub.PackageDownloads{{PackageName: "pkg1", Version: "1.0.0", Day: "08/12/2021"}},
+			},
+			{
+				"day is in the future",
+				"repo1",
+				[]*hub.PackageDownloads{{PackageName: "pkg1", Version: "1.0.0", Day: tomorrow}},
+			},
+			{
+				"invalid total",
+				"repo1",
+				[]*hub.PackageDownloads{{PackageName: "pkg1", Version: "1.0.0", Day: "2021-12-08", Total: -1}},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.RegisterPackagesDownloads(ctx, tc.name, tc.downloads)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(`
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"organization_name": "orgName"
+		}
+		`), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, db, az, nil)
+
+		err := m.RegisterPackagesDownloads(ctx, "repo1", downloads)
+		assert.Equal(t, tests.ErrFake, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(`
+				{
+					"repository_id": "00000000-0000-0000-0000-000000000001",
+					"name": "repo1",
+					"user_alias": "user1"
+				}
+				`), nil)
+				db.On("Exec", ctx, registerPkgsDownloadsDBQ, "userID", "repo1", downloadsJSON).Return(tc.dbErr)
+				m := NewManager(cfg, db, nil, nil)
 
-		r := &hub.Repository{
-			Kind: hub.Helm,
-			URL:  ociRepoURL,
+				err := m.RegisterPackagesDownloads(ctx, "repo1", downloads)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
 		}
-		md, err := m.GetMetadata(r, "")
-		assert.Equal(t, expectedMetadata, md)
-		assert.NoError(t, err)
-		op.AssertExpectations(t)
 	})
 
-	t.Run("remote file (oci - with creds): success", func(t *testing.T) {
+	t.Run("register packages downloads succeeded", func(t *testing.T) {
 		t.Parallel()
-		op := &oci.PullerMock{}
-		op.On("PullLayer", mock.Anything, ociRef, MetadataLayerMediaType, "user", "pass").
-			Return(ocispec.Descriptor{}, expectedMetadataYaml, nil)
-		m := NewManager(cfg, nil, nil, nil, WithOCIPuller(op))
-
-		r := &hub.Repository{
-			Kind:     hub.Helm,
-			URL:      ociRepoURL,
-			AuthUser: "user",
-			AuthPass: "pass",
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(`
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"organization_name": "orgName"
 		}
-		md, err := m.GetMetadata(r, "")
-		assert.Equal(t, expectedMetadata, md)
+		`), nil)
+		db.On("Exec", ctx, registerPkgsDownloadsDBQ, "userID", "repo1", downloadsJSON).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+		}).Return(nil)
+		m := NewManager(cfg, db, az, nil)
+
+		err := m.RegisterPackagesDownloads(ctx, "repo1", downloads)
 		assert.NoError(t, err)
-		op.AssertExpectations(t)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
 	})
 }
 
-func TestGetPackagesDigest(t *testing.T) {
+func TestRegisterTrackingRun(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			repositoryID string
+			duration     time.Duration
+			outcome      string
+			errMsg       string
+		}{
+			{
+				"invalid",
+				time.Second,
+				"success",
+				"invalid repository id",
+			},
+			{
+				repoID,
+				-time.Second,
+				"success",
+				"invalid duration",
+			},
+			{
+				repoID,
+				time.Second,
+				"invalid",
+				"invalid outcome",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil, nil)
+				err := m.RegisterTrackingRun(ctx, tc.repositoryID, tc.duration, tc.outcome)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database update succeeded", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(cfg, nil, nil, nil)
-		_, err := m.GetPackagesDigest(context.Background(), "invalid")
-		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerTrackingRunDBQ, repoID, 1.5, "success").Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.RegisterTrackingRun(ctx, repoID, 1500*time.Millisecond, "success")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
 	})
 
-	t.Run("database query succeeded", func(t *testing.T) {
+	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getRepoPkgsDigestDBQ, "00000000-0000-0000-0000-000000000001").Return([]byte(`
-		{
-			"package1@1.0.0": "digest-package1-1.0.0",
-			"package1@0.0.9": "digest-package1-0.0.9",
-			"package2@1.0.0": "digest-package2-1.0.0",
-			"package2@0.0.9": "digest-package2-0.0.9"
-		}
-		`), nil)
+		db.On("Exec", ctx, registerTrackingRunDBQ, repoID, 1.5, "error").Return(tests.ErrFakeDB)
 		m := NewManager(cfg, db, nil, nil)
 
-		pd, err := m.GetPackagesDigest(ctx, "00000000-0000-0000-0000-000000000001")
-		require.NoError(t, err)
-		assert.Len(t, pd, 4)
-		assert.Equal(t, "digest-package1-1.0.0", pd["package1@1.0.0"])
-		assert.Equal(t, "digest-package1-0.0.9", pd["package1@0.0.9"])
-		assert.Equal(t, "digest-package2-1.0.0", pd["package2@1.0.0"])
-		assert.Equal(t, "digest-package2-0.0.9", pd["package2@0.0.9"])
+		err := m.RegisterTrackingRun(ctx, repoID, 1500*time.Millisecond, "error")
+		assert.Equal(t, tests.ErrFakeDB, err)
 		db.AssertExpectations(t)
 	})
 }
 
-func TestGetRemoteDigest(t *testing.T) {
-	ctx := context.Background()
-	helmHTTP := &hub.Repository{
-		Kind: hub.Helm,
-		Name: "repo1",
-		URL:  "https://myrepo.url",
-	}
-	helmOCI := &hub.Repository{
-		Kind: hub.Helm,
-		Name: "repo1",
-		URL:  "oci://myrepo.url/chart",
-	}
+func TestRejectChange(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	changeID := "00000000-0000-0000-0000-000000000001"
 
-	t.Run("helm-http: error loading index", func(t *testing.T) {
+	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		l := &HelmIndexLoaderMock{}
-		l.On("LoadIndex", helmHTTP).Return(nil, "", tests.ErrFake)
-		m := NewManager(cfg, nil, nil, nil, WithHelmIndexLoader(l))
+		m := NewManager(cfg, nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.RejectChange(context.Background(), "orgName", changeID)
+		})
+	})
 
-		digest, err := m.GetRemoteDigest(ctx, helmHTTP)
-		assert.Empty(t, digest)
-		assert.Equal(t, tests.ErrFake, err)
-		l.AssertExpectations(t)
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil, nil)
+		err := m.RejectChange(ctx, "orgName", "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid change id")
 	})
 
-	t.Run("helm-http: success", func(t *testing.T) {
+	t.Run("change not found", func(t *testing.T) {
 		t.Parallel()
-		l := &HelmIndexLoaderMock{}
-		l.On("LoadIndex", helmHTTP).Return(nil, "digest", nil)
-		m := NewManager(cfg, nil, nil, nil, WithHelmIndexLoader(l))
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoChangeDBQ, "orgName", changeID).Return(nil, pgx.ErrNoRows)
+		m := NewManager(cfg, db, nil, nil)
 
-		digest, err := m.GetRemoteDigest(ctx, helmHTTP)
-		assert.Equal(t, "digest", digest)
-		assert.Nil(t, err)
-		l.AssertExpectations(t)
+		err := m.RejectChange(ctx, "orgName", changeID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
 	})
 
-	t.Run("helm-oci: error getting tags", func(t *testing.T) {
+	t.Run("authorization failed", func(t *testing.T) {
 		t.Parallel()
-		tg := &oci.TagsGetterMock{}
-		tg.On("Tags", ctx, helmOCI, true).Return(nil, tests.ErrFake)
-		m := NewManager(cfg, nil, nil, nil, WithOCITagsGetter(tg))
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoChangeDBQ, "orgName", changeID).Return([]interface{}{"add", "user2ID"}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationRepository,
+		}).Return(tests.ErrFake)
+		m := NewManager(cfg, db, az, nil)
 
-		digest, err := m.GetRemoteDigest(ctx, helmOCI)
-		assert.Empty(t, digest)
+		err := m.RejectChange(ctx, "orgName", changeID)
 		assert.Equal(t, tests.ErrFake, err)
-		tg.AssertExpectations(t)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
 	})
 
-	t.Run("helm-oci: success", func(t *testing.T) {
+	t.Run("change rejected successfully", func(t *testing.T) {
 		t.Parallel()
-		tg := &oci.TagsGetterMock{}
-		tg.On("Tags", ctx, helmOCI, true).Return([]string{"2.0.0", "1.0.0"}, nil)
-		m := NewManager(cfg, nil, nil, nil, WithOCITagsGetter(tg))
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoChangeDBQ, "orgName", changeID).Return([]interface{}{"add", "userID"}, nil)
+		db.On("Exec", ctx, rejectRepoChangeDBQ, "userID", "orgName", changeID).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.AddOrganizationRepository,
+		}).Return(nil)
+		m := NewManager(cfg, db, az, nil)
 
-		digest, err := m.GetRemoteDigest(ctx, helmOCI)
-		assert.Equal(t, "32b4478532e3fbd46940cfaa0b288bc328817cec9ef38e81c9d19a803bcff285", digest)
-		assert.Nil(t, err)
-		tg.AssertExpectations(t)
+		err := m.RejectChange(ctx, "orgName", changeID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
 	})
 }
 
@@ -1472,21 +2647,37 @@ func TestSetLastScanningResults(t *testing.T) {
 
 func TestSetLastTrackingResults(t *testing.T) {
 	ctx := context.Background()
+	errs := []*hub.TrackingError{
+		{Code: hub.TrackingErrorNetwork, Message: "error1"},
+		{Code: hub.TrackingErrorUnknown, Message: "error2"},
+	}
+	errsDetailsJSON := []byte(`[{"code":"network","message":"error1"},{"code":"unknown","message":"error2"}]`)
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
-		err := m.SetLastTrackingResults(ctx, "invalid", "errors")
+		err := m.SetLastTrackingResults(ctx, "invalid", errs)
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
 
 	t.Run("database update succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, setLastTrackingResultsDBQ, repoID, "errors", false).Return(nil)
+		db.On("Exec", ctx, setLastTrackingResultsDBQ, repoID, "error1\nerror2", errsDetailsJSON, false).Return(nil)
+		m := NewManager(cfg, db, nil, nil)
+
+		err := m.SetLastTrackingResults(ctx, repoID, errs)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database update succeeded (no errors)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setLastTrackingResultsDBQ, repoID, "", []byte(nil), false).Return(nil)
 		m := NewManager(cfg, db, nil, nil)
 
-		err := m.SetLastTrackingResults(ctx, repoID, "errors")
+		err := m.SetLastTrackingResults(ctx, repoID, nil)
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
@@ -1494,10 +2685,10 @@ func TestSetLastTrackingResults(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, setLastTrackingResultsDBQ, repoID, "errors", false).Return(tests.ErrFakeDB)
+		db.On("Exec", ctx, setLastTrackingResultsDBQ, repoID, "error1\nerror2", errsDetailsJSON, false).Return(tests.ErrFakeDB)
 		m := NewManager(cfg, db, nil, nil)
 
-		err := m.SetLastTrackingResults(ctx, repoID, "errors")
+		err := m.SetLastTrackingResults(ctx, repoID, errs)
 		assert.Equal(t, tests.ErrFakeDB, err)
 		db.AssertExpectations(t)
 	})
@@ -1547,7 +2738,7 @@ func TestTransfer(t *testing.T) {
 		t.Parallel()
 		m := NewManager(cfg, nil, nil, nil)
 		assert.Panics(t, func() {
-			_ = m.Transfer(context.Background(), "repo1", "", false)
+			_ = m.Transfer(context.Background(), "repo1", "")
 		})
 	})
 
@@ -1567,7 +2758,7 @@ func TestTransfer(t *testing.T) {
 				t.Parallel()
 				m := NewManager(cfg, nil, nil, nil)
 
-				err := m.Transfer(ctx, tc.repoName, "", false)
+				err := m.Transfer(ctx, tc.repoName, "")
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 			})
 		}
@@ -1591,7 +2782,7 @@ func TestTransfer(t *testing.T) {
 		}).Return(tests.ErrFake)
 		m := NewManager(cfg, db, az, nil)
 
-		err := m.Transfer(ctx, "repo1", "orgDest", false)
+		err := m.Transfer(ctx, "repo1", "orgDest")
 		assert.Equal(t, tests.ErrFake, err)
 		db.AssertExpectations(t)
 		az.AssertExpectations(t)
@@ -1623,7 +2814,8 @@ func TestTransfer(t *testing.T) {
 					"organization_name": "orgName"
 				}
 				`), nil)
-				db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, false).Return(tc.dbErr)
+				db.On("QueryRow", ctx, isApprovalRequiredDBQ, "orgName").Return(false, nil)
+				db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, (*string)(nil)).Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
 				az.On("Authorize", ctx, &hub.AuthorizeInput{
 					OrganizationName: "orgName",
@@ -1632,7 +2824,7 @@ func TestTransfer(t *testing.T) {
 				}).Return(nil)
 				m := NewManager(cfg, db, az, nil)
 
-				err := m.Transfer(ctx, "repo1", org, false)
+				err := m.Transfer(ctx, "repo1", org)
 				assert.Equal(t, tc.expectedError, err)
 				db.AssertExpectations(t)
 				az.AssertExpectations(t)
@@ -1650,13 +2842,40 @@ func TestTransfer(t *testing.T) {
 			"user_alias": "user1"
 		}
 		`), nil)
-		db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, false).Return(nil)
+		db.On("Exec", ctx, transferRepoDBQ, "repo1", userIDP, orgP, (*string)(nil)).Return(nil)
 		m := NewManager(cfg, db, nil, nil)
 
-		err := m.Transfer(ctx, "repo1", org, false)
+		err := m.Transfer(ctx, "repo1", org)
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("transfer repository pending approval", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(`
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"organization_name": "orgName"
+		}
+		`), nil)
+		db.On("QueryRow", ctx, isApprovalRequiredDBQ, "orgName").Return(true, nil)
+		changeJSON := []byte(`{"kind":"transfer","repository_name":"repo1","transfer_organization_name":"org1"}`)
+		db.On("QueryRow", ctx, registerRepoChangeDBQ, "userID", "orgName", changeJSON).Return("changeID", nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "orgName",
+			UserID:           "userID",
+			Action:           hub.TransferOrganizationRepository,
+		}).Return(nil)
+		m := NewManager(cfg, db, az, nil)
+
+		err := m.Transfer(ctx, "repo1", org)
+		assert.True(t, errors.Is(err, hub.ErrPendingApproval))
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -1811,6 +3030,34 @@ func TestUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("version conflict", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{
+			Name:        "repo1",
+			DisplayName: "Repository 1",
+			URL:         "https://repo1.com",
+			Kind:        hub.Helm,
+			Version:     1,
+		}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoByNameDBQ, "repo1", false).Return([]byte(`
+		{
+			"repository_id": "00000000-0000-0000-0000-000000000001",
+			"name": "repo1",
+			"organization_name": "orgName",
+			"version": 2
+		}
+		`), nil)
+		l := &HelmIndexLoaderMock{}
+		l.On("LoadIndex", r).Return(nil, "", nil)
+		m := NewManager(cfg, db, nil, nil, WithHelmIndexLoader(l))
+
+		err := m.Update(ctx, r)
+		assert.True(t, errors.Is(err, hub.ErrConflict))
+		db.AssertExpectations(t)
+		l.AssertExpectations(t)
+	})
+
 	t.Run("authorization failed", func(t *testing.T) {
 		t.Parallel()
 		r := &hub.Repository{
@@ -1870,6 +3117,16 @@ func TestUpdate(t *testing.T) {
 				util.ErrDBInsufficientPrivilege,
 				hub.ErrInsufficientPrivilege,
 			},
+			{
+				&hub.Repository{
+					Name:        "repo1",
+					DisplayName: "Repository 1",
+					URL:         "https://repo1.com",
+					Kind:        hub.Helm,
+				},
+				util.ErrDBVersionConflict,
+				errRepoVersionConflict,
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -1883,6 +3140,7 @@ func TestUpdate(t *testing.T) {
 					"organization_name": "orgName"
 				}
 				`), nil)
+				db.On("QueryRow", ctx, isApprovalRequiredDBQ, "orgName").Return(false, nil)
 				db.On("Exec", ctx, updateRepoDBQ, "userID", mock.Anything).Return(tc.dbErr)
 				az := &authz.AuthorizerMock{}
 				az.On("Authorize", ctx, &hub.AuthorizeInput{
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/stretchr/testify/mock"
@@ -63,6 +64,12 @@ func (m *ManagerMock) Add(ctx context.Context, orgName string, r *hub.Repository
 	return args.Error(0)
 }
 
+// ApproveChange implements the RepositoryManager interface.
+func (m *ManagerMock) ApproveChange(ctx context.Context, orgName, changeID string) error {
+	args := m.Called(ctx, orgName, changeID)
+	return args.Error(0)
+}
+
 // CheckAvailability implements the RepositoryManager interface.
 func (m *ManagerMock) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
 	args := m.Called(ctx, resourceKind, value)
@@ -110,6 +117,17 @@ func (m *ManagerMock) GetMetadata(r *hub.Repository, basePath string) (*hub.Repo
 	return data, args.Error(1)
 }
 
+// GetEventsJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetEventsJSON(
+	ctx context.Context,
+	name string,
+	input *hub.GetRepositoryEventsInput,
+) ([]byte, error) {
+	args := m.Called(ctx, name, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetPackagesDigest implements the RepositoryManager interface.
 func (m *ManagerMock) GetPackagesDigest(
 	ctx context.Context,
@@ -120,12 +138,73 @@ func (m *ManagerMock) GetPackagesDigest(
 	return data, args.Error(1)
 }
 
+// GetPendingChangesJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetPendingChangesJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetRemoteDigest implements the RepositoryManager interface.
 func (m *ManagerMock) GetRemoteDigest(ctx context.Context, r *hub.Repository) (string, error) {
 	args := m.Called(ctx, r)
 	return args.String(0), args.Error(1)
 }
 
+// GetStatsJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetStatsJSON(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetSubscriptionsJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetSubscriptionsJSON(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetViewsJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetViewsJSON(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// RegisterOwnershipClaimToken implements the RepositoryManager interface.
+func (m *ManagerMock) RegisterOwnershipClaimToken(ctx context.Context, name string) (string, error) {
+	args := m.Called(ctx, name)
+	return args.String(0), args.Error(1)
+}
+
+// RegisterPackagesDownloads implements the RepositoryManager interface.
+func (m *ManagerMock) RegisterPackagesDownloads(
+	ctx context.Context,
+	name string,
+	downloads []*hub.PackageDownloads,
+) error {
+	args := m.Called(ctx, name, downloads)
+	return args.Error(0)
+}
+
+// RegisterTrackingRun implements the RepositoryManager interface.
+func (m *ManagerMock) RegisterTrackingRun(
+	ctx context.Context,
+	repositoryID string,
+	duration time.Duration,
+	outcome string,
+) error {
+	args := m.Called(ctx, repositoryID, duration, outcome)
+	return args.Error(0)
+}
+
+// RejectChange implements the RepositoryManager interface.
+func (m *ManagerMock) RejectChange(ctx context.Context, orgName, changeID string) error {
+	args := m.Called(ctx, orgName, changeID)
+	return args.Error(0)
+}
+
 // Search implements the RepositoryManager interface.
 func (m *ManagerMock) Search(
 	ctx context.Context,
@@ -153,7 +232,11 @@ func (m *ManagerMock) SetLastScanningResults(ctx context.Context, repositoryID,
 }
 
 // SetLastTrackingResults implements the RepositoryManager interface.
-func (m *ManagerMock) SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error {
+func (m *ManagerMock) SetLastTrackingResults(
+	ctx context.Context,
+	repositoryID string,
+	errs []*hub.TrackingError,
+) error {
 	args := m.Called(ctx, repositoryID, errs)
 	return args.Error(0)
 }
@@ -165,8 +248,8 @@ func (m *ManagerMock) SetVerifiedPublisher(ctx context.Context, repositoryID str
 }
 
 // Transfer implements the RepositoryManager interface.
-func (m *ManagerMock) Transfer(ctx context.Context, name, orgName string, ownershipClaim bool) error {
-	args := m.Called(ctx, name, orgName, ownershipClaim)
+func (m *ManagerMock) Transfer(ctx context.Context, name, orgName string) error {
+	args := m.Called(ctx, name, orgName)
 	return args.Error(0)
 }
 
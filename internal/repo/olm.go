@@ -3,15 +3,23 @@ package repo
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 )
 
+const (
+	// olmExportTimeout represents the maximum time allowed to export the
+	// packages available in an OLM repository stored in an OCI registry.
+	olmExportTimeout = 5 * time.Minute
+)
+
 // OLMOCIExporter provides a mechanism to export the packages available in an
 // OLM repository stored in an OCI registry.
 type OLMOCIExporter struct{}
@@ -28,6 +36,8 @@ func (e *OLMOCIExporter) ExportRepository(ctx context.Context, r *hub.Repository
 	}
 
 	// Export repository packages using opm (external tool)
+	ctx, cancel := context.WithTimeout(ctx, olmExportTimeout)
+	defer cancel()
 	indexRef := strings.TrimPrefix(r.URL, hub.RepositoryOCIPrefix)
 	cmd := exec.CommandContext(ctx, "opm", "index", "export", "-i", indexRef, "-f", tmpDir) // #nosec
 	var stderr bytes.Buffer
@@ -38,6 +48,10 @@ func (e *OLMOCIExporter) ExportRepository(ctx context.Context, r *hub.Repository
 		"HOME=" + os.Getenv("HOME"),
 	}
 	if err := cmd.Run(); err != nil {
+		os.RemoveAll(tmpDir)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("error running opm index export (%s): timeout after %s", indexRef, olmExportTimeout)
+		}
 		return "", fmt.Errorf("error running opm index export (%s): %w: %s", indexRef, err, stderr.String())
 	}
 
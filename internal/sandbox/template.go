@@ -0,0 +1,257 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+const (
+	// TemplateExecutionTimeout represents the maximum amount of time a
+	// template execution can take.
+	TemplateExecutionTimeout = 5 * time.Second
+
+	// TemplateMaxOutputSize represents the maximum size of the output a
+	// template execution can produce.
+	TemplateMaxOutputSize = 1 << 20
+
+	// TemplateMaxSize represents the maximum size of the templates parsed
+	// with ParseTemplate.
+	TemplateMaxSize = 64 << 10
+)
+
+var (
+	// ErrTemplateNotAllowed indicates that the template uses some actions or
+	// functions that are not allowed in sandboxed templates.
+	ErrTemplateNotAllowed = errors.New("template not allowed")
+
+	// ErrTemplateOutputTooLarge indicates that the template execution was
+	// aborted because its output exceeded the maximum size allowed.
+	ErrTemplateOutputTooLarge = errors.New("template output too large")
+
+	// ErrTemplateTimeout indicates that the template execution was aborted
+	// because it took longer than allowed.
+	ErrTemplateTimeout = errors.New("template execution timed out")
+)
+
+// allowedTemplateFuncs represents the functions that can be used in sandboxed
+// templates. Some of the builtin ones, like call, are not allowed.
+var allowedTemplateFuncs = map[string]struct{}{
+	"and":      {},
+	"eq":       {},
+	"ge":       {},
+	"gt":       {},
+	"html":     {},
+	"index":    {},
+	"js":       {},
+	"le":       {},
+	"len":      {},
+	"lt":       {},
+	"ne":       {},
+	"not":      {},
+	"or":       {},
+	"print":    {},
+	"printf":   {},
+	"println":  {},
+	"slice":    {},
+	"urlquery": {},
+}
+
+// TemplateExecutor describes the method a template must provide to be
+// executed by ExecuteTemplate. It is implemented by both text and html
+// templates.
+type TemplateExecutor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// ParseTemplate parses the text template provided, which is usually
+// provided by users (i.e. webhooks payloads). Only a restricted set of
+// functions can be used, and invoking other templates is not allowed.
+func ParseTemplate(text string) (*template.Template, error) {
+	if len(text) > TemplateMaxSize {
+		return nil, fmt.Errorf("%w: template too large (max: %d bytes)", ErrTemplateNotAllowed, TemplateMaxSize)
+	}
+	tmpl, err := template.New("").Funcs(template.FuncMap{"printf": printf}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		if err := checkTemplateNode(t.Tree.Root); err != nil {
+			return nil, err
+		}
+		addCancellationPoints(t.Tree.Root)
+	}
+	return tmpl, nil
+}
+
+// checkTemplateNode checks recursively that the template node provided only
+// uses the actions and functions allowed in sandboxed templates.
+func checkTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := checkTemplateNode(c); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkTemplateNode(n.Pipe)
+	case *parse.IfNode:
+		return checkTemplateBranchNode(&n.BranchNode)
+	case *parse.RangeNode:
+		return checkTemplateBranchNode(&n.BranchNode)
+	case *parse.WithNode:
+		return checkTemplateBranchNode(&n.BranchNode)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := checkTemplateNode(cmd); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := checkTemplateNode(arg); err != nil {
+				return err
+			}
+		}
+	case *parse.ChainNode:
+		return checkTemplateNode(n.Node)
+	case *parse.IdentifierNode:
+		if _, ok := allowedTemplateFuncs[n.Ident]; !ok {
+			return fmt.Errorf("%w: function %s not allowed", ErrTemplateNotAllowed, n.Ident)
+		}
+	case *parse.TemplateNode:
+		return fmt.Errorf("%w: template action not allowed", ErrTemplateNotAllowed)
+	}
+	return nil
+}
+
+// checkTemplateBranchNode checks the pipeline and lists of the branch node
+// provided.
+func checkTemplateBranchNode(n *parse.BranchNode) error {
+	if err := checkTemplateNode(n.Pipe); err != nil {
+		return err
+	}
+	if err := checkTemplateNode(n.List); err != nil {
+		return err
+	}
+	return checkTemplateNode(n.ElseList)
+}
+
+// addCancellationPoints adds an empty text node at the beginning of the body
+// of all range actions in the template node provided. Text nodes are always
+// written to the output, even when they are empty, so this allows aborting
+// the execution of loops that do not produce any output once the execution
+// has timed out.
+func addCancellationPoints(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			addCancellationPoints(c)
+		}
+	case *parse.IfNode:
+		addCancellationPoints(n.List)
+		addCancellationPoints(n.ElseList)
+	case *parse.RangeNode:
+		addCancellationPoints(n.List)
+		addCancellationPoints(n.ElseList)
+		cp := &parse.TextNode{NodeType: parse.NodeText, Pos: n.Pos, Text: []byte{}}
+		n.List.Nodes = append([]parse.Node{cp}, n.List.Nodes...)
+	case *parse.WithNode:
+		addCancellationPoints(n.List)
+		addCancellationPoints(n.ElseList)
+	}
+}
+
+// printf replaces the printf builtin function in sandboxed templates. Setting
+// the width or precision of the values formatted is not allowed, as it could
+// be used to allocate large amounts of memory before the output size limit is
+// applied.
+func printf(format string, args ...interface{}) (string, error) {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		if i < len(format) && format[i] == '[' {
+			for i < len(format) && format[i] != ']' {
+				i++
+			}
+			i++
+		}
+		if i < len(format) && strings.IndexByte("*.0123456789", format[i]) >= 0 {
+			return "", fmt.Errorf("%w: width and precision not allowed in printf", ErrTemplateNotAllowed)
+		}
+	}
+	return fmt.Sprintf(format, args...), nil
+}
+
+// ExecuteTemplate applies the template provided to the data given, returning
+// the output produced. The execution is aborted when it takes longer than
+// TemplateExecutionTimeout or its output exceeds TemplateMaxOutputSize.
+func ExecuteTemplate(tmpl TemplateExecutor, data interface{}) ([]byte, error) {
+	return executeTemplate(tmpl, data, TemplateExecutionTimeout)
+}
+
+// executeTemplate applies the template provided to the data given, aborting
+// the execution when it takes longer than the timeout provided.
+func executeTemplate(tmpl TemplateExecutor, data interface{}, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	w := &templateWriter{ctx: ctx, maxSize: TemplateMaxOutputSize}
+	errC := make(chan error, 1)
+	go func() {
+		errC <- tmpl.Execute(w, data)
+	}()
+	select {
+	case err := <-errC:
+		if err != nil {
+			return nil, err
+		}
+		return w.buf.Bytes(), nil
+	case <-ctx.Done():
+		return nil, ErrTemplateTimeout
+	}
+}
+
+// templateWriter is an io.Writer used to collect the output of a template
+// execution. It stops accepting data when the output exceeds the maximum size
+// allowed or the context provided is done, which aborts the execution.
+type templateWriter struct {
+	ctx     context.Context
+	maxSize int
+	buf     bytes.Buffer
+}
+
+// Write implements the io.Writer interface.
+func (w *templateWriter) Write(p []byte) (int, error) {
+	if w.ctx.Err() != nil {
+		return 0, ErrTemplateTimeout
+	}
+	if w.buf.Len()+len(p) > w.maxSize {
+		return 0, ErrTemplateOutputTooLarge
+	}
+	return w.buf.Write(p)
+}
@@ -0,0 +1,156 @@
+package sandbox
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplate(t *testing.T) {
+	t.Run("invalid template", func(t *testing.T) {
+		t.Parallel()
+		_, err := ParseTemplate("{{ .Name ")
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrTemplateNotAllowed))
+	})
+
+	t.Run("template not allowed", func(t *testing.T) {
+		testCases := []struct {
+			tmpl   string
+			errMsg string
+		}{
+			{
+				strings.Repeat("a", TemplateMaxSize+1),
+				"template too large",
+			},
+			{
+				"{{ call .Func }}",
+				"function call not allowed",
+			},
+			{
+				"{{ if true }}{{ call .Func }}{{ end }}",
+				"function call not allowed",
+			},
+			{
+				"{{ range .Items }}{{ else }}{{ call .Func }}{{ end }}",
+				"function call not allowed",
+			},
+			{
+				`{{ with .Name }}{{ printf "%s" (call .Func) }}{{ end }}`,
+				"function call not allowed",
+			},
+			{
+				`{{ define "t" }}{{ template "t" }}{{ end }}{{ template "t" }}`,
+				"template action not allowed",
+			},
+			{
+				`{{ block "t" . }}{{ end }}`,
+				"template action not allowed",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				_, err := ParseTemplate(tc.tmpl)
+				assert.True(t, errors.Is(err, ErrTemplateNotAllowed))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("template allowed", func(t *testing.T) {
+		t.Parallel()
+		tmpl, err := ParseTemplate(`{{ range $i, $e := .Items }}{{ if $i }}, {{ end }}{{ printf "%q" $e }}{{ end }}`)
+		require.NoError(t, err)
+		output, err := ExecuteTemplate(tmpl, map[string]interface{}{
+			"Items": []string{"a", "b"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []byte(`"a", "b"`), output)
+	})
+
+	t.Run("printf width and precision not allowed", func(t *testing.T) {
+		t.Parallel()
+		for _, format := range []string{"%999999999d", "%.999999999f", "%*d", "%-10s", "%[1]5d"} {
+			tmpl, err := ParseTemplate(`{{ printf "` + format + `" 1 }}`)
+			require.NoError(t, err)
+			_, err = ExecuteTemplate(tmpl, nil)
+			assert.True(t, errors.Is(err, ErrTemplateNotAllowed), format)
+		}
+	})
+}
+
+func TestExecuteTemplate(t *testing.T) {
+	t.Run("output too large", func(t *testing.T) {
+		t.Parallel()
+		tmpl := template.Must(template.New("").Parse(`{{ range .Items }}{{ range $.Items }}{{ $.Text }}{{ end }}{{ end }}`))
+		data := map[string]interface{}{
+			"Items": make([]struct{}, 1000),
+			"Text":  strings.Repeat("a", 10),
+		}
+		_, err := ExecuteTemplate(tmpl, data)
+		assert.True(t, errors.Is(err, ErrTemplateOutputTooLarge))
+	})
+
+	t.Run("execution timed out", func(t *testing.T) {
+		t.Parallel()
+		_, err := executeTemplate(&slowTemplate{d: 1 * time.Second}, nil, 10*time.Millisecond)
+		assert.True(t, errors.Is(err, ErrTemplateTimeout))
+	})
+
+	t.Run("execution without output is aborted after timing out", func(t *testing.T) {
+		t.Parallel()
+		tmpl, err := ParseTemplate(`{{ range .Items }}{{ range $.Items }}{{ if $.Check }}{{ end }}{{ end }}{{ end }}`)
+		require.NoError(t, err)
+		data := &loopData{Items: make([]struct{}, 1000000)}
+		_, err = executeTemplate(tmpl, data, 50*time.Millisecond)
+		assert.True(t, errors.Is(err, ErrTemplateTimeout))
+		time.Sleep(50 * time.Millisecond)
+		callsAfterTimeout := atomic.LoadInt64(&data.calls)
+		assert.Greater(t, callsAfterTimeout, int64(0))
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, callsAfterTimeout, atomic.LoadInt64(&data.calls))
+	})
+
+	t.Run("execution succeeded", func(t *testing.T) {
+		t.Parallel()
+		tmpl := template.Must(template.New("").Parse(`Hello {{ .Name }}`))
+		output, err := ExecuteTemplate(tmpl, map[string]string{"Name": "test"})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("Hello test"), output)
+	})
+}
+
+// loopData is the data used to check that executions that do not produce any
+// output are aborted once they time out.
+type loopData struct {
+	Items []struct{}
+	calls int64
+}
+
+// Check records that it has been called.
+func (d *loopData) Check() bool {
+	atomic.AddInt64(&d.calls, 1)
+	return false
+}
+
+// slowTemplate is a TemplateExecutor implementation that takes the duration
+// provided to execute.
+type slowTemplate struct {
+	d time.Duration
+}
+
+// Execute implements the TemplateExecutor interface.
+func (t *slowTemplate) Execute(w io.Writer, data interface{}) error {
+	time.Sleep(t.d)
+	_, err := w.Write([]byte("done"))
+	return err
+}
@@ -0,0 +1,172 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+// defaultCacheTTL represents the default period of time during which the
+// results of scanning an image are reused.
+const defaultCacheTTL = 12 * time.Hour
+
+// imageScansCache tracks the lookups of images scans in the cache.
+var imageScansCache = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "scanner_image_scans_cache_total",
+	Help: "Number of images scans cache lookups by result.",
+},
+	[]string{"result"},
+)
+
+// ImageScanStore describes the methods an ImageScanStore implementation must
+// provide. An image scan store is used to cache the results of scanning
+// containers images, identified by their digest, so that they can be reused
+// across packages.
+type ImageScanStore interface {
+	GetImageScan(ctx context.Context, digest string) (*hub.ImageScan, error)
+	RegisterImageScan(ctx context.Context, s *hub.ImageScan) error
+}
+
+// ImageDigestResolver describes the methods an ImageDigestResolver
+// implementation must provide. An image digest resolver is responsible of
+// getting the digest a container image reference points to.
+type ImageDigestResolver interface {
+	// ResolveDigest returns the digest of the image provided.
+	ResolveDigest(image string) (string, error)
+}
+
+// RemoteImageDigestResolver is an ImageDigestResolver implementation that gets
+// the digest of containers images from the registries hosting them.
+type RemoteImageDigestResolver struct {
+	ctx context.Context
+	cfg *viper.Viper
+}
+
+// ResolveDigest implements the ImageDigestResolver interface.
+func (r *RemoteImageDigestResolver) ResolveDigest(image string) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	options := []remote.Option{
+		remote.WithContext(r.ctx),
+	}
+	if strings.HasSuffix(ref.Context().Registry.Name(), "docker.io") {
+		options = append(options, remote.WithAuth(&authn.Basic{
+			Username: r.cfg.GetString("creds.dockerUsername"),
+			Password: r.cfg.GetString("creds.dockerPassword"),
+		}))
+	}
+	desc, err := remote.Head(ref, options...)
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest.String(), nil
+}
+
+// getImageScan returns the results of scanning the image provided. When an
+// image scan store is available, results are cached by image digest, so the
+// image is only scanned again when its digest changes or the cached results
+// expire. Concurrent requests for the same digest are serialized, so that
+// images shared by several snapshots being processed at the same time are
+// scanned only once.
+func (s *Scanner) getImageScan(sn *hub.SnapshotToScan, image string) (*hub.ImageScan, error) {
+	if s.store == nil {
+		return s.scanImage(sn, image, image)
+	}
+
+	// Resolve image digest
+	digest, err := s.dr.ResolveDigest(image)
+	if err != nil {
+		log.Debug().Err(err).Str("image", image).Msg("error resolving image digest, skipping cache")
+		return s.scanImage(sn, image, image)
+	}
+	unlock := s.locks.lock(digest)
+	defer unlock()
+
+	// Reuse cached results when they are still fresh
+	cached, err := s.store.GetImageScan(s.ctx, digest)
+	if err != nil && !errors.Is(err, hub.ErrNotFound) {
+		log.Warn().Err(err).Str("digest", digest).Msg("error getting cached image scan")
+	}
+	if cached != nil && time.Since(time.Unix(cached.CreatedAt, 0)) < s.cacheTTL {
+		imageScansCache.WithLabelValues("hit").Inc()
+		return cached, nil
+	}
+	imageScansCache.WithLabelValues("miss").Inc()
+
+	// Scan image, pinning the reference to the digest resolved, and cache the
+	// results (only when all SBOMs were generated successfully)
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return s.scanImage(sn, image, image)
+	}
+	imageScan, err := s.scanImage(sn, image, ref.Context().Digest(digest).Name())
+	if err != nil {
+		return nil, err
+	}
+	imageScan.Digest = digest
+	if len(imageScan.SBOMs) == len(hub.SBOMFormats) {
+		if err := s.store.RegisterImageScan(s.ctx, imageScan); err != nil {
+			log.Warn().Err(err).Str("digest", digest).Msg("error caching image scan")
+		}
+	}
+	return imageScan, nil
+}
+
+// scanImage scans the target image provided for security vulnerabilities and
+// generates its SBOMs. Errors generating the SBOMs are collected, but they
+// don't prevent the scan results from being returned.
+func (s *Scanner) scanImage(sn *hub.SnapshotToScan, image, target string) (*hub.ImageScan, error) {
+	report, err := s.is.ScanImage(target)
+	if err != nil {
+		return nil, err
+	}
+	imageScan := &hub.ImageScan{
+		Report: report,
+	}
+	sboms, err := s.sbg.GenerateSBOMs(target)
+	if err != nil {
+		err := fmt.Errorf("error generating sboms for image %s: %w (package %s:%s)", image, err, sn.PackageName, sn.Version)
+		s.ec.Append(sn.RepositoryID, err.Error())
+	} else {
+		imageScan.SBOMs = sboms
+	}
+	return imageScan, nil
+}
+
+// keyedMutex provides a set of mutexes identified by a key.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock locks the mutex identified by the key provided, returning a function
+// that unlocks it.
+func (km *keyedMutex) lock(key string) func() {
+	km.mu.Lock()
+	if km.locks == nil {
+		km.locks = make(map[string]*sync.Mutex)
+	}
+	l, ok := km.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		km.locks[key] = l
+	}
+	km.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
@@ -0,0 +1,186 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/viper"
+)
+
+// osPkgsTypes represents the Grype artifacts types that correspond to
+// operating system packages.
+var osPkgsTypes = map[string]bool{
+	"apk": true,
+	"deb": true,
+	"rpm": true,
+}
+
+// GrypeScanner is an ImageScanner implementation that uses Grype to scan
+// containers images for security vulnerabilities. Grype reports are converted
+// to the Trivy report format, which is the one used to store and display the
+// security reports in Artifact Hub.
+type GrypeScanner struct {
+	ctx context.Context
+	cfg *viper.Viper
+}
+
+// ScanImage implements the ImageScanner interface.
+func (s *GrypeScanner) ScanImage(image string) ([]byte, error) {
+	// Setup grype command
+	cmd := exec.CommandContext(s.ctx, "grype", "--quiet", "-o", "json", "registry:"+image) // #nosec
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"USER=" + os.Getenv("USER"),
+		"HOME=" + os.Getenv("HOME"),
+		"GRYPE_DB_CACHE_DIR=" + os.Getenv("GRYPE_DB_CACHE_DIR"),
+		"GRYPE_CHECK_FOR_APP_UPDATE=false",
+	}
+
+	// If the registry is the Docker Hub, include credentials to avoid rate
+	// limiting issues.
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing image %s ref: %w", image, err)
+	}
+	if strings.HasSuffix(ref.Context().Registry.Name(), "docker.io") {
+		cmd.Env = append(cmd.Env,
+			"GRYPE_REGISTRY_AUTH_AUTHORITY="+ref.Context().Registry.Name(),
+			"GRYPE_REGISTRY_AUTH_USERNAME="+s.cfg.GetString("creds.dockerUsername"),
+			"GRYPE_REGISTRY_AUTH_PASSWORD="+s.cfg.GetString("creds.dockerPassword"),
+		)
+	}
+
+	// Run grype command
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "MANIFEST_UNKNOWN") {
+			return nil, ErrImageNotFound
+		}
+		if strings.Contains(stderr.String(), "UNAUTHORIZED") {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("error running grype on image %s: %s", image, strings.TrimSpace(stderr.String()))
+	}
+	return convertGrypeReport(image, stdout.Bytes())
+}
+
+// grypeReport represents the subset of fields of a Grype json report used to
+// convert it to the Trivy report format.
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID          string   `json:"id"`
+			DataSource  string   `json:"dataSource"`
+			Severity    string   `json:"severity"`
+			URLs        []string `json:"urls"`
+			Description string   `json:"description"`
+			Fix         struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			Type    string `json:"type"`
+		} `json:"artifact"`
+	} `json:"matches"`
+	Distro struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"distro"`
+}
+
+// trivyReport represents the subset of fields of a Trivy json report that
+// are populated from a Grype report.
+type trivyReport struct {
+	SchemaVersion int            `json:"SchemaVersion"`
+	ArtifactName  string         `json:"ArtifactName"`
+	ArtifactType  string         `json:"ArtifactType"`
+	Results       []*trivyResult `json:"Results,omitempty"`
+}
+
+// trivyResult represents a result entry in a Trivy json report.
+type trivyResult struct {
+	Target          string                `json:"Target"`
+	Class           string                `json:"Class"`
+	Type            string                `json:"Type"`
+	Vulnerabilities []*trivyVulnerability `json:"Vulnerabilities,omitempty"`
+}
+
+// trivyVulnerability represents a vulnerability entry in a Trivy json report.
+type trivyVulnerability struct {
+	VulnerabilityID  string   `json:"VulnerabilityID"`
+	PkgName          string   `json:"PkgName"`
+	InstalledVersion string   `json:"InstalledVersion"`
+	FixedVersion     string   `json:"FixedVersion,omitempty"`
+	PrimaryURL       string   `json:"PrimaryURL,omitempty"`
+	Description      string   `json:"Description,omitempty"`
+	Severity         string   `json:"Severity"`
+	References       []string `json:"References,omitempty"`
+}
+
+// convertGrypeReport converts the Grype json report provided to the Trivy
+// json report format.
+func convertGrypeReport(image string, data []byte) ([]byte, error) {
+	var gr *grypeReport
+	if err := json.Unmarshal(data, &gr); err != nil {
+		return nil, fmt.Errorf("error unmarshalling grype report: %w", err)
+	}
+	tr := &trivyReport{
+		SchemaVersion: 2,
+		ArtifactName:  image,
+		ArtifactType:  "container_image",
+	}
+
+	// Group vulnerabilities by artifact type, as Trivy does
+	results := make(map[string]*trivyResult)
+	for _, m := range gr.Matches {
+		r, ok := results[m.Artifact.Type]
+		if !ok {
+			r = &trivyResult{
+				Target: image,
+				Class:  "lang-pkgs",
+				Type:   m.Artifact.Type,
+			}
+			if osPkgsTypes[m.Artifact.Type] {
+				r.Class = "os-pkgs"
+				if gr.Distro.Name != "" {
+					r.Target = fmt.Sprintf("%s (%s %s)", image, gr.Distro.Name, gr.Distro.Version)
+					r.Type = gr.Distro.Name
+				}
+			}
+			results[m.Artifact.Type] = r
+		}
+		v := &trivyVulnerability{
+			VulnerabilityID:  m.Vulnerability.ID,
+			PkgName:          m.Artifact.Name,
+			InstalledVersion: m.Artifact.Version,
+			FixedVersion:     strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			PrimaryURL:       m.Vulnerability.DataSource,
+			Description:      m.Vulnerability.Description,
+			Severity:         strings.ToUpper(m.Vulnerability.Severity),
+			References:       m.Vulnerability.URLs,
+		}
+		if v.Severity == "NEGLIGIBLE" {
+			v.Severity = "LOW"
+		}
+		r.Vulnerabilities = append(r.Vulnerabilities, v)
+	}
+	for _, r := range results {
+		tr.Results = append(tr.Results, r)
+	}
+	sort.Slice(tr.Results, func(i, j int) bool {
+		return tr.Results[i].Type < tr.Results[j].Type
+	})
+
+	return json.Marshal(tr)
+}
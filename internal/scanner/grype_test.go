@@ -0,0 +1,51 @@
+package scanner
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	trivy "github.com/aquasecurity/trivy/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertGrypeReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid grype report", func(t *testing.T) {
+		t.Parallel()
+		_, err := convertGrypeReport("repo/image:tag", []byte("{invalid"))
+		assert.Error(t, err)
+	})
+
+	t.Run("grype report converted successfully", func(t *testing.T) {
+		t.Parallel()
+		data, err := ioutil.ReadFile("testdata/grype-report.json")
+		require.NoError(t, err)
+
+		reportData, err := convertGrypeReport("repo/image:tag", data)
+		require.NoError(t, err)
+		var report *trivy.Report
+		require.NoError(t, json.Unmarshal(reportData, &report))
+
+		assert.Equal(t, "repo/image:tag", report.ArtifactName)
+		require.Len(t, report.Results, 2)
+		assert.Equal(t, "repo/image:tag (debian 11)", report.Results[0].Target)
+		assert.Equal(t, "os-pkgs", string(report.Results[0].Class))
+		require.Len(t, report.Results[0].Vulnerabilities, 1)
+		v1 := report.Results[0].Vulnerabilities[0]
+		assert.Equal(t, "CVE-2022-0001", v1.VulnerabilityID)
+		assert.Equal(t, "libssl", v1.PkgName)
+		assert.Equal(t, "1.2.3", v1.InstalledVersion)
+		assert.Equal(t, "1.2.4", v1.FixedVersion)
+		assert.Equal(t, "HIGH", v1.Severity)
+		assert.Equal(t, "repo/image:tag", report.Results[1].Target)
+		assert.Equal(t, "lang-pkgs", string(report.Results[1].Class))
+		require.Len(t, report.Results[1].Vulnerabilities, 1)
+		v2 := report.Results[1].Vulnerabilities[0]
+		assert.Equal(t, "golang.org/x/text", v2.PkgName)
+		assert.Equal(t, "", v2.FixedVersion)
+		assert.Equal(t, "LOW", v2.Severity)
+	})
+}
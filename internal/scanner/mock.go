@@ -1,6 +1,10 @@
 package scanner
 
-import "github.com/stretchr/testify/mock"
+import (
+	"encoding/json"
+
+	"github.com/stretchr/testify/mock"
+)
 
 // ImageScannerMock is an ImageScanner mock implementation.
 type ImageScannerMock struct {
@@ -13,3 +17,26 @@ func (m *ImageScannerMock) ScanImage(image string) ([]byte, error) {
 	data, _ := args.Get(0).([]byte)
 	return data, args.Error(1)
 }
+
+// SBOMGeneratorMock is a SBOMGenerator mock implementation.
+type SBOMGeneratorMock struct {
+	mock.Mock
+}
+
+// GenerateSBOMs implements the SBOMGenerator interface.
+func (m *SBOMGeneratorMock) GenerateSBOMs(image string) (map[string]json.RawMessage, error) {
+	args := m.Called(image)
+	data, _ := args.Get(0).(map[string]json.RawMessage)
+	return data, args.Error(1)
+}
+
+// ImageDigestResolverMock is an ImageDigestResolver mock implementation.
+type ImageDigestResolverMock struct {
+	mock.Mock
+}
+
+// ResolveDigest implements the ImageDigestResolver interface.
+func (m *ImageDigestResolverMock) ResolveDigest(image string) (string, error) {
+	args := m.Called(image)
+	return args.String(0), args.Error(1)
+}
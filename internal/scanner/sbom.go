@@ -0,0 +1,159 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/viper"
+)
+
+// syftOutputFormats maps the SBOM formats supported to the corresponding Syft
+// output formats.
+var syftOutputFormats = map[string]string{
+	hub.SBOMFormatCycloneDX: "cyclonedx-json",
+	hub.SBOMFormatSPDX:      "spdx-json",
+}
+
+// SBOMGenerator describes the methods a SBOMGenerator implementation must
+// provide. A SBOM generator is responsible of generating the software bill of
+// materials of a container image.
+type SBOMGenerator interface {
+	// GenerateSBOMs generates the SBOMs of the provided image in all the
+	// formats supported, returning them in json format keyed by format.
+	GenerateSBOMs(image string) (map[string]json.RawMessage, error)
+}
+
+// SyftSBOMGenerator is a SBOMGenerator implementation that uses Syft to
+// generate the SBOMs of containers images.
+type SyftSBOMGenerator struct {
+	ctx context.Context
+	cfg *viper.Viper
+}
+
+// GenerateSBOMs implements the SBOMGenerator interface. The image is analyzed
+// only once, as Syft writes the SBOMs in all the formats requested in a
+// single run.
+func (g *SyftSBOMGenerator) GenerateSBOMs(image string) (map[string]json.RawMessage, error) {
+	tmpDir, err := os.MkdirTemp("", "artifacthub-sbom")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Setup syft command
+	args := []string{"packages", "--quiet"}
+	for _, format := range hub.SBOMFormats {
+		args = append(args, "-o", syftOutputFormats[format]+"="+filepath.Join(tmpDir, format+".json"))
+	}
+	args = append(args, "registry:"+image)
+	cmd := exec.CommandContext(g.ctx, "syft", args...) // #nosec
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"USER=" + os.Getenv("USER"),
+		"HOME=" + os.Getenv("HOME"),
+		"SYFT_CHECK_FOR_APP_UPDATE=false",
+	}
+
+	// If the registry is the Docker Hub, include credentials to avoid rate
+	// limiting issues.
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing image %s ref: %w", image, err)
+	}
+	if strings.HasSuffix(ref.Context().Registry.Name(), "docker.io") {
+		cmd.Env = append(cmd.Env,
+			"SYFT_REGISTRY_AUTH_AUTHORITY="+ref.Context().Registry.Name(),
+			"SYFT_REGISTRY_AUTH_USERNAME="+g.cfg.GetString("creds.dockerUsername"),
+			"SYFT_REGISTRY_AUTH_PASSWORD="+g.cfg.GetString("creds.dockerPassword"),
+		)
+	}
+
+	// Run syft command
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running syft on image %s: %s", image, strings.TrimSpace(stderr.String()))
+	}
+
+	// Read the SBOMs generated
+	sboms := make(map[string]json.RawMessage, len(hub.SBOMFormats))
+	for _, format := range hub.SBOMFormats {
+		sbom, err := os.ReadFile(filepath.Join(tmpDir, format+".json"))
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s sbom of image %s: %w", format, image, err)
+		}
+		sboms[format] = sbom
+	}
+	return sboms, nil
+}
+
+// extractLicenses extracts the licenses of the packages listed in the
+// CycloneDX SBOM provided, returning them along with the packages using each
+// of them.
+func extractLicenses(sbom []byte) ([]*hub.ImageLicense, error) {
+	var bom struct {
+		Components []struct {
+			Name     string `json:"name"`
+			Licenses []struct {
+				License struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"license"`
+				Expression string `json:"expression"`
+			} `json:"licenses"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(sbom, &bom); err != nil {
+		return nil, fmt.Errorf("invalid cyclonedx sbom: %w", err)
+	}
+
+	// Group packages by license
+	packagesByLicense := make(map[string]map[string]struct{})
+	for _, c := range bom.Components {
+		for _, l := range c.Licenses {
+			var license string
+			switch {
+			case l.License.ID != "":
+				license = l.License.ID
+			case l.License.Name != "":
+				license = l.License.Name
+			default:
+				license = l.Expression
+			}
+			if license == "" {
+				continue
+			}
+			if packagesByLicense[license] == nil {
+				packagesByLicense[license] = make(map[string]struct{})
+			}
+			packagesByLicense[license][c.Name] = struct{}{}
+		}
+	}
+
+	// Prepare licenses list, sorting licenses and packages by name
+	licenses := make([]*hub.ImageLicense, 0, len(packagesByLicense))
+	for license, packages := range packagesByLicense {
+		il := &hub.ImageLicense{
+			License:  license,
+			Packages: make([]string, 0, len(packages)),
+		}
+		for p := range packages {
+			il.Packages = append(il.Packages, p)
+		}
+		sort.Strings(il.Packages)
+		licenses = append(licenses, il)
+	}
+	sort.Slice(licenses, func(i, j int) bool {
+		return licenses[i].License < licenses[j].License
+	})
+	return licenses, nil
+}
@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractLicenses(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid sbom", func(t *testing.T) {
+		t.Parallel()
+		_, err := extractLicenses([]byte("{invalid"))
+		assert.Error(t, err)
+	})
+
+	t.Run("licenses extracted successfully", func(t *testing.T) {
+		t.Parallel()
+		licenses, err := extractLicenses([]byte(`{
+			"bomFormat": "CycloneDX",
+			"components": [
+				{
+					"name": "zlib",
+					"licenses": [{"license": {"id": "Zlib"}}]
+				},
+				{
+					"name": "musl",
+					"licenses": [{"license": {"id": "MIT"}}]
+				},
+				{
+					"name": "busybox",
+					"licenses": [{"license": {"id": "GPL-2.0-only"}}]
+				},
+				{
+					"name": "ca-certificates",
+					"licenses": [{"license": {"name": "MPL"}}, {"license": {"id": "MIT"}}]
+				},
+				{
+					"name": "libssl",
+					"licenses": [{"expression": "Apache-2.0 OR MIT"}]
+				},
+				{
+					"name": "unlicensed"
+				}
+			]
+		}`))
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.ImageLicense{
+			{License: "Apache-2.0 OR MIT", Packages: []string{"libssl"}},
+			{License: "GPL-2.0-only", Packages: []string{"busybox"}},
+			{License: "MIT", Packages: []string{"ca-certificates", "musl"}},
+			{License: "MPL", Packages: []string{"ca-certificates"}},
+			{License: "Zlib", Packages: []string{"zlib"}},
+		}, licenses)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"sort"
 	"strings"
+	"time"
 
 	trivy "github.com/aquasecurity/trivy/pkg/types"
 	"github.com/artifacthub/hub/internal/hub"
@@ -29,21 +30,39 @@ var (
 	ErrSchemaV1NotSupported = errors.New("schema v1 manifest not supported by trivy")
 )
 
+const (
+	// Trivy represents the Trivy image scanner backend.
+	Trivy = "trivy"
+
+	// Grype represents the Grype image scanner backend.
+	Grype = "grype"
+)
+
 // ImageScanner describes the methods an ImageScanner implementation must
 // provide. An image scanner is responsible of scanning a container image for
 // security vulnerabilities.
 type ImageScanner interface {
 	// ScanImage scans the provided image for security vulnerabilities,
-	// returning a report in json format.
+	// returning a report in json format. Reports must use the Trivy report
+	// format, regardless of the tool used to scan the image.
 	ScanImage(image string) ([]byte, error)
 }
 
 // Scanner is in charge of scanning packages' snapshots for security
 // vulnerabilities. It relies on an image scanner to scan all the containers
-// images listed on the snapshot.
+// images listed on the snapshot, and on a SBOM generator to generate their
+// software bill of materials. When an image scan store is provided, the
+// results of scanning each image are cached by digest and reused across
+// snapshots.
 type Scanner struct {
-	is ImageScanner
-	ec hub.ErrorsCollector
+	ctx      context.Context
+	is       ImageScanner
+	sbg      SBOMGenerator
+	ec       hub.ErrorsCollector
+	store    ImageScanStore
+	dr       ImageDigestResolver
+	cacheTTL time.Duration
+	locks    keyedMutex
 }
 
 // New creates a new Scanner instance.
@@ -53,22 +72,68 @@ func New(
 	ec hub.ErrorsCollector,
 	opts ...func(s *Scanner),
 ) *Scanner {
-	if cfg.GetString("scanner.trivyURL") == "" {
-		log.Fatal().Msg("trivy url not set")
-	}
 	s := &Scanner{
-		is: &TrivyScanner{
-			ctx: ctx,
-			cfg: cfg,
-		},
-		ec: ec,
+		ctx:      ctx,
+		ec:       ec,
+		cacheTTL: cfg.GetDuration("scanner.cacheTTL"),
 	}
 	for _, o := range opts {
 		o(s)
 	}
+	if s.cacheTTL == 0 {
+		s.cacheTTL = defaultCacheTTL
+	}
+	if s.store != nil && s.dr == nil {
+		s.dr = &RemoteImageDigestResolver{
+			ctx: ctx,
+			cfg: cfg,
+		}
+	}
+	if s.is == nil {
+		s.is = NewImageScanner(ctx, cfg)
+	}
+	if s.sbg == nil {
+		s.sbg = &SyftSBOMGenerator{
+			ctx: ctx,
+			cfg: cfg,
+		}
+	}
 	return s
 }
 
+// NewImageScanner creates a new ImageScanner instance of the backend selected
+// in the configuration provided (defaults to Trivy).
+func NewImageScanner(ctx context.Context, cfg *viper.Viper) ImageScanner {
+	switch backend := GetBackend(cfg); backend {
+	case Trivy:
+		if cfg.GetString("scanner.trivyURL") == "" {
+			log.Fatal().Msg("trivy url not set")
+		}
+		return &TrivyScanner{
+			ctx: ctx,
+			cfg: cfg,
+		}
+	case Grype:
+		return &GrypeScanner{
+			ctx: ctx,
+			cfg: cfg,
+		}
+	default:
+		log.Fatal().Str("backend", backend).Msg("invalid scanner backend")
+		return nil
+	}
+}
+
+// GetBackend returns the image scanner backend selected in the configuration
+// provided (defaults to Trivy).
+func GetBackend(cfg *viper.Viper) string {
+	backend := cfg.GetString("scanner.backend")
+	if backend == "" {
+		return Trivy
+	}
+	return backend
+}
+
 // WithImageScanner allows providing a specific ImageScanner implementation for
 // a Scanner instance.
 func WithImageScanner(is ImageScanner) func(s *Scanner) {
@@ -77,8 +142,38 @@ func WithImageScanner(is ImageScanner) func(s *Scanner) {
 	}
 }
 
+// WithImageDigestResolver allows providing a specific ImageDigestResolver
+// implementation for a Scanner instance.
+func WithImageDigestResolver(dr ImageDigestResolver) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.dr = dr
+	}
+}
+
+// WithImageScanStore allows providing an ImageScanStore implementation for a
+// Scanner instance, enabling the caching of images scans results.
+func WithImageScanStore(store ImageScanStore) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.store = store
+	}
+}
+
+// WithSBOMGenerator allows providing a specific SBOMGenerator implementation
+// for a Scanner instance.
+func WithSBOMGenerator(sbg SBOMGenerator) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.sbg = sbg
+	}
+}
+
 // Scan scans the provided package's snapshot for security vulnerabilities
-// returning a report with the results.
+// returning a report with the results. The SBOMs of the images scanned, as
+// well as the licenses found in them, are included in the report too. Errors
+// generating SBOMs are collected but they don't prevent the security report
+// from being generated. Vulnerabilities covered by the snapshot's
+// vulnerability statements are suppressed from the images reports, so they
+// are not taken into account when generating the summary and alert digest.
+// Images scans results are reused across snapshots when they are cached.
 func (s *Scanner) Scan(sn *hub.SnapshotToScan) (*hub.SnapshotSecurityReport, error) {
 	s.ec.Init(sn.RepositoryID)
 
@@ -87,21 +182,64 @@ func (s *Scanner) Scan(sn *hub.SnapshotToScan) (*hub.SnapshotSecurityReport, err
 		Version:   sn.Version,
 	}
 
+	statements := make(map[string]*hub.VulnerabilityStatement, len(sn.VulnerabilityStatements))
+	for _, s := range sn.VulnerabilityStatements {
+		statements[s.VulnerabilityID] = s
+	}
 	imagesReports := make(map[string]*trivy.Report)
 	for _, image := range sn.ContainersImages {
-		imageReportJSON, err := s.is.ScanImage(image.Image)
+		imageScan, err := s.getImageScan(sn, image.Image)
 		if err != nil {
 			err := fmt.Errorf("error scanning image %s: %w (package %s:%s)", image.Image, err, sn.PackageName, sn.Version)
 			s.ec.Append(sn.RepositoryID, err.Error())
 			return report, err
 		}
 		var imageReport *trivy.Report
-		if err := json.Unmarshal(imageReportJSON, &imageReport); err != nil {
+		if err := json.Unmarshal(imageScan.Report, &imageReport); err != nil {
 			return report, fmt.Errorf("error unmarshalling image %s report: %w", image.Image, err)
 		}
+		if imageReport != nil && len(statements) > 0 {
+			suppressed := applyVulnerabilityStatements(imageReport, statements)
+			if len(suppressed) > 0 {
+				if report.SuppressedVulnerabilities == nil {
+					report.SuppressedVulnerabilities = make(map[string][]*hub.SuppressedVulnerability)
+				}
+				report.SuppressedVulnerabilities[image.Image] = suppressed
+			}
+		}
 		if imageReport != nil && len(imageReport.Results) > 0 {
 			imagesReports[image.Image] = imageReport
 		}
+		for _, format := range hub.SBOMFormats {
+			sbom, ok := imageScan.SBOMs[format]
+			if !ok {
+				continue
+			}
+			if report.SBOMs == nil {
+				report.SBOMs = make(map[string]map[string]json.RawMessage)
+			}
+			if report.SBOMs[format] == nil {
+				report.SBOMs[format] = make(map[string]json.RawMessage)
+			}
+			report.SBOMs[format][image.Image] = sbom
+
+			// Extract images licenses from the CycloneDX SBOM
+			if format != hub.SBOMFormatCycloneDX {
+				continue
+			}
+			licenses, err := extractLicenses(sbom)
+			if err != nil {
+				err := fmt.Errorf("error extracting licenses for image %s: %w (package %s:%s)", image.Image, err, sn.PackageName, sn.Version)
+				s.ec.Append(sn.RepositoryID, err.Error())
+				continue
+			}
+			if len(licenses) > 0 {
+				if report.ImagesLicenses == nil {
+					report.ImagesLicenses = make(map[string][]*hub.ImageLicense)
+				}
+				report.ImagesLicenses[image.Image] = licenses
+			}
+		}
 	}
 	if len(imagesReports) > 0 {
 		report.ImagesReports = imagesReports
@@ -112,6 +250,36 @@ func (s *Scanner) Scan(sn *hub.SnapshotToScan) (*hub.SnapshotSecurityReport, err
 	return report, nil
 }
 
+// applyVulnerabilityStatements removes from the image report provided the
+// vulnerabilities covered by the statements, returning them as a list of
+// suppressed vulnerabilities.
+func applyVulnerabilityStatements(
+	imageReport *trivy.Report,
+	statements map[string]*hub.VulnerabilityStatement,
+) []*hub.SuppressedVulnerability {
+	var suppressed []*hub.SuppressedVulnerability
+	for i, result := range imageReport.Results {
+		var vulnerabilities []trivy.DetectedVulnerability
+		for _, v := range result.Vulnerabilities {
+			s, ok := statements[v.VulnerabilityID]
+			if !ok {
+				vulnerabilities = append(vulnerabilities, v)
+				continue
+			}
+			suppressed = append(suppressed, &hub.SuppressedVulnerability{
+				VulnerabilityID:  v.VulnerabilityID,
+				PkgName:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				Severity:         v.Severity,
+				Status:           s.Status,
+				Justification:    s.Justification,
+			})
+		}
+		imageReport.Results[i].Vulnerabilities = vulnerabilities
+	}
+	return suppressed
+}
+
 // generateSummary generates a summary of the security report from the images
 // reports.
 func generateSummary(imagesReports map[string]*trivy.Report) *hub.SecurityReportSummary {
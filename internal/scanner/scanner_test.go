@@ -6,9 +6,11 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	trivy "github.com/aquasecurity/trivy/pkg/types"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -24,6 +26,8 @@ func TestScan(t *testing.T) {
 	packageName := "pkg1"
 	version := "1.0.0"
 	image := "repo/image:tag"
+	digest := "sha256:becb8e06fb01f0324dabac05d700755bcd324071e66ebf4bc10151e356de9c71"
+	pinnedImage := "index.docker.io/repo/image@" + digest
 	snapshot := &hub.SnapshotToScan{
 		RepositoryID: repositoryID,
 		PackageID:    packageID,
@@ -59,7 +63,8 @@ func TestScan(t *testing.T) {
 				ecMock.On("Append", repositoryID, tc.expectedLoggedError)
 				isMock := &ImageScannerMock{}
 				isMock.On("ScanImage", image).Return(nil, tc.scanError)
-				s := New(ctx, cfg, ecMock, WithImageScanner(isMock))
+				sbgMock := &SBOMGeneratorMock{}
+				s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithSBOMGenerator(sbgMock))
 
 				report, err := s.Scan(snapshot)
 				assert.True(t, errors.Is(err, tc.scanError))
@@ -79,7 +84,9 @@ func TestScan(t *testing.T) {
 		ecMock.On("Init", repositoryID)
 		isMock := &ImageScannerMock{}
 		isMock.On("ScanImage", image).Return(`invalid: "`, nil)
-		s := New(ctx, cfg, ecMock, WithImageScanner(isMock))
+		sbgMock := &SBOMGeneratorMock{}
+		sbgMock.On("GenerateSBOMs", image).Return(sampleSBOMs, nil)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithSBOMGenerator(sbgMock))
 
 		report, err := s.Scan(snapshot)
 		require.Error(t, err)
@@ -98,7 +105,9 @@ func TestScan(t *testing.T) {
 		ecMock.On("Init", repositoryID)
 		isMock := &ImageScannerMock{}
 		isMock.On("ScanImage", image).Return(sampleReport1Data, nil)
-		s := New(ctx, cfg, ecMock, WithImageScanner(isMock))
+		sbgMock := &SBOMGeneratorMock{}
+		sbgMock.On("GenerateSBOMs", image).Return(sampleSBOMs, nil)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithSBOMGenerator(sbgMock))
 
 		report, err := s.Scan(snapshot)
 		require.Nil(t, err)
@@ -108,8 +117,35 @@ func TestScan(t *testing.T) {
 		assert.Equal(t, &hub.SnapshotSecurityReport{
 			PackageID: packageID,
 			Version:   version,
+			SBOMs: map[string]map[string]json.RawMessage{
+				hub.SBOMFormatCycloneDX: {image: []byte(`{"bomFormat": "CycloneDX"}`)},
+				hub.SBOMFormatSPDX:      {image: []byte(`{"spdxVersion": "SPDX-2.2"}`)},
+			},
 		}, report)
 		isMock.AssertExpectations(t)
+		sbgMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("error generating image sbom", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		ecMock.On("Append", repositoryID, "error generating sboms for image repo/image:tag: fake error (package pkg1:1.0.0)")
+		isMock := &ImageScannerMock{}
+		isMock.On("ScanImage", image).Return(sampleReport1Data, nil)
+		sbgMock := &SBOMGeneratorMock{}
+		sbgMock.On("GenerateSBOMs", image).Return(nil, errors.New("fake error"))
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithSBOMGenerator(sbgMock))
+
+		report, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SnapshotSecurityReport{
+			PackageID: packageID,
+			Version:   version,
+		}, report)
+		isMock.AssertExpectations(t)
+		sbgMock.AssertExpectations(t)
 		ecMock.AssertExpectations(t)
 	})
 
@@ -119,7 +155,9 @@ func TestScan(t *testing.T) {
 		ecMock.On("Init", repositoryID)
 		isMock := &ImageScannerMock{}
 		isMock.On("ScanImage", image).Return(sampleReport2Data, nil)
-		s := New(ctx, cfg, ecMock, WithImageScanner(isMock))
+		sbgMock := &SBOMGeneratorMock{}
+		sbgMock.On("GenerateSBOMs", image).Return(sampleSBOMs, nil)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithSBOMGenerator(sbgMock))
 
 		report, err := s.Scan(snapshot)
 		require.Nil(t, err)
@@ -137,10 +175,217 @@ func TestScan(t *testing.T) {
 				High:   3,
 				Medium: 1,
 			},
+			SBOMs: map[string]map[string]json.RawMessage{
+				hub.SBOMFormatCycloneDX: {image: []byte(`{"bomFormat": "CycloneDX"}`)},
+				hub.SBOMFormatSPDX:      {image: []byte(`{"spdxVersion": "SPDX-2.2"}`)},
+			},
 		}, report)
 		isMock.AssertExpectations(t)
+		sbgMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("vulnerability statements applied to image report", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		isMock := &ImageScannerMock{}
+		isMock.On("ScanImage", image).Return(sampleReport2Data, nil)
+		sbgMock := &SBOMGeneratorMock{}
+		sbgMock.On("GenerateSBOMs", image).Return(sampleSBOMs, nil)
+		s := New(ctx, cfg, ecMock, WithImageScanner(isMock), WithSBOMGenerator(sbgMock))
+
+		snapshotWithStatements := *snapshot
+		snapshotWithStatements.VulnerabilityStatements = []*hub.VulnerabilityStatement{
+			{
+				VulnerabilityID: "CVE-2017-11468",
+				Status:          hub.VulnerabilityStatementNotAffected,
+				Justification:   "Vulnerable code not in execute path",
+			},
+			{
+				VulnerabilityID: "CVE-2021-32723",
+				Status:          hub.VulnerabilityStatementAcknowledged,
+			},
+		}
+		report, err := s.Scan(&snapshotWithStatements)
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SecurityReportSummary{
+			High: 2,
+		}, report.Summary)
+		assert.NotEmpty(t, report.AlertDigest)
+		assert.NotEqual(t, "a53cf4b4d20faac813dd30d4ed017df345f5675f5f83b52517d229e0c7fdbf5aa89e7a8b7dbc809164352af539990df894bf52824709605fe6fe289133843e1c", report.AlertDigest)
+		assert.Equal(t, map[string][]*hub.SuppressedVulnerability{
+			image: {
+				{
+					VulnerabilityID:  "CVE-2017-11468",
+					PkgName:          "github.com/docker/distribution",
+					InstalledVersion: "v0.0.0-20191216044856-a8371794149d",
+					Severity:         "HIGH",
+					Status:           hub.VulnerabilityStatementNotAffected,
+					Justification:    "Vulnerable code not in execute path",
+				},
+				{
+					VulnerabilityID:  "CVE-2021-32723",
+					PkgName:          "prismjs",
+					InstalledVersion: "1.23.0",
+					Severity:         "MEDIUM",
+					Status:           hub.VulnerabilityStatementAcknowledged,
+				},
+			},
+		}, report.SuppressedVulnerabilities)
+		isMock.AssertExpectations(t)
+		sbgMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("cached image scan reused", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		isMock := &ImageScannerMock{}
+		sbgMock := &SBOMGeneratorMock{}
+		drMock := &ImageDigestResolverMock{}
+		drMock.On("ResolveDigest", image).Return(digest, nil)
+		pmMock := &pkg.ManagerMock{}
+		pmMock.On("GetImageScan", ctx, digest).Return(&hub.ImageScan{
+			Digest: digest,
+			Report: sampleReport2Data,
+			SBOMs: map[string]json.RawMessage{
+				hub.SBOMFormatCycloneDX: []byte(`{"bomFormat": "CycloneDX"}`),
+				hub.SBOMFormatSPDX:      []byte(`{"spdxVersion": "SPDX-2.2"}`),
+			},
+			CreatedAt: time.Now().Unix(),
+		}, nil)
+		s := New(ctx, cfg, ecMock,
+			WithImageScanner(isMock),
+			WithSBOMGenerator(sbgMock),
+			WithImageDigestResolver(drMock),
+			WithImageScanStore(pmMock),
+		)
+
+		report, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SecurityReportSummary{
+			High:   3,
+			Medium: 1,
+		}, report.Summary)
+		assert.Equal(t, map[string]map[string]json.RawMessage{
+			hub.SBOMFormatCycloneDX: {image: []byte(`{"bomFormat": "CycloneDX"}`)},
+			hub.SBOMFormatSPDX:      {image: []byte(`{"spdxVersion": "SPDX-2.2"}`)},
+		}, report.SBOMs)
+		isMock.AssertExpectations(t)
+		sbgMock.AssertExpectations(t)
+		drMock.AssertExpectations(t)
+		pmMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("expired cached image scan refreshed", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		isMock := &ImageScannerMock{}
+		isMock.On("ScanImage", pinnedImage).Return(sampleReport2Data, nil)
+		sbgMock := &SBOMGeneratorMock{}
+		sbgMock.On("GenerateSBOMs", pinnedImage).Return(sampleSBOMs, nil)
+		drMock := &ImageDigestResolverMock{}
+		drMock.On("ResolveDigest", image).Return(digest, nil)
+		pmMock := &pkg.ManagerMock{}
+		pmMock.On("GetImageScan", ctx, digest).Return(&hub.ImageScan{
+			Digest:    digest,
+			Report:    sampleReport1Data,
+			CreatedAt: time.Now().Add(-24 * time.Hour).Unix(),
+		}, nil)
+		pmMock.On("RegisterImageScan", ctx, &hub.ImageScan{
+			Digest: digest,
+			Report: sampleReport2Data,
+			SBOMs: map[string]json.RawMessage{
+				hub.SBOMFormatCycloneDX: []byte(`{"bomFormat": "CycloneDX"}`),
+				hub.SBOMFormatSPDX:      []byte(`{"spdxVersion": "SPDX-2.2"}`),
+			},
+		}).Return(nil)
+		s := New(ctx, cfg, ecMock,
+			WithImageScanner(isMock),
+			WithSBOMGenerator(sbgMock),
+			WithImageDigestResolver(drMock),
+			WithImageScanStore(pmMock),
+		)
+
+		report, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SecurityReportSummary{
+			High:   3,
+			Medium: 1,
+		}, report.Summary)
+		isMock.AssertExpectations(t)
+		sbgMock.AssertExpectations(t)
+		drMock.AssertExpectations(t)
+		pmMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+
+	t.Run("image scan not cached when sbom generation fails", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		ecMock.On("Append", repositoryID, "error generating sboms for image repo/image:tag: fake error (package pkg1:1.0.0)")
+		isMock := &ImageScannerMock{}
+		isMock.On("ScanImage", pinnedImage).Return(sampleReport2Data, nil)
+		sbgMock := &SBOMGeneratorMock{}
+		sbgMock.On("GenerateSBOMs", pinnedImage).Return(nil, errors.New("fake error"))
+		drMock := &ImageDigestResolverMock{}
+		drMock.On("ResolveDigest", image).Return(digest, nil)
+		pmMock := &pkg.ManagerMock{}
+		pmMock.On("GetImageScan", ctx, digest).Return(nil, hub.ErrNotFound)
+		s := New(ctx, cfg, ecMock,
+			WithImageScanner(isMock),
+			WithSBOMGenerator(sbgMock),
+			WithImageDigestResolver(drMock),
+			WithImageScanStore(pmMock),
+		)
+
+		report, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		assert.NotNil(t, report.Summary)
+		isMock.AssertExpectations(t)
+		sbgMock.AssertExpectations(t)
+		drMock.AssertExpectations(t)
+		pmMock.AssertExpectations(t)
 		ecMock.AssertExpectations(t)
 	})
+
+	t.Run("cache skipped when image digest cannot be resolved", func(t *testing.T) {
+		t.Parallel()
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+		isMock := &ImageScannerMock{}
+		isMock.On("ScanImage", image).Return(sampleReport2Data, nil)
+		sbgMock := &SBOMGeneratorMock{}
+		sbgMock.On("GenerateSBOMs", image).Return(sampleSBOMs, nil)
+		drMock := &ImageDigestResolverMock{}
+		drMock.On("ResolveDigest", image).Return("", errors.New("fake error"))
+		pmMock := &pkg.ManagerMock{}
+		s := New(ctx, cfg, ecMock,
+			WithImageScanner(isMock),
+			WithSBOMGenerator(sbgMock),
+			WithImageDigestResolver(drMock),
+			WithImageScanStore(pmMock),
+		)
+
+		report, err := s.Scan(snapshot)
+		require.Nil(t, err)
+		assert.NotNil(t, report.Summary)
+		isMock.AssertExpectations(t)
+		sbgMock.AssertExpectations(t)
+		drMock.AssertExpectations(t)
+		pmMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+}
+
+var sampleSBOMs = map[string]json.RawMessage{
+	hub.SBOMFormatCycloneDX: []byte(`{"bomFormat": "CycloneDX"}`),
+	hub.SBOMFormatSPDX:      []byte(`{"spdxVersion": "SPDX-2.2"}`),
 }
 
 var sampleReport1Data = []byte(`
@@ -0,0 +1,33 @@
+package sitemap
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the SitemapManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// GetPackages implements the SitemapManager interface.
+func (m *ManagerMock) GetPackages(ctx context.Context, limit, offset int) ([]*hub.SitemapPackage, error) {
+	args := m.Called(ctx, limit, offset)
+	data, _ := args.Get(0).([]*hub.SitemapPackage)
+	return data, args.Error(1)
+}
+
+// GetPackagesCount implements the SitemapManager interface.
+func (m *ManagerMock) GetPackagesCount(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+// GetRepositories implements the SitemapManager interface.
+func (m *ManagerMock) GetRepositories(ctx context.Context) ([]*hub.SitemapRepository, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]*hub.SitemapRepository)
+	return data, args.Error(1)
+}
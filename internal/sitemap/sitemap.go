@@ -0,0 +1,104 @@
+package sitemap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/cache"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const (
+	// Database queries
+	getPackagesDBQ      = `select get_sitemap_packages($1::int, $2::int)`
+	getPackagesCountDBQ = `select count(*) from package`
+	getRepositoriesDBQ  = `select get_sitemap_repositories()`
+)
+
+// Manager provides an API to get the information needed to build the sitemap.
+type Manager struct {
+	db    hub.DB
+	cache hub.Cache
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB, opts ...func(m *Manager)) *Manager {
+	m := &Manager{
+		db: db,
+	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// WithCache allows providing a Cache implementation for a Manager instance.
+// The sitemap data is kept in the cache until the tracker finishes a run, at
+// which point it is invalidated so that it is regenerated on the next request.
+func WithCache(c hub.Cache) func(m *Manager) {
+	return func(m *Manager) {
+		m.cache = c
+	}
+}
+
+// GetPackages returns the packages that should be included in the sitemap,
+// using the limit and offset provided to paginate the results.
+func (m *Manager) GetPackages(ctx context.Context, limit, offset int) ([]*hub.SitemapPackage, error) {
+	key := fmt.Sprintf("sitemap:pkgs:%d:%d", limit, offset)
+	dataJSON, err := m.getJSON(ctx, key, getPackagesDBQ, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []*hub.SitemapPackage
+	if err := json.Unmarshal(dataJSON, &pkgs); err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// GetPackagesCount returns the number of packages available.
+func (m *Manager) GetPackagesCount(ctx context.Context) (int, error) {
+	key := "sitemap:pkgs-count"
+	if data, ok := cache.Load(ctx, m.cache, key); ok {
+		if count, err := strconv.Atoi(string(data)); err == nil {
+			return count, nil
+		}
+	}
+	var count int
+	if err := m.db.QueryRow(ctx, getPackagesCountDBQ).Scan(&count); err != nil {
+		return 0, err
+	}
+	cache.Store(ctx, m.cache, key, []byte(strconv.Itoa(count)), cache.SitemapTag)
+	return count, nil
+}
+
+// GetRepositories returns the repositories that should be included in the
+// sitemap.
+func (m *Manager) GetRepositories(ctx context.Context) ([]*hub.SitemapRepository, error) {
+	dataJSON, err := m.getJSON(ctx, "sitemap:repos", getRepositoriesDBQ)
+	if err != nil {
+		return nil, err
+	}
+	var repos []*hub.SitemapRepository
+	if err := json.Unmarshal(dataJSON, &repos); err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// getJSON returns the json data produced by the query provided, reading it
+// from the cache when available.
+func (m *Manager) getJSON(ctx context.Context, key, query string, args ...interface{}) ([]byte, error) {
+	if dataJSON, ok := cache.Load(ctx, m.cache, key); ok {
+		return dataJSON, nil
+	}
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	cache.Store(ctx, m.cache, key, dataJSON, cache.SitemapTag)
+	return dataJSON, nil
+}
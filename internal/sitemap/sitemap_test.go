@@ -0,0 +1,163 @@
+package sitemap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/cache"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPackages(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagesDBQ, 10, 0).Return([]byte(`
+		[{
+			"normalized_name": "pkg1",
+			"repository_name": "repo1",
+			"repository_kind_id": 0,
+			"ts": 1592299234
+		}]
+		`), nil)
+		m := NewManager(db)
+
+		pkgs, err := m.GetPackages(ctx, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.SitemapPackage{
+			{
+				NormalizedName: "pkg1",
+				RepositoryName: "repo1",
+				RepositoryKind: hub.Helm,
+				TS:             1592299234,
+			},
+		}, pkgs)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagesDBQ, 10, 0).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		pkgs, err := m.GetPackages(ctx, 10, 0)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, pkgs)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("packages returned from cache", func(t *testing.T) {
+		t.Parallel()
+		c := &cache.Mock{}
+		c.On("Get", ctx, "sitemap:pkgs:10:0").Return([]byte(`[{"normalized_name": "pkg1"}]`), nil)
+		m := NewManager(nil, WithCache(c))
+
+		pkgs, err := m.GetPackages(ctx, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.SitemapPackage{{NormalizedName: "pkg1"}}, pkgs)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("packages not in cache, stored after being read from database", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagesDBQ, 10, 0).Return([]byte(`[{"normalized_name": "pkg1"}]`), nil)
+		c := &cache.Mock{}
+		c.On("Get", ctx, "sitemap:pkgs:10:0").Return(nil, hub.ErrNotFound)
+		c.On("Set", ctx, "sitemap:pkgs:10:0", []byte(`[{"normalized_name": "pkg1"}]`), []string{cache.SitemapTag}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		pkgs, err := m.GetPackages(ctx, 10, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.SitemapPackage{{NormalizedName: "pkg1"}}, pkgs)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
+}
+
+func TestGetPackagesCount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagesCountDBQ).Return(25, nil)
+		m := NewManager(db)
+
+		count, err := m.GetPackagesCount(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 25, count)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagesCountDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		count, err := m.GetPackagesCount(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Zero(t, count)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("count stored in cache after being read from database", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagesCountDBQ).Return(25, nil)
+		c := &cache.Mock{}
+		c.On("Get", ctx, "sitemap:pkgs-count").Return(nil, hub.ErrNotFound)
+		c.On("Set", ctx, "sitemap:pkgs-count", []byte("25"), []string{cache.SitemapTag}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		count, err := m.GetPackagesCount(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 25, count)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
+}
+
+func TestGetRepositories(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepositoriesDBQ).Return([]byte(`
+		[{
+			"name": "repo1",
+			"ts": 1592299234
+		}]
+		`), nil)
+		m := NewManager(db)
+
+		repos, err := m.GetRepositories(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.SitemapRepository{
+			{
+				Name: "repo1",
+				TS:   1592299234,
+			},
+		}, repos)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepositoriesDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		repos, err := m.GetRepositories(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, repos)
+		db.AssertExpectations(t)
+	})
+}
@@ -17,3 +17,10 @@ func (m *ManagerMock) GetJSON(ctx context.Context) ([]byte, error) {
 	data, _ := args.Get(0).([]byte)
 	return data, args.Error(1)
 }
+
+// GetTimeSeriesJSON implements the StatsManager interface.
+func (m *ManagerMock) GetTimeSeriesJSON(ctx context.Context, granularity string) ([]byte, error) {
+	args := m.Called(ctx, granularity)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
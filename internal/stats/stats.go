@@ -2,29 +2,84 @@ package stats
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/artifacthub/hub/internal/cache"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
 )
 
 const (
 	// Database queries
-	getStatsDBQ = `select get_stats()`
+	getStatsDBQ           = `select get_stats()`
+	getStatsTimeSeriesDBQ = `select get_stats_time_series($1::text)`
 )
 
+// validGranularities represents the granularities supported when requesting
+// the stats time series.
+var validGranularities = map[string]struct{}{
+	"day":   {},
+	"week":  {},
+	"month": {},
+}
+
 // Manager provides an API to manage stats.
 type Manager struct {
-	db hub.DB
+	db    hub.DB
+	cache hub.Cache
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(db hub.DB) *Manager {
-	return &Manager{
+func NewManager(db hub.DB, opts ...func(m *Manager)) *Manager {
+	m := &Manager{
 		db: db,
 	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// WithReplicaDB allows providing a read-only database replica for a Manager
+// instance. As stats are only read, all queries will be sent to it.
+func WithReplicaDB(rdb hub.DB) func(m *Manager) {
+	return func(m *Manager) {
+		m.db = rdb
+	}
+}
+
+// WithCache allows providing a Cache implementation for a Manager instance,
+// used to serve the stats without hitting the database on every request.
+func WithCache(c hub.Cache) func(m *Manager) {
+	return func(m *Manager) {
+		m.cache = c
+	}
 }
 
 // GetJSON returns some stats as a json object built by the database.
 func (m *Manager) GetJSON(ctx context.Context) ([]byte, error) {
-	return util.DBQueryJSON(ctx, m.db, getStatsDBQ)
+	key := cache.Key("stats", nil)
+	if dataJSON, ok := cache.Load(ctx, m.cache, key); ok {
+		return dataJSON, nil
+	}
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getStatsDBQ)
+	if err != nil {
+		return nil, err
+	}
+	cache.Store(ctx, m.cache, key, dataJSON, cache.StatsTag)
+	return dataJSON, nil
+}
+
+// GetTimeSeriesJSON returns the evolution over time of the number of packages,
+// repositories, organizations and users registered as a json object built by
+// the database. Data points are aggregated using the granularity provided.
+// Only the items that currently exist are counted.
+func (m *Manager) GetTimeSeriesJSON(ctx context.Context, granularity string) ([]byte, error) {
+	// Validate input
+	if _, ok := validGranularities[granularity]; !ok {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid granularity")
+	}
+
+	// Get time series from database
+	return util.DBQueryJSON(ctx, m.db, getStatsTimeSeriesDBQ, granularity)
 }
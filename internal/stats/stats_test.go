@@ -2,8 +2,11 @@ package stats
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/artifacthub/hub/internal/cache"
+	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/stretchr/testify/assert"
 )
@@ -34,4 +37,84 @@ func TestGetJSON(t *testing.T) {
 		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("database replica query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		rdb := &tests.DBMock{}
+		rdb.On("QueryRow", ctx, getStatsDBQ).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, WithReplicaDB(rdb))
+
+		dataJSON, err := m.GetJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		rdb.AssertExpectations(t)
+	})
+
+	t.Run("stats returned from cache", func(t *testing.T) {
+		t.Parallel()
+		c := &cache.Mock{}
+		c.On("Get", ctx, cache.Key("stats", nil)).Return([]byte("cachedJSON"), nil)
+		m := NewManager(nil, WithCache(c))
+
+		dataJSON, err := m.GetJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("cachedJSON"), dataJSON)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("stats not in cache, stored after being read from database", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getStatsDBQ).Return([]byte("dataJSON"), nil)
+		c := &cache.Mock{}
+		key := cache.Key("stats", nil)
+		c.On("Get", ctx, key).Return(nil, hub.ErrNotFound)
+		c.On("Set", ctx, key, []byte("dataJSON"), []string{cache.StatsTag}).Return(nil)
+		m := NewManager(db, WithCache(c))
+
+		dataJSON, err := m.GetJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		c.AssertExpectations(t)
+	})
+}
+
+func TestGetTimeSeriesJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid granularity", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		dataJSON, err := m.GetTimeSeriesJSON(ctx, "year")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Nil(t, dataJSON)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getStatsTimeSeriesDBQ, "month").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetTimeSeriesJSON(ctx, "month")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getStatsTimeSeriesDBQ, "week").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetTimeSeriesJSON(ctx, "week")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
 }
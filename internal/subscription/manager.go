@@ -16,7 +16,7 @@ const (
 	addSubscriptionDBQ         = `select add_subscription($1::jsonb)`
 	deleteOptOutDBQ            = `select delete_opt_out($1::uuid, $2::uuid)`
 	deleteSubscriptionDBQ      = `select delete_subscription($1::jsonb)`
-	getPkgSubscriptorsDBQ      = `select get_package_subscriptors($1::uuid, $2::integer)`
+	getPkgSubscriptorsDBQ      = `select get_package_subscriptors($1::uuid, $2::integer, $3::jsonb)`
 	getRepoSubscriptorsDBQ     = `select get_repository_subscriptors($1::uuid, $2::integer)`
 	getUserOptOutEntriesDBQ    = `select * from get_user_opt_out_entries($1::uuid, $2::int, $3::int)`
 	getUserPkgSubscriptionsDBQ = `select get_user_package_subscriptions($1::uuid, $2::uuid)`
@@ -28,6 +28,17 @@ var (
 	validEventKinds = []hub.EventKind{
 		hub.NewRelease,
 		hub.SecurityAlert,
+		hub.ContentWarnings,
+		hub.PackageVersionEOL,
+	}
+
+	// validSeverities contains the minimum severities supported in security
+	// alerts subscriptions.
+	validSeverities = []string{
+		"low",
+		"medium",
+		"high",
+		"critical",
 	}
 )
 
@@ -119,8 +130,9 @@ func (m *Manager) GetSubscriptors(ctx context.Context, e *hub.Event) ([]*hub.Use
 	var dataJSON []byte
 	var err error
 	switch e.EventKind {
-	case hub.NewRelease, hub.SecurityAlert:
-		err = m.db.QueryRow(ctx, getPkgSubscriptorsDBQ, e.PackageID, e.EventKind).Scan(&dataJSON)
+	case hub.NewRelease, hub.SecurityAlert, hub.ContentWarnings, hub.PackageVersionEOL:
+		eventDataJSON, _ := json.Marshal(e.Data)
+		err = m.db.QueryRow(ctx, getPkgSubscriptorsDBQ, e.PackageID, e.EventKind, eventDataJSON).Scan(&dataJSON)
 	case hub.RepositoryScanningErrors, hub.RepositoryTrackingErrors:
 		err = m.db.QueryRow(ctx, getRepoSubscriptorsDBQ, e.RepositoryID, e.EventKind).Scan(&dataJSON)
 	case hub.RepositoryOwnershipClaim:
@@ -147,6 +159,14 @@ func validateSubscription(s *hub.Subscription) error {
 	if !isValidEventKind(s.EventKind) {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid event kind")
 	}
+	if s.MinSeverity != "" {
+		if s.EventKind != hub.SecurityAlert {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "min severity only supported in security alerts subscriptions")
+		}
+		if !isValidSeverity(s.MinSeverity) {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid min severity")
+		}
+	}
 	return nil
 }
 
@@ -173,3 +193,13 @@ func isValidEventKind(kind hub.EventKind) bool {
 	}
 	return false
 }
+
+// isValidSeverity checks if the provided severity is valid.
+func isValidSeverity(severity string) bool {
+	for _, validSeverity := range validSeverities {
+		if severity == validSeverity {
+			return true
+		}
+	}
+	return false
+}
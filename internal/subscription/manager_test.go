@@ -44,7 +44,23 @@ func TestAdd(t *testing.T) {
 				"invalid event kind",
 				&hub.Subscription{
 					PackageID: packageID,
-					EventKind: hub.EventKind(5),
+					EventKind: hub.EventKind(7),
+				},
+			},
+			{
+				"min severity only supported in security alerts subscriptions",
+				&hub.Subscription{
+					PackageID:   packageID,
+					EventKind:   hub.NewRelease,
+					MinSeverity: "high",
+				},
+			},
+			{
+				"invalid min severity",
+				&hub.Subscription{
+					PackageID:   packageID,
+					EventKind:   hub.SecurityAlert,
+					MinSeverity: "invalid",
 				},
 			},
 		}
@@ -82,8 +98,9 @@ func TestAdd(t *testing.T) {
 		m := NewManager(db)
 
 		s := &hub.Subscription{
-			PackageID: packageID,
-			EventKind: hub.NewRelease,
+			PackageID:   packageID,
+			EventKind:   hub.SecurityAlert,
+			MinSeverity: "high",
 		}
 		err := m.Add(ctx, s)
 		assert.NoError(t, err)
@@ -191,7 +208,7 @@ func TestDelete(t *testing.T) {
 				"invalid event kind",
 				&hub.Subscription{
 					PackageID: packageID,
-					EventKind: hub.EventKind(5),
+					EventKind: hub.EventKind(7),
 				},
 			},
 		}
@@ -419,8 +436,9 @@ func TestGetOptOutListJSON(t *testing.T) {
 func TestGetSubscriptors(t *testing.T) {
 	ctx := context.Background()
 	pkgNewReleaseEvent := &hub.Event{
-		PackageID: packageID,
-		EventKind: hub.NewRelease,
+		PackageID:      packageID,
+		PackageVersion: "1.0.0",
+		EventKind:      hub.NewRelease,
 	}
 	repoTrackingErrorsEvent := &hub.Event{
 		RepositoryID: repositoryID,
@@ -440,7 +458,7 @@ func TestGetSubscriptors(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.EventKind(0)).Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.EventKind(0), []byte("null")).Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
 		subscriptors, err := m.GetSubscriptors(ctx, pkgNewReleaseEvent)
@@ -461,7 +479,7 @@ func TestGetSubscriptors(t *testing.T) {
 		}
 
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, pkgNewReleaseEvent.EventKind).
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, pkgNewReleaseEvent.EventKind, []byte("null")).
 			Return([]byte(`
 		[
 			{
@@ -480,6 +498,29 @@ func TestGetSubscriptors(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("database query succeeded (pkg security alert event)", func(t *testing.T) {
+		t.Parallel()
+		e := &hub.Event{
+			PackageID:      packageID,
+			PackageVersion: "1.0.0",
+			EventKind:      hub.SecurityAlert,
+			Data: map[string]interface{}{
+				"new_vulnerabilities": map[string]interface{}{"critical": 1},
+			},
+		}
+		eventDataJSON := []byte(`{"new_vulnerabilities":{"critical":1}}`)
+
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.SecurityAlert, eventDataJSON).
+			Return([]byte(`[{"user_id": "00000000-0000-0000-0000-000000000001"}]`), nil)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(ctx, e)
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000001"}}, subscriptors)
+		db.AssertExpectations(t)
+	})
+
 	t.Run("database query succeeded (repo tracking errors event)", func(t *testing.T) {
 		t.Parallel()
 		expectedSubscriptors := []*hub.User{
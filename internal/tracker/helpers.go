@@ -4,23 +4,13 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
-	"github.com/artifacthub/hub/internal/tracker/source/container"
-	"github.com/artifacthub/hub/internal/tracker/source/falco"
-	"github.com/artifacthub/hub/internal/tracker/source/generic"
-	"github.com/artifacthub/hub/internal/tracker/source/helm"
-	"github.com/artifacthub/hub/internal/tracker/source/helmplugin"
-	"github.com/artifacthub/hub/internal/tracker/source/krew"
-	"github.com/artifacthub/hub/internal/tracker/source/olm"
-	"github.com/artifacthub/hub/internal/tracker/source/tekton"
 	"github.com/spf13/viper"
 )
 
-const (
-	cloudNativeSecurityHub = "https://github.com/falcosecurity/cloud-native-security-hub/resources/falco"
-)
-
 // GetRepositories gets the repositories the tracker will process based on the
 // configuration provided:
 //
@@ -30,6 +20,9 @@ const (
 //   kinds will be returned.
 // - Otherwise, all the repositories will be returned.
 //
+// When trackingRequestedOnly is enabled, only the repositories whose tracking
+// has been requested (i.e. by a GitHub App push event) will be returned.
+//
 // NOTE: disabled repositories will be filtered out.
 func GetRepositories(
 	ctx context.Context,
@@ -38,6 +31,7 @@ func GetRepositories(
 ) ([]*hub.Repository, error) {
 	reposNames := cfg.GetStringSlice("tracker.repositoriesNames")
 	reposKinds := cfg.GetStringSlice("tracker.repositoriesKinds")
+	trackingRequestedOnly := cfg.GetBool("tracker.trackingRequestedOnly")
 
 	var repos []*hub.Repository
 	switch {
@@ -76,50 +70,22 @@ func GetRepositories(
 		repos = result.Repositories
 	}
 
-	// Filter out disabled repositories
+	// Filter out disabled and blocked repositories, as well as the ones whose
+	// tracking has not been requested when required
 	var reposFiltered []*hub.Repository
 	for _, repo := range repos {
-		if !repo.Disabled {
-			reposFiltered = append(reposFiltered, repo)
+		if repo.Disabled || repo.Blocked {
+			continue
 		}
+		if trackingRequestedOnly && !repo.TrackingRequested {
+			continue
+		}
+		reposFiltered = append(reposFiltered, repo)
 	}
 
 	return reposFiltered, nil
 }
 
-// SetupSource returns the tracker source that should be used for the
-// repository provided.
-func SetupSource(i *hub.TrackerSourceInput) hub.TrackerSource {
-	var source hub.TrackerSource
-	switch i.Repository.Kind {
-	case hub.Container:
-		source = container.NewTrackerSource(i)
-	case hub.Falco:
-		// Temporary solution to maintain backwards compatibility with
-		// the only Falco rules repository registered at the moment in
-		// artifacthub.io using the structure and metadata format used
-		// by the cloud native security hub.
-		if i.Repository.URL == cloudNativeSecurityHub {
-			source = falco.NewTrackerSource(i)
-		} else {
-			source = generic.NewTrackerSource(i)
-		}
-	case hub.Helm:
-		source = helm.NewTrackerSource(i)
-	case hub.HelmPlugin:
-		source = helmplugin.NewTrackerSource(i)
-	case hub.Krew:
-		source = krew.NewTrackerSource(i)
-	case hub.OLM:
-		source = olm.NewTrackerSource(i)
-	case hub.OPA, hub.TBAction, hub.KedaScaler, hub.CoreDNS, hub.Keptn:
-		source = generic.NewTrackerSource(i)
-	case hub.TektonTask, hub.TektonPipeline:
-		source = tekton.NewTrackerSource(i)
-	}
-	return source
-}
-
 // setVerifiedPublisherFlag sets the repository verified publisher flag for the
 // repository provided when needed.
 func setVerifiedPublisherFlag(
@@ -156,6 +122,26 @@ func shouldIgnorePackage(md *hub.RepositoryMetadata, name, version string) bool
 	return false
 }
 
+// sortByVersion sorts the package versions provided in ascending order.
+// Versions that are not valid semver are placed at the end, sorted
+// lexicographically.
+func sortByVersion(versions []*hub.Package) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, errI := semver.NewVersion(versions[i].Version)
+		vj, errJ := semver.NewVersion(versions[j].Version)
+		switch {
+		case errI == nil && errJ == nil:
+			return vi.LessThan(vj)
+		case errI == nil:
+			return true
+		case errJ == nil:
+			return false
+		default:
+			return versions[i].Version < versions[j].Version
+		}
+	})
+}
+
 // matchesEntry checks if the package name and version provide match a given
 // ignore entry.
 func matchesEntry(ignoreEntry *hub.RepositoryIgnoreEntry, name, version string) bool {
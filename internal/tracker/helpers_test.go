@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/hub"
@@ -21,14 +20,20 @@ func TestGetRepositories(t *testing.T) {
 		Kind: hub.Helm,
 	}
 	repo2 := &hub.Repository{
-		Name: "repo2",
-		Kind: hub.OLM,
+		Name:              "repo2",
+		Kind:              hub.OLM,
+		TrackingRequested: true,
 	}
 	repo3 := &hub.Repository{
 		Name:     "repo3",
 		Kind:     hub.OPA,
 		Disabled: true,
 	}
+	repo4 := &hub.Repository{
+		Name:    "repo4",
+		Kind:    hub.Helm,
+		Blocked: true,
+	}
 
 	t.Run("error getting repository by name", func(t *testing.T) {
 		t.Parallel()
@@ -155,91 +160,36 @@ func TestGetRepositories(t *testing.T) {
 		rm.On("Search", ctx, &hub.SearchRepositoryInput{
 			IncludeCredentials: true,
 		}).Return(&hub.SearchRepositoryResult{
-			Repositories: []*hub.Repository{repo1, repo2, repo3},
+			Repositories: []*hub.Repository{repo1, repo2, repo3, repo4},
 		}, nil)
 
 		// Run test and check expectations
 		cfg := viper.New()
 		repos, err := GetRepositories(ctx, cfg, rm)
 		assert.Nil(t, err)
-		assert.ElementsMatch(t, []*hub.Repository{repo1, repo2}, repos) // repo3 is disabled
+		assert.ElementsMatch(t, []*hub.Repository{repo1, repo2}, repos) // repo3 is disabled, repo4 is blocked
 		rm.AssertExpectations(t)
 	})
-}
 
-func TestSetupSource(t *testing.T) {
-	testCases := []struct {
-		r            *hub.Repository
-		expectedType string
-	}{
-		{
-			&hub.Repository{
-				Kind: hub.Falco,
-				URL:  cloudNativeSecurityHub,
-			},
-			"*falco.TrackerSource",
-		},
-		{
-			&hub.Repository{
-				Kind: hub.Falco,
-			},
-			"*generic.TrackerSource",
-		},
-		{
-			&hub.Repository{
-				Kind: hub.Helm,
-			},
-			"*helm.TrackerSource",
-		},
-		{
-			&hub.Repository{
-				Kind: hub.HelmPlugin,
-			},
-			"*helmplugin.TrackerSource",
-		},
-		{
-			&hub.Repository{
-				Kind: hub.Krew,
-			},
-			"*krew.TrackerSource",
-		},
-		{
-			&hub.Repository{
-				Kind: hub.OLM,
-			},
-			"*olm.TrackerSource",
-		},
-		{
-			&hub.Repository{
-				Kind: hub.OPA,
-			},
-			"*generic.TrackerSource",
-		},
-		{
-			&hub.Repository{
-				Kind: hub.TBAction,
-			},
-			"*generic.TrackerSource",
-		},
-		{
-			&hub.Repository{
-				Kind: hub.TektonTask,
-			},
-			"*tekton.TrackerSource",
-		},
-	}
-	for i, tc := range testCases {
-		tc := tc
-		t.Run(fmt.Sprintf("Test case %d", i), func(t *testing.T) {
-			t.Parallel()
+	t.Run("get only repositories with tracking requested", func(t *testing.T) {
+		t.Parallel()
 
-			i := &hub.TrackerSourceInput{
-				Repository: tc.r,
-			}
-			source := SetupSource(i)
-			assert.Equal(t, tc.expectedType, reflect.TypeOf(source).String())
-		})
-	}
+		// Setup expectations
+		rm := &repo.ManagerMock{}
+		rm.On("Search", ctx, &hub.SearchRepositoryInput{
+			IncludeCredentials: true,
+		}).Return(&hub.SearchRepositoryResult{
+			Repositories: []*hub.Repository{repo1, repo2, repo3, repo4},
+		}, nil)
+
+		// Run test and check expectations
+		cfg := viper.New()
+		cfg.Set("tracker.trackingRequestedOnly", true)
+		repos, err := GetRepositories(ctx, cfg, rm)
+		assert.Nil(t, err)
+		assert.Equal(t, []*hub.Repository{repo2}, repos)
+		rm.AssertExpectations(t)
+	})
 }
 
 func TestSetVerifiedPublisherFlag(t *testing.T) {
@@ -448,3 +398,20 @@ func TestShouldIgnorePackage(t *testing.T) {
 		})
 	}
 }
+
+func TestSortByVersion(t *testing.T) {
+	versions := []*hub.Package{
+		{Version: "2.0.0"},
+		{Version: "latest"},
+		{Version: "1.10.0"},
+		{Version: "edge"},
+		{Version: "1.2.0"},
+		{Version: "1.2.0-rc.1"},
+	}
+	sortByVersion(versions)
+	sorted := make([]string, 0, len(versions))
+	for _, p := range versions {
+		sorted = append(sorted, p.Version)
+	}
+	assert.Equal(t, []string{"1.2.0-rc.1", "1.2.0", "1.10.0", "2.0.0", "edge", "latest"}, sorted)
+}
@@ -0,0 +1,119 @@
+package source
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// Artifact Hub specific OCI annotations. They can be used to provide the
+	// package metadata in OCI artifacts, as an alternative to the metadata
+	// files.
+	AlternativeLocationsOCIAnnotation = "io.artifacthub.package.alternative-locations"
+	ChangesOCIAnnotation              = "io.artifacthub.package.changes"
+	DeprecatedOCIAnnotation           = "io.artifacthub.package.deprecated"
+	KeywordsOCIAnnotation             = "io.artifacthub.package.keywords"
+	LicenseOCIAnnotation              = "io.artifacthub.package.license"
+	LogoDarkURLOCIAnnotation          = "io.artifacthub.package.logo-dark-url"
+	LogoURLOCIAnnotation              = "io.artifacthub.package.logo-url"
+	MaintainersOCIAnnotation          = "io.artifacthub.package.maintainers"
+	PrereleaseOCIAnnotation           = "io.artifacthub.package.prerelease"
+	ReadmeURLOCIAnnotation            = "io.artifacthub.package.readme-url"
+	SecurityUpdatesOCIAnnotation      = "io.artifacthub.package.contains-security-updates"
+)
+
+// ErrInvalidOCIAnnotation indicates that the OCI annotation provided is not
+// valid.
+var ErrInvalidOCIAnnotation = errors.New("invalid annotation")
+
+// EnrichPackageFromOCIAnnotations adds to the package provided the information
+// available in the Artifact Hub specific OCI annotations. Only the fields set
+// in the annotations are updated. Annotations that require fetching some
+// external content (logo and readme urls) must be handled by the caller.
+func EnrichPackageFromOCIAnnotations(p *hub.Package, annotations map[string]string) error {
+	var errs *multierror.Error
+
+	// License
+	if v, ok := annotations[LicenseOCIAnnotation]; ok && v != "" {
+		p.License = v
+	}
+
+	// Keywords
+	if v, ok := annotations[KeywordsOCIAnnotation]; ok && v != "" {
+		var keywords []string
+		for _, keyword := range strings.Split(v, ",") {
+			keywords = append(keywords, strings.TrimSpace(keyword))
+		}
+		p.Keywords = keywords
+	}
+
+	// Maintainers
+	if v, ok := annotations[MaintainersOCIAnnotation]; ok {
+		var maintainers []*hub.Maintainer
+		if err := json.Unmarshal([]byte(v), &maintainers); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: invalid maintainers value", ErrInvalidOCIAnnotation))
+		} else {
+			p.Maintainers = maintainers
+		}
+	}
+
+	// Changes
+	if v, ok := annotations[ChangesOCIAnnotation]; ok {
+		changes, err := ParseChangesAnnotation(v)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: %s", ErrInvalidOCIAnnotation, err.Error()))
+		} else {
+			p.Changes = changes
+		}
+	}
+
+	// Security updates
+	if v, ok := annotations[SecurityUpdatesOCIAnnotation]; ok {
+		containsSecurityUpdates, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: invalid containsSecurityUpdates value", ErrInvalidOCIAnnotation))
+		} else {
+			p.ContainsSecurityUpdates = containsSecurityUpdates
+		}
+	}
+
+	// Pre-release
+	if v, ok := annotations[PrereleaseOCIAnnotation]; ok {
+		prerelease, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: invalid prerelease value", ErrInvalidOCIAnnotation))
+		} else {
+			p.Prerelease = prerelease
+		}
+	}
+
+	// Deprecated
+	if v, ok := annotations[DeprecatedOCIAnnotation]; ok {
+		deprecated, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: invalid deprecated value", ErrInvalidOCIAnnotation))
+		} else {
+			p.Deprecated = deprecated
+		}
+	}
+
+	// Alternative locations
+	if v, ok := annotations[AlternativeLocationsOCIAnnotation]; ok && v != "" {
+		var alternativeLocations []string
+		for _, l := range strings.Split(v, ",") {
+			alternativeLocations = append(alternativeLocations, strings.TrimSpace(l))
+		}
+		if p.Data == nil {
+			p.Data = make(map[string]interface{})
+		}
+		p.Data["alternativeLocations"] = alternativeLocations
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,163 @@
+package source
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrichPackageFromOCIAnnotations(t *testing.T) {
+	testCases := []struct {
+		pkg            *hub.Package
+		annotations    map[string]string
+		expectedPkg    *hub.Package
+		expectedErrMsg string
+	}{
+		// No annotations
+		{
+			&hub.Package{
+				License: "Apache-2.0",
+			},
+			nil,
+			&hub.Package{
+				License: "Apache-2.0",
+			},
+			"",
+		},
+		// License and keywords
+		{
+			&hub.Package{
+				License: "Apache-2.0",
+			},
+			map[string]string{
+				LicenseOCIAnnotation:  "MIT",
+				KeywordsOCIAnnotation: "key1, key2",
+			},
+			&hub.Package{
+				License:  "MIT",
+				Keywords: []string{"key1", "key2"},
+			},
+			"",
+		},
+		// Maintainers
+		{
+			&hub.Package{},
+			map[string]string{
+				MaintainersOCIAnnotation: "invalid",
+			},
+			&hub.Package{},
+			"invalid annotation: invalid maintainers value",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				MaintainersOCIAnnotation: `[{"name": "user1", "email": "user1@email.com"}]`,
+			},
+			&hub.Package{
+				Maintainers: []*hub.Maintainer{
+					{
+						Name:  "user1",
+						Email: "user1@email.com",
+					},
+				},
+			},
+			"",
+		},
+		// Changes
+		{
+			&hub.Package{},
+			map[string]string{
+				ChangesOCIAnnotation: "1234",
+			},
+			&hub.Package{},
+			"invalid annotation: invalid changes annotation",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				ChangesOCIAnnotation: `
+- kind: added
+  description: feature 1
+`,
+			},
+			&hub.Package{
+				Changes: []*hub.Change{
+					{
+						Kind:        "added",
+						Description: "feature 1",
+					},
+				},
+			},
+			"",
+		},
+		// Flags
+		{
+			&hub.Package{},
+			map[string]string{
+				SecurityUpdatesOCIAnnotation: "invalid",
+			},
+			&hub.Package{},
+			"invalid annotation: invalid containsSecurityUpdates value",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				PrereleaseOCIAnnotation: "invalid",
+			},
+			&hub.Package{},
+			"invalid annotation: invalid prerelease value",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				DeprecatedOCIAnnotation: "invalid",
+			},
+			&hub.Package{},
+			"invalid annotation: invalid deprecated value",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				SecurityUpdatesOCIAnnotation: "true",
+				PrereleaseOCIAnnotation:      "true",
+				DeprecatedOCIAnnotation:      "true",
+			},
+			&hub.Package{
+				ContainsSecurityUpdates: true,
+				Prerelease:              true,
+				Deprecated:              true,
+			},
+			"",
+		},
+		// Alternative locations
+		{
+			&hub.Package{},
+			map[string]string{
+				AlternativeLocationsOCIAnnotation: "registry1/repo/image, registry2/repo/image",
+			},
+			&hub.Package{
+				Data: map[string]interface{}{
+					"alternativeLocations": []string{"registry1/repo/image", "registry2/repo/image"},
+				},
+			},
+			"",
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			err := EnrichPackageFromOCIAnnotations(tc.pkg, tc.annotations)
+			if tc.expectedErrMsg != "" {
+				assert.True(t, errors.Is(err, ErrInvalidOCIAnnotation))
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expectedPkg, tc.pkg)
+		})
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"net/url"
 	"path"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +17,7 @@ import (
 	"github.com/artifacthub/hub/internal/img"
 	"github.com/artifacthub/hub/internal/oci"
 	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -27,9 +27,6 @@ import (
 )
 
 const (
-	// Number of tags processed concurrently
-	concurrency = 10
-
 	// Annotations based on OCI pre-defined annotation keys
 	// https://github.com/opencontainers/image-spec/blob/main/annotations.md#pre-defined-annotation-keys
 	appVersionAnnotation       = "org.opencontainers.image.version"
@@ -41,18 +38,10 @@ const (
 	sourceURLAnnotation        = "org.opencontainers.image.source"
 	vendorAnnotation           = "org.opencontainers.image.vendor"
 
-	// Artifact Hub specific annotations
-	alternativeLocationsAnnotation = "io.artifacthub.package.alternative-locations"
-	deprecatedAnnotation           = "io.artifacthub.package.deprecated"
-	digestAnnotation               = "io.artifacthub.package.digest" // Populated internally in getMetadata
-	keywordsAnnotation             = "io.artifacthub.package.keywords"
-	licenseAnnotation              = "io.artifacthub.package.license"
-	logoURLAnnotation              = "io.artifacthub.package.logo-url"
-	maintainersAnnotation          = "io.artifacthub.package.maintainers"
-	platformsAnnotation            = "io.artifacthub.package.platforms" // Populated internally in getMetadata
-	prereleaseAnnotation           = "io.artifacthub.package.prerelease"
-	readmeURLAnnotation            = "io.artifacthub.package.readme-url"
-	securityUpdatesAnnotation      = "io.artifacthub.package.contains-security-updates"
+	// Artifact Hub specific annotations populated internally in getMetadata
+	// (the ones that can be set by publishers are defined in the source pkg)
+	digestAnnotation    = "io.artifacthub.package.digest"
+	platformsAnnotation = "io.artifacthub.package.platforms"
 )
 
 const (
@@ -65,15 +54,12 @@ var (
 	// supported and should not be processed.
 	errUnsupportedMediaType = errors.New("image media type not supported")
 
-	// errInvalidAnnotation indicates that the annotation provided is not valid.
-	errInvalidAnnotation = errors.New("invalid annotation")
-
 	// requiredMetadata represents the fields that must be present in the image
 	// metadata.
 	requiredMetadata = []string{
 		createdAnnotation,
 		descriptionAnnotation,
-		readmeURLAnnotation,
+		source.ReadmeURLOCIAnnotation,
 	}
 )
 
@@ -81,6 +67,7 @@ var (
 // repositories.
 type TrackerSource struct {
 	i  *hub.TrackerSourceInput
+	pg hub.OCIProvenanceGetter
 	sc hub.OCISignatureChecker
 }
 
@@ -90,6 +77,9 @@ func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource))
 	for _, o := range opts {
 		o(s)
 	}
+	if s.pg == nil {
+		s.pg = &oci.ProvenanceGetter{}
+	}
 	if s.sc == nil {
 		s.sc = &oci.SignatureChecker{}
 	}
@@ -126,7 +116,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 	}
 
 	// Iterate over tags to process and prepare a package version for each
-	limiter := make(chan struct{}, concurrency)
+	limiter := make(chan struct{}, source.PackagesConcurrency(s.i.Svc.Cfg))
 	var wg sync.WaitGroup
 	for _, tag := range tagsToProcess {
 		// Return ASAP if context is cancelled
@@ -145,7 +135,21 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 				<-limiter
 				wg.Done()
 			}()
-			p, err := PreparePackage(s.i.Svc.Ctx, s.i.Svc.Cfg, s.i.Svc.Hc, s.i.Svc.Is, s.sc, s.i.Repository, tag)
+			var cosignCfg *hub.CosignConfig
+			if s.i.Metadata != nil {
+				cosignCfg = s.i.Metadata.Cosign
+			}
+			p, err := PreparePackage(
+				s.i.Svc.Ctx,
+				s.i.Svc.Cfg,
+				s.i.Svc.Hc,
+				s.i.Svc.Is,
+				s.pg,
+				s.sc,
+				cosignCfg,
+				s.i.Repository,
+				tag,
+			)
 			if err != nil {
 				s.warn(fmt.Errorf("error preparing package (tag: %s): %w", tag, err))
 				return
@@ -174,7 +178,9 @@ func PreparePackage(
 	cfg *viper.Viper,
 	hc hub.HTTPClient,
 	is img.Store,
+	pg hub.OCIProvenanceGetter,
 	sc hub.OCISignatureChecker,
+	cosignCfg *hub.CosignConfig,
 	r *hub.Repository,
 	tag string,
 ) (*hub.Package, error) {
@@ -202,7 +208,6 @@ func PreparePackage(
 		HomeURL:     md[homeURLAnnotation],
 		Digest:      md[digestAnnotation],
 		AppVersion:  md[appVersionAnnotation],
-		License:     md[licenseAnnotation],
 		Provider:    md[vendorAnnotation],
 		ContainersImages: []*hub.ContainerImage{
 			{
@@ -224,7 +229,7 @@ func PreparePackage(
 	}
 
 	// Readme
-	if v, ok := md[readmeURLAnnotation]; ok {
+	if v, ok := md[source.ReadmeURLOCIAnnotation]; ok {
 		data, err := getContent(ctx, hc, v)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("error getting readme file content: %w", err))
@@ -233,17 +238,8 @@ func PreparePackage(
 		}
 	}
 
-	// Keywords
-	if v, ok := md[keywordsAnnotation]; ok && v != "" {
-		var keywords []string
-		for _, keyword := range strings.Split(v, ",") {
-			keywords = append(keywords, strings.TrimSpace(keyword))
-		}
-		p.Keywords = keywords
-	}
-
 	// Store logo when available if requested
-	if v, ok := md[logoURLAnnotation]; ok {
+	if v, ok := md[source.LogoURLOCIAnnotation]; ok {
 		logoImageID, err := is.DownloadAndSaveImage(ctx, v)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("error downloading logo image: %w", err))
@@ -252,6 +248,14 @@ func PreparePackage(
 			p.LogoImageID = logoImageID
 		}
 	}
+	if v, ok := md[source.LogoDarkURLOCIAnnotation]; ok {
+		logoDarkImageID, err := is.DownloadAndSaveImage(ctx, v)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("error downloading dark logo image: %w", err))
+		} else {
+			p.LogoDarkImageID = logoDarkImageID
+		}
+	}
 
 	// Links
 	var links []*hub.Link
@@ -269,58 +273,14 @@ func PreparePackage(
 	}
 	p.Links = links
 
-	// Maintainers
-	if v, ok := md[maintainersAnnotation]; ok {
-		var maintainers []*hub.Maintainer
-		if err := json.Unmarshal([]byte(v), &maintainers); err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("%w: invalid maintainers value", errInvalidAnnotation))
-		} else {
-			p.Maintainers = maintainers
-		}
-	}
-
-	// Security updates
-	if v, ok := md[securityUpdatesAnnotation]; ok {
-		containsSecurityUpdates, err := strconv.ParseBool(v)
-		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("%w: invalid containsSecurityUpdates value", errInvalidAnnotation))
-		} else {
-			p.ContainsSecurityUpdates = containsSecurityUpdates
-		}
-	}
-
-	// Pre-release
-	if v, ok := md[prereleaseAnnotation]; ok {
-		prerelease, err := strconv.ParseBool(v)
-		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("%w: invalid prerelease value", errInvalidAnnotation))
-		} else {
-			p.Prerelease = prerelease
-		}
-	}
-
-	// Deprecated
-	if v, ok := md[deprecatedAnnotation]; ok {
-		deprecated, err := strconv.ParseBool(v)
-		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("%w: invalid deprecated value", errInvalidAnnotation))
-		} else {
-			p.Deprecated = deprecated
-		}
-	}
-
 	// Platforms
 	if v, ok := md[platformsAnnotation]; ok && v != "" {
 		p.Data["platforms"] = strings.Split(v, ",")
 	}
 
-	// Alternative locations
-	if v, ok := md[alternativeLocationsAnnotation]; ok && v != "" {
-		var alternativeLocations []string
-		for _, l := range strings.Split(v, ",") {
-			alternativeLocations = append(alternativeLocations, strings.TrimSpace(l))
-		}
-		p.Data["alternativeLocations"] = alternativeLocations
+	// Enrich package with information from Artifact Hub specific annotations
+	if err := source.EnrichPackageFromOCIAnnotations(p, md); err != nil {
+		errs = multierror.Append(errs, err)
 	}
 
 	// Signature
@@ -335,6 +295,22 @@ func PreparePackage(
 	} else if hasCosignSignature {
 		p.Signed = true
 		p.Signatures = []string{cosign}
+		if cosignCfg != nil {
+			verified, err := sc.VerifyCosignSignature(ctx, imageRef, r.AuthUser, r.AuthPass, cosignCfg)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("error verifying cosign signature: %w", err))
+			} else {
+				p.SignatureVerified = verified
+			}
+		}
+	}
+
+	// Build provenance
+	provenance, err := pg.GetSLSAProvenance(ctx, imageRef, r.AuthUser, r.AuthPass)
+	if err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("error getting slsa provenance: %w", err))
+	} else {
+		p.Provenance = provenance
 	}
 
 	if errs.ErrorOrNil() != nil {
@@ -0,0 +1,149 @@
+package source
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// crdAPIGroup represents the API group of the CustomResourceDefinition
+	// objects.
+	crdAPIGroup = "apiextensions.k8s.io/"
+
+	// crdKind represents the kind of the CustomResourceDefinition objects.
+	crdKind = "CustomResourceDefinition"
+
+	// xrdAPIGroup represents the API group of the Crossplane
+	// CompositeResourceDefinition objects.
+	xrdAPIGroup = "apiextensions.crossplane.io/"
+
+	// xrdKind represents the kind of the Crossplane CompositeResourceDefinition
+	// objects.
+	xrdKind = "CompositeResourceDefinition"
+
+	// xrdScope represents the scope of the composite resources defined by a
+	// CompositeResourceDefinition, which are always cluster scoped.
+	xrdScope = "Cluster"
+)
+
+// yamlDocsSeparatorRE is a regexp used to split multi-document yaml files.
+var yamlDocsSeparatorRE = regexp.MustCompile(`(?m)^---\s*$`)
+
+// crd represents the subset of a CustomResourceDefinition object (v1 or
+// v1beta1) needed to extract its schemas. Crossplane CompositeResourceDefinition
+// objects share most of this structure, so they are handled as well.
+type crd struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Scope      string         `json:"scope"`
+		Validation *crdValidation `json:"validation"`
+		Version    string         `json:"version"`
+		Versions   []struct {
+			Name          string         `json:"name"`
+			Served        bool           `json:"served"`
+			Storage       bool           `json:"storage"`
+			Referenceable bool           `json:"referenceable"`
+			Schema        *crdValidation `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// crdValidation represents the validation section of a CRD or one of its
+// versions.
+type crdValidation struct {
+	OpenAPIV3Schema json.RawMessage `json:"openAPIV3Schema"`
+}
+
+// ExtractCRDsSchemas extracts the schemas of the CustomResourceDefinitions
+// found in the manifests provided. Both apiextensions.k8s.io v1 and v1beta1
+// CRDs are supported, as well as Crossplane CompositeResourceDefinitions
+// (XRDs). Manifests that cannot be parsed or that do not contain CRDs are
+// ignored.
+func ExtractCRDsSchemas(manifests [][]byte) []*hub.CRDSchema {
+	var crdsSchemas []*hub.CRDSchema
+	for _, manifest := range manifests {
+		for _, doc := range yamlDocsSeparatorRE.Split(string(manifest), -1) {
+			var obj *crd
+			if err := yaml.Unmarshal([]byte(doc), &obj); err != nil || obj == nil {
+				continue
+			}
+			if obj.Metadata.Name == "" || (!isCRD(obj) && !isXRD(obj)) {
+				continue
+			}
+			crdsSchemas = append(crdsSchemas, newCRDSchema(obj))
+		}
+	}
+	return crdsSchemas
+}
+
+// isCRD checks if the object provided is a CustomResourceDefinition.
+func isCRD(obj *crd) bool {
+	return strings.HasPrefix(obj.APIVersion, crdAPIGroup) && obj.Kind == crdKind
+}
+
+// isXRD checks if the object provided is a Crossplane
+// CompositeResourceDefinition.
+func isXRD(obj *crd) bool {
+	return strings.HasPrefix(obj.APIVersion, xrdAPIGroup) && obj.Kind == xrdKind
+}
+
+// newCRDSchema creates a new CRDSchema instance from the CRD provided.
+func newCRDSchema(obj *crd) *hub.CRDSchema {
+	s := &hub.CRDSchema{
+		Name:     obj.Metadata.Name,
+		Group:    obj.Spec.Group,
+		Kind:     obj.Spec.Names.Kind,
+		Scope:    obj.Spec.Scope,
+		Versions: make([]*hub.CRDSchemaVersion, 0, len(obj.Spec.Versions)),
+	}
+
+	// In v1beta1 CRDs the schema can be defined at the top level, applying to
+	// all versions, and a single version may be provided
+	var sharedSchema json.RawMessage
+	if obj.Spec.Validation != nil {
+		sharedSchema = obj.Spec.Validation.OpenAPIV3Schema
+	}
+	if len(obj.Spec.Versions) == 0 && obj.Spec.Version != "" {
+		s.Versions = append(s.Versions, &hub.CRDSchemaVersion{
+			Name:    obj.Spec.Version,
+			Served:  true,
+			Storage: true,
+			Schema:  sharedSchema,
+		})
+	}
+	for _, v := range obj.Spec.Versions {
+		schema := sharedSchema
+		if v.Schema != nil && len(v.Schema.OpenAPIV3Schema) > 0 {
+			schema = v.Schema.OpenAPIV3Schema
+		}
+		storage := v.Storage
+		if isXRD(obj) {
+			// XRDs don't declare a storage version, the referenceable one is
+			// used to compose resources instead
+			storage = v.Referenceable
+		}
+		s.Versions = append(s.Versions, &hub.CRDSchemaVersion{
+			Name:    v.Name,
+			Served:  v.Served,
+			Storage: storage,
+			Schema:  schema,
+		})
+	}
+	if isXRD(obj) && s.Scope == "" {
+		s.Scope = xrdScope
+	}
+
+	return s
+}
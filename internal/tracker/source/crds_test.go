@@ -0,0 +1,156 @@
+package source
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractCRDsSchemas(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		manifests           [][]byte
+		expectedCRDsSchemas []*hub.CRDSchema
+	}{
+		{
+			"no manifests",
+			nil,
+			nil,
+		},
+		{
+			"invalid manifest and non crd objects are ignored",
+			[][]byte{
+				[]byte("{{ invalid"),
+				[]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+`),
+			},
+			nil,
+		},
+		{
+			"v1 crd in multi-document manifest",
+			[][]byte{
+				[]byte(`
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa1
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+spec:
+  group: stable.example.com
+  names:
+    kind: CronTab
+    plural: crontabs
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+    - name: v1alpha1
+      served: false
+      storage: false
+`),
+			},
+			[]*hub.CRDSchema{
+				{
+					Name:  "crontabs.stable.example.com",
+					Group: "stable.example.com",
+					Kind:  "CronTab",
+					Scope: "Namespaced",
+					Versions: []*hub.CRDSchemaVersion{
+						{Name: "v1", Served: true, Storage: true, Schema: json.RawMessage(`{"type":"object"}`)},
+						{Name: "v1alpha1"},
+					},
+				},
+			},
+		},
+		{
+			"v1beta1 crd with top level schema",
+			[][]byte{
+				[]byte(`
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: backups.example.com
+spec:
+  group: example.com
+  names:
+    kind: Backup
+  scope: Cluster
+  version: v1beta1
+  validation:
+    openAPIV3Schema:
+      type: object
+`),
+			},
+			[]*hub.CRDSchema{
+				{
+					Name:  "backups.example.com",
+					Group: "example.com",
+					Kind:  "Backup",
+					Scope: "Cluster",
+					Versions: []*hub.CRDSchemaVersion{
+						{Name: "v1beta1", Served: true, Storage: true, Schema: json.RawMessage(`{"type":"object"}`)},
+					},
+				},
+			},
+		},
+		{
+			"crossplane composite resource definition",
+			[][]byte{
+				[]byte(`
+apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: xpostgresqlinstances.database.example.org
+spec:
+  group: database.example.org
+  names:
+    kind: XPostgreSQLInstance
+    plural: xpostgresqlinstances
+  claimNames:
+    kind: PostgreSQLInstance
+    plural: postgresqlinstances
+  versions:
+    - name: v1alpha1
+      served: true
+      referenceable: true
+      schema:
+        openAPIV3Schema:
+          type: object
+`),
+			},
+			[]*hub.CRDSchema{
+				{
+					Name:  "xpostgresqlinstances.database.example.org",
+					Group: "database.example.org",
+					Kind:  "XPostgreSQLInstance",
+					Scope: "Cluster",
+					Versions: []*hub.CRDSchemaVersion{
+						{Name: "v1alpha1", Served: true, Storage: true, Schema: json.RawMessage(`{"type":"object"}`)},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			crdsSchemas := ExtractCRDsSchemas(tc.manifests)
+			assert.Equal(t, tc.expectedCRDsSchemas, crdsSchemas)
+		})
+	}
+}
@@ -110,21 +110,9 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *RulesMetadata, pkg
 	}
 
 	// Prepare source link url whenever possible
-	var repoBaseURL, host, pkgsPath string
-	matches := repo.GitRepoURLRE.FindStringSubmatch(r.URL)
-	if len(matches) >= 3 {
-		repoBaseURL = matches[1]
-		host = matches[2]
-	}
-	if len(matches) == 4 {
-		pkgsPath = strings.TrimSuffix(matches[3], "/")
-	}
 	var sourceURL string
-	switch host {
-	case "github.com":
-		sourceURL = fmt.Sprintf("%s/blob/%s/%s%s", repoBaseURL, repo.GetBranch(r), pkgsPath, pkgPath)
-	case "gitlab.com":
-		sourceURL = fmt.Sprintf("%s/-/blob/%s/%s%s", repoBaseURL, repo.GetBranch(r), pkgsPath, pkgPath)
+	if u, err := repo.ParseGitRepoURL(r.URL); err == nil {
+		sourceURL = u.BlobURL(repo.GetBranch(r), u.PackagesPath+pkgPath)
 	}
 	if sourceURL != "" {
 		p.Links = append(p.Links, &hub.Link{
@@ -0,0 +1,114 @@
+package generic
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"sigs.k8s.io/yaml"
+)
+
+// yamlDocsSeparatorRE is a regexp used to split multi-document yaml files.
+var yamlDocsSeparatorRE = regexp.MustCompile(`(?m)^---\s*$`)
+
+// addCRDs extracts the schemas of the CRDs found in the manifests provided,
+// adding them to the package along with the CRDs summary and the examples
+// available for them.
+func addCRDs(p *hub.Package, manifests [][]byte) {
+	addCRDsSchemas(p, source.ExtractCRDsSchemas(manifests), manifests)
+}
+
+// addCRDsSchemas adds the CRDs schemas provided to the package, along with
+// the CRDs summary and the examples for them found in the manifests given.
+func addCRDsSchemas(p *hub.Package, schemas []*hub.CRDSchema, manifests [][]byte) {
+	p.CRDsSchemas = schemas
+	crds := make([]interface{}, 0, len(schemas))
+	kinds := make(map[string]struct{}, len(schemas))
+	for _, s := range schemas {
+		crds = append(crds, map[string]interface{}{
+			"name":        s.Name,
+			"version":     getCRDSchemaVersion(s),
+			"kind":        s.Kind,
+			"displayName": s.Kind,
+			"description": getCRDSchemaDescription(s),
+		})
+		kinds[s.Group+"/"+s.Kind] = struct{}{}
+	}
+	if len(crds) > 0 {
+		p.CRDs = crds
+		p.CRDsExamples = getCRDsExamples(manifests, kinds)
+	}
+}
+
+// getCRDsExamples returns the objects found in the manifests provided whose
+// group and kind match one of the kinds given. Only the first example found
+// for each kind is returned.
+func getCRDsExamples(manifests [][]byte, kinds map[string]struct{}) []interface{} {
+	var examples []interface{}
+	found := make(map[string]struct{})
+	for _, manifest := range manifests {
+		for _, doc := range yamlDocsSeparatorRE.Split(string(manifest), -1) {
+			var obj map[string]interface{}
+			if err := yaml.Unmarshal([]byte(doc), &obj); err != nil || obj == nil {
+				continue
+			}
+			apiVersion, _ := obj["apiVersion"].(string)
+			kind, _ := obj["kind"].(string)
+			group := apiVersion
+			if i := strings.LastIndex(apiVersion, "/"); i >= 0 {
+				group = apiVersion[:i]
+			}
+			key := group + "/" + kind
+			if _, ok := kinds[key]; !ok {
+				continue
+			}
+			if _, ok := found[key]; ok {
+				continue
+			}
+			found[key] = struct{}{}
+			examples = append(examples, obj)
+		}
+	}
+	sort.SliceStable(examples, func(i, j int) bool {
+		ki, _ := examples[i].(map[string]interface{})["kind"].(string)
+		kj, _ := examples[j].(map[string]interface{})["kind"].(string)
+		return ki < kj
+	})
+	return examples
+}
+
+// getCRDSchemaVersion returns the name of the storage version of the CRD
+// schema provided. If none is marked as storage version, the first one is
+// returned.
+func getCRDSchemaVersion(s *hub.CRDSchema) string {
+	for _, v := range s.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	if len(s.Versions) > 0 {
+		return s.Versions[0].Name
+	}
+	return ""
+}
+
+// getCRDSchemaDescription returns the description of the storage version
+// schema of the CRD schema provided, if available.
+func getCRDSchemaDescription(s *hub.CRDSchema) string {
+	version := getCRDSchemaVersion(s)
+	for _, v := range s.Versions {
+		if v.Name != version || len(v.Schema) == 0 {
+			continue
+		}
+		var schema struct {
+			Description string `json:"description"`
+		}
+		if err := json.Unmarshal(v.Schema, &schema); err == nil {
+			return schema.Description
+		}
+	}
+	return ""
+}
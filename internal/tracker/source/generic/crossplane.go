@@ -0,0 +1,183 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	ignore "github.com/sabhiram/go-gitignore"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// CrossplaneControllerImageKey represents the key used in the package's
+	// data field that contains the controller image of a Crossplane provider.
+	CrossplaneControllerImageKey = "controllerImage"
+
+	// CrossplaneDependsOnKey represents the key used in the package's data
+	// field that contains the dependencies of a Crossplane package.
+	CrossplaneDependsOnKey = "dependsOn"
+
+	// CrossplanePackageTypeKey represents the key used in the package's data
+	// field that contains the Crossplane package type (Configuration or
+	// Provider).
+	CrossplanePackageTypeKey = "packageType"
+
+	// CrossplaneVersionKey represents the key used in the package's data field
+	// that contains the Crossplane versions supported by the package.
+	CrossplaneVersionKey = "crossplaneVersion"
+
+	// crossplaneManifestFile is the name of the file that contains the
+	// Crossplane package metadata.
+	crossplaneManifestFile = "crossplane.yaml"
+
+	// crossplaneMetaAPIGroup represents the API group of the Crossplane
+	// packages metadata objects.
+	crossplaneMetaAPIGroup = "meta.pkg.crossplane.io/"
+)
+
+// errInvalidCrossplaneManifest indicates that the Crossplane package manifest
+// provided is not valid.
+var errInvalidCrossplaneManifest = errors.New("invalid crossplane package manifest")
+
+// crossplaneManifest represents the subset of a Crossplane package metadata
+// object (crossplane.yaml) used to prepare the package data.
+type crossplaneManifest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Spec       struct {
+		Controller *struct {
+			Image string `json:"image"`
+		} `json:"controller"`
+		Crossplane *struct {
+			Version string `json:"version"`
+		} `json:"crossplane"`
+		DependsOn []struct {
+			Configuration string `json:"configuration"`
+			Function      string `json:"function"`
+			Provider      string `json:"provider"`
+			Version       string `json:"version"`
+		} `json:"dependsOn"`
+	} `json:"spec"`
+}
+
+// crossplaneDependency represents a dependency of a Crossplane package.
+type crossplaneDependency struct {
+	Kind    string `json:"kind"`
+	Package string `json:"package"`
+	Version string `json:"version,omitempty"`
+}
+
+// prepareCrossplaneData reads and formats Crossplane specific data available
+// in the path provided, returning the resulting data structure. The CRDs and
+// XRDs found in the package, as well as the examples available for them, are
+// added to the package provided.
+func prepareCrossplaneData(
+	p *hub.Package,
+	pkgPath string,
+	ignorer ignore.IgnoreParser,
+) (map[string]interface{}, error) {
+	// Read and validate package manifest
+	data, err := os.ReadFile(filepath.Join(pkgPath, crossplaneManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("error reading crossplane package manifest: %w", err)
+	}
+	var manifest *crossplaneManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil || manifest == nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidCrossplaneManifest, err)
+	}
+	if !strings.HasPrefix(manifest.APIVersion, crossplaneMetaAPIGroup) ||
+		(manifest.Kind != "Configuration" && manifest.Kind != "Provider") {
+		return nil, fmt.Errorf("%w: %s", errInvalidCrossplaneManifest, "configuration or provider expected")
+	}
+
+	// Prepare package data
+	kindData := map[string]interface{}{
+		CrossplanePackageTypeKey: manifest.Kind,
+	}
+	if manifest.Spec.Crossplane != nil && manifest.Spec.Crossplane.Version != "" {
+		kindData[CrossplaneVersionKey] = manifest.Spec.Crossplane.Version
+	}
+	if manifest.Spec.Controller != nil && manifest.Spec.Controller.Image != "" {
+		kindData[CrossplaneControllerImageKey] = manifest.Spec.Controller.Image
+		if !hasContainerImage(p, manifest.Spec.Controller.Image) {
+			p.ContainersImages = append(p.ContainersImages, &hub.ContainerImage{
+				Name:  "controller",
+				Image: manifest.Spec.Controller.Image,
+			})
+		}
+	}
+	var dependencies []*crossplaneDependency
+	for _, d := range manifest.Spec.DependsOn {
+		dep := &crossplaneDependency{Version: d.Version}
+		switch {
+		case d.Provider != "":
+			dep.Kind, dep.Package = "provider", d.Provider
+		case d.Configuration != "":
+			dep.Kind, dep.Package = "configuration", d.Configuration
+		case d.Function != "":
+			dep.Kind, dep.Package = "function", d.Function
+		default:
+			continue
+		}
+		dependencies = append(dependencies, dep)
+	}
+	if len(dependencies) > 0 {
+		kindData[CrossplaneDependsOnKey] = dependencies
+	}
+
+	// Extract CRDs, XRDs and examples from the package manifests
+	manifests, err := getCrossplaneManifests(pkgPath, ignorer)
+	if err != nil {
+		return nil, err
+	}
+	addCRDs(p, manifests)
+
+	return kindData, nil
+}
+
+// getCrossplaneManifests returns the content of the yaml files available in
+// the path provided, excluding the package manifest file and the ones the
+// ignorer matches.
+func getCrossplaneManifests(pkgPath string, ignorer ignore.IgnoreParser) ([][]byte, error) {
+	var manifests [][]byte
+	err := filepath.Walk(pkgPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading files: %w", err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+		name := strings.TrimPrefix(path, pkgPath+"/")
+		if ignorer.MatchesPath(name) || name == crossplaneManifestFile {
+			return nil
+		}
+		if ext := filepath.Ext(name); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading file: %w", err)
+		}
+		manifests = append(manifests, content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifests, nil
+}
+
+// hasContainerImage checks if the package provided already contains the
+// given container image.
+func hasContainerImage(p *hub.Package, image string) bool {
+	for _, ci := range p.ContainersImages {
+		if ci.Image == image {
+			return true
+		}
+	}
+	return false
+}
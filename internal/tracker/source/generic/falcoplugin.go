@@ -0,0 +1,113 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// FalcoPluginKey represents the key used in the package's data field that
+	// contains the Falco plugin information.
+	FalcoPluginKey = "plugin"
+
+	// FalcoPluginLayerMediaType represents the media type used by the layer
+	// that contains the plugin in the Falco plugins OCI artifacts.
+	FalcoPluginLayerMediaType = "application/vnd.cncf.falco.plugin.layer.v1+tar.gz"
+
+	// falcoPluginFile is the name of the file that contains the Falco plugin
+	// metadata.
+	falcoPluginFile = "falco-plugin.yaml"
+)
+
+// errInvalidFalcoPlugin indicates that the Falco plugin metadata provided is
+// not valid.
+var errInvalidFalcoPlugin = errors.New("invalid falco plugin metadata")
+
+// FalcoPlugin represents some information about a Falco plugin distributed as
+// an OCI artifact.
+type FalcoPlugin struct {
+	Name                 string              `json:"name"`
+	Ref                  string              `json:"ref"`
+	Digest               string              `json:"digest,omitempty"`
+	Capabilities         []string            `json:"capabilities,omitempty"`
+	EventSources         []string            `json:"eventSources,omitempty"`
+	RequiredAPIVersion   string              `json:"requiredAPIVersion,omitempty"`
+	RequiredFalcoVersion string              `json:"requiredFalcoVersion,omitempty"`
+	Fields               []*FalcoPluginField `json:"fields,omitempty"`
+}
+
+// FalcoPluginField represents a field a Falco plugin with field extraction
+// capability can extract from events. Its format matches the one of the
+// fields schema returned by the plugins get_fields function.
+type FalcoPluginField struct {
+	Name       string               `json:"name"`
+	Type       string               `json:"type"`
+	Desc       string               `json:"desc,omitempty"`
+	Display    string               `json:"display,omitempty"`
+	IsList     bool                 `json:"isList,omitempty"`
+	Properties []string             `json:"properties,omitempty"`
+	Arg        *FalcoPluginFieldArg `json:"arg,omitempty"`
+}
+
+// FalcoPluginFieldArg represents the argument a Falco plugin field accepts.
+type FalcoPluginFieldArg struct {
+	IsRequired bool `json:"isRequired,omitempty"`
+	IsIndex    bool `json:"isIndex,omitempty"`
+	IsKey      bool `json:"isKey,omitempty"`
+}
+
+// getFalcoPlugin reads and validates the Falco plugin metadata file available
+// in the path provided. A nil plugin is returned when the file doesn't exist.
+func getFalcoPlugin(pkgPath string) (*FalcoPlugin, error) {
+	data, err := os.ReadFile(filepath.Join(pkgPath, falcoPluginFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading falco plugin metadata file: %w", err)
+	}
+	var plugin *FalcoPlugin
+	if err := yaml.Unmarshal(data, &plugin); err != nil || plugin == nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidFalcoPlugin, err)
+	}
+	plugin.Ref = strings.TrimPrefix(plugin.Ref, hub.RepositoryOCIPrefix)
+	plugin.Digest = ""
+	if err := validateFalcoPlugin(plugin); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidFalcoPlugin, err)
+	}
+	return plugin, nil
+}
+
+// validateFalcoPlugin checks if the Falco plugin provided is valid.
+func validateFalcoPlugin(plugin *FalcoPlugin) error {
+	if plugin.Name == "" {
+		return errors.New("name not provided")
+	}
+	if plugin.Ref == "" {
+		return errors.New("oci artifact reference not provided")
+	}
+	if plugin.RequiredFalcoVersion != "" {
+		if _, err := semver.NewConstraint(plugin.RequiredFalcoVersion); err != nil {
+			return fmt.Errorf("invalid required falco version constraint: %w", err)
+		}
+	}
+	for _, f := range plugin.Fields {
+		if f.Name == "" || f.Type == "" {
+			return errors.New("fields must provide a name and a type")
+		}
+	}
+	return nil
+}
+
+// falcoPluginInstall returns the instructions to install the Falco plugin
+// provided using falcoctl.
+func falcoPluginInstall(plugin *FalcoPlugin) string {
+	return fmt.Sprintf("```\nfalcoctl artifact install %s\n```\n", plugin.Ref)
+}
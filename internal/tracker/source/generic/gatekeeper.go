@@ -0,0 +1,174 @@
+package generic
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	ignore "github.com/sabhiram/go-gitignore"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// OPAConstraintTemplatesKey represents the key used in the package's data
+	// field that contains the Gatekeeper constraint templates available in
+	// the package.
+	OPAConstraintTemplatesKey = "constraintTemplates"
+
+	// gatekeeperConstraintsGroup represents the API group of the constraints
+	// defined by the Gatekeeper constraint templates.
+	gatekeeperConstraintsGroup = "constraints.gatekeeper.sh"
+
+	// gatekeeperConstraintsVersion represents the API version of the
+	// constraints defined by the Gatekeeper constraint templates.
+	gatekeeperConstraintsVersion = "v1beta1"
+
+	// gatekeeperTemplatesAPIGroup represents the API group of the Gatekeeper
+	// ConstraintTemplate objects.
+	gatekeeperTemplatesAPIGroup = "templates.gatekeeper.sh/"
+
+	// Gatekeeper constraint templates annotations.
+	gatekeeperDescriptionAnnotation = "description"
+	gatekeeperTitleAnnotation       = "metadata.gatekeeper.sh/title"
+	gatekeeperVersionAnnotation     = "metadata.gatekeeper.sh/version"
+)
+
+// constraintTemplate represents the subset of a Gatekeeper ConstraintTemplate
+// object used to prepare the package data.
+type constraintTemplate struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		CRD struct {
+			Spec struct {
+				Names struct {
+					Kind string `json:"kind"`
+				} `json:"names"`
+				Validation *struct {
+					OpenAPIV3Schema json.RawMessage `json:"openAPIV3Schema"`
+				} `json:"validation"`
+			} `json:"spec"`
+		} `json:"crd"`
+		Targets []struct {
+			Target string `json:"target"`
+		} `json:"targets"`
+	} `json:"spec"`
+}
+
+// gatekeeperConstraintTemplate represents the information about a Gatekeeper
+// constraint template stored in the package's data field.
+type gatekeeperConstraintTemplate struct {
+	Name        string   `json:"name"`
+	Kind        string   `json:"kind"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Targets     []string `json:"targets,omitempty"`
+}
+
+// prepareGatekeeperTemplates looks for Gatekeeper constraint templates in the
+// path provided, returning a summary of the templates found. The templates
+// files are added to the policies files provided, and the schemas of the
+// constraints they define, as well as the example constraints available for
+// them, are added to the package.
+func prepareGatekeeperTemplates(
+	p *hub.Package,
+	pkgPath string,
+	ignorer ignore.IgnoreParser,
+	policiesFiles map[string]string,
+) ([]*gatekeeperConstraintTemplate, error) {
+	files, err := getYAMLFiles(pkgPath, ignorer)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		templates []*gatekeeperConstraintTemplate
+		schemas   []*hub.CRDSchema
+		manifests = make([][]byte, 0, len(names))
+	)
+	for _, name := range names {
+		content := files[name]
+		manifests = append(manifests, []byte(content))
+		for _, ct := range getConstraintTemplates(content) {
+			policiesFiles[name] = content
+			templates = append(templates, newGatekeeperConstraintTemplate(ct))
+			schemas = append(schemas, newConstraintSchema(ct))
+		}
+	}
+	if len(templates) == 0 {
+		return nil, nil
+	}
+	addCRDsSchemas(p, schemas, manifests)
+
+	return templates, nil
+}
+
+// getConstraintTemplates returns the Gatekeeper constraint templates found in
+// the yaml content provided, which may contain multiple documents.
+func getConstraintTemplates(content string) []*constraintTemplate {
+	var templates []*constraintTemplate
+	for _, doc := range yamlDocsSeparatorRE.Split(content, -1) {
+		var ct *constraintTemplate
+		if err := yaml.Unmarshal([]byte(doc), &ct); err != nil || ct == nil {
+			continue
+		}
+		if !strings.HasPrefix(ct.APIVersion, gatekeeperTemplatesAPIGroup) || ct.Kind != "ConstraintTemplate" {
+			continue
+		}
+		if ct.Spec.CRD.Spec.Names.Kind == "" {
+			continue
+		}
+		templates = append(templates, ct)
+	}
+	return templates
+}
+
+// newGatekeeperConstraintTemplate creates a new gatekeeperConstraintTemplate
+// instance from the constraint template provided.
+func newGatekeeperConstraintTemplate(ct *constraintTemplate) *gatekeeperConstraintTemplate {
+	t := &gatekeeperConstraintTemplate{
+		Name:        ct.Metadata.Name,
+		Kind:        ct.Spec.CRD.Spec.Names.Kind,
+		Title:       ct.Metadata.Annotations[gatekeeperTitleAnnotation],
+		Description: strings.TrimSpace(ct.Metadata.Annotations[gatekeeperDescriptionAnnotation]),
+		Version:     ct.Metadata.Annotations[gatekeeperVersionAnnotation],
+	}
+	for _, target := range ct.Spec.Targets {
+		if target.Target != "" {
+			t.Targets = append(t.Targets, target.Target)
+		}
+	}
+	return t
+}
+
+// newConstraintSchema creates a new CRDSchema instance describing the
+// constraint defined by the constraint template provided.
+func newConstraintSchema(ct *constraintTemplate) *hub.CRDSchema {
+	kind := ct.Spec.CRD.Spec.Names.Kind
+	v := &hub.CRDSchemaVersion{
+		Name:    gatekeeperConstraintsVersion,
+		Served:  true,
+		Storage: true,
+	}
+	if ct.Spec.CRD.Spec.Validation != nil && len(ct.Spec.CRD.Spec.Validation.OpenAPIV3Schema) > 0 {
+		v.Schema = ct.Spec.CRD.Spec.Validation.OpenAPIV3Schema
+	}
+	return &hub.CRDSchema{
+		Name:     strings.ToLower(kind) + "." + gatekeeperConstraintsGroup,
+		Group:    gatekeeperConstraintsGroup,
+		Kind:     kind,
+		Scope:    "Cluster",
+		Versions: []*hub.CRDSchemaVersion{v},
+	}
+}
@@ -31,6 +31,10 @@ const (
 	opaPoliciesSuffix = ".rego"
 )
 
+// errNoFilesFound indicates that no files matching the expected criteria were
+// found in the package path.
+var errNoFilesFound = errors.New("no files found")
+
 // TrackerSource is a hub.TrackerSource implementation used by several kinds
 // of repositories.
 type TrackerSource struct {
@@ -76,13 +80,25 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 			s.warn(err)
 			return nil
 		}
+		if plugin, ok := p.Data[FalcoPluginKey].(*FalcoPlugin); ok {
+			if err := s.verifyFalcoPlugin(plugin); err != nil {
+				s.warn(fmt.Errorf("error preparing package %s version %s plugin: %w", md.Name, md.Version, err))
+				return nil
+			}
+		}
 		packagesAvailable[pkg.BuildKey(p)] = p
-		logoImageID, err := s.prepareLogoImage(md, pkgPath)
+		logoImageID, err := s.prepareLogoImage(md.LogoPath, md.LogoURL, pkgPath)
 		if err != nil {
 			s.warn(fmt.Errorf("error preparing package %s version %s logo image: %w", md.Name, md.Version, err))
 		} else {
 			p.LogoImageID = logoImageID
 		}
+		logoDarkImageID, err := s.prepareLogoImage(md.LogoDarkPath, md.LogoDarkURL, pkgPath)
+		if err != nil {
+			s.warn(fmt.Errorf("error preparing package %s version %s dark logo image: %w", md.Name, md.Version, err))
+		} else {
+			p.LogoDarkImageID = logoDarkImageID
+		}
 
 		return nil
 	})
@@ -93,14 +109,15 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 	return packagesAvailable, nil
 }
 
-// prepareLogoImage processes and stores the logo image provided.
-func (s *TrackerSource) prepareLogoImage(md *hub.PackageMetadata, pkgPath string) (string, error) {
+// prepareLogoImage processes and stores the logo image provided. Images
+// available locally in the package path take precedence over remote ones.
+func (s *TrackerSource) prepareLogoImage(logoPath, logoURL, pkgPath string) (string, error) {
 	var logoImageID string
 	var err error
 
 	// Store logo image when available
-	if md.LogoPath != "" {
-		data, err := os.ReadFile(filepath.Join(pkgPath, md.LogoPath))
+	if logoPath != "" {
+		data, err := os.ReadFile(filepath.Join(pkgPath, logoPath))
 		if err != nil {
 			return "", fmt.Errorf("error reading logo image: %w", err)
 		}
@@ -108,8 +125,8 @@ func (s *TrackerSource) prepareLogoImage(md *hub.PackageMetadata, pkgPath string
 		if err != nil && !errors.Is(err, image.ErrFormat) {
 			return "", fmt.Errorf("error saving logo image: %w", err)
 		}
-	} else if md.LogoURL != "" {
-		logoImageID, err = s.i.Svc.Is.DownloadAndSaveImage(s.i.Svc.Ctx, md.LogoURL)
+	} else if logoURL != "" {
+		logoImageID, err = s.i.Svc.Is.DownloadAndSaveImage(s.i.Svc.Ctx, logoURL)
 		if err != nil {
 			return "", fmt.Errorf("error downloading and saving logo image: %w", err)
 		}
@@ -118,6 +135,17 @@ func (s *TrackerSource) prepareLogoImage(md *hub.PackageMetadata, pkgPath string
 	return logoImageID, nil
 }
 
+// verifyFalcoPlugin checks that the OCI artifact of the Falco plugin provided
+// is available and contains the plugin layer, updating the plugin's digest.
+func (s *TrackerSource) verifyFalcoPlugin(plugin *FalcoPlugin) error {
+	desc, _, err := s.i.Svc.Op.PullLayer(s.i.Svc.Ctx, plugin.Ref, FalcoPluginLayerMediaType, "", "")
+	if err != nil {
+		return fmt.Errorf("error pulling oci artifact: %w", err)
+	}
+	plugin.Digest = desc.Digest.String()
+	return nil
+}
+
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
 func (s *TrackerSource) warn(err error) {
@@ -144,14 +172,25 @@ func PreparePackage(r *hub.Repository, md *hub.PackageMetadata, pkgPath string)
 		}
 	}
 
+	// Get translated READMEs (i.e. README_zh.md), if available
+	pkg.AddReadmeTranslationsFromPath(p, pkgPath)
+
 	// Include kind specific data into package
 	ignorer := ignore.CompileIgnoreLines(md.Ignore...)
 	var kindData map[string]interface{}
 	switch r.Kind {
+	case hub.Crossplane:
+		kindData, err = prepareCrossplaneData(p, pkgPath, ignorer)
 	case hub.Falco:
-		kindData, err = prepareFalcoData(pkgPath, ignorer)
+		kindData, err = prepareFalcoData(p, pkgPath, ignorer)
+	case hub.Headlamp:
+		kindData, err = prepareHeadlampData(p)
+	case hub.KnativeFunc:
+		kindData, err = prepareKnativeFuncData(p, pkgPath)
+	case hub.Kyverno:
+		kindData, err = prepareKyvernoData(p, pkgPath, ignorer)
 	case hub.OPA:
-		kindData, err = prepareOPAData(pkgPath, ignorer)
+		kindData, err = prepareOPAData(p, pkgPath, ignorer)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error preparing package %s version %s data: %w", md.Name, md.Version, err)
@@ -170,33 +209,67 @@ func PreparePackage(r *hub.Repository, md *hub.PackageMetadata, pkgPath string)
 }
 
 // prepareFalcoData reads and formats Falco specific data available in the path
-// provided, returning the resulting data structure.
-func prepareFalcoData(pkgPath string, ignorer ignore.IgnoreParser) (map[string]interface{}, error) {
-	// Read rules files
-	files, err := getFilesWithSuffix(falcoRulesSuffix, pkgPath, ignorer)
+// provided, returning the resulting data structure. Packages can contain rules
+// files, a plugin distributed as an OCI artifact or both.
+func prepareFalcoData(p *hub.Package, pkgPath string, ignorer ignore.IgnoreParser) (map[string]interface{}, error) {
+	// Read plugin metadata (if available)
+	plugin, err := getFalcoPlugin(pkgPath)
 	if err != nil {
+		return nil, err
+	}
+
+	// Read rules files (optional for plugins)
+	files, err := getFilesWithSuffix(falcoRulesSuffix, pkgPath, ignorer)
+	if err != nil && (plugin == nil || !errors.Is(err, errNoFilesFound)) {
 		return nil, fmt.Errorf("error getting falco rules files: %w", err)
 	}
 
 	// Return package data field
-	return map[string]interface{}{
-		FalcoRulesKey: files,
-	}, nil
+	kindData := make(map[string]interface{})
+	if len(files) > 0 {
+		kindData[FalcoRulesKey] = files
+	}
+	if plugin != nil {
+		kindData[FalcoPluginKey] = plugin
+		if p.Install == "" {
+			p.Install = falcoPluginInstall(plugin)
+		}
+	}
+	return kindData, nil
 }
 
 // prepareOPAData reads and formats OPA specific data available in the path
-// provided, returning the resulting data structure.
-func prepareOPAData(pkgPath string, ignorer ignore.IgnoreParser) (map[string]interface{}, error) {
+// provided, returning the resulting data structure. Gatekeeper constraint
+// templates bundles are supported as well: the templates files are added to
+// the policies and the schemas of the constraints they define are added to
+// the package provided.
+func prepareOPAData(p *hub.Package, pkgPath string, ignorer ignore.IgnoreParser) (map[string]interface{}, error) {
 	// Read policies files
 	files, err := getFilesWithSuffix(opaPoliciesSuffix, pkgPath, ignorer)
-	if err != nil {
+	if err != nil && !errors.Is(err, errNoFilesFound) {
 		return nil, fmt.Errorf("error getting opa policies files: %w", err)
 	}
+	if files == nil {
+		files = make(map[string]string)
+	}
+
+	// Read Gatekeeper constraint templates
+	templates, err := prepareGatekeeperTemplates(p, pkgPath, ignorer, files)
+	if err != nil {
+		return nil, fmt.Errorf("error getting gatekeeper constraint templates: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("error getting opa policies files: %w", errNoFilesFound)
+	}
 
 	// Return package data field
-	return map[string]interface{}{
+	kindData := map[string]interface{}{
 		OPAPoliciesKey: files,
-	}, nil
+	}
+	if len(templates) > 0 {
+		kindData[OPAConstraintTemplatesKey] = templates
+	}
+	return kindData, nil
 }
 
 // getFilesWithSuffix returns the files with a given suffix in the path
@@ -228,7 +301,7 @@ func getFilesWithSuffix(suffix, pkgPath string, ignorer ignore.IgnoreParser) (ma
 		return nil, err
 	}
 	if len(files) == 0 {
-		return nil, errors.New("no files found")
+		return nil, errNoFilesFound
 	}
 	return files, nil
 }
@@ -1,6 +1,7 @@
 package generic
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/tracker/source"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -167,6 +169,134 @@ func TestTrackerSource(t *testing.T) {
 		sw.AssertExpectations(t)
 	})
 
+	t.Run("crossplane packages must contain a package manifest file", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{Kind: hub.Crossplane},
+			BasePath:   "testdata/path11",
+			Svc:        sw.Svc,
+		}
+		expectedErr := "error preparing package pkg1 version 1.0.0 data: error reading crossplane package manifest: open testdata/path11/crossplane.yaml: no such file or directory"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("kyverno packages must contain at least one policy", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{Kind: hub.Kyverno},
+			BasePath:   "testdata/path13",
+			Svc:        sw.Svc,
+		}
+		expectedErr := "error preparing package pkg1 version 1.0.0 data: error getting kyverno policies: no policies found"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("headlamp plugins must provide the archive url", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{Kind: hub.Headlamp},
+			BasePath:   "testdata/path4",
+			Svc:        sw.Svc,
+		}
+		expectedErr := "error preparing package pkg1 version 1.0.0 data: invalid headlamp plugin metadata: archive url not provided"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("invalid knative function template manifest", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{Kind: hub.KnativeFunc},
+			BasePath:   "testdata/path19",
+			Svc:        sw.Svc,
+		}
+		expectedErr := "error preparing package pkg1 version 1.0.0 data: invalid knative function template manifest: error converting YAML to JSON: yaml: line 1: did not find expected node content"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("invalid falco plugin metadata", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{Kind: hub.Falco},
+			BasePath:   "testdata/path17",
+			Svc:        sw.Svc,
+		}
+		expectedErr := "error preparing package pkg1 version 1.0.0 data: invalid falco plugin metadata: invalid required falco version constraint: improper constraint: invalid"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("error pulling falco plugin oci artifact", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{Kind: hub.Falco},
+			BasePath:   "testdata/path16",
+			Svc:        sw.Svc,
+		}
+		sw.Op.On(
+			"PullLayer",
+			sw.Svc.Ctx,
+			"ghcr.io/falcosecurity/plugins/plugin/k8saudit:0.7.0",
+			FalcoPluginLayerMediaType,
+			"",
+			"",
+		).Return(nil, nil, tests.ErrFake)
+		expectedErr := "error preparing package pkg1 version 1.0.0 plugin: error pulling oci artifact: fake error for tests"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
 	t.Run("error reading logo image, package returned anyway", func(t *testing.T) {
 		t.Parallel()
 
@@ -278,6 +408,65 @@ func TestTrackerSource(t *testing.T) {
 		sw.AssertExpectations(t)
 	})
 
+	t.Run("falco plugin package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Falco,
+			},
+			BasePath: "testdata/path16",
+			Svc:      sw.Svc,
+		}
+		sw.Op.On(
+			"PullLayer",
+			sw.Svc.Ctx,
+			"ghcr.io/falcosecurity/plugins/plugin/k8saudit:0.7.0",
+			FalcoPluginLayerMediaType,
+			"",
+			"",
+		).Return(ocispec.Descriptor{Digest: "sha256:0123456789"}, []byte("plugin"), nil)
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data[FalcoPluginKey] = &FalcoPlugin{
+			Name:                 "k8saudit",
+			Ref:                  "ghcr.io/falcosecurity/plugins/plugin/k8saudit:0.7.0",
+			Digest:               "sha256:0123456789",
+			Capabilities:         []string{"sourcing", "extraction"},
+			EventSources:         []string{"k8s_audit"},
+			RequiredAPIVersion:   "3.0.0",
+			RequiredFalcoVersion: ">=0.36.0",
+			Fields: []*FalcoPluginField{
+				{
+					Name: "ka.user.name",
+					Type: "string",
+					Desc: "The user name performing the request",
+				},
+				{
+					Name:   "ka.req.pod.containers.image",
+					Type:   "string",
+					Desc:   "When the request object refers to a pod, the container's images",
+					IsList: true,
+					Arg: &FalcoPluginFieldArg{
+						IsIndex: true,
+					},
+				},
+			},
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
 	t.Run("falco package (using logo url) returned, no errors", func(t *testing.T) {
 		t.Parallel()
 
@@ -308,7 +497,306 @@ func TestTrackerSource(t *testing.T) {
 		sw.AssertExpectations(t)
 	})
 
-	t.Run("opa package returned (README.md file), no errors", func(t *testing.T) {
+	t.Run("falco package (using logo and dark logo urls) returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Falco,
+			},
+			BasePath: "testdata/path10",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, "https://logo.url/red-dot.png").Return("logoImageID", nil)
+		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, "https://logo.url/red-dot-dark.png").Return("logoDarkImageID", nil)
+
+		// Run test and check expectations
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoURL = "https://logo.url/red-dot.png"
+		p.LogoImageID = "logoImageID"
+		p.LogoDarkImageID = "logoDarkImageID"
+		p.Data[FalcoRulesKey] = map[string]string{
+			"file1-rules.yaml": "falco rules\n",
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("crossplane package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Crossplane,
+			},
+			BasePath: "testdata/path12",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.ContainersImages = append(p.ContainersImages, &hub.ContainerImage{
+			Name:  "controller",
+			Image: "registry/test/provider-test:v1.0.0",
+		})
+		p.Data[CrossplanePackageTypeKey] = "Provider"
+		p.Data[CrossplaneVersionKey] = ">=v1.12.0"
+		p.Data[CrossplaneControllerImageKey] = "registry/test/provider-test:v1.0.0"
+		p.Data[CrossplaneDependsOnKey] = []*crossplaneDependency{
+			{
+				Kind:    "provider",
+				Package: "xpkg.upbound.io/upbound/provider-family-aws",
+				Version: ">=v0.1.0",
+			},
+		}
+		p.CRDs = []interface{}{
+			map[string]interface{}{
+				"name":        "buckets.test.example.org",
+				"version":     "v1beta1",
+				"kind":        "Bucket",
+				"displayName": "Bucket",
+				"description": "A Bucket is a managed resource.",
+			},
+		}
+		p.CRDsExamples = []interface{}{
+			map[string]interface{}{
+				"apiVersion": "test.example.org/v1beta1",
+				"kind":       "Bucket",
+				"metadata": map[string]interface{}{
+					"name": "example",
+				},
+				"spec": map[string]interface{}{
+					"forProvider": map[string]interface{}{
+						"region": "us-east-1",
+					},
+				},
+			},
+		}
+		p.CRDsSchemas = []*hub.CRDSchema{
+			{
+				Name:  "buckets.test.example.org",
+				Group: "test.example.org",
+				Kind:  "Bucket",
+				Scope: "Cluster",
+				Versions: []*hub.CRDSchemaVersion{
+					{
+						Name:    "v1beta1",
+						Served:  true,
+						Storage: true,
+						Schema:  json.RawMessage(`{"description":"A Bucket is a managed resource.","type":"object"}`),
+					},
+				},
+			},
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("kyverno package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Kyverno,
+			},
+			BasePath: "testdata/path14",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		requireLabels, _ := ioutil.ReadFile("testdata/path14/require-labels.yaml")
+		disallowPrivileged, _ := ioutil.ReadFile("testdata/path14/disallow-privileged.yaml")
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Keywords = append(p.Keywords, "best practices", "pod security standards (baseline)", "severity-high")
+		p.Data[KyvernoPoliciesKey] = map[string]string{
+			"disallow-privileged.yaml": string(disallowPrivileged),
+			"require-labels.yaml":      string(requireLabels),
+		}
+		p.Data[KyvernoCategoriesKey] = []string{"Best Practices", "Pod Security Standards (Baseline)"}
+		p.Data[KyvernoSubjectsKey] = []string{"Label", "Pod"}
+		p.Data[KyvernoSeverityKey] = "high"
+		p.Data[KyvernoVersionKey] = "1.6.0"
+		p.Data[KyvernoKubernetesVersionKey] = "1.23"
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("headlamp plugin package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Headlamp,
+			},
+			BasePath: "testdata/path20",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data[HeadlampArchiveURLKey] = "https://github.com/headlamp-k8s/plugins/releases/download/flux-0.1.0/flux-0.1.0.tar.gz"
+		p.Data[HeadlampArchiveChecksumKey] = "sha256:4d86e1ac8c5f0a29b8d5d8b5f3e1c5d8f0e2b7a8c3d1e0f9a8b7c6d5e4f3a2b1"
+		p.Data[HeadlampVersionCompatKey] = ">=0.20.0"
+		p.Data[HeadlampDistroCompatKey] = "app,in-cluster"
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("knative function template package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.KnativeFunc,
+			},
+			BasePath: "testdata/path18",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Keywords = append(p.Keywords, "go")
+		p.Data[KnativeFuncRuntimeKey] = "go"
+		p.Data[KnativeFuncTemplateKey] = "http"
+		p.Data[KnativeFuncBuildpacksKey] = []string{
+			"paketo-buildpacks/go-dist",
+			"ghcr.io/boson-project/go-function-buildpack:tip",
+		}
+		p.Data[KnativeFuncBuilderImagesKey] = map[string]string{
+			"pack": "ghcr.io/knative/builder-jammy-tiny:latest",
+		}
+		p.Data[KnativeFuncHealthEndpointsKey] = map[string]string{
+			"liveness":  "/health/liveness",
+			"readiness": "/health/readiness",
+		}
+		p.Data[KnativeFuncInvokeKey] = "http"
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("opa package with gatekeeper constraint template returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.OPA,
+			},
+			BasePath: "testdata/path15",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		template, _ := ioutil.ReadFile("testdata/path15/template.yaml")
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoImageID = "logoImageID"
+		p.Data[OPAPoliciesKey] = map[string]string{
+			"template.yaml": string(template),
+		}
+		p.Data[OPAConstraintTemplatesKey] = []*gatekeeperConstraintTemplate{
+			{
+				Name:        "k8srequiredlabels",
+				Kind:        "K8sRequiredLabels",
+				Title:       "Required Labels",
+				Description: "Requires resources to contain specified labels.",
+				Version:     "1.0.0",
+				Targets:     []string{"admission.k8s.gatekeeper.sh"},
+			},
+		}
+		p.CRDs = []interface{}{
+			map[string]interface{}{
+				"name":        "k8srequiredlabels.constraints.gatekeeper.sh",
+				"version":     "v1beta1",
+				"kind":        "K8sRequiredLabels",
+				"displayName": "K8sRequiredLabels",
+				"description": "",
+			},
+		}
+		p.CRDsExamples = []interface{}{
+			map[string]interface{}{
+				"apiVersion": "constraints.gatekeeper.sh/v1beta1",
+				"kind":       "K8sRequiredLabels",
+				"metadata": map[string]interface{}{
+					"name": "all-must-have-owner",
+				},
+				"spec": map[string]interface{}{
+					"parameters": map[string]interface{}{
+						"labels": []interface{}{"owner"},
+					},
+				},
+			},
+		}
+		p.CRDsSchemas = []*hub.CRDSchema{
+			{
+				Name:  "k8srequiredlabels.constraints.gatekeeper.sh",
+				Group: "constraints.gatekeeper.sh",
+				Kind:  "K8sRequiredLabels",
+				Scope: "Cluster",
+				Versions: []*hub.CRDSchemaVersion{
+					{
+						Name:    "v1beta1",
+						Served:  true,
+						Storage: true,
+						Schema:  json.RawMessage(`{"properties":{"labels":{"items":{"type":"string"},"type":"array"}},"type":"object"}`),
+					},
+				},
+			},
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("opa package returned (README.md and README_es.md files), no errors", func(t *testing.T) {
 		t.Parallel()
 
 		// Setup services and expectations
@@ -330,6 +818,9 @@ func TestTrackerSource(t *testing.T) {
 			"policy1.rego": "policy content\n",
 		}
 		p.Readme = "# Package documentation in markdown format\n"
+		p.Translations = hub.PackageTranslations{
+			"es": {Readme: "# Documentación del paquete\n"},
+		}
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
 		assert.Equal(t, map[string]*hub.Package{
 			pkg.BuildKey(p): p,
@@ -0,0 +1,77 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// HeadlampArchiveChecksumKey represents the key used in the package's
+	// data field that contains the checksum of the plugin archive.
+	HeadlampArchiveChecksumKey = "headlamp/plugin/archive-checksum"
+
+	// HeadlampArchiveURLKey represents the key used in the package's data
+	// field that contains the url of the plugin archive.
+	HeadlampArchiveURLKey = "headlamp/plugin/archive-url"
+
+	// HeadlampDistroCompatKey represents the key used in the package's data
+	// field that contains the Headlamp distributions (i.e. app, in-cluster)
+	// the plugin is compatible with.
+	HeadlampDistroCompatKey = "headlamp/plugin/distro-compat"
+
+	// HeadlampVersionCompatKey represents the key used in the package's data
+	// field that contains the Headlamp versions the plugin is compatible with.
+	HeadlampVersionCompatKey = "headlamp/plugin/version-compat"
+)
+
+var (
+	// errInvalidHeadlampPlugin indicates that the Headlamp plugin metadata
+	// provided is not valid.
+	errInvalidHeadlampPlugin = errors.New("invalid headlamp plugin metadata")
+
+	// headlampChecksumRE is a regexp used to validate the plugin archive
+	// checksum.
+	headlampChecksumRE = regexp.MustCompile(`(?i)^sha256:[a-f0-9]{64}$`)
+)
+
+// prepareHeadlampData validates the Headlamp plugin specific data available
+// in the package provided. Headlamp plugins provide this information using
+// annotations in the package metadata file, which are already included in the
+// package's data field.
+func prepareHeadlampData(p *hub.Package) (map[string]interface{}, error) {
+	annotation := func(key string) string {
+		v, _ := p.Data[key].(string)
+		return v
+	}
+
+	// Archive url and checksum
+	archiveURL := annotation(HeadlampArchiveURLKey)
+	if archiveURL == "" {
+		return nil, fmt.Errorf("%w: archive url not provided", errInvalidHeadlampPlugin)
+	}
+	u, err := url.Parse(archiveURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: invalid archive url", errInvalidHeadlampPlugin)
+	}
+	checksum := annotation(HeadlampArchiveChecksumKey)
+	if checksum == "" {
+		return nil, fmt.Errorf("%w: archive checksum not provided", errInvalidHeadlampPlugin)
+	}
+	if !headlampChecksumRE.MatchString(checksum) {
+		return nil, fmt.Errorf("%w: invalid archive checksum (sha256:<hex> expected)", errInvalidHeadlampPlugin)
+	}
+
+	// Compatibility
+	if versionCompat := annotation(HeadlampVersionCompatKey); versionCompat != "" {
+		if _, err := semver.NewConstraint(versionCompat); err != nil {
+			return nil, fmt.Errorf("%w: invalid version compatibility constraint: %v", errInvalidHeadlampPlugin, err)
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,111 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// KnativeFuncBuilderImagesKey represents the key used in the package's
+	// data field that contains the builder images used to build the functions
+	// created from the template.
+	KnativeFuncBuilderImagesKey = "builderImages"
+
+	// KnativeFuncBuildpacksKey represents the key used in the package's data
+	// field that contains the buildpacks used to build the functions created
+	// from the template.
+	KnativeFuncBuildpacksKey = "buildpacks"
+
+	// KnativeFuncHealthEndpointsKey represents the key used in the package's
+	// data field that contains the health endpoints of the functions created
+	// from the template.
+	KnativeFuncHealthEndpointsKey = "healthEndpoints"
+
+	// KnativeFuncInvokeKey represents the key used in the package's data field
+	// that contains the invocation hint of the functions created from the
+	// template (i.e. http or cloudevent).
+	KnativeFuncInvokeKey = "invoke"
+
+	// KnativeFuncRuntimeKey represents the key used in the package's data
+	// field that contains the runtime of the template.
+	KnativeFuncRuntimeKey = "runtime"
+
+	// KnativeFuncTemplateKey represents the key used in the package's data
+	// field that contains the name of the template.
+	KnativeFuncTemplateKey = "template"
+
+	// knativeFuncManifestFile is the name of the file that contains the
+	// Knative function template manifest.
+	knativeFuncManifestFile = "manifest.yaml"
+)
+
+// errInvalidKnativeFuncManifest indicates that the Knative function template
+// manifest provided is not valid.
+var errInvalidKnativeFuncManifest = errors.New("invalid knative function template manifest")
+
+// knativeFuncManifest represents the subset of a Knative function template
+// manifest (manifest.yaml) used to prepare the package data.
+type knativeFuncManifest struct {
+	Buildpacks      []string          `json:"buildpacks"`
+	BuilderImages   map[string]string `json:"builderImages"`
+	HealthEndpoints map[string]string `json:"healthEndpoints"`
+	Invoke          string            `json:"invoke"`
+}
+
+// prepareKnativeFuncData reads and formats Knative function template specific
+// data available in the path provided, returning the resulting data
+// structure. Templates are expected to follow the layout used by func
+// templates repositories (<runtime>/<template>), so the runtime and the
+// template names are taken from the package path.
+func prepareKnativeFuncData(p *hub.Package, pkgPath string) (map[string]interface{}, error) {
+	runtime := filepath.Base(filepath.Dir(pkgPath))
+	template := filepath.Base(pkgPath)
+
+	// Prepare package data
+	kindData := map[string]interface{}{
+		KnativeFuncRuntimeKey:  runtime,
+		KnativeFuncTemplateKey: template,
+	}
+
+	// Read template manifest (if available)
+	data, err := os.ReadFile(filepath.Join(pkgPath, knativeFuncManifestFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error reading knative function template manifest: %w", err)
+	}
+	if err == nil {
+		var manifest *knativeFuncManifest
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidKnativeFuncManifest, err)
+		}
+		if manifest != nil {
+			if len(manifest.Buildpacks) > 0 {
+				kindData[KnativeFuncBuildpacksKey] = manifest.Buildpacks
+			}
+			if len(manifest.BuilderImages) > 0 {
+				kindData[KnativeFuncBuilderImagesKey] = manifest.BuilderImages
+			}
+			if len(manifest.HealthEndpoints) > 0 {
+				kindData[KnativeFuncHealthEndpointsKey] = manifest.HealthEndpoints
+			}
+			if manifest.Invoke != "" {
+				kindData[KnativeFuncInvokeKey] = manifest.Invoke
+			}
+		}
+	}
+
+	// Make runtime searchable and provide install instructions if needed
+	addKeyword(p, runtime)
+	if p.Install == "" && p.Repository != nil && p.Repository.URL != "" {
+		p.Install = fmt.Sprintf(
+			"```\nfunc create -l %s -t %s --repository %s my-function\n```\n",
+			runtime, template, p.Repository.URL,
+		)
+	}
+
+	return kindData, nil
+}
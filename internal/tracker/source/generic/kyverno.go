@@ -0,0 +1,257 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	ignore "github.com/sabhiram/go-gitignore"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// KyvernoCategoriesKey represents the key used in the package's data field
+	// that contains the categories of the policies.
+	KyvernoCategoriesKey = "categories"
+
+	// KyvernoKubernetesVersionKey represents the key used in the package's
+	// data field that contains the Kubernetes versions the policies support.
+	KyvernoKubernetesVersionKey = "kubernetesVersion"
+
+	// KyvernoPoliciesKey represents the key used in the package's data field
+	// that contains the raw policies.
+	KyvernoPoliciesKey = "policies"
+
+	// KyvernoSeverityKey represents the key used in the package's data field
+	// that contains the highest severity of the policies.
+	KyvernoSeverityKey = "severity"
+
+	// KyvernoSubjectsKey represents the key used in the package's data field
+	// that contains the kinds of resources the policies apply to.
+	KyvernoSubjectsKey = "subjects"
+
+	// KyvernoVersionKey represents the key used in the package's data field
+	// that contains the minimum Kyverno version required by the policies.
+	KyvernoVersionKey = "kyvernoVersion"
+
+	// kyvernoAPIGroup represents the API group of the Kyverno policies.
+	kyvernoAPIGroup = "kyverno.io/"
+
+	// Kyverno policies annotations.
+	kyvernoCategoryAnnotation          = "policies.kyverno.io/category"
+	kyvernoKubernetesVersionAnnotation = "kyverno.io/kubernetes-version"
+	kyvernoMinVersionAnnotation        = "policies.kyverno.io/minversion"
+	kyvernoSeverityAnnotation          = "policies.kyverno.io/severity"
+	kyvernoSubjectAnnotation           = "policies.kyverno.io/subject"
+	kyvernoVersionAnnotation           = "kyverno.io/kyverno-version"
+)
+
+var (
+	// errNoKyvernoPolicies indicates that no Kyverno policies were found in
+	// the package path.
+	errNoKyvernoPolicies = errors.New("no policies found")
+
+	// kyvernoSeverities contains the severity levels supported in the
+	// policies, sorted from lowest to highest.
+	kyvernoSeverities = []string{"low", "medium", "high", "critical"}
+)
+
+// kyvernoPolicy represents the subset of a Kyverno policy object (Policy or
+// ClusterPolicy) used to prepare the package data.
+type kyvernoPolicy struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+}
+
+// prepareKyvernoData reads and formats Kyverno specific data available in the
+// path provided, returning the resulting data structure. The categories and
+// severity of the policies are added to the package keywords so that they can
+// be used when searching for packages.
+func prepareKyvernoData(
+	p *hub.Package,
+	pkgPath string,
+	ignorer ignore.IgnoreParser,
+) (map[string]interface{}, error) {
+	// Read policies files
+	files, err := getYAMLFiles(pkgPath, ignorer)
+	if err != nil {
+		return nil, fmt.Errorf("error getting kyverno policies: %w", err)
+	}
+	var (
+		policiesFiles     = make(map[string]string)
+		categories        = make(map[string]struct{})
+		subjects          = make(map[string]struct{})
+		severity          string
+		kyvernoVersion    string
+		kubernetesVersion string
+	)
+	for name, content := range files {
+		policies := getKyvernoPolicies(content)
+		if len(policies) == 0 {
+			continue
+		}
+		policiesFiles[name] = content
+		for _, policy := range policies {
+			annotations := policy.Metadata.Annotations
+			for _, category := range splitAnnotation(annotations[kyvernoCategoryAnnotation]) {
+				categories[category] = struct{}{}
+			}
+			for _, subject := range splitAnnotation(annotations[kyvernoSubjectAnnotation]) {
+				subjects[subject] = struct{}{}
+			}
+			if s := strings.ToLower(strings.TrimSpace(annotations[kyvernoSeverityAnnotation])); s != "" {
+				if severityLevel(s) > severityLevel(severity) {
+					severity = s
+				}
+			}
+			if kyvernoVersion == "" {
+				kyvernoVersion = annotations[kyvernoMinVersionAnnotation]
+				if kyvernoVersion == "" {
+					kyvernoVersion = annotations[kyvernoVersionAnnotation]
+				}
+			}
+			if kubernetesVersion == "" {
+				kubernetesVersion = annotations[kyvernoKubernetesVersionAnnotation]
+			}
+		}
+	}
+	if len(policiesFiles) == 0 {
+		return nil, fmt.Errorf("error getting kyverno policies: %w", errNoKyvernoPolicies)
+	}
+
+	// Prepare package data
+	kindData := map[string]interface{}{
+		KyvernoPoliciesKey: policiesFiles,
+	}
+	if len(categories) > 0 {
+		kindData[KyvernoCategoriesKey] = sortedKeys(categories)
+	}
+	if len(subjects) > 0 {
+		kindData[KyvernoSubjectsKey] = sortedKeys(subjects)
+	}
+	if severity != "" {
+		kindData[KyvernoSeverityKey] = severity
+	}
+	if kyvernoVersion != "" {
+		kindData[KyvernoVersionKey] = kyvernoVersion
+	}
+	if kubernetesVersion != "" {
+		kindData[KyvernoKubernetesVersionKey] = kubernetesVersion
+	}
+
+	// Make categories and severity searchable
+	for _, category := range sortedKeys(categories) {
+		addKeyword(p, strings.ToLower(category))
+	}
+	if severity != "" {
+		addKeyword(p, "severity-"+severity)
+	}
+
+	return kindData, nil
+}
+
+// getKyvernoPolicies returns the Kyverno policies found in the yaml content
+// provided, which may contain multiple documents.
+func getKyvernoPolicies(content string) []*kyvernoPolicy {
+	var policies []*kyvernoPolicy
+	for _, doc := range yamlDocsSeparatorRE.Split(content, -1) {
+		var policy *kyvernoPolicy
+		if err := yaml.Unmarshal([]byte(doc), &policy); err != nil || policy == nil {
+			continue
+		}
+		if !strings.HasPrefix(policy.APIVersion, kyvernoAPIGroup) {
+			continue
+		}
+		if policy.Kind != "ClusterPolicy" && policy.Kind != "Policy" {
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	return policies
+}
+
+// getYAMLFiles returns the yaml files available in the path provided,
+// ignoring the ones the ignorer matches and the package metadata file.
+func getYAMLFiles(pkgPath string, ignorer ignore.IgnoreParser) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.Walk(pkgPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading files: %w", err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+		name := strings.TrimPrefix(path, pkgPath+"/")
+		if ignorer.MatchesPath(name) {
+			return nil
+		}
+		ext := filepath.Ext(name)
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		if strings.TrimSuffix(info.Name(), ext) == hub.PackageMetadataFile {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading file: %w", err)
+		}
+		files[name] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// splitAnnotation returns the comma separated values of the annotation
+// provided, ignoring the empty ones.
+func splitAnnotation(annotation string) []string {
+	var values []string
+	for _, v := range strings.Split(annotation, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// sortedKeys returns the keys of the set provided sorted alphabetically.
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// severityLevel returns the level of the severity provided. Unknown
+// severities have the lowest level.
+func severityLevel(severity string) int {
+	for i, s := range kyvernoSeverities {
+		if s == severity {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// addKeyword adds the keyword provided to the package if it isn't present
+// yet.
+func addKeyword(p *hub.Package, keyword string) {
+	for _, kw := range p.Keywords {
+		if strings.EqualFold(kw, keyword) {
+			return
+		}
+	}
+	p.Keywords = append(p.Keywords, keyword)
+}
@@ -1,8 +1,10 @@
 package helm
 
 import (
+	"archive/tar"
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -15,8 +17,10 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/contentcheck"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/license"
 	"github.com/artifacthub/hub/internal/oci"
@@ -34,22 +38,26 @@ import (
 )
 
 const (
-	concurrency = 10
-
 	changesAnnotation              = "artifacthub.io/changes"
 	crdsAnnotation                 = "artifacthub.io/crds"
 	crdsExamplesAnnotation         = "artifacthub.io/crdsExamples"
 	imagesAnnotation               = "artifacthub.io/images"
+	k8sVersionsAnnotation          = "artifacthub.io/k8sVersions"
 	licenseAnnotation              = "artifacthub.io/license"
 	linksAnnotation                = "artifacthub.io/links"
+	logoDarkURLAnnotation          = "artifacthub.io/logoDarkURL"
 	maintainersAnnotation          = "artifacthub.io/maintainers"
 	operatorAnnotation             = "artifacthub.io/operator"
 	operatorCapabilitiesAnnotation = "artifacthub.io/operatorCapabilities"
 	prereleaseAnnotation           = "artifacthub.io/prerelease"
 	recommendationsAnnotation      = "artifacthub.io/recommendations"
+	replacedByAnnotation           = "artifacthub.io/replacedBy"
 	screenshotsAnnotation          = "artifacthub.io/screenshots"
 	securityUpdatesAnnotation      = "artifacthub.io/containsSecurityUpdates"
 	signKeyAnnotation              = "artifacthub.io/signKey"
+	supportAnnotation              = "artifacthub.io/support"
+	translationsAnnotation         = "artifacthub.io/translations"
+	videosAnnotation               = "artifacthub.io/videos"
 
 	legacyChartContentLayerMediaType = "application/tar+gzip"
 	ChartContentLayerMediaType       = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
@@ -60,9 +68,28 @@ const (
 	kubeVersionKey  = "kubeVersion"
 	typeKey         = "type"
 
+	// hookAnnotation represents the annotation used to define Helm hooks
+	hookAnnotation = "helm.sh/hook"
+
 	// Signatures kinds
 	prov   = "prov"
 	cosign = "cosign"
+
+	// maxChartArchiveSize represents the maximum size of a chart archive.
+	maxChartArchiveSize = 20 << 20
+
+	// maxChartSize represents the maximum size of the content of a chart
+	// archive once decompressed.
+	maxChartSize = 100 << 20
+
+	// maxChartFileSize represents the maximum size of a file in a chart
+	// archive once decompressed.
+	maxChartFileSize = 5 << 20
+
+	// chartArchivePullTimeout represents the maximum time allowed to pull a
+	// chart archive from an OCI registry. Archives fetched over http are
+	// already subject to the http client timeout.
+	chartArchivePullTimeout = 1 * time.Minute
 )
 
 var (
@@ -87,7 +114,9 @@ var (
 // TrackerSource is a hub.TrackerSource implementation for Helm repositories.
 type TrackerSource struct {
 	i  *hub.TrackerSourceInput
+	ag hub.OCIAnnotationsGetter
 	il hub.HelmIndexLoader
+	pg hub.OCIProvenanceGetter
 	sc hub.OCISignatureChecker
 	tg hub.OCITagsGetter
 }
@@ -98,9 +127,15 @@ func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource))
 	for _, o := range opts {
 		o(s)
 	}
+	if s.ag == nil {
+		s.ag = &oci.AnnotationsGetter{}
+	}
 	if s.il == nil {
 		s.il = &repo.HelmIndexLoader{}
 	}
+	if s.pg == nil {
+		s.pg = &oci.ProvenanceGetter{}
+	}
 	if s.sc == nil {
 		s.sc = &oci.SignatureChecker{}
 	}
@@ -120,7 +155,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 	if err != nil {
 		return nil, err
 	}
-	limiter := make(chan struct{}, concurrency)
+	limiter := make(chan struct{}, source.PackagesConcurrency(s.i.Svc.Cfg))
 	var wg sync.WaitGroup
 	for _, chartVersions := range charts {
 		for _, chartVersion := range chartVersions {
@@ -282,6 +317,14 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 				s.warn(md, fmt.Errorf("error getting logo image %s: %w", md.Icon, err))
 			}
 		}
+		if v := md.Annotations[logoDarkURLAnnotation]; v != "" {
+			logoDarkImageID, err := s.i.Svc.Is.DownloadAndSaveImage(s.i.Svc.Ctx, v)
+			if err == nil {
+				p.LogoDarkImageID = logoDarkImageID
+			} else {
+				s.warn(md, fmt.Errorf("error getting dark logo image %s: %w", v, err))
+			}
+		}
 
 		// Check if the chart version is signed
 		var signatures []string
@@ -305,6 +348,19 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 			}
 			if hasCosignSignature {
 				signatures = append(signatures, cosign)
+				if s.i.Metadata != nil && s.i.Metadata.Cosign != nil {
+					verified, err := s.sc.VerifyCosignSignature(
+						s.i.Svc.Ctx,
+						ref,
+						s.i.Repository.AuthUser,
+						s.i.Repository.AuthPass,
+						s.i.Metadata.Cosign,
+					)
+					if err != nil {
+						s.warn(md, fmt.Errorf("error verifying cosign signature: %w", err))
+					}
+					p.SignatureVerified = verified
+				}
 			}
 		}
 		if len(signatures) > 0 {
@@ -312,6 +368,21 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 			p.Signatures = signatures
 		}
 
+		// Get build provenance from SLSA provenance attestation (if available)
+		if repo.SchemeIsOCI(chartURL) {
+			ref := strings.TrimPrefix(chartURL.String(), hub.RepositoryOCIPrefix)
+			provenance, err := s.pg.GetSLSAProvenance(
+				s.i.Svc.Ctx,
+				ref,
+				s.i.Repository.AuthUser,
+				s.i.Repository.AuthPass,
+			)
+			if err != nil {
+				s.warn(md, fmt.Errorf("error getting slsa provenance: %w", err))
+			}
+			p.Provenance = provenance
+		}
+
 		// Enrich package with data available in chart archive
 		EnrichPackageFromChart(p, chrt)
 
@@ -319,6 +390,22 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 		if err := EnrichPackageFromAnnotations(p, chrt.Metadata.Annotations); err != nil {
 			return nil, fmt.Errorf("error enriching package from annotations: %w", err)
 		}
+
+		// Enrich package with information from the OCI artifact annotations
+		if repo.SchemeIsOCI(chartURL) {
+			ref := strings.TrimPrefix(chartURL.String(), hub.RepositoryOCIPrefix)
+			annotations, err := s.ag.GetAnnotations(
+				s.i.Svc.Ctx,
+				ref,
+				s.i.Repository.AuthUser,
+				s.i.Repository.AuthPass,
+			)
+			if err != nil {
+				s.warn(md, fmt.Errorf("error getting oci annotations: %w", err))
+			} else if err := source.EnrichPackageFromOCIAnnotations(p, annotations); err != nil {
+				return nil, fmt.Errorf("error enriching package from oci annotations: %w", err)
+			}
+		}
 	}
 
 	return p, nil
@@ -393,10 +480,77 @@ type LoadChartArchiveOptions struct {
 }
 
 // LoadChartArchive loads a chart from a remote archive located at the url
-// provided.
+// provided. Archives whose content exceeds the maximum size allowed once
+// decompressed are rejected before being loaded.
 func LoadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOptions) (*chart.Chart, error) {
-	var r io.Reader
+	data, err := GetChartArchive(ctx, u, o)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkChartArchiveSize(data); err != nil {
+		return nil, err
+	}
+	return loader.LoadArchive(bytes.NewReader(data))
+}
+
+// checkChartArchiveSize checks that the content of the chart archive provided
+// does not exceed the maximum size allowed once decompressed, protecting the
+// tracker against decompression bombs.
+func checkChartArchiveSize(data []byte) error {
+	gzr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error reading chart archive: %w", err)
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(&sizeLimitedReader{r: gzr, maxSize: maxChartSize})
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err == nil {
+			var n int64
+			n, err = io.CopyN(io.Discard, tr, maxChartFileSize+1)
+			if n > maxChartFileSize {
+				return fmt.Errorf("chart archive file %s: %w", hdr.Name, source.NewArtifactTooLargeError(maxChartFileSize))
+			}
+			if errors.Is(err, io.EOF) {
+				continue
+			}
+		}
+		if errors.Is(err, source.ErrArtifactTooLarge) {
+			return fmt.Errorf("chart archive content: %w", err)
+		}
+		return fmt.Errorf("error reading chart archive: %w", err)
+	}
+}
 
+// sizeLimitedReader is a reader that returns an error wrapping
+// source.ErrArtifactTooLarge once the underlying reader provides more than
+// maxSize bytes.
+type sizeLimitedReader struct {
+	r       io.Reader
+	maxSize int64
+	read    int64
+}
+
+// Read implements the io.Reader interface.
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if remaining := l.maxSize - l.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.maxSize {
+		return 0, source.NewArtifactTooLargeError(l.maxSize)
+	}
+	return n, err
+}
+
+// GetChartArchive returns the raw content of the remote chart archive located
+// at the url provided. Archives larger than the maximum size allowed are
+// rejected.
+func GetChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOptions) ([]byte, error) {
 	switch u.Scheme {
 	case "http", "https":
 		// Get chart content
@@ -422,13 +576,19 @@ func LoadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOption
 		default:
 			return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
 		}
-		r = resp.Body
+		data, err := source.ReadAll(resp.Body, maxChartArchiveSize)
+		if err != nil {
+			return nil, fmt.Errorf("chart archive: %w", err)
+		}
+		return data, nil
 	case "oci":
 		op := o.Op
 		if op == nil {
 			op = &oci.Puller{}
 		}
 		ref := strings.TrimPrefix(u.String(), hub.RepositoryOCIPrefix)
+		ctx, cancel := context.WithTimeout(ctx, chartArchivePullTimeout)
+		defer cancel()
 		_, data, err := op.PullLayer(ctx, ref, ChartContentLayerMediaType, o.Username, o.Password)
 		if err != nil {
 			if errors.Is(err, oci.ErrLayerNotFound) {
@@ -440,17 +600,13 @@ func LoadChartArchive(ctx context.Context, u *url.URL, o *LoadChartArchiveOption
 				return nil, err
 			}
 		}
-		r = bytes.NewReader(data)
+		if len(data) > maxChartArchiveSize {
+			return nil, fmt.Errorf("chart archive: %w", source.NewArtifactTooLargeError(maxChartArchiveSize))
+		}
+		return data, nil
 	default:
 		return nil, repo.ErrSchemeNotSupported
 	}
-
-	// Load chart from reader previously set up
-	chrt, err := loader.LoadArchive(r)
-	if err != nil {
-		return nil, err
-	}
-	return chrt, nil
 }
 
 // EnrichPackageFromChart adds some extra information to the package from the
@@ -468,6 +624,16 @@ func EnrichPackageFromChart(p *hub.Package, chrt *chart.Chart) {
 	// API version
 	p.Data[apiVersionKey] = chrt.Metadata.APIVersion
 
+	// Content warnings
+	p.ContentWarnings = checkContent(chrt)
+
+	// CRDs schemas
+	crdsData := make([][]byte, 0, len(chrt.CRDObjects()))
+	for _, crd := range chrt.CRDObjects() {
+		crdsData = append(crdsData, crd.File.Data)
+	}
+	p.CRDsSchemas = source.ExtractCRDsSchemas(crdsData)
+
 	// Containers images
 	imagesRefs, err := extractContainersImages(chrt)
 	if err == nil && len(imagesRefs) > 0 {
@@ -495,6 +661,9 @@ func EnrichPackageFromChart(p *hub.Package, chrt *chart.Chart) {
 
 	// Kubernetes version
 	p.Data[kubeVersionKey] = chrt.Metadata.KubeVersion
+	if k8sCompatibility, err := pkg.GetK8sCompatibility(chrt.Metadata.KubeVersion, nil); err == nil {
+		p.K8sCompatibility = k8sCompatibility
+	}
 
 	// License
 	licenseFile := getFile(chrt, "LICENSE")
@@ -538,11 +707,38 @@ func EnrichPackageFromChart(p *hub.Package, chrt *chart.Chart) {
 	if readme != nil {
 		p.Readme = string(readme.Data)
 	}
+	for _, file := range chrt.Files {
+		if locale := pkg.GetReadmeTranslationLocale(file.Name); locale != "" {
+			pkg.AddReadmeTranslation(p, locale, string(file.Data))
+		}
+	}
 
 	// Type
 	p.Data[typeKey] = chrt.Metadata.Type
 }
 
+// checkContent runs the content static checks on the chart files, including
+// the ones of its dependencies. Files defining Helm hooks are flagged as such,
+// as some checks only apply to them.
+func checkContent(chrt *chart.Chart) []*hub.ContentWarning {
+	var files []*contentcheck.File
+	var collectFiles func(c *chart.Chart, prefix string)
+	collectFiles = func(c *chart.Chart, prefix string) {
+		for _, f := range c.Raw {
+			files = append(files, &contentcheck.File{
+				Name: path.Join(prefix, f.Name),
+				Data: f.Data,
+				Hook: bytes.Contains(f.Data, []byte(hookAnnotation)),
+			})
+		}
+		for _, dep := range c.Dependencies() {
+			collectFiles(dep, path.Join(prefix, "charts", dep.Name()))
+		}
+	}
+	collectFiles(chrt, "")
+	return contentcheck.Check(files)
+}
+
 // extractContainersImages extracts the containers images references found in
 // the manifest generated as a result of Helm dry-run install with the default
 // values.
@@ -634,6 +830,21 @@ func EnrichPackageFromAnnotations(p *hub.Package, annotations map[string]string)
 		}
 	}
 
+	// Kubernetes versions
+	if v, ok := annotations[k8sVersionsAnnotation]; ok {
+		var k8sVersions []string
+		if err := yaml.Unmarshal([]byte(v), &k8sVersions); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: invalid k8sVersions value", errInvalidAnnotation))
+		} else {
+			k8sCompatibility, err := pkg.GetK8sCompatibility("", k8sVersions)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("%w: %v", errInvalidAnnotation, err))
+			} else {
+				p.K8sCompatibility = k8sCompatibility
+			}
+		}
+	}
+
 	// License
 	if v, ok := annotations[licenseAnnotation]; ok && v != "" {
 		p.License = v
@@ -717,11 +928,22 @@ func EnrichPackageFromAnnotations(p *hub.Package, annotations map[string]string)
 		}
 	}
 
+	// Replaced by
+	if v, ok := annotations[replacedByAnnotation]; ok && v != "" {
+		if err := pkg.ValidateReplacedBy(p.Deprecated, v); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: %s", errInvalidAnnotation, err.Error()))
+		} else {
+			p.ReplacedBy = v
+		}
+	}
+
 	// Screenshots
 	if v, ok := annotations[screenshotsAnnotation]; ok {
 		var screenshots []*hub.Screenshot
 		if err := yaml.Unmarshal([]byte(v), &screenshots); err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("%w: invalid screenshots value", errInvalidAnnotation))
+		} else if err := pkg.ValidateScreenshots(screenshots); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: %v", errInvalidAnnotation, err))
 		} else {
 			p.Screenshots = screenshots
 		}
@@ -751,6 +973,52 @@ func EnrichPackageFromAnnotations(p *hub.Package, annotations map[string]string)
 		}
 	}
 
+	// Support
+	if v, ok := annotations[supportAnnotation]; ok {
+		var support *hub.VersionSupport
+		if err := yaml.Unmarshal([]byte(v), &support); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: invalid support value", errInvalidAnnotation))
+		} else if err := pkg.ValidateSupport(support); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: %v", errInvalidAnnotation, err))
+		} else {
+			p.Support = support
+		}
+	}
+
+	// Translations
+	if v, ok := annotations[translationsAnnotation]; ok {
+		var translations hub.PackageTranslations
+		if err := yaml.Unmarshal([]byte(v), &translations); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: invalid translations value", errInvalidAnnotation))
+		} else if translations, err = pkg.NormalizeTranslations(translations); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: %v", errInvalidAnnotation, err))
+		} else {
+			// Translated READMEs provided in the chart files are kept unless
+			// they are overridden in the annotation
+			if p.Translations == nil && len(translations) > 0 {
+				p.Translations = make(hub.PackageTranslations)
+			}
+			for locale, t := range translations {
+				if current, ok := p.Translations[locale]; ok && t.Readme == "" {
+					t.Readme = current.Readme
+				}
+				p.Translations[locale] = t
+			}
+		}
+	}
+
+	// Videos
+	if v, ok := annotations[videosAnnotation]; ok {
+		var videos []*hub.Video
+		if err := yaml.Unmarshal([]byte(v), &videos); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: invalid videos value", errInvalidAnnotation))
+		} else if err := pkg.ValidateVideos(videos); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%w: %v", errInvalidAnnotation, err))
+		} else {
+			p.Videos = videos
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
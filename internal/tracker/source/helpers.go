@@ -2,12 +2,73 @@ package source
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"os"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/spf13/viper"
 	"gopkg.in/yaml.v2"
 )
 
+const (
+	// DefaultPackagesConcurrency represents the number of packages versions
+	// processed concurrently by default when tracking a repository.
+	DefaultPackagesConcurrency = 10
+)
+
+// ErrArtifactTooLarge indicates that an artifact, or a file in it, exceeds the
+// maximum size allowed.
+var ErrArtifactTooLarge = errors.New("artifact too large")
+
+// PackagesConcurrency returns the number of packages versions that should be
+// processed concurrently when tracking a repository, as configured in the
+// tracker.packagesConcurrency setting.
+func PackagesConcurrency(cfg *viper.Viper) int {
+	if cfg == nil || !cfg.IsSet("tracker.packagesConcurrency") {
+		return DefaultPackagesConcurrency
+	}
+	if n := cfg.GetInt("tracker.packagesConcurrency"); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// ReadAll reads from the reader provided until EOF. An error wrapping
+// ErrArtifactTooLarge is returned as soon as more than maxSize bytes are read.
+func ReadAll(r io.Reader, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, NewArtifactTooLargeError(maxSize)
+	}
+	return data, nil
+}
+
+// ReadFile reads the file located at the path provided. An error wrapping
+// ErrArtifactTooLarge is returned when the file is larger than maxSize bytes.
+func ReadFile(path string, maxSize int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadAll(f, maxSize)
+}
+
+// NewArtifactTooLargeError returns an error wrapping ErrArtifactTooLarge that
+// includes the maximum size allowed provided.
+func NewArtifactTooLargeError(maxSize int64) error {
+	const mib = 1 << 20
+	if maxSize%mib == 0 {
+		return fmt.Errorf("%w (maximum size allowed: %dMiB)", ErrArtifactTooLarge, maxSize/mib)
+	}
+	return fmt.Errorf("%w (maximum size allowed: %d bytes)", ErrArtifactTooLarge, maxSize)
+}
+
 // ParseChangesAnnotation parses the provided changes annotation returning a
 // slice of changes entries. Changes entries are also validated an normalized.
 func ParseChangesAnnotation(annotation string) ([]*hub.Change, error) {
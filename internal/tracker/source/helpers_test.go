@@ -1,10 +1,16 @@
 package source
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -106,3 +112,56 @@ func TestParseChangesAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestPackagesConcurrency(t *testing.T) {
+	testCases := []struct {
+		value    interface{}
+		expected int
+	}{
+		{nil, DefaultPackagesConcurrency},
+		{0, 1},
+		{-1, 1},
+		{25, 25},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("%v", tc.value), func(t *testing.T) {
+			t.Parallel()
+			cfg := viper.New()
+			if tc.value != nil {
+				cfg.Set("tracker.packagesConcurrency", tc.value)
+			}
+			assert.Equal(t, tc.expected, PackagesConcurrency(cfg))
+		})
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	t.Run("content within the limit", func(t *testing.T) {
+		t.Parallel()
+		data, err := ReadAll(strings.NewReader("content"), 7)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("content"), data)
+	})
+
+	t.Run("content exceeds the limit", func(t *testing.T) {
+		t.Parallel()
+		data, err := ReadAll(strings.NewReader("content"), 6)
+		assert.True(t, errors.Is(err, ErrArtifactTooLarge))
+		assert.Equal(t, "artifact too large (maximum size allowed: 6 bytes)", err.Error())
+		assert.Nil(t, data)
+	})
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, ioutil.WriteFile(path, make([]byte, 1<<20+1), 0644))
+
+	_, err := ReadFile(path, 1<<20)
+	assert.True(t, errors.Is(err, ErrArtifactTooLarge))
+	assert.Equal(t, "artifact too large (maximum size allowed: 1MiB)", err.Error())
+
+	data, err := ReadFile(path, 2<<20)
+	require.NoError(t, err)
+	assert.Len(t, data, 1<<20+1)
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -30,6 +29,13 @@ const (
 
 	formatKey           = "format"
 	isGlobalOperatorKey = "isGlobalOperator"
+	replacesKey         = "replaces"
+	skipRangeKey        = "skipRange"
+	skipsKey            = "skips"
+
+	// skipRangeAnnotation represents the OLM annotation used to declare the
+	// range of versions that can be upgraded directly to a given version.
+	skipRangeAnnotation = "olm.skipRange"
 
 	// Artifact Hub special annotations
 	changesAnnotation         = "artifacthub.io/changes"
@@ -40,11 +46,20 @@ const (
 	recommendationsAnnotation = "artifacthub.io/recommendations"
 	securityUpdatesAnnotation = "artifacthub.io/containsSecurityUpdates"
 	screenshotsAnnotation     = "artifacthub.io/screenshots"
+
+	// maxManifestFileSize represents the maximum size of each of the
+	// manifests files of a package version (i.e. the csv or the crds).
+	maxManifestFileSize = 10 << 20
+
+	// maxCRDsDataSize represents the maximum size of all the manifests files
+	// other than the csv of a package version.
+	maxCRDsDataSize = 50 << 20
 )
 
 var (
 	// channelVersionRE is a regexp used to extract the version from the
-	// channel CurrentCSVName in the PackageManifest format.
+	// cluster service versions names (i.e. channels CurrentCSVName in the
+	// PackageManifest format).
 	channelVersionRE = regexp.MustCompile(`^[A-Za-z0-9_-]+\.v?(.*)$`)
 )
 
@@ -149,6 +164,7 @@ type Metadata struct {
 	CSV                *operatorsv1alpha1.ClusterServiceVersion
 	CSVData            []byte
 	CSVPath            string
+	CRDsData           [][]byte
 }
 
 // validate checks if the metadata provided is valid.
@@ -182,6 +198,10 @@ func GetMetadata(path string) (*Metadata, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error getting package %s csv (path: %s): %w", manifest.PackageName, path, err)
 		}
+		crdsData, err := getCRDsData(path)
+		if err != nil {
+			return nil, fmt.Errorf("error getting package %s crds (path: %s): %w", manifest.PackageName, path, err)
+		}
 		var channels []*hub.Channel
 		for _, channel := range manifest.Channels {
 			matches := channelVersionRE.FindStringSubmatch(channel.CurrentCSVName)
@@ -202,6 +222,7 @@ func GetMetadata(path string) (*Metadata, error) {
 			DefaultChannelName: manifest.DefaultChannelName,
 			CSV:                csv,
 			CSVData:            csvData,
+			CRDsData:           crdsData,
 		}
 	}
 
@@ -215,6 +236,10 @@ func GetMetadata(path string) (*Metadata, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error getting package %s csv (path: %s): %w", annotations.PackageName, path, err)
 		}
+		crdsData, err := getCRDsData(filepath.Join(path, "manifests"))
+		if err != nil {
+			return nil, fmt.Errorf("error getting package %s crds (path: %s): %w", annotations.PackageName, path, err)
+		}
 		var channels []*hub.Channel
 		for _, channelName := range strings.Split(annotations.Channels, ",") {
 			channels = append(channels, &hub.Channel{
@@ -230,6 +255,7 @@ func GetMetadata(path string) (*Metadata, error) {
 			DefaultChannelName: annotations.DefaultChannelName,
 			CSV:                csv,
 			CSVData:            csvData,
+			CRDsData:           crdsData,
 		}
 	}
 
@@ -255,7 +281,7 @@ func getManifest(path string) (*manifests.PackageManifest, error) {
 	manifestPath := matches[0]
 
 	// Read and parse manifest file
-	manifestData, err := ioutil.ReadFile(manifestPath)
+	manifestData, err := source.ReadFile(manifestPath, maxManifestFileSize)
 	if err != nil {
 		return nil, fmt.Errorf("error reading manifest file: %w", err)
 	}
@@ -276,7 +302,7 @@ func getBundleAnnotations(path string) (*manifests.Annotations, error) {
 	}
 
 	// Read and parse annotations file
-	annotationsData, err := ioutil.ReadFile(annotationsPath)
+	annotationsData, err := source.ReadFile(annotationsPath, maxManifestFileSize)
 	if err != nil {
 		return nil, fmt.Errorf("error reading annotations file: %w", err)
 	}
@@ -302,7 +328,7 @@ func getCSV(path string) (*operatorsv1alpha1.ClusterServiceVersion, []byte, erro
 	csvPath := matches[0]
 
 	// Read and parse cluster service version file
-	csvData, err := ioutil.ReadFile(csvPath)
+	csvData, err := source.ReadFile(csvPath, maxManifestFileSize)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error reading csv file: %w", err)
 	}
@@ -314,6 +340,34 @@ func getCSV(path string) (*operatorsv1alpha1.ClusterServiceVersion, []byte, erro
 	return csv, csvData, nil
 }
 
+// getCRDsData reads the manifests files other than the cluster service version
+// located in the path provided, as they may contain the operator's CRDs.
+func getCRDsData(path string) ([][]byte, error) {
+	var crdsData [][]byte
+	var size int
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(path, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("error locating manifests files: %w", err)
+		}
+		for _, manifestPath := range matches {
+			if strings.HasSuffix(manifestPath, ".clusterserviceversion.yaml") {
+				continue
+			}
+			data, err := source.ReadFile(manifestPath, maxManifestFileSize)
+			if err != nil {
+				return nil, fmt.Errorf("error reading manifest file: %w", err)
+			}
+			size += len(data)
+			if size > maxCRDsDataSize {
+				return nil, fmt.Errorf("manifests files: %w", source.NewArtifactTooLargeError(maxCRDsDataSize))
+			}
+			crdsData = append(crdsData, data)
+		}
+	}
+	return crdsData, nil
+}
+
 // getContainersImages returns all containers images declared in the csv data
 // provided.
 func getContainersImages(
@@ -389,6 +443,14 @@ func PreparePackage(r *hub.Repository, md *Metadata) (*hub.Package, error) {
 	}
 	p.ContainersImages = containersImages
 
+	// Kubernetes compatibility
+	if md.CSV.Spec.MinKubeVersion != "" {
+		constraint := ">=" + md.CSV.Spec.MinKubeVersion
+		if k8sCompatibility, err := pkg.GetK8sCompatibility(constraint, nil); err == nil {
+			p.K8sCompatibility = k8sCompatibility
+		}
+	}
+
 	// TS
 	ts, err := time.Parse(time.RFC3339, md.CSV.Annotations["createdAt"])
 	if err == nil {
@@ -448,6 +510,7 @@ func PreparePackage(r *hub.Repository, md *Metadata) (*hub.Package, error) {
 	if len(crds) > 0 {
 		p.CRDs = crds
 	}
+	p.CRDsSchemas = source.ExtractCRDsSchemas(md.CRDsData)
 	var crdsExamples []interface{}
 	if err := json.Unmarshal([]byte(md.CSV.Annotations["alm-examples"]), &crdsExamples); err == nil {
 		p.CRDsExamples = crdsExamples
@@ -510,9 +573,35 @@ func PreparePackage(r *hub.Repository, md *Metadata) (*hub.Package, error) {
 		isGlobalOperatorKey: isGlobalOperator,
 	}
 
+	// Upgrade edges
+	if md.CSV.Spec.Replaces != "" {
+		p.Data[replacesKey] = getVersionFromCSVName(md.CSV.Spec.Replaces)
+	}
+	if len(md.CSV.Spec.Skips) > 0 {
+		skips := make([]string, 0, len(md.CSV.Spec.Skips))
+		for _, csvName := range md.CSV.Spec.Skips {
+			skips = append(skips, getVersionFromCSVName(csvName))
+		}
+		p.Data[skipsKey] = skips
+	}
+	if skipRange := md.CSV.Annotations[skipRangeAnnotation]; skipRange != "" {
+		p.Data[skipRangeKey] = skipRange
+	}
+
 	return p, nil
 }
 
+// getVersionFromCSVName returns the version included in the cluster service
+// version name provided (i.e. my-operator.v1.0.0). When the version cannot be
+// extracted, the name is returned as is.
+func getVersionFromCSVName(csvName string) string {
+	matches := channelVersionRE.FindStringSubmatch(csvName)
+	if len(matches) != 2 {
+		return csvName
+	}
+	return matches[1]
+}
+
 // setPackagesChannels prepares and updates the channels in the packages which
 // use the bundle format when needed.
 //
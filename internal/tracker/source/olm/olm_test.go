@@ -1,6 +1,7 @@
 package olm
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"testing"
 
@@ -87,6 +88,17 @@ func TestTrackerSource(t *testing.T) {
 				"kind":       "Test",
 			},
 		},
+		CRDsSchemas: []*hub.CRDSchema{
+			{
+				Name:  "test.crds.com",
+				Group: "crds.com",
+				Kind:  "Test",
+				Scope: "Namespaced",
+				Versions: []*hub.CRDSchemaVersion{
+					{Name: "v1", Served: true, Storage: true, Schema: json.RawMessage(`{"type":"object"}`)},
+				},
+			},
+		},
 		Recommendations: []*hub.Recommendation{
 			{
 				URL: "https://artifacthub.io/packages/helm/artifact-hub/artifact-hub",
@@ -245,6 +257,13 @@ func TestTrackerSource(t *testing.T) {
 		}
 		p2 := source.ClonePackage(p1)
 		p2.Version = "0.2.0"
+		p2.Data = map[string]interface{}{
+			formatKey:           "bundle",
+			isGlobalOperatorKey: true,
+			replacesKey:         "0.1.0",
+			skipsKey:            []string{"0.1.1"},
+			skipRangeKey:        ">=0.0.1 <0.2.0",
+		}
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
 		assert.Equal(t, map[string]*hub.Package{
 			pkg.BuildKey(p1): p1,
@@ -0,0 +1,36 @@
+package plugin
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName represents the name of the codec used to encode the messages
+// exchanged with the plugins. Messages are encoded using JSON so that plugins
+// can be written in any language with gRPC support without having to
+// generate code from a protobuf definition.
+const codecName = "json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec is a grpc encoding.Codec implementation that encodes messages
+// using JSON.
+type jsonCodec struct{}
+
+// Marshal implements the encoding.Codec interface.
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements the encoding.Codec interface.
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Name implements the encoding.Codec interface.
+func (jsonCodec) Name() string {
+	return codecName
+}
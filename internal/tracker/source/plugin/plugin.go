@@ -0,0 +1,142 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"google.golang.org/grpc"
+)
+
+const (
+	// ServiceName represents the name of the gRPC service that tracker source
+	// plugins must implement.
+	ServiceName = "artifacthub.tracker.v1.TrackerSource"
+
+	// getPackagesAvailableMethod represents the full name of the gRPC method
+	// used to get the packages available in a repository from a plugin.
+	getPackagesAvailableMethod = "/" + ServiceName + "/GetPackagesAvailable"
+)
+
+// GetPackagesAvailableRequest represents the request sent to a plugin to get
+// the packages available in a repository.
+type GetPackagesAvailableRequest struct {
+	Repository         *hub.Repository         `json:"repository"`
+	PackagesRegistered map[string]string       `json:"packages_registered"`
+	BasePath           string                  `json:"base_path"`
+	Metadata           *hub.RepositoryMetadata `json:"metadata"`
+}
+
+// GetPackagesAvailableResponse represents the response returned by a plugin
+// with the packages available in a repository. Errors that did not prevent
+// the plugin from processing the repository (i.e. a package version that
+// could not be prepared) can be reported in the errors field, so that they
+// are shown to the repository owner.
+type GetPackagesAvailableResponse struct {
+	Packages []*hub.Package `json:"packages"`
+	Errors   []string       `json:"errors"`
+}
+
+// Server defines the methods a tracker source plugin server must provide.
+type Server interface {
+	GetPackagesAvailable(ctx context.Context, req *GetPackagesAvailableRequest) (*GetPackagesAvailableResponse, error)
+}
+
+// RegisterServer registers the tracker source plugin server implementation
+// provided in the gRPC server.
+func RegisterServer(s *grpc.Server, srv Server) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+// serviceDesc describes the tracker source plugins gRPC service.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*Server)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPackagesAvailable",
+			Handler:    getPackagesAvailableHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// getPackagesAvailableHandler handles the GetPackagesAvailable gRPC method
+// requests, delegating them to the plugin server implementation.
+func getPackagesAvailableHandler(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	req := &GetPackagesAvailableRequest{}
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Server).GetPackagesAvailable(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: getPackagesAvailableMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Server).GetPackagesAvailable(ctx, req.(*GetPackagesAvailableRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+// TrackerSource is a hub.TrackerSource implementation that delegates getting
+// the packages available in a repository to an external plugin.
+type TrackerSource struct {
+	i    *hub.TrackerSourceInput
+	conn grpc.ClientConnInterface
+}
+
+// NewTrackerSource creates a new TrackerSource instance that will use the
+// gRPC connection provided to talk to the plugin.
+func NewTrackerSource(i *hub.TrackerSourceInput, conn grpc.ClientConnInterface) *TrackerSource {
+	return &TrackerSource{
+		i:    i,
+		conn: conn,
+	}
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	req := &GetPackagesAvailableRequest{
+		Repository:         s.i.Repository,
+		PackagesRegistered: s.i.PackagesRegistered,
+		BasePath:           s.i.BasePath,
+		Metadata:           s.i.Metadata,
+	}
+	resp := &GetPackagesAvailableResponse{}
+	err := s.conn.Invoke(s.i.Svc.Ctx, getPackagesAvailableMethod, req, resp, grpc.CallContentSubtype(codecName))
+	if err != nil {
+		return nil, fmt.Errorf("error getting packages available from plugin: %w", err)
+	}
+
+	packagesAvailable := make(map[string]*hub.Package)
+	for _, e := range resp.Errors {
+		s.warn(errors.New(e))
+	}
+	for _, p := range resp.Packages {
+		if p == nil || p.Name == "" || p.Version == "" {
+			s.warn(errors.New("invalid package returned by plugin: name and version must be provided"))
+			continue
+		}
+		p.Repository = s.i.Repository
+		packagesAvailable[pkg.BuildKey(p)] = p
+	}
+
+	return packagesAvailable, nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(err error) {
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+}
@@ -0,0 +1,138 @@
+package plugin
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestTrackerSource(t *testing.T) {
+	t.Run("error getting packages available from plugin", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{},
+			Svc:        sw.Svc,
+		}
+		srv := &serverMock{err: tests.ErrFake}
+		conn := setupPlugin(t, srv)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, conn).GetPackagesAvailable()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), tests.ErrFake.Error())
+		assert.Nil(t, packages)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("packages returned, errors reported by plugin collected", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				RepositoryID: "repo1",
+				Name:         "repo1",
+				Kind:         hub.RepositoryKind(100),
+			},
+			PackagesRegistered: map[string]string{"pkg1@0.9.0": "digest"},
+			BasePath:           "/tmp/repo1",
+			Svc:                sw.Svc,
+		}
+		srv := &serverMock{
+			resp: &GetPackagesAvailableResponse{
+				Packages: []*hub.Package{
+					{
+						Name:    "pkg1",
+						Version: "1.0.0",
+						Data: map[string]interface{}{
+							"key": "value",
+						},
+					},
+					{
+						Name: "pkg2",
+					},
+				},
+				Errors: []string{"error preparing package pkg3 version 1.0.0"},
+			},
+		}
+		conn := setupPlugin(t, srv)
+		sw.Ec.On("Append", "repo1", "error preparing package pkg3 version 1.0.0").Return()
+		sw.Ec.On("Append", "repo1", "invalid package returned by plugin: name and version must be provided").Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, conn).GetPackagesAvailable()
+		p := &hub.Package{
+			Name:    "pkg1",
+			Version: "1.0.0",
+			Data: map[string]interface{}{
+				"key": "value",
+			},
+			Repository: i.Repository,
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.Equal(t, &GetPackagesAvailableRequest{
+			Repository:         i.Repository,
+			PackagesRegistered: i.PackagesRegistered,
+			BasePath:           i.BasePath,
+		}, srv.req)
+		sw.AssertExpectations(t)
+	})
+}
+
+// setupPlugin starts a gRPC server for the plugin server provided, returning
+// a client connection to it.
+func setupPlugin(t *testing.T, srv Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	RegisterServer(s, srv)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// serverMock is a plugin Server implementation used in tests.
+type serverMock struct {
+	req  *GetPackagesAvailableRequest
+	resp *GetPackagesAvailableResponse
+	err  error
+}
+
+// GetPackagesAvailable implements the Server interface.
+func (s *serverMock) GetPackagesAvailable(
+	ctx context.Context,
+	req *GetPackagesAvailableRequest,
+) (*GetPackagesAvailableResponse, error) {
+	s.req = req
+	return s.resp, s.err
+}
@@ -265,28 +265,13 @@ func PreparePackage(
 	}
 
 	// Prepare content and source urls whenever possible
-	var repoBaseURL, host, pkgsPath string
-	matches := repo.GitRepoURLRE.FindStringSubmatch(r.URL)
-	if len(matches) >= 3 {
-		repoBaseURL = matches[1]
-		host = matches[2]
-	}
-	if len(matches) == 4 {
-		pkgsPath = strings.TrimSuffix(matches[3], "/")
-	}
 	var contentURL, sourceURL string
-	branch := repo.GetBranch(r)
-	pkgVersionPath := strings.TrimPrefix(pkgPath, basePath)
-	switch host {
-	case "bitbucket.org":
-		contentURL = fmt.Sprintf("%s/raw/%s/%s%s/%s.yaml", repoBaseURL, branch, pkgsPath, pkgVersionPath, name)
-		sourceURL = fmt.Sprintf("%s/src/%s/%s%s/%s.yaml", repoBaseURL, branch, pkgsPath, pkgVersionPath, name)
-	case "github.com":
-		contentURL = fmt.Sprintf("%s/raw/%s/%s%s/%s.yaml", repoBaseURL, branch, pkgsPath, pkgVersionPath, name)
-		sourceURL = fmt.Sprintf("%s/blob/%s/%s%s/%s.yaml", repoBaseURL, branch, pkgsPath, pkgVersionPath, name)
-	case "gitlab.com":
-		contentURL = fmt.Sprintf("%s/-/raw/%s/%s%s/%s.yaml", repoBaseURL, branch, pkgsPath, pkgVersionPath, name)
-		sourceURL = fmt.Sprintf("%s/-/blob/%s/%s%s/%s.yaml", repoBaseURL, branch, pkgsPath, pkgVersionPath, name)
+	if u, err := repo.ParseGitRepoURL(r.URL); err == nil {
+		branch := repo.GetBranch(r)
+		pkgVersionPath := strings.TrimPrefix(pkgPath, basePath)
+		manifestPath := fmt.Sprintf("%s%s/%s.yaml", u.PackagesPath, pkgVersionPath, name)
+		contentURL = u.RawContentURL(branch, manifestPath)
+		sourceURL = u.BlobURL(branch, manifestPath)
 	}
 	if contentURL != "" {
 		p.ContentURL = contentURL
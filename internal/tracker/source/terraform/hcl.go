@@ -0,0 +1,399 @@
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// errUnexpectedEOF indicates that the end of the configuration was reached
+// while a construct was still open.
+var errUnexpectedEOF = errors.New("unexpected end of file")
+
+// block represents a block found in a Terraform configuration file. Only the
+// structure of the configuration is extracted: attributes values are kept as
+// raw expressions, as evaluating them is not needed to document a module.
+type block struct {
+	Type   string
+	Labels []string
+	Attrs  map[string]string
+	Blocks []*block
+}
+
+// parseConfig parses the Terraform configuration (HCL native syntax) provided,
+// returning the top level blocks found.
+func parseConfig(src []byte) ([]*block, error) {
+	p := &parser{src: []rune(string(src))}
+	b := &block{Attrs: make(map[string]string)}
+	if err := p.parseBody(b, false); err != nil {
+		return nil, fmt.Errorf("%w (line %d)", err, p.line())
+	}
+	return b.Blocks, nil
+}
+
+// parser is a minimal parser of the HCL native syntax.
+type parser struct {
+	src []rune
+	pos int
+}
+
+// parseBody parses the attributes and blocks of a body, storing them in the
+// block provided. When the body belongs to a nested block, parsing stops when
+// the closing brace is found.
+func (p *parser) parseBody(b *block, nested bool) error {
+	for {
+		p.skipSpaceAndComments(true)
+		if p.eof() {
+			if nested {
+				return errUnexpectedEOF
+			}
+			return nil
+		}
+		if p.peek() == '}' {
+			if !nested {
+				return errors.New("unexpected closing brace")
+			}
+			p.pos++
+			return nil
+		}
+
+		// Read attribute or block identifier
+		name := p.readIdentifier()
+		if name == "" {
+			return fmt.Errorf("unexpected character %q", p.peek())
+		}
+		p.skipSpaceAndComments(false)
+		if p.eof() {
+			return errUnexpectedEOF
+		}
+
+		// Attribute
+		if p.peek() == '=' && p.peekAt(1) != '=' {
+			p.pos++
+			value, err := p.readExpression()
+			if err != nil {
+				return err
+			}
+			b.Attrs[name] = value
+			continue
+		}
+
+		// Block
+		child := &block{Type: name, Attrs: make(map[string]string)}
+		for {
+			p.skipSpaceAndComments(false)
+			if p.eof() {
+				return errUnexpectedEOF
+			}
+			c := p.peek()
+			if c == '{' {
+				p.pos++
+				break
+			}
+			var label string
+			if c == '"' {
+				start := p.pos
+				if err := p.skipString(); err != nil {
+					return err
+				}
+				label = unquote(string(p.src[start:p.pos]))
+			} else {
+				label = p.readIdentifier()
+				if label == "" {
+					return fmt.Errorf("unexpected character %q", c)
+				}
+			}
+			child.Labels = append(child.Labels, label)
+		}
+		if err := p.parseBody(child, true); err != nil {
+			return err
+		}
+		b.Blocks = append(b.Blocks, child)
+	}
+}
+
+// readExpression reads the raw expression of an attribute, which ends at the
+// first new line found outside brackets, strings or heredocs. Comments are
+// not included in the expression returned.
+func (p *parser) readExpression() (string, error) {
+	var expr strings.Builder
+	depth := 0
+	p.skipSpaceAndComments(false)
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == '\n' && depth == 0:
+			return strings.TrimSpace(expr.String()), nil
+		case c == '#' || (c == '/' && p.peekAt(1) == '/'):
+			p.skipLineComment()
+			trimmed := strings.TrimRight(expr.String(), " \t")
+			expr.Reset()
+			expr.WriteString(trimmed)
+			continue
+		case c == '/' && p.peekAt(1) == '*':
+			if err := p.skipBlockComment(); err != nil {
+				return "", err
+			}
+			expr.WriteRune(' ')
+			continue
+		case c == '"':
+			start := p.pos
+			if err := p.skipString(); err != nil {
+				return "", err
+			}
+			expr.WriteString(string(p.src[start:p.pos]))
+			continue
+		case c == '<' && p.peekAt(1) == '<':
+			start := p.pos
+			ok, err := p.skipHeredoc()
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				expr.WriteString(string(p.src[start:p.pos]))
+				continue
+			}
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			if depth == 0 {
+				// Closing brace of the enclosing block in the same line
+				return strings.TrimSpace(expr.String()), nil
+			}
+			depth--
+		}
+		expr.WriteRune(c)
+		p.pos++
+	}
+	if depth > 0 {
+		return "", errUnexpectedEOF
+	}
+	return strings.TrimSpace(expr.String()), nil
+}
+
+// skipString skips the quoted string starting at the current position,
+// including any template interpolations or directives it may contain.
+func (p *parser) skipString() error {
+	p.pos++ // Opening quote
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == '\\':
+			p.pos += 2
+			continue
+		case c == '"':
+			p.pos++
+			return nil
+		case c == '\n':
+			return errors.New("unterminated string")
+		case (c == '$' || c == '%') && p.peekAt(1) == '{':
+			if p.peekAt(-1) == c {
+				// Escaped sequence ($${ or %%{)
+				break
+			}
+			p.pos += 2
+			if err := p.skipTemplate(); err != nil {
+				return err
+			}
+			continue
+		}
+		p.pos++
+	}
+	return errUnexpectedEOF
+}
+
+// skipTemplate skips a template interpolation or directive, positioning the
+// parser right after its closing brace.
+func (p *parser) skipTemplate() error {
+	depth := 1
+	for !p.eof() {
+		switch p.peek() {
+		case '"':
+			if err := p.skipString(); err != nil {
+				return err
+			}
+			continue
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		}
+		p.pos++
+	}
+	return errUnexpectedEOF
+}
+
+// skipHeredoc skips the heredoc starting at the current position. It returns
+// false when the characters found do not start a heredoc.
+func (p *parser) skipHeredoc() (bool, error) {
+	i := p.pos + 2
+	if i < len(p.src) && p.src[i] == '-' {
+		i++
+	}
+	start := i
+	for i < len(p.src) && isIdentifierRune(p.src[i]) {
+		i++
+	}
+	if i == start || i >= len(p.src) || (p.src[i] != '\n' && p.src[i] != '\r') {
+		return false, nil
+	}
+	marker := string(p.src[start:i])
+	for i < len(p.src) {
+		end := i + 1
+		for end < len(p.src) && p.src[end] != '\n' {
+			end++
+		}
+		if i+1 <= len(p.src) && strings.TrimSpace(string(p.src[i+1:end])) == marker {
+			p.pos = end
+			return true, nil
+		}
+		i = end
+	}
+	return false, errUnexpectedEOF
+}
+
+// skipSpaceAndComments skips whitespace and comments. New lines are only
+// skipped when requested.
+func (p *parser) skipSpaceAndComments(newLines bool) {
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == '\n' && !newLines:
+			return
+		case unicode.IsSpace(c):
+			p.pos++
+		case c == '#' || (c == '/' && p.peekAt(1) == '/'):
+			p.skipLineComment()
+		case c == '/' && p.peekAt(1) == '*':
+			if err := p.skipBlockComment(); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// skipLineComment skips a line comment, leaving the parser at the new line
+// that ends it.
+func (p *parser) skipLineComment() {
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+// skipBlockComment skips a block comment.
+func (p *parser) skipBlockComment() error {
+	p.pos += 2
+	for !p.eof() {
+		if p.peek() == '*' && p.peekAt(1) == '/' {
+			p.pos += 2
+			return nil
+		}
+		p.pos++
+	}
+	return errUnexpectedEOF
+}
+
+// readIdentifier reads the identifier at the current position.
+func (p *parser) readIdentifier() string {
+	start := p.pos
+	for !p.eof() && isIdentifierRune(p.peek()) {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+// eof checks if the end of the source has been reached.
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+// peek returns the rune at the current position.
+func (p *parser) peek() rune {
+	return p.src[p.pos]
+}
+
+// peekAt returns the rune at the offset provided from the current position,
+// or zero if it's out of range.
+func (p *parser) peekAt(offset int) rune {
+	i := p.pos + offset
+	if i < 0 || i >= len(p.src) {
+		return 0
+	}
+	return p.src[i]
+}
+
+// line returns the line number of the current position.
+func (p *parser) line() int {
+	end := p.pos
+	if end > len(p.src) {
+		end = len(p.src)
+	}
+	return strings.Count(string(p.src[:end]), "\n") + 1
+}
+
+// isIdentifierRune checks if the rune provided can be part of an identifier.
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
+
+// stringValue returns the value of the raw expression provided when it's a
+// string literal or a heredoc. Templates are returned as they are.
+func stringValue(expr string) (string, bool) {
+	switch {
+	case strings.HasPrefix(expr, `"`) && strings.HasSuffix(expr, `"`) && len(expr) >= 2:
+		return unquote(expr), true
+	case strings.HasPrefix(expr, "<<"):
+		return heredocValue(expr), true
+	default:
+		return "", false
+	}
+}
+
+// unquote returns the content of the quoted string provided, processing the
+// escape sequences it may contain.
+func unquote(s string) string {
+	v, err := strconv.Unquote(s)
+	if err != nil {
+		v = strings.TrimSuffix(strings.TrimPrefix(s, `"`), `"`)
+	}
+	v = strings.ReplaceAll(v, "$${", "${")
+	v = strings.ReplaceAll(v, "%%{", "%{")
+	return v
+}
+
+// heredocValue returns the content of the heredoc provided. Indentation is
+// removed from indented heredocs (<<-).
+func heredocValue(expr string) string {
+	lines := strings.Split(expr, "\n")
+	if len(lines) < 2 {
+		return ""
+	}
+	indented := strings.HasPrefix(lines[0], "<<-")
+	lines = lines[1 : len(lines)-1]
+	if indented {
+		minIndent := -1
+		for _, l := range lines {
+			if strings.TrimSpace(l) == "" {
+				continue
+			}
+			indent := len(l) - len(strings.TrimLeft(l, " \t"))
+			if minIndent == -1 || indent < minIndent {
+				minIndent = indent
+			}
+		}
+		for i, l := range lines {
+			if len(l) >= minIndent && minIndent > 0 {
+				lines[i] = l[minIndent:]
+			}
+		}
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
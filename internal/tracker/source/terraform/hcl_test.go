@@ -0,0 +1,112 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Run("valid configuration", func(t *testing.T) {
+		t.Parallel()
+
+		src := []byte(`
+# Comment
+variable "name" {
+  description = "Name // not a comment"
+  type        = list(object({
+    key   = string # Key
+    value = string
+  }))
+  default = ["a", "b"] /* Block comment */
+}
+
+output "out" { value = "${var.name}-${join("}", ["x"])}" }
+
+locals {
+  text = <<EOT
+Some text with "quotes" and { braces
+EOT
+  empty = {}
+}
+`)
+		blocks, err := parseConfig(src)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+
+		assert.Equal(t, "variable", blocks[0].Type)
+		assert.Equal(t, []string{"name"}, blocks[0].Labels)
+		assert.Equal(t, `"Name // not a comment"`, blocks[0].Attrs["description"])
+		assert.Equal(t, "list(object({\n    key   = string\n    value = string\n  }))", blocks[0].Attrs["type"])
+		assert.Equal(t, `["a", "b"]`, blocks[0].Attrs["default"])
+
+		assert.Equal(t, "output", blocks[1].Type)
+		assert.Equal(t, []string{"out"}, blocks[1].Labels)
+		assert.Equal(t, `"${var.name}-${join("}", ["x"])}"`, blocks[1].Attrs["value"])
+
+		assert.Equal(t, "locals", blocks[2].Type)
+		assert.Empty(t, blocks[2].Labels)
+		v, ok := stringValue(blocks[2].Attrs["text"])
+		assert.True(t, ok)
+		assert.Equal(t, `Some text with "quotes" and { braces`, v)
+		assert.Equal(t, "{}", blocks[2].Attrs["empty"])
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			src         string
+			expectedErr string
+		}{
+			{
+				`variable "name" {`,
+				"unexpected end of file (line 1)",
+			},
+			{
+				"variable \"name\" {\n  default = [1, 2\n}\n",
+				"unexpected end of file (line 4)",
+			},
+			{
+				"}\n",
+				"unexpected closing brace (line 1)",
+			},
+			{
+				"variable \"name\" {\n  description = \"unterminated\n}\n",
+				"unterminated string (line 2)",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.src, func(t *testing.T) {
+				t.Parallel()
+				_, err := parseConfig([]byte(tc.src))
+				assert.EqualError(t, err, tc.expectedErr)
+			})
+		}
+	})
+}
+
+func TestStringValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expr          string
+		expectedValue string
+		expectedOK    bool
+	}{
+		{`"text"`, "text", true},
+		{`"line1\nline2"`, "line1\nline2", true},
+		{`"$${literal}"`, "${literal}", true},
+		{"<<EOT\n  line1\n  line2\nEOT", "  line1\n  line2", true},
+		{"<<-EOT\n    line1\n      line2\n  EOT", "line1\n  line2", true},
+		{"var.name", "", false},
+		{"true", "", false},
+	}
+	for _, tc := range testCases {
+		v, ok := stringValue(tc.expr)
+		assert.Equal(t, tc.expectedValue, v)
+		assert.Equal(t, tc.expectedOK, ok)
+	}
+}
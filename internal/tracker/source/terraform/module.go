@@ -0,0 +1,183 @@
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// OutputsKey represents the key used in the package's data field that
+	// contains the module's outputs.
+	OutputsKey = "outputs"
+
+	// ProvidersKey represents the key used in the package's data field that
+	// contains the providers required by the module.
+	ProvidersKey = "providers"
+
+	// RequiredVersionKey represents the key used in the package's data field
+	// that contains the Terraform versions constraint of the module.
+	RequiredVersionKey = "requiredVersion"
+
+	// VariablesKey represents the key used in the package's data field that
+	// contains the module's input variables.
+	VariablesKey = "variables"
+)
+
+var (
+	// errNoConfigFiles indicates that no Terraform configuration files were
+	// found in the module path.
+	errNoConfigFiles = errors.New("no terraform configuration files found")
+
+	// providerAttrRE is a regexp used to extract the source and version
+	// attributes from a required provider object expression.
+	providerAttrRE = regexp.MustCompile(`(?m)\b(source|version)\s*=\s*("(?:[^"\\]|\\.)*")`)
+)
+
+// Module represents the information extracted from a Terraform module
+// configuration.
+type Module struct {
+	Variables       []*Variable
+	Outputs         []*Output
+	Providers       []*Provider
+	RequiredVersion string
+}
+
+// Variable represents an input variable of a Terraform module.
+type Variable struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Required    bool   `json:"required"`
+	Sensitive   bool   `json:"sensitive"`
+}
+
+// Output represents an output value of a Terraform module.
+type Output struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Sensitive   bool   `json:"sensitive"`
+}
+
+// Provider represents a provider required by a Terraform module.
+type Provider struct {
+	Name    string `json:"name"`
+	Source  string `json:"source,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// LoadModule loads the Terraform (or OpenTofu) module located in the path
+// provided, extracting its variables, outputs and requirements from the
+// configuration files in the module's root directory.
+func LoadModule(path string) (*Module, error) {
+	var files []string
+	for _, pattern := range []string{"*.tf", "*.tofu"} {
+		matches, err := filepath.Glob(filepath.Join(path, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, errNoConfigFiles
+	}
+	sort.Strings(files)
+
+	m := &Module{}
+	providers := make(map[string]*Provider)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading file %s: %w", filepath.Base(file), err)
+		}
+		blocks, err := parseConfig(data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing file %s: %w", filepath.Base(file), err)
+		}
+		for _, b := range blocks {
+			switch b.Type {
+			case "variable":
+				if len(b.Labels) != 1 {
+					continue
+				}
+				m.Variables = append(m.Variables, newVariable(b))
+			case "output":
+				if len(b.Labels) != 1 {
+					continue
+				}
+				description, _ := stringValue(b.Attrs["description"])
+				m.Outputs = append(m.Outputs, &Output{
+					Name:        b.Labels[0],
+					Description: description,
+					Sensitive:   b.Attrs["sensitive"] == "true",
+				})
+			case "terraform":
+				if v, ok := stringValue(b.Attrs["required_version"]); ok {
+					m.RequiredVersion = v
+				}
+				for _, child := range b.Blocks {
+					if child.Type != "required_providers" {
+						continue
+					}
+					for name, expr := range child.Attrs {
+						providers[name] = newProvider(name, expr)
+					}
+				}
+			}
+		}
+	}
+	for _, p := range providers {
+		m.Providers = append(m.Providers, p)
+	}
+
+	// Sort results so that they are consistent between runs
+	sort.Slice(m.Variables, func(i, j int) bool { return m.Variables[i].Name < m.Variables[j].Name })
+	sort.Slice(m.Outputs, func(i, j int) bool { return m.Outputs[i].Name < m.Outputs[j].Name })
+	sort.Slice(m.Providers, func(i, j int) bool { return m.Providers[i].Name < m.Providers[j].Name })
+
+	return m, nil
+}
+
+// newVariable creates a new Variable instance from the variable block
+// provided. Variables without a default value are required.
+func newVariable(b *block) *Variable {
+	v := &Variable{
+		Name:      b.Labels[0],
+		Type:      b.Attrs["type"],
+		Sensitive: b.Attrs["sensitive"] == "true",
+	}
+	v.Description, _ = stringValue(b.Attrs["description"])
+	if def, ok := b.Attrs["default"]; ok {
+		v.Default = def
+	} else {
+		v.Required = true
+	}
+	return v
+}
+
+// newProvider creates a new Provider instance from the required provider
+// expression provided. Both the legacy version string format and the object
+// format including the source address are supported.
+func newProvider(name, expr string) *Provider {
+	p := &Provider{Name: name}
+	if v, ok := stringValue(expr); ok {
+		p.Version = v
+		return p
+	}
+	if strings.HasPrefix(expr, "{") {
+		for _, m := range providerAttrRE.FindAllStringSubmatch(expr, -1) {
+			switch m[1] {
+			case "source":
+				p.Source = unquote(m[2])
+			case "version":
+				p.Version = unquote(m[2])
+			}
+		}
+	}
+	return p
+}
@@ -0,0 +1,398 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Number of tags processed concurrently
+	concurrency = 5
+)
+
+// gitRepository describes the methods used by the TrackerSource to interact
+// with the git repository where the module is hosted.
+type gitRepository interface {
+	Tags(ctx context.Context, r *hub.Repository) (map[string]string, error)
+	Export(ctx context.Context, r *hub.Repository, tag, dst string) (time.Time, error)
+}
+
+// TrackerSource is a hub.TrackerSource implementation for Terraform (and
+// OpenTofu) modules repositories. Each semver tag in the git repository is
+// considered a version of the module.
+type TrackerSource struct {
+	i  *hub.TrackerSourceInput
+	gr gitRepository
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
+	s := &TrackerSource{i: i}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.gr == nil {
+		s.gr = &remoteGitRepository{}
+	}
+	return s
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	var mu sync.Mutex
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Get versions available from the repository tags
+	tags, err := s.gr.Tags(s.i.Svc.Ctx, s.i.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("error getting repository tags: %w", err)
+	}
+	name, packagesPath := moduleName(s.i.Repository)
+	tagsToProcess := make(map[string]string)
+	for tag, hash := range tags {
+		sv, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+		p := &hub.Package{
+			Name:    name,
+			Version: sv.String(),
+		}
+		key := pkg.BuildKey(p)
+		if digest, ok := s.i.PackagesRegistered[key]; ok && digest == hash {
+			p.Digest = hub.HasNotChanged
+			packagesAvailable[key] = p
+		} else {
+			tagsToProcess[tag] = hash
+		}
+	}
+
+	// Iterate over tags to process and prepare a package version for each
+	limiter := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for tag, hash := range tagsToProcess {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			wg.Wait()
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		// Prepare and store package version
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func(tag, hash string) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			p, err := s.preparePackageFromTag(name, packagesPath, tag, hash)
+			if err != nil {
+				s.warn(fmt.Errorf("error preparing package (tag: %s): %w", tag, err))
+				return
+			}
+			mu.Lock()
+			packagesAvailable[pkg.BuildKey(p)] = p
+			mu.Unlock()
+		}(tag, hash)
+	}
+	wg.Wait()
+
+	return packagesAvailable, nil
+}
+
+// preparePackageFromTag exports the content of the repository at the tag
+// provided and prepares a package version from the module found in it.
+func (s *TrackerSource) preparePackageFromTag(name, packagesPath, tag, hash string) (*hub.Package, error) {
+	tmpDir, err := ioutil.TempDir("", "artifact-hub")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	ts, err := s.gr.Export(s.i.Svc.Ctx, s.i.Repository, tag, tmpDir)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting tag: %w", err)
+	}
+	return PreparePackage(&PreparePackageInput{
+		R:       s.i.Repository,
+		Name:    name,
+		Tag:     tag,
+		Digest:  hash,
+		TS:      ts,
+		PkgPath: filepath.Join(tmpDir, packagesPath),
+	})
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(err error) {
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+}
+
+// PreparePackageInput represents the information required to prepare a
+// package version of a Terraform module.
+type PreparePackageInput struct {
+	R       *hub.Repository
+	Name    string
+	Tag     string
+	Digest  string
+	TS      time.Time
+	PkgPath string
+}
+
+// PreparePackage prepares a package version from the Terraform module located
+// in the path provided. Publishers can optionally provide an Artifact Hub
+// package metadata file in the module's directory to enrich the information
+// extracted from the module (name and version are always taken from the
+// repository).
+func PreparePackage(i *PreparePackageInput) (*hub.Package, error) {
+	// Load module
+	m, err := LoadModule(i.PkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading module: %w", err)
+	}
+
+	// Read readme file
+	var readme string
+	if data, err := ioutil.ReadFile(filepath.Join(i.PkgPath, "README.md")); err == nil {
+		readme = string(data)
+	}
+
+	// Prepare package metadata
+	md, err := getMetadata(filepath.Join(i.PkgPath, hub.PackageMetadataFile))
+	if err != nil {
+		return nil, err
+	}
+	md.Name = i.Name
+	md.Version = i.Tag
+	if md.DisplayName == "" {
+		md.DisplayName = i.Name
+	}
+	if md.CreatedAt == "" {
+		md.CreatedAt = i.TS.UTC().Format(time.RFC3339)
+	}
+	if md.Description == "" {
+		md.Description = readmeDescription(readme)
+	}
+	if md.Description == "" {
+		md.Description = fmt.Sprintf("Terraform module %s", i.Name)
+	}
+	if md.Readme == "" {
+		md.Readme = readme
+	}
+	if md.HomeURL == "" {
+		md.HomeURL = strings.TrimSuffix(repoBaseURL(i.R), ".git")
+	}
+	if md.Install == "" {
+		md.Install = installInstructions(i.R, i.Name, i.Tag)
+	}
+	if err := pkg.ValidatePackageMetadata(md); err != nil {
+		return nil, fmt.Errorf("error validating package metadata: %w", err)
+	}
+
+	// Prepare package from metadata
+	p, err := pkg.PreparePackageFromMetadata(md)
+	if err != nil {
+		return nil, fmt.Errorf("error preparing package from metadata: %w", err)
+	}
+	p.Repository = i.R
+	p.Digest = i.Digest
+	if sv, err := semver.NewVersion(i.Tag); err == nil && sv.Prerelease() != "" {
+		p.Prerelease = true
+	}
+	pkg.AddReadmeTranslationsFromPath(p, i.PkgPath)
+	if sourceURL := sourceURL(i.R, i.Tag); sourceURL != "" {
+		p.Links = append(p.Links, &hub.Link{
+			Name: "source",
+			URL:  sourceURL,
+		})
+	}
+
+	// Include module data into package
+	if p.Data == nil {
+		p.Data = make(map[string]interface{})
+	}
+	p.Data[VariablesKey] = m.Variables
+	p.Data[OutputsKey] = m.Outputs
+	p.Data[ProvidersKey] = m.Providers
+	if m.RequiredVersion != "" {
+		p.Data[RequiredVersionKey] = m.RequiredVersion
+	}
+
+	return p, nil
+}
+
+// getMetadata reads and parses the optional package metadata file provided.
+// As the metadata file is optional for Terraform modules and most of its
+// fields can be obtained from the module itself, it isn't validated here.
+func getMetadata(mdFile string) (*hub.PackageMetadata, error) {
+	for _, extension := range []string{".yml", ".yaml"} {
+		data, err := ioutil.ReadFile(mdFile + extension)
+		if err != nil {
+			continue
+		}
+		var md *hub.PackageMetadata
+		if err := yaml.UnmarshalStrict(data, &md); err != nil {
+			return nil, fmt.Errorf("error unmarshaling package metadata file: %w", err)
+		}
+		if md != nil {
+			return md, nil
+		}
+	}
+	return &hub.PackageMetadata{}, nil
+}
+
+// readmeDescription returns the first paragraph of the readme provided that
+// isn't a heading, a badge or some html content.
+func readmeDescription(readme string) string {
+	var paragraph []string
+	for _, line := range strings.Split(readme, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			if len(paragraph) > 0 {
+				return strings.Join(paragraph, " ")
+			}
+		case strings.HasPrefix(line, "#"),
+			strings.HasPrefix(line, "!["),
+			strings.HasPrefix(line, "[!["),
+			strings.HasPrefix(line, "<"),
+			strings.HasPrefix(line, "```"):
+			if len(paragraph) > 0 {
+				return strings.Join(paragraph, " ")
+			}
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	return strings.Join(paragraph, " ")
+}
+
+// installInstructions returns some installation instructions for the module
+// version provided, based on its git source address.
+func installInstructions(r *hub.Repository, name, tag string) string {
+	source := "git::" + repoBaseURL(r)
+	if !strings.HasSuffix(source, ".git") {
+		source += ".git"
+	}
+	if _, packagesPath := moduleName(r); packagesPath != "" {
+		source += "//" + packagesPath
+	}
+	source += "?ref=" + tag
+	return fmt.Sprintf("```hcl\nmodule \"%s\" {\n  source = \"%s\"\n}\n```\n", strings.ReplaceAll(name, "-", "_"), source)
+}
+
+// sourceURL returns the url of the module's source code at the tag provided,
+// when the repository is hosted in a known provider.
+func sourceURL(r *hub.Repository, tag string) string {
+	u, err := repo.ParseGitRepoURL(r.URL)
+	if err != nil {
+		return ""
+	}
+	return u.TreeURL(tag, u.PackagesPath)
+}
+
+// moduleName returns the name of the module in the repository provided as
+// well as its path in the git repository. The name of the module is the name
+// of the directory where it's located or, if it's located at the root of the
+// repository, the name of the git repository.
+func moduleName(r *hub.Repository) (string, string) {
+	matches := repo.GitRepoURLRE.FindStringSubmatch(r.URL)
+	if len(matches) < 3 {
+		return "", ""
+	}
+	var packagesPath string
+	if len(matches) == 4 {
+		packagesPath = strings.Trim(matches[3], "/")
+	}
+	if packagesPath != "" {
+		return path.Base(packagesPath), packagesPath
+	}
+	return strings.TrimSuffix(path.Base(matches[1]), ".git"), ""
+}
+
+// repoBaseURL returns the base url of the git repository provided.
+func repoBaseURL(r *hub.Repository) string {
+	matches := repo.GitRepoURLRE.FindStringSubmatch(r.URL)
+	if len(matches) < 3 {
+		return r.URL
+	}
+	return matches[1]
+}
+
+// remoteGitRepository is a gitRepository implementation that interacts with
+// remote git repositories using go-git.
+type remoteGitRepository struct{}
+
+// Tags implements the gitRepository interface. It returns the tags available
+// in the repository along with the hash they point to.
+func (gr *remoteGitRepository) Tags(ctx context.Context, r *hub.Repository) (map[string]string, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		URLs: []string{repoBaseURL(r)},
+	})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth(r)})
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			tags[ref.Name().Short()] = ref.Hash().String()
+		}
+	}
+	return tags, nil
+}
+
+// Export implements the gitRepository interface. It clones the repository at
+// the tag provided into the destination directory given, returning the time
+// of the commit the tag points to.
+func (gr *remoteGitRepository) Export(ctx context.Context, r *hub.Repository, tag, dst string) (time.Time, error) {
+	gitRepo, err := git.PlainCloneContext(ctx, dst, false, &git.CloneOptions{
+		URL:           repoBaseURL(r),
+		Auth:          auth(r),
+		ReferenceName: plumbing.NewTagReferenceName(tag),
+		SingleBranch:  true,
+		Depth:         1,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	head, err := gitRepo.Head()
+	if err != nil {
+		return time.Time{}, err
+	}
+	commit, err := gitRepo.CommitObject(head.Hash())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return commit.Committer.When, nil
+}
+
+// auth returns the authentication method to use with the repository provided,
+// if any.
+func auth(r *hub.Repository) transport.AuthMethod {
+	if auth := repo.GitCloneAuth(r); auth != nil {
+		return auth
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testsTS = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func TestTrackerSource(t *testing.T) {
+	t.Run("error getting tags", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Terraform,
+				URL:  "https://github.com/user/terraform-aws-s3",
+			},
+			Svc: sw.Svc,
+		}
+		gr := &fakeGitRepository{tagsErr: errors.New("fake error")}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withGitRepository(gr)).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.EqualError(t, err, "error getting repository tags: fake error")
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("non semver tags are ignored and registered versions are not processed again", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Terraform,
+				URL:  "https://github.com/user/terraform-aws-s3",
+			},
+			PackagesRegistered: map[string]string{
+				"terraform-aws-s3@1.0.0": "hash1",
+			},
+			Svc: sw.Svc,
+		}
+		gr := &fakeGitRepository{
+			tags: map[string]string{
+				"latest": "hash0",
+				"v1.0.0": "hash1",
+			},
+		}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withGitRepository(gr)).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			"terraform-aws-s3@1.0.0": {
+				Name:    "terraform-aws-s3",
+				Version: "1.0.0",
+				Digest:  hub.HasNotChanged,
+			},
+		}, packages)
+		assert.NoError(t, err)
+		assert.Empty(t, gr.exported)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("error exporting tag", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Terraform,
+				URL:  "https://github.com/user/terraform-aws-s3",
+			},
+			Svc: sw.Svc,
+		}
+		gr := &fakeGitRepository{
+			tags: map[string]string{
+				"v1.0.0": "hash1",
+			},
+			exportErr: errors.New("fake error"),
+		}
+		expectedErr := "error preparing package (tag: v1.0.0): error exporting tag: fake error"
+		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withGitRepository(gr)).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
+
+	t.Run("new version returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.Terraform,
+				URL:  "https://github.com/user/modules/storage/s3",
+			},
+			PackagesRegistered: map[string]string{
+				"s3@1.0.0": "hash1",
+			},
+			Svc: sw.Svc,
+		}
+		gr := &fakeGitRepository{
+			tags: map[string]string{
+				"v1.0.0": "hash2",
+			},
+			srcPath: "testdata/module1",
+			dstPath: "storage/s3",
+		}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withGitRepository(gr)).GetPackagesAvailable()
+		require.NoError(t, err)
+		require.Len(t, packages, 1)
+		p := packages["s3@1.0.0"]
+		require.NotNil(t, p)
+		assert.Equal(t, "s3", p.Name)
+		assert.Equal(t, "1.0.0", p.Version)
+		assert.Equal(t, "hash2", p.Digest)
+		assert.Equal(t, "https://github.com/user/modules/tree/v1.0.0/storage/s3", p.Links[0].URL)
+		assert.Contains(t, p.Install, `source = "git::https://github.com/user/modules.git//storage/s3?ref=v1.0.0"`)
+		assert.Equal(t, []string{"v1.0.0"}, gr.exported)
+		sw.AssertExpectations(t)
+	})
+}
+
+func TestPreparePackage(t *testing.T) {
+	t.Run("module without configuration files", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := PreparePackage(&PreparePackageInput{
+			R:       &hub.Repository{URL: "https://github.com/user/repo"},
+			Name:    "repo",
+			Tag:     "v1.0.0",
+			TS:      testsTS,
+			PkgPath: "testdata/module3",
+		})
+		assert.EqualError(t, err, "error loading module: no terraform configuration files found")
+	})
+
+	t.Run("module without metadata file", func(t *testing.T) {
+		t.Parallel()
+
+		r := &hub.Repository{URL: "https://github.com/user/terraform-aws-s3"}
+		readme, _ := ioutil.ReadFile("testdata/module1/README.md")
+		p, err := PreparePackage(&PreparePackageInput{
+			R:       r,
+			Name:    "terraform-aws-s3",
+			Tag:     "v1.2.0-beta.1",
+			Digest:  "hash",
+			TS:      testsTS,
+			PkgPath: "testdata/module1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &hub.Package{
+			Name:        "terraform-aws-s3",
+			DisplayName: "terraform-aws-s3",
+			Description: "Terraform module which creates an S3 bucket.",
+			HomeURL:     "https://github.com/user/terraform-aws-s3",
+			Readme:      string(readme),
+			Install:     "```hcl\nmodule \"terraform_aws_s3\" {\n  source = \"git::https://github.com/user/terraform-aws-s3.git?ref=v1.2.0-beta.1\"\n}\n```\n",
+			Version:     "1.2.0-beta.1",
+			Prerelease:  true,
+			Digest:      "hash",
+			TS:          testsTS.Unix(),
+			Links: []*hub.Link{
+				{
+					Name: "source",
+					URL:  "https://github.com/user/terraform-aws-s3/tree/v1.2.0-beta.1",
+				},
+			},
+			Data: map[string]interface{}{
+				VariablesKey: []*Variable{
+					{
+						Name:        "bucket_name",
+						Type:        "string",
+						Description: "Name of the bucket",
+						Required:    true,
+					},
+					{
+						Name:      "kms_key",
+						Type:      "string",
+						Default:   "null",
+						Sensitive: true,
+					},
+					{
+						Name:        "tags",
+						Type:        "map(string)",
+						Description: "Tags to assign to the bucket.\nDefaults to no tags.",
+						Default:     "{}",
+					},
+				},
+				OutputsKey: []*Output{
+					{
+						Name:        "bucket_arn",
+						Description: "ARN of the bucket",
+					},
+					{
+						Name:      "bucket_id",
+						Sensitive: true,
+					},
+				},
+				ProvidersKey: []*Provider{
+					{
+						Name:    "aws",
+						Source:  "hashicorp/aws",
+						Version: ">= 4.0",
+					},
+					{
+						Name:    "random",
+						Version: ">= 3.1",
+					},
+				},
+				RequiredVersionKey: ">= 1.0",
+			},
+			Repository: r,
+		}, p)
+	})
+
+	t.Run("module with metadata file", func(t *testing.T) {
+		t.Parallel()
+
+		r := &hub.Repository{URL: "https://gitlab.com/user/modules/module2"}
+		p, err := PreparePackage(&PreparePackageInput{
+			R:       r,
+			Name:    "module2",
+			Tag:     "0.1.0",
+			Digest:  "hash",
+			TS:      testsTS,
+			PkgPath: "testdata/module2",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Module 2", p.DisplayName)
+		assert.Equal(t, "Some description", p.Description)
+		assert.Equal(t, []string{"terraform", "test"}, p.Keywords)
+		assert.Equal(t, "Apache-2.0", p.License)
+		assert.Equal(t, "0.1.0", p.Version)
+		assert.False(t, p.Prerelease)
+		assert.Equal(t, "https://gitlab.com/user/modules/-/tree/0.1.0/module2", p.Links[0].URL)
+		assert.Equal(t, []*Variable{{Name: "name", Type: "string", Required: true}}, p.Data[VariablesKey])
+		assert.Equal(t, []*Output{{Name: "name"}}, p.Data[OutputsKey])
+		assert.NotContains(t, p.Data, RequiredVersionKey)
+	})
+}
+
+func TestReadmeDescription(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		readme              string
+		expectedDescription string
+	}{
+		{"", ""},
+		{"# Title\n", ""},
+		{"# Title\n\nFirst paragraph.\n\nSecond paragraph.\n", "First paragraph."},
+		{"<p>html</p>\nText\n# Heading\n", "Text"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedDescription, readmeDescription(tc.readme))
+	}
+}
+
+func withGitRepository(gr gitRepository) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.gr = gr
+	}
+}
+
+// fakeGitRepository is a gitRepository implementation used in tests. The
+// content of srcPath is copied to dstPath (relative to the export destination
+// directory) when a tag is exported.
+type fakeGitRepository struct {
+	tags      map[string]string
+	tagsErr   error
+	srcPath   string
+	dstPath   string
+	exportErr error
+	exported  []string
+}
+
+func (gr *fakeGitRepository) Tags(ctx context.Context, r *hub.Repository) (map[string]string, error) {
+	return gr.tags, gr.tagsErr
+}
+
+func (gr *fakeGitRepository) Export(ctx context.Context, r *hub.Repository, tag, dst string) (time.Time, error) {
+	gr.exported = append(gr.exported, tag)
+	if gr.exportErr != nil {
+		return time.Time{}, gr.exportErr
+	}
+	dst = filepath.Join(dst, gr.dstPath)
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return time.Time{}, err
+	}
+	files, err := ioutil.ReadDir(gr.srcPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(filepath.Join(gr.srcPath, f.Name()))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dst, f.Name()), data, 0600); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return testsTS, nil
+}
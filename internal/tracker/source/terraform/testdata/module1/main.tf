@@ -0,0 +1,37 @@
+terraform {
+  required_version = ">= 1.0"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 4.0"
+    }
+    random = ">= 3.1"
+  }
+}
+
+# Name of the bucket
+variable "bucket_name" {
+  description = "Name of the bucket"
+  type        = string
+}
+
+variable "tags" {
+  description = <<-EOT
+    Tags to assign to the bucket.
+    Defaults to no tags.
+  EOT
+  type        = map(string)
+  default     = {}
+}
+
+variable "kms_key" {
+  type      = string
+  default   = null
+  sensitive = true
+}
+
+resource "aws_s3_bucket" "this" {
+  bucket = var.bucket_name
+  tags   = merge(var.tags, { "Name" = "${var.bucket_name}-bucket" })
+}
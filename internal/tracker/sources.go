@@ -0,0 +1,242 @@
+package tracker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tracker/source/container"
+	"github.com/artifacthub/hub/internal/tracker/source/falco"
+	"github.com/artifacthub/hub/internal/tracker/source/generic"
+	"github.com/artifacthub/hub/internal/tracker/source/helm"
+	"github.com/artifacthub/hub/internal/tracker/source/helmplugin"
+	"github.com/artifacthub/hub/internal/tracker/source/krew"
+	"github.com/artifacthub/hub/internal/tracker/source/olm"
+	"github.com/artifacthub/hub/internal/tracker/source/plugin"
+	"github.com/artifacthub/hub/internal/tracker/source/tekton"
+	"github.com/artifacthub/hub/internal/tracker/source/terraform"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	cloudNativeSecurityHub = "https://github.com/falcosecurity/cloud-native-security-hub/resources/falco"
+)
+
+// SourceRegistration represents the information the tracker needs to process
+// the repositories of a given kind.
+type SourceRegistration struct {
+	// NewSource returns the tracker source that will be used to get the
+	// packages available in the repository provided.
+	NewSource hub.TrackerSourceLoader
+
+	// CloneRepository indicates whether the repository must be cloned before
+	// getting the packages available in it. When it's set, the tracker
+	// source input's base path will point to the packages path in the local
+	// copy of the repository.
+	CloneRepository bool
+}
+
+// PluginConfig represents the configuration of an external tracker source
+// plugin. Plugins are gRPC servers running in a separate process that
+// implement the tracker source plugins service (see the plugin package).
+// Connections to plugins must use TLS unless they listen on a unix socket.
+type PluginConfig struct {
+	Kind            hub.RepositoryKind `mapstructure:"kind"`
+	Address         string             `mapstructure:"address"`
+	CloneRepository bool               `mapstructure:"cloneRepository"`
+	TLS             *PluginTLSConfig   `mapstructure:"tls"`
+}
+
+// PluginTLSConfig represents the TLS configuration used to connect to a
+// plugin. The CA file is used to verify the plugin's certificate (the system
+// pool is used when it's not provided), and a client certificate can be
+// provided when the plugin requires it.
+type PluginTLSConfig struct {
+	CAFile     string `mapstructure:"caFile"`
+	CertFile   string `mapstructure:"certFile"`
+	KeyFile    string `mapstructure:"keyFile"`
+	ServerName string `mapstructure:"serverName"`
+}
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = map[hub.RepositoryKind]*SourceRegistration{
+		hub.Container:      {NewSource: newContainerSource},
+		hub.CoreDNS:        {NewSource: newGenericSource, CloneRepository: true},
+		hub.Crossplane:     {NewSource: newGenericSource, CloneRepository: true},
+		hub.Falco:          {NewSource: newFalcoSource, CloneRepository: true},
+		hub.Headlamp:       {NewSource: newGenericSource, CloneRepository: true},
+		hub.Helm:           {NewSource: newHelmSource},
+		hub.HelmPlugin:     {NewSource: newHelmPluginSource, CloneRepository: true},
+		hub.KedaScaler:     {NewSource: newGenericSource, CloneRepository: true},
+		hub.Keptn:          {NewSource: newGenericSource, CloneRepository: true},
+		hub.KnativeFunc:    {NewSource: newGenericSource, CloneRepository: true},
+		hub.Krew:           {NewSource: newKrewSource, CloneRepository: true},
+		hub.Kyverno:        {NewSource: newGenericSource, CloneRepository: true},
+		hub.OLM:            {NewSource: newOLMSource, CloneRepository: true},
+		hub.OPA:            {NewSource: newGenericSource, CloneRepository: true},
+		hub.TBAction:       {NewSource: newGenericSource, CloneRepository: true},
+		hub.TektonPipeline: {NewSource: newTektonSource, CloneRepository: true},
+		hub.TektonTask:     {NewSource: newTektonSource, CloneRepository: true},
+		hub.Terraform:      {NewSource: newTerraformSource, CloneRepository: true},
+	}
+)
+
+// RegisterSource registers the tracker source that will be used to process
+// the repositories of the kind provided. Registering a kind that has already
+// been registered replaces the previous registration, which allows
+// deployments to override the built-in sources or add new kinds.
+func RegisterSource(kind hub.RepositoryKind, reg *SourceRegistration) error {
+	if reg == nil || reg.NewSource == nil {
+		return errors.New("invalid source registration: source loader not provided")
+	}
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources[kind] = reg
+	return nil
+}
+
+// RegisterPluginSources registers the external tracker source plugins defined
+// in the configuration provided. The connections to the plugins are returned
+// so that they can be closed once the tracker is done.
+func RegisterPluginSources(cfg *viper.Viper) ([]io.Closer, error) {
+	var plugins []*PluginConfig
+	if err := cfg.UnmarshalKey("tracker.plugins", &plugins); err != nil {
+		return nil, fmt.Errorf("error reading plugins configuration: %w", err)
+	}
+
+	var conns []io.Closer
+	for _, pc := range plugins {
+		if hub.GetKindName(pc.Kind) == "" {
+			return conns, fmt.Errorf("plugin for kind %d: kind not registered", pc.Kind)
+		}
+		if pc.Address == "" {
+			return conns, fmt.Errorf("plugin for kind %d: address not provided", pc.Kind)
+		}
+		creds, err := pluginTransportCredentials(pc)
+		if err != nil {
+			return conns, fmt.Errorf("plugin for kind %d: %w", pc.Kind, err)
+		}
+		conn, err := grpc.Dial(pc.Address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return conns, fmt.Errorf("plugin for kind %d: error setting up connection: %w", pc.Kind, err)
+		}
+		conns = append(conns, conn)
+		err = RegisterSource(pc.Kind, &SourceRegistration{
+			NewSource: func(i *hub.TrackerSourceInput) hub.TrackerSource {
+				return plugin.NewTrackerSource(i, conn)
+			},
+			CloneRepository: pc.CloneRepository,
+		})
+		if err != nil {
+			return conns, err
+		}
+	}
+	return conns, nil
+}
+
+// pluginTransportCredentials returns the transport credentials that should be
+// used to connect to the plugin provided. Plaintext connections are only
+// allowed when the plugin listens on a unix socket.
+func pluginTransportCredentials(pc *PluginConfig) (credentials.TransportCredentials, error) {
+	if pc.TLS == nil {
+		if !strings.HasPrefix(pc.Address, "unix:") {
+			return nil, errors.New("tls must be configured when not using a unix socket")
+		}
+		return insecure.NewCredentials(), nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: pc.TLS.ServerName,
+	}
+	if pc.TLS.CAFile != "" {
+		ca, err := ioutil.ReadFile(pc.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ca file: %w", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New("invalid ca file: no certificates found")
+		}
+	}
+	if pc.TLS.CertFile != "" || pc.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(pc.TLS.CertFile, pc.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// SetupSource returns the tracker source that should be used for the
+// repository provided. A nil source is returned when no source has been
+// registered for the repository kind.
+func SetupSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	reg := getSourceRegistration(i.Repository.Kind)
+	if reg == nil {
+		return nil
+	}
+	return reg.NewSource(i)
+}
+
+// getSourceRegistration returns the source registration for the repository
+// kind provided, if any.
+func getSourceRegistration(kind hub.RepositoryKind) *SourceRegistration {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	return sources[kind]
+}
+
+func newContainerSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	return container.NewTrackerSource(i)
+}
+
+func newFalcoSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	// Temporary solution to maintain backwards compatibility with the only
+	// Falco rules repository registered at the moment in artifacthub.io
+	// using the structure and metadata format used by the cloud native
+	// security hub.
+	if i.Repository.URL == cloudNativeSecurityHub {
+		return falco.NewTrackerSource(i)
+	}
+	return generic.NewTrackerSource(i)
+}
+
+func newGenericSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	return generic.NewTrackerSource(i)
+}
+
+func newHelmSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	return helm.NewTrackerSource(i)
+}
+
+func newHelmPluginSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	return helmplugin.NewTrackerSource(i)
+}
+
+func newKrewSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	return krew.NewTrackerSource(i)
+}
+
+func newOLMSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	return olm.NewTrackerSource(i)
+}
+
+func newTektonSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	return tekton.NewTrackerSource(i)
+}
+
+func newTerraformSource(i *hub.TrackerSourceInput) hub.TrackerSource {
+	return terraform.NewTrackerSource(i)
+}
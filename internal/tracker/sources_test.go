@@ -0,0 +1,238 @@
+package tracker
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterSource(t *testing.T) {
+	t.Run("invalid registration", func(t *testing.T) {
+		t.Parallel()
+
+		err := RegisterSource(hub.RepositoryKind(1001), nil)
+		assert.Error(t, err)
+		err = RegisterSource(hub.RepositoryKind(1001), &SourceRegistration{})
+		assert.Error(t, err)
+		assert.Nil(t, getSourceRegistration(hub.RepositoryKind(1001)))
+	})
+
+	t.Run("source registered successfully", func(t *testing.T) {
+		t.Parallel()
+
+		src := &source.Mock{}
+		err := RegisterSource(hub.RepositoryKind(1002), &SourceRegistration{
+			NewSource: func(i *hub.TrackerSourceInput) hub.TrackerSource {
+				return src
+			},
+			CloneRepository: true,
+		})
+		require.NoError(t, err)
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.RepositoryKind(1002),
+			},
+		}
+		assert.Equal(t, src, SetupSource(i))
+		assert.True(t, getSourceRegistration(hub.RepositoryKind(1002)).CloneRepository)
+	})
+}
+
+func TestRegisterPluginSources(t *testing.T) {
+	for _, kind := range []hub.RepositoryKind{1003, 1004, 1005, 1006, 1007} {
+		err := hub.RegisterCustomRepositoryKind(&hub.CustomRepositoryKind{
+			Kind:        kind,
+			Name:        fmt.Sprintf("custom-%d", kind),
+			DisplayName: "Custom packages",
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("plugin kind not registered", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := viper.New()
+		cfg.Set("tracker.plugins", []map[string]interface{}{
+			{"kind": 1008, "address": "unix:///tmp/plugin.sock"},
+		})
+		conns, err := RegisterPluginSources(cfg)
+		assert.Error(t, err)
+		assert.Empty(t, conns)
+		assert.Nil(t, getSourceRegistration(hub.RepositoryKind(1008)))
+	})
+
+	t.Run("plugin address not provided", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := viper.New()
+		cfg.Set("tracker.plugins", []map[string]interface{}{
+			{"kind": 1003},
+		})
+		conns, err := RegisterPluginSources(cfg)
+		assert.Error(t, err)
+		assert.Empty(t, conns)
+		assert.Nil(t, getSourceRegistration(hub.RepositoryKind(1003)))
+	})
+
+	t.Run("plugin tls not configured when not using a unix socket", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := viper.New()
+		cfg.Set("tracker.plugins", []map[string]interface{}{
+			{"kind": 1005, "address": "localhost:50051"},
+		})
+		conns, err := RegisterPluginSources(cfg)
+		assert.Error(t, err)
+		assert.Empty(t, conns)
+		assert.Nil(t, getSourceRegistration(hub.RepositoryKind(1005)))
+	})
+
+	t.Run("plugin tls ca file not found", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := viper.New()
+		cfg.Set("tracker.plugins", []map[string]interface{}{
+			{"kind": 1006, "address": "localhost:50051", "tls": map[string]interface{}{
+				"caFile": "testdata/not-found.pem",
+			}},
+		})
+		conns, err := RegisterPluginSources(cfg)
+		assert.Error(t, err)
+		assert.Empty(t, conns)
+		assert.Nil(t, getSourceRegistration(hub.RepositoryKind(1006)))
+	})
+
+	t.Run("plugin source using tls registered successfully", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := viper.New()
+		cfg.Set("tracker.plugins", []map[string]interface{}{
+			{"kind": 1007, "address": "localhost:50051", "tls": map[string]interface{}{
+				"serverName": "plugin.local",
+			}},
+		})
+		conns, err := RegisterPluginSources(cfg)
+		require.NoError(t, err)
+		require.Len(t, conns, 1)
+		defer conns[0].Close()
+		assert.NotNil(t, getSourceRegistration(hub.RepositoryKind(1007)))
+	})
+
+	t.Run("plugin source registered successfully", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := viper.New()
+		cfg.Set("tracker.plugins", []map[string]interface{}{
+			{"kind": 1004, "address": "unix:///tmp/plugin.sock", "cloneRepository": true},
+		})
+		conns, err := RegisterPluginSources(cfg)
+		require.NoError(t, err)
+		require.Len(t, conns, 1)
+		defer conns[0].Close()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.RepositoryKind(1004),
+			},
+		}
+		assert.Equal(t, "*plugin.TrackerSource", reflect.TypeOf(SetupSource(i)).String())
+		assert.True(t, getSourceRegistration(hub.RepositoryKind(1004)).CloneRepository)
+	})
+}
+
+func TestSetupSource(t *testing.T) {
+	testCases := []struct {
+		r            *hub.Repository
+		expectedType string
+	}{
+		{
+			&hub.Repository{
+				Kind: hub.Falco,
+				URL:  cloudNativeSecurityHub,
+			},
+			"*falco.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.Falco,
+			},
+			"*generic.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.Helm,
+			},
+			"*helm.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.HelmPlugin,
+			},
+			"*helmplugin.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.Krew,
+			},
+			"*krew.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.OLM,
+			},
+			"*olm.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.OPA,
+			},
+			"*generic.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.TBAction,
+			},
+			"*generic.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.TektonTask,
+			},
+			"*tekton.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.Terraform,
+			},
+			"*terraform.TrackerSource",
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("Test case %d", i), func(t *testing.T) {
+			t.Parallel()
+
+			i := &hub.TrackerSourceInput{
+				Repository: tc.r,
+			}
+			source := SetupSource(i)
+			assert.Equal(t, tc.expectedType, reflect.TypeOf(source).String())
+		})
+	}
+
+	t.Run("no source registered for kind", func(t *testing.T) {
+		t.Parallel()
+
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				Kind: hub.RepositoryKind(1000),
+			},
+		}
+		assert.Nil(t, SetupSource(i))
+	})
+}
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 	"strings"
+	"sync"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/releasenotes"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/rs/zerolog"
 )
 
@@ -75,15 +79,10 @@ func (t *Tracker) Run() error {
 		return fmt.Errorf("error getting packages available: %w", err)
 	}
 
-	// Register available packages when needed
+	// Select the packages versions that need to be registered, grouped by
+	// package name
+	packagesToRegister := make(map[string][]*hub.Package)
 	for _, p := range packagesAvailable {
-		// Return ASAP if context is cancelled
-		select {
-		case <-t.svc.Ctx.Done():
-			return t.svc.Ctx.Err()
-		default:
-		}
-
 		// Check if this package version is already registered
 		digest, ok := t.packagesRegistered[pkg.BuildKey(p)]
 		if ok && (p.Digest == digest || p.Digest == hub.HasNotChanged) && !bypassDigestCheck {
@@ -95,11 +94,43 @@ func (t *Tracker) Run() error {
 			continue
 		}
 
-		// Register package
-		t.logger.Debug().Str("name", p.Name).Str("v", p.Version).Msg("registering package")
-		if err := t.svc.Pm.Register(t.svc.Ctx, p); err != nil {
-			t.warn(fmt.Errorf("error registering package %s version %s: %w", p.Name, p.Version, err))
+		packagesToRegister[p.Name] = append(packagesToRegister[p.Name], p)
+	}
+
+	// Register packages. Different packages are registered concurrently, but
+	// the versions of a given package are registered sequentially (oldest
+	// first), as registering a version may update the package's latest version
+	limiter := make(chan struct{}, source.PackagesConcurrency(t.svc.Cfg))
+	var wg sync.WaitGroup
+	for _, versions := range packagesToRegister {
+		// Return ASAP if context is cancelled
+		select {
+		case <-t.svc.Ctx.Done():
+			wg.Wait()
+			return t.svc.Ctx.Err()
+		default:
 		}
+
+		// Prepare and register package versions
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func(versions []*hub.Package) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			sortByVersion(versions)
+			for _, p := range versions {
+				if t.svc.Ctx.Err() != nil {
+					return
+				}
+				t.registerPackageSafely(p)
+			}
+		}(versions)
+	}
+	wg.Wait()
+	if err := t.svc.Ctx.Err(); err != nil {
+		return err
 	}
 
 	// Unregister packages not available anymore
@@ -144,35 +175,46 @@ func (t *Tracker) Run() error {
 	return nil
 }
 
+// registerPackageSafely registers the package version provided, recovering
+// from any panic that may occur in the process.
+func (t *Tracker) registerPackageSafely(p *hub.Package) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger.Error().Bytes("stacktrace", debug.Stack()).Interface("recover", r).Send()
+		}
+	}()
+	t.registerPackage(p)
+}
+
+// registerPackage prepares the package version provided (screenshots and
+// release notes) and registers it.
+func (t *Tracker) registerPackage(p *hub.Package) {
+	// Prepare package screenshots
+	t.prepareScreenshots(p)
+
+	// Fetch package release notes
+	t.prepareReleaseNotes(p)
+
+	// Register package
+	t.logger.Debug().Str("name", p.Name).Str("v", p.Version).Msg("registering package")
+	if err := t.svc.Pm.Register(t.svc.Ctx, p); err != nil {
+		t.warn(fmt.Errorf("error registering package %s version %s: %w", p.Name, p.Version, err))
+	}
+}
+
 // cloneRepository creates a local copy of the repository provided to the
 // tracker instance when applicable to the repository kind.
 func (t *Tracker) cloneRepository() (string, string, error) {
 	var tmpDir, packagesPath string
 	var err error
 
-	switch t.r.Kind {
-	case
-		hub.Container,
-		hub.Helm:
-		// These repositories are not cloned
-	case hub.OLM:
-		if strings.HasPrefix(t.r.URL, hub.RepositoryOCIPrefix) {
-			tmpDir, err = t.svc.Oe.ExportRepository(t.svc.Ctx, t.r)
-		} else {
+	switch {
+	case t.r.Kind == hub.OLM && strings.HasPrefix(t.r.URL, hub.RepositoryOCIPrefix):
+		tmpDir, err = t.svc.Oe.ExportRepository(t.svc.Ctx, t.r)
+	default:
+		if reg := getSourceRegistration(t.r.Kind); reg != nil && reg.CloneRepository {
 			tmpDir, packagesPath, err = t.svc.Rc.CloneRepository(t.svc.Ctx, t.r)
 		}
-	case
-		hub.CoreDNS,
-		hub.Falco,
-		hub.HelmPlugin,
-		hub.KedaScaler,
-		hub.Keptn,
-		hub.Krew,
-		hub.OPA,
-		hub.TBAction,
-		hub.TektonTask,
-		hub.TektonPipeline:
-		tmpDir, packagesPath, err = t.svc.Rc.CloneRepository(t.svc.Ctx, t.r)
 	}
 
 	return tmpDir, packagesPath, err
@@ -186,6 +228,7 @@ func (t *Tracker) getPackagesAvailable() (map[string]*hub.Package, error) {
 		Repository:         t.r,
 		PackagesRegistered: t.packagesRegistered,
 		BasePath:           t.basePath,
+		Metadata:           t.md,
 		Svc: &hub.TrackerSourceServices{
 			Ctx:    t.svc.Ctx,
 			Cfg:    t.svc.Cfg,
@@ -196,8 +239,47 @@ func (t *Tracker) getPackagesAvailable() (map[string]*hub.Package, error) {
 			Logger: t.logger,
 		},
 	}
-	source := t.svc.SetupTrackerSource(i)
-	return source.GetPackagesAvailable()
+	ts := t.svc.SetupTrackerSource(i)
+	if ts == nil {
+		return nil, fmt.Errorf("no tracker source registered for repository kind %d", t.r.Kind)
+	}
+	return ts.GetPackagesAvailable()
+}
+
+// prepareScreenshots downloads and stores the screenshots of the package
+// provided, so that they can be served from the hub in different sizes.
+// Screenshots that cannot be processed are still listed using their url.
+func (t *Tracker) prepareScreenshots(p *hub.Package) {
+	for _, s := range p.Screenshots {
+		imageID, err := t.svc.Is.DownloadAndSaveImage(t.svc.Ctx, s.URL)
+		if err != nil {
+			t.warn(fmt.Errorf("error preparing package %s version %s screenshot %s: %w", p.Name, p.Version, s.URL, err))
+			continue
+		}
+		s.ImageID = imageID
+	}
+}
+
+// prepareReleaseNotes fetches the release notes of the package version
+// provided when its source is hosted on GitHub. Failing to fetch them is not
+// considered a tracking error, as they are an optional changelog source.
+func (t *Tracker) prepareReleaseNotes(p *hub.Package) {
+	if t.svc.Rn == nil || p.ReleaseNotes != nil {
+		return
+	}
+	if owner, _ := releasenotes.GetGitHubRepository(p); owner == "" {
+		return
+	}
+	rn, err := t.svc.Rn.Fetch(t.svc.Ctx, p)
+	if err != nil {
+		if errors.Is(err, releasenotes.ErrRateLimited) {
+			t.logger.Debug().Str("name", p.Name).Str("v", p.Version).Msg("release notes not fetched: rate limited")
+			return
+		}
+		t.logger.Warn().Err(fmt.Errorf("error fetching package %s version %s release notes: %w", p.Name, p.Version, err)).Send()
+		return
+	}
+	p.ReleaseNotes = rn
 }
 
 // warn is a helper that sends the error provided to the errors collector and
@@ -5,17 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/img"
 	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/releasenotes"
 	"github.com/artifacthub/hub/internal/repo"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestTracker(t *testing.T) {
@@ -209,6 +213,107 @@ func TestTracker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("package with screenshots registered successfully", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		p := &hub.Package{
+			Name:       "pkg1",
+			Version:    "1.0.0",
+			Repository: r1,
+			Screenshots: []*hub.Screenshot{
+				{Title: "Screenshot 1", URL: "https://screenshot1.url"},
+				{Title: "Screenshot 2", URL: "https://screenshot2.url"},
+			},
+		}
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		sw.is.On("DownloadAndSaveImage", sw.svc.Ctx, "https://screenshot1.url").Return("imageID1", nil)
+		sw.is.On("DownloadAndSaveImage", sw.svc.Ctx, "https://screenshot2.url").Return("", tests.ErrFake)
+		expectedErr := "error preparing package pkg1 version 1.0.0 screenshot https://screenshot2.url: fake error for tests"
+		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.pm.On("Register", sw.svc.Ctx, p).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		assert.Equal(t, []*hub.Screenshot{
+			{Title: "Screenshot 1", URL: "https://screenshot1.url", ImageID: "imageID1"},
+			{Title: "Screenshot 2", URL: "https://screenshot2.url"},
+		}, p.Screenshots)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("package with github source registered successfully with release notes", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		p := &hub.Package{
+			Name:       "pkg1",
+			Version:    "1.0.0",
+			Repository: r1,
+			Links: []*hub.Link{
+				{Name: "source", URL: "https://github.com/org/repo"},
+			},
+		}
+		rn := &hub.ReleaseNotes{
+			Source:  releasenotes.GitHubSource,
+			URL:     "https://github.com/org/repo/releases/tag/v1.0.0",
+			TagName: "v1.0.0",
+			Body:    "release notes",
+		}
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		sw.rn.On("Fetch", sw.svc.Ctx, p).Return(rn, nil)
+		sw.pm.On("Register", sw.svc.Ctx, p).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		assert.Equal(t, rn, p.ReleaseNotes)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("package with github source registered successfully without release notes", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		p := &hub.Package{
+			Name:       "pkg1",
+			Version:    "1.0.0",
+			Repository: r1,
+			HomeURL:    "https://github.com/org/repo",
+		}
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, nil)
+		sw.rn.On("Fetch", sw.svc.Ctx, p).Return(nil, releasenotes.ErrRateLimited)
+		sw.pm.On("Register", sw.svc.Ctx, p).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		assert.Nil(t, p.ReleaseNotes)
+		sw.assertExpectations(t)
+	})
+
 	t.Run("package available but not registered because it already was (same digest)", func(t *testing.T) {
 		t.Parallel()
 
@@ -344,6 +449,59 @@ func TestTracker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("several versions of the same package registered sequentially", func(t *testing.T) {
+		t.Parallel()
+		p1v3 := &hub.Package{
+			Name:       "pkg1",
+			Version:    "3.0.0",
+			Repository: r1,
+		}
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.svc.Cfg.Set("tracker.packagesConcurrency", 4)
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.rm.On("GetMetadata", r1, "").Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p1v3): p1v3,
+			pkg.BuildKey(p1v1): p1v1,
+			pkg.BuildKey(p2v1): p2v1,
+			pkg.BuildKey(p1v2): p1v2,
+		}, nil)
+		var mu sync.Mutex
+		inFlight := make(map[string]int)
+		maxInFlight := make(map[string]int)
+		var registered []string
+		trackRegistration := func(args mock.Arguments) {
+			p := args.Get(1).(*hub.Package)
+			mu.Lock()
+			inFlight[p.Name]++
+			if inFlight[p.Name] > maxInFlight[p.Name] {
+				maxInFlight[p.Name] = inFlight[p.Name]
+			}
+			if p.Name == "pkg1" {
+				registered = append(registered, p.Version)
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			inFlight[p.Name]--
+			mu.Unlock()
+		}
+		for _, p := range []*hub.Package{p1v1, p1v2, p1v3, p2v1} {
+			sw.pm.On("Register", sw.svc.Ctx, p).Run(trackRegistration).Return(nil)
+		}
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, maxInFlight["pkg1"])
+		assert.Equal(t, []string{"1.0.0", "2.0.0", "3.0.0"}, registered)
+		sw.assertExpectations(t)
+	})
+
 	t.Run("error unregistering package", func(t *testing.T) {
 		t.Parallel()
 
@@ -492,6 +650,7 @@ type servicesWrapper struct {
 	ec  *repo.ErrorsCollectorMock
 	hc  *tests.HTTPClientMock
 	is  *img.StoreMock
+	rn  *releasenotes.FetcherMock
 	src *source.Mock
 	svc *hub.TrackerServices
 }
@@ -505,6 +664,7 @@ func newServicesWrapper() *servicesWrapper {
 	ec := &repo.ErrorsCollectorMock{}
 	hc := &tests.HTTPClientMock{}
 	is := &img.StoreMock{}
+	rn := &releasenotes.FetcherMock{}
 	src := &source.Mock{}
 
 	// Setup tracker services using mocks
@@ -518,6 +678,7 @@ func newServicesWrapper() *servicesWrapper {
 		Ec:  ec,
 		Hc:  hc,
 		Is:  is,
+		Rn:  rn,
 		SetupTrackerSource: func(i *hub.TrackerSourceInput) hub.TrackerSource {
 			return src
 		},
@@ -532,6 +693,7 @@ func newServicesWrapper() *servicesWrapper {
 		ec:  ec,
 		hc:  hc,
 		is:  is,
+		rn:  rn,
 		src: src,
 		svc: svc,
 	}
@@ -545,5 +707,6 @@ func (sw *servicesWrapper) assertExpectations(t *testing.T) {
 	sw.ec.AssertExpectations(t)
 	sw.hc.AssertExpectations(t)
 	sw.is.AssertExpectations(t)
+	sw.rn.AssertExpectations(t)
 	sw.src.AssertExpectations(t)
 }
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"html/template"
 	"image/png"
+	"strings"
 	"time"
 
 	_ "embed" // Used by templates
@@ -29,30 +30,46 @@ import (
 
 const (
 	// Database queries
-	approveSessionDBQ            = `select approve_session($1::text, $2::text)`
-	checkUserAliasAvailDBQ       = `select check_user_alias_availability($1::text)`
-	checkUserCredsDBQ            = `select user_id, password from "user" where email = $1 and password is not null and email_verified = true`
-	deleteSessionDBQ             = `delete from session where session_id = $1`
-	deleteUserDBQ                = `select delete_user($1::uuid, $2::text)`
-	disableTFADBQ                = `update "user" set tfa_enabled = false, tfa_url = null, tfa_recovery_codes = null where user_id = $1 and tfa_enabled = true`
-	enableTFADBQ                 = `update "user" set tfa_enabled = true where user_id = $1`
-	getSessionDBQ                = `select user_id, floor(extract(epoch from created_at)), approved from session where session_id = $1`
-	getTFAConfigDBQ              = `select get_user_tfa_config($1::uuid)`
-	getUserEmailDBQ              = `select email from "user" where user_id = $1`
-	getUserIDFromEmailDBQ        = `select user_id from "user" where email = $1`
-	getUserIDFromSessionIDDBQ    = `select user_id from session where session_id = $1`
-	getUserPasswordDBQ           = `select password from "user" where user_id = $1 and password is not null`
-	getUserProfileDBQ            = `select get_user_profile($1::uuid)`
-	registerPasswordResetCodeDBQ = `select register_password_reset_code($1::text, $2::text)`
-	registerSessionDBQ           = `select register_session($1::jsonb)`
-	registerUserDBQ              = `select register_user($1::jsonb)`
-	registerDeleteUserCodeDBQ    = `select register_delete_user_code($1::uuid, $2::text)`
-	resetUserPasswordDBQ         = `select reset_user_password($1::text, $2::text)`
-	updateTFAInfoDBQ             = `update "user" set tfa_url = $2, tfa_recovery_codes = $3 where user_id = $1`
-	updateUserPasswordDBQ        = `select update_user_password($1::uuid, $2::text, $3::text)`
-	updateUserProfileDBQ         = `select update_user_profile($1::uuid, $2::jsonb)`
-	verifyEmailDBQ               = `select verify_email($1::uuid)`
-	verifyPasswordResetCodeDBQ   = `select verify_password_reset_code($1::text)`
+	approveSessionDBQ                = `select approve_session($1::text, $2::text)`
+	checkUserAliasAvailDBQ           = `select check_user_alias_availability($1::text)`
+	checkUserCredsDBQ                = `select user_id, password from "user" where email = $1 and password is not null and email_verified = true and disabled = false`
+	deleteEmailSuppressionDBQ        = `select delete_user_email_suppression($1::uuid)`
+	deleteSessionDBQ                 = `delete from session where session_id = $1`
+	deleteUserDBQ                    = `select delete_user($1::uuid, $2::text)`
+	disableTFADBQ                    = `update "user" set tfa_enabled = false, tfa_url = null, tfa_recovery_codes = null where user_id = $1 and tfa_enabled = true`
+	enableTFADBQ                     = `update "user" set tfa_enabled = true where user_id = $1`
+	getAdminAuditLogDBQ              = `select get_admin_audit_log($1::jsonb)`
+	getEmailSuppressionDBQ           = `select get_user_email_suppression($1::uuid)`
+	getSessionDBQ                    = `select s.user_id, floor(extract(epoch from s.created_at)), s.approved, s.impersonated, coalesce(host(s.ip), '') from session s join "user" u using (user_id) where s.session_id = $1 and u.disabled = false`
+	getTFAConfigDBQ                  = `select get_user_tfa_config($1::uuid)`
+	getUserIdentitiesDBQ             = `select get_user_identities($1::uuid)`
+	getUserEmailDBQ                  = `select email from "user" where user_id = $1`
+	getUserIDFromEmailDBQ            = `select user_id from "user" where email = $1`
+	getUserIDFromIdentityDBQ         = `select user_id from user_identity where provider = $1 and subject = $2`
+	getUserIDFromSessionIDDBQ        = `select user_id from session where session_id = $1`
+	getUserLocaleDBQ                 = `select coalesce(locale, '') from "user" where user_id = $1`
+	getUserLocaleFromEmailDBQ        = `select coalesce(locale, '') from "user" where email = $1`
+	getUserPasswordDBQ               = `select password from "user" where user_id = $1 and password is not null`
+	getUserProfileDBQ                = `select get_user_profile($1::uuid)`
+	getUserPublicProfileDBQ          = `select get_user_public_profile($1::text)`
+	linkUserIdentityDBQ              = `select link_user_identity($1::jsonb)`
+	registerEmailVerificationCodeDBQ = `select register_email_verification_code($1::text, $2::text)`
+	registerPasswordResetCodeDBQ     = `select register_password_reset_code($1::text, $2::text)`
+	registerSessionDBQ               = `select register_session($1::jsonb)`
+	registerUserDBQ                  = `select register_user($1::jsonb, $2::text)`
+	registerDeleteUserCodeDBQ        = `select register_delete_user_code($1::uuid, $2::text)`
+	registerImpersonationDBQ         = `select register_impersonation_session($1::jsonb, $2::jsonb)`
+	resetUserPasswordDBQ             = `select reset_user_password($1::text, $2::text)`
+	resetUserTFADBQ                  = `select reset_user_tfa($1::uuid, $2::jsonb)`
+	searchUsersDBQ                   = `select * from search_users($1::jsonb)`
+	setUserDisabledDBQ               = `select set_user_disabled($1::uuid, $2::boolean, $3::jsonb)`
+	unlinkUserIdentityDBQ            = `select unlink_user_identity($1::uuid, $2::text)`
+	updateTFAInfoDBQ                 = `update "user" set tfa_url = $2, tfa_recovery_codes = $3 where user_id = $1`
+	updateUserPasswordDBQ            = `select update_user_password($1::uuid, $2::text, $3::text)`
+	updateUserProfileDBQ             = `select update_user_profile($1::uuid, $2::jsonb)`
+	verifyAdminAuditLogDBQ           = `select coalesce(verify_admin_audit_log()::text, '')`
+	verifyEmailDBQ                   = `select verify_email($1::text)`
+	verifyPasswordResetCodeDBQ       = `select verify_password_reset_code($1::text)`
 
 	numRecoveryCodes = 10
 )
@@ -61,6 +78,10 @@ const (
 	// PasswordMinEntropyBits represents the minimum amount of entropy bits
 	// required for a password.
 	PasswordMinEntropyBits = 50
+
+	// ImpersonationSessionDuration represents the maximum duration of the
+	// sessions used by site admins to impersonate users.
+	ImpersonationSessionDuration = 1 * time.Hour
 )
 
 type templateID int
@@ -118,6 +139,22 @@ var (
 	// database when the password reset code is not valid.
 	errInvalidPasswordResetCodeDB = errors.New("ERROR: invalid password reset code (SQLSTATE P0001)")
 
+	// errIdentityAlreadyLinkedDB represents the error returned from the
+	// database when the identity is already linked to another user.
+	errIdentityAlreadyLinkedDB = errors.New("ERROR: identity already linked to another user (SQLSTATE P0001)")
+
+	// errLastSignInMethodDB represents the error returned from the database
+	// when trying to unlink the last sign in method of a user.
+	errLastSignInMethodDB = errors.New("ERROR: last sign in method cannot be unlinked (SQLSTATE P0001)")
+
+	// errProviderAlreadyLinkedDB represents the error returned from the
+	// database when the user already has an identity of the same provider.
+	errProviderAlreadyLinkedDB = errors.New("ERROR: provider already linked (SQLSTATE P0001)")
+
+	// errUserNotFoundDB represents the error returned from the database when
+	// the user the operation applies to does not exist.
+	errUserNotFoundDB = errors.New("ERROR: user not found (SQLSTATE P0001)")
+
 	// errInvalidTFAPasscode indicates that the TFA passcode provided is not
 	// valid.
 	errInvalidTFAPasscode = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid passcode")
@@ -141,13 +178,13 @@ func NewManager(cfg *viper.Viper, db hub.DB, es hub.EmailSender) *Manager {
 		db:  db,
 		es:  es,
 		tmpl: map[templateID]*template.Template{
-			confirmUserDeletionEmail:  template.Must(template.New("").Parse(email.BaseTmpl + confirmUserDeletionEmailTmpl)),
-			passwordResetEmail:        template.Must(template.New("").Parse(email.BaseTmpl + passwordResetEmailTmpl)),
-			passwordResetSuccessEmail: template.Must(template.New("").Parse(email.BaseTmpl + passwordResetSuccessEmailTmpl)),
-			tfaDisabledEmail:          template.Must(template.New("").Parse(email.BaseTmpl + tfaDisabledEmailTmpl)),
-			tfaEnabledEmail:           template.Must(template.New("").Parse(email.BaseTmpl + tfaEnabledEmailTmpl)),
-			userDeletedEmail:          template.Must(template.New("").Parse(email.BaseTmpl + userDeletedEmailTmpl)),
-			verificationEmail:         template.Must(template.New("").Parse(email.BaseTmpl + verificationEmailTmpl)),
+			confirmUserDeletionEmail:  email.ParseTemplate(confirmUserDeletionEmailTmpl),
+			passwordResetEmail:        email.ParseTemplate(passwordResetEmailTmpl),
+			passwordResetSuccessEmail: email.ParseTemplate(passwordResetSuccessEmailTmpl),
+			tfaDisabledEmail:          email.ParseTemplate(tfaDisabledEmailTmpl),
+			tfaEnabledEmail:           email.ParseTemplate(tfaEnabledEmailTmpl),
+			userDeletedEmail:          email.ParseTemplate(userDeletedEmailTmpl),
+			verificationEmail:         email.ParseTemplate(verificationEmailTmpl),
 		},
 	}
 }
@@ -280,10 +317,10 @@ func (m *Manager) CheckSession(
 	}
 
 	// Get session details from database
-	var userID string
+	var userID, ip string
 	var createdAt int64
-	var approved bool
-	err := m.db.QueryRow(ctx, getSessionDBQ, hash(sessionID)).Scan(&userID, &createdAt, &approved)
+	var approved, impersonated bool
+	err := m.db.QueryRow(ctx, getSessionDBQ, hash(sessionID)).Scan(&userID, &createdAt, &approved, &impersonated, &ip)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return &hub.CheckSessionOutput{Valid: false}, nil
@@ -291,7 +328,11 @@ func (m *Manager) CheckSession(
 		return nil, err
 	}
 
-	// Check if the session has expired
+	// Check if the session has expired. Sessions used by site admins to
+	// impersonate users are valid for a shorter period of time.
+	if impersonated && duration > ImpersonationSessionDuration {
+		duration = ImpersonationSessionDuration
+	}
 	if time.Unix(createdAt, 0).Add(duration).Before(time.Now()) {
 		return &hub.CheckSessionOutput{Valid: false}, nil
 	}
@@ -305,9 +346,18 @@ func (m *Manager) CheckSession(
 	return &hub.CheckSessionOutput{
 		Valid:  true,
 		UserID: userID,
+		IP:     ip,
 	}, nil
 }
 
+// DeleteEmailSuppression removes the email address of the user doing the
+// request from the suppression list, so that emails are sent to it again.
+func (m *Manager) DeleteEmailSuppression(ctx context.Context) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	_, err := m.db.Exec(ctx, deleteEmailSuppressionDBQ, userID)
+	return err
+}
+
 // DeleteSession deletes a user session from the database.
 func (m *Manager) DeleteSession(ctx context.Context, sessionID string) error {
 	// Validate input
@@ -325,6 +375,15 @@ func (m *Manager) DeleteSession(ctx context.Context, sessionID string) error {
 func (m *Manager) DeleteUser(ctx context.Context, code string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
 
+	// Get user locale before deleting the account, as it'll be needed to
+	// compose the notification email
+	var locale string
+	if m.es != nil {
+		if err := m.db.QueryRow(ctx, getUserLocaleDBQ, userID).Scan(&locale); err != nil {
+			return err
+		}
+	}
+
 	// Delete user from database
 	var userEmail string
 	if err := m.db.QueryRow(ctx, deleteUserDBQ, userID, hash(code)).Scan(&userEmail); err != nil {
@@ -337,12 +396,12 @@ func (m *Manager) DeleteUser(ctx context.Context, code string) error {
 	// Notify user by email that the account has been deleted
 	if m.es != nil {
 		var emailBody bytes.Buffer
-		if err := m.tmpl[userDeletedEmail].Execute(&emailBody, baseTemplateData(m.cfg)); err != nil {
+		if err := email.ExecuteTemplate(&emailBody, m.tmpl[userDeletedEmail], locale, baseTemplateData(m.cfg)); err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
-			Subject: "Your account has been deleted",
+			Subject: email.Translate(locale, "user_deleted.subject"),
 			Body:    emailBody.Bytes(),
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
@@ -388,13 +447,17 @@ func (m *Manager) DisableTFA(ctx context.Context, passcode string) error {
 		if err := m.db.QueryRow(ctx, getUserEmailDBQ, userID).Scan(&userEmail); err != nil {
 			return err
 		}
+		var locale string
+		if err := m.db.QueryRow(ctx, getUserLocaleDBQ, userID).Scan(&locale); err != nil {
+			return err
+		}
 		var emailBody bytes.Buffer
-		if err := m.tmpl[tfaDisabledEmail].Execute(&emailBody, baseTemplateData(m.cfg)); err != nil {
+		if err := email.ExecuteTemplate(&emailBody, m.tmpl[tfaDisabledEmail], locale, baseTemplateData(m.cfg)); err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
-			Subject: "Two-factor authentication disabled",
+			Subject: email.Translate(locale, "tfa_disabled.subject"),
 			Body:    emailBody.Bytes(),
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
@@ -441,13 +504,17 @@ func (m *Manager) EnableTFA(ctx context.Context, passcode string) error {
 		if err := m.db.QueryRow(ctx, getUserEmailDBQ, userID).Scan(&userEmail); err != nil {
 			return err
 		}
+		var locale string
+		if err := m.db.QueryRow(ctx, getUserLocaleDBQ, userID).Scan(&locale); err != nil {
+			return err
+		}
 		var emailBody bytes.Buffer
-		if err := m.tmpl[tfaEnabledEmail].Execute(&emailBody, baseTemplateData(m.cfg)); err != nil {
+		if err := email.ExecuteTemplate(&emailBody, m.tmpl[tfaEnabledEmail], locale, baseTemplateData(m.cfg)); err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
-			Subject: "Two-factor authentication enabled",
+			Subject: email.Translate(locale, "tfa_enabled.subject"),
 			Body:    emailBody.Bytes(),
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
@@ -458,6 +525,40 @@ func (m *Manager) EnableTFA(ctx context.Context, passcode string) error {
 	return nil
 }
 
+// GetAdminAuditLogJSON returns the entries registered in the admin audit log,
+// optionally limited to the time range provided, as a json array.
+func (m *Manager) GetAdminAuditLogJSON(ctx context.Context, input *hub.GetAdminAuditLogInput) ([]byte, error) {
+	// Validate input
+	if input.From != "" {
+		if _, err := time.Parse(time.RFC3339, input.From); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid from")
+		}
+	}
+	if input.To != "" {
+		if _, err := time.Parse(time.RFC3339, input.To); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid to")
+		}
+	}
+
+	// Get admin audit log entries from database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, getAdminAuditLogDBQ, inputJSON)
+}
+
+// GetEmailSuppressionJSON returns the suppression status of the email address
+// of the user doing the request as a json object.
+func (m *Manager) GetEmailSuppressionJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSON(ctx, m.db, getEmailSuppressionDBQ, userID)
+}
+
+// GetIdentitiesJSON returns the external identities linked to the account of
+// the user doing the request as a json array.
+func (m *Manager) GetIdentitiesJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	return util.DBQueryJSON(ctx, m.db, getUserIdentitiesDBQ, userID)
+}
+
 // GetProfile returns the profile of the user doing the request.
 func (m *Manager) GetProfile(ctx context.Context) (*hub.User, error) {
 	dataJSON, err := m.GetProfileJSON(ctx)
@@ -480,6 +581,18 @@ func (m *Manager) GetProfileJSON(ctx context.Context) ([]byte, error) {
 	return profile, err
 }
 
+// GetPublicProfileJSON returns the public profile of the provided user as a
+// json object. Users who haven't enabled their public profile are not found.
+func (m *Manager) GetPublicProfileJSON(ctx context.Context, userAlias string) ([]byte, error) {
+	// Validate input
+	if userAlias == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Get user public profile from database
+	return util.DBQueryJSON(ctx, m.db, getUserPublicProfileDBQ, userAlias)
+}
+
 // GetUserID returns the id of the user with the email provided.
 func (m *Manager) GetUserID(ctx context.Context, email string) (string, error) {
 	// Validate input
@@ -499,6 +612,98 @@ func (m *Manager) GetUserID(ctx context.Context, email string) (string, error) {
 	return userID, nil
 }
 
+// GetUserIDFromIdentity returns the id of the user the external identity
+// provided has been linked to.
+func (m *Manager) GetUserIDFromIdentity(ctx context.Context, provider, subject string) (string, error) {
+	// Validate input
+	if provider == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "provider not provided")
+	}
+	if subject == "" {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "subject not provided")
+	}
+
+	// Get user id from database
+	var userID string
+	err := m.db.QueryRow(ctx, getUserIDFromIdentityDBQ, provider, subject).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return userID, nil
+}
+
+// Impersonate registers a session that allows a site admin to act on behalf of
+// the provided user for support purposes. Impersonation sessions are approved
+// even if the user has enabled TFA, and are recorded in the admin audit log.
+func (m *Manager) Impersonate(ctx context.Context, userID string, info *hub.AdminAuditInfo) (*hub.Session, error) {
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+	if info == nil || info.Reason == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "reason not provided")
+	}
+
+	// Generate session id
+	sessionID, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	// Register impersonation session in database
+	sessionJSON, _ := json.Marshal(&hub.Session{
+		SessionID: hash(sessionID),
+		UserID:    userID,
+		IP:        info.IP,
+		UserAgent: info.UserAgent,
+	})
+	infoJSON, _ := json.Marshal(info)
+	if _, err := m.db.Exec(ctx, registerImpersonationDBQ, sessionJSON, infoJSON); err != nil {
+		if err.Error() == errUserNotFoundDB.Error() {
+			return nil, hub.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &hub.Session{
+		SessionID: sessionID,
+		UserID:    userID,
+		Approved:  true,
+	}, nil
+}
+
+// LinkIdentity links the external identity provided to the account of the
+// user it belongs to, so that it can be used to sign in to it.
+func (m *Manager) LinkIdentity(ctx context.Context, identity *hub.UserIdentity) error {
+	// Validate input
+	if identity.UserID == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user id not provided")
+	}
+	if identity.Provider == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "provider not provided")
+	}
+	if identity.Subject == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "subject not provided")
+	}
+
+	// Link identity in database
+	identityJSON, _ := json.Marshal(identity)
+	_, err := m.db.Exec(ctx, linkUserIdentityDBQ, identityJSON)
+	if err != nil {
+		switch err.Error() {
+		case errIdentityAlreadyLinkedDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "identity already linked to another user")
+		case errProviderAlreadyLinkedDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "provider already linked")
+		}
+		return err
+	}
+	return nil
+}
+
 // RegisterDeleteUserCode registers a code that allows the user doing the
 // request to initiate the process to delete his account. A link containing the
 // code will be emailed to the user.
@@ -522,15 +727,19 @@ func (m *Manager) RegisterDeleteUserCode(ctx context.Context) error {
 		if err := m.db.QueryRow(ctx, getUserEmailDBQ, userID).Scan(&userEmail); err != nil {
 			return err
 		}
+		var locale string
+		if err := m.db.QueryRow(ctx, getUserLocaleDBQ, userID).Scan(&locale); err != nil {
+			return err
+		}
 		templateData := baseTemplateData(m.cfg)
 		templateData["Link"] = fmt.Sprintf("%s/delete-user?code=%s", templateData["BaseURL"], code)
 		var emailBody bytes.Buffer
-		if err := m.tmpl[confirmUserDeletionEmail].Execute(&emailBody, templateData); err != nil {
+		if err := email.ExecuteTemplate(&emailBody, m.tmpl[confirmUserDeletionEmail], locale, templateData); err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
-			Subject: "Confirm account deletion",
+			Subject: email.Translate(locale, "confirm_user_deletion.subject"),
 			Body:    emailBody.Bytes(),
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
@@ -541,6 +750,43 @@ func (m *Manager) RegisterDeleteUserCode(ctx context.Context) error {
 	return nil
 }
 
+// RegisterEmailVerificationCode registers a new email verification code for
+// the user identified by the email provided, whose email must be pending of
+// verification. The code is sent to the user by email, replacing the previous
+// one. New codes cannot be requested too often.
+func (m *Manager) RegisterEmailVerificationCode(ctx context.Context, userEmail string) error {
+	// Validate input
+	if userEmail == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "email not provided")
+	}
+
+	// Register email verification code in database
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return err
+	}
+	code := base64.URLEncoding.EncodeToString(randomBytes)
+	var registered bool
+	err := m.db.QueryRow(ctx, registerEmailVerificationCodeDBQ, userEmail, hash(code)).Scan(&registered)
+	if err != nil {
+		return err
+	}
+	if !registered {
+		return fmt.Errorf("%w: %s", hub.ErrTooManyRequests, "verification email sent recently")
+	}
+
+	// Send email verification code
+	if m.es != nil {
+		var locale string
+		if err := m.db.QueryRow(ctx, getUserLocaleFromEmailDBQ, userEmail).Scan(&locale); err != nil {
+			return err
+		}
+		return m.sendVerificationEmail(userEmail, locale, code)
+	}
+
+	return nil
+}
+
 // RegisterPasswordResetCode registers a code that allows the user identified
 // by the email provided to reset the password. A link containing the code will
 // be emailed to the user to initiate the password reset process.
@@ -563,15 +809,19 @@ func (m *Manager) RegisterPasswordResetCode(ctx context.Context, userEmail strin
 
 	// Send password reset email
 	if m.es != nil {
+		var locale string
+		if err := m.db.QueryRow(ctx, getUserLocaleFromEmailDBQ, userEmail).Scan(&locale); err != nil {
+			return err
+		}
 		templateData := baseTemplateData(m.cfg)
 		templateData["Link"] = fmt.Sprintf("%s/reset-password?code=%s", templateData["BaseURL"], code)
 		var emailBody bytes.Buffer
-		if err := m.tmpl[passwordResetEmail].Execute(&emailBody, templateData); err != nil {
+		if err := email.ExecuteTemplate(&emailBody, m.tmpl[passwordResetEmail], locale, templateData); err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
-			Subject: "Password reset",
+			Subject: email.Translate(locale, "password_reset.subject"),
 			Body:    emailBody.Bytes(),
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
@@ -593,18 +843,16 @@ func (m *Manager) RegisterSession(ctx context.Context, session *hub.Session) (*h
 	}
 
 	// Generate session id
-	randomBytes := make([]byte, 32)
-	if _, err := rand.Read(randomBytes); err != nil {
+	sessionID, err := newSessionID()
+	if err != nil {
 		return nil, err
 	}
-	sessionID := base64.StdEncoding.EncodeToString(randomBytes)
-	sessionIDHashed := hash(sessionID)
 
 	// Register session in database
-	session.SessionID = sessionIDHashed
+	session.SessionID = hash(sessionID)
 	sessionJSON, _ := json.Marshal(session)
 	var approved bool
-	err := m.db.QueryRow(ctx, registerSessionDBQ, sessionJSON).Scan(&approved)
+	err = m.db.QueryRow(ctx, registerSessionDBQ, sessionJSON).Scan(&approved)
 	if err != nil {
 		return nil, err
 	}
@@ -634,6 +882,12 @@ func (m *Manager) RegisterUser(ctx context.Context, user *hub.User) error {
 			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid profile image id")
 		}
 	}
+	if user.Locale != "" && !email.IsLocaleSupported(user.Locale) {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "unsupported locale")
+	}
+	if !isEmailDomainAllowed(m.cfg.GetStringSlice("server.allowedEmailDomains"), user.Email) {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "email domain not allowed")
+	}
 	if !user.EmailVerified {
 		if err := pwvalidator.Validate(user.Password, PasswordMinEntropyBits); err != nil {
 			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
@@ -649,30 +903,28 @@ func (m *Manager) RegisterUser(ctx context.Context, user *hub.User) error {
 		user.Password = string(hashedPassword)
 	}
 
+	// Prepare email verification code (only stored hashed)
+	var code string
+	var codeHash *string
+	if !user.EmailVerified {
+		randomBytes := make([]byte, 32)
+		if _, err := rand.Read(randomBytes); err != nil {
+			return err
+		}
+		code = base64.URLEncoding.EncodeToString(randomBytes)
+		h := hash(code)
+		codeHash = &h
+	}
+
 	// Register user in database
 	userJSON, _ := json.Marshal(user)
-	var code *string
-	err := m.db.QueryRow(ctx, registerUserDBQ, userJSON).Scan(&code)
-	if err != nil {
+	if _, err := m.db.Exec(ctx, registerUserDBQ, userJSON, codeHash); err != nil {
 		return err
 	}
 
 	// Send email verification code
-	if code != nil && m.es != nil {
-		templateData := baseTemplateData(m.cfg)
-		templateData["Link"] = fmt.Sprintf("%s/verify-email?code=%s", templateData["BaseURL"], *code)
-		var emailBody bytes.Buffer
-		if err := m.tmpl[verificationEmail].Execute(&emailBody, templateData); err != nil {
-			return err
-		}
-		emailData := &email.Data{
-			To:      user.Email,
-			Subject: "Verify your email address",
-			Body:    emailBody.Bytes(),
-		}
-		if err := m.es.SendEmail(emailData); err != nil {
-			return err
-		}
+	if code != "" && m.es != nil {
+		return m.sendVerificationEmail(user.Email, user.Locale, code)
 	}
 
 	return nil
@@ -709,14 +961,18 @@ func (m *Manager) ResetPassword(ctx context.Context, code, newPassword string) e
 
 	// Send password reset success email
 	if m.es != nil {
+		var locale string
+		if err := m.db.QueryRow(ctx, getUserLocaleFromEmailDBQ, userEmail).Scan(&locale); err != nil {
+			return err
+		}
 		templateData := baseTemplateData(m.cfg)
 		var emailBody bytes.Buffer
-		if err := m.tmpl[passwordResetSuccessEmail].Execute(&emailBody, templateData); err != nil {
+		if err := email.ExecuteTemplate(&emailBody, m.tmpl[passwordResetSuccessEmail], locale, templateData); err != nil {
 			return err
 		}
 		emailData := &email.Data{
 			To:      userEmail,
-			Subject: "Your password has been reset",
+			Subject: email.Translate(locale, "password_reset_success.subject"),
 			Body:    emailBody.Bytes(),
 		}
 		if err := m.es.SendEmail(emailData); err != nil {
@@ -727,6 +983,53 @@ func (m *Manager) ResetPassword(ctx context.Context, code, newPassword string) e
 	return nil
 }
 
+// ResetTFA disables two-factor authentication for the provided user, so that
+// they can set it up again. This is used by site admins to help users who
+// have lost access to their TFA devices and recovery codes.
+func (m *Manager) ResetTFA(ctx context.Context, userID string, info *hub.AdminAuditInfo) error {
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+
+	// Reset user TFA in database
+	infoJSON, _ := json.Marshal(info)
+	if _, err := m.db.Exec(ctx, resetUserTFADBQ, userID, infoJSON); err != nil {
+		if err.Error() == errUserNotFoundDB.Error() {
+			return hub.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// SearchJSON returns a list of users that match the criteria provided. The
+// result is returned as a json object.
+func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchUsersInput) (*hub.JSONQueryResult, error) {
+	// Search users in database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSONWithPagination(ctx, m.db, searchUsersDBQ, inputJSON)
+}
+
+// SetDisabled disables or enables the provided user. Disabled users cannot
+// log in, and all their sessions and api keys stop being valid.
+func (m *Manager) SetDisabled(ctx context.Context, userID string, disabled bool, info *hub.AdminAuditInfo) error {
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+
+	// Update user in database
+	infoJSON, _ := json.Marshal(info)
+	if _, err := m.db.Exec(ctx, setUserDisabledDBQ, userID, disabled, infoJSON); err != nil {
+		if err.Error() == errUserNotFoundDB.Error() {
+			return hub.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
 // SetupTFA sets up two-factor authentication for the requesting user. This
 // generates a new TOTP key and some recovery codes for the user and stores
 // them in the database. To complete the process, the user must enable TFA
@@ -781,6 +1084,25 @@ func (m *Manager) SetupTFA(ctx context.Context) ([]byte, error) {
 	return json.Marshal(output)
 }
 
+// UnlinkIdentity unlinks the identity of the provider given from the account of
+// the user doing the request. The last sign in method available cannot be
+// unlinked.
+func (m *Manager) UnlinkIdentity(ctx context.Context, provider string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if provider == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "provider not provided")
+	}
+
+	// Unlink identity in database
+	_, err := m.db.Exec(ctx, unlinkUserIdentityDBQ, userID, provider)
+	if err != nil && err.Error() == errLastSignInMethodDB.Error() {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "last sign in method cannot be unlinked")
+	}
+	return err
+}
+
 // UpdatePassword updates the user password in the database.
 func (m *Manager) UpdatePassword(ctx context.Context, old, new string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -831,13 +1153,30 @@ func (m *Manager) UpdateProfile(ctx context.Context, user *hub.User) error {
 			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid profile image id")
 		}
 	}
+	if user.Locale != "" && !email.IsLocaleSupported(user.Locale) {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "unsupported locale")
+	}
 
 	// Update user profile in database
 	userJSON, _ := json.Marshal(user)
 	_, err := m.db.Exec(ctx, updateUserProfileDBQ, userID, userJSON)
+	if err != nil && err.Error() == util.ErrDBVersionConflict.Error() {
+		return fmt.Errorf("%w: %s", hub.ErrConflict, "profile modified since it was read")
+	}
 	return err
 }
 
+// VerifyAdminAuditLog checks that the entries of the admin audit log have not
+// been tampered with. It returns the id of the first invalid entry found, or
+// an empty string when the whole log is valid.
+func (m *Manager) VerifyAdminAuditLog(ctx context.Context) (string, error) {
+	var invalidEntryID string
+	if err := m.db.QueryRow(ctx, verifyAdminAuditLogDBQ).Scan(&invalidEntryID); err != nil {
+		return "", err
+	}
+	return invalidEntryID, nil
+}
+
 // VerifyEmail verifies a user's email using the email verification code
 // provided.
 func (m *Manager) VerifyEmail(ctx context.Context, code string) (bool, error) {
@@ -849,7 +1188,7 @@ func (m *Manager) VerifyEmail(ctx context.Context, code string) (bool, error) {
 	}
 
 	// Verify email in database
-	err := m.db.QueryRow(ctx, verifyEmailDBQ, code).Scan(&verified)
+	err := m.db.QueryRow(ctx, verifyEmailDBQ, hash(code)).Scan(&verified)
 	return verified, err
 }
 
@@ -868,11 +1207,56 @@ func (m *Manager) VerifyPasswordResetCode(ctx context.Context, code string) erro
 	return err
 }
 
+// sendVerificationEmail sends an email to the address provided containing a
+// link to verify it using the code given.
+func (m *Manager) sendVerificationEmail(userEmail, locale, code string) error {
+	templateData := baseTemplateData(m.cfg)
+	templateData["Link"] = fmt.Sprintf("%s/verify-email?code=%s", templateData["BaseURL"], code)
+	var emailBody bytes.Buffer
+	if err := email.ExecuteTemplate(&emailBody, m.tmpl[verificationEmail], locale, templateData); err != nil {
+		return err
+	}
+	emailData := &email.Data{
+		To:      userEmail,
+		Subject: email.Translate(locale, "verification.subject"),
+		Body:    emailBody.Bytes(),
+	}
+	return m.es.SendEmail(emailData)
+}
+
 // hash is a helper function that creates a sha512 hash of the text provided.
 func hash(text string) string {
 	return fmt.Sprintf("%x", sha512.Sum512([]byte(text)))
 }
 
+// isEmailDomainAllowed checks if the domain of the email provided is in the
+// list of allowed domains. All domains are allowed when the list is empty.
+func isEmailDomainAllowed(allowedDomains []string, emailAddress string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+	i := strings.LastIndex(emailAddress, "@")
+	if i == -1 {
+		return false
+	}
+	domain := emailAddress[i+1:]
+	for _, allowedDomain := range allowedDomains {
+		if strings.EqualFold(strings.TrimSpace(allowedDomain), domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// newSessionID generates a new random session id.
+func newSessionID() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(randomBytes), nil
+}
+
 // isValidRecoveryCode checks if the code provided is a valid recovery code.
 func isValidRecoveryCode(recoveryCodes []string, code string) bool {
 	for _, recoveryCode := range recoveryCodes {
@@ -11,6 +11,7 @@ import (
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
 	"github.com/jackc/pgx/v4"
 	"github.com/pquerna/otp/totp"
 	"github.com/satori/uuid"
@@ -364,6 +365,8 @@ func TestCheckSession(t *testing.T) {
 			"userID",
 			int64(1),
 			true,
+			false,
+			"192.168.1.1",
 		}, nil)
 		m := NewManager(cfg, db, nil)
 
@@ -381,6 +384,8 @@ func TestCheckSession(t *testing.T) {
 			"userID",
 			time.Now().Unix(),
 			false,
+			false,
+			"192.168.1.1",
 		}, nil)
 		m := NewManager(cfg, db, nil)
 
@@ -391,6 +396,25 @@ func TestCheckSession(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("impersonation session has expired", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSessionDBQ, hashedSessionID).Return([]interface{}{
+			"userID",
+			time.Now().Add(-2 * ImpersonationSessionDuration).Unix(),
+			true,
+			true,
+			"192.168.1.1",
+		}, nil)
+		m := NewManager(cfg, db, nil)
+
+		output, err := m.CheckSession(ctx, sessionID, 30*24*time.Hour)
+		assert.NoError(t, err)
+		assert.False(t, output.Valid)
+		assert.Empty(t, output.UserID)
+		db.AssertExpectations(t)
+	})
+
 	t.Run("valid session", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
@@ -398,6 +422,8 @@ func TestCheckSession(t *testing.T) {
 			"userID",
 			time.Now().Unix(),
 			true,
+			false,
+			"192.168.1.1",
 		}, nil)
 		m := NewManager(cfg, db, nil)
 
@@ -405,6 +431,41 @@ func TestCheckSession(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, output.Valid)
 		assert.Equal(t, "userID", output.UserID)
+		assert.Equal(t, "192.168.1.1", output.IP)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDeleteEmailSuppression(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.DeleteEmailSuppression(context.Background())
+		})
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteEmailSuppressionDBQ, "userID").Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.DeleteEmailSuppression(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("email suppression deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteEmailSuppressionDBQ, "userID").Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		err := m.DeleteEmailSuppression(ctx)
+		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
 }
@@ -495,6 +556,7 @@ func TestDeleteUser(t *testing.T) {
 	t.Run("error sending account deleted email nofication", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserLocaleDBQ, "userID").Return("", nil)
 		db.On("QueryRow", ctx, deleteUserDBQ, "userID", codeHashed).Return("email", nil)
 		es := &email.SenderMock{}
 		es.On("SendEmail", mock.Anything).Return(email.ErrFakeSenderFailure)
@@ -508,9 +570,12 @@ func TestDeleteUser(t *testing.T) {
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserLocaleDBQ, "userID").Return("es", nil)
 		db.On("QueryRow", ctx, deleteUserDBQ, "userID", codeHashed).Return("email", nil)
 		es := &email.SenderMock{}
-		es.On("SendEmail", mock.Anything).Return(nil)
+		es.On("SendEmail", mock.MatchedBy(func(d *email.Data) bool {
+			return d.Subject == "Tu cuenta ha sido eliminada"
+		})).Return(nil)
 		m := NewManager(cfg, db, es)
 
 		err := m.DeleteUser(ctx, code)
@@ -591,6 +656,7 @@ func TestDisableTFA(t *testing.T) {
 		db.On("QueryRow", ctx, getTFAConfigDBQ, "userID").Return(tfaConfigJSON, nil)
 		db.On("Exec", ctx, disableTFADBQ, "userID").Return(nil)
 		db.On("QueryRow", ctx, getUserEmailDBQ, "userID").Return("email", nil)
+		db.On("QueryRow", ctx, getUserLocaleDBQ, "userID").Return("", nil)
 		es := &email.SenderMock{}
 		es.On("SendEmail", mock.Anything).Return(email.ErrFakeSenderFailure)
 		m := NewManager(cfg, db, es)
@@ -607,6 +673,7 @@ func TestDisableTFA(t *testing.T) {
 		db.On("QueryRow", ctx, getTFAConfigDBQ, "userID").Return(tfaConfigJSON, nil)
 		db.On("Exec", ctx, disableTFADBQ, "userID").Return(nil)
 		db.On("QueryRow", ctx, getUserEmailDBQ, "userID").Return("email", nil)
+		db.On("QueryRow", ctx, getUserLocaleDBQ, "userID").Return("", nil)
 		es := &email.SenderMock{}
 		es.On("SendEmail", mock.Anything).Return(nil)
 		m := NewManager(cfg, db, es)
@@ -700,6 +767,7 @@ func TestEnableTFA(t *testing.T) {
 		db.On("QueryRow", ctx, getTFAConfigDBQ, "userID").Return(tfaConfigJSON, nil)
 		db.On("Exec", ctx, enableTFADBQ, "userID").Return(nil)
 		db.On("QueryRow", ctx, getUserEmailDBQ, "userID").Return("email", nil)
+		db.On("QueryRow", ctx, getUserLocaleDBQ, "userID").Return("", nil)
 		es := &email.SenderMock{}
 		es.On("SendEmail", mock.Anything).Return(email.ErrFakeSenderFailure)
 		m := NewManager(cfg, db, es)
@@ -716,6 +784,7 @@ func TestEnableTFA(t *testing.T) {
 		db.On("QueryRow", ctx, getTFAConfigDBQ, "userID").Return(tfaConfigJSON, nil)
 		db.On("Exec", ctx, enableTFADBQ, "userID").Return(nil)
 		db.On("QueryRow", ctx, getUserEmailDBQ, "userID").Return("email", nil)
+		db.On("QueryRow", ctx, getUserLocaleDBQ, "userID").Return("", nil)
 		es := &email.SenderMock{}
 		es.On("SendEmail", mock.Anything).Return(nil)
 		m := NewManager(cfg, db, es)
@@ -727,6 +796,133 @@ func TestEnableTFA(t *testing.T) {
 	})
 }
 
+func TestGetAdminAuditLogJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.GetAdminAuditLogInput
+		}{
+			{
+				"invalid from",
+				&hub.GetAdminAuditLogInput{From: "2021-01-01"},
+			},
+			{
+				"invalid to",
+				&hub.GetAdminAuditLogInput{To: "invalid"},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil)
+				_, err := m.GetAdminAuditLogJSON(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAdminAuditLogDBQ, mock.Anything).Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil)
+
+		input := &hub.GetAdminAuditLogInput{From: "2021-01-01T00:00:00Z"}
+		data, err := m.GetAdminAuditLogJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAdminAuditLogDBQ, mock.Anything).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		data, err := m.GetAdminAuditLogJSON(ctx, &hub.GetAdminAuditLogInput{})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, data)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetEmailSuppressionJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetEmailSuppressionJSON(context.Background())
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getEmailSuppressionDBQ, "userID").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil)
+
+		data, err := m.GetEmailSuppressionJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getEmailSuppressionDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		data, err := m.GetEmailSuppressionJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, data)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetIdentitiesJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetIdentitiesJSON(context.Background())
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIdentitiesDBQ, "userID").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil)
+
+		data, err := m.GetIdentitiesJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIdentitiesDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		data, err := m.GetIdentitiesJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, data)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetProfile(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -819,6 +1015,41 @@ func TestGetProfileJSON(t *testing.T) {
 	})
 }
 
+func TestGetPublicProfileJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		_, err := m.GetPublicProfileJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserPublicProfileDBQ, "user1").Return([]byte("dataJSON"), nil)
+		m := NewManager(cfg, db, nil)
+
+		data, err := m.GetPublicProfileJSON(ctx, "user1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), data)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserPublicProfileDBQ, "user1").Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		data, err := m.GetPublicProfileJSON(ctx, "user1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, data)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetUserID(t *testing.T) {
 	ctx := context.Background()
 
@@ -847,9 +1078,254 @@ func TestGetUserID(t *testing.T) {
 		db.On("QueryRow", ctx, getUserIDFromEmailDBQ, "email").Return("", tests.ErrFakeDB)
 		m := NewManager(cfg, db, nil)
 
-		userID, err := m.GetUserID(ctx, "email")
-		assert.Equal(t, tests.ErrFakeDB, err)
-		assert.Empty(t, userID)
+		userID, err := m.GetUserID(ctx, "email")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Empty(t, userID)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetUserIDFromIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			provider string
+			subject  string
+		}{
+			{
+				"provider not provided",
+				"",
+				"1",
+			},
+			{
+				"subject not provided",
+				"github",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil)
+				_, err := m.GetUserIDFromIdentity(ctx, tc.provider, tc.subject)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromIdentityDBQ, "github", "1").Return("userID", nil)
+		m := NewManager(cfg, db, nil)
+
+		userID, err := m.GetUserIDFromIdentity(ctx, "github", "1")
+		assert.NoError(t, err)
+		assert.Equal(t, "userID", userID)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("identity not linked", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromIdentityDBQ, "github", "1").Return("", pgx.ErrNoRows)
+		m := NewManager(cfg, db, nil)
+
+		userID, err := m.GetUserIDFromIdentity(ctx, "github", "1")
+		assert.Equal(t, ErrNotFound, err)
+		assert.Empty(t, userID)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserIDFromIdentityDBQ, "github", "1").Return("", tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		userID, err := m.GetUserIDFromIdentity(ctx, "github", "1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Empty(t, userID)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestImpersonate(t *testing.T) {
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+	info := &hub.AdminAuditInfo{Reason: "support", IP: "192.168.1.100"}
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			userID string
+			info   *hub.AdminAuditInfo
+		}{
+			{
+				"invalid user id",
+				"invalid",
+				info,
+			},
+			{
+				"reason not provided",
+				userID,
+				nil,
+			},
+			{
+				"reason not provided",
+				userID,
+				&hub.AdminAuditInfo{},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil)
+				_, err := m.Impersonate(ctx, tc.userID, tc.info)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				errUserNotFoundDB,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, registerImpersonationDBQ, mock.Anything, mock.Anything).Return(tc.dbErr)
+				m := NewManager(cfg, db, nil)
+
+				s, err := m.Impersonate(ctx, userID, info)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, s)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("impersonation session registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerImpersonationDBQ, mock.Anything, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		s, err := m.Impersonate(ctx, userID, info)
+		require.NoError(t, err)
+		assert.NotEmpty(t, s.SessionID)
+		assert.Equal(t, userID, s.UserID)
+		assert.True(t, s.Approved)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestLinkIdentity(t *testing.T) {
+	ctx := context.Background()
+	identity := &hub.UserIdentity{
+		UserID:   "userID",
+		Provider: "github",
+		Subject:  "1",
+		Email:    "user1@email.com",
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			identity *hub.UserIdentity
+		}{
+			{
+				"user id not provided",
+				&hub.UserIdentity{},
+			},
+			{
+				"provider not provided",
+				&hub.UserIdentity{UserID: "userID"},
+			},
+			{
+				"subject not provided",
+				&hub.UserIdentity{UserID: "userID", Provider: "github"},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(cfg, nil, nil)
+				err := m.LinkIdentity(ctx, tc.identity)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr          error
+			expectedErrMsg string
+		}{
+			{
+				errIdentityAlreadyLinkedDB,
+				"identity already linked to another user",
+			},
+			{
+				errProviderAlreadyLinkedDB,
+				"provider already linked",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.expectedErrMsg, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, linkUserIdentityDBQ, mock.Anything).Return(tc.dbErr)
+				m := NewManager(cfg, db, nil)
+
+				err := m.LinkIdentity(ctx, identity)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				db.AssertExpectations(t)
+			})
+		}
+
+		t.Run("unexpected error", func(t *testing.T) {
+			t.Parallel()
+			db := &tests.DBMock{}
+			db.On("Exec", ctx, linkUserIdentityDBQ, mock.Anything).Return(tests.ErrFakeDB)
+			m := NewManager(cfg, db, nil)
+
+			err := m.LinkIdentity(ctx, identity)
+			assert.Equal(t, tests.ErrFakeDB, err)
+			db.AssertExpectations(t)
+		})
+	})
+
+	t.Run("identity linked successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, linkUserIdentityDBQ, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		err := m.LinkIdentity(ctx, identity)
+		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
 }
@@ -881,6 +1357,7 @@ func TestRegisterDeleteUserCode(t *testing.T) {
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, registerDeleteUserCodeDBQ, "userID", mock.Anything).Return(nil)
 		db.On("QueryRow", ctx, getUserEmailDBQ, "userID").Return("email", nil)
+		db.On("QueryRow", ctx, getUserLocaleDBQ, "userID").Return("", nil)
 		es := &email.SenderMock{}
 		es.On("SendEmail", mock.Anything).Return(email.ErrFakeSenderFailure)
 		m := NewManager(cfg, db, es)
@@ -895,6 +1372,7 @@ func TestRegisterDeleteUserCode(t *testing.T) {
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, registerDeleteUserCodeDBQ, "userID", mock.Anything).Return(nil)
 		db.On("QueryRow", ctx, getUserEmailDBQ, "userID").Return("email", nil)
+		db.On("QueryRow", ctx, getUserLocaleDBQ, "userID").Return("", nil)
 		es := &email.SenderMock{}
 		es.On("SendEmail", mock.Anything).Return(nil)
 		m := NewManager(cfg, db, es)
@@ -906,6 +1384,75 @@ func TestRegisterDeleteUserCode(t *testing.T) {
 	})
 }
 
+func TestRegisterEmailVerificationCode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		err := m.RegisterEmailVerificationCode(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "email not provided")
+	})
+
+	t.Run("successful email verification code registration in database", func(t *testing.T) {
+		testCases := []struct {
+			description         string
+			emailSenderResponse error
+		}{
+			{
+				"email verification code sent successfully",
+				nil,
+			},
+			{
+				"error sending email verification code",
+				email.ErrFakeSenderFailure,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, registerEmailVerificationCodeDBQ, "email@email.com", mock.Anything).Return(true, nil)
+				db.On("QueryRow", ctx, getUserLocaleFromEmailDBQ, "email@email.com").Return("", nil)
+				es := &email.SenderMock{}
+				es.On("SendEmail", mock.Anything).Return(tc.emailSenderResponse)
+				m := NewManager(cfg, db, es)
+
+				err := m.RegisterEmailVerificationCode(ctx, "email@email.com")
+				assert.Equal(t, tc.emailSenderResponse, err)
+				db.AssertExpectations(t)
+				es.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("email verification code requested too recently", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerEmailVerificationCodeDBQ, "email@email.com", mock.Anything).Return(false, nil)
+		es := &email.SenderMock{}
+		m := NewManager(cfg, db, es)
+
+		err := m.RegisterEmailVerificationCode(ctx, "email@email.com")
+		assert.True(t, errors.Is(err, hub.ErrTooManyRequests))
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("database error registering email verification code", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerEmailVerificationCodeDBQ, "email@email.com", mock.Anything).Return(false, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.RegisterEmailVerificationCode(ctx, "email@email.com")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestRegisterPasswordResetCode(t *testing.T) {
 	ctx := context.Background()
 
@@ -953,6 +1500,7 @@ func TestRegisterPasswordResetCode(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
 				db.On("Exec", ctx, registerPasswordResetCodeDBQ, "email@email.com", mock.Anything).Return(nil)
+				db.On("QueryRow", ctx, getUserLocaleFromEmailDBQ, "email@email.com").Return("", nil)
 				es := &email.SenderMock{}
 				es.On("SendEmail", mock.Anything).Return(tc.emailSenderResponse)
 				m := NewManager(cfg, db, es)
@@ -1062,6 +1610,10 @@ func TestRegisterUser(t *testing.T) {
 				"invalid profile image id",
 				&hub.User{Alias: "user1", Email: "email", ProfileImageID: "invalid"},
 			},
+			{
+				"unsupported locale",
+				&hub.User{Alias: "user1", Email: "email", Locale: "xx"},
+			},
 			{
 				"insecure password",
 				&hub.User{Alias: "user1", Email: "email", Password: "hello"},
@@ -1081,8 +1633,19 @@ func TestRegisterUser(t *testing.T) {
 		}
 	})
 
+	t.Run("email domain not allowed", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.allowedEmailDomains", []string{"example.com"})
+		m := NewManager(cfg, nil, nil)
+
+		u := &hub.User{Alias: "user1", Email: "user1@other.com", Password: password}
+		err := m.RegisterUser(ctx, u)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "email domain not allowed")
+	})
+
 	t.Run("successful user registration in database", func(t *testing.T) {
-		code := "emailVerificationCode"
 		testCases := []struct {
 			description         string
 			emailSenderResponse error
@@ -1101,7 +1664,7 @@ func TestRegisterUser(t *testing.T) {
 			t.Run(tc.description, func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("QueryRow", ctx, registerUserDBQ, mock.Anything).Return(&code, nil)
+				db.On("Exec", ctx, registerUserDBQ, mock.Anything, mock.Anything).Return(nil)
 				es := &email.SenderMock{}
 				es.On("SendEmail", mock.Anything).Return(tc.emailSenderResponse)
 				m := NewManager(cfg, db, es)
@@ -1122,11 +1685,28 @@ func TestRegisterUser(t *testing.T) {
 		}
 	})
 
+	t.Run("user with verified email registered without sending any email", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerUserDBQ, mock.Anything, (*string)(nil)).Return(nil)
+		es := &email.SenderMock{}
+		m := NewManager(cfg, db, es)
+
+		u := &hub.User{
+			Alias:         "alias",
+			Email:         "email@email.com",
+			EmailVerified: true,
+		}
+		err := m.RegisterUser(ctx, u)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
 	t.Run("database error registering user", func(t *testing.T) {
 		t.Parallel()
-		code := ""
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, registerUserDBQ, mock.Anything).Return(&code, tests.ErrFakeDB)
+		db.On("Exec", ctx, registerUserDBQ, mock.Anything, mock.Anything).Return(tests.ErrFakeDB)
 		m := NewManager(cfg, db, nil)
 
 		u := &hub.User{
@@ -1230,6 +1810,7 @@ func TestResetPassword(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
 				db.On("QueryRow", ctx, resetUserPasswordDBQ, codeHashed, mock.Anything).Return("email", nil)
+				db.On("QueryRow", ctx, getUserLocaleFromEmailDBQ, "email").Return("", nil)
 				es := &email.SenderMock{}
 				es.On("SendEmail", mock.Anything).Return(tc.emailSenderResponse)
 				m := NewManager(cfg, db, es)
@@ -1243,6 +1824,143 @@ func TestResetPassword(t *testing.T) {
 	})
 }
 
+func TestResetTFA(t *testing.T) {
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+	info := &hub.AdminAuditInfo{Reason: "device lost"}
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		err := m.ResetTFA(ctx, "invalid", info)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				errUserNotFoundDB,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, resetUserTFADBQ, userID, mock.Anything).Return(tc.dbErr)
+				m := NewManager(cfg, db, nil)
+
+				err := m.ResetTFA(ctx, userID, info)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("tfa reset successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, resetUserTFADBQ, userID, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		err := m.ResetTFA(ctx, userID, info)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestSearchJSON(t *testing.T) {
+	ctx := context.Background()
+	input := &hub.SearchUsersInput{TSQueryWeb: "user1", Limit: 10}
+	inputJSON, _ := json.Marshal(input)
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchUsersDBQ, inputJSON).Return([]interface{}{[]byte("dataJSON"), 1}, nil)
+		m := NewManager(cfg, db, nil)
+
+		result, err := m.SearchJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), result.Data)
+		assert.Equal(t, 1, result.TotalCount)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchUsersDBQ, inputJSON).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		result, err := m.SearchJSON(ctx, input)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, result)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestSetDisabled(t *testing.T) {
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+	info := &hub.AdminAuditInfo{Reason: "spam"}
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		err := m.SetDisabled(ctx, "invalid", true, info)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				errUserNotFoundDB,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, setUserDisabledDBQ, userID, true, mock.Anything).Return(tc.dbErr)
+				m := NewManager(cfg, db, nil)
+
+				err := m.SetDisabled(ctx, userID, true, info)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user disabled successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setUserDisabledDBQ, userID, true, mock.Anything).Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		err := m.SetDisabled(ctx, userID, true, info)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestSetupTFA(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -1302,6 +2020,60 @@ func TestSetupTFA(t *testing.T) {
 	})
 }
 
+func TestUnlinkIdentity(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.UnlinkIdentity(context.Background(), "github")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(cfg, nil, nil)
+		err := m.UnlinkIdentity(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "provider not provided")
+	})
+
+	t.Run("last sign in method", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unlinkUserIdentityDBQ, "userID", "github").Return(errLastSignInMethodDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.UnlinkIdentity(ctx, "github")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "last sign in method cannot be unlinked")
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unlinkUserIdentityDBQ, "userID", "github").Return(tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		err := m.UnlinkIdentity(ctx, "github")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("identity unlinked successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unlinkUserIdentityDBQ, "userID", "github").Return(nil)
+		m := NewManager(cfg, db, nil)
+
+		err := m.UnlinkIdentity(ctx, "github")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestUpdatePassword(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 	oldHashed, _ := bcrypt.GenerateFromPassword([]byte("old"), bcrypt.DefaultCost)
@@ -1421,6 +2193,10 @@ func TestUpdateProfile(t *testing.T) {
 				"invalid profile image id",
 				&hub.User{Alias: "user1", Email: "email", ProfileImageID: "invalid"},
 			},
+			{
+				"unsupported locale",
+				&hub.User{Alias: "user1", Locale: "xx"},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -1455,6 +2231,44 @@ func TestUpdateProfile(t *testing.T) {
 		assert.Equal(t, tests.ErrFakeDB, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateUserProfileDBQ, "userID", mock.Anything).Return(util.ErrDBVersionConflict)
+		m := NewManager(cfg, db, nil)
+
+		err := m.UpdateProfile(ctx, &hub.User{Alias: "user1", Version: 1})
+		assert.True(t, errors.Is(err, hub.ErrConflict))
+		db.AssertExpectations(t)
+	})
+}
+
+func TestVerifyAdminAuditLog(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, verifyAdminAuditLogDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(cfg, db, nil)
+
+		_, err := m.VerifyAdminAuditLog(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, verifyAdminAuditLogDBQ).Return("entryID", nil)
+		m := NewManager(cfg, db, nil)
+
+		invalidEntryID, err := m.VerifyAdminAuditLog(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "entryID", invalidEntryID)
+		db.AssertExpectations(t)
+	})
 }
 
 func TestVerifyEmail(t *testing.T) {
@@ -1470,7 +2284,7 @@ func TestVerifyEmail(t *testing.T) {
 	t.Run("successful email verification", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, verifyEmailDBQ, "emailVerificationCode").Return(true, nil)
+		db.On("QueryRow", ctx, verifyEmailDBQ, hash("emailVerificationCode")).Return(true, nil)
 		m := NewManager(cfg, db, nil)
 
 		verified, err := m.VerifyEmail(ctx, "emailVerificationCode")
@@ -1482,7 +2296,7 @@ func TestVerifyEmail(t *testing.T) {
 	t.Run("database error verifying email", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, verifyEmailDBQ, "emailVerificationCode").Return(false, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, verifyEmailDBQ, hash("emailVerificationCode")).Return(false, tests.ErrFakeDB)
 		m := NewManager(cfg, db, nil)
 
 		verified, err := m.VerifyEmail(ctx, "emailVerificationCode")
@@ -1559,3 +2373,25 @@ func TestVerifyPasswordResetCode(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 }
+
+func TestIsEmailDomainAllowed(t *testing.T) {
+	testCases := []struct {
+		allowedDomains []string
+		email          string
+		expectedResult bool
+	}{
+		{nil, "user1@email.com", true},
+		{[]string{"email.com"}, "user1@email.com", true},
+		{[]string{"other.com", " Email.com "}, "user1@EMAIL.com", true},
+		{[]string{"email.com"}, "user1@sub.email.com", false},
+		{[]string{"email.com"}, "user1@other.com", false},
+		{[]string{"email.com"}, "email.com", false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.email, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedResult, isEmailDomainAllowed(tc.allowedDomains, tc.email))
+		})
+	}
+}
@@ -47,6 +47,12 @@ func (m *ManagerMock) CheckSession(
 	return data, args.Error(1)
 }
 
+// DeleteEmailSuppression implements the UserManager interface.
+func (m *ManagerMock) DeleteEmailSuppression(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 // DeleteSession implements the UserManager interface.
 func (m *ManagerMock) DeleteSession(ctx context.Context, sessionID string) error {
 	args := m.Called(ctx, sessionID)
@@ -71,6 +77,27 @@ func (m *ManagerMock) EnableTFA(ctx context.Context, passcode string) error {
 	return args.Error(0)
 }
 
+// GetAdminAuditLogJSON implements the UserManager interface.
+func (m *ManagerMock) GetAdminAuditLogJSON(ctx context.Context, input *hub.GetAdminAuditLogInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetEmailSuppressionJSON implements the UserManager interface.
+func (m *ManagerMock) GetEmailSuppressionJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetIdentitiesJSON implements the UserManager interface.
+func (m *ManagerMock) GetIdentitiesJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetProfile implements the UserManager interface.
 func (m *ManagerMock) GetProfile(ctx context.Context) (*hub.User, error) {
 	args := m.Called(ctx)
@@ -85,18 +112,54 @@ func (m *ManagerMock) GetProfileJSON(ctx context.Context) ([]byte, error) {
 	return data, args.Error(1)
 }
 
+// GetPublicProfileJSON implements the UserManager interface.
+func (m *ManagerMock) GetPublicProfileJSON(ctx context.Context, userAlias string) ([]byte, error) {
+	args := m.Called(ctx, userAlias)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetUserID implements the UserManager interface.
 func (m *ManagerMock) GetUserID(ctx context.Context, email string) (string, error) {
 	args := m.Called(ctx)
 	return args.String(0), args.Error(1)
 }
 
+// GetUserIDFromIdentity implements the UserManager interface.
+func (m *ManagerMock) GetUserIDFromIdentity(ctx context.Context, provider, subject string) (string, error) {
+	args := m.Called(ctx, provider, subject)
+	return args.String(0), args.Error(1)
+}
+
+// Impersonate implements the UserManager interface.
+func (m *ManagerMock) Impersonate(
+	ctx context.Context,
+	userID string,
+	info *hub.AdminAuditInfo,
+) (*hub.Session, error) {
+	args := m.Called(ctx, userID, info)
+	data, _ := args.Get(0).(*hub.Session)
+	return data, args.Error(1)
+}
+
+// LinkIdentity implements the UserManager interface.
+func (m *ManagerMock) LinkIdentity(ctx context.Context, identity *hub.UserIdentity) error {
+	args := m.Called(ctx, identity)
+	return args.Error(0)
+}
+
 // RegisterDeleteUserCode implements the UserManager interface.
 func (m *ManagerMock) RegisterDeleteUserCode(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
 }
 
+// RegisterEmailVerificationCode implements the UserManager interface.
+func (m *ManagerMock) RegisterEmailVerificationCode(ctx context.Context, userEmail string) error {
+	args := m.Called(ctx, userEmail)
+	return args.Error(0)
+}
+
 // RegisterPasswordResetCode implements the UserManager interface.
 func (m *ManagerMock) RegisterPasswordResetCode(ctx context.Context, userEmail string) error {
 	args := m.Called(ctx, userEmail)
@@ -122,6 +185,30 @@ func (m *ManagerMock) ResetPassword(ctx context.Context, code, newPassword strin
 	return args.Error(0)
 }
 
+// ResetTFA implements the UserManager interface.
+func (m *ManagerMock) ResetTFA(ctx context.Context, userID string, info *hub.AdminAuditInfo) error {
+	args := m.Called(ctx, userID, info)
+	return args.Error(0)
+}
+
+// SearchJSON implements the UserManager interface.
+func (m *ManagerMock) SearchJSON(ctx context.Context, input *hub.SearchUsersInput) (*hub.JSONQueryResult, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).(*hub.JSONQueryResult)
+	return data, args.Error(1)
+}
+
+// SetDisabled implements the UserManager interface.
+func (m *ManagerMock) SetDisabled(
+	ctx context.Context,
+	userID string,
+	disabled bool,
+	info *hub.AdminAuditInfo,
+) error {
+	args := m.Called(ctx, userID, disabled, info)
+	return args.Error(0)
+}
+
 // SetupTFA implements the UserManager interface.
 func (m *ManagerMock) SetupTFA(ctx context.Context) ([]byte, error) {
 	args := m.Called(ctx)
@@ -129,6 +216,12 @@ func (m *ManagerMock) SetupTFA(ctx context.Context) ([]byte, error) {
 	return data, args.Error(1)
 }
 
+// UnlinkIdentity implements the UserManager interface.
+func (m *ManagerMock) UnlinkIdentity(ctx context.Context, provider string) error {
+	args := m.Called(ctx, provider)
+	return args.Error(0)
+}
+
 // UpdatePassword implements the UserManager interface.
 func (m *ManagerMock) UpdatePassword(ctx context.Context, old, new string) error {
 	args := m.Called(ctx, old, new)
@@ -141,6 +234,12 @@ func (m *ManagerMock) UpdateProfile(ctx context.Context, user *hub.User) error {
 	return args.Error(0)
 }
 
+// VerifyAdminAuditLog implements the UserManager interface.
+func (m *ManagerMock) VerifyAdminAuditLog(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
 // VerifyEmail implements the UserManager interface.
 func (m *ManagerMock) VerifyEmail(ctx context.Context, code string) (bool, error) {
 	args := m.Called(ctx, code)
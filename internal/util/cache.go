@@ -0,0 +1,20 @@
+package util
+
+import (
+	"github.com/artifacthub/hub/internal/cache"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+)
+
+// SetupCache creates a new cache based on the configuration provided. The
+// cache is optional, so nil is returned when it has not been configured.
+func SetupCache(cfg *viper.Viper) (hub.Cache, error) {
+	if cfg.GetString("cache.redis.addr") == "" {
+		return nil, nil
+	}
+	c, err := cache.NewRedisCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -1,11 +1,22 @@
 package util
 
 import (
+	"context"
+	"os"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 )
 
+// defaultConfigWatchInterval represents how often the config file is checked
+// for changes when watching it has been enabled.
+const defaultConfigWatchInterval = 30 * time.Second
+
 // SetupConfig creates a new Viper instance to handle the configuration for a
 // particular cmd. Configuration can be provided in a config file or using env
 // variables. See configs folder for some examples.
@@ -28,3 +39,116 @@ func SetupConfig(cmd string) (*viper.Viper, error) {
 
 	return cfg, nil
 }
+
+// configReloadHandler represents a function in charge of applying some
+// settings from the configuration reloaded.
+type configReloadHandler struct {
+	name string
+	fn   func(cfg *viper.Viper) error
+}
+
+// ConfigReloader reloads the configuration of a cmd when a SIGHUP signal is
+// received or, when watching it has been enabled, when the config file
+// changes. The configuration reloaded is passed to the registered handlers,
+// so that they can apply the settings they are interested in without having
+// to restart the process. The configuration instance used to set up the cmd
+// is never modified, as viper does not support concurrent reads and writes.
+type ConfigReloader struct {
+	cmd      string
+	file     string
+	interval time.Duration
+	modTime  time.Time
+	setup    func(cmd string) (*viper.Viper, error)
+
+	mu       sync.Mutex
+	handlers []*configReloadHandler
+}
+
+// NewConfigReloader creates a new ConfigReloader instance for the cmd whose
+// configuration is provided.
+func NewConfigReloader(cfg *viper.Viper) *ConfigReloader {
+	r := &ConfigReloader{
+		cmd:   cfg.GetString("cmd"),
+		file:  cfg.ConfigFileUsed(),
+		setup: SetupConfig,
+	}
+	if cfg.GetBool("config.watch") {
+		r.interval = cfg.GetDuration("config.watchInterval")
+		if r.interval <= 0 {
+			r.interval = defaultConfigWatchInterval
+		}
+		r.modTime = r.fileModTime()
+	}
+	return r
+}
+
+// OnReload registers a handler that will be called with the new configuration
+// each time it's reloaded.
+func (r *ConfigReloader) OnReload(name string, fn func(cfg *viper.Viper) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, &configReloadHandler{name: name, fn: fn})
+}
+
+// Reload reads the configuration again and passes it to the registered
+// handlers. When the configuration cannot be read, the handlers are not
+// called and the settings currently in use are kept.
+func (r *ConfigReloader) Reload() {
+	cfg, err := r.setup(r.cmd)
+	if err != nil {
+		log.Error().Err(err).Msg("error reloading configuration")
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, h := range r.handlers {
+		if err := h.fn(cfg); err != nil {
+			log.Error().Err(err).Str("settings", h.name).Msg("error applying configuration reloaded")
+		}
+	}
+	log.Info().Msg("configuration reloaded")
+}
+
+// Run reloads the configuration when a SIGHUP signal is received or the
+// config file changes. It'll keep running until the context provided is done.
+func (r *ConfigReloader) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var watch <-chan time.Time
+	if r.interval > 0 && r.file != "" {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		watch = ticker.C
+	}
+
+	for {
+		select {
+		case <-hup:
+			r.Reload()
+		case <-watch:
+			// Config files mounted from Kubernetes secrets or config maps are
+			// updated replacing a symlink, so the file info is checked again
+			// on each tick instead of relying on filesystem events
+			if t := r.fileModTime(); !t.Equal(r.modTime) {
+				r.modTime = t
+				r.Reload()
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fileModTime returns the modification time of the config file. A zero time
+// is returned if the file info cannot be obtained.
+func (r *ConfigReloader) fileModTime() time.Time {
+	fi, err := os.Stat(r.file)
+	if err != nil {
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
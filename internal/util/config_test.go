@@ -1,11 +1,16 @@
 package util
 
 import (
+	"context"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -46,3 +51,87 @@ func TestSetupConfig(t *testing.T) {
 	assert.Equal(t, "value3", cfg.GetString("key3.extra"))
 	assert.Equal(t, "value4", cfg.GetString("key4-extra"))
 }
+
+func TestConfigReloader(t *testing.T) {
+	t.Run("watch interval", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		assert.Equal(t, time.Duration(0), NewConfigReloader(cfg).interval)
+		cfg.Set("config.watch", true)
+		assert.Equal(t, defaultConfigWatchInterval, NewConfigReloader(cfg).interval)
+		cfg.Set("config.watchInterval", "1m")
+		assert.Equal(t, 1*time.Minute, NewConfigReloader(cfg).interval)
+	})
+
+	t.Run("handlers not called when configuration cannot be read", func(t *testing.T) {
+		t.Parallel()
+		r := NewConfigReloader(viper.New())
+		r.setup = func(cmd string) (*viper.Viper, error) {
+			return nil, errors.New("fake error")
+		}
+		r.OnReload("test", func(cfg *viper.Viper) error {
+			t.Fatal("handler should not be called")
+			return nil
+		})
+		r.Reload()
+	})
+
+	t.Run("all handlers called even if some fail", func(t *testing.T) {
+		t.Parallel()
+		newCfg := viper.New()
+		r := NewConfigReloader(viper.New())
+		r.setup = func(cmd string) (*viper.Viper, error) {
+			return newCfg, nil
+		}
+		var calls []string
+		r.OnReload("h1", func(cfg *viper.Viper) error {
+			assert.Equal(t, newCfg, cfg)
+			calls = append(calls, "h1")
+			return errors.New("fake error")
+		})
+		r.OnReload("h2", func(cfg *viper.Viper) error {
+			calls = append(calls, "h2")
+			return nil
+		})
+		r.Reload()
+		assert.Equal(t, []string{"h1", "h2"}, calls)
+	})
+
+	t.Run("configuration reloaded when config file changes", func(t *testing.T) {
+		t.Parallel()
+		file := filepath.Join(t.TempDir(), "test.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("key: value1"), 0600))
+		cfg := viper.New()
+		cfg.SetConfigFile(file)
+		require.NoError(t, cfg.ReadInConfig())
+		cfg.Set("config.watch", true)
+		cfg.Set("config.watchInterval", "10ms")
+		r := NewConfigReloader(cfg)
+		r.setup = func(cmd string) (*viper.Viper, error) {
+			newCfg := viper.New()
+			newCfg.SetConfigFile(file)
+			return newCfg, newCfg.ReadInConfig()
+		}
+		reloaded := make(chan string, 1)
+		r.OnReload("test", func(cfg *viper.Viper) error {
+			reloaded <- cfg.GetString("key")
+			return nil
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go r.Run(ctx, &wg)
+
+		modTime := time.Now().Add(time.Minute)
+		require.NoError(t, os.WriteFile(file, []byte("key: value2"), 0600))
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+		select {
+		case value := <-reloaded:
+			assert.Equal(t, "value2", value)
+		case <-time.After(5 * time.Second):
+			t.Fatal("configuration should have been reloaded")
+		}
+		cancel()
+		wg.Wait()
+	})
+}
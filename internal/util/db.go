@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgconn/stmtcache"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/log/zerologadapter"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -19,33 +22,100 @@ const (
 	// DBLockKeyUpdatePackagesViews represents the lock key used when updating
 	// the packages views counters in the database.
 	DBLockKeyUpdatePackagesViews = 1
+
+	// DBLockKeyRefreshPackagesRankings represents the lock key used when
+	// refreshing the packages rankings in the database.
+	DBLockKeyRefreshPackagesRankings = 2
+
+	// DBLockKeyArchiveEvents represents the lock key used when archiving the
+	// events partitions in the database.
+	DBLockKeyArchiveEvents = 3
+
+	// DBLockKeyRegisterEOLEvents represents the lock key used when registering
+	// the packages versions end of life events in the database.
+	DBLockKeyRegisterEOLEvents = 4
+
+	// DBLockKeyUpdateAPIKeysUsage represents the lock key used when updating
+	// the api keys usage counters in the database.
+	DBLockKeyUpdateAPIKeysUsage = 5
 )
 
 var (
 	// ErrDBInsufficientPrivilege indicates that the user does not have the
 	// required privilege to perform the operation.
 	ErrDBInsufficientPrivilege = errors.New("ERROR: insufficient_privilege (SQLSTATE 42501)")
+
+	// ErrDBVersionConflict indicates that the resource being updated has been
+	// modified since the version provided was read.
+	ErrDBVersionConflict = errors.New("ERROR: version conflict (SQLSTATE P0001)")
+)
+
+const (
+	// dbContentBlockedErrPrefix represents the prefix of the error returned
+	// from the database when the content requested has been blocked. The
+	// reason why the content was blocked follows the prefix.
+	dbContentBlockedErrPrefix = "ERROR: content blocked: "
+
+	// dbSnapshotNotScannableErrPrefix represents the prefix of the error
+	// returned from the database when a scan is requested for a snapshot that
+	// is excluded from scanning. The reason why it is excluded follows it.
+	dbSnapshotNotScannableErrPrefix = "ERROR: snapshot not scannable: "
+
+	// dbRaiseErrSuffix represents the suffix of the errors raised by the
+	// database functions.
+	dbRaiseErrSuffix = " (SQLSTATE P0001)"
+
+	// Database connections pool defaults
+	dbDefaultMaxConns               = 50
+	dbDefaultStatementCacheMode     = "prepare"
+	dbDefaultStatementCacheCapacity = 512
 )
 
 // SetupDB creates a database connection pool using the configuration provided.
 func SetupDB(cfg *viper.Viper) (*pgxpool.Pool, error) {
-	// Setup pool config
-	url := fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
+	return setupDBPool(
+		cfg,
 		cfg.GetString("db.user"),
 		cfg.GetString("db.password"),
 		cfg.GetString("db.host"),
 		cfg.GetString("db.port"),
 		cfg.GetString("db.database"),
 	)
-	poolConfig, err := pgxpool.ParseConfig(url)
+}
+
+// SetupDBReplica creates a connection pool to the read-only database replica
+// using the configuration provided. Any connection setting not provided for
+// the replica defaults to the one of the primary database. When no replica has
+// been configured, the primary database pool provided is returned.
+func SetupDBReplica(cfg *viper.Viper, primary *pgxpool.Pool) (*pgxpool.Pool, error) {
+	host := cfg.GetString("db.replica.host")
+	if host == "" {
+		return primary, nil
+	}
+	setting := func(key string) string {
+		if v := cfg.GetString("db.replica." + key); v != "" {
+			return v
+		}
+		return cfg.GetString("db." + key)
+	}
+	return setupDBPool(
+		cfg,
+		setting("user"),
+		setting("password"),
+		host,
+		setting("port"),
+		setting("database"),
+	)
+}
+
+// setupDBPool creates a database connection pool using the connection
+// settings provided.
+func setupDBPool(cfg *viper.Viper, user, password, host, port, database string) (*pgxpool.Pool, error) {
+	// Setup pool config
+	poolConfig, err := newDBPoolConfig(cfg, user, password, host, port, database)
 	if err != nil {
 		return nil, err
 	}
-	poolConfig.MaxConns = 50
-	poolConfig.MaxConnLifetime = 30 * time.Minute
-	poolConfig.HealthCheckPeriod = 30 * time.Second
-	poolConfig.ConnConfig.Logger = zerologadapter.NewLogger(log.Logger)
-	poolConfig.ConnConfig.LogLevel = pgx.LogLevelWarn
 
 	// Create pool
 	pool, err := pgxpool.ConnectConfig(context.Background(), poolConfig)
@@ -56,6 +126,53 @@ func SetupDB(cfg *viper.Viper) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
+// newDBPoolConfig creates a new database connection pool configuration using
+// the connection settings provided. The size of the pool and the prepared
+// statements cache used by each connection can be customized in the
+// configuration provided.
+//
+// By default, the statements executed are prepared and cached per connection
+// so that they don't need to be parsed and planned again on subsequent
+// executions. The describe mode must be used when connecting through a
+// connection pooler in transaction mode, like PgBouncer.
+func newDBPoolConfig(cfg *viper.Viper, user, password, host, port, database string) (*pgxpool.Config, error) {
+	url := fmt.Sprintf("postgres://%s:%s@%s:%s/%s", user, password, host, port, database)
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, err
+	}
+	cfg.SetDefault("db.maxConns", dbDefaultMaxConns)
+	cfg.SetDefault("db.statementCache.mode", dbDefaultStatementCacheMode)
+	cfg.SetDefault("db.statementCache.capacity", dbDefaultStatementCacheCapacity)
+	maxConns := cfg.GetInt32("db.maxConns")
+	if maxConns <= 0 {
+		return nil, fmt.Errorf("invalid max connections: %d", maxConns)
+	}
+	var cacheMode int
+	switch mode := cfg.GetString("db.statementCache.mode"); mode {
+	case "prepare":
+		cacheMode = stmtcache.ModePrepare
+	case "describe":
+		cacheMode = stmtcache.ModeDescribe
+	default:
+		return nil, fmt.Errorf("invalid statement cache mode: %s", mode)
+	}
+	cacheCapacity := cfg.GetInt("db.statementCache.capacity")
+	poolConfig.MaxConns = maxConns
+	poolConfig.MaxConnLifetime = 30 * time.Minute
+	poolConfig.HealthCheckPeriod = 30 * time.Second
+	poolConfig.ConnConfig.Logger = zerologadapter.NewLogger(log.Logger)
+	poolConfig.ConnConfig.LogLevel = pgx.LogLevelWarn
+	if cacheCapacity > 0 {
+		poolConfig.ConnConfig.BuildStatementCache = func(conn *pgconn.PgConn) stmtcache.Cache {
+			return stmtcache.New(conn, cacheMode, cacheCapacity)
+		}
+	} else {
+		poolConfig.ConnConfig.BuildStatementCache = nil
+	}
+	return poolConfig, nil
+}
+
 // DBTransact is a helper function that wraps some database transactions taking
 // care of committing and rolling back when needed.
 func DBTransact(ctx context.Context, db hub.DB, txFunc func(pgx.Tx) error) (err error) {
@@ -130,3 +247,25 @@ func DBQueryUnmarshal(ctx context.Context, db hub.DB, v interface{}, query strin
 	}
 	return json.Unmarshal(dataJSON, &v)
 }
+
+// IsDBContentBlocked checks if the error provided was returned from the
+// database because the content requested has been blocked. When that's the
+// case, the reason why the content was blocked is returned as well.
+func IsDBContentBlocked(err error) (string, bool) {
+	msg := err.Error()
+	if !strings.HasPrefix(msg, dbContentBlockedErrPrefix) {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(msg, dbContentBlockedErrPrefix), dbRaiseErrSuffix), true
+}
+
+// IsDBSnapshotNotScannable checks if the error provided was returned from the
+// database because the snapshot requested to be scanned is excluded from
+// scanning. When that's the case, the reason is returned as well.
+func IsDBSnapshotNotScannable(err error) (string, bool) {
+	msg := err.Error()
+	if !strings.HasPrefix(msg, dbSnapshotNotScannableErrPrefix) {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(msg, dbSnapshotNotScannableErrPrefix), dbRaiseErrSuffix), true
+}
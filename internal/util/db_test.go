@@ -0,0 +1,55 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDBPoolConfig(t *testing.T) {
+	t.Run("invalid settings", func(t *testing.T) {
+		testCases := []struct {
+			key         string
+			value       interface{}
+			expectedErr string
+		}{
+			{"db.maxConns", 0, "invalid max connections"},
+			{"db.statementCache.mode", "invalid", "invalid statement cache mode"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.expectedErr, func(t *testing.T) {
+				t.Parallel()
+				cfg := viper.New()
+				cfg.Set(tc.key, tc.value)
+				_, err := newDBPoolConfig(cfg, "user", "pass", "host", "5432", "db")
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+			})
+		}
+	})
+
+	t.Run("default settings", func(t *testing.T) {
+		t.Parallel()
+		poolConfig, err := newDBPoolConfig(viper.New(), "user", "pass", "host", "5432", "db")
+		require.NoError(t, err)
+		assert.Equal(t, int32(dbDefaultMaxConns), poolConfig.MaxConns)
+		assert.Equal(t, "host", poolConfig.ConnConfig.Host)
+		assert.Equal(t, "db", poolConfig.ConnConfig.Database)
+		assert.NotNil(t, poolConfig.ConnConfig.BuildStatementCache)
+	})
+
+	t.Run("custom settings", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("db.maxConns", 10)
+		cfg.Set("db.statementCache.mode", "describe")
+		cfg.Set("db.statementCache.capacity", 0)
+		poolConfig, err := newDBPoolConfig(cfg, "user", "pass", "host", "5432", "db")
+		require.NoError(t, err)
+		assert.Equal(t, int32(10), poolConfig.MaxConns)
+		assert.Nil(t, poolConfig.ConnConfig.BuildStatementCache)
+	})
+}
@@ -0,0 +1,192 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrDBCircuitOpen is returned by the CircuitBreakerDB when the circuit is
+// open and the call has not been sent to the database.
+var ErrDBCircuitOpen = fmt.Errorf("%w: database circuit breaker open", hub.ErrUnavailable)
+
+// Circuit breaker states.
+const (
+	circuitClosed = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreakerDB is a hub.DB implementation that wraps a database, failing
+// fast when it looks unavailable. After a number of consecutive calls fail
+// because the database could not be reached or did not reply in time, the
+// circuit opens and all calls fail immediately with ErrDBCircuitOpen. Once
+// the open timeout expires, a single trial call is let through: the circuit
+// is closed again if it succeeds, or reopened otherwise. This prevents a
+// stuck database from piling up goroutines waiting on it.
+type CircuitBreakerDB struct {
+	db               hub.DB
+	failureThreshold int
+	openTimeout      time.Duration
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+}
+
+// NewCircuitBreakerDB creates a new CircuitBreakerDB instance that wraps the
+// database provided. When the failure threshold provided is not positive, the
+// circuit breaker is disabled and the database provided is returned as is.
+func NewCircuitBreakerDB(db hub.DB, failureThreshold int, openTimeout time.Duration) hub.DB {
+	if failureThreshold <= 0 {
+		return db
+	}
+	return &CircuitBreakerDB{
+		db:               db,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+}
+
+// Acquire implements the hub.DB interface.
+func (db *CircuitBreakerDB) Acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	if !db.allow() {
+		return nil, ErrDBCircuitOpen
+	}
+	conn, err := db.db.Acquire(ctx)
+	db.record(err)
+	return conn, err
+}
+
+// Begin implements the hub.DB interface.
+func (db *CircuitBreakerDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	if !db.allow() {
+		return nil, ErrDBCircuitOpen
+	}
+	tx, err := db.db.Begin(ctx)
+	db.record(err)
+	return tx, err
+}
+
+// Exec implements the hub.DB interface.
+func (db *CircuitBreakerDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	if !db.allow() {
+		return nil, ErrDBCircuitOpen
+	}
+	tag, err := db.db.Exec(ctx, sql, args...)
+	db.record(err)
+	return tag, err
+}
+
+// QueryRow implements the hub.DB interface. The outcome of the query is
+// recorded once the row returned has been scanned.
+func (db *CircuitBreakerDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if !db.allow() {
+		return &errRow{err: ErrDBCircuitOpen}
+	}
+	return &circuitBreakerRow{
+		row: db.db.QueryRow(ctx, sql, args...),
+		db:  db,
+	}
+}
+
+// allow checks if a call can be sent to the database.
+func (db *CircuitBreakerDB) allow() bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	switch db.state {
+	case circuitOpen, circuitHalfOpen:
+		// While half open, only one trial call is allowed per open timeout
+		// period, in case the outcome of the previous one is never recorded
+		if time.Since(db.openedAt) < db.openTimeout {
+			return false
+		}
+		db.state = circuitHalfOpen
+		db.openedAt = time.Now()
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the circuit state based on the outcome of a call.
+func (db *CircuitBreakerDB) record(err error) {
+	if errors.Is(err, context.Canceled) {
+		// The caller is gone, this tells nothing about the database
+		return
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if isDBUnavailableError(err) {
+		db.failures++
+		if db.state == circuitHalfOpen || (db.state == circuitClosed && db.failures >= db.failureThreshold) {
+			if db.state == circuitClosed {
+				log.Error().Err(err).Int("failures", db.failures).Msg("database circuit breaker opened")
+			}
+			db.state = circuitOpen
+			db.openedAt = time.Now()
+		}
+		return
+	}
+	if db.state != circuitClosed {
+		log.Info().Msg("database circuit breaker closed")
+	}
+	db.state = circuitClosed
+	db.failures = 0
+}
+
+// isDBUnavailableError checks if the error provided indicates that the
+// database could not be reached or did not reply in time. Errors returned by
+// the database itself (i.e. raised by a function) do not.
+func isDBUnavailableError(err error) bool {
+	if err == nil || errors.Is(err, pgx.ErrNoRows) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "57014" // query_canceled (statement timeout)
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// circuitBreakerRow is a pgx.Row implementation that records the outcome of
+// the query that returned it once it has been scanned.
+type circuitBreakerRow struct {
+	row pgx.Row
+	db  *CircuitBreakerDB
+}
+
+// Scan implements the pgx.Row interface.
+func (r *circuitBreakerRow) Scan(dest ...interface{}) error {
+	err := r.row.Scan(dest...)
+	r.db.record(err)
+	return err
+}
+
+// errRow is a pgx.Row implementation that always returns the error provided
+// when scanned.
+type errRow struct {
+	err error
+}
+
+// Scan implements the pgx.Row interface.
+func (r *errRow) Scan(dest ...interface{}) error {
+	return r.err
+}
@@ -0,0 +1,131 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCircuitBreakerDB(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("failure threshold not positive, database returned as is", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		assert.Equal(t, db, NewCircuitBreakerDB(db, 0, time.Minute))
+	})
+
+	t.Run("circuit opens after consecutive unavailable errors", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", mock.Anything, "query").Return(context.DeadlineExceeded).Times(2)
+		db.On("QueryRow", mock.Anything, "query").Return(nil, context.DeadlineExceeded).Once()
+
+		cdb := NewCircuitBreakerDB(db, 3, time.Hour)
+		for i := 0; i < 2; i++ {
+			_, err := cdb.Exec(ctx, "query")
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+		err := cdb.QueryRow(ctx, "query").Scan()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// Circuit is open now, calls fail fast
+		_, err = cdb.Exec(ctx, "query")
+		assert.ErrorIs(t, err, ErrDBCircuitOpen)
+		assert.ErrorIs(t, err, hub.ErrUnavailable)
+		err = cdb.QueryRow(ctx, "query").Scan()
+		assert.ErrorIs(t, err, ErrDBCircuitOpen)
+		_, err = cdb.Begin(ctx)
+		assert.ErrorIs(t, err, ErrDBCircuitOpen)
+		_, err = cdb.Acquire(ctx)
+		assert.ErrorIs(t, err, ErrDBCircuitOpen)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("other errors and successful calls keep the circuit closed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", mock.Anything, "query").Return(context.DeadlineExceeded).Once()
+		db.On("Exec", mock.Anything, "query").Return(tests.ErrFakeDB).Once()
+		db.On("Exec", mock.Anything, "query").Return(context.DeadlineExceeded).Once()
+		db.On("QueryRow", mock.Anything, "query").Return(nil, pgx.ErrNoRows).Once()
+		db.On("Exec", mock.Anything, "query").Return(context.DeadlineExceeded).Once()
+		db.On("Exec", mock.Anything, "query").Return(context.Canceled).Once()
+		db.On("Exec", mock.Anything, "query").Return(nil).Once()
+
+		cdb := NewCircuitBreakerDB(db, 2, time.Hour)
+		_, _ = cdb.Exec(ctx, "query")
+		_, _ = cdb.Exec(ctx, "query")
+		_, _ = cdb.Exec(ctx, "query")
+		_ = cdb.QueryRow(ctx, "query").Scan()
+		_, _ = cdb.Exec(ctx, "query")
+		_, _ = cdb.Exec(ctx, "query")
+		_, err := cdb.Exec(ctx, "query")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("trial call succeeds after open timeout, circuit closed", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", mock.Anything, "query").Return(context.DeadlineExceeded).Once()
+		db.On("Exec", mock.Anything, "query").Return(nil).Times(2)
+
+		cdb := NewCircuitBreakerDB(db, 1, 10*time.Millisecond)
+		_, _ = cdb.Exec(ctx, "query")
+		_, err := cdb.Exec(ctx, "query")
+		assert.ErrorIs(t, err, ErrDBCircuitOpen)
+
+		time.Sleep(20 * time.Millisecond)
+		_, err = cdb.Exec(ctx, "query")
+		assert.NoError(t, err)
+		_, err = cdb.Exec(ctx, "query")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("trial call fails after open timeout, circuit reopened", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", mock.Anything, "query").Return(context.DeadlineExceeded).Times(2)
+
+		cdb := NewCircuitBreakerDB(db, 1, 10*time.Millisecond)
+		_, _ = cdb.Exec(ctx, "query")
+
+		time.Sleep(20 * time.Millisecond)
+		_, err := cdb.Exec(ctx, "query")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		_, err = cdb.Exec(ctx, "query")
+		assert.ErrorIs(t, err, ErrDBCircuitOpen)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestIsDBUnavailableError(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{pgx.ErrNoRows, false},
+		{tests.ErrFakeDB, false},
+		{&pgconn.PgError{Code: "P0001"}, false},
+		{&pgconn.PgError{Code: "57014"}, true},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), true},
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, isDBUnavailableError(tc.err), tc.err)
+	}
+}
@@ -0,0 +1,107 @@
+package util
+
+import (
+	"context"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+// TimeoutDB is a hub.DB implementation that wraps a database, making sure the
+// queries executed through it are canceled if they take longer than the
+// timeout configured. It allows applying different timeouts to each class of
+// queries (i.e. the ones used to serve the API read paths), even when the
+// same connections pool is used for all of them. Queries that take longer
+// than the slow query threshold configured are logged.
+type TimeoutDB struct {
+	db                 hub.DB
+	timeout            time.Duration
+	slowQueryThreshold time.Duration
+}
+
+// NewTimeoutDB creates a new TimeoutDB instance that wraps the database
+// provided. When the timeout provided is not positive, queries are not
+// subject to any timeout. Slow queries are not logged when the threshold
+// provided is not positive. If both are disabled, the database provided is
+// returned as is.
+func NewTimeoutDB(db hub.DB, timeout, slowQueryThreshold time.Duration) hub.DB {
+	if timeout <= 0 && slowQueryThreshold <= 0 {
+		return db
+	}
+	return &TimeoutDB{
+		db:                 db,
+		timeout:            timeout,
+		slowQueryThreshold: slowQueryThreshold,
+	}
+}
+
+// Acquire implements the hub.DB interface. Connections acquired from the pool
+// are not subject to the timeout.
+func (db *TimeoutDB) Acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	return db.db.Acquire(ctx)
+}
+
+// Begin implements the hub.DB interface. Transactions are not subject to the
+// timeout, as they may involve several queries.
+func (db *TimeoutDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	return db.db.Begin(ctx)
+}
+
+// Exec implements the hub.DB interface.
+func (db *TimeoutDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+	defer db.logSlowQuery(sql, time.Now())
+	return db.db.Exec(ctx, sql, args...)
+}
+
+// QueryRow implements the hub.DB interface. The query's context is canceled
+// once the row returned has been scanned.
+func (db *TimeoutDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	start := time.Now()
+	ctx, cancel := db.withTimeout(ctx)
+	return &timeoutRow{
+		row: db.db.QueryRow(ctx, sql, args...),
+		done: func() {
+			cancel()
+			db.logSlowQuery(sql, start)
+		},
+	}
+}
+
+// withTimeout returns a copy of the context provided that is canceled once
+// the timeout configured expires, if any.
+func (db *TimeoutDB) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, db.timeout)
+}
+
+// logSlowQuery logs the query provided if it took longer than the slow query
+// threshold configured since it was started.
+func (db *TimeoutDB) logSlowQuery(sql string, start time.Time) {
+	if db.slowQueryThreshold <= 0 {
+		return
+	}
+	if duration := time.Since(start); duration >= db.slowQueryThreshold {
+		log.Warn().Str("sql", sql).Dur("duration", duration).Msg("slow query")
+	}
+}
+
+// timeoutRow is a pgx.Row implementation that cancels the context of the
+// query that returned it once it has been scanned.
+type timeoutRow struct {
+	row  pgx.Row
+	done func()
+}
+
+// Scan implements the pgx.Row interface.
+func (r *timeoutRow) Scan(dest ...interface{}) error {
+	defer r.done()
+	return r.row.Scan(dest...)
+}
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTimeoutDB(t *testing.T) {
+	// hasDeadline checks if the context provided has a deadline set that is
+	// not later than the timeout provided.
+	hasDeadline := func(timeout time.Duration) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			deadline, ok := ctx.Deadline()
+			return ok && time.Until(deadline) <= timeout
+		})
+	}
+
+	t.Run("timeout and slow query threshold not positive, database returned as is", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		assert.Equal(t, db, NewTimeoutDB(db, 0, 0))
+	})
+
+	t.Run("only slow query threshold set, queries not subject to timeout", func(t *testing.T) {
+		t.Parallel()
+		noDeadline := mock.MatchedBy(func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return !ok
+		})
+		db := &tests.DBMock{}
+		db.On("Exec", noDeadline, "query").Return(nil)
+		db.On("QueryRow", noDeadline, "query").Return(nil, nil)
+
+		tdb := NewTimeoutDB(db, 0, time.Nanosecond)
+		_, err := tdb.Exec(context.Background(), "query")
+		assert.NoError(t, err)
+		err = tdb.QueryRow(context.Background(), "query").Scan()
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", hasDeadline(time.Minute), "query", 1).Return(tests.ErrFakeDB)
+
+		tdb := NewTimeoutDB(db, time.Minute, 0)
+		_, err := tdb.Exec(context.Background(), "query", 1)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("query row", func(t *testing.T) {
+		t.Parallel()
+		var queryCtx context.Context
+		db := &tests.DBMock{}
+		db.On("QueryRow", hasDeadline(time.Minute), "query", 1).
+			Run(func(args mock.Arguments) { queryCtx = args.Get(0).(context.Context) }).
+			Return([]byte("dataJSON"), nil)
+
+		tdb := NewTimeoutDB(db, time.Minute, 0)
+		row := tdb.QueryRow(context.Background(), "query", 1)
+		assert.NoError(t, queryCtx.Err())
+		var data []byte
+		err := row.Scan(&data)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), data)
+		assert.ErrorIs(t, queryCtx.Err(), context.Canceled)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("query row timeout exceeded", func(t *testing.T) {
+		t.Parallel()
+		var queryCtx context.Context
+		db := &tests.DBMock{}
+		db.On("QueryRow", mock.Anything, "query").
+			Run(func(args mock.Arguments) { queryCtx = args.Get(0).(context.Context) }).
+			Return(nil, context.DeadlineExceeded)
+
+		tdb := NewTimeoutDB(db, time.Millisecond, 0)
+		row := tdb.QueryRow(context.Background(), "query")
+		<-queryCtx.Done()
+		assert.ErrorIs(t, queryCtx.Err(), context.DeadlineExceeded)
+		err := row.Scan()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		db.AssertExpectations(t)
+	})
+}
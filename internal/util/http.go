@@ -4,12 +4,15 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
 )
 
 var (
@@ -20,6 +23,24 @@ var (
 	// HTTPClientDefaultTimeout represents the default timeout used for http
 	// clients.
 	HTTPClientDefaultTimeout = 10 * time.Second
+
+	// WebhooksDefaultEgressDenylist represents the networks webhooks are never
+	// allowed to send requests to: loopback, private (RFC1918), shared
+	// address space, link-local (including cloud providers metadata
+	// endpoints) and unique local addresses. The webhooks egress denylist
+	// configured extends this list.
+	WebhooksDefaultEgressDenylist = []string{
+		"0.0.0.0/8",
+		"10.0.0.0/8",
+		"100.64.0.0/10",
+		"127.0.0.0/8",
+		"169.254.0.0/16",
+		"172.16.0.0/12",
+		"192.168.0.0/16",
+		"::1/128",
+		"fc00::/7",
+		"fe80::/10",
+	}
 )
 
 // SetupHTTPClient is a helper that returns an http client. If restricted is
@@ -27,20 +48,64 @@ var (
 // restricted addresses.
 func SetupHTTPClient(restricted bool, timeout time.Duration) hub.HTTPClient {
 	if restricted {
-		return setupRestrictedHTTPClient(timeout)
+		return setupRestrictedHTTPClient(timeout, checkRestrictions)
 	}
 	return &http.Client{
 		Timeout: timeout,
 	}
 }
 
+// SetupWebhooksHTTPClient returns the http client used to deliver requests to
+// user configured webhooks. The restrictions applied by the restricted http
+// client are always enforced, regardless of whether it has been enabled or
+// not, and requests to the networks in the default webhooks egress denylist,
+// extended with the ones configured, won't be allowed either. Addresses are
+// checked when dialing, once the webhook host has been resolved, so the
+// environment proxy is not used. Redirections are not followed, as they could
+// be used to reach addresses that are not allowed.
+func SetupWebhooksHTTPClient(cfg *viper.Viper, timeout time.Duration) (hub.HTTPClient, error) {
+	denylist, err := ParseCIDRs(cfg.GetStringSlice("webhooks.egressDenylist"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhooks egress denylist: %w", err)
+	}
+	defaultDenylist, _ := ParseCIDRs(WebhooksDefaultEgressDenylist)
+	denylist = append(defaultDenylist, denylist...)
+	hc := setupRestrictedHTTPClient(timeout, func(network, address string, conn syscall.RawConn) error {
+		if err := checkRestrictions(network, address, conn); err != nil {
+			return err
+		}
+		return checkDenylist(address, denylist)
+	})
+	hc.Transport.(*http.Transport).Proxy = nil
+	hc.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return hc, nil
+}
+
+// ParseCIDRs parses the CIDR ranges provided.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
 // setupRestrictedHTTPClient returns an http client that is not allowed to make
-// requests to a set of restricted addresses.
-func setupRestrictedHTTPClient(timeout time.Duration) hub.HTTPClient {
+// requests to the addresses restricted by the control function provided.
+func setupRestrictedHTTPClient(
+	timeout time.Duration,
+	control func(network, address string, conn syscall.RawConn) error,
+) *http.Client {
 	dialer := &net.Dialer{
 		Timeout:   timeout,
 		DualStack: true,
-		Control:   checkRestrictions,
+		Control:   control,
 	}
 	transport := &http.Transport{
 		DialContext: dialer.DialContext,
@@ -81,3 +146,22 @@ func checkRestrictions(network string, address string, conn syscall.RawConn) err
 	}
 	return nil
 }
+
+// checkDenylist checks if a connection to the provided address should be
+// restricted because it belongs to any of the networks in the denylist given.
+func checkDenylist(address string, denylist []*net.IPNet) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrRestrictedConnection
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ErrRestrictedConnection
+	}
+	for _, ipNet := range denylist {
+		if ipNet.Contains(ip) {
+			return ErrRestrictedConnection
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupWebhooksHTTPClient(t *testing.T) {
+	t.Run("invalid egress denylist", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("webhooks.egressDenylist", []string{"10.0.0.0/8", "invalid"})
+		hc, err := SetupWebhooksHTTPClient(cfg, HTTPClientDefaultTimeout)
+		assert.Error(t, err)
+		assert.Nil(t, hc)
+	})
+
+	t.Run("requests to restricted addresses are not allowed", func(t *testing.T) {
+		t.Parallel()
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer s.Close()
+
+		// Restricted http client disabled and egress denylist not configured
+		cfg := viper.New()
+		cfg.Set("restrictedHTTPClient", false)
+		hc, err := SetupWebhooksHTTPClient(cfg, HTTPClientDefaultTimeout)
+		require.NoError(t, err)
+		req, _ := http.NewRequest("GET", s.URL, nil)
+		_, err = hc.Do(req)
+		assert.ErrorIs(t, err, ErrRestrictedConnection)
+	})
+
+	t.Run("redirections are not followed", func(t *testing.T) {
+		t.Parallel()
+		hc, err := SetupWebhooksHTTPClient(viper.New(), HTTPClientDefaultTimeout)
+		require.NoError(t, err)
+		req, _ := http.NewRequest("GET", "https://webhook.example.com", nil)
+		err = hc.(*http.Client).CheckRedirect(req, []*http.Request{req})
+		assert.Equal(t, http.ErrUseLastResponse, err)
+	})
+}
+
+func TestWebhooksDefaultEgressDenylist(t *testing.T) {
+	t.Parallel()
+	denylist, err := ParseCIDRs(WebhooksDefaultEgressDenylist)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		address     string
+		expectedErr error
+	}{
+		{"127.0.0.1:80", ErrRestrictedConnection},
+		{"10.1.2.3:443", ErrRestrictedConnection},
+		{"172.16.0.1:443", ErrRestrictedConnection},
+		{"192.168.1.1:443", ErrRestrictedConnection},
+		{"100.100.100.200:80", ErrRestrictedConnection},
+		{"169.254.169.254:80", ErrRestrictedConnection},
+		{"[::ffff:169.254.169.254]:80", ErrRestrictedConnection},
+		{"[::1]:443", ErrRestrictedConnection},
+		{"[fd00:ec2::254]:80", ErrRestrictedConnection},
+		{"[fe80::1]:443", ErrRestrictedConnection},
+		{"1.1.1.1:443", nil},
+		{"[2606:4700::1111]:443", nil},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedErr, checkDenylist(tc.address, denylist), tc.address)
+	}
+}
+
+func TestCheckDenylist(t *testing.T) {
+	t.Parallel()
+	denylist, err := ParseCIDRs([]string{"10.0.0.0/8", " 169.254.169.254/32", "fd00::/8"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		address     string
+		expectedErr error
+	}{
+		{"10.1.2.3:443", ErrRestrictedConnection},
+		{"169.254.169.254:80", ErrRestrictedConnection},
+		{"[fd00::1]:443", ErrRestrictedConnection},
+		{"invalid", ErrRestrictedConnection},
+		{"1.1.1.1:443", nil},
+		{"[2606:4700::1111]:443", nil},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedErr, checkDenylist(tc.address, denylist), tc.address)
+	}
+}
@@ -4,20 +4,33 @@ import (
 	"errors"
 
 	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/img/objstore"
 	"github.com/artifacthub/hub/internal/img/pg"
 	"github.com/spf13/viper"
 )
 
 // SetupImageStore creates a new image store based on the configuration provided.
-func SetupImageStore(
-	cfg *viper.Viper,
-	db pg.DB,
-	hc img.HTTPClient,
-) (img.Store, error) {
+// When an object storage backend is used, images previously stored in the
+// database will still be served from it.
+func SetupImageStore(cfg *viper.Viper, db pg.DB) (img.Store, error) {
+	// Remote images are downloaded from untrusted sources, so the http client
+	// used is not allowed to reach private networks unless explicitly enabled
+	timeout := cfg.GetDuration("images.fetch.timeout")
+	if timeout == 0 {
+		timeout = HTTPClientDefaultTimeout
+	}
+	hc := SetupHTTPClient(!cfg.GetBool("images.fetch.allowPrivateNetworks"), timeout)
+
 	imageStore := cfg.GetString("images.store")
 	switch imageStore {
 	case "pg":
 		return pg.NewImageStore(cfg, db, hc), nil
+	case "s3", "gcs":
+		bucket, err := SetupBucket(cfg, "images")
+		if err != nil {
+			return nil, err
+		}
+		return objstore.NewImageStore(cfg, bucket, hc, pg.NewImageStore(cfg, db, hc)), nil
 	default:
 		return nil, errors.New("invalid image store")
 	}
@@ -13,14 +13,32 @@ func TestSetupImageStore(t *testing.T) {
 	// Check a valid image store provider must be provided
 	cfg := viper.New()
 	cfg.Set("images.store", "invalid")
-	imageStore, err := SetupImageStore(cfg, nil, nil)
+	imageStore, err := SetupImageStore(cfg, nil)
 	require.Error(t, err)
 	require.Nil(t, imageStore)
 
 	// Check image store was setup successfully
 	cfg = viper.New()
 	cfg.Set("images.store", "pg")
-	imageStore, err = SetupImageStore(cfg, nil, nil)
+	imageStore, err = SetupImageStore(cfg, nil)
+	require.NoError(t, err)
+	require.NotNil(t, imageStore)
+
+	// Check object storage backends configuration must be valid
+	cfg = viper.New()
+	cfg.Set("images.store", "s3")
+	imageStore, err = SetupImageStore(cfg, nil)
+	require.Error(t, err)
+	require.Nil(t, imageStore)
+
+	// Check s3 image store was setup successfully
+	cfg = viper.New()
+	cfg.Set("images.store", "s3")
+	cfg.Set("images.s3.bucket", "bucket1")
+	cfg.Set("images.s3.region", "us-east-1")
+	cfg.Set("images.s3.accessKeyID", "AKID")
+	cfg.Set("images.s3.secretAccessKey", "secret")
+	imageStore, err = SetupImageStore(cfg, nil)
 	require.NoError(t, err)
 	require.NotNil(t, imageStore)
 }
@@ -0,0 +1,51 @@
+package util
+
+import "sync"
+
+// Limiter limits the number of operations that can run concurrently. Unlike a
+// buffered channel used as a semaphore, its limit can be updated while it's
+// in use (i.e. when the configuration is reloaded).
+type Limiter struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	limit   int
+	running int
+}
+
+// NewLimiter creates a new Limiter instance.
+func NewLimiter(limit int) *Limiter {
+	l := &Limiter{}
+	l.cond = sync.NewCond(&l.mu)
+	l.SetLimit(limit)
+	return l
+}
+
+// Acquire blocks until a new operation can run.
+func (l *Limiter) Acquire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.running >= l.limit {
+		l.cond.Wait()
+	}
+	l.running++
+}
+
+// Release signals that an operation has finished.
+func (l *Limiter) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.running--
+	l.cond.Signal()
+}
+
+// SetLimit updates the maximum number of operations that can run at the same
+// time. Operations already running are not affected when the limit decreases.
+func (l *Limiter) SetLimit(limit int) {
+	if limit < 1 {
+		limit = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+	l.cond.Broadcast()
+}
@@ -0,0 +1,60 @@
+package util
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Run("concurrent operations limited", func(t *testing.T) {
+		t.Parallel()
+		l := NewLimiter(2)
+		var running, maxRunning int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Acquire()
+				defer l.Release()
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			}()
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, maxRunning, int32(2))
+	})
+
+	t.Run("waiting operations run when limit increases", func(t *testing.T) {
+		t.Parallel()
+		l := NewLimiter(1)
+		l.Acquire()
+		acquired := make(chan struct{})
+		go func() {
+			l.Acquire()
+			close(acquired)
+		}()
+		select {
+		case <-acquired:
+			t.Fatal("operation should be waiting")
+		case <-time.After(20 * time.Millisecond):
+		}
+		l.SetLimit(2)
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("operation should be running")
+		}
+	})
+}
@@ -14,11 +14,9 @@ func SetupLogger(cfg *viper.Viper, fields map[string]interface{}) error {
 	log.Logger = log.With().Fields(fields).Logger()
 
 	// Set log level
-	level, err := zerolog.ParseLevel(cfg.GetString("log.level"))
-	if err != nil {
+	if err := SetLogLevel(cfg); err != nil {
 		return err
 	}
-	zerolog.SetGlobalLevel(level)
 
 	// Enable pretty logging (not JSON) if requested
 	if cfg.GetBool("log.pretty") {
@@ -27,3 +25,13 @@ func SetupLogger(cfg *viper.Viper, fields map[string]interface{}) error {
 
 	return nil
 }
+
+// SetLogLevel sets the global log level using the configuration provided.
+func SetLogLevel(cfg *viper.Viper) error {
+	level, err := zerolog.ParseLevel(cfg.GetString("log.level"))
+	if err != nil {
+		return err
+	}
+	zerolog.SetGlobalLevel(level)
+	return nil
+}
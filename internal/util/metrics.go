@@ -0,0 +1,129 @@
+package util
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/rs/zerolog/log"
+)
+
+// SetupMetricsServer launches an http server that exposes the metrics
+// collected at /metrics on the address provided.
+func SetupMetricsServer(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Fatal().Err(err).Msg("metrics server ListenAndServe failed")
+		}
+	}()
+}
+
+// PushMetrics pushes the metrics collected to the Prometheus Pushgateway
+// available at the url provided. This is useful for short lived processes,
+// like the tracker or the scanner, that may finish before Prometheus has a
+// chance to scrape them.
+func PushMetrics(url, job string) error {
+	return push.New(url, job).Gatherer(prometheus.DefaultGatherer).Push()
+}
+
+// DBStatsCollector is a prometheus collector that exposes some stats about
+// the database connections pool provided.
+type DBStatsCollector struct {
+	pool *pgxpool.Pool
+
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	acquiredConns        *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	idleConns            *prometheus.Desc
+	maxConns             *prometheus.Desc
+	totalConns           *prometheus.Desc
+}
+
+// NewDBStatsCollector creates a new DBStatsCollector instance. The name
+// provided is used to label the stats of the pool, so that the stats of
+// several pools (i.e. primary and replica) can be collected.
+func NewDBStatsCollector(pool *pgxpool.Pool, name string) *DBStatsCollector {
+	labels := prometheus.Labels{"pool": name}
+	return &DBStatsCollector{
+		pool: pool,
+		acquireCount: prometheus.NewDesc(
+			"db_pool_acquire_count_total",
+			"Number of successful connections acquired from the pool.",
+			nil, labels,
+		),
+		acquireDuration: prometheus.NewDesc(
+			"db_pool_acquire_duration_seconds_total",
+			"Total time spent acquiring connections from the pool.",
+			nil, labels,
+		),
+		acquiredConns: prometheus.NewDesc(
+			"db_pool_acquired_conns",
+			"Number of connections currently acquired from the pool.",
+			nil, labels,
+		),
+		canceledAcquireCount: prometheus.NewDesc(
+			"db_pool_canceled_acquire_count_total",
+			"Number of acquires from the pool canceled by a context.",
+			nil, labels,
+		),
+		constructingConns: prometheus.NewDesc(
+			"db_pool_constructing_conns",
+			"Number of connections currently being constructed by the pool.",
+			nil, labels,
+		),
+		emptyAcquireCount: prometheus.NewDesc(
+			"db_pool_empty_acquire_count_total",
+			"Number of acquires from the pool that had to wait for a connection.",
+			nil, labels,
+		),
+		idleConns: prometheus.NewDesc(
+			"db_pool_idle_conns",
+			"Number of idle connections in the pool.",
+			nil, labels,
+		),
+		maxConns: prometheus.NewDesc(
+			"db_pool_max_conns",
+			"Maximum size of the pool.",
+			nil, labels,
+		),
+		totalConns: prometheus.NewDesc(
+			"db_pool_total_conns",
+			"Total number of connections in the pool.",
+			nil, labels,
+		),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *DBStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.acquiredConns
+	ch <- c.canceledAcquireCount
+	ch <- c.constructingConns
+	ch <- c.emptyAcquireCount
+	ch <- c.idleConns
+	ch <- c.maxConns
+	ch <- c.totalConns
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *DBStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(s.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+}
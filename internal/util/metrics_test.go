@@ -0,0 +1,25 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBStatsCollectorDescribe(t *testing.T) {
+	t.Parallel()
+
+	c := NewDBStatsCollector(nil, "primary")
+	ch := make(chan *prometheus.Desc, 10)
+	c.Describe(ch)
+	close(ch)
+
+	var descs []*prometheus.Desc
+	for d := range ch {
+		descs = append(descs, d)
+	}
+	assert.Len(t, descs, 9)
+	assert.Contains(t, descs[2].String(), "db_pool_acquired_conns")
+	assert.Contains(t, descs[2].String(), `pool="primary"`)
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/img/objstore"
+	"github.com/spf13/viper"
+)
+
+// SetupBucket creates a new object storage bucket using the configuration
+// available under the key provided (i.e. images). The kind of bucket to use is
+// selected by the store setting (s3 or gcs), and each of them is configured in
+// its own section.
+func SetupBucket(cfg *viper.Viper, key string) (objstore.Bucket, error) {
+	// Requests to the object storage backends are not subject to the
+	// restrictions that apply to the http client used to download images
+	hc := SetupHTTPClient(false, HTTPClientDefaultTimeout)
+
+	switch store := cfg.GetString(key + ".store"); store {
+	case "s3":
+		bucket, err := objstore.NewS3Bucket(cfg, key+".s3", hc)
+		if err != nil {
+			return nil, err
+		}
+		return bucket, nil
+	case "gcs":
+		bucket, err := objstore.NewGCSBucket(cfg, key+".gcs", hc)
+		if err != nil {
+			return nil, err
+		}
+		return bucket, nil
+	default:
+		return nil, fmt.Errorf("invalid object storage: %s", store)
+	}
+}
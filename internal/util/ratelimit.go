@@ -0,0 +1,137 @@
+package util
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// keyRateLimiterIdleTTL represents how long the rate limiter of a key is
+	// kept after it was last used.
+	keyRateLimiterIdleTTL = 10 * time.Minute
+
+	// keyRateLimiterCleanupInterval represents how often the rate limiters of
+	// idle keys are removed.
+	keyRateLimiterCleanupInterval = 1 * time.Minute
+)
+
+// HostRateLimitedHTTPClient is an http client that limits the rate at which
+// requests are sent to each host.
+type HostRateLimitedHTTPClient struct {
+	hc    hub.HTTPClient
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewHostRateLimitedHTTPClient creates a new HostRateLimitedHTTPClient
+// instance that wraps the http client provided. Up to rps requests per second
+// will be sent to each host. When rps is not positive, requests are not rate
+// limited and the http client provided is returned as is.
+func NewHostRateLimitedHTTPClient(hc hub.HTTPClient, rps float64) hub.HTTPClient {
+	if rps <= 0 {
+		return hc
+	}
+	burst := int(rps)
+	if burst < 1 {
+		burst = 1
+	}
+	return &HostRateLimitedHTTPClient{
+		hc:       hc,
+		limit:    rate.Limit(rps),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Do implements the hub.HTTPClient interface. It waits until the request can
+// be sent to its host without exceeding the rate limit, or until the request
+// context is done.
+func (c *HostRateLimitedHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if err := c.limiter(req.URL.Host).Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return c.hc.Do(req)
+}
+
+// limiter returns the rate limiter of the host provided, creating it if
+// needed.
+func (c *HostRateLimitedHTTPClient) limiter(host string) *rate.Limiter {
+	host = strings.ToLower(host)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.limiters[host]
+	if !ok {
+		l = rate.NewLimiter(c.limit, c.burst)
+		c.limiters[host] = l
+	}
+	return l
+}
+
+// KeyRateLimiter limits the rate at which the operations identified by a given
+// key (i.e. the requests of a client ip) are allowed.
+type KeyRateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu          sync.Mutex
+	limiters    map[string]*keyLimiter
+	lastCleanup time.Time
+}
+
+// keyLimiter represents the rate limiter of a key in a KeyRateLimiter.
+type keyLimiter struct {
+	l        *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewKeyRateLimiter creates a new KeyRateLimiter instance that allows up to
+// rps operations per second for each key, with bursts of up to burst
+// operations. When burst is not positive, it defaults to rps (at least 1).
+func NewKeyRateLimiter(rps float64, burst int) *KeyRateLimiter {
+	if burst < 1 {
+		burst = int(rps)
+		if burst < 1 {
+			burst = 1
+		}
+	}
+	return &KeyRateLimiter{
+		limit:       rate.Limit(rps),
+		burst:       burst,
+		limiters:    make(map[string]*keyLimiter),
+		lastCleanup: time.Now(),
+	}
+}
+
+// Allow reports whether an operation identified by the key provided can run
+// now without exceeding the rate limit.
+func (l *KeyRateLimiter) Allow(key string) bool {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Remove the rate limiters of the keys that have been idle for a while
+	if now.Sub(l.lastCleanup) > keyRateLimiterCleanupInterval {
+		for k, kl := range l.limiters {
+			if now.Sub(kl.lastSeen) > keyRateLimiterIdleTTL {
+				delete(l.limiters, k)
+			}
+		}
+		l.lastCleanup = now
+	}
+
+	kl, ok := l.limiters[key]
+	if !ok {
+		kl = &keyLimiter{l: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[key] = kl
+	}
+	kl.lastSeen = now
+	return kl.l.AllowN(now, 1)
+}
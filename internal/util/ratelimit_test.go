@@ -0,0 +1,88 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostRateLimitedHTTPClient(t *testing.T) {
+	t.Run("rate limit disabled", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		assert.Equal(t, hc, NewHostRateLimitedHTTPClient(hc, 0))
+	})
+
+	t.Run("requests rate limited per host", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+		c := NewHostRateLimitedHTTPClient(hc, 1)
+
+		// The first request to each host is not delayed
+		start := time.Now()
+		for _, u := range []string{"https://host1.com/a", "https://host2.com/a"} {
+			req, _ := http.NewRequest("GET", u, nil)
+			_, err := c.Do(req)
+			require.NoError(t, err)
+		}
+		assert.Less(t, int64(time.Since(start)), int64(500*time.Millisecond))
+
+		// The next request to the same host must wait
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, "GET", "https://HOST1.com/b", nil)
+		_, err := c.Do(req)
+		assert.Error(t, err)
+		hc.AssertNumberOfCalls(t, "Do", 2)
+	})
+}
+
+func TestKeyRateLimiter(t *testing.T) {
+	t.Run("operations rate limited per key", func(t *testing.T) {
+		t.Parallel()
+		l := NewKeyRateLimiter(1, 2)
+
+		// Bursts are allowed for each key
+		for _, key := range []string{"key1", "key1", "key2", "key2"} {
+			assert.True(t, l.Allow(key))
+		}
+
+		// Once the burst is exhausted, operations are not allowed
+		assert.False(t, l.Allow("key1"))
+		assert.False(t, l.Allow("key2"))
+		assert.True(t, l.Allow("key3"))
+	})
+
+	t.Run("burst defaults to rps", func(t *testing.T) {
+		t.Parallel()
+		l := NewKeyRateLimiter(0.5, 0)
+		assert.True(t, l.Allow("key1"))
+		assert.False(t, l.Allow("key1"))
+	})
+
+	t.Run("idle keys removed", func(t *testing.T) {
+		t.Parallel()
+		l := NewKeyRateLimiter(1, 1)
+		assert.True(t, l.Allow("key1"))
+		assert.True(t, l.Allow("key2"))
+
+		// Make key1 idle and force a cleanup on the next operation
+		l.mu.Lock()
+		l.limiters["key1"].lastSeen = time.Now().Add(-2 * keyRateLimiterIdleTTL)
+		l.lastCleanup = time.Now().Add(-2 * keyRateLimiterCleanupInterval)
+		l.mu.Unlock()
+
+		assert.False(t, l.Allow("key2"))
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		assert.NotContains(t, l.limiters, "key1")
+		assert.Contains(t, l.limiters, "key2")
+	})
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"html/template"
 	"net/url"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/sandbox"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/satori/uuid"
 )
@@ -20,9 +21,16 @@ const (
 	getOrgWebhooksDBQ             = `select * from get_org_webhooks($1::uuid, $2::text, $3::int, $4::int)`
 	getUserWebhooksDBQ            = `select * from get_user_webhooks($1::uuid, $2::int, $3::int)`
 	getWebhookDBQ                 = `select get_webhook($1::uuid, $2::uuid)`
+	replayWebhookEventsDBQ        = `select replay_webhook_events($1::uuid, $2::uuid, $3::timestamptz, $4::timestamptz)`
 	updateWebhookDBQ              = `select update_webhook($1::uuid, $2::jsonb)`
 )
 
+const (
+	// MaxReplayRange represents the maximum time range of the events that can
+	// be replayed for a webhook in a single request.
+	MaxReplayRange = 30 * 24 * time.Hour
+)
+
 // Manager provides an API to manage webhooks.
 type Manager struct {
 	db hub.DB
@@ -50,7 +58,7 @@ func (m *Manager) Add(ctx context.Context, orgName string, wh *hub.Webhook) erro
 	if err != nil || u.Scheme == "" || u.Host == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid url")
 	}
-	if _, err := template.New("").Parse(wh.Template); err != nil {
+	if _, err := sandbox.ParseTemplate(wh.Template); err != nil {
 		return fmt.Errorf("%w: %s %s", hub.ErrInvalidInput, "invalid template", err)
 	}
 	if len(wh.EventKinds) == 0 {
@@ -143,7 +151,7 @@ func (m *Manager) GetSubscribedTo(ctx context.Context, e *hub.Event) ([]*hub.Web
 	var dataJSON []byte
 	var err error
 	switch e.EventKind {
-	case hub.NewRelease, hub.SecurityAlert:
+	case hub.NewRelease, hub.SecurityAlert, hub.ContentWarnings, hub.PackageVersionEOL:
 		if _, err := uuid.FromString(e.PackageID); err != nil {
 			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
 		}
@@ -161,6 +169,40 @@ func (m *Manager) GetSubscribedTo(ctx context.Context, e *hub.Event) ([]*hub.Web
 	return webhooks, err
 }
 
+// Replay queues again the notifications of the events that occurred in the
+// time range provided and that the webhook is subscribed to, so that they are
+// delivered once more. The number of notifications queued is returned.
+func (m *Manager) Replay(ctx context.Context, webhookID string, input *hub.WebhookReplayInput) (int, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return 0, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if input.From <= 0 || input.To <= 0 {
+		return 0, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "time range not provided")
+	}
+	from := time.Unix(input.From, 0)
+	to := time.Unix(input.To, 0)
+	if !from.Before(to) {
+		return 0, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid time range: from must be before to")
+	}
+	if to.Sub(from) > MaxReplayRange {
+		return 0, fmt.Errorf("%w: %s (max: %s)", hub.ErrInvalidInput, "time range too large", MaxReplayRange)
+	}
+
+	// Replay webhook events in database
+	var notificationsQueued int
+	err := m.db.QueryRow(ctx, replayWebhookEventsDBQ, userID, webhookID, from, to).Scan(&notificationsQueued)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return 0, hub.ErrInsufficientPrivilege
+		}
+		return 0, err
+	}
+	return notificationsQueued, nil
+}
+
 // Update updates the provided webhook in the database.
 func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -179,8 +221,8 @@ func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 	if err != nil || u.Scheme == "" || u.Host == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid url")
 	}
-	if _, err := template.New("").Parse(wh.Template); err != nil {
-		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid template")
+	if _, err := sandbox.ParseTemplate(wh.Template); err != nil {
+		return fmt.Errorf("%w: %s %s", hub.ErrInvalidInput, "invalid template", err)
 	}
 	if len(wh.EventKinds) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no event kinds provided")
@@ -197,8 +239,14 @@ func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 	// Update webhook in database
 	whJSON, _ := json.Marshal(wh)
 	_, err = m.db.Exec(ctx, updateWebhookDBQ, userID, whJSON)
-	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
-		return hub.ErrInsufficientPrivilege
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBVersionConflict.Error():
+			return fmt.Errorf("%w: %s", hub.ErrConflict, "webhook modified since it was read")
+		}
+		return err
 	}
-	return err
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
@@ -73,6 +74,15 @@ func TestAdd(t *testing.T) {
 					Template: "{{ .",
 				},
 			},
+			{
+				"function call not allowed",
+				"org1",
+				&hub.Webhook{
+					Name:     "webhook",
+					URL:      "http://webhook1.url",
+					Template: "{{ call .Func }}",
+				},
+			},
 			{
 				"no event kinds provided",
 				"org1",
@@ -436,6 +446,105 @@ func TestGetSubscribedTo(t *testing.T) {
 	})
 }
 
+func TestReplay(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	input := &hub.WebhookReplayInput{
+		From: 1640995200,
+		To:   1641081600,
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.Replay(context.Background(), validUUID, input)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			webhookID string
+			input     *hub.WebhookReplayInput
+		}{
+			{
+				"invalid webhook id",
+				"invalid",
+				input,
+			},
+			{
+				"time range not provided",
+				validUUID,
+				&hub.WebhookReplayInput{},
+			},
+			{
+				"invalid time range",
+				validUUID,
+				&hub.WebhookReplayInput{From: 1641081600, To: 1640995200},
+			},
+			{
+				"time range too large",
+				validUUID,
+				&hub.WebhookReplayInput{From: 1640995200, To: 1640995200 + 31*24*3600},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.Replay(ctx, tc.webhookID, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, replayWebhookEventsDBQ, "userID", validUUID, time.Unix(input.From, 0), time.Unix(input.To, 0)).
+					Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				notificationsQueued, err := m.Replay(ctx, validUUID, input)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Zero(t, notificationsQueued)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook events replayed successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, replayWebhookEventsDBQ, "userID", validUUID, time.Unix(input.From, 0), time.Unix(input.To, 0)).
+			Return(3, nil)
+		m := NewManager(db)
+
+		notificationsQueued, err := m.Replay(ctx, validUUID, input)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, notificationsQueued)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -500,6 +609,15 @@ func TestUpdate(t *testing.T) {
 					Template:  "{{ .",
 				},
 			},
+			{
+				"function call not allowed",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Template:  "{{ call .Func }}",
+				},
+			},
 			{
 				"no event kinds provided",
 				&hub.Webhook{
@@ -556,6 +674,10 @@ func TestUpdate(t *testing.T) {
 				util.ErrDBInsufficientPrivilege,
 				hub.ErrInsufficientPrivilege,
 			},
+			{
+				util.ErrDBVersionConflict,
+				hub.ErrConflict,
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -566,7 +688,7 @@ func TestUpdate(t *testing.T) {
 				m := NewManager(db)
 
 				err := m.Update(ctx, wh)
-				assert.Equal(t, tc.expectedError, err)
+				assert.True(t, errors.Is(err, tc.expectedError))
 				db.AssertExpectations(t)
 			})
 		}
@@ -56,6 +56,12 @@ func (m *ManagerMock) GetJSON(ctx context.Context, webhookID string) ([]byte, er
 	return data, args.Error(1)
 }
 
+// Replay implements the WebhookManager interface.
+func (m *ManagerMock) Replay(ctx context.Context, webhookID string, input *hub.WebhookReplayInput) (int, error) {
+	args := m.Called(ctx, webhookID, input)
+	return args.Int(0), args.Error(1)
+}
+
 // Update implements the WebhookManager interface.
 func (m *ManagerMock) Update(ctx context.Context, wh *hub.Webhook) error {
 	args := m.Called(ctx, wh)
@@ -387,6 +387,21 @@ class API_CLASS {
     });
   }
 
+  public verifyMaintainer(code: string): Promise<null> {
+    return this.apiFetch({
+      url: `${this.API_BASE_URL}/maintainers/verify`,
+      opts: {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+        },
+        body: JSON.stringify({
+          code: code,
+        }),
+      },
+    });
+  }
+
   public login(user: UserLogin): Promise<null | string> {
     return this.apiFetch({
       url: `${this.API_BASE_URL}/users/login`,
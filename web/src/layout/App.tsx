@@ -52,6 +52,7 @@ export default function App() {
               path={[
                 '/',
                 '/verify-email',
+                '/verify-maintainer',
                 '/login',
                 '/accept-invitation',
                 '/oauth-failed',
@@ -72,6 +73,9 @@ export default function App() {
                     emailCode={
                       location.pathname === '/verify-email' ? getQueryParam(location.search, 'code') : undefined
                     }
+                    maintainerCode={
+                      location.pathname === '/verify-maintainer' ? getQueryParam(location.search, 'code') : undefined
+                    }
                     deleteCode={
                       location.pathname === '/delete-user' ? getQueryParam(location.search, 'code') : undefined
                     }
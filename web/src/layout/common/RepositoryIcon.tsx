@@ -61,6 +61,26 @@ const ICONS = {
     default: '/static/media/container.svg',
     white: '/static/media/container-light.svg',
   },
+  [RepositoryKind.Terraform]: {
+    default: '/static/media/terraform-module.svg',
+    white: '/static/media/terraform-module-light.svg',
+  },
+  [RepositoryKind.Crossplane]: {
+    default: '/static/media/crossplane.svg',
+    white: '/static/media/crossplane-light.svg',
+  },
+  [RepositoryKind.Kyverno]: {
+    default: '/static/media/kyverno-policies.svg',
+    white: '/static/media/kyverno-policies-light.svg',
+  },
+  [RepositoryKind.KnativeFunc]: {
+    default: '/static/media/knative-func.svg',
+    white: '/static/media/knative-func-light.svg',
+  },
+  [RepositoryKind.Headlamp]: {
+    default: '/static/media/headlamp.svg',
+    white: '/static/media/headlamp-light.svg',
+  },
 };
 
 const RepositoryIcon = (props: Props) => {
@@ -366,6 +366,61 @@ const RepositoryModal = (props: Props) => {
           </ExternalLink>
         );
         break;
+      case RepositoryKind.Terraform:
+        link = (
+          <ExternalLink
+            href="/docs/topics/repositories#terraform-modules-repositories"
+            className="text-primary fw-bold"
+            label="Open documentation"
+          >
+            Terraform modules
+          </ExternalLink>
+        );
+        break;
+      case RepositoryKind.Crossplane:
+        link = (
+          <ExternalLink
+            href="/docs/topics/repositories#crossplane-packages-repositories"
+            className="text-primary fw-bold"
+            label="Open documentation"
+          >
+            Crossplane packages
+          </ExternalLink>
+        );
+        break;
+      case RepositoryKind.Kyverno:
+        link = (
+          <ExternalLink
+            href="/docs/topics/repositories#kyverno-policies-repositories"
+            className="text-primary fw-bold"
+            label="Open documentation"
+          >
+            Kyverno policies
+          </ExternalLink>
+        );
+        break;
+      case RepositoryKind.KnativeFunc:
+        link = (
+          <ExternalLink
+            href="/docs/topics/repositories#knative-function-templates-repositories"
+            className="text-primary fw-bold"
+            label="Open documentation"
+          >
+            Knative function templates
+          </ExternalLink>
+        );
+        break;
+      case RepositoryKind.Headlamp:
+        link = (
+          <ExternalLink
+            href="/docs/topics/repositories#headlamp-plugins-repositories"
+            className="text-primary fw-bold"
+            label="Open documentation"
+          >
+            Headlamp plugins
+          </ExternalLink>
+        );
+        break;
     }
 
     if (isUndefined(link)) return;
@@ -386,6 +441,11 @@ const RepositoryModal = (props: Props) => {
               case RepositoryKind.CoreDNS:
               case RepositoryKind.Keptn:
               case RepositoryKind.TektonPipeline:
+              case RepositoryKind.Terraform:
+              case RepositoryKind.Crossplane:
+              case RepositoryKind.Kyverno:
+              case RepositoryKind.KnativeFunc:
+              case RepositoryKind.Headlamp:
                 return (
                   <>
                     <p
@@ -770,6 +830,11 @@ const RepositoryModal = (props: Props) => {
               RepositoryKind.CoreDNS,
               RepositoryKind.Keptn,
               RepositoryKind.TektonPipeline,
+              RepositoryKind.Terraform,
+              RepositoryKind.Crossplane,
+              RepositoryKind.Kyverno,
+              RepositoryKind.KnativeFunc,
+              RepositoryKind.Headlamp,
             ].includes(selectedKind) && (
               <div>
                 <InputField
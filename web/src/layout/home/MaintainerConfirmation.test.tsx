@@ -0,0 +1,102 @@
+import { render, screen, waitFor } from '@testing-library/react';
+import { mocked } from 'jest-mock';
+import { BrowserRouter as Router } from 'react-router-dom';
+
+import API from '../../api';
+import { ErrorKind } from '../../types';
+import MaintainerConfirmation from './MaintainerConfirmation';
+jest.mock('../../api');
+
+const defaultProps = {
+  code: 'code',
+};
+
+describe('MaintainerConfirmation', () => {
+  afterEach(() => {
+    jest.resetAllMocks();
+  });
+
+  it('when code is valid', async () => {
+    mocked(API).verifyMaintainer.mockResolvedValue(null);
+
+    render(
+      <Router>
+        <MaintainerConfirmation {...defaultProps} />
+      </Router>
+    );
+
+    await waitFor(() => {
+      expect(API.verifyMaintainer).toHaveBeenCalledTimes(1);
+    });
+    expect(screen.getByText(/Your email has been verified!/g)).toBeInTheDocument();
+  });
+
+  it('does not render component when code is undefined', () => {
+    render(
+      <Router>
+        <MaintainerConfirmation />
+      </Router>
+    );
+
+    expect(screen.queryByTestId('maintainerConfirmationModal')).toBeNull();
+  });
+
+  describe('when code is invalid', () => {
+    it('with custom error message', async () => {
+      mocked(API).verifyMaintainer.mockRejectedValue({
+        kind: ErrorKind.Other,
+        message: 'custom error',
+      });
+
+      render(
+        <Router>
+          <MaintainerConfirmation {...defaultProps} />
+        </Router>
+      );
+
+      await waitFor(() => {
+        expect(API.verifyMaintainer).toHaveBeenCalledTimes(1);
+      });
+      expect(screen.getByText('Sorry, custom error')).toBeInTheDocument();
+    });
+
+    it('Code has expired', async () => {
+      mocked(API).verifyMaintainer.mockRejectedValue({
+        kind: ErrorKind.Other,
+        message: 'maintainer verification code has expired.',
+      });
+
+      render(
+        <Router>
+          <MaintainerConfirmation {...defaultProps} />
+        </Router>
+      );
+
+      await waitFor(() => {
+        expect(API.verifyMaintainer).toHaveBeenCalledTimes(1);
+      });
+
+      expect(screen.getByText('Sorry, maintainer verification code has expired.')).toBeInTheDocument();
+    });
+
+    it('default error message', async () => {
+      mocked(API).verifyMaintainer.mockRejectedValue({
+        kind: ErrorKind.Other,
+      });
+
+      render(
+        <Router>
+          <MaintainerConfirmation {...defaultProps} />
+        </Router>
+      );
+
+      await waitFor(() => {
+        expect(API.verifyMaintainer).toHaveBeenCalledTimes(1);
+      });
+
+      expect(
+        screen.getByText('An error occurred verifying your maintainer email, please contact us about this issue.')
+      ).toBeInTheDocument();
+    });
+  });
+});
@@ -0,0 +1,87 @@
+import isUndefined from 'lodash/isUndefined';
+import { useEffect, useState } from 'react';
+import { MdClose, MdDone } from 'react-icons/md';
+import { useHistory } from 'react-router-dom';
+
+import API from '../../api';
+import Loading from '../common/Loading';
+import Modal from '../common/Modal';
+import styles from './UserConfirmation.module.css';
+
+interface Props {
+  code?: string;
+}
+
+const MaintainerConfirmation = (props: Props) => {
+  const [code] = useState(props.code);
+  const [verifying, setVerifying] = useState(false);
+  const [validEmail, setValidEmail] = useState<boolean | null>(null);
+  const [apiError, setApiError] = useState<string | null>(null);
+  const history = useHistory();
+
+  useEffect(() => {
+    async function fetchMaintainerConfirmation() {
+      setVerifying(true);
+      try {
+        await API.verifyMaintainer(code!);
+        setValidEmail(true);
+      } catch (err: any) {
+        let error = 'An error occurred verifying your maintainer email, please contact us about this issue.';
+        if (!isUndefined(err.message)) {
+          error = `Sorry, ${err.message}`;
+        }
+        setApiError(error);
+        setValidEmail(false);
+      } finally {
+        setVerifying(false);
+      }
+    }
+
+    if (!isUndefined(code)) {
+      history.replace({
+        pathname: '/',
+        search: '',
+      });
+      fetchMaintainerConfirmation();
+    }
+  }, [code, history]);
+
+  if (isUndefined(code)) return null;
+
+  return (
+    <Modal
+      data-testid="maintainerConfirmationModal"
+      header={<div className={`h3 m-2 flex-grow-1 ${styles.title}`}>Maintainer email confirmation</div>}
+      disabledClose={verifying}
+      modalClassName={styles.modal}
+      open={!isUndefined(code)}
+    >
+      <div
+        className={`d-flex flex-column h-100 w-100 px-3 align-items-center justify-content-center text-center position-relative ${styles.content}`}
+      >
+        {verifying ? (
+          <>
+            <Loading className="position-relative" spinnerClassName="mt-0" />
+            <small className="text-muted">We are verifying your email...</small>
+          </>
+        ) : (
+          <>
+            {validEmail ? (
+              <>
+                <MdDone className="display-4 text-success mb-4" />
+                Your email has been verified! You will now be displayed as a verified maintainer.
+              </>
+            ) : (
+              <>
+                <MdClose className="display-4 text-danger mb-4" />
+                {apiError}
+              </>
+            )}
+          </>
+        )}
+      </div>
+    </Modal>
+  );
+};
+
+export default MaintainerConfirmation;
@@ -20,11 +20,13 @@ import styles from './HomeView.module.css';
 import RandomPackages from './RandomPackages';
 import ResetPasswordModal from './ResetPasswordModal';
 import SearchTip from './SearchTip';
+import MaintainerConfirmation from './MaintainerConfirmation';
 import UserConfirmation from './UserConfirmation';
 
 interface Props {
   isSearching: boolean;
   emailCode?: string;
+  maintainerCode?: string;
   deleteCode?: string;
   resetPwdCode?: string;
   orgToConfirm?: string;
@@ -356,6 +358,7 @@ const HomeView = (props: Props) => {
       )}
 
       <UserConfirmation emailCode={props.emailCode} />
+      <MaintainerConfirmation code={props.maintainerCode} />
       <AccountDeletion code={props.deleteCode} />
       <UserInvitation orgToConfirm={props.orgToConfirm} />
       <ResetPasswordModal code={props.resetPwdCode} />
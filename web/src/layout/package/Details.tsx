@@ -5,12 +5,16 @@ import { useCallback, useEffect, useState } from 'react';
 
 import {
   Channel,
+  CrossplaneDependency,
+  GatekeeperConstraintTemplate,
+  HeadlampData,
   HelmChartType,
   KeptnData,
   Package,
   PackageViewsStats,
   RepositoryKind,
   SearchFiltersURL,
+  TerraformProvider,
   Version as VersionData,
 } from '../../types';
 import RSSLinkTitle from '../common/RSSLinkTitle';
@@ -270,6 +274,74 @@ const Details = (props: Props) => {
               </>
             );
 
+          case RepositoryKind.Falco:
+            const plugin = props.package.data ? props.package.data.plugin : undefined;
+
+            return (
+              <>
+                {plugin && plugin.requiredFalcoVersion && (
+                  <div>
+                    <SmallTitle text="Falco version" />
+                    <p data-testid="falcoVersion" className="text-truncate">
+                      {plugin.requiredFalcoVersion}
+                    </p>
+                  </div>
+                )}
+
+                {plugin && plugin.requiredAPIVersion && (
+                  <div>
+                    <SmallTitle text="Plugin API version" />
+                    <p data-testid="falcoPluginAPIVersion" className="text-truncate">
+                      {plugin.requiredAPIVersion}
+                    </p>
+                  </div>
+                )}
+
+                {plugin && plugin.capabilities && plugin.capabilities.length > 0 && (
+                  <div>
+                    <SmallTitle text="Capabilities" />
+                    <p data-testid="falcoPluginCapabilities" className="text-truncate text-capitalize">
+                      {plugin.capabilities.join(', ')}
+                    </p>
+                  </div>
+                )}
+
+                {plugin && plugin.eventSources && plugin.eventSources.length > 0 && (
+                  <div>
+                    <SmallTitle text="Event sources" />
+                    <p data-testid="falcoPluginEventSources" className="text-truncate">
+                      {plugin.eventSources.join(', ')}
+                    </p>
+                  </div>
+                )}
+              </>
+            );
+          case RepositoryKind.OPA:
+            return (
+              <>
+                {props.package.data &&
+                  props.package.data.constraintTemplates &&
+                  props.package.data.constraintTemplates.length > 0 && (
+                    <div>
+                      <SmallTitle text="Constraint templates" />
+                      {props.package.data.constraintTemplates.map(
+                        (template: GatekeeperConstraintTemplate, index: number) => (
+                          <p
+                            data-testid="gatekeeperTemplate"
+                            className={classnames('text-truncate', {
+                              'mb-1': index + 1 !== props.package.data!.constraintTemplates!.length,
+                            })}
+                            key={`gatekeeper-template-${template.name}`}
+                          >
+                            {template.title || template.kind}
+                            {template.version && <small className="text-muted ms-1">({template.version})</small>}
+                          </p>
+                        )
+                      )}
+                    </div>
+                  )}
+              </>
+            );
           case RepositoryKind.Keptn:
             const kinds: string[] =
               props.package.data &&
@@ -306,6 +378,217 @@ const Details = (props: Props) => {
                 )}
               </>
             );
+          case RepositoryKind.Terraform:
+            return (
+              <>
+                {props.package.data && props.package.data.requiredVersion && (
+                  <div>
+                    <SmallTitle text="Terraform version" />
+                    <p data-testid="terraformVersion" className="text-truncate">
+                      {props.package.data.requiredVersion}
+                    </p>
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.providers && props.package.data.providers.length > 0 && (
+                  <div>
+                    <SmallTitle text="Providers" />
+                    {props.package.data.providers.map((provider: TerraformProvider, index: number) => (
+                      <p
+                        data-testid="terraformProvider"
+                        className={classnames('text-truncate', {
+                          'mb-1': index + 1 !== props.package.data!.providers!.length,
+                        })}
+                        key={`terraform-provider-${provider.name}`}
+                      >
+                        {provider.source || provider.name}
+                        {provider.version && <small className="text-muted ms-1">({provider.version})</small>}
+                      </p>
+                    ))}
+                  </div>
+                )}
+              </>
+            );
+          case RepositoryKind.Crossplane:
+            return (
+              <>
+                {props.package.data && props.package.data.packageType && (
+                  <div>
+                    <SmallTitle text="Package type" />
+                    <p data-testid="crossplanePackageType" className="text-truncate">
+                      {props.package.data.packageType}
+                    </p>
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.crossplaneVersion && (
+                  <div>
+                    <SmallTitle text="Crossplane version" />
+                    <p data-testid="crossplaneVersion" className="text-truncate">
+                      {props.package.data.crossplaneVersion}
+                    </p>
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.dependsOn && props.package.data.dependsOn.length > 0 && (
+                  <div>
+                    <SmallTitle text="Dependencies" />
+                    {props.package.data.dependsOn.map((dep: CrossplaneDependency, index: number) => (
+                      <p
+                        data-testid="crossplaneDependency"
+                        className={classnames('text-truncate', {
+                          'mb-1': index + 1 !== props.package.data!.dependsOn!.length,
+                        })}
+                        key={`crossplane-dependency-${dep.package}`}
+                      >
+                        {dep.package}
+                        {dep.version && <small className="text-muted ms-1">({dep.version})</small>}
+                      </p>
+                    ))}
+                  </div>
+                )}
+              </>
+            );
+          case RepositoryKind.Headlamp:
+            const distros: string[] =
+              props.package.data &&
+              !isUndefined(props.package.data[HeadlampData.DistroCompat]) &&
+              props.package.data[HeadlampData.DistroCompat] !== ''
+                ? props.package.data[HeadlampData.DistroCompat]!.split(',')
+                : [];
+
+            return (
+              <>
+                {props.package.data && props.package.data[HeadlampData.VersionCompat] && (
+                  <div>
+                    <SmallTitle text="Headlamp version" />
+                    <p data-testid="headlampVersionCompat" className="text-truncate">
+                      {props.package.data[HeadlampData.VersionCompat]}
+                    </p>
+                  </div>
+                )}
+                {distros.length > 0 && (
+                  <div>
+                    <SmallTitle text="Distributions" />
+                    {distros.map((distro: string, index: number) => (
+                      <p
+                        data-testid="headlampDistro"
+                        className={classnames('text-truncate', { 'mb-1': index + 1 !== distros.length })}
+                        key={`headlamp-distro-${distro}`}
+                      >
+                        {distro.trim()}
+                      </p>
+                    ))}
+                  </div>
+                )}
+              </>
+            );
+          case RepositoryKind.KnativeFunc:
+            return (
+              <>
+                {props.package.data && props.package.data.runtime && (
+                  <div>
+                    <SmallTitle text="Runtime" />
+                    <p data-testid="knativeFuncRuntime" className="text-truncate">
+                      {props.package.data.runtime}
+                    </p>
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.template && (
+                  <div>
+                    <SmallTitle text="Template" />
+                    <p data-testid="knativeFuncTemplate" className="text-truncate">
+                      {props.package.data.template}
+                    </p>
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.invoke && (
+                  <div>
+                    <SmallTitle text="Invocation" />
+                    <p data-testid="knativeFuncInvoke" className="text-truncate">
+                      {props.package.data.invoke}
+                    </p>
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.buildpacks && props.package.data.buildpacks.length > 0 && (
+                  <div>
+                    <SmallTitle text="Buildpacks" />
+                    {props.package.data.buildpacks.map((buildpack: string, index: number) => (
+                      <p
+                        data-testid="knativeFuncBuildpack"
+                        className={classnames('text-truncate', {
+                          'mb-1': index + 1 !== props.package.data!.buildpacks!.length,
+                        })}
+                        key={`knative-func-buildpack-${buildpack}`}
+                      >
+                        {buildpack}
+                      </p>
+                    ))}
+                  </div>
+                )}
+              </>
+            );
+          case RepositoryKind.Kyverno:
+            return (
+              <>
+                {props.package.data && props.package.data.severity && (
+                  <div>
+                    <SmallTitle text="Severity" />
+                    <p data-testid="kyvernoSeverity" className="text-truncate text-capitalize">
+                      {props.package.data.severity}
+                    </p>
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.categories && props.package.data.categories.length > 0 && (
+                  <div>
+                    <SmallTitle text="Categories" />
+                    {props.package.data.categories.map((category: string, index: number) => (
+                      <p
+                        data-testid="kyvernoCategory"
+                        className={classnames('text-truncate', {
+                          'mb-1': index + 1 !== props.package.data!.categories!.length,
+                        })}
+                        key={`kyverno-category-${category}`}
+                      >
+                        {category}
+                      </p>
+                    ))}
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.subjects && props.package.data.subjects.length > 0 && (
+                  <div>
+                    <SmallTitle text="Subjects" />
+                    <p data-testid="kyvernoSubjects" className="text-truncate">
+                      {props.package.data.subjects.join(', ')}
+                    </p>
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.kyvernoVersion && (
+                  <div>
+                    <SmallTitle text="Kyverno version" />
+                    <p data-testid="kyvernoVersion" className="text-truncate">
+                      {props.package.data.kyvernoVersion}
+                    </p>
+                  </div>
+                )}
+
+                {props.package.data && props.package.data.kubernetesVersion && (
+                  <div>
+                    <SmallTitle text="Kubernetes version" />
+                    <p data-testid="kyvernoKubernetesVersion" className="text-truncate">
+                      {props.package.data.kubernetesVersion}
+                    </p>
+                  </div>
+                )}
+              </>
+            );
+
           default:
             return null;
         }
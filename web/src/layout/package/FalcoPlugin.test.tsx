@@ -0,0 +1,42 @@
+import { render, screen } from '@testing-library/react';
+
+import FalcoPlugin from './FalcoPlugin';
+
+const defaultProps = {
+  fields: [
+    { name: 'ka.user.name', type: 'string', desc: 'The user name performing the request' },
+    {
+      name: 'ka.req.pod.containers.image',
+      type: 'string',
+      desc: "When the request object refers to a pod, the container's images",
+      isList: true,
+      arg: { isIndex: true, isRequired: true },
+    },
+  ],
+  scrollIntoView: jest.fn(),
+};
+
+describe('FalcoPlugin', () => {
+  afterEach(() => {
+    jest.resetAllMocks();
+  });
+
+  describe('Render', () => {
+    it('renders properly', () => {
+      render(<FalcoPlugin {...defaultProps} />);
+
+      expect(screen.getByText('Fields')).toBeInTheDocument();
+      expect(screen.getByTestId('falcoPluginFields')).toBeInTheDocument();
+      expect(screen.getByText('ka.user.name')).toBeInTheDocument();
+      expect(screen.getByText('The user name performing the request')).toBeInTheDocument();
+      expect(screen.getByText('ka.req.pod.containers.image')).toBeInTheDocument();
+      expect(screen.getByText('[index]')).toBeInTheDocument();
+      expect(screen.getByText('Arg required')).toBeInTheDocument();
+    });
+
+    it('does not render component when no fields are available', () => {
+      const { container } = render(<FalcoPlugin scrollIntoView={jest.fn()} />);
+      expect(container).toBeEmptyDOMElement();
+    });
+  });
+});
@@ -0,0 +1,55 @@
+import isUndefined from 'lodash/isUndefined';
+
+import { FalcoPluginField } from '../../types';
+import AnchorHeader from '../common/AnchorHeader';
+import styles from './FalcoPlugin.module.css';
+
+interface Props {
+  fields?: FalcoPluginField[];
+  scrollIntoView: (id?: string) => void;
+}
+
+const FalcoPlugin = (props: Props) => {
+  if (isUndefined(props.fields) || props.fields.length === 0) return null;
+
+  return (
+    <div className="mb-5">
+      <AnchorHeader level={2} scrollIntoView={props.scrollIntoView} title="Fields" />
+      <div className="table-responsive">
+        <table className={`table table-bordered ${styles.table}`} data-testid="falcoPluginFields">
+          <thead>
+            <tr>
+              <th className={styles.nameCell}>Name</th>
+              <th>Type</th>
+              <th>Description</th>
+            </tr>
+          </thead>
+          <tbody>
+            {props.fields.map((field: FalcoPluginField) => (
+              <tr key={`field_${field.name}`}>
+                <td className="text-break">
+                  <code>{field.name}</code>
+                  {field.arg && (field.arg.isIndex || field.arg.isKey) && (
+                    <code>{field.arg.isIndex ? '[index]' : '[key]'}</code>
+                  )}
+                  {field.arg && field.arg.isRequired && (
+                    <span className={`badge bg-secondary ms-2 ${styles.badge}`}>Arg required</span>
+                  )}
+                </td>
+                <td>
+                  <code>
+                    {field.type}
+                    {field.isList && ' (list)'}
+                  </code>
+                </td>
+                <td className="text-break">{field.desc || '-'}</td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      </div>
+    </div>
+  );
+};
+
+export default FalcoPlugin;
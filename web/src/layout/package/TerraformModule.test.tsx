@@ -0,0 +1,45 @@
+import { render, screen } from '@testing-library/react';
+
+import TerraformModule from './TerraformModule';
+
+const defaultProps = {
+  variables: [
+    { name: 'bucket_name', type: 'string', description: 'Name of the bucket', required: true, sensitive: false },
+    { name: 'kms_key', type: 'string', default: 'null', required: false, sensitive: true },
+  ],
+  outputs: [{ name: 'bucket_arn', description: 'ARN of the bucket', sensitive: false }],
+  scrollIntoView: jest.fn(),
+};
+
+describe('TerraformModule', () => {
+  afterEach(() => {
+    jest.resetAllMocks();
+  });
+
+  describe('Render', () => {
+    it('renders properly', () => {
+      render(<TerraformModule {...defaultProps} />);
+
+      expect(screen.getByText('Inputs')).toBeInTheDocument();
+      expect(screen.getByText('Outputs')).toBeInTheDocument();
+      expect(screen.getByText('bucket_name')).toBeInTheDocument();
+      expect(screen.getByText('Name of the bucket')).toBeInTheDocument();
+      expect(screen.getByText('Required')).toBeInTheDocument();
+      expect(screen.getByText('Sensitive')).toBeInTheDocument();
+      expect(screen.getByText('bucket_arn')).toBeInTheDocument();
+      expect(screen.getByText('ARN of the bucket')).toBeInTheDocument();
+    });
+
+    it('renders only inputs', () => {
+      render(<TerraformModule {...defaultProps} outputs={[]} />);
+
+      expect(screen.getByTestId('terraformInputs')).toBeInTheDocument();
+      expect(screen.queryByTestId('terraformOutputs')).toBeNull();
+    });
+
+    it('does not render component when no inputs or outputs are available', () => {
+      const { container } = render(<TerraformModule scrollIntoView={jest.fn()} />);
+      expect(container).toBeEmptyDOMElement();
+    });
+  });
+});
@@ -0,0 +1,84 @@
+import isUndefined from 'lodash/isUndefined';
+
+import { TerraformOutput, TerraformVariable } from '../../types';
+import AnchorHeader from '../common/AnchorHeader';
+import styles from './TerraformModule.module.css';
+
+interface Props {
+  variables?: TerraformVariable[];
+  outputs?: TerraformOutput[];
+  scrollIntoView: (id?: string) => void;
+}
+
+const TerraformModule = (props: Props) => {
+  const hasVariables = !isUndefined(props.variables) && props.variables.length > 0;
+  const hasOutputs = !isUndefined(props.outputs) && props.outputs.length > 0;
+  if (!hasVariables && !hasOutputs) return null;
+
+  return (
+    <>
+      {hasVariables && (
+        <div className="mb-5">
+          <AnchorHeader level={2} scrollIntoView={props.scrollIntoView} title="Inputs" />
+          <div className="table-responsive">
+            <table className={`table table-bordered ${styles.table}`} data-testid="terraformInputs">
+              <thead>
+                <tr>
+                  <th className={styles.nameCell}>Name</th>
+                  <th>Description</th>
+                  <th>Type</th>
+                  <th>Default</th>
+                </tr>
+              </thead>
+              <tbody>
+                {props.variables!.map((variable: TerraformVariable) => (
+                  <tr key={`var_${variable.name}`}>
+                    <td className="text-break">
+                      <code>{variable.name}</code>
+                      {variable.required && (
+                        <span className={`badge bg-secondary ms-2 ${styles.badge}`}>Required</span>
+                      )}
+                      {variable.sensitive && <span className={`badge bg-dark ms-2 ${styles.badge}`}>Sensitive</span>}
+                    </td>
+                    <td className="text-break">{variable.description || '-'}</td>
+                    <td>{variable.type ? <code>{variable.type}</code> : '-'}</td>
+                    <td>{variable.default ? <code>{variable.default}</code> : '-'}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          </div>
+        </div>
+      )}
+
+      {hasOutputs && (
+        <div className="mb-5">
+          <AnchorHeader level={2} scrollIntoView={props.scrollIntoView} title="Outputs" />
+          <div className="table-responsive">
+            <table className={`table table-bordered ${styles.table}`} data-testid="terraformOutputs">
+              <thead>
+                <tr>
+                  <th className={styles.nameCell}>Name</th>
+                  <th>Description</th>
+                </tr>
+              </thead>
+              <tbody>
+                {props.outputs!.map((output: TerraformOutput) => (
+                  <tr key={`output_${output.name}`}>
+                    <td className="text-break">
+                      <code>{output.name}</code>
+                      {output.sensitive && <span className={`badge bg-dark ms-2 ${styles.badge}`}>Sensitive</span>}
+                    </td>
+                    <td className="text-break">{output.description || '-'}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          </div>
+        </div>
+      )}
+    </>
+  );
+};
+
+export default TerraformModule;
@@ -53,6 +53,7 @@ import SubNavbar from '../navigation/SubNavbar';
 import ChangelogModal from './changelog/Modal';
 import ChartTemplatesModal from './chartTemplates';
 import Details from './Details';
+import FalcoPlugin from './FalcoPlugin';
 import InProductionButton from './InProductionButton';
 import InstallationModal from './installation/Modal';
 import ModalHeader from './ModalHeader';
@@ -69,6 +70,7 @@ import StarButton from './StarButton';
 import Stats from './Stats';
 import SubscriptionsButton from './SubscriptionsButton';
 import TektonManifestModal from './TektonManifestModal';
+import TerraformModule from './TerraformModule';
 import Values from './values';
 import ValuesSchema from './valuesSchema';
 
@@ -549,6 +551,37 @@ const PackageView = (props: Props) => {
                 </>
               );
 
+            case RepositoryKind.Falco:
+              if (
+                detail.data &&
+                detail.data.plugin &&
+                detail.data.plugin.fields &&
+                detail.data.plugin.fields.length > 0
+              ) {
+                additionalTitles += '# Fields\n';
+              }
+              return (
+                <FalcoPlugin
+                  fields={detail.data && detail.data.plugin ? detail.data.plugin.fields : undefined}
+                  scrollIntoView={scrollIntoView}
+                />
+              );
+
+            case RepositoryKind.Terraform:
+              if (detail.data && detail.data.variables && detail.data.variables.length > 0) {
+                additionalTitles += '# Inputs\n';
+              }
+              if (detail.data && detail.data.outputs && detail.data.outputs.length > 0) {
+                additionalTitles += '# Outputs\n';
+              }
+              return (
+                <TerraformModule
+                  variables={detail.data ? detail.data.variables : undefined}
+                  outputs={detail.data ? detail.data.outputs : undefined}
+                  scrollIntoView={scrollIntoView}
+                />
+              );
+
             default:
               return null;
           }
@@ -889,7 +922,7 @@ const PackageView = (props: Props) => {
                               kind={FileModalKind.Policy}
                               packageId={detail.packageId}
                               modalName="policies"
-                              language="text"
+                              language={detail.repository.kind === RepositoryKind.Kyverno ? 'yaml' : 'text'}
                               visibleModal={!isUndefined(props.visibleModal) && props.visibleModal === 'policies'}
                               visibleFile={
                                 !isUndefined(props.visibleModal) && props.visibleModal === 'policies'
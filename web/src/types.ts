@@ -14,6 +14,11 @@ export enum RepositoryKind {
   Keptn,
   TektonPipeline,
   Container,
+  Terraform,
+  Crossplane,
+  Kyverno,
+  KnativeFunc,
+  Headlamp,
 }
 
 export enum KeptnData {
@@ -21,6 +26,13 @@ export enum KeptnData {
   Kind = 'keptnKind',
 }
 
+export enum HeadlampData {
+  ArchiveURL = 'headlamp/plugin/archive-url',
+  ArchiveChecksum = 'headlamp/plugin/archive-checksum',
+  VersionCompat = 'headlamp/plugin/version-compat',
+  DistroCompat = 'headlamp/plugin/distro-compat',
+}
+
 export enum HelmChartType {
   Library = 'library',
   Application = 'application',
@@ -58,8 +70,10 @@ export interface Repository {
 }
 
 export interface Maintainer {
+  maintainerId?: string;
   name?: string;
   email: string;
+  verified?: boolean;
 }
 
 export interface PackageLink {
@@ -79,6 +93,7 @@ export interface Package {
   normalizedName: string;
   description: string;
   logoImageId?: string;
+  logoDarkImageId?: string;
   appVersion?: string;
   repository: Repository;
   readme?: string | null;
@@ -118,6 +133,7 @@ export interface Package {
   signKey?: HelmChartSignKey;
   signatures?: Signature[];
   screenshots?: Screenshot[];
+  videos?: Video[];
   productionOrganizations?: Organization[];
   productionOrganizationsCount?: number;
 }
@@ -125,6 +141,12 @@ export interface Package {
 export interface Screenshot {
   title?: string;
   url: string;
+  imageId?: string;
+}
+
+export interface Video {
+  title?: string;
+  url: string;
 }
 
 export interface HelmChartSignKey {
@@ -201,8 +223,92 @@ export interface PackageData {
   kubeVersion?: string;
   [KeptnData.Version]?: string;
   [KeptnData.Kind]?: string;
+  [HeadlampData.ArchiveURL]?: string;
+  [HeadlampData.ArchiveChecksum]?: string;
+  [HeadlampData.VersionCompat]?: string;
+  [HeadlampData.DistroCompat]?: string;
   tasks?: TektonTaskInPipeline[];
   alternativeLocations?: string[];
+  requiredVersion?: string;
+  variables?: TerraformVariable[];
+  outputs?: TerraformOutput[];
+  providers?: TerraformProvider[];
+  packageType?: string;
+  crossplaneVersion?: string;
+  controllerImage?: string;
+  dependsOn?: CrossplaneDependency[];
+  categories?: string[];
+  subjects?: string[];
+  severity?: string;
+  kyvernoVersion?: string;
+  kubernetesVersion?: string;
+  constraintTemplates?: GatekeeperConstraintTemplate[];
+  plugin?: FalcoPlugin;
+  runtime?: string;
+  template?: string;
+  invoke?: string;
+  buildpacks?: string[];
+}
+
+export interface FalcoPlugin {
+  name: string;
+  ref: string;
+  digest?: string;
+  capabilities?: string[];
+  eventSources?: string[];
+  requiredAPIVersion?: string;
+  requiredFalcoVersion?: string;
+  fields?: FalcoPluginField[];
+}
+
+export interface FalcoPluginField {
+  name: string;
+  type: string;
+  desc?: string;
+  display?: string;
+  isList?: boolean;
+  properties?: string[];
+  arg?: {
+    isRequired?: boolean;
+    isIndex?: boolean;
+    isKey?: boolean;
+  };
+}
+
+export interface GatekeeperConstraintTemplate {
+  name: string;
+  kind: string;
+  title?: string;
+  description?: string;
+  version?: string;
+  targets?: string[];
+}
+
+export interface CrossplaneDependency {
+  kind: string;
+  package: string;
+  version?: string;
+}
+
+export interface TerraformVariable {
+  name: string;
+  type?: string;
+  description?: string;
+  default?: string;
+  required: boolean;
+  sensitive: boolean;
+}
+
+export interface TerraformOutput {
+  name: string;
+  description?: string;
+  sensitive: boolean;
+}
+
+export interface TerraformProvider {
+  name: string;
+  source?: string;
+  version?: string;
 }
 
 export interface TektonTaskInPipeline {
@@ -337,6 +443,7 @@ export interface Organization {
   displayName?: string;
   homeUrl?: string;
   logoImageId?: string;
+  logoDarkImageId?: string;
   description?: string;
   membersCount?: number | null;
   confirmed?: boolean | null;
@@ -106,6 +106,15 @@ export const REPOSITORY_KINDS: RepoKindDef[] = [
     icon: <RepositoryIcon kind={RepositoryKind.CoreDNS} className="mw-100 mh-100" />,
     active: true,
   },
+  {
+    kind: RepositoryKind.Crossplane,
+    label: 'crossplane',
+    name: 'Crossplane packages',
+    singular: 'Crossplane package',
+    plural: 'Crossplane packages',
+    icon: <RepositoryIcon kind={RepositoryKind.Crossplane} className="mw-100 mh-100" />,
+    active: true,
+  },
   {
     kind: RepositoryKind.Falco,
     label: 'falco',
@@ -115,6 +124,15 @@ export const REPOSITORY_KINDS: RepoKindDef[] = [
     icon: <RepositoryIcon kind={RepositoryKind.Falco} className="mw-100 mh-100" />,
     active: true,
   },
+  {
+    kind: RepositoryKind.Headlamp,
+    label: 'headlamp',
+    name: 'Headlamp plugins',
+    singular: 'Headlamp plugin',
+    plural: 'Headlamp plugins',
+    icon: <RepositoryIcon kind={RepositoryKind.Headlamp} className="mw-100 mh-100" />,
+    active: true,
+  },
   {
     kind: RepositoryKind.Helm,
     label: 'helm',
@@ -151,6 +169,15 @@ export const REPOSITORY_KINDS: RepoKindDef[] = [
     icon: <RepositoryIcon kind={RepositoryKind.Keptn} className="mw-100 mh-100" />,
     active: true,
   },
+  {
+    kind: RepositoryKind.KnativeFunc,
+    label: 'knative-func',
+    name: 'Knative function templates',
+    singular: 'Knative function template',
+    plural: 'Knative function templates',
+    icon: <RepositoryIcon kind={RepositoryKind.KnativeFunc} className="mw-100 mh-100" />,
+    active: true,
+  },
   {
     kind: RepositoryKind.Krew,
     label: 'krew',
@@ -160,6 +187,15 @@ export const REPOSITORY_KINDS: RepoKindDef[] = [
     icon: <RepositoryIcon kind={RepositoryKind.Krew} className="mw-100 mh-100" />,
     active: true,
   },
+  {
+    kind: RepositoryKind.Kyverno,
+    label: 'kyverno',
+    name: 'Kyverno policies',
+    singular: 'Kyverno policy',
+    plural: 'Kyverno policies',
+    icon: <RepositoryIcon kind={RepositoryKind.Kyverno} className="mw-100 mh-100" />,
+    active: true,
+  },
   {
     kind: RepositoryKind.OLM,
     label: 'olm',
@@ -196,6 +232,15 @@ export const REPOSITORY_KINDS: RepoKindDef[] = [
     icon: <RepositoryIcon kind={RepositoryKind.TektonTask} className="mw-100 mh-100" />,
     active: true,
   },
+  {
+    kind: RepositoryKind.Terraform,
+    label: 'terraform',
+    name: 'Terraform modules',
+    singular: 'Terraform module',
+    plural: 'Terraform modules',
+    icon: <RepositoryIcon kind={RepositoryKind.Terraform} className="mw-100 mh-100" />,
+    active: true,
+  },
   {
     kind: RepositoryKind.TBAction,
     label: 'tbaction',
@@ -378,6 +423,137 @@ user_roles[role] {
       },
     },
   },
+  {
+    name: 'read-only-members.v1',
+    label: 'read-only-members.v1',
+    policy: `package artifacthub.authz
+
+# Actions that don't modify the organization
+read_actions := {"getAuthorizationPolicy"}
+
+# Get user allowed actions
+allowed_actions[action] {
+  # Owner can perform all actions
+  user_roles[_] == "owner"
+  action := "all"
+}
+allowed_actions[action] {
+  # Other members can only perform read actions
+  action := read_actions[_]
+}
+
+# Get user roles
+user_roles[role] {
+  data.roles[role].users[_] == input.user
+}`,
+    data: {
+      roles: {
+        owner: {
+          users: ['user1'],
+        },
+      },
+    },
+  },
+  {
+    name: 'repo-maintainers.v1',
+    label: 'repo-maintainers.v1',
+    policy: `package artifacthub.authz
+
+# Actions that don't modify the organization
+read_actions := {"getAuthorizationPolicy"}
+
+# Actions that manage the organization repositories
+repository_actions := {
+  "addOrganizationRepository",
+  "deleteOrganizationRepository",
+  "transferOrganizationRepository",
+  "updateOrganizationRepository"
+}
+
+# Get user allowed actions
+allowed_actions[action] {
+  # Owner can perform all actions
+  user_roles[_] == "owner"
+  action := "all"
+}
+allowed_actions[action] {
+  # Maintainers can manage the organization repositories
+  user_roles[_] == "maintainer"
+  action := repository_actions[_]
+}
+allowed_actions[action] {
+  # Other members can only perform read actions
+  action := read_actions[_]
+}
+
+# Get user roles
+user_roles[role] {
+  data.roles[role].users[_] == input.user
+}`,
+    data: {
+      roles: {
+        owner: {
+          users: ['user1'],
+        },
+        maintainer: {
+          users: [],
+        },
+      },
+    },
+  },
+  {
+    name: 'approval-required.v1',
+    label: 'approval-required.v1',
+    policy: `package artifacthub.authz
+
+# Actions that any member can perform
+member_actions := {
+  "addOrganizationMember",
+  "addOrganizationRepository",
+  "getAuthorizationPolicy",
+  "updateOrganization",
+  "updateOrganizationRepository"
+}
+
+# Actions with a high impact, that require an approver
+sensitive_actions := {
+  "deleteOrganization",
+  "deleteOrganizationMember",
+  "deleteOrganizationRepository",
+  "transferOrganizationRepository"
+}
+
+# Get user allowed actions
+allowed_actions[action] {
+  # Owner can perform all actions
+  user_roles[_] == "owner"
+  action := "all"
+}
+allowed_actions[action] {
+  # Approvers can perform sensitive actions
+  user_roles[_] == "approver"
+  action := sensitive_actions[_]
+}
+allowed_actions[action] {
+  # Other members can only perform non sensitive actions
+  action := member_actions[_]
+}
+
+# Get user roles
+user_roles[role] {
+  data.roles[role].users[_] == input.user
+}`,
+    data: {
+      roles: {
+        owner: {
+          users: ['user1'],
+        },
+        approver: {
+          users: [],
+        },
+      },
+    },
+  },
 ];
 
 export const OPERATOR_CAPABILITIES = [
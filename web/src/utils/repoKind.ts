@@ -28,6 +28,16 @@ const getRepoKind = (repoName: string): RepositoryKind | null => {
       return RepositoryKind.TektonPipeline;
     case 'container':
       return RepositoryKind.Container;
+    case 'terraform':
+      return RepositoryKind.Terraform;
+    case 'crossplane':
+      return RepositoryKind.Crossplane;
+    case 'kyverno':
+      return RepositoryKind.Kyverno;
+    case 'knative-func':
+      return RepositoryKind.KnativeFunc;
+    case 'headlamp':
+      return RepositoryKind.Headlamp;
     default:
       return null;
   }
@@ -61,6 +71,16 @@ const getRepoKindName = (repoKind: RepositoryKind): string | null => {
       return 'tekton-pipeline';
     case RepositoryKind.Container:
       return 'container';
+    case RepositoryKind.Terraform:
+      return 'terraform';
+    case RepositoryKind.Crossplane:
+      return 'crossplane';
+    case RepositoryKind.Kyverno:
+      return 'kyverno';
+    case RepositoryKind.KnativeFunc:
+      return 'knative-func';
+    case RepositoryKind.Headlamp:
+      return 'headlamp';
     default:
       return null;
   }